@@ -0,0 +1,344 @@
+package app
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+	"golang.org/x/time/rate"
+	oauth2api "google.golang.org/api/oauth2/v2"
+)
+
+const (
+	// RecaptchaHeader carries the reCAPTCHA token of a deploy request, checked when the server
+	// has a reCAPTCHA secret.
+	RecaptchaHeader    = "X-Recaptcha-Token"
+	recaptchaVerifyUrl = "https://www.google.com/recaptcha/api/siteverify"
+
+	// maxAccountCacheTime bounds how long the account of an access token is cached, so a revoked
+	// token isn't trusted for long.
+	maxAccountCacheTime = 10 * time.Minute
+	// guardSweepInterval is how often the limiters and accounts no longer needed are dropped.
+	guardSweepInterval = time.Minute
+)
+
+var requestsRejected = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "requests_rejected",
+	Help: "Number of requests rejected by the abuse protection of the server",
+}, []string{"reason"})
+
+func init() {
+	prometheus.MustRegister(requestsRejected)
+}
+
+// ClientIdentity is who a request comes from: the Google account of its access token when the
+// token is valid, and its client IP.
+type ClientIdentity struct {
+	Account string
+	IP      string
+}
+
+// key is the limiter of the client, of its account when it's known, else of its IP.
+func (id ClientIdentity) key() string {
+	if id.Account != "" {
+		return "account:" + id.Account
+	}
+	return "ip:" + id.IP
+}
+
+// AbuseCheck is called with the deploy requests the rate limits let through; a request it
+// returns an error for is rejected with that error. The body of r can be read again by the
+// handler.
+type AbuseCheck func(r *http.Request, id ClientIdentity) error
+
+type identityKey struct{}
+
+// requestIdentity returns the identity abuseGuard found for the request of ctx.
+func requestIdentity(ctx context.Context) (ClientIdentity, bool) {
+	id, ok := ctx.Value(identityKey{}).(ClientIdentity)
+	return id, ok
+}
+
+// TokenVerifier returns the Google account of an access token and how long it's valid for.
+type TokenVerifier func(ctx context.Context, token string) (string, time.Duration, error)
+
+// verifyGoogleToken is the TokenVerifier asking the tokeninfo endpoint of Google.
+func verifyGoogleToken(ctx context.Context, token string) (string, time.Duration, error) {
+	service, err := oauth2api.New(http.DefaultClient)
+	if err != nil {
+		return "", 0, err
+	}
+	info, err := service.Tokeninfo().AccessToken(token).Context(ctx).Do()
+	if err != nil {
+		return "", 0, err
+	}
+	if info.Email == "" || !info.VerifiedEmail {
+		return "", 0, fmt.Errorf("the access token has no verified email; it needs the email scope")
+	}
+	return info.Email, time.Duration(info.ExpiresIn) * time.Second, nil
+}
+
+type cachedAccount struct {
+	account string
+	expires time.Time
+}
+
+type clientLimiter struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+// abuseGuard protects the endpoints of a publicly exposed server: it bounds the size of the
+// requests, rate limits each client IP and each Google account of a valid access token, and
+// runs the AbuseChecks on the deploy requests. A nil abuseGuard lets everything through.
+type abuseGuard struct {
+	maxRequestBytes int64
+	// limit is the requests per second of each client, with bursts of burst; no limit when 0.
+	limit  rate.Limit
+	burst  int
+	checks []AbuseCheck
+	verify TokenVerifier
+	now    func() time.Time
+
+	mu        sync.Mutex
+	limiters  map[string]*clientLimiter
+	accounts  map[string]cachedAccount
+	lastSweep time.Time
+}
+
+// newAbuseGuard returns a guard limiting requests to maxRequestBytes and each client to
+// perMinute requests a minute with bursts of burst. A limit <= 0 is no limit.
+func newAbuseGuard(maxRequestBytes int64, perMinute float64, burst int, checks ...AbuseCheck) *abuseGuard {
+	g := &abuseGuard{
+		maxRequestBytes: maxRequestBytes,
+		burst:           burst,
+		checks:          checks,
+		verify:          verifyGoogleToken,
+		now:             time.Now,
+		limiters:        make(map[string]*clientLimiter),
+		accounts:        make(map[string]cachedAccount),
+	}
+	if perMinute > 0 {
+		g.limit = rate.Limit(perMinute / 60)
+		if g.burst < 1 {
+			g.burst = 1
+		}
+	}
+	return g
+}
+
+// clientIP is the address of the client of r: the last address of X-Forwarded-For, which the
+// load balancer or Cloud Run in front of the server appends, else the remote address. The
+// addresses before it are set by the client and can't be trusted.
+func clientIP(r *http.Request) string {
+	if forwarded := r.Header.Get("X-Forwarded-For"); forwarded != "" {
+		addresses := strings.Split(forwarded, ",")
+		return strings.TrimSpace(addresses[len(addresses)-1])
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// requestToken is the access token of a request, from its Authorization header or the Token
+// field of its JSON body.
+func requestToken(r *http.Request, body []byte) string {
+	if auth := r.Header.Get("Authorization"); strings.HasPrefix(auth, "Bearer ") {
+		return strings.TrimPrefix(auth, "Bearer ")
+	}
+	var request struct {
+		Token string
+	}
+	if err := json.Unmarshal(body, &request); err != nil {
+		return ""
+	}
+	return request.Token
+}
+
+// account returns the Google account of token, cached until the token expires or
+// maxAccountCacheTime passes. An invalid token has no account.
+func (g *abuseGuard) account(ctx context.Context, token string) string {
+	if token == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(token))
+	key := hex.EncodeToString(sum[:])
+	g.mu.Lock()
+	cached, ok := g.accounts[key]
+	g.mu.Unlock()
+	if ok && cached.expires.After(g.now()) {
+		return cached.account
+	}
+	account, expiresIn, err := g.verify(ctx, token)
+	if err != nil {
+		log.Infof("Couldn't verify the access token of a request: %v", err)
+		return ""
+	}
+	if expiresIn <= 0 || expiresIn > maxAccountCacheTime {
+		expiresIn = maxAccountCacheTime
+	}
+	g.mu.Lock()
+	g.accounts[key] = cachedAccount{account: account, expires: g.now().Add(expiresIn)}
+	g.mu.Unlock()
+	return account
+}
+
+// allow takes a request from the limiter of key, returning how long to wait for the next one
+// when it's over its limit.
+func (g *abuseGuard) allow(key string) (bool, time.Duration) {
+	now := g.now()
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.sweep(now)
+	if g.limit == 0 {
+		return true, 0
+	}
+	l, ok := g.limiters[key]
+	if !ok {
+		l = &clientLimiter{limiter: rate.NewLimiter(g.limit, g.burst)}
+		g.limiters[key] = l
+	}
+	l.lastSeen = now
+	reservation := l.limiter.ReserveN(now, 1)
+	if delay := reservation.DelayFrom(now); delay > 0 {
+		reservation.CancelAt(now)
+		return false, delay
+	}
+	return true, 0
+}
+
+// sweep drops the limiters refilled since their client's last request, which are the same as
+// new ones, and the expired accounts. g.mu must be held.
+func (g *abuseGuard) sweep(now time.Time) {
+	if now.Sub(g.lastSweep) < guardSweepInterval {
+		return
+	}
+	g.lastSweep = now
+	if g.limit > 0 {
+		refill := time.Duration(float64(g.burst) / float64(g.limit) * float64(time.Second))
+		for key, l := range g.limiters {
+			if now.Sub(l.lastSeen) > refill {
+				delete(g.limiters, key)
+			}
+		}
+	}
+	for key, cached := range g.accounts {
+		if !cached.expires.After(now) {
+			delete(g.accounts, key)
+		}
+	}
+}
+
+// reject answers a rejected request with status and the error in the body the endpoints use.
+func reject(w http.ResponseWriter, status int, reason string, err error) {
+	requestsRejected.WithLabelValues(reason).Inc()
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(basicServerResponse{Err: err.Error()})
+}
+
+// wrap returns h guarded by g; the AbuseChecks are run when checked is set. The identity of the
+// client is in the context of the requests h serves.
+func (g *abuseGuard) wrap(h http.Handler, checked bool) http.Handler {
+	if g == nil {
+		return h
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body []byte
+		if r.Body != nil {
+			reader := io.Reader(r.Body)
+			if g.maxRequestBytes > 0 {
+				reader = io.LimitReader(r.Body, g.maxRequestBytes+1)
+			}
+			var err error
+			if body, err = ioutil.ReadAll(reader); err != nil {
+				reject(w, http.StatusBadRequest, "unreadable", fmt.Errorf("couldn't read the request: %v", err))
+				return
+			}
+			if g.maxRequestBytes > 0 && int64(len(body)) > g.maxRequestBytes {
+				reject(w, http.StatusRequestEntityTooLarge, "too_large",
+					fmt.Errorf("the request is larger than %v bytes", g.maxRequestBytes))
+				return
+			}
+			r.Body = ioutil.NopCloser(bytes.NewReader(body))
+		}
+
+		// The IP is limited before the token is verified, so a flood of requests doesn't turn
+		// into one of tokeninfo calls; the account is limited whichever IPs it comes from.
+		id := ClientIdentity{IP: clientIP(r)}
+		ok, delay := g.allow(id.key())
+		if ok {
+			if id.Account = g.account(r.Context(), requestToken(r, body)); id.Account != "" {
+				ok, delay = g.allow(id.key())
+			}
+		}
+		if !ok {
+			w.Header().Set("Retry-After", strconv.Itoa(int(delay/time.Second)+1))
+			reject(w, http.StatusTooManyRequests, "rate_limited",
+				fmt.Errorf("too many requests; retry in %v", delay.Round(time.Second)))
+			return
+		}
+		if checked {
+			for _, check := range g.checks {
+				if err := check(r, id); err != nil {
+					log.Warnf("Rejected a request of %v: %v", id.key(), err)
+					reject(w, http.StatusForbidden, "abuse", err)
+					return
+				}
+				r.Body = ioutil.NopCloser(bytes.NewReader(body))
+			}
+		}
+		h.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), identityKey{}, id)))
+	})
+}
+
+// recaptchaCheck is the AbuseCheck verifying the reCAPTCHA token of RecaptchaHeader with
+// secret. The score of a reCAPTCHA v3 token must be at least minScore; v2 tokens have none.
+func recaptchaCheck(secret string, minScore float64) AbuseCheck {
+	return func(r *http.Request, id ClientIdentity) error {
+		token := r.Header.Get(RecaptchaHeader)
+		if token == "" {
+			return fmt.Errorf("the request has no reCAPTCHA token in %v", RecaptchaHeader)
+		}
+		resp, err := http.PostForm(recaptchaVerifyUrl, url.Values{
+			"secret":   {secret},
+			"response": {token},
+			"remoteip": {id.IP},
+		})
+		if err != nil {
+			return fmt.Errorf("couldn't verify the reCAPTCHA token: %v", err)
+		}
+		defer resp.Body.Close()
+		var result struct {
+			Success    bool     `json:"success"`
+			Score      *float64 `json:"score"`
+			ErrorCodes []string `json:"error-codes"`
+		}
+		if err = json.NewDecoder(resp.Body).Decode(&result); err != nil {
+			return fmt.Errorf("couldn't read the reCAPTCHA verification: %v", err)
+		}
+		if !result.Success {
+			return fmt.Errorf("the reCAPTCHA token is invalid: %v", strings.Join(result.ErrorCodes, ", "))
+		}
+		if result.Score != nil && *result.Score < minScore {
+			return fmt.Errorf("the reCAPTCHA score %v is below %v", *result.Score, minScore)
+		}
+		return nil
+	}
+}
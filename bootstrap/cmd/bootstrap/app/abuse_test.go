@@ -0,0 +1,120 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package app
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+)
+
+func TestAbuseGuard(t *testing.T) {
+	now := time.Now()
+	verified := 0
+	g := newAbuseGuard(64, 60, 2, func(r *http.Request, id ClientIdentity) error {
+		if id.Account == "blocked@example.com" {
+			return errors.New("blocked")
+		}
+		return nil
+	})
+	g.now = func() time.Time { return now }
+	g.verify = func(_ context.Context, token string) (string, time.Duration, error) {
+		verified++
+		if strings.HasPrefix(token, "valid-") {
+			return strings.TrimPrefix(token, "valid-") + "@example.com", time.Hour, nil
+		}
+		return "", 0, errors.New("invalid token")
+	}
+	var got ClientIdentity
+	var gotBody string
+	h := g.wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got, _ = requestIdentity(r.Context())
+		body, _ := ioutil.ReadAll(r.Body)
+		gotBody = string(body)
+	}), true)
+	serve := func(ip string, body string) int {
+		r := httptest.NewRequest("POST", "/kfctl/e2eDeploy", strings.NewReader(body))
+		r.Header.Set("X-Forwarded-For", "10.0.0.1, "+ip)
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		return w.Code
+	}
+
+	if code := serve("1.1.1.1", `{"Token": "valid-alice"}`); code != http.StatusOK {
+		t.Fatalf("the first request got %v", code)
+	}
+	if got.Account != "alice@example.com" || got.IP != "1.1.1.1" || gotBody != `{"Token": "valid-alice"}` {
+		t.Errorf("the handler got %+v and body %q", got, gotBody)
+	}
+	if code := serve("1.1.1.1", strings.Repeat("x", 65)); code != http.StatusRequestEntityTooLarge {
+		t.Errorf("a request over the size limit got %v", code)
+	}
+	// 1.1.1.1 uses its burst of 2; alice is limited from any IP once the account used its own.
+	if code := serve("1.1.1.1", `{}`); code != http.StatusOK {
+		t.Errorf("the second request of the IP got %v", code)
+	}
+	if code := serve("1.1.1.1", `{}`); code != http.StatusTooManyRequests {
+		t.Errorf("a request over the rate of the IP got %v", code)
+	}
+	if code := serve("2.2.2.2", `{"Token": "valid-alice"}`); code != http.StatusOK {
+		t.Errorf("the second request of alice got %v", code)
+	}
+	if code := serve("3.3.3.3", `{"Token": "valid-alice"}`); code != http.StatusTooManyRequests {
+		t.Errorf("a request over the rate of alice got %v", code)
+	}
+	if verified != 1 {
+		t.Errorf("the token of alice was verified %v times; want 1", verified)
+	}
+	now = now.Add(time.Second)
+	if code := serve("1.1.1.1", `{}`); code != http.StatusOK {
+		t.Errorf("a request of the IP after a second got %v", code)
+	}
+	if code := serve("4.4.4.4", `{"Token": "valid-blocked"}`); code != http.StatusForbidden {
+		t.Errorf("a request failing the abuse check got %v", code)
+	}
+	if code := serve("5.5.5.5", `{"Token": "expired"}`); code != http.StatusOK || got.Account != "" {
+		t.Errorf("a request with an invalid token got %v and account %q", code, got.Account)
+	}
+}
+
+func TestDeployQueueAccountJobs(t *testing.T) {
+	q := newDeployQueue(0, 1)
+	release := make(chan struct{})
+	defer close(release)
+	for _, name := range []string{"a", "b"} {
+		q.Submit(&deployJob{
+			project: "p1",
+			name:    name,
+			account: "alice@example.com",
+			steps: []deployStep{{"wait", func() error {
+				<-release
+				return nil
+			}}},
+			done: func(error) {},
+		})
+	}
+	if n := q.AccountJobs("alice@example.com"); n != 2 {
+		t.Errorf("alice has %v jobs; want 2", n)
+	}
+	if n := q.AccountJobs("bob@example.com"); n != 0 {
+		t.Errorf("bob has %v jobs; want 0", n)
+	}
+}
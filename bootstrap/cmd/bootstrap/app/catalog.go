@@ -0,0 +1,324 @@
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"cloud.google.com/go/datastore"
+	"github.com/go-kit/kit/endpoint"
+	"github.com/kubeflow/kubeflow/bootstrap/config"
+	kstypes "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps/kfdef/v1alpha1"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+	"golang.org/x/oauth2"
+	"google.golang.org/api/cloudresourcemanager/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DeploymentKind is the Datastore kind of the deployments recorded by the server.
+const DeploymentKind = "KubeflowDeployment"
+
+// Status of a recorded deployment.
+const (
+	DEPLOYMENT_QUEUED    = "QUEUED"
+	DEPLOYMENT_DEPLOYING = "DEPLOYING"
+	DEPLOYMENT_DONE      = "DONE"
+	DEPLOYMENT_FAILED    = "FAILED"
+	DEPLOYMENT_CANCELLED = "CANCELLED"
+)
+
+// DeploymentRecord is a deployment created through the server, as kept in the catalog.
+type DeploymentRecord struct {
+	Project string `json:"project"`
+	Name    string `json:"name"`
+	Email   string `json:"email"`
+	Zone    string `json:"zone"`
+	Cluster string `json:"cluster"`
+	// Status is QUEUED until the deployment starts, then DEPLOYING until it's done, failed or
+	// cancelled.
+	Status string `json:"status"`
+	// QueuePosition is the position in the queue of a QUEUED deployment, 1 being the next to run.
+	QueuePosition int `json:"queuePosition,omitempty" datastore:"-"`
+	// Error is the reason the deployment failed.
+	Error      string    `json:"error,omitempty" datastore:",noindex"`
+	CreateTime time.Time `json:"createTime"`
+	UpdateTime time.Time `json:"updateTime"`
+	// KfDefJson is the KfDef of the deployment; Datastore can't hold its nested maps.
+	KfDefJson string         `json:"-" datastore:",noindex"`
+	KfDef     *kstypes.KfDef `json:"kfDef,omitempty" datastore:"-"`
+}
+
+// DeploymentCatalog keeps the deployments created through the server so users can find their
+// existing Kubeflow installs.
+type DeploymentCatalog interface {
+	// Put records d, keeping the create time of an existing record.
+	Put(ctx context.Context, d *DeploymentRecord) error
+	// Get returns the deployment name of project, or nil if there's none.
+	Get(ctx context.Context, project string, name string) (*DeploymentRecord, error)
+	// List returns the deployments of project, newest first.
+	List(ctx context.Context, project string) ([]*DeploymentRecord, error)
+}
+
+// datastoreCatalog is a DeploymentCatalog in the Datastore of the server's project, one
+// DeploymentKind entity per deployment keyed by project/name.
+type datastoreCatalog struct {
+	client *datastore.Client
+}
+
+// NewDatastoreCatalog returns a DeploymentCatalog in the Datastore of project, using the
+// credentials of the server.
+func NewDatastoreCatalog(ctx context.Context, project string) (DeploymentCatalog, error) {
+	client, err := datastore.NewClient(ctx, project)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't create the Datastore client of %v: %v", project, err)
+	}
+	return &datastoreCatalog{client: client}, nil
+}
+
+func deploymentKey(project string, name string) *datastore.Key {
+	return datastore.NameKey(DeploymentKind, project+"/"+name, nil)
+}
+
+func (c *datastoreCatalog) Put(ctx context.Context, d *DeploymentRecord) error {
+	key := deploymentKey(d.Project, d.Name)
+	_, err := c.client.RunInTransaction(ctx, func(tx *datastore.Transaction) error {
+		existing := &DeploymentRecord{}
+		err := tx.Get(key, existing)
+		if err != nil && err != datastore.ErrNoSuchEntity {
+			return err
+		}
+		if err == nil {
+			d.CreateTime = existing.CreateTime
+		}
+		_, err = tx.Put(key, d)
+		return err
+	})
+	return err
+}
+
+func (c *datastoreCatalog) Get(ctx context.Context, project string, name string) (*DeploymentRecord, error) {
+	d := &DeploymentRecord{}
+	err := c.client.Get(ctx, deploymentKey(project, name), d)
+	if err == datastore.ErrNoSuchEntity {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return d, d.decodeKfDef()
+}
+
+func (c *datastoreCatalog) List(ctx context.Context, project string) ([]*DeploymentRecord, error) {
+	deployments := []*DeploymentRecord{}
+	q := datastore.NewQuery(DeploymentKind).Filter("Project =", project)
+	if _, err := c.client.GetAll(ctx, q, &deployments); err != nil {
+		return nil, err
+	}
+	// Sorted here rather than in the query, which would need a composite index.
+	sort.Slice(deployments, func(i, j int) bool {
+		return deployments[i].CreateTime.After(deployments[j].CreateTime)
+	})
+	for _, d := range deployments {
+		if err := d.decodeKfDef(); err != nil {
+			return nil, err
+		}
+	}
+	return deployments, nil
+}
+
+func (d *DeploymentRecord) decodeKfDef() error {
+	if d.KfDefJson == "" {
+		return nil
+	}
+	d.KfDef = &kstypes.KfDef{}
+	if err := json.Unmarshal([]byte(d.KfDefJson), d.KfDef); err != nil {
+		return fmt.Errorf("couldn't decode the KfDef of deployment %v in %v: %v", d.Name, d.Project, err)
+	}
+	return nil
+}
+
+// kfDefFromRequest is the KfDef of the deployment req creates.
+func kfDefFromRequest(req CreateRequest, useIstio bool) *kstypes.KfDef {
+	kfDef := &kstypes.KfDef{
+		TypeMeta: meta_v1.TypeMeta{
+			Kind:       "KfDef",
+			APIVersion: "kfdef.apps.kubeflow.org/v1alpha1",
+		},
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      req.Name,
+			Namespace: req.Namespace,
+		},
+		Spec: kstypes.KfDefSpec{
+			ComponentConfig: config.ComponentConfig{
+				Platform:        "gcp",
+				ComponentParams: config.Parameters{},
+			},
+			Version:      getRegistryVersion(req, KubeflowRegName),
+			Project:      req.Project,
+			Email:        req.Email,
+			IpName:       req.IpName,
+			Zone:         req.Zone,
+			UseBasicAuth: req.Username != "",
+			UseIstio:     useIstio,
+		},
+	}
+	for _, c := range req.AppConfig.Components {
+		kfDef.Spec.Components = append(kfDef.Spec.Components, c.Name)
+	}
+	for _, p := range req.AppConfig.Packages {
+		kfDef.Spec.Packages = append(kfDef.Spec.Packages, p.Name)
+	}
+	for _, params := range [][]kstypes.KsParameter{req.AppConfig.Parameters, req.AppConfig.ApplyParameters} {
+		for _, p := range params {
+			kfDef.Spec.ComponentParams[p.Component] = append(kfDef.Spec.ComponentParams[p.Component],
+				config.NameValue{Name: p.Name, Value: p.Value})
+		}
+	}
+	return kfDef
+}
+
+// RecordDeployment records the deployment of req in the catalog with status. Failing to do so only
+// logs a warning, the catalog isn't needed for the deployment itself.
+func (s *ksServer) RecordDeployment(ctx context.Context, req CreateRequest, status string, reason string) {
+	if s.catalog == nil {
+		return
+	}
+	kfDef, err := json.Marshal(kfDefFromRequest(req, s.installIstio))
+	if err != nil {
+		log.Warnf("Failed to encode the KfDef of deployment %v in %v: %v", req.Name, req.Project, err)
+		return
+	}
+	now := time.Now()
+	d := &DeploymentRecord{
+		Project:    req.Project,
+		Name:       req.Name,
+		Email:      req.Email,
+		Zone:       req.Zone,
+		Cluster:    req.Cluster,
+		Status:     status,
+		Error:      reason,
+		CreateTime: now,
+		UpdateTime: now,
+		KfDefJson:  string(kfDef),
+	}
+	if err := s.catalog.Put(ctx, d); err != nil {
+		log.Warnf("Failed to record deployment %v in %v in the catalog: %v", req.Name, req.Project, err)
+	}
+}
+
+// DeploymentsRequest asks for the deployments of Project, or only deployment Name when set.
+type DeploymentsRequest struct {
+	Project string
+	Name    string
+	// Token is the access token of the user; they must be able to get Project.
+	Token string
+}
+
+type ListDeploymentsResponse struct {
+	basicServerResponse
+	Deployments []*DeploymentRecord `json:"deployments"`
+}
+
+type GetDeploymentResponse struct {
+	basicServerResponse
+	Deployment *DeploymentRecord `json:"deployment,omitempty"`
+}
+
+// checkProjectAccess checks the token of req can get its project, so users only see the
+// deployments of the projects they have access to.
+func (s *ksServer) checkProjectAccess(ctx context.Context, req DeploymentsRequest) error {
+	if req.Project == "" {
+		return fmt.Errorf("missing input fields: [Project]")
+	}
+	ts := oauth2.StaticTokenSource(&oauth2.Token{
+		AccessToken: req.Token,
+	})
+	resourceManager, err := cloudresourcemanager.New(oauth2.NewClient(ctx, ts))
+	if err != nil {
+		return err
+	}
+	if _, err := resourceManager.Projects.Get(req.Project).Context(ctx).Do(); err != nil {
+		return fmt.Errorf("couldn't get project %v: %v", req.Project, err)
+	}
+	return nil
+}
+
+func (s *ksServer) checkCatalog() error {
+	if s.catalog == nil {
+		return fmt.Errorf("the deployment catalog isn't enabled on this server")
+	}
+	return nil
+}
+
+// setQueuePosition sets the position of d in the queue when it's queued.
+func (s *ksServer) setQueuePosition(d *DeploymentRecord) {
+	if d.Status != DEPLOYMENT_QUEUED {
+		return
+	}
+	if position, _, ok := s.queue.Position(d.Project, d.Name); ok {
+		d.QueuePosition = position
+	}
+}
+
+// ListDeployments returns the deployments of the project of req, newest first.
+func (s *ksServer) ListDeployments(ctx context.Context, req DeploymentsRequest) ([]*DeploymentRecord, error) {
+	if err := s.checkCatalog(); err != nil {
+		return nil, err
+	}
+	if err := s.checkProjectAccess(ctx, req); err != nil {
+		return nil, err
+	}
+	deployments, err := s.catalog.List(ctx, req.Project)
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range deployments {
+		s.setQueuePosition(d)
+	}
+	return deployments, nil
+}
+
+// GetDeployment returns deployment req.Name of the project of req.
+func (s *ksServer) GetDeployment(ctx context.Context, req DeploymentsRequest) (*DeploymentRecord, error) {
+	if err := s.checkCatalog(); err != nil {
+		return nil, err
+	}
+	if err := s.checkProjectAccess(ctx, req); err != nil {
+		return nil, err
+	}
+	d, err := s.catalog.Get(ctx, req.Project, req.Name)
+	if err != nil {
+		return nil, err
+	}
+	if d == nil {
+		return nil, fmt.Errorf("deployment %v not found in %v", req.Name, req.Project)
+	}
+	s.setQueuePosition(d)
+	return d, nil
+}
+
+func makeListDeploymentsEndpoint(svc KsService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(DeploymentsRequest)
+		deployments, err := svc.ListDeployments(ctx, req)
+		r := &ListDeploymentsResponse{Deployments: deployments}
+		if err != nil {
+			r.Err = err.Error()
+		}
+		return r, nil
+	}
+}
+
+func makeGetDeploymentEndpoint(svc KsService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(DeploymentsRequest)
+		deployment, err := svc.GetDeployment(ctx, req)
+		r := &GetDeploymentResponse{Deployment: deployment}
+		if err != nil {
+			r.Err = err.Error()
+		}
+		return r, nil
+	}
+}
@@ -0,0 +1,82 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package app
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/kubeflow/kubeflow/bootstrap/config"
+	kstypes "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps/kfdef/v1alpha1"
+)
+
+func TestKfDefFromRequest(t *testing.T) {
+	req := CreateRequest{
+		Name:      "kf-app",
+		Namespace: "kubeflow",
+		Project:   "my-project",
+		Zone:      "us-east1-d",
+		Email:     "user@example.com",
+		IpName:    "kf-app-ip",
+		Username:  "admin",
+		AppConfig: kstypes.AppConfig{
+			Registries: []*kstypes.RegistryConfig{{Name: KubeflowRegName, Version: "v0.5.0"}},
+			Packages:   []kstypes.KsPackage{{Name: "core", Registry: KubeflowRegName}},
+			Components: []kstypes.KsComponent{{Name: "ambassador", Prototype: "ambassador"}},
+			Parameters: []kstypes.KsParameter{{Component: "ambassador", Name: "platform", Value: "gke"}},
+			ApplyParameters: []kstypes.KsParameter{
+				{Component: "pipeline", Name: "mysqlPd", Value: "kf-app-storage-metadata-store"},
+			},
+		},
+	}
+
+	kfDef := kfDefFromRequest(req, true)
+
+	if kfDef.Name != req.Name || kfDef.Namespace != req.Namespace {
+		t.Errorf("Got metadata %v/%v; want %v/%v", kfDef.Namespace, kfDef.Name, req.Namespace, req.Name)
+	}
+	spec := kfDef.Spec
+	if spec.Project != req.Project || spec.Zone != req.Zone || spec.Email != req.Email || spec.IpName != req.IpName {
+		t.Errorf("Spec doesn't match the request: %+v", spec)
+	}
+	if spec.Version != "v0.5.0" || spec.Platform != "gcp" || !spec.UseBasicAuth || !spec.UseIstio {
+		t.Errorf("Got version %v, platform %v, useBasicAuth %v, useIstio %v; want v0.5.0, gcp, true, true",
+			spec.Version, spec.Platform, spec.UseBasicAuth, spec.UseIstio)
+	}
+	if !reflect.DeepEqual(spec.Components, []string{"ambassador"}) || !reflect.DeepEqual(spec.Packages, []string{"core"}) {
+		t.Errorf("Got components %v and packages %v; want [ambassador] and [core]", spec.Components, spec.Packages)
+	}
+	params := config.Parameters{
+		"ambassador": {{Name: "platform", Value: "gke"}},
+		"pipeline":   {{Name: "mysqlPd", Value: "kf-app-storage-metadata-store"}},
+	}
+	if !reflect.DeepEqual(spec.ComponentParams, params) {
+		t.Errorf("Got params %v; want %v", spec.ComponentParams, params)
+	}
+
+	// The record keeps the KfDef as JSON and decodes it back when read.
+	data, err := json.Marshal(kfDef)
+	if err != nil {
+		t.Fatalf("Failed to encode the KfDef: %v", err)
+	}
+	d := &DeploymentRecord{Project: req.Project, Name: req.Name, KfDefJson: string(data)}
+	if err := d.decodeKfDef(); err != nil {
+		t.Fatalf("Failed to decode the KfDef: %v", err)
+	}
+	if !reflect.DeepEqual(d.KfDef.Spec.ComponentParams, params) {
+		t.Errorf("Got decoded params %v; want %v", d.KfDef.Spec.ComponentParams, params)
+	}
+}
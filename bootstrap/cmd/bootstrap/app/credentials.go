@@ -0,0 +1,164 @@
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"golang.org/x/net/context"
+	"golang.org/x/oauth2"
+	oauth2api "google.golang.org/api/oauth2/v2"
+)
+
+const (
+	cloudPlatformScope = "https://www.googleapis.com/auth/cloud-platform"
+	userinfoEmailScope = "https://www.googleapis.com/auth/userinfo.email"
+	// tokenExpiryDelta is how long before it expires a refreshable token is refreshed, so a
+	// deployment step doesn't start with a token expiring in the middle of it.
+	tokenExpiryDelta = 5 * time.Minute
+)
+
+// deployScopes are the scopes the server needs of the access token of the end user: the GCP
+// APIs of the project and the email of the account. They're the only scopes it asks for when
+// it refreshes the token.
+var deployScopes = []string{cloudPlatformScope, userinfoEmailScope}
+
+// TokenInspector returns the scopes granted to an access token and how long it's valid for.
+type TokenInspector func(ctx context.Context, token string) ([]string, time.Duration, error)
+
+// inspectGoogleToken is the TokenInspector asking the tokeninfo endpoint of Google.
+func inspectGoogleToken(ctx context.Context, token string) ([]string, time.Duration, error) {
+	service, err := oauth2api.New(http.DefaultClient)
+	if err != nil {
+		return nil, 0, err
+	}
+	info, err := service.Tokeninfo().AccessToken(token).Context(ctx).Do()
+	if err != nil {
+		return nil, 0, err
+	}
+	return strings.Fields(info.Scope), time.Duration(info.ExpiresIn) * time.Second, nil
+}
+
+// UserTokenSource returns the token source of the end user a request acts as, built from the
+// access token the deploy UI sends; the server never falls back to its own credentials. The
+// token must grant deployScopes. With a refresh token and the OAuth client of the deploy UI,
+// the access token is refreshed before it expires, asking for deployScopes only, so queued
+// and long deployments outlive it. Without one, the source fails once the token expired.
+func (s *ksServer) UserTokenSource(ctx context.Context, token string, refreshToken string) (oauth2.TokenSource, error) {
+	if token == "" {
+		return nil, fmt.Errorf("the request has no access token; deployments are made as the user deploying")
+	}
+	scopes, expiresIn, err := s.inspectToken(ctx, token)
+	if err != nil {
+		return nil, fmt.Errorf("invalid access token: %v", err)
+	}
+	granted := map[string]bool{}
+	for _, scope := range scopes {
+		granted[scope] = true
+	}
+	missing := []string{}
+	for _, scope := range deployScopes {
+		if !granted[scope] {
+			missing = append(missing, scope)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("the access token lacks the scopes %v", strings.Join(missing, ", "))
+	}
+	expiry := time.Now().Add(expiresIn)
+	if refreshToken == "" {
+		return &expiringTokenSource{token: &oauth2.Token{
+			AccessToken: token,
+			TokenType:   "Bearer",
+			Expiry:      expiry,
+		}}, nil
+	}
+	if s.oauthConfig == nil {
+		return nil, fmt.Errorf("the server can't refresh tokens; it has no OAuth client")
+	}
+	refresher := &scopedRefresher{
+		client:       http.DefaultClient,
+		config:       s.oauthConfig,
+		refreshToken: refreshToken,
+	}
+	return oauth2.ReuseTokenSource(&oauth2.Token{
+		AccessToken:  token,
+		TokenType:    "Bearer",
+		RefreshToken: refreshToken,
+		Expiry:       expiry.Add(-tokenExpiryDelta),
+	}, refresher), nil
+}
+
+// expiringTokenSource returns an access token until it expires.
+type expiringTokenSource struct {
+	token *oauth2.Token
+}
+
+func (ts *expiringTokenSource) Token() (*oauth2.Token, error) {
+	if !ts.token.Valid() {
+		return nil, fmt.Errorf("the access token expired at %v; deploy again with a new token",
+			ts.token.Expiry.Format(time.RFC3339))
+	}
+	return ts.token, nil
+}
+
+// scopedRefresher refreshes an access token with its refresh token, asking for the scopes of
+// config only rather than all those granted to the refresh token.
+type scopedRefresher struct {
+	client       *http.Client
+	config       *oauth2.Config
+	refreshToken string
+}
+
+func (r *scopedRefresher) Token() (*oauth2.Token, error) {
+	resp, err := r.client.PostForm(r.config.Endpoint.TokenURL, url.Values{
+		"grant_type":    {"refresh_token"},
+		"refresh_token": {r.refreshToken},
+		"client_id":     {r.config.ClientID},
+		"client_secret": {r.config.ClientSecret},
+		"scope":         {strings.Join(r.config.Scopes, " ")},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("couldn't refresh the access token: %v", err)
+	}
+	defer resp.Body.Close()
+	var body struct {
+		AccessToken      string `json:"access_token"`
+		TokenType        string `json:"token_type"`
+		ExpiresIn        int64  `json:"expires_in"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	if err = json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("couldn't decode the refreshed access token: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK || body.AccessToken == "" {
+		return nil, fmt.Errorf("couldn't refresh the access token: %v %v %v", resp.Status, body.Error,
+			body.ErrorDescription)
+	}
+	return &oauth2.Token{
+		AccessToken:  body.AccessToken,
+		TokenType:    body.TokenType,
+		RefreshToken: r.refreshToken,
+		Expiry:       time.Now().Add(time.Duration(body.ExpiresIn)*time.Second - tokenExpiryDelta),
+	}, nil
+}
+
+// withUserToken makes each of steps run with a current access token of ts in req.Token,
+// refreshed as needed; the steps of deploymentJob read the token of the request they close over.
+func withUserToken(steps []deployStep, req *CreateRequest, ts oauth2.TokenSource) {
+	for i := range steps {
+		run := steps[i].run
+		steps[i].run = func() error {
+			token, err := ts.Token()
+			if err != nil {
+				return err
+			}
+			req.Token = token.AccessToken
+			return run()
+		}
+	}
+}
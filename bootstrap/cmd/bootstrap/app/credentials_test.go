@@ -0,0 +1,131 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package app
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+	"golang.org/x/oauth2"
+)
+
+func TestUserTokenSource(t *testing.T) {
+	s := &ksServer{
+		inspectToken: func(_ context.Context, token string) ([]string, time.Duration, error) {
+			switch token {
+			case "full":
+				return deployScopes, time.Hour, nil
+			case "expired":
+				return deployScopes, 0, nil
+			case "narrow":
+				return []string{userinfoEmailScope}, time.Hour, nil
+			}
+			return nil, 0, errors.New("invalid token")
+		},
+	}
+	ctx := context.Background()
+	for _, token := range []string{"", "invalid", "narrow"} {
+		if _, err := s.UserTokenSource(ctx, token, ""); err == nil {
+			t.Errorf("UserTokenSource(%q) succeeded", token)
+		}
+	}
+	ts, err := s.UserTokenSource(ctx, "full", "")
+	if err != nil {
+		t.Fatalf("UserTokenSource failed: %v", err)
+	}
+	if token, err := ts.Token(); err != nil || token.AccessToken != "full" {
+		t.Errorf("Token() = %v, %v; want full", token, err)
+	}
+	ts, err = s.UserTokenSource(ctx, "expired", "")
+	if err != nil {
+		t.Fatalf("UserTokenSource failed: %v", err)
+	}
+	if _, err = ts.Token(); err == nil {
+		t.Errorf("an expired token was returned")
+	}
+	if _, err = s.UserTokenSource(ctx, "full", "refresh"); err == nil {
+		t.Errorf("a refresh token was accepted without an OAuth client")
+	}
+}
+
+func TestScopedRefresher(t *testing.T) {
+	var form map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		form = map[string]string{}
+		for key := range r.PostForm {
+			form[key] = r.PostForm.Get(key)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"access_token": "fresh", "token_type": "Bearer", "expires_in": 3600}`))
+	}))
+	defer server.Close()
+
+	r := &scopedRefresher{
+		client: server.Client(),
+		config: &oauth2.Config{
+			ClientID:     "id",
+			ClientSecret: "secret",
+			Endpoint:     oauth2.Endpoint{TokenURL: server.URL},
+			Scopes:       deployScopes,
+		},
+		refreshToken: "refresh",
+	}
+	token, err := r.Token()
+	if err != nil {
+		t.Fatalf("Token failed: %v", err)
+	}
+	if token.AccessToken != "fresh" || token.RefreshToken != "refresh" || !token.Valid() {
+		t.Errorf("got token %+v", token)
+	}
+	if form["refresh_token"] != "refresh" || form["client_id"] != "id" ||
+		form["scope"] != strings.Join(deployScopes, " ") {
+		t.Errorf("the refresh request was %v", form)
+	}
+}
+
+type tokenSourceFunc func() (*oauth2.Token, error)
+
+func (f tokenSourceFunc) Token() (*oauth2.Token, error) {
+	return f()
+}
+
+func TestWithUserToken(t *testing.T) {
+	req := CreateRequest{Token: "old"}
+	var seen []string
+	steps := []deployStep{
+		{"first", func() error { seen = append(seen, req.Token); return nil }},
+		{"second", func() error { seen = append(seen, req.Token); return nil }},
+	}
+	tokens := []string{"one", "two"}
+	withUserToken(steps, &req, tokenSourceFunc(func() (*oauth2.Token, error) {
+		token := &oauth2.Token{AccessToken: tokens[0]}
+		tokens = tokens[1:]
+		return token, nil
+	}))
+	for _, step := range steps {
+		if err := step.run(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if strings.Join(seen, ",") != "one,two" {
+		t.Errorf("the steps ran with tokens %v; want one,two", seen)
+	}
+}
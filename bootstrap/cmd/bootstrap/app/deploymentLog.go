@@ -0,0 +1,106 @@
+package app
+
+import (
+	"fmt"
+
+	"cloud.google.com/go/logging"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+	"golang.org/x/oauth2"
+	"google.golang.org/api/option"
+)
+
+// DeploymentLogPrefix prefixes the name of the Cloud Logging log of a deployment.
+const DeploymentLogPrefix = "kubeflow-deployment-"
+
+// deploymentLog logs the steps of a deployment to the server log and, when the server runs with
+// --deployment-logging, to a log named after the deployment in its project, so the owner of the
+// project can see what was done to it and not only the operator of the service.
+type deploymentLog struct {
+	project string
+	name    string
+	client  *logging.Client
+	logger  *logging.Logger
+	// lastError is the message of the last Errorf, the reason a failed deployment failed.
+	lastError string
+}
+
+// newDeploymentLog returns the log of the deployment of req. Failing to reach Cloud Logging only
+// logs a warning, the deployment is still logged to the server log.
+func newDeploymentLog(ctx context.Context, req CreateRequest, cloudLogging bool) *deploymentLog {
+	l := &deploymentLog{
+		project: req.Project,
+		name:    req.Name,
+	}
+	if !cloudLogging {
+		return l
+	}
+	ts := oauth2.StaticTokenSource(&oauth2.Token{
+		AccessToken: req.Token,
+	})
+	client, err := logging.NewClient(ctx, req.Project, option.WithTokenSource(ts))
+	if err != nil {
+		log.Warnf("Failed to create the Cloud Logging client of deployment %v in %v: %v", req.Name, req.Project, err)
+		return l
+	}
+	client.OnError = func(err error) {
+		log.Warnf("Failed to write to the Cloud Logging log of deployment %v in %v: %v", req.Name, req.Project, err)
+	}
+	l.client = client
+	l.logger = client.Logger(DeploymentLogPrefix+req.Name, logging.CommonLabels(map[string]string{
+		"deployment": req.Name,
+	}))
+	return l
+}
+
+func (l *deploymentLog) Infof(format string, args ...interface{}) {
+	l.entry().Infof(format, args...)
+	l.write(logging.Info, format, args...)
+}
+
+func (l *deploymentLog) Warnf(format string, args ...interface{}) {
+	l.entry().Warnf(format, args...)
+	l.write(logging.Warning, format, args...)
+}
+
+func (l *deploymentLog) Errorf(format string, args ...interface{}) {
+	l.lastError = fmt.Sprintf(format, args...)
+	l.entry().Errorf(format, args...)
+	l.write(logging.Error, format, args...)
+}
+
+// LastError is the message of the last error logged.
+func (l *deploymentLog) LastError() string {
+	return l.lastError
+}
+
+// Close flushes the entries buffered for Cloud Logging.
+func (l *deploymentLog) Close() {
+	if l.client == nil {
+		return
+	}
+	if err := l.client.Close(); err != nil {
+		log.Warnf("Failed to flush the Cloud Logging log of deployment %v in %v: %v", l.name, l.project, err)
+	}
+}
+
+func (l *deploymentLog) entry() *log.Entry {
+	return log.WithFields(log.Fields{
+		"project":    l.project,
+		"deployment": l.name,
+	})
+}
+
+func (l *deploymentLog) write(severity logging.Severity, format string, args ...interface{}) {
+	if l.logger == nil {
+		return
+	}
+	l.logger.Log(logging.Entry{
+		Severity: severity,
+		Payload: map[string]interface{}{
+			"message":    fmt.Sprintf(format, args...),
+			"project":    l.project,
+			"deployment": l.name,
+		},
+	})
+}
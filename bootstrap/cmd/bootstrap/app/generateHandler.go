@@ -0,0 +1,74 @@
+package app
+
+import (
+	"fmt"
+	"github.com/go-kit/kit/endpoint"
+	kftypes "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps"
+	kstypes "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps/kfdef/v1alpha1"
+	"golang.org/x/net/context"
+	valid "k8s.io/apimachinery/pkg/api/validation"
+	"strings"
+)
+
+// GenerateFunc returns the configs generated for kfdef, app.yaml and the DM configs, by their
+// path in the app dir. main passes the one of the gcp kfapp to Run, as it imports this package.
+type GenerateFunc func(kfdef *kstypes.KfDef) (map[string][]byte, error)
+
+// GenerateRequest is the app to generate the configs of.
+type GenerateRequest struct {
+	KfDef kstypes.KfDef
+}
+
+// GenerateResponse holds the generated configs by their path in the app dir. Nothing is kept
+// on the server, so it can run statelessly, e.g. on Cloud Run.
+type GenerateResponse struct {
+	Files map[string]string `json:"files,omitempty"`
+	Err   string            `json:"err,omitempty"`
+}
+
+// validateGenerateRequest checks the app is named and fills in the defaults kfctl init sets.
+func validateGenerateRequest(req *GenerateRequest) error {
+	kfdef := &req.KfDef
+	if errs := valid.NameIsDNSLabel(kfdef.Name, false); len(errs) > 0 {
+		return fmt.Errorf("invalid name %v: %v", kfdef.Name, strings.Join(errs, ", "))
+	}
+	if kfdef.Spec.Project == "" {
+		return fmt.Errorf("project is required")
+	}
+	if kfdef.Spec.Platform == "" {
+		kfdef.Spec.Platform = kftypes.GCP
+	} else if kfdef.Spec.Platform != kftypes.GCP {
+		return fmt.Errorf("unsupported platform %v; only %v is generated", kfdef.Spec.Platform, kftypes.GCP)
+	}
+	if kfdef.Namespace == "" {
+		kfdef.Namespace = kftypes.DefaultNamespace
+	}
+	if kfdef.Spec.Zone == "" {
+		kfdef.Spec.Zone = kftypes.DefaultZone
+	}
+	if kfdef.Spec.Version == "" {
+		kfdef.Spec.Version = kftypes.DefaultVersion
+	}
+	return nil
+}
+
+func makeGenerateEndpoint(generate GenerateFunc) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(GenerateRequest)
+		r := &GenerateResponse{}
+		if err := validateGenerateRequest(&req); err != nil {
+			r.Err = err.Error()
+			return r, nil
+		}
+		files, err := generate(&req.KfDef)
+		if err != nil {
+			r.Err = err.Error()
+			return r, nil
+		}
+		r.Files = make(map[string]string)
+		for name, data := range files {
+			r.Files[name] = string(data)
+		}
+		return r, nil
+	}
+}
@@ -25,6 +25,7 @@ import (
 	kApp "github.com/ksonnet/ksonnet/pkg/app"
 	"github.com/ksonnet/ksonnet/pkg/client"
 	kstypes "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps/kfdef/v1alpha1"
+	"github.com/kubeflow/kubeflow/bootstrap/pkg/utils"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	log "github.com/sirupsen/logrus"
@@ -97,6 +98,19 @@ type KsService interface {
 	GetDeploymentStatus(context.Context, CreateRequest, string) (string, string, error)
 	ApplyIamPolicy(context.Context, ApplyIamRequest) error
 	GetProjectLock(string) *sync.Mutex
+	// RecordDeployment records a deployment and its status in the deployment catalog.
+	RecordDeployment(ctx context.Context, req CreateRequest, status string, reason string)
+	ListDeployments(context.Context, DeploymentsRequest) ([]*DeploymentRecord, error)
+	GetDeployment(context.Context, DeploymentsRequest) (*DeploymentRecord, error)
+	// QueueDeployment queues the job deploying req and returns its position in the queue and the
+	// operation tracking it.
+	QueueDeployment(context.Context, CreateRequest, *deployJob) (int, *OperationRecord, error)
+	GetDeploymentQueueStatus(context.Context, DeploymentsRequest) (*DeploymentStatusResponse, error)
+	GetOperation(context.Context, OperationRequest) (*OperationRecord, error)
+	CancelOperation(context.Context, OperationRequest) (*OperationRecord, error)
+	// UserTokenSource returns the token source of the end user of a request from its access
+	// token and optional refresh token.
+	UserTokenSource(ctx context.Context, token string, refreshToken string) (oauth2.TokenSource, error)
 }
 
 // appInfo keeps track of information about apps.
@@ -127,6 +141,35 @@ type ksServer struct {
 
 	// Whether to install istio.
 	installIstio bool
+
+	// Whether to also log each deployment to Cloud Logging in its project.
+	deploymentLogging bool
+
+	// catalog records the deployments created through the server; nil when it isn't enabled.
+	catalog DeploymentCatalog
+
+	// queue runs the deployments, limiting how many run at once.
+	queue *deployQueue
+	// maxUserDeployments is how many deployments a Google account can have queued or running at
+	// once; no limit when <= 0.
+	maxUserDeployments int
+
+	// guard rate limits the requests of the HTTP server; nil lets them all through.
+	guard *abuseGuard
+
+	// operations tracks the deployments for the operations API; operationsMux serializes their
+	// updates.
+	operations    OperationStore
+	operationsMux sync.Mutex
+
+	// generate serves /kfctl/apps/generate; it isn't served when nil.
+	generate GenerateFunc
+
+	// inspectToken checks the access tokens of the deploy requests.
+	inspectToken TokenInspector
+	// oauthConfig is the OAuth client of the deploy UI the tokens of the users are refreshed
+	// with; they aren't refreshed when nil.
+	oauthConfig *oauth2.Config
 }
 
 type MultiError struct {
@@ -152,10 +195,15 @@ func (m MultiError) ToError() error {
 }
 
 // NewServer constructs a ksServer.
-func NewServer(appsDir string, registries []*kstypes.RegistryConfig, gkeVersionOverride string, installIstio bool) (*ksServer, error) {
+func NewServer(appsDir string, registries []*kstypes.RegistryConfig, gkeVersionOverride string, installIstio bool,
+	deploymentLogging bool, catalog DeploymentCatalog, operations OperationStore, maxDeployments int,
+	maxProjectDeployments int, generate GenerateFunc) (*ksServer, error) {
 	if appsDir == "" {
 		return nil, fmt.Errorf("appsDir can't be empty")
 	}
+	if operations == nil {
+		operations = newMemoryOperationStore()
+	}
 
 	s := &ksServer{
 		appsDir:            appsDir,
@@ -164,6 +212,12 @@ func NewServer(appsDir string, registries []*kstypes.RegistryConfig, gkeVersionO
 		gkeVersionOverride: gkeVersionOverride,
 		fs:                 afero.NewOsFs(),
 		installIstio:       installIstio,
+		deploymentLogging:  deploymentLogging,
+		catalog:            catalog,
+		queue:              newDeployQueue(maxDeployments, maxProjectDeployments),
+		operations:         operations,
+		generate:           generate,
+		inspectToken:       inspectGoogleToken,
 	}
 
 	for _, r := range registries {
@@ -212,10 +266,12 @@ type CreateRequest struct {
 	ProjectNumber string
 	Zone          string
 
-	// Access token, need to access target cluster in order for AutoConfigure
+	// Access token of the user deploying; the deployment is made with it.
 	Token string
-	Apply bool
-	Email string
+	// RefreshToken, when set, lets the server refresh Token as the deployment outlives it.
+	RefreshToken string `json:",omitempty"`
+	Apply        bool
+	Email        string
 	// temporary
 	ClientId     string
 	ClientSecret string
@@ -659,7 +715,10 @@ func runCmd(rawcmd string) error {
 	bo := backoff.WithMaxRetries(backoff.NewConstantBackOff(2*time.Second), 10)
 	return backoff.Retry(func() error {
 		cmd := exec.Command("sh", "-c", rawcmd)
-		result, err := cmd.CombinedOutput()
+		result, err := utils.CombinedOutput(cmd)
+		if _, ok := err.(*utils.NoExecError); ok {
+			return backoff.Permanent(err)
+		}
 		if err != nil {
 			return fmt.Errorf("Error occrued during execute cmd %v. Error: %v", rawcmd, string(result))
 		}
@@ -1023,10 +1082,13 @@ func (s *ksServer) SaveAppToRepo(project string, email string, repoDir string) e
 	bo := backoff.WithMaxRetries(backoff.NewConstantBackOff(2*time.Second), 10)
 	return backoff.Retry(func() error {
 		pushcmd := exec.Command("sh", "-c", "git push origin master")
-		result, err := pushcmd.CombinedOutput()
+		result, err := utils.CombinedOutput(pushcmd)
 		if err != nil {
+			if _, ok := err.(*utils.NoExecError); ok {
+				return backoff.Permanent(err)
+			}
 			pullcmd := exec.Command("sh", "-c", "git pull --rebase")
-			pullResult, _ := pullcmd.CombinedOutput()
+			pullResult, _ := utils.CombinedOutput(pullcmd)
 			return fmt.Errorf("Error occrued during git push. Error: %v; try rebase: %v", string(result), string(pullResult))
 		}
 		return nil
@@ -1178,7 +1240,7 @@ func timeSinceStart(ctx context.Context) time.Duration {
 	return time.Since(startTime)
 }
 
-func checkDeploymentFinished(svc KsService, req CreateRequest, deployName string) error {
+func checkDeploymentFinished(svc KsService, req CreateRequest, dlog *deploymentLog, deployName string) error {
 	status := ""
 	errMsg := ""
 	var err error
@@ -1189,7 +1251,7 @@ func checkDeploymentFinished(svc KsService, req CreateRequest, deployName string
 		time.Sleep(10 * time.Second)
 		status, errMsg, err = svc.GetDeploymentStatus(ctx, req, deployName)
 		if err != nil {
-			log.Warningf("Failed to get deployment status: %v\nWill retry...", err)
+			dlog.Warnf("Failed to get deployment status: %v\nWill retry...", err)
 			continue
 		}
 		if status == "DONE" {
@@ -1197,16 +1259,16 @@ func checkDeploymentFinished(svc KsService, req CreateRequest, deployName string
 				// Deploy successfully
 				break
 			}
-			log.Errorf("Deployment manager returned error message: %v", errMsg)
+			dlog.Errorf("Deployment manager returned error message: %v", errMsg)
 			// Mark status "INVALID_ARGUMENT" as most deployment manager failures are caused by insufficient quota or permission.
 			// Error messages are available from UI, and should be resolvable by retries.
 			deployReqCounter.WithLabelValues("INVALID_ARGUMENT").Inc()
 			return fmt.Errorf(errMsg)
 		}
-		log.Infof("status: %v, waiting...", status)
+		dlog.Infof("status: %v, waiting...", status)
 	}
 	if status != "DONE" {
-		log.Errorf("Deployment status is not done: %v", status)
+		dlog.Errorf("Deployment status is not done: %v", status)
 		deployReqCounter.WithLabelValues("INTERNAL").Inc()
 		deploymentFailure.WithLabelValues("INTERNAL").Inc()
 		return err
@@ -1214,83 +1276,156 @@ func checkDeploymentFinished(svc KsService, req CreateRequest, deployName string
 	return nil
 }
 
-func finishDeployment(svc KsService, req CreateRequest,
-	clusterDmDeploy *deploymentmanager.Deployment, storageDmDeploy *deploymentmanager.Deployment) {
-	ctx := context.Background()
-	ctx = context.WithValue(ctx, StartTime, time.Now())
-
-	err := checkDeploymentFinished(svc, req, clusterDmDeploy.Name)
-	if err != nil {
-		return
+// Add heartbeat every 10 seconds
+func countHeartbeat() {
+	for {
+		time.Sleep(10 * time.Second)
+		serviceHeartbeat.Inc()
 	}
+}
 
-	if storageDmDeploy != nil {
-		err = checkDeploymentFinished(svc, req, storageDmDeploy.Name)
-		if err != nil {
-			return
-		}
-	}
-	clusterDeploymentLatencies.Observe(timeSinceStart(ctx).Seconds())
-	log.Infof("Deployment is done")
+// deploymentJob is the job deploying req: it creates the DM deployments, waits for them to be
+// done, patches the IAM bindings and then configures the cluster and creates the app.
+func deploymentJob(svc KsService, req CreateRequest, dlog *deploymentLog, ts oauth2.TokenSource) *deployJob {
+	ctx := context.Background()
+	var clusterDmDeploy, storageDmDeploy *deploymentmanager.Deployment
 
-	log.Info("Patching IAM bindings...")
-	err = svc.ApplyIamPolicy(ctx, ApplyIamRequest{
-		Project: req.Project,
-		Cluster: req.Cluster,
-		Email:   req.Email,
-		Token:   req.Token,
-		Action:  "add",
-	})
-	if err != nil {
-		log.Errorf("Failed to update IAM: %v", err)
-		deployReqCounter.WithLabelValues("INTERNAL").Inc()
-		deploymentFailure.WithLabelValues("INTERNAL").Inc()
-		return
+	steps := []deployStep{
+		{"start the deployment", func() error {
+			dlog.Infof("Deploying Kubeflow to cluster %v in zone %v", req.Cluster, req.Zone)
+			svc.RecordDeployment(ctx, req, DEPLOYMENT_DEPLOYING, "")
+			return nil
+		}},
 	}
-
-	log.Infof("Configuring cluster...")
-	if err = svc.ConfigCluster(ctx, req); err != nil {
-		deployReqCounter.WithLabelValues("INTERNAL").Inc()
-		deploymentFailure.WithLabelValues("INTERNAL").Inc()
-		return
+	if req.StorageOption.CreatePipelinePersistentStorage {
+		steps = append(steps, deployStep{"create the storage deployment", func() error {
+			var err error
+			storageDmDeploy, err = svc.InsertDeployment(ctx, req, StorageDmSpec)
+			if err != nil {
+				dlog.Errorf("Failed to create the storage deployment: %v", err)
+				return err
+			}
+			req.AppConfig.ApplyParameters = append(
+				req.AppConfig.ApplyParameters,
+				kstypes.KsParameter{
+					Component: "pipeline",
+					Name:      "mysqlPd",
+					Value:     req.Name + StorageDmSpec.DmNameSuffix + MetadataStoreDiskSuffix})
+			req.AppConfig.ApplyParameters = append(
+				req.AppConfig.ApplyParameters,
+				kstypes.KsParameter{
+					Component: "pipeline",
+					Name:      "minioPd",
+					Value:     req.Name + StorageDmSpec.DmNameSuffix + ArtifactStoreDiskSuffix})
+			return nil
+		}})
 	}
-
-	if err = svc.InstallIstio(ctx, req); err != nil {
-		log.Errorf("Failed to install istio: %v", err)
-		deployReqCounter.WithLabelValues("INTERNAL").Inc()
-		deploymentFailure.WithLabelValues("INTERNAL").Inc()
-		return
+	steps = append(steps,
+		deployStep{"create the cluster deployment", func() error {
+			var err error
+			clusterDmDeploy, err = svc.InsertDeployment(ctx, req, ClusterDmSpec)
+			if err != nil {
+				dlog.Errorf("Failed to create the cluster deployment: %v", err)
+			}
+			return err
+		}},
+		deployStep{"finish the deployments", func() error {
+			ctx = context.WithValue(ctx, StartTime, time.Now())
+			if err := checkDeploymentFinished(svc, req, dlog, clusterDmDeploy.Name); err != nil {
+				return err
+			}
+			if storageDmDeploy != nil {
+				if err := checkDeploymentFinished(svc, req, dlog, storageDmDeploy.Name); err != nil {
+					return err
+				}
+			}
+			clusterDeploymentLatencies.Observe(timeSinceStart(ctx).Seconds())
+			dlog.Infof("Deployment is done")
+			return nil
+		}},
+		deployStep{"update IAM", func() error {
+			dlog.Infof("Patching IAM bindings...")
+			err := svc.ApplyIamPolicy(ctx, ApplyIamRequest{
+				Project: req.Project,
+				Cluster: req.Cluster,
+				Email:   req.Email,
+				Token:   req.Token,
+				Action:  "add",
+			})
+			return internalFailure(dlog, "Failed to update IAM", err)
+		}},
+		deployStep{"configure cluster", func() error {
+			dlog.Infof("Configuring cluster...")
+			return internalFailure(dlog, "Failed to configure cluster", svc.ConfigCluster(ctx, req))
+		}},
+		deployStep{"install istio", func() error {
+			return internalFailure(dlog, "Failed to install istio", svc.InstallIstio(ctx, req))
+		}},
+		deployStep{"create app", func() error {
+			dlog.Infof("Creating app...")
+			return internalFailure(dlog, "Failed to create app", svc.CreateApp(ctx, req, clusterDmDeploy))
+		}},
+	)
+	withUserToken(steps, &req, ts)
+
+	return &deployJob{
+		project: req.Project,
+		name:    req.Name,
+		steps:   steps,
+		done: func(err error) {
+			defer dlog.Close()
+			if err == errDeploymentCancelled {
+				dlog.Warnf("The deployment was cancelled")
+				svc.RecordDeployment(context.Background(), req, DEPLOYMENT_CANCELLED, "")
+				return
+			}
+			if err != nil {
+				if isQuotaExceeded(err) {
+					dlog.Errorf("Giving up after %v retries on exhausted quotas: %v", maxQuotaRetries, err)
+					deployReqCounter.WithLabelValues("RESOURCE_EXHAUSTED").Inc()
+					deploymentFailure.WithLabelValues("RESOURCE_EXHAUSTED").Inc()
+				}
+				svc.RecordDeployment(context.Background(), req, DEPLOYMENT_FAILED, dlog.LastError())
+				return
+			}
+			dlog.Infof("Kubeflow is deployed")
+			deployReqCounter.WithLabelValues("OK").Inc()
+			if req.Project != "kubeflow-prober-deploy" {
+				kfDeploymentsDoneRaw.Inc()
+				kfDeploymentsDoneUser.Inc()
+			}
+			kfDeploymentLatencies.Observe(timeSinceStart(ctx).Seconds())
+			svc.RecordDeployment(context.Background(), req, DEPLOYMENT_DONE, "")
+		},
 	}
+}
 
-	log.Infof("Creating app...")
-	err = svc.CreateApp(ctx, req, clusterDmDeploy)
-	if err != nil {
-		log.Errorf("Failed to create app: %v", err)
+// internalFailure logs err, the failure of a deployment step, and counts it as INTERNAL unless
+// it's an exhausted quota the step is retried on.
+func internalFailure(dlog *deploymentLog, msg string, err error) error {
+	if err == nil {
+		return nil
+	}
+	dlog.Errorf("%v: %v", msg, err)
+	if !isQuotaExceeded(err) {
 		deployReqCounter.WithLabelValues("INTERNAL").Inc()
 		deploymentFailure.WithLabelValues("INTERNAL").Inc()
-		return
 	}
-
-	deployReqCounter.WithLabelValues("OK").Inc()
-	if req.Project != "kubeflow-prober-deploy" {
-		kfDeploymentsDoneRaw.Inc()
-		kfDeploymentsDoneUser.Inc()
-	}
-	kfDeploymentLatencies.Observe(timeSinceStart(ctx).Seconds())
+	return err
 }
 
-// Add heartbeat every 10 seconds
-func countHeartbeat() {
-	for {
-		time.Sleep(10 * time.Second)
-		serviceHeartbeat.Inc()
-	}
+// DeployResponse is the response of a deployment request, queued at QueuePosition and tracked
+// by operation OperationId.
+type DeployResponse struct {
+	basicServerResponse
+	QueuePosition int    `json:"queuePosition,omitempty"`
+	OperationId   string `json:"operationId,omitempty"`
 }
 
-func makeDeployEndpoint(svc KsService) endpoint.Endpoint {
+func makeDeployEndpoint(svc KsService, deploymentLogging bool) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(CreateRequest)
-		r := &basicServerResponse{}
+		r := &DeployResponse{}
 		if req.Project != "kubeflow-prober-deploy" {
 			deployReqCounterRaw.Inc()
 			deployReqCounterUser.Inc()
@@ -1301,35 +1436,26 @@ func makeDeployEndpoint(svc KsService) endpoint.Endpoint {
 			return r, err
 		}
 
-		var storageDmDeployment *deploymentmanager.Deployment
-
-		if req.StorageOption.CreatePipelinePersistentStorage {
-			var err error
-			storageDmDeployment, err = svc.InsertDeployment(ctx, req, StorageDmSpec)
-			if err != nil {
-				r.Err = err.Error()
-				return r, err
-			}
-			req.AppConfig.ApplyParameters = append(
-				req.AppConfig.ApplyParameters,
-				kstypes.KsParameter{
-					Component: "pipeline",
-					Name:      "mysqlPd",
-					Value:     req.Name + StorageDmSpec.DmNameSuffix + MetadataStoreDiskSuffix})
-			req.AppConfig.ApplyParameters = append(
-				req.AppConfig.ApplyParameters,
-				kstypes.KsParameter{
-					Component: "pipeline",
-					Name:      "minioPd",
-					Value:     req.Name + StorageDmSpec.DmNameSuffix + ArtifactStoreDiskSuffix})
+		ts, err := svc.UserTokenSource(ctx, req.Token, req.RefreshToken)
+		if err != nil {
+			r.Err = err.Error()
+			deployReqCounter.WithLabelValues("INVALID_ARGUMENT").Inc()
+			return r, err
 		}
 
-		clusterDmDeployment, err := svc.InsertDeployment(ctx, req, ClusterDmSpec)
+		dlog := newDeploymentLog(context.Background(), req, deploymentLogging)
+		position, op, err := svc.QueueDeployment(ctx, req, deploymentJob(svc, req, dlog, ts))
 		if err != nil {
+			dlog.Errorf("Failed to queue the deployment: %v", err)
+			dlog.Close()
 			r.Err = err.Error()
+			deployReqCounter.WithLabelValues("INVALID_ARGUMENT").Inc()
 			return r, err
 		}
-		go finishDeployment(svc, req, clusterDmDeployment, storageDmDeployment)
+		dlog.Infof("Queued the deployment of Kubeflow to cluster %v in zone %v at position %v, operation %v",
+			req.Cluster, req.Zone, position, op.Id)
+		r.QueuePosition = position
+		r.OperationId = op.Id
 		return r, nil
 	}
 }
@@ -1374,7 +1500,8 @@ func optionsHandler(h http.Handler) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Access-Control-Allow-Origin", "*")
 		w.Header().Set("Access-Control-Allow-Methods", "POST, GET, OPTIONS, PUT, DELETE")
-		w.Header().Set("Access-Control-Allow-Headers", "Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization")
+		w.Header().Set("Access-Control-Allow-Headers", "Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, "+
+			RecaptchaHeader)
 		if r.Method == "OPTIONS" {
 			return
 		} else {
@@ -1442,19 +1569,85 @@ func (s *ksServer) StartHttp(port int) {
 	)
 
 	deployHandler := httptransport.NewServer(
-		makeDeployEndpoint(s),
+		makeDeployEndpoint(s, s.deploymentLogging),
 		decodeCreateAppRequest,
 		encodeResponse,
 	)
 
+	decodeDeploymentsRequest := func(_ context.Context, r *http.Request) (interface{}, error) {
+		var request DeploymentsRequest
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			return nil, err
+		}
+		return request, nil
+	}
+
+	listDeploymentsHandler := httptransport.NewServer(
+		makeListDeploymentsEndpoint(s),
+		decodeDeploymentsRequest,
+		encodeResponse,
+	)
+
+	getDeploymentHandler := httptransport.NewServer(
+		makeGetDeploymentEndpoint(s),
+		decodeDeploymentsRequest,
+		encodeResponse,
+	)
+
+	deploymentStatusHandler := httptransport.NewServer(
+		makeDeploymentStatusEndpoint(s),
+		decodeDeploymentsRequest,
+		encodeResponse,
+	)
+
+	decodeOperationRequest := func(_ context.Context, r *http.Request) (interface{}, error) {
+		var request OperationRequest
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			return nil, err
+		}
+		return request, nil
+	}
+
+	getOperationHandler := httptransport.NewServer(
+		makeGetOperationEndpoint(s),
+		decodeOperationRequest,
+		encodeResponse,
+	)
+
+	cancelOperationHandler := httptransport.NewServer(
+		makeCancelOperationEndpoint(s),
+		decodeOperationRequest,
+		encodeResponse,
+	)
+
+	if s.generate != nil {
+		generateHandler := httptransport.NewServer(
+			makeGenerateEndpoint(s.generate),
+			func(_ context.Context, r *http.Request) (interface{}, error) {
+				var request GenerateRequest
+				if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+					return nil, err
+				}
+				return request, nil
+			},
+			encodeResponse,
+		)
+		http.Handle("/kfctl/apps/generate", optionsHandler(s.guard.wrap(generateHandler, false)))
+	}
+
 	// TODO: add deployment manager config generate / deploy handler here. So we'll have user's DM configs stored in
 	// k8s storage / github, instead of gone with browser tabs.
 	http.Handle("/", optionsHandler(healthzHandler))
-	http.Handle("/kfctl/apps/apply", optionsHandler(applyAppHandler))
-	http.Handle("/kfctl/apps/create", optionsHandler(createAppHandler))
-	http.Handle("/kfctl/iam/apply", optionsHandler(applyIamHandler))
-	http.Handle("/kfctl/initProject", optionsHandler(initProjectHandler))
-	http.Handle("/kfctl/e2eDeploy", optionsHandler(deployHandler))
+	http.Handle("/kfctl/apps/apply", optionsHandler(s.guard.wrap(applyAppHandler, false)))
+	http.Handle("/kfctl/apps/create", optionsHandler(s.guard.wrap(createAppHandler, false)))
+	http.Handle("/kfctl/iam/apply", optionsHandler(s.guard.wrap(applyIamHandler, false)))
+	http.Handle("/kfctl/initProject", optionsHandler(s.guard.wrap(initProjectHandler, false)))
+	http.Handle("/kfctl/e2eDeploy", optionsHandler(s.guard.wrap(deployHandler, true)))
+	http.Handle("/kfctl/deployments/list", optionsHandler(s.guard.wrap(listDeploymentsHandler, false)))
+	http.Handle("/kfctl/deployments/get", optionsHandler(s.guard.wrap(getDeploymentHandler, false)))
+	http.Handle("/kfctl/deployments/status", optionsHandler(s.guard.wrap(deploymentStatusHandler, false)))
+	http.Handle("/kfctl/operations/get", optionsHandler(s.guard.wrap(getOperationHandler, false)))
+	http.Handle("/kfctl/operations/cancel", optionsHandler(s.guard.wrap(cancelOperationHandler, false)))
 
 	// add an http handler for prometheus metrics
 	http.Handle("/metrics", promhttp.Handler())
@@ -0,0 +1,261 @@
+package app
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"cloud.google.com/go/datastore"
+	"github.com/go-kit/kit/endpoint"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+)
+
+// OperationKind is the Datastore kind of the operations of the server.
+const OperationKind = "KubeflowOperation"
+
+// Type of an operation.
+const (
+	OPERATION_DEPLOY = "DEPLOY"
+)
+
+// Status of an operation.
+const (
+	OPERATION_RUNNING   = "RUNNING"
+	OPERATION_DONE      = "DONE"
+	OPERATION_FAILED    = "FAILED"
+	OPERATION_CANCELLED = "CANCELLED"
+)
+
+// OperationRecord tracks a long-running request of the server, e.g. a deployment, so the web app
+// can show its progress again after a page refresh and cancel it.
+type OperationRecord struct {
+	Id         string `json:"id"`
+	Type       string `json:"type"`
+	Project    string `json:"project"`
+	Deployment string `json:"deployment"`
+	// Status is RUNNING, including while the deployment waits in the queue, until it's DONE,
+	// FAILED or CANCELLED.
+	Status string `json:"status"`
+	// Step is the step being run, empty while the deployment is queued.
+	Step string `json:"step,omitempty"`
+	// CancelRequested is set once the operation is asked to be cancelled; a running step still
+	// finishes.
+	CancelRequested bool `json:"cancelRequested,omitempty"`
+	// Error is the reason a FAILED operation failed.
+	Error      string    `json:"error,omitempty" datastore:",noindex"`
+	StartTime  time.Time `json:"startTime"`
+	UpdateTime time.Time `json:"updateTime"`
+}
+
+// Done tells whether the operation reached a terminal status.
+func (op *OperationRecord) Done() bool {
+	return op.Status != OPERATION_RUNNING
+}
+
+// OperationStore keeps the operations of the server.
+type OperationStore interface {
+	// Put records op, replacing the record with the same id.
+	Put(ctx context.Context, op *OperationRecord) error
+	// Get returns operation id, or nil if there's none.
+	Get(ctx context.Context, id string) (*OperationRecord, error)
+}
+
+// datastoreOperationStore is an OperationStore in the Datastore of the server's project, one
+// OperationKind entity per operation keyed by its id. The operations outlive restarts of the
+// server, though those of the deployments it was running are left RUNNING.
+type datastoreOperationStore struct {
+	client *datastore.Client
+}
+
+// NewDatastoreOperationStore returns an OperationStore in the Datastore of project, using the
+// credentials of the server.
+func NewDatastoreOperationStore(ctx context.Context, project string) (OperationStore, error) {
+	client, err := datastore.NewClient(ctx, project)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't create the Datastore client of %v: %v", project, err)
+	}
+	return &datastoreOperationStore{client: client}, nil
+}
+
+func (c *datastoreOperationStore) Put(ctx context.Context, op *OperationRecord) error {
+	_, err := c.client.Put(ctx, datastore.NameKey(OperationKind, op.Id, nil), op)
+	return err
+}
+
+func (c *datastoreOperationStore) Get(ctx context.Context, id string) (*OperationRecord, error) {
+	op := &OperationRecord{}
+	err := c.client.Get(ctx, datastore.NameKey(OperationKind, id, nil), op)
+	if err == datastore.ErrNoSuchEntity {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return op, nil
+}
+
+// memoryOperationStore keeps the operations in memory, when the server has no Datastore.
+type memoryOperationStore struct {
+	mu         sync.Mutex
+	operations map[string]OperationRecord
+}
+
+func newMemoryOperationStore() *memoryOperationStore {
+	return &memoryOperationStore{operations: make(map[string]OperationRecord)}
+}
+
+func (m *memoryOperationStore) Put(ctx context.Context, op *OperationRecord) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.operations[op.Id] = *op
+	return nil
+}
+
+func (m *memoryOperationStore) Get(ctx context.Context, id string) (*OperationRecord, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	op, ok := m.operations[id]
+	if !ok {
+		return nil, nil
+	}
+	return &op, nil
+}
+
+// updateOperation applies update to operation id and records it. Failing to do so only logs a
+// warning, the operations aren't needed for the deployment itself.
+func (s *ksServer) updateOperation(ctx context.Context, id string, update func(op *OperationRecord)) {
+	s.operationsMux.Lock()
+	defer s.operationsMux.Unlock()
+	op, err := s.operations.Get(ctx, id)
+	if err != nil || op == nil {
+		log.Warnf("Failed to get operation %v: %v", id, err)
+		return
+	}
+	if op.Done() {
+		return
+	}
+	update(op)
+	op.UpdateTime = time.Now()
+	if err = s.operations.Put(ctx, op); err != nil {
+		log.Warnf("Failed to record operation %v: %v", id, err)
+	}
+}
+
+// trackDeployment records an operation for job, the deployment of req, and has job update it
+// with the step it runs and how it ends.
+func (s *ksServer) trackDeployment(ctx context.Context, req CreateRequest, job *deployJob) (*OperationRecord, error) {
+	now := time.Now()
+	op := &OperationRecord{
+		Id:         fmt.Sprintf("%v-%v", now.Unix(), generateRandStr(12)),
+		Type:       OPERATION_DEPLOY,
+		Project:    req.Project,
+		Deployment: req.Name,
+		Status:     OPERATION_RUNNING,
+		StartTime:  now,
+		UpdateTime: now,
+	}
+	if err := s.operations.Put(ctx, op); err != nil {
+		return nil, fmt.Errorf("couldn't record the operation of deployment %v in %v: %v", req.Name, req.Project, err)
+	}
+	onStep := job.onStep
+	job.onStep = func(step string) {
+		s.updateOperation(context.Background(), op.Id, func(op *OperationRecord) {
+			op.Step = step
+		})
+		if onStep != nil {
+			onStep(step)
+		}
+	}
+	done := job.done
+	job.done = func(err error) {
+		s.updateOperation(context.Background(), op.Id, func(op *OperationRecord) {
+			switch {
+			case err == errDeploymentCancelled:
+				op.Status = OPERATION_CANCELLED
+			case err != nil:
+				op.Status = OPERATION_FAILED
+				op.Error = err.Error()
+			default:
+				op.Status = OPERATION_DONE
+			}
+		})
+		done(err)
+	}
+	return op, nil
+}
+
+// OperationRequest asks for operation Id of Project.
+type OperationRequest struct {
+	Project string
+	Id      string
+	// Token is the access token of the user; they must be able to get Project.
+	Token string
+}
+
+type OperationResponse struct {
+	basicServerResponse
+	Operation *OperationRecord `json:"operation,omitempty"`
+}
+
+// GetOperation returns operation req.Id of the project of req.
+func (s *ksServer) GetOperation(ctx context.Context, req OperationRequest) (*OperationRecord, error) {
+	if err := s.checkProjectAccess(ctx, DeploymentsRequest{Project: req.Project, Token: req.Token}); err != nil {
+		return nil, err
+	}
+	op, err := s.operations.Get(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	// The operations of the other projects aren't disclosed.
+	if op == nil || op.Project != req.Project {
+		return nil, fmt.Errorf("operation %v not found in %v", req.Id, req.Project)
+	}
+	return op, nil
+}
+
+// CancelOperation cancels operation req.Id of the project of req and returns it. A queued
+// deployment is cancelled right away; a running one stops after its current step.
+func (s *ksServer) CancelOperation(ctx context.Context, req OperationRequest) (*OperationRecord, error) {
+	op, err := s.GetOperation(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if op.Done() {
+		return nil, fmt.Errorf("operation %v is already %v", op.Id, op.Status)
+	}
+	s.updateOperation(ctx, op.Id, func(op *OperationRecord) {
+		op.CancelRequested = true
+	})
+	if !s.queue.Cancel(op.Project, op.Deployment) {
+		// The server restarted since; nothing runs the deployment anymore.
+		s.updateOperation(ctx, op.Id, func(op *OperationRecord) {
+			op.Status = OPERATION_CANCELLED
+		})
+	}
+	return s.operations.Get(ctx, op.Id)
+}
+
+func makeGetOperationEndpoint(svc KsService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(OperationRequest)
+		op, err := svc.GetOperation(ctx, req)
+		r := &OperationResponse{Operation: op}
+		if err != nil {
+			r.Err = err.Error()
+		}
+		return r, nil
+	}
+}
+
+func makeCancelOperationEndpoint(svc KsService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(OperationRequest)
+		op, err := svc.CancelOperation(ctx, req)
+		r := &OperationResponse{Operation: op}
+		if err != nil {
+			r.Err = err.Error()
+		}
+		return r, nil
+	}
+}
@@ -0,0 +1,117 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package app
+
+import (
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+)
+
+func TestCancelDeployment(t *testing.T) {
+	s := &ksServer{
+		queue:      newDeployQueue(0, 1),
+		operations: newMemoryOperationStore(),
+	}
+	ctx := context.Background()
+	release := make(chan struct{})
+	ran := make(chan string, 4)
+	done := make(chan string, 2)
+	job := func(name string) *deployJob {
+		return &deployJob{
+			project: "p1",
+			name:    name,
+			steps: []deployStep{
+				{"wait", func() error {
+					ran <- name + "/wait"
+					<-release
+					return nil
+				}},
+				{"create app", func() error {
+					ran <- name + "/create app"
+					return nil
+				}},
+			},
+			done: func(error) {
+				done <- name
+			},
+		}
+	}
+	track := func(name string) (*deployJob, *OperationRecord) {
+		j := job(name)
+		op, err := s.trackDeployment(ctx, CreateRequest{Project: "p1", Name: name}, j)
+		if err != nil {
+			t.Fatalf("trackDeployment failed: %v", err)
+		}
+		s.queue.Submit(j)
+		return j, op
+	}
+	waitDone := func() {
+		select {
+		case <-done:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("the deployment wasn't done")
+		}
+	}
+	get := func(id string) *OperationRecord {
+		op, err := s.operations.Get(ctx, id)
+		if err != nil || op == nil {
+			t.Fatalf("couldn't get operation %v: %v", id, err)
+		}
+		return op
+	}
+
+	_, running := track("a")
+	_, queued := track("b")
+	if r := <-ran; r != "a/wait" {
+		t.Fatalf("%v ran first; want a/wait", r)
+	}
+	if op := get(running.Id); op.Status != OPERATION_RUNNING || op.Step != "wait" {
+		t.Errorf("operation of a: got %v at step %q; want RUNNING at step wait", op.Status, op.Step)
+	}
+
+	// b waits for a, p1 running one deployment at once; it's cancelled right away.
+	if !s.queue.Cancel("p1", "b") {
+		t.Fatalf("b wasn't found in the queue")
+	}
+	waitDone()
+	if op := get(queued.Id); op.Status != OPERATION_CANCELLED || op.Step != "" {
+		t.Errorf("operation of b: got %v at step %q; want CANCELLED before any step", op.Status, op.Step)
+	}
+
+	// a finishes the step it runs, then stops.
+	if !s.queue.Cancel("p1", "a") {
+		t.Fatalf("a wasn't found in the queue")
+	}
+	close(release)
+	waitDone()
+	if op := get(running.Id); op.Status != OPERATION_CANCELLED {
+		t.Errorf("operation of a: got %v; want CANCELLED", op.Status)
+	}
+	select {
+	case r := <-ran:
+		t.Errorf("%v ran after the deployment was cancelled", r)
+	default:
+	}
+	// The slot of a job is released right after it's done.
+	for i := 0; i < 50; i++ {
+		if _, _, ok := s.queue.Position("p1", "a"); !ok {
+			return
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	t.Errorf("a is still in the queue after it was cancelled")
+}
@@ -16,24 +16,40 @@ package options
 
 import (
 	"flag"
+	"os"
+	"strconv"
 )
 
 // ServerOption is the main context object for the controller manager.
 type ServerOption struct {
-	Apply                bool
-	PrintVersion         bool
-	JsonLogFormat        bool
-	InCluster            bool
-	KeepAlive            bool
-	InstallIstio         bool
-	Port                 int
-	AppName              string
-	AppDir               string
-	Config               string
-	Email                string
-	GkeVersionOverride   string
-	NameSpace            string
-	RegistriesConfigFile string
+	Apply                 bool
+	PrintVersion          bool
+	JsonLogFormat         bool
+	InCluster             bool
+	KeepAlive             bool
+	InstallIstio          bool
+	DeploymentLogging     bool
+	NoExec                bool
+	Port                  int
+	MaxDeployments        int
+	MaxProjectDeployments int
+	MaxUserDeployments    int
+	MaxRequestBytes       int64
+	RateLimit             float64
+	RateLimitBurst        int
+	RecaptchaMinScore     float64
+	RecaptchaSecret       string
+	OAuthClientId         string
+	OAuthClientSecret     string
+	AppName               string
+	AppDir                string
+	Config                string
+	Assets                string
+	Email                 string
+	CatalogProject        string
+	GkeVersionOverride    string
+	NameSpace             string
+	RegistriesConfigFile  string
 }
 
 // NewServerOption creates a new CMServer with a default config.
@@ -44,11 +60,19 @@ func NewServerOption() *ServerOption {
 
 const RegistriesDefaultConfig = "/opt/kubeflow/image_registries.yaml"
 
+// defaultPort is $PORT, which Cloud Run sets to the port it sends the requests to, else 8080.
+func defaultPort() int {
+	if port, err := strconv.Atoi(os.Getenv("PORT")); err == nil && port > 0 {
+		return port
+	}
+	return 8080
+}
+
 // AddFlags adds flags for a specific Server to the specified FlagSet
 func (s *ServerOption) AddFlags(fs *flag.FlagSet) {
 	fs.BoolVar(&s.PrintVersion, "version", false, "Show version and quit")
 	fs.BoolVar(&s.JsonLogFormat, "json-log-format", true, "Set true to use json style log format. Set false to use plaintext style log format")
-	fs.IntVar(&s.Port, "port", 8080, "The port to use when running an http server.")
+	fs.IntVar(&s.Port, "port", defaultPort(), "The port to use when running an http server; $PORT when it's set, e.g. on Cloud Run.")
 	fs.StringVar(&s.AppDir, "app-dir", "/opt/bootstrap", "The directory for the ksonnet applications.")
 	fs.StringVar(&s.GkeVersionOverride, "gke-version-override", "", "Override GKE master version only when GKE latest breaks")
 	fs.StringVar(&s.NameSpace, "namespace", "kubeflow", "The namespace where all resources for kubeflow will be created")
@@ -66,4 +90,35 @@ func (s *ServerOption) AddFlags(fs *flag.FlagSet) {
 	fs.StringVar(&s.Config, "config", "", "Path to a YAML file describing an app to create on startup.")
 	// Whether to install istio. Remove after we always install it.
 	fs.BoolVar(&s.InstallIstio, "install-istio", false, "Whether to install istio.")
+	fs.BoolVar(&s.DeploymentLogging, "deployment-logging", false,
+		"Whether to also write the log of each deployment to Cloud Logging in the deployed project, so its owner can see it.")
+	fs.StringVar(&s.CatalogProject, "catalog-project", "",
+		"The project whose Datastore records the deployments created through the server and their operations, for the deployments and operations APIs. Not recorded when empty; the operations are then kept in memory.")
+	fs.IntVar(&s.MaxDeployments, "max-deployments", 20,
+		"How many deployments the server runs at once; the others wait in a queue. No limit when <= 0.")
+	fs.IntVar(&s.MaxProjectDeployments, "max-project-deployments", 1,
+		"How many deployments the server runs at once in a project. No limit when <= 0.")
+	fs.IntVar(&s.MaxUserDeployments, "max-user-deployments", 2,
+		"How many deployments a Google account can have queued or running at once. No limit when <= 0.")
+	fs.Int64Var(&s.MaxRequestBytes, "max-request-bytes", 1<<20,
+		"The largest request body the server accepts. No limit when <= 0.")
+	fs.Float64Var(&s.RateLimit, "rate-limit", 60,
+		"How many requests a minute each client IP and each Google account can make. No limit when <= 0.")
+	fs.IntVar(&s.RateLimitBurst, "rate-limit-burst", 20,
+		"How many requests over --rate-limit a client can make at once.")
+	fs.StringVar(&s.RecaptchaSecret, "recaptcha-secret", os.Getenv("RECAPTCHA_SECRET"),
+		"The reCAPTCHA secret /kfctl/e2eDeploy verifies the token of its X-Recaptcha-Token header with; $RECAPTCHA_SECRET when it's set. "+
+			"Deploy requests aren't checked when empty.")
+	fs.Float64Var(&s.RecaptchaMinScore, "recaptcha-min-score", 0.5,
+		"The lowest reCAPTCHA v3 score of the deploy requests accepted.")
+	fs.StringVar(&s.Assets, "assets", "",
+		"Where /kfctl/apps/generate reads the deployment manager templates from: a gs://bucket/prefix copy of the kubeflow repo, "+
+			"a local checkout, or the ones built into the server when empty or \"embedded\".")
+	fs.BoolVar(&s.NoExec, "no-exec", false,
+		"Forbid running gcloud, ks or any other command; deployments needing one fail instead.")
+	fs.StringVar(&s.OAuthClientId, "oauth-client-id", os.Getenv("OAUTH_CLIENT_ID"),
+		"The OAuth client id of the deploy UI, which the refresh tokens of the deploy requests are refreshed with; $OAUTH_CLIENT_ID when it's set. "+
+			"Deployments are limited to the lifetime of the access token of their request without it.")
+	fs.StringVar(&s.OAuthClientSecret, "oauth-client-secret", os.Getenv("OAUTH_CLIENT_SECRET"),
+		"The OAuth client secret of --oauth-client-id; $OAUTH_CLIENT_SECRET when it's set.")
 }
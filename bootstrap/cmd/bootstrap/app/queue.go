@@ -0,0 +1,382 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-kit/kit/endpoint"
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+	"google.golang.org/api/googleapi"
+)
+
+const (
+	// maxQuotaRetries is how many times a deployment is deferred on an exhausted quota before it fails.
+	maxQuotaRetries = 6
+	// quotaRetryDelay is the delay before the first retry of a deployment deferred on an exhausted
+	// quota; it doubles with each retry, up to maxQuotaRetryDelay.
+	quotaRetryDelay    = 30 * time.Second
+	maxQuotaRetryDelay = 10 * time.Minute
+)
+
+var (
+	deploymentsQueued = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "deployments_queued",
+		Help: "Number of deployments waiting in the queue",
+	})
+	deploymentsRunning = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "deployments_running",
+		Help: "Number of deployments being run",
+	})
+	quotaExceededCounter = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "deployments_quota_exceeded",
+		Help: "Number of deployment steps deferred on an exhausted quota",
+	})
+)
+
+// errDeploymentCancelled is the error a cancelled deployment is done with.
+var errDeploymentCancelled = errors.New("the deployment was cancelled")
+
+func init() {
+	prometheus.MustRegister(deploymentsQueued)
+	prometheus.MustRegister(deploymentsRunning)
+	prometheus.MustRegister(quotaExceededCounter)
+}
+
+// isQuotaExceeded is true when err is a rate limit of a GCP API, which goes away by retrying later.
+// Some callers only keep the message of the error, so it's matched too.
+func isQuotaExceeded(err error) bool {
+	if err == nil {
+		return false
+	}
+	if e, ok := err.(*googleapi.Error); ok && e.Code == http.StatusTooManyRequests {
+		return true
+	}
+	msg := err.Error()
+	for _, s := range []string{"Error 429", "RESOURCE_EXHAUSTED", "rateLimitExceeded"} {
+		if strings.Contains(msg, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// deployStep is a step of a deployment. Steps are retried from the one that hit an exhausted
+// quota, so they must not redo the work of the steps before them.
+type deployStep struct {
+	name string
+	run  func() error
+}
+
+// deployJob is a deployment waiting in or run by the deployQueue.
+type deployJob struct {
+	project string
+	name    string
+	// account is the Google account which requested the job, when the server knows it.
+	account string
+	steps   []deployStep
+	// next is the step to run when the job gets a slot.
+	next    int
+	retries int
+	// notBefore is when a job deferred on an exhausted quota can be retried.
+	notBefore time.Time
+	// onStep, when set, is called with the name of each step before it's run.
+	onStep func(string)
+	// cancelled stops the job before its next step; it's guarded by the mutex of the queue.
+	cancelled bool
+	// done is called with the error of the failed step, errDeploymentCancelled, or nil, once the
+	// job is over.
+	done func(error)
+}
+
+func (j *deployJob) key() string {
+	return j.project + "/" + j.name
+}
+
+// deployQueue runs the deployments of the server in the order they were submitted, at most
+// maxPerProject at once in a project and maxTotal at once overall, so a burst of requests doesn't
+// get the DM and IAM calls of the server rate limited. A job whose step hits an exhausted quota
+// gives its slot back and is retried later from that step.
+type deployQueue struct {
+	mu            sync.Mutex
+	maxTotal      int
+	maxPerProject int
+	pending       []*deployJob
+	running       map[string]*deployJob
+	perProject    map[string]int
+	wake          chan struct{}
+}
+
+// newDeployQueue returns a queue running its jobs in the background; a limit <= 0 is no limit.
+func newDeployQueue(maxTotal int, maxPerProject int) *deployQueue {
+	q := &deployQueue{
+		maxTotal:      maxTotal,
+		maxPerProject: maxPerProject,
+		running:       make(map[string]*deployJob),
+		perProject:    make(map[string]int),
+		wake:          make(chan struct{}, 1),
+	}
+	go q.loop()
+	return q
+}
+
+// Submit queues job and returns its position in the queue, 1 being the next to run.
+func (q *deployQueue) Submit(job *deployJob) int {
+	q.mu.Lock()
+	q.pending = append(q.pending, job)
+	position := len(q.pending)
+	deploymentsQueued.Set(float64(len(q.pending)))
+	q.mu.Unlock()
+	q.notify()
+	return position
+}
+
+// Position returns the position in the queue of deployment name of project, 0 when it's running,
+// and false when it's neither queued nor running. A deferred job is also given the time it's
+// retried at.
+func (q *deployQueue) Position(project string, name string) (int, *time.Time, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	key := project + "/" + name
+	if _, ok := q.running[key]; ok {
+		return 0, nil, true
+	}
+	for i, job := range q.pending {
+		if job.key() == key {
+			if job.notBefore.After(time.Now()) {
+				retryTime := job.notBefore
+				return i + 1, &retryTime, true
+			}
+			return i + 1, nil, true
+		}
+	}
+	return 0, nil, false
+}
+
+// AccountJobs returns how many of the jobs queued or running were requested by account.
+func (q *deployQueue) AccountJobs(account string) int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	n := 0
+	for _, job := range q.running {
+		if job.account == account {
+			n++
+		}
+	}
+	for _, job := range q.pending {
+		if job.account == account {
+			n++
+		}
+	}
+	return n
+}
+
+// Cancel cancels deployment name of project and returns false when it's neither queued nor
+// running. A queued deployment is done right away; a running one once its current step returns,
+// as the DM and GKE calls of a step can't be interrupted halfway.
+func (q *deployQueue) Cancel(project string, name string) bool {
+	q.mu.Lock()
+	key := project + "/" + name
+	if job, ok := q.running[key]; ok {
+		job.cancelled = true
+		q.mu.Unlock()
+		return true
+	}
+	for i, job := range q.pending {
+		if job.key() == key {
+			q.pending = append(q.pending[:i], q.pending[i+1:]...)
+			deploymentsQueued.Set(float64(len(q.pending)))
+			q.mu.Unlock()
+			job.done(errDeploymentCancelled)
+			return true
+		}
+	}
+	q.mu.Unlock()
+	return false
+}
+
+// isCancelled tells whether job was cancelled while it ran.
+func (q *deployQueue) isCancelled(job *deployJob) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return job.cancelled
+}
+
+func (q *deployQueue) notify() {
+	select {
+	case q.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (q *deployQueue) loop() {
+	for {
+		for q.startNext() {
+		}
+		select {
+		case <-q.wake:
+		case <-time.After(time.Second):
+		}
+	}
+}
+
+// startNext starts the first pending job that can run now, and returns false when there's none.
+func (q *deployQueue) startNext() bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.maxTotal > 0 && len(q.running) >= q.maxTotal {
+		return false
+	}
+	now := time.Now()
+	for i, job := range q.pending {
+		if job.notBefore.After(now) {
+			continue
+		}
+		if q.maxPerProject > 0 && q.perProject[job.project] >= q.maxPerProject {
+			continue
+		}
+		q.pending = append(q.pending[:i], q.pending[i+1:]...)
+		q.running[job.key()] = job
+		q.perProject[job.project]++
+		deploymentsQueued.Set(float64(len(q.pending)))
+		deploymentsRunning.Set(float64(len(q.running)))
+		go q.run(job)
+		return true
+	}
+	return false
+}
+
+// run runs the steps of job from job.next, deferring it when a step hits an exhausted quota.
+func (q *deployQueue) run(job *deployJob) {
+	for ; job.next < len(job.steps); job.next++ {
+		if q.isCancelled(job) {
+			job.done(errDeploymentCancelled)
+			q.release(job, false)
+			return
+		}
+		step := job.steps[job.next]
+		if job.onStep != nil {
+			job.onStep(step.name)
+		}
+		err := step.run()
+		if err == nil {
+			continue
+		}
+		if isQuotaExceeded(err) && job.retries < maxQuotaRetries {
+			quotaExceededCounter.Inc()
+			delay := quotaRetryDelay << uint(job.retries)
+			if delay > maxQuotaRetryDelay {
+				delay = maxQuotaRetryDelay
+			}
+			job.retries++
+			log.Warnf("Deployment %v exceeded a quota to %v; retrying in %v: %v", job.key(), step.name, delay, err)
+			job.notBefore = time.Now().Add(delay)
+			q.release(job, true)
+			return
+		}
+		// The job is done before its slot is released, so its status isn't lost in between.
+		job.done(fmt.Errorf("failed to %v: %v", step.name, err))
+		q.release(job, false)
+		return
+	}
+	job.done(nil)
+	q.release(job, false)
+}
+
+// release frees the slot of job, putting it back at the head of the queue when requeue is set.
+func (q *deployQueue) release(job *deployJob, requeue bool) {
+	q.mu.Lock()
+	delete(q.running, job.key())
+	q.perProject[job.project]--
+	if q.perProject[job.project] == 0 {
+		delete(q.perProject, job.project)
+	}
+	if requeue {
+		q.pending = append([]*deployJob{job}, q.pending...)
+	}
+	deploymentsQueued.Set(float64(len(q.pending)))
+	deploymentsRunning.Set(float64(len(q.running)))
+	q.mu.Unlock()
+	q.notify()
+}
+
+// QueueDeployment queues job, the deployment of req, unless that deployment is already queued or
+// running, and tracks it with an operation.
+func (s *ksServer) QueueDeployment(ctx context.Context, req CreateRequest, job *deployJob) (int, *OperationRecord, error) {
+	if _, _, ok := s.queue.Position(req.Project, req.Name); ok {
+		return 0, nil, fmt.Errorf("deployment %v of %v is already queued or running", req.Name, req.Project)
+	}
+	// The account is the one of the access token the abuse guard verified, not the email of the
+	// request, which anyone can set.
+	if id, ok := requestIdentity(ctx); ok && id.Account != "" {
+		job.account = id.Account
+		if s.maxUserDeployments > 0 && s.queue.AccountJobs(id.Account) >= s.maxUserDeployments {
+			return 0, nil, fmt.Errorf("%v already has %v deployments queued or running; wait for one to finish",
+				id.Account, s.maxUserDeployments)
+		}
+	}
+	op, err := s.trackDeployment(ctx, req, job)
+	if err != nil {
+		return 0, nil, err
+	}
+	s.RecordDeployment(ctx, req, DEPLOYMENT_QUEUED, "")
+	return s.queue.Submit(job), op, nil
+}
+
+// DeploymentStatusResponse is where a deployment is in the queue or, once it left the queue, its
+// status in the catalog.
+type DeploymentStatusResponse struct {
+	basicServerResponse
+	Status string `json:"status,omitempty"`
+	// QueuePosition is the position of a QUEUED deployment, 1 being the next to run.
+	QueuePosition int `json:"queuePosition,omitempty"`
+	// RetryTime is when a deployment deferred on an exhausted quota is retried.
+	RetryTime *time.Time `json:"retryTime,omitempty"`
+	// Error is the reason a FAILED deployment failed.
+	Error string `json:"error,omitempty"`
+}
+
+// GetDeploymentQueueStatus returns the status of deployment req.Name of the project of req.
+func (s *ksServer) GetDeploymentQueueStatus(ctx context.Context, req DeploymentsRequest) (*DeploymentStatusResponse, error) {
+	if err := s.checkProjectAccess(ctx, req); err != nil {
+		return nil, err
+	}
+	if position, retryTime, ok := s.queue.Position(req.Project, req.Name); ok {
+		if position == 0 {
+			return &DeploymentStatusResponse{Status: DEPLOYMENT_DEPLOYING}, nil
+		}
+		return &DeploymentStatusResponse{
+			Status:        DEPLOYMENT_QUEUED,
+			QueuePosition: position,
+			RetryTime:     retryTime,
+		}, nil
+	}
+	if s.catalog == nil {
+		return nil, fmt.Errorf("deployment %v isn't queued in %v", req.Name, req.Project)
+	}
+	d, err := s.catalog.Get(ctx, req.Project, req.Name)
+	if err != nil {
+		return nil, err
+	}
+	if d == nil {
+		return nil, fmt.Errorf("deployment %v not found in %v", req.Name, req.Project)
+	}
+	return &DeploymentStatusResponse{Status: d.Status, Error: d.Error}, nil
+}
+
+func makeDeploymentStatusEndpoint(svc KsService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(DeploymentsRequest)
+		r, err := svc.GetDeploymentQueueStatus(ctx, req)
+		if err != nil {
+			r = &DeploymentStatusResponse{}
+			r.Err = err.Error()
+		}
+		return r, nil
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package app
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"google.golang.org/api/googleapi"
+)
+
+func TestIsQuotaExceeded(t *testing.T) {
+	cases := []struct {
+		err      error
+		expected bool
+	}{
+		{nil, false},
+		{&googleapi.Error{Code: 429, Message: "Quota exceeded"}, true},
+		{&googleapi.Error{Code: 403, Message: "The caller does not have permission"}, false},
+		{fmt.Errorf("Cannot set new policy: %v", &googleapi.Error{Code: 429, Message: "Too many requests"}), true},
+		{errors.New("rpc error: code = ResourceExhausted desc = RESOURCE_EXHAUSTED"), true},
+		{errors.New("Deployment manager returned error message: QUOTA_EXCEEDED CPUS"), false},
+	}
+	for _, c := range cases {
+		if actual := isQuotaExceeded(c.err); actual != c.expected {
+			t.Errorf("isQuotaExceeded(%v) = %v; want %v", c.err, actual, c.expected)
+		}
+	}
+}
+
+func TestDeployQueueLimitsProjects(t *testing.T) {
+	q := newDeployQueue(0, 1)
+	release := make(chan struct{})
+	done := make(chan string, 3)
+	job := func(project string, name string) *deployJob {
+		return &deployJob{
+			project: project,
+			name:    name,
+			steps: []deployStep{{"wait", func() error {
+				<-release
+				return nil
+			}}},
+			done: func(error) {
+				done <- project + "/" + name
+			},
+		}
+	}
+
+	q.Submit(job("p1", "a"))
+	q.Submit(job("p1", "b"))
+	q.Submit(job("p2", "c"))
+
+	waitFor := func(project string, name string, expected int) {
+		for i := 0; i < 50; i++ {
+			if position, _, _ := q.Position(project, name); position == expected {
+				return
+			}
+			time.Sleep(20 * time.Millisecond)
+		}
+		position, _, _ := q.Position(project, name)
+		t.Fatalf("Position of %v/%v is %v; want %v", project, name, position, expected)
+	}
+	// a and c run, b waits for a since p1 runs one deployment at once.
+	waitFor("p1", "a", 0)
+	waitFor("p2", "c", 0)
+	waitFor("p1", "b", 1)
+
+	close(release)
+	for i := 0; i < 3; i++ {
+		select {
+		case <-done:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("Deployments didn't finish")
+		}
+	}
+	// The slot of a job is released right after it's done.
+	for i := 0; i < 50; i++ {
+		if _, _, ok := q.Position("p1", "b"); !ok {
+			return
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	t.Errorf("p1/b is still in the queue after it finished")
+}
@@ -27,8 +27,11 @@ import (
 	"github.com/ghodss/yaml"
 	"github.com/kubeflow/kubeflow/bootstrap/cmd/bootstrap/app/options"
 	kstypes "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps/kfdef/v1alpha1"
+	"github.com/kubeflow/kubeflow/bootstrap/pkg/utils"
 	"github.com/kubeflow/kubeflow/bootstrap/version"
 	log "github.com/sirupsen/logrus"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
 	"k8s.io/api/storage/v1"
 	k8sVersion "k8s.io/apimachinery/pkg/version"
 	"k8s.io/client-go/rest"
@@ -239,13 +242,17 @@ func processFile(opt *options.ServerOption, ksServer *ksServer) error {
 	return nil
 }
 
-// Run the application.
-func Run(opt *options.ServerOption) error {
+// Run the application. generate serves /kfctl/apps/generate unless it's nil. checks are run on
+// the deploy requests besides the reCAPTCHA check of --recaptcha-secret, e.g. to block the
+// accounts or projects abusing the server.
+func Run(opt *options.ServerOption, generate GenerateFunc, checks ...AbuseCheck) error {
 	// Check if the -version flag was passed and, if so, print the version and exit.
 	if opt.PrintVersion {
 		version.PrintVersionAndExit()
 	}
 
+	utils.SetNoExec(opt.NoExec)
+
 	// Load information about the default registries.
 	var regConfig kstypes.RegistriesConfigFile
 
@@ -258,11 +265,41 @@ func Run(opt *options.ServerOption) error {
 		log.Info("--registries-config-file not provided; not loading any registries")
 	}
 
-	ksServer, err := NewServer(opt.AppDir, regConfig.Registries, opt.GkeVersionOverride, opt.InstallIstio)
+	var catalog DeploymentCatalog
+	var operations OperationStore
+	if opt.CatalogProject != "" {
+		c, err := NewDatastoreCatalog(context.Background(), opt.CatalogProject)
+		if err != nil {
+			return err
+		}
+		catalog = c
+		o, err := NewDatastoreOperationStore(context.Background(), opt.CatalogProject)
+		if err != nil {
+			return err
+		}
+		operations = o
+		log.Infof("Recording deployments and their operations in the Datastore of %v", opt.CatalogProject)
+	}
+
+	ksServer, err := NewServer(opt.AppDir, regConfig.Registries, opt.GkeVersionOverride, opt.InstallIstio,
+		opt.DeploymentLogging, catalog, operations, opt.MaxDeployments, opt.MaxProjectDeployments, generate)
 
 	if err != nil {
 		return err
 	}
+	ksServer.maxUserDeployments = opt.MaxUserDeployments
+	if opt.RecaptchaSecret != "" {
+		checks = append(checks, recaptchaCheck(opt.RecaptchaSecret, opt.RecaptchaMinScore))
+	}
+	ksServer.guard = newAbuseGuard(opt.MaxRequestBytes, opt.RateLimit, opt.RateLimitBurst, checks...)
+	if opt.OAuthClientId != "" {
+		ksServer.oauthConfig = &oauth2.Config{
+			ClientID:     opt.OAuthClientId,
+			ClientSecret: opt.OAuthClientSecret,
+			Endpoint:     google.Endpoint,
+			Scopes:       deployScopes,
+		}
+	}
 
 	if opt.Config != "" {
 		log.Infof("Processing file: %v", opt.Config)
@@ -16,11 +16,20 @@ package main
 
 import (
 	"flag"
+	"net/http"
+	"strings"
+
 	"github.com/onrik/logrus/filename"
 	log "github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+	"golang.org/x/oauth2/google"
+	"google.golang.org/api/storage/v1"
 
 	"github.com/kubeflow/kubeflow/bootstrap/cmd/bootstrap/app"
 	"github.com/kubeflow/kubeflow/bootstrap/cmd/bootstrap/app/options"
+	kstypes "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps/kfdef/v1alpha1"
+	"github.com/kubeflow/kubeflow/bootstrap/pkg/kfapp/assets"
+	"github.com/kubeflow/kubeflow/bootstrap/pkg/kfapp/gcp"
 )
 
 func init() {
@@ -30,6 +39,35 @@ func init() {
 	log.AddHook(filenameHook)
 }
 
+// newGenerateFunc returns the Generate of the gcp kfapp reading the templates from source, the
+// --assets flag. A bucket is read with the credentials of the server, e.g. the service account
+// of its Cloud Run service.
+func newGenerateFunc(source string) (app.GenerateFunc, error) {
+	var client *http.Client
+	if strings.HasPrefix(source, assets.GCS_SCHEME) {
+		c, err := google.DefaultClient(context.Background(), storage.DevstorageReadOnlyScope)
+		if err != nil {
+			return nil, err
+		}
+		client = c
+	}
+	loader, err := assets.NewLoader(source, client)
+	if err != nil {
+		return nil, err
+	}
+	return func(kfdef *kstypes.KfDef) (map[string][]byte, error) {
+		bundle, err := gcp.GenerateConfigs(kfdef, loader)
+		if err != nil {
+			return nil, err
+		}
+		files := make(map[string][]byte)
+		for _, name := range bundle.Names() {
+			files[name], _ = bundle.Get(name)
+		}
+		return files, nil
+	}, nil
+}
+
 func main() {
 	s := options.NewServerOption()
 	s.AddFlags(flag.CommandLine)
@@ -41,7 +79,11 @@ func main() {
 		log.SetFormatter(&log.JSONFormatter{})
 	}
 
-	if err := app.Run(s); err != nil {
+	generate, err := newGenerateFunc(s.Assets)
+	if err != nil {
+		log.Fatalf("Couldn't read the assets of %v: %v\n", s.Assets, err)
+	}
+	if err := app.Run(s, generate); err != nil {
 		log.Fatalf("%v\n", err)
 	}
 }
@@ -0,0 +1,151 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	kftypes "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps"
+	"github.com/kubeflow/kubeflow/bootstrap/pkg/kfapp/coordinator"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var adoptCfg = viper.New()
+
+// adoptCmd represents the adopt command
+var adoptCmd = &cobra.Command{
+	Use:   "adopt --project=<project> --zone=<zone> --cluster=<cluster>",
+	Short: "Create a kubeflow application for a deployment kfctl didn't create.",
+	Long: `Create a kubeflow application under <cluster> in the current directory for an existing deployment on
+GCP, e.g. one created by the web deployer or older scripts, so it can be managed by kfctl afterwards.
+app.yaml is filled in from the labels of the deployment manager deployments, the IAM policy of the
+project, the auth secrets, the ingress and the components installed in the cluster, and gcp_config
+holds the configs of the deployments and their IAM bindings as they're deployed.
+
+Review app.yaml, then run kfctl diff to check the app matches the deployment. kfctl generate platform
+would replace gcp_config with the configs of this kfctl; kfctl generate k8s creates the ksonnet app of
+the components.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if adoptCfg.GetBool(string(kftypes.VERBOSE)) == true {
+			log.SetLevel(log.InfoLevel)
+		} else {
+			log.SetLevel(log.WarnLevel)
+		}
+		options := map[string]interface{}{
+			string(kftypes.PROJECT):             adoptCfg.GetString(string(kftypes.PROJECT)),
+			string(kftypes.ZONE):                adoptCfg.GetString(string(kftypes.ZONE)),
+			string(kftypes.CLUSTER):             adoptCfg.GetString(string(kftypes.CLUSTER)),
+			string(kftypes.NAMESPACE):           adoptCfg.GetString(string(kftypes.NAMESPACE)),
+			string(kftypes.EMAIL):               adoptCfg.GetString(string(kftypes.EMAIL)),
+			string(kftypes.VERSION):             adoptCfg.GetString(string(kftypes.VERSION)),
+			string(kftypes.USE_EMBEDDED_ASSETS): adoptCfg.GetBool(string(kftypes.USE_EMBEDDED_ASSETS)),
+			string(kftypes.MIRROR):              adoptCfg.GetString(string(kftypes.MIRROR)),
+		}
+		kfApp, kfAppErr := coordinator.AdoptKfApp(options)
+		if kfAppErr != nil || kfApp == nil {
+			return fmt.Errorf("couldn't adopt KfApp: %v", kfAppErr)
+		}
+		initErr := kfApp.Init(kftypes.ALL)
+		if initErr != nil {
+			return fmt.Errorf("KfApp initialization failed: %v", initErr)
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(adoptCmd)
+
+	adoptCfg.SetConfigName("app")
+	adoptCfg.SetConfigType("yaml")
+
+	adoptCmd.Flags().String(string(kftypes.PROJECT), "",
+		string(kftypes.PROJECT)+" the deployment is in")
+	bindErr := adoptCfg.BindPFlag(string(kftypes.PROJECT), adoptCmd.Flags().Lookup(string(kftypes.PROJECT)))
+	if bindErr != nil {
+		log.Errorf("couldn't set flag --%v: %v", string(kftypes.PROJECT), bindErr)
+		return
+	}
+
+	adoptCmd.Flags().String(string(kftypes.ZONE), kftypes.DefaultZone,
+		string(kftypes.ZONE)+" of the cluster")
+	bindErr = adoptCfg.BindPFlag(string(kftypes.ZONE), adoptCmd.Flags().Lookup(string(kftypes.ZONE)))
+	if bindErr != nil {
+		log.Errorf("couldn't set flag --%v: %v", string(kftypes.ZONE), bindErr)
+		return
+	}
+
+	adoptCmd.Flags().String(string(kftypes.CLUSTER), "",
+		"name of the GKE cluster, which is also the name of the deployment and of the app")
+	bindErr = adoptCfg.BindPFlag(string(kftypes.CLUSTER), adoptCmd.Flags().Lookup(string(kftypes.CLUSTER)))
+	if bindErr != nil {
+		log.Errorf("couldn't set flag --%v: %v", string(kftypes.CLUSTER), bindErr)
+		return
+	}
+
+	adoptCmd.Flags().StringP(string(kftypes.NAMESPACE), "n", kftypes.DefaultNamespace,
+		string(kftypes.NAMESPACE)+" kubeflow is deployed in")
+	bindErr = adoptCfg.BindPFlag(string(kftypes.NAMESPACE), adoptCmd.Flags().Lookup(string(kftypes.NAMESPACE)))
+	if bindErr != nil {
+		log.Errorf("couldn't set flag --%v: %v", string(kftypes.NAMESPACE), bindErr)
+		return
+	}
+
+	adoptCmd.Flags().String(string(kftypes.EMAIL), "",
+		string(kftypes.EMAIL)+" of the deployment; taken from the IAP users of the project when they're only one")
+	bindErr = adoptCfg.BindPFlag(string(kftypes.EMAIL), adoptCmd.Flags().Lookup(string(kftypes.EMAIL)))
+	if bindErr != nil {
+		log.Errorf("couldn't set flag --%v: %v", string(kftypes.EMAIL), bindErr)
+		return
+	}
+
+	adoptCmd.Flags().StringP(string(kftypes.VERSION), "v", kftypes.DefaultVersion,
+		string(kftypes.VERSION)+" of Kubeflow the deployment runs, its templates are used by kfctl afterwards")
+	bindErr = adoptCfg.BindPFlag(string(kftypes.VERSION), adoptCmd.Flags().Lookup(string(kftypes.VERSION)))
+	if bindErr != nil {
+		log.Errorf("couldn't set flag --%v: %v", string(kftypes.VERSION), bindErr)
+		return
+	}
+
+	adoptCmd.Flags().Bool(string(kftypes.USE_EMBEDDED_ASSETS), false,
+		string(kftypes.USE_EMBEDDED_ASSETS)+" take the app configs, deployment manager templates and istio manifests "+
+			"from kfctl instead of the kubeflow repo, which isn't downloaded; the ksonnet registry is read from --"+
+			string(kftypes.MIRROR)+".")
+	bindErr = adoptCfg.BindPFlag(string(kftypes.USE_EMBEDDED_ASSETS), adoptCmd.Flags().Lookup(string(kftypes.USE_EMBEDDED_ASSETS)))
+	if bindErr != nil {
+		log.Errorf("couldn't set flag --%v: %v", string(kftypes.USE_EMBEDDED_ASSETS), bindErr)
+		return
+	}
+
+	adoptCmd.Flags().String(string(kftypes.MIRROR), "",
+		"local checkout or tarball of the kubeflow repo used instead of downloading --"+string(kftypes.VERSION)+
+			" from github.")
+	bindErr = adoptCfg.BindPFlag(string(kftypes.MIRROR), adoptCmd.Flags().Lookup(string(kftypes.MIRROR)))
+	if bindErr != nil {
+		log.Errorf("couldn't set flag --%v: %v", string(kftypes.MIRROR), bindErr)
+		return
+	}
+
+	// verbose output
+	adoptCmd.Flags().BoolP(string(kftypes.VERBOSE), "V", false,
+		string(kftypes.VERBOSE)+" output default is false")
+	bindErr = adoptCfg.BindPFlag(string(kftypes.VERBOSE), adoptCmd.Flags().Lookup(string(kftypes.VERBOSE)))
+	if bindErr != nil {
+		log.Errorf("couldn't set flag --%v: %v", string(kftypes.VERBOSE), bindErr)
+		return
+	}
+}
@@ -42,7 +42,18 @@ var applyCmd = &cobra.Command{
 		if resourceErr != nil {
 			return fmt.Errorf("invalid resource: %v", resourceErr)
 		}
-		kfApp, kfAppErr := coordinator.LoadKfApp(map[string]interface{}{})
+		options := map[string]interface{}{
+			string(kftypes.KUBECONFIG):        applyCfg.GetString(string(kftypes.KUBECONFIG)),
+			string(kftypes.KUBECONTEXT):       applyCfg.GetString(string(kftypes.KUBECONTEXT)),
+			string(kftypes.PASSWORD_FILE):     applyCfg.GetString(string(kftypes.PASSWORD_FILE)),
+			string(kftypes.OAUTH_CLIENT_FILE): applyCfg.GetString(string(kftypes.OAUTH_CLIENT_FILE)),
+			string(kftypes.BCRYPT_COST):       applyCfg.GetInt(string(kftypes.BCRYPT_COST)),
+			string(kftypes.ROTATE_SA_KEYS):    applyCfg.GetBool(string(kftypes.ROTATE_SA_KEYS)),
+			string(kftypes.DM_RECOVERY):       applyCfg.GetString(string(kftypes.DM_RECOVERY)),
+			string(kftypes.TIMEOUT):           applyCfg.GetDuration(string(kftypes.TIMEOUT)),
+			string(kftypes.TARGET):            applyCfg.GetStringSlice(string(kftypes.TARGET)),
+		}
+		kfApp, kfAppErr := coordinator.LoadKfApp(options)
 		if kfAppErr != nil {
 			return fmt.Errorf("couldn't load KfApp: %v", kfAppErr)
 		}
@@ -50,6 +61,9 @@ var applyCmd = &cobra.Command{
 		if applyErr != nil {
 			return fmt.Errorf("couldn't apply KfApp: %v", applyErr)
 		}
+		if resource != kftypes.PLATFORM {
+			printEndpoint(kfApp)
+		}
 		return nil
 	},
 }
@@ -68,4 +82,80 @@ func init() {
 		log.Errorf("couldn't set flag --%v: %v", string(kftypes.VERBOSE), bindErr)
 		return
 	}
+
+	applyCmd.Flags().String(string(kftypes.KUBECONFIG), "",
+		"Path to a kubeconfig used to reach the cluster instead of looking it up through the GKE API.")
+	bindErr = applyCfg.BindPFlag(string(kftypes.KUBECONFIG), applyCmd.Flags().Lookup(string(kftypes.KUBECONFIG)))
+	if bindErr != nil {
+		log.Errorf("couldn't set flag --%v: %v", string(kftypes.KUBECONFIG), bindErr)
+		return
+	}
+
+	applyCmd.Flags().String(string(kftypes.KUBECONTEXT), "",
+		"Name of the kubeconfig context used to reach the cluster instead of looking it up through the GKE API.")
+	bindErr = applyCfg.BindPFlag(string(kftypes.KUBECONTEXT), applyCmd.Flags().Lookup(string(kftypes.KUBECONTEXT)))
+	if bindErr != nil {
+		log.Errorf("couldn't set flag --%v: %v", string(kftypes.KUBECONTEXT), bindErr)
+		return
+	}
+
+	applyCmd.Flags().String(string(kftypes.PASSWORD_FILE), "",
+		"File holding the basic auth password, or - to read it from stdin. Used instead of "+
+			kftypes.KUBEFLOW_PASSWORD+".")
+	bindErr = applyCfg.BindPFlag(string(kftypes.PASSWORD_FILE), applyCmd.Flags().Lookup(string(kftypes.PASSWORD_FILE)))
+	if bindErr != nil {
+		log.Errorf("couldn't set flag --%v: %v", string(kftypes.PASSWORD_FILE), bindErr)
+		return
+	}
+
+	applyCmd.Flags().String(string(kftypes.OAUTH_CLIENT_FILE), "",
+		"client_secret JSON file of the OAuth client of IAP. Used instead of spec.auth.oauthClient, "+
+			"CLIENT_ID and CLIENT_SECRET.")
+	bindErr = applyCfg.BindPFlag(string(kftypes.OAUTH_CLIENT_FILE), applyCmd.Flags().Lookup(string(kftypes.OAUTH_CLIENT_FILE)))
+	if bindErr != nil {
+		log.Errorf("couldn't set flag --%v: %v", string(kftypes.OAUTH_CLIENT_FILE), bindErr)
+		return
+	}
+
+	applyCmd.Flags().Int(string(kftypes.BCRYPT_COST), 0,
+		"Cost of the bcrypt hash of the basic auth password; saved in app.yaml.")
+	bindErr = applyCfg.BindPFlag(string(kftypes.BCRYPT_COST), applyCmd.Flags().Lookup(string(kftypes.BCRYPT_COST)))
+	if bindErr != nil {
+		log.Errorf("couldn't set flag --%v: %v", string(kftypes.BCRYPT_COST), bindErr)
+		return
+	}
+
+	applyCmd.Flags().Bool(string(kftypes.ROTATE_SA_KEYS), false,
+		"Delete the oldest service account key created by kfctl when a service account has too many keys "+
+			"to create the one of its secret.")
+	bindErr = applyCfg.BindPFlag(string(kftypes.ROTATE_SA_KEYS), applyCmd.Flags().Lookup(string(kftypes.ROTATE_SA_KEYS)))
+	if bindErr != nil {
+		log.Errorf("couldn't set flag --%v: %v", string(kftypes.ROTATE_SA_KEYS), bindErr)
+		return
+	}
+	applyCmd.Flags().String(string(kftypes.DM_RECOVERY), "",
+		"How to recover a deployment manager deployment whose last operation failed or is stuck: stop to "+
+			"stop its operation, abandon to delete it keeping its resources and create it again taking them "+
+			"over, recreate to delete it with its resources and create it again.")
+	bindErr = applyCfg.BindPFlag(string(kftypes.DM_RECOVERY), applyCmd.Flags().Lookup(string(kftypes.DM_RECOVERY)))
+	if bindErr != nil {
+		log.Errorf("couldn't set flag --%v: %v", string(kftypes.DM_RECOVERY), bindErr)
+		return
+	}
+	applyCmd.Flags().Duration(string(kftypes.TIMEOUT), 0,
+		"Time limit of the whole apply, e.g. 60m, shared by its platform, k8s and post-apply phases. "+
+			"When it's exceeded apply fails and the next apply resumes after the phases done. 0 for no limit.")
+	bindErr = applyCfg.BindPFlag(string(kftypes.TIMEOUT), applyCmd.Flags().Lookup(string(kftypes.TIMEOUT)))
+	if bindErr != nil {
+		log.Errorf("couldn't set flag --%v: %v", string(kftypes.TIMEOUT), bindErr)
+		return
+	}
+	applyCmd.Flags().StringSlice(string(kftypes.TARGET), []string{},
+		"Only apply these pieces of the platform, e.g. --target=storage,network,iam,istio, to re-run the one "+
+			"which failed. The k8s resources of the package managers aren't applied.")
+	bindErr = applyCfg.BindPFlag(string(kftypes.TARGET), applyCmd.Flags().Lookup(string(kftypes.TARGET)))
+	if bindErr != nil {
+		log.Errorf("couldn't set flag --%v: %v", string(kftypes.TARGET), bindErr)
+		return
+	}
 }
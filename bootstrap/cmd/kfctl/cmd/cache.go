@@ -0,0 +1,97 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	kftypes "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps"
+	"github.com/kubeflow/kubeflow/bootstrap/pkg/kfapp/coordinator"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"os"
+	"text/tabwriter"
+	"time"
+)
+
+var cacheCfg = viper.New()
+
+// cacheCmd represents the cache command
+var cacheCmd = &cobra.Command{
+	Use:   "cache",
+	Short: "Manage the kubeflow repo tarballs downloaded by kfctl init.",
+	Long: `List or purge the kubeflow repo tarballs kfctl init downloads, kept by commit in kfctl/repos of the
+user's cache directory, or in ` + coordinator.KFCTL_CACHE_DIR + ` when set. A cached tarball is checked against
+the checksum it was downloaded with before it's used, and downloaded again when it doesn't match.`,
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		if cacheCfg.GetBool(string(kftypes.VERBOSE)) == true {
+			log.SetLevel(log.InfoLevel)
+		} else {
+			log.SetLevel(log.WarnLevel)
+		}
+	},
+}
+
+var cacheListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the cached kubeflow repo tarballs, the latest first.",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		entries, err := coordinator.ListCache()
+		if err != nil {
+			return fmt.Errorf("couldn't list the cache: %v", err)
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+		fmt.Fprintln(w, "COMMIT\tVERSION\tSIZE (MB)\tDOWNLOADED\tSHA256")
+		for _, entry := range entries {
+			fmt.Fprintf(w, "%v\t%v\t%.1f\t%v\t%v\n", entry.Sha, entry.Version, float64(entry.Size)/(1<<20),
+				entry.Downloaded.Local().Format(time.RFC3339), entry.Checksum)
+		}
+		return w.Flush()
+	},
+}
+
+var cachePurgeCmd = &cobra.Command{
+	Use:   "purge [<commit>...]",
+	Short: "Remove the cached kubeflow repo tarballs of the commits given, or all of them.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		removed, err := coordinator.PurgeCache(args)
+		for _, sha := range removed {
+			fmt.Printf("removed %v\n", sha)
+		}
+		if err != nil {
+			return fmt.Errorf("couldn't purge the cache: %v", err)
+		}
+		if len(args) > 0 && len(removed) == 0 {
+			return fmt.Errorf("no cached kubeflow repo matches %v", args)
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(cacheCmd)
+	cacheCmd.AddCommand(cacheListCmd)
+	cacheCmd.AddCommand(cachePurgeCmd)
+
+	// verbose output
+	cacheCmd.PersistentFlags().BoolP(string(kftypes.VERBOSE), "V", false,
+		string(kftypes.VERBOSE)+" output default is false")
+	bindErr := cacheCfg.BindPFlag(string(kftypes.VERBOSE), cacheCmd.PersistentFlags().Lookup(string(kftypes.VERBOSE)))
+	if bindErr != nil {
+		log.Errorf("couldn't set flag --%v: %v", string(kftypes.VERBOSE), bindErr)
+		return
+	}
+}
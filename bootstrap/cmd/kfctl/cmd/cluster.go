@@ -0,0 +1,187 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	kftypes "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps"
+	"github.com/kubeflow/kubeflow/bootstrap/pkg/kfapp/coordinator"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var clusterCfg = viper.New()
+
+// clusterUpgradeCfg holds the flags of cluster upgrade, whose --nodes is a version rather than
+// the number of nodes of cluster resize.
+var clusterUpgradeCfg = viper.New()
+
+// loadKfCluster loads the KfApp of the current directory for the cluster subcommands.
+func loadKfCluster() (kftypes.KfCluster, error) {
+	if clusterCfg.GetBool(string(kftypes.VERBOSE)) == true {
+		log.SetLevel(log.InfoLevel)
+	} else {
+		log.SetLevel(log.WarnLevel)
+	}
+	kfApp, kfAppErr := coordinator.LoadKfApp(map[string]interface{}{})
+	if kfAppErr != nil {
+		return nil, fmt.Errorf("couldn't load KfApp: %v", kfAppErr)
+	}
+	cluster, ok := kfApp.(kftypes.KfCluster)
+	if !ok || cluster == nil {
+		return nil, fmt.Errorf("KfApp does not manage the node pools of its cluster")
+	}
+	return cluster, nil
+}
+
+// clusterCmd represents the cluster command
+var clusterCmd = &cobra.Command{
+	Use:   "cluster",
+	Short: "Change the node pools of the cluster of a kubeflow application.",
+	Long: `Resize the node pools of the cluster, change their machine type or upgrade the cluster. The
+change is applied to the cluster and recorded in app.yaml and the cluster config, instead of drifting
+from them when made in the console.`,
+}
+
+var clusterResizeCmd = &cobra.Command{
+	Use:   "resize",
+	Short: "Set the number of nodes of a node pool.",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cluster, err := loadKfCluster()
+		if err != nil {
+			return err
+		}
+		pool := clusterCfg.GetString(string(kftypes.POOL))
+		nodes := clusterCfg.GetInt(string(kftypes.NODES))
+		if resizeErr := cluster.ResizeNodePool(pool, nodes); resizeErr != nil {
+			return fmt.Errorf("couldn't resize %v: %v", pool, resizeErr)
+		}
+		return nil
+	},
+}
+
+var clusterSetMachineTypeCmd = &cobra.Command{
+	Use:   "set-machine-type",
+	Short: "Move a node pool to another machine type.",
+	Long: `Move a node pool to another machine type. The pool is replaced by a new one with the machine
+type, its pods are rescheduled on the new pool as the old one is deleted.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cluster, err := loadKfCluster()
+		if err != nil {
+			return err
+		}
+		pool := clusterCfg.GetString(string(kftypes.POOL))
+		machineType := clusterCfg.GetString(string(kftypes.MACHINE_TYPE))
+		if setErr := cluster.SetNodePoolMachineType(pool, machineType); setErr != nil {
+			return fmt.Errorf("couldn't set the machine type of %v: %v", pool, setErr)
+		}
+		return nil
+	},
+}
+
+var clusterUpgradeCmd = &cobra.Command{
+	Use:   "upgrade",
+	Short: "Upgrade the GKE version of the master and the node pools.",
+	Long: `Upgrade the GKE version of the master, then of the node pools. A version is latest, a minor
+version like 1.13 for its latest patch, or a version GKE supports in the zone. Each node pool is
+replaced by one at the new version: the old nodes are cordoned and drained --max-surge at a time,
+respecting the PodDisruptionBudgets of the components, which are checked to be available afterwards.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cluster, err := loadKfCluster()
+		if err != nil {
+			return err
+		}
+		master := clusterUpgradeCfg.GetString(string(kftypes.MASTER))
+		nodes := clusterUpgradeCfg.GetString(string(kftypes.NODES))
+		maxSurge := clusterUpgradeCfg.GetInt(string(kftypes.MAX_SURGE))
+		if upgradeErr := cluster.UpgradeCluster(master, nodes, maxSurge); upgradeErr != nil {
+			return fmt.Errorf("couldn't upgrade the cluster: %v", upgradeErr)
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(clusterCmd)
+	clusterCmd.AddCommand(clusterResizeCmd)
+	clusterCmd.AddCommand(clusterSetMachineTypeCmd)
+	clusterCmd.AddCommand(clusterUpgradeCmd)
+
+	clusterCfg.SetConfigName("app")
+	clusterCfg.SetConfigType("yaml")
+
+	// verbose output
+	clusterCmd.PersistentFlags().BoolP(string(kftypes.VERBOSE), "V", false,
+		string(kftypes.VERBOSE)+" output default is false")
+	bindErr := clusterCfg.BindPFlag(string(kftypes.VERBOSE), clusterCmd.PersistentFlags().Lookup(string(kftypes.VERBOSE)))
+	if bindErr != nil {
+		log.Errorf("couldn't set flag --%v: %v", string(kftypes.VERBOSE), bindErr)
+		return
+	}
+
+	clusterCmd.PersistentFlags().String(string(kftypes.POOL), "cpu-pool",
+		"node pool to change, cpu-pool or gpu-pool")
+	bindErr = clusterCfg.BindPFlag(string(kftypes.POOL), clusterCmd.PersistentFlags().Lookup(string(kftypes.POOL)))
+	if bindErr != nil {
+		log.Errorf("couldn't set flag --%v: %v", string(kftypes.POOL), bindErr)
+		return
+	}
+
+	clusterResizeCmd.Flags().Int(string(kftypes.NODES), 0,
+		"number of nodes of the node pool")
+	bindErr = clusterCfg.BindPFlag(string(kftypes.NODES), clusterResizeCmd.Flags().Lookup(string(kftypes.NODES)))
+	if bindErr != nil {
+		log.Errorf("couldn't set flag --%v: %v", string(kftypes.NODES), bindErr)
+		return
+	}
+
+	clusterSetMachineTypeCmd.Flags().String(string(kftypes.MACHINE_TYPE), "",
+		"machine type of the node pool, e.g. n1-standard-16")
+	bindErr = clusterCfg.BindPFlag(string(kftypes.MACHINE_TYPE), clusterSetMachineTypeCmd.Flags().Lookup(
+		string(kftypes.MACHINE_TYPE)))
+	if bindErr != nil {
+		log.Errorf("couldn't set flag --%v: %v", string(kftypes.MACHINE_TYPE), bindErr)
+		return
+	}
+
+	clusterUpgradeCmd.Flags().String(string(kftypes.MASTER), "",
+		"GKE version of the master, e.g. 1.13 or latest")
+	bindErr = clusterUpgradeCfg.BindPFlag(string(kftypes.MASTER), clusterUpgradeCmd.Flags().Lookup(string(kftypes.MASTER)))
+	if bindErr != nil {
+		log.Errorf("couldn't set flag --%v: %v", string(kftypes.MASTER), bindErr)
+		return
+	}
+
+	clusterUpgradeCmd.Flags().String(string(kftypes.NODES), "",
+		"GKE version of the node pools, no newer than the master")
+	bindErr = clusterUpgradeCfg.BindPFlag(string(kftypes.NODES), clusterUpgradeCmd.Flags().Lookup(string(kftypes.NODES)))
+	if bindErr != nil {
+		log.Errorf("couldn't set flag --%v: %v", string(kftypes.NODES), bindErr)
+		return
+	}
+
+	clusterUpgradeCmd.Flags().Int(string(kftypes.MAX_SURGE), 1,
+		"number of nodes added to a node pool and drained from it at a time")
+	bindErr = clusterUpgradeCfg.BindPFlag(string(kftypes.MAX_SURGE), clusterUpgradeCmd.Flags().Lookup(
+		string(kftypes.MAX_SURGE)))
+	if bindErr != nil {
+		log.Errorf("couldn't set flag --%v: %v", string(kftypes.MAX_SURGE), bindErr)
+		return
+	}
+}
@@ -0,0 +1,118 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	kftypes "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps"
+	"github.com/kubeflow/kubeflow/bootstrap/pkg/kfapp/coordinator"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"os"
+	"strings"
+)
+
+var configCfg = viper.New()
+
+// configCmd represents the config command
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Get and change the settings of app.yaml.",
+	Long: `Get and change the settings of app.yaml of the kubeflow application in the current directory.
+They're picked up by the next generate and apply.`,
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		if configCfg.GetBool(string(kftypes.VERBOSE)) == true {
+			log.SetLevel(log.InfoLevel)
+		} else {
+			log.SetLevel(log.WarnLevel)
+		}
+	},
+}
+
+var configSetCmd = &cobra.Command{
+	Use:   "set <key>=<value>...",
+	Short: "Set settings of app.yaml, e.g. usage-reporting=false or spec.zone=us-east1-d.",
+	Long: `Set settings of app.yaml. The keys are:
+  usage-reporting           true or false; false removes spartakus and its params, so the
+                            anonymous usage reports aren't sent.
+  usage-reporting-endpoint  the database spartakus sends the reports to.
+  spec.<field>              a field of the spec by its path in app.yaml, e.g. spec.gpu.machineType,
+                            set to a YAML value, e.g. spec.skipApis=[a, b]; null clears it.
+
+app.yaml is only written when all the settings are valid. The command run next, generate or apply,
+is printed.`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		appDir, err := os.Getwd()
+		if err != nil {
+			return fmt.Errorf("could not get current directory %v", err)
+		}
+		changes, err := coordinator.SetAppConfig(appDir, args)
+		if err != nil {
+			return fmt.Errorf("couldn't set %v: %v", strings.Join(args, " "), err)
+		}
+		regenerate := []string{}
+		for _, change := range changes {
+			if change.Regenerate {
+				regenerate = append(regenerate, change.Key)
+			}
+		}
+		if len(regenerate) > 0 {
+			fmt.Printf("Run kfctl generate and kfctl apply for %v to take effect.\n",
+				strings.Join(regenerate, ", "))
+		} else {
+			fmt.Println("Run kfctl apply for the changes to take effect.")
+		}
+		return nil
+	},
+}
+
+var configGetCmd = &cobra.Command{
+	Use:   "get spec.<field>...",
+	Short: "Print settings of app.yaml, e.g. spec.zone.",
+	Long: `Print the fields of the spec of app.yaml by their path, e.g. spec.gpu.machineType, one per line:
+strings as is and the other values as YAML.`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		appDir, err := os.Getwd()
+		if err != nil {
+			return fmt.Errorf("could not get current directory %v", err)
+		}
+		values, err := coordinator.GetAppConfig(appDir, args)
+		if err != nil {
+			return fmt.Errorf("couldn't get %v: %v", strings.Join(args, " "), err)
+		}
+		for _, value := range values {
+			fmt.Println(value)
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(configCmd)
+	configCmd.AddCommand(configSetCmd)
+	configCmd.AddCommand(configGetCmd)
+
+	// verbose output
+	configCmd.PersistentFlags().BoolP(string(kftypes.VERBOSE), "V", false,
+		string(kftypes.VERBOSE)+" output default is false")
+	bindErr := configCfg.BindPFlag(string(kftypes.VERBOSE), configCmd.PersistentFlags().Lookup(string(kftypes.VERBOSE)))
+	if bindErr != nil {
+		log.Errorf("couldn't set flag --%v: %v", string(kftypes.VERBOSE), bindErr)
+		return
+	}
+}
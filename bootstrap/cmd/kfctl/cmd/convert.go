@@ -0,0 +1,81 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	kftypes "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps"
+	"github.com/kubeflow/kubeflow/bootstrap/pkg/kfapp/coordinator"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"os"
+)
+
+var convertCfg = viper.New()
+
+// convertCmd represents the convert command
+var convertCmd = &cobra.Command{
+	Use:   "convert",
+	Short: "Convert app.yaml to the v1beta1 KfDef API.",
+	Long: `Convert app.yaml of the kubeflow application in the current directory to the v1beta1 KfDef API.
+The original is kept as app.yaml.v1alpha1.bak. Use --dry-run to print the result instead.
+Both versions are read and validated; commands that rewrite app.yaml, such as generate, still write v1alpha1.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		log.SetLevel(log.InfoLevel)
+		if convertCfg.GetBool(string(kftypes.VERBOSE)) == true {
+			log.SetLevel(log.InfoLevel)
+		} else {
+			log.SetLevel(log.WarnLevel)
+		}
+		appDir, err := os.Getwd()
+		if err != nil {
+			return fmt.Errorf("could not get current directory %v", err)
+		}
+		dryRun := convertCfg.GetBool(string(kftypes.DRY_RUN))
+		converted, convertErr := coordinator.ConvertAppYaml(appDir, dryRun)
+		if convertErr != nil {
+			return fmt.Errorf("couldn't convert %v: %v", kftypes.KfConfigFile, convertErr)
+		}
+		if dryRun {
+			fmt.Print(string(converted))
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(convertCmd)
+
+	convertCfg.SetConfigName("app")
+	convertCfg.SetConfigType("yaml")
+
+	// verbose output
+	convertCmd.Flags().BoolP(string(kftypes.VERBOSE), "V", false,
+		string(kftypes.VERBOSE)+" output default is false")
+	bindErr := convertCfg.BindPFlag(string(kftypes.VERBOSE), convertCmd.Flags().Lookup(string(kftypes.VERBOSE)))
+	if bindErr != nil {
+		log.Errorf("couldn't set flag --%v: %v", string(kftypes.VERBOSE), bindErr)
+		return
+	}
+
+	convertCmd.Flags().Bool(string(kftypes.DRY_RUN), false,
+		"Print the converted app.yaml instead of writing it.")
+	bindErr = convertCfg.BindPFlag(string(kftypes.DRY_RUN), convertCmd.Flags().Lookup(string(kftypes.DRY_RUN)))
+	if bindErr != nil {
+		log.Errorf("couldn't set flag --%v: %v", string(kftypes.DRY_RUN), bindErr)
+		return
+	}
+}
@@ -0,0 +1,96 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	kftypes "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps"
+	"github.com/kubeflow/kubeflow/bootstrap/pkg/kfapp/coordinator"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"os"
+	"text/tabwriter"
+)
+
+var costEstimateCfg = viper.New()
+
+// costEstimateCmd represents the cost-estimate command
+var costEstimateCmd = &cobra.Command{
+	Use:   "cost-estimate",
+	Short: "Estimate the monthly cost of a kubeflow application before applying it.",
+	Long: `Price the node pools, static IP, disks and filestore of the configs written by generate platform
+with the list prices of the Cloud Billing Catalog API. It's an approximation: discounts, network
+traffic and autoscaling aren't accounted for.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if costEstimateCfg.GetBool(string(kftypes.VERBOSE)) == true {
+			log.SetLevel(log.InfoLevel)
+		} else {
+			log.SetLevel(log.WarnLevel)
+		}
+		kfApp, kfAppErr := coordinator.LoadKfApp(map[string]interface{}{})
+		if kfAppErr != nil {
+			return fmt.Errorf("couldn't load KfApp: %v", kfAppErr)
+		}
+		costEstimate, ok := kfApp.(kftypes.KfCostEstimate)
+		if !ok || costEstimate == nil {
+			return fmt.Errorf("KfApp does not price the resources of the app")
+		}
+		currency := costEstimateCfg.GetString(string(kftypes.CURRENCY))
+		estimate, estimateErr := costEstimate.EstimateCost(currency)
+		if estimateErr != nil {
+			return fmt.Errorf("couldn't estimate the cost: %v", estimateErr)
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+		fmt.Fprintf(w, "RESOURCE\tMONTHLY (%v)\n", estimate.Currency)
+		for _, item := range estimate.Items {
+			if item.PerNode {
+				fmt.Fprintf(w, "%v\t(%.2f)\n", item.Resource, item.Monthly)
+			} else {
+				fmt.Fprintf(w, "%v\t%.2f\n", item.Resource, item.Monthly)
+			}
+		}
+		for _, resource := range estimate.Unpriced {
+			fmt.Fprintf(w, "%v\tunknown\n", resource)
+		}
+		fmt.Fprintf(w, "TOTAL\t%.2f\n", estimate.Total())
+		return w.Flush()
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(costEstimateCmd)
+
+	costEstimateCfg.SetConfigName("app")
+	costEstimateCfg.SetConfigType("yaml")
+
+	// verbose output
+	costEstimateCmd.Flags().BoolP(string(kftypes.VERBOSE), "V", false,
+		string(kftypes.VERBOSE)+" output default is false")
+	bindErr := costEstimateCfg.BindPFlag(string(kftypes.VERBOSE), costEstimateCmd.Flags().Lookup(string(kftypes.VERBOSE)))
+	if bindErr != nil {
+		log.Errorf("couldn't set flag --%v: %v", string(kftypes.VERBOSE), bindErr)
+		return
+	}
+
+	costEstimateCmd.Flags().String(string(kftypes.CURRENCY), "USD",
+		"ISO 4217 code of the currency the prices are in.")
+	bindErr = costEstimateCfg.BindPFlag(string(kftypes.CURRENCY), costEstimateCmd.Flags().Lookup(string(kftypes.CURRENCY)))
+	if bindErr != nil {
+		log.Errorf("couldn't set flag --%v: %v", string(kftypes.CURRENCY), bindErr)
+		return
+	}
+}
@@ -15,12 +15,15 @@
 package cmd
 
 import (
+	"bufio"
 	"fmt"
 	kftypes "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps"
 	"github.com/kubeflow/kubeflow/bootstrap/pkg/kfapp/coordinator"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"os"
+	"strings"
 )
 
 var deleteCfg = viper.New()
@@ -29,7 +32,12 @@ var deleteCfg = viper.New()
 var deleteCmd = &cobra.Command{
 	Use:   "delete [all(=default)|k8s|platform]",
 	Short: "Delete a kubeflow application.",
-	Long:  `Delete a kubeflow application.`,
+	Long: `Delete a kubeflow application.
+
+kfctl delete k8s deletes the components and, on GCP, the objects kfctl applied from manifests,
+e.g. Istio, as recorded in the kfctl-inventory ConfigMap of kube-system, the ClusterRoleBinding of
+the admin, the secrets kfctl created and the namespace of the app. With --preserve-data the
+namespace and its persistent volume claims are kept.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		log.SetLevel(log.InfoLevel)
 		log.Info("deleting kubeflow application")
@@ -43,8 +51,20 @@ var deleteCmd = &cobra.Command{
 			return fmt.Errorf("invalid resource: %v", resourceErr)
 		}
 		deleteStorage := deleteCfg.GetBool(string(kftypes.DELETE_STORAGE))
+		deleteFilestore := deleteCfg.GetBool(string(kftypes.DELETE_FILESTORE))
+		deleteIp := deleteCfg.GetBool(string(kftypes.DELETE_IP))
+		preserveData := deleteCfg.GetBool(string(kftypes.PRESERVE_DATA))
+		if !deleteCfg.GetBool(string(kftypes.YES)) {
+			if confirmErr := confirmDelete(resource, deleteStorage, deleteFilestore, deleteIp); confirmErr != nil {
+				return confirmErr
+			}
+		}
 		options := map[string]interface{}{
-			string(kftypes.DELETE_STORAGE): deleteStorage,
+			string(kftypes.DELETE_STORAGE):   deleteStorage,
+			string(kftypes.DELETE_FILESTORE): deleteFilestore,
+			string(kftypes.DELETE_IP):        deleteIp,
+			string(kftypes.PRESERVE_DATA):    preserveData,
+			string(kftypes.TARGET):           deleteCfg.GetStringSlice(string(kftypes.TARGET)),
 		}
 		kfApp, kfAppErr := coordinator.LoadKfApp(options)
 		if kfAppErr != nil {
@@ -58,6 +78,38 @@ var deleteCmd = &cobra.Command{
 	},
 }
 
+// confirmDelete asks the user to type the app name back before anything is destroyed.
+func confirmDelete(resource kftypes.ResourceEnum, deleteStorage bool, deleteFilestore bool, deleteIp bool) error {
+	appDir, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("could not get current directory %v", err)
+	}
+	appName, err := coordinator.AppName(appDir)
+	if err != nil {
+		return fmt.Errorf("couldn't read the application name: %v", err)
+	}
+	fmt.Printf("This will delete %v resources of kubeflow application %v", resource, appName)
+	if deleteStorage {
+		fmt.Printf(" including its storage deployment")
+	}
+	if deleteFilestore {
+		fmt.Printf(" including its Filestore instance")
+	}
+	if deleteIp {
+		fmt.Printf(" including the static IP kfctl reserved")
+	}
+	fmt.Printf(".\nType the application name to confirm: ")
+	answer, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil {
+		return fmt.Errorf("couldn't read confirmation: %v", err)
+	}
+	if strings.TrimSpace(answer) != appName {
+		return fmt.Errorf("confirmation %v does not match application name %v; aborting delete",
+			strings.TrimSpace(answer), appName)
+	}
+	return nil
+}
+
 func init() {
 	rootCmd.AddCommand(deleteCmd)
 
@@ -80,4 +132,43 @@ func init() {
 		log.Errorf("couldn't set flag --%v: %v", string(kftypes.DELETE_STORAGE), bindErr)
 		return
 	}
+
+	deleteCmd.Flags().Bool(string(kftypes.DELETE_FILESTORE), false,
+		"Set if you want to delete app's Filestore instance and the files on it.")
+	bindErr = deleteCfg.BindPFlag(string(kftypes.DELETE_FILESTORE), deleteCmd.Flags().Lookup(string(kftypes.DELETE_FILESTORE)))
+	if bindErr != nil {
+		log.Errorf("couldn't set flag --%v: %v", string(kftypes.DELETE_FILESTORE), bindErr)
+		return
+	}
+
+	deleteCmd.Flags().Bool(string(kftypes.DELETE_IP), false,
+		"Set if you want to release the static IP of the ingress kfctl reserved for the app.")
+	bindErr = deleteCfg.BindPFlag(string(kftypes.DELETE_IP), deleteCmd.Flags().Lookup(string(kftypes.DELETE_IP)))
+	if bindErr != nil {
+		log.Errorf("couldn't set flag --%v: %v", string(kftypes.DELETE_IP), bindErr)
+		return
+	}
+
+	deleteCmd.Flags().Bool(string(kftypes.PRESERVE_DATA), false,
+		"Keep the namespace of the app and its persistent volume claims, deleting the components only.")
+	bindErr = deleteCfg.BindPFlag(string(kftypes.PRESERVE_DATA), deleteCmd.Flags().Lookup(string(kftypes.PRESERVE_DATA)))
+	if bindErr != nil {
+		log.Errorf("couldn't set flag --%v: %v", string(kftypes.PRESERVE_DATA), bindErr)
+		return
+	}
+
+	deleteCmd.Flags().BoolP(string(kftypes.YES), "y", false,
+		"Skip the interactive confirmation and delete right away.")
+	bindErr = deleteCfg.BindPFlag(string(kftypes.YES), deleteCmd.Flags().Lookup(string(kftypes.YES)))
+	if bindErr != nil {
+		log.Errorf("couldn't set flag --%v: %v", string(kftypes.YES), bindErr)
+		return
+	}
+	deleteCmd.Flags().StringSlice(string(kftypes.TARGET), []string{},
+		"Only delete these pieces of the platform, e.g. --target=network,iam. The k8s resources are kept.")
+	bindErr = deleteCfg.BindPFlag(string(kftypes.TARGET), deleteCmd.Flags().Lookup(string(kftypes.TARGET)))
+	if bindErr != nil {
+		log.Errorf("couldn't set flag --%v: %v", string(kftypes.TARGET), bindErr)
+		return
+	}
 }
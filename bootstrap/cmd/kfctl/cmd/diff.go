@@ -0,0 +1,111 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	kftypes "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps"
+	"github.com/kubeflow/kubeflow/bootstrap/pkg/kfapp/coordinator"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var diffCfg = viper.New()
+
+// diffCmd represents the diff command
+var diffCmd = &cobra.Command{
+	Use:   "diff [all(=default)|k8s|platform]",
+	Short: "Show how a deployed kubeflow application differs from its configs.",
+	Long: `Compare the deployment manager configs and templates in gcp_config with the manifests of the
+deployments, iam_bindings.yaml with the IAM policy of the project and the Istio manifests with the
+objects in the cluster. The differences are printed as a unified diff from what's deployed to what
+apply would deploy, showing the changes made out of band. kfctl diff fails when they
+differ.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if diffCfg.GetBool(string(kftypes.VERBOSE)) == true {
+			log.SetLevel(log.InfoLevel)
+		} else {
+			log.SetLevel(log.WarnLevel)
+		}
+		resource, resourceErr := processResourceArg(args)
+		if resourceErr != nil {
+			return fmt.Errorf("invalid resource: %v", resourceErr)
+		}
+		options := map[string]interface{}{
+			string(kftypes.KUBECONFIG):  diffCfg.GetString(string(kftypes.KUBECONFIG)),
+			string(kftypes.KUBECONTEXT): diffCfg.GetString(string(kftypes.KUBECONTEXT)),
+			string(kftypes.TARGET):      diffCfg.GetStringSlice(string(kftypes.TARGET)),
+		}
+		kfApp, kfAppErr := coordinator.LoadKfApp(options)
+		if kfAppErr != nil {
+			return fmt.Errorf("couldn't load KfApp: %v", kfAppErr)
+		}
+		differ, ok := kfApp.(kftypes.KfDiff)
+		if !ok || differ == nil {
+			return fmt.Errorf("KfApp does not compare its configs with what's deployed")
+		}
+		diff, diffErr := differ.Diff(resource)
+		if diffErr != nil {
+			return fmt.Errorf("couldn't diff KfApp: %v", diffErr)
+		}
+		if diff != "" {
+			fmt.Print(diff)
+			return fmt.Errorf("the deployed app differs from its configs")
+		}
+		fmt.Println("No drift found.")
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(diffCmd)
+
+	diffCfg.SetConfigName("app")
+	diffCfg.SetConfigType("yaml")
+
+	// verbose output
+	diffCmd.Flags().BoolP(string(kftypes.VERBOSE), "V", false,
+		string(kftypes.VERBOSE)+" output default is false")
+	bindErr := diffCfg.BindPFlag(string(kftypes.VERBOSE), diffCmd.Flags().Lookup(string(kftypes.VERBOSE)))
+	if bindErr != nil {
+		log.Errorf("couldn't set flag --%v: %v", string(kftypes.VERBOSE), bindErr)
+		return
+	}
+
+	diffCmd.Flags().String(string(kftypes.KUBECONFIG), "",
+		"Path to a kubeconfig used to reach the cluster instead of looking it up through the GKE API.")
+	bindErr = diffCfg.BindPFlag(string(kftypes.KUBECONFIG), diffCmd.Flags().Lookup(string(kftypes.KUBECONFIG)))
+	if bindErr != nil {
+		log.Errorf("couldn't set flag --%v: %v", string(kftypes.KUBECONFIG), bindErr)
+		return
+	}
+
+	diffCmd.Flags().String(string(kftypes.KUBECONTEXT), "",
+		"Name of the kubeconfig context used to reach the cluster.")
+	bindErr = diffCfg.BindPFlag(string(kftypes.KUBECONTEXT), diffCmd.Flags().Lookup(string(kftypes.KUBECONTEXT)))
+	if bindErr != nil {
+		log.Errorf("couldn't set flag --%v: %v", string(kftypes.KUBECONTEXT), bindErr)
+		return
+	}
+
+	diffCmd.Flags().StringSlice(string(kftypes.TARGET), []string{},
+		"Only diff these pieces of the platform, e.g. --target=cluster,iam.")
+	bindErr = diffCfg.BindPFlag(string(kftypes.TARGET), diffCmd.Flags().Lookup(string(kftypes.TARGET)))
+	if bindErr != nil {
+		log.Errorf("couldn't set flag --%v: %v", string(kftypes.TARGET), bindErr)
+		return
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	kftypes "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps"
+	"github.com/kubeflow/kubeflow/bootstrap/pkg/kfapp/coordinator"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var doctorCfg = viper.New()
+
+// doctorCmd represents the doctor command
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Diagnose a broken kubeflow application.",
+	Long: `Inspect the deployment of a kubeflow application: the errors of its deployment manager
+deployments, the cluster and its node pools, the IAM bindings against iam_bindings.yaml, the
+secrets, the ingress and IAP, and the Istio pods. The problems found are printed with how to fix
+them, the most severe first. It fails when a critical or error problem is found.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if doctorCfg.GetBool(string(kftypes.VERBOSE)) == true {
+			log.SetLevel(log.InfoLevel)
+		} else {
+			log.SetLevel(log.WarnLevel)
+		}
+		kfApp, kfAppErr := coordinator.LoadKfApp(map[string]interface{}{})
+		if kfAppErr != nil {
+			return fmt.Errorf("couldn't load KfApp: %v", kfAppErr)
+		}
+		doctor, ok := kfApp.(kftypes.KfDoctor)
+		if !ok || doctor == nil {
+			return fmt.Errorf("KfApp does not diagnose its deployments")
+		}
+		problems, diagnoseErr := doctor.Diagnose()
+		if diagnoseErr != nil {
+			return fmt.Errorf("couldn't diagnose the app: %v", diagnoseErr)
+		}
+		if len(problems) == 0 {
+			fmt.Println("No problems found.")
+			return nil
+		}
+		failed := 0
+		for i, problem := range problems {
+			fmt.Printf("%v. [%v] %v: %v\n   %v\n", i+1, problem.Severity, problem.Check, problem.Message,
+				problem.Remediation)
+			if problem.Severity != kftypes.WARNING {
+				failed++
+			}
+		}
+		if failed != 0 {
+			return fmt.Errorf("found %v problems needing a fix", failed)
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(doctorCmd)
+
+	doctorCfg.SetConfigName("app")
+	doctorCfg.SetConfigType("yaml")
+
+	// verbose output
+	doctorCmd.Flags().BoolP(string(kftypes.VERBOSE), "V", false,
+		string(kftypes.VERBOSE)+" output default is false")
+	bindErr := doctorCfg.BindPFlag(string(kftypes.VERBOSE), doctorCmd.Flags().Lookup(string(kftypes.VERBOSE)))
+	if bindErr != nil {
+		log.Errorf("couldn't set flag --%v: %v", string(kftypes.VERBOSE), bindErr)
+		return
+	}
+}
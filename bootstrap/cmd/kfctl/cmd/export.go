@@ -0,0 +1,109 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	kftypes "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps"
+	"github.com/kubeflow/kubeflow/bootstrap/pkg/kfapp/coordinator"
+	"github.com/kubeflow/kubeflow/bootstrap/pkg/utils"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"os"
+	"path/filepath"
+)
+
+var exportCfg = viper.New()
+
+// exportCmd represents the export command
+var exportCmd = &cobra.Command{
+	Use:   "export [all(=default)|k8s|platform]",
+	Short: "Package what apply would deploy into a tar.gz archive.",
+	Long: `Package app.yaml, the deployment manager configs and templates of gcp_config, the k8s manifests
+of the components and the Istio manifests into a tar.gz archive, with a SHA256SUMS manifest of their
+checksums. The archive only depends on the files, so exporting the same app twice gives the same
+bytes; it can be reviewed before apply or kept with a release.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if exportCfg.GetBool(string(kftypes.VERBOSE)) == true {
+			log.SetLevel(log.InfoLevel)
+		} else {
+			log.SetLevel(log.WarnLevel)
+		}
+		resource, resourceErr := processResourceArg(args)
+		if resourceErr != nil {
+			return fmt.Errorf("invalid resource: %v", resourceErr)
+		}
+		kfApp, kfAppErr := coordinator.LoadKfApp(map[string]interface{}{})
+		if kfAppErr != nil {
+			return fmt.Errorf("couldn't load KfApp: %v", kfAppErr)
+		}
+		exporter, ok := kfApp.(kftypes.KfExport)
+		if !ok || exporter == nil {
+			return fmt.Errorf("KfApp does not export its files")
+		}
+		files, exportErr := exporter.Export(resource)
+		if exportErr != nil {
+			return fmt.Errorf("couldn't export KfApp: %v", exportErr)
+		}
+		output := exportCfg.GetString(string(kftypes.OUTPUT))
+		if output == "" {
+			cwd, err := os.Getwd()
+			if err != nil {
+				return fmt.Errorf("couldn't get the current directory: %v", err)
+			}
+			output = filepath.Base(cwd) + ".tar.gz"
+		}
+		f, err := os.Create(output)
+		if err != nil {
+			return fmt.Errorf("couldn't create %v: %v", output, err)
+		}
+		if err = utils.WriteArchive(f, files); err == nil {
+			err = f.Close()
+		} else {
+			f.Close()
+		}
+		if err != nil {
+			os.Remove(output)
+			return fmt.Errorf("couldn't write %v: %v", output, err)
+		}
+		fmt.Printf("Exported %v files to %v\n", len(files), output)
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(exportCmd)
+
+	exportCfg.SetConfigName("app")
+	exportCfg.SetConfigType("yaml")
+
+	// verbose output
+	exportCmd.Flags().BoolP(string(kftypes.VERBOSE), "V", false,
+		string(kftypes.VERBOSE)+" output default is false")
+	bindErr := exportCfg.BindPFlag(string(kftypes.VERBOSE), exportCmd.Flags().Lookup(string(kftypes.VERBOSE)))
+	if bindErr != nil {
+		log.Errorf("couldn't set flag --%v: %v", string(kftypes.VERBOSE), bindErr)
+		return
+	}
+
+	exportCmd.Flags().StringP(string(kftypes.OUTPUT), "o", "",
+		"Path of the archive; <app dir name>.tar.gz in the app dir by default.")
+	bindErr = exportCfg.BindPFlag(string(kftypes.OUTPUT), exportCmd.Flags().Lookup(string(kftypes.OUTPUT)))
+	if bindErr != nil {
+		log.Errorf("couldn't set flag --%v: %v", string(kftypes.OUTPUT), bindErr)
+		return
+	}
+}
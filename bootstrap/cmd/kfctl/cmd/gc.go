@@ -0,0 +1,102 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	kftypes "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps"
+	"github.com/kubeflow/kubeflow/bootstrap/pkg/kfapp/coordinator"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var gcCfg = viper.New()
+
+// gcCmd represents the gc command
+var gcCmd = &cobra.Command{
+	Use:   "gc",
+	Short: "Delete platform resources left behind by the kubeflow application.",
+	Long: `Delete platform resources kfctl created for the application in the current directory,
+found by the ownership labels of its name or deployment id, that no longer have a cluster.
+Resources created less than 30 minutes ago are kept, as an apply may still be creating them.
+Storage is kept unless --delete_storage is set, and Filestore instances unless --delete_filestore
+is. Nothing is deleted with deletionProtection set in app.yaml.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		log.SetLevel(log.InfoLevel)
+		log.Info("collecting orphaned kubeflow resources")
+		if gcCfg.GetBool(string(kftypes.VERBOSE)) == true {
+			log.SetLevel(log.InfoLevel)
+		} else {
+			log.SetLevel(log.WarnLevel)
+		}
+		options := map[string]interface{}{
+			string(kftypes.DELETE_STORAGE):   gcCfg.GetBool(string(kftypes.DELETE_STORAGE)),
+			string(kftypes.DELETE_FILESTORE): gcCfg.GetBool(string(kftypes.DELETE_FILESTORE)),
+		}
+		kfApp, kfAppErr := coordinator.LoadKfApp(options)
+		if kfAppErr != nil {
+			return fmt.Errorf("couldn't load KfApp: %v", kfAppErr)
+		}
+		gc, ok := kfApp.(kftypes.KfGarbageCollect)
+		if !ok || gc == nil {
+			return fmt.Errorf("KfApp does not support garbage collection")
+		}
+		if gcErr := gc.GarbageCollect(gcCfg.GetBool(string(kftypes.DRY_RUN))); gcErr != nil {
+			return fmt.Errorf("couldn't collect orphaned resources: %v", gcErr)
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(gcCmd)
+
+	gcCfg.SetConfigName("app")
+	gcCfg.SetConfigType("yaml")
+
+	// verbose output
+	gcCmd.Flags().BoolP(string(kftypes.VERBOSE), "V", false,
+		string(kftypes.VERBOSE)+" output default is false")
+	bindErr := gcCfg.BindPFlag(string(kftypes.VERBOSE), gcCmd.Flags().Lookup(string(kftypes.VERBOSE)))
+	if bindErr != nil {
+		log.Errorf("couldn't set flag --%v: %v", string(kftypes.VERBOSE), bindErr)
+		return
+	}
+
+	gcCmd.Flags().Bool(string(kftypes.DRY_RUN), false,
+		"Only report the orphaned resources without deleting them.")
+	bindErr = gcCfg.BindPFlag(string(kftypes.DRY_RUN), gcCmd.Flags().Lookup(string(kftypes.DRY_RUN)))
+	if bindErr != nil {
+		log.Errorf("couldn't set flag --%v: %v", string(kftypes.DRY_RUN), bindErr)
+		return
+	}
+
+	gcCmd.Flags().Bool(string(kftypes.DELETE_STORAGE), false,
+		"Set if you want orphaned storage deployments to be deleted as well.")
+	bindErr = gcCfg.BindPFlag(string(kftypes.DELETE_STORAGE), gcCmd.Flags().Lookup(string(kftypes.DELETE_STORAGE)))
+	if bindErr != nil {
+		log.Errorf("couldn't set flag --%v: %v", string(kftypes.DELETE_STORAGE), bindErr)
+		return
+	}
+
+	gcCmd.Flags().Bool(string(kftypes.DELETE_FILESTORE), false,
+		"Set if you want orphaned Filestore deployments to be deleted as well.")
+	bindErr = gcCfg.BindPFlag(string(kftypes.DELETE_FILESTORE), gcCmd.Flags().Lookup(string(kftypes.DELETE_FILESTORE)))
+	if bindErr != nil {
+		log.Errorf("couldn't set flag --%v: %v", string(kftypes.DELETE_FILESTORE), bindErr)
+		return
+	}
+}
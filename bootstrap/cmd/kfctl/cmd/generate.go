@@ -35,7 +35,11 @@ var generateCmd = &cobra.Command{
   k8s: kubernetes resources
   all: both platform and k8s
 
-The default is 'all' for any selected platform.`,
+The default is 'all' for any selected platform.
+
+With --zone auto the zone of the gcp cluster is picked among those of --region, the region of the default
+zone when unset, as the first offering the machine types and GPUs of the node pools once the CPU and GPU
+quotas of the region are checked to fit them. The zone picked is recorded in app.yaml.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		log.SetLevel(log.InfoLevel)
 		if generateCfg.GetBool(string(kftypes.VERBOSE)) == true {
@@ -57,7 +61,9 @@ The default is 'all' for any selected platform.`,
 			string(kftypes.IPNAME):      ipName,
 			string(kftypes.HOSTNAME):    hostName,
 			string(kftypes.ZONE):        zone,
+			string(kftypes.REGION):      generateCfg.GetString(string(kftypes.REGION)),
 			string(kftypes.MOUNT_LOCAL): mountLocal,
+			string(kftypes.ENV):         generateCfg.GetString(string(kftypes.ENV)),
 		}
 		kfApp, kfAppErr := coordinator.LoadKfApp(options)
 		if kfAppErr != nil {
@@ -88,13 +94,22 @@ func init() {
 
 	// platform gcp
 	generateCmd.Flags().String(string(kftypes.ZONE), "",
-		string(kftypes.ZONE)+" if '--platform gcp'")
+		string(kftypes.ZONE)+" if '--platform gcp', or "+kftypes.AutoZone+" to pick one in --"+string(kftypes.REGION))
 	bindErr = generateCfg.BindPFlag(string(kftypes.ZONE), generateCmd.Flags().Lookup(string(kftypes.ZONE)))
 	if bindErr != nil {
 		log.Errorf("couldn't set flag --%v: %v", string(kftypes.ZONE), bindErr)
 		return
 	}
 
+	// platform gcp
+	generateCmd.Flags().String(string(kftypes.REGION), "",
+		"region --"+string(kftypes.ZONE)+" "+kftypes.AutoZone+" picks the zone in if '--platform gcp'")
+	bindErr = generateCfg.BindPFlag(string(kftypes.REGION), generateCmd.Flags().Lookup(string(kftypes.REGION)))
+	if bindErr != nil {
+		log.Errorf("couldn't set flag --%v: %v", string(kftypes.REGION), bindErr)
+		return
+	}
+
 	// platform gcp
 	generateCmd.Flags().String(string(kftypes.IPNAME), "",
 		string(kftypes.IPNAME)+" if '--platform gcp'")
@@ -122,6 +137,14 @@ func init() {
 		return
 	}
 
+	generateCmd.Flags().String(string(kftypes.ENV), "",
+		"Environment of componentParamOverrides to generate, e.g. dev or prod; saved in app.yaml.")
+	bindErr = generateCfg.BindPFlag(string(kftypes.ENV), generateCmd.Flags().Lookup(string(kftypes.ENV)))
+	if bindErr != nil {
+		log.Errorf("couldn't set flag --%v: %v", string(kftypes.ENV), bindErr)
+		return
+	}
+
 	// verbose output
 	generateCmd.Flags().BoolP(string(kftypes.VERBOSE), "V", false,
 		string(kftypes.VERBOSE)+" output default is false")
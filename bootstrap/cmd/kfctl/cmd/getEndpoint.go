@@ -0,0 +1,87 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	kftypes "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps"
+	"github.com/kubeflow/kubeflow/bootstrap/pkg/kfapp/coordinator"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var getEndpointCfg = viper.New()
+
+// getEndpointCmd represents the get-endpoint command
+var getEndpointCmd = &cobra.Command{
+	Use:   "get-endpoint",
+	Short: "Print the URL of a kubeflow application and how to check it's ready.",
+	Long: `Print the URL the app is served at, its auth mode, how long its TLS certificate may take to be
+provisioned after apply and the kubectl command showing its ingress. kfctl apply prints it too.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if getEndpointCfg.GetBool(string(kftypes.VERBOSE)) == true {
+			log.SetLevel(log.InfoLevel)
+		} else {
+			log.SetLevel(log.WarnLevel)
+		}
+		kfApp, kfAppErr := coordinator.LoadKfApp(map[string]interface{}{})
+		if kfAppErr != nil {
+			return fmt.Errorf("couldn't load KfApp: %v", kfAppErr)
+		}
+		endpoint, ok := kfApp.(kftypes.KfEndpoint)
+		if !ok || endpoint == nil {
+			return fmt.Errorf("KfApp does not expose the app at a URL")
+		}
+		info, endpointErr := endpoint.Endpoint()
+		if endpointErr != nil {
+			return fmt.Errorf("couldn't get the endpoint: %v", endpointErr)
+		}
+		fmt.Print(info.Summary())
+		return nil
+	},
+}
+
+// printEndpoint prints the summary of the endpoint of kfApp after apply; platforms without one,
+// e.g. minikube, print nothing.
+func printEndpoint(kfApp kftypes.KfApp) {
+	endpoint, ok := kfApp.(kftypes.KfEndpoint)
+	if !ok || endpoint == nil {
+		return
+	}
+	info, err := endpoint.Endpoint()
+	if err != nil {
+		log.Infof("No endpoint to print: %v", err)
+		return
+	}
+	fmt.Print(info.Summary())
+}
+
+func init() {
+	rootCmd.AddCommand(getEndpointCmd)
+
+	getEndpointCfg.SetConfigName("app")
+	getEndpointCfg.SetConfigType("yaml")
+
+	// verbose output
+	getEndpointCmd.Flags().BoolP(string(kftypes.VERBOSE), "V", false,
+		string(kftypes.VERBOSE)+" output default is false")
+	bindErr := getEndpointCfg.BindPFlag(string(kftypes.VERBOSE), getEndpointCmd.Flags().Lookup(string(kftypes.VERBOSE)))
+	if bindErr != nil {
+		log.Errorf("couldn't set flag --%v: %v", string(kftypes.VERBOSE), bindErr)
+		return
+	}
+}
@@ -0,0 +1,104 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	kftypes "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps"
+	"github.com/kubeflow/kubeflow/bootstrap/pkg/kfapp/coordinator"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var grantUserCfg = viper.New()
+
+// grantUserCmd represents the grant-user command
+var grantUserCmd = &cobra.Command{
+	Use:   "grant-user",
+	Short: "Grant a user access to an applied kubeflow application.",
+	Long: `Grant a user access to an applied kubeflow application, without running apply again.
+The user is bound to the edit role in --` + string(kftypes.NAMESPACE) + `, the namespace of the app by default,
+and is allowed to log in: with IAP the user is added to iapMembers and the IAP policy, with basic auth
+a login is added, its password read from --` + string(kftypes.PASSWORD_FILE) + `, then from ` +
+		kftypes.KUBEFLOW_PASSWORD + `, else prompted for.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if grantUserCfg.GetBool(string(kftypes.VERBOSE)) == true {
+			log.SetLevel(log.InfoLevel)
+		} else {
+			log.SetLevel(log.WarnLevel)
+		}
+		email := grantUserCfg.GetString(string(kftypes.EMAIL))
+		if email == "" {
+			return fmt.Errorf("--%v is required", kftypes.EMAIL)
+		}
+		options := map[string]interface{}{
+			string(kftypes.PASSWORD_FILE): grantUserCfg.GetString(string(kftypes.PASSWORD_FILE)),
+		}
+		kfApp, kfAppErr := coordinator.LoadKfApp(options)
+		if kfAppErr != nil {
+			return fmt.Errorf("couldn't load KfApp: %v", kfAppErr)
+		}
+		granter, ok := kfApp.(kftypes.KfGrantUser)
+		if !ok || granter == nil {
+			return fmt.Errorf("KfApp does not grant users access")
+		}
+		if grantErr := granter.GrantUser(email, grantUserCfg.GetString(string(kftypes.NAMESPACE))); grantErr != nil {
+			return fmt.Errorf("couldn't grant %v: %v", email, grantErr)
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(grantUserCmd)
+
+	grantUserCfg.SetConfigName("app")
+	grantUserCfg.SetConfigType("yaml")
+
+	grantUserCmd.Flags().String(string(kftypes.EMAIL), "", "Email of the user to grant.")
+	bindErr := grantUserCfg.BindPFlag(string(kftypes.EMAIL), grantUserCmd.Flags().Lookup(string(kftypes.EMAIL)))
+	if bindErr != nil {
+		log.Errorf("couldn't set flag --%v: %v", string(kftypes.EMAIL), bindErr)
+		return
+	}
+
+	grantUserCmd.Flags().String(string(kftypes.NAMESPACE), "",
+		"Namespace the user is granted the edit role in, the namespace of the app by default.")
+	bindErr = grantUserCfg.BindPFlag(string(kftypes.NAMESPACE), grantUserCmd.Flags().Lookup(string(kftypes.NAMESPACE)))
+	if bindErr != nil {
+		log.Errorf("couldn't set flag --%v: %v", string(kftypes.NAMESPACE), bindErr)
+		return
+	}
+
+	grantUserCmd.Flags().String(string(kftypes.PASSWORD_FILE), "",
+		"File holding the password of the basic auth login, or - to read it from stdin. Used instead of "+
+			kftypes.KUBEFLOW_PASSWORD+".")
+	bindErr = grantUserCfg.BindPFlag(string(kftypes.PASSWORD_FILE), grantUserCmd.Flags().Lookup(string(kftypes.PASSWORD_FILE)))
+	if bindErr != nil {
+		log.Errorf("couldn't set flag --%v: %v", string(kftypes.PASSWORD_FILE), bindErr)
+		return
+	}
+
+	// verbose output
+	grantUserCmd.Flags().BoolP(string(kftypes.VERBOSE), "V", false,
+		string(kftypes.VERBOSE)+" output default is false")
+	bindErr = grantUserCfg.BindPFlag(string(kftypes.VERBOSE), grantUserCmd.Flags().Lookup(string(kftypes.VERBOSE)))
+	if bindErr != nil {
+		log.Errorf("couldn't set flag --%v: %v", string(kftypes.VERBOSE), bindErr)
+		return
+	}
+}
@@ -17,12 +17,14 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	kftypes "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps"
 	"github.com/kubeflow/kubeflow/bootstrap/pkg/kfapp/coordinator"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"k8s.io/apimachinery/pkg/util/validation"
 )
 
 var initCfg = viper.New()
@@ -32,7 +34,11 @@ var initCmd = &cobra.Command{
 	Use:   "init <[path/]name>",
 	Short: "Create a kubeflow application under <[path/]name>",
 	Long: `Create a kubeflow application under <[path/]name>. The <[path/]name> argument can either be a full path
-or a <name>. If just <name> a directory <name> will be created in the current directory.`,
+or a <name>. If just <name> a directory <name> will be created in the current directory.
+
+With --interactive the project, zone, auth provider, email and Istio use of a gcp app are asked for instead,
+each checked against GCP as it's entered: the project must be accessible, the zone must exist and IAP needs
+the OAuth consent screen of the project set up. The flags give the defaults of the answers.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		log.SetLevel(log.InfoLevel)
 		if initCfg.GetBool(string(kftypes.VERBOSE)) == true {
@@ -52,17 +58,64 @@ or a <name>. If just <name> a directory <name> will be created in the current di
 		init_gcp := initCfg.GetBool(string(kftypes.SKIP_INIT_GCP_PROJECT))
 
 		useBasicAuth := initCfg.GetBool(string(kftypes.USE_BASIC_AUTH))
-		if useBasicAuth && (os.Getenv(kftypes.KUBEFLOW_USERNAME) == "" ||
-			os.Getenv(kftypes.KUBEFLOW_PASSWORD) == "") {
+		authProvider := initCfg.GetString(string(kftypes.AUTH_PROVIDER))
+		if authProvider == kftypes.AUTH_BASIC_AUTH {
+			useBasicAuth = true
+		} else if authProvider != "" && useBasicAuth {
+			return fmt.Errorf("--%v conflicts with --%v=%v", kftypes.USE_BASIC_AUTH, kftypes.AUTH_PROVIDER, authProvider)
+		}
+		useIstio := initCfg.GetBool(string(kftypes.USE_ISTIO))
+		zone := ""
+		email := ""
+		if initCfg.GetBool(string(kftypes.INTERACTIVE)) {
+			settings := &initSettings{
+				Platform:     platform,
+				Project:      project,
+				AuthProvider: authProvider,
+				UseIstio:     useIstio,
+			}
+			if authProvider == "" && useBasicAuth {
+				settings.AuthProvider = kftypes.AUTH_BASIC_AUTH
+			}
+			if err := askInitSettings(settings); err != nil {
+				return fmt.Errorf("couldn't set up %v: %v", appName, err)
+			}
+			platform = settings.Platform
+			project = settings.Project
+			zone = settings.Zone
+			authProvider = settings.AuthProvider
+			email = settings.Email
+			useIstio = settings.UseIstio
+			useBasicAuth = authProvider == kftypes.AUTH_BASIC_AUTH
+		}
+		if authProvider == kftypes.AUTH_OIDC || authProvider == kftypes.AUTH_LDAP {
+			log.Warnf("set spec.auth.%v in %v/app.yaml before running generate.", authProvider, appName)
+		}
+		if useBasicAuth && os.Getenv(kftypes.KUBEFLOW_USERNAME) == "" {
 			// Printing warning message instead of bailing out as both ENV are used in apply,
 			// not init.
 			log.Warnf("you need to set the environment variable %s to the username you "+
-				"want to use to login and variable %s to the password you want to use.",
-				kftypes.KUBEFLOW_USERNAME, kftypes.KUBEFLOW_PASSWORD)
+				"want to use to login and pass the password you want to use to apply with "+
+				"--%s, or set it in variable %s.",
+				kftypes.KUBEFLOW_USERNAME, kftypes.PASSWORD_FILE, kftypes.KUBEFLOW_PASSWORD)
 		}
 
-		useIstio := initCfg.GetBool(string(kftypes.USE_ISTIO))
 		disableUsageReport := initCfg.GetBool(string(kftypes.DISABLE_USAGE_REPORT))
+		combinedDeployment := initCfg.GetBool(string(kftypes.COMBINED_DEPLOYMENT))
+		useEmbeddedAssets := initCfg.GetBool(string(kftypes.USE_EMBEDDED_ASSETS))
+		mirror := initCfg.GetString(string(kftypes.MIRROR))
+		repoChecksum := initCfg.GetString(string(kftypes.REPO_CHECKSUM))
+		manifestsTool := initCfg.GetString(string(kftypes.MANIFESTS_TOOL))
+		if manifestsTool != "" && manifestsTool != kftypes.MANIFESTS_KSONNET &&
+			manifestsTool != kftypes.MANIFESTS_KUSTOMIZE {
+			return fmt.Errorf("unknown --%v %v; use %v or %v", kftypes.MANIFESTS_TOOL, manifestsTool,
+				kftypes.MANIFESTS_KSONNET, kftypes.MANIFESTS_KUSTOMIZE)
+		}
+		namespacePrefix := initCfg.GetString(string(kftypes.NAMESPACE_PREFIX))
+		if errs := validation.IsDNS1123Label(namespacePrefix); namespacePrefix != "" && len(errs) > 0 {
+			return fmt.Errorf("invalid --%v %v: %v", kftypes.NAMESPACE_PREFIX, namespacePrefix,
+				strings.Join(errs, "; "))
+		}
 
 		options := map[string]interface{}{
 			string(kftypes.PLATFORM):              platform,
@@ -71,10 +124,19 @@ or a <name>. If just <name> a directory <name> will be created in the current di
 			string(kftypes.APPNAME):               appName,
 			string(kftypes.REPO):                  repo,
 			string(kftypes.PROJECT):               project,
+			string(kftypes.ZONE):                  zone,
+			string(kftypes.EMAIL):                 email,
 			string(kftypes.SKIP_INIT_GCP_PROJECT): init_gcp,
 			string(kftypes.USE_BASIC_AUTH):        useBasicAuth,
+			string(kftypes.AUTH_PROVIDER):         authProvider,
 			string(kftypes.USE_ISTIO):             useIstio,
+			string(kftypes.MANIFESTS_TOOL):        manifestsTool,
+			string(kftypes.NAMESPACE_PREFIX):      namespacePrefix,
 			string(kftypes.DISABLE_USAGE_REPORT):  disableUsageReport,
+			string(kftypes.COMBINED_DEPLOYMENT):   combinedDeployment,
+			string(kftypes.USE_EMBEDDED_ASSETS):   useEmbeddedAssets,
+			string(kftypes.MIRROR):                mirror,
+			string(kftypes.REPO_CHECKSUM):         repoChecksum,
 		}
 		kfApp, kfAppErr := coordinator.NewKfApp(options)
 		if kfAppErr != nil || kfApp == nil {
@@ -164,6 +226,15 @@ func init() {
 		return
 	}
 
+	// Authentication provider
+	initCmd.Flags().String(string(kftypes.AUTH_PROVIDER), "",
+		"how users sign in, one of 'iap|basic-auth|oidc|ldap'; oidc and ldap run Dex, configured in spec.auth of app.yaml.")
+	bindErr = initCfg.BindPFlag(string(kftypes.AUTH_PROVIDER), initCmd.Flags().Lookup(string(kftypes.AUTH_PROVIDER)))
+	if bindErr != nil {
+		log.Errorf("couldn't set flag --%v: %v", string(kftypes.AUTH_PROVIDER), bindErr)
+		return
+	}
+
 	// Use Istio
 	initCmd.Flags().Bool(string(kftypes.USE_ISTIO), false,
 		string(kftypes.USE_ISTIO)+" use istio for auth and traffic routing.")
@@ -173,6 +244,73 @@ func init() {
 		return
 	}
 
+	// Manifests tool
+	initCmd.Flags().String(string(kftypes.MANIFESTS_TOOL), "",
+		"generate the manifests of the components with 'ksonnet|kustomize'; ksonnet when unset.")
+	bindErr = initCfg.BindPFlag(string(kftypes.MANIFESTS_TOOL), initCmd.Flags().Lookup(string(kftypes.MANIFESTS_TOOL)))
+	if bindErr != nil {
+		log.Errorf("couldn't set flag --%v: %v", string(kftypes.MANIFESTS_TOOL), bindErr)
+		return
+	}
+
+	// Namespace prefix
+	initCmd.Flags().String(string(kftypes.NAMESPACE_PREFIX), "",
+		"prefix the namespaces of the app, e.g. mlplatform for mlplatform-kubeflow and mlplatform-istio-system.")
+	bindErr = initCfg.BindPFlag(string(kftypes.NAMESPACE_PREFIX), initCmd.Flags().Lookup(string(kftypes.NAMESPACE_PREFIX)))
+	if bindErr != nil {
+		log.Errorf("couldn't set flag --%v: %v", string(kftypes.NAMESPACE_PREFIX), bindErr)
+		return
+	}
+
+	// Use a single DM deployment
+	initCmd.Flags().Bool(string(kftypes.COMBINED_DEPLOYMENT), false,
+		string(kftypes.COMBINED_DEPLOYMENT)+" create the gcp resources in a single deployment manager deployment.")
+	bindErr = initCfg.BindPFlag(string(kftypes.COMBINED_DEPLOYMENT), initCmd.Flags().Lookup(string(kftypes.COMBINED_DEPLOYMENT)))
+	if bindErr != nil {
+		log.Errorf("couldn't set flag --%v: %v", string(kftypes.COMBINED_DEPLOYMENT), bindErr)
+		return
+	}
+
+	// Use the assets built into kfctl
+	initCmd.Flags().Bool(string(kftypes.USE_EMBEDDED_ASSETS), false,
+		string(kftypes.USE_EMBEDDED_ASSETS)+" take the app configs, deployment manager templates and istio manifests "+
+			"from kfctl instead of the kubeflow repo, which isn't downloaded; the ksonnet registry is read from --"+
+			string(kftypes.MIRROR)+".")
+	bindErr = initCfg.BindPFlag(string(kftypes.USE_EMBEDDED_ASSETS), initCmd.Flags().Lookup(string(kftypes.USE_EMBEDDED_ASSETS)))
+	if bindErr != nil {
+		log.Errorf("couldn't set flag --%v: %v", string(kftypes.USE_EMBEDDED_ASSETS), bindErr)
+		return
+	}
+
+	// Local mirror of the kubeflow repo
+	initCmd.Flags().String(string(kftypes.MIRROR), "",
+		"local checkout or tarball of the kubeflow repo used instead of downloading --"+string(kftypes.VERSION)+
+			" from github. Nothing is fetched from the internet then.")
+	bindErr = initCfg.BindPFlag(string(kftypes.MIRROR), initCmd.Flags().Lookup(string(kftypes.MIRROR)))
+	if bindErr != nil {
+		log.Errorf("couldn't set flag --%v: %v", string(kftypes.MIRROR), bindErr)
+		return
+	}
+
+	// Checksum of the kubeflow repo tarball
+	initCmd.Flags().String(string(kftypes.REPO_CHECKSUM), "",
+		"sha256 the tarball of --"+string(kftypes.VERSION)+" downloaded from github must have. The commit and "+
+			"checksum of the tarball are pinned in app.yaml either way.")
+	bindErr = initCfg.BindPFlag(string(kftypes.REPO_CHECKSUM), initCmd.Flags().Lookup(string(kftypes.REPO_CHECKSUM)))
+	if bindErr != nil {
+		log.Errorf("couldn't set flag --%v: %v", string(kftypes.REPO_CHECKSUM), bindErr)
+		return
+	}
+
+	// Ask for the settings
+	initCmd.Flags().BoolP(string(kftypes.INTERACTIVE), "i", false,
+		"ask for the project, zone, auth provider, email and Istio use of a gcp app, checking them against GCP.")
+	bindErr = initCfg.BindPFlag(string(kftypes.INTERACTIVE), initCmd.Flags().Lookup(string(kftypes.INTERACTIVE)))
+	if bindErr != nil {
+		log.Errorf("couldn't set flag --%v: %v", string(kftypes.INTERACTIVE), bindErr)
+		return
+	}
+
 	// Skip usage report
 	initCmd.Flags().Bool(string(kftypes.DISABLE_USAGE_REPORT), false,
 		string(kftypes.DISABLE_USAGE_REPORT)+" disable anonymous usage reporting.")
@@ -0,0 +1,105 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"net/mail"
+	"os"
+
+	kftypes "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps"
+	"github.com/kubeflow/kubeflow/bootstrap/pkg/kfapp/gcp"
+	"github.com/kubeflow/kubeflow/bootstrap/pkg/utils"
+)
+
+// initSettings are the settings kfctl init --interactive asks for. They start as the flags,
+// the defaults of the answers.
+type initSettings struct {
+	Platform     string
+	Project      string
+	Zone         string
+	AuthProvider string
+	Email        string
+	UseIstio     bool
+}
+
+// checkEmail checks email is a bare address, as IAP and the cert-manager ACME account take it.
+func checkEmail(email string) error {
+	address, err := mail.ParseAddress(email)
+	if err != nil || address.Address != email {
+		return fmt.Errorf("%v isn't an email address", email)
+	}
+	return nil
+}
+
+// askInitSettings asks for the settings on the terminal, each until it passes its check against
+// GCP, so the typos which would fail apply are caught right away.
+func askInitSettings(settings *initSettings) error {
+	if settings.Platform == "" {
+		settings.Platform = kftypes.GCP
+	}
+	if settings.Platform != kftypes.GCP {
+		return fmt.Errorf("--%v only sets up --%v %v apps", kftypes.INTERACTIVE, kftypes.PLATFORM, kftypes.GCP)
+	}
+	checker, err := gcp.NewInitChecker()
+	if err != nil {
+		return err
+	}
+	p := utils.NewPrompter(os.Stdin, os.Stdout)
+
+	if settings.Project == "" {
+		settings.Project = checker.DefaultProject()
+	}
+	if settings.Project, err = p.Ask("GCP project", settings.Project, checker.CheckProject); err != nil {
+		return err
+	}
+	if settings.Zone == "" {
+		settings.Zone = kftypes.DefaultZone
+	}
+	settings.Zone, err = p.Ask("Zone of the cluster, or "+kftypes.AutoZone+" to let generate pick one", settings.Zone,
+		func(zone string) error {
+			if zone == kftypes.AutoZone {
+				return nil
+			}
+			return checker.CheckZone(settings.Project, zone)
+		})
+	if err != nil {
+		return err
+	}
+	if settings.AuthProvider == "" {
+		settings.AuthProvider = kftypes.AUTH_IAP
+	}
+	providers := []string{kftypes.AUTH_IAP, kftypes.AUTH_BASIC_AUTH, kftypes.AUTH_OIDC, kftypes.AUTH_LDAP}
+	settings.AuthProvider, err = p.Choose("How users sign in", providers, settings.AuthProvider,
+		func(provider string) error {
+			if provider == kftypes.AUTH_IAP {
+				return checker.CheckOAuthBrand(settings.Project)
+			}
+			return nil
+		})
+	if err != nil {
+		return err
+	}
+	if settings.Email == "" {
+		settings.Email = checker.Account()
+	}
+	if settings.Email, err = p.Ask("Email of the admin", settings.Email, checkEmail); err != nil {
+		return err
+	}
+	if settings.UseIstio, err = p.Confirm("Use Istio", settings.UseIstio); err != nil {
+		return err
+	}
+	return nil
+}
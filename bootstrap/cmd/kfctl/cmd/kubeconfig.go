@@ -0,0 +1,73 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	kftypes "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps"
+	"github.com/kubeflow/kubeflow/bootstrap/pkg/kfapp/coordinator"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var kubeconfigCfg = viper.New()
+
+// kubeconfigCmd represents the kubeconfig command
+var kubeconfigCmd = &cobra.Command{
+	Use:   "kubeconfig",
+	Short: "Configure kubectl for the cluster of a kubeflow application.",
+	Long: `Add the cluster of the app to KUBECONFIG, ~/.kube/config by default, with a context named after the
+app and set to its namespace, and make it the current context. kfctl apply does it too.
+
+The context doesn't expire: kubectl refreshes its access token with gcloud, or with the application
+default credentials when gcloud isn't installed. Run it again when the cluster is recreated.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if kubeconfigCfg.GetBool(string(kftypes.VERBOSE)) == true {
+			log.SetLevel(log.InfoLevel)
+		} else {
+			log.SetLevel(log.WarnLevel)
+		}
+		kfApp, kfAppErr := coordinator.LoadKfApp(map[string]interface{}{})
+		if kfAppErr != nil {
+			return fmt.Errorf("couldn't load KfApp: %v", kfAppErr)
+		}
+		kubeconfig, ok := kfApp.(kftypes.KfKubeconfig)
+		if !ok || kubeconfig == nil {
+			return fmt.Errorf("KfApp does not configure kubectl for its cluster")
+		}
+		if writeErr := kubeconfig.WriteKubeconfig(); writeErr != nil {
+			return fmt.Errorf("couldn't write KUBECONFIG: %v", writeErr)
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(kubeconfigCmd)
+
+	kubeconfigCfg.SetConfigName("app")
+	kubeconfigCfg.SetConfigType("yaml")
+
+	// verbose output
+	kubeconfigCmd.Flags().BoolP(string(kftypes.VERBOSE), "V", false,
+		string(kftypes.VERBOSE)+" output default is false")
+	bindErr := kubeconfigCfg.BindPFlag(string(kftypes.VERBOSE), kubeconfigCmd.Flags().Lookup(string(kftypes.VERBOSE)))
+	if bindErr != nil {
+		log.Errorf("couldn't set flag --%v: %v", string(kftypes.VERBOSE), bindErr)
+		return
+	}
+}
@@ -0,0 +1,108 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	kftypes "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps"
+	"github.com/kubeflow/kubeflow/bootstrap/pkg/kfapp/coordinator"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var planCfg = viper.New()
+
+// planCmd represents the plan command
+var planCmd = &cobra.Command{
+	Use:   "plan [all(=default)|k8s|platform]",
+	Short: "List what apply would do to a generated kubeflow application.",
+	Long: `List, in order, what apply would do to a generated kubeflow application without doing it: the deployment
+manager deployments it creates or updates, the IAM bindings it adds or removes, the namespaces and
+secrets it creates in the cluster and the manifests it applies. Run it between generate and apply to
+check the changes before making them.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if planCfg.GetBool(string(kftypes.VERBOSE)) == true {
+			log.SetLevel(log.InfoLevel)
+		} else {
+			log.SetLevel(log.WarnLevel)
+		}
+		resource, resourceErr := processResourceArg(args)
+		if resourceErr != nil {
+			return fmt.Errorf("invalid resource: %v", resourceErr)
+		}
+		options := map[string]interface{}{
+			string(kftypes.KUBECONFIG):  planCfg.GetString(string(kftypes.KUBECONFIG)),
+			string(kftypes.KUBECONTEXT): planCfg.GetString(string(kftypes.KUBECONTEXT)),
+			string(kftypes.TARGET):      planCfg.GetStringSlice(string(kftypes.TARGET)),
+		}
+		kfApp, kfAppErr := coordinator.LoadKfApp(options)
+		if kfAppErr != nil {
+			return fmt.Errorf("couldn't load KfApp: %v", kfAppErr)
+		}
+		actions, planErr := kfApp.Plan(resource)
+		if planErr != nil {
+			return fmt.Errorf("couldn't plan KfApp: %v", planErr)
+		}
+		if len(actions) == 0 {
+			fmt.Println("Nothing to do.")
+			return nil
+		}
+		for _, action := range actions {
+			fmt.Println(action)
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(planCmd)
+
+	planCfg.SetConfigName("app")
+	planCfg.SetConfigType("yaml")
+
+	// verbose output
+	planCmd.Flags().BoolP(string(kftypes.VERBOSE), "V", false,
+		string(kftypes.VERBOSE)+" output default is false")
+	bindErr := planCfg.BindPFlag(string(kftypes.VERBOSE), planCmd.Flags().Lookup(string(kftypes.VERBOSE)))
+	if bindErr != nil {
+		log.Errorf("couldn't set flag --%v: %v", string(kftypes.VERBOSE), bindErr)
+		return
+	}
+
+	planCmd.Flags().String(string(kftypes.KUBECONFIG), "",
+		"Path to a kubeconfig used to reach the cluster instead of looking it up through the GKE API.")
+	bindErr = planCfg.BindPFlag(string(kftypes.KUBECONFIG), planCmd.Flags().Lookup(string(kftypes.KUBECONFIG)))
+	if bindErr != nil {
+		log.Errorf("couldn't set flag --%v: %v", string(kftypes.KUBECONFIG), bindErr)
+		return
+	}
+
+	planCmd.Flags().String(string(kftypes.KUBECONTEXT), "",
+		"Name of the kubeconfig context used to reach the cluster.")
+	bindErr = planCfg.BindPFlag(string(kftypes.KUBECONTEXT), planCmd.Flags().Lookup(string(kftypes.KUBECONTEXT)))
+	if bindErr != nil {
+		log.Errorf("couldn't set flag --%v: %v", string(kftypes.KUBECONTEXT), bindErr)
+		return
+	}
+
+	planCmd.Flags().StringSlice(string(kftypes.TARGET), []string{},
+		"Only plan these pieces of the platform, e.g. --target=cluster,iam.")
+	bindErr = planCfg.BindPFlag(string(kftypes.TARGET), planCmd.Flags().Lookup(string(kftypes.TARGET)))
+	if bindErr != nil {
+		log.Errorf("couldn't set flag --%v: %v", string(kftypes.TARGET), bindErr)
+		return
+	}
+}
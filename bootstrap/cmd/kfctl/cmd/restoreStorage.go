@@ -0,0 +1,73 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	kftypes "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps"
+	"github.com/kubeflow/kubeflow/bootstrap/pkg/kfapp/coordinator"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var restoreStorageCfg = viper.New()
+
+// restoreStorageCmd represents the restore-storage command
+var restoreStorageCmd = &cobra.Command{
+	Use:   "restore-storage",
+	Short: "Recreate the storage disks of a kubeflow application from their snapshots.",
+	Long: `Recreate the metadata-store and artifact-store disks of the app which were deleted from their latest
+snapshot recorded in app.yaml. Snapshots are taken by kfctl delete --delete_storage when snapshotStorage is
+set. Disks which still exist are left as they are.
+
+Run kfctl apply afterwards; the storage deployment takes the restored disks over.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if restoreStorageCfg.GetBool(string(kftypes.VERBOSE)) == true {
+			log.SetLevel(log.InfoLevel)
+		} else {
+			log.SetLevel(log.WarnLevel)
+		}
+		kfApp, kfAppErr := coordinator.LoadKfApp(map[string]interface{}{})
+		if kfAppErr != nil {
+			return fmt.Errorf("couldn't load KfApp: %v", kfAppErr)
+		}
+		restore, ok := kfApp.(kftypes.KfRestoreStorage)
+		if !ok || restore == nil {
+			return fmt.Errorf("KfApp does not restore its storage")
+		}
+		if restoreErr := restore.RestoreStorage(); restoreErr != nil {
+			return fmt.Errorf("couldn't restore storage: %v", restoreErr)
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(restoreStorageCmd)
+
+	restoreStorageCfg.SetConfigName("app")
+	restoreStorageCfg.SetConfigType("yaml")
+
+	// verbose output
+	restoreStorageCmd.Flags().BoolP(string(kftypes.VERBOSE), "V", false,
+		string(kftypes.VERBOSE)+" output default is false")
+	bindErr := restoreStorageCfg.BindPFlag(string(kftypes.VERBOSE), restoreStorageCmd.Flags().Lookup(string(kftypes.VERBOSE)))
+	if bindErr != nil {
+		log.Errorf("couldn't set flag --%v: %v", string(kftypes.VERBOSE), bindErr)
+		return
+	}
+}
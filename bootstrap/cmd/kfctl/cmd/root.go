@@ -17,10 +17,15 @@ package cmd
 import (
 	"fmt"
 	kftypes "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps"
+	"github.com/kubeflow/kubeflow/bootstrap/pkg/utils"
+	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 	"os"
 )
 
+var rootCfg = viper.New()
+
 func processResourceArg(args []string) (kftypes.ResourceEnum, error) {
 	if len(args) > 1 {
 		return kftypes.ALL, fmt.Errorf("unknown extra args %v", args[1:])
@@ -59,8 +64,58 @@ func Execute() {
 
 func init() {
 	cobra.OnInitialize(initConfig)
+
+	rootCmd.PersistentFlags().String(string(kftypes.CREDENTIALS_FILE), "",
+		"Service account key file used for GCP instead of the application default credentials.")
+	bindErr := rootCfg.BindPFlag(string(kftypes.CREDENTIALS_FILE),
+		rootCmd.PersistentFlags().Lookup(string(kftypes.CREDENTIALS_FILE)))
+	if bindErr != nil {
+		log.Errorf("couldn't set flag --%v: %v", string(kftypes.CREDENTIALS_FILE), bindErr)
+		return
+	}
+
+	rootCmd.PersistentFlags().String(string(kftypes.IMPERSONATE_SA), "",
+		"Service account impersonated for GCP, through the IAM Credentials API, by the caller of the credentials; "+
+			"it needs roles/iam.serviceAccountTokenCreator on it.")
+	bindErr = rootCfg.BindPFlag(string(kftypes.IMPERSONATE_SA),
+		rootCmd.PersistentFlags().Lookup(string(kftypes.IMPERSONATE_SA)))
+	if bindErr != nil {
+		log.Errorf("couldn't set flag --%v: %v", string(kftypes.IMPERSONATE_SA), bindErr)
+		return
+	}
+
+	rootCmd.PersistentFlags().Bool(string(kftypes.NO_EXEC), false,
+		"Forbid running gcloud or any other command; only the native GCP and Kubernetes API code paths are used.")
+	bindErr = rootCfg.BindPFlag(string(kftypes.NO_EXEC), rootCmd.PersistentFlags().Lookup(string(kftypes.NO_EXEC)))
+	if bindErr != nil {
+		log.Errorf("couldn't set flag --%v: %v", string(kftypes.NO_EXEC), bindErr)
+		return
+	}
+
+	rootCmd.PersistentFlags().Bool(string(kftypes.DEBUG_HTTP), false,
+		"Log the method, URL, status and latency of the calls to the Google APIs, and the errors they return, "+
+			"with the credentials redacted.")
+	bindErr = rootCfg.BindPFlag(string(kftypes.DEBUG_HTTP), rootCmd.PersistentFlags().Lookup(string(kftypes.DEBUG_HTTP)))
+	if bindErr != nil {
+		log.Errorf("couldn't set flag --%v: %v", string(kftypes.DEBUG_HTTP), bindErr)
+		return
+	}
 }
 
 // initConfig creates a Viper config file and set's it's name and type
 func initConfig() {
+	// The GCP clients all look up the application default credentials, which honor
+	// GOOGLE_APPLICATION_CREDENTIALS, so --credentials-file applies to every subcommand.
+	if credentialsFile := rootCfg.GetString(string(kftypes.CREDENTIALS_FILE)); credentialsFile != "" {
+		if _, err := os.Stat(credentialsFile); err != nil {
+			log.Fatalf("couldn't read --%v %v: %v", string(kftypes.CREDENTIALS_FILE), credentialsFile, err)
+		}
+		os.Setenv("GOOGLE_APPLICATION_CREDENTIALS", credentialsFile)
+	}
+	// gcloud reads the impersonated service account from the same variable as the gcp kfapp.
+	if serviceAccount := rootCfg.GetString(string(kftypes.IMPERSONATE_SA)); serviceAccount != "" {
+		os.Setenv("CLOUDSDK_AUTH_IMPERSONATE_SERVICE_ACCOUNT", serviceAccount)
+	}
+	utils.SetNoExec(rootCfg.GetBool(string(kftypes.NO_EXEC)))
+	utils.SetDebugHTTP(rootCfg.GetBool(string(kftypes.DEBUG_HTTP)))
 }
@@ -0,0 +1,77 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	kftypes "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps"
+	"github.com/kubeflow/kubeflow/bootstrap/pkg/kfapp/coordinator"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var secretsCfg = viper.New()
+
+// secretsCmd represents the secrets command
+var secretsCmd = &cobra.Command{
+	Use:   "secrets",
+	Short: "Manage the secrets of a kubeflow application.",
+}
+
+var secretsSyncCmd = &cobra.Command{
+	Use:   "sync",
+	Short: "Create the secrets missing from the namespaces of a kubeflow application.",
+	Long: `Create the service account secrets missing from the app and istio namespaces, reusing the key
+of the existing copies, and copy the user secret and image pull secrets into the profile namespaces
+and the namespaces labeled kubeflow.org/sync-secrets=true.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if secretsCfg.GetBool(string(kftypes.VERBOSE)) == true {
+			log.SetLevel(log.InfoLevel)
+		} else {
+			log.SetLevel(log.WarnLevel)
+		}
+		kfApp, kfAppErr := coordinator.LoadKfApp(map[string]interface{}{})
+		if kfAppErr != nil {
+			return fmt.Errorf("couldn't load KfApp: %v", kfAppErr)
+		}
+		secrets, ok := kfApp.(kftypes.KfSecrets)
+		if !ok || secrets == nil {
+			return fmt.Errorf("KfApp does not manage the secrets of its namespaces")
+		}
+		if syncErr := secrets.SyncSecrets(); syncErr != nil {
+			return fmt.Errorf("couldn't sync secrets: %v", syncErr)
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(secretsCmd)
+	secretsCmd.AddCommand(secretsSyncCmd)
+
+	secretsCfg.SetConfigName("app")
+	secretsCfg.SetConfigType("yaml")
+
+	// verbose output
+	secretsCmd.PersistentFlags().BoolP(string(kftypes.VERBOSE), "V", false,
+		string(kftypes.VERBOSE)+" output default is false")
+	bindErr := secretsCfg.BindPFlag(string(kftypes.VERBOSE), secretsCmd.PersistentFlags().Lookup(string(kftypes.VERBOSE)))
+	if bindErr != nil {
+		log.Errorf("couldn't set flag --%v: %v", string(kftypes.VERBOSE), bindErr)
+		return
+	}
+}
@@ -0,0 +1,137 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	kftypes "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps"
+	"github.com/kubeflow/kubeflow/bootstrap/pkg/kfapp/coordinator"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var userCfg = viper.New()
+
+// loadKfUsers loads the KfApp of the current directory for the user subcommands.
+func loadKfUsers(options map[string]interface{}) (kftypes.KfUsers, error) {
+	if userCfg.GetBool(string(kftypes.VERBOSE)) == true {
+		log.SetLevel(log.InfoLevel)
+	} else {
+		log.SetLevel(log.WarnLevel)
+	}
+	kfApp, kfAppErr := coordinator.LoadKfApp(options)
+	if kfAppErr != nil {
+		return nil, fmt.Errorf("couldn't load KfApp: %v", kfAppErr)
+	}
+	users, ok := kfApp.(kftypes.KfUsers)
+	if !ok || users == nil {
+		return nil, fmt.Errorf("KfApp does not manage basic auth users")
+	}
+	return users, nil
+}
+
+// userCmd represents the user command
+var userCmd = &cobra.Command{
+	Use:   "user",
+	Short: "Manage the basic auth users of a kubeflow application.",
+	Long: `Add, remove or list the users allowed to log in to a kubeflow application using basic auth.
+Changes regenerate the kubeflow-login secret and restart the basic-auth deployment.`,
+}
+
+var userAddCmd = &cobra.Command{
+	Use:   "add <username>",
+	Short: "Add a basic auth user, or change the password of an existing one.",
+	Long: `Add a basic auth user, or change the password of an existing one. The password is read
+from --` + string(kftypes.PASSWORD_FILE) + `, then from ` + kftypes.KUBEFLOW_PASSWORD + `, else prompted for.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		options := map[string]interface{}{
+			string(kftypes.PASSWORD_FILE): userCfg.GetString(string(kftypes.PASSWORD_FILE)),
+		}
+		users, err := loadKfUsers(options)
+		if err != nil {
+			return err
+		}
+		if addErr := users.AddUser(args[0]); addErr != nil {
+			return fmt.Errorf("couldn't add user %v: %v", args[0], addErr)
+		}
+		return nil
+	},
+}
+
+var userRemoveCmd = &cobra.Command{
+	Use:   "remove <username>",
+	Short: "Remove a basic auth user.",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		users, err := loadKfUsers(map[string]interface{}{})
+		if err != nil {
+			return err
+		}
+		if removeErr := users.RemoveUser(args[0]); removeErr != nil {
+			return fmt.Errorf("couldn't remove user %v: %v", args[0], removeErr)
+		}
+		return nil
+	},
+}
+
+var userListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the basic auth users.",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		users, err := loadKfUsers(map[string]interface{}{})
+		if err != nil {
+			return err
+		}
+		usernames, listErr := users.ListUsers()
+		if listErr != nil {
+			return fmt.Errorf("couldn't list users: %v", listErr)
+		}
+		for _, username := range usernames {
+			fmt.Println(username)
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(userCmd)
+	userCmd.AddCommand(userAddCmd)
+	userCmd.AddCommand(userRemoveCmd)
+	userCmd.AddCommand(userListCmd)
+
+	userCfg.SetConfigName("app")
+	userCfg.SetConfigType("yaml")
+
+	// verbose output
+	userCmd.PersistentFlags().BoolP(string(kftypes.VERBOSE), "V", false,
+		string(kftypes.VERBOSE)+" output default is false")
+	bindErr := userCfg.BindPFlag(string(kftypes.VERBOSE), userCmd.PersistentFlags().Lookup(string(kftypes.VERBOSE)))
+	if bindErr != nil {
+		log.Errorf("couldn't set flag --%v: %v", string(kftypes.VERBOSE), bindErr)
+		return
+	}
+
+	userAddCmd.Flags().String(string(kftypes.PASSWORD_FILE), "",
+		"File holding the password, or - to read it from stdin. Used instead of "+
+			kftypes.KUBEFLOW_PASSWORD+".")
+	bindErr = userCfg.BindPFlag(string(kftypes.PASSWORD_FILE), userAddCmd.Flags().Lookup(string(kftypes.PASSWORD_FILE)))
+	if bindErr != nil {
+		log.Errorf("couldn't set flag --%v: %v", string(kftypes.PASSWORD_FILE), bindErr)
+		return
+	}
+}
@@ -0,0 +1,69 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// The operator watches KfDef resources and keeps the kubeflow deployments they describe applied.
+// It needs the same credentials and environment as kfctl apply, e.g. CLIENT_ID and CLIENT_SECRET
+// for IAP or KUBEFLOW_USERNAME and KUBEFLOW_PASSWORD for basic auth on gcp.
+package main
+
+import (
+	"flag"
+	kfdefs "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps/kfdef/v1alpha1"
+	"github.com/kubeflow/kubeflow/bootstrap/pkg/controller/kfdef"
+	"github.com/onrik/logrus/filename"
+	log "github.com/sirupsen/logrus"
+	"sigs.k8s.io/controller-runtime/pkg/client/config"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/runtime/signals"
+	"time"
+)
+
+func init() {
+	// Add filename as one of the fields of the structured log message
+	filenameHook := filename.NewHook()
+	filenameHook.Field = "filename"
+	log.AddHook(filenameHook)
+}
+
+func main() {
+	namespace := flag.String("namespace", "", "Namespace to watch for KfDef resources; all namespaces when empty.")
+	appsRoot := flag.String("apps-root", "/opt/kubeflow/apps", "Directory holding the app dir of each KfDef.")
+	resyncPeriod := flag.Duration("resync-period", 30*time.Minute, "How often applied KfDefs are reconciled again.")
+	jsonLogFormat := flag.Bool("json-log-format", true, "Set true to use json style log format.")
+	flag.Parse()
+
+	if *jsonLogFormat {
+		// Output logs in a json format so that it can be parsed by services like Stackdriver
+		log.SetFormatter(&log.JSONFormatter{})
+	}
+
+	cfg, err := config.GetConfig()
+	if err != nil {
+		log.Fatalf("couldn't get kubernetes config: %v", err)
+	}
+	mgr, err := manager.New(cfg, manager.Options{Namespace: *namespace})
+	if err != nil {
+		log.Fatalf("couldn't create manager: %v", err)
+	}
+	if err = kfdefs.AddToScheme(mgr.GetScheme()); err != nil {
+		log.Fatalf("couldn't register KfDef types: %v", err)
+	}
+	if err = kfdef.Add(mgr, *appsRoot, *resyncPeriod); err != nil {
+		log.Fatalf("couldn't create KfDef controller: %v", err)
+	}
+	log.Infof("starting KfDef operator")
+	if err = mgr.Start(signals.SetupSignalHandler()); err != nil {
+		log.Fatalf("manager exited: %v", err)
+	}
+}
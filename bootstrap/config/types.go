@@ -24,6 +24,30 @@ type NameValue struct {
 
 type Parameters map[string][]NameValue
 
+// Merge returns a copy of p with overrides set on top of it: a parameter with the
+// same component and name is replaced, any other is appended.
+func (p Parameters) Merge(overrides Parameters) Parameters {
+	merged := p.DeepCopy()
+	if merged == nil {
+		merged = make(Parameters)
+	}
+	for component, namevals := range overrides {
+		for _, nv := range namevals {
+			replaced := false
+			for i := range merged[component] {
+				if merged[component][i].Name == nv.Name {
+					merged[component][i] = nv
+					replaced = true
+				}
+			}
+			if !replaced {
+				merged[component] = append(merged[component], nv)
+			}
+		}
+	}
+	return merged
+}
+
 // Default components configuration definitions.
 type ComponentConfig struct {
 	// Name of repository.
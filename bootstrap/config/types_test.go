@@ -0,0 +1,67 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParametersMerge(t *testing.T) {
+	params := Parameters{
+		"cert-manager": {{Name: "acmeEmail", Value: "user@example.com"}},
+		"iap-ingress":  {{Name: "hostname", Value: "kf.example.com"}, {Name: "ipName", Value: "kf-ip"}},
+	}
+	tests := []struct {
+		name      string
+		params    Parameters
+		overrides Parameters
+		want      Parameters
+	}{
+		{
+			name:   "no overrides",
+			params: params,
+			want:   params,
+		},
+		{
+			name:   "replaced and appended",
+			params: params,
+			overrides: Parameters{
+				"iap-ingress": {{Name: "hostname", Value: "dev.example.com"}, {Name: "replicas", Value: "2"}},
+				"jupyter":     {{Name: "image", Value: "notebook:dev"}},
+			},
+			want: Parameters{
+				"cert-manager": {{Name: "acmeEmail", Value: "user@example.com"}},
+				"iap-ingress": {{Name: "hostname", Value: "dev.example.com"}, {Name: "ipName", Value: "kf-ip"},
+					{Name: "replicas", Value: "2"}},
+				"jupyter": {{Name: "image", Value: "notebook:dev"}},
+			},
+		},
+		{
+			name:      "no params",
+			overrides: Parameters{"jupyter": {{Name: "image", Value: "notebook:dev"}}},
+			want:      Parameters{"jupyter": {{Name: "image", Value: "notebook:dev"}}},
+		},
+	}
+	for _, test := range tests {
+		if got := test.params.Merge(test.overrides); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%v: Merge = %v; want %v", test.name, got, test.want)
+		}
+	}
+	// The params merged into aren't modified.
+	if params["iap-ingress"][0].Value != "kf.example.com" || len(params["iap-ingress"]) != 2 {
+		t.Errorf("Merge modified the params: %v", params)
+	}
+}
@@ -0,0 +1,97 @@
+// +build ignore
+
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// gen-assets writes the files and directories given as arguments, relative to -root, into a go
+// source file as gzipped string literals, in the manner of go-bindata. It's run by go generate
+// in pkg/kfapp/assets:
+//
+//	go run ../../../hack/gen-assets.go -root ../../../.. -pkg assets -o zz_generated.assets.go <path>...
+package main
+
+import (
+	"bytes"
+	"compress/gzip"
+	"flag"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+func main() {
+	root := flag.String("root", ".", "directory the paths are relative to")
+	pkg := flag.String("pkg", "assets", "package of the generated file")
+	out := flag.String("o", "zz_generated.assets.go", "generated file")
+	flag.Parse()
+
+	names := []string{}
+	for _, arg := range flag.Args() {
+		err := filepath.Walk(filepath.Join(*root, arg), func(p string, info os.FileInfo, err error) error {
+			if err != nil || info.IsDir() {
+				return err
+			}
+			name, err := filepath.Rel(*root, p)
+			if err != nil {
+				return err
+			}
+			names = append(names, filepath.ToSlash(name))
+			return nil
+		})
+		if err != nil {
+			log.Fatalf("couldn't read %v: %v", arg, err)
+		}
+	}
+	sort.Strings(names)
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by hack/gen-assets.go. DO NOT EDIT.\n// sources:\n")
+	for _, name := range names {
+		fmt.Fprintf(&buf, "// %v\n", name)
+	}
+	fmt.Fprintf(&buf, "\npackage %v\n\nvar _assets = map[string]string{\n", *pkg)
+	for _, name := range names {
+		data, err := ioutil.ReadFile(filepath.Join(*root, name))
+		if err != nil {
+			log.Fatalf("couldn't read %v: %v", name, err)
+		}
+		fmt.Fprintf(&buf, "\t%q: \"%v\",\n", name, gzipped(data))
+	}
+	fmt.Fprintf(&buf, "}\n")
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatalf("couldn't format %v: %v", *out, err)
+	}
+	if err = ioutil.WriteFile(*out, src, 0644); err != nil {
+		log.Fatalf("couldn't write %v: %v", *out, err)
+	}
+}
+
+// gzipped returns data compressed, as the \x escapes of a string literal.
+func gzipped(data []byte) string {
+	var compressed bytes.Buffer
+	w, _ := gzip.NewWriterLevel(&compressed, gzip.BestCompression)
+	w.Write(data)
+	w.Close()
+	var escaped strings.Builder
+	for _, b := range compressed.Bytes() {
+		fmt.Fprintf(&escaped, "\\x%02x", b)
+	}
+	return escaped.String()
+}
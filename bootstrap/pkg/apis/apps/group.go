@@ -19,6 +19,7 @@ import (
 	"fmt"
 	kfdefs "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps/kfdef/v1alpha1"
 	log "github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
 	"io"
 	ext "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
 	crdclientset "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
@@ -28,11 +29,15 @@ import (
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+	"math/rand"
 	"os"
 	"path/filepath"
 	"plugin"
 	"regexp"
+	"runtime"
+	"strconv"
 	"strings"
+	"time"
 )
 
 const (
@@ -46,14 +51,21 @@ const (
 	DefaultConfigFile = "kfctl_default.yaml"
 	GcpIapConfig      = "kfctl_iap.yaml"
 	GcpBasicAuth      = "kfctl_basic_auth.yaml"
+	GcpDexConfig      = "kfctl_dex.yaml"
 	DefaultZone       = "us-east1-d"
-	DefaultGkeApiVer  = "v1beta1"
-	DefaultAppLabel   = "app.kubernetes.io/name"
-	KUBEFLOW_USERNAME = "KUBEFLOW_USERNAME"
-	KUBEFLOW_PASSWORD = "KUBEFLOW_PASSWORD"
+	// AutoZone as the zone lets generate pick the zone of the region that fits the node pools.
+	AutoZone           = "auto"
+	DefaultGkeApiVer   = "v1beta1"
+	DefaultAppLabel    = "app.kubernetes.io/name"
+	KUBEFLOW_USERNAME  = "KUBEFLOW_USERNAME"
+	KUBEFLOW_PASSWORD  = "KUBEFLOW_PASSWORD"
 	DefaultSwaggerFile = "bootstrap/k8sSpec/v1.11.7/api/openapi-spec/swagger.json"
 )
 
+// AppDirVersion is the app dir layout version written by this kfctl.
+// Bump it together with a new migration in coordinator when the layout changes.
+const AppDirVersion = 1
+
 type ResourceEnum string
 
 const (
@@ -78,10 +90,46 @@ const (
 	APPNAME               CliOption = "appname"
 	DATA                  CliOption = "Data"
 	ZONE                  CliOption = "zone"
+	REGION                CliOption = "region"
 	USE_BASIC_AUTH        CliOption = "use_basic_auth"
+	AUTH_PROVIDER         CliOption = "auth_provider"
 	USE_ISTIO             CliOption = "use_istio"
+	MANIFESTS_TOOL        CliOption = "manifests_tool"
+	NAMESPACE_PREFIX      CliOption = "namespace_prefix"
 	DELETE_STORAGE        CliOption = "delete_storage"
+	DELETE_FILESTORE      CliOption = "delete_filestore"
+	DELETE_IP             CliOption = "delete_ip"
+	PRESERVE_DATA         CliOption = "preserve_data"
 	DISABLE_USAGE_REPORT  CliOption = "disable_usage_report"
+	YES                   CliOption = "yes"
+	KUBECONFIG            CliOption = "kubeconfig"
+	KUBECONTEXT           CliOption = "context"
+	DRY_RUN               CliOption = "dry-run"
+	CREDENTIALS_FILE      CliOption = "credentials-file"
+	IMPERSONATE_SA        CliOption = "impersonate-service-account"
+	NO_EXEC               CliOption = "no-exec"
+	DEBUG_HTTP            CliOption = "debug-http"
+	PASSWORD_FILE         CliOption = "password-file"
+	OAUTH_CLIENT_FILE     CliOption = "oauth-client-file"
+	BCRYPT_COST           CliOption = "bcrypt-cost"
+	ENV                   CliOption = "env"
+	COMBINED_DEPLOYMENT   CliOption = "combined_deployment"
+	USE_EMBEDDED_ASSETS   CliOption = "use-embedded-assets"
+	MIRROR                CliOption = "mirror"
+	REPO_CHECKSUM         CliOption = "repo-checksum"
+	ROTATE_SA_KEYS        CliOption = "rotate-sa-keys"
+	DM_RECOVERY           CliOption = "dm-recovery"
+	TIMEOUT               CliOption = "timeout"
+	TARGET                CliOption = "target"
+	POOL                  CliOption = "pool"
+	NODES                 CliOption = "nodes"
+	MACHINE_TYPE          CliOption = "machine-type"
+	MASTER                CliOption = "master"
+	MAX_SURGE             CliOption = "max-surge"
+	CURRENCY              CliOption = "currency"
+	OUTPUT                CliOption = "output"
+	CLUSTER               CliOption = "cluster"
+	INTERACTIVE           CliOption = "interactive"
 )
 
 //
@@ -94,6 +142,37 @@ type KfApp interface {
 	Delete(resources ResourceEnum) error
 	Generate(resources ResourceEnum) error
 	Init(resources ResourceEnum) error
+	// Plan lists the changes Apply would make, in order, without making them.
+	Plan(resources ResourceEnum) ([]Action, error)
+}
+
+// ActionType is the kind of change an Action makes.
+type ActionType string
+
+const (
+	CREATE_DEPLOYMENT ActionType = "CreateDeployment"
+	UPDATE_DEPLOYMENT ActionType = "UpdateDeployment"
+	UPDATE_IAM_POLICY ActionType = "UpdateIamPolicy"
+	UPDATE_ENDPOINTS  ActionType = "UpdateEndpoints"
+	CREATE_NAMESPACE  ActionType = "CreateNamespace"
+	CREATE_SECRET     ActionType = "CreateSecret"
+	APPLY_MANIFEST    ActionType = "ApplyManifest"
+)
+
+// Action is a change Apply would make, listed by Plan for the user to confirm.
+type Action struct {
+	Type ActionType `json:"type"`
+	// Target is what's changed, e.g. the DM deployment storage or the secret kubeflow/admin-gcp-sa.
+	Target string `json:"target"`
+	// Detail is how it's changed, e.g. +5 bindings; it may be empty.
+	Detail string `json:"detail,omitempty"`
+}
+
+func (a Action) String() string {
+	if a.Detail == "" {
+		return fmt.Sprintf("%v %v", a.Type, a.Target)
+	}
+	return fmt.Sprintf("%v %v %v", a.Type, a.Target, a.Detail)
 }
 
 //
@@ -103,6 +182,194 @@ type KfShow interface {
 	Show(resources ResourceEnum, options map[string]interface{}) error
 }
 
+//
+// This is used by platforms that label the resources they create, for `kfctl gc`
+//
+type KfGarbageCollect interface {
+	GarbageCollect(dryRun bool) error
+}
+
+//
+// This is used by platforms that configure what the k8s resources created, e.g. their load balancers,
+// once they're applied. PostApply returns once ctx is done.
+//
+type KfPostApply interface {
+	PostApply(ctx context.Context, resources ResourceEnum) error
+}
+
+//
+// This is used by platforms that can change the node pools of the cluster in place, for `kfctl cluster`
+//
+type KfCluster interface {
+	ResizeNodePool(pool string, nodes int) error
+	SetNodePoolMachineType(pool string, machineType string) error
+	UpgradeCluster(masterVersion string, nodeVersion string, maxSurge int) error
+}
+
+//
+// This is used by platforms that create secrets in the namespaces of the app, for `kfctl secrets sync`
+//
+type KfSecrets interface {
+	SyncSecrets() error
+}
+
+//
+// This is used by platforms that can configure kubectl for their cluster, for `kfctl kubeconfig`
+//
+type KfKubeconfig interface {
+	WriteKubeconfig() error
+}
+
+//
+// This is used by platforms that snapshot their storage, for `kfctl restore-storage`
+//
+type KfRestoreStorage interface {
+	RestoreStorage() error
+}
+
+//
+// This is used by platforms that can price the resources of their generated configs, for `kfctl cost-estimate`
+//
+type KfCostEstimate interface {
+	EstimateCost(currency string) (*CostEstimate, error)
+}
+
+// CostEstimate is the approximate monthly cost of the resources of an app, at list prices.
+type CostEstimate struct {
+	Currency string
+	Items    []CostItem
+	// Unpriced are the resources no price was found for.
+	Unpriced []string
+}
+
+// CostItem is the monthly cost of a resource of the app, e.g. the cores of a node pool.
+type CostItem struct {
+	Resource string
+	Monthly  float64
+	// PerNode items are the cost of one node of a pool autoscaled from zero; they're left out
+	// of the total.
+	PerNode bool
+}
+
+// Total is the monthly cost of the items, without the PerNode ones.
+func (e *CostEstimate) Total() float64 {
+	total := 0.0
+	for _, item := range e.Items {
+		if !item.PerNode {
+			total += item.Monthly
+		}
+	}
+	return total
+}
+
+//
+// This is used by platforms that can diagnose a broken deployment, for `kfctl doctor`
+//
+type KfDoctor interface {
+	Diagnose() ([]Problem, error)
+}
+
+// Severity of a Problem; the lower, the more severe.
+type Severity int
+
+const (
+	// CRITICAL problems leave the app unusable, e.g. a missing cluster.
+	CRITICAL Severity = iota
+	// ERROR problems break part of the app, e.g. a missing secret.
+	ERROR
+	// WARNING problems may be transient or harmless, e.g. an ingress without an address yet.
+	WARNING
+)
+
+func (s Severity) String() string {
+	switch s {
+	case CRITICAL:
+		return "critical"
+	case ERROR:
+		return "error"
+	}
+	return "warning"
+}
+
+// Problem is an issue found in a deployment and how to fix it.
+type Problem struct {
+	Severity Severity
+	// Check that found the problem, e.g. iam.
+	Check       string
+	Message     string
+	Remediation string
+}
+
+//
+// This is used by platforms that can compare the generated configs with what's deployed, for `kfctl diff`
+//
+type KfDiff interface {
+	Diff(resources ResourceEnum) (string, error)
+}
+
+//
+// This is used by platforms and package managers that can list the files they apply, for `kfctl export`.
+// The files are keyed by their slash separated path in the archive.
+//
+type KfExport interface {
+	Export(resources ResourceEnum) (map[string][]byte, error)
+}
+
+//
+// This is used by platforms that manage the basic auth users, for `kfctl user`
+//
+type KfUsers interface {
+	AddUser(username string) error
+	RemoveUser(username string) error
+	ListUsers() ([]string, error)
+}
+
+//
+// This is used by platforms that onboard users to an applied app, for `kfctl grant-user`
+//
+type KfGrantUser interface {
+	GrantUser(email string, namespace string) error
+}
+
+//
+// This is used by platforms that expose the app at a URL, for `kfctl get-endpoint` and the summary of `kfctl apply`
+//
+type KfEndpoint interface {
+	Endpoint() (*Endpoint, error)
+}
+
+// Endpoint is where an applied app is reached and what to expect of it.
+type Endpoint struct {
+	URL string
+	// AuthMode is the auth provider in front of the URL, e.g. iap.
+	AuthMode string
+	// CertType is how the TLS certificate of the URL is provisioned, e.g. acme.
+	CertType string
+	// CertProvisioning is how long the certificate may take to be provisioned after apply; 0 when
+	// it's there once the ingress is.
+	CertProvisioning time.Duration
+	// StatusCommand shows the ingress serving the URL, e.g. whether it has an address yet.
+	StatusCommand string
+}
+
+// Summary is the endpoint as kfctl prints it after apply.
+func (e *Endpoint) Summary() string {
+	lines := []string{
+		fmt.Sprintf("Kubeflow will be available at %v", e.URL),
+		fmt.Sprintf("Auth: %v", e.AuthMode),
+	}
+	if e.CertProvisioning > 0 {
+		lines = append(lines, fmt.Sprintf("Certificate: %v, which may take up to %v to be provisioned",
+			e.CertType, e.CertProvisioning))
+	} else if e.CertType != "" {
+		lines = append(lines, fmt.Sprintf("Certificate: %v", e.CertType))
+	}
+	if e.StatusCommand != "" {
+		lines = append(lines, fmt.Sprintf("Check the ingress with: %v", e.StatusCommand))
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
 func QuoteItems(items []string) []string {
 	var withQuotes []string
 	for _, item := range items {
@@ -137,6 +404,186 @@ const (
 	MINIKUBE = "minikube"
 )
 
+// Authentication providers, of spec.auth.provider
+const (
+	AUTH_IAP        = "iap"
+	AUTH_BASIC_AUTH = "basic-auth"
+	AUTH_OIDC       = "oidc"
+	AUTH_LDAP       = "ldap"
+)
+
+// AuthProvider is spec.auth.provider, or the provider useBasicAuth selects when it's unset.
+func AuthProvider(spec *kfdefs.KfDefSpec) string {
+	if spec.Auth != nil && spec.Auth.Provider != "" {
+		return spec.Auth.Provider
+	}
+	if spec.UseBasicAuth {
+		return AUTH_BASIC_AUTH
+	}
+	return AUTH_IAP
+}
+
+// Tools generating the manifests of the components, of spec.manifestsTool
+const (
+	MANIFESTS_KSONNET   = "ksonnet"
+	MANIFESTS_KUSTOMIZE = "kustomize"
+)
+
+// ManifestsTool is spec.manifestsTool, ksonnet when it's unset.
+func ManifestsTool(spec *kfdefs.KfDefSpec) string {
+	if spec.ManifestsTool != "" {
+		return spec.ManifestsTool
+	}
+	return MANIFESTS_KSONNET
+}
+
+// PrefixedNamespace returns namespace with spec.namespacePrefix, e.g. mlplatform-kubeflow or
+// mlplatform-istio-system, so several apps can be deployed in one cluster. It's namespace when
+// there's no prefix or namespace already has it.
+func PrefixedNamespace(spec *kfdefs.KfDefSpec, namespace string) string {
+	if spec.NamespacePrefix == "" || strings.HasPrefix(namespace, spec.NamespacePrefix+"-") {
+		return namespace
+	}
+	return spec.NamespacePrefix + "-" + namespace
+}
+
+// Roles the email of the app is bound to, of spec.adminRole
+const (
+	ADMIN_ROLE_CLUSTER_ADMIN  = "cluster-admin"
+	ADMIN_ROLE_KUBEFLOW_ADMIN = "kubeflow-admin"
+	ADMIN_ROLE_NONE           = "none"
+)
+
+// AdminRole is spec.adminRole, cluster-admin when it's unset.
+func AdminRole(spec *kfdefs.KfDefSpec) string {
+	if spec.AdminRole == "" {
+		return ADMIN_ROLE_CLUSTER_ADMIN
+	}
+	return spec.AdminRole
+}
+
+// METADATA_STORE is where pipeline keeps its runs: the disk its mysqlPd param names, which only
+// the storage deployment of gcp creates, or the MySQL it runs in the cluster without one.
+const METADATA_STORE = "metadata-store"
+
+// ComponentDependencies are the components each component requires. METADATA_STORE isn't a
+// component but what pipeline stores its metadata in.
+var ComponentDependencies = map[string][]string{
+	"pipeline":        {METADATA_STORE},
+	"iap-ingress":     {"cert-manager"},
+	"jupyter-web-app": {"notebook-controller"},
+}
+
+// ApplyComponentMatrix adds the enabled components of spec.componentMatrix to spec.components
+// and sets their params, and removes the disabled ones with their params. Like
+// SetComponentParam, it never modifies the slices and maps already handed out.
+func ApplyComponentMatrix(spec *kfdefs.KfDefSpec) {
+	for _, c := range spec.ComponentMatrix {
+		if !c.Enabled {
+			spec.Components = RemoveItem(spec.Components, c.Name)
+			if _, ok := spec.ComponentParams[c.Name]; ok {
+				params := spec.ComponentParams.DeepCopy()
+				delete(params, c.Name)
+				spec.ComponentParams = params
+			}
+			continue
+		}
+		enabled := false
+		for _, component := range spec.Components {
+			if component == c.Name {
+				enabled = true
+			}
+		}
+		if !enabled {
+			spec.Components = append(append([]string{}, spec.Components...), c.Name)
+		}
+		for _, nv := range c.Params {
+			spec.SetComponentParam(c.Name, nv.Name, nv.Value, nv.InitRequired)
+		}
+	}
+}
+
+// SPARTAKUS is the component sending the anonymous usage reports of spec.usageReporting.
+const SPARTAKUS = "spartakus"
+
+// ApplyUsageReporting adds spartakus to spec.components and sets its params from
+// spec.usageReporting when it's enabled, setting a random usageId when it has none, and removes
+// spartakus with its params when it's disabled. Nothing is changed without usageReporting. Like
+// ApplyComponentMatrix, it never modifies what's already handed out.
+func ApplyUsageReporting(spec *kfdefs.KfDefSpec) {
+	usage := spec.UsageReporting
+	if usage == nil {
+		return
+	}
+	if !usage.Enabled {
+		spec.Components = RemoveItem(spec.Components, SPARTAKUS)
+		if _, ok := spec.ComponentParams[SPARTAKUS]; ok {
+			params := spec.ComponentParams.DeepCopy()
+			delete(params, SPARTAKUS)
+			spec.ComponentParams = params
+		}
+		return
+	}
+	if usage.UsageId == "" {
+		usage = usage.DeepCopy()
+		spec.UsageReporting = usage
+		// A source of its own rather than the global one, which other apps use concurrently.
+		random := rand.New(rand.NewSource(time.Now().UnixNano()))
+		usage.UsageId = strconv.Itoa(random.Int())
+	}
+	enabled := false
+	for _, component := range spec.Components {
+		if component == SPARTAKUS {
+			enabled = true
+		}
+	}
+	if !enabled {
+		spec.Components = append(append([]string{}, spec.Components...), SPARTAKUS)
+	}
+	spec.SetComponentParam(SPARTAKUS, "usageId", usage.UsageId, true)
+	spec.SetComponentParam(SPARTAKUS, "reportUsage", "true", true)
+	if usage.ReportEndpoint != "" {
+		spec.SetComponentParam(SPARTAKUS, "reportEndpoint", usage.ReportEndpoint, false)
+	}
+}
+
+// ValidateComponents checks the components of spec have those they require, per
+// ComponentDependencies and the requires of spec.componentMatrix, and returns an error naming
+// each one missing.
+func ValidateComponents(spec *kfdefs.KfDefSpec) error {
+	enabled := map[string]bool{}
+	for _, component := range spec.Components {
+		enabled[component] = true
+	}
+	requires := map[string][]string{}
+	for component, dependencies := range ComponentDependencies {
+		requires[component] = dependencies
+	}
+	for _, c := range spec.ComponentMatrix {
+		requires[c.Name] = append(append([]string{}, requires[c.Name]...), c.Requires...)
+	}
+	missing := []string{}
+	for _, component := range spec.Components {
+		for _, dependency := range requires[component] {
+			if dependency == METADATA_STORE {
+				if pd, _ := spec.GetComponentParam("pipeline", "mysqlPd"); pd != "" && spec.Platform != GCP {
+					missing = append(missing, fmt.Sprintf("%v requires %v: the disk %v of its mysqlPd param "+
+						"is only created on %v; unset it to run MySQL in the cluster", component, dependency, pd, GCP))
+				}
+				continue
+			}
+			if !enabled[dependency] {
+				missing = append(missing, fmt.Sprintf("%v requires %v: enable it in componentMatrix, or "+
+					"disable %v", component, dependency, component))
+			}
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("%v", strings.Join(missing, "\n"))
+	}
+	return nil
+}
+
 func LoadKfApp(client *kfdefs.KfDef) (KfApp, error) {
 	platform := strings.Replace(client.Spec.Platform, "-", "", -1)
 	plugindir := os.Getenv("PLUGINS_ENVIRONMENT")
@@ -153,22 +600,46 @@ func LoadKfApp(client *kfdefs.KfDef) (KfApp, error) {
 	return symbol.(func(*kfdefs.KfDef) KfApp)(client), nil
 }
 
+// KubeConfigPath is the kubeconfig file, the one kubectl writes new entries to: the first existing
+// file of KUBECONFIG, a list separated like PATH, or its last file when none exists, and
+// .kube/config of HomeDir otherwise. Without a home directory, e.g. in a scratch container, it's
+// in the temp directory.
 // TODO(#2586): Consolidate kubeconfig and API calls.
 func KubeConfigPath() string {
-	kubeconfigEnv := os.Getenv("KUBECONFIG")
-	if kubeconfigEnv == "" {
-		home := os.Getenv("HOMEDRIVE") + os.Getenv("HOMEPATH")
-		if home == "" {
-			for _, h := range []string{"HOME", "USERPROFILE"} {
-				if home = os.Getenv(h); home != "" {
-					break
-				}
-			}
+	files := []string{}
+	for _, file := range filepath.SplitList(os.Getenv("KUBECONFIG")) {
+		if file != "" {
+			files = append(files, file)
 		}
-		kubeconfigPath := filepath.Join(home, ".kube", "config")
-		return kubeconfigPath
 	}
-	return kubeconfigEnv
+	for _, file := range files {
+		if _, err := os.Stat(file); err == nil {
+			return file
+		}
+	}
+	if len(files) > 0 {
+		return files[len(files)-1]
+	}
+	home := HomeDir()
+	if home == "" {
+		home = os.TempDir()
+	}
+	return filepath.Join(home, ".kube", "config")
+}
+
+// HomeDir is the home directory of the user, from the environment only as a scratch container has
+// neither a shell nor getent to look it up: USERPROFILE, HOMEDRIVE and HOMEPATH, then HOME on
+// Windows, HOME elsewhere. It's empty when they aren't set.
+func HomeDir() string {
+	if runtime.GOOS == "windows" {
+		if home := os.Getenv("USERPROFILE"); home != "" {
+			return home
+		}
+		if drive, homePath := os.Getenv("HOMEDRIVE"), os.Getenv("HOMEPATH"); drive != "" && homePath != "" {
+			return drive + homePath
+		}
+	}
+	return os.Getenv("HOME")
 }
 
 // GetConfig returns rest.Config using $HOME/.kube/config
@@ -193,6 +664,38 @@ func GetServerVersion(c *clientset.Clientset) string {
 	return "version:" + version
 }
 
+// GetConfigForContext returns rest.Config using the given kubeconfig file and context.
+// An empty kubeconfig falls back to KubeConfigPath() and an empty context to the current-context.
+func GetConfigForContext(kubeconfig string, kubecontext string) (*rest.Config, error) {
+	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	loadingRules.ExplicitPath = kubeconfig
+	if kubeconfig == "" {
+		loadingRules.ExplicitPath = KubeConfigPath()
+	}
+	overrides := &clientcmd.ConfigOverrides{
+		CurrentContext: kubecontext,
+	}
+	return clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, overrides).ClientConfig()
+}
+
+// GetKubeConfigForContext loads the given kubeconfig file with current-context set to kubecontext.
+func GetKubeConfigForContext(kubeconfig string, kubecontext string) (*clientcmdapi.Config, error) {
+	if kubeconfig == "" {
+		kubeconfig = KubeConfigPath()
+	}
+	config, err := clientcmd.LoadFromFile(kubeconfig)
+	if err != nil {
+		return nil, fmt.Errorf("could not load %v Error: %v", kubeconfig, err)
+	}
+	if kubecontext != "" {
+		if _, ok := config.Contexts[kubecontext]; !ok {
+			return nil, fmt.Errorf("context %v not found in %v", kubecontext, kubeconfig)
+		}
+		config.CurrentContext = kubecontext
+	}
+	return config, nil
+}
+
 // Get $HOME/.kube/config
 func GetKubeConfig() *clientcmdapi.Config {
 	kubeconfig := KubeConfigPath()
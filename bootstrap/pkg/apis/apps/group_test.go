@@ -0,0 +1,200 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apps
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/kubeflow/kubeflow/bootstrap/config"
+	kfdefs "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps/kfdef/v1alpha1"
+)
+
+// setEnv sets the environment variables of vars, unsetting those set to "", and returns a func
+// restoring them.
+func setEnv(vars map[string]string) func() {
+	saved := map[string]*string{}
+	for key, value := range vars {
+		if old, ok := os.LookupEnv(key); ok {
+			saved[key] = &old
+		} else {
+			saved[key] = nil
+		}
+		if value == "" {
+			os.Unsetenv(key)
+		} else {
+			os.Setenv(key, value)
+		}
+	}
+	return func() {
+		for key, old := range saved {
+			if old == nil {
+				os.Unsetenv(key)
+			} else {
+				os.Setenv(key, *old)
+			}
+		}
+	}
+}
+
+func TestKubeConfigPathList(t *testing.T) {
+	dir, err := ioutil.TempDir("", "kubeconfig")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	first := filepath.Join(dir, "first")
+	second := filepath.Join(dir, "second")
+	list := strings.Join([]string{first, "", second}, string(filepath.ListSeparator))
+	defer setEnv(map[string]string{"KUBECONFIG": list})()
+
+	if path := KubeConfigPath(); path != second {
+		t.Errorf("KubeConfigPath is %v without any file; want the last one %v", path, second)
+	}
+	if err = ioutil.WriteFile(second, []byte{}, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if path := KubeConfigPath(); path != second {
+		t.Errorf("KubeConfigPath is %v; want the existing %v", path, second)
+	}
+	if err = ioutil.WriteFile(first, []byte{}, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if path := KubeConfigPath(); path != first {
+		t.Errorf("KubeConfigPath is %v; want the first existing %v", path, first)
+	}
+}
+
+func TestKubeConfigPathWithoutHome(t *testing.T) {
+	defer setEnv(map[string]string{
+		"KUBECONFIG":  "",
+		"HOME":        "",
+		"USERPROFILE": "",
+		"HOMEDRIVE":   "",
+		"HOMEPATH":    "",
+	})()
+	expected := filepath.Join(os.TempDir(), ".kube", "config")
+	if path := KubeConfigPath(); path != expected {
+		t.Errorf("KubeConfigPath is %v without a home directory; want %v", path, expected)
+	}
+}
+
+func TestApplyComponentMatrix(t *testing.T) {
+	spec := &kfdefs.KfDefSpec{}
+	spec.Components = []string{"argo", "pipeline", "spartakus"}
+	spec.ComponentParams = config.Parameters{
+		"spartakus": {{Name: "usageId", Value: "1"}},
+	}
+	spec.ComponentMatrix = []kfdefs.ComponentSpec{
+		{Name: "spartakus", Enabled: false},
+		{Name: "katib", Enabled: true, Params: []config.NameValue{{Name: "injectIstio", Value: "true"}}},
+	}
+	components := spec.Components
+	ApplyComponentMatrix(spec)
+	if want := []string{"argo", "pipeline", "katib"}; !reflect.DeepEqual(spec.Components, want) {
+		t.Errorf("components are %v; want %v", spec.Components, want)
+	}
+	if _, ok := spec.ComponentParams["spartakus"]; ok {
+		t.Errorf("the params of the disabled spartakus are kept")
+	}
+	if value, _ := spec.GetComponentParam("katib", "injectIstio"); value != "true" {
+		t.Errorf("injectIstio of katib is %q; want true", value)
+	}
+	if components[2] != "spartakus" {
+		t.Errorf("ApplyComponentMatrix modified the components it was given")
+	}
+}
+
+func TestApplyUsageReporting(t *testing.T) {
+	spec := &kfdefs.KfDefSpec{}
+	spec.Components = []string{"argo"}
+	spec.UsageReporting = &kfdefs.UsageReportingConfig{Enabled: true,
+		ReportEndpoint: "https://stats.example.com"}
+	ApplyUsageReporting(spec)
+	if want := []string{"argo", SPARTAKUS}; !reflect.DeepEqual(spec.Components, want) {
+		t.Errorf("components are %v; want %v", spec.Components, want)
+	}
+	usageId := spec.UsageReporting.UsageId
+	if value, _ := spec.GetComponentParam(SPARTAKUS, "usageId"); usageId == "" || value != usageId {
+		t.Errorf("usageId of spartakus is %q; want the usageId %q set in usageReporting", value, usageId)
+	}
+	if value, _ := spec.GetComponentParam(SPARTAKUS, "reportEndpoint"); value != "https://stats.example.com" {
+		t.Errorf("reportEndpoint of spartakus is %q", value)
+	}
+	ApplyUsageReporting(spec)
+	if spec.UsageReporting.UsageId != usageId || len(spec.Components) != 2 {
+		t.Errorf("applying usageReporting again changed usageId to %v and components to %v",
+			spec.UsageReporting.UsageId, spec.Components)
+	}
+
+	params := spec.ComponentParams
+	spec.UsageReporting = &kfdefs.UsageReportingConfig{Enabled: false}
+	ApplyUsageReporting(spec)
+	if want := []string{"argo"}; !reflect.DeepEqual(spec.Components, want) {
+		t.Errorf("components are %v; want %v", spec.Components, want)
+	}
+	if _, ok := spec.ComponentParams[SPARTAKUS]; ok {
+		t.Errorf("the params of the disabled spartakus are kept")
+	}
+	if _, ok := params[SPARTAKUS]; !ok {
+		t.Errorf("ApplyUsageReporting modified the params it was given")
+	}
+
+	spec = &kfdefs.KfDefSpec{Components: []string{SPARTAKUS}}
+	ApplyUsageReporting(spec)
+	if len(spec.Components) != 1 || spec.ComponentParams != nil {
+		t.Errorf("ApplyUsageReporting without usageReporting changed the spec to %+v", spec)
+	}
+}
+
+func TestValidateComponents(t *testing.T) {
+	cases := []struct {
+		name       string
+		components []string
+		matrix     []kfdefs.ComponentSpec
+		platform   string
+		mysqlPd    string
+		valid      bool
+	}{
+		{"all there", []string{"iap-ingress", "cert-manager", "pipeline"}, nil, GCP, "kf-storage-metadata-store", true},
+		{"cert-manager missing", []string{"iap-ingress"}, nil, GCP, "", false},
+		{"in-cluster metadata store", []string{"pipeline"}, nil, MINIKUBE, "", true},
+		{"disk off gcp", []string{"pipeline"}, nil, MINIKUBE, "kf-storage-metadata-store", false},
+		{"declared requirement missing", []string{"pipeline"},
+			[]kfdefs.ComponentSpec{{Name: "pipeline", Enabled: true, Requires: []string{"argo"}}}, GCP, "", false},
+		{"declared requirement there", []string{"pipeline", "argo"},
+			[]kfdefs.ComponentSpec{{Name: "pipeline", Enabled: true, Requires: []string{"argo"}}}, GCP, "", true},
+	}
+	for _, c := range cases {
+		spec := &kfdefs.KfDefSpec{}
+		spec.Components = c.components
+		spec.ComponentMatrix = c.matrix
+		spec.Platform = c.platform
+		if c.mysqlPd != "" {
+			spec.SetComponentParam("pipeline", "mysqlPd", c.mysqlPd, false)
+		}
+		err := ValidateComponents(spec)
+		if c.valid && err != nil {
+			t.Errorf("%v: ValidateComponents failed: %v", c.name, err)
+		}
+		if !c.valid && err == nil {
+			t.Errorf("%v: ValidateComponents succeeded", c.name)
+		}
+	}
+}
@@ -0,0 +1,38 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !windows
+// +build !windows
+
+package apps
+
+import (
+	"testing"
+)
+
+func TestHomeDirUnix(t *testing.T) {
+	defer setEnv(map[string]string{
+		"HOME":        "/home/alice",
+		"USERPROFILE": "C:\\Users\\alice",
+		"HOMEDRIVE":   "C:",
+		"HOMEPATH":    "\\alice",
+	})()
+	if home := HomeDir(); home != "/home/alice" {
+		t.Errorf("HomeDir is %v; want HOME", home)
+	}
+	defer setEnv(map[string]string{"HOME": ""})()
+	if home := HomeDir(); home != "" {
+		t.Errorf("HomeDir is %v without HOME; want none", home)
+	}
+}
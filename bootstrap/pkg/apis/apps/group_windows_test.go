@@ -0,0 +1,44 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apps
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestHomeDirWindows(t *testing.T) {
+	defer setEnv(map[string]string{
+		"KUBECONFIG":  "",
+		"HOME":        "/home/git-bash",
+		"USERPROFILE": "C:\\Users\\alice",
+		"HOMEDRIVE":   "D:",
+		"HOMEPATH":    "\\alice",
+	})()
+	if home := HomeDir(); home != "C:\\Users\\alice" {
+		t.Errorf("HomeDir is %v; want USERPROFILE", home)
+	}
+	if path := KubeConfigPath(); path != filepath.Join("C:\\Users\\alice", ".kube", "config") {
+		t.Errorf("KubeConfigPath is %v", path)
+	}
+	defer setEnv(map[string]string{"USERPROFILE": ""})()
+	if home := HomeDir(); home != "D:\\alice" {
+		t.Errorf("HomeDir is %v; want HOMEDRIVE and HOMEPATH", home)
+	}
+	defer setEnv(map[string]string{"HOMEDRIVE": ""})()
+	if home := HomeDir(); home != "/home/git-bash" {
+		t.Errorf("HomeDir is %v; want HOME", home)
+	}
+}
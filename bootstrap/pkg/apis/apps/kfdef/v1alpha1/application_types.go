@@ -18,6 +18,8 @@ import (
 	"github.com/kubeflow/kubeflow/bootstrap/config"
 	"k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sort"
+	"time"
 )
 
 // KfDefSpec holds common attributes used by each platform
@@ -36,6 +38,296 @@ type KfDefSpec struct {
 	UseIstio               bool   `json:"useIstio"`
 	ServerVersion          string `json:"serverVersion,omitempty"`
 	DeleteStorage          bool   `json:"deleteStorage,omitempty"`
+	// DeleteFilestore lets kfctl delete tear down the Filestore instance of spec.filestore; it's
+	// kept otherwise, like the storage deployment.
+	DeleteFilestore bool `json:"deleteFilestore,omitempty"`
+	// IpReserved is set by kfctl apply when it reserved spec.ipName itself, the cluster deployment
+	// not having created it. kfctl delete keeps such an address unless DeleteIp is set.
+	IpReserved bool `json:"ipReserved,omitempty"`
+	// DeleteIp lets kfctl delete release the static IP kfctl reserved, see IpReserved.
+	DeleteIp bool `json:"deleteIp,omitempty"`
+	// SnapshotStorage snapshots the metadata-store and artifact-store disks before kfctl delete
+	// deletes them with --delete_storage, and before kfctl upgrade replaces the node pools they're
+	// attached to; kfctl restore-storage recreates them from the snapshots.
+	SnapshotStorage bool `json:"snapshotStorage,omitempty"`
+	// StorageSnapshots record the snapshots taken of the storage disks, the latest last. They're
+	// maintained by kfctl delete.
+	StorageSnapshots []StorageSnapshot `json:"storageSnapshots,omitempty"`
+	// DeletionProtection must be unset before kfctl delete is allowed to run.
+	DeletionProtection bool `json:"deletionProtection,omitempty"`
+	// CombinedDeployment puts the storage, network, gcfs and cluster configs of gcp in a single
+	// DM deployment instead of one each, using less of the project's deployment quota. It only
+	// applies to new apps; existing deployments aren't migrated.
+	CombinedDeployment bool `json:"combinedDeployment,omitempty"`
+	// UseEmbeddedAssets takes the app configs, DM templates and Istio manifests from the ones
+	// built into kfctl instead of the kubeflow repo.
+	UseEmbeddedAssets bool `json:"useEmbeddedAssets,omitempty"`
+	// EnableStackdriver sends the logs and metrics of the cluster to Stackdriver, and the container
+	// logs of the Kubeflow namespaces to the kubeflow log of the project.
+	EnableStackdriver bool `json:"enableStackdriver,omitempty"`
+	// CloudAuditLog also writes the audit log of the changes kfctl makes, kept in audit.jsonl of
+	// the app dir, to the kfctl-audit log of the project.
+	CloudAuditLog bool `json:"cloudAuditLog,omitempty"`
+	// EnableTpu turns on Cloud TPU for the cluster and enables tpu.googleapis.com.
+	EnableTpu bool `json:"enableTpu,omitempty"`
+	// CaBundle is a PEM file of CAs trusted besides the system ones by the calls of kfctl to GCP,
+	// the cluster and the notification sinks, e.g. those of a corporate proxy. The proxy is set
+	// with HTTPS_PROXY, HTTP_PROXY and NO_PROXY.
+	CaBundle string `json:"caBundle,omitempty"`
+	// Mirror is a local checkout or tarball of the kubeflow repo used instead of downloading it
+	// from github.
+	Mirror string `json:"mirror,omitempty"`
+	// RepoSha is the commit version was resolved to when the kubeflow repo was downloaded, and
+	// RepoChecksum the sha256 of its tarball. Set by kfctl, they pin the repo the cache of the app
+	// dir is recreated with.
+	RepoSha      string `json:"repoSha,omitempty"`
+	RepoChecksum string `json:"repoChecksum,omitempty"`
+	// AppDirVersion is the layout version of the app dir, used to migrate older app dirs on load.
+	AppDirVersion int `json:"appDirVersion,omitempty"`
+	// Kubeconfig and KubeContext, when set, are used to reach the cluster instead of
+	// looking it up through the GKE API, e.g. where that API is blocked but kubectl works.
+	// The DM deployments and IAM policy are still applied through the cloud APIs; apply
+	// --target=istio,secrets,gpu-drivers leaves them out.
+	Kubeconfig  string `json:"kubeconfig,omitempty"`
+	KubeContext string `json:"kubeContext,omitempty"`
+	// ReportEndpoint is an opt-in URL that receives a deployment report after a successful apply.
+	ReportEndpoint string `json:"reportEndpoint,omitempty"`
+	// StateBucket is a GCS bucket holding the app state and a lock so several operators
+	// can work on the same deployment. StatePrefix defaults to kfctl.
+	StateBucket string `json:"stateBucket,omitempty"`
+	StatePrefix string `json:"statePrefix,omitempty"`
+	// DeploymentId is a UUID set on the first generate; GCP resources are labeled with it
+	// so delete finds them from any machine.
+	DeploymentId string `json:"deploymentId,omitempty"`
+	// ComponentParamOverrides holds per environment parameters set on top of componentParams,
+	// e.g. a dev and a prod hostname. Env selects the environment used by generate.
+	ComponentParamOverrides map[string]config.Parameters `json:"componentParamOverrides,omitempty"`
+	Env                     string                       `json:"env,omitempty"`
+	// ComponentMatrix enables or disables components on top of components and componentParams,
+	// e.g. {name: spartakus, enabled: false}. It's applied when app.yaml is read, and generate
+	// checks the enabled components have the ones they require.
+	ComponentMatrix []ComponentSpec `json:"componentMatrix,omitempty"`
+	// UsageReporting configures the anonymous usage reports of spartakus. When set it's applied
+	// like componentMatrix, adding or removing spartakus; when unset spartakus is deployed if it's
+	// in components, with a new usageId on each generate.
+	UsageReporting *UsageReportingConfig `json:"usageReporting,omitempty"`
+	// NodePoolServiceAccounts gives node pools their own service account instead of <name>-vm.
+	NodePoolServiceAccounts []NodePoolServiceAccount `json:"nodePoolServiceAccounts,omitempty"`
+	// NodePools record the size and machine type of the node pools changed by kfctl cluster, so
+	// the cluster config generated for them matches the cluster.
+	NodePools []NodePool `json:"nodePools,omitempty"`
+	// Region is the region generate picks the zone of when zone is auto, the region of the
+	// default zone when empty.
+	Region string `json:"region,omitempty"`
+	// Notifications receive an event when init, generate, apply or delete starts, succeeds or fails.
+	Notifications []Notification `json:"notifications,omitempty"`
+	// Hooks are commands or webhooks run before or after generate, apply of the platform or of
+	// k8s, and delete, e.g. to run policy checks or register the cluster in an inventory.
+	Hooks []Hook `json:"hooks,omitempty"`
+	// BcryptCost is the cost of the basic auth password hash; bcrypt's default cost is used when 0.
+	BcryptCost int `json:"bcryptCost,omitempty"`
+	// IapMembers are granted access through IAP besides the email the app was created with,
+	// e.g. group:ml-team@example.com. kfctl apply sets them on the IAP backend service.
+	IapMembers []string `json:"iapMembers,omitempty"`
+	// AdminRole is the ClusterRole the email is bound to: cluster-admin when empty, kubeflow-admin,
+	// created by kfctl with only what Kubeflow needs, or none.
+	AdminRole string `json:"adminRole,omitempty"`
+	// AdminMembers are administrators besides the email: bound to adminRole, and granted the
+	// IAM and IAP roles of the email. They're user:, group: or serviceAccount: members, or emails.
+	AdminMembers []string `json:"adminMembers,omitempty"`
+	// Tenants are teams sharing the deployment, each getting its own namespace in which its
+	// members are bound to namespace roles instead of cluster-admin.
+	Tenants []Tenant `json:"tenants,omitempty"`
+	// ServiceAccountKeys are the names of the service account keys created by kfctl for the
+	// secrets of the app; --rotate-sa-keys only deletes those.
+	ServiceAccountKeys []string `json:"serviceAccountKeys,omitempty"`
+	// DisableServiceAccountKeys leaves out the admin and user service account key secrets, the
+	// pods reaching GCP through workload identity instead, which it needs. Generate sets it when
+	// the org policy iam.disableServiceAccountKeyCreation is enforced on the project.
+	DisableServiceAccountKeys bool `json:"disableServiceAccountKeys,omitempty"`
+	// ExtraApis are enabled by kfctl init besides the APIs Kubeflow needs, e.g. tpu.googleapis.com.
+	ExtraApis []string `json:"extraApis,omitempty"`
+	// SkipApis are left out of the APIs enabled by kfctl init, e.g. those an admin enables.
+	SkipApis []string `json:"skipApis,omitempty"`
+	// Certificate selects how the ingress gets its TLS certificate; cert-manager is used when unset.
+	Certificate *Certificate `json:"certificate,omitempty"`
+	// ManifestsTool generates the manifests of the components: ksonnet, when unset, or kustomize,
+	// which builds the overlays of kubeflow/manifests selected by the platform, auth provider and
+	// useIstio, with the componentParams set in their parameters ConfigMap.
+	ManifestsTool string `json:"manifestsTool,omitempty"`
+	// NamespacePrefix is prepended to the namespaces of the app, e.g. mlplatform for
+	// mlplatform-kubeflow and mlplatform-istio-system, so several apps can share a cluster. The
+	// cluster-scoped objects kfctl creates, e.g. the admin binding, are prefixed too.
+	NamespacePrefix string `json:"namespacePrefix,omitempty"`
+	// TemplateOverrides record the DM templates overridden in <appDir>/overrides with the hash of
+	// the upstream template each override was made from, so generate can warn when it changes.
+	// They're maintained by generate.
+	TemplateOverrides []TemplateOverride `json:"templateOverrides,omitempty"`
+	// TemplateSha256 is the hex SHA-256 of each DM template of the repo the configs were last
+	// generated from, by file, so generate tells which changed since. It's maintained by generate.
+	TemplateSha256 map[string]string `json:"templateSha256,omitempty"`
+	// Gke holds the options of the GKE cluster rendered into cluster-kubeflow.yaml by generate.
+	Gke *GkeConfig `json:"gke,omitempty"`
+	// HostProject is the shared VPC host project the network of the cluster is in. The cluster,
+	// its service accounts and the DM deployments stay in project, the service project.
+	HostProject string `json:"hostProject,omitempty"`
+	// SharedVpc is the subnetwork of HostProject the cluster is created in.
+	SharedVpc *SharedVpcConfig `json:"sharedVpc,omitempty"`
+	// DeploymentManagerSA is the email of the service account DM creates the resources of the
+	// deployments as, instead of the Google APIs service account of the project. Apply grants it
+	// the roles it needs in the project.
+	DeploymentManagerSA string `json:"deploymentManagerSA,omitempty"`
+	// SecretsSync installs the secrets-sync controller generate writes in k8s_specs, which copies
+	// user-gcp-sa into the namespaces labeled kubeflow-profile as they're created and keeps the
+	// copies fresh, so the users created between applies get GCP credentials too.
+	SecretsSync bool `json:"secretsSync,omitempty"`
+	// ServicePerimeter is the VPC Service Controls perimeter the project is in. The cluster then
+	// reaches the Google APIs through restricted.googleapis.com.
+	ServicePerimeter *ServicePerimeterConfig `json:"servicePerimeter,omitempty"`
+	// Metadata is the database pipelines and metadata keep their records in.
+	Metadata *MetadataConfig `json:"metadata,omitempty"`
+	// BinaryAuthorization enables GKE Binary Authorization on the cluster, generate writing a
+	// starter policy admitting the images of Kubeflow.
+	BinaryAuthorization *BinaryAuthorizationConfig `json:"binaryAuthorization,omitempty"`
+	// Gpu creates the gpu-pool node pool of the cluster and installs the NVIDIA drivers on it.
+	Gpu *GpuConfig `json:"gpu,omitempty"`
+	// Filestore creates a Filestore (GCFS) instance with the app, mounted in the cluster by a
+	// PersistentVolume and StorageClass.
+	Filestore *FilestoreConfig `json:"filestore,omitempty"`
+	// Istio selects the mTLS profile of the Istio installed with useIstio and sizes its gateways.
+	Istio *IstioConfig `json:"istio,omitempty"`
+	// Auth selects how users sign in; IAP, or basic auth with useBasicAuth, when unset.
+	Auth *AuthConfig `json:"auth,omitempty"`
+	// PasswordFile is where kfctl apply reads the basic auth password from, "-" for stdin.
+	// It's only set from the command line and never written to app.yaml.
+	PasswordFile string `json:"-"`
+	// OAuthClientFile is the credentials file of the OAuth client of IAP kfctl apply reads
+	// before spec.auth.oauthClient. It's only set from the command line.
+	OAuthClientFile string `json:"-"`
+	// RotateSaKeys lets kfctl apply delete the oldest key in ServiceAccountKeys of a service
+	// account having too many keys to create the one of its secret. Only set from the command line.
+	RotateSaKeys bool `json:"-"`
+	// DmRecovery is how kfctl apply recovers a DM deployment whose last operation failed or is
+	// stuck: stop, abandon or recreate. Only set from the command line.
+	DmRecovery string `json:"-"`
+	// Timeout bounds kfctl apply, 0 for no limit. Only set from the command line.
+	Timeout time.Duration `json:"-"`
+	// Targets limit kfctl apply and delete to pieces of the app, e.g. storage or iam. Only set
+	// from the command line.
+	Targets []string `json:"-"`
+	// PreserveData has kfctl delete keep the namespace of the app and its persistent volume
+	// claims, deleting the objects of the components only. Only set from the command line.
+	PreserveData bool `json:"-"`
+}
+
+// GetComponentParams returns the componentParams with the overrides of env applied.
+func (spec *KfDefSpec) GetComponentParams() config.Parameters {
+	if spec.Env == "" {
+		return spec.ComponentParams
+	}
+	return spec.ComponentParams.Merge(spec.ComponentParamOverrides[spec.Env])
+}
+
+// SortedComponentParams returns componentParams with the params of each component sorted by
+// name, which is how app.yaml is written so it doesn't change with the order they were set in.
+// The params of the spec aren't modified.
+func (spec *KfDefSpec) SortedComponentParams() config.Parameters {
+	if spec.ComponentParams == nil {
+		return nil
+	}
+	params := make(config.Parameters, len(spec.ComponentParams))
+	for component, namevals := range spec.ComponentParams {
+		sorted := append([]config.NameValue{}, namevals...)
+		sort.SliceStable(sorted, func(i, j int) bool {
+			return sorted[i].Name < sorted[j].Name
+		})
+		params[component] = sorted
+	}
+	return params
+}
+
+// GetComponentParam returns the value of the parameter name of component in componentParams.
+func (spec *KfDefSpec) GetComponentParam(component string, name string) (string, bool) {
+	for _, nv := range spec.ComponentParams[component] {
+		if nv.Name == name {
+			return nv.Value, true
+		}
+	}
+	return "", false
+}
+
+// SetComponentParam sets the parameter name of component in componentParams, appending it
+// when it's missing. The parameters are copied on write: the map and slices already handed
+// out, e.g. by GetComponentParams or a KfDef shared with an informer cache, are never modified
+// so readers holding them don't race with the change. Concurrent writers must still be
+// serialized by the caller.
+func (spec *KfDefSpec) SetComponentParam(component string, name string, value string, required bool) {
+	params := make(config.Parameters, len(spec.ComponentParams)+1)
+	for c, namevals := range spec.ComponentParams {
+		params[c] = namevals
+	}
+	namevals := make([]config.NameValue, 0, len(params[component])+1)
+	found := false
+	for _, nv := range params[component] {
+		if nv.Name == name {
+			nv.Value = value
+			found = true
+		}
+		namevals = append(namevals, nv)
+	}
+	if !found {
+		namevals = append(namevals, config.NameValue{
+			Name:         name,
+			Value:        value,
+			InitRequired: required,
+		})
+	}
+	params[component] = namevals
+	spec.ComponentParams = params
+}
+
+// ComponentSpec enables or disables a component of the app.
+type ComponentSpec struct {
+	Name string `json:"name"`
+	// Enabled adds the component to components with its params; when false it's removed from
+	// them with its params.
+	Enabled bool `json:"enabled"`
+	// Params are set in componentParams of the component when it's enabled.
+	Params []config.NameValue `json:"params,omitempty"`
+	// Requires are the components it needs besides those kfctl knows it does, e.g. argo for
+	// pipeline.
+	Requires []string `json:"requires,omitempty"`
+}
+
+// UsageReportingConfig enables or disables the anonymous usage reports of spartakus.
+type UsageReportingConfig struct {
+	// Enabled adds spartakus to components with its params; when false it's removed from them
+	// with its params.
+	Enabled bool `json:"enabled"`
+	// UsageId identifies the deployment in the reports. A random one is set, and kept in
+	// app.yaml, when it's empty.
+	UsageId string `json:"usageId,omitempty"`
+	// ReportEndpoint is the database the reports are sent to; the one of kubeflow.org when empty.
+	ReportEndpoint string `json:"reportEndpoint,omitempty"`
+}
+
+// NodePoolServiceAccount declares a dedicated GCP service account, <name>-<pool>, for a GKE node pool.
+type NodePoolServiceAccount struct {
+	// Pool is the node pool using the service account: cpu-pool or gpu-pool.
+	Pool string `json:"pool"`
+	// Roles granted to the service account. The roles of <name>-vm are used when empty.
+	Roles []string `json:"roles,omitempty"`
+}
+
+// Tenant is a team of a shared deployment. Its members are IAM style members, e.g.
+// user:alice@example.com or group:ml-team@example.com; a plain email is a user.
+type Tenant struct {
+	// Namespace is created for the team, labeled so the user service account secret is copied in.
+	Namespace string `json:"namespace"`
+	// Admins are bound to the admin ClusterRole in the namespace.
+	Admins []string `json:"admins,omitempty"`
+	// Members are bound to the edit ClusterRole in the namespace.
+	Members []string `json:"members,omitempty"`
 }
 
 var DefaultRegistry = &RegistryConfig{
@@ -128,13 +420,305 @@ type AppConfig struct {
 	ApplyParameters []KsParameter `json:"applyParameters,omitempty"`
 }
 
+// NodePool is the size and machine type a node pool was changed to.
+type NodePool struct {
+	// Pool is the node pool: cpu-pool or gpu-pool.
+	Pool        string `json:"pool"`
+	Nodes       int    `json:"nodes"`
+	MachineType string `json:"machineType"`
+	// PoolVersion is the version suffix of the pool's name, e.g. v2, after kfctl recreated it.
+	// It's the pool-version of the cluster config when unset.
+	PoolVersion string `json:"poolVersion,omitempty"`
+}
+
+// StorageSnapshot is a snapshot of a storage disk of the app.
+type StorageSnapshot struct {
+	// Disk is the name of the disk, e.g. <name>-storage-metadata-store.
+	Disk string `json:"disk"`
+	// Snapshot is the name of the snapshot in the project.
+	Snapshot string `json:"snapshot"`
+	// CreationTime is when the snapshot was taken, in RFC 3339.
+	CreationTime string `json:"creationTime"`
+}
+
+// TemplateOverride is a DM template of the app dir's overrides directory used instead of the repo's.
+type TemplateOverride struct {
+	// File is the name of the template, e.g. cluster.jinja.
+	File string `json:"file"`
+	// UpstreamSha256 is the hex SHA-256 of the repo's template when the override was first used.
+	UpstreamSha256 string `json:"upstreamSha256"`
+}
+
+// GkeConfig holds cluster level options of GKE; those left empty keep the GKE defaults.
+type GkeConfig struct {
+	// ReleaseChannel enrolls the cluster in the RAPID, REGULAR or STABLE release channel.
+	ReleaseChannel string `json:"releaseChannel,omitempty"`
+	// MaintenanceStartTime is the start, HH:MM in GMT, of the daily 4 hour maintenance window.
+	MaintenanceStartTime string `json:"maintenanceStartTime,omitempty"`
+	// AutoscalingProfile is the cluster autoscaler profile, BALANCED or OPTIMIZE_UTILIZATION.
+	AutoscalingProfile string `json:"autoscalingProfile,omitempty"`
+	// VerticalPodAutoscaling enables the vertical pod autoscaler.
+	VerticalPodAutoscaling bool `json:"verticalPodAutoscaling,omitempty"`
+	// ApiVersion is the container API the cluster is deployed with, v1 or v1beta1; v1beta1 when
+	// empty. Beta features need v1beta1.
+	ApiVersion string `json:"apiVersion,omitempty"`
+	// FeatureGates turn GKE features on: workloadIdentity, networkPolicy and sandbox.
+	FeatureGates map[string]bool `json:"featureGates,omitempty"`
+	// MasterVersion and NodeVersion are the GKE versions of the master and the node pools set by
+	// kfctl cluster upgrade. MasterVersion is the version a new cluster is created with, and the
+	// node pools are rendered at NodeVersion.
+	MasterVersion string `json:"masterVersion,omitempty"`
+	NodeVersion   string `json:"nodeVersion,omitempty"`
+	// InitialVersion is the version the existing cluster was created with, recorded by kfctl
+	// cluster upgrade. It's rendered instead of MasterVersion since the initial version of a
+	// cluster can't change; delete clears it with the cluster.
+	InitialVersion string `json:"initialVersion,omitempty"`
+	// PrivateCluster gives the nodes internal IPs only. It needs apiVersion v1beta1. Generate sets
+	// it when the org policy compute.vmExternalIpAccess denies the nodes external IPs.
+	PrivateCluster bool `json:"privateCluster,omitempty"`
+	// Autoprovisioning configures node auto-provisioning, which creates node pools for the pods
+	// no pool fits, e.g. of training jobs; the limits of cluster-kubeflow.yaml are kept when unset.
+	// It needs apiVersion v1beta1.
+	Autoprovisioning *AutoprovisioningConfig `json:"autoprovisioning,omitempty"`
+}
+
+// SharedVpcConfig names the subnetwork of a shared VPC host project a cluster is created in,
+// with the secondary ranges of its pods and services.
+type SharedVpcConfig struct {
+	Network    string `json:"network"`
+	Subnetwork string `json:"subnetwork"`
+	// PodsRange and ServicesRange are the names of secondary ranges of the subnetwork.
+	PodsRange     string `json:"podsRange"`
+	ServicesRange string `json:"servicesRange"`
+}
+
+// ServicePerimeterConfig names a VPC Service Controls perimeter.
+type ServicePerimeterConfig struct {
+	// AccessPolicy is the access policy of the organization the perimeter is in, its number or
+	// accessPolicies/<number>.
+	AccessPolicy string `json:"accessPolicy"`
+	// Perimeter is the short name of the perimeter.
+	Perimeter string `json:"perimeter"`
+	// AddProject has apply add the project to the perimeter when it isn't in it; otherwise apply
+	// fails until it's added.
+	AddProject bool `json:"addProject,omitempty"`
+}
+
+// BinaryAuthorizationConfig tunes the Binary Authorization policy generate writes to
+// binauthz-policy.yaml of gcp_config.
+type BinaryAuthorizationConfig struct {
+	// AdmitPatterns are image name patterns admitted besides those of the registries of Kubeflow,
+	// GKE and the project, e.g. us.gcr.io/my-team/*.
+	AdmitPatterns []string `json:"admitPatterns,omitempty"`
+	// Attestors, projects/<project>/attestors/<name>, must have attested the other images; those
+	// are denied when there are none.
+	Attestors []string `json:"attestors,omitempty"`
+	// DryRun only writes the images the policy would deny to the audit log.
+	DryRun bool `json:"dryRun,omitempty"`
+	// ApplyPolicy has apply set the policy of the project to binauthz-policy.yaml; otherwise it's
+	// left to be reviewed and imported with gcloud container binauthz policy import.
+	ApplyPolicy bool `json:"applyPolicy,omitempty"`
+}
+
+// MetadataConfig picks the database of the metadata store.
+type MetadataConfig struct {
+	// Db is the database: cloudsql-postgres provisions a Cloud SQL Postgres instance with the
+	// storage deployment and points pipelines and metadata at it. Empty keeps the in-cluster mysql.
+	Db string `json:"db,omitempty"`
+	// Tier is the machine type of the Cloud SQL instance, db-custom-1-3840 by default.
+	Tier string `json:"tier,omitempty"`
+}
+
+// AutoprovisioningConfig bounds the resources of the cluster node auto-provisioning scales to.
+type AutoprovisioningConfig struct {
+	// Enabled turns node auto-provisioning on.
+	Enabled bool `json:"enabled,omitempty"`
+	// MinCpu and MaxCpu bound the cores of the cluster, MinMemory and MaxMemory its memory in GB.
+	MinCpu    int64 `json:"minCpu,omitempty"`
+	MaxCpu    int64 `json:"maxCpu,omitempty"`
+	MinMemory int64 `json:"minMemory,omitempty"`
+	MaxMemory int64 `json:"maxMemory,omitempty"`
+	// Accelerators bound the GPUs of each type; none can be provisioned without them.
+	Accelerators []AutoprovisioningAccelerator `json:"accelerators,omitempty"`
+	// ServiceAccount is the email of the service account of the node pools created, the default
+	// compute service account when empty.
+	ServiceAccount string `json:"serviceAccount,omitempty"`
+	// OauthScopes are the scopes of the node pools created, the default GKE scopes when empty.
+	OauthScopes []string `json:"oauthScopes,omitempty"`
+}
+
+// AutoprovisioningAccelerator bounds the accelerators of a type in the cluster.
+type AutoprovisioningAccelerator struct {
+	// Type is the accelerator, e.g. nvidia-tesla-k80.
+	Type string `json:"type"`
+	Min  int64  `json:"min,omitempty"`
+	Max  int64  `json:"max"`
+}
+
+// GpuConfig sizes the gpu-pool node pool; the pool isn't created without it.
+type GpuConfig struct {
+	// Type is the accelerator attached to the nodes, nvidia-tesla-k80 when empty.
+	Type string `json:"type,omitempty"`
+	// Count is the number of accelerators per node, 1 when 0.
+	Count int `json:"count,omitempty"`
+	// MaxNodes is the most nodes the pool is autoscaled to, 1 when 0.
+	MaxNodes int `json:"maxNodes,omitempty"`
+}
+
+// FilestoreConfig sizes the Filestore instance of the app, rendered into gcfs.yaml by generate.
+type FilestoreConfig struct {
+	// Tier is STANDARD, the default, or PREMIUM.
+	Tier string `json:"tier,omitempty"`
+	// CapacityGb is the size of the kubeflow file share, the minimum of the tier when 0: 1024 for
+	// STANDARD and 2560 for PREMIUM.
+	CapacityGb int `json:"capacityGb,omitempty"`
+	// Network is the VPC network the instance is reachable from, default when empty. It must be
+	// the network of the cluster.
+	Network string `json:"network,omitempty"`
+}
+
+// IstioConfig configures the Istio installed with useIstio.
+type IstioConfig struct {
+	// Profile is the mTLS profile of the mesh: noauth, the default, mtls-permissive or
+	// mtls-strict. mtls-strict isn't supported with IAP.
+	Profile string `json:"profile,omitempty"`
+	// IngressGateway and EgressGateway size the gateways; unset, they keep the sizes of the
+	// Istio manifest.
+	IngressGateway *IstioGatewayConfig `json:"ingressGateway,omitempty"`
+	EgressGateway  *IstioGatewayConfig `json:"egressGateway,omitempty"`
+}
+
+// IstioGatewayConfig sizes an Istio gateway, autoscaled on its CPU usage.
+type IstioGatewayConfig struct {
+	// MinReplicas and MaxReplicas bound the autoscaling of the gateway, 1 and 5 when 0.
+	MinReplicas int32 `json:"minReplicas,omitempty"`
+	MaxReplicas int32 `json:"maxReplicas,omitempty"`
+	// Cpu and Memory are the resource requests of the gateway, e.g. 500m and 256Mi. The
+	// autoscaler adds replicas above 80% of the CPU request.
+	Cpu    string `json:"cpu,omitempty"`
+	Memory string `json:"memory,omitempty"`
+}
+
+// AuthConfig selects the authentication provider of the app.
+type AuthConfig struct {
+	// Provider is iap, basic-auth, oidc (Dex federating an OpenID Connect provider) or ldap
+	// (Dex checking the users of an LDAP directory); iap, or basic-auth with useBasicAuth, when empty.
+	Provider string `json:"provider,omitempty"`
+	// Oidc is the provider Dex federates for oidc.
+	Oidc *OidcConfig `json:"oidc,omitempty"`
+	// Ldap is the directory Dex checks the users against for ldap.
+	Ldap *LdapConfig `json:"ldap,omitempty"`
+	// OAuthClient is where kfctl apply reads the OAuth client of iap from, instead of
+	// CLIENT_ID and CLIENT_SECRET.
+	OAuthClient *OAuthClientConfig `json:"oauthClient,omitempty"`
+}
+
+// OAuthClientConfig references the OAuth client of IAP so it needn't be in the environment of
+// kfctl apply. --oauth-client-file is read first, then CredentialsFile, then the Secret Manager
+// secrets, then CLIENT_ID and CLIENT_SECRET.
+type OAuthClientConfig struct {
+	// CredentialsFile is the client_secret JSON file of the client downloaded from the Cloud
+	// Console, or a JSON file with its client_id and client_secret.
+	CredentialsFile string `json:"credentialsFile,omitempty"`
+	// ClientIdSecret and ClientSecretSecret are the Secret Manager secret versions holding the id
+	// and the secret of the client, e.g. projects/my-project/secrets/iap-client-secret/versions/1.
+	// The latest version is read when it's omitted, and a bare secret name is one of spec.project.
+	ClientIdSecret     string `json:"clientIdSecret,omitempty"`
+	ClientSecretSecret string `json:"clientSecretSecret,omitempty"`
+}
+
+// OidcConfig is an OpenID Connect provider, e.g. Okta or Azure AD. kfctl apply reads the secret
+// of the client from OIDC_CLIENT_SECRET.
+type OidcConfig struct {
+	// Issuer is the URL of the provider, e.g. https://accounts.google.com.
+	Issuer string `json:"issuer"`
+	// ClientId is the OAuth client registered with the provider for https://<hostname>/dex/callback.
+	ClientId string `json:"clientId"`
+	// Scopes are requested besides openid; profile and email when empty.
+	Scopes []string `json:"scopes,omitempty"`
+}
+
+// LdapConfig is an LDAP directory. kfctl apply reads the password of BindDn from LDAP_BIND_PASSWORD.
+type LdapConfig struct {
+	// Host is the host:port of the server, e.g. ldap.example.com:636.
+	Host string `json:"host"`
+	// InsecureNoSsl connects to the server without TLS.
+	InsecureNoSsl bool `json:"insecureNoSsl,omitempty"`
+	// BindDn searches the directory; the searches are anonymous when empty.
+	BindDn string `json:"bindDn,omitempty"`
+	// UserBaseDn is where the users are searched, e.g. ou=People,dc=example,dc=com.
+	UserBaseDn string `json:"userBaseDn"`
+	// UserFilter restricts the users who can sign in, e.g. (objectClass=person).
+	UserFilter string `json:"userFilter,omitempty"`
+	// UsernameAttr is matched with the username entered, uid when empty.
+	UsernameAttr string `json:"usernameAttr,omitempty"`
+	// EmailAttr is the email of the users, mail when empty.
+	EmailAttr string `json:"emailAttr,omitempty"`
+	// GroupBaseDn, when set, is where the groups of the users are searched, e.g. ou=Groups,dc=example,dc=com,
+	// matching the member attribute of the groups with the DN of the users.
+	GroupBaseDn string `json:"groupBaseDn,omitempty"`
+}
+
+// Certificate configures the TLS certificate of the ingress.
+type Certificate struct {
+	// Type is acme (cert-manager with Let's Encrypt, the default), managed-cert (a Google-managed
+	// certificate), self-signed (created by kfctl) or byo-secret (from CertFile and KeyFile).
+	Type string `json:"type,omitempty"`
+	// CertFile and KeyFile are the PEM encoded certificate chain and key of byo-secret;
+	// the certificate must be valid for spec.hostname.
+	CertFile string `json:"certFile,omitempty"`
+	KeyFile  string `json:"keyFile,omitempty"`
+}
+
+// Notification is a sink for deployment lifecycle events.
+type Notification struct {
+	// Type is one of slack, http or pubsub.
+	Type string `json:"type"`
+	// Url is the Slack incoming webhook or the HTTP endpoint events are POSTed to.
+	Url string `json:"url,omitempty"`
+	// Topic is the Pub/Sub topic events are published to, projects/<project>/topics/<topic>.
+	Topic string `json:"topic,omitempty"`
+}
+
+// Hook is a command or webhook kfctl runs before or after a step. It's passed the context of
+// the step as JSON: on stdin of the command, as the body POSTed to the webhook.
+type Hook struct {
+	// Name identifies the hook in logs and errors.
+	Name string `json:"name"`
+	// Step is generate, apply-platform, apply-k8s or delete.
+	Step string `json:"step"`
+	// When is pre or post. A failing pre hook aborts the step; post hooks run once it succeeded.
+	When string `json:"when"`
+	// Command is the program to run and its arguments, run in the app dir.
+	Command []string `json:"command,omitempty"`
+	// Url is the endpoint the context is POSTed to; a status other than 2xx fails the hook.
+	Url string `json:"url,omitempty"`
+	// TimeoutSeconds bounds the hook, 300 when 0.
+	TimeoutSeconds int `json:"timeoutSeconds,omitempty"`
+	// IgnoreFailure logs a failure of the hook instead of failing the step.
+	IgnoreFailure bool `json:"ignoreFailure,omitempty"`
+}
+
 // KfDefStatus defines the observed state of KfDef
 type KfDefStatus struct {
 	Conditions []KfDefCondition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type" protobuf:"bytes,6,rep,name=conditions"`
+	// ObservedGeneration is the generation of the KfDef the operator last reconciled.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
 }
 
 type KfDefConditionType string
 
+// Conditions set by the KfDef operator after each reconcile.
+const (
+	// KfAvailable is True when the last Generate and Apply succeeded.
+	KfAvailable KfDefConditionType = "Available"
+	// KfDegraded is True when the last reconcile failed; its message holds the error.
+	KfDegraded KfDefConditionType = "Degraded"
+	// KfProgressing is True while apply creates the objects of a manifest; its message counts
+	// those applied so far, e.g. "istio-crds.yaml: 12/57 objects applied".
+	KfProgressing KfDefConditionType = "Progressing"
+)
+
 type KfDefCondition struct {
 	// Type of deployment condition.
 	Type KfDefConditionType `json:"type" protobuf:"bytes,1,opt,name=type,casttype=KfDefConditionType"`
@@ -150,6 +734,35 @@ type KfDefCondition struct {
 	Message string `json:"message,omitempty" protobuf:"bytes,5,opt,name=message"`
 }
 
+// SetCondition updates the condition of type conditionType, keeping its LastTransitionTime
+// unless its status changed.
+func (status *KfDefStatus) SetCondition(conditionType KfDefConditionType,
+	conditionStatus v1.ConditionStatus, reason string, message string) {
+	now := metav1.Now()
+	for i := range status.Conditions {
+		condition := &status.Conditions[i]
+		if condition.Type != conditionType {
+			continue
+		}
+		if condition.Status != conditionStatus {
+			condition.LastTransitionTime = now
+		}
+		condition.Status = conditionStatus
+		condition.LastUpdateTime = now
+		condition.Reason = reason
+		condition.Message = message
+		return
+	}
+	status.Conditions = append(status.Conditions, KfDefCondition{
+		Type:               conditionType,
+		Status:             conditionStatus,
+		LastUpdateTime:     now,
+		LastTransitionTime: now,
+		Reason:             reason,
+		Message:            message,
+	})
+}
+
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
 // KfDef is the Schema for the applications API
@@ -19,9 +19,68 @@
 package v1alpha1
 
 import (
+	config "github.com/kubeflow/kubeflow/bootstrap/config"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoprovisioningAccelerator) DeepCopyInto(out *AutoprovisioningAccelerator) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoprovisioningAccelerator.
+func (in *AutoprovisioningAccelerator) DeepCopy() *AutoprovisioningAccelerator {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoprovisioningAccelerator)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoprovisioningConfig) DeepCopyInto(out *AutoprovisioningConfig) {
+	*out = *in
+	if in.Accelerators != nil {
+		in, out := &in.Accelerators, &out.Accelerators
+		*out = make([]AutoprovisioningAccelerator, len(*in))
+		copy(*out, *in)
+	}
+	if in.OauthScopes != nil {
+		in, out := &in.OauthScopes, &out.OauthScopes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoprovisioningConfig.
+func (in *AutoprovisioningConfig) DeepCopy() *AutoprovisioningConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoprovisioningConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Certificate) DeepCopyInto(out *Certificate) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Certificate.
+func (in *Certificate) DeepCopy() *Certificate {
+	if in == nil {
+		return nil
+	}
+	out := new(Certificate)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AppConfig) DeepCopyInto(out *AppConfig) {
 	*out = *in
@@ -69,6 +128,108 @@ func (in *AppConfig) DeepCopy() *AppConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GkeConfig) DeepCopyInto(out *GkeConfig) {
+	*out = *in
+	if in.FeatureGates != nil {
+		in, out := &in.FeatureGates, &out.FeatureGates
+		*out = make(map[string]bool, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Autoprovisioning != nil {
+		in, out := &in.Autoprovisioning, &out.Autoprovisioning
+		*out = new(AutoprovisioningConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GkeConfig.
+func (in *GkeConfig) DeepCopy() *GkeConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(GkeConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GpuConfig) DeepCopyInto(out *GpuConfig) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GpuConfig.
+func (in *GpuConfig) DeepCopy() *GpuConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(GpuConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FilestoreConfig) DeepCopyInto(out *FilestoreConfig) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FilestoreConfig.
+func (in *FilestoreConfig) DeepCopy() *FilestoreConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(FilestoreConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IstioConfig) DeepCopyInto(out *IstioConfig) {
+	*out = *in
+	if in.IngressGateway != nil {
+		in, out := &in.IngressGateway, &out.IngressGateway
+		*out = new(IstioGatewayConfig)
+		**out = **in
+	}
+	if in.EgressGateway != nil {
+		in, out := &in.EgressGateway, &out.EgressGateway
+		*out = new(IstioGatewayConfig)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IstioConfig.
+func (in *IstioConfig) DeepCopy() *IstioConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(IstioConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IstioGatewayConfig) DeepCopyInto(out *IstioGatewayConfig) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IstioGatewayConfig.
+func (in *IstioGatewayConfig) DeepCopy() *IstioGatewayConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(IstioGatewayConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KfDef) DeepCopyInto(out *KfDef) {
 	*out = *in
@@ -152,6 +313,171 @@ func (in *KfDefList) DeepCopyObject() runtime.Object {
 func (in *KfDefSpec) DeepCopyInto(out *KfDefSpec) {
 	*out = *in
 	in.ComponentConfig.DeepCopyInto(&out.ComponentConfig)
+	if in.NodePoolServiceAccounts != nil {
+		in, out := &in.NodePoolServiceAccounts, &out.NodePoolServiceAccounts
+		*out = make([]NodePoolServiceAccount, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.NodePools != nil {
+		in, out := &in.NodePools, &out.NodePools
+		*out = make([]NodePool, len(*in))
+		copy(*out, *in)
+	}
+	if in.Notifications != nil {
+		in, out := &in.Notifications, &out.Notifications
+		*out = make([]Notification, len(*in))
+		copy(*out, *in)
+	}
+	if in.Hooks != nil {
+		in, out := &in.Hooks, &out.Hooks
+		*out = make([]Hook, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.IapMembers != nil {
+		in, out := &in.IapMembers, &out.IapMembers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AdminMembers != nil {
+		in, out := &in.AdminMembers, &out.AdminMembers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Tenants != nil {
+		in, out := &in.Tenants, &out.Tenants
+		*out = make([]Tenant, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ServiceAccountKeys != nil {
+		in, out := &in.ServiceAccountKeys, &out.ServiceAccountKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExtraApis != nil {
+		in, out := &in.ExtraApis, &out.ExtraApis
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SkipApis != nil {
+		in, out := &in.SkipApis, &out.SkipApis
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Certificate != nil {
+		in, out := &in.Certificate, &out.Certificate
+		*out = new(Certificate)
+		**out = **in
+	}
+	if in.StorageSnapshots != nil {
+		in, out := &in.StorageSnapshots, &out.StorageSnapshots
+		*out = make([]StorageSnapshot, len(*in))
+		copy(*out, *in)
+	}
+	if in.TemplateOverrides != nil {
+		in, out := &in.TemplateOverrides, &out.TemplateOverrides
+		*out = make([]TemplateOverride, len(*in))
+		copy(*out, *in)
+	}
+	if in.TemplateSha256 != nil {
+		in, out := &in.TemplateSha256, &out.TemplateSha256
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Gke != nil {
+		in, out := &in.Gke, &out.Gke
+		*out = new(GkeConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SharedVpc != nil {
+		in, out := &in.SharedVpc, &out.SharedVpc
+		*out = new(SharedVpcConfig)
+		**out = **in
+	}
+	if in.ServicePerimeter != nil {
+		in, out := &in.ServicePerimeter, &out.ServicePerimeter
+		*out = new(ServicePerimeterConfig)
+		**out = **in
+	}
+	if in.Metadata != nil {
+		in, out := &in.Metadata, &out.Metadata
+		*out = new(MetadataConfig)
+		**out = **in
+	}
+	if in.BinaryAuthorization != nil {
+		in, out := &in.BinaryAuthorization, &out.BinaryAuthorization
+		*out = new(BinaryAuthorizationConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Gpu != nil {
+		in, out := &in.Gpu, &out.Gpu
+		*out = new(GpuConfig)
+		**out = **in
+	}
+	if in.Filestore != nil {
+		in, out := &in.Filestore, &out.Filestore
+		*out = new(FilestoreConfig)
+		**out = **in
+	}
+	if in.Istio != nil {
+		in, out := &in.Istio, &out.Istio
+		*out = new(IstioConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Auth != nil {
+		in, out := &in.Auth, &out.Auth
+		*out = new(AuthConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Targets != nil {
+		in, out := &in.Targets, &out.Targets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ComponentMatrix != nil {
+		in, out := &in.ComponentMatrix, &out.ComponentMatrix
+		*out = make([]ComponentSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.UsageReporting != nil {
+		in, out := &in.UsageReporting, &out.UsageReporting
+		*out = new(UsageReportingConfig)
+		**out = **in
+	}
+	if in.ComponentParamOverrides != nil {
+		in, out := &in.ComponentParamOverrides, &out.ComponentParamOverrides
+		*out = make(map[string]config.Parameters, len(*in))
+		for key, val := range *in {
+			var outVal map[string][]config.NameValue
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = make(config.Parameters, len(*in))
+				for key, val := range *in {
+					var outVal []config.NameValue
+					if val == nil {
+						(*out)[key] = nil
+					} else {
+						in, out := &val, &outVal
+						*out = make([]config.NameValue, len(*in))
+						copy(*out, *in)
+					}
+					(*out)[key] = outVal
+				}
+			}
+			(*out)[key] = outVal
+		}
+	}
 	return
 }
 
@@ -387,3 +713,335 @@ func (in *RegistryConfig) DeepCopy() *RegistryConfig {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodePool) DeepCopyInto(out *NodePool) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodePool.
+func (in *NodePool) DeepCopy() *NodePool {
+	if in == nil {
+		return nil
+	}
+	out := new(NodePool)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodePoolServiceAccount) DeepCopyInto(out *NodePoolServiceAccount) {
+	*out = *in
+	if in.Roles != nil {
+		in, out := &in.Roles, &out.Roles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodePoolServiceAccount.
+func (in *NodePoolServiceAccount) DeepCopy() *NodePoolServiceAccount {
+	if in == nil {
+		return nil
+	}
+	out := new(NodePoolServiceAccount)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Tenant) DeepCopyInto(out *Tenant) {
+	*out = *in
+	if in.Admins != nil {
+		in, out := &in.Admins, &out.Admins
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Members != nil {
+		in, out := &in.Members, &out.Members
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Tenant.
+func (in *Tenant) DeepCopy() *Tenant {
+	if in == nil {
+		return nil
+	}
+	out := new(Tenant)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StorageSnapshot) DeepCopyInto(out *StorageSnapshot) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageSnapshot.
+func (in *StorageSnapshot) DeepCopy() *StorageSnapshot {
+	if in == nil {
+		return nil
+	}
+	out := new(StorageSnapshot)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemplateOverride) DeepCopyInto(out *TemplateOverride) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemplateOverride.
+func (in *TemplateOverride) DeepCopy() *TemplateOverride {
+	if in == nil {
+		return nil
+	}
+	out := new(TemplateOverride)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Notification) DeepCopyInto(out *Notification) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Notification.
+func (in *Notification) DeepCopy() *Notification {
+	if in == nil {
+		return nil
+	}
+	out := new(Notification)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Hook) DeepCopyInto(out *Hook) {
+	*out = *in
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Hook.
+func (in *Hook) DeepCopy() *Hook {
+	if in == nil {
+		return nil
+	}
+	out := new(Hook)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuthConfig) DeepCopyInto(out *AuthConfig) {
+	*out = *in
+	if in.Oidc != nil {
+		in, out := &in.Oidc, &out.Oidc
+		*out = new(OidcConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Ldap != nil {
+		in, out := &in.Ldap, &out.Ldap
+		*out = new(LdapConfig)
+		**out = **in
+	}
+	if in.OAuthClient != nil {
+		in, out := &in.OAuthClient, &out.OAuthClient
+		*out = new(OAuthClientConfig)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuthConfig.
+func (in *AuthConfig) DeepCopy() *AuthConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(AuthConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OAuthClientConfig) DeepCopyInto(out *OAuthClientConfig) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OAuthClientConfig.
+func (in *OAuthClientConfig) DeepCopy() *OAuthClientConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(OAuthClientConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OidcConfig) DeepCopyInto(out *OidcConfig) {
+	*out = *in
+	if in.Scopes != nil {
+		in, out := &in.Scopes, &out.Scopes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OidcConfig.
+func (in *OidcConfig) DeepCopy() *OidcConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(OidcConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LdapConfig) DeepCopyInto(out *LdapConfig) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LdapConfig.
+func (in *LdapConfig) DeepCopy() *LdapConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(LdapConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComponentSpec) DeepCopyInto(out *ComponentSpec) {
+	*out = *in
+	if in.Params != nil {
+		in, out := &in.Params, &out.Params
+		*out = make([]config.NameValue, len(*in))
+		copy(*out, *in)
+	}
+	if in.Requires != nil {
+		in, out := &in.Requires, &out.Requires
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentSpec.
+func (in *ComponentSpec) DeepCopy() *ComponentSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ComponentSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SharedVpcConfig) DeepCopyInto(out *SharedVpcConfig) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SharedVpcConfig.
+func (in *SharedVpcConfig) DeepCopy() *SharedVpcConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(SharedVpcConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UsageReportingConfig) DeepCopyInto(out *UsageReportingConfig) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UsageReportingConfig.
+func (in *UsageReportingConfig) DeepCopy() *UsageReportingConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(UsageReportingConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BinaryAuthorizationConfig) DeepCopyInto(out *BinaryAuthorizationConfig) {
+	*out = *in
+	if in.AdmitPatterns != nil {
+		in, out := &in.AdmitPatterns, &out.AdmitPatterns
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Attestors != nil {
+		in, out := &in.Attestors, &out.Attestors
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BinaryAuthorizationConfig.
+func (in *BinaryAuthorizationConfig) DeepCopy() *BinaryAuthorizationConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(BinaryAuthorizationConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServicePerimeterConfig) DeepCopyInto(out *ServicePerimeterConfig) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServicePerimeterConfig.
+func (in *ServicePerimeterConfig) DeepCopy() *ServicePerimeterConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ServicePerimeterConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetadataConfig) DeepCopyInto(out *MetadataConfig) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetadataConfig.
+func (in *MetadataConfig) DeepCopy() *MetadataConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(MetadataConfig)
+	in.DeepCopyInto(out)
+	return out
+}
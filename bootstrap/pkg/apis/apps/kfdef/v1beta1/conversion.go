@@ -0,0 +1,581 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1beta1
+
+import (
+	"github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps/kfdef/v1alpha1"
+)
+
+// ConvertFromV1alpha1 returns the v1beta1 form of a v1alpha1 KfDef.
+func ConvertFromV1alpha1(in *v1alpha1.KfDef) *KfDef {
+	// out takes the maps and slices of a copy of in, e.g. componentParamOverrides and extraApis,
+	// so changing one doesn't change the other.
+	in = in.DeepCopy()
+	out := &KfDef{
+		TypeMeta:   in.TypeMeta,
+		ObjectMeta: in.ObjectMeta,
+		Spec: KfDefSpec{
+			ComponentConfig:           in.Spec.ComponentConfig,
+			AppDir:                    in.Spec.AppDir,
+			Version:                   in.Spec.Version,
+			MountLocal:                in.Spec.MountLocal,
+			Project:                   in.Spec.Project,
+			Email:                     in.Spec.Email,
+			IpName:                    in.Spec.IpName,
+			Hostname:                  in.Spec.Hostname,
+			Zone:                      in.Spec.Zone,
+			UseBasicAuth:              in.Spec.UseBasicAuth,
+			SkipInitProject:           in.Spec.SkipInitProject,
+			UseIstio:                  in.Spec.UseIstio,
+			ServerVersion:             in.Spec.ServerVersion,
+			DeleteStorage:             in.Spec.DeleteStorage,
+			DeletionProtection:        in.Spec.DeletionProtection,
+			CombinedDeployment:        in.Spec.CombinedDeployment,
+			UseEmbeddedAssets:         in.Spec.UseEmbeddedAssets,
+			EnableStackdriver:         in.Spec.EnableStackdriver,
+			EnableTpu:                 in.Spec.EnableTpu,
+			Mirror:                    in.Spec.Mirror,
+			AppDirVersion:             in.Spec.AppDirVersion,
+			Kubeconfig:                in.Spec.Kubeconfig,
+			KubeContext:               in.Spec.KubeContext,
+			ReportEndpoint:            in.Spec.ReportEndpoint,
+			StateBucket:               in.Spec.StateBucket,
+			StatePrefix:               in.Spec.StatePrefix,
+			BcryptCost:                in.Spec.BcryptCost,
+			DeploymentId:              in.Spec.DeploymentId,
+			Env:                       in.Spec.Env,
+			DeleteFilestore:           in.Spec.DeleteFilestore,
+			IpReserved:                in.Spec.IpReserved,
+			DeleteIp:                  in.Spec.DeleteIp,
+			SnapshotStorage:           in.Spec.SnapshotStorage,
+			CloudAuditLog:             in.Spec.CloudAuditLog,
+			CaBundle:                  in.Spec.CaBundle,
+			RepoSha:                   in.Spec.RepoSha,
+			RepoChecksum:              in.Spec.RepoChecksum,
+			Region:                    in.Spec.Region,
+			AdminRole:                 in.Spec.AdminRole,
+			DisableServiceAccountKeys: in.Spec.DisableServiceAccountKeys,
+			ManifestsTool:             in.Spec.ManifestsTool,
+			NamespacePrefix:           in.Spec.NamespacePrefix,
+			HostProject:               in.Spec.HostProject,
+			DeploymentManagerSA:       in.Spec.DeploymentManagerSA,
+			SecretsSync:               in.Spec.SecretsSync,
+		},
+	}
+	out.APIVersion = SchemeGroupVersion.String()
+	out.Spec.ComponentParamOverrides = in.Spec.ComponentParamOverrides
+	out.Spec.IapMembers = in.Spec.IapMembers
+	out.Spec.ServiceAccountKeys = in.Spec.ServiceAccountKeys
+	out.Spec.ExtraApis = in.Spec.ExtraApis
+	out.Spec.SkipApis = in.Spec.SkipApis
+	out.Spec.AdminMembers = in.Spec.AdminMembers
+	out.Spec.TemplateSha256 = in.Spec.TemplateSha256
+	for _, s := range in.Spec.StorageSnapshots {
+		out.Spec.StorageSnapshots = append(out.Spec.StorageSnapshots, StorageSnapshot{
+			Disk:         s.Disk,
+			Snapshot:     s.Snapshot,
+			CreationTime: s.CreationTime,
+		})
+	}
+	for _, c := range in.Spec.ComponentMatrix {
+		out.Spec.ComponentMatrix = append(out.Spec.ComponentMatrix, ComponentSpec{
+			Name:     c.Name,
+			Enabled:  c.Enabled,
+			Params:   c.Params,
+			Requires: c.Requires,
+		})
+	}
+	if u := in.Spec.UsageReporting; u != nil {
+		out.Spec.UsageReporting = &UsageReportingConfig{
+			Enabled:        u.Enabled,
+			UsageId:        u.UsageId,
+			ReportEndpoint: u.ReportEndpoint,
+		}
+	}
+	for _, h := range in.Spec.Hooks {
+		out.Spec.Hooks = append(out.Spec.Hooks, Hook{
+			Name:           h.Name,
+			Step:           h.Step,
+			When:           h.When,
+			Command:        h.Command,
+			Url:            h.Url,
+			TimeoutSeconds: h.TimeoutSeconds,
+			IgnoreFailure:  h.IgnoreFailure,
+		})
+	}
+	for _, t := range in.Spec.Tenants {
+		out.Spec.Tenants = append(out.Spec.Tenants, Tenant{
+			Namespace: t.Namespace,
+			Admins:    t.Admins,
+			Members:   t.Members,
+		})
+	}
+	if v := in.Spec.SharedVpc; v != nil {
+		out.Spec.SharedVpc = &SharedVpcConfig{
+			Network:       v.Network,
+			Subnetwork:    v.Subnetwork,
+			PodsRange:     v.PodsRange,
+			ServicesRange: v.ServicesRange,
+		}
+	}
+	if p := in.Spec.ServicePerimeter; p != nil {
+		out.Spec.ServicePerimeter = &ServicePerimeterConfig{
+			AccessPolicy: p.AccessPolicy,
+			Perimeter:    p.Perimeter,
+			AddProject:   p.AddProject,
+		}
+	}
+	if m := in.Spec.Metadata; m != nil {
+		out.Spec.Metadata = &MetadataConfig{
+			Db:   m.Db,
+			Tier: m.Tier,
+		}
+	}
+	if b := in.Spec.BinaryAuthorization; b != nil {
+		out.Spec.BinaryAuthorization = &BinaryAuthorizationConfig{
+			AdmitPatterns: b.AdmitPatterns,
+			Attestors:     b.Attestors,
+			DryRun:        b.DryRun,
+			ApplyPolicy:   b.ApplyPolicy,
+		}
+	}
+	if f := in.Spec.Filestore; f != nil {
+		out.Spec.Filestore = &FilestoreConfig{
+			Tier:       f.Tier,
+			CapacityGb: f.CapacityGb,
+			Network:    f.Network,
+		}
+	}
+	if i := in.Spec.Istio; i != nil {
+		out.Spec.Istio = &IstioConfig{Profile: i.Profile}
+		if g := i.IngressGateway; g != nil {
+			out.Spec.Istio.IngressGateway = &IstioGatewayConfig{
+				MinReplicas: g.MinReplicas,
+				MaxReplicas: g.MaxReplicas,
+				Cpu:         g.Cpu,
+				Memory:      g.Memory,
+			}
+		}
+		if g := i.EgressGateway; g != nil {
+			out.Spec.Istio.EgressGateway = &IstioGatewayConfig{
+				MinReplicas: g.MinReplicas,
+				MaxReplicas: g.MaxReplicas,
+				Cpu:         g.Cpu,
+				Memory:      g.Memory,
+			}
+		}
+	}
+	if in.Spec.Certificate != nil {
+		out.Spec.Certificate = &Certificate{
+			Type:     in.Spec.Certificate.Type,
+			CertFile: in.Spec.Certificate.CertFile,
+			KeyFile:  in.Spec.Certificate.KeyFile,
+		}
+	}
+	for _, sa := range in.Spec.NodePoolServiceAccounts {
+		out.Spec.NodePoolServiceAccounts = append(out.Spec.NodePoolServiceAccounts, NodePoolServiceAccount{
+			Pool:  sa.Pool,
+			Roles: sa.Roles,
+		})
+	}
+	for _, p := range in.Spec.NodePools {
+		out.Spec.NodePools = append(out.Spec.NodePools, NodePool{
+			Pool:        p.Pool,
+			Nodes:       p.Nodes,
+			MachineType: p.MachineType,
+			PoolVersion: p.PoolVersion,
+		})
+	}
+	for _, n := range in.Spec.Notifications {
+		out.Spec.Notifications = append(out.Spec.Notifications, Notification{
+			Type:  n.Type,
+			Url:   n.Url,
+			Topic: n.Topic,
+		})
+	}
+	for _, o := range in.Spec.TemplateOverrides {
+		out.Spec.TemplateOverrides = append(out.Spec.TemplateOverrides, TemplateOverride{
+			File:           o.File,
+			UpstreamSha256: o.UpstreamSha256,
+		})
+	}
+	if in.Spec.Gke != nil {
+		out.Spec.Gke = &GkeConfig{
+			ReleaseChannel:         in.Spec.Gke.ReleaseChannel,
+			MaintenanceStartTime:   in.Spec.Gke.MaintenanceStartTime,
+			AutoscalingProfile:     in.Spec.Gke.AutoscalingProfile,
+			VerticalPodAutoscaling: in.Spec.Gke.VerticalPodAutoscaling,
+			ApiVersion:             in.Spec.Gke.ApiVersion,
+			MasterVersion:          in.Spec.Gke.MasterVersion,
+			NodeVersion:            in.Spec.Gke.NodeVersion,
+			InitialVersion:         in.Spec.Gke.InitialVersion,
+			PrivateCluster:         in.Spec.Gke.PrivateCluster,
+		}
+		if a := in.Spec.Gke.Autoprovisioning; a != nil {
+			out.Spec.Gke.Autoprovisioning = &AutoprovisioningConfig{
+				Enabled:        a.Enabled,
+				MinCpu:         a.MinCpu,
+				MaxCpu:         a.MaxCpu,
+				MinMemory:      a.MinMemory,
+				MaxMemory:      a.MaxMemory,
+				ServiceAccount: a.ServiceAccount,
+				OauthScopes:    a.OauthScopes,
+			}
+			for _, acc := range a.Accelerators {
+				out.Spec.Gke.Autoprovisioning.Accelerators = append(out.Spec.Gke.Autoprovisioning.Accelerators,
+					AutoprovisioningAccelerator{
+						Type: acc.Type,
+						Min:  acc.Min,
+						Max:  acc.Max,
+					})
+			}
+		}
+		for feature, enabled := range in.Spec.Gke.FeatureGates {
+			if out.Spec.Gke.FeatureGates == nil {
+				out.Spec.Gke.FeatureGates = map[string]bool{}
+			}
+			out.Spec.Gke.FeatureGates[feature] = enabled
+		}
+	}
+	if in.Spec.Gpu != nil {
+		out.Spec.Gpu = &GpuConfig{
+			Type:     in.Spec.Gpu.Type,
+			Count:    in.Spec.Gpu.Count,
+			MaxNodes: in.Spec.Gpu.MaxNodes,
+		}
+	}
+	if in.Spec.Auth != nil {
+		out.Spec.Auth = &AuthConfig{Provider: in.Spec.Auth.Provider}
+		if c := in.Spec.Auth.OAuthClient; c != nil {
+			out.Spec.Auth.OAuthClient = &OAuthClientConfig{
+				CredentialsFile:    c.CredentialsFile,
+				ClientIdSecret:     c.ClientIdSecret,
+				ClientSecretSecret: c.ClientSecretSecret,
+			}
+		}
+		if o := in.Spec.Auth.Oidc; o != nil {
+			out.Spec.Auth.Oidc = &OidcConfig{
+				Issuer:   o.Issuer,
+				ClientId: o.ClientId,
+				Scopes:   o.Scopes,
+			}
+		}
+		if l := in.Spec.Auth.Ldap; l != nil {
+			out.Spec.Auth.Ldap = &LdapConfig{
+				Host:          l.Host,
+				InsecureNoSsl: l.InsecureNoSsl,
+				BindDn:        l.BindDn,
+				UserBaseDn:    l.UserBaseDn,
+				UserFilter:    l.UserFilter,
+				UsernameAttr:  l.UsernameAttr,
+				EmailAttr:     l.EmailAttr,
+				GroupBaseDn:   l.GroupBaseDn,
+			}
+		}
+	}
+	out.Status.ObservedGeneration = in.Status.ObservedGeneration
+	for _, c := range in.Status.Conditions {
+		out.Status.Conditions = append(out.Status.Conditions, KfDefCondition{
+			Type:               KfDefConditionType(c.Type),
+			Status:             c.Status,
+			LastUpdateTime:     c.LastUpdateTime,
+			LastTransitionTime: c.LastTransitionTime,
+			Reason:             c.Reason,
+			Message:            c.Message,
+		})
+	}
+	return out
+}
+
+// ConvertToV1alpha1 returns the v1alpha1 form of a v1beta1 KfDef, which is what the
+// KfApp implementations work on.
+func ConvertToV1alpha1(in *KfDef) *v1alpha1.KfDef {
+	// Like ConvertFromV1alpha1, out doesn't share the maps and slices of in.
+	in = in.DeepCopy()
+	out := &v1alpha1.KfDef{
+		TypeMeta:   in.TypeMeta,
+		ObjectMeta: in.ObjectMeta,
+		Spec: v1alpha1.KfDefSpec{
+			ComponentConfig:           in.Spec.ComponentConfig,
+			AppDir:                    in.Spec.AppDir,
+			Version:                   in.Spec.Version,
+			MountLocal:                in.Spec.MountLocal,
+			Project:                   in.Spec.Project,
+			Email:                     in.Spec.Email,
+			IpName:                    in.Spec.IpName,
+			Hostname:                  in.Spec.Hostname,
+			Zone:                      in.Spec.Zone,
+			UseBasicAuth:              in.Spec.UseBasicAuth,
+			SkipInitProject:           in.Spec.SkipInitProject,
+			UseIstio:                  in.Spec.UseIstio,
+			ServerVersion:             in.Spec.ServerVersion,
+			DeleteStorage:             in.Spec.DeleteStorage,
+			DeletionProtection:        in.Spec.DeletionProtection,
+			CombinedDeployment:        in.Spec.CombinedDeployment,
+			UseEmbeddedAssets:         in.Spec.UseEmbeddedAssets,
+			EnableStackdriver:         in.Spec.EnableStackdriver,
+			EnableTpu:                 in.Spec.EnableTpu,
+			Mirror:                    in.Spec.Mirror,
+			AppDirVersion:             in.Spec.AppDirVersion,
+			Kubeconfig:                in.Spec.Kubeconfig,
+			KubeContext:               in.Spec.KubeContext,
+			ReportEndpoint:            in.Spec.ReportEndpoint,
+			StateBucket:               in.Spec.StateBucket,
+			StatePrefix:               in.Spec.StatePrefix,
+			BcryptCost:                in.Spec.BcryptCost,
+			DeploymentId:              in.Spec.DeploymentId,
+			Env:                       in.Spec.Env,
+			DeleteFilestore:           in.Spec.DeleteFilestore,
+			IpReserved:                in.Spec.IpReserved,
+			DeleteIp:                  in.Spec.DeleteIp,
+			SnapshotStorage:           in.Spec.SnapshotStorage,
+			CloudAuditLog:             in.Spec.CloudAuditLog,
+			CaBundle:                  in.Spec.CaBundle,
+			RepoSha:                   in.Spec.RepoSha,
+			RepoChecksum:              in.Spec.RepoChecksum,
+			Region:                    in.Spec.Region,
+			AdminRole:                 in.Spec.AdminRole,
+			DisableServiceAccountKeys: in.Spec.DisableServiceAccountKeys,
+			ManifestsTool:             in.Spec.ManifestsTool,
+			NamespacePrefix:           in.Spec.NamespacePrefix,
+			HostProject:               in.Spec.HostProject,
+			DeploymentManagerSA:       in.Spec.DeploymentManagerSA,
+			SecretsSync:               in.Spec.SecretsSync,
+		},
+	}
+	out.APIVersion = v1alpha1.SchemeGroupVersion.String()
+	out.Spec.ComponentParamOverrides = in.Spec.ComponentParamOverrides
+	out.Spec.IapMembers = in.Spec.IapMembers
+	out.Spec.ServiceAccountKeys = in.Spec.ServiceAccountKeys
+	out.Spec.ExtraApis = in.Spec.ExtraApis
+	out.Spec.SkipApis = in.Spec.SkipApis
+	out.Spec.AdminMembers = in.Spec.AdminMembers
+	out.Spec.TemplateSha256 = in.Spec.TemplateSha256
+	for _, s := range in.Spec.StorageSnapshots {
+		out.Spec.StorageSnapshots = append(out.Spec.StorageSnapshots, v1alpha1.StorageSnapshot{
+			Disk:         s.Disk,
+			Snapshot:     s.Snapshot,
+			CreationTime: s.CreationTime,
+		})
+	}
+	for _, c := range in.Spec.ComponentMatrix {
+		out.Spec.ComponentMatrix = append(out.Spec.ComponentMatrix, v1alpha1.ComponentSpec{
+			Name:     c.Name,
+			Enabled:  c.Enabled,
+			Params:   c.Params,
+			Requires: c.Requires,
+		})
+	}
+	if u := in.Spec.UsageReporting; u != nil {
+		out.Spec.UsageReporting = &v1alpha1.UsageReportingConfig{
+			Enabled:        u.Enabled,
+			UsageId:        u.UsageId,
+			ReportEndpoint: u.ReportEndpoint,
+		}
+	}
+	for _, h := range in.Spec.Hooks {
+		out.Spec.Hooks = append(out.Spec.Hooks, v1alpha1.Hook{
+			Name:           h.Name,
+			Step:           h.Step,
+			When:           h.When,
+			Command:        h.Command,
+			Url:            h.Url,
+			TimeoutSeconds: h.TimeoutSeconds,
+			IgnoreFailure:  h.IgnoreFailure,
+		})
+	}
+	for _, t := range in.Spec.Tenants {
+		out.Spec.Tenants = append(out.Spec.Tenants, v1alpha1.Tenant{
+			Namespace: t.Namespace,
+			Admins:    t.Admins,
+			Members:   t.Members,
+		})
+	}
+	if v := in.Spec.SharedVpc; v != nil {
+		out.Spec.SharedVpc = &v1alpha1.SharedVpcConfig{
+			Network:       v.Network,
+			Subnetwork:    v.Subnetwork,
+			PodsRange:     v.PodsRange,
+			ServicesRange: v.ServicesRange,
+		}
+	}
+	if p := in.Spec.ServicePerimeter; p != nil {
+		out.Spec.ServicePerimeter = &v1alpha1.ServicePerimeterConfig{
+			AccessPolicy: p.AccessPolicy,
+			Perimeter:    p.Perimeter,
+			AddProject:   p.AddProject,
+		}
+	}
+	if m := in.Spec.Metadata; m != nil {
+		out.Spec.Metadata = &v1alpha1.MetadataConfig{
+			Db:   m.Db,
+			Tier: m.Tier,
+		}
+	}
+	if b := in.Spec.BinaryAuthorization; b != nil {
+		out.Spec.BinaryAuthorization = &v1alpha1.BinaryAuthorizationConfig{
+			AdmitPatterns: b.AdmitPatterns,
+			Attestors:     b.Attestors,
+			DryRun:        b.DryRun,
+			ApplyPolicy:   b.ApplyPolicy,
+		}
+	}
+	if f := in.Spec.Filestore; f != nil {
+		out.Spec.Filestore = &v1alpha1.FilestoreConfig{
+			Tier:       f.Tier,
+			CapacityGb: f.CapacityGb,
+			Network:    f.Network,
+		}
+	}
+	if i := in.Spec.Istio; i != nil {
+		out.Spec.Istio = &v1alpha1.IstioConfig{Profile: i.Profile}
+		if g := i.IngressGateway; g != nil {
+			out.Spec.Istio.IngressGateway = &v1alpha1.IstioGatewayConfig{
+				MinReplicas: g.MinReplicas,
+				MaxReplicas: g.MaxReplicas,
+				Cpu:         g.Cpu,
+				Memory:      g.Memory,
+			}
+		}
+		if g := i.EgressGateway; g != nil {
+			out.Spec.Istio.EgressGateway = &v1alpha1.IstioGatewayConfig{
+				MinReplicas: g.MinReplicas,
+				MaxReplicas: g.MaxReplicas,
+				Cpu:         g.Cpu,
+				Memory:      g.Memory,
+			}
+		}
+	}
+	if in.Spec.Certificate != nil {
+		out.Spec.Certificate = &v1alpha1.Certificate{
+			Type:     in.Spec.Certificate.Type,
+			CertFile: in.Spec.Certificate.CertFile,
+			KeyFile:  in.Spec.Certificate.KeyFile,
+		}
+	}
+	for _, sa := range in.Spec.NodePoolServiceAccounts {
+		out.Spec.NodePoolServiceAccounts = append(out.Spec.NodePoolServiceAccounts, v1alpha1.NodePoolServiceAccount{
+			Pool:  sa.Pool,
+			Roles: sa.Roles,
+		})
+	}
+	for _, p := range in.Spec.NodePools {
+		out.Spec.NodePools = append(out.Spec.NodePools, v1alpha1.NodePool{
+			Pool:        p.Pool,
+			Nodes:       p.Nodes,
+			MachineType: p.MachineType,
+			PoolVersion: p.PoolVersion,
+		})
+	}
+	for _, n := range in.Spec.Notifications {
+		out.Spec.Notifications = append(out.Spec.Notifications, v1alpha1.Notification{
+			Type:  n.Type,
+			Url:   n.Url,
+			Topic: n.Topic,
+		})
+	}
+	for _, o := range in.Spec.TemplateOverrides {
+		out.Spec.TemplateOverrides = append(out.Spec.TemplateOverrides, v1alpha1.TemplateOverride{
+			File:           o.File,
+			UpstreamSha256: o.UpstreamSha256,
+		})
+	}
+	if in.Spec.Gke != nil {
+		out.Spec.Gke = &v1alpha1.GkeConfig{
+			ReleaseChannel:         in.Spec.Gke.ReleaseChannel,
+			MaintenanceStartTime:   in.Spec.Gke.MaintenanceStartTime,
+			AutoscalingProfile:     in.Spec.Gke.AutoscalingProfile,
+			VerticalPodAutoscaling: in.Spec.Gke.VerticalPodAutoscaling,
+			ApiVersion:             in.Spec.Gke.ApiVersion,
+			MasterVersion:          in.Spec.Gke.MasterVersion,
+			NodeVersion:            in.Spec.Gke.NodeVersion,
+			InitialVersion:         in.Spec.Gke.InitialVersion,
+			PrivateCluster:         in.Spec.Gke.PrivateCluster,
+		}
+		if a := in.Spec.Gke.Autoprovisioning; a != nil {
+			out.Spec.Gke.Autoprovisioning = &v1alpha1.AutoprovisioningConfig{
+				Enabled:        a.Enabled,
+				MinCpu:         a.MinCpu,
+				MaxCpu:         a.MaxCpu,
+				MinMemory:      a.MinMemory,
+				MaxMemory:      a.MaxMemory,
+				ServiceAccount: a.ServiceAccount,
+				OauthScopes:    a.OauthScopes,
+			}
+			for _, acc := range a.Accelerators {
+				out.Spec.Gke.Autoprovisioning.Accelerators = append(out.Spec.Gke.Autoprovisioning.Accelerators,
+					v1alpha1.AutoprovisioningAccelerator{
+						Type: acc.Type,
+						Min:  acc.Min,
+						Max:  acc.Max,
+					})
+			}
+		}
+		for feature, enabled := range in.Spec.Gke.FeatureGates {
+			if out.Spec.Gke.FeatureGates == nil {
+				out.Spec.Gke.FeatureGates = map[string]bool{}
+			}
+			out.Spec.Gke.FeatureGates[feature] = enabled
+		}
+	}
+	if in.Spec.Gpu != nil {
+		out.Spec.Gpu = &v1alpha1.GpuConfig{
+			Type:     in.Spec.Gpu.Type,
+			Count:    in.Spec.Gpu.Count,
+			MaxNodes: in.Spec.Gpu.MaxNodes,
+		}
+	}
+	if in.Spec.Auth != nil {
+		out.Spec.Auth = &v1alpha1.AuthConfig{Provider: in.Spec.Auth.Provider}
+		if c := in.Spec.Auth.OAuthClient; c != nil {
+			out.Spec.Auth.OAuthClient = &v1alpha1.OAuthClientConfig{
+				CredentialsFile:    c.CredentialsFile,
+				ClientIdSecret:     c.ClientIdSecret,
+				ClientSecretSecret: c.ClientSecretSecret,
+			}
+		}
+		if o := in.Spec.Auth.Oidc; o != nil {
+			out.Spec.Auth.Oidc = &v1alpha1.OidcConfig{
+				Issuer:   o.Issuer,
+				ClientId: o.ClientId,
+				Scopes:   o.Scopes,
+			}
+		}
+		if l := in.Spec.Auth.Ldap; l != nil {
+			out.Spec.Auth.Ldap = &v1alpha1.LdapConfig{
+				Host:          l.Host,
+				InsecureNoSsl: l.InsecureNoSsl,
+				BindDn:        l.BindDn,
+				UserBaseDn:    l.UserBaseDn,
+				UserFilter:    l.UserFilter,
+				UsernameAttr:  l.UsernameAttr,
+				EmailAttr:     l.EmailAttr,
+				GroupBaseDn:   l.GroupBaseDn,
+			}
+		}
+	}
+	out.Status.ObservedGeneration = in.Status.ObservedGeneration
+	for _, c := range in.Status.Conditions {
+		out.Status.Conditions = append(out.Status.Conditions, v1alpha1.KfDefCondition{
+			Type:               v1alpha1.KfDefConditionType(c.Type),
+			Status:             c.Status,
+			LastUpdateTime:     c.LastUpdateTime,
+			LastTransitionTime: c.LastTransitionTime,
+			Reason:             c.Reason,
+			Message:            c.Message,
+		})
+	}
+	return out
+}
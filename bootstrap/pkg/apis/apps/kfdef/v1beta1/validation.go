@@ -0,0 +1,493 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1beta1
+
+import (
+	kftypes "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps"
+	"k8s.io/apimachinery/pkg/api/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"net/url"
+	"regexp"
+	"sort"
+)
+
+// Platforms accepted in spec.platform. Other platforms are loaded as plugins by older kfctl
+// versions; they must be added here before they can be used with v1beta1.
+var validPlatforms = []string{"gcp", "minikube", "docker-for-desktop"}
+
+var validPools = []string{"cpu-pool", "gpu-pool"}
+
+var validNotificationTypes = []string{"slack", "http", "pubsub"}
+
+var validCertificateTypes = []string{"acme", "managed-cert", "self-signed", "byo-secret"}
+
+var validAuthProviders = []string{"iap", "basic-auth", "oidc", "ldap"}
+
+var validTemplateOverrides = []string{"cluster.jinja", "cluster.jinja.schema", "storage.jinja", "storage.jinja.schema",
+	"iam_bindings_template.yaml"}
+
+var validAdminRoles = []string{"cluster-admin", "kubeflow-admin", "none"}
+
+var validManifestsTools = []string{"ksonnet", "kustomize"}
+
+var validFilestoreTiers = []string{"STANDARD", "PREMIUM"}
+
+var validIstioProfiles = []string{"noauth", "mtls-permissive", "mtls-strict"}
+
+var validMetadataDbs = []string{"cloudsql-postgres"}
+
+var validHookSteps = []string{"generate", "apply-platform", "apply-k8s", "delete"}
+
+var validHookWhens = []string{"pre", "post"}
+
+var validReleaseChannels = []string{"RAPID", "REGULAR", "STABLE"}
+
+var validAutoscalingProfiles = []string{"BALANCED", "OPTIMIZE_UTILIZATION"}
+
+var validGkeApiVersions = []string{"v1", "v1beta1"}
+
+var validGkeFeatures = []string{"workloadIdentity", "networkPolicy", "sandbox"}
+
+// betaGkeFeatures are the features of validGkeFeatures needing the v1beta1 API.
+var betaGkeFeatures = []string{"workloadIdentity", "sandbox"}
+
+var sha256Pattern = regexp.MustCompile("^[0-9a-f]{64}$")
+
+var topicPattern = regexp.MustCompile("^projects/[^/]+/topics/[^/]+$")
+
+var zonePattern = regexp.MustCompile("^[a-z]+-[a-z]+[0-9]+-[a-z]$")
+
+var regionPattern = regexp.MustCompile("^[a-z]+-[a-z]+[0-9]+$")
+
+var repoShaPattern = regexp.MustCompile("^[0-9a-f]{40}$")
+
+var emailPattern = regexp.MustCompile("^[^@]+@[^@]+$")
+
+var maintenanceTimePattern = regexp.MustCompile("^([01][0-9]|2[0-3]):[0-5][0-9]$")
+
+var gpuTypePattern = regexp.MustCompile("^nvidia-[a-z0-9-]+$")
+
+var apiPattern = regexp.MustCompile("^[a-z0-9-]+(\\.[a-z0-9-]+)+$")
+
+var attestorPattern = regexp.MustCompile("^projects/[^/]+/attestors/[^/]+$")
+
+var adminMemberPattern = regexp.MustCompile("^((user|group|serviceAccount):)?[^@:]+@[^@]+$")
+
+var iapMemberPattern = regexp.MustCompile("^(user|group|serviceAccount):[^@]+@[^@]+$|^domain:[^@]+$")
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateKfDef checks the fields kfctl relies on and returns one error per offending field,
+// mirroring the OpenAPI validation markers on the types.
+func ValidateKfDef(kfdef *KfDef) field.ErrorList {
+	allErrs := field.ErrorList{}
+	metaPath := field.NewPath("metadata")
+	if kfdef.Name == "" {
+		allErrs = append(allErrs, field.Required(metaPath.Child("name"), ""))
+	} else {
+		for _, msg := range validation.NameIsDNSLabel(kfdef.Name, false) {
+			allErrs = append(allErrs, field.Invalid(metaPath.Child("name"), kfdef.Name, msg))
+		}
+	}
+
+	spec := kfdef.Spec
+	specPath := field.NewPath("spec")
+	if spec.AppDir == "" {
+		allErrs = append(allErrs, field.Required(specPath.Child("appDir"), ""))
+	}
+	if spec.Platform != "" && !contains(validPlatforms, spec.Platform) {
+		allErrs = append(allErrs, field.NotSupported(specPath.Child("platform"), spec.Platform, validPlatforms))
+	}
+	if spec.Platform == "gcp" && spec.Project == "" {
+		allErrs = append(allErrs, field.Required(specPath.Child("project"), "required for platform gcp"))
+	}
+	if spec.Zone != "" && spec.Zone != kftypes.AutoZone && !zonePattern.MatchString(spec.Zone) {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("zone"), spec.Zone, "must be a zone like us-east1-d or auto"))
+	}
+	if spec.Email != "" && !emailPattern.MatchString(spec.Email) {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("email"), spec.Email, "must be an email address"))
+	}
+	if spec.AppDirVersion < 0 {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("appDirVersion"), spec.AppDirVersion, "must not be negative"))
+	}
+	if _, ok := spec.ComponentParamOverrides[spec.Env]; spec.Env != "" && !ok {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("env"), spec.Env, "must be a key of componentParamOverrides"))
+	}
+	if spec.BcryptCost != 0 && (spec.BcryptCost < 4 || spec.BcryptCost > 31) {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("bcryptCost"), spec.BcryptCost, "must be between 4 and 31"))
+	}
+	if spec.ReportEndpoint != "" {
+		if u, err := url.Parse(spec.ReportEndpoint); err != nil || u.Scheme == "" || u.Host == "" {
+			allErrs = append(allErrs, field.Invalid(specPath.Child("reportEndpoint"), spec.ReportEndpoint, "must be an absolute URL"))
+		}
+	}
+	if c := spec.Certificate; c != nil {
+		certificatePath := specPath.Child("certificate")
+		if c.Type != "" && !contains(validCertificateTypes, c.Type) {
+			allErrs = append(allErrs, field.NotSupported(certificatePath.Child("type"), c.Type, validCertificateTypes))
+		}
+		if c.Type == "byo-secret" {
+			if c.CertFile == "" {
+				allErrs = append(allErrs, field.Required(certificatePath.Child("certFile"), "required for byo-secret"))
+			}
+			if c.KeyFile == "" {
+				allErrs = append(allErrs, field.Required(certificatePath.Child("keyFile"), "required for byo-secret"))
+			}
+		}
+	}
+	if a := spec.Auth; a != nil {
+		authPath := specPath.Child("auth")
+		if a.Provider != "" && !contains(validAuthProviders, a.Provider) {
+			allErrs = append(allErrs, field.NotSupported(authPath.Child("provider"), a.Provider, validAuthProviders))
+		} else if spec.UseBasicAuth && a.Provider != "" && a.Provider != "basic-auth" {
+			allErrs = append(allErrs, field.Invalid(authPath.Child("provider"), a.Provider, "conflicts with useBasicAuth"))
+		}
+		if a.Provider == "oidc" {
+			if a.Oidc == nil {
+				allErrs = append(allErrs, field.Required(authPath.Child("oidc"), "required for oidc"))
+			} else {
+				if u, err := url.Parse(a.Oidc.Issuer); err != nil || u.Scheme != "https" || u.Host == "" {
+					allErrs = append(allErrs, field.Invalid(authPath.Child("oidc", "issuer"), a.Oidc.Issuer, "must be an https URL"))
+				}
+				if a.Oidc.ClientId == "" {
+					allErrs = append(allErrs, field.Required(authPath.Child("oidc", "clientId"), ""))
+				}
+			}
+		}
+		if a.Provider == "ldap" {
+			if a.Ldap == nil {
+				allErrs = append(allErrs, field.Required(authPath.Child("ldap"), "required for ldap"))
+			} else {
+				if a.Ldap.Host == "" {
+					allErrs = append(allErrs, field.Required(authPath.Child("ldap", "host"), ""))
+				}
+				if a.Ldap.UserBaseDn == "" {
+					allErrs = append(allErrs, field.Required(authPath.Child("ldap", "userBaseDn"), ""))
+				}
+			}
+		}
+	}
+	for i, member := range spec.IapMembers {
+		if !iapMemberPattern.MatchString(member) {
+			allErrs = append(allErrs, field.Invalid(specPath.Child("iapMembers").Index(i), member,
+				"must be an IAM member like user:, group:, serviceAccount: or domain:"))
+		}
+	}
+	for i, api := range spec.ExtraApis {
+		if !apiPattern.MatchString(api) {
+			allErrs = append(allErrs, field.Invalid(specPath.Child("extraApis").Index(i), api,
+				"must be a service name like tpu.googleapis.com"))
+		}
+	}
+	for i, api := range spec.SkipApis {
+		if !apiPattern.MatchString(api) {
+			allErrs = append(allErrs, field.Invalid(specPath.Child("skipApis").Index(i), api,
+				"must be a service name like ml.googleapis.com"))
+		}
+	}
+	seen := map[string]bool{}
+	for i, sa := range spec.NodePoolServiceAccounts {
+		poolPath := specPath.Child("nodePoolServiceAccounts").Index(i).Child("pool")
+		if !contains(validPools, sa.Pool) {
+			allErrs = append(allErrs, field.NotSupported(poolPath, sa.Pool, validPools))
+		} else if seen[sa.Pool] {
+			allErrs = append(allErrs, field.Duplicate(poolPath, sa.Pool))
+		}
+		seen[sa.Pool] = true
+	}
+	seen = map[string]bool{}
+	for i, p := range spec.NodePools {
+		nodePoolPath := specPath.Child("nodePools").Index(i)
+		if !contains(validPools, p.Pool) {
+			allErrs = append(allErrs, field.NotSupported(nodePoolPath.Child("pool"), p.Pool, validPools))
+		} else if seen[p.Pool] {
+			allErrs = append(allErrs, field.Duplicate(nodePoolPath.Child("pool"), p.Pool))
+		}
+		seen[p.Pool] = true
+		if p.Nodes < 0 {
+			allErrs = append(allErrs, field.Invalid(nodePoolPath.Child("nodes"), p.Nodes, "must be greater than or equal to 0"))
+		}
+		if p.MachineType == "" {
+			allErrs = append(allErrs, field.Required(nodePoolPath.Child("machineType"), ""))
+		}
+	}
+	for i, n := range spec.Notifications {
+		notificationPath := specPath.Child("notifications").Index(i)
+		switch n.Type {
+		case "slack", "http":
+			if u, err := url.Parse(n.Url); err != nil || u.Scheme == "" || u.Host == "" {
+				allErrs = append(allErrs, field.Invalid(notificationPath.Child("url"), n.Url, "must be an absolute URL"))
+			}
+		case "pubsub":
+			if !topicPattern.MatchString(n.Topic) {
+				allErrs = append(allErrs, field.Invalid(notificationPath.Child("topic"), n.Topic,
+					"must be a topic like projects/<project>/topics/<topic>"))
+			}
+		default:
+			allErrs = append(allErrs, field.NotSupported(notificationPath.Child("type"), n.Type, validNotificationTypes))
+		}
+	}
+	for i, o := range spec.TemplateOverrides {
+		overridePath := specPath.Child("templateOverrides").Index(i)
+		if !contains(validTemplateOverrides, o.File) {
+			allErrs = append(allErrs, field.NotSupported(overridePath.Child("file"), o.File, validTemplateOverrides))
+		}
+		if !sha256Pattern.MatchString(o.UpstreamSha256) {
+			allErrs = append(allErrs, field.Invalid(overridePath.Child("upstreamSha256"), o.UpstreamSha256,
+				"must be a hex SHA-256"))
+		}
+	}
+	if g := spec.Gke; g != nil {
+		gkePath := specPath.Child("gke")
+		if g.ReleaseChannel != "" && !contains(validReleaseChannels, g.ReleaseChannel) {
+			allErrs = append(allErrs, field.NotSupported(gkePath.Child("releaseChannel"), g.ReleaseChannel, validReleaseChannels))
+		}
+		if g.MaintenanceStartTime != "" && !maintenanceTimePattern.MatchString(g.MaintenanceStartTime) {
+			allErrs = append(allErrs, field.Invalid(gkePath.Child("maintenanceStartTime"), g.MaintenanceStartTime,
+				"must be a GMT time like 03:00"))
+		}
+		if g.AutoscalingProfile != "" && !contains(validAutoscalingProfiles, g.AutoscalingProfile) {
+			allErrs = append(allErrs, field.NotSupported(gkePath.Child("autoscalingProfile"), g.AutoscalingProfile,
+				validAutoscalingProfiles))
+		}
+		if g.ApiVersion != "" && !contains(validGkeApiVersions, g.ApiVersion) {
+			allErrs = append(allErrs, field.NotSupported(gkePath.Child("apiVersion"), g.ApiVersion, validGkeApiVersions))
+		}
+		if g.ApiVersion == "v1" {
+			if g.ReleaseChannel != "" {
+				allErrs = append(allErrs, field.Invalid(gkePath.Child("releaseChannel"), g.ReleaseChannel,
+					"needs apiVersion v1beta1"))
+			}
+			if g.AutoscalingProfile != "" {
+				allErrs = append(allErrs, field.Invalid(gkePath.Child("autoscalingProfile"), g.AutoscalingProfile,
+					"needs apiVersion v1beta1"))
+			}
+			if g.VerticalPodAutoscaling {
+				allErrs = append(allErrs, field.Invalid(gkePath.Child("verticalPodAutoscaling"), g.VerticalPodAutoscaling,
+					"needs apiVersion v1beta1"))
+			}
+			if spec.EnableTpu {
+				allErrs = append(allErrs, field.Invalid(specPath.Child("enableTpu"), spec.EnableTpu,
+					"needs gke apiVersion v1beta1"))
+			}
+			if g.PrivateCluster {
+				allErrs = append(allErrs, field.Invalid(gkePath.Child("privateCluster"), g.PrivateCluster,
+					"needs apiVersion v1beta1"))
+			}
+			if g.Autoprovisioning != nil {
+				allErrs = append(allErrs, field.Invalid(gkePath.Child("autoprovisioning"), "",
+					"needs apiVersion v1beta1"))
+			}
+		}
+		features := []string{}
+		for feature := range g.FeatureGates {
+			features = append(features, feature)
+		}
+		sort.Strings(features)
+		for _, feature := range features {
+			enabled := g.FeatureGates[feature]
+			featurePath := gkePath.Child("featureGates").Key(feature)
+			if !contains(validGkeFeatures, feature) {
+				allErrs = append(allErrs, field.NotSupported(featurePath, feature, validGkeFeatures))
+			} else if enabled && g.ApiVersion == "v1" && contains(betaGkeFeatures, feature) {
+				allErrs = append(allErrs, field.Invalid(featurePath, enabled, "needs apiVersion v1beta1"))
+			}
+		}
+		if a := g.Autoprovisioning; a != nil {
+			autoprovisioningPath := gkePath.Child("autoprovisioning")
+			if a.MinCpu < 0 || a.MaxCpu < a.MinCpu {
+				allErrs = append(allErrs, field.Invalid(autoprovisioningPath.Child("maxCpu"), a.MaxCpu,
+					"must be at least minCpu, which must not be negative"))
+			}
+			if a.MinMemory < 0 || a.MaxMemory < a.MinMemory {
+				allErrs = append(allErrs, field.Invalid(autoprovisioningPath.Child("maxMemory"), a.MaxMemory,
+					"must be at least minMemory, which must not be negative"))
+			}
+			for i, acc := range a.Accelerators {
+				acceleratorPath := autoprovisioningPath.Child("accelerators").Index(i)
+				if !gpuTypePattern.MatchString(acc.Type) {
+					allErrs = append(allErrs, field.Invalid(acceleratorPath.Child("type"), acc.Type,
+						"must be an NVIDIA GPU like nvidia-tesla-k80"))
+				}
+				if acc.Min < 0 || acc.Max < acc.Min {
+					allErrs = append(allErrs, field.Invalid(acceleratorPath.Child("max"), acc.Max,
+						"must be at least min, which must not be negative"))
+				}
+			}
+		}
+	}
+	if g := spec.Gpu; g != nil {
+		gpuPath := specPath.Child("gpu")
+		if g.Type != "" && !gpuTypePattern.MatchString(g.Type) {
+			allErrs = append(allErrs, field.Invalid(gpuPath.Child("type"), g.Type, "must be an NVIDIA GPU like nvidia-tesla-k80"))
+		}
+		if g.Count < 0 {
+			allErrs = append(allErrs, field.Invalid(gpuPath.Child("count"), g.Count, "must not be negative"))
+		}
+		if g.MaxNodes < 0 {
+			allErrs = append(allErrs, field.Invalid(gpuPath.Child("maxNodes"), g.MaxNodes, "must not be negative"))
+		}
+	}
+	if spec.Region != "" && !regionPattern.MatchString(spec.Region) {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("region"), spec.Region, "must be a region like us-east1"))
+	}
+	if spec.RepoSha != "" && !repoShaPattern.MatchString(spec.RepoSha) {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("repoSha"), spec.RepoSha, "must be a git commit SHA"))
+	}
+	if spec.RepoChecksum != "" && !sha256Pattern.MatchString(spec.RepoChecksum) {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("repoChecksum"), spec.RepoChecksum, "must be a hex SHA-256"))
+	}
+	if spec.AdminRole != "" && !contains(validAdminRoles, spec.AdminRole) {
+		allErrs = append(allErrs, field.NotSupported(specPath.Child("adminRole"), spec.AdminRole, validAdminRoles))
+	}
+	for i, member := range spec.AdminMembers {
+		if !adminMemberPattern.MatchString(member) {
+			allErrs = append(allErrs, field.Invalid(specPath.Child("adminMembers").Index(i), member,
+				"must be an email or an IAM member like user:, group: or serviceAccount:"))
+		}
+	}
+	if spec.ManifestsTool != "" && !contains(validManifestsTools, spec.ManifestsTool) {
+		allErrs = append(allErrs, field.NotSupported(specPath.Child("manifestsTool"), spec.ManifestsTool, validManifestsTools))
+	}
+	if spec.NamespacePrefix != "" {
+		for _, msg := range validation.NameIsDNSLabel(spec.NamespacePrefix, false) {
+			allErrs = append(allErrs, field.Invalid(specPath.Child("namespacePrefix"), spec.NamespacePrefix, msg))
+		}
+	}
+	if spec.DeploymentManagerSA != "" && !emailPattern.MatchString(spec.DeploymentManagerSA) {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("deploymentManagerSA"), spec.DeploymentManagerSA,
+			"must be the email of a service account"))
+	}
+	seen = map[string]bool{}
+	for i, t := range spec.Tenants {
+		namespacePath := specPath.Child("tenants").Index(i).Child("namespace")
+		for _, msg := range validation.NameIsDNSLabel(t.Namespace, false) {
+			allErrs = append(allErrs, field.Invalid(namespacePath, t.Namespace, msg))
+		}
+		if seen[t.Namespace] {
+			allErrs = append(allErrs, field.Duplicate(namespacePath, t.Namespace))
+		}
+		seen[t.Namespace] = true
+	}
+	for i, c := range spec.ComponentMatrix {
+		if c.Name == "" {
+			allErrs = append(allErrs, field.Required(specPath.Child("componentMatrix").Index(i).Child("name"), ""))
+		}
+	}
+	if u := spec.UsageReporting; u != nil && u.ReportEndpoint != "" {
+		if parsed, err := url.Parse(u.ReportEndpoint); err != nil || parsed.Scheme == "" || parsed.Host == "" {
+			allErrs = append(allErrs, field.Invalid(specPath.Child("usageReporting", "reportEndpoint"), u.ReportEndpoint,
+				"must be an absolute URL"))
+		}
+	}
+	for i, h := range spec.Hooks {
+		hookPath := specPath.Child("hooks").Index(i)
+		if h.Name == "" {
+			allErrs = append(allErrs, field.Required(hookPath.Child("name"), ""))
+		}
+		if !contains(validHookSteps, h.Step) {
+			allErrs = append(allErrs, field.NotSupported(hookPath.Child("step"), h.Step, validHookSteps))
+		}
+		if !contains(validHookWhens, h.When) {
+			allErrs = append(allErrs, field.NotSupported(hookPath.Child("when"), h.When, validHookWhens))
+		}
+		if (len(h.Command) == 0) == (h.Url == "") {
+			allErrs = append(allErrs, field.Invalid(hookPath, h.Name, "must set one of command and url"))
+		}
+		if h.TimeoutSeconds < 0 {
+			allErrs = append(allErrs, field.Invalid(hookPath.Child("timeoutSeconds"), h.TimeoutSeconds, "must not be negative"))
+		}
+	}
+	if spec.HostProject != "" && spec.SharedVpc == nil {
+		allErrs = append(allErrs, field.Required(specPath.Child("sharedVpc"), "required for hostProject"))
+	}
+	if v := spec.SharedVpc; v != nil {
+		sharedVpcPath := specPath.Child("sharedVpc")
+		if spec.HostProject == "" {
+			allErrs = append(allErrs, field.Required(specPath.Child("hostProject"), "required for sharedVpc"))
+		}
+		if v.Network == "" {
+			allErrs = append(allErrs, field.Required(sharedVpcPath.Child("network"), ""))
+		}
+		if v.Subnetwork == "" {
+			allErrs = append(allErrs, field.Required(sharedVpcPath.Child("subnetwork"), ""))
+		}
+		if v.PodsRange == "" {
+			allErrs = append(allErrs, field.Required(sharedVpcPath.Child("podsRange"), ""))
+		}
+		if v.ServicesRange == "" {
+			allErrs = append(allErrs, field.Required(sharedVpcPath.Child("servicesRange"), ""))
+		}
+	}
+	if p := spec.ServicePerimeter; p != nil {
+		if p.AccessPolicy == "" {
+			allErrs = append(allErrs, field.Required(specPath.Child("servicePerimeter", "accessPolicy"), ""))
+		}
+		if p.Perimeter == "" {
+			allErrs = append(allErrs, field.Required(specPath.Child("servicePerimeter", "perimeter"), ""))
+		}
+	}
+	if m := spec.Metadata; m != nil && m.Db != "" && !contains(validMetadataDbs, m.Db) {
+		allErrs = append(allErrs, field.NotSupported(specPath.Child("metadata", "db"), m.Db, validMetadataDbs))
+	}
+	if b := spec.BinaryAuthorization; b != nil {
+		for i, attestor := range b.Attestors {
+			if !attestorPattern.MatchString(attestor) {
+				allErrs = append(allErrs, field.Invalid(specPath.Child("binaryAuthorization", "attestors").Index(i), attestor,
+					"must be an attestor like projects/<project>/attestors/<name>"))
+			}
+		}
+	}
+	if f := spec.Filestore; f != nil {
+		filestorePath := specPath.Child("filestore")
+		if f.Tier != "" && !contains(validFilestoreTiers, f.Tier) {
+			allErrs = append(allErrs, field.NotSupported(filestorePath.Child("tier"), f.Tier, validFilestoreTiers))
+		}
+		if f.CapacityGb < 0 {
+			allErrs = append(allErrs, field.Invalid(filestorePath.Child("capacityGb"), f.CapacityGb, "must not be negative"))
+		}
+	}
+	if i := spec.Istio; i != nil {
+		istioPath := specPath.Child("istio")
+		if i.Profile != "" && !contains(validIstioProfiles, i.Profile) {
+			allErrs = append(allErrs, field.NotSupported(istioPath.Child("profile"), i.Profile, validIstioProfiles))
+		}
+		allErrs = append(allErrs, validateIstioGateway(istioPath.Child("ingressGateway"), i.IngressGateway)...)
+		allErrs = append(allErrs, validateIstioGateway(istioPath.Child("egressGateway"), i.EgressGateway)...)
+	}
+	return allErrs
+}
+
+// validateIstioGateway checks the replicas of gateway g, which may be unset.
+func validateIstioGateway(gatewayPath *field.Path, g *IstioGatewayConfig) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if g == nil {
+		return allErrs
+	}
+	if g.MinReplicas < 0 {
+		allErrs = append(allErrs, field.Invalid(gatewayPath.Child("minReplicas"), g.MinReplicas, "must not be negative"))
+	}
+	if g.MaxReplicas < 0 {
+		allErrs = append(allErrs, field.Invalid(gatewayPath.Child("maxReplicas"), g.MaxReplicas, "must not be negative"))
+	}
+	return allErrs
+}
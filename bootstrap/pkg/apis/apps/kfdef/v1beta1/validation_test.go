@@ -0,0 +1,392 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1beta1
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/kubeflow/kubeflow/bootstrap/config"
+	"github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps/kfdef/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestValidateKfDef(t *testing.T) {
+	valid := func() *KfDef {
+		return &KfDef{
+			ObjectMeta: metav1.ObjectMeta{Name: "kubeflow"},
+			Spec: KfDefSpec{
+				ComponentConfig: config.ComponentConfig{Platform: "gcp"},
+				AppDir:          "/tmp/kubeflow",
+				Project:         "my-project",
+				Zone:            "us-east1-d",
+			},
+		}
+	}
+	tests := []struct {
+		name    string
+		mutate  func(*KfDef)
+		wantErr []string
+	}{
+		{
+			name:   "valid",
+			mutate: func(*KfDef) {},
+		},
+		{
+			name: "unknown platform and bad zone",
+			mutate: func(k *KfDef) {
+				k.Spec.Platform = "aws"
+				k.Spec.Zone = "us-east1"
+			},
+			wantErr: []string{"spec.platform", "spec.zone"},
+		},
+		{
+			name: "auto zone",
+			mutate: func(k *KfDef) {
+				k.Spec.Zone = "auto"
+			},
+		},
+		{
+			name: "gcp without project",
+			mutate: func(k *KfDef) {
+				k.Spec.Project = ""
+			},
+			wantErr: []string{"spec.project"},
+		},
+		{
+			name: "duplicate node pool",
+			mutate: func(k *KfDef) {
+				k.Spec.NodePoolServiceAccounts = []NodePoolServiceAccount{{Pool: "cpu-pool"}, {Pool: "cpu-pool"}}
+			},
+			wantErr: []string{"spec.nodePoolServiceAccounts[1].pool"},
+		},
+		{
+			name: "node pools",
+			mutate: func(k *KfDef) {
+				k.Spec.NodePools = []NodePool{
+					{Pool: "cpu-pool", Nodes: 5, MachineType: "n1-standard-8"},
+					{Pool: "gpu-pool", Nodes: -1},
+				}
+			},
+			wantErr: []string{"spec.nodePools[1].nodes", "spec.nodePools[1].machineType"},
+		},
+		{
+			name: "unknown env",
+			mutate: func(k *KfDef) {
+				k.Spec.ComponentParamOverrides = map[string]config.Parameters{"dev": {}}
+				k.Spec.Env = "prod"
+			},
+			wantErr: []string{"spec.env"},
+		},
+		{
+			name: "iap members",
+			mutate: func(k *KfDef) {
+				k.Spec.IapMembers = []string{"group:ml-team@example.com", "domain:example.com", "ml-team@example.com"}
+			},
+			wantErr: []string{"spec.iapMembers[2]"},
+		},
+		{
+			name: "apis",
+			mutate: func(k *KfDef) {
+				k.Spec.ExtraApis = []string{"tpu.googleapis.com", "projects/p/services/tpu.googleapis.com"}
+				k.Spec.SkipApis = []string{"ml"}
+			},
+			wantErr: []string{"spec.extraApis[1]", "spec.skipApis[0]"},
+		},
+		{
+			name: "byo-secret without key",
+			mutate: func(k *KfDef) {
+				k.Spec.Certificate = &Certificate{Type: "byo-secret", CertFile: "tls.crt"}
+			},
+			wantErr: []string{"spec.certificate.keyFile"},
+		},
+		{
+			name: "oidc without client",
+			mutate: func(k *KfDef) {
+				k.Spec.Auth = &AuthConfig{Provider: "oidc", Oidc: &OidcConfig{Issuer: "http://dex.example.com"}}
+			},
+			wantErr: []string{"spec.auth.oidc.issuer", "spec.auth.oidc.clientId"},
+		},
+		{
+			name: "ldap with basic auth",
+			mutate: func(k *KfDef) {
+				k.Spec.UseBasicAuth = true
+				k.Spec.Auth = &AuthConfig{Provider: "ldap"}
+			},
+			wantErr: []string{"spec.auth.provider", "spec.auth.ldap"},
+		},
+		{
+			name: "template overrides",
+			mutate: func(k *KfDef) {
+				k.Spec.TemplateOverrides = []TemplateOverride{
+					{File: "cluster.jinja", UpstreamSha256: strings.Repeat("ab", 32)},
+					{File: "network.jinja", UpstreamSha256: strings.Repeat("ab", 32)},
+					{File: "storage.jinja", UpstreamSha256: "abc"},
+				}
+			},
+			wantErr: []string{"spec.templateOverrides[1].file", "spec.templateOverrides[2].upstreamSha256"},
+		},
+		{
+			name: "gke",
+			mutate: func(k *KfDef) {
+				k.Spec.Gke = &GkeConfig{
+					ReleaseChannel:       "BETA",
+					MaintenanceStartTime: "3:00",
+					AutoscalingProfile:   "BALANCED",
+				}
+			},
+			wantErr: []string{"spec.gke.releaseChannel", "spec.gke.maintenanceStartTime"},
+		},
+		{
+			name: "gke feature gates",
+			mutate: func(k *KfDef) {
+				k.Spec.Gke = &GkeConfig{
+					ApiVersion: "v1",
+					FeatureGates: map[string]bool{
+						"networkPolicy":    true,
+						"workloadIdentity": true,
+						"sandbox":          false,
+						"istio":            true,
+					},
+				}
+			},
+			wantErr: []string{"spec.gke.featureGates[istio]", "spec.gke.featureGates[workloadIdentity]"},
+		},
+		{
+			name: "gke beta settings with v1",
+			mutate: func(k *KfDef) {
+				k.Spec.EnableTpu = true
+				k.Spec.Gke = &GkeConfig{
+					ApiVersion:             "v1",
+					ReleaseChannel:         "REGULAR",
+					MaintenanceStartTime:   "03:00",
+					AutoscalingProfile:     "BALANCED",
+					VerticalPodAutoscaling: true,
+				}
+			},
+			wantErr: []string{"spec.gke.releaseChannel", "spec.gke.autoscalingProfile",
+				"spec.gke.verticalPodAutoscaling", "spec.enableTpu"},
+		},
+		{
+			name: "gpu",
+			mutate: func(k *KfDef) {
+				k.Spec.Gpu = &GpuConfig{Type: "tesla-k80", Count: 2, MaxNodes: -1}
+			},
+			wantErr: []string{"spec.gpu.type", "spec.gpu.maxNodes"},
+		},
+		{
+			name: "notifications",
+			mutate: func(k *KfDef) {
+				k.Spec.Notifications = []Notification{
+					{Type: "slack", Url: "https://hooks.slack.com/services/T/B/X"},
+					{Type: "http", Url: "hooks"},
+					{Type: "pubsub", Topic: "kubeflow"},
+					{Type: "email"},
+				}
+			},
+			wantErr: []string{"spec.notifications[1].url", "spec.notifications[2].topic", "spec.notifications[3].type"},
+		},
+		{
+			name: "admins and tenants",
+			mutate: func(k *KfDef) {
+				k.Spec.AdminRole = "owner"
+				k.Spec.AdminMembers = []string{"alice@example.com", "group:ml-team@example.com", "bob"}
+				k.Spec.Tenants = []Tenant{{Namespace: "team-a"}, {Namespace: "Team_B"}, {Namespace: "team-a"}}
+			},
+			wantErr: []string{"spec.adminRole", "spec.adminMembers[2]", "spec.tenants[1].namespace",
+				"spec.tenants[2].namespace"},
+		},
+		{
+			name: "hooks",
+			mutate: func(k *KfDef) {
+				k.Spec.Hooks = []Hook{
+					{Name: "policy", Step: "generate", When: "post", Command: []string{"conftest", "test", "."}},
+					{Name: "inventory", Step: "apply", When: "post", Url: "https://inventory.example.com"},
+					{Name: "both", Step: "delete", When: "pre"},
+				}
+			},
+			wantErr: []string{"spec.hooks[1].step", "spec.hooks[2]"},
+		},
+		{
+			name: "shared vpc without host project",
+			mutate: func(k *KfDef) {
+				k.Spec.SharedVpc = &SharedVpcConfig{Network: "shared", Subnetwork: "kubeflow", PodsRange: "pods"}
+			},
+			wantErr: []string{"spec.hostProject", "spec.sharedVpc.servicesRange"},
+		},
+		{
+			name: "filestore, istio and metadata",
+			mutate: func(k *KfDef) {
+				k.Spec.ManifestsTool = "helm"
+				k.Spec.Filestore = &FilestoreConfig{Tier: "BASIC_HDD"}
+				k.Spec.Istio = &IstioConfig{Profile: "mtls-strict", EgressGateway: &IstioGatewayConfig{MaxReplicas: -1}}
+				k.Spec.Metadata = &MetadataConfig{Db: "mysql"}
+			},
+			wantErr: []string{"spec.manifestsTool", "spec.metadata.db", "spec.filestore.tier",
+				"spec.istio.egressGateway.maxReplicas"},
+		},
+		{
+			name: "private cluster and autoprovisioning on v1",
+			mutate: func(k *KfDef) {
+				k.Spec.Gke = &GkeConfig{
+					ApiVersion:     "v1",
+					PrivateCluster: true,
+					Autoprovisioning: &AutoprovisioningConfig{
+						Enabled: true,
+						MaxCpu:  64,
+						Accelerators: []AutoprovisioningAccelerator{
+							{Type: "nvidia-tesla-k80", Max: 4},
+							{Type: "nvidia-tesla-v100", Min: 2, Max: 1},
+						},
+					},
+				}
+			},
+			wantErr: []string{"spec.gke.privateCluster", "spec.gke.autoprovisioning",
+				"spec.gke.autoprovisioning.accelerators[1].max"},
+		},
+	}
+	for _, test := range tests {
+		kfdef := valid()
+		test.mutate(kfdef)
+		errs := ValidateKfDef(kfdef)
+		if len(errs) != len(test.wantErr) {
+			t.Errorf("%v: got errors %v, want errors on %v", test.name, errs, test.wantErr)
+			continue
+		}
+		for i, err := range errs {
+			if err.Field != test.wantErr[i] {
+				t.Errorf("%v: got error on %v, want %v", test.name, err.Field, test.wantErr[i])
+			}
+		}
+	}
+}
+
+func TestConversionRoundTrip(t *testing.T) {
+	gateway := &v1alpha1.IstioGatewayConfig{MinReplicas: 2, MaxReplicas: 10, Cpu: "500m", Memory: "256Mi"}
+	in := &v1alpha1.KfDef{
+		ObjectMeta: metav1.ObjectMeta{Name: "kubeflow"},
+		Spec: v1alpha1.KfDefSpec{
+			AppDir:  "/tmp/kubeflow",
+			Project: "my-project",
+			NodePoolServiceAccounts: []v1alpha1.NodePoolServiceAccount{
+				{Pool: "gpu-pool", Roles: []string{"roles/storage.objectViewer"}},
+			},
+			DeleteFilestore:  true,
+			IpReserved:       true,
+			DeleteIp:         true,
+			SnapshotStorage:  true,
+			StorageSnapshots: []v1alpha1.StorageSnapshot{{Disk: "kubeflow-storage-metadata-store", Snapshot: "s1", CreationTime: "2019-04-01T00:00:00Z"}},
+			CloudAuditLog:    true,
+			CaBundle:         "/etc/ssl/proxy.pem",
+			RepoSha:          "0123456789abcdef0123456789abcdef01234567",
+			RepoChecksum:     "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef",
+			ComponentMatrix: []v1alpha1.ComponentSpec{
+				{Name: "spartakus", Enabled: false},
+				{Name: "seldon", Enabled: true, Params: []config.NameValue{{Name: "replicas", Value: "2"}}, Requires: []string{"istio"}},
+			},
+			UsageReporting: &v1alpha1.UsageReportingConfig{Enabled: true, UsageId: "42"},
+			Region:         "us-east1",
+			Hooks: []v1alpha1.Hook{
+				{Name: "policy", Step: "generate", When: "post", Command: []string{"conftest"}, TimeoutSeconds: 60, IgnoreFailure: true},
+			},
+			AdminRole:                 "kubeflow-admin",
+			AdminMembers:              []string{"group:ml-admins@example.com"},
+			Tenants:                   []v1alpha1.Tenant{{Namespace: "team-a", Admins: []string{"alice@example.com"}, Members: []string{"bob@example.com"}}},
+			DisableServiceAccountKeys: true,
+			ManifestsTool:             "kustomize",
+			NamespacePrefix:           "mlplatform",
+			TemplateSha256:            map[string]string{"cluster.jinja": "abc"},
+			Gke: &v1alpha1.GkeConfig{
+				ApiVersion:     "v1beta1",
+				InitialVersion: "1.12.7-gke.10",
+				PrivateCluster: true,
+				Autoprovisioning: &v1alpha1.AutoprovisioningConfig{
+					Enabled:        true,
+					MaxCpu:         64,
+					MaxMemory:      256,
+					Accelerators:   []v1alpha1.AutoprovisioningAccelerator{{Type: "nvidia-tesla-k80", Max: 4}},
+					ServiceAccount: "nap@my-project.iam.gserviceaccount.com",
+					OauthScopes:    []string{"https://www.googleapis.com/auth/cloud-platform"},
+				},
+			},
+			HostProject:         "host-project",
+			SharedVpc:           &v1alpha1.SharedVpcConfig{Network: "shared", Subnetwork: "kubeflow", PodsRange: "pods", ServicesRange: "services"},
+			DeploymentManagerSA: "dm@my-project.iam.gserviceaccount.com",
+			SecretsSync:         true,
+			ServicePerimeter:    &v1alpha1.ServicePerimeterConfig{AccessPolicy: "123", Perimeter: "kubeflow", AddProject: true},
+			Metadata:            &v1alpha1.MetadataConfig{Db: "cloudsql-postgres", Tier: "db-custom-2-7680"},
+			BinaryAuthorization: &v1alpha1.BinaryAuthorizationConfig{
+				AdmitPatterns: []string{"us.gcr.io/my-team/*"},
+				Attestors:     []string{"projects/my-project/attestors/built-by-ci"},
+				DryRun:        true,
+				ApplyPolicy:   true,
+			},
+			Filestore: &v1alpha1.FilestoreConfig{Tier: "PREMIUM", CapacityGb: 2560, Network: "default"},
+			Istio:     &v1alpha1.IstioConfig{Profile: "mtls-permissive", IngressGateway: gateway, EgressGateway: gateway},
+		},
+	}
+	out := ConvertToV1alpha1(ConvertFromV1alpha1(in))
+	if !reflect.DeepEqual(out.Spec, in.Spec) {
+		t.Errorf("round trip changed the spec:\ngot  %+v\nwant %+v", out.Spec, in.Spec)
+	}
+	if out.APIVersion != v1alpha1.SchemeGroupVersion.String() {
+		t.Errorf("apiVersion = %v, want %v", out.APIVersion, v1alpha1.SchemeGroupVersion.String())
+	}
+}
+
+func TestComponentParamOverridesConversion(t *testing.T) {
+	in := &KfDef{
+		ObjectMeta: metav1.ObjectMeta{Name: "kubeflow"},
+		Spec: KfDefSpec{
+			ComponentConfig: config.ComponentConfig{
+				ComponentParams: config.Parameters{
+					"iap-ingress": {{Name: "hostname", Value: "kf.example.com"}},
+				},
+			},
+			ComponentParamOverrides: map[string]config.Parameters{
+				"dev": {"iap-ingress": {{Name: "hostname", Value: "dev.example.com"}}},
+			},
+			Env:       "dev",
+			ExtraApis: []string{"bigquery.googleapis.com"},
+			SkipApis:  []string{"file.googleapis.com"},
+		},
+	}
+	out := ConvertToV1alpha1(in)
+	want := config.Parameters{"iap-ingress": {{Name: "hostname", Value: "dev.example.com"}}}
+	if got := out.Spec.GetComponentParams(); !reflect.DeepEqual(got, want) {
+		t.Errorf("GetComponentParams of env dev = %v; want %v", got, want)
+	}
+	out.Spec.Env = ""
+	if got := out.Spec.GetComponentParams(); got["iap-ingress"][0].Value != "kf.example.com" {
+		t.Errorf("GetComponentParams without an env = %v; want the componentParams", got)
+	}
+
+	// The converted KfDef doesn't share its maps and slices with the one it was converted from.
+	out.Spec.ComponentParamOverrides["dev"]["iap-ingress"][0].Value = "changed"
+	out.Spec.ComponentParamOverrides["prod"] = config.Parameters{}
+	out.Spec.ExtraApis[0] = "changed"
+	out.Spec.SkipApis[0] = "changed"
+	back := ConvertFromV1alpha1(out)
+	back.Spec.ComponentParamOverrides["dev"]["iap-ingress"][0].Value = "changed back"
+	if in.Spec.ComponentParamOverrides["dev"]["iap-ingress"][0].Value != "dev.example.com" ||
+		len(in.Spec.ComponentParamOverrides) != 1 || in.Spec.ExtraApis[0] != "bigquery.googleapis.com" ||
+		in.Spec.SkipApis[0] != "file.googleapis.com" {
+		t.Errorf("changing the converted KfDef changed the original: %+v", in.Spec)
+	}
+	if out.Spec.ComponentParamOverrides["dev"]["iap-ingress"][0].Value != "changed" {
+		t.Errorf("changing the KfDef converted back changed the converted one")
+	}
+}
@@ -21,10 +21,11 @@ import (
 type StatusCode int
 
 const (
-	OK               StatusCode = 200
-	INVALID_ARGUMENT StatusCode = 400
-	INTERNAL_ERROR   StatusCode = 500
-	UNKNOWN          StatusCode = 520
+	OK                StatusCode = 200
+	INVALID_ARGUMENT  StatusCode = 400
+	PERMISSION_DENIED StatusCode = 403
+	INTERNAL_ERROR    StatusCode = 500
+	UNKNOWN           StatusCode = 520
 )
 
 // KfError stands for Kubeflow error. This is the standard error interface
@@ -0,0 +1,235 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package deploy is a Go API to provision Kubeflow on GCP from other programs, e.g. controllers.
+//
+//	d, err := deploy.NewDeployment(deploy.Config{...})
+//	err = d.Apply(ctx)
+//
+// Unlike kfctl it doesn't read an app dir, environment variables or gcloud: everything is taken
+// from Config. It covers the platform resources (deployment manager deployments, IAM bindings,
+// the secrets kubeflow expects and Istio); kubeflow components are deployed separately.
+package deploy
+
+import (
+	"fmt"
+	configtypes "github.com/kubeflow/kubeflow/bootstrap/config"
+	kfapis "github.com/kubeflow/kubeflow/bootstrap/pkg/apis"
+	kftypes "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps"
+	kfdefs "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps/kfdef/v1alpha1"
+	"github.com/kubeflow/kubeflow/bootstrap/pkg/kfapp/assets"
+	"github.com/kubeflow/kubeflow/bootstrap/pkg/kfapp/gcp"
+	"golang.org/x/net/context"
+	"golang.org/x/oauth2"
+	valid "k8s.io/apimachinery/pkg/api/validation"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"net/http"
+	"strings"
+)
+
+// Config describes a kubeflow deployment on GCP.
+type Config struct {
+	// Name of the deployment, used for the GKE cluster and the deployment manager deployments.
+	Name      string
+	Namespace string
+	Project   string
+	Zone      string
+	// Email is granted access to the deployment.
+	Email    string
+	IpName   string
+	Hostname string
+	Version  string
+	// Repo is a local checkout of kubeflow/kubeflow/kubeflow; the deployment manager templates
+	// are read from its sibling deployment/gke directory. It's unused with Assets, and the
+	// assets built into the package are used without either.
+	Repo string
+	// Assets reads the deployment manager templates and manifests instead of Repo, e.g. from
+	// a GCS bucket with assets.NewGCSLoader or the ones built in with assets.Embedded.
+	Assets       assets.Loader
+	UseBasicAuth bool
+	UseIstio     bool
+	// Auth holds the basic auth or IAP credentials matching UseBasicAuth.
+	// Auth.Password is zeroed by NewDeployment once hashed.
+	Auth gcp.Auth
+	// BcryptCost is the cost of the basic auth password hash; bcrypt's default when 0.
+	BcryptCost int
+	// Client and TokenSource authenticate the calls to GCP.
+	Client      *http.Client
+	TokenSource oauth2.TokenSource
+	// WorkDir is where the generated deployment manager configs are written.
+	// They're only kept in memory when empty.
+	WorkDir string
+	// Options are passed on to gcp.NewGcp after the client and token source, e.g. gcp.WithClock
+	// in tests.
+	Options []gcp.Option
+	// KfDef, when set, is the app deployed, e.g. one built in memory by a controller. It's used
+	// instead of the fields of the app above, from Name to BcryptCost, with their defaults
+	// filled in where it leaves them unset. It isn't modified.
+	KfDef *kfdefs.KfDef
+}
+
+// Deployment provisions the GCP resources described by a Config.
+type Deployment struct {
+	platform *gcp.Gcp
+}
+
+// NewDeployment validates config, fills in the same defaults kfctl uses and returns a Deployment.
+// kfDef returns a copy of config.KfDef, or the KfDef of the fields of config.
+func (config *Config) kfDef() *kfdefs.KfDef {
+	if config.KfDef != nil {
+		kfdef := config.KfDef.DeepCopy()
+		if kfdef.Spec.Platform == "" {
+			kfdef.Spec.Platform = kftypes.GCP
+		}
+		if kfdef.Spec.ComponentParams == nil {
+			kfdef.Spec.ComponentParams = make(configtypes.Parameters)
+		}
+		if kfdef.Spec.AppDir == "" {
+			kfdef.Spec.AppDir = config.WorkDir
+		}
+		if kfdef.Spec.AppDirVersion == 0 {
+			kfdef.Spec.AppDirVersion = kftypes.AppDirVersion
+		}
+		return kfdef
+	}
+	return &kfdefs.KfDef{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "KfDef",
+			APIVersion: "kfdef.apps.kubeflow.org/v1alpha1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      config.Name,
+			Namespace: config.Namespace,
+		},
+		Spec: kfdefs.KfDefSpec{
+			ComponentConfig: configtypes.ComponentConfig{
+				Repo:            config.Repo,
+				Platform:        kftypes.GCP,
+				ComponentParams: make(configtypes.Parameters),
+			},
+			AppDir:        config.WorkDir,
+			AppDirVersion: kftypes.AppDirVersion,
+			Version:       config.Version,
+			Project:       config.Project,
+			Email:         config.Email,
+			IpName:        config.IpName,
+			Hostname:      config.Hostname,
+			Zone:          config.Zone,
+			UseBasicAuth:  config.UseBasicAuth,
+			UseIstio:      config.UseIstio,
+			BcryptCost:    config.BcryptCost,
+		},
+	}
+}
+
+// NewDeployment returns the deployment of config. The DM templates and manifests are read from
+// config.Assets, the repo of the app, or the ones built into the package when neither is set.
+func NewDeployment(config Config) (*Deployment, error) {
+	kfdef := config.kfDef()
+	if errs := valid.NameIsDNSLabel(kfdef.Name, false); len(errs) > 0 {
+		return nil, &kfapis.KfError{
+			Code:    int(kfapis.INVALID_ARGUMENT),
+			Message: fmt.Sprintf("invalid name %v: %v", kfdef.Name, strings.Join(errs, ", ")),
+		}
+	}
+	missing := []string{}
+	if kfdef.Spec.Project == "" {
+		missing = append(missing, "Project")
+	}
+	if kfdef.Spec.Email == "" {
+		missing = append(missing, "Email")
+	}
+	if config.Client == nil {
+		missing = append(missing, "Client")
+	}
+	if config.TokenSource == nil {
+		missing = append(missing, "TokenSource")
+	}
+	if len(missing) > 0 {
+		return nil, &kfapis.KfError{
+			Code:    int(kfapis.INVALID_ARGUMENT),
+			Message: fmt.Sprintf("missing required config fields: %v", strings.Join(missing, ", ")),
+		}
+	}
+	if kfdef.Namespace == "" {
+		kfdef.Namespace = kftypes.DefaultNamespace
+	}
+	if kfdef.Spec.Zone == "" {
+		kfdef.Spec.Zone = kftypes.DefaultZone
+	}
+	if kfdef.Spec.Version == "" {
+		kfdef.Spec.Version = kftypes.DefaultVersion
+	}
+	if kfdef.Spec.IpName == "" {
+		kfdef.Spec.IpName = kfdef.Name + "-ip"
+	}
+	if kfdef.Spec.Hostname == "" {
+		kfdef.Spec.Hostname = fmt.Sprintf("%v.endpoints.%v.cloud.goog", kfdef.Name, kfdef.Spec.Project)
+	}
+	if config.Assets == nil && kfdef.Spec.Repo == "" {
+		config.Assets = assets.Embedded
+	}
+
+	opts := []gcp.Option{gcp.WithClient(config.Client), gcp.WithTokenSource(config.TokenSource)}
+	if config.WorkDir != "" {
+		opts = append(opts, gcp.WithConfigStore(gcp.NewAppDirStore(config.WorkDir)))
+	}
+	if config.Assets != nil {
+		opts = append(opts, gcp.WithAssetLoader(config.Assets))
+	}
+	opts = append(opts, config.Options...)
+	platform, err := gcp.NewGcp(kfdef, config.Auth, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &Deployment{
+		platform: platform,
+	}, nil
+}
+
+// Apply generates the deployment manager configs and creates or updates the GCP resources.
+// ctx is checked between steps; a step that has started runs to completion.
+func (d *Deployment) Apply(ctx context.Context) error {
+	// The platform is applied with its resources in the cluster, Istio and the secrets.
+	steps := []struct {
+		name      string
+		run       func(kftypes.ResourceEnum) error
+		resources kftypes.ResourceEnum
+	}{
+		{"generate", d.platform.Generate, kftypes.PLATFORM},
+		{"apply", d.platform.Apply, kftypes.ALL},
+	}
+	for _, step := range steps {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := step.run(step.resources); err != nil {
+			return fmt.Errorf("couldn't %v deployment %v: %v", step.name, d.platform.Name, err)
+		}
+	}
+	return nil
+}
+
+// Delete removes the GCP resources of the deployment. The storage deployment is kept
+// unless deleteStorage is set.
+func (d *Deployment) Delete(ctx context.Context, deleteStorage bool) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	d.platform.Spec.DeleteStorage = deleteStorage
+	if err := d.platform.Delete(kftypes.PLATFORM); err != nil {
+		return fmt.Errorf("couldn't delete deployment %v: %v", d.platform.Name, err)
+	}
+	return nil
+}
@@ -0,0 +1,300 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	kfapis "github.com/kubeflow/kubeflow/bootstrap/pkg/apis"
+	kfdefs "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps/kfdef/v1alpha1"
+	"github.com/kubeflow/kubeflow/bootstrap/pkg/kfapp/assets"
+	"github.com/kubeflow/kubeflow/bootstrap/pkg/kfapp/gcp"
+	"golang.org/x/net/context"
+	"golang.org/x/oauth2"
+	"google.golang.org/api/deploymentmanager/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type fakeClock struct{}
+
+func (fakeClock) Now() time.Time {
+	return time.Date(2019, 4, 1, 0, 0, 0, 0, time.UTC)
+}
+
+func (fakeClock) Sleep(time.Duration) {}
+
+// redirect sends the requests to the Google APIs to server.
+type redirect struct {
+	server *url.URL
+}
+
+func (r redirect) RoundTrip(req *http.Request) (*http.Response, error) {
+	out := *req
+	u := *req.URL
+	u.Scheme = r.server.Scheme
+	u.Host = r.server.Host
+	out.URL = &u
+	out.Host = r.server.Host
+	return http.DefaultTransport.RoundTrip(&out)
+}
+
+// fakeApis serves the DM and org policy calls of an apply of the storage deployment. There's
+// no deployment yet and its insert is done at once, unless insertError is set.
+type fakeApis struct {
+	mu          sync.Mutex
+	requests    []string
+	inserted    []*deploymentmanager.Deployment
+	insertError int
+}
+
+func (f *fakeApis) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.requests = append(f.requests, req.Method+" "+req.URL.Path)
+	w.Header().Set("Content-Type", "application/json")
+	switch {
+	case req.Method == "GET" && strings.Contains(req.URL.Path, "/global/deployments/"):
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"error": {"code": 404, "message": "not found"}}`)
+	case req.Method == "POST" && strings.HasSuffix(req.URL.Path, "/global/deployments"):
+		if f.insertError != 0 {
+			w.WriteHeader(f.insertError)
+			fmt.Fprintf(w, `{"error": {"code": %v, "message": "insert refused"}}`, f.insertError)
+			return
+		}
+		d := &deploymentmanager.Deployment{}
+		if err := json.NewDecoder(req.Body).Decode(d); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, `{"error": {"code": 400, "message": %q}}`, err.Error())
+			return
+		}
+		f.inserted = append(f.inserted, d)
+		fmt.Fprint(w, `{"name": "op-insert", "status": "DONE"}`)
+	case req.Method == "GET" && strings.Contains(req.URL.Path, "/global/operations/"):
+		fmt.Fprint(w, `{"name": "op-insert", "status": "DONE"}`)
+	case req.Method == "POST" && strings.HasSuffix(req.URL.Path, ":getEffectiveOrgPolicy"):
+		fmt.Fprint(w, `{}`)
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, `{"error": {"code": 400, "message": "unexpected %v %v"}}`, req.Method, req.URL.Path)
+	}
+}
+
+// newTestDeployment returns a Deployment of the storage of kf calling apis.
+func newTestDeployment(t *testing.T, apis *fakeApis) (*Deployment, func()) {
+	server := httptest.NewServer(apis)
+	serverUrl, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	d, err := NewDeployment(Config{
+		Name:        "kf",
+		Project:     "my-project",
+		Email:       "user@example.com",
+		Assets:      assets.Embedded,
+		Auth:        gcp.Auth{OAuthClientId: "id", OAuthClientSecret: "secret"},
+		Client:      &http.Client{Transport: redirect{serverUrl}},
+		TokenSource: oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "token"}),
+		Options:     []gcp.Option{gcp.WithClock(fakeClock{})},
+	})
+	if err != nil {
+		server.Close()
+		t.Fatalf("NewDeployment failed: %v", err)
+	}
+	d.platform.Spec.Targets = []string{gcp.COMPONENT_STORAGE}
+	return d, server.Close
+}
+
+func TestNewDeploymentConfig(t *testing.T) {
+	valid := Config{
+		Name:        "kf",
+		Project:     "my-project",
+		Email:       "user@example.com",
+		Assets:      assets.Embedded,
+		Auth:        gcp.Auth{OAuthClientId: "id", OAuthClientSecret: "secret"},
+		Client:      &http.Client{},
+		TokenSource: oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "token"}),
+	}
+	cases := []struct {
+		name   string
+		modify func(*Config)
+	}{
+		{"invalid name", func(c *Config) { c.Name = "Kf_App" }},
+		{"missing project", func(c *Config) { c.Project = "" }},
+		{"missing email", func(c *Config) { c.Email = "" }},
+		{"missing client", func(c *Config) { c.Client = nil }},
+		{"missing token source", func(c *Config) { c.TokenSource = nil }},
+	}
+	for _, c := range cases {
+		config := valid
+		c.modify(&config)
+		_, err := NewDeployment(config)
+		kfErr, ok := err.(*kfapis.KfError)
+		if !ok || kfErr.Code != int(kfapis.INVALID_ARGUMENT) {
+			t.Errorf("%v: NewDeployment = %v; want an invalid argument error", c.name, err)
+		}
+	}
+
+	d, err := NewDeployment(valid)
+	if err != nil {
+		t.Fatalf("NewDeployment failed: %v", err)
+	}
+	spec := d.platform.Spec
+	if d.platform.Namespace != "kubeflow" || spec.Zone != "us-east1-d" || spec.IpName != "kf-ip" ||
+		spec.Hostname != "kf.endpoints.my-project.cloud.goog" {
+		t.Errorf("NewDeployment defaults: namespace %v, zone %v, ipName %v, hostname %v", d.platform.Namespace,
+			spec.Zone, spec.IpName, spec.Hostname)
+	}
+}
+
+func TestNewDeploymentKfDef(t *testing.T) {
+	apis := &fakeApis{}
+	server := httptest.NewServer(apis)
+	defer server.Close()
+	serverUrl, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	kfdef := &kfdefs.KfDef{
+		ObjectMeta: metav1.ObjectMeta{Name: "team-kf"},
+		Spec: kfdefs.KfDefSpec{
+			Project: "team-project",
+			Email:   "team@example.com",
+			Zone:    "us-west1-b",
+			Targets: []string{gcp.COMPONENT_STORAGE},
+		},
+	}
+	// The fields of the app are ignored with a KfDef, and the built in assets are used without
+	// a repo.
+	d, err := NewDeployment(Config{
+		Name:        "ignored",
+		Project:     "ignored-project",
+		KfDef:       kfdef,
+		Auth:        gcp.Auth{OAuthClientId: "id", OAuthClientSecret: "secret"},
+		Client:      &http.Client{Transport: redirect{serverUrl}},
+		TokenSource: oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "token"}),
+		Options:     []gcp.Option{gcp.WithClock(fakeClock{})},
+	})
+	if err != nil {
+		t.Fatalf("NewDeployment of a KfDef failed: %v", err)
+	}
+	spec := d.platform.Spec
+	if d.platform.Name != "team-kf" || d.platform.Namespace != "kubeflow" || spec.Project != "team-project" ||
+		spec.Zone != "us-west1-b" || spec.Hostname != "team-kf.endpoints.team-project.cloud.goog" {
+		t.Errorf("NewDeployment of a KfDef: name %v, namespace %v, project %v, zone %v, hostname %v",
+			d.platform.Name, d.platform.Namespace, spec.Project, spec.Zone, spec.Hostname)
+	}
+	if kfdef.Namespace != "" || kfdef.Spec.Hostname != "" {
+		t.Errorf("NewDeployment modified the KfDef: %+v", kfdef)
+	}
+	if err = d.Apply(context.Background()); err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+	if len(apis.inserted) != 1 || apis.inserted[0].Name != "team-kf-storage" {
+		t.Errorf("Apply of a KfDef made requests %v; want the storage deployment of team-kf", apis.requests)
+	}
+
+	kfdef.Spec.Email = ""
+	if _, err = NewDeployment(Config{KfDef: kfdef, Client: &http.Client{},
+		TokenSource: oauth2.StaticTokenSource(&oauth2.Token{})}); err == nil {
+		t.Errorf("NewDeployment of a KfDef without an email succeeded")
+	}
+}
+
+func TestApplyInsertsDeployment(t *testing.T) {
+	apis := &fakeApis{}
+	d, done := newTestDeployment(t, apis)
+	defer done()
+	if err := d.Apply(context.Background()); err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+	if len(apis.inserted) != 1 {
+		t.Fatalf("got %v deployment inserts in %v; want the storage deployment", len(apis.inserted), apis.requests)
+	}
+	inserted := apis.inserted[0]
+	if inserted.Name != "kf-storage" {
+		t.Errorf("inserted deployment %v; want kf-storage", inserted.Name)
+	}
+	labels := map[string]string{}
+	for _, label := range inserted.Labels {
+		labels[label.Key] = label.Value
+	}
+	if labels[gcp.LABEL_CREATED_BY] != gcp.CREATED_BY_KFCTL || labels[gcp.LABEL_NAME] != "kf" ||
+		labels[gcp.LABEL_COMPONENT] != gcp.COMPONENT_STORAGE || labels[gcp.LABEL_DEPLOYMENT_ID] == "" {
+		t.Errorf("inserted deployment has labels %v", labels)
+	}
+	if inserted.Target == nil || inserted.Target.Config == nil ||
+		!strings.Contains(inserted.Target.Config.Content, "resources") {
+		t.Errorf("inserted deployment has no config: %+v", inserted.Target)
+	}
+	if len(inserted.Target.Imports) == 0 {
+		t.Errorf("inserted deployment has no imports")
+	}
+}
+
+func TestApplyErrors(t *testing.T) {
+	apis := &fakeApis{insertError: http.StatusForbidden}
+	d, done := newTestDeployment(t, apis)
+	defer done()
+	err := d.Apply(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "couldn't apply deployment kf") ||
+		!strings.Contains(err.Error(), "insert refused") {
+		t.Errorf("Apply = %v; want the refused insert", err)
+	}
+
+	apis = &fakeApis{}
+	d, done = newTestDeployment(t, apis)
+	defer done()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err = d.Apply(ctx); err != context.Canceled {
+		t.Errorf("Apply with a canceled context = %v; want %v", err, context.Canceled)
+	}
+	if err = d.Delete(ctx, false); err != context.Canceled {
+		t.Errorf("Delete with a canceled context = %v; want %v", err, context.Canceled)
+	}
+	if len(apis.requests) > 0 {
+		t.Errorf("requests made with a canceled context: %v", apis.requests)
+	}
+}
+
+func TestRedirect(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, _ := ioutil.ReadAll(req.Body)
+		fmt.Fprintf(w, "%v %v %s", req.Method, req.URL.Path, body)
+	}))
+	defer server.Close()
+	serverUrl, _ := url.Parse(server.URL)
+	client := &http.Client{Transport: redirect{serverUrl}}
+	resp, err := client.Post("https://www.googleapis.com/deploymentmanager/v2/projects/p/global/deployments",
+		"application/json", strings.NewReader("{}"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, _ := ioutil.ReadAll(resp.Body)
+	if string(body) != "POST /deploymentmanager/v2/projects/p/global/deployments {}" {
+		t.Errorf("redirected request = %s", body)
+	}
+}
@@ -0,0 +1,180 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package marketplace adapts the deploy package to the GCP Cloud Marketplace deployer contract,
+// so the Marketplace listing deploys kubeflow with the same code as kfctl.
+//
+//	values, err := marketplace.LoadValues(marketplace.ValuesFile)
+//	status := marketplace.Apply(ctx, values, marketplace.Environment{...})
+//	err = status.Write(os.Stdout)
+//
+// The values are the properties of schema.yaml, as written by the deployer; nothing is read
+// from the environment, gcloud or a terminal.
+package marketplace
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/ghodss/yaml"
+	kfapis "github.com/kubeflow/kubeflow/bootstrap/pkg/apis"
+	"github.com/kubeflow/kubeflow/bootstrap/pkg/client/deploy"
+	"github.com/kubeflow/kubeflow/bootstrap/pkg/kfapp/gcp"
+	"golang.org/x/net/context"
+	"golang.org/x/oauth2"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+)
+
+// ValuesFile is where the deployer finds the values of the schema.yaml properties.
+const ValuesFile = "/data/values.yaml"
+
+// Phases of a Status.
+const (
+	PhaseSucceeded = "Succeeded"
+	PhaseFailed    = "Failed"
+)
+
+// Values are the properties of schema.yaml.
+type Values struct {
+	Name         string `json:"name"`
+	Namespace    string `json:"namespace,omitempty"`
+	Project      string `json:"project"`
+	Zone         string `json:"zone,omitempty"`
+	Email        string `json:"email"`
+	IpName       string `json:"ipName,omitempty"`
+	Hostname     string `json:"hostname,omitempty"`
+	Version      string `json:"version,omitempty"`
+	UseBasicAuth bool   `json:"useBasicAuth,omitempty"`
+	UseIstio     bool   `json:"useIstio,omitempty"`
+	// Username and Password are used with basic auth, Password is generated by the Marketplace.
+	Username   string `json:"username,omitempty"`
+	Password   string `json:"password,omitempty"`
+	BcryptCost int    `json:"bcryptCost,omitempty"`
+	// OAuthClientId and OAuthClientSecret are used with IAP.
+	OAuthClientId     string `json:"oauthClientId,omitempty"`
+	OAuthClientSecret string `json:"oauthClientSecret,omitempty"`
+}
+
+// Environment is what the deployer image provides besides the values.
+type Environment struct {
+	// Repo, when set, is a checkout of kubeflow/kubeflow/kubeflow in the deployer image. The
+	// deployment manager templates and manifests built into kfctl are used when it's empty.
+	Repo string
+	// Client and TokenSource authenticate the calls to GCP, usually as the deployer's
+	// service account.
+	Client      *http.Client
+	TokenSource oauth2.TokenSource
+	// WorkDir is where the generated deployment manager configs are written; a temporary
+	// directory when empty.
+	WorkDir string
+}
+
+// Status is the outcome of Apply reported back to the deployer.
+type Status struct {
+	Phase    string    `json:"phase"`
+	Name     string    `json:"name"`
+	Project  string    `json:"project"`
+	Hostname string    `json:"hostname,omitempty"`
+	Code     int       `json:"code,omitempty"`
+	Message  string    `json:"message,omitempty"`
+	Time     time.Time `json:"time"`
+}
+
+// Write writes the status as JSON.
+func (status *Status) Write(w io.Writer) error {
+	buf, err := json.MarshalIndent(status, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(buf, '\n'))
+	return err
+}
+
+// LoadValues reads the values written by the deployer to valuesFile.
+func LoadValues(valuesFile string) (*Values, error) {
+	buf, err := ioutil.ReadFile(valuesFile)
+	if err != nil {
+		return nil, &kfapis.KfError{
+			Code:    int(kfapis.INVALID_ARGUMENT),
+			Message: fmt.Sprintf("couldn't read values %v: %v", valuesFile, err),
+		}
+	}
+	values := &Values{}
+	if err = yaml.Unmarshal(buf, values); err != nil {
+		return nil, &kfapis.KfError{
+			Code:    int(kfapis.INVALID_ARGUMENT),
+			Message: fmt.Sprintf("couldn't parse values %v: %v", valuesFile, err),
+		}
+	}
+	return values, nil
+}
+
+// Config maps values onto the deploy package's Config.
+func (values *Values) Config(env Environment) deploy.Config {
+	return deploy.Config{
+		Name:         values.Name,
+		Namespace:    values.Namespace,
+		Project:      values.Project,
+		Zone:         values.Zone,
+		Email:        values.Email,
+		IpName:       values.IpName,
+		Hostname:     values.Hostname,
+		Version:      values.Version,
+		Repo:         env.Repo,
+		UseBasicAuth: values.UseBasicAuth,
+		UseIstio:     values.UseIstio,
+		Auth: gcp.Auth{
+			Username:          values.Username,
+			Password:          []byte(values.Password),
+			OAuthClientId:     values.OAuthClientId,
+			OAuthClientSecret: values.OAuthClientSecret,
+		},
+		BcryptCost:  values.BcryptCost,
+		Client:      env.Client,
+		TokenSource: env.TokenSource,
+		WorkDir:     env.WorkDir,
+	}
+}
+
+// Apply deploys kubeflow as described by values. Errors are reported in the returned Status,
+// with the KfError code when there is one.
+func Apply(ctx context.Context, values *Values, env Environment) *Status {
+	config := values.Config(env)
+	status := &Status{
+		Name:    values.Name,
+		Project: values.Project,
+	}
+	d, err := deploy.NewDeployment(config)
+	if err == nil {
+		err = d.Apply(ctx)
+	}
+	status.Time = time.Now()
+	if err != nil {
+		status.Phase = PhaseFailed
+		status.Code = int(kfapis.INTERNAL_ERROR)
+		if kfErr, ok := err.(*kfapis.KfError); ok {
+			status.Code = kfErr.Code
+		}
+		status.Message = err.Error()
+		return status
+	}
+	status.Phase = PhaseSucceeded
+	status.Hostname = values.Hostname
+	if status.Hostname == "" {
+		status.Hostname = fmt.Sprintf("%v.endpoints.%v.cloud.goog", values.Name, values.Project)
+	}
+	return status
+}
@@ -0,0 +1,122 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package marketplace
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	kfapis "github.com/kubeflow/kubeflow/bootstrap/pkg/apis"
+	"golang.org/x/net/context"
+	"golang.org/x/oauth2"
+)
+
+func TestLoadValues(t *testing.T) {
+	dir, err := ioutil.TempDir("", "marketplace")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	valuesFile := filepath.Join(dir, "values.yaml")
+	err = ioutil.WriteFile(valuesFile, []byte(`name: kf
+project: my-project
+email: user@example.com
+useBasicAuth: true
+username: admin
+password: secret
+`), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	values, err := LoadValues(valuesFile)
+	if err != nil {
+		t.Fatalf("LoadValues: %v", err)
+	}
+	expected := &Values{
+		Name:         "kf",
+		Project:      "my-project",
+		Email:        "user@example.com",
+		UseBasicAuth: true,
+		Username:     "admin",
+		Password:     "secret",
+	}
+	if !reflect.DeepEqual(values, expected) {
+		t.Errorf("LoadValues got %+v; want %+v", values, expected)
+	}
+
+	if _, err = LoadValues(filepath.Join(dir, "missing.yaml")); err == nil {
+		t.Errorf("LoadValues should fail on a missing file")
+	}
+	if err = ioutil.WriteFile(valuesFile, []byte("name: [kf"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = LoadValues(valuesFile); err == nil {
+		t.Errorf("LoadValues should fail on invalid yaml")
+	}
+}
+
+func TestConfig(t *testing.T) {
+	values := &Values{
+		Name:              "kf",
+		Project:           "my-project",
+		Email:             "user@example.com",
+		OAuthClientId:     "id",
+		OAuthClientSecret: "secret",
+	}
+	client := &http.Client{}
+	config := values.Config(Environment{Client: client, WorkDir: "/tmp/kf"})
+	if config.Name != "kf" || config.Project != "my-project" || config.Email != "user@example.com" {
+		t.Errorf("Config didn't copy the values: %+v", config)
+	}
+	if config.Auth.OAuthClientId != "id" || config.Auth.OAuthClientSecret != "secret" {
+		t.Errorf("Config didn't copy the OAuth client: %+v", config.Auth)
+	}
+	if config.Client != client || config.WorkDir != "/tmp/kf" {
+		t.Errorf("Config didn't copy the environment")
+	}
+	// The assets built into kfctl are used without a repo.
+	if config.Repo != "" || config.Assets != nil {
+		t.Errorf("Config should leave the assets to the deploy package; got repo %q", config.Repo)
+	}
+}
+
+func TestApplyFailure(t *testing.T) {
+	values := &Values{Name: "kf", Email: "user@example.com"}
+	status := Apply(context.Background(), values, Environment{
+		Client:      &http.Client{},
+		TokenSource: oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "token"}),
+	})
+	if status.Phase != PhaseFailed || status.Code != int(kfapis.INVALID_ARGUMENT) || status.Message == "" {
+		t.Errorf("Apply without a project got status %+v", status)
+	}
+
+	var buf bytes.Buffer
+	if err := status.Write(&buf); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	written := &Status{}
+	if err := json.Unmarshal(buf.Bytes(), written); err != nil {
+		t.Fatalf("Write wrote invalid JSON %q: %v", buf.String(), err)
+	}
+	if written.Phase != PhaseFailed || written.Name != "kf" || written.Code != status.Code {
+		t.Errorf("Write wrote %+v", written)
+	}
+}
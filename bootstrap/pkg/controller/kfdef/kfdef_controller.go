@@ -0,0 +1,163 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package kfdef reconciles KfDef custom resources by running Generate and Apply of the
+// coordinator KfApp, the same code path as kfctl generate all && kfctl apply all.
+package kfdef
+
+import (
+	"fmt"
+	kftypes "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps"
+	kfdefs "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps/kfdef/v1alpha1"
+	"github.com/kubeflow/kubeflow/bootstrap/pkg/kfapp/coordinator"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+	"k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"path/filepath"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+	"time"
+)
+
+const controllerName = "kfdef-controller"
+
+// Add creates the KfDef controller and adds it to mgr. App dirs are kept under
+// appsRoot/<namespace>/<name>. KfDefs are reconciled when their spec changes, i.e. their
+// generation, and successfully applied ones again every resyncPeriod so drift from the spec
+// gets corrected.
+func Add(mgr manager.Manager, appsRoot string, resyncPeriod time.Duration) error {
+	r := &ReconcileKfDef{
+		Client:       mgr.GetClient(),
+		appsRoot:     appsRoot,
+		resyncPeriod: resyncPeriod,
+		newKfApp:     coordinator.NewKfAppForKfDef,
+	}
+	// The KfApp implementations aren't safe to run concurrently, so KfDefs are reconciled one at a time.
+	c, err := controller.New(controllerName, mgr, controller.Options{
+		Reconciler:              r,
+		MaxConcurrentReconciles: 1,
+	})
+	if err != nil {
+		return err
+	}
+	// The status updates of the reconciles don't change the generation, so they don't trigger
+	// another one.
+	return c.Watch(&source.Kind{Type: &kfdefs.KfDef{}}, &handler.EnqueueRequestForObject{},
+		predicate.GenerationChangedPredicate{})
+}
+
+// ReconcileKfDef brings the deployment described by a KfDef up to date.
+// Deleting a KfDef doesn't delete the deployment; use kfctl delete for that.
+type ReconcileKfDef struct {
+	client.Client
+	appsRoot     string
+	resyncPeriod time.Duration
+	// newKfApp returns the KfApp reconciling a KfDef, coordinator.NewKfAppForKfDef but in tests.
+	newKfApp func(*kfdefs.KfDef) (kftypes.KfApp, error)
+}
+
+// Reconcile runs Generate and Apply for all resources of the KfDef and records the
+// outcome in its status conditions and observed generation. A generation applied
+// successfully is only applied again once resyncPeriod passed.
+func (r *ReconcileKfDef) Reconcile(request reconcile.Request) (reconcile.Result, error) {
+	ctx := context.TODO()
+	instance := &kfdefs.KfDef{}
+	err := r.Get(ctx, request.NamespacedName, instance)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+	if instance.Status.ObservedGeneration == instance.Generation {
+		if wait := r.untilResync(&instance.Status); wait > 0 {
+			return reconcile.Result{RequeueAfter: wait}, nil
+		}
+	}
+	log.Infof("reconciling KfDef %v", request.NamespacedName)
+
+	kfdef := &kfdefs.KfDef{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "KfDef",
+			APIVersion: "kfdef.apps.kubeflow.org/v1alpha1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      instance.Name,
+			Namespace: instance.Namespace,
+		},
+		Spec: *instance.Spec.DeepCopy(),
+	}
+	kfdef.Spec.AppDir = filepath.Join(r.appsRoot, instance.Namespace, instance.Name)
+	reconcileErr := r.apply(kfdef)
+	if reconcileErr != nil {
+		log.Errorf("reconciling KfDef %v failed: %v", request.NamespacedName, reconcileErr)
+	}
+
+	setConditions(&instance.Status, reconcileErr)
+	instance.Status.ObservedGeneration = instance.Generation
+	if err = r.Status().Update(ctx, instance); err != nil {
+		return reconcile.Result{}, fmt.Errorf("couldn't update status of KfDef %v: %v",
+			request.NamespacedName, err)
+	}
+	if reconcileErr != nil {
+		// Returning the error requeues the request with backoff.
+		return reconcile.Result{}, reconcileErr
+	}
+	return reconcile.Result{RequeueAfter: r.resyncPeriod}, nil
+}
+
+// untilResync is how long until the generation last applied is due to be applied again, 0 when
+// it is or the last reconcile failed.
+func (r *ReconcileKfDef) untilResync(status *kfdefs.KfDefStatus) time.Duration {
+	for _, condition := range status.Conditions {
+		if condition.Type == kfdefs.KfAvailable && condition.Status == v1.ConditionTrue {
+			if wait := r.resyncPeriod - time.Since(condition.LastUpdateTime.Time); wait > 0 {
+				return wait
+			}
+		}
+	}
+	return 0
+}
+
+func (r *ReconcileKfDef) apply(kfdef *kfdefs.KfDef) error {
+	kfApp, err := r.newKfApp(kfdef)
+	if err != nil {
+		return fmt.Errorf("couldn't load KfApp: %v", err)
+	}
+	if err = kfApp.Generate(kftypes.ALL); err != nil {
+		return fmt.Errorf("couldn't generate KfApp: %v", err)
+	}
+	if err = kfApp.Apply(kftypes.ALL); err != nil {
+		return fmt.Errorf("couldn't apply KfApp: %v", err)
+	}
+	return nil
+}
+
+// setConditions sets KfAvailable and KfDegraded according to the reconcile result.
+func setConditions(status *kfdefs.KfDefStatus, reconcileErr error) {
+	if reconcileErr == nil {
+		status.SetCondition(kfdefs.KfAvailable, v1.ConditionTrue, "Applied", "")
+		status.SetCondition(kfdefs.KfDegraded, v1.ConditionFalse, "Applied", "")
+		return
+	}
+	status.SetCondition(kfdefs.KfAvailable, v1.ConditionFalse, "ReconcileFailed", reconcileErr.Error())
+	status.SetCondition(kfdefs.KfDegraded, v1.ConditionTrue, "ReconcileFailed", reconcileErr.Error())
+}
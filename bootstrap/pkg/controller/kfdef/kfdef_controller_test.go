@@ -0,0 +1,224 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kfdef
+
+import (
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	kftypes "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps"
+	kfdefs "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps/kfdef/v1alpha1"
+	"golang.org/x/net/context"
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func init() {
+	if err := kfdefs.AddToScheme(scheme.Scheme); err != nil {
+		panic(err)
+	}
+}
+
+// fakeKfApp records the calls of a reconcile, failing the one of failOn.
+type fakeKfApp struct {
+	kfdef  *kfdefs.KfDef
+	calls  []string
+	failOn string
+}
+
+func (app *fakeKfApp) call(name string, resources kftypes.ResourceEnum) error {
+	app.calls = append(app.calls, fmt.Sprintf("%v %v", name, resources))
+	if name == app.failOn {
+		return fmt.Errorf("%v failed", name)
+	}
+	return nil
+}
+
+func (app *fakeKfApp) Apply(resources kftypes.ResourceEnum) error {
+	return app.call("apply", resources)
+}
+
+func (app *fakeKfApp) Delete(resources kftypes.ResourceEnum) error {
+	return app.call("delete", resources)
+}
+
+func (app *fakeKfApp) Generate(resources kftypes.ResourceEnum) error {
+	return app.call("generate", resources)
+}
+
+func (app *fakeKfApp) Init(resources kftypes.ResourceEnum) error {
+	return app.call("init", resources)
+}
+
+func (app *fakeKfApp) Plan(resources kftypes.ResourceEnum) ([]kftypes.Action, error) {
+	return nil, app.call("plan", resources)
+}
+
+func newTestReconciler(app *fakeKfApp, existing ...*kfdefs.KfDef) *ReconcileKfDef {
+	objs := []runtime.Object{}
+	for _, kfdef := range existing {
+		objs = append(objs, kfdef)
+	}
+	return &ReconcileKfDef{
+		Client:       fake.NewFakeClient(objs...),
+		appsRoot:     "/apps",
+		resyncPeriod: time.Hour,
+		newKfApp: func(kfdef *kfdefs.KfDef) (kftypes.KfApp, error) {
+			app.kfdef = kfdef
+			return app, nil
+		},
+	}
+}
+
+func newTestKfDef() *kfdefs.KfDef {
+	return &kfdefs.KfDef{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "KfDef",
+			APIVersion: "kfdef.apps.kubeflow.org/v1alpha1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "kf",
+			Namespace: "team",
+		},
+		Spec: kfdefs.KfDefSpec{
+			Project: "my-project",
+		},
+	}
+}
+
+// conditions returns the status of the conditions of the KfDef kf in team.
+func conditions(t *testing.T, r *ReconcileKfDef) map[kfdefs.KfDefConditionType]v1.ConditionStatus {
+	kfdef := &kfdefs.KfDef{}
+	if err := r.Get(context.TODO(), types.NamespacedName{Namespace: "team", Name: "kf"}, kfdef); err != nil {
+		t.Fatalf("couldn't get KfDef: %v", err)
+	}
+	statuses := map[kfdefs.KfDefConditionType]v1.ConditionStatus{}
+	for _, condition := range kfdef.Status.Conditions {
+		statuses[condition.Type] = condition.Status
+	}
+	return statuses
+}
+
+var kfRequest = reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "team", Name: "kf"}}
+
+func TestReconcileApplies(t *testing.T) {
+	app := &fakeKfApp{}
+	r := newTestReconciler(app, newTestKfDef())
+	result, err := r.Reconcile(kfRequest)
+	if err != nil {
+		t.Fatalf("Reconcile failed: %v", err)
+	}
+	if result.RequeueAfter != time.Hour {
+		t.Errorf("requeued after %v; want the resync period", result.RequeueAfter)
+	}
+	if want := []string{"generate all", "apply all"}; !reflect.DeepEqual(app.calls, want) {
+		t.Errorf("reconcile calls %v; want %v", app.calls, want)
+	}
+	if app.kfdef.Spec.AppDir != filepath.Join("/apps", "team", "kf") || app.kfdef.Spec.Project != "my-project" {
+		t.Errorf("reconciled KfDef has app dir %v and project %v", app.kfdef.Spec.AppDir, app.kfdef.Spec.Project)
+	}
+	want := map[kfdefs.KfDefConditionType]v1.ConditionStatus{
+		kfdefs.KfAvailable: v1.ConditionTrue,
+		kfdefs.KfDegraded:  v1.ConditionFalse,
+	}
+	if got := conditions(t, r); !reflect.DeepEqual(got, want) {
+		t.Errorf("conditions %v; want %v", got, want)
+	}
+}
+
+func TestReconcileFailures(t *testing.T) {
+	for _, step := range []string{"generate", "apply"} {
+		app := &fakeKfApp{failOn: step}
+		r := newTestReconciler(app, newTestKfDef())
+		if _, err := r.Reconcile(kfRequest); err == nil {
+			t.Errorf("Reconcile with a failed %v succeeded", step)
+		}
+		want := map[kfdefs.KfDefConditionType]v1.ConditionStatus{
+			kfdefs.KfAvailable: v1.ConditionFalse,
+			kfdefs.KfDegraded:  v1.ConditionTrue,
+		}
+		if got := conditions(t, r); !reflect.DeepEqual(got, want) {
+			t.Errorf("conditions after a failed %v: %v; want %v", step, got, want)
+		}
+		if step == "generate" && len(app.calls) != 1 {
+			t.Errorf("applied after a failed generate: %v", app.calls)
+		}
+	}
+}
+
+func TestReconcileDeletedKfDef(t *testing.T) {
+	app := &fakeKfApp{}
+	r := newTestReconciler(app)
+	result, err := r.Reconcile(kfRequest)
+	if err != nil || result.RequeueAfter != 0 || result.Requeue {
+		t.Errorf("Reconcile of a deleted KfDef = %+v, %v; want no requeue", result, err)
+	}
+	if len(app.calls) != 0 {
+		t.Errorf("deleted KfDef reconciled: %v", app.calls)
+	}
+}
+
+func TestReconcileObservedGeneration(t *testing.T) {
+	applied := func(generation int64, available v1.ConditionStatus, updated time.Time) *kfdefs.KfDef {
+		kfdef := newTestKfDef()
+		kfdef.Generation = 2
+		kfdef.Status.ObservedGeneration = generation
+		kfdef.Status.Conditions = []kfdefs.KfDefCondition{{
+			Type:           kfdefs.KfAvailable,
+			Status:         available,
+			LastUpdateTime: metav1.NewTime(updated),
+		}}
+		return kfdef
+	}
+	cases := []struct {
+		name    string
+		kfdef   *kfdefs.KfDef
+		applied bool
+	}{
+		{"observed generation", applied(2, v1.ConditionTrue, time.Now()), false},
+		{"resync due", applied(2, v1.ConditionTrue, time.Now().Add(-2*time.Hour)), true},
+		{"failed reconcile", applied(2, v1.ConditionFalse, time.Now()), true},
+		{"new generation", applied(1, v1.ConditionTrue, time.Now()), true},
+	}
+	for _, c := range cases {
+		app := &fakeKfApp{}
+		r := newTestReconciler(app, c.kfdef)
+		result, err := r.Reconcile(kfRequest)
+		if err != nil {
+			t.Fatalf("%v: Reconcile failed: %v", c.name, err)
+		}
+		if applied := len(app.calls) != 0; applied != c.applied {
+			t.Errorf("%v: reconcile calls %v; want applied %v", c.name, app.calls, c.applied)
+		}
+		if result.RequeueAfter <= 0 || result.RequeueAfter > time.Hour {
+			t.Errorf("%v: requeued after %v; want within the resync period", c.name, result.RequeueAfter)
+		}
+		kfdef := &kfdefs.KfDef{}
+		if err = r.Get(context.TODO(), kfRequest.NamespacedName, kfdef); err != nil {
+			t.Fatalf("couldn't get KfDef: %v", err)
+		}
+		if kfdef.Status.ObservedGeneration != 2 && c.applied {
+			t.Errorf("%v: observed generation %v; want 2", c.name, kfdef.Status.ObservedGeneration)
+		}
+	}
+}
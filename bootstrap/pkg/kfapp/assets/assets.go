@@ -0,0 +1,79 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package assets holds the files of the kubeflow repo kfctl needs besides the ksonnet packages:
+// the app configs, the gcp deployment manager templates, the Istio manifests and the NVIDIA
+// driver installer. They're named by their path in the repo, so restoring them gives the layout
+// of a checkout.
+//
+// Run go generate after changing any of them.
+package assets
+
+//go:generate go run ../../../hack/gen-assets.go -root ../../../.. -pkg assets -o zz_generated.assets.go bootstrap/config/kfctl_default.yaml bootstrap/config/kfctl_iap.yaml bootstrap/config/kfctl_basic_auth.yaml bootstrap/config/kfctl_dex.yaml deployment/gke/deployment_manager_configs dependencies/istio/install/crds.yaml dependencies/istio/install/istio-noauth.yaml dependencies/istio/install/profiles dependencies/istio/kf-istio-resources.yaml dependencies/gpu/nvidia-driver-installer.yaml
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// Asset returns the content of the named asset.
+func Asset(name string) ([]byte, error) {
+	data, ok := _assets[name]
+	if !ok {
+		return nil, fmt.Errorf("asset %v not found", name)
+	}
+	r, err := gzip.NewReader(bytes.NewBufferString(data))
+	if err != nil {
+		return nil, fmt.Errorf("couldn't read asset %v: %v", name, err)
+	}
+	defer r.Close()
+	buf, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't read asset %v: %v", name, err)
+	}
+	return buf, nil
+}
+
+// AssetNames returns the names of the assets, sorted.
+func AssetNames() []string {
+	names := make([]string, 0, len(_assets))
+	for name := range _assets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// RestoreAssets writes every asset under dir, overwriting what's there.
+func RestoreAssets(dir string) error {
+	for _, name := range AssetNames() {
+		buf, err := Asset(name)
+		if err != nil {
+			return err
+		}
+		file := filepath.Join(dir, filepath.FromSlash(name))
+		if err = os.MkdirAll(filepath.Dir(file), os.ModePerm); err != nil {
+			return fmt.Errorf("couldn't create directory %v: %v", filepath.Dir(file), err)
+		}
+		if err = ioutil.WriteFile(file, buf, 0644); err != nil {
+			return fmt.Errorf("couldn't write %v: %v", file, err)
+		}
+	}
+	return nil
+}
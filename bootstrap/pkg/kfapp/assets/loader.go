@@ -0,0 +1,162 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package assets
+
+import (
+	"fmt"
+	"golang.org/x/net/context"
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/storage/v1"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// GCS_SCHEME prefixes the NewLoader sources of a GCS bucket, e.g. gs://bucket/v0.5.0.
+const GCS_SCHEME = "gs://"
+
+// EMBEDDED is the NewLoader source of the assets built into the binary.
+const EMBEDDED = "embedded"
+
+// Loader reads the assets by their path in the kubeflow repo, e.g.
+// deployment/gke/deployment_manager_configs/cluster.jinja, wherever they're kept: a checkout,
+// a GCS bucket or the binary. It's safe for concurrent use.
+type Loader interface {
+	// Read returns the content of the asset name, or an error satisfying os.IsNotExist when
+	// it's missing.
+	Read(name string) ([]byte, error)
+}
+
+// NewLoader returns the Loader of source: the embedded assets for "" or EMBEDDED, the bucket
+// and prefix of a gs:// URL, read with client, or else the directory of a checkout.
+func NewLoader(source string, client *http.Client) (Loader, error) {
+	switch {
+	case source == "" || source == EMBEDDED:
+		return Embedded, nil
+	case strings.HasPrefix(source, GCS_SCHEME):
+		bucket := strings.TrimPrefix(source, GCS_SCHEME)
+		prefix := ""
+		if i := strings.Index(bucket, "/"); i >= 0 {
+			bucket, prefix = bucket[:i], bucket[i+1:]
+		}
+		if bucket == "" {
+			return nil, fmt.Errorf("no bucket in %v", source)
+		}
+		return NewGCSLoader(client, bucket, prefix)
+	default:
+		return NewDirLoader(source), nil
+	}
+}
+
+// Embedded is the Loader of the assets built into the binary.
+var Embedded Loader = embeddedLoader{}
+
+type embeddedLoader struct{}
+
+func (embeddedLoader) Read(name string) ([]byte, error) {
+	if _, ok := _assets[name]; !ok {
+		return nil, &os.PathError{Op: "open", Path: name, Err: os.ErrNotExist}
+	}
+	return Asset(name)
+}
+
+// dirLoader reads the assets from a checkout of the kubeflow repo.
+type dirLoader struct {
+	dir string
+}
+
+// NewDirLoader returns a Loader reading the assets under dir, the root of a checkout.
+func NewDirLoader(dir string) Loader {
+	return &dirLoader{dir: dir}
+}
+
+func (l *dirLoader) Read(name string) ([]byte, error) {
+	return ioutil.ReadFile(l.file(name))
+}
+
+func (l *dirLoader) file(name string) string {
+	return filepath.Join(l.dir, filepath.FromSlash(name))
+}
+
+// gcsLoader reads the assets from the objects of a GCS bucket, named by their path under prefix.
+type gcsLoader struct {
+	service *storage.Service
+	bucket  string
+	prefix  string
+}
+
+// NewGCSLoader returns a Loader reading the assets from bucket under prefix, e.g. the copy of a
+// release uploaded with gsutil cp -r, with client, which needs read access to the bucket.
+func NewGCSLoader(client *http.Client, bucket string, prefix string) (Loader, error) {
+	service, err := storage.New(client)
+	if err != nil {
+		return nil, fmt.Errorf("Error creating storage service: %v", err)
+	}
+	return &gcsLoader{
+		service: service,
+		bucket:  bucket,
+		prefix:  strings.Trim(prefix, "/"),
+	}, nil
+}
+
+func (l *gcsLoader) Read(name string) ([]byte, error) {
+	object := path.Join(l.prefix, name)
+	resp, err := l.service.Objects.Get(l.bucket, object).Context(context.Background()).Download()
+	if err != nil {
+		if e, ok := err.(*googleapi.Error); ok && e.Code == http.StatusNotFound {
+			return nil, &os.PathError{Op: "open", Path: GCS_SCHEME + l.bucket + "/" + object, Err: os.ErrNotExist}
+		}
+		return nil, fmt.Errorf("couldn't read %v%v/%v: %v", GCS_SCHEME, l.bucket, object, err)
+	}
+	defer resp.Body.Close()
+	buf, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't read %v%v/%v: %v", GCS_SCHEME, l.bucket, object, err)
+	}
+	return buf, nil
+}
+
+// LocalFile returns a file with the content of the asset name for the functions taking a path,
+// with the function removing it once it's no longer needed. It's the file of the checkout for a
+// Loader of NewDirLoader, and a temporary copy otherwise.
+func LocalFile(loader Loader, name string) (string, func(), error) {
+	if l, ok := loader.(*dirLoader); ok {
+		return l.file(name), func() {}, nil
+	}
+	buf, err := loader.Read(name)
+	if err != nil {
+		return "", nil, err
+	}
+	tmp, err := ioutil.TempFile("", "kfctl-asset")
+	if err != nil {
+		return "", nil, err
+	}
+	cleanup := func() {
+		os.Remove(tmp.Name())
+	}
+	if _, err = tmp.Write(buf); err != nil {
+		tmp.Close()
+		cleanup()
+		return "", nil, err
+	}
+	if err = tmp.Close(); err != nil {
+		cleanup()
+		return "", nil, err
+	}
+	return tmp.Name(), cleanup, nil
+}
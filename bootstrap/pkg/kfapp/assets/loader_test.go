@@ -0,0 +1,110 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package assets
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const clusterTemplate = "deployment/gke/deployment_manager_configs/cluster.jinja"
+
+func TestNewLoader(t *testing.T) {
+	cases := []struct {
+		source string
+		bucket string
+		prefix string
+		dir    string
+	}{
+		{source: ""},
+		{source: EMBEDDED},
+		{source: "gs://kubeflow-assets/v0.5.0/", bucket: "kubeflow-assets", prefix: "v0.5.0"},
+		{source: "gs://kubeflow-assets", bucket: "kubeflow-assets"},
+		{source: "/opt/kubeflow", dir: "/opt/kubeflow"},
+	}
+	for _, c := range cases {
+		loader, err := NewLoader(c.source, http.DefaultClient)
+		if err != nil {
+			t.Errorf("NewLoader(%q) failed: %v", c.source, err)
+			continue
+		}
+		switch l := loader.(type) {
+		case embeddedLoader:
+			if c.bucket != "" || c.dir != "" {
+				t.Errorf("NewLoader(%q) reads the embedded assets", c.source)
+			}
+		case *gcsLoader:
+			if l.bucket != c.bucket || l.prefix != c.prefix {
+				t.Errorf("NewLoader(%q) reads gs://%v under %q; want gs://%v under %q", c.source, l.bucket,
+					l.prefix, c.bucket, c.prefix)
+			}
+		case *dirLoader:
+			if l.dir != c.dir {
+				t.Errorf("NewLoader(%q) reads %v; want %v", c.source, l.dir, c.dir)
+			}
+		}
+	}
+	if _, err := NewLoader("gs://", http.DefaultClient); err == nil {
+		t.Errorf("NewLoader(gs://) succeeded")
+	}
+}
+
+func TestLoadersReadTheSameAssets(t *testing.T) {
+	dir, err := ioutil.TempDir("", "kfctl-assets")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	if err = RestoreAssets(dir); err != nil {
+		t.Fatal(err)
+	}
+	want, err := Embedded.Read(clusterTemplate)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, loader := range []Loader{Embedded, NewDirLoader(dir)} {
+		buf, err := loader.Read(clusterTemplate)
+		if err != nil || !bytes.Equal(buf, want) {
+			t.Errorf("%T read %v bytes of %v (%v); want %v", loader, len(buf), clusterTemplate, err, len(want))
+		}
+		if _, err = loader.Read("missing.yaml"); !os.IsNotExist(err) {
+			t.Errorf("%T read missing.yaml: %v; want a not exist error", loader, err)
+		}
+	}
+
+	file, cleanup, err := LocalFile(NewDirLoader(dir), clusterTemplate)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cleanup()
+	if file != filepath.Join(dir, filepath.FromSlash(clusterTemplate)) {
+		t.Errorf("the local file of a checkout is %v; want the one of the checkout", file)
+	}
+	file, cleanup, err = LocalFile(Embedded, clusterTemplate)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if buf, err := ioutil.ReadFile(file); err != nil || !bytes.Equal(buf, want) {
+		t.Errorf("the local file of the embedded %v has %v bytes (%v); want %v", clusterTemplate, len(buf), err, len(want))
+	}
+	cleanup()
+	if _, err = os.Stat(file); !os.IsNotExist(err) {
+		t.Errorf("the local file %v of an embedded asset is left after cleanup", file)
+	}
+}
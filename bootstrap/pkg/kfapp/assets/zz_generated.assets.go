@@ -0,0 +1,49 @@
+// Code generated by hack/gen-assets.go. DO NOT EDIT.
+// sources:
+// bootstrap/config/kfctl_basic_auth.yaml
+// bootstrap/config/kfctl_default.yaml
+// bootstrap/config/kfctl_dex.yaml
+// bootstrap/config/kfctl_iap.yaml
+// dependencies/gpu/nvidia-driver-installer.yaml
+// dependencies/istio/install/crds.yaml
+// dependencies/istio/install/istio-noauth.yaml
+// dependencies/istio/install/profiles/mtls-permissive.yaml
+// dependencies/istio/install/profiles/mtls-strict.yaml
+// dependencies/istio/install/profiles/noauth.yaml
+// dependencies/istio/kf-istio-resources.yaml
+// deployment/gke/deployment_manager_configs/cluster-kubeflow.yaml
+// deployment/gke/deployment_manager_configs/cluster.jinja
+// deployment/gke/deployment_manager_configs/cluster.jinja.schema
+// deployment/gke/deployment_manager_configs/gcfs.yaml
+// deployment/gke/deployment_manager_configs/iam_bindings_template.yaml
+// deployment/gke/deployment_manager_configs/network.jinja
+// deployment/gke/deployment_manager_configs/network.yaml
+// deployment/gke/deployment_manager_configs/storage-kubeflow.yaml
+// deployment/gke/deployment_manager_configs/storage.jinja
+// deployment/gke/deployment_manager_configs/storage.jinja.schema
+
+package assets
+
+var _assets = map[string]string{
+	"bootstrap/config/kfctl_basic_auth.yaml":                               "\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x8c\x55\x4d\x8f\xdc\x36\x0c\xbd\xcf\xaf\x20\xb2\x87\xb6\x07\x8f\xbb\x3d\x0e\x82\x45\x8b\xa4\x28\x8a\x06\xc9\xa2\xd9\x9e\x8a\xa2\xa0\x25\xda\xd6\x8e\x24\x6a\x29\x7a\xb6\xfe\xf7\x85\x6c\xcf\x87\x07\xd9\x64\x4f\x23\xd2\xe2\x23\xdf\x13\xc9\xb9\x81\x77\x1c\x5b\xd7\x01\x45\x95\x11\x86\x4c\x16\x5a\x16\xb0\x94\x3c\x8f\x2e\x76\xc0\x11\x7e\x7b\x77\x0f\xcf\x4e\x7b\x68\x30\x3b\x03\x38\x68\x0f\x14\xb1\xf1\x64\x37\x37\xf0\x81\xd1\x82\xf6\x2e\x43\xeb\x3c\x01\x66\xe0\xe6\x91\x8c\xc2\xf7\x7f\x64\x8e\x91\xf4\x73\x22\xf3\xc3\xdf\xbd\x6a\xca\xbb\xba\xee\x9c\xf6\x43\xb3\x35\x1c\xea\xfd\xd0\x50\xeb\xf9\xf9\x7c\x68\x3c\x37\x75\xc0\xac\x24\x75\xc3\xac\x59\x05\x53\x9d\xf6\x5d\x8d\xc9\xe5\x1a\x53\xca\xf5\x7e\x46\xad\x0f\xb7\xe8\x53\x8f\xb7\xc5\xeb\x9d\x41\x75\x1c\xff\xd5\x31\x51\xde\x76\x7c\xf3\xe1\xa7\x1f\x6f\xff\xd9\xdc\xc0\x2f\xde\xc3\xc3\xa7\xf7\x9f\xa0\x75\xe4\x6d\x86\x48\x64\x41\x19\x1a\x02\xd3\x63\xec\x26\xbe\xde\xf3\x73\xe1\x3a\x64\x92\xef\x32\xb8\x98\x06\x85\x86\x5a\x16\x82\x82\x3e\x6e\x6e\x66\x90\x39\x04\x84\x12\x03\x47\xd0\x9e\xa0\xf5\xe3\x0e\x32\x29\x38\x05\x65\xf0\x6c\xd0\x83\x86\x04\xd6\x09\x18\x8e\x8a\x2e\x16\xec\x23\x47\x10\xea\x5c\x56\x19\x37\x05\x65\x07\x75\x42\xed\x6b\xe5\x7a\x8a\xac\x35\xa4\xfa\x1c\x75\x52\x66\x93\xd0\xec\xb1\xa3\xbc\xdb\x00\x54\x80\xd2\xf1\x74\x30\x1c\x02\xc7\xe9\x48\xff\x61\x48\x9e\xf2\x64\x74\x26\x4d\xbf\x8f\x43\x1a\x95\x64\x3a\xef\x51\x5d\x33\x9d\x02\x29\x96\x24\xc2\xde\x2f\x1f\x03\x5b\xf2\x76\xf9\x9c\x5c\xf5\xc8\xf3\x39\xb9\x44\xde\x45\x9a\x0d\xe1\xd6\x1d\x53\xa4\x51\x59\x4c\x7f\xba\x99\xc9\xdb\xa5\x14\xa5\x98\x59\x1a\x46\xb1\xb3\xdd\x56\x99\xe4\xe0\x62\x77\x34\x55\x66\x82\x1b\xc3\x21\x71\xa4\xa8\x47\x66\xa1\xc1\x9c\xd1\xb2\xac\x89\x4e\xad\x57\x95\xd6\xbb\x32\x2b\x17\x3b\xa1\x3c\xd7\x64\x4a\x1b\xa3\xb7\x98\xfb\x73\x76\x43\xa2\x55\xc0\x88\xdd\xc2\xd5\x78\x1e\x6c\x45\xd1\x26\x76\x51\x4f\x82\x55\x46\xc8\x52\x54\x87\x3e\x57\x68\x83\xcb\xd9\x71\xac\x9e\xa9\xe9\x99\xf7\xf3\xad\x34\x54\x56\xdc\x81\xe4\x52\xdd\x72\xa5\xc2\x94\xbe\xad\x72\x64\xa5\x86\x79\x5f\x5d\xf9\xbf\xad\x32\x27\x12\xd4\x45\x95\x9c\x50\x14\xf7\x43\x7e\x49\xed\x47\x6e\xce\x11\x27\x89\xef\x51\x30\x4c\x3a\x5f\x4a\x52\xec\xa9\x36\x0c\xb4\x03\x34\x81\x7e\x0d\xe8\xfc\xe4\x05\x58\x37\xfe\x01\xfd\x40\xab\xce\x1f\x32\xc1\xc8\x83\x00\x95\xa0\x69\x77\xe4\xec\xa7\x04\x0b\xc2\x14\xb3\x83\x47\xee\xe3\x7b\xa6\x9f\x4b\x82\x32\xfd\xcb\x57\x17\x9d\xfe\x49\x4f\x83\x13\xb2\x3b\x50\x19\x68\x03\x5f\x78\xdd\x75\x91\x2e\x7d\xc4\x40\xaf\xac\x70\xf1\xb4\x4b\xd4\x69\x03\x04\x54\xd3\x83\x50\xe6\x41\x0c\x4d\xc8\xe0\xe2\xb2\xf9\x02\x45\x9d\x77\xe2\x76\x4d\x63\x95\xfa\x8b\xc5\x9f\xcb\xec\x39\x6b\x7c\x7d\xa1\x42\xc9\xa3\xa1\x79\xd5\x96\x55\x54\x25\xe1\x83\xb3\x04\xa9\x3c\x1c\x29\x49\xde\xc2\x43\xd9\xb4\x17\x5b\xac\x1d\xbc\x1f\xe1\x69\x40\xef\x5a\x47\x16\x2c\x07\x74\x71\xa6\xa3\x5c\x70\x66\xc0\x45\xc8\x2b\x3a\x6f\x67\xba\x85\xd3\xdd\xf6\x34\x11\xdb\xb7\xf7\xc2\x65\x87\xdf\x6d\xa7\x59\xd9\x76\xcc\xdd\xd7\x38\x5f\x4d\xd4\xfa\xb5\x32\x19\x21\xbd\x90\x6d\xc9\x5d\x46\x2c\x56\x65\xf0\x32\x6e\xe0\x62\xf2\xd7\xe1\x67\xff\xe7\xb2\x43\x0c\x3d\x8c\xe9\x0a\xe9\x23\x5b\xba\xe7\xa9\xe3\x8e\x93\xb4\xc6\x08\x63\x7e\xf2\xf7\xf6\x65\xee\x55\x56\x16\xec\xa8\x2a\x43\x6b\x51\x71\x72\xac\x9f\x33\xb8\xe8\xf8\x55\x20\x28\xea\x5a\x34\x7a\x02\x39\x0d\xec\xba\xac\x21\x63\x47\xbf\x5f\x23\x0a\x46\xcb\xc1\x8f\x55\x47\xb1\x8c\x30\xd9\xca\xd9\xbb\xd7\xb5\x5c\xf9\x4f\x11\xfd\xab\xe0\xae\x51\xdf\x94\xab\x6f\x5e\x06\x49\x1e\xb5\x65\x09\xbb\xe9\xaf\xe3\xff\x01\x00\xdf\x5c\xa0\xd6\x17\x08\x00\x00",
+	"bootstrap/config/kfctl_default.yaml":                                  "\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x84\x92\xcd\x6e\xdc\x3a\x0c\x85\xf7\x7e\x0a\x02\xb3\xb8\xc9\xc2\xd1\x4d\x97\xb3\x2b\xda\x5d\x83\x26\x40\xb2\x2b\x8a\x82\x92\x68\x5b\x63\x49\x24\x24\x7a\xa6\x7e\xfb\xc2\xf6\xfc\x14\x01\x8a\xac\x7c\x8e\x48\x1e\xe9\x23\xbc\x83\x2f\x9c\xbb\xd0\x03\x65\x2d\x33\x4c\x95\x3c\x74\x5c\xc0\x93\x44\x9e\x43\xee\xe1\x14\x74\x80\xcc\x20\x11\xb5\xe3\x92\xa0\x0a\xb9\xd0\x05\xf2\x70\xc7\x05\xda\xf6\x5a\xc8\x9c\xe9\x1e\x30\xfb\xa5\xdd\x62\x0d\x0e\x70\xd2\x01\x28\xa3\x8d\xe4\x9b\x1d\x3c\x31\x7a\xd0\x21\x54\xe8\x42\x24\xc0\x0a\x6c\x0f\xe4\x14\xee\xbe\x55\xce\x99\xf4\x55\xc8\xdd\xff\x18\x54\xa5\xee\x8d\xe9\x83\x0e\x93\x7d\x70\x9c\xcc\x38\x59\xea\x22\x9f\x6e\xc2\x46\xb6\x26\x61\x55\x2a\xc6\x32\x6b\xd5\x82\x62\x64\xec\x0d\x4a\xa8\x06\x45\xaa\x19\xb7\x54\x73\x7c\xc4\x28\x03\x3e\x2e\xa7\x31\x38\xd4\xc0\xf9\x97\xce\x42\xf5\xa1\xe7\xdd\xd3\xa7\xff\x1f\x7f\x36\x3b\xf8\x1c\x23\xbc\x3d\x7f\x7d\x86\x2e\x50\xf4\x15\x32\x91\x07\x65\xb0\x04\x6e\xc0\xdc\xaf\xab\x89\x91\x4f\xcb\x5a\xa6\x4a\xe5\xbf\x0a\x21\xcb\xa4\x60\xa9\xe3\x42\xb0\xa4\xcf\xcd\x6e\x0b\xd9\x46\xa0\x90\x30\x70\x06\x1d\x08\xba\x38\xef\xa1\x92\x42\xd0\x25\x37\xb2\xc3\x08\x9a\x04\x7c\x28\xe0\x38\x2b\x86\xbc\x64\x5f\x18\xa1\x50\x1f\xaa\x96\xb9\x59\x52\xf6\x60\x04\x75\x30\xca\x66\x9d\x34\x9a\xc4\xdc\xa6\xae\x9b\x69\x04\xdd\x88\x3d\xd5\x7d\x03\xd0\x02\x96\x9e\x57\xe1\x38\x25\xce\xab\xa4\xdf\x98\x24\x52\x5d\x4d\xef\x64\xfd\x1e\x26\x99\x95\xca\xaa\x47\xd4\x60\x57\x95\x48\x71\xb9\xa4\x70\x8c\xe7\x62\x62\x4f\xd1\x9f\xcb\x12\xda\x03\x6f\x5a\x82\x50\x0c\x99\x36\x53\xb8\x0b\x97\x2b\x64\x56\x2e\x6e\xb8\x76\x56\x8a\xfe\xfc\x14\xa5\x5c\xb9\x58\xc6\xe2\x37\xdf\xb5\x95\xca\x31\xe4\xfe\x62\xb5\x6c\x80\x8d\xe3\x24\x9c\x29\xeb\x85\x2c\x59\xac\x15\x3d\x97\x77\xa0\xcb\xbf\x8c\xd1\x63\x1d\x6e\xb9\x67\xba\xf6\x44\xb6\x45\x91\x8f\x29\x33\x2b\x59\xe6\xb1\x7d\x77\xfe\x31\x25\x0b\x15\x54\x2e\xff\xe2\x3b\xb0\xbd\xf5\x5c\xa1\x5e\xb0\x60\x5a\xc9\x6e\x5c\x8b\x5b\xdf\x82\x89\xf6\x7f\x9d\xbf\x2e\x1b\x72\xf4\x36\x0b\xad\x1d\x00\x47\x8c\x13\xed\xe1\x3b\x7b\x7a\xe1\xa2\xcd\x9f\x01\x00\xd0\xd4\x0b\xcb\xd8\x03\x00\x00",
+	"bootstrap/config/kfctl_dex.yaml":                                      "\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xb4\x55\x4f\x6f\xdb\xc6\x13\xbd\xeb\x53\x0c\xa2\xc3\xef\x57\xa0\x14\x9b\x1e\x85\x20\x68\x10\x17\x45\xd0\x20\x11\x9a\xf4\x54\x14\xc5\x70\x77\x48\xae\xb5\xbb\xb3\x9e\x1d\xda\xe6\xb7\x2f\x96\xa4\x24\xd3\xb0\x1b\x03\x45\x4f\x9a\xfd\x33\x6f\xe6\x3d\xee\x3c\x6d\xe1\x3d\xc7\xd6\x75\x40\x51\x65\x84\x21\x93\x85\x96\x05\x2c\x25\xcf\xa3\x8b\x1d\x70\x84\x5f\xde\x1f\xe0\xce\x69\x0f\x57\x74\x0f\x14\xb1\xf1\x64\xbf\x87\x96\x2c\x09\x6a\xb9\x83\x11\x3e\x7f\xb8\x7a\x0f\x49\xf8\xd6\x59\x12\x60\x01\xd3\x93\x39\x2e\x87\x1f\xaf\xde\x1d\x36\x5b\xb0\x4e\xc8\x28\xcb\x08\x98\x21\x93\x82\x8b\x90\x13\x99\x1d\x0e\xda\x6f\xb6\xf0\x91\xd1\x82\xf6\x2e\x43\xeb\x3c\x95\x4b\xdc\x5c\x93\x51\xf8\xff\xaf\x99\x63\x24\xfd\x92\xc8\x7c\xf7\x47\xaf\x9a\xf2\xbe\xae\x3b\xa7\xfd\xd0\xec\x0c\x87\xfa\x38\x34\xd4\x7a\xbe\xbb\x04\x8d\xe7\xa6\x0e\x98\x95\xa4\x6e\x98\x35\xab\x60\xaa\xd3\xb1\xab\x31\xb9\x5c\x63\x4a\xb9\x3e\xce\xa8\xf5\xed\x6b\xf4\xa9\xc7\xd7\x65\xd7\x3b\x83\xea\x38\xfe\xa5\x63\xa2\xbc\xeb\x78\xfb\xf1\xc7\x1f\x5e\xff\xb9\xd9\xc2\x3b\xef\xe1\xeb\xe7\xab\xcf\xd0\x3a\xf2\x36\x43\x24\xb2\xa0\x0c\x0d\x81\xe9\x31\x76\x93\x72\xde\xf3\x5d\x21\x3d\x64\x92\xff\x65\x70\x31\x0d\x0a\x0d\xb5\x2c\x04\x05\x7d\xdc\x6c\x67\x90\x39\x05\x84\x12\x03\x47\xd0\x9e\xa0\xf5\xe3\x7e\x96\x45\x0b\xae\x67\x83\x1e\x34\xa4\xa2\x1b\x18\x8e\x8a\x2e\x16\xec\x13\x47\x10\xea\x5c\x56\x19\x37\x05\x65\x0f\x75\x42\xed\x6b\xe5\x7a\xca\xac\x35\xa4\xfa\x92\x75\x56\x66\x93\xd0\x1c\xb1\xa3\xbc\xdf\x00\x54\x80\xd2\xf1\x14\x18\x0e\x81\xe3\x14\xd2\x3d\x86\xe4\x29\x4f\x8b\xce\xa4\xe9\xf7\x7a\x48\xa3\x92\x4c\xf1\x11\xd5\x35\x53\x14\x48\xb1\x14\x11\xf6\x7e\x39\x0c\x6c\xc9\xdb\xe5\x38\xb9\xea\x9a\xe7\x38\xb9\x44\xde\x45\x9a\x17\xc2\xad\x3b\x95\x48\xa3\xb2\x98\xfe\x7c\x33\x93\xb7\x4b\x2b\x4a\x31\xb3\x34\x8c\x62\xe7\x75\x5b\x65\x92\x5b\x17\xbb\xd3\x52\x65\x26\xb8\x31\x1c\x12\x47\x8a\x7a\x62\x16\x1a\xcc\x19\x2d\xcb\x9a\x68\x83\xd9\x99\xaa\x3c\xb9\xca\xc5\x4e\x28\xcf\x4d\x98\x32\x01\xe8\x2d\xe6\xfe\x52\xce\x90\x68\x15\x30\x62\xb7\x90\x33\x9e\x07\x5b\x51\xb4\x89\x5d\xd4\x39\xd3\xd2\xfd\x49\xa9\xca\x08\x59\x8a\xea\xd0\xe7\x0a\x6d\x70\x39\x3b\x8e\xd5\x1d\x35\x3d\xf3\x71\xbe\x95\x86\xca\x8a\xbb\x25\x79\x28\x6b\xb9\x52\x61\x4a\xdf\x96\x37\xb2\x52\xc3\x7c\xac\x1e\xed\x7f\x5b\x5e\x4e\x65\x5e\x17\x39\x72\x42\x51\x3c\x0e\xf9\x39\x99\xaf\xb9\xb9\x64\x9c\xb5\x3d\xa0\x60\x98\x04\x7e\x28\x4d\x59\x4f\xbd\x61\xa0\x3d\xa0\x09\xf4\x73\x40\xe7\xa7\x5d\x80\xf5\x8b\xbf\x45\x3f\xd0\xea\xc9\x0f\x99\x60\xe4\x41\x80\x4a\xd2\x64\x3f\x39\xfb\xa9\xc0\x82\x30\xe5\xec\xe1\x9a\xfb\x78\xc5\xf4\x53\x29\x50\xc6\x7e\x39\x75\xd1\xe9\x6f\x74\x33\x38\x21\xbb\x07\x95\x81\x36\xf0\xc4\x57\x5e\x37\xe9\xd2\x27\x0c\xf4\xc2\x0e\x97\x9d\x76\xc9\x3a\x8f\x7e\x40\x35\x3d\x08\x65\x1e\xc4\xd0\x84\x0c\x2e\x2e\xe6\x19\x28\xea\x6c\xab\xbb\x35\x8d\x55\xe9\x27\x9b\xbf\xb4\xd9\x73\xd6\xf8\xf2\x46\x85\x92\x47\x43\xb3\x5b\x17\x0f\xaa\x16\x4b\x86\x54\x3e\x1c\x29\x49\xde\xc1\xd7\x62\xb1\x0f\xec\xab\x1d\xbc\x1f\xe1\x66\x40\xef\x5a\x47\x16\x2c\x07\x74\x71\xa6\xa3\x5c\x70\x66\xc0\x45\xc8\x47\x74\xde\xcc\x74\x0b\xa7\xb7\xbb\xf3\x64\xec\xde\x1c\x84\x8b\x79\xbf\xdd\x4d\x33\xb3\xeb\x98\xbb\x7f\xe2\x6c\xe9\x7e\xff\x6f\xa8\x97\xe0\x94\x02\xdc\x3e\xf1\x00\xfe\x9b\xbe\x1f\x39\xc2\x9a\x43\x26\x23\xa4\x0f\x3e\xf7\x52\xbb\x58\x43\xac\x8a\x61\x64\xdc\xc0\x03\xab\x5a\xa7\x5f\xf6\xbf\x14\xd3\x33\xf4\x75\x4c\x8f\x90\x3e\xb1\xa5\x03\x4f\x93\x72\x72\x80\x35\x46\x18\xf3\x8d\x3f\xd8\xe7\xb9\x57\x59\x59\xb0\xa3\xaa\x98\x8d\x45\xc5\x69\x63\xfd\x0c\x83\x8b\x8e\x5f\x04\x82\xa2\xae\x45\xa3\x67\x90\xb3\xd1\xac\xdb\x1a\x32\x76\xf4\xe1\x31\xa2\x60\xb4\x1c\xfc\x58\x75\x14\x8b\xf5\x90\xad\x9c\x7d\xfb\xb2\x51\x29\x7f\x82\xa2\xbf\x17\xdc\x35\xea\xab\x72\xf5\xd5\xf3\x20\xc9\xa3\xb6\x2c\x61\x3f\xfd\xd7\xfd\x3d\x00\x15\x55\x38\x6a\x12\x09\x00\x00",
+	"bootstrap/config/kfctl_iap.yaml":                                      "\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x8c\x54\xc1\x6e\xe4\x36\x0c\xbd\xcf\x57\x10\x9b\x43\xdb\x83\xc7\x4d\x8f\x83\x45\xd0\xc5\x6e\x51\x2c\xba\xe8\x06\xed\xf6\x54\x14\x05\x2d\xd1\x36\x33\x92\xa8\x50\x74\x52\xff\x7d\x21\xdb\x99\x64\x82\xa6\x9b\x93\x29\x59\x7c\xe4\x7b\x12\xdf\x05\xbc\x97\xd4\xf3\x00\x94\x4c\x67\x98\x0a\x79\xe8\x45\xc1\x53\x0e\x32\x73\x1a\x40\x12\xfc\xfc\xfe\x1a\xee\xd9\x46\xf8\xf8\xee\x1a\x28\x61\x17\xc8\xef\x2e\xe0\x93\xa0\x07\x1b\xb9\x40\xcf\x81\x00\x0b\x48\x77\x43\xce\xe0\xdb\x5f\x8a\xa4\x44\xf6\x7b\x26\xf7\xdd\x9f\xa3\x59\x2e\x87\xb6\x1d\xd8\xc6\xa9\xdb\x3b\x89\xed\x71\xea\xa8\x0f\x72\xff\x18\x74\x41\xba\x36\x62\x31\xd2\xb6\x13\xb1\x62\x8a\xb9\xcd\xc7\xa1\xc5\xcc\xa5\xc5\x9c\x4b\x7b\x5c\x51\xdb\xbb\x4b\x0c\x79\xc4\xcb\xba\x1b\xd8\xa1\xb1\xa4\xbf\x6d\xce\x54\xf6\x83\x5c\x7c\xfa\xe1\xfb\xcb\xbf\x76\x17\xf0\x2e\x04\xf8\xf2\xf9\xc3\x67\xe8\x99\x82\x2f\x90\x88\x3c\x98\x40\x47\xe0\x46\x4c\xc3\x42\x34\x04\xb9\xaf\x24\xa7\x42\xfa\x4d\x01\x4e\x79\x32\xe8\xa8\x17\x25\xa8\xe8\xf3\xee\x62\x05\x59\x53\x40\x29\x0b\x48\x02\x1b\x09\xfa\x30\x1f\xa0\x90\x01\x1b\x98\x40\x10\x87\x01\x2c\x66\xf0\xac\xe0\x24\x19\x72\xaa\xd8\x0f\x1c\x41\x69\xe0\x62\x3a\xef\x2a\xca\x01\xda\x8c\x36\xb6\x26\xed\x92\xd9\x5a\xcc\xed\x63\xd6\x49\x99\x5d\x46\x77\xc4\x81\xca\x61\x07\xd0\x00\xea\x20\x4b\xe0\x24\x46\x49\x4b\x48\xff\x60\xcc\x81\xca\xb2\x18\x5c\x5e\xbe\x37\x53\x9e\x8d\x74\x89\x8f\x68\xdc\x2d\x51\x24\xc3\x5a\x44\x25\x84\xed\x67\x14\x4f\xc1\x6f\xbf\x33\x37\x37\xb2\xc6\x99\x33\x05\x4e\xb4\x2e\x54\x7a\x7e\x28\x91\x67\x13\x75\xe3\xe9\x64\xa1\xe0\xb7\x56\x8c\x52\x11\xed\x04\xd5\xaf\xeb\xbe\x29\xa4\x77\x9c\x86\x87\xa5\xe9\x4a\x70\xe7\x24\x66\x49\x94\xec\x81\x59\xec\xb0\x14\xf4\xa2\xcf\x88\xd6\x97\x89\xc1\x63\x19\x1f\x71\x1d\xa9\x35\x11\x13\x0e\x1b\x0b\x17\x64\xf2\x0d\x25\x9f\x85\x93\x9d\xa4\x68\x9c\x92\xa7\x64\x8c\xa1\x34\xe8\x23\x97\xc2\x92\x9a\x7b\xea\x46\x91\xe3\x7a\x2a\x4f\x8d\x57\xbe\xdb\x80\x18\x73\xc3\x69\x50\x2a\xe5\xa9\x8e\x35\xa5\xc1\x9c\xbf\xae\x67\x12\xa3\x4e\xe4\xd8\x3c\xdb\xff\xba\x9e\x92\x49\xd1\x36\xfe\x25\xa3\x1a\x1e\xa7\xf2\x92\xae\x37\xd2\x3d\x66\x9c\xc4\xbc\x46\xc5\xb8\x28\xfa\x54\xa2\xba\x5e\x7a\xc3\x48\x07\x40\x17\xe9\xa7\x88\x1c\x96\x5d\x80\xf3\x27\x7e\x87\x61\xa2\xb3\x37\x3e\x15\x82\x59\x26\x05\xaa\x49\x8b\x3d\x94\x12\x96\x02\x1b\xc2\x92\x73\x80\x1b\x19\xd3\x07\xa1\x1f\x6b\x81\x3a\xe7\xdb\x5f\x4e\x6c\xbf\xd1\xed\xc4\x4a\xfe\x00\xa6\x13\xed\xe0\xa9\xce\xe7\xdd\x71\xfe\x15\x23\xbd\xb2\xb5\x6d\xa7\xdf\xb2\x4e\x43\x1e\xd1\xdc\x08\x4a\x45\x26\x75\xb4\x20\x03\xa7\xcd\xd5\x22\x25\x5b\xfd\x6e\x7f\xde\xff\x59\xe9\xff\xec\xfa\xb1\xcd\x51\x8a\xa5\xd7\x37\xaa\x94\x03\x3a\x5a\x6d\xb4\xba\x4d\x93\x55\xee\xd8\x13\xe4\x7a\x63\x64\xa4\x65\x0f\x5f\xaa\x99\x3e\x31\xaa\x7e\x0a\x61\x86\xdb\x09\x03\xf7\x4c\x1e\xbc\x44\xe4\xb4\xd2\x31\xa9\x38\x2b\xe0\x26\xe4\x33\x3a\x6f\x57\xba\x95\xd3\xd5\xfe\x34\x1a\xfb\xb7\x59\xa5\xda\xf4\xd5\x7e\x19\x9a\xfd\x20\x32\xfc\x1f\xe7\x67\xa3\x75\x7e\x5b\x85\x9c\x92\x3d\x91\x6d\xab\x5d\x67\x2d\x35\x75\x02\x0b\xee\xe0\xf4\xf8\xcf\x93\xe3\x5c\x6e\xc3\xb5\x7f\xb9\xeb\xa6\x98\x28\x0e\xd4\xd4\x39\xf3\x68\xb8\x6c\x9c\x5f\x44\xe4\xc4\xf2\x2a\x10\x54\xe3\x1e\x9d\x9d\x40\x4e\x33\x76\xde\xd6\x54\x70\xa0\x8f\xcf\x11\x15\x93\x97\x18\xe6\x66\xa0\x54\xa7\x8e\x7c\xc3\xfe\xea\x75\x8f\xa5\x1a\xbe\xda\x1f\x15\xf7\x1c\xf5\x4d\x3d\xfa\xe6\x65\x90\x1c\xd0\x7a\xd1\x78\x58\x7c\xfd\xdf\x01\x00\x6f\xc6\xf2\x75\xad\x07\x00\x00",
+	"dependencies/gpu/nvidia-driver-installer.yaml":                        "\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xa4\x55\x5d\x6f\xea\x38\x10\x7d\xe7\x57\x8c\xe8\xeb\x06\xdf\xbb\xd2\x4a\xab\xbc\x55\x50\xdd\x45\xcb\x25\xa8\xd0\xbe\x22\x63\x0f\x89\x85\x63\x7b\xed\x71\x0a\xfb\xeb\x57\x49\x28\x4d\x28\x50\xb4\x97\x37\x3c\x73\xce\x7c\x9d\x99\x3c\xc0\xd4\x04\xe2\x5a\x07\xa0\x02\x61\xfe\x3a\x9d\x4c\x1f\x41\x7a\x55\xa1\x0f\x60\x4d\xf3\x3a\xb6\x86\xb8\x32\xe8\x93\xcc\x91\x2a\xd5\xbf\x28\x21\x5b\x82\xb1\x12\x03\xbc\x29\x2a\xe0\xc7\xe2\x25\xfc\x06\x5b\x6f\xcb\xc1\x03\x14\x44\x2e\xa4\x8c\xe5\x8a\x8a\xb8\x19\x09\x5b\xb2\x1f\xd6\xe6\x1a\xc7\xda\x46\xb9\xd0\x9c\xb6\xd6\x97\x4c\x9c\x58\xd1\xe4\xca\x60\xc2\x85\x40\x8d\x9e\x93\xf5\x81\x6d\xb4\xdd\xb0\x92\x07\x42\xcf\x4c\xa5\xa4\xe2\x49\x9b\x55\xa2\xda\x84\xd1\x33\x61\x03\x93\x1c\x4b\x6b\x02\x52\xe2\x3c\x6a\xcb\x25\xca\xd1\x81\x97\x7a\xf0\x00\xbb\xad\x20\x0d\xdc\x39\x7d\x00\xe1\x91\x13\x06\x50\x04\x6f\x05\x1a\x08\x0e\xc5\x28\x77\x11\x54\x80\x80\x34\x1a\x70\xa7\x5e\xd1\x07\x65\x4d\x5a\x43\x02\xab\xbe\x0f\x76\xca\xc8\x14\x26\x4d\x84\x25\xd2\xa0\x44\xe2\x92\x13\x4f\x07\x00\x86\x97\x98\xc2\x95\xcc\x8e\xf6\xe0\xb8\xc0\x14\x76\x71\x83\x49\x38\x04\xc2\x72\x00\xa0\xf9\x06\x75\xa8\x29\x00\x76\x7f\x86\x84\x3b\x77\x9d\xa7\x4e\xb3\x76\x0d\xa8\x51\x90\xf5\x2d\xac\xe4\x24\x8a\x59\x87\xe7\x0e\x26\x80\xe8\x24\x27\x5c\x92\xe7\x84\xf9\xa1\x05\xd2\xc1\x61\x0a\xcf\x56\x6b\x65\xf2\x97\xc6\x61\x00\x40\x58\x3a\xcd\x09\x8f\xd1\x3a\x55\x03\xf4\x0b\x00\x68\x2b\xbd\x15\xf7\xee\x04\x01\xde\xcb\xad\x7f\x7c\xbb\x55\x46\xd1\xa1\x13\xc8\x4a\x7c\xfc\xf4\x0a\xe0\xf1\x9f\xa8\x3c\xca\x49\xf4\xca\xe4\x4b\x51\xa0\x8c\x75\x3d\xd3\xdc\xd8\xd3\xf3\xd3\x1e\x45\xa4\x7a\xbc\x1d\x64\xcb\xb9\x3c\x36\x77\x85\xbe\x0c\x7d\x73\xd2\xf6\xfa\x69\xef\x3c\x86\x5a\x1c\x67\x76\x80\x04\x76\x78\x48\x41\xd4\xd2\x1e\xe5\x8d\xcc\x1b\xc5\xe7\xbb\x9e\xa0\xcf\x50\x00\xd6\xb5\x86\x14\x9e\xf6\x2a\x50\x38\x3a\x90\x6d\x00\xdd\x48\x49\xc7\x77\xd8\x3a\x0f\x8f\xa6\xc2\x06\x9a\x23\xbd\x59\xbf\x4b\x81\x7c\xc4\xce\xfb\x62\x3a\xe9\xbd\x55\x56\xc7\x12\x3b\xac\xed\xdc\x24\x56\xa7\xdc\x1a\x18\xa7\xa2\x5b\xa3\xab\xff\x03\xfb\x70\x7b\x07\x56\x51\xef\xb8\x49\x94\x90\x49\x69\xa3\xa1\x7b\x58\x0a\x5b\x22\xab\xd7\xc1\x1b\x24\x0c\x6c\xa3\xcc\x71\xb9\x59\x4b\xc7\x94\x90\x23\x79\x16\xe9\xa8\x98\xa3\x54\x12\xa9\x7c\x52\xc7\xf8\xb5\x80\x67\x31\xbc\xb5\x74\x7f\x1d\x67\x60\x61\x43\x42\xd6\xea\x70\x0f\xb6\xe2\x9e\x69\xb5\x61\xe7\xa0\x5a\xd6\xa7\x43\xdb\x19\x93\x2a\x79\x8e\x29\x0c\x6b\xff\x7e\x27\xd0\xa7\x5b\xb5\x47\x39\x3c\x05\x69\x7c\x17\x51\xeb\x85\xd5\x4a\x1c\x52\x98\x63\xd5\xd9\xc1\xfb\x36\xd5\x63\xb0\xd1\x0b\x0c\xe7\x3b\x86\x81\x7a\x6f\x00\xc2\xc5\x14\x86\xdf\x46\xdf\xff\xf8\xc8\x21\xa0\x88\x5e\xd1\xa1\xae\x05\xf7\xd4\xeb\x80\x57\x95\xd2\x98\xa3\xec\x29\x13\x00\x4d\xf5\xe1\xf7\xde\xd5\xf6\x5b\xb4\x9e\xce\x97\xab\xc7\xd9\x6c\x3d\x99\x3e\xaf\xff\xca\x96\xab\x0e\x5f\xc5\x75\xc4\x7b\xa6\x7c\x93\x74\x9c\xcd\x57\x8f\xd3\xf9\xd3\xf3\x05\xe6\x18\x3c\xd3\x56\x70\x7d\x8d\xef\xf5\x65\xf6\xf7\xe3\x7c\x3d\x1d\x4f\xfe\x5f\x82\x97\x74\x7f\x95\xfd\x56\xa6\x48\xe2\x36\xd7\x73\x96\xad\xd6\x3f\xb3\x97\xf9\xaa\xe6\xba\xc0\x50\xaf\xc0\x27\xd4\x38\x5b\xae\x57\x59\x36\x5b\xde\x2a\xef\x9a\xa4\xaf\xd1\xdc\xaa\x63\x13\x95\x96\x17\x98\xda\xf3\xf5\xb3\xde\xd0\xf0\x59\x2b\x5f\x9d\x08\x80\x66\xb7\x17\xed\x0a\x7e\x39\xd5\xab\xb7\xad\xcf\xf3\x65\xcf\xbb\xc7\xb5\x0f\xed\x5a\x6e\xdc\xa0\x3e\xe8\xe2\x84\x3e\xb7\xaa\x0f\xba\xdc\x50\x71\xe3\xd2\xe4\xc2\x8f\x94\x65\xed\xc7\x2c\xf9\xf0\x64\x8e\xc7\x80\xe9\xef\xa3\x6f\xc3\xb3\x93\xd2\x18\x06\xff\x0d\x00\x54\xf6\x5e\x6e\x4c\x0a\x00\x00",
+	"dependencies/istio/install/crds.yaml":                                 "\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xe4\x9c\x4b\x93\xdc\xa6\x16\xc7\xf7\xfd\x29\x54\xde\x77\xbb\xe6\xde\xcd\xad\xde\xdd\xd8\x4e\xe2\x4a\xe2\x72\xc6\x2e\xef\x69\x74\x5a\xa2\x9a\x06\x05\x50\x8f\x27\x9f\x3e\xa5\x07\x88\x87\xda\xee\xa1\x5f\xe0\xec\x3c\xe2\x1c\xf4\xff\xeb\x67\x34\x0c\x07\x81\x1a\xf2\x05\x84\x24\x9c\xad\x0b\xd4\x10\xf8\xaa\x80\x75\x3f\xc9\xd5\xee\x7f\x72\x45\xf8\xeb\xc3\xc3\x06\x14\x7a\x58\xec\x08\x2b\xd7\xc5\x9b\x56\x2a\xbe\x7f\x04\xc9\x5b\x81\xe1\x2d\x6c\x09\x23\x8a\x70\xb6\xd8\x83\x42\x25\x52\x68\xbd\x28\x0a\x86\xf6\xb0\x2e\x0e\x44\xa8\x16\x51\x09\xe2\x40\x30\xc8\x15\x03\xf5\xc4\xc5\x8e\xb0\x6a\x45\xa4\x22\x7c\x45\xf8\xa2\x28\x28\xda\x00\x95\x5d\x56\x51\xa0\xa6\x59\x17\x7d\xdb\xb2\x21\x94\xab\xfe\x22\xae\x91\x50\xe3\xe5\xfe\x42\x0d\x82\x28\x54\xc1\xba\xf8\x4c\x28\x05\xd1\x5f\x14\x40\x01\x49\xd0\x71\xb2\x01\xdc\xf5\x59\x09\xde\x36\xeb\x62\xfe\xd6\x9d\xcc\xf1\xce\x83\xb9\x2f\x83\xe2\x4f\x83\xe2\xbe\x81\x12\xa9\x7e\x9b\x69\xfc\x9d\xc8\x41\x5e\x43\x5b\x81\x68\xe0\xb6\x6f\x93\x84\x55\x2d\x45\xc2\x6f\x1d\x7c\x21\x05\x15\x17\x44\x4b\x58\x8e\xce\x47\x97\x4b\x4b\xf4\xd2\x6a\x91\x98\x37\xb0\x2e\x3e\x74\xda\x1b\x84\xa1\x5c\x14\xc5\x41\x03\x3c\x3c\x20\xda\xd4\xe8\xbf\x8b\xe5\x72\xb9\xb8\x1e\xd9\x12\xa4\x22\x0c\x75\xad\xa2\xa5\x59\xa0\x7d\x3b\x49\x7e\x6c\xa9\xcf\xd6\x6b\x0d\xe0\xfa\x86\x3d\xba\x5e\x73\xee\x78\xc7\xff\xa5\xc0\x94\x20\x59\xc0\x1d\xc7\xe4\x3b\xa6\xc4\xb3\x47\xd6\x6e\x0a\xb0\xba\x46\x3d\xa8\x56\xe3\x73\xee\x44\x2b\xa4\xe0\x09\x3d\xe7\xc0\xf2\x97\x41\xaa\xc3\x49\xcb\xf7\x08\x55\x56\x68\xce\xc3\x8d\x94\x80\x91\xc8\x62\xa0\x0d\x52\xdd\x41\x34\xca\xf7\x87\x8f\x15\x9a\x31\x1c\x60\x07\xfe\xbc\x25\x54\x41\x16\x80\xde\x75\x72\x7f\xee\xe5\x3a\x90\x6c\x1b\x1e\x28\xab\xe9\x36\xb0\xbe\xc3\xe0\x64\x96\x21\x2c\x4c\x5b\xa9\x40\x88\x0d\xc2\x98\xb3\x2d\xa9\xe4\xaa\xfb\xf7\x8b\x60\xf5\x3f\xaf\x8b\x2e\x2f\x96\x95\x7f\xcf\x80\xd2\x9b\x41\xe7\xe3\x06\xe1\x37\xbd\x4e\x87\x55\xe8\xc2\x23\x16\x04\x9c\xc6\xad\x8b\x9f\x21\x36\x8a\x99\xc1\xf5\x70\x65\x5c\x0d\xa7\x04\x13\x90\x2b\xd4\xaa\x1a\x98\x22\xb8\x9f\x42\x9d\x80\x0b\x13\x85\x4a\xa0\x17\x1b\x5d\xc7\x05\x04\xec\x3e\x76\xa2\xdd\x5f\x4f\xda\x87\x87\xa9\x99\x22\xbf\xcb\xc6\x15\xf0\xd2\x71\x75\x6d\x50\x7b\x90\x75\x96\xb0\xfe\x00\x59\x5b\xc0\xa6\x69\xe1\xd4\x10\x4c\x0a\x6d\xb3\x1e\x51\xd3\x74\x29\xaa\xf7\x1b\x7b\xb5\x52\x0d\x6a\x48\xf7\x68\x37\x84\x95\x84\x55\x72\x35\xbc\x4c\x4e\x20\xba\x27\x5f\x41\x5c\x8c\x67\x78\xdb\x80\xe3\xaf\x9f\x3f\x7f\xfc\xff\xc7\xf7\x9f\x1a\xc0\x3f\x0d\x6a\x1d\x64\x33\x66\x3c\x72\x61\xc4\x89\x04\x1b\xb2\x7f\xe9\x68\xfc\xcf\xed\xd0\x65\xc2\xec\x18\xac\x6f\x50\xca\x17\xcf\x5f\x2d\x57\x28\x93\x71\xf5\x67\xa7\xf5\xd8\xa8\x0a\x8c\x78\xb4\xfc\xf6\x1f\x00\x59\x16\xac\xe6\x21\x1d\xa5\x93\x2f\x96\x61\x6d\xf1\x04\x22\x13\x8b\x06\xe1\x5d\xff\xe4\x75\xf8\x6a\x6a\x1b\x67\xf6\x98\x0b\xb8\x25\x37\xb3\x22\xa8\x91\xcd\xad\x20\x9e\xbe\x6c\x38\xcc\x3f\x52\x43\x85\x94\x12\x64\xd3\x2a\xd8\x23\x46\xb6\x20\x55\xfe\xdc\x02\x4b\x0e\xc4\xd0\xb0\x47\x74\x3e\x3d\x53\xbc\x9b\xe7\x06\x49\x19\x3d\x18\x87\x74\x1b\x66\x1f\xb4\x44\x25\x6a\xd4\x6d\xdf\xa2\x96\x14\x8d\x52\x9b\xf3\x00\x5a\x91\x99\x52\xc3\x44\x60\xce\xda\x78\x6e\xba\x83\x24\xc8\x39\x62\xcc\x5a\x89\xb1\xe8\xaf\x91\xd8\xd1\x99\xf2\x2b\x81\x91\x6e\xd9\x31\x0e\xde\x90\x9d\x04\x3a\x4b\xca\x54\x51\xeb\xbd\x05\x85\x34\x13\x98\x29\xb3\x2d\x6d\x81\xa9\x32\x16\xda\x98\x9e\x04\x35\x5b\x8b\xc6\xa6\xed\x79\xdc\xec\xd0\x4c\xc1\xed\xda\x0d\x08\x06\x0a\x24\xb0\x43\x2c\x3e\xa7\x93\x24\x20\x86\x8a\x34\x4a\xd7\xb0\x07\x34\x4c\xcb\x14\x2b\x25\x52\xe1\x1a\xf0\x2e\xfe\x45\x6a\x75\x91\x04\x52\x5f\x8f\x06\x6a\x5b\xf5\x70\xfa\x29\x99\xc2\xdc\xc3\x7e\xf8\xb3\x36\x92\xa4\xce\x4f\x02\xa3\x23\x66\x5a\x7c\x1e\x1d\x06\x2b\xcf\x56\x70\xa6\xf4\x18\xe7\x4d\x2c\xb9\x2e\x37\x09\x6a\x46\x88\x26\xd6\xbb\xf2\x68\x99\xa0\x4c\x49\xf1\x26\x7a\x88\xf1\x26\x8d\xd1\xa5\x75\x68\x4c\x9d\x25\x8f\x92\x0e\xc9\x14\x52\x23\xf8\x1e\x54\x0d\x67\xfc\x7d\x37\x75\x91\x04\x33\x4f\x8e\x29\xaf\x5a\x46\xfd\x12\xab\x9b\x91\x29\xc9\xae\x30\x1f\x8b\xd0\xec\x53\xb8\x37\x3c\x23\xc4\x2c\x73\x76\xae\xfc\x65\x4e\x1d\x94\x2b\x29\x28\x89\x3c\x6b\x0a\x32\xf5\x90\x06\x35\x57\x8e\x61\x37\xf9\xf4\x09\xda\x09\x29\x20\x19\xb7\x69\x62\xce\x94\xe0\x34\x16\x8b\xdb\x4b\x12\x68\x66\x24\x79\xfb\x56\xb5\xe7\xf9\x8d\xab\x76\x62\xa6\xc3\x4d\x92\x8a\x21\xba\xfd\x1a\x4d\x75\xcc\x4f\x83\xa7\x2d\xc6\x90\xd4\x0e\x7d\x86\x76\x70\xae\xf4\x38\x45\xe2\x89\xb0\x32\x7e\x82\x32\x75\x91\x06\x42\x57\x8e\x81\x68\x19\xf5\x39\xba\x19\xb9\x92\x54\x08\xef\x4a\x41\x0e\xf1\x8b\x28\x56\x17\x69\xa0\xf4\xf4\x18\x96\xd3\xf5\x80\xa5\x97\x92\x2f\x4c\x25\xcb\x33\x38\x2a\x59\xa6\x82\x50\x4b\xb1\xe8\x75\xde\x42\x70\x3a\x30\x5b\x66\x25\xe1\xf1\xc8\xca\xd1\xde\xfd\x89\x69\x25\x13\xb0\xce\x58\xc0\x4b\x87\x65\x8a\x0b\x35\x64\x07\xcf\xb1\xbc\x86\xec\x10\x18\x61\x52\x21\x86\x6f\xbb\x09\x62\xd2\xa2\x91\x8d\xe6\x3c\x66\x56\x60\xae\xd0\x5a\x55\x73\x41\xfe\xee\xf7\xea\x46\xb3\xb3\x3b\x49\x04\x61\x20\xc9\x90\x74\x1c\xfb\x40\x83\xb4\x4c\xb9\xf6\x75\x0f\xc6\x55\x7d\xea\x5e\xcc\x19\xac\x76\x1f\x69\x50\x0d\x14\x69\xa8\x8e\x5d\x8f\x69\x90\x94\x7f\xad\x36\x7a\xa0\x0e\xbf\xfd\x56\x0a\xf6\x0d\x45\x0a\x56\x53\x9f\x69\x00\xf6\xf4\xcc\x54\x6d\x8f\xd7\x6c\x73\x2f\xd8\xea\x2f\x8f\xe3\xeb\xb5\xd3\x57\xbb\x77\x07\xe9\xca\xb1\x8b\xb5\xf3\xdf\x1d\xbb\xf1\xb9\x52\xe4\xd5\x99\x10\x79\x35\x3d\x84\xfb\x33\xe4\xd5\x0c\x42\xe3\xd1\x27\xc8\xab\xec\x01\x42\x59\x45\xb3\xeb\x72\xd3\xe0\x66\x94\x68\x66\xbd\x2d\x0f\x97\x09\xca\x76\x57\x84\x12\x04\xc7\xef\x89\xe8\xb2\xd3\xc0\x65\x69\x99\x36\x44\xf4\xe6\x82\xed\x10\x26\x30\x53\x68\x67\x15\x91\x8e\xd4\x8f\xee\x81\x2c\xac\x1d\xcd\x96\x8d\xb2\xdf\xbc\x22\xa0\xe1\x42\x9d\xf9\x67\x84\xd3\x49\x1a\xfc\x42\x49\x53\x0d\xd0\x76\x1c\x94\x01\xfd\xb4\x4c\xb9\xba\xc5\xb2\xc1\xd6\x65\x8a\x88\x43\x5f\x69\x50\x3e\xaa\x6c\xbe\xa2\x38\x3e\x86\x6f\xd6\x15\xad\x4e\x32\x45\xaf\x04\xc2\xdd\xdd\xa3\x17\x7b\x4c\x07\x69\x40\x76\xe5\x68\xb2\x93\x4b\x0f\xa7\x1b\x9e\xf1\xd6\x99\x93\x8f\xfa\x78\xd1\xe7\x66\xd3\x56\x95\x0b\xd0\xfb\xee\x89\x20\x47\x8e\x02\x39\x7e\x06\xc8\x85\x0e\xff\xb8\xeb\xb1\x12\xe3\x0b\x45\x70\x0a\x79\xd3\x1b\x0f\x19\x7b\xe4\xde\x97\x9e\xb6\xc1\xf9\x97\xa9\xe0\xa7\x7e\xf7\x99\x38\x40\xf3\xb1\xfb\x0f\xc2\x71\xee\x83\xf8\x19\xbb\xc7\xa9\xbe\xe8\xa3\xf8\x14\xe1\x8e\x2b\xa4\x67\x2e\xb0\x26\x51\x77\xb4\x6f\xa9\x61\x6a\x7b\x1e\x41\x47\x5d\x9e\xbf\x13\xf5\xdc\x23\x96\x9c\x33\x77\xb9\xfb\xac\xc6\xb9\xa7\x86\x67\x2c\x7a\xf4\x5c\x81\x99\x4e\x4b\xc7\x8a\x44\xf4\xac\x74\xcc\x0f\xf1\x39\x2d\xb7\x9a\x94\xda\xf7\x34\x73\x52\x6d\xd1\x9f\x92\x3a\x02\xf3\xc4\x57\x23\x56\xd2\xf8\xd7\xe6\x98\x1e\xc2\xb3\x1b\x6e\xc4\xce\xbe\xa5\x39\xbd\x67\xb4\xe7\x9f\xdc\x63\xab\xcb\xb4\x4e\x4c\x79\x5b\x3e\xa1\xee\x3b\xb9\xe8\x32\xb1\xe9\xe2\xf8\xaf\xbd\x88\x52\xaf\xdb\xeb\x74\x4c\xe0\xa4\x37\x38\x20\xd0\xc9\xc8\xf5\xf3\x77\x5e\x9d\xb7\x57\xcd\x74\x70\x49\x1a\x6e\xa7\xe6\x73\x76\xa3\xd5\xff\xa2\xdd\x09\xbf\x2a\x89\xeb\x1d\x82\x3a\x1e\x38\x49\xa4\x6c\xfb\x37\x1b\x08\xb5\x47\x0c\x55\x20\xc6\xae\x17\x45\x81\x18\xe3\x6a\xd8\x49\x32\x78\x7b\x55\x03\xdd\xaf\x64\xfd\xba\xe6\x7c\xf7\x6a\x5d\x60\x51\x0e\x13\x08\x4a\xe7\xf8\x59\x9d\xda\xef\x38\xff\x72\xcc\x9b\x6e\x4e\x6e\x38\x9b\x3e\x7e\x4e\xe7\xfb\xde\xf7\xdc\x19\x9d\xe3\x13\x09\x0f\xf4\xbb\x2e\x8f\x7f\x1d\x88\x19\x02\xc1\xa3\xb7\x46\xc8\x95\x47\x03\x08\x45\xb6\x04\x0f\x73\xb4\x1f\x14\xc1\xdc\x6b\x27\x1c\x1f\xd3\x93\x70\x47\xc7\x74\x7d\x7c\x1f\xd6\x5c\xa8\x0f\x53\x7a\x51\x2c\xfb\x20\xe7\x07\xb9\xf8\x67\x00\x1c\x8d\x8f\x26\x8c\x62\x00\x00",
+	"dependencies/istio/install/istio-noauth.yaml":                         "\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xec\xfd\xeb\x7a\xdb\x36\xb6\x00\x0c\xff\xf7\x55\xa0\x4c\x67\x62\x77\x9b\xb2\x24\xdb\x89\xad\x4e\x66\x1e\xc7\x76\x5a\xcf\xc4\xb1\xb7\xed\x74\xf6\xec\x26\x5b\x03\x91\x90\x84\x9a\x22\x59\x02\xf4\xa1\x4e\xe6\x1a\xbe\x1f\xdf\xff\xf7\x16\xdf\x4b\x78\x1f\x1c\x78\x06\x29\xea\x68\xd9\x41\xf7\x9e\xd6\x02\x71\x5c\x58\xc0\x3a\x62\x2d\xe8\xe3\x5f\x50\x40\xb0\xe7\x76\xc0\x4d\x6b\xed\x1a\xbb\x76\x07\x7c\x80\x23\x44\x7c\x68\xa1\xb5\x11\xa2\xd0\x86\x14\x76\xd6\x00\x70\xe1\x08\x75\x00\x26\x14\x7b\x26\xb9\x27\x14\x8d\xd6\x00\x70\x60\x0f\x39\x84\x7d\x06\xf2\x13\x76\x7f\x43\x16\xe5\x1d\xda\x98\xc0\x9e\x83\xec\x35\xd3\x34\xd7\x5e\x80\x4b\x2f\x0c\xac\xa8\x8b\x2d\x6b\x08\x03\x4a\xb6\x06\xd0\x71\xd0\xfd\x16\x45\x23\xdf\x81\x14\x91\x2d\xcb\x73\xfb\x78\x30\x82\x7e\xe3\x1e\x8e\x9c\x35\xe5\x04\x0f\x79\x9d\x53\xe8\x97\x4e\x50\x74\x6b\x8a\xce\xc2\x00\xb2\x09\xc9\x2a\x7c\x65\x95\x0b\x81\xbe\xdf\x01\xa2\x07\xfe\x9b\x4f\x35\x53\x32\x44\x01\xa6\x70\x80\x3a\xe0\x0a\x3b\x0e\x0a\x78\x61\x80\x1c\x04\x49\xd4\x75\x02\x92\xb8\x65\x34\xd1\x1b\xe8\x60\x1b\x52\xec\x0e\x6e\x51\x6f\xe8\x79\xd7\x99\x69\xf2\x75\x77\xc0\x17\x53\xce\x25\x59\x3f\xb4\x47\x98\xb0\x3f\x03\x34\xc0\x84\xca\xea\xd7\x7b\xa4\x81\xbd\xad\x9b\x56\x0f\x51\xd8\xe2\x8d\x04\x94\x7e\x89\x87\xf9\xa7\x18\xe6\x30\x07\x0d\x00\xd2\xe0\x03\x40\xc0\x27\x0b\xc2\xd4\x07\x35\xe0\x00\xc8\x02\x4f\x05\x40\x35\x10\x4b\x01\x59\x02\xcc\x22\x40\x01\x00\x40\x82\x30\x1e\xdd\x94\x8b\xf0\xb1\xe3\xd1\x46\x04\x6a\xcf\x6d\xf0\xa6\x8d\x54\x5f\x96\x83\x91\x4b\x05\x50\x92\xb9\x03\x40\x50\x70\x83\x2d\x94\x2e\xaa\x80\x4c\x1d\xf8\x88\x7f\x7c\x48\x87\x1d\x60\x6c\xb1\x6d\xa4\x7c\x7a\x46\xea\xbb\x05\xdf\x86\xae\xed\xa0\x0e\x30\x92\xe2\x20\x74\x10\x49\x4f\xc4\x04\x9e\x8f\xc4\x06\x92\xec\x04\x4d\x70\x78\x71\x7c\x70\x75\x9c\x2b\xfc\x78\x7e\x94\x2f\x84\x3e\xfe\x29\xf0\x42\xbf\xd0\x81\x40\xc3\x22\xa0\xb2\x78\x58\x68\x76\xd3\x82\x8e\x3f\x84\xed\x4c\x71\x80\x08\x3f\xee\x85\xda\x43\x4a\x7d\xe8\x63\xe2\x23\x8b\x94\x7f\xea\x61\xd7\xc6\xee\x20\x5f\xe3\xf7\xd0\xa3\x50\xd5\x34\xfe\xa0\x68\xb8\x50\x98\x05\x3d\x68\x4d\x0c\x31\xe3\x07\xa3\x1e\xb0\xb2\x15\x17\xba\x10\x18\xd2\x21\x72\x29\xb6\x4a\x4e\xcb\x13\x5c\x92\x8b\xe8\xad\x17\x5c\x63\x77\xb0\xb8\xe5\xd8\x88\x50\xec\xf2\x05\xf0\xc3\x9a\xfb\x8c\xdc\x1b\xef\xbe\x8f\x1d\x8a\x82\xfc\xa7\x01\xa4\xe8\x16\xde\xe7\x8b\xe5\xed\x83\x5c\x1a\xe0\x42\x77\x37\x38\xa0\x21\x74\x64\x9d\xe4\x6b\x1f\x62\x27\x0c\xd0\xb9\xe7\x60\xeb\xbe\x03\xde\x41\xec\xe4\xae\xc3\x11\xbe\x43\xc1\xea\x5e\x87\x7c\x7a\xdf\xe2\x75\xa8\x42\x1a\x48\x69\x80\x7b\x21\x45\x23\xe8\xe2\x3e\x22\x34\x5f\xc1\xc2\x81\xe5\xb9\x21\x29\xb4\xb4\x91\x8b\x8b\x98\xd6\x77\x42\xe4\x52\x3b\x5f\x7c\x1d\xf6\x50\xe0\x22\x8a\x08\x72\x6f\xf2\x1f\x1d\x4c\xa8\x35\x44\xd6\x75\xb1\xbb\x11\x1a\x89\xeb\x36\x57\xee\x7a\x9e\x9f\x2f\xf3\xfc\x42\x35\x3f\xf0\x46\x88\x0e\x91\x62\xfa\xec\x32\x2d\x39\x0f\x96\xe7\xd2\xc0\x73\x0a\x5f\x3d\x07\x06\xb7\xd8\xb5\x8b\x9d\x11\x0a\xad\x6b\x3b\xc0\x37\xc5\x15\x58\x8e\x17\xda\xb7\x90\x2d\xb0\x00\x41\x6f\x40\x28\xa4\xc4\x56\x74\xa7\x2c\xb5\xb1\x57\xd8\x3f\x1f\x5f\xa3\x7b\xa2\xb8\x60\xbd\x00\xff\x21\xb0\x35\x3f\x23\x06\x6a\xd7\xa3\xc3\x02\xd1\x7b\x91\xdd\x29\xd5\x3e\xa9\xef\x0a\xc7\x1b\xa8\x3f\x8c\x10\x0d\x70\x09\x05\xcd\x6f\x08\xf2\xbd\x80\x2a\xe7\x95\xdf\x19\x51\x35\x5f\x87\x06\xd0\x62\x37\x81\x5b\x7d\x57\x55\x09\x08\x01\xec\x43\x17\xaa\x24\x04\xd3\x0a\x09\xf5\x46\x66\x7c\xb8\x66\x13\x19\xc4\x40\x85\x4e\x27\x14\x1b\x44\x2f\x19\xb9\x21\x55\x34\xa9\xe0\x20\x9b\x46\xf3\x55\x2e\x58\x2d\x2b\xa8\xc9\xf9\x96\xbc\x9d\xd2\x72\x82\xd8\x89\x0a\x79\x20\x82\x0c\xdf\x60\x73\x44\x1d\x62\xc6\xa2\xdd\xf4\xe2\x41\x0a\x2a\x25\xc0\x9a\x54\x40\x60\xfc\x5f\x34\x0a\x85\xc1\x00\xd1\x82\x54\x90\xef\x9e\x2f\x29\x99\x99\x09\xdc\x70\xd4\x43\x41\x07\x6c\x37\x9b\xcd\x35\x00\x82\xd0\x6d\x90\x61\x0c\xe1\x17\xdf\x6d\xf5\xb0\xbb\x45\x86\x6b\x62\x40\x44\x81\x79\x27\xfe\xc6\x7d\xf0\x2b\x30\xbe\x7f\x61\x00\xd3\x45\xc0\x68\x19\xe0\xf3\x8f\x80\xed\x41\xdc\x39\xb2\x86\x1e\x30\xfa\x38\x20\x14\xc0\x60\x10\x8e\x90\x4b\x01\x19\x7a\xa1\x63\x83\x1e\xe2\xf4\x10\x50\x4f\xee\x71\x4c\x31\x00\xdb\xe2\x84\x04\xa2\x3b\x4c\x81\xd8\xbd\x3e\x16\x23\xb3\x86\x57\xde\x85\xac\xff\xaf\x83\xd3\xf7\x6f\xbe\x7f\x68\x7d\x15\x1f\xd9\xd5\x61\x51\x07\x0c\x10\x1d\x23\x77\x66\xa8\x39\x68\xff\x75\xcb\x46\x37\x5b\x6e\xe8\x38\xe9\xe5\xfd\xcd\x00\x26\xfa\x1d\x34\x4b\x16\x97\xe9\x62\xcc\x70\x7d\x2f\x74\x6d\x60\x82\x5b\x88\x59\x1d\xd0\xf7\x82\xec\x14\x6c\xe4\x3b\xde\x3d\x87\x12\xf5\x18\x84\x02\x04\xed\xfb\x04\x14\xb7\x43\xec\x20\x40\x83\x10\xfd\x08\xec\x2c\x79\x8e\x56\x6d\xba\x19\x7c\xe4\x50\x48\x75\x5b\xb9\xe2\xe8\x9f\x71\x2b\x8f\xfe\xe9\x05\x08\x5e\x67\x4a\xfb\x38\xf3\x93\x38\x08\xf9\x72\xef\xd8\x3f\xb6\xe7\xa2\xb5\x71\x33\x0e\x3c\xc7\xf1\x42\xca\xc9\x4f\x48\xca\x26\xbf\xa6\x98\xac\x8b\x4a\x26\xab\xd8\xaa\x54\xb7\xb9\x01\x39\x61\xe2\x77\x36\xb2\xb3\x2c\x71\x0a\x13\x73\x8b\x1d\x33\x00\xdb\x45\xbe\xdb\x59\x7c\x48\x78\x53\x63\x2d\xd5\xa1\x80\xda\x6e\x06\x99\xa1\xef\x3b\xf7\xc0\xec\x83\xef\x1f\x8a\xc8\xff\x75\x6d\x6d\x5a\x7a\x62\x43\x32\xec\x79\x30\xb0\x25\x25\x99\x99\x94\xa4\x17\x98\xea\x3d\x52\x4f\xc5\x25\xab\x44\x63\xf2\x73\x6b\xfc\x46\xd8\xf2\x5f\x3e\xac\x01\x60\x74\xbb\xd8\xf5\x43\x4a\x8c\x0e\xf8\x75\x0d\x00\x00\x1e\xf8\xbf\x01\x30\xd8\xfc\x8d\x0e\x30\x8e\x2e\xbb\xe7\x17\x67\xa7\xc7\x57\x3f\x1f\x7f\xbc\x34\x36\xa3\xcf\x7c\x11\xec\xfb\x79\xcc\xfe\x25\x1f\x6d\x44\xac\x00\xfb\x7c\xef\x3b\xc0\x48\x3e\xd0\x7b\x9f\x77\xca\xe6\x26\x76\x38\xf9\xe6\x3b\xe1\x00\xbb\x27\x36\xfb\xee\x2b\x3a\x15\xdf\x3f\xc8\x69\xa5\x86\xe5\x15\xbe\xae\x01\xf0\x79\x53\x2c\x29\x40\xbf\x87\x38\x40\x8a\x45\x45\xe3\x4b\x18\x25\x9d\x63\x5b\x59\x1c\x01\xe1\xa7\xfc\x87\x1b\x81\x45\xec\xdb\x6e\xa3\xdd\xd8\x96\x93\xd8\x54\x0f\xe7\x43\x17\x39\x8a\xc1\xfc\xa1\x72\x28\x7f\x58\x32\x50\xb3\xd1\xac\x1e\x48\x05\x57\x5c\x0a\x51\xb7\x08\xcb\x71\xe3\xc6\x50\x86\xae\xeb\x51\xc1\xfa\x1a\x1d\x39\x11\x83\x31\xaf\x31\xd0\x93\xe9\x01\x60\xf4\x42\xec\xd0\x13\xd6\x5d\x6b\x33\x29\x4d\x4d\xb7\x03\x0c\xd3\x04\x12\xce\xc0\x34\x8d\x54\x35\xe4\x32\xde\xc4\xe8\x70\xda\x90\x2a\x1f\x62\x5b\x51\x8a\x2d\xcf\x3d\xf4\x1c\x2f\x60\x7d\x06\x83\x1e\x5c\x6f\x6e\x82\x76\xab\xb5\x09\xda\xbb\xbb\x9b\xa0\xb5\x91\xee\x3a\x02\xc1\x41\xb2\x1c\xf0\x67\x70\xe0\xa0\x80\x92\x74\xbd\x04\xc0\xf2\x14\x45\x77\xe7\x57\xfe\xdf\xcf\x6b\x72\x4f\x0c\x64\x63\x2a\x67\xdb\x67\x82\x3d\x2f\x1c\xb8\x88\x72\xc4\x66\xc4\x48\x94\xb0\x5d\xbe\xf2\x3c\x87\x62\xdf\xe8\x80\xe6\xe6\x5a\xb4\x53\x71\x15\x07\xbb\xd7\x1c\x85\x05\xc0\x39\x0e\x29\x50\x1a\x3a\x18\x12\xbe\x5e\xbe\x11\x5f\xe3\x1d\xec\xc1\x80\xa4\x67\x21\x21\x4e\x86\xef\x91\x3b\xa0\x43\xb6\x15\xcd\x4c\x39\x52\x55\x4f\x6f\x90\x0a\x4f\xfa\xd8\x71\xd2\xbb\x6a\x0c\x02\x6c\x9f\x7b\x09\x52\x88\x9d\x32\x3a\x60\x37\x05\xce\x5b\xa3\x03\xda\x3b\xa9\x82\xbb\x08\x08\xe2\x1f\xe3\x9e\xfd\x8e\x40\x9c\x45\xe5\x9d\x57\xf1\x6f\x07\x0d\x90\x6b\x67\x87\x82\x37\x83\xfc\x32\x00\x30\xac\x30\x08\x90\x4b\x15\x5f\x46\xf0\x4e\x55\x8a\x5d\x45\x29\x19\x7a\xb7\x45\x84\xa3\x1e\x85\x8e\xa2\xf6\x0d\x74\xc2\x04\xa6\x85\xc5\x38\xd8\x45\x24\xd7\x1b\x2f\xbc\xc5\xb6\xd8\x9e\x74\x69\x0a\x15\xd8\x3f\x06\x43\x93\x73\x0f\xbb\xf4\xd4\xe3\x87\x80\x17\xa4\x2e\x4b\x14\x58\xc8\x65\x54\xa3\xb0\xa7\x3e\x6b\x15\x40\x1b\x87\x24\xbd\x2b\xa2\xbc\x88\x02\x01\x72\x6d\x14\x20\x7e\x9a\xfa\x8e\x47\x93\x31\x08\x62\xc2\xe8\xd9\x0d\x0a\x02\x6c\xa3\xdc\xfc\x38\xe9\x53\x61\x1a\x17\xe3\x0b\xa3\x10\x8a\x7c\x1f\xd9\xef\xb1\x5b\x9c\xb0\x64\xfd\x53\x17\x4b\xfa\x6a\x01\xc0\x40\x77\x3e\x9f\x1e\x09\x47\xeb\x9c\x08\x76\xd9\x75\x63\x3f\x58\xde\xc8\xf7\x5c\xe4\xd2\x37\x9f\x0c\x41\x05\x3f\x19\x5f\x37\x40\xef\x1e\xac\x53\x38\x48\xdf\x03\x00\x18\x7d\x2f\x18\x41\xca\xba\xa1\x78\x84\xba\x62\x71\xd9\x2a\xd8\xa5\x28\xb8\x81\xce\x3b\x68\x51\x2f\x48\xef\x50\x0a\x1b\xdf\xc5\xfd\x3c\x3c\x00\x0a\x07\xe0\xeb\xd7\x6c\x2f\x01\xea\x0b\x22\x77\x90\xf0\x5f\x5f\xe5\x5f\x09\xfc\xe8\x30\x40\x64\xe8\x39\x76\x0e\xae\x6c\x72\xef\x02\x6f\x94\xba\x29\xe2\xf2\xcb\x21\xee\xd3\xe2\x07\xea\x08\xc2\xc2\x21\x00\x22\x35\x57\x8a\x28\xc7\xd7\xd0\x43\x1a\xd5\x61\x80\xec\x22\xb2\x13\x2f\xa0\xb9\xb3\xca\xf1\xbc\x1b\xdd\x90\x4c\x5f\x7f\x83\xed\x10\x3a\x46\x01\xe5\x53\xe4\x37\x4d\xe3\xee\xe0\x1d\xce\x5d\x18\xbd\xd0\xba\x16\x7b\x9e\x5e\x0c\x00\xc6\x48\xa2\x3b\x5b\xaf\xe2\x22\xcf\xd5\x56\x1f\xd8\xf8\x60\xfe\xfa\xb9\x30\xc5\x7b\x78\x87\x2a\x50\x2d\xc1\x12\x32\x64\x90\xc8\x22\x80\xe4\x89\xb2\x93\x60\x1f\xbc\xc1\x5b\x48\x50\x01\x63\xc4\xdd\x53\xa8\x2e\x2e\x9f\x42\x71\x6a\x31\x09\xe2\x6c\xae\xec\x3c\xd3\xb7\x9e\x0a\xc3\xef\x8b\xbb\x0e\x1d\x3c\x50\xdd\xbb\xbc\xfc\x3d\xba\x89\x67\x9d\x21\xbe\x79\x66\xc8\xf2\x1c\x07\xfa\x04\xd9\xf9\x9e\xca\x69\x53\x6b\x52\xda\xb4\x5b\x42\x9b\x9a\xc9\x75\x1a\xd3\xeb\xcf\xc5\xb3\x18\xc9\x37\xe0\x23\x61\x57\x74\xe1\x80\x04\xde\xad\x9a\xd1\x7b\x32\xd4\x7e\x2f\x07\xd1\x57\x63\x00\xfa\x4a\x0d\xd0\x6d\x4d\xec\x35\xb1\x57\x12\x7b\x3f\xf0\x2c\x44\x48\x57\x9a\xd0\xba\x23\x34\xf2\x82\xfb\x6e\xef\x9e\x22\xf2\xf0\x9b\xd7\x4b\xd3\xfc\xd9\x29\x7d\xbb\x92\xd2\xff\x22\xe6\x00\x4e\xf9\x1c\x6a\x90\xfb\xcd\x7a\x6b\x0b\x10\xc1\x36\x72\xe9\xa3\x2e\xee\x42\x4e\xa2\x7a\x75\x6f\xeb\xaf\x6e\xe0\xb1\xf5\x70\xfb\x4b\x77\x88\xa0\xdf\x25\xf7\xe4\x71\x96\xc6\x46\x07\xe4\x9e\x94\xac\xe9\x70\x86\x35\x41\xc7\xf1\xac\x47\x5c\x15\x1f\xbf\x64\x5d\x47\xd3\xad\xeb\x11\x97\x74\x50\xb1\x9a\x77\x33\xec\x12\x66\x46\xd7\xc7\x59\xd2\xcf\x6c\x97\xb0\x6b\x86\x04\x95\x2c\xec\xa7\xe9\x16\xc6\xef\xdc\xc7\x5c\xd9\x25\x9b\x40\xf5\xd2\x7e\xae\xbf\x34\x26\xd4\x31\x93\x24\xc4\x2e\x0a\xa2\x8b\x30\x64\x6c\x93\x5c\x5d\xf2\x91\xc9\x00\x6f\xfe\x93\xac\x74\x13\xf8\x9e\x1d\x97\x66\x1c\x09\x1b\x3f\x30\x51\x70\xd1\x12\xe0\x15\x63\x16\xc0\xfa\x35\x26\x1b\x25\x80\x38\x5e\xae\x0c\x98\xbb\xc2\xb5\xe8\xa7\x45\xbf\x67\x25\xfa\x3d\x1b\xf1\xe8\x55\x4d\xf1\x68\x4f\x8b\x47\x5a\x3c\x2a\x23\x9b\x01\xa4\x28\x45\x3b\x2d\x3f\x94\x84\x93\x30\x27\x1f\x9b\x74\xf9\x6e\xcd\x40\x40\x7f\x6d\x8d\x3e\x6f\x6c\x2c\x9a\x9f\x88\xa8\xe8\x1e\xd9\x98\x5d\xb4\x5a\x22\x5c\xb8\xa2\x39\xdb\xc9\xc2\x61\xf5\xf0\x00\xb2\x23\x82\xaf\x5f\x2b\x41\x37\x81\xdc\x86\x39\xdc\x22\xd9\x94\x41\x2d\x0b\xaf\x1c\x9b\xc9\x81\xb0\xe8\xf5\x8a\xd1\xc0\x3a\x41\x4e\xdf\x14\x2e\x6b\xc8\xde\xa8\x21\xcf\x2d\x81\xd9\x3a\x3c\xff\xa8\x39\x2d\xcd\x69\x2d\x71\x9e\x9a\xd1\x9a\x98\xd1\x6a\xb5\xeb\x71\x5a\x3b\x6d\xcd\x69\x69\x4e\xab\x4a\x59\xeb\xf9\xc8\xed\xf6\xed\xa5\xab\x5b\xce\x7c\xe4\x82\x77\x47\x04\xac\x8b\x21\xe7\xc0\x24\x25\x2c\x44\x9f\xcc\x49\xd7\xf2\x28\x8c\x4f\x0d\x9e\x67\x09\x7c\xc0\x11\x26\xd7\x9a\x11\xd0\x8c\x80\x56\xb9\xac\x36\x27\xb0\x57\x93\x13\xd8\xd1\x9c\x80\xe6\x04\x4a\xac\x30\x03\x2f\xf0\x42\xca\x20\xbb\x68\x36\xe0\xd1\xdc\xc8\xe2\x15\x6a\x9a\xa6\x69\xda\xb3\x14\x6e\x97\xe1\x40\xd6\xda\x51\x53\x97\x56\x5d\x0f\xb2\xd0\xcd\x20\xf0\x33\x72\x1d\x7b\x95\x83\xe5\xde\x38\x50\x96\x38\xe3\x69\x89\x5d\xd3\xe9\x6a\x1b\x80\x20\xce\xdd\x40\x9c\xa5\x2e\x0f\xc1\x83\x06\xf7\x5d\xcf\xed\x5a\x43\xe8\x0e\x90\xd0\x6c\x4b\x75\xfe\x0f\xe0\x55\x73\xd1\x1e\x03\x97\x72\x0a\xe0\x90\x8f\x0f\x8e\x6f\x90\x4b\xc9\x1c\xcd\x1e\xb9\x25\x4b\xe5\x85\x17\x74\x11\x1f\x29\x2a\x40\xf6\xd2\x97\x7e\x1e\x8d\x5c\xbd\xe8\xb7\xf3\x5c\x34\x71\xa1\x4f\x86\x1e\x5b\x77\xd8\x73\x30\x19\x3e\xc2\xba\x2f\xe5\x1c\xc0\x79\x34\x85\xd5\x30\x5f\xf0\x5d\x00\x17\x90\x6a\x4e\x6f\x81\x9c\x9e\x00\x33\xd9\x62\x84\xe4\x29\x70\x7c\x86\xa1\x8d\x19\x2b\xcb\x19\xed\xd5\xe4\x8c\xb4\x06\x43\x73\x46\xd3\x71\x46\xec\x57\xd0\x1d\xc1\x3b\xfe\x57\x37\x40\xd0\x7a\x0c\x9a\x79\x0a\xef\xc0\x15\x1e\x21\x70\x21\x26\xb0\x38\x06\x29\xb7\xf2\xdf\x43\x8c\x88\xf5\x78\x0b\xff\x6f\x31\xfe\x98\x75\xbf\x9d\xfb\xba\x03\x44\x10\x25\x4b\x5f\x2e\xdb\xe3\x00\x5c\xf0\xc1\x57\x83\x2b\x92\x33\xd2\x5c\x91\xe6\x8a\xb4\x93\xc7\x53\xe1\x8b\x5a\xaf\x6a\x32\x46\xda\x9d\x76\x8e\x8c\xd1\xf6\x63\x31\x46\x99\x65\xcf\x85\x2f\x1a\x62\x42\xbd\x41\x00\x47\xdd\xdf\x43\xe8\x52\xec\xa0\xf5\x66\x63\xb7\xb9\x09\x48\x38\xe2\x6e\x9e\x0e\xda\x00\xe3\xd5\x0c\x91\x92\x85\x6f\x6b\x57\xdc\xd0\x1b\x0b\x7f\x85\x72\xbe\xdb\x9c\x9d\x3f\x52\x42\x60\xff\xa9\x40\x60\xbf\x39\x3b\xa7\xa4\x86\xc0\xee\x53\x81\xc0\xee\xec\x8f\x1c\xd5\x10\xd8\x7f\x2a\x10\xd8\xaf\xf1\x1c\x72\x59\x7a\x35\x02\xce\x51\x00\x22\xd5\x9f\xe6\x24\xb5\x25\x55\x73\x90\x4f\x49\xb3\xd6\x6e\xa9\x19\x48\x1d\xae\x42\x6b\xd6\xca\xf4\x2c\x22\xf0\xd4\xbd\x8f\x3e\x5e\xbc\x2f\xd2\x49\xf6\x78\x5b\xdc\xdc\x5d\xec\x12\x0a\x5d\x0b\x49\x12\xb9\x94\x40\x55\x62\x5a\x2b\x13\xac\xea\x92\x41\x03\x5c\xdd\xfb\x08\x9c\x44\xd0\xd0\x54\x72\x71\xfa\x96\x43\x2f\x74\xa9\x56\xb5\x3c\x6b\x97\xa3\xf6\xeb\x92\x47\xc4\x3b\xf5\x5c\x8e\x7e\x49\x02\xfa\x6a\xaf\x23\xd0\xde\x2b\xf1\x3a\xd2\x3a\x24\xcd\x02\xa8\xdd\x83\x05\xc1\x4f\xe2\x62\x77\x2d\x14\xd0\xee\x35\xba\xef\x86\xbe\x0d\x17\x12\xa9\xa5\x9a\xf0\xff\x03\xdd\x83\x8f\x62\xe8\xd9\x35\x44\x63\x97\xd7\x45\x41\xe0\x05\x8f\xb8\x48\x70\xcc\x27\xd0\x01\x0f\x0f\x80\xcf\xa5\x9c\xdd\x59\xf2\x13\xa1\xe4\x6a\x05\x32\xb7\x1b\x38\x44\x01\xc5\x7d\x96\xef\x01\x69\xc6\x47\xab\x07\xb4\x7a\xe0\x49\xa9\x07\x4a\x98\x83\xed\xa6\x66\x0e\x34\x73\x50\xe6\x87\x51\xa4\xa0\x3e\x64\x0e\xb1\x79\x8a\x29\x22\x8b\x0c\x58\x92\xb3\x4d\x20\x03\xf5\x6f\xc6\x09\x66\x16\xaf\x49\xe7\x93\xe2\x54\x94\xcf\x01\x7c\xfd\xba\xf5\xf0\x10\x4d\x44\xfc\x8a\x26\x53\x4b\x9f\xb0\x39\x0d\x68\x44\x0a\x93\xc9\x40\xc3\xfe\x82\xc4\x73\x17\x0e\xa2\x77\x7c\x72\x75\x41\x04\xd6\x1f\x1e\xe4\xcc\xbe\x7e\xdd\x58\x0d\x8e\x24\x8e\x50\xac\x94\xfa\x34\x1b\xa2\xd9\x10\xcd\x86\xac\xbe\x9f\x4b\x29\x1f\xa2\xdf\x46\x69\x3e\x64\x02\x62\xcb\x32\x39\x0b\xf1\x5d\x4d\x70\x45\x72\xb1\x65\x18\x2a\xc4\x48\x2b\x63\xa7\x48\xa8\x23\xf8\xf9\xea\xea\x5c\x6a\x18\x34\xa5\xd4\x94\x52\x9b\x29\xa6\x34\x53\x6c\x97\xbd\x8c\x6e\xd7\x33\x53\xfc\x23\x4e\xae\x2b\x13\x06\x6a\x6b\x45\x07\x6c\x97\x38\xbc\xb6\xf4\x53\x20\xcd\x08\x28\x19\x81\xf4\xa3\x10\x96\xa7\xb3\x2b\x50\xb7\x2b\x12\x54\xa3\xa0\x3b\x84\xae\xed\x20\xe1\xcc\xd7\x25\xa1\xc5\x83\xa0\x25\x0f\x44\x96\xf3\x76\x56\x0c\x3b\xbb\x8e\x61\xb2\xd5\x72\x4e\x68\xd9\x6b\xe5\xac\xc5\x6a\x28\x07\xc4\xbd\x0a\x92\x17\xc2\x9a\xe1\xd1\x4f\x61\xb4\x8a\xe0\x29\x5a\x2a\x4a\x19\x03\xed\xc9\xa8\x19\x83\x89\x48\xa5\xfc\x8f\xe5\xb9\x37\x28\xa0\x28\x78\x2c\x9e\xe0\x21\x49\xf2\xfd\xe6\x93\xf1\x90\xfa\xf9\xf5\xeb\x27\x63\x93\xeb\xe3\xf9\x07\xfe\x17\x2f\x63\x79\xc0\x79\x11\xfb\x83\x95\xac\x88\x76\x21\x25\xca\x9c\xf5\x7e\x43\x16\x65\xb9\xca\x23\x23\x82\xe4\x7d\xb4\x5f\xe4\x62\xfd\x22\x23\x78\x6b\x0a\xac\x29\xf0\x62\x94\xf4\x65\x24\xb8\xad\x65\x73\x4d\x82\x67\x23\xc1\xcc\x48\x1e\x06\x68\x95\x44\xd5\x55\x22\xa1\xef\x04\x78\x34\x05\x5d\x20\x05\x8d\x60\xac\xc9\xe7\xb3\xd7\xdc\xef\x94\x3c\x8a\x6b\xed\xd5\xd3\xdc\x9f\x22\x32\x64\xc7\xb3\x8f\x07\x61\x20\x8c\x6a\xe7\x81\x47\x3d\xcb\x73\xb4\x0e\x9f\xa5\x20\x29\xe1\x13\xb4\x53\xa1\xe6\x13\xc6\x19\xf3\x47\x96\xcf\x28\xfb\x0d\xcb\x77\xe6\xe0\xe4\xfd\xfd\xc2\x6d\xf6\x87\x62\xb4\xd5\xe0\x06\x0e\x3d\xd7\x45\x16\x45\x36\xc8\x4f\x4b\x53\x7e\x6d\xa9\xd7\xe2\xf2\x13\x50\x58\x97\x52\x41\xed\xd2\xa6\xa9\xe0\x58\x69\x39\x45\x06\x03\xf4\x7b\x88\x08\xed\x42\xeb\x7a\xe9\x3a\xea\xd5\xa0\x86\x17\x02\x02\xe0\xe0\xf0\x1f\x9a\x10\x2e\x50\x04\x66\xf0\xd5\xe2\xaf\x26\x87\x0b\xd1\x1e\x97\xd2\x43\x6d\xc0\xd5\xf4\x70\x1a\x7a\xe8\x2e\x83\x20\x3e\x36\xc9\xfb\xa0\x69\xde\x62\x69\xde\x07\x4d\xf4\x96\x4f\xf4\xd6\x64\xb7\xec\x78\xb1\x93\x63\x74\x80\xb1\x2b\xd0\xdc\x20\xd6\x10\x8d\xa0\xf4\x84\x88\x09\x89\x41\xe8\xbd\x38\x19\x36\x0c\x44\x96\x52\x83\xc2\x41\x72\xde\x0c\x8a\x46\xbe\x03\x29\x76\x07\xf1\x14\x0d\xe6\x15\x19\xe1\xce\x57\x51\x0b\x8f\x50\xf2\xbd\x2f\xce\xa6\xe1\x7a\xb7\xe6\xee\x48\x82\xdc\xa0\x9e\x2c\x33\x32\xcd\x7c\x6c\x5d\xa3\x20\x69\x2c\xe7\xde\x8d\x6e\x90\x34\x2a\x46\xab\xe1\x7f\xb7\x9a\xa9\x1f\xdb\xe9\x1f\xad\x51\xf2\xf7\x6e\xea\xef\x56\xfa\xc7\x76\x33\xfd\x25\x75\x16\xdb\xa9\xbf\x5b\xb6\xb1\x96\xda\x2d\x71\xdf\x79\x3e\xd3\x4d\x67\xe7\x55\x36\x4a\xba\xe3\x57\xe9\x8e\xd3\xa3\xb4\x77\xd2\x3f\x92\xa8\xee\xc6\x6b\x3b\x3d\xdf\x68\x2e\x19\xf0\xfd\xe1\xb9\x28\x16\x29\x92\x8b\xee\x84\x50\xec\x81\x9f\xf8\x2d\x0f\x8e\x20\x19\xf6\x3c\x18\x88\xde\x8c\x90\x33\x08\xc6\xd5\xe5\xf1\xbf\x5e\xfd\xf6\x7e\x24\xb7\xfd\x26\x46\x8d\xed\xb5\xaf\x6b\x2f\xd7\x4c\xd3\x5c\x4b\x7c\x67\x3a\xe0\xa6\xb5\xc6\xdc\x63\x3a\x52\x43\x7f\x0a\xfd\xb5\x11\xa2\x90\x31\x3f\x9d\x35\x00\xd8\xed\xd3\x01\x32\xf1\x6f\x00\xfb\xd0\x85\xa6\x95\xd6\xe5\x9b\x76\x34\x0b\x62\x8a\x6a\x23\x44\x86\x49\xa9\xec\x83\x53\xc4\xa8\x23\x72\x4f\x28\x1a\xad\x01\xc0\x0f\x2a\x61\xe3\x00\x00\x7d\xbf\x03\xe4\x10\xbc\xc0\x1a\xc2\x80\x66\x8b\x86\x28\xc0\x8c\xac\x77\xc0\x15\x76\x1c\x14\xf0\xc2\x00\x39\x08\x92\xa8\x73\x5e\xc4\xff\x4a\x9a\x46\x8b\x51\xcd\xaf\xf1\x1b\x61\x60\x78\xf9\xb0\x06\x80\xd1\xed\x62\xd7\x0f\x53\xd4\x36\xe6\x34\xe5\x2d\x6c\x1c\x5d\x76\xcf\x2f\xce\x4e\x8f\xaf\x7e\x3e\xfe\x78\x99\xec\x62\x7c\x41\xaa\xd8\x44\x1b\x11\x2b\xc0\x1c\xbb\x32\x52\x62\x4c\x35\x52\x9c\x66\xfc\xcd\x77\xc2\x01\x76\x05\x35\xf5\x15\x9d\x8a\xef\x1f\xe4\xb4\x52\xc3\xe6\xee\x8c\x2e\xe7\x00\x70\x80\x14\x8b\x4a\x51\x2d\x06\x27\x23\xcb\x6a\x16\x8b\x23\x20\xfc\x94\xff\x90\x20\x99\xb1\xdb\x68\x37\xb6\xd5\x26\x9c\x68\x38\x6e\x2d\x52\x0c\xe6\x0f\x95\x43\xf9\xc3\x92\x81\x9a\x8d\x66\xf5\x40\x2a\xb8\xe2\x52\x88\xba\x45\x58\x4e\x39\xae\x72\x81\x04\xbb\x03\x07\xb1\x87\x6e\xc5\x21\x2f\x15\xdf\xe6\x30\x24\x85\x3d\x07\x15\x47\xbb\xca\x16\xcf\x63\x20\x74\xa7\x58\xd5\x55\xa6\xb4\x64\x98\x18\x51\xa1\xeb\x7a\x14\x46\x57\x70\x8e\x24\xad\xe5\x59\x00\xa3\x17\x62\x87\x9e\xb8\x39\x83\x66\x56\x66\x33\x4d\x20\x51\x15\x98\x66\x9a\xa7\x42\x2e\x07\x41\x81\x7f\x1a\x62\x5b\x51\x8a\x2d\xcf\xe5\x42\x26\xeb\x33\x18\xf4\xe0\x7a\x73\x13\xb4\x5b\xad\x4d\xd0\xde\xdd\xdd\x04\xad\x0d\x05\xbb\x66\x1c\x24\xcb\x01\x7f\x06\x07\x0e\x0a\x32\xd6\x92\x14\x8e\x46\x97\x78\x86\xe8\x27\xd4\x00\xd9\x98\xca\xd9\xc6\x7c\x83\x31\x70\x11\x3d\xb1\x25\xb7\x20\x4a\xd8\x41\xb9\x8a\x19\x5e\xce\xc6\x1a\x38\x53\x25\x2b\x48\xa5\x8c\xb6\x79\x9b\xb2\x4b\x85\xb4\x68\xfc\xc5\xc6\x37\x7f\xfd\xe4\x02\xc0\xfe\x00\x9c\xa9\x78\xf3\xc9\xf0\x3d\x82\x29\x27\x1e\xb0\x47\x3c\x27\xa4\xe8\x47\xd0\xf3\x28\xf5\x46\x1d\xd0\xfc\x64\xf0\x06\x00\xfc\x05\x82\x61\x80\xfa\x6f\x3e\x19\xec\x99\x29\xe9\x6c\x6d\xf1\xeb\xb7\x81\xbd\x4f\x06\x10\x52\xcd\x9b\x4f\x46\xb7\xe7\x40\xf7\xfa\x93\x11\x77\xde\xf7\x5c\x6a\x12\xfc\x07\x62\x61\x55\xfc\xbb\x1f\x01\xc3\x2d\xd3\x46\x96\x27\xe8\x4d\x07\xb8\x9e\x8b\x7e\x04\x16\xdb\x90\x0e\xc0\x2e\xa7\x09\x9f\x8c\xbf\xfe\x05\x8f\x06\x80\x04\x96\x62\xc0\x2d\x3c\x1a\x88\x1f\xa6\xe3\x0d\xbc\x06\xb9\x19\xa4\x46\x1c\x22\x3c\x18\xd2\x0e\xd8\x6d\xfa\x77\x9f\x8c\xbf\x02\x4e\x63\xff\xb2\x05\xc5\xc2\xb7\x26\x07\xc1\x8f\x20\x10\x3d\x36\x7f\x04\xa9\xe5\xb4\x76\x79\xff\x02\x3a\x7c\x10\x80\x09\x80\xae\x02\x52\x03\x4c\x87\x61\xaf\x61\x79\x23\x31\x6d\xf1\x6f\x15\xdc\xfe\xea\xf9\xc8\x05\x8c\x95\x63\x1c\x31\x9b\x35\xa0\x43\x48\x81\x1f\x78\x37\x4c\x2e\x05\x10\x84\x2e\x66\xdf\xc0\x2d\xbc\x07\xd4\x03\x96\x30\x96\x6d\x8e\xdb\xa5\x2d\xdb\xb3\xc8\x96\xe5\xb9\x16\xf2\x29\xd9\xa2\x01\xec\xf7\xb1\x65\x8e\xa0\x0b\x07\x68\x84\x5c\xba\xe5\xdd\xa0\xe0\x06\xa3\xdb\xc6\x90\x8e\x1c\xe5\xe4\x44\x65\x36\xab\x4d\x00\x5d\x1b\x4c\x38\xa6\x8b\xe8\xad\x17\x5c\x9b\xd0\xb5\x4d\x18\xd2\xe1\x16\xfb\x57\xf9\x68\x04\x59\x61\xc0\x47\x03\x23\x6c\x05\x1e\x93\x80\xb1\x85\x48\x43\x0e\xdb\x0b\x24\xf0\x3f\x20\x64\x83\x21\x72\xfc\xbf\x81\xbf\x7b\xd8\x05\x74\x88\xaa\x26\x65\x79\xa3\x51\xe8\x62\x7a\xbf\xa5\x1c\x56\x6c\x65\x5c\x89\x8d\xdf\x48\xa3\x8e\xf8\xaf\x31\x5e\x49\xb4\x3d\xa9\x67\x46\xb3\x20\xe4\x09\x5c\xe6\x77\x6c\xd3\xbf\xcb\x5d\xd3\xad\xed\x2a\x9d\x4a\x24\x7f\x32\xf0\x1a\x29\xd5\x84\x10\x24\x52\x53\x8d\x51\x9a\xd5\x6e\xed\xf9\x54\x21\x0e\x47\xfc\x6a\xd2\x11\x0d\xa0\x4b\x7c\x28\xf5\x50\x19\xb5\x4f\x74\x15\x72\x2a\xa2\x76\x73\x61\x69\x91\x58\x8e\x1e\x2f\x2c\x28\x01\xf8\x45\xf0\x16\x5a\xd7\xcc\xf1\xdb\x2d\x3a\xc2\xf0\xef\xbf\x30\xc1\x58\xfd\x29\x2b\x13\x8b\xfb\xbd\xbd\xb3\xbb\x09\x76\x77\xc4\xff\x9a\x8d\xfd\xcc\x0d\x2f\x6a\x6c\xbf\xde\x04\xad\xf6\xfe\x26\xd8\x69\xb2\x2a\x7b\x8a\x3a\x2c\x93\x44\xeb\x75\x7b\x13\xb0\xde\x9a\x8d\xfd\xd7\x1b\x46\x41\x4a\xac\xa1\x64\x8c\x25\x5d\xcf\x4f\x15\x0f\x60\x38\xc8\x6d\xcc\x08\xde\x45\xeb\x6c\x35\x9b\x59\x7d\x5e\xf4\xa1\x59\xd4\x21\xe4\xa5\xd1\x58\x3d\xf3\x9e\x73\xe7\x55\x35\x4e\x61\x70\x8d\x02\x92\x15\x86\xbf\x4e\x8e\xe9\xaf\xc6\xbd\x73\x1d\xeb\x22\x13\x89\x95\x79\xec\x50\x21\x3a\xf4\x7d\xec\x0e\xae\x04\xd6\xb5\x54\xe5\x15\x7a\x92\x88\xbe\x73\x55\x0b\xa0\x1e\xc8\xf0\x3e\x89\x16\x86\x75\x3d\x56\x8b\x11\x75\x16\xf0\xac\xb0\x95\x9d\xb5\x2b\x54\x0d\x23\x78\x77\x04\x29\x3c\x8f\x74\x98\xa9\xdd\x2f\xaa\x4a\xad\xc8\x59\xc2\xc8\xd4\xe1\xcc\x5a\x0e\x78\xbe\x47\x68\x1f\xdf\x65\x0f\xb2\x2c\x7c\xe7\xb9\xf4\x52\x5e\x02\xbb\xcd\x3f\xa5\xbe\x07\xa8\xd8\x26\x40\x55\x4d\xf8\xf2\x4f\xa1\x5f\xa5\x9e\x8a\x74\x0f\x69\x45\x2f\xfb\x47\xdc\x1a\x4c\x35\xb5\x75\x90\xfb\xe0\xc5\x0d\x2a\x60\xc7\xee\xa4\x6b\x47\x68\x5e\xd3\x97\x1c\x76\x9c\x2c\xcf\xb7\xdd\xda\x04\xad\xd6\xde\x26\x68\xed\xed\xb3\xe3\xdc\xda\xcb\x9c\xf8\x7e\xe8\x38\xf2\x1c\xa4\x4a\x59\xc7\xe9\x6e\x44\x2f\x6d\x76\x31\xec\x6f\x6f\x18\xc5\xc3\xa8\x3c\x47\x9c\xff\x3b\xf4\x9c\x70\x94\x17\x20\x6b\x2b\x87\xd9\xd5\xb8\xce\x1c\x87\x30\xd7\x13\x73\xda\x16\xe9\x86\xa5\x5a\xf8\x21\x40\xbe\x17\x50\x14\xbc\xf9\xc4\x44\x56\x8a\x5d\xce\x70\x7d\x32\xbe\x8a\xd4\x70\x6c\xd1\xcd\x66\x6b\x63\x5a\x8d\x70\xa6\x9c\xa9\xbc\x99\x99\xa3\xae\x9a\xd8\x30\x8a\xc4\xe5\x27\xc7\xeb\x41\x07\x44\xca\xdf\x5f\x18\x80\x50\x19\xc5\xc9\x69\xd5\x25\xc9\x51\x09\x65\xfc\xc4\xa5\x71\x75\xaf\xf9\xa7\xdc\xd7\x6a\x5c\xf5\xd8\xca\x8c\x37\x35\xd1\x34\x3a\xe0\x63\x31\x95\x57\x8c\x04\x7e\x66\xe8\xd1\xa4\xb2\x94\x54\x4a\x0b\x10\x63\xc8\x66\x26\x99\x7b\x8b\xa2\x99\x6a\xcb\xd8\xd4\x44\xf3\x55\x4d\xa2\xd9\xd2\x44\x53\x13\x4d\x4d\x34\x27\x48\x9b\x3b\x11\xb5\xe4\x71\x29\x7d\xcf\x25\xec\xc5\x8e\x8d\xbe\xfb\xcf\x27\x63\xb7\xf1\x43\x4c\x45\xc1\x16\x98\xae\x5f\xd9\x7e\x81\x26\xdb\xd9\x08\x34\xcb\x99\xc6\xb2\x86\xed\xef\x37\x76\xcb\x89\xb5\x7c\xde\xca\xc3\x4b\x80\x75\xd7\x73\xcd\xdd\xbb\xbb\x18\x62\x64\x43\x93\x6f\x4d\xbe\xbf\x25\x49\xb7\xd5\xae\x49\xb5\xdb\x9a\x6a\x6b\xaa\xad\xa9\x76\x3d\xaa\x8d\x67\x26\xdb\x6f\xfe\xf3\xc9\xd8\x49\x93\xed\x95\xa5\xbb\x0a\x5a\xbb\x73\x77\x47\x34\x21\xd5\x84\xf4\x9b\x22\xa4\x7b\x35\x09\xe9\xb6\x26\xa4\x9a\x90\x6a\x42\xba\x4c\x42\xba\xfb\x54\x09\xe9\xae\x26\xa4\x8c\xe2\x85\x23\x37\x77\x1f\xd6\xa1\x41\xc9\x7a\x5b\xcd\xf4\x82\x4b\xaf\xf8\xf6\xc4\xa1\x09\x5e\x15\x0d\xe0\xd8\xe6\x54\x3f\x72\xcc\x2f\x6c\x14\x27\x02\xaf\x2b\x2d\xe1\x3e\x1c\x20\x39\xf5\xcc\xd5\x16\x20\x1f\x41\xaa\x2e\x3d\xc2\x01\xb2\x22\x0f\xbf\x9b\x64\xb1\xc4\x0a\xbc\xc2\x21\xe7\x07\xf7\x67\x04\x6d\x14\x14\xbe\x08\x2f\xf4\x14\x64\x2c\x8f\x35\x4f\x43\x82\xf9\x13\x96\x1d\x7b\x6e\xaf\xaf\x40\x25\xfe\x62\xc6\xe8\x00\xe3\x9f\x5e\x70\xed\x78\xd0\xce\xe2\x0e\x67\x6f\xe4\xad\x5f\xf0\x7b\x56\xf0\x3e\xf5\xf8\x9f\xba\x3c\x50\x1d\x3e\x28\x83\xc2\x11\x2e\xa2\xe4\x0d\xde\xbf\xfe\xf5\xaf\x7f\x99\xa7\xa7\xe6\xd1\x11\xf8\xf9\xe7\xce\x68\xd4\xc9\xc7\x91\xb5\x91\x85\x47\xc2\xff\xb8\x9d\xf9\xc0\x30\xa1\xc8\xb9\xc8\x0f\x57\xfc\xd6\x7c\xeb\xc0\xaa\x3a\xb1\xf7\x55\x0c\x5d\x60\x67\x7c\x74\x33\xd5\x3f\x06\x6c\x63\x8d\xad\xb8\xca\x96\xdd\x93\x5e\x4a\xb7\xb2\x79\xe2\xa6\xfa\xb7\x1b\x18\x98\xb1\x23\xed\x9b\xef\xbb\x5d\x0b\x39\x4e\xb7\xfd\x67\x56\x1e\x55\x8f\x8b\xb3\x83\xf9\x90\x52\x14\x70\xcc\x4c\xdd\xd4\xdd\x5b\x25\x06\xf8\x01\xe2\x2f\x47\x62\x88\x16\xd7\x4a\xa0\x8b\xa9\x38\x1f\xc5\x8f\x25\x8f\x3b\x32\x97\xe4\x10\xdb\x36\xca\x3d\x21\xe0\xa6\x9a\xd8\x9d\x7f\x2c\x17\x14\xe3\xb1\xc6\x5f\xc5\x36\x5f\x65\xdf\x90\x3c\xc2\xb6\x48\xa3\x2c\xd1\xdb\xa3\xd8\x1e\x2e\x59\x81\x17\x07\x93\x6f\x91\x1b\x8e\x7a\x28\x50\x6f\x11\x93\xf5\xea\x6f\xd0\xf9\x6e\x13\xbc\x87\x14\xb9\xd6\xbd\xde\xa3\xf2\x3d\x7a\x3b\xdf\x3d\x9a\x68\x87\xf6\xf5\x0e\xd5\xd8\xa1\xa3\xc7\xdc\xa1\x7d\xbd\x43\xe3\x77\xe8\xf8\xf1\x76\x28\x6d\x6e\x2c\xdf\x22\x83\x31\x4d\xc6\xb7\xbd\x49\xef\xaa\x36\x29\x3b\xeb\xc6\xfe\x6e\x7e\x21\xa0\xd5\x68\x36\xcb\xa7\x5d\x67\x5b\xd3\x2e\x3b\xf5\x37\x58\x8b\x31\x25\x62\x4c\x56\xa9\x54\x94\x50\xa4\xa8\x30\x5f\x01\xa5\x20\x88\xb4\xff\xac\x96\x5b\xb6\x27\x92\x50\xba\x37\x30\x98\xf3\x1d\x32\x21\x3b\x7b\x29\x9e\x12\x68\x2c\x5b\x2a\x96\xc9\x07\x1c\x39\x24\x93\xa5\x11\x2e\xd5\xc2\x24\xa2\xda\xbf\x3a\x48\x44\x68\xc0\xde\x48\x6b\x51\x75\x51\xe4\x47\x79\xde\xe3\x0b\x63\xb1\x42\x6c\x51\x21\x5c\x57\x37\xce\xdf\xfb\x76\x7f\xf3\xb0\xbb\x3e\x17\x2f\xb1\x37\x9f\x8c\x76\xb3\x99\x28\xc9\x59\xf6\x45\x15\x64\x36\x41\x35\xbc\x36\x81\x02\xeb\x37\x36\xc1\xa7\xd2\x7b\x95\x4d\xe5\x93\xd1\xf8\x64\x94\x55\x2a\xff\x92\x0c\xfb\xc9\x28\xf7\x4b\xcb\x3c\x36\x05\xa9\x57\x96\x45\xdd\x11\x76\x09\x85\x85\xf7\x41\x60\xba\x94\x92\xaa\x19\x7f\xfd\xda\x28\xf9\x92\xac\x05\xcc\x23\xb9\x75\x0a\x3f\x86\x98\x50\x6f\x10\xc0\x51\xf7\xf7\x10\xba\x14\x3b\x68\xbd\xd9\x60\x87\x4e\x8d\x38\x5d\x5b\x3e\xac\xef\x12\x64\x79\xae\x4d\xba\x22\x62\xc8\x38\xbf\x40\x8e\x32\x0e\x52\x23\xc8\x38\xb4\xd9\xd0\x28\x32\x1f\x14\x79\x3b\x3f\x14\xd9\xd7\x28\xb2\xa2\x28\x02\x66\xc3\x91\xa3\x39\xe2\xc8\xbe\xc6\x91\x67\x89\x23\xc7\x53\xe1\xc8\xfa\x62\x3c\xd6\xa7\x65\x46\x66\xf6\x75\x9f\x7e\xe4\xe7\x84\x88\x45\xd1\x61\x25\x50\xf4\x5d\x25\x23\x5d\x1a\xca\x4d\x7a\x56\xb0\xb4\xda\x5b\x3f\x5d\x9c\x1f\x82\x48\x83\x93\x77\xb4\x60\xf0\x2c\x42\xb3\x96\x13\x86\x68\xf2\xc8\xde\x0c\xad\xbd\x49\xbd\x19\xda\xaf\xa7\x74\x67\x68\x35\xf7\xa7\xf7\x67\x58\xb4\xe7\x42\x5b\x7b\x2e\x2c\x49\x19\x33\x17\xcf\x85\xd9\x34\x36\xd4\xf2\x27\x71\x5e\x50\x7a\x2f\x7c\x43\xce\x0b\x6f\xef\x79\x3e\x73\x76\x97\x69\x64\x2e\x35\x4d\x1c\xd4\x37\x4d\xcc\x66\x82\x78\xeb\x93\x89\x37\xef\x02\x59\x08\xdf\x20\x7d\x1b\x55\x6c\xe0\xe1\x7c\x95\xf7\x93\x6d\x93\xde\x18\xc5\xc6\x3c\xbe\x83\x90\xa6\xe2\xab\x64\xb8\x53\x58\xe8\xd4\x9e\x85\xed\x25\x18\xee\xb4\xcd\xe5\x19\xdb\x5c\xb4\xa5\x55\x5b\x5a\x67\x36\xd7\x2f\xc2\x70\x47\x2d\xbf\x1b\x48\x66\xae\xdb\x63\xbc\x5d\x51\x49\x25\x94\x04\xda\x4c\xb7\x78\xe5\x69\x89\xd2\xe9\x70\x2a\xbd\xa8\x62\xa7\x09\x72\xa9\xde\xe5\x15\xdd\xe5\x83\x99\x54\x8b\x57\x87\xe7\x4b\x56\x2a\x3e\x99\x34\x34\xfb\x13\xe7\x7e\x2d\x49\x62\xde\x6a\xb5\xaa\xf3\xd0\xb0\x8c\x06\x07\xe4\x2a\x1f\xb8\x78\x09\x69\x6a\x78\xec\xdd\x4b\x25\x56\xeb\x14\x36\xab\x9f\xd8\x54\x5c\xd1\x2c\xba\xb7\x2d\x6e\x5e\xcb\x1b\xf9\x9e\x8b\x5c\xba\x09\x28\x1c\x2c\x3c\xb1\xe9\xc3\x03\x88\x47\x04\x5f\xbf\x76\xc0\xc3\x03\x1b\x77\xda\xcb\x6a\xee\xa9\x6e\x44\xb4\xe1\xc3\x68\x8a\x84\xc1\x28\x4a\xff\xf1\x18\x89\x6f\x6a\xdc\x9c\x3a\x23\x6a\xfa\x16\x7b\xa6\xd9\x70\xd2\x77\xa4\x4e\x87\xa3\xd3\xe1\x80\x85\xa4\xc3\xe9\x05\xde\x2d\x91\x52\x62\xfe\x4e\xe4\x79\xe6\x73\x39\x71\x92\xa4\x13\x3b\x4b\xca\x7e\xe3\xa3\x80\x9f\x46\x37\x2d\x4d\xaf\x5e\x12\x1c\xe5\x34\x75\x2e\x1c\x9d\x0b\x27\x3b\xe4\x04\xb9\x70\x74\x22\x97\x55\x4b\xe4\xf2\x64\xc5\xd2\x4c\x50\xc4\x7a\x89\x2f\x44\x6c\x27\x9d\x1b\x55\x0b\x96\x95\x91\x7c\x59\x6e\x23\x88\x5d\x14\x74\x2d\x3f\xec\x86\x04\x0e\x50\xec\x20\x2b\xf4\x80\xbe\x27\x54\x69\x2c\x6e\x91\xf4\x69\x41\x0e\x1a\x21\x1a\xdc\x9b\x29\xa7\xc8\x2d\xb0\x5e\x27\xa6\x7e\x36\x72\xfe\x56\xab\xd9\x6c\x2e\x5c\x82\xcd\xcd\x7a\xf6\xc7\x0c\x33\x41\x0f\xbb\x83\x00\x11\x32\x80\x14\xdd\xc2\x0c\x08\x41\x4d\x18\x3e\x88\x6b\x26\xd6\x1b\xbe\x51\x77\x2d\xfc\x58\xcb\x94\xb8\x8f\xb1\x05\xd9\xf9\xcd\xfe\x62\x60\x82\x7d\x48\x6a\xf0\xdd\x88\x20\xe6\x07\xde\xdd\xfd\xd3\xc1\x61\x3e\xdd\xd9\x2d\x01\x33\xe1\xaf\xef\x39\xd8\x7a\x5a\x47\x5f\x4c\xb9\xc6\xeb\x83\x25\xa8\xab\x6e\x0e\xcf\x3f\x82\x2d\xd0\xba\x06\x81\xaf\x33\x33\x6b\x25\xd4\x33\x49\xc9\xbc\xf9\x5c\x39\xed\x56\xbb\x1e\xab\xfd\x4a\xb3\xda\x9a\xd5\x5e\x0e\xab\xad\xf9\xe5\x65\xc2\x61\xd5\x99\xd6\x6f\x99\xf3\xfc\x36\xd9\x47\xcd\x35\x6a\xae\x51\x73\x8d\x4f\x4a\x3f\xbb\xaf\x66\x1a\x77\x34\xd3\xa8\x99\xc6\x32\x3a\x99\x90\xc8\x11\x1a\x79\xc1\xbd\xa4\x92\xdc\x47\x73\x3c\xab\xc8\xb5\x89\x75\x14\x32\x4c\x19\xf1\x08\x5a\xd8\xa2\x0e\x64\x26\xf6\x72\x42\x60\xa9\x98\x4a\x06\x09\xcb\x0b\x5d\x3a\x97\xce\x16\x0d\x4e\x1f\x05\x60\x02\x06\xf5\x70\x8e\xe0\x1c\xc3\x96\xd6\x84\xe3\xb8\x5e\x96\x07\xc0\x2a\xde\xf6\xed\x42\xd1\x30\xcd\xd1\xae\xe8\x81\x15\x53\x1c\x77\x5a\x97\xcc\x05\x9f\x72\xe0\x6a\x3e\x78\x36\x3e\xd8\x46\x16\x47\x4c\xcd\x0a\x6b\x56\x78\x89\x0a\xd4\x12\x5e\x78\x57\xf3\xc2\x9a\x17\xae\x97\x76\x49\xc6\x66\x12\xaf\x95\x48\x38\x7a\x50\xbd\xa6\x79\x53\x60\x8c\x13\x43\xfb\x7f\x81\x12\x42\x3b\x7d\x9f\x4f\x52\x29\x3b\x06\xac\xf3\xf0\x33\x58\x21\x25\xed\xe1\x22\x81\x93\x3c\x37\xfb\x2e\x86\x93\xf0\x68\xad\x86\x8f\x12\x17\x6b\x21\x78\xed\x11\x95\x91\xc4\xea\x1c\x81\x45\x2e\xb1\xce\x71\x9b\x69\x85\x8f\xaf\x17\x3f\x9a\x2b\xbe\x55\xde\x47\x82\x4b\x9f\xe3\x05\x97\xeb\x70\x05\x14\xed\xc7\xcb\x15\x31\x44\x30\x1b\xf1\x46\x12\x05\x01\xb2\xc1\x16\x20\xc8\xd2\x32\xc7\x6c\x32\x87\x16\x38\xb4\xc0\xb1\xe4\x27\xbb\xad\x3d\xb5\xc0\xb1\xa7\x1f\xec\x6a\x59\x45\x3f\xd8\xd5\x0f\x76\xf5\x83\x5d\x4d\x79\x1f\xe7\xc1\xae\x7e\x9d\xfb\xbc\x5f\xe7\x2a\x9f\xe5\x9e\x27\x2f\x4d\x1f\xff\x75\x6e\x21\xce\xd5\xea\xbd\xcc\x2d\x4c\x31\xf3\x2a\x57\x3f\xae\x4c\x5f\x2d\x73\x7a\x5c\x49\x91\xc0\x17\x06\xa6\xdd\xed\x57\x3b\x3b\xed\xdd\x66\x6b\xb7\xd9\x9a\xe4\xb1\x25\x33\x85\x0a\x9a\x6a\xfc\xc5\xc6\x37\xc0\x72\x20\x21\x6f\x3e\x25\x33\x37\x87\x3c\x6c\x36\x60\x79\xdb\x4d\xc6\x2a\xa2\xe0\x93\xf1\xd7\x4f\xee\x5f\x88\x0f\xdd\xbf\x5e\x1e\x5f\xfc\x72\x72\x78\xdc\x01\xdf\xcb\xfd\xff\xcb\x16\x2f\xff\xe4\xfe\x65\xcb\xc6\x37\x7f\xad\x11\x70\x7c\x7b\x52\x49\xa1\xc4\xb5\x7b\xaf\x32\x98\x78\x44\xe0\x87\x74\xe4\x28\x32\xe6\x97\xc5\x54\xca\xf0\xdc\xd1\xae\x32\x50\x94\x84\x69\x87\xd6\x90\x07\x3e\xf7\xc2\x02\x67\xc5\xe3\xfd\xbd\x85\xd6\xf5\x80\x3f\xc3\x29\x70\x31\xfc\xfb\x2f\x32\x09\xbe\xe2\x53\x96\x7d\x18\x1f\x26\xb0\x4e\x88\xc0\x71\xe1\x01\x27\x8b\x35\x1f\xf1\x06\x5e\xfa\xc9\xcc\x00\x86\x03\x94\xdd\xf5\x11\xbc\x8b\xd6\xd9\x6a\x36\xb3\x82\x56\xf4\xa1\x59\x64\xb7\xf2\xe4\x3b\xe6\x79\xdf\xf3\xfb\xae\xaa\xc6\x29\x0c\xae\x51\x40\xca\x62\xb8\x97\xe2\xe6\x4e\x0e\x37\x5f\x8d\x41\xcd\xed\x92\xb0\x53\xc9\x0b\xdf\x54\x76\x84\x0c\x76\xa8\x50\x16\xfa\x3e\x76\x07\x57\x02\xeb\x5a\xaa\xf2\x0a\x96\x32\xba\xaa\x38\x57\x0a\xa8\xc7\x0f\x70\x96\x61\xbb\x89\x36\x61\x2c\xdf\x17\x75\x16\x40\x77\x30\xa6\xb3\x76\x05\x6f\x36\x82\x77\x47\x90\xc2\xf3\x48\x80\x4c\xed\x7e\x51\x4e\xb5\x3c\xd7\x45\x16\x4d\x85\xa8\xe6\x75\xae\xd8\xc8\x39\xe0\xf9\x1e\xa1\x7d\x7c\x97\x3d\xc8\xb2\xf0\x5d\x2a\x05\xc2\x6e\x3a\x03\x82\x1f\xa0\x62\x9b\x00\x55\x35\xe1\xcb\x3f\x85\x7e\x15\x27\x1f\xf1\x6f\x61\x3e\xf0\x24\x15\x13\x37\x3e\x6c\xe5\x03\x83\x7b\x71\x83\x0a\xd8\xb1\x3b\xe9\xda\x11\x62\x6f\x32\x22\xd7\xc8\x64\xc9\xd7\x76\x6b\x13\xb4\x5a\x7b\x9b\xa0\xb5\xb7\xcf\x8e\x73\x6b\x2f\x73\xe2\xfb\x61\x21\x3b\x82\x54\x27\xa4\xbb\x11\xbd\xb4\xd9\xc5\xb0\xbf\xbd\x61\x14\x0f\xa3\xf2\x1c\x71\x52\x76\xc8\x53\x55\xe4\x2e\xd5\xba\x92\x79\xf2\x42\xb1\x56\xf6\x95\xc8\x84\xb1\xa9\x88\x58\xc8\xfc\x77\x22\xaa\xc4\x34\xe4\xdb\x4d\x92\x7a\xd8\x38\x6f\xf1\x3e\x25\xa6\x67\xca\x99\xbe\x82\x33\x8a\x35\x65\x77\x43\x41\x9c\x0e\x1d\x8c\x5c\x0a\x64\x6e\x7a\xf0\x0b\x03\xf0\x84\x51\x00\x09\x76\x07\x0e\x22\x14\x26\xa7\x56\x9c\xd8\x34\xae\xef\x35\xff\x94\xfb\x5a\x8d\xeb\x1e\x5b\x99\xf1\xa6\x26\x9a\x47\x17\xc4\x58\x4c\xe7\x15\xa3\xa8\x28\x91\xae\x6e\x25\xc8\xad\x82\x50\x4e\x43\x6f\x8b\x54\x7b\x0a\x72\x9b\x0a\xbc\x9b\x59\x77\x3f\xed\x3a\x17\x67\x26\x9e\x95\x1c\xef\x2d\x8a\x1e\xab\x55\x9e\x53\x13\xe4\x57\x35\x09\xf2\x8e\x26\xc8\x9a\x20\x6b\x82\x3c\x8d\x5b\xcf\xec\xb4\x78\xb3\x2c\x6f\x9b\x20\xd1\x32\xfd\x1a\x5e\x10\x07\xb0\x30\xca\xff\xb6\xb6\x82\xde\xd8\xdf\xdd\x04\x2c\x09\xe2\xfe\x7e\x63\xb7\x9c\xe0\x5f\x86\x96\x85\x08\x01\x17\x90\x22\xb0\xee\x7a\xae\xb9\x7b\x77\x17\x27\xbd\x23\x1b\xdf\x08\x0b\xc0\x8c\x78\x4f\xdb\x18\x3a\x8e\x6a\xb5\xda\xf5\xc8\xd6\xde\xeb\x65\x1a\x43\x99\xbe\xec\x78\xe4\xd3\xfb\x92\x6f\xff\x8b\x02\x6f\x8e\x36\xd4\xbc\xb5\x48\x9b\x50\x57\xd9\x84\x5a\x99\x1e\x1a\x4f\x97\xd8\x75\x92\xbb\x3c\x9d\xf5\x75\x86\x3b\x7d\xe6\x24\x95\xe7\xbb\xcd\xd9\x9d\x3c\x2b\x13\x29\xaf\x3e\x30\x2b\x62\xf1\x4f\x06\xcb\xfd\xe6\xec\xef\x7b\x2a\x13\x0e\x7f\x4b\xb0\xdc\xaf\xe1\x5f\xbb\x04\x3f\x82\x9c\xf2\xe2\x48\x42\x5b\x7b\xe8\xcd\xe8\x27\xa0\x7d\x04\x56\xc1\x47\xe0\xf1\x14\x52\x2f\xda\xfb\xfb\xd6\xce\xab\x49\x15\x50\x2f\xec\x9d\x1d\xb8\x0d\x67\x33\xf0\xbc\xfd\x66\x0c\x3c\x99\x6c\xc8\x55\x9c\xb9\x56\x28\x69\x85\x92\x56\x28\x4d\xa1\x50\x9a\x24\x7f\xd9\x26\xa8\xcb\x80\x3d\x1a\xdf\x35\xb3\xf3\xa6\x61\xa8\x53\x43\x45\x09\x7b\xc1\xdb\x8c\x6f\xfe\x37\xaa\xfa\xd1\x8e\x16\xdf\xb6\xa3\xc5\x6b\x35\x19\xde\x7f\xad\xc9\xb0\x26\xc3\x9a\x0c\xcf\xdd\xd1\x22\xf3\x74\xf3\x79\x7b\x5b\xb0\x4c\xbe\x28\xd0\xde\x16\xda\xdb\x42\x7b\x5b\x4c\xe8\x6d\x51\x46\x95\xf7\x34\x55\xd6\x54\x59\x53\xe5\x79\x7a\x5b\x4c\x48\x90\xe7\xe9\x72\x31\x25\x2f\xf0\x04\xfc\x2e\x24\xe9\xd7\x7e\x17\xdf\xaa\xdf\x45\x19\x01\xdb\xd7\x7e\x17\xda\xef\xe2\xd9\xfa\x5d\x4c\x7c\xa1\x6b\xe7\x8b\x15\x83\xe8\xb3\xf7\xc0\x78\xba\x00\x5d\x11\x37\x8c\x9c\x56\x43\xbb\x61\x68\x37\x0c\xed\x86\xa1\xdd\x30\x9e\xaa\x1b\x46\x09\xa3\x9e\x5a\xa6\x56\x35\x69\x55\x93\x56\x35\x4d\xe6\x87\x41\x90\x4b\xe7\xe7\x83\x01\x9e\x9f\x13\xc6\x25\x72\xa9\x76\xc0\x98\x43\xdc\x90\xc3\xf7\x27\xc7\x1f\xae\xc0\x3f\xcf\x2e\xfe\xf1\xfe\xec\xe0\xe8\x72\x09\x61\x43\x5a\x2d\x35\xcd\xd8\xd9\x7d\xfc\xb8\x21\xab\xa1\x51\x6b\x8e\x87\xfa\xab\x49\x53\x2a\xb5\x76\xd4\x50\x6f\xcf\x37\x90\x7c\x5a\x67\x96\xbb\x70\x9f\x53\x8c\x79\x00\x09\xf8\x03\x05\xde\x37\xae\x04\xab\x95\x7e\x5d\x72\x35\x52\xaf\x10\x06\x98\xde\x77\x45\xe8\xe2\x37\x9f\x8c\x51\x48\x43\xe8\x74\xa9\x43\x6a\x5a\x4f\x8a\x74\x30\x1f\x76\x9d\xb7\x08\xac\x5b\xa7\xf8\x2d\x89\x90\x1d\xd5\x72\x49\xca\xf8\xc2\xb4\x14\xb9\x16\x9b\xa0\xb4\x8b\x4d\x90\xb1\xe5\x2c\xcc\xc7\x42\x11\xb0\x32\x37\x27\xf0\xf5\x6b\xe3\xe1\xa1\x7c\xa6\xe0\xeb\x57\xc0\xe3\x5a\x66\x26\xcc\x4a\xd7\xff\xdf\xff\xe7\xff\xff\xff\x1b\x5d\xbd\xbf\xdc\x28\x55\x37\xa9\xfc\x3a\xda\xb5\x75\x50\x33\x62\xc8\x77\x79\x14\x19\xcf\xf4\x28\x43\xab\x17\xb6\x31\x83\x25\xe0\x49\xa0\xc9\xbc\x98\xb2\x39\xe2\x8f\x31\x89\x33\x50\x7b\xb9\x61\x53\x5d\xcb\x1b\x61\x77\x10\x69\xda\x78\xdc\xd4\x4b\xbe\x42\x70\xe0\xda\xe0\x42\x2e\x05\x1c\xb2\x6b\xbd\xa6\xf6\xad\x40\xa6\x9e\x95\xfa\x2d\x2b\xa1\xd1\x64\xce\x22\x50\xde\x94\x9a\x39\xcf\x5f\xa8\x6e\xce\xc8\x29\xca\xb5\x6a\xee\x99\x05\x30\x7f\x35\x71\xc6\xa4\x52\x56\xf7\xd5\xb2\x58\xdd\x39\x59\x87\xb5\x09\xf8\x69\xa4\xaa\xaf\x1d\x1b\x05\xd4\xe7\x85\xc1\xb4\xae\x44\x8f\xc8\xea\x44\xee\x4b\x8f\x09\x94\x47\x5c\xfd\x13\xe3\xe8\xc6\xf2\xfe\x07\x33\xf2\xfe\x73\x45\x85\xef\xf4\x01\x99\x13\x54\xf4\x09\xa9\x79\x42\xa6\x15\x89\x97\x29\xdd\x8c\x73\x91\x04\x6f\x23\xa9\x47\x7b\x18\xcc\xe6\x61\xa0\x7a\x57\x30\x8b\xa0\x60\xb4\x1a\xcd\x96\xa1\x25\x9a\xe7\x22\xd1\xd8\x88\x58\x01\xf6\x65\x1c\x78\x63\x1e\xa2\xce\xde\x18\x9d\x7e\xbb\x24\x02\x7b\xfb\xd1\xc2\x93\x2d\x46\x0e\xd2\x19\x9e\xb4\x9b\xec\x13\x91\x1c\x12\x77\xc7\x49\x34\xc4\x8f\xea\x1a\xf9\x90\x4f\x5c\xca\x39\xa3\xd2\xd9\x7e\xfd\x0a\xce\x77\x9b\xe3\xa5\x87\xa3\x19\xa5\x87\x85\xc6\x62\xd3\x98\xf3\x48\x98\xb3\xdf\x5c\xbc\xdc\xa9\xc6\x9c\x5d\x8d\x39\x4f\x1b\x73\x76\x17\x6f\xad\x5c\x68\xcc\x42\x8d\x39\x8f\x85\x39\xfb\xe3\x31\xe7\x70\x11\x98\xb3\x04\x3e\xe7\x3b\x8d\x3a\x8b\x65\x74\x4a\xd3\x6b\x3f\x4d\xee\x46\xe3\xcb\x82\xd9\x9b\x12\x7c\x79\xf7\x44\x79\x1a\x8d\x2f\x0b\x66\x6a\x4a\xf0\xe5\xa7\x27\xca\xc9\x68\x7c\x59\x30\x2b\x53\x82\x2f\x3f\xaf\xa0\xcb\x55\xfc\xb8\x31\x71\xbd\xd2\x46\x88\x15\x7e\xe6\xa8\x0d\x0f\x4f\xd2\xf0\x30\x3f\xfb\xc2\x5e\x5d\xfb\xc2\xde\x8a\xd8\x17\xb4\x11\x41\x1b\x11\x54\xcc\x8c\x78\x3a\xa7\x75\x31\xda\x72\x50\x4b\xb6\xd6\xe8\xb2\xa2\xe8\xb2\xa2\xf6\x02\x8d\x2f\xda\x48\x30\x81\x68\xad\xd1\x65\x75\xaf\x97\x55\x34\x0d\xcc\x82\x2f\x5a\xff\xf2\x8d\xd8\x03\x34\x92\x68\x23\xc0\x42\x19\x15\x8d\x24\xdf\x88\xe6\x5f\x23\x89\x56\xf7\xcf\x45\xdd\xcf\xe2\xe7\xe8\xf7\x06\xf3\x52\xf5\xdb\xc8\xea\x65\x02\x1c\x69\x8d\xbf\xd6\xf8\xcf\x5b\xe3\xdf\x7a\x55\x53\xe5\xff\x4a\xab\xfc\xb5\xca\x7f\x35\x55\xfe\xf2\x8d\xa7\xd6\xb2\x68\x9d\x7f\x2d\xf9\x59\xe3\x8b\x56\xfa\x4f\x24\x4b\x6b\x84\xd1\x5a\xff\x49\xc4\x6a\x8d\x2f\x5a\xed\xbf\x6c\x0e\x46\x2b\x62\x9e\xbd\xde\x5f\x63\x89\x56\xfc\x2f\x85\x59\xd1\x58\xf2\xec\x35\xff\x1a\x4b\xb4\xea\x7f\x06\xd5\x7f\x1c\x3c\x55\xab\xfc\xb5\xca\x5f\xab\xfc\x9f\x56\xbc\xd4\x42\x14\xa1\x57\x6a\x95\x7f\x92\xfc\x7a\x2e\xe1\x52\x75\xe0\xd3\x67\xa3\x9c\x57\x07\x75\x67\x89\x6b\x02\x64\x21\x7c\x83\xec\xf1\xc9\x6b\x9e\x87\xee\xe3\x29\x46\x73\x5f\x46\xe8\xcf\xc5\x62\xc8\x77\xdf\x06\x8a\x2c\x01\x13\x56\x38\xc4\x25\xcf\xee\x04\x2e\x24\xba\x00\x96\x65\x0c\xc4\x2e\x27\x2c\x07\xd4\x61\x8c\x1f\x9a\xef\x9c\x8d\xef\x7c\xab\x43\xf4\xd7\xce\xf2\xa9\x39\xce\x39\x44\xe8\x2f\x65\x39\xdb\x9a\xe5\xd4\x2c\xe7\x64\x0c\x45\x21\x57\xe2\x04\x9c\xe5\x74\x69\x15\x35\x2f\xb1\x72\x5c\xe5\x04\x48\xf0\x9d\xc6\x82\x6f\x9a\xa3\xe4\xa9\x43\xa9\xa7\x79\x49\xcd\x4b\x7e\x3b\xbc\xe4\x8c\x59\x62\x2f\x8f\x2f\x7e\x39\x39\x3c\x5e\x6a\x9a\xd8\xed\x76\x89\x27\xf2\xbe\x4e\x13\xbb\xb8\x34\xb1\xdb\xbb\x6a\xa8\xef\x37\x75\x9a\x58\x9d\x26\xf6\x09\xa6\x89\x4d\x55\xce\x35\xcd\xf0\x6d\x36\xa1\xb7\x4e\x49\x85\x1c\xef\x66\x13\x5a\xc8\x81\xa3\x6a\xb6\x09\x54\xa5\x2b\x91\x3f\x56\x35\x31\xc9\xda\x55\xcf\xf9\xdb\xcd\x24\x9b\xc5\x23\x30\x16\x91\xc0\x53\xc4\xa4\x39\x1a\x25\xe6\x8f\x62\x4f\x2d\xd9\xec\x51\xb2\x4c\x9d\x71\x56\x67\x9c\x05\xda\x83\xe2\xd9\xea\xb3\x4b\xb9\xe6\x96\xce\x38\xab\xb5\xe0\x60\xf9\x19\x67\x73\xdc\xca\x52\xd2\xce\xae\x14\x4b\x34\x51\xa6\xcd\x45\x42\x6b\xc5\xc0\xf2\x64\x59\xc2\xd5\xc9\x56\x5b\x1b\x5b\xbe\xd3\x87\x6b\xd1\xe0\xd2\xa7\x6b\x2e\xa7\x4b\x67\xba\xd5\x26\x1c\x9d\xe9\x56\x4b\x52\x2b\x9b\xe9\x76\xa7\x55\x22\x60\xed\xe8\x4c\xb7\x5a\x36\x7b\xb6\x99\x6e\x9f\x90\x60\x92\x38\xb2\x4c\xa7\xc1\x7e\xe4\xf7\x79\xb3\x09\x27\x4f\x36\x21\xae\x46\xb0\x27\x82\x60\x4f\x35\x6f\xae\x46\xb0\xa7\x82\x60\x4f\x34\xbd\xae\x46\xb0\xa7\x82\x60\x4f\x34\x0b\xef\x53\xd2\x5f\x7d\xeb\x4c\xd8\x13\x4a\xd6\xab\xd1\xea\xe9\xb0\x5e\x4f\x28\xa7\xaf\x46\xab\xa7\xc3\x70\x3d\xa1\xd4\xbf\x1a\xad\x9e\x0e\x9b\xa5\x33\x04\x7f\xcb\xc6\x1b\xed\xfa\xa6\x0d\x36\xcb\xcb\x10\x5c\x6a\x97\xd9\xd5\xe9\x02\xb4\xf1\xe5\x09\x66\x08\xd6\xea\x24\x6d\x71\x99\x7f\x52\x3e\x8d\x55\x4f\x03\xab\x9e\x58\xbe\x61\x8d\x56\xda\xb8\x32\xff\xbc\x7f\x1a\xab\x9e\xcc\x65\xf5\x94\xb2\x17\x6b\x15\x92\xb6\xa3\x2c\x9d\x9f\xd2\xb8\xa4\x8d\x27\x1a\x97\xb4\xc5\x64\xd5\x58\x27\x8d\x4b\xda\x4c\xa2\x33\x2b\x03\xa0\xd3\x2c\x68\x4b\x09\xf8\x36\x32\x2b\x97\x9a\x4a\x5e\x69\x53\x89\x36\x95\x3c\xc5\xcc\xca\x5a\x51\xa4\x6d\x25\x0b\x48\x64\xa8\xd1\x4a\x1b\x4b\x16\x91\xfa\x50\xe3\x95\xb6\x96\x2c\x20\x57\xa2\x46\x2b\x6d\x2e\x79\x54\xee\x4a\xeb\x92\xb4\xbd\x44\x23\x93\x36\x98\xac\x1e\x23\xa5\x91\x49\x5b\x4c\x34\x32\x69\x93\x89\xce\x48\xad\x4d\x25\xda\x54\xa2\x4d\x25\x75\x93\x90\xec\xbc\x2e\x31\x95\xe8\xf4\x80\xda\xa8\xf1\x44\x32\x52\xaf\x12\xe7\xf1\xb4\x13\x42\x7c\xa3\xf9\xaa\x9f\x07\x02\x2d\x15\x4f\x74\x36\x6b\xcd\xb3\xea\x0c\x84\x3a\x9b\xf5\x92\xb3\x7f\x94\xb2\xab\xdb\x9a\x5d\xd5\xec\xea\xea\x67\xb3\xd6\x9c\x46\x9e\xd3\x58\x81\x74\x14\x2b\x96\xeb\x5a\xe3\xc8\xc4\x38\xa2\x33\x61\x6b\x3e\x54\xf3\xa1\x8f\xce\x87\xae\xc9\x6e\xd9\x09\x64\xe7\x88\xad\xba\xd5\x14\x5b\x63\x10\x6b\x88\x46\xf0\x17\x14\x10\x91\xcc\x40\x78\x6d\x1b\x84\xde\x8b\x83\x62\xc3\xe0\x5a\xd4\xa4\x70\x90\x1c\x3f\x83\xa2\x91\xef\x40\x8a\xdd\x41\x3c\x47\xc3\xc1\x84\xa6\xf0\x22\x33\x73\xe7\x17\x86\x51\x45\xf4\x1b\xcb\xb8\x26\x3a\x98\xf4\x81\xc2\xae\xe5\x84\x36\x3a\x70\x54\xdc\x5c\xb4\x7f\xc6\xa5\xb8\xbe\xd3\x7d\x8d\x42\x87\x62\x45\x1b\x79\x38\x0c\x52\x6c\xe2\xf1\x44\x0f\xd9\xab\x07\x00\xe3\xf7\x10\x05\x8c\xf9\x15\xc3\x75\xc5\x89\x59\x57\x50\x92\xf4\x2d\x9d\xda\x83\x56\xa6\x74\x80\xee\x32\xa9\x24\xd4\xf7\x08\x85\x03\x0e\x47\xf2\xdf\xd1\xe0\x46\xf6\x6b\x61\x96\xac\x4c\x5d\x59\x5e\x34\x62\x19\xa9\x0f\x21\x41\x57\xa2\x23\x25\x33\x5c\x6b\x57\x13\x1e\x3e\x73\xea\x78\x3e\x71\xc6\x1a\xa4\xf8\xdf\xe4\xba\x61\x5f\xbe\xef\x76\xa1\x13\x5f\x8b\x99\xf3\x3b\x17\x54\xc9\x5d\x73\x31\xa6\x1c\x3a\x18\xb9\x14\xfc\x33\x22\x70\x1f\x22\x32\xa6\xc2\x9d\x5c\x27\x31\xea\x04\x96\x4b\x26\x41\x1c\xfe\x07\x33\x10\x87\x0e\x5d\x17\x86\xe3\xc9\x52\x49\xa9\xd8\x96\x34\xd7\xf2\x55\xb0\x18\x02\x68\x2a\x3e\x02\x78\x41\x6a\x5c\x25\x6f\x35\xd9\xe8\xff\x99\x68\xf8\x49\x4e\xc6\x56\xe3\x87\x84\x97\xfa\x64\xac\xff\xfa\x7f\x9f\x8c\xcf\x3f\x6c\x34\x7e\xd8\x52\x9c\x99\xb6\x3e\x33\x4b\x3f\x33\x93\x9d\x94\x5b\xe7\xd1\x4f\x4a\x8e\xc1\x2f\x45\x53\x51\x91\x1d\xee\x32\x94\x5e\xf0\x39\x9a\x6d\x6e\x13\x1e\xb2\x58\xb6\x19\x77\xc6\xb6\xf5\x19\x5b\xfc\x19\x93\x1c\xcc\x6c\x84\x89\x4b\x9d\xab\x48\x98\xc6\x48\xb9\x4b\xa1\x4e\x63\xe6\xa0\x49\xd4\x73\x3a\x3e\x93\x1d\x9a\xd5\xa3\x51\x13\xa8\x98\xca\xb1\x7b\xe1\xd4\x6a\x3e\xb3\x7c\xe6\x74\x8b\xff\xf7\xf3\x9a\x3c\x0b\x42\x59\x13\x4b\xd2\x7d\xa1\xd4\x32\x5c\xef\xd6\xdc\x1d\xc9\xde\x0c\xea\xc9\x32\x23\xd3\xcc\xc7\xd6\x35\x0a\x92\xc6\x12\x56\xdd\x48\xd7\x97\xd6\xd5\x18\xbb\xc9\xb9\x8a\x95\x00\xfc\xc7\x76\xfa\x47\x6b\x94\xfc\xbd\x9b\xfa\xbb\x95\xfe\xb1\xdd\x4c\x7f\x49\x29\xab\xda\xa9\xbf\x5b\xb6\x38\xf5\x9f\xa3\x35\x30\xcd\x64\xea\x28\x8d\x1d\x25\xdd\xf1\xab\x74\xc7\xe9\x51\xda\x3b\xe9\x1f\xc9\x31\x37\x5e\xdb\xe9\xf9\x46\x73\xc9\x80\xef\x0f\xcf\x45\xf1\x66\xa6\x42\x50\xb0\xc3\x01\xa2\x0b\xe4\x08\x92\x61\xcf\x83\x81\xe8\xce\x08\xb9\x79\xcb\x78\xff\xf7\x6e\xf8\xf7\x83\x9b\x91\xd4\x90\xdc\x24\x5a\x94\xb5\xaf\x6b\x2f\xd7\x4c\xd3\x5c\x83\x3e\x96\xca\x95\x0e\xb8\x69\xad\x5d\x63\xd7\xee\x30\x15\x63\x1f\x0f\x4e\xa1\xbf\x36\x42\x14\xb2\x0b\xb0\xb3\x06\x00\x3b\x21\x1d\xc0\x0f\xa5\x39\x08\x60\x1f\xba\xd0\xb4\x78\x4d\x19\x23\xdc\xb4\xa3\x59\x10\x53\x54\x8b\x90\x3e\xf9\x22\xfb\xe1\x27\x2d\xea\x8c\xdc\x13\x8a\x46\x6b\x00\xf0\x7b\x91\xb0\xb1\x00\x80\xbe\xdf\x01\x72\x18\x5e\x60\x0d\x61\x40\xb3\x45\x43\x14\x60\x66\x98\xea\x80\x2b\xec\x38\x28\xe0\x85\x01\x72\x10\x24\x51\xe7\xbc\x88\xff\x95\x34\x8d\x16\x54\x36\xc7\xc6\x6f\x84\x81\xe3\x25\xc3\x57\xa3\xdb\xc5\xae\x1f\xa6\x6c\x46\xb1\xc1\x34\xba\x89\x8f\x2e\xbb\xe7\x17\x67\xa7\xc7\x57\x3f\x1f\x7f\xbc\x4c\xb6\x33\xbe\xe4\x27\xca\xd0\x19\x1d\xd3\x14\xd5\x89\xbf\xf9\x4e\x38\xc0\xae\x50\x52\xfb\x8a\x4e\xc5\xf7\x0f\x72\x5a\xa9\x61\x73\x7a\xb6\x2e\xbf\xf3\x71\x80\x14\x8b\x4a\xe9\x77\x19\xac\x8c\xac\xc1\xb4\x58\x1c\x01\xe1\xa7\xfc\x87\x04\xd9\x8c\xdd\x46\xb3\xb1\x63\x28\x8d\xce\xd1\x70\x3e\x74\x91\xa3\x18\xcc\x1f\x2a\x87\xf2\x87\xa5\x03\x35\xab\x07\x52\xc1\x15\x97\x42\xd4\x2d\xc2\x72\xca\x71\x95\x0b\x24\xd8\x1d\x38\x88\x50\x48\x8b\x43\x5e\x2a\xbe\xcd\x61\x48\xce\x0b\x15\x06\xbb\x42\x77\xe3\x87\x89\xf1\x07\xba\xae\x47\x61\x74\x45\x8e\x55\xae\xf6\x42\xec\xd0\x13\x37\x47\x1b\xb3\x6c\x95\x69\x02\x89\x41\xc0\x34\xd3\xd4\x09\xb9\x32\xae\x86\x22\x9d\x6b\xb1\x14\x5b\x9e\xcb\x5d\x18\x58\x9f\xc1\xa0\x07\xd7\x9b\x9b\xa0\xdd\x6a\x6d\x82\xf6\xee\xee\x26\xc8\x18\xa4\xe2\xc5\x1f\x24\xcb\x01\x7f\x06\x07\x0e\x0a\x28\x51\x51\xce\xf8\x7e\x30\x4a\x68\x24\xb2\x31\xcd\x47\x01\x31\x06\x2e\xa2\x27\x76\x8a\x57\x15\x68\x7d\x15\x5b\x6c\x38\xd3\x68\xe0\x4c\x15\x4c\x91\xb8\x52\x19\xd0\x76\xb7\x5b\xdb\x3b\xbb\x3b\xaf\x5a\x3b\xaf\xb8\x89\x3d\x67\xc3\x17\xfb\xac\x38\xca\x96\xe7\x52\xc1\x0d\x1b\x7f\xb1\xf1\x0d\xb0\x1c\x48\xc8\x9b\x4f\xc9\x3a\xcc\x21\x82\x36\x0a\x00\xc3\x09\x93\xd9\xf8\x51\xf0\xc9\xf8\xeb\x27\xf7\x2f\xc4\x87\xee\x5f\xff\x79\x76\xf1\x8f\xf7\x67\x07\x47\x1d\xf0\x7d\x74\x45\x36\xbe\x8f\xef\xee\xbf\x6c\xf1\x4a\x9f\xdc\xbf\x6c\xd9\xf8\xe6\xaf\xc6\x78\xb7\x8d\xed\x9c\xdb\x46\x7b\x67\x8c\x93\x71\x53\xed\xb4\xb1\xb7\x5f\xe5\xcc\x10\x19\x97\x86\x74\x94\xc2\xfd\x98\x6a\xa6\x8a\x02\xe8\x12\x1f\x4a\x79\x21\x8d\x47\xf1\x86\xf3\xb3\xa2\x3c\x62\x16\xb4\x86\xe8\x0a\x8f\x90\x17\x16\x6c\x78\x16\xc3\xbf\xb7\xd0\xba\x1e\x70\x0b\x71\xc1\x07\x81\x7f\x8f\x04\x18\xc5\xa7\xac\xe9\x4a\x60\x71\x7b\x67\x77\x13\xec\xee\x88\xff\x35\x1b\xfb\x59\xde\x93\xd7\xd8\x7e\xbd\x09\x5a\xed\xfd\x4d\xb0\xd3\x64\x55\xf6\x14\x75\xd8\x2b\xd7\xd6\xeb\xf6\x26\x60\xbd\x35\x1b\xfb\xaf\x37\x8c\x82\x59\xa7\x86\xa3\x4e\x6c\x9a\xf2\x52\xf6\x33\x63\x00\xc3\x01\xca\xee\xfa\x08\xde\x45\xeb\x6c\x35\x9b\x59\x97\x98\xe8\x43\xb3\x68\xea\xcb\x9b\x3e\x62\xeb\xea\x7b\xce\x1e\x54\xd5\x38\x85\xc1\x35\x0a\x48\xd6\x7a\xf5\x75\x3c\x6e\xee\x4c\x98\xed\x7a\x5b\x8d\x9a\x89\xe3\x51\x6c\xc8\xce\x63\x87\x0a\x65\xa1\xef\x63\x77\x70\x25\xb0\xae\xa5\x2a\xaf\x30\x67\x46\xb7\x18\x97\x65\x01\xf5\x40\xe6\x86\xcf\x88\xb9\xad\xb1\x66\xc7\xa8\xb3\x00\xba\x83\x31\x9d\x55\xd9\xc3\x47\xf0\xee\x08\x52\x78\x1e\xb9\x01\xa5\x76\xbf\xe8\x6d\x24\x9d\x23\x90\x6d\x64\xea\x5c\x09\xa1\x3d\x03\x3c\xdf\x23\xb4\x8f\xb3\x86\xa8\xa8\xf0\x9d\xe7\x52\xf6\xb4\x88\x53\xad\xe6\x9f\x52\xdf\x03\x54\x6c\x13\xa0\xaa\x26\x7c\xf9\xa7\xd0\xaf\xb2\x22\x47\x12\x50\x98\x57\x2a\x44\xda\x86\x0f\x5b\x39\x0f\x13\xea\xc5\x0d\x2a\x60\xc7\xee\xa4\x6b\x47\x38\x2f\x25\x23\x72\xf7\xb8\x2c\x65\xdb\x6e\x6d\x82\x56\x6b\x6f\x13\xb4\xf6\xf6\xd9\x71\x6e\xed\x65\x4e\x7c\x3f\x54\x6a\x1e\xb0\x8b\xd2\xdd\x88\x5e\xda\xec\x62\xd8\xdf\xde\x30\x8a\x87\x51\x79\x8e\x38\x95\x3b\xf4\x9c\x70\x94\xe7\x5e\x27\xf7\xaf\x2a\x06\x6f\x1c\x2b\xe3\xd7\x90\xdf\xe3\x5f\x25\xd5\x79\xa5\xe8\x07\x73\x78\xd9\x6e\x92\xcf\x1b\x0b\xf3\x5b\x19\xe3\x74\xb4\x53\xd7\xb7\xc4\x30\x6a\xc4\x22\xfc\x85\x6d\x0c\x2a\xa3\x74\x39\x87\x38\x49\xea\x54\x9c\x28\x3f\xe9\xe9\x33\xb2\xd7\xfc\x53\xee\x6b\xf5\x19\xf1\xd8\xda\x8c\x37\x35\x8f\x47\xac\x8c\x1b\x77\x42\x78\xc5\x48\xca\x89\x54\x7e\x2b\x41\xa6\x15\x04\x76\x1a\x3a\x5d\xa4\xf6\x53\x90\x69\x1b\x59\x78\x24\x94\x2b\x99\x75\x27\xc8\x2c\x1d\x3b\x43\x17\xd3\x99\xc9\xf8\xde\xa2\xe8\xb8\xda\xe1\x75\x6a\x42\xbe\x57\x93\x90\xef\x68\x42\xae\x09\xb9\x26\xe4\xe3\x09\x79\x49\x00\xe6\x47\xa0\xe1\x9b\x71\x24\x03\xcb\xb3\xd1\x77\xff\xf9\x64\xec\x36\x7e\x48\x48\x3b\xd8\x02\xab\x33\xd7\x68\x52\x0b\xe3\x33\xde\x1a\xb5\x59\x0a\x16\x61\x84\x05\x86\xd8\xdf\x6f\xec\x56\xb1\x17\x97\xa1\x65\x21\x42\xc0\x05\xa4\x08\xac\xbb\x9e\x6b\xee\xde\xdd\x81\x08\xe8\x64\xe3\x1b\x61\x38\xd8\xa3\x90\xa7\xfd\x7e\x66\x67\xd2\xc0\xb8\x25\x44\x72\xef\xf5\x63\xc5\xc5\x55\x7d\xfb\x5f\x14\x78\x33\xbe\xc9\x49\xc7\xcc\xcd\xfb\x4c\xeb\xe7\x3a\xcf\x2a\xbb\xe0\xc4\x19\x95\xeb\x10\x20\x30\x19\xa1\x48\xbf\xb2\x70\xd0\x0c\xc4\x20\x67\xea\x9d\xfc\x19\x45\x79\x80\xb4\x03\x63\x85\x72\xf0\xaf\xe2\x1e\xcc\xeb\xfd\x7d\x79\x58\xb1\xb7\xc6\x0a\x25\x16\x7f\xde\x5b\xb0\x5f\x1a\x73\x72\xc9\xa1\x92\xb2\xb9\xb7\xf5\x3b\x9f\x15\xce\xb8\x5d\xef\xf9\xcc\x93\x8b\x8e\xf4\xd8\xfa\xb4\x17\xed\xfd\x7d\x6b\xe7\xd5\xa4\xfa\xb3\x17\xf6\xce\x0e\xdc\x86\xb3\xd9\xb5\xde\x3e\x5b\xbb\xd6\xd8\xc0\x4e\x25\x0f\xe5\xf7\xb4\x3e\x4c\xeb\xc3\xb4\x3e\x6c\x0a\x7d\xd8\x0c\xde\xab\x35\x54\x4d\xa0\x96\xae\x49\xf0\x57\xff\x05\xa6\x89\x9e\xb4\xb4\xf9\x3d\x1a\xdb\x57\x43\xf2\x99\xdc\x28\xc7\x1e\x6a\x33\xdf\x4c\x14\x80\xab\x00\xf6\xfb\xd8\xfa\xd6\xd5\x63\x9a\x77\x78\x6e\xbc\x43\xab\x5d\x93\x79\xd8\xd5\xcc\x83\x66\x1e\x34\xf3\x30\x7f\xe6\x21\x09\x10\x53\xfd\x00\x33\x43\x92\x73\x55\xe7\xca\x2d\x2c\x6e\x42\xcf\x8f\x3d\x90\xcf\x93\x35\x7b\x00\xe6\xe0\xac\x7c\xf2\xe1\xed\xd9\xc7\x0f\x47\x20\x72\x5a\xbe\x5c\x82\x7f\x72\xab\x24\x5f\xe4\xce\xee\xe3\x3b\x28\xaf\x86\x31\xb2\xb9\x80\xd0\xd3\xad\x1d\x35\xd4\xdb\xbb\x73\x8d\xe5\x37\xa7\x34\x9c\xab\x6e\x37\x04\x90\x80\x3f\x50\xe0\xe9\x70\x7f\x8a\x58\x6e\x39\xff\x90\x29\xc3\x4f\xcf\x49\x4c\xce\x44\x86\xcb\x49\xe5\x2a\x0a\xca\x63\x58\xd4\x8f\xcd\x10\x79\xc9\x28\x22\x34\x54\x74\xb1\x09\x32\x4e\x37\xcb\x8c\xfb\x96\x9b\x93\x0c\x40\x5c\x3a\x53\xf0\xf5\x2b\xe8\x80\x87\x87\xec\x84\x6b\x05\x0a\x7c\xbb\x90\x40\x81\xb5\x91\xeb\x3b\x8d\x5d\xb3\x62\xd7\x1c\x83\xa4\xcf\x0b\xed\xa6\x0d\x4a\xb9\x04\xf3\x63\xde\xa1\x9c\x80\x5e\x94\xad\x05\x1c\xb8\x36\x88\x33\xb9\x1c\x32\x42\x52\xd3\x36\x59\x20\x8c\xcf\xca\x38\x99\x15\x68\x69\x32\xe7\xc8\x92\x36\x95\xdd\xd2\xd3\xf1\x09\xe7\x64\xb8\x7c\xc6\xb1\xad\x4b\xf9\xe1\x57\xcb\xe2\x87\xe7\xe4\x7d\xa7\x5d\xec\x56\x9a\x45\x9e\xf2\xad\x16\x78\x64\xae\x59\xed\x14\xfe\x88\x4c\x4d\xe4\x88\xfe\x04\x61\xf9\x88\x40\x7b\x62\x2c\xdf\xc2\x83\x8f\xcf\x1f\x83\xbe\xd3\xc7\xf1\xa9\x01\x53\x9f\xc7\x9a\xe7\x71\x85\x03\xbd\xd7\x7e\x5e\xa3\x53\x66\xce\xcb\x1d\x54\xf5\xee\x75\x16\xb9\xc5\x68\x35\x9a\x2d\x43\x0b\x58\x8f\x28\x60\x95\x86\xd3\x9a\x5e\xf2\x1a\x17\x02\xa4\x5d\x12\x9e\xa6\xfd\x68\xaf\xa2\x16\x23\x96\xa5\x1f\x45\x69\x91\x4d\xbf\x8a\x9a\xab\x44\x32\xe3\xd3\x91\xf9\x70\x40\xc9\xf3\x93\x49\x54\xd3\x8f\x9c\x2a\x3c\x37\x33\xce\x03\x95\xce\x96\x25\x07\xdf\x6d\x8e\x97\x4a\x8e\x16\x92\x74\x5e\x23\xdc\x37\x89\x70\xfb\xcd\xc5\x8b\xc1\x6a\x84\xdb\xd5\x08\xf7\x4d\x22\xdc\xee\xe2\x6d\xb9\x8b\x7f\x61\xa9\x11\xee\xe9\x20\xdc\xfe\x78\x84\x3b\x5c\x04\xc2\x2d\x8b\x87\xfb\x4e\x63\xdc\xaa\x31\x71\x25\x68\x76\xfc\x94\x39\x37\x8d\x66\x2b\xc7\xba\x95\xa0\xd9\xbb\xa7\xcc\xaf\x69\x34\x5b\x39\x86\xad\x04\xcd\x7e\x7a\xca\x5c\x9a\x46\xb3\x95\x63\xd3\x4a\xd0\xec\xe7\x15\x74\xb6\x8b\x83\x7e\x24\x4e\x77\xda\xde\xb3\xc2\xe1\x3f\xb4\x8d\x67\xf5\x9d\xe8\xc6\x05\x81\x2d\x35\xe5\xec\xad\x88\x29\x47\xdb\x6b\xb4\xbd\x46\xc5\xb6\x88\x57\x98\x5a\xa3\xa4\x8d\x34\x8b\x14\xf5\x35\x96\x3d\x2f\x2c\x5b\x51\xd3\x8c\x46\x33\x6d\x8f\x59\xbc\xa4\xaf\xb1\xec\xd9\x5d\x66\xab\x68\x85\x99\x19\xcd\xb4\x16\x49\x9b\x5e\x34\x6e\x69\x7b\xcb\x53\x63\xc2\x34\x6e\x69\x23\x8b\xc6\x2d\x6d\x59\x59\x41\xcb\x0a\x8b\x8b\xa5\x5f\xd1\xcc\xcb\xaa\x62\x23\x8b\x1f\x68\x6d\x5c\xd1\xc6\x95\xca\xec\x41\x65\xd6\x95\x57\xda\xba\xa2\xad\x2b\xab\x69\x5d\x91\x6f\x94\xb5\xae\x48\x9b\x57\x16\x29\xce\x6b\x34\xd3\xf6\x95\x65\x88\xf6\x1a\xcf\xb4\x81\x65\x09\x52\xbe\x46\x33\x6d\x61\x59\x86\x85\x65\x5e\x78\xa6\xd5\x49\xda\xc4\xa2\x91\x4b\xdb\x58\x9e\x1e\x23\xa6\x91\x4b\x1b\x59\x34\x72\x69\x2b\xcb\x0a\x58\x59\xe2\x60\xd0\xda\xba\xa2\xad\x2b\xda\xba\xb2\xf0\xf8\xcf\x85\x30\x64\xaf\x4a\x92\xae\x35\xe7\x1a\xfe\x59\x07\x72\x7e\x36\x76\x10\x75\x3a\x8a\x29\x72\x81\x3e\x87\x68\xc4\xd3\x70\x10\x4f\x31\x0f\xc5\x32\x62\x12\x2f\x01\xb1\xbe\xd3\x98\xf5\x48\xf9\x73\x56\x3a\x88\xee\x5b\x86\x56\xe0\x42\x62\x19\x60\x59\x28\x41\xec\xfe\xc3\x73\xed\xc5\x18\xa5\x19\xd3\xd9\x18\xd3\xb7\x3a\x27\x49\x05\x4f\x9a\x9d\xe7\xb7\x9a\x92\xa4\x94\x27\x6d\x6b\x9e\x54\xf3\xa4\x93\xb1\x0e\x85\xf4\xb7\x13\x70\x9d\x4b\x4d\x63\xaf\xb9\x86\x95\x63\x3b\x27\xc0\x9d\xef\x34\xf2\x68\x96\x73\x72\x96\xf3\x12\xb9\x14\x50\x4f\x33\x9b\x9a\xd9\x5c\x69\x66\x73\xc6\xec\xda\x67\x1f\xaf\x44\x7a\xed\xcb\xe3\x8b\x5f\x4e\x0e\x8f\x97\x91\x5d\x7b\xbb\x5d\xe2\x36\xbe\xaf\xb3\x6b\x2f\x2e\xbb\xf6\xf6\xae\x1a\xea\xaf\x9b\x3a\xbb\xb6\xce\xae\xbd\x22\xd9\xb5\xab\xd9\xa1\xb1\x56\xe6\x92\xac\xc7\xa2\x6a\x9e\x61\x23\x28\xb8\xc1\xb2\x67\x9b\x50\x72\x63\xe5\x92\x66\x29\x2a\x3f\x62\x56\x6c\xc5\x6c\x9e\x53\xe6\xeb\x6f\x66\xe7\xe7\x68\x29\x98\x04\x25\x56\x38\x2b\xf5\x59\x48\x07\x5e\x3e\x2b\xf5\x51\xb2\x36\x9d\x9a\x5a\xa7\xa6\x06\xda\x35\x61\x95\xf4\xc0\xa5\xcc\x64\x4b\xa7\xa6\xd6\xda\x63\xb0\xfc\xd4\xd4\x09\xa1\x7f\x14\x6e\xb3\x2c\xf9\xed\xac\x5c\xc7\x44\x39\x6c\x1f\x19\x06\xb3\x2f\x76\x05\x79\xa9\xd5\x49\xf7\x5c\x67\x77\xbf\xd3\x28\xbe\x58\x20\x3c\x69\x1c\x57\xa3\xf8\x0a\x6b\xe2\x63\xc1\xa0\x4e\x06\xe5\x94\xc0\xa0\x95\xf3\x3a\x8d\xb2\x16\x06\x1e\x3b\x8d\xf2\x4e\xab\x44\x46\x68\xeb\x34\xca\x5a\xbc\x78\xb6\x69\x94\xe7\xc1\x85\x8f\xf3\x21\xa8\xeb\x93\xa0\xe6\x50\x12\x67\x02\x45\xdd\x47\x7f\x44\x55\xc6\x89\x3f\xd9\x34\xc7\x1a\x21\x16\x84\x10\x4f\x35\x0d\xb1\x46\x88\x45\x21\xc4\x13\x4d\x13\xac\x11\x62\x51\x08\xf1\x44\xd3\xf8\xce\x45\xcd\xa1\x31\x42\xc9\x44\x3c\xa1\x34\xbb\x1a\x0d\x16\xc7\x3a\x3c\xa1\x34\xb8\x1a\x0d\x16\xc7\x30\x3c\xa1\x34\xb5\x1a\x0d\x16\xc7\x26\xac\x70\x18\x8e\xbc\x77\x4c\x26\x8d\xac\x56\x7a\xeb\x5c\xb2\x5a\xd1\xbd\x9c\x5c\xb2\xa5\xfa\xec\x6d\x1d\xed\x5c\x2b\xad\x9f\x60\x2e\x59\xad\x76\xf8\x96\x34\xd5\x1a\x0b\xb4\x7a\x5a\x63\x81\xd6\x49\x6b\x2c\xd0\x8a\xe8\xd9\xb1\x40\x2b\x1a\x9e\xb6\xf6\x59\xef\xfd\xb7\xab\x72\xd6\x7b\xff\xed\xea\x99\xf5\xde\x6b\xe5\x72\x95\x72\x39\x8a\xf5\xac\x15\xcb\x3a\xe0\xb3\xd6\x2f\x2f\x37\x9d\x66\xa9\x82\x79\x47\x2b\x98\xb5\x82\xf9\x29\xa6\xd3\xd4\xfa\x84\x6f\x43\xc3\xac\xd1\x40\xab\x98\x35\x1a\x68\x1d\xb3\x46\x83\x47\x44\x83\xa7\x96\xcd\x51\xab\x1c\x9e\xb3\x9a\x59\x6f\xfe\x37\xac\x67\xd6\x9b\xff\x0d\x2b\x9a\xf5\xe6\x6b\x4d\x73\x8d\x6c\x82\x5a\xc3\xac\x35\xcc\x5a\xc3\xbc\xdc\x94\x82\x3b\xaf\x4b\x34\xcc\xaf\x74\xfa\x16\xad\x0b\x7e\x94\xf4\x2d\x09\x3b\xb0\xd4\x28\x5d\x95\x24\x7e\x05\xc2\x3a\x3f\x83\xdc\x2a\x7a\x67\x9f\x58\xb8\xb5\x54\xe2\x13\xcf\x05\xb1\x6b\x80\x4e\x7c\x02\x80\x4e\x7c\xa2\xb3\xec\x2d\x2f\xba\x72\x29\x9b\xb6\xa7\xd9\x34\xcd\xa6\xcd\x37\x41\xef\xea\x07\x0d\xd6\xac\xda\x42\x37\xf7\x3b\xbd\xbb\x4f\x99\x5d\xcb\xa6\x46\xd6\x4c\x9b\x66\xda\x56\x8a\x69\x5b\x93\x67\x80\x1d\x1d\x76\x00\x78\x34\xe0\xa6\x80\xa6\x41\xac\x21\x1a\xc1\x5f\x50\x40\x44\x58\x5a\xe1\x11\x69\x10\x7a\x2f\x30\xdc\x86\xc1\xb5\xa8\x49\xe1\x20\x39\x37\x06\x45\x23\xdf\x81\x14\xbb\x83\x18\x91\x0c\x07\x13\x9a\xda\xca\x8c\x7f\xa4\xf3\x0b\x43\x82\x22\xc6\x24\xac\xd1\x43\x0a\xbc\xe3\x99\xbf\x44\x9d\x9f\x3e\x1a\xd8\xb5\x9c\xd0\x46\x07\x8e\x8a\x23\x8a\x76\xc2\xf8\x10\x5d\x9f\xe9\xde\x46\xa1\x43\xb1\xa2\x95\x44\x74\xc3\x55\x35\xf2\x78\x34\xdf\xec\x75\x02\x80\xf1\x7b\x88\x02\xc6\x44\x8a\x3f\x98\x3d\x26\x74\xa8\xa0\x1e\x8a\xe0\xea\xc5\xab\x57\x91\x48\x14\x78\x01\x48\x3a\x50\xe9\x08\x6a\x75\x93\xbe\xc5\x53\xf8\xd0\xca\x94\x0e\x10\xc3\x40\x63\xab\xf1\x43\x8a\xee\x7c\x32\xd6\x7f\xfd\xbf\x4f\xc6\xe7\x1f\x36\x1a\x3f\x6c\x19\xd5\x37\x14\x85\x03\xbe\xdd\xe4\xbf\x23\x40\x18\xd9\xaf\x05\x88\xb1\x32\x75\x65\x79\x85\x09\x90\xa6\x3e\x84\x04\x5d\x89\x8e\x94\x0c\xee\xca\x22\xdf\x3f\xe5\xa6\x4c\x82\x7b\xb7\x8a\x36\x13\xa1\x5e\x29\xee\x3d\x4c\x96\x6e\xf7\x6b\x39\x92\xe5\x30\x34\x37\x4c\x6d\x7e\xe6\xab\x32\xc5\xee\x06\xef\x7d\xbd\xfa\x00\x2c\x6c\x2d\xca\xc1\x66\x5e\xd1\x84\x47\x31\xe6\xef\xc6\x1d\xc4\x96\x3e\x88\xe9\x83\x98\xe3\x45\xe2\x73\x78\xe2\xf6\x18\x4f\x0f\xa2\xf3\x08\x2a\xa9\x42\xae\x97\xe8\x60\xf2\xe4\xd0\x53\x9f\xca\xf2\xe3\x32\x59\x7a\xec\x37\x15\x1c\xff\xb4\xf8\x39\x11\xe9\x59\xad\xf9\x4e\x78\xb0\xea\x93\xb8\xb6\x3e\x59\xd3\x9c\xac\xc9\xce\xd3\xad\xf3\xc4\xcf\x53\xdd\x84\xf2\x6a\xb2\xb0\x62\x87\x6d\xb6\xc5\x2c\x8a\xc4\x6d\xeb\x83\x58\xe7\x20\xa6\xf3\x77\x5e\x0a\x75\xca\x04\x67\x51\xe8\x87\x16\x7b\x18\x4b\xed\x93\xd3\x3b\x97\x7d\x2d\xd7\x64\x4d\x76\xb6\x96\x3b\xb7\x09\x8f\x8a\x4a\xa9\x37\xee\xd4\xec\xac\xc6\xa9\x59\x8b\x54\x13\x5f\x85\x22\x01\x73\x74\x93\x2a\x84\xbe\x50\xc3\x19\xae\x77\x6b\xee\x8e\x64\x6f\x06\xf5\x64\x99\x91\x69\xe6\x63\xeb\x1a\x05\x49\x63\x09\xb6\x6e\xa4\x78\x4c\xeb\x95\x8c\xdd\xe4\x48\xc5\xda\x0f\xfe\x63\x3b\xfd\xa3\x35\x4a\xfe\xde\x4d\xfd\xdd\x4a\xff\xd8\x6e\xa6\xbf\xa4\x14\x6b\xed\xd4\xdf\x2d\xdb\x58\x4b\xa9\x1f\x85\x9a\x34\x75\x82\xc6\x8e\x92\xee\xf8\x55\xba\xe3\xf4\x28\xed\x9d\xf4\x8f\x84\xd4\x1a\xaf\xed\xf4\x7c\xa3\xb9\x64\xc0\xf7\x87\xe7\x26\x69\xfb\x13\x9d\xe6\x09\x3b\x1d\x09\x5b\x7c\x04\xc9\xb0\xe7\xc1\x40\xf4\x67\x84\xdc\x0a\x66\x7c\xec\x91\xcb\xff\xbd\x3a\xc2\x52\x37\x74\x93\xe8\x8f\xd6\xbe\xae\xbd\x5c\x33\x4d\x73\x0d\xfa\x58\xaa\x95\x3a\xe0\xa6\xb5\x76\x8d\x5d\xbb\xc3\xf4\xa1\x7d\x3c\x38\x85\xfe\xda\x08\x51\xc8\x2e\xbf\xce\x1a\x00\xec\x88\x74\x00\x3f\x96\xe6\x20\x80\x7d\xe8\x42\xd3\xe2\x35\x65\x9c\x55\xd3\x8e\x66\x41\xcc\x11\xbe\x43\x41\x52\x20\x9b\xf3\x13\x16\xf5\x41\xee\x09\x45\xa3\x35\x00\xf8\x55\x48\xd8\x10\x00\x40\xdf\xef\x00\xd9\x3b\x2f\xb0\x86\x30\xa0\xd9\xa2\x21\x0a\x30\x33\x5b\x75\xc0\x15\x76\x1c\x14\xf0\xc2\x00\x39\x08\x92\xa8\x73\x5e\xc4\xff\x4a\x9a\x46\xeb\xc8\x4d\xad\xf1\x1b\x61\x8b\x7f\xc9\xd0\xd3\xe8\x76\xb1\xeb\x87\x29\x43\x52\x6c\x45\x8d\x2e\xdc\xa3\xcb\xee\xf9\xc5\xd9\xe9\xf1\xd5\xcf\xc7\x1f\x2f\x93\xdd\x8b\xaf\xf3\x89\x72\x4a\x45\xa7\x32\x45\x5f\xe2\x6f\xbe\x13\x0e\xb0\x2b\xb4\xe8\xbe\xa2\x53\xf1\xfd\x83\x9c\x56\x6a\xd8\x9c\x3e\xb1\xcb\x6f\x76\x1c\x20\xc5\xa2\x52\xaa\x67\x06\x22\x23\x6b\x45\x2d\x16\x47\x40\xf8\x29\xff\x21\x41\x2d\x63\xb7\xd1\x6e\x6c\x1b\x4a\x4b\x74\x34\x9c\x0f\x5d\xe4\x28\x06\xf3\x87\xca\xa1\xfc\x61\xc9\x40\xcd\x46\xb3\x7a\x20\x15\x5c\x71\x29\x44\xdd\x22\x2c\xa7\x1c\x57\xb9\x40\x8a\xee\x68\x71\xb0\xab\x4c\x69\xc9\x30\xf1\x66\x42\xd7\xf5\x28\x8c\xae\xa7\xb1\x1a\xdd\x5e\x88\x1d\x7a\xe2\xe6\x48\x54\x96\x9b\x31\x4d\x20\xb7\x13\x98\x66\x9a\x32\x20\x57\x3e\x94\x57\x64\x03\x2b\x96\x62\xcb\x73\xb9\x93\x01\xeb\x33\x18\xf4\xe0\x7a\x73\x13\xb4\x5b\xad\x4d\xd0\xde\xdd\xdd\x04\x19\xcb\x94\xe1\xe0\x11\xe6\x5a\x90\x66\x53\xc1\xd3\x1c\x24\x8b\x04\x7f\x06\x07\x0e\x0a\x68\x86\xd3\x62\xda\x74\xbe\xa8\xa6\x82\xc0\xc5\xe7\xda\x28\x21\x65\xc8\xc6\x34\x1f\x01\xc0\x18\xb8\x88\x9e\xd8\x29\x7e\x50\xa0\xe3\x55\x6c\x04\xe2\xf0\x33\x70\xa6\x0a\xa6\x48\x5c\x7c\xec\xfb\xee\xce\xf6\xde\x5e\xab\xbd\xdd\xde\xdd\xe6\x8c\x6f\xce\x20\x2f\x50\x42\x71\x04\x2d\xcf\xa5\x82\xe3\x34\xfe\xc2\xcc\xf1\x28\xf8\xeb\x5f\x86\xed\xbf\x1e\x21\xdf\xf1\xee\x91\x0d\xe4\xed\x4c\xfe\xb2\x35\x6c\xff\xf5\x2f\x5b\xb2\x8a\x31\xde\x9b\x62\x3b\xe7\x4d\xd1\xde\x19\xe3\xf3\xda\x2c\x98\x7d\x86\x88\xc5\x26\x60\x53\xdb\x69\xe6\xb0\xf9\x55\xbb\xca\xf3\x20\xb2\x47\x0d\xe9\x28\x75\x0c\x62\xe2\x95\x2a\x0a\xa0\x4b\x7c\x28\x79\xee\x34\x4a\xc5\x1b\xca\x8f\xcd\xd3\x76\x6c\xd9\x9d\x74\x2b\xb6\xd5\x6e\x2d\xaf\x76\xb4\x5b\x8b\x76\x6b\x29\x4b\x8e\x2c\x04\x26\x76\xe5\xdb\x0f\x96\x37\xf2\x3d\x17\xb9\x94\xf9\x2c\x30\x7e\x27\x16\x6b\x28\x1c\x2c\xc3\x49\x80\xc2\x41\xb9\x53\xc0\x81\xb1\x54\xfb\xff\x29\x03\x40\x7c\x91\x6a\x13\xff\x8c\xe1\xff\x57\xc3\x7a\xfe\xe4\x5e\xd0\xa4\x21\x8d\x89\x22\x32\x91\x2a\x24\x11\x2b\x7f\x8f\x6e\xe2\x59\x67\x78\x9a\xaf\x9b\xb5\x58\x89\x0b\x24\xe8\x18\xf8\x48\xe0\x00\x2d\x9e\x8f\xd8\x9b\x84\x8f\x68\xef\x6b\x3e\xa2\x36\x1f\xf1\x3a\xb7\x15\xaf\xc6\xec\x44\xab\x24\x4e\xd6\xae\x66\x23\x34\x1b\x51\xc6\x46\xf8\x81\x67\x21\x42\xba\x37\x38\xe0\xbe\x8e\x23\x34\xf2\x82\x7b\xa1\x7f\x7d\xf8\xcd\xeb\x31\x75\xa9\x50\xe0\x50\xe4\xa0\x11\xa2\xc1\xfd\x17\xf1\x5b\xb8\x49\xc6\x9c\xc6\x6f\x5e\x6f\x1a\x4e\x83\x50\xa8\x42\x33\x15\x17\xd2\xae\xe4\x42\x7e\x11\x0b\x00\xa7\x7c\x01\x60\xfd\xe1\x01\xfc\xe6\xf5\xc0\xd7\xaf\x65\xee\xa7\x27\x46\x6d\x4f\xd3\x34\xa0\x02\x44\xb0\xcd\x54\xd4\xcb\x86\xd4\x44\xd0\xb8\x90\xb3\xac\x0f\x8e\x9f\x27\x03\xc7\xc0\x63\x00\x20\x14\x52\xd2\x1d\x22\xe8\x77\xc9\x3d\x59\x16\x2c\x94\x2a\x89\xc9\x61\xc4\xa6\x0d\xc8\x3d\xa9\x01\x9c\x83\x19\x81\x03\x1d\xc7\xb3\x9e\x22\x78\xf8\xc4\x6b\x00\xe8\x68\x7a\x00\x2d\x15\x36\x13\xc2\xe0\xa0\xe6\xf2\xdf\xcd\x88\x1f\xd8\x0d\x09\x5a\x16\x0c\xea\x87\x5c\xa8\x06\xce\xcf\x0c\x41\xb0\x6b\x86\x04\xd5\x00\xd1\xf1\xf4\x20\xe2\x24\x70\xb9\x30\x9a\x10\x14\x97\x6c\x86\xf5\x61\xf1\xd3\x64\xb0\xe0\x82\x4c\x37\x40\xbe\x03\x2d\xb4\xce\xf8\x7e\x88\x5d\x14\x44\x14\x28\x64\x8c\xbe\x04\x4c\xf2\x91\x49\x8a\x6f\xfe\x23\xf5\x01\x11\x64\x02\xef\xee\xfe\x93\xb1\x09\x7c\xcf\x8e\x2b\xe4\x80\x68\x36\x7e\xc8\xc0\xd1\x6c\xfc\xf0\xc9\xf8\xba\x09\x3e\x19\xd2\xa6\xc9\xda\x7f\x32\xbe\x6f\x7d\x32\x00\xfb\x23\xea\x4a\x14\xaf\x57\x6d\xc9\x06\xef\x4b\x3e\x6b\x88\x8c\xac\x8f\x86\xbe\x0f\x0f\x20\xf5\x5a\x85\x33\xa5\x60\xfd\x7a\x8f\x94\x47\x08\xd3\x9b\x96\x9d\xe9\x26\x58\xb1\x4d\x34\xc1\xc3\x03\xc8\xce\x91\x15\x57\xed\xea\xdb\x25\x2b\xa7\xf8\xee\x6b\xa5\xd4\x6c\x4a\xa9\x85\x47\x75\xd1\x4a\xa9\x29\x95\x52\xcf\x46\xb9\xf2\xaa\xa6\x72\x45\x47\x88\xd1\xca\x15\x25\x0f\x90\xa5\xff\x8c\x23\xe0\x8f\x54\x13\xea\x64\xf9\xa1\xe4\x02\xa2\xdc\xd6\x71\x58\x91\x85\x70\x03\xa9\x27\xa5\x51\xdb\x8d\x79\x72\x08\x4f\x86\x91\x9b\x40\x98\x5f\xe5\x4d\xcc\xf3\x62\xcf\x79\x53\x67\x64\xec\x6a\xb0\xeb\xe2\x09\x79\xa4\xe4\x63\xdb\x9a\xdd\xd0\x7a\x12\x67\x6a\x7b\x1e\x55\x34\x8f\x25\x50\x26\x67\x39\x7d\x53\xba\xd0\xda\x75\x64\x9b\x25\x70\xc1\x87\xe7\x1f\x35\x0b\xac\xed\xb2\x8f\xf0\xfa\x5a\x73\xc0\xb5\x39\xe0\x56\xbb\x26\x0b\xfc\x5a\xb3\xc0\x9a\x05\x1e\x67\x36\xf3\x7c\xe4\x76\xfb\xf6\xa3\x9a\x3f\xc6\xdb\x1a\x73\xef\x28\x26\x27\xbc\x67\x3e\x72\xc1\xbb\xa3\x05\xd8\x94\xca\xf4\x89\x7d\xf2\xad\xeb\x12\x17\xc0\x59\xae\x86\xb2\xf0\x08\x93\x6b\xcd\x27\x2d\x4c\x55\x68\x18\xcb\xe2\x3e\x4a\x8e\xb7\x8d\x2c\x3c\x12\x4f\xa2\x0a\xfd\x24\x2b\x70\x3d\x17\x4d\xba\x80\x66\x7b\x27\x3b\x59\xad\x44\x7c\x14\x16\x6a\xaf\x26\x0b\xf5\xe8\x9e\xde\xf9\x62\xcd\x43\xad\x10\x0f\x35\xf0\xba\x03\x2f\xf0\x42\xca\xa0\xbb\x72\xb6\xef\x0f\xe1\xa8\x87\x02\xe0\xf5\xc1\x4f\xf1\x24\x27\x64\x7f\x96\x40\x4b\x93\xb9\x69\x8a\xba\x30\xcd\xc3\xa3\x53\xd4\x6f\x58\xef\x50\xe2\x0e\x2e\x5e\x43\xb0\x0b\xed\x06\xa3\xdb\xc5\xbb\x83\xb7\xaa\xfd\xc1\xfd\xbb\x9c\x47\xf8\x76\x53\x7b\x84\x4f\x1d\x32\x79\x9c\x47\x78\xbb\x84\xdd\xd8\xd7\x1a\x1b\xcd\x6d\x94\x71\x1b\xdc\x10\x32\x08\x7c\xab\x8b\x3d\x1e\xc7\x82\x99\xb9\xbc\x91\xef\x20\x8a\xec\x6e\xe0\x5b\x44\x58\x39\x1e\xcd\xb8\xc1\x15\x1a\x60\xfd\x8a\x07\x58\x9c\x8f\xf5\xa7\xee\x9a\x39\x53\xc5\xeb\xc9\x4a\xec\x30\x7b\xf6\xc2\x79\x2c\xb9\xe4\x87\x07\x50\x1c\xbc\x82\xc7\x5a\xb2\x59\xe7\xc4\xb5\xbc\x11\x76\x07\xe0\x42\x06\x97\xd1\xac\xd6\x6c\xac\x96\xe7\x6b\x2f\x27\xad\xa0\x58\x20\xc3\xf0\xaa\x26\xc3\xa0\x13\x2c\xac\x2c\xc3\xa0\x3e\x9d\x1c\x43\xb9\xb6\x3b\xa7\xcb\x8e\xce\x44\xab\xe2\xdc\x3c\x06\xe7\xa1\xce\x9f\xbc\x09\xca\xa8\xb3\xfc\x8f\x03\x29\x72\xad\xfb\x28\x9f\xe6\xd7\x4a\x32\x3d\x65\x8a\xca\xc9\xed\x0b\x4a\x22\x0d\x9a\x8d\xdd\xd9\xb9\x15\x75\xc2\xd1\x67\x06\xa7\xfd\xd9\x5d\xf0\x2b\x13\xb3\x3e\x1f\x40\xed\xd7\x78\x8f\xb5\xcc\x2c\xa6\x47\x32\x38\x98\x66\xfd\x66\x64\xfd\x46\x9a\xf3\xd3\x9c\xdf\x02\x39\xbf\x56\xbb\x26\xeb\xd7\x6a\x69\xde\x4f\x2b\x8b\xc6\x2b\x4e\x24\x75\x1a\x42\xd7\x76\x90\x2d\xbd\x66\xf9\x17\xcb\xb3\xb9\x9f\xc9\x47\xf7\xda\xf5\x6e\xdd\x2f\x1f\x5d\xcc\x34\x2b\x23\xe4\x52\x64\x7f\x39\x61\xc4\xd0\x85\xce\x97\x23\x48\xe1\x7b\x8f\x90\xac\x1b\x2d\xef\x61\x49\x5a\x16\xa1\x2b\x8f\xc8\x6d\x4c\x67\x57\xc3\x19\xe4\x92\xc3\x17\x1c\x07\x81\x17\x80\x0b\x48\x11\x58\xdf\xbd\xbb\x03\x51\x5e\x79\xb2\xa1\x29\xae\xf6\xa8\xd5\x1e\xb5\x2b\x4d\x73\xf7\xea\xd2\xdc\xb6\xa6\xb9\x9a\xe6\x56\x3f\x55\xa9\x41\x74\x59\x0a\xc3\xb3\x7f\x7c\x32\x36\xe3\xba\x32\xcf\x60\xe3\x87\x53\x19\x25\x50\x53\xda\x22\xa5\xfd\xe0\xb9\x26\x09\x2d\xe6\xb6\x8c\x08\x58\xdf\xb9\xbb\xd3\xc4\x55\x13\xd7\x6f\xcb\x6d\xe4\xc0\x86\x3e\x45\x01\x01\xd0\xb5\x65\x80\xf8\xc5\xfb\x8e\xb4\x4b\xf2\x3b\xb7\xf7\xb4\x8f\xc8\xd4\x3e\xa9\x85\xb4\xda\x85\xe8\xc3\xcd\x12\x26\x64\x5b\x33\x21\x9a\x09\xa9\x66\x42\xb8\x5f\x42\x37\x08\x5d\xce\x18\xd8\x98\xf8\x90\x5a\xc3\x38\x73\x0b\x14\xb7\x08\x4f\xb9\x22\xff\xce\xf2\x1c\xb2\x70\x19\x2a\x74\x39\xd4\xca\x84\x29\x96\x37\x2c\x38\x92\x40\x03\x87\x5e\xe8\x52\xcd\x67\x68\x3e\x43\x6b\xce\x57\x9b\x80\xb6\xda\x75\x29\xa8\x0e\xe0\xaf\x29\xe8\xc4\xde\x0e\x55\x94\xb5\x6b\x4b\xfb\x6a\x1c\x9d\x42\xda\xa9\xeb\x52\xda\xa5\x59\xac\x13\x72\x0b\x4c\xe0\xef\x36\x67\x7f\x04\x5b\xe5\xf9\xf0\x1c\x61\xb6\xdf\x04\x8b\x72\x17\x79\xce\x50\xdb\x5f\x0d\x5f\xd8\x02\x7b\x17\x79\x46\xcc\xc6\xe1\xb5\x34\x87\xa7\xb9\x3b\xcd\xdd\x2d\x2f\xa9\xc2\x76\x89\x4e\xea\x55\x53\xf3\x76\x9a\xb7\x53\x6a\x47\x2c\xe8\xc0\x60\x9d\x7a\xfe\xf5\x7a\x6b\x13\x8c\xe0\x9d\x24\xb1\x22\x7b\x66\x37\x08\x1d\x14\xfd\x6d\x31\x99\x3f\x0e\x87\xd1\xc7\x83\x93\xa3\x8d\x8d\x85\x27\x6c\xba\x08\x1d\x44\x4a\xc8\xe4\x24\x51\x49\x26\x58\x28\x62\x6e\x0b\x8f\xb4\x5c\xa1\xbe\x16\x9e\x13\x64\x0e\xef\x85\x26\x58\xf6\x88\xb3\x52\x8f\xb9\xf8\x53\x36\x83\xea\xb5\x1f\xce\x6f\xed\xa1\x4b\x20\xc5\xa4\x8f\x91\xdd\x85\x16\x67\x1f\x85\x6d\xf2\xb1\x96\xff\x31\x99\x10\x38\xb0\xb2\x9e\xa9\xe0\x51\x3d\x66\x33\x67\x50\x6b\xfb\xb4\xb6\x6f\xf1\xf3\xd4\xec\xe0\xcc\x61\xa0\xcb\xd8\xc1\x5d\x1d\x07\x5a\xb3\x83\x53\x91\x4c\x11\x96\xce\x7a\x64\x96\xf0\x44\xce\x82\xac\x86\x71\x2c\x9e\x0e\xc0\x2e\x78\x0f\x29\x22\x54\x7a\x21\x68\x92\xa9\x49\xa6\x26\x99\x4f\x2a\x6e\x6c\x29\xcd\xd4\xe6\x31\x4d\x33\xa7\xa2\x99\x89\x4c\xf9\x88\x24\xf3\x67\x31\x89\x15\xa1\x98\xd1\x6c\x34\xc1\xd4\x04\x53\x13\xcc\xa7\x1d\x25\xb4\x94\x60\xea\x30\x1c\x9a\x60\x4e\x45\x30\x21\xa5\x01\xee\x85\x14\xcd\x8f\x56\x4e\x90\xb8\xb9\x9a\x8e\x1e\x44\x73\x5b\x11\x4a\x9a\xcc\x47\xd3\x52\x4d\x4b\xbf\xe9\x57\x20\x27\x31\x5e\x82\xe8\x41\xc8\xe2\x5f\x81\xec\xec\x94\xbc\x02\xd9\x7e\xfc\x57\x20\x96\xe7\x38\xd0\x27\xc8\xce\x43\xbc\x7c\xfd\xad\x89\xd7\x5f\x42\xfd\x77\x12\x15\xb3\x0f\x5d\xe4\xe4\x00\x10\x20\x1f\x09\xec\x95\x0e\x51\x0a\x28\x44\x0e\x5b\xe0\xa0\x50\x45\x2e\x3d\xf0\x6e\xbf\xb5\xf7\x2f\x3b\x7b\x25\xde\xbb\x3a\xaf\x8d\xe6\xb6\x6a\x64\x05\x9c\xe8\x25\x4c\xd1\x69\x72\xf3\x93\x21\xd5\x19\x51\x5e\x15\xb0\xfe\xfd\xf6\x86\xf8\x91\xf9\xb2\xde\xf8\x61\xe3\xd3\xa7\x4f\x9f\x1a\xd9\x3f\x8c\x65\x38\x59\xca\x89\xf0\x8c\x72\xdc\x9d\xa1\x03\x1e\x1e\x00\xe0\x7f\xae\x4e\x90\xf7\xec\x5b\x1a\xf0\xf6\x1e\x48\xb5\x88\x66\xdc\x34\xe3\xa6\x1d\x2f\x9f\xd8\xb3\x9a\x52\xc2\xac\xd5\x20\x9a\x30\xd7\x20\xcc\x63\x43\x8a\x4e\xf9\xf2\xa1\xec\xe1\x83\xa4\x91\x9b\x82\x2a\x8a\xf7\x0f\x9b\x20\xa1\xe2\x5d\x7e\x3b\xad\x2c\x95\xf7\x77\x9b\x22\x1b\x5a\x66\xba\x39\x8a\x3f\x09\xc1\xdf\x9c\x79\xb3\xe6\xf1\x4e\xe5\xb9\xee\xd6\xfe\x1c\x76\xeb\x68\xbe\xbb\xa5\xb7\xab\x62\xbb\xf6\x67\xdf\xae\xe3\x47\xe2\xa6\xa3\xa7\x4b\x9a\xa1\xd6\xaf\x98\x9e\x95\x16\x74\x4d\x76\xcb\x8e\x18\x3b\x3d\x46\x07\x18\xbb\x62\x6b\x0c\x62\x0d\xd1\x08\xfe\x82\x02\x82\x3d\xd6\x75\xeb\x95\x28\xa6\xf7\xe2\x74\xd8\x30\x10\xc9\x39\x0d\x0a\x07\xc9\x99\x33\x28\x1a\xf9\x0e\xa4\xd8\x1d\xc4\x53\x34\x1c\x4c\x68\x0a\x2b\x32\x13\x77\x7e\x61\xf8\x54\x44\xbe\x84\x61\x7d\x48\x6d\xd5\x78\x96\x3c\x49\xb4\x92\x3e\x65\xd8\xb5\x9c\xd0\x46\x07\x8e\x23\xe1\xbb\xb9\x56\xd8\x54\x23\xaf\x15\x04\xc0\x18\x85\x0e\xc5\xc5\x26\xf2\xbc\x14\x54\x8d\x00\x18\x9e\x2f\xbc\xec\xd3\x97\x10\x00\xc6\xef\x21\x0a\xee\x13\x42\x22\x0e\x91\x2a\xfe\x47\x6a\x2f\xda\x99\xd2\x01\xba\xcb\xa5\x18\x53\x3d\x8a\xa4\x70\xc0\x01\x4a\xfe\x3b\x1a\xd0\xc8\x7e\x2d\xcc\x8c\x95\xa9\x2b\xcb\xfb\x46\x4c\x3d\xf5\x21\x24\xe8\x4a\x74\x94\x61\xec\xf9\x7f\x3f\xaf\xc9\xa3\x25\xee\x98\x18\x05\xfa\xe2\x0a\x36\x5c\xef\xd6\xdc\x1d\xc9\xde\x0c\xea\xc9\x32\x23\xd3\xcc\xc7\xd6\x35\x0a\x92\xc6\x12\x24\xdd\x88\xe8\xa4\xaf\x98\x08\x61\xf9\xdf\xad\x66\xea\xc7\x76\xfa\x47\x6b\x94\xfc\xbd\x9b\xfa\xbb\x95\xfe\xb1\xdd\x4c\x7f\x49\xdd\xb1\xed\xd4\xdf\x2d\xdb\x58\x4b\x1d\x48\x41\x22\x53\xbb\x3e\x76\x94\x74\xc7\xaf\xd2\x1d\xa7\x47\x69\xef\xa4\x7f\xd8\xc9\xdf\xaf\xed\xf4\x7c\xa3\xb9\x64\xc0\xf7\x87\xe7\x26\x4a\xff\x94\x53\x29\xcb\x90\x08\x44\xe8\xb9\x23\x48\x86\x3d\x0f\x06\xa2\x33\xe3\x26\x3e\xe6\x3b\x6b\x5f\xd7\x5e\xae\x99\xa6\xb9\x06\x7d\x2c\x4f\x7f\x07\xdc\xb4\xd6\xae\xb1\x6b\x77\xa4\x1d\xf0\x14\xfa\x6b\x23\x44\x21\x3b\x8c\x9d\x35\x00\xd8\x71\xe8\x00\x91\x81\x71\x10\xc0\x3e\x74\xa1\x29\xac\xab\x92\x72\x9a\x76\x34\x1e\x31\x7d\xec\x78\x34\x29\x90\xcd\xb9\xb8\x14\xf5\x41\xee\x09\x45\xa3\x35\x00\xf8\x61\x21\x6c\x08\x00\xa0\xef\x77\x80\xec\x9d\x17\x58\x43\x18\xd0\x6c\xd1\x10\x05\x98\xc9\x7c\x1d\x70\x85\x1d\x07\x05\xbc\x30\x40\x0e\x82\x24\xea\x9c\x17\xf1\xbf\x92\xa6\xd1\x3a\x72\x53\x6b\xfc\x46\xd8\xe2\x5f\x3e\xac\x01\x60\x74\xbb\xd8\xf5\xc3\x94\x14\x16\xab\x20\xa2\xcb\xe0\xe8\xb2\x7b\x7e\x71\x76\x7a\x7c\xf5\xf3\xf1\xc7\xcb\x64\x93\xe2\xeb\x45\xa5\x3f\xb0\x11\xb1\x02\xcc\x91\x27\x67\xa6\x91\x87\x2f\x75\xdf\xc5\xdf\x7c\x27\x1c\x60\x57\xf0\x44\xbe\xa2\x53\xf1\xfd\x83\x9c\x56\x6a\xd8\xdc\xad\xdf\xed\x06\xe8\xf7\x10\x07\x48\xb1\xa8\x14\xb3\xc1\x40\x94\xcb\x5a\x57\x2c\x8e\x80\xf0\x53\xfe\x43\x82\x5a\xc6\x6e\xa3\xdd\xd8\x56\x9b\x57\xa2\xe1\xb8\x65\x47\x31\x98\x3f\x54\x0e\xe5\x0f\x4b\x06\x6a\x36\x9a\xd5\x03\xa9\xe0\x8a\x4b\x21\xea\x16\x61\x39\xe5\xb8\xca\x05\x72\x83\x5b\x61\xb0\xab\x4c\x69\xc9\x30\xf1\x66\x42\xd7\xf5\x28\x8c\x6e\xa1\xb1\x84\xb7\x17\x62\x87\x9e\xb8\x39\xea\x91\xa5\xae\xa6\x09\xe4\x76\x02\xd3\x4c\x13\x00\xe4\xc2\x9e\x53\x4c\x32\xaf\x4e\x3d\x6f\x60\xcb\x73\xb9\x86\x8e\xf5\x19\x0c\x7a\x70\xbd\xb9\x09\xda\xad\xd6\x26\x68\xef\xee\x6e\x82\xd6\x86\x82\x13\x35\x0e\x92\xe5\x80\x3f\x83\x03\x07\x05\x94\xa8\x88\x53\x7c\x58\x8d\x12\x32\x84\x6c\x4c\xe5\x6c\x63\xee\xc8\x18\xb8\x88\x9e\xd8\x29\xa6\x43\xe0\xd8\x55\xcc\xcb\x73\xa0\x18\x38\x53\x25\xab\x84\x4a\x19\x21\x6b\xd9\x92\x8f\x90\xef\x78\xf7\xc8\x06\xf2\x52\x5d\x82\x25\xb9\x59\x99\x8a\xd2\x28\x77\xae\xd2\x41\x06\xc7\x6c\xc5\xee\xa4\x5b\xb1\xad\x9f\x4d\x69\x55\xee\xc4\x31\x06\x19\x97\xd2\x65\x37\xb5\xfd\xc0\xb2\x31\x7a\x2e\x72\xe9\x9b\x4f\x06\x67\x53\xe2\x1c\xd7\x14\x0e\x16\xee\xeb\xfd\xf0\x00\x28\x1c\xac\x4c\xec\xc0\x73\x06\x80\xf8\x22\xd5\xba\x18\x6d\xdc\x7c\x9e\xc6\xcd\x12\x56\xe2\x02\x09\x3a\x06\x3e\x12\x38\x40\x8b\xe7\x23\xf6\x26\xe1\x23\xda\xfb\x9a\x8f\x58\x58\x34\x9e\x56\x49\xc2\x84\x5d\xcd\x46\x68\x36\x42\xc5\x46\xf8\x81\xc7\xe2\xf8\x77\x6f\x70\x40\x43\xe8\x74\x47\x68\xe4\x05\xf7\xdd\xde\x3d\x45\xe4\xe1\x37\xaf\x97\xe2\x26\x16\xeb\x03\x5f\x6d\x1e\xfa\x45\x4c\x0f\x9c\xf2\xe9\x95\x30\x19\x27\xd9\x72\x06\x1f\xd6\x71\x6d\x13\x5e\x04\x8b\x00\x11\x6c\x23\x97\xce\x19\x18\x13\x2d\xf8\x42\xce\xa1\x7a\xc5\x3f\xcf\xb8\xe2\x81\xc7\xd6\x48\x28\xa4\xa4\x3b\x44\xd0\xef\x92\x7b\x32\x9f\xe5\x2a\x25\xfd\xc9\xc1\xc0\x26\x05\xc8\xfd\x1c\x82\x31\x15\x96\x0a\x1d\xc7\xb3\x56\x6f\xb1\x7c\x5a\xb3\x9b\xa3\xd3\xcb\x9d\xe3\x4a\x27\x5c\xd1\x41\xc5\x62\xde\xcd\x1b\x77\xb1\x1b\x12\x34\xd7\x0d\x9d\xf9\xda\xfa\x99\xed\x28\x76\xcd\x90\xa0\x52\x93\xf5\xfc\xa0\xc0\xc9\xc4\x3c\xc1\x30\xe1\x6a\x2f\xd9\xf8\xd5\xcb\xfd\x69\xc6\xe5\x32\xa9\x97\x31\xbd\x10\xbb\x3c\xd3\x2b\xbf\x9f\x43\xc6\xe5\xca\x15\x27\x1f\x99\x98\xc4\x82\xb0\xda\x98\x58\xde\x0d\x0a\xee\xbf\x08\xdb\x81\x1f\x78\x77\xf7\x9f\x8c\x4d\xe0\x7b\x76\x5c\x49\x7e\xe2\xea\xfd\xc6\x0f\x4c\x74\x7e\x34\x9c\xb9\x62\xac\x11\x58\xbf\xde\x23\x1b\xa5\xf1\xc8\x66\x03\xe2\x52\x00\xf8\x68\xf0\x7b\x78\x00\xd9\x35\x70\x27\x92\x0a\x70\xbe\x1d\x07\xce\x25\x28\x2c\x72\x54\x5e\x2b\x2a\xa6\x52\x54\x70\x0c\xd6\x8a\x0a\xed\x85\xbd\xbc\x78\x67\x65\x02\xb7\xd6\xdb\x6b\x81\xbb\x3a\x29\x70\x42\xa4\x2c\x3f\x94\x24\x38\xf2\xf9\x14\x6f\xa2\xe6\x45\x8a\x85\x97\xe8\x0a\x33\x34\x07\x73\xe0\x0a\x1f\x05\xa6\x51\x98\x80\x54\x97\x1b\xcf\x88\xf1\x19\x03\x76\xe1\xc7\x1c\x69\x4d\x18\xc4\xb3\xb0\xce\x4a\x1f\x1c\x62\x8f\x06\x1c\x3e\x0b\xb0\x4e\x90\xd3\x37\x03\xe4\x7b\x01\x45\xf6\xd4\xfc\xf5\x12\x18\xc2\xc3\xf3\x8f\x9a\x1b\xd4\x66\x2b\x1d\x98\xe8\x49\x45\xf2\x2b\xe3\x06\xf5\x4b\x79\xcd\x0d\x56\x9a\x1c\x3c\x1f\xb9\xdd\xbe\xbd\x40\x4d\xf4\x78\x53\x4c\xce\xbd\x7b\x72\x1a\x7b\xe6\x23\x17\xbc\x3b\x22\x60\x9d\xcf\x7f\x0e\x4f\xe0\x12\x7e\xa6\x4f\x56\x43\x4b\x35\x07\x9e\x6c\x85\xd5\x50\x47\x98\x5c\x6b\xb6\x63\x61\x4a\x28\xc3\x58\x16\x31\x2f\x39\x57\x36\xb2\xf0\x48\x3c\x8f\x28\xf4\x93\xac\xc0\xf5\x5c\x34\xe9\x02\x9a\xed\x9d\xec\x64\xb5\x7a\x6a\x25\x42\x25\x96\x71\x24\x8f\x1e\x5a\x38\x5f\xac\x59\x92\x15\x61\x49\x06\x5e\x77\xe0\x05\x5e\x48\x19\x64\x97\x6c\x41\xfc\x10\x8e\x7a\x28\x00\x5e\x1f\xfc\x14\x4f\x61\x5a\x9d\xd1\x12\xc8\xa5\x62\x92\x9a\x68\xce\x5b\x56\x7f\x74\xa2\xa9\xc3\x1e\xe6\xfd\x4b\xef\x8e\x2e\x17\xef\x54\xda\x2a\x89\x6e\xd3\xd6\xef\x50\xea\xf3\x0b\xaf\x72\x50\xdf\x1b\x03\xf4\x76\x19\xbb\xa0\xb3\x39\x6a\x76\xa1\xfc\x1d\x0a\x37\x04\x20\xf7\xc6\xbb\xef\x5a\x4e\x48\x28\x0a\xba\xa1\x6f\x43\x8a\xba\x24\xb4\x98\x82\xe3\x21\x2a\xe6\x92\xf8\x27\xe3\xce\x26\xe6\x20\xf0\xad\x65\x58\xa7\xaa\x5f\xae\xfc\xcf\xd1\x25\xf8\xe9\xe2\xfc\x10\x5c\x8a\x99\x22\xb2\x1a\xf9\x91\x3f\x72\xf8\x69\xc6\x62\x46\xc6\xc2\xf3\x9f\x82\x43\xc8\x23\xcd\x52\xcb\xdb\xb3\xd2\xcf\xbd\xba\xf4\xb3\xad\xe9\xa7\xa6\x9f\x2a\xfa\x19\x78\xa1\x6b\xaf\xc7\x1e\x0c\x4a\x22\x0a\x29\x0b\x9a\x43\xc7\x12\x51\x60\x82\xea\x8e\xea\x52\xe3\x85\x87\xfb\x8a\xa9\xee\x0a\x4b\xf7\xef\x20\x76\xc2\x40\x93\xe0\x6f\x83\x04\x6b\x9f\xcc\x27\x4b\x84\x5b\xaf\xea\x52\xe1\x96\xa6\xc2\x9a\x0a\x97\x49\xb1\x79\x8a\x49\x68\x80\xe0\xa8\x6b\xdd\xf1\x4c\xed\x37\xa8\x82\x6a\x2e\x9c\x5e\x8a\x80\x06\xeb\x11\xd9\x5c\x65\xcf\xb5\x03\x0e\x2c\x16\x08\xcc\x45\xb9\x84\xf2\x9a\x80\x6a\x47\xb6\xc5\xcf\x53\x53\xd0\x02\x05\xdd\x9b\x54\x0d\x5c\x96\x62\x47\xc7\x11\xd0\x04\x54\xed\xc8\xc6\x08\x14\xcf\xa6\xe7\x60\x8b\x76\xb1\xdb\x63\x72\x6d\xd7\xc1\x84\x22\x17\x05\x73\xb7\x23\x8f\xcb\xd6\xce\x87\x07\xef\xe5\xf0\xa4\xd4\xe7\xaa\x7e\x70\x80\xec\x02\xbd\x90\x66\x57\xd8\x1d\x52\xea\x77\x99\x07\x5a\x57\x22\x7c\x97\x5a\xfe\x92\xd7\x7d\x16\xd2\xdc\xc2\xc1\x3a\x9b\x17\x60\xf3\x02\x72\x5e\x80\x5a\xfe\x1c\x7c\xf3\xc6\x02\x84\x5a\x2b\x09\x0f\x6a\xd5\x07\xc7\xe1\x22\xc1\xc1\x36\x66\x15\xe1\xc1\xe6\x55\x27\x20\xfe\x32\x5e\x41\x48\x70\x6a\x16\x52\xb3\x90\x9a\x85\x5c\x69\x16\x72\xaf\x2e\x0b\xa9\xdf\x42\x68\x16\xb2\x82\x82\xb2\x40\x54\x8c\xd4\xdd\x60\x6b\x01\xee\x87\xad\x5a\x51\xa6\x2e\xe5\xf0\xf3\x62\x92\x1e\x69\x39\x63\x96\xf1\x76\xda\xe4\x36\x4c\x59\x26\x16\x76\x67\x93\xae\x65\xb3\x88\x59\xbf\x21\x8b\xe6\x96\x27\x5e\xc7\xba\x9e\x8d\x78\x52\x9a\x8d\x4d\xf0\xc9\x60\xbf\xa2\x1c\x34\x9f\x8c\x6c\x49\xe3\x87\xff\x34\x7e\xf8\x0f\xcb\x37\xf3\x1f\xf6\x36\x63\x63\x71\x61\x98\x5a\x63\x42\x6f\xb1\xc5\x20\x1b\x1c\x1e\x5d\xca\x78\xf6\x44\xe4\xa6\x61\x73\x05\x5f\xbf\x46\xb9\x68\xca\xdf\x72\x4c\xcc\x37\x32\x40\xa2\x32\x40\x3e\x3a\x20\x8e\x13\x40\xcc\x1e\x99\x2a\x5e\xf0\x92\x4f\x83\x54\x48\xb2\xe5\xb8\xb6\xbc\x2d\xc7\xa7\x11\x1a\xb3\x18\xf1\x02\x3a\xde\xc2\xdb\x00\x53\xd4\x65\x33\xf6\x42\x3a\x97\xf7\xcf\x13\x2e\xf2\x9f\x6c\x02\xe0\x4a\x4c\x80\x94\x46\xde\x9a\x76\x7d\x7e\x48\x86\x8f\xb9\xbc\xf3\x90\x0c\xc7\xad\xee\xa7\x99\x56\x87\xc8\x63\xad\x0b\x11\xb0\xce\xc2\x28\xdf\xfb\xa8\x22\xc5\xd5\xcf\xb3\xed\x1d\xcf\xa0\xf5\x68\x4b\x04\xc7\x7c\xf8\x3a\xeb\x3c\x59\xae\xa0\x79\x70\x74\x09\x4e\x3d\x17\x53\x2f\xc0\xee\x40\x4b\x9b\x5a\xda\xd4\xd2\xe6\x2a\x4b\x9b\xad\x57\x75\xc5\xcd\x7d\x2d\x6e\x6a\x71\xf3\xe9\xca\x34\x93\xe7\x61\x90\x42\x0a\x00\xeb\xb1\x94\xb2\x22\xd9\xe7\x55\x82\x95\x26\xb5\x9a\xd4\x6a\x52\xbb\x4a\x4f\xca\xc7\xf9\x06\x6c\xef\xaa\x29\xed\xae\x76\x71\xd7\x94\x76\x62\x4a\x8b\x9e\x01\xa5\x5d\x7f\x78\x40\x41\xb0\x7a\x64\xf6\x58\x93\x59\x4d\x66\x35\x99\x7d\x1a\x64\x76\xaf\x2e\x99\xdd\xd1\x64\x56\x93\xd9\x49\xc9\xac\xa3\xc9\xec\xc2\xc8\xec\x7b\x4d\x66\x35\x99\xd5\x64\xf6\x69\x90\xd9\xd6\xab\xba\x74\x76\x5b\xd3\x59\x4d\x67\x27\xa5\xb3\x81\xa6\xb3\x0b\xa3\xb3\x17\x9a\xce\x6a\x3a\xfb\x6d\xd1\xd9\xa4\x9b\xe8\x3d\xc1\x97\xbd\xe6\x97\x2f\x36\xea\xc3\xd0\xa1\x26\xf3\xd4\x37\x7b\xd0\xba\x46\xae\xdd\xb8\x0e\x7b\xc8\x24\xf7\x84\xa2\x51\x83\xdc\x58\x0d\xf9\x5a\xb5\xe1\x78\x16\xa7\x1b\x46\x30\xe8\xc1\xf5\xf6\xee\xee\x26\x48\xfe\xd5\x6c\xec\xbf\xde\x28\xe2\xfa\x53\xd7\x51\xef\xb4\x4b\x88\xba\x7e\x00\xbe\xb2\x44\x5d\x7d\x17\xf0\x03\x61\x74\x66\x3f\x00\x99\x53\x1e\x9d\xdf\x56\xc5\x19\x7f\xac\x67\xe8\x59\xdd\xb8\x08\xdc\x5e\xf4\x1d\x96\xa9\x67\xc4\x12\x97\xc1\x1d\xc8\xa1\x56\x26\xc5\x3f\x53\x6e\x9f\x44\xc0\xd1\xfc\x80\xe6\x07\x9e\x07\x3f\xb0\x26\xbb\x65\xe7\x8a\x1d\x19\xb6\xea\x5d\x81\xdf\x06\xb1\x86\x68\x04\x7f\x41\x01\xc1\x9e\x1b\xcb\xb2\x06\xa1\xf7\xe2\x48\xd8\x30\x10\xd1\xfb\x0d\x0a\x07\xc9\x41\x33\x28\x1a\xf9\x0e\xa4\xcc\xb1\x31\x9a\xa2\xe1\x60\x42\x23\xa4\xf9\x2a\x6a\xe1\x11\x4a\xbe\xf7\xc5\xa1\x34\x5c\xef\xd6\xdc\x1d\x49\x90\x1b\xd4\x93\x65\x46\xa6\x99\x8f\xad\x6b\x14\x24\x8d\xe5\xdc\xbb\xd1\x2d\x93\xc6\xc1\x68\x35\xfc\xef\x56\x33\xf5\x63\x3b\xfd\xa3\x35\x4a\xfe\xde\x4d\xfd\xdd\x4a\xff\xd8\x6e\xa6\xbf\xa4\x0e\x61\x3b\xf5\x77\xcb\x16\x67\xf7\x73\xb4\x06\x76\x27\x7a\xbe\x88\x4c\x91\x9e\x57\xd9\x28\xe9\x8e\x5f\xa5\x3b\x4e\x8f\xd2\xde\x49\xff\xb0\x93\xbf\x5f\xdb\xe9\xf9\x46\x73\xc9\x80\xef\x0f\x8f\xd3\x0c\xa3\x17\x78\xb7\x04\x05\x72\x0b\xa3\x8b\xee\x84\x50\xec\x01\x11\x00\xe4\x08\x92\x61\xcf\x83\x81\xe8\xd3\xb8\x89\x51\x61\x67\xed\xeb\xda\xcb\x35\xd3\x34\xd7\x5e\x80\x4b\xce\x74\x75\x00\x4f\x76\xb1\x65\x0d\x61\x40\xc9\xd6\x20\x80\x7d\xe8\xc2\x2d\x89\x0b\x88\x6c\x59\x5c\x94\x1a\x41\xbf\x71\x0f\x47\xce\x1a\xf4\xb1\xc4\xac\x0e\xb8\x69\xad\x5d\x63\xd7\xee\x48\x71\xeb\x14\xfa\x6b\x23\x44\x21\xe3\xe8\x3a\x6b\x00\xb0\xeb\x49\x76\x6f\xca\x7e\x65\x29\xa7\x99\xd1\x27\x41\x8a\xd7\x00\xe0\x47\x94\xb0\x96\x00\x40\xdf\xef\x80\xa4\x11\x00\x7c\x7e\xd9\xa2\x21\x0a\x30\x63\x3f\x3a\xe0\x0a\x3b\x0e\x0a\x78\x61\x80\x1c\x04\x49\xd4\x39\x2f\xe2\x7f\x25\x4d\xa3\xe9\x25\x8c\x27\xe1\x4b\xeb\x80\x2f\x72\xe8\x64\x85\x82\xf0\xa7\x6a\x8a\xd9\x99\x00\xf2\x00\x69\x1d\xc0\xd3\x87\xf0\x32\x00\x90\x8d\x29\xec\x39\x28\x73\x0d\x60\x72\x24\x98\x90\x4c\xe9\x6f\xc4\x73\x8f\xe4\x3c\xc4\x3f\x6c\x83\x4f\xe4\x39\xe8\x80\x5d\x22\x3f\x08\x18\x26\x5c\xb1\x2c\xf6\x82\xc1\x89\x1d\x4d\x0f\x70\x27\x69\x3e\x97\x6c\xad\x30\x70\x3a\xfc\x99\x6e\x67\x6b\x2b\xf9\xd8\xd9\x6f\xee\x37\xd7\x38\x00\x24\x96\xf8\x81\x77\x83\x6d\x14\x8c\x83\x43\x5c\x2f\x82\x82\x8d\x09\x5b\xf0\x11\x72\x10\xe5\xf5\xd2\x6c\x68\xdf\x73\x6c\x14\xa4\x37\x22\x83\x12\xd1\x4a\xc4\x09\x4b\x00\xe1\x43\x3a\xec\x80\xad\x1b\x18\x6c\x39\xb8\x17\x23\x64\x3c\x59\xb2\x95\x69\xae\x02\x44\x1f\x3b\x68\xad\x0a\xcb\xaf\x31\x74\xf0\x1c\x71\x9c\xf7\x37\x19\x6e\x47\x4d\x62\xcc\x4e\x0a\xea\xe0\x75\x34\xbe\x98\x79\x66\xdf\x78\x85\x6e\xf9\x54\x00\x60\xef\xcc\x50\x10\x81\xdc\xf7\xd8\xf0\xed\x66\xb3\x29\xa0\x88\xee\x28\x0a\x5c\xe8\xc4\xcf\xd1\xa2\x8a\xbf\x41\x34\x48\x9a\x09\xf4\x92\x3f\xe4\x2e\xe5\xbe\x55\x6d\x41\x82\x8f\x73\xdc\x87\xcc\x09\x98\x60\x33\x32\xed\xe2\x1d\xc9\x95\x4e\xb2\x2d\x49\xd3\xc6\x3d\xdf\x19\x93\xd7\x1e\x38\x5e\x0f\xc6\x40\x23\x56\x00\x7d\x14\x53\xbf\x0e\x68\xc9\x63\x2f\x3f\x08\x68\x90\xce\x9a\x3c\x6d\xbf\x79\xbd\xae\x58\xe7\x4b\xb1\xa0\x11\x22\xc3\x97\xb2\x33\x26\xd5\x04\x2e\xa2\x88\x74\x89\x9d\x34\x15\x1f\x4d\x10\x78\x0e\xea\x00\x14\xbd\x68\x92\xe5\x29\x20\x91\x64\xef\x64\x69\xba\xc0\xcc\x82\x50\x7e\x09\x90\xd0\x71\x16\x46\x13\x97\x65\x57\xc2\x19\xfc\xda\xed\xb2\xbd\xea\xa6\xe7\x28\x70\x8b\xaf\x67\x13\x14\xbf\x47\x33\xed\x32\xec\xe4\xb5\x3e\xc7\xd3\x81\x96\xb8\x6c\xae\x11\xf2\xe3\xc2\x00\x0d\xd0\x5d\xb4\xd3\x14\x39\x68\x84\x68\x70\xff\x63\x6a\x0f\x79\xd5\x17\xe0\x92\x03\x57\x1e\x1c\xd0\xf7\x02\xc0\x03\x7a\x01\x9e\x1b\xbf\x08\x69\xfe\xd1\xe4\x1f\x23\x50\xb3\x9e\xb1\x45\xba\xf2\xa6\xe2\xdf\xb6\x0a\x97\x6f\xad\x0d\xf1\x3d\x7b\x0e\xc0\x64\xa9\xab\x92\xf4\x51\x13\x42\xec\x65\xe3\x07\x53\x2c\x92\x2d\xe1\x65\xc5\xb0\xd0\xb6\x03\x44\x48\xb7\xbb\x09\xd4\x73\x80\xae\xeb\x51\xc8\x86\xea\x26\xe0\xe8\x62\x8f\x4f\xa9\x38\x1b\xa9\x1a\xcf\x4f\x68\xfd\xd7\xff\xeb\x7c\xfe\xaf\x8d\xf5\xbf\x75\x3a\x9f\xec\xff\xda\xf8\xdb\x8f\xeb\xec\x3f\xa9\x5a\xbc\xd5\x08\xb9\xb4\x03\xbe\x6f\x75\x5a\xbb\x8c\x9c\x45\x5f\x85\x08\x2d\x66\xdd\x01\xa9\x39\xc7\xeb\x8a\x46\xe7\x55\x46\xb0\x00\x0f\xf5\xd2\xc4\xde\xac\x37\xfe\x6b\xa3\x02\x40\xf9\x86\xf1\xe1\x1a\xbf\xf6\xec\xbc\xe3\x86\x13\x22\x81\x7a\xd7\xab\xc7\x8a\xda\xad\x65\xb0\xbb\x5b\x82\x8e\x2f\xc0\xf1\x9d\xe5\x84\x36\x02\xc4\x1b\x21\xe0\xf5\x01\x1d\x22\x79\x82\xe4\xb9\x00\x74\x08\x29\x18\xc2\x1b\x04\x46\x90\x10\x7c\x83\x80\x05\x03\x1b\xbb\xd0\xc1\xf4\x3e\xee\xe7\x6a\x88\x09\x60\xe2\x05\x18\xc1\x7b\xe0\x22\x64\x03\xea\x81\x1e\x02\x7e\x10\xba\xc8\x06\xfd\x30\xa0\x43\x14\x80\x91\x77\x83\x5d\x7e\x50\x6f\x61\x60\x6f\x02\x48\x00\x76\x99\xa8\x87\xec\xb8\xaf\xde\x3d\xf0\x51\xc0\x0a\x99\x98\x0f\xa0\x6b\x03\x62\x41\x07\xf6\x30\x1b\x12\x50\x44\x98\x54\xd3\x28\x85\x26\x48\x87\xec\x03\x9f\x0b\x47\x64\x3d\xd6\x2a\xc9\x80\x45\x5f\x52\xf8\xcd\xcf\xff\x46\xe3\x87\x97\x05\xc0\xdb\x81\xe7\x97\x8f\xc9\x02\xbd\xf8\x01\xea\xe3\xbb\x65\x8d\x18\xc7\x98\x91\xa7\x42\x35\x6e\xe3\xbf\x36\x26\xed\x96\x47\x34\xb2\x3c\xd7\xed\x8e\xa0\x0b\x07\x28\x48\x82\xd9\x94\xaf\x6f\x2e\xe3\xcc\xb5\xfb\x2e\xdf\xfd\x6e\x57\xd1\x9d\x08\xf9\x48\x1d\xd2\xf8\x61\xfe\xbd\x5a\x7e\xd7\xf6\x6e\x5d\x11\x46\x72\x01\x03\x70\xb0\xad\x73\x9c\xf9\x02\xed\x11\x76\x37\x16\x30\x48\x74\x80\xd6\x9d\xde\x97\x80\x51\xde\x2f\x3d\xcc\x91\x57\xf0\x92\x5f\x46\xf0\xee\x8b\x17\xe0\x01\xbb\x05\xca\xc7\x2f\x63\x74\x7c\xcf\xc1\xd6\xfd\xe3\xb0\x3a\xab\xce\xeb\x08\xd8\xfc\xc8\x55\xdc\xa3\xf8\x69\x6a\x29\x28\x63\xd6\x48\x33\x8e\x95\x8c\xe3\x78\x78\x72\x15\xbb\x86\xa2\x0a\x25\x19\x64\x6a\x40\x70\x00\x1d\x07\x69\x44\x54\x81\x50\x80\x46\x0d\xc3\x17\x80\x14\x64\x98\x83\xf3\x13\x29\xd6\x2b\x64\x98\x64\x56\x26\xf4\xb1\xac\xb6\x2c\xb0\x4b\x1b\x5c\x2c\xf3\x0e\x11\x9b\x13\x5b\x58\xd4\x19\x75\x88\x1c\x37\x69\x69\xc1\x2e\xd3\xe2\x48\x45\x50\x10\xba\x5b\x04\x59\x01\x62\xba\x9b\x78\xbe\x0d\xec\x6d\xc9\x5d\x80\x96\xe5\x85\x2e\xdd\xb2\x60\xc3\x0a\xa2\xb1\x7a\x08\x06\x28\xe8\x52\xef\x1a\xb9\x53\xf5\xc6\x5b\xae\x2e\xa6\x24\x5d\xfc\x28\xc0\x59\x8a\x1d\xcc\x65\x87\x80\x75\xd6\xc0\x41\x74\xa3\x12\x43\x78\xdd\x97\x4f\x7d\xbb\x6a\x61\x35\x5b\xea\xb8\xed\x9d\x5c\x5c\x64\xbd\xaa\xe4\xc5\x71\xa2\x69\x4e\xb4\x4d\x2d\x55\x1e\x21\x66\xad\xee\xec\xec\x6c\x4f\x22\x81\xb2\xb9\x64\x31\x2a\x92\xb2\x93\xa9\x15\xa5\xe6\xb4\x86\xa3\x6c\x7e\x5b\xd0\xc7\x5b\x37\xad\x2d\x8e\x2f\x5b\xdf\x3f\xb4\xbe\x6e\x71\x65\xf8\x96\x6c\x5c\xae\x6f\xf9\x87\x40\x43\x60\x1d\xd8\x37\x98\x78\x81\x90\xcb\x5e\xac\xa5\xe4\x42\x4c\x40\x80\x7e\x0f\x71\x80\xec\xb8\x89\x58\x12\x68\x35\x5e\x37\xb6\xb9\x88\xe7\x40\x8a\x82\x4d\x70\x3b\x44\x01\x8a\x3b\x8b\xa4\x50\xd9\xdb\x3a\x1d\x7a\x04\x81\x5b\xfe\x6f\x7e\x3b\x81\x1e\x1a\x60\x17\xdc\x62\x3a\x04\x2f\x13\xad\xc9\xcb\x0d\x21\xb2\xf6\x10\x72\x41\x80\x46\xde\x0d\x1b\x3a\xf0\x46\x80\x0e\x91\xec\x2c\x9a\xb8\x1c\x22\xbe\x15\x1b\x40\xcc\xfa\x37\xaf\x27\x0f\x1f\x61\xad\x92\x39\x45\x15\x01\xf5\x64\x57\x8c\x47\xc6\xe8\x06\x01\x31\x3f\xd9\x63\x16\x12\x27\x6e\x7e\xdd\x4d\x93\xfd\xbb\xbd\xc9\x7a\x4f\x5a\x01\x18\x20\xe0\xb9\xce\x3d\x40\x77\xbe\x47\x90\x0d\x3c\x37\x33\xbe\xec\xef\xe7\xab\xab\xf3\x78\x2a\x3f\x82\x90\x20\x60\x8c\xd9\xd2\xce\x4e\x6b\x7f\x27\xbb\xaf\x86\xec\x0d\xbb\x42\xd0\xb7\x20\x41\x60\x9d\xed\x07\x72\x49\x98\xda\x89\x97\x44\x8c\x28\xe8\x0d\x18\x42\xe2\xbe\xa4\x02\xbe\xd2\x6e\x60\xf3\x5d\x90\xfd\xb1\x09\x9b\xa6\x05\x45\x63\x93\xdd\x85\x6f\x9a\x31\xc8\xfb\x0e\x1c\x6c\x28\x10\x85\x81\x1c\x13\xe0\x7a\x14\xb8\x88\x99\x64\x60\x70\x2f\xe4\xff\xa1\x17\x3a\x36\xe8\xa1\x78\x33\x71\x0e\x9c\xaf\x58\x3d\xd9\x17\x82\x81\x83\x51\x00\xa4\xa5\x8c\x6c\x02\x2f\x00\x98\x82\x5b\xec\x38\xc0\x82\x0c\x54\x6c\x7e\x11\xc0\x85\xa2\x42\x6c\xb5\x0d\xe8\x2d\xb6\x50\xa3\xf2\x52\x8d\x96\xa5\xef\x55\x7d\xaf\xce\x78\xaf\x6e\x45\xb8\x54\x4e\xee\xe5\xfe\x25\x5c\x5b\x35\x72\xca\xea\x66\x5c\x7d\x26\xd6\x70\x0e\x8c\x52\xa9\x1e\x59\x2c\xb5\x1e\x77\x94\xb2\xb6\xce\x69\x6c\x76\x64\x27\xd5\xae\x76\x65\xbb\x6e\x37\x3f\x3f\x1e\x70\x9b\xfc\x6d\x63\x9e\x73\x64\x78\x35\xf9\x0c\x4b\xb1\x32\x8f\xd0\x13\x1b\x05\xc6\x4f\xb8\x96\x61\xa0\xce\x09\x9f\xc6\x74\xf0\x7d\x7b\x86\xeb\x28\x5a\xdb\x72\x2d\x03\xaa\x3e\xa6\x14\x45\x66\x1c\x7a\xad\xf2\x4e\xf1\x3d\x9b\xbc\x9c\xd4\x12\xa6\xbe\x40\x38\x2b\xd4\x07\x7d\x1c\x10\x0a\xe8\xad\x27\xcd\xb7\x00\x06\x08\xf8\x01\x22\xc8\xa5\x9b\xac\x79\x8a\xe4\x47\x94\x19\xf4\xee\x39\xdd\x96\xa2\x3f\xb2\xc2\x00\xb1\x09\x37\x26\xb4\xab\xcc\xfb\x42\x7a\x01\xfe\x81\x90\x2f\xe1\x0b\x30\x37\xa2\x04\xe8\x25\x01\xae\x07\x08\xb6\x91\x05\x03\xce\x80\xf4\xd3\x86\x64\x46\x98\xf9\x5d\x02\x30\x61\x9c\x9e\x83\x2d\x4c\x9d\x7b\x40\x10\x05\xd4\x03\x06\xbb\x4f\x8c\xe9\x17\x26\xc7\xed\x0a\xf7\x01\xb6\x36\x0a\x69\x48\x26\xb6\xf5\x95\xdd\x93\x2a\x90\xac\xaf\xff\xd8\xf8\x61\xe3\xcb\x7a\xe3\x07\x2e\xc1\x6e\x6c\x4c\x3f\x7d\x31\xed\x11\x75\xc8\xe7\x2a\xdd\x76\x32\x34\xdb\x8e\x8d\x79\xe1\xc1\xaa\x5d\xbc\x8f\x66\x8d\xfd\xbe\x5d\xba\x5a\x6d\x8a\x9d\xcc\x14\x5b\x75\xb5\x9a\xe9\x1b\x6d\x7a\xa1\x02\x51\x4b\x78\x53\x99\x16\x62\x4e\x39\x81\xe7\x51\xfe\x67\xc3\x47\xa3\xa4\x3e\x0a\x68\x59\x0b\xf6\x6f\xd3\x1a\x42\xec\x66\x9a\x5c\xa3\xfb\xb2\x16\xd7\xe8\x3e\x53\x15\xbb\x62\x15\x5d\x72\x8d\xfd\xee\x0d\x0a\x70\xff\x5e\x5c\x96\x00\xbc\x48\xdd\x7f\xc0\xf6\x90\x10\xf4\x48\xe8\x33\x14\x06\xa2\x1d\xdb\xa1\x94\x71\x77\x46\x62\xf3\xe8\x84\x21\xba\xfe\xc5\xed\x0b\x92\x01\x00\x26\x00\xda\x36\xb2\x19\x51\x8b\x6a\x61\x97\x3d\x7a\xf3\x82\x58\x94\x65\x5d\x88\xab\xf0\xd6\x0b\xae\x1d\x0f\xda\xfc\x4e\xec\x46\xa6\x70\x0b\xba\x9c\x40\xfa\xc8\xc2\x7d\x6c\x41\xc7\xb9\x07\x1c\x25\xb9\xd2\x00\x32\xc8\x00\xea\x01\xe6\xd0\x6e\x41\x8a\x00\xa6\x04\x44\x6d\xa9\x07\x02\x64\x21\xcc\x0c\xfb\xd4\x21\x80\x06\xb0\xdf\xc7\x56\xe3\x31\x68\x4e\xd5\x75\xaf\xa6\x34\xbf\xfe\xdf\x8f\x9f\xff\x6b\xe3\x47\xfe\xdf\x1f\x36\x36\xbe\xac\xcb\xbf\x7e\x14\x94\x60\x63\x7e\x3b\xaf\xa6\x7f\x4a\x22\xc4\xa9\xde\x73\x20\x42\x9f\x01\x78\x01\xce\x98\x0e\x8a\x41\x1f\xc8\x72\xa1\x21\xc2\x04\x0c\x3d\x42\x3b\xec\xd0\x2a\x37\x4b\xa0\xad\xc7\x98\xa0\x5b\x4c\x10\x80\x2e\x40\x77\x34\x80\x72\xae\x52\x57\xc7\x44\xf4\x97\xc9\x29\x60\x82\x2e\xbf\xe9\xe4\xbd\x57\x42\xb8\x3a\x69\x7a\xa5\xe9\xe8\xb7\x45\x47\x2b\xfc\x4f\x39\xf1\xc0\xf4\x7e\xee\x9e\xee\x51\xc7\xa6\x15\x12\xea\x8d\xcc\x00\x89\x85\x4e\xe8\x92\x1a\x75\x93\x76\x48\xcd\x94\x4d\xe8\xfd\x6e\x61\x0a\x6d\xe4\x24\x8e\xc3\xb9\xd9\x45\x2e\xc4\xa6\xd4\x2a\x1d\x84\x74\x88\x5c\x8a\x2d\x41\x7c\x84\x97\x01\xa0\x1e\x40\x2e\x53\xdd\x02\x1f\x05\x23\x2c\xbc\xbb\xd8\xf3\x24\x7e\x1c\xa1\xe3\x44\xba\x27\x02\xd6\x13\x37\x30\x79\xcd\x6f\x08\x9d\x31\x02\xcc\x6f\xb5\x51\xf0\x31\x37\x60\x66\xc8\x06\x9f\x38\x93\x7a\x6e\x5a\xd0\xf1\x87\xb0\x25\x84\x1b\xb1\x0f\xc6\x29\x22\xc3\x73\x3e\x29\x51\x9c\xde\x0e\x10\x7b\x9a\x1b\x52\xc5\x67\xc8\xd2\x34\x98\xd5\xa0\x2e\x03\x77\x29\xc8\x4b\xc0\xce\x28\x6c\x34\x8e\x8f\x62\x9f\x79\x00\x4c\x4e\x3c\x3b\x71\x5b\xc0\xe1\xd7\x01\xe7\xc7\x17\xa7\x27\x97\x97\x27\xbf\x1c\xaf\x01\x10\x84\x6e\x83\x0c\x93\xed\xf8\x6e\xab\x87\xdd\x2d\x32\x14\x9c\x21\x41\x14\x98\x77\xe2\x6f\xdc\x07\xbf\x02\xe3\xfb\x17\x06\x30\x5d\x04\x8c\x96\x01\x3e\xff\xc8\x80\xec\xc6\x03\x20\x6b\xe8\x01\x43\x88\xcf\x30\x18\x84\xec\x8e\x49\xc9\xca\xec\xde\x07\xd4\x93\x08\x01\x22\x84\x00\x0c\x1f\x8c\xa4\x93\x3b\x4c\xa5\x97\x7d\x1f\x8b\x91\x59\xc3\x2b\xef\x42\xd6\xff\xd7\xc1\xe9\xfb\x37\x4c\x5b\x29\x3e\xb2\x53\x6c\x51\x07\xb0\xeb\xfb\x06\x3a\xd8\xe6\xef\x8a\x6e\x51\x6f\xe8\x79\xd7\xe2\xa0\x85\x41\xc4\xd7\x24\x06\x6f\xd0\xfe\xeb\x96\x8d\x6e\xb6\xe2\x07\x4f\x72\x79\x7f\x33\x80\x89\x7e\x07\xcd\x92\xc5\x65\xba\x18\x33\x5c\x9f\x27\x36\x33\xc1\x2d\xc4\x54\x3a\x16\x66\xa7\x60\x23\xdf\xf1\xee\x39\x94\x84\x9e\x3f\x40\xd0\xbe\x4f\x40\x71\x3b\xc4\x0e\xe2\x4c\xdb\x8f\xc0\xf6\xe2\xe2\xf4\xaa\x4d\x37\x73\xbe\x39\x14\x52\xdd\x56\xae\x38\xfa\x67\xdc\xca\xa3\x7f\x7a\x01\x82\xd7\x99\xd2\x3e\xce\xfc\x24\x0e\x42\x7e\xea\x09\xab\xed\xb9\x68\x6d\xdc\x8c\x03\xcf\x71\xbc\x90\x46\x6c\x68\xc9\xe4\xd7\x14\x93\x75\x51\xc9\x64\x15\x5b\x95\xea\x36\x37\xa0\x35\x44\xd6\x35\xe8\x43\xec\x20\xdb\xc8\x76\x93\x60\x62\x6e\xb1\x63\x06\x60\xbb\xc8\x77\x3b\x8b\x0f\x11\xba\x78\xae\xb1\x96\xea\x50\x40\x6d\x37\x83\xcc\xd0\xf7\x9d\x7b\x60\xf6\xc1\xf7\x0f\x45\xe4\xff\xba\xa6\x7e\xef\x50\x4a\x5f\xa6\x24\x30\x93\x91\x91\xa8\x49\x7c\xa9\x89\x06\xad\x46\xab\xd1\x9c\xf8\x55\xc3\x08\xa5\xef\x24\x70\x89\x28\xdb\x65\xd0\x67\x5b\x77\xcb\xce\xd2\x0d\x0c\x30\xa7\x0e\xd4\xe3\x07\x84\xfd\x57\xda\xfa\x22\x0a\xc2\x37\x96\x44\x8a\xb9\x53\x7c\x87\x22\xfb\x2f\xf8\xe0\x51\x24\x38\xc6\xc8\xce\xc6\xcd\x6f\x84\xb2\x7f\xf3\x73\xc8\x38\x2e\xe1\x27\x9c\x6b\x2c\x07\x11\x04\xe1\x90\x0f\x11\x3d\x46\x4a\x4d\x56\x90\xae\xab\x00\x5a\x6c\xb2\xd4\x13\x35\xd2\x93\x64\x46\x67\x44\x28\xa0\xa2\x8e\xe8\x3c\xd3\x4c\x8a\x6b\xa9\x5e\xc5\x53\xb0\xf7\xde\xe0\x1d\x16\x2b\x47\x23\x9f\xde\x03\x42\x03\x39\x4a\xd4\xb9\xa8\x08\x1c\x4f\xf6\x9b\x69\xd8\x01\x06\xbf\x07\x08\xb5\xbd\x90\x1a\xd1\x00\x27\xfd\xa4\xda\xb1\x6b\x79\x36\xeb\x13\x13\x70\x75\xfc\x3f\x57\x9b\x80\x3f\xea\x15\x50\xea\x21\x10\x12\x64\x03\x1b\x07\x0c\x57\xef\x01\x14\x36\x68\xc7\xe3\x57\xdc\x08\x52\xd9\x23\xba\x83\x23\x9f\x8f\xf7\xeb\x9f\x2e\xaf\x0e\x2e\xae\xba\x57\x27\xa7\xc7\x7f\xfa\x0c\xfe\x74\x71\xfc\xdf\xeb\x9d\xd3\xe3\xab\x9f\xcf\x8e\x36\xfe\x24\x7e\xfe\x8f\x79\xfc\xe1\x97\xb3\x7f\x99\x67\x17\x27\x3f\x9d\x7c\x38\x78\x6f\x9e\x1f\x5c\xfd\xfc\xb7\x0e\xfb\x37\xab\x72\x7e\x71\x76\x75\x76\x78\xf6\xfe\x4f\x9f\x5c\x23\x99\xf0\x81\x6a\xc2\x7f\xbf\x3c\xfb\x90\x9f\xb0\x0f\x03\x36\x65\x48\xc0\x08\xfa\xbf\x0a\x80\x7d\x16\xff\xc9\x4f\xf6\xe5\x03\x7b\x4f\x1f\xd0\xae\x7c\x7e\x6a\xa4\xe7\x6e\x6c\x02\x23\x40\xbf\x33\xfe\x71\xe8\xf1\x47\xe6\xd9\xb5\x18\x5f\x5f\xca\xfe\xde\x23\x78\x83\xe4\x06\x51\x8f\x41\x2c\xf2\x4f\xca\x03\x2a\xd9\x1c\x5e\xd6\x01\x2f\x5f\x2a\x37\x3d\x5e\x23\xf5\xf8\x1a\x81\x17\xf0\xcd\x61\xbf\xa3\x8b\x26\xe6\x80\x52\x28\x90\x1d\x24\xea\xa5\x03\x5e\xb2\xc6\xd1\x50\xe2\xdf\xe0\x08\xf9\x01\xb2\x20\x45\x76\x07\x8c\x18\xd2\x03\x4c\x40\x48\xd8\xa8\xc7\x47\x97\xbc\x12\x2f\xe6\x88\x7f\x29\x1e\x77\x65\xbc\x43\x1b\xe9\x6b\xa2\x18\xf4\x80\xbd\x08\x6c\x25\xdd\x5c\xf0\x73\x96\xed\x27\x76\x8c\xac\xd5\x95\x9c\xb7\x9f\x1c\xc7\x77\x10\x3b\x67\x3e\x72\x01\x64\x77\x45\xac\x3b\x00\xd8\xe5\x0e\x04\x84\x79\x71\x48\xe6\x90\x2f\x50\x34\x8d\x2d\x99\x16\x17\xc2\x24\x31\xb6\x86\xc8\x6e\xc4\xa0\x11\xbb\x87\x89\x3c\xcc\xb7\x43\x6c\x0d\xc1\x08\x41\x57\xe0\x7f\x3c\x10\x23\x62\x2e\x46\x76\x32\x92\xe5\x60\x41\xd0\x40\xe8\x46\xb7\x96\x25\xb2\x3f\xb1\x3f\x53\xd7\x8b\x62\x21\xe9\xb7\x8e\x0c\xaf\xa8\x7c\x7a\x3b\x60\x1c\x31\x76\x07\x4c\x44\x93\x6e\x20\x7e\xd8\x73\xb0\x05\x4e\xce\xa3\xe7\x12\xe2\xa9\xae\xac\x34\x80\x14\xdd\x42\x41\x4a\x65\x91\x4c\xc8\x16\xc1\x3e\x57\x51\x0e\xf9\xd1\xc5\x77\xe0\xc8\x1b\x41\xec\x82\x4b\xcf\xba\xe6\x97\x71\xe0\x85\x83\xa1\x84\x81\x78\x94\x61\x79\xa3\x51\xe8\x72\x65\x0e\x11\x82\xf4\x07\xcf\x46\x07\x03\xb6\xf2\xcb\xa3\x4b\x40\x3d\x30\x40\xd1\xdd\x70\x8d\xee\xb9\x46\x8f\x93\xc9\xd1\xd5\xfb\xcb\x06\xf8\x48\x10\x10\x5e\x02\xe6\x88\x79\x03\xf0\xc7\x95\x04\x60\x97\x50\x04\x6d\xb6\x22\xd6\x0b\xee\x47\x66\x08\x7e\xb6\x04\xd4\x88\x4d\x3e\xda\xe4\x1c\xd2\x61\x67\x2d\xed\xb3\xc1\x7c\x39\x04\x06\x4b\x65\x16\xef\x9e\x5d\x93\x16\x4b\x30\x1b\xef\xfa\xfa\xe5\xd1\xe5\x46\x23\xb9\x57\xe4\x08\xec\xfe\x5d\xf7\x03\xc4\x1d\x84\x08\xa6\x91\xc6\xb3\xb3\x95\x73\x63\xb0\x3d\x8b\x13\x5d\x0b\xf9\x4c\xe0\xa3\x5e\x00\x07\x68\xeb\xc6\x73\xc2\x11\x22\x5b\x2f\xfc\xc0\x13\x11\xa3\x36\x36\xe5\x8e\xf0\x7b\x49\xa8\xd4\x80\xac\x06\xf8\xa2\xe5\x1c\x18\x54\xae\xf7\x48\x34\x3f\x20\x1d\x24\xc0\xdf\xff\x79\xb5\x09\x88\x27\x68\xd7\x3f\xf6\x48\xca\x1b\x53\xb4\x27\x65\xcd\x84\x40\x25\xf6\x49\x7a\x22\x6d\x8a\xed\x93\x43\x66\xee\x76\xbe\x57\x2e\x0a\x20\x45\xc9\x56\xa1\x1b\xe4\xd2\x90\x29\xf2\x1a\x91\xef\x94\xfb\x32\x56\x92\x4a\x45\x89\xeb\xb9\x26\x9b\x02\x3b\x6b\x69\xa2\x76\x69\x93\x2b\xe6\xdd\x71\xca\x26\x94\xa3\x98\xf3\xda\xab\x4d\xb9\x42\xbe\x94\x3e\xa2\xd6\x10\xb8\xec\x4e\x75\x4a\x81\xc2\xdd\xae\x5e\x4e\xe1\xa4\x12\x5d\xf3\xeb\x15\x08\x41\x21\xb9\x26\x5b\xe2\xe6\x35\x19\x47\x27\xe5\x4d\x53\xde\x63\xd1\xa7\xe8\xe7\x0b\xf1\x1b\xbb\x03\x93\x0e\x11\x73\xa0\x35\xd9\xfc\x4c\xc8\x14\xf1\x1b\x72\x40\xe8\xda\xc0\x87\x84\x00\xea\x31\xb4\x97\x9b\x2f\xb7\xb2\xb0\x89\x11\x97\xa1\xda\xc3\xd8\x1b\x2a\x05\x7c\x3c\x70\xbd\x00\xd9\xec\x94\x29\xb6\x8d\xd5\x20\x88\x36\xb2\xfe\x73\x75\x70\x80\x1d\x50\x82\xfe\xb1\x47\x2e\xe1\xdf\x6f\x8b\xdb\xcf\x85\x1c\x42\x81\x2d\x2e\x19\xcb\x0b\x02\x44\x7c\xcf\xb5\x49\xc4\x81\x89\xef\xcc\x6a\x00\xbc\x3e\x80\x40\x52\x05\xd9\xc3\x05\xea\xa3\x80\x55\x8d\x76\x63\x80\xe9\x30\xec\x35\x2c\x6f\xb4\x45\x7c\xdc\xef\xa3\xe8\x3f\x3d\xc7\xeb\x6d\x8d\x20\x87\x37\xa1\xd0\xb5\xf9\x3b\xef\xcb\xf3\x93\x77\xef\x8e\xcd\x93\xa3\xc6\xc8\x7e\xd1\x6e\x99\x7c\x34\x53\xcc\x86\x0f\xc1\x0b\xc4\x15\xd8\x49\x53\x4c\x49\xd2\x0f\x33\x76\x8f\x17\xd1\x7f\xc1\xd5\xe1\x79\x74\xcf\x63\xcf\x05\x32\x4f\x1d\xe8\x21\x7a\x8b\x90\x0b\x8e\x39\xb6\xfe\x99\x2f\x30\x85\x1f\x9b\x7c\x93\x33\x95\xa4\x5f\x0d\x88\x7a\x93\x19\xe7\x58\x14\x20\x92\x1f\xf5\xc5\x0b\x70\x70\xf4\xcb\xc1\x87\xc3\xe3\x23\x70\x79\x7c\x75\x75\xf2\xe1\xa7\x4b\x56\x9a\xfc\x13\xd5\x04\xff\xe4\x0e\x8d\x52\x42\xe7\x67\xe7\x25\xc9\x09\x2d\x3d\x04\x08\x15\x38\xe1\xa6\x2c\xdc\xdc\x51\x28\xb9\x4a\x92\xd9\xf5\xf1\x80\xdf\xc3\xc0\x48\xec\x31\xc2\xad\x28\x12\xae\x7a\xd8\x85\xc1\x7d\x54\x29\x24\xc1\x16\xa7\xe7\x5c\xef\xc0\xe7\x60\x24\xf0\x63\x34\x8d\xa0\xd0\xf6\xe2\xd3\xcb\x24\x12\x81\xde\x0c\xd3\x38\x70\x22\xd8\xc8\x2a\x87\xe2\x38\x75\xd2\x33\x4d\xf7\xc8\xc9\x0c\x65\x12\xb9\xd4\x1a\xdb\x88\x32\x15\x93\x8b\xc0\xd0\xbb\x05\x8e\xe7\x0e\x00\x74\x81\xe7\xd8\xa2\xfb\xb8\xad\x1f\x78\xec\x7c\xa6\x34\x1a\xd7\xc8\xa7\x00\x3a\x8c\x10\xc3\x3e\x45\x01\x6f\x67\x59\x90\x49\x5a\xd0\x01\x01\x62\x66\x91\x68\x7a\x76\x00\xb1\x7b\x24\xe1\xda\x01\x3b\x71\xe4\x05\x1f\x06\xc8\xa5\x97\xc3\x90\xb2\xd7\x59\x49\x8d\xd6\xa8\xb8\xb9\x1c\x24\x5c\x0d\x96\x1c\x71\xc1\x88\x03\xf9\x7c\x2f\x85\x70\xfc\xf4\x08\x10\x89\xc3\x2a\xd7\x1d\x77\x36\x84\x04\xb8\x1e\x40\xfd\x3e\xeb\xc0\x73\x41\xf4\x16\x30\x62\x66\x3a\x00\xfb\x3c\xd2\x04\x01\x17\xc7\x47\x27\x17\xc7\x87\x57\x00\x13\x00\x9d\x5b\x78\x4f\xe2\x6d\x88\xfb\x8b\x9b\xa7\xe6\xd0\x88\xbf\x9e\xf4\x81\x11\xf5\x62\x6c\xb2\xd6\x8a\xde\xa9\x07\x3e\x1c\x5c\xf1\x13\x10\xaf\x2c\x5e\x45\x06\x0a\x49\x5f\x02\x20\x8e\x47\x10\x91\xba\xde\xc8\x2c\x80\x08\xb0\x43\x2e\x1d\x45\x9d\x31\x7d\x5f\x66\x46\x57\xe7\x17\x67\xff\xf3\xaf\xfc\x7c\x44\x69\x06\xbe\x25\xb3\x90\xed\xc5\x1c\xb8\x97\x48\x70\x83\x08\xe8\x79\x74\xc8\xcf\x4b\x34\x21\xd7\x06\x36\x7f\x1d\x2a\xce\xd5\xc9\x79\xdc\x4d\x32\x57\x7e\xb9\x7b\x01\x25\x09\x91\xa7\x43\x14\xdb\xd2\x62\xb4\x87\xf6\x0d\x74\x2d\xf6\x03\x3b\x14\x05\xe9\x2d\x65\x5d\x8c\xa0\x8b\xfd\xd0\x81\xd9\xc5\x16\x67\x0b\x1d\x92\x12\x1d\x04\x0b\x19\xc9\x0f\x6c\xe5\xa1\x74\x5e\x8e\xfc\x92\x59\x2f\x87\x07\xe7\xdd\x0f\xc7\x57\xdd\x83\xa3\xd3\x93\x0f\xc0\x82\xbe\xb4\xd9\x45\x14\x28\xed\x4f\x2d\xe5\x1e\x31\x66\x3c\x11\xfe\x52\x8f\x71\x4a\xd8\x73\x4f\xb9\x42\x32\xda\xc8\x02\xb2\x9f\x7b\x01\x95\x5e\xd7\x1c\xf4\xf2\x5d\x29\x01\xeb\x9e\x0b\xf8\xad\xc1\xed\x3d\x1b\x12\x28\x23\x4e\x3b\x46\x23\xe8\xda\xf1\xc1\x01\xff\xf2\x42\x0e\x3f\x74\x87\x2c\x80\x5d\x49\x4f\x94\xb7\x18\xc0\x91\x61\x32\x6d\xe4\xb4\xc2\xc0\xe1\x6d\xc4\x00\x6c\x7f\xc0\x3a\x2f\x94\x01\x4f\xf8\x44\xd8\x3c\xd8\x0b\xf1\x66\x73\x6b\x03\x50\x0f\x78\x3d\x1a\x11\x0f\xd6\x89\x8d\xe1\xc0\xf5\x08\xe5\x82\x88\x10\xfe\xb8\x8a\x90\xf1\x1f\xf2\x84\x5e\xa2\x04\xca\x11\x1d\x73\xee\xfb\xb4\x21\x89\x19\xf6\xc4\xf5\x28\x98\x0b\xcf\x47\xe2\x9e\x20\x5b\x7c\x5e\x8d\x21\x1d\x39\x71\x7b\xce\x55\x7b\x01\x02\x36\xa2\x10\x3b\xf1\x4d\xc3\xd6\x7b\xc0\xaa\x9f\xf3\x50\x1c\x7c\xbe\x05\xa8\x33\xa9\x93\x31\xb3\x3c\xca\xa0\x50\xc5\xc3\xd8\x8e\x0b\xdc\x70\xd4\x13\xc4\x96\xc1\x2d\xf0\x04\x6c\x64\xa9\xd7\x07\xe7\x6c\x0c\xc0\x6c\xc2\xac\xd6\x90\x29\xc3\xb2\x77\x80\x64\xd8\x9a\x60\x5d\x52\xcf\x0d\xee\x65\xee\x02\x2e\x76\x67\x5b\xf2\x85\x30\x39\x0c\x1c\x9e\x7f\x94\x65\x5b\x96\x17\xa0\x14\x3d\x8c\xe6\xd9\x01\xf9\xa5\x48\xdd\x4a\xa2\x01\xff\x03\xfb\xd7\x8c\x82\x83\xf8\x9f\x17\xe0\x40\x9c\xbe\x48\x62\xfa\x5f\x5e\x05\x58\x9e\xe3\x70\x7b\x77\xaa\xae\x3c\xa7\x1d\xd9\x4d\x46\x3e\xed\xec\xef\xb4\x5a\x05\x48\x9e\x86\x8c\xd7\x02\x57\xef\x2f\x41\xd6\xe4\x10\x53\x77\x79\xdc\xc4\xd1\xe7\x1d\xc6\x60\xf5\x1d\xe8\xa6\xd7\xc9\x0a\xcf\x59\x19\x33\x98\x08\xfd\x53\x07\x7c\x38\xfb\x70\x5c\x18\x36\x5a\x92\x38\x39\x38\x15\xae\x29\xa2\xa2\xec\x94\x86\xae\x9b\x5c\x1c\x31\x9b\x7d\x10\x2d\x32\xf5\xc0\x2e\xbb\xd2\xd6\x6e\xb3\xc5\xa3\xfa\xbc\x90\x2a\xc4\x58\xdf\x8d\x1d\x61\x9d\x89\x2c\x2f\xc0\x45\x94\x6d\x67\xe4\xc6\x1e\xb1\xf6\xec\xfb\xa5\xef\x60\x0a\x7e\xf6\x02\xfc\x87\xe7\x32\xe5\x42\x43\xaa\xfd\x3e\xc8\x36\xb1\xfa\xcf\x8d\x0b\x1e\xbe\x8e\x53\x7c\x4a\x70\x9a\x91\xb7\x82\x39\x1f\x4d\xa8\x99\xef\xf7\x11\x54\xa3\x29\x0b\x5b\x61\x36\xd9\x18\x3d\x31\xe4\x7c\x89\x24\xd1\x23\x08\x5e\x18\x01\x2b\xae\xc5\x46\xe2\x69\xa2\x0f\x7c\x9f\x3d\xa3\x38\x0f\xbc\x1e\xca\x06\x58\xc2\x2e\x66\x3c\xae\xb8\x25\x53\xf6\xa5\x34\x88\x58\x9d\x44\x4b\x3f\xe2\xab\x31\x06\x56\xc0\xee\x2d\x51\x43\x2e\x48\x70\x83\x5d\x56\xbf\x23\x8b\x18\x28\xcc\x76\xb3\xb5\xdf\x6c\xb5\x5a\x66\x73\xdf\x6c\xed\x26\xaa\x78\x18\x24\xde\x32\x00\x98\xc0\x30\x7d\x23\xf5\xf3\xd7\x5f\x41\x83\x99\xe8\xc4\x26\x36\xf8\xf5\xf3\x9e\x93\x09\x4e\x3d\x3e\x7f\xce\x34\x0d\xd3\x4d\x5b\xdb\xdb\xaf\x33\x5f\x47\xb9\x8e\x13\x83\x3c\x68\x9c\xf5\x18\xb0\x4f\x11\x85\xe0\xdf\x72\x03\x12\xc3\x61\x9e\xa6\xfd\x1b\x88\x89\xc8\x49\x9d\xe4\x3e\xe7\x67\x85\xd3\xe3\x1a\x15\x03\x47\x8e\x30\x8a\x09\xf0\x58\x19\x67\x92\x01\x3b\x39\xbf\x80\xee\x00\x91\x7f\x03\x60\xfc\x60\x00\xf0\xf9\x73\x66\x04\xf3\x6e\xe6\x01\xd1\x5d\xe9\x80\x8a\xf1\x7a\xf3\x5a\xe0\x89\x60\x72\xd9\xd6\x92\x7f\x83\x75\x45\x29\x68\x5c\xfa\xc8\x6a\x24\xe8\xba\x51\x98\x8d\x9d\x9f\x0d\xba\xcb\x77\x03\xd6\xcb\x66\x28\xcc\x46\xc5\x09\x32\xbe\xe0\xdf\x80\x91\xd4\x76\x13\x6c\x80\xf5\x89\x57\x58\x9c\x84\x84\x66\x76\x01\xfc\x68\x9d\xf3\x80\xb9\xe2\x74\x9f\xf4\x3f\x78\xf4\x5c\xb8\x27\xa7\xec\xc4\x99\x30\x72\x51\x21\xd7\x06\x64\xca\x00\xb0\xfc\x90\xc9\x91\xa3\x4c\xe1\x08\x8d\xbc\xe0\x9e\x95\x9f\xa6\x6d\x7c\x0e\x1e\xe1\xe9\xdb\x47\x66\x6e\xb6\x37\xe8\x8e\xa6\xbb\x89\x19\x49\x8c\x72\xdd\x43\xdb\xce\x16\x98\x20\xe6\x3f\xd3\xcb\xa5\x30\xa0\x11\x48\x0e\xb8\x7c\x92\x22\x9d\x55\x17\x57\x5a\x40\x8c\x6f\xae\x49\x0e\x3f\xef\xe0\x84\xb5\xfb\x37\xa8\xba\xf2\x6e\xda\x55\xf7\x1d\x48\x5f\x0a\x5c\x08\x48\x5f\x7a\xf1\x22\x62\xc6\x2d\x15\x8a\x88\x33\x76\xd4\xb3\x3c\xa7\xc3\x54\x0e\xb9\x57\xd2\x42\x69\x99\x8a\xbc\x54\x7a\xb3\x66\x21\x61\x46\x74\x26\x55\x62\x9a\x89\x88\x9f\xbb\x83\xd3\xf7\xdd\x61\x5c\x29\x7b\xd3\x99\x66\x22\xfc\x57\x34\x7f\x1b\x57\xca\x37\xcf\x8a\xf8\xf1\xa7\x5f\x7f\x05\xb8\x0f\x5c\xc4\x4e\xcb\x3a\x76\x6d\x74\x97\xde\xb2\xc6\x7b\x4e\x90\x81\x01\x7d\xdf\xd8\x00\x66\xa6\x4f\xd6\xb4\xb2\x01\xf8\xfc\xb9\xf1\xeb\xaf\xc2\x9e\x74\x16\x44\x56\x80\xc6\x51\x6c\xef\xe5\x2d\x3e\x44\xdc\x40\xe2\x08\x92\x9e\x3b\xbb\x63\x1c\x82\x8a\x63\xd7\xe8\x36\x32\x34\x0b\x25\xca\xdc\xa6\xe3\xda\xb9\xd9\x98\x66\x46\x43\x91\x9d\xa7\x10\x59\xa2\x6f\xd9\xed\x3a\x4a\x37\xcb\xef\x98\x5a\xb9\x51\xbf\xf3\x73\x65\xfb\xfc\x28\x79\xf6\x75\x4a\x32\x9e\xef\x26\x47\xc6\x8f\x72\x9f\xf3\xb3\x10\x72\x81\x72\x0a\x99\x7e\x7e\x42\x54\xda\x78\xd9\x9f\x42\xe2\x60\x7f\x95\x74\x9b\xd5\xf9\xd5\x07\xdd\x61\xa6\x5d\x61\x63\x32\xb2\x60\xc5\x6c\xcf\x33\x15\x73\x68\x84\xfb\x60\x40\x0b\xc3\xc6\xa2\x63\xb3\x80\x63\x29\x81\xad\xfa\x02\x89\xfb\x18\x8f\xb7\x6a\xe1\x68\x4a\x1c\x50\x77\xf6\xef\xc2\xfc\x14\x95\x92\xa9\xfe\xfa\xab\xc9\x40\xb3\xee\xa2\x99\x19\x09\xa3\x69\x6c\x14\xae\x41\xde\xb4\xb8\x6f\x33\x0d\x95\x1f\x24\xa5\x91\x66\x23\x91\x9a\x1c\x1c\x13\xd1\xb1\x8b\x08\x69\x94\x8d\x9a\xef\xf8\xdf\x60\x3d\x5f\x54\xc9\xc4\x31\xe0\x32\x34\x48\xcd\x17\xb9\x37\x69\x42\x26\x63\xd9\x9e\x1d\x75\x3f\x1c\x9c\x1e\xc7\x1f\x80\xb8\x36\x59\x78\xf7\x4e\xce\x8f\x09\x39\xf6\x05\xea\x67\x4b\x65\xb9\xd0\x54\x47\x92\x61\x83\xf5\x5d\x3a\xd4\xe5\xf9\xc1\xe1\x02\xc6\x4b\xbb\xb3\x26\x83\x9e\x7c\xb8\xbc\x62\x6a\xfe\xee\xc9\xf9\x5c\x86\x94\xfb\xe5\x7b\xf6\xc9\x79\x71\xb0\xcb\xab\x93\xb3\xee\xe9\xf1\xd5\x41\x77\x89\x70\x4d\x8d\x7a\x78\xf6\xe1\xdd\xc9\x4f\x8f\x00\xe5\x64\x0a\x27\x1f\xae\x8e\x2f\x0e\x8f\xcf\xaf\x4e\xce\x3e\x74\x4f\xcf\x8e\x0a\x73\xe0\x7c\xa3\x17\xa8\x78\x90\x83\xf8\xb8\x10\x60\x8c\x95\x1f\x8d\x8d\x6a\x01\xb2\x71\x29\x7c\x7e\x0a\x97\x71\xd9\x90\x9f\x3f\x57\x2c\x8c\x79\x93\x74\x0f\x3e\x7c\x38\xbb\x3a\x60\x4b\xbb\x2c\x2e\xeb\x4b\x0e\x84\x62\x3c\xea\xb1\x96\x75\xc6\x94\x17\x77\xe5\x74\x25\xd3\x35\x7e\xa6\xef\x0f\xde\x1e\xbf\x9f\x65\x92\xc5\x91\x8a\xf3\xab\x27\x66\xfd\xfa\xeb\xa2\xae\xf9\xf8\x1a\x15\xea\x98\xb5\x64\x4d\x8c\x9b\xff\x09\x65\xa4\xa7\x38\x96\xf5\x10\x41\x87\x0e\xff\xd8\x62\xad\xef\xb3\x15\xb8\xdc\x30\x4f\x1a\x21\xd4\x42\x18\x3a\x47\xc8\x81\xf7\x97\xc8\x62\xf6\xda\xca\x21\xc6\x93\x06\x45\x8f\x6c\xec\xdc\xb8\x3e\x0a\xb0\x67\xcf\x67\xc4\x4c\x5f\xff\x06\xa0\x9d\x1b\x8b\x39\x86\x86\x01\xba\x8a\xd2\x85\xcc\x38\x5c\xbe\xbb\x7f\x03\xb0\xdd\x04\x0a\x54\x34\x3f\x7f\xae\x10\x97\xd9\x40\xec\x0d\xcc\x85\xe7\x51\xe6\xd3\x27\x55\xb3\xd9\xac\x10\x11\x7a\xa2\xdf\x2b\xb0\x73\x46\x4d\xd6\x46\x62\x52\x32\x3f\x7f\x9e\x9f\x40\xcf\x1d\xd3\x0f\xc8\x4f\x81\x17\xfa\x9d\xac\xa2\x2e\x2b\x4d\xe5\x5b\x7c\x24\x28\x50\x34\x28\x70\x0c\x4a\x05\x89\x3c\xce\x98\x10\x44\x4b\xaf\xb5\x12\x05\xc0\xe1\xf9\xc7\x7f\x6f\xe4\x80\x50\xa1\x70\x31\x94\x82\x67\xcd\x71\x32\x0a\xa1\xb4\xba\x65\xea\x5e\x4f\x79\x07\xd9\x8e\x55\x42\xeb\x58\x25\x52\x15\xaf\x2e\x3c\x9d\xb8\x2b\x49\x46\xef\xc0\x3d\x97\x04\x41\xce\x3b\x0d\x14\x74\x19\xfc\x9b\x50\x66\x54\xf4\x20\x1e\x80\x96\x34\xe6\x1f\x15\x87\x49\x71\x7c\x38\x17\x3f\x29\x3a\x84\x04\x05\xbf\x24\x4b\xfd\x77\xf6\x42\xf9\xf5\x57\x10\x30\x55\x29\xf8\x9e\x6f\xd4\x26\xf8\x9e\x93\x2f\xd0\x79\xc3\xad\x81\x9c\x58\xad\x4f\xba\x87\xf9\x21\x37\x54\x64\x94\x21\x87\x18\x34\x87\x3f\x9c\x4a\xfe\x0b\x8e\x9c\x68\x2e\x5f\x38\x12\xb9\x14\xec\x14\xe6\x9e\x3b\x46\x8a\xb3\x25\x76\x39\xa5\x6c\xe3\x8e\x7a\x47\x38\xc8\xbc\x44\x41\x36\x0e\x47\x1d\x20\xf0\x6e\x6d\xdc\x1e\x9b\x15\x1b\x28\x9c\xb6\xd2\x9d\x8b\x4c\x0f\xd0\x29\xbf\x0f\x85\x78\x21\xbd\x1f\x0f\x84\x63\x97\xd0\xb5\xe4\xef\x31\xd1\xfb\x87\x64\xf4\x46\x36\xcc\x60\xf9\x41\x49\xb7\xfc\xf5\x57\xe0\x07\xd8\xa5\x7d\xa9\xcc\x69\xfc\x89\x18\xe5\x93\x50\xc1\xdc\xcc\x90\x88\x19\xf1\xf2\xdf\x39\x82\xb3\x48\x84\x4c\xe3\xe2\x18\x4c\x2c\xc7\xc3\xf6\x58\x66\x32\x82\x51\x65\xca\x19\xfe\x54\x23\x6d\x2e\xcc\x78\xf6\x55\x3c\xc6\xcb\x6e\x52\x79\xee\x19\x3e\x40\x1c\xe6\x48\xf6\x3b\x61\x2a\x9a\xe4\xbd\x4b\x94\x89\x26\x29\xa9\x63\x28\xac\x86\x00\xf7\xec\x25\x63\x60\x30\x13\x10\x50\xda\x87\x78\x36\x58\xa0\x82\xdf\x72\x0c\x12\xb1\x8e\xda\x40\x61\x30\x99\x65\x51\xd8\x9d\xdf\xaa\xb0\x3b\xd7\x65\xd5\xcf\xaf\x14\x20\x48\x51\xe1\xb5\xa8\xc9\xc2\xd4\xcc\x8e\xfa\x62\x38\xd3\xf7\x08\x35\x79\x8e\x40\xc7\x99\xf2\x00\xcc\x9e\x8b\x29\xbf\xdb\x41\x0f\x5a\x0d\xe6\x81\xc1\xdc\x0d\x84\x5b\xd4\xf5\x1e\x11\x2f\x3e\x7b\x88\xc2\xd8\x11\x40\xd8\x15\x2e\x3c\x07\x4d\xb6\xd0\x25\xac\x29\x08\x1d\x46\x54\x4d\x00\x7d\xcc\xd9\x62\x26\xf9\x94\xbd\x64\x35\x3e\x83\x17\x3c\xd3\x81\xf0\xc2\x12\x3b\x1f\x3f\x40\x61\x8d\xa2\x87\xb6\x6b\x69\x56\x18\xfc\x6a\xfc\x60\x7c\x5e\x03\xe0\x06\x05\xbd\xf8\xe7\x1c\xa0\xf9\x96\x85\x79\x70\x07\x93\x01\x35\xf0\x1c\x64\xf6\x44\xcb\xa5\x40\xd8\x73\x90\x54\x17\x45\x30\xae\x58\xec\x1a\x00\x85\x65\x4e\x8c\x2a\x24\xe4\x04\x95\x2f\xc4\x04\xca\x03\x07\x40\x8d\x4e\x61\xae\xb2\xea\xa8\xe5\xb7\xb1\x07\xa9\x35\xdc\x8a\xcf\xf9\xdf\xbd\xde\x84\xdb\x53\x6a\xcb\xac\x3c\xef\x89\x24\x2a\xb7\xcf\x18\x22\x67\xd4\x20\xc3\x2d\xf6\x82\xd6\xe8\x80\xf4\x20\xc5\x0a\xa6\x8d\x1c\x44\x91\x7c\x6e\x64\x74\x00\x2f\x24\xa1\x65\x71\x6c\x5f\x10\x6a\x44\x6f\xac\x63\xe7\x99\x8a\x97\xe0\xe5\x20\xab\x7a\x1a\x9e\x69\x95\x7f\x1f\x9e\x2f\x9e\xf6\x79\x38\x29\xf0\x9a\xb5\xb1\x4a\x65\x47\x4f\x18\x3a\xf9\x6e\x35\x2e\x1f\xe3\x01\x24\xab\xd7\x72\xff\x01\x91\x4b\x67\x07\xfc\x0a\x0c\xee\x25\xde\x83\x64\xc8\x5e\xe2\x6d\xd1\x91\x1f\xd3\x39\xf1\x90\xbd\x50\xac\x8c\x3e\x60\x80\x34\x87\xad\x16\x4b\x0b\x82\x65\xa6\x5f\x23\x53\x2f\xda\x7a\x3a\xf2\x13\x8f\xb3\xdc\x66\xe6\xc4\xa2\x04\x76\x55\x8d\x40\xec\x54\x7f\x0a\xfd\xec\xdc\x54\xc8\xa6\x08\x04\xa1\xf0\x8e\x38\x73\xdf\x09\x2d\xd4\x44\x99\xeb\xe6\xc7\x2b\xf3\x9e\x67\x63\xa1\x66\xcc\x69\x57\xb5\x6e\xfe\x2a\x70\x91\xfc\x31\x1f\x60\xb6\xe5\xf3\x2e\xd2\xcb\x4f\x0a\x66\x5d\x3e\x77\xf8\x5c\xa0\x88\xc4\xfb\x9f\x6d\xf5\xbc\x8b\xf4\xea\x93\x82\x99\x57\xaf\xca\x15\x38\x3f\x10\x3c\x7a\xd6\xc0\x49\xe3\xd4\x38\x08\xba\xa1\x6f\xca\x77\x71\x62\xe9\xe2\x25\x41\x80\x20\xe1\x31\x2e\x02\xc1\x5d\x32\x6b\x14\xcc\x41\x00\x40\xd7\x4e\xb3\x47\xd9\x60\x60\xc2\x53\x18\x93\xb5\x17\x82\xe4\x0b\xc2\xce\xe9\x39\xb2\xa3\x70\xdb\x3d\x94\xc4\xc4\x96\x81\x65\xf2\x83\xf0\x5a\x6c\x78\xd1\x81\xbd\xf6\x02\xf4\x50\xdf\x0b\x10\xef\x9d\xf7\xc7\xea\x38\x30\x74\xf9\x7b\x5e\x70\x26\x1e\x32\xf1\x28\x50\x60\x08\x5d\x7b\x33\x72\x86\x4e\xb5\x88\xbb\x40\x6b\x2f\x44\xcf\xd8\x73\x23\x27\xf1\x68\x26\xeb\xa8\x31\x68\x00\x3f\x40\x72\xf2\x1b\xe0\xd6\x63\xcf\xe1\xc4\x2f\x5e\x55\x82\x2e\xbd\x90\xfb\xb5\xe4\xb5\x65\x80\x4c\xc1\x9c\xdb\x00\x8f\x98\x52\x0c\x52\xe4\xdc\x47\x4e\xd2\xd4\x33\x7b\x51\xdf\x76\x34\x6a\x63\xed\xc5\xda\x0b\x70\x42\x5f\x12\xf1\x80\x03\x8f\x98\x89\x06\xba\x34\x7e\x30\x92\x83\xd0\x66\x66\x0f\x72\x7b\x12\x71\xe6\xe9\x29\x41\xfb\x3e\x5a\x7f\x02\x98\x78\x67\xfe\xee\xf5\xd8\xcf\x20\x06\x10\xff\x00\x6e\x11\x1e\x0c\x59\x00\xe8\x99\xae\x87\x1c\xc6\x4d\x74\x51\xd4\xe3\x2c\x05\x34\xa7\x61\x2c\xf3\x0d\xc4\x8a\x8d\x0e\x8b\x85\xb3\xb0\xc0\x4a\x8b\x14\x65\xf3\xc0\xfe\x16\xe0\xa9\x16\xa3\xb9\xd0\x9b\x91\x83\x25\x4c\xb2\xd2\x30\xcf\x51\xbe\x09\x0c\xb1\xb4\xe5\x88\xc6\x2b\xbb\x49\xed\x05\x6e\xd2\xbc\x25\xf1\x4a\x20\x4e\x21\x84\x8f\xbf\xa6\x16\x22\x87\xe7\x87\x5d\xa8\x08\xbe\x08\x9c\xd9\x5e\x1c\xce\x4c\x2e\xa2\xe7\xa0\xf9\xa8\x81\xdb\xca\x25\xf3\x3a\xa8\xf6\x88\xc2\x79\x3d\x3f\x99\x44\x88\x4f\x15\x99\x20\x96\xe6\x33\xa5\xa6\x95\xf9\xf9\xd7\xd4\x2f\x90\x0b\xf8\x26\x80\x02\x4c\x93\xa9\x2b\x62\x84\x27\xe0\x0b\x18\x04\xc8\x8f\x8c\x6d\xd8\x63\xb1\x80\x4d\xe8\xda\xdc\x74\x68\x80\x2f\x51\x6c\x35\xc6\xeb\x00\x33\x00\xc8\x65\x99\xf8\xf2\x41\xd6\xd8\x3f\x2e\x79\xf3\xfd\x3a\x8f\xf7\xf5\x3d\xaf\x04\xbe\x00\x78\x7b\x0d\x5e\x3e\x70\x7b\x1e\xf8\xbe\xf5\xf5\xe5\xc6\x8f\xc5\x56\x70\x84\xaa\xdb\xb5\x95\xed\xa2\xb5\x89\x23\x16\x2d\xef\x7b\xd6\x1d\x30\x5d\xf0\xbd\x4b\xf2\x6d\x52\x31\xd6\x4a\xc5\x7c\xf1\x99\x3d\x3b\x71\x31\xbd\x4f\xb6\x80\x25\xfe\x38\x28\x94\x82\xf8\x01\xef\x11\x7f\x3a\x7d\xc9\xb8\xe6\xd0\xc1\xee\xe0\x44\x44\x87\x10\xc5\xc7\x77\xc8\x0a\xf9\x43\xf5\xcc\x8c\x58\x9f\x97\x48\xbc\x9d\xbc\x42\xc1\xa8\xa8\x51\x61\xd7\xdd\xf1\x9d\x1f\x20\x42\xb2\xf9\xf8\xa3\x1a\xd7\xe8\xbe\x03\x18\xb5\x6c\x64\x83\x6a\xc0\xc0\x1a\x16\x20\x26\xde\xc1\x7a\x41\x07\x9c\x14\x23\xd6\x71\x2b\x62\x61\x04\x00\x4c\x00\x47\xf6\xab\x1d\x45\xb9\xef\x5b\xaf\x76\x1c\xa4\xf8\x42\xb6\xf7\x9b\x77\x99\x27\x17\x2c\xe8\xc4\x54\x50\x32\x25\xb3\xdc\x01\xed\xcc\x40\xa2\x4b\xe4\x5a\x28\x3f\xe5\xd5\x04\xda\x53\x5d\x47\x71\x93\x9f\xea\x4a\x04\x52\x4e\x2a\xd5\x8f\xb3\x03\xce\x24\xc1\x45\x03\xce\xc1\x12\xb8\xf2\x02\x94\x7a\xad\x4b\x59\xd6\x23\x9a\x03\xb3\x43\xca\xe7\xd9\xec\x31\x4b\xf5\x70\xd1\x12\x33\x61\x30\xf8\x32\x26\x1f\x94\x85\x3c\xe0\xe7\x2e\x40\x03\x4c\x68\x90\xde\xc7\xa2\x2c\x57\x1d\xa7\x35\x27\xe3\x0d\x10\x2d\x8e\x87\xee\x28\x72\x49\x5c\x39\xd3\x7b\x12\xf9\x93\x88\xf0\x80\xfc\x29\x03\x41\x54\xd1\xf1\x26\x88\x65\xc8\x5b\x76\x81\x2c\x47\x84\x54\xa3\xe9\x44\xe6\xd5\xd5\x14\xeb\x6a\x9c\xbf\x29\x84\xbb\x71\x37\x58\xb5\x68\x37\xad\x6c\x57\xb2\x49\x4f\xc5\xc8\xfa\x48\x32\x9c\x12\x6a\x2b\x2a\xc9\x8d\xc7\xab\x27\x24\xc7\xa9\x8d\xb1\x31\xb7\x91\xb3\xc6\xc6\xe5\xf3\x36\xc7\x46\x1d\xd7\xb1\xc7\xe6\xb6\xb8\xa6\x41\x36\xd7\xaa\xa6\x45\xb6\x2a\x36\xbf\x96\xd5\xb4\xac\xa6\x65\x35\x2d\xab\xd5\x91\xd5\xe6\x6d\x7e\x97\x36\xc4\xd9\x0c\xf0\x73\xa1\xf5\x95\x80\x10\xee\xe4\x27\x32\xbe\xd1\x3f\x05\xd7\xbe\x40\xb0\x14\xe2\x46\xcd\x06\x1f\xe5\xf4\x33\xd0\x2a\xaf\x31\x6b\x2c\xa8\x89\x1c\xe0\x65\xe8\x59\xc6\x98\x17\x81\x38\x57\xaf\x59\x3e\xb2\xb9\x70\xe7\xf7\x12\xb1\x78\x71\xe2\xa2\x42\x38\x15\xd5\xb3\xd2\x30\xff\x21\x23\x8e\x1f\x5e\x1c\x01\x2e\xfd\xa1\x20\x3f\xf4\x0f\xf5\x44\xc6\x1a\x12\xf8\x5c\x7a\x2e\x55\x27\xcc\xa5\x77\x8e\x5f\x73\xee\xf3\x87\x6a\xf9\x3c\xfd\x91\xc7\x48\x61\x15\xd2\x18\x5a\x43\x17\xa0\x18\x22\xce\x4c\x3d\xcd\x94\xab\x74\x0b\x71\x78\xf7\x5a\x1d\x4f\xf8\xf2\xa3\x70\xf8\x17\x76\xfa\xb3\x4f\x42\x96\xf7\xea\x43\x7d\x1b\xd4\x3a\x2f\x37\x38\xa0\x21\x74\x24\x1d\x20\xc2\xea\x1f\xeb\xad\x78\xc7\xac\x2c\x9a\x92\x72\x7f\xc4\xbe\xa4\x36\x2a\xf4\x6d\x38\x2f\xbf\x81\xba\x4f\x55\x96\xf8\x1a\x65\xa5\xc1\x5d\xd3\xcd\x75\x6a\x8a\x58\x6b\x87\x22\x37\xd5\x79\x3b\xb4\x96\x78\xb5\x6c\xf2\x10\x56\x1c\x72\x30\xa4\x1e\xb1\x20\x13\x7a\xd8\xcf\x9e\xbc\x32\x72\x0f\xa1\x4d\x10\x0b\xbe\x44\xfc\x0c\x3c\xf7\x37\xaf\x27\x7e\xa4\x6e\x52\xfe\x3b\x9d\x90\xdf\x04\x43\x11\xcb\x93\x42\xc7\xf7\xec\x68\x38\x14\x88\x8f\x71\x1f\x89\x91\x56\xfc\xf4\x6c\xf9\x17\x4b\x36\x2b\xfe\x10\xd9\x12\xc4\x8f\x08\x21\xc4\x0f\x0a\x29\xea\x87\x0e\x41\xf2\x6b\x4a\xd7\x9a\xfe\xcd\x36\x46\x86\xf1\x91\x13\x10\xa8\xc2\xeb\x88\x1c\x14\x26\x60\x48\xc2\xff\xe0\x68\x33\x96\x86\x17\x21\x05\x7d\x7c\x8d\xee\xc5\xc0\x19\x9c\x90\x90\x63\x39\x3c\x5c\x8f\x0e\xb1\x3b\x90\x25\x38\xb0\x3c\x37\x24\x28\x82\xa6\x8b\x23\xf0\xf4\x9d\x10\xb9\x54\x02\x80\xb9\x24\xc6\x80\x4b\xe4\x11\xe4\xde\x14\x8a\x48\xbc\x14\x3e\x1c\x0a\x92\x02\xe4\xd2\x00\x47\x15\xbc\x41\xfa\xe7\x08\x8d\x7e\x0f\x3d\x0a\xa3\x5f\x3c\x05\x12\xff\xdb\xf3\x61\xbc\x09\xd2\xb7\x54\xb6\x49\x35\x10\x7f\xfa\xc8\x92\xca\xe3\x5c\xa9\xf8\xc9\x8e\x4a\xbc\x29\x5e\x40\x33\x90\x88\xb4\xfe\xf1\xfe\xca\xdd\x12\x55\x55\x5f\x64\x99\xe7\xc0\xe0\x16\xbb\x36\x49\x30\xc2\xba\xb6\x03\x7c\x83\x82\x04\x45\x88\x1d\xfd\x6d\x63\x2f\xb7\xfb\xb1\x53\x8b\x02\x11\x7c\x8e\x08\xa5\x28\x31\xfe\x1e\x93\x23\x64\xaf\x2d\x31\x56\x72\x95\xc6\x2b\x4b\xef\x48\xee\xfa\x9b\x7e\xce\x93\x78\xb6\x2f\x8f\xfe\xf3\x91\xcd\x05\x7b\xb5\xab\xef\xc0\x72\x66\xbc\x3e\x1b\x2e\x0c\x58\x9c\xfe\xa8\x18\xaf\x4d\x60\xf8\x25\xfc\xb3\x8f\x13\xee\xae\x54\xde\x10\x2a\xc2\xa8\xc8\x46\x5c\xc9\xa7\x36\x4c\x8d\xe7\x26\x15\xdd\xc7\x37\x3a\x6b\x96\xb0\xaa\x6c\xda\x9e\xcd\xff\x9b\x26\xbb\xc9\x05\x2d\xbe\x08\x37\xc8\xc4\xb0\x95\xbb\x5c\xe7\xcd\xf1\x4e\x6c\x3e\xcb\x43\xdc\x9f\x43\xa7\x93\x3c\x90\x58\x9a\x08\xcd\x07\x36\x17\xfc\x38\xa2\xe6\x21\x9a\xdc\x62\x3b\x95\xb0\x97\xe5\xe8\xe6\x22\xf8\xfe\x30\x3f\x29\xf7\x87\x05\x1f\xf8\x1f\xa6\x95\x15\x37\x41\xf2\x63\x4b\x44\x22\x1a\xdb\x73\xe5\xbd\x31\xe1\x6d\x98\xf0\xdd\xe3\xc6\xa8\xbe\x8d\xe6\x72\xfd\x65\xef\x33\xce\x6f\xa6\x2f\xb6\x99\xaf\x03\xd5\x8b\xa1\x25\xdc\x09\xc9\xb8\xe6\xd2\x5e\x0f\xd5\xf2\x9d\xcf\xb1\xf5\x19\x06\x3e\x2b\x30\xc4\x1c\x3f\xaf\x1d\xc7\xfb\x99\x6d\xc7\x8b\x42\x4c\x41\x9b\xe3\x7a\x6e\x94\x4e\xf5\xe3\xc5\x7b\xfe\x69\x4b\x32\xc1\x2a\xf5\xcf\xc4\x6f\xa6\x96\x44\x0f\x22\x55\xfe\x23\xba\x1b\xcd\x70\x69\xd4\xbf\x23\x94\x27\xb5\xd0\x5f\x99\xf0\x9f\x79\xad\x31\x7e\xa8\xb4\x59\x81\x4c\x43\x93\x4a\x3b\x9d\xaa\xb7\x1c\x69\x2a\x23\x28\x3c\x25\x5d\x80\x6e\x30\xba\x55\xc3\xe9\xf3\x6c\xe6\x96\xa5\xe1\x74\xc1\x0e\xb3\xd2\xa6\x96\x59\x0f\x45\x4d\x96\x76\x22\xe3\xc5\x28\xa4\x93\x78\xba\x55\x09\x33\xd3\x5a\x92\xa4\x5a\x60\x2e\x98\x32\x36\x70\x08\x9f\x87\xc9\x60\xe4\x4e\x18\x30\x64\x66\x53\xd3\xdc\xc3\x85\x28\x6c\x64\xd3\x04\x08\x29\x0b\x42\x55\x6e\xc3\x9c\xde\x70\x20\xa1\x4d\xe6\xa3\x40\x18\xb7\xdb\x8f\x66\x47\x98\xf7\x56\x57\x2c\x24\xd9\xf1\xd2\xfd\x9e\x47\xb4\xad\x25\xb8\x9d\x3e\xa2\x21\x62\xde\xfb\x85\xdd\xb9\x6d\x18\x76\x67\xd8\xb1\xc9\x8d\x18\x53\x5d\xc6\x93\xef\x35\x9f\xc1\x34\xd7\xf0\xac\x86\x8f\xf9\xed\xb4\x18\xb8\xf6\x6e\x4e\x1a\xc5\x64\x4a\xcd\x6c\x66\xff\x16\x7d\x62\xf9\x3c\xa6\xd9\xc5\x59\x55\xb7\xf3\x3e\xaf\x0a\x85\xf3\x14\x94\xb4\x2c\x52\xcb\x74\x9b\x5c\xa1\x38\x5c\x26\xc7\xb4\x1c\x35\xe2\xbc\x37\x54\x31\xeb\x29\x36\xb4\x2c\xf8\xcc\x94\x1b\x3a\x46\xf5\x93\xe5\x8d\x16\xb6\xad\x8f\xa2\x09\x9a\xdf\xfe\x96\x4d\xbf\xf6\x45\x5c\x2b\xa8\xce\xf4\x4a\x9c\x65\x9e\xcd\xe5\xaa\x74\xe6\x7d\x46\x95\xb3\x9f\xe2\x94\x96\xfb\xa8\x4e\xb9\xbf\x13\xa8\x37\x96\xb9\xdb\x05\x65\xc7\x14\x84\xf7\x71\xf5\x1f\xf3\xc6\xa0\x6a\xf5\xcf\x14\xa8\x54\xc3\xaf\x77\x6e\x52\xf1\x8c\x76\xf4\x49\x7c\xba\x84\x87\x43\xd9\xbc\x67\x0a\x71\x53\xa6\x97\x64\x2f\xdb\x26\x87\xc7\x1c\xd4\x03\x53\x48\x9a\x63\xc0\x33\x1d\xd6\x8e\x97\x0a\xd9\xb0\xf3\x15\x06\xa7\x88\x65\x3e\xde\x17\x7e\x8c\x2e\x6d\xc9\xd1\xca\x53\xf7\x8b\x6c\x19\x3d\xa6\x8b\x73\x60\x9a\xfc\xcf\x0e\xd8\xd9\xd9\x4e\x1d\x70\x9e\x22\xdd\x8c\x7c\xb5\x3d\x37\x55\x71\xbf\xb9\x9f\xaf\x69\x8e\x3c\x17\x53\x4f\xa6\xca\x8f\x2b\xee\x37\x5b\xa9\x8a\x83\xc0\xb7\xcc\x91\xe5\xaf\x01\x40\xe4\xb3\xa8\x8e\x62\x82\xd3\x05\x58\xaf\xf1\xb4\xbe\x9e\xde\x6b\x92\x87\x9f\xe9\xfd\x9a\x56\x99\x02\xb2\x21\x6a\xf3\x73\x89\xa1\x93\xfd\x10\xed\x22\xbd\xf7\x51\x7c\xe5\x9f\x9c\x17\x40\x3b\x97\xc1\x52\xc8\x02\x80\x99\x79\x26\xca\x36\x3f\x7a\x27\x24\x36\x7d\xaf\x59\x52\x8d\x64\xaa\x45\xd8\x96\xad\x47\x9d\x6c\xad\xd6\x6e\x54\x0f\x00\x0a\x83\x01\xa2\xe7\xa9\xf2\x71\x51\xe7\x6b\x5e\x68\x93\xec\x38\x10\xcf\x98\x2c\xc7\x0b\xed\xc6\xc0\xf3\x06\x0e\x6a\x58\xde\x68\xab\x07\xad\x6b\xc4\x02\xe0\xc8\x14\xe1\x2f\x1f\x0c\x0e\x33\xa3\x03\x1e\x0c\x0e\x24\xa3\x63\x60\xe8\x9b\xb2\xa2\xa8\x67\x7c\xfd\xfa\x72\x31\x68\x84\xdd\xb2\xad\xc5\x6e\x05\x22\x7d\xf0\x6c\x24\xf3\x1e\x4e\x82\x47\xb5\x47\xab\x89\x49\xae\x9c\x46\x07\x6c\xb7\xb6\x25\x42\xe5\xf0\x2b\x8b\x0f\x63\x91\x2e\xd3\xe3\x7e\xb3\x0e\x2a\x5a\x7e\xf4\x3b\xd5\x76\xa7\x99\x6d\x9b\x94\x28\xc6\x36\x13\x65\x5b\x82\xd0\xcd\xf6\xbe\x12\xa1\xa3\x72\x55\x3f\x39\x11\x32\xd5\xd9\x76\x53\xdd\xd9\x76\xf9\xa4\xb2\x21\x90\x93\x9e\x5a\x25\x3d\xb5\x4a\x7b\xa2\x22\xcb\x6a\xa1\xa7\x76\x49\x4f\xed\xb9\x9c\xf8\xc9\xb2\x32\xcc\x42\xb7\x13\x48\x4d\x45\x11\xe6\x1e\x1d\xbd\x70\xd7\xa7\x0f\x53\x84\x90\xcd\xa6\x0a\x25\x52\xc5\xaa\x64\xd6\xc9\xae\x16\x4e\x7e\x66\x15\x53\x44\xb4\x9e\x0a\xf2\xd1\xc1\x59\x5e\xcc\x6a\x05\x5b\x94\x62\x6f\x92\xbe\x8a\xd0\x13\x70\x6f\x37\x9b\xcd\x96\x1a\x78\xa2\xf1\x14\x41\xb1\x67\xe1\x69\xe2\x78\x33\xcb\x8b\x7b\x9d\xba\xf6\x45\xc3\x52\x98\x0a\x4e\x30\xee\x3c\x66\x2b\x5b\xca\x3a\xe6\x28\xba\x24\xe2\x2b\xa6\xb9\x93\xdf\xa1\x0c\x03\x0a\xb2\x9c\xaa\x92\xdb\x4c\x86\x4f\x6b\xa3\xc5\x19\x93\xc0\x9b\x9a\xbf\xa0\xc8\x41\x23\x44\x03\x0d\xff\xfd\xed\x54\xc5\x1c\x19\x93\xd4\xb7\xbd\xd3\x6e\x4f\xb3\x49\x09\x90\x39\x55\x98\x22\xea\xfa\x0c\xc2\x5c\xa4\xaf\x5f\x5e\x5c\xf5\x14\x54\x44\xc3\x52\x41\xae\xb5\xdb\x6c\x35\xf3\x72\xd7\x9d\x4d\xc0\x0b\x60\xe3\x00\x59\x34\x57\xb7\x55\x10\xfb\x44\xe5\xd1\xd5\xfb\xcb\x54\xd5\xbd\xe6\x5e\x33\x57\xd3\x74\xd0\x00\x5a\xf7\x49\x7e\x78\xd5\x10\x63\xc5\x45\xe5\xce\x8b\x35\x4e\x1b\x4c\x7e\xaa\x9d\xad\x19\x3e\xbe\x20\x11\x24\xed\x98\x7a\x85\x58\x01\x64\xe8\xf9\x92\x06\x21\x7a\xb9\x50\x33\x41\x84\x01\x0a\x9a\x93\xe9\x4d\x4d\xd3\xf2\xa7\xb1\x84\xb0\xed\x37\xf7\x9b\x53\x86\x55\x98\x70\x17\x5e\x80\x5b\x04\xa2\x68\xf4\xae\x17\x8c\xa0\xc3\xe7\xcb\x60\x81\x64\x30\xf8\x97\xc9\xac\x5f\x6e\xf0\x36\x90\x44\xdc\x09\xc0\x04\x44\x0e\x59\x22\x34\x9a\xe8\x0c\x13\x56\x09\x02\x36\x0e\x10\x0a\x3a\xb5\x6a\xfd\x11\xc2\x34\xa4\x30\x3e\x9a\x44\xe1\x5c\xe7\xee\xe4\x64\xb2\x69\xa1\xe8\x95\x5a\x2c\x7a\x55\xce\xf5\x55\x5d\xdf\x75\x08\x68\x34\x8f\x6a\xdc\xe0\xdb\x3c\x08\xa0\x3f\x9c\xd3\x29\x4d\x77\x39\x13\x67\x9e\xeb\x28\xb5\x87\xb9\xf2\xb9\xf3\xe8\x7b\xcd\xbd\x3d\xe5\x6e\xed\xed\x95\xee\xd6\x78\x1e\x3d\x33\xef\x79\x84\xff\x98\x81\x3c\x16\x6c\x19\x4f\x27\xc0\xc7\x18\xfd\xa8\xf2\x28\xcc\x16\x25\x24\x79\xf7\x5b\x84\x78\xf2\x22\x25\xa7\xb7\x3f\x8a\x1b\x3d\x15\x85\x73\xf4\x4a\xac\x03\xb8\x9c\x44\x03\x48\xd1\x40\x06\xdb\x62\xaf\xdd\xb0\x3b\xf8\xc8\x9d\xca\x3b\x6b\x51\x5c\xa1\xbb\xcb\x30\x18\x20\xd1\x40\x96\x7c\x74\xe1\x0d\xc4\x0e\xec\x39\xa8\x03\x9a\x63\x83\xd9\x29\x83\xd4\xa5\x96\xa4\x5e\xe8\xa4\x01\xea\x8a\x8b\x96\xa3\xe5\x59\x05\xf6\x8f\x22\x27\x38\xc3\x9a\x0e\x30\xfa\xd0\x21\x28\x09\xb5\x46\x44\x1c\x2d\x14\x34\xa0\xe3\x0f\xf3\xb1\x93\xac\x00\x53\x6c\x41\xc7\xf4\x3d\xbb\x03\x0c\x63\x92\x6c\x64\xe5\x2e\xac\xd5\x21\xf2\x72\x20\x1a\x13\x21\x4f\xd4\x9e\x73\x80\xbc\xd4\x75\x1a\xcd\x2d\x9e\xf1\x79\x46\x9d\x57\xf2\x39\x66\x47\xcb\xbe\x47\x46\x8b\x6c\x40\xbe\x4c\x93\xad\x90\x04\x5b\x8e\x67\x41\x87\xc7\xe8\x2b\x80\xc5\x04\xa6\x39\x42\x64\x28\xb2\xba\xb3\x0c\xf2\x6f\x78\xde\x6c\x56\x26\x55\xc4\xfc\xef\x5c\x13\x07\xdf\x20\x17\x11\x72\x1e\x78\x3d\xc4\x53\xc1\xdf\x40\xe7\x4d\x8b\x54\x55\x63\xd1\xfa\xde\x6c\x0d\x11\x74\xe8\x30\xfa\x90\xab\x1f\xa7\xcd\x2f\x34\x60\x5f\xee\x2b\x6b\x97\xcf\x02\xbb\x9c\xd9\x41\x6f\x72\x49\xa0\xd9\xb7\xc4\x6c\x64\x4a\x47\x79\xb9\x6a\xb3\x8f\x73\xc1\xc6\x38\x5c\x24\x48\xaa\x23\x03\x89\xcb\x31\x07\xe4\x98\x6d\x61\x9b\xf7\x26\xb7\xb7\x65\xa1\x0e\x23\x74\xce\x67\x2a\x07\xea\x44\xe7\x99\x1a\xa5\xd9\xcc\xe3\x4e\xf9\x9c\xab\x7b\x2d\x56\x19\xdb\x6d\x0a\x75\x2a\xfb\x2e\xab\x57\x3a\x40\x06\x9b\x3a\x99\x36\xe8\x2e\xb9\x4d\x2a\xce\x43\xdd\x53\x11\xd5\xf3\xd9\x48\x8a\x72\xd3\xe4\x5f\x4c\xbf\x1a\xa5\xd3\x28\x18\x21\x67\x33\x5b\x81\x3f\xf8\x84\xce\x11\x72\xe0\xfd\x25\xb2\x3c\xd7\x26\x1d\xb0\x9b\xa9\xe2\xa3\x00\x7b\xb6\xf2\x63\xf6\x04\xac\x1c\x48\xf2\x87\x76\x09\xf0\x48\xbd\xfe\x4b\x17\xff\x1e\x22\x42\x49\x01\x1c\x7e\xd8\x01\xad\xe6\x68\xad\x2c\x61\xbd\xea\xf0\x15\xb3\xcc\x17\x33\xc3\xd7\x20\x5e\x25\x87\x50\x19\x51\x54\xf1\xa8\x23\x73\xdf\xac\x8d\x3f\x80\xe3\xfa\xd5\xe1\x46\xc7\x47\xce\x2c\x8f\x44\x99\x8d\xec\x98\x8f\x44\xa9\xc3\x8d\xea\x70\xa3\x2b\x1d\x6e\x54\xe1\xab\x92\x97\xf4\xe6\x26\xea\xd5\xf7\x60\x79\x7c\x97\x95\xac\x1c\x38\x99\xf8\xa6\x9a\xf1\x34\xc2\xda\x4d\x04\xf4\x56\xa3\xd5\x68\xaa\x52\x45\x17\x17\x55\xba\xb0\x95\x96\xf5\xc6\x3d\x60\x1b\x27\xf2\x89\x5e\xa2\xe0\x01\xb5\xe4\x3e\x5e\xf9\xa6\xbd\x68\xc1\x4f\x21\xbb\xed\x35\xab\xbf\x67\x45\x43\x45\x85\xb4\xf7\x41\x69\x95\xe6\x7e\x76\x18\xb5\xba\x0e\x80\xbc\xda\xce\x44\xee\x8d\x77\xcf\xf5\xee\xa9\x3a\x30\x18\xe4\x84\x93\x3c\xb0\x4d\x10\x78\x21\x45\x41\xa6\xc8\x34\x1d\x6f\xd0\xf5\x42\xea\x87\xb4\xeb\xa0\x1b\x94\x15\x8d\x5e\x62\xb7\xef\xbd\xcc\xb5\xb0\x03\x88\xdd\xa3\x2c\x7b\x23\xab\xef\xec\x92\x97\xe0\x45\x79\x05\xd3\xf4\x61\x80\x5c\x7a\x39\x0c\xa9\xed\xdd\x96\xf4\xd2\x1a\x35\x59\x37\x35\xaa\x9a\x8c\x95\x72\x91\x45\xaf\xf0\x08\x79\x21\xcd\xf5\xc3\xbb\xa9\xa8\x61\x46\x98\x2c\x35\xad\x99\x8f\x55\x07\x58\x34\xfe\x03\xfb\xd7\xd8\x3d\xb0\xed\x20\x2f\x2d\x8b\x2f\x9d\xfd\x9d\x56\x6b\xad\xc0\x88\xdf\xdd\x1f\x30\x17\x72\xe9\x20\x95\x7c\x34\x98\x45\xb4\x69\x14\x17\x48\x03\xcf\x39\x77\xa0\x8b\x0e\x42\x3a\x3c\x8f\x2c\xf2\x49\xa5\x0f\x67\x1f\x8e\xf3\x7b\x14\x99\xd2\x54\xb3\x4b\xd9\x1e\x3b\x89\x95\x6f\x2e\x5c\x3a\xfb\x07\xb9\x37\x2a\x31\xf9\xfc\xec\xa8\xfb\xe1\xe0\xf4\x78\xad\x40\x19\xdf\x05\xde\x28\xdf\x71\x1f\x23\xc7\x96\x0e\xc0\xd9\x7f\x72\x9a\x64\x00\x14\x0d\x85\x24\x1b\x91\x81\x06\x9b\x40\xc5\x8c\x2e\xcf\x0f\x0e\x1f\x6b\x5a\x9c\xbc\x2a\xe6\x76\xf2\xe1\xf2\xea\xe0\xc3\xe1\x71\xf7\xe4\x7c\x79\x33\x13\x51\x88\x1a\xbe\x67\x9f\x9c\xab\xe6\x74\x79\x75\x72\xd6\x3d\x3d\xbe\x3a\xe8\xae\xde\x66\xa6\x26\x77\x78\xf6\xe1\xdd\xc9\x4f\xf3\xdb\xda\x89\xf7\x2e\x99\xca\xc5\xd9\xc7\xab\xe3\x8b\xee\xe9\xd9\x91\x62\x16\x1d\x40\x5c\x6c\xda\x2e\xa4\x13\x68\x9b\xa4\xa9\x71\x11\x3a\xa7\x2c\x91\xaf\x1c\xc2\xe0\x63\x08\x46\x55\xd1\xcc\x98\x69\x60\x38\xf5\xd8\xb0\xf6\xf0\x25\xea\x04\x15\x74\xeb\x2b\x15\xea\x70\x49\x42\x58\x60\x94\x0c\x3a\x99\x19\xd5\xd9\x85\xea\xa9\x18\xaa\x59\x14\x00\x32\xc9\xe0\x70\x0e\xe3\xc3\x9a\x53\xd0\xea\x8d\x0a\x49\xbd\x5c\xf2\xcd\x4a\x92\x79\xc9\x57\xab\x37\xb4\x7a\xe3\xd1\xd5\x1b\x79\x07\xc8\x59\x54\x14\x13\xbc\xb9\x58\x85\xf7\x10\x4f\x43\x4b\xa1\x58\x56\xf9\xd2\x56\x5a\x4f\x31\x36\x6e\x0b\xd0\x8a\x8a\x54\x85\xf4\x4b\x94\x92\x2a\xe9\xa7\x26\xe5\x55\xb6\xc7\xf7\xb2\xdd\x1a\x5f\xa5\xad\xf5\x2a\xdf\x88\x5e\xa5\xe4\xca\xd1\x8a\x15\x00\xb4\x62\x65\x1e\xd3\xd2\x8a\x15\xad\x58\x59\x15\xc5\x4a\x8e\x2b\xa9\xad\xdd\x50\xb5\x33\x66\x1b\x1a\x4e\x3f\xfa\x23\x2b\x57\x6a\xb1\x76\x60\x9c\x82\xa3\x72\x2b\x6a\xa9\x37\xc6\x6d\xca\x44\xc3\xc3\x79\xcc\x40\x6b\x58\xb4\x86\x45\x6b\x58\xbe\x75\x0d\xcb\x64\x0f\xda\xe7\xff\x5a\xa0\xce\x33\xf7\x45\xbe\x6b\x9f\x45\xd5\x52\x98\x4b\xc9\x7c\xc0\xc4\xea\x97\x47\xd6\x93\x88\x77\x64\x87\x9e\x4b\xd1\x5d\x8a\xac\x04\xa1\x7b\x40\x3e\x12\x14\x74\xc0\xce\xeb\x04\xe1\xfb\x44\x06\x5a\x4a\x0a\x2b\xfd\xf8\x73\x90\x49\xe9\x49\x24\xbe\xc9\xff\x76\x76\x1b\x3b\x8d\xe6\x62\xfc\xf4\x53\x21\x09\xc0\x18\xaf\x5b\x26\xe5\xff\x94\xa5\xae\x7c\x0c\xc1\xdb\x39\xde\x00\xe7\x4f\x9e\xaf\x1a\xa2\x44\x0c\xfb\xe9\xe2\xe0\xdd\xc1\x07\xc6\xc1\x5f\x5c\xa9\x58\x52\x63\xbb\x20\x84\xca\x86\xef\xba\x07\x1f\xaf\x7e\xee\xbe\x3d\xb8\x3c\x39\xec\x1e\x7f\x38\x78\xfb\xfe\xf8\x48\xd9\x43\x0e\x3b\x8a\x5d\x1c\x7c\x38\xfb\xf0\xaf\xd3\xb3\x8f\x97\x95\xdd\x30\x96\xa0\x5e\x2f\x67\x17\x3f\x75\x2f\xce\xde\x2b\x59\x6c\x2e\x6b\xab\x7b\x39\x3f\xb8\xfa\xf9\xb2\x7b\x74\x70\x75\xa0\x6a\xb8\xc5\x4e\xe2\x56\x11\x79\xe6\x20\x21\x8f\xe3\xf1\xd9\xc8\xa5\xcc\x7d\xc9\xb4\x92\xb6\x64\xd8\xf3\x60\x60\x13\x33\xe3\xed\x1b\x97\x97\xf3\xd4\x37\x30\xd8\x72\x70\x2f\xbe\x86\x93\xae\x24\xab\x9d\xef\xaa\xf1\x1b\xf1\xdc\x2c\xa3\x4d\xc2\x9e\xe8\x4e\x59\x77\x32\xa1\xa0\xb0\x14\xf1\x6f\xee\x9b\x3c\x8f\xe5\xa8\xba\xab\x5a\x52\x69\xfd\xb9\x2c\xcb\x47\x41\x9f\x3d\x03\x76\x2d\x34\xc7\xd5\x29\x7b\x1d\xbf\xc8\xf2\x66\x73\x59\x6b\x24\x15\xcd\x6f\x9d\x85\x1e\xc7\xaf\x51\xdd\x64\x2e\xeb\x63\x99\xc4\x1c\x0f\xda\x73\x5c\x60\xb1\xcb\xf1\x2b\x2c\x69\x33\xe3\x12\x45\x58\x8a\x79\xac\x2c\xd7\x53\xd5\x82\x54\x55\x67\x5c\x87\x08\xe0\x3c\x8f\x75\xe4\x7a\xaa\x5a\x87\xaa\xea\x3c\x9f\x5c\x09\xcd\x48\x34\x53\x3f\xf0\x6e\x30\x63\x91\xb1\x3b\xe0\x84\x43\x92\xae\xf4\xdf\x9c\xb1\x2e\x99\x6d\xbe\xda\x7c\xa7\x13\x43\x31\xfe\x93\x57\xb0\x51\x30\x66\x52\xca\xda\x5a\x7b\xa0\xb5\x07\x5a\x7b\xf0\x8c\xb5\x07\x69\xde\xb9\xf8\xc0\x6e\xd2\x37\x70\x19\x06\x5a\xc9\x78\xa3\x91\x4f\xef\x8f\x70\xd0\x01\x0f\x5f\xd7\xa6\x65\xb2\x2b\x26\x92\x9d\x49\xf6\x39\x9e\x99\x1a\xa1\xa4\xef\xe9\xb8\xe4\x39\xcc\xa7\xa2\xff\x99\x58\xdc\xb9\x4d\xad\x6a\x98\xa9\x19\xd3\xb9\xcd\xae\x6c\x88\xe9\x59\xca\xb9\x4d\xad\x74\x8c\x89\x79\xc1\x39\x4c\x49\xdd\xf5\xc4\xdc\xdc\x1c\x66\x92\xef\x7a\x92\xb8\x90\xf3\x57\x60\x3e\x5e\xb4\xc8\x5c\x4c\x93\x4c\x6c\x18\x4e\x28\xde\xa7\x06\xce\x0d\x5d\xad\xe8\xcc\xae\x0c\x94\xa9\x3e\xd3\x15\x14\x6b\x01\x4f\x4d\xed\x59\x74\x0f\x2b\x4b\x04\x05\x94\x3a\x4e\x33\xd6\x64\xda\x9e\x75\x8d\xb8\xd3\x97\x40\x40\xfe\xef\xce\x4d\xb3\xd1\x6a\x27\xf3\x2d\x42\x59\xf1\x9c\xdf\x04\xc6\x96\xe7\xd3\x74\x3f\x46\xfa\xa3\x3c\x26\x99\x32\x51\x2d\x7b\x80\x64\xdc\x87\x1c\x23\xcf\x3a\xb8\xc9\xfc\xdc\x31\xd6\x94\x1a\xcb\xe8\xa0\x1f\x1c\x5e\x9d\xfc\x72\xac\x34\xe6\x97\x98\xf2\xcb\x0c\xf9\xf5\xcc\xf8\xd1\xb8\x97\xc7\x17\xbf\x1c\x5f\x74\x0f\x2f\x8e\x8f\x8e\x3f\x5c\x9d\x1c\xbc\xbf\xec\x7e\xbc\x3c\xbe\xc8\x39\x3d\x94\x4c\x41\x58\x43\xff\x81\xee\x15\xd3\x50\xed\x82\xf8\x87\xb3\x52\x21\x41\x41\xc6\xc5\xa1\x62\x42\xe7\x07\x97\x97\xff\x3c\xbb\x38\x5a\xe8\x84\x7c\x48\x88\x3f\x0c\x20\x29\x4e\xe9\xfc\xe2\xec\xf4\xf8\xea\xe7\xe3\x8f\x97\x5d\x36\xbb\x93\xc3\xe3\xee\xc7\x8b\xf7\xf9\xd9\x08\xbf\xb5\xce\x56\x2a\x86\x61\x67\x3f\xed\xfe\x96\x5b\xe1\x3f\x8f\xdf\x76\x2f\xce\xce\xae\x8a\xfd\x6c\x65\x27\xa9\x56\xa3\x9a\xe9\x05\x29\x83\x1c\x14\x64\x54\x45\x55\x23\x75\x71\x28\xf4\xbd\x6a\x6d\x6f\xcd\x60\x10\x55\x33\xab\x20\x57\xe9\xc5\x6b\x29\x57\x4b\xb9\x5a\xca\xfd\xd6\x82\x2c\xe4\x23\x67\x2f\x2c\xc2\xc2\x34\xf1\xb4\x53\x02\xc1\x6c\x51\x9d\x15\x0f\x01\x94\x81\x9e\x17\x14\x62\xaf\x1e\x5f\xeb\xa7\xdd\x70\x0b\x4b\x2d\x59\xac\x72\xb9\xca\x05\x83\x62\x4c\xe8\x8a\xd0\xdd\x53\xf9\x11\x64\x56\xa0\x5c\xc3\xdc\xde\x70\x28\xd6\x52\xb9\x1a\xb0\xba\x2f\x37\xca\xd3\x78\x2e\xc8\x9f\xb0\x6a\x40\x30\xce\x8f\x2f\xb4\x89\x49\x98\x68\x40\xab\xb4\x5a\x9a\x99\xd0\xcc\x84\x66\x26\x9e\xaf\xca\x5c\xa5\x38\x90\x61\xde\xb2\x37\x72\xd5\xf3\x31\x5e\xb5\xd6\xe3\xb1\x7a\x4e\x53\x39\x97\xa9\xea\xc8\xa5\x85\xaf\x51\x1e\x85\xb2\xd7\x4f\xe3\x82\x65\xb2\x1a\x50\xf1\x34\x26\x74\xf1\x5d\x67\x6b\x6b\x8b\x5d\x9a\x62\xc9\x8d\xdc\xfd\x19\xbf\xca\xe9\xe3\xc1\x25\xf5\x02\xf4\xf1\xe2\xfd\x9b\x91\xc5\xe4\xdb\xb4\x29\xa0\x91\x66\x97\x1a\xe4\xc6\xea\xe4\x42\xad\x26\xbd\x1c\xa1\x3e\x0c\x1d\xfa\x21\x62\xb5\xde\xe4\x38\xad\x74\x8b\x90\xa0\x03\x1b\xfa\x14\x05\x87\x17\x47\x44\x15\x8d\x94\x06\xd0\x42\x5d\xf1\x00\xa9\x1b\x06\xce\x1b\x29\x7c\xa7\x9e\x24\x6d\x41\x1f\x6f\xdd\xb4\xb6\x88\x0f\x5d\x52\xad\x7d\xf9\xe9\xec\xe8\xf8\xed\xc7\x9f\x8a\xa2\xb8\x31\xb0\xf8\x48\x6f\xda\x73\x91\x94\xc7\x09\xf3\xea\x57\x0f\xe3\xde\x3c\x94\xd8\xd2\x2b\xa8\x63\xb6\x4f\xf6\x31\xfe\x54\x1a\x3a\x54\xe9\xaf\x27\xbd\xf5\x24\x43\x94\xfd\x54\xc4\xef\x1a\x41\x2b\x4b\x42\x56\x56\x3d\x08\x9d\xdb\x73\xd0\xb9\x9d\xe8\x56\xf9\xd7\x28\xcf\x4a\xf9\xe7\xcc\x83\xc9\xb2\xc7\x92\xd5\x0f\x25\xb3\x17\x45\xfe\x91\x64\xc5\x8b\x40\x33\x2f\x18\x25\x2d\x22\xd6\xfb\x5d\x3a\xd8\xaf\x0c\xf5\x2b\xdd\x43\xd8\x28\x5b\x7c\x3a\x5d\xd1\x01\x17\xd7\x1a\x74\xe4\x3b\x6b\x13\xbc\xf5\xcb\xbd\xf4\x53\x1e\x58\xc5\xc3\xac\x09\xd5\xa4\x95\x4f\xb2\xc6\x3e\xc8\x1a\xf7\xb6\x6e\x19\x93\x51\x2b\x74\xd5\x2f\xea\x16\x34\x1f\xe5\x4b\xba\xe7\x79\x3f\xce\x35\x6e\xc2\x94\xb9\xa4\xbe\x2d\xdd\x43\x1a\x48\x8f\xaa\x7e\x48\x4f\x64\x0a\x0d\x44\x7e\x1d\x2b\xa1\x84\xc8\x4f\x4a\xeb\x21\xb4\x1e\x42\xeb\x21\xb4\x1e\x42\xeb\x21\xb4\x1e\x42\xeb\x21\xb4\x1e\x42\xeb\x21\xb4\x1e\xe2\xf1\xf5\x10\x45\xd6\x79\x72\x55\x44\xdc\x87\xd6\x46\x68\x6d\xc4\xb3\xd2\x46\xd4\x4c\x8c\xbc\xa8\x54\x84\xe3\xd3\x25\xbf\x00\x57\x67\x47\x67\x1d\x60\x0b\x92\x1b\x0b\xcf\xc0\xf6\x10\x71\x5f\x52\x30\x84\x37\x22\x99\xeb\x26\xb8\x1d\x62\x6b\x08\x3c\x17\x01\x4c\x40\xc0\x78\x40\xf0\xb7\x65\x64\x5c\x56\x88\xbe\xd6\x10\x59\xd7\x24\x1c\x49\xdf\x4e\x53\x60\x41\x07\xf4\xf7\x6c\xd8\xdc\xeb\xbd\xea\xed\x59\xad\xd7\x4d\xdb\x46\xaf\xdb\x2d\xd4\xb7\x5f\xed\x35\xdb\xaf\x9b\xbd\xf6\x7e\xb3\x85\x5e\xef\x36\xed\x1d\x08\x5b\x7b\xaf\x50\xaf\xf7\xca\x6a\xb7\x7b\xa8\xbf\xdb\x7b\xbd\x07\x77\xb6\xfb\xfb\x2b\x98\x35\x31\x01\x9e\x12\xa2\x60\xfa\x9c\x89\xe9\x4e\x56\x54\xb9\xc0\xa7\x38\x45\x54\xca\x38\xea\x5c\xed\x98\x94\x6c\xa0\x39\x47\xa4\x2c\x32\xe1\x46\x3c\x2f\xa3\x94\x39\x67\x71\xf2\xde\x74\x14\xdc\xb9\xc8\xfb\xf7\x53\xe0\x5b\xac\x4a\xb6\xdf\x42\x77\x96\xcf\x72\xc5\xa2\x80\x55\x25\xd3\x70\xe3\xe3\x03\x49\xc4\x89\xc9\xcb\x19\x94\xd6\x7c\x22\x4d\xa8\x52\xaf\xf9\xaa\x39\x4c\x93\x72\x2d\x17\x89\x93\x8a\xf0\x8c\xca\xb6\x3a\x9e\xe0\x1c\xe2\x09\x16\x05\xa8\x4a\x11\x2a\xb5\xa8\x93\xf7\x67\x57\xdd\xf3\x8f\x97\x3f\x77\xaf\x7e\xbe\x38\xbb\xba\x7a\x7f\xdc\x3d\x3c\xfb\xf8\x41\x1d\x3c\xa4\xd5\x6c\x96\x77\x72\x75\x71\x70\x78\xdc\xbd\x3c\x38\x3d\x7f\x7f\xf2\xe1\xa7\x3a\xed\xa7\x89\xb5\xb1\xdb\x6c\x8e\x72\xe5\x23\x34\xf2\x82\xfb\x0e\x68\x37\x77\xf6\x4e\xf1\x24\x91\x38\x32\x84\xae\x32\xde\x9e\xe4\x34\xf2\xaf\xa1\x17\x16\xb6\x6f\xf5\x23\x01\x2b\x45\xab\xed\x71\x15\x76\xc7\x55\x78\x3d\xee\xf6\x6b\x4d\x18\x17\xb7\x46\x18\xd8\x2c\xe1\xaf\x10\xb6\xaa\x2c\xbf\xac\x0f\x85\xa8\x35\x6d\x98\x57\x7d\x2d\x3e\xb3\x30\xab\x4b\x08\x2c\x34\xe7\x5b\xa8\xf2\x2d\x76\xfe\xd2\xac\x99\x3e\x74\x3e\xd6\xaa\x2a\x0e\x16\xe8\xc0\x93\x59\x3c\xd7\xf6\x27\x6d\x7f\x1a\xb7\xc9\xcf\xf7\x51\x4d\xf2\x2a\xb1\x42\x35\x14\x29\x6c\xe8\x10\x01\x2f\xc0\x03\xec\x42\x27\xd1\xda\x0c\x21\x89\xa4\x69\x20\xef\x9a\x4d\x10\x78\x0e\x22\x9b\x00\x51\x6b\x0e\x8a\xa5\x64\x92\x93\x39\xc6\x64\xda\x25\x7a\x8c\x6c\xe9\xa2\xdf\x80\x67\x86\x9b\x46\x17\x93\x9d\x6e\xf9\x42\xc0\xd3\x7f\x00\x5e\x58\x51\x95\xbe\x45\xb1\x7c\xa0\x78\x12\xce\xaa\xa5\x1f\xdf\xde\xb4\x1b\xdb\x8d\xd6\x3c\x74\x2b\x3c\xcf\x82\x69\x12\xea\x05\x70\x80\x1a\x94\xd8\xbd\x46\x80\x28\x72\x19\x44\xdf\xbc\x1a\xbe\x2c\xd6\x95\xcf\xc2\xfb\xd8\x41\x6f\x38\xaf\x91\x3a\x7f\xc9\x9f\x8d\xfb\x91\xf3\x72\xc2\x34\x23\xfb\xc5\x94\x1a\x89\x15\x26\xf5\xa1\xd4\x80\x36\x4e\x21\x62\xca\x9c\xf4\x6a\xa5\x48\x6e\xf8\xe9\xf5\x2e\x66\x85\xe6\xa5\x30\xc8\xc2\x59\xc6\xfa\x12\xb0\x12\x1b\x4d\xb5\xa0\xac\xe0\x41\xcb\xd8\xbe\x79\x31\x99\x85\xe9\x4d\xc6\x69\x4a\x55\xfd\xa9\x67\x23\x66\xf6\x6f\x8e\x63\x26\xd5\xdc\xa9\xec\x45\x33\x9c\x9a\xe1\xd4\x0c\xe7\x37\xca\x70\x46\xe1\xae\x2b\xd9\x4d\xde\x02\x1c\x1e\x80\x5b\xb6\x2a\xec\x0e\x00\x74\x9c\x84\xf3\x23\x73\x33\x56\x5a\x98\x42\x1b\x39\x93\xb1\x95\xd1\x12\xd2\x4c\x65\xa6\x6c\x42\x8b\x63\x34\x89\xd5\xb3\x03\x66\x56\x55\xb6\x5a\x30\xbd\x35\x30\x01\x3f\x58\x5d\x7b\xa0\x9c\xe4\x14\x16\xc1\xec\xf2\xc0\x38\xcd\xb4\xac\xbe\x60\x8b\xa0\x74\xbb\xf3\x7d\xe4\xda\xa6\xed\x12\x93\x63\x7e\xde\x89\x4d\xd4\x1a\x04\xbe\x65\x32\xde\xeb\xcd\x5e\xf3\x55\x53\xfd\x79\xe8\x11\xca\xba\x78\x53\x5c\xad\xa8\x15\xc3\x4f\xf0\xc9\xa6\x3b\xce\xc5\x4e\x36\x0b\x09\xf5\x46\xa9\x19\x56\xe8\xb6\x32\x96\xc5\x4e\xaa\x62\xa3\x72\x08\x82\x9c\xbe\x49\xf0\xc0\x45\xb6\x69\x41\x35\x08\x68\x10\x12\x6a\xda\xde\x08\x62\xf7\x8d\x25\x74\xe4\x0d\xc7\xb3\xa0\x33\x3f\x4e\x54\x73\x42\x9a\x13\xd2\x9c\xd0\xb7\xc7\x09\xf1\x3b\x6c\x10\x40\x7f\xb8\x50\xbf\xac\xf4\x40\x93\x72\x3a\x99\x96\x29\xfa\x9f\x2b\x5f\x6a\x0e\x98\xc2\xe8\x55\x33\x03\x4f\x4b\x2b\x56\xc5\x4e\x28\xd7\x36\x86\xa7\x48\xb7\x79\x94\xe4\xb7\x7b\x7b\x95\x06\x69\xd3\x4c\x34\x13\xdc\x0b\x69\x4c\xb4\xbc\x19\x14\x58\x79\xb8\x81\xb4\x47\xcf\xde\x5c\x94\x57\x13\x0c\xa1\x19\x06\xcd\x30\x68\x86\x41\x33\x0c\x93\x30\x0c\x82\x06\x9d\x70\xca\xe3\x05\xff\x44\xbd\xa1\xe7\x5d\x2f\xc1\xa5\x5b\x0e\x6c\x62\x39\xf2\x84\x4c\x84\x72\xda\x19\x76\xa2\xbc\xc6\x84\xaa\x94\xc2\x4c\x57\x50\xa7\x52\xbe\xd8\x7a\x20\x01\xd3\xeb\x5b\x14\x1b\x09\x56\x57\xf1\x92\x9f\xed\x14\x1a\x98\x42\x17\xb7\x39\x40\x8e\x63\x9f\x44\xfb\x6e\xd4\x7e\x29\xba\x19\x0b\x1e\xa2\x80\xbe\x8b\x6d\x84\xf2\x12\x60\x26\x9a\xad\xc0\xf3\x28\xb7\xd6\x34\x7c\x85\x42\x83\x3a\xa4\xbc\x29\xfb\xb7\x69\x0d\x21\x76\xcb\xda\xfe\x03\xdd\xab\x9b\x5e\xa3\x7b\x65\x1b\x01\x96\x43\x6e\x76\x4a\xb7\x12\xe5\x5b\x8a\xec\x38\xac\x15\x4b\x9e\x50\x6c\x23\x2a\x6f\xb1\x8f\x85\x16\xc2\xf2\x78\xc8\x1e\x60\x9c\xb8\x14\x05\x37\xd0\x79\xd3\x26\x55\xd5\xc4\x3a\x44\xc1\x32\x3d\x5e\xeb\xa4\x30\x1a\xeb\x80\x96\x02\xfd\xa4\x89\xa4\x19\xdc\xcd\xea\xac\x44\x85\x7d\xaa\x37\x46\x05\xdf\x8d\xee\x92\xe3\x5c\x1a\x5a\x3d\x99\xeb\x56\x48\x82\x2d\xae\x42\xdb\xea\x61\x77\xab\xe4\x52\x4a\xb7\xf0\xd9\x98\x8a\x72\x2e\x3d\xf4\x90\xc9\xd8\x6f\xc5\x6e\xa7\x11\x55\x62\xcd\x0e\x19\xef\xba\xbf\x53\xe5\xba\xbf\x53\x53\x50\xf8\x76\x61\x32\x93\x64\xb3\x48\xa7\xca\x69\xdd\x29\x6b\xd2\xa1\xea\x43\x38\x6e\xae\x66\xc5\x96\x63\x8a\x46\xb9\xeb\x8a\x73\xa4\x8a\x73\x2e\x04\xd1\xcc\x07\x2d\x2c\x6a\x61\x51\x0b\x8b\xdf\x9a\xb0\x48\x03\x68\xb1\x44\x83\x2a\xe9\xd0\xfc\x0d\xa2\x01\x0a\x64\xd8\xf4\xf9\x85\x2f\x13\x43\x4e\x26\x1d\x8a\xa9\xa4\xa5\xc1\xa4\x9b\xc9\xf4\xca\x53\xc8\x62\xa9\xc1\x4b\x26\x00\x56\x5f\x7d\x2c\xf0\x35\xf6\x1d\xc4\xde\x16\xb1\x02\xe8\x17\x93\x48\x67\x2b\x09\xbd\xa4\xd1\x7a\xf5\x6a\xef\xd5\x64\x7a\xe8\x1c\xd4\x54\x4e\x97\xa2\x4a\x84\x83\xd0\x71\x4c\xec\x9a\x9e\x8b\x3a\xad\xc6\xde\xa2\x74\xcd\x2c\xa8\x4a\x75\x0d\xbe\xd6\xea\x2a\xbb\xaf\x5f\xef\xe6\x15\xb8\x71\x90\x8b\x8f\x47\xe7\xd5\xad\x5f\xed\x6d\xb7\x66\x6a\xdd\xae\xd9\x7a\xcc\xf3\xa7\x55\x7c\x6c\x74\x78\xf6\xfe\xfd\xf1\xe1\xd5\xd9\x45\xf7\x7f\x4f\xce\xff\x71\xf2\xa1\xfb\xf3\xd5\xd5\x79\x79\x22\x76\xb6\x9b\xaa\xc7\x94\xa7\xc7\xa7\x67\x17\xff\xea\x9e\x1e\xfc\x8f\x78\x51\x79\xa9\x6c\xbd\xdb\x2c\xc9\xe3\xfe\xdf\x1f\x8f\x2f\xfe\xc5\xb2\xb8\x1f\xf3\x04\xe8\xaa\xc6\x5b\x05\xfc\x9e\xda\xe4\xa0\x34\x05\xe4\xb1\x70\x7a\x73\x43\xcd\xee\xb5\xa9\x41\x73\x8f\x9a\x7b\xd4\xdc\x63\x9e\x7b\xac\xe4\x1f\x07\x90\xa2\x5b\x78\x9f\x7e\x16\x04\x43\xea\x11\x0b\x3a\x48\x91\x6f\x27\xfa\xc6\xc8\xfd\x4d\x3b\xcd\x38\xfe\xec\x05\xf8\x0f\x46\xe8\x9c\x73\xcf\x3e\x88\xba\x08\x4a\xb9\x48\x34\x60\xc0\x91\xc3\x4f\xc6\x4b\xe6\x9b\xc6\x1c\x5d\xb4\x98\x89\x79\xca\x11\xbc\xbb\x88\x2d\x07\x8c\x31\x18\x61\xf7\x22\x6b\x4a\x60\xab\xb9\x82\xc1\x00\xd1\x98\x8a\x66\x00\xe3\xfb\x09\x2b\x0d\x00\x00\x05\x76\x1a\x80\x6a\x00\x8c\x10\x0d\xb0\x25\xd7\x69\x02\x11\xa8\xf5\x42\xde\xea\x6b\xd9\x4b\xbe\x93\x4b\x77\x68\xf9\x61\x5c\x42\xf9\x24\x0f\x6e\x10\xf3\xc2\xfb\x48\xb1\x83\xff\xe0\x6c\x2a\x33\x4e\x17\xc2\x18\xcf\x6f\x3f\xb1\x3b\xfd\x86\x62\xf7\x59\xec\x28\x76\x1f\x6b\x4b\x6b\xa6\xd2\x5a\xce\xc9\x9e\x26\xaf\xd6\x8c\x51\xad\x13\x91\xa6\xb0\xef\x8a\x9d\x57\xef\xfd\x98\xdd\x2f\xd9\xff\x92\xa5\x3f\xf9\xe3\x3c\x65\x8c\xf2\x67\xb2\x8f\xe9\xd5\x3f\xfe\x31\xce\x87\x74\x5b\xd2\x31\x1e\x1b\xe0\x6d\xde\xd1\xd9\x1e\xf3\xee\x8e\x66\x9a\xda\x6b\xe5\x4e\xe7\xf7\x39\xbf\xcb\x95\x7b\x3c\x99\x22\x2f\x32\x3f\x64\xb4\x78\x39\x69\x5d\xac\xeb\x52\xd4\x54\x6c\xa5\x68\x6b\xfe\x1e\xa2\x31\x27\x39\xa7\xce\xaa\xd2\xdf\xc9\x3e\xb1\xdb\x0f\x60\x3c\x82\x9c\xec\x3c\x14\x7c\x29\x25\x50\x24\xce\xf3\xf9\x9b\xa9\x27\xae\x45\x19\x58\x15\xa3\x54\x6c\x46\x56\x29\x94\x7d\xcd\x9d\x5e\x5b\xfe\x32\xad\x0f\x5e\xcb\x73\x9c\x29\x3c\x66\x4a\x61\x1a\xf7\xb7\x10\xb0\x9a\x25\x93\x37\xa9\x35\x84\xae\x2b\x9f\x76\x48\x08\xef\xb4\x5f\xbd\x5e\x53\xc3\x37\x03\x5d\x59\xaf\xb4\xef\x78\xef\x92\x7e\xf7\x94\xbd\xec\x29\x47\x2b\xdf\x35\x20\x4f\xa9\x8c\x6b\x74\x72\x3e\xc3\x3e\xc2\x81\xb8\x1a\xe6\xb1\x87\xbc\xaf\x85\xee\x9f\x18\x41\x84\x9a\x36\xe9\x30\xc0\x7d\x9a\x82\x70\xac\xe1\x2c\xea\x16\xd3\x20\x97\xd5\xb2\x7d\x5a\xde\xc8\x87\x56\xba\xb7\x58\xe3\x59\xdd\x9b\xac\x96\xed\xad\x87\x5d\x18\xdc\x67\x3b\x6b\xd7\xeb\x8c\x55\xb3\xa2\x8d\xed\x80\x0f\x9e\x8b\x2a\x91\x61\x6d\xba\x2b\xb6\xe8\x46\x57\x03\x67\x04\xe0\x1f\xcd\x0a\x92\x43\xfa\xdc\xb5\xe9\xe7\x55\xe5\x69\xc0\xa6\x8a\x55\xb7\x66\x26\x9e\xc0\x5c\xaf\xcb\x3a\x96\xa3\x09\x08\xd1\xfc\xe9\x0c\x5b\x74\x4c\x2c\x41\xca\xab\x5b\x09\xae\x52\x2a\x53\x8d\xa3\xb3\xb8\x7e\x8e\x42\x06\x1b\x77\x20\xfd\xdb\x8a\x88\x0b\xed\x11\xe6\x3a\xa7\x00\x0d\x30\xf7\x7f\xc4\x9e\xdb\xb8\xde\xe3\xca\xa5\xac\xa9\xef\x54\xf6\x25\x47\x39\xcc\xa4\x6a\x7f\xa2\x3e\xa2\x12\x2e\x99\x7b\x32\x3f\xe5\xd8\x3a\x27\xc6\x75\x30\x72\xa5\x6f\x5b\xce\x57\x31\xcf\xcb\x8f\x71\xe0\x28\x87\x43\x5a\x9b\x6f\x48\x9b\x60\x64\xb5\xb0\xe0\xdb\xd0\xb5\x1d\x14\xdb\xf9\x82\xd0\x49\x3b\xc6\x08\xdd\x1f\x3f\x0e\xe0\x57\x60\x1c\x5e\x1c\x1f\x5c\x1d\x1b\xe0\x73\xca\xc2\x89\x7f\x0a\xbc\xd0\x67\xdf\x0d\x23\x53\x2e\xb1\x82\x7f\xb9\x69\xa5\xbe\x25\xc6\x02\xf0\xab\xe1\x7b\x36\x91\xdf\xfa\x10\x3b\x61\x80\x22\x2b\xdd\x3b\x88\x9d\xb5\xcc\xd2\x2e\x33\x68\xad\x8c\x86\x03\x62\x6d\x08\x5b\xa6\xb0\x36\xbb\xcc\x95\xd6\x1e\xa7\x89\x64\xc1\x63\x53\xb8\xee\x7b\xb6\x8d\x49\x10\xf2\xc0\x13\xbd\xd0\x1e\x20\x55\x06\x70\x21\x72\xe7\x50\xfb\xdc\xb3\x8f\xe2\xa6\x6f\x79\xd3\x52\x8c\x16\xe3\x4e\x86\xc7\x71\x9b\x94\xa2\x2a\x2e\x99\xd0\x97\x59\xb6\x14\xb7\x92\x10\x76\x0e\x12\xef\xe3\xfa\x01\x88\x52\x33\x50\x0e\x97\x1f\x70\x42\xad\xf0\x92\x76\x63\x3a\xb5\xf0\xb4\xda\x42\x90\xcd\xad\x96\x1f\x3c\x06\x59\xf6\xc3\x2c\x5b\xa5\xdc\x98\xca\x29\x94\x4c\x22\x4f\x7e\x67\x86\x3c\x76\x1f\x13\xf4\x85\xd1\xe3\x65\x63\x77\x69\xc0\x57\x4c\xa2\x6c\x1a\x0c\xfc\x93\xa8\x5e\x97\x74\x7c\xa6\xd1\xbd\xc6\x6d\x16\x98\x52\x10\x54\xe5\xc2\x9f\xf9\xea\x4b\xad\xa0\xfa\xea\xab\xce\xf4\x27\xbb\x99\xfb\xc9\x9a\x52\x97\x9a\x6e\xb6\xf4\xad\x49\x06\x9f\x79\x77\xb2\xeb\x98\x65\x83\x92\x9e\xc6\x1d\xbf\xbc\xca\x74\x59\xc7\x6f\xe9\x3a\x53\x90\xcf\xb9\x30\xfb\x61\x8a\xc7\xaf\xde\x2a\x51\x6f\x92\x3b\x50\x46\xc5\x2b\xc2\xdd\x90\x5f\x62\xb7\xb9\x9b\x16\x77\x8b\x6b\x1b\x72\x0f\x20\xa5\x01\xee\x85\x14\x8d\xa0\x8b\xfb\x88\x94\xee\x40\x14\xf8\x7a\xf9\x26\x8b\x78\x8a\xb2\x6f\x11\x40\xb3\x81\x63\x47\x70\x6e\xa7\xbf\xe2\x78\x7c\x72\xde\x3d\x38\x3a\xba\x38\xbe\xbc\x4c\x57\x0d\xb1\xad\xa8\x7b\x79\x75\x11\xc5\x91\x8f\xea\x11\x14\x54\x57\x94\x4e\x3f\x8d\x21\x82\x76\xca\xb5\xaf\x50\xb9\x7b\x7a\x70\x9e\x69\x30\x6e\x06\x71\xc7\x1e\xa1\xf5\x6a\x32\x4f\x44\xaf\x66\xaf\x5c\x40\xaa\x55\x33\x40\x90\x78\x6e\xdd\xba\xcc\x49\xa3\x26\xc4\x98\x5f\xe6\x08\xd5\xab\x4b\x3d\x0a\x9d\x2e\xc1\x7f\xa8\xea\x9f\x7c\xb8\x7a\xb5\x93\xa9\x5e\xbb\x22\xc5\xca\x19\x5c\x9d\x9c\x1e\x5f\x5e\x1d\x9c\x66\x77\x8c\x21\x03\x57\xb3\x8d\x9b\x33\xf1\x3d\x97\xa0\x86\xe5\xd9\xe3\x66\x21\x6b\xda\x52\x15\xa0\xa8\x7d\xf4\xf1\xe2\xe0\xea\xe4\xec\x43\xb6\xc1\x04\xd8\x26\x5b\xd4\x86\xa0\xac\x5f\xbf\x66\x4d\x18\xca\xda\x2c\xc0\x51\x57\x04\x2c\xaf\x09\x46\xde\x62\x84\x08\x61\xb7\x43\x65\x13\x21\x68\x8f\x3f\xdc\x51\x3d\x76\xb8\xc1\x0b\x70\x74\x7c\x7e\x71\x7c\x78\x70\x75\x7c\x54\xa7\x95\x1f\x60\xd7\xc2\x3e\x74\xaa\xc7\xb0\x11\xa1\xd8\x15\x2a\xa1\xb1\x13\x4a\x57\x9e\xa2\x7f\xae\x39\xab\xda\x2b\xcb\x73\x5d\xa1\x20\x68\xa0\x9b\xb1\x08\x9c\xaa\x8d\xed\xda\x55\x03\x64\x21\x7c\x83\xec\x46\xef\x9e\x22\x52\x77\x3a\xd9\x56\x5d\x8e\xa5\x75\xdb\x12\xf6\x2a\x7b\xa2\xd1\x92\x16\x93\x8d\x54\xf7\x7c\xa6\x9a\x8c\xa8\xa3\x9a\xd6\xdb\xb3\xb3\xf7\x45\x18\xf0\xfb\x05\xd9\x5d\xc2\x93\xfd\x74\x39\x91\x1d\x07\x77\x8a\xee\x68\x23\x56\x92\xd6\xaa\xcd\x4e\x2a\xa1\x70\xe4\x8f\x3d\xae\xe9\x16\x63\x2b\xbf\x60\xc6\xde\x00\x59\x90\x22\x7b\x13\x5c\x23\x9f\x82\xbe\x17\x00\x6e\xd9\xa0\xb8\x87\x9d\x38\xb0\x9f\xec\x35\x40\x0c\x63\xa3\xa0\x5f\x63\xa0\x94\x6d\xc1\x79\x94\x5a\x8b\x8d\x9b\x8c\x3d\x7d\xd0\xc7\x8d\x9c\x1a\xb2\xbc\x62\xc4\xe2\x8f\xad\x18\x2b\x13\xc7\x57\xad\xb7\x8b\x11\x19\x82\x21\x1d\xd6\xbd\x25\x32\x6d\x60\x68\x63\xe4\x5a\x88\x4c\x34\x0e\x7f\x37\x80\x82\x09\xda\x58\x0e\xc4\xa3\x09\x38\x21\xde\x28\x80\xb7\xdd\x31\x0d\xb3\x8d\x7c\xdc\x65\x8e\x9d\xd5\x95\x7b\xd0\x6a\xf8\x28\xe0\x7a\xfa\x1b\xd4\x88\x68\x4a\xb7\x84\x34\x57\x34\x45\xfd\x3e\x3b\xae\x37\xa8\x2b\x24\x97\x6e\x15\x56\x15\xe4\xda\xb9\x70\xdd\x89\xf7\xea\x4a\x70\xdd\x92\x1e\xd6\xe1\xba\x65\xd5\xf4\xcc\xaa\x10\x43\x56\xcf\xbf\x37\x1a\xdb\x60\xfc\xbd\x99\xaa\x28\x80\x57\xa7\xb6\x77\xeb\xa2\xa0\x56\xcd\xe8\x16\x01\x13\x73\x15\xd9\x90\x0d\x93\x0c\x46\x6a\x55\xbe\xf5\x82\x6b\xc7\x83\x76\x6d\xe6\x28\x6e\x50\x1b\xa8\x99\x16\x35\xa0\x9b\xe6\x60\xea\x20\x51\xba\x7e\x5d\x4c\x4a\xb7\xa9\x8f\x4e\xe9\x56\x35\x36\x3f\x5d\x7d\x3c\xb4\xd2\xb5\xe3\xd7\x4a\x13\xb6\x9b\x02\xc2\x31\x6a\xd6\xc6\x4c\x45\xeb\xc9\x38\xd9\xa8\xd1\x64\x6b\x4b\xb7\x9a\x6e\x8d\x35\x44\x66\x45\xab\x5a\x47\x2f\xdd\xae\xfe\x91\x52\xb6\x9a\x0c\x2a\x93\x1c\xae\x89\x89\xcf\x10\xba\xb6\xda\x3d\x91\x50\x1b\x7b\x8f\x42\x6d\x18\xf7\x88\x1d\x64\xcb\xac\xf0\xc9\x54\x7c\x18\xc0\x88\x49\xf0\x42\xea\x87\xf4\x80\xfc\x9d\x69\x28\x44\x74\x8d\x89\x17\xef\x78\x4c\xa4\x0f\xee\x15\xab\x87\x96\x85\x08\x71\xbc\xc1\xa3\x40\x80\xa0\x1b\x56\xfb\xbe\x03\x5e\x1a\x27\x6e\xdf\x33\x5e\xae\x01\x90\xb0\xf0\x19\x1d\xc6\x1a\x00\x37\x30\xc0\x4c\xff\x98\xa1\xce\x27\x7e\x27\xa1\xd3\xe0\x0b\xc0\xfe\xba\xd1\x6c\xf0\xff\x33\x36\x52\xf5\x0e\xfc\xa4\xa2\x58\xcf\xaf\x06\xf4\x7d\xe3\x33\xf8\x12\x07\x18\xe2\x5f\xcf\x63\xbe\xb3\x20\x0f\xe7\xab\x8a\xa8\x0f\x29\x8a\x9b\xaf\xf0\x4f\x89\xd3\x1d\x25\x05\x51\x75\x27\x37\x20\x4f\xc5\xf3\x55\xcf\xf8\x9d\x9d\x21\xdf\x49\x95\xd4\xa9\xe2\xab\x2e\x92\x95\xc2\xd2\x53\x55\x4e\x72\x2d\x4a\xa0\x9a\xaa\x22\x5d\x74\x7e\x66\x77\x52\xe9\x6d\xa5\x1c\x2b\x81\x4f\xe9\x4d\xa0\x6c\x27\x00\x9f\xa7\x17\xa5\x55\x25\x50\x95\xf4\x45\xd9\x48\x82\xb7\x40\x25\x95\x95\x53\x08\xa3\xd4\x72\x24\x8d\xa0\x8f\x0f\x85\x00\x50\x26\x19\x64\xea\xfe\x03\xdd\x77\xf2\xd2\x00\xf8\x92\xd7\xc7\xfe\x6a\xdc\x99\xd0\xc7\xe6\x35\xba\x4f\xef\x68\x2c\x71\xe5\xb4\x91\xe0\x4b\x41\xb4\x66\x8d\x98\xaf\x92\x11\xf9\xa7\x33\x1d\x6b\x4e\xe7\x9a\x74\x1c\x06\x4e\x27\xa3\x64\x4d\x3e\x45\xc2\xc7\x21\x93\x3d\xb2\x5a\x42\xf0\x05\x34\x33\x75\x2e\x99\xf6\x2d\xab\x8c\x8b\xeb\x24\x42\xc9\x45\xb6\xc7\x2a\x61\x87\x4d\xc3\xf5\x5c\xf9\x74\xbd\xd8\x85\x70\x43\x39\x39\xea\xd4\x11\x7c\xb2\x9d\xc9\xd5\x46\x53\x4e\x34\xb0\xa9\x55\xf1\xc2\x13\xbb\xa3\xda\x1e\x59\x64\x62\x3b\xbd\x43\xc2\x57\xe8\x2a\x80\x16\x2a\x69\x27\x6a\xf0\x10\x09\x28\xd7\xd8\x81\x14\xb9\xd6\x7d\xa7\xa8\x61\x65\x75\x9a\x23\x62\xe4\x74\x30\xdd\x28\xfc\xbe\x5c\x24\x8f\xf7\x62\x63\xca\xd3\xb0\xac\xaf\x2b\xd5\x10\xac\x2b\xec\xf6\xbc\xd0\xb5\x8d\x0d\xf0\xe6\x0d\x30\xbc\x90\x8a\x9f\x9b\xc0\x08\xdd\x6b\xd7\xbb\x75\x8d\xcd\x4c\x4f\x39\x05\x11\xf8\x02\x78\xb0\x82\x4d\x60\x8c\x42\x1a\x42\xa7\x4b\x1d\x62\x6c\x4a\xe8\x6e\x6c\xa4\xa1\x87\x6c\x91\x0a\xfa\x43\x14\xbd\xa7\x52\x7d\x94\xc2\x48\x82\x82\x03\xae\xb5\x2e\x2a\xb2\x8b\xc8\x79\x95\xa6\x2f\x29\x05\xaf\xac\x24\x94\x75\x6f\xb9\xce\x4d\xa1\x9c\x8f\x77\x9c\xe9\x0c\xe2\x5a\x05\x0d\x74\x0a\x31\x84\xbd\x20\x6f\x40\x48\xe6\xc5\x4e\x1e\x4b\x5f\xea\x09\x4a\x58\x40\x83\x0e\x8c\x3e\xf2\xfd\x4f\x1b\x4d\x92\x4e\xee\xde\x79\xc1\x2d\x0c\x6c\x64\xbf\xf3\x02\x25\x2e\xf5\xa3\x0a\xec\x2f\x81\x49\xd1\x85\x2e\x27\x2a\x36\x7e\x76\xbc\x10\x44\x89\xfd\x95\xba\x0e\x25\xd5\x60\x9a\xee\x4b\xa1\x1a\x57\x2a\xcc\x93\x15\xb1\xc2\x53\xa9\x12\x57\x6b\xca\xa3\xba\x32\x23\x39\xb2\xbb\x91\x23\x5a\x57\x18\x78\x5f\x1a\x03\xc7\xeb\x41\xc7\x78\x39\x57\x9e\x89\x5a\xfe\x6a\xb3\x4d\x69\xd5\x26\xf8\x92\x7c\x59\x37\xda\xcd\xd6\x6b\xb3\xd9\x32\x9b\xad\xab\x66\xb3\xc3\xff\xff\x7f\x8d\x8d\x22\x6b\x95\x9c\xbd\x63\xae\x4c\x2f\xa8\xd7\xf3\xfc\x88\xe6\xc2\x34\x17\xf6\x54\xb8\xb0\x84\x33\x52\x31\x42\xd4\xf2\xf3\xa4\xf3\x28\xb2\x8d\xa8\x0c\x26\xdf\x1e\xb9\xcd\xd1\xc8\x52\x53\x97\x8a\x54\x2a\x2d\x55\x71\x45\x4e\x3f\x2f\x6a\x76\x2f\xec\x5b\xd9\xb6\x97\x63\x46\xca\xb5\x59\x06\xcd\x5b\x08\x75\x62\x5e\xdd\x2b\xa5\xcb\xe0\xee\x3f\x8a\x03\xc5\xe0\xc4\x45\x0b\xf0\xe5\x8b\xfa\x2b\xa3\xe8\xc6\x1a\x00\xd0\x8a\x5f\x69\x98\x91\xba\x26\x59\x11\x00\xd8\x25\x14\x26\xa6\x1d\x33\x51\x5d\x34\x62\x5a\x3d\x57\x38\x52\xcb\x5f\x39\x50\xca\xcb\x69\x62\x58\xa5\x59\x96\x19\xc0\x25\x9e\xb3\x2a\x00\x26\x6f\x8c\x28\xb0\xe5\xf2\x81\x16\x85\x70\xe2\xd1\x9f\x6c\x3c\x92\xe1\xe8\x3a\x4b\x64\x6d\xe5\xe1\xbe\x1d\xcb\x6c\x44\x17\xb9\xaa\x55\xd7\x2d\x70\x1f\x45\xbd\x68\x59\x27\x7e\x35\xcf\xa4\x6a\x02\xab\xf8\x31\x55\x83\xd8\xff\x33\xd7\x48\x96\x2b\x1a\xa6\x20\x95\x02\x4e\x25\xa7\x31\xb6\x7d\x1a\x4c\xd5\x3a\xe4\xca\xee\xfc\x1a\x3c\x43\x69\x63\x38\x96\xa9\x2b\x6d\x1a\x03\x51\xd5\xbc\x1e\x24\x63\x63\x47\x15\xa7\x37\xae\x75\xd7\x2d\x70\x70\x69\xdb\x44\xed\x2e\x14\x3b\x51\xb0\x71\x14\x3a\x93\x77\x46\x37\x61\xc7\xd2\x8e\x02\x25\x6a\xaa\x5c\x0f\x69\x5b\x77\x41\xdf\xd4\x6e\xe6\xb5\x49\xdd\x7c\x8b\x69\xf4\x49\x49\x27\x82\xb3\xc3\xf6\x14\x1a\xa5\xa7\xc0\x28\x56\x70\x2e\x1f\x3f\x5c\x9e\x1f\x1f\x9e\xbc\x3b\x39\x3e\x32\x5e\xce\x9f\x8e\x44\xbc\xf5\x63\x92\x92\x2a\xbd\x9a\x26\x30\x9a\xc0\x68\x02\xa3\x09\x8c\x26\x30\x4f\x94\xc0\x30\x15\xfd\xe3\x12\x97\x82\x0d\x49\xd3\x14\x4d\x53\x34\x4d\xd1\x34\x45\xd3\x94\xa7\x47\x53\x04\x68\x1f\x9f\xa8\x14\x7d\x29\x34\x55\xd1\x54\x45\x53\x15\x4d\x55\x34\x55\x79\x6a\x54\x85\x5a\x3e\x37\x59\xa2\xc7\xb5\xa8\x54\x19\x6c\x35\x71\xd1\xc4\x45\x13\x97\x55\x27\x2e\xfa\x7e\xad\xba\x5f\x23\x9f\x92\x15\xb9\x63\x95\x1e\x34\xfa\x9e\xd5\xf7\xac\xbe\x67\xf5\x3d\xfb\x54\xef\xd9\x64\x9e\xc4\xf3\x91\x5b\x79\xd9\x6a\x87\x1e\x7d\x01\xea\x0b\x70\xa6\xdb\x4b\x5f\x80\x2b\x7c\x01\x5a\x8e\x47\xf4\x05\xa8\x2f\x40\x7d\x01\xea\x0b\xf0\x79\x5d\x80\xe5\x21\x16\x92\x14\xd2\xab\x11\x67\x21\x33\x9f\x74\xb0\x05\x71\x87\x93\xe3\x3b\x1f\x0b\x9f\x3f\x19\xba\x3e\x0a\x4f\x9f\xfa\x7c\x9f\x3c\x0a\x32\x5a\xcd\x91\xa1\x4a\xed\x95\x75\x39\x11\x6f\x4f\x64\x57\x91\x87\x7e\xb7\xe8\x3f\xdf\x10\xbd\x34\x14\x51\xff\x05\xa0\x0f\xcf\x3e\x7e\xb8\x3a\xbe\x48\xa7\x28\x17\x38\x9b\x84\xf9\x8f\x10\x2b\x2e\x48\x2e\xc7\x7c\x51\x7c\x35\xe4\x3f\x44\x37\x4f\x59\x79\x37\x9f\xb7\xd9\xcc\xdd\xa8\x71\x71\xee\x8e\x51\x96\x17\xa7\xa1\xba\x25\x2b\x3f\x2a\x26\xa4\xb8\xa2\x94\xdf\xd2\x89\x75\x4a\xbe\xf1\xde\xc7\x56\xc8\x0e\x9f\xb7\xeb\xa4\x3e\xa4\xac\x2b\x71\x69\x99\x75\xa6\xb2\x42\x64\x79\x89\x2b\x95\xdf\x42\x2a\xb4\xec\x46\xee\xad\xac\xb2\xe7\xda\xa4\x12\x41\xa3\xca\x63\x71\xf4\xe8\x84\x45\x6a\x79\xfb\x31\x8e\x1b\xa8\x11\x55\x23\xea\x64\x88\x0a\x40\x2f\xb4\xae\x51\x3a\xd9\x38\xba\xf3\x1d\x6c\x61\xda\x2d\x7c\x01\x80\x53\x4a\x96\x81\xa4\xd9\x68\x36\x77\x37\x41\xb3\xd1\x6c\xf1\x7f\xb7\xc5\x0f\xfe\x6f\x5e\x22\x0a\x76\x37\x41\x6b\x13\xb4\xd9\x7f\xd9\x9f\xcd\xcf\xca\xd3\xc1\xd5\xb0\x95\x47\x82\x39\x5b\xe8\xe3\xa0\x8f\xc3\xe3\x1c\x07\xcf\x45\x2e\xc5\xd0\x79\x5b\x3c\x10\x6e\x38\x7a\x87\x5d\x4c\x51\xf4\x0d\xec\xa5\xbe\xf2\x9c\x97\x51\xe2\x53\xf1\xcf\x20\xf0\x6e\xe9\xf0\x1d\xe4\x41\xdd\x41\xab\x99\x3b\x10\x72\xde\x95\x27\x22\x71\x84\xd2\x47\x42\x1f\x89\x67\x7e\x24\xa8\xe5\x77\x09\x72\x25\x91\xa8\x62\xef\x53\xbe\x1c\x9a\xbb\x7f\x36\x47\xa2\x26\xa3\xcd\xd0\x24\x32\xeb\xd6\x46\x95\xa8\x81\x46\x97\x6f\x11\x5d\x92\xaa\xa4\x2b\x2c\x66\x63\x50\xa6\x60\x61\xd3\x78\xf3\xad\xe3\x8d\x30\x34\xd4\xc7\x1b\x51\x5f\xe3\xcd\xb7\x80\x37\xf3\x8a\x93\xc2\x14\xa9\x32\xb3\xef\xa3\xc5\x49\x59\x9f\x36\xe6\xcc\x06\xf8\xf3\x9f\xc1\x3a\xef\x65\x7d\x5d\x19\x37\xd0\x34\x36\x36\x81\xc1\x62\xd2\x9b\x7e\xe0\xf5\xd0\x0f\x42\x2f\xcf\x55\xec\x1b\xa5\x61\x58\x32\xda\x65\x45\x2c\x16\x85\xae\x37\xfb\x25\xa7\x64\xcb\x7e\x4c\xc9\x56\x6b\x59\x06\x3c\xfd\x65\x9e\x1b\xfc\xe4\xe2\xe0\x8c\xdb\x80\x22\x37\x9e\xfd\x90\xe3\xbd\xe6\x0d\xcc\xe4\x5c\x32\x52\x5d\xc3\x12\x3c\x0e\x10\x1c\x8f\xd7\x55\x11\xfa\x5c\x18\x59\x92\x7c\x24\x8c\xba\x53\x43\xac\x8c\xc3\x58\x1c\x74\x6a\x5b\xca\xe7\x00\x1f\x3e\xd6\xdc\x00\x94\x21\xa5\xf3\x34\xa4\x25\xe9\x31\x90\x7b\xb3\x1a\xb6\xb4\xfc\x94\xd2\xe6\xb4\x17\xe0\x76\x88\x5c\x10\x84\xae\x8b\xdd\x01\xe8\x07\xde\x48\x0c\x0b\x02\xcf\xa3\x9b\x20\x24\x08\xd0\x21\x02\x7d\xcf\x71\xbc\x5b\x56\x45\x80\x06\xc0\x3e\x45\x01\x80\xb6\xcd\xca\xa0\xec\x8b\xdc\x8f\x7a\x8c\x7c\x01\x07\xbb\xd7\x80\x7a\x00\xa6\x06\x8f\x5a\xf6\xb1\x83\xc0\x0d\x86\x72\x02\xe2\xdf\xe0\x7b\xe0\xb8\xc0\x24\xe0\x3f\x5b\x0d\xd6\x44\xa6\x0e\x14\x73\xd9\x82\x62\x29\x5b\x49\x67\xfc\x4f\x51\x27\xd3\x4d\x52\xdc\x95\xd9\xb7\x6b\xf4\x60\xac\xcd\xed\x88\xb0\x4e\x21\xa5\xc1\x00\xb9\xac\x06\xaf\xf5\x18\x48\xa0\x3e\x23\x79\x4c\x50\x1c\x93\x24\xbf\x4a\x23\xa9\x3d\x37\xf0\x50\xcb\x5f\x15\x08\x4d\x4d\xbb\x96\x07\xc4\x54\xdb\x22\x28\x93\x31\x1e\x05\x7c\x2f\xc0\x39\x24\x84\xdf\x0d\x8c\xed\xc1\x01\xb2\x93\x29\x01\x36\x55\x40\x3d\xfe\x59\x1e\xbd\xb5\xd8\x9b\x27\xc4\x89\x9f\x52\x88\xe3\xb0\xe5\xf2\x2b\xae\x0a\x53\x0b\x5e\x00\x1b\xf5\x61\xe8\x50\xd6\x7b\xe8\xb2\xd9\xe0\x3e\x46\x36\xc0\x3e\xbb\x8c\xd8\x28\x69\xd6\x9b\x0f\x95\x4b\x82\x17\x8d\x97\xae\xc7\x73\xd7\x15\xb2\xd9\x49\xdf\xfe\x78\x59\xdd\x1e\x76\xd9\x7d\x17\xdf\x9d\xef\xb0\xe3\xf0\x35\xba\xe8\x36\xa9\x46\xc4\x35\x9a\x5a\x3b\x23\x4e\x76\x68\x21\x5b\xe6\x8a\x68\x44\x97\x9e\x17\x52\x11\x7e\x9a\xf0\xdb\xd2\x8d\x51\x09\x78\x7d\x70\xc6\xeb\x5e\xc9\x6c\xaa\x40\x86\x54\xce\x65\x3d\xe2\x7d\x34\x22\x59\xcd\xb3\xbb\x95\xb1\x7d\x1b\x1c\x20\xf9\x26\x12\x28\x0a\xcf\xab\x28\xc5\x4d\xa6\x89\x28\x03\x5f\x00\x1a\xf9\xf4\xfe\x92\x06\xd8\x1d\x9c\x42\x7f\x7d\xa3\x90\x05\xa9\x30\x52\x85\x37\x5a\x2a\xa7\x48\xa6\x59\xc6\x97\x47\x6e\xbe\x51\x4c\x8c\x94\x69\x13\x87\xa0\x55\x8d\x93\xcb\xb4\x92\x69\x28\xbf\x75\xa1\xf8\x58\x39\xdf\x4c\xe6\x95\x4c\x2f\xd1\x97\x2a\xc0\x66\x33\xb0\xa8\x9b\xd7\x1a\xbd\x04\x6c\x45\xa1\xb7\xca\xb1\x2a\xc1\xa4\xec\xa9\x28\x45\xa7\x7c\x62\x49\x75\x63\xd5\xfa\x15\x09\x94\x8a\x8d\xab\x51\xac\x90\xea\x48\x3d\xfa\x38\x6f\xb2\x7c\xe2\xa3\x62\x2f\x71\x85\xf1\x7d\xe5\xb7\x21\xdd\x4d\x39\x0a\x17\x73\x3c\x15\x5b\xab\x91\xb9\x22\x77\x50\xb1\x8b\x5a\x68\x5d\x9a\x55\xa8\xd8\x5f\x25\x82\x97\xe7\x19\xaa\xe8\xa8\xfe\x8c\xaa\x00\x3d\x06\xe9\x45\xe6\xea\x43\x4e\x75\xa5\x00\x0f\x5c\x84\x6c\x64\x83\xde\x3d\x38\x65\x74\xb0\xb1\xf6\x42\xfc\x01\x2c\x27\x24\x14\x05\x00\x13\x60\x23\x07\xdf\x20\x46\xe1\x6e\x30\x04\x87\x47\x97\x2c\xff\x35\xa7\x3c\xf7\x60\x94\xa9\x4c\x10\xa5\x8c\x40\x64\xe8\xbc\x8b\x28\x9b\x18\x76\x8b\xa4\x7e\x5b\x52\xfa\xa3\x64\x11\x17\x6a\xce\x69\xfa\x6c\xff\x73\x22\xf8\x3c\x9d\x55\x66\x1a\x19\x0d\x64\x83\xdc\x58\x0d\x09\x06\x91\x51\x73\x0d\x00\x1a\xc0\x7e\x1f\x5b\x69\x17\xbd\x44\x06\x3b\xf7\x92\x5c\x93\x4c\x17\x14\xfd\x2d\x7e\xb5\x4f\xe1\xdd\x85\xd4\xa7\x30\xb3\x5e\xb3\xd9\x8c\xbf\x8f\x92\x4f\xe7\x28\x38\x8c\x7b\x8c\xea\xe5\x19\xad\x39\x6e\xc0\x94\x99\xfd\x17\xb0\x07\xf1\x4c\x56\x7a\x1b\xd6\xfe\xbf\x01\x00\xb9\xb1\xd4\x57\x12\x33\x07\x00",
+	"dependencies/istio/install/profiles/mtls-permissive.yaml":             "\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xb4\x92\xc1\x6a\xdb\x40\x10\x86\xef\x7a\x8a\x21\xb9\x95\x68\x4b\xe8\x4d\x37\x97\xf8\x20\xb0\x5b\x13\x3b\xb9\x96\xe9\x6a\x94\x1d\xbc\xda\x5d\x76\x46\x72\xf3\xf6\x45\x6b\xbb\xa5\x35\x84\x1a\x1a\x9d\x06\xc1\xee\x7e\xdf\x3f\xff\x2d\xec\x1c\xc1\xa0\x5e\xea\x44\x79\x60\x11\x9e\x08\x52\x8e\x3d\x7b\x6a\x40\x1d\x81\x70\x47\x16\xb3\x80\x50\xe8\x60\xd8\xad\xb6\x80\xa1\x03\x51\xf6\x1e\xd0\x5a\x4a\x0a\xc9\x23\x07\x50\xfa\xa1\x77\x40\xe6\xc5\x40\x9f\xe3\x50\xdd\x96\xf3\x29\x76\x02\xb1\x2f\xf3\x7e\xfc\x4e\xbd\x8f\x07\x08\x38\x90\x24\xb4\x74\x07\x07\xc7\xd6\x81\xc3\x89\x20\xc4\xf3\x6b\xa6\xc2\xc4\xcf\x94\x85\x63\x68\x00\x47\x75\x14\x94\x2d\x2a\xc7\x60\x58\x94\xa3\xe1\xf8\x71\xba\x47\x9f\x1c\xde\x57\x7b\x0e\x5d\x03\x6b\x12\xb7\x89\x9e\xed\x6b\x35\x90\x62\x87\x8a\x4d\x05\xe5\xad\x06\x3a\xea\x71\xf4\x5a\x49\x22\x3b\xff\x4d\x44\x59\xe6\xa1\x2e\xfa\xf3\x34\x7f\x43\xec\xa8\x81\xcd\xf2\x71\xdd\x6e\xb7\xed\xf3\xb2\xaa\xeb\xfa\x0f\x96\x40\x7a\x88\x79\xcf\xe1\xe5\x82\xe3\xd3\x89\xe3\x81\x44\x39\x14\xd6\xc7\xd1\xd3\x1b\x30\xf0\x3b\x88\x06\xca\x75\xb5\xbc\x8a\xd2\xf0\x0b\xd3\x45\xd1\x06\x6e\x3e\x18\x1f\x2d\xfa\x9b\x0a\x40\x33\xf6\x3d\xdb\xa3\xe8\x11\xfb\x82\xbf\xdd\xee\xda\xaf\xdf\xd6\x4f\xbb\xa7\xc5\xaa\x18\x1c\xf7\x2c\x94\x27\xb6\xf4\xc6\x3a\xfe\xde\x03\x68\x04\x9d\x8b\x11\x50\xa9\x2c\xdf\xfc\xff\x34\xce\x18\x75\xa9\xd1\xdc\xa2\x6b\x82\x39\x9f\x36\x32\x59\x63\xfd\x28\x4a\xf9\xba\xb4\x1e\xda\xed\xe2\xf3\x6a\x79\x0a\xea\x4b\xcc\xe0\x50\x4a\x40\x8b\x4d\x5b\x42\xa3\xfc\x0e\xda\x98\xb8\x3e\x5e\xfe\xcf\xba\xb3\x6b\x0e\xa4\x24\xe6\x54\xa1\x4b\xeb\x2b\xa5\x7f\x0e\x00\xc8\x8d\x87\x6e\x02\x04\x00\x00",
+	"dependencies/istio/install/profiles/mtls-strict.yaml":                 "\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xb4\x92\xc1\x6a\xdb\x40\x10\x86\xef\x7a\x8a\x21\x39\x14\x0a\x52\x09\xbd\xe9\xe6\x36\x3d\x18\x9c\x36\xc4\x4a\xaf\x65\xba\x1a\x75\x07\xaf\x76\x96\x9d\x91\x5d\xbf\x7d\xd1\xca\x4e\x29\x86\x52\x43\xa3\xd3\x6a\x90\x86\xef\xfb\xff\xbd\x85\xce\x13\x8c\x16\xb4\x56\xcb\xec\x0c\x52\x96\x81\x03\xb5\x60\x9e\x40\xb9\x27\x87\x59\x41\x29\xf6\x30\x76\x9b\x2d\x60\xec\x41\x62\x38\x02\x3a\x47\xc9\xca\xb0\x29\x5b\x0e\x92\x77\x41\xb0\x57\x38\xb0\x79\x99\x0c\xb0\xba\x3d\xaf\x00\x87\xf1\x8d\x41\x26\x74\x1e\xcc\x8b\x52\xf9\x0a\x24\x12\x60\x3c\x8e\x92\xa9\xa9\x30\xf1\x57\xca\xca\x12\x5b\xc0\xc9\x3c\x45\x63\x87\xc6\x12\x1b\x56\x63\x69\x58\xde\xed\xef\x30\x24\x8f\x77\xd5\x8e\x63\xdf\xc2\x03\xa9\x7f\x94\xc0\xee\x58\x8d\x64\xd8\xa3\x61\x5b\x01\x44\x1c\xa9\x85\x9e\x06\x9c\x82\x55\x9a\xc8\xcd\xd3\x44\x94\x75\x3e\xd4\x45\x79\x3e\xcd\xcf\x28\x3d\xb5\xb0\xed\x9e\xd6\x1f\xbb\xaa\xae\xeb\x3f\x38\x22\xd9\x2c\xc6\xf1\xc7\x05\xc3\xfb\x13\xc3\x3d\xa9\x71\x2c\x9c\x4f\x53\xa0\xbf\x80\x2c\xef\x9a\xd0\x51\x0b\x65\x5d\xad\x47\x35\x1a\x5f\x10\xbd\xa8\xb5\x70\xf3\xb6\x09\xe2\x30\xdc\x54\x00\x96\x71\x18\xd8\x2d\x92\x0b\xf2\x05\xfb\x7a\xdb\xad\xbf\x7c\x7b\x78\xee\x9e\x57\x9b\x62\xb0\xf4\xaa\x94\xf7\xec\x48\x41\x86\x52\xe7\x6e\xfa\x4e\x43\x90\xc3\x6f\x0a\xf0\xb8\x27\x88\xf2\x52\x93\x09\x18\xe5\x71\xd6\xa1\xa5\xdb\xff\x9f\xc6\x19\xa3\x4e\x01\x39\x1a\xfd\xbc\x2a\x98\xf3\xdf\x8d\xee\x5d\xe3\xc2\xa4\x46\xf9\xba\xb4\xee\xd7\xdb\xd5\x87\xcd\xa7\x53\x50\x9f\x25\x83\x47\x2d\x01\xad\x1e\xd7\x25\x34\xca\xaf\xa0\x8d\x89\xeb\x65\xf9\x3f\xeb\xce\xae\x39\x92\x91\x36\xa7\x2b\x74\x69\x7d\xa5\xf4\xaf\x01\x00\xf9\xdb\x4f\x39\xf2\x03\x00\x00",
+	"dependencies/istio/install/profiles/noauth.yaml":                      "\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x7c\xcf\xcf\x0a\x1a\x31\x10\x06\xf0\x7b\x9e\x62\xd0\x5b\xe9\x6e\x91\xde\x72\xb3\xe8\x41\x50\x10\x57\xbc\x4f\xb3\x13\x77\x30\x3b\x09\x99\xd9\xb6\xbe\x7d\xd9\xb5\x2d\x2d\x42\x73\xfa\xf2\x87\x2f\xbf\x59\xc3\x75\x20\x90\x8c\x93\x0d\x50\x6a\x8e\x9c\xc8\x83\x0d\x04\xca\x3d\x05\xac\x0a\x18\x02\x15\x83\xaf\x79\x7e\x91\x90\x05\x8c\x7e\x18\xa0\xf4\x30\x5e\x8f\xdd\xc7\x25\x29\x49\xff\xd7\x6d\xeb\xd6\xb0\x2d\x25\x3d\x59\xee\xc0\x06\x18\x8d\x2a\x64\x21\xc8\x71\x69\x1f\x2d\xe9\xef\xff\x14\x6c\xaa\xa2\x4b\x1b\xe4\x18\x01\xef\xc8\xd2\x3a\x2c\x7c\xa3\xaa\x9c\xc5\xc3\xec\x23\x31\x0e\x68\x9c\xa5\x65\x35\xce\x2d\xe7\x4f\xdf\x36\x98\xca\x80\x1b\xf7\x60\xe9\x3d\x9c\x48\x87\x73\x4e\x1c\x9e\x6e\x24\xc3\x1e\x0d\xbd\x03\x10\x1c\xc9\x43\x4f\x11\xa7\x64\x4e\x0b\x85\xf9\xb4\x10\x55\x9d\x43\xb3\x70\xe6\x34\xaf\x31\xf7\xe4\xe1\xbc\xbf\x9c\x0e\x5d\x77\xb8\xed\x5d\xd3\x34\xff\x58\x84\xec\x7b\xae\x0f\x96\xfb\x9b\xe3\xf3\x2f\xc7\x8e\xd4\x58\x16\xeb\x65\x4a\xf4\x1f\xcc\x6b\xaf\x05\x03\x79\x58\xea\x1a\x7d\xaa\xd1\xf8\x87\x39\x64\x35\x0f\xab\x0f\x6d\xca\x01\xd3\xca\x01\x58\xc5\x18\x39\xbc\x06\x7d\xb1\xdf\xfc\xbb\x43\xb7\xfd\x72\xdc\xbb\x9f\x03\x00\xc8\xc2\x74\x56\xe1\x01\x00\x00",
+	"dependencies/istio/kf-istio-resources.yaml":                           "\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xdc\x53\xc1\x6e\xdb\x30\x0c\xbd\xfb\x2b\x08\x1f\x07\xc8\x49\x91\x1e\x06\xdd\x06\x2c\x68\x0f\x5b\x31\x2c\x41\xb1\x5b\xa1\x2a\x8c\x2d\x44\x96\x0c\x91\xb6\xdb\xbf\x1f\x6c\xd9\x71\xb6\x25\x5d\x92\xa1\x97\xdd\x1c\xf2\xf1\xe9\xf1\xf1\x45\x55\xe6\x11\x03\x19\xef\x24\x38\xe4\xd6\x87\x9d\x71\x79\x66\x88\x8d\xcf\x8c\x9f\x35\x37\xca\x56\x85\x5a\x24\x3b\xe3\x36\x12\xee\x14\x63\xab\x5e\x93\x12\x59\x6d\x14\x2b\x99\x00\x38\x55\xa2\x84\x5d\xfd\x8c\x5b\xeb\x5b\x91\x0f\x90\xd8\xa0\x4a\xe9\x83\x6e\x42\x15\xea\x6e\x88\xd0\xa2\x66\x1f\xba\x6f\x80\xfe\x39\x09\xc6\xe5\x01\x89\x26\x06\xc2\xd0\x60\xa0\x0e\x24\xa0\xf2\x81\x23\x1c\xc0\xd5\xe5\x33\x06\x09\x1f\xe7\x63\xa1\x17\x51\x30\x57\x43\xa1\x0a\x9e\xbd\xf6\x56\xc2\xfd\x7a\xfd\xad\x2f\x16\x9e\x98\x22\x83\x80\xf4\x43\x9a\x08\x21\x92\x0b\x0d\x78\x34\x81\x6b\x65\x57\x18\x1a\xa3\xf1\x88\x0f\x79\x50\x5b\xe5\x94\x68\xe8\x2f\x0e\xec\xd5\x44\x2d\x00\xc3\xda\x63\xed\x77\x43\x3b\x48\xb7\x5f\x6c\x97\x8a\x75\x31\xee\x52\x07\x13\x3f\xe3\xe2\xb8\x35\x2f\x12\xd2\x59\xbf\xc3\x6c\x10\x34\x4b\x07\x44\x89\x5c\xf8\xcd\x84\xc7\x17\xa5\x59\x42\x7a\xb7\x5c\x47\x48\xc0\x36\x18\xc6\x11\xd1\x91\x43\x3a\x8c\x07\x5f\x8f\x1d\x01\x1b\x24\x36\x4e\x71\xe7\xdd\x9e\xae\x5b\x4b\x42\x3a\xbc\x1a\x7d\x14\xf4\x4a\x8c\x65\x46\x8d\xce\xb4\xad\x89\x31\x64\xd6\x6b\x65\xd3\x49\xf5\xc1\x71\x0f\x0f\xbc\x98\xcf\xe7\xd7\xdc\x69\x38\xd0\xd2\x71\x38\x96\xd6\xdc\xfb\xdc\xa2\x50\x95\x11\xd8\x43\xce\xbe\x55\xdb\xb6\x59\x9c\x56\x95\xa1\x4c\xfb\x32\x89\xea\x87\xfe\x28\xfc\xf6\x76\x91\xfc\x1a\x4c\x4a\xfe\xcc\xe5\x2a\x01\x08\x48\xde\xd6\xbd\x8b\xf0\xf9\xa1\xab\x74\xde\xc4\xdf\x5f\x97\xab\xfb\xa7\xe5\x8f\xf5\xf2\xfb\xc3\xa7\x2f\xef\x93\xd7\xc9\x89\x86\xfe\xd1\x06\xb6\x74\x24\x9d\xfd\x69\xf7\x7e\x00\x90\x33\x4f\xc5\xf4\x57\x3c\xc9\x76\x7e\xda\x4e\x8c\xbf\x95\xab\x49\x4e\x8b\x26\x2f\x58\xc2\xcd\x7b\x06\x8d\xd8\x07\x95\x5f\x15\xb8\x61\xf4\x7f\x0c\xdd\xa1\x2b\x97\x84\xef\xa4\x25\x57\x07\xf0\x24\xe3\xf9\x21\x7c\x83\xe2\xf2\x20\xfe\x1c\x00\x40\xa3\xc1\x07\x97\x07\x00\x00",
+	"deployment/gke/deployment_manager_configs/cluster-kubeflow.yaml":      "\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xb4\x58\xdd\x8e\x1b\x37\xd2\xbd\xd7\x53\x1c\xcc\x5c\xc4\xc1\x27\xb5\x46\x93\x20\x9f\xa1\x60\x81\x55\x26\x13\x47\x70\x56\x23\x58\x72\x7e\x76\xb1\x18\x50\xec\x52\x37\xad\x6e\x16\x43\xb2\x25\x2b\x4f\xbf\xe0\x4f\x6b\xa4\xb1\xbd\xf6\xcd\x0e\x30\x40\x77\x93\xac\x3a\x3c\x75\xaa\x8a\xd4\x35\xee\xd8\x1c\xad\xaa\x6a\x8f\xdb\x9b\xc9\x77\x78\xc5\x5c\x35\x84\xb9\x96\x05\x66\x4d\x83\x38\xe4\x60\xc9\x91\xdd\x53\x59\x0c\xae\x07\xd7\xf8\x45\x49\xd2\x8e\x4a\x74\xba\x24\x0b\x5f\x13\x66\x46\xc8\x9a\xfa\x91\x21\x7e\x25\xeb\x14\x6b\xdc\x16\x37\x78\x11\x26\x5c\xe5\xa1\xab\xaf\xbf\x1f\x5c\xe3\xc8\x1d\x5a\x71\x84\x66\x8f\xce\x11\x7c\xad\x1c\xb6\xaa\x21\xd0\x7b\x49\xc6\x43\x69\x48\x6e\x4d\xa3\x84\x96\x84\x83\xf2\x75\x74\x93\x8d\x14\x83\x6b\xfc\x91\x4d\xf0\xc6\x0b\xa5\x21\x20\xd9\x1c\xc1\xdb\xf3\x79\x10\x3e\x02\x0e\x7f\xb5\xf7\x66\x3a\x1e\x1f\x0e\x87\x42\x44\xb0\x05\xdb\x6a\xdc\xa4\x89\x6e\xfc\xcb\xfc\xee\x7e\xb1\xba\x1f\xdd\x16\x37\x71\xc9\x5b\xdd\x90\x0b\x1b\xff\xb3\x53\x96\x4a\x6c\x8e\x10\xc6\x34\x4a\x8a\x4d\x43\x68\xc4\x01\x6c\x21\x2a\x4b\x54\xc2\x73\xc0\x7b\xb0\xca\x2b\x5d\x0d\xe1\x78\xeb\x0f\xc2\xd2\xe0\x1a\xa5\x72\xde\xaa\x4d\xe7\x2f\xc8\xea\xd1\x29\x77\x31\x81\x35\x84\xc6\xd5\x6c\x85\xf9\xea\x0a\x3f\xcc\x56\xf3\xd5\x70\x70\x8d\xdf\xe6\xeb\x9f\x1f\xde\xae\xf1\xdb\xec\xcd\x9b\xd9\x62\x3d\xbf\x5f\xe1\xe1\x0d\xee\x1e\x16\x3f\xce\xd7\xf3\x87\xc5\x0a\x0f\x3f\x61\xb6\xf8\x03\xaf\xe7\x8b\x1f\x87\x20\xe5\x6b\xb2\xa0\xf7\xc6\x06\xfc\x6c\xa1\x02\x8d\x31\x74\x58\x11\x5d\x00\xd8\x72\x02\xe4\x0c\x49\xb5\x55\x12\x8d\xd0\x55\x27\x2a\x42\xc5\x7b\xb2\x5a\xe9\x0a\x86\x6c\xab\x5c\x08\xa6\x83\xd0\xe5\xe0\x1a\x8d\x6a\x95\x17\x3e\x7e\xf9\x60\x53\xc5\x60\xa0\x5a\xc3\xd6\xbb\xe9\x60\x04\x23\x7c\x3d\x85\x6c\x3a\xe7\xc9\x16\xef\x94\x7e\x27\x06\x03\x4b\x8e\x3b\x2b\xc9\x4d\x07\xc0\x35\x7e\x24\xd3\xf0\xb1\x25\xed\xd1\x0a\x2d\x2a\xb2\x28\x99\x9c\xfe\xca\xc3\x75\x26\x98\x42\x49\x86\x74\xe9\xc0\x1a\x96\xb6\x64\x49\x4b\x72\x50\x1a\x9e\x5a\xd3\x08\x4f\xf0\x47\x43\x45\x34\xb7\xe2\x08\xc7\x1f\x18\x86\x9d\x53\x21\x5c\x07\xb6\x3b\x08\xcb\x5d\x30\x12\x22\x13\x26\x4e\x0a\xbc\x75\x04\x01\xa7\x74\xd0\xfc\xc9\xd6\x8b\x04\x34\xe9\x31\x50\x24\x42\x22\xf4\xa0\xbf\x06\xdb\xb8\xfe\xb6\xc0\x9d\xa5\xe8\xfc\xc0\x70\x64\x84\x0d\x2f\xe5\x69\x3b\x91\x2f\x34\xa2\xd3\x32\xa9\x77\xc3\xec\xe1\xbc\x15\xc6\x50\xb2\x21\xb6\x3e\xd3\x97\x39\x82\x72\x90\xd1\x6a\x19\xb7\x13\xfe\xb1\xfe\x94\xfd\x9e\xa7\x56\xec\x08\x6d\x27\x6b\xb8\x10\x83\xef\x71\x20\x48\xee\x9a\x12\xef\x3a\x17\x33\x2c\xda\xd9\x75\x1b\x92\xbe\x81\xf0\xf0\xb5\xf0\x30\xac\xb4\x2f\x02\x63\x07\x82\xe9\xfc\xe5\x46\xa1\xf4\x13\x39\x4f\x5e\x8b\xc1\x08\x5a\xb4\x34\x8d\xe6\xb6\x0d\x1f\x06\x88\xf4\x3f\x8f\x33\x60\x2c\x1b\xb2\x5e\xa5\x48\x03\x29\x69\x75\x4e\x99\x2e\x92\xff\x17\xeb\x9c\xdf\x3f\x58\x16\xe5\x81\x9a\x06\x1b\x92\x22\x55\x05\xe1\xbf\x72\x38\x04\xac\xeb\x9f\x56\x64\xf7\x41\x90\x39\x25\x5d\x11\x6d\x86\xf5\x53\xac\xee\xd7\x8f\xeb\x9f\xef\x1f\xff\xf9\xb0\xb8\xcf\xae\xae\x26\xc5\xef\x57\x53\x18\x25\x77\x2e\x32\x5c\xab\xaa\x26\xe7\xb1\x17\x8d\x2a\x83\x32\x65\xfd\x7f\xd5\x8e\x8a\x45\x7a\x8e\x72\xaa\x09\x93\xe2\x77\xec\x53\xf5\xca\x96\x42\xe5\x70\xd3\xf1\x58\x36\xdc\x95\x45\x15\x2b\x64\x21\xb9\x1d\x07\x02\xac\x26\x4f\x6e\x44\xba\x52\x9a\xc6\x25\x4b\x37\x3e\x49\x74\x6c\xc9\xf9\xf1\x7e\x32\x36\x96\xdf\x91\xf4\xae\x08\x68\x5d\x91\x79\x72\xd1\x7e\x7e\x19\x65\x9f\xd3\x00\x7c\x32\xb9\xca\xbe\x57\xe4\x53\x71\xf4\x8c\xfd\x64\x43\x5e\x4c\x7a\xee\xc2\x0b\xb6\x24\x7c\x67\xc9\xc1\x85\xe8\x0b\x07\x63\xd5\x5e\xf8\x93\x9e\x9c\xcb\x86\x82\x14\xc3\xf6\x5e\x9f\x30\xc3\x79\x21\x77\xa5\x55\x7b\x0a\x95\x2c\x08\x2a\x51\x5a\xed\x68\x66\xd4\xaf\x3d\x9e\xc0\xed\xab\xd7\xf7\x8f\xb3\xe5\xfc\xf1\xd7\xfb\x37\xab\xf9\xc3\x22\xdb\x9c\x69\x08\xbb\x51\xde\x0a\x7b\x44\xa8\x63\xba\x0a\x45\x92\x74\x19\x9e\x3c\x47\x9d\x80\xb7\xd0\x5c\x92\x61\x6e\x7a\x30\x9b\xae\x35\x69\x5b\x6a\x1b\x7b\xc1\x41\x68\x1f\x16\xb4\x5c\xaa\xed\x31\x02\x0d\x6b\x10\x17\x15\x79\xd5\x3a\x2c\x38\xa8\xa6\x41\x92\x07\xbd\x57\x2e\x54\xdc\xb3\xa9\xf0\x8c\x4d\x50\x6b\x43\x9e\xca\xb8\x69\x4d\x07\x04\xd6\xf3\xd0\x59\x72\x01\xd7\xb1\x02\x18\x4b\x5b\xf5\x1e\x7b\x38\x86\xf2\xc9\xc3\x86\xe0\xd3\x4c\x08\x07\x91\x77\xd7\xaf\x92\xa6\x1b\x05\x7f\x7d\xd0\xa2\xa3\xea\xd9\xc7\x21\x1c\xf9\xd0\x37\x76\x5b\xe9\x9b\x21\x42\x45\xb5\xaa\xa4\xe0\x23\x54\x15\xd6\x09\x74\x32\x7a\xbe\x72\x8a\xfd\x24\x7b\xba\x5b\xbe\xc5\x92\xb9\xc1\x1d\xeb\xad\xaa\x7a\x02\x43\x45\x50\x0e\xae\x0d\x19\x4b\x9a\xbb\xaa\x86\x67\x6c\x23\x7c\x5f\x2b\x8d\x92\xb6\xa2\x6b\x3c\xfe\xec\xd8\x8b\xe4\xe2\x84\x5a\x69\xe5\x95\x68\x16\x5c\xd2\x1d\x77\xda\x4f\x71\x9b\xed\xb6\x42\xd6\x4a\xa7\x72\x1a\x41\x06\x6a\x63\x25\x90\xa6\x4b\x68\x31\xdb\x0b\xd5\xc4\x2e\xc8\x26\x36\x81\xe9\xa7\x33\x24\xf4\xf0\xce\xe7\xbc\xc8\xc6\x47\xc1\xb8\xbb\x44\x74\x3e\x34\x85\x9e\x8c\x9c\x17\xba\x14\xb6\x1c\xbd\xec\xb5\xd6\x79\x76\x52\x34\xb1\x25\x09\x2b\x5a\x7a\xca\xa0\xde\x0a\xe9\x00\x6b\x24\x9e\xa6\x4e\xe1\x6d\x47\xcf\x7c\x29\x3d\x8a\xdb\x9a\xe2\xe6\x39\x8a\xf7\xfd\xc8\xe4\x26\xfb\x7d\xf5\xb1\x00\x54\x9f\x66\xf2\xe6\xb3\x4c\x56\xff\x0b\x26\xab\x2f\x67\xf2\xd5\xf2\x6d\x6c\x82\xd0\xec\x91\x28\x8b\xc7\x9b\xac\x98\x02\x6b\x86\x28\xcb\x38\x2f\x2f\x71\xe4\x51\x7d\xc0\x12\x3c\x43\x40\xb3\xa6\xd1\x5f\x64\x39\x14\xd6\x8e\x86\x60\x1b\xcf\x13\x45\xd8\x67\x68\x21\xc6\x14\x47\xd1\x66\x99\x57\x5f\x14\xab\xea\x93\xb1\xaa\x3e\x12\xab\x9e\xf2\x3b\xd6\xde\x72\xe3\xc2\x24\xe8\xae\xdd\x90\x85\xa1\xc4\xfc\x30\x97\x7d\xa5\x4d\xe7\xa7\xf8\xd7\x64\x18\x66\x3c\x4a\xd3\x3d\x1a\xb2\x8f\x61\xca\xbf\x87\x29\x4e\xe7\x94\x7d\x38\x0b\x7f\xc3\xcb\x13\x92\xe4\x64\x64\xc8\x46\x2c\x53\x9c\xd2\xb6\x26\xb9\xfb\xc2\x60\x56\xa6\x73\xe3\xe8\x5a\x9c\xf4\x10\x54\xd7\x72\x49\x8d\xeb\x0b\xb7\xb2\xb0\x54\x05\x91\x9c\xbc\xe7\x10\xef\x55\xa9\xc4\xc8\x93\x6b\xc4\x68\xf7\xf2\xe6\x2c\x5f\x8c\xe5\xbd\x0a\x05\xe5\x32\x69\xf0\x82\x75\x73\xec\x0f\x57\x54\x46\x51\x9e\xd7\xfc\xbe\xd3\x7c\x7d\x51\x79\x95\x83\x8c\x39\xd0\xe5\xf3\xb0\xaf\xe9\xd9\x3a\x47\x3e\x14\xe4\xef\xc3\x03\xb6\x96\xdb\xac\x84\x1d\x15\xe2\x39\x1e\xd5\xb7\xd7\x5e\x20\x43\x1c\x6a\x15\x9a\x58\xe3\x18\x5e\xec\xc8\x21\x84\x5f\x9a\x6e\x18\x1f\x5a\x6a\xd9\x1e\x87\x10\xe1\x2d\x06\x56\x48\x49\x0d\x59\xe1\xd9\x86\x62\x6b\xf7\x4a\xd2\x48\x48\x19\x32\x31\x9e\x55\x93\x03\x16\x9d\xaf\x47\x4e\xb2\xe9\x0f\x0d\xcf\xc1\x8c\xd2\xc6\xd2\x29\x05\x7d\x52\x9c\x09\x12\x08\x7a\x93\xa6\x9b\xe2\xf6\xe6\xec\x4b\xc2\x14\x3e\x9e\x7f\x3d\xc3\xd5\x9b\x04\x46\xf8\x2f\xf1\x4a\x7f\x32\x95\x90\x3e\x51\x7f\xab\x29\x9e\xe5\x3d\x67\x44\x58\x2f\xdf\xba\xe7\xe4\xa6\xa1\xf5\xc7\x92\x2d\x0d\x3d\xfa\x00\x7b\x2b\x1a\x47\xcf\x0d\x9f\x9d\x3b\x2d\x85\x3b\x51\x3a\x29\xe5\x4b\xe0\x6c\x39\x0f\xef\x36\x76\x17\x4b\xa1\x05\x4a\x4f\xbd\x90\x85\x51\x2e\x88\xf9\x09\x4f\xb6\x1e\x51\xe5\x68\x2c\xc9\xaa\x28\xba\x0f\xc1\x3d\x19\x4c\xee\x66\x46\xb9\x73\x98\x8e\x64\x67\x95\x3f\xde\x5d\x84\xe6\x82\x95\x74\x82\xec\x77\x10\x0f\x91\xfd\xf1\x67\xbe\x74\xa7\x15\xa1\xc7\x67\x45\x43\x18\x95\xbf\xe7\x99\x79\xf5\xb9\xe7\x54\xbe\xc3\xd7\xb9\xd9\x7f\x7b\xa7\x4a\xfb\x43\xc3\x72\x17\x53\xf4\xf9\xf1\x6a\x08\xb5\xed\xf5\xf2\x19\x87\x1f\x31\x39\xc5\xe4\xff\x6f\x8b\xc9\x77\xc5\x4d\x31\xf9\x6e\x7c\xfb\xf2\x64\x61\x69\xd9\x93\xf4\xf1\xe2\x92\x2b\x18\x5a\xf2\xa2\x14\x5e\xa4\xd8\x1b\x2e\x3f\xb7\xc3\xc8\x20\x85\xde\xf4\x8f\xbc\xf4\xf9\x2e\x3f\x54\xd8\x92\x4b\xac\x32\xf3\x58\x72\xa3\xe4\x11\xb3\x32\x5f\x00\xfb\x0a\xdb\x90\x3d\x99\xf8\x04\xb9\x5c\xf6\x56\x92\x91\x4b\xcf\x89\x8a\x59\xe7\x6b\xb6\xea\x2f\x2a\x17\xe4\xc3\x4e\x5d\x8a\xf5\x7d\x9f\x7e\x5f\xbe\x24\x30\xda\x4b\x64\x04\x79\xc6\x6f\x71\x5b\x7c\x53\x7c\x3b\xfe\x26\x9d\x70\x3a\x47\xd6\x3d\x69\xe9\x17\x95\x6e\x47\xd6\xc1\x33\x2a\x2b\xb4\x0f\x2a\xb5\x6c\xac\x0a\x51\x7e\x75\xb7\xbc\xb8\xff\x66\xcd\xbd\xce\x17\x9f\xe2\x64\x68\x5d\x93\x23\x48\xa1\xfb\xfb\xf7\x86\xa0\x74\xa9\xf6\xaa\xec\x44\x93\x5d\xbc\xc8\x79\x95\x2b\x54\xbc\x49\xe6\x5c\x3b\x19\x7a\x65\xb9\x33\xee\xc9\xf0\x28\xae\x9d\xbe\xe3\x5a\xff\x5d\xc8\x36\xb6\x8f\xb3\xc1\x2a\x4c\x9f\x86\xd0\x8e\x9c\x54\xa4\xbd\x72\xde\x5d\x4e\x7c\xaa\xde\xf1\x54\x9d\x4f\xe4\x31\xcd\xef\x96\x70\xe1\x22\x2f\xa1\x4c\x68\xf9\x36\xfd\xe2\x91\x7e\xea\x89\x72\x3f\x72\x67\x51\x72\x2b\x94\xee\x7b\xc1\xbd\x90\xf5\x89\x81\xb3\x7b\x21\x94\x4e\xd3\xf3\x65\x07\xae\x8e\x37\xd0\x40\x18\x6b\x42\xa7\xd5\x9f\x1d\x41\x99\x45\x80\x20\x5a\x0e\x97\x85\xa6\xc9\x3d\x25\x6f\x39\x8d\x3e\x5d\x2d\x47\xca\x0c\xfe\x33\x00\x34\x7c\xd9\x4e\xa4\x12\x00\x00",
+	"deployment/gke/deployment_manager_configs/cluster.jinja":              "\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xcc\x3b\xed\x72\x1b\x39\x8e\xff\xfb\x29\x70\x49\xb9\x3a\xb9\xb1\xda\xf1\xcc\xee\x5c\xad\x66\x3d\x75\x1a\x59\x93\x51\x25\x96\x55\x92\xe3\xcc\x5e\xca\xe5\xa2\xbb\xe1\x16\x27\x2d\xb2\x8f\x64\xcb\xd1\x6a\xf5\xee\x57\xfc\x68\x89\xfd\x21\x59\x4e\x32\xb9\xd5\x1f\x49\x4d\x10\x00\x01\x10\x00\x09\xf4\xea\x79\xd0\xe7\xf9\x52\xd0\x74\xa6\xe0\xfb\x57\xa7\x3f\xc2\x6b\xce\xd3\x0c\x61\xc8\xe2\x08\x7a\x59\x06\x66\x48\x82\x40\x89\x62\x81\x49\x14\xbc\xa5\x31\x32\x89\x09\x14\x2c\x41\x01\x6a\x86\xd0\xcb\x49\x3c\x43\x70\x23\xc7\x70\x8d\x42\x52\xce\xe0\xfb\xe8\x15\xbc\xd0\x00\xcf\xdc\xd0\xb3\x97\x3f\x05\x4b\x5e\xc0\x9c\x2c\x81\x71\x05\x85\x44\x50\x33\x2a\xe1\x9e\x66\x08\xf8\x29\xc6\x5c\x01\x65\x10\xf3\x79\x9e\x51\xc2\x62\x84\x07\xaa\x66\x86\x88\x43\x11\x05\xff\x70\x08\xf8\x9d\x22\x94\x01\x81\x98\xe7\x4b\xe0\xf7\x3e\x14\x10\x15\x00\x00\xcc\x94\xca\xbb\x27\x27\x0f\x0f\x0f\x11\x31\x4c\x46\x5c\xa4\x27\x99\x05\x92\x27\x6f\x87\xfd\xc1\x68\x3a\xe8\x7c\x1f\xbd\x0a\xde\xb1\x0c\xa5\x5e\xe8\xff\x16\x54\x60\x02\x77\x4b\x20\x79\x9e\xd1\x98\xdc\x65\x08\x19\x79\x00\x2e\x80\xa4\x02\x31\x01\xc5\x35\x97\x0f\x82\x2a\xca\xd2\x63\x90\xfc\x5e\x3d\x10\x81\x41\x42\xa5\x12\xf4\xae\x50\x15\xf1\x94\x3c\x51\x09\x3e\x00\x67\x40\x18\x3c\xeb\x4d\x61\x38\x7d\x06\xbf\xf4\xa6\xc3\xe9\x71\xf0\x7e\x78\xf5\xdb\xe5\xbb\x2b\x78\xdf\x9b\x4c\x7a\xa3\xab\xe1\x60\x0a\x97\x13\xe8\x5f\x8e\xce\x87\x57\xc3\xcb\xd1\x14\x2e\x7f\x85\xde\xe8\x1f\xf0\x66\x38\x3a\x3f\x06\xa4\x6a\x86\x02\xf0\x53\x2e\x34\xef\x5c\x00\xd5\x82\xd3\x6a\x9a\x22\x56\x88\xdf\x73\xcb\x8c\xcc\x31\xa6\xf7\x34\x86\x8c\xb0\xb4\x20\x29\x42\xca\x17\x28\x18\x65\x29\xe4\x28\xe6\x54\x6a\xd5\x49\x20\x2c\x09\x32\x3a\xa7\x8a\x28\xf3\xbf\xb1\x9c\x28\x78\xbe\x0e\x82\x60\x75\x04\x12\x15\x8c\x7a\x17\x83\xdb\xf1\x64\xf0\xeb\xf0\x77\x38\x03\x64\x8b\x0f\x61\x82\x79\xc6\x97\x73\x64\x2a\xbc\x81\xa3\x75\x09\xd9\x7f\xfb\x6e\x7a\x35\x98\xdc\xea\x19\x70\x56\x99\xe8\x01\x8d\xdf\xdd\x8e\x2f\x2f\xdf\xd6\x00\xbe\x83\xb0\x13\xe7\x45\x27\xe7\x3c\xeb\x84\xf0\x1d\xe4\x82\xe7\x28\x14\x45\x19\xa5\xa8\x5e\x84\x9b\xc1\x85\x35\xc1\xf0\xd8\x03\xf9\x10\x56\x86\x6e\x5e\x7a\x04\x5f\xef\x26\x98\xee\x23\x98\x7e\x2e\xc1\x69\x6f\x74\xfe\xcb\xe5\xef\xbb\x88\x4a\xc2\x92\x3b\xfe\xa9\x8d\x70\x1d\xab\x87\xf4\xfa\xe2\xf6\xb2\xf7\xee\xea\xb7\xdb\x69\xff\x72\x3c\x98\xc2\x19\x7c\x08\xf5\x16\x90\x6e\x0f\xa4\x66\x73\x93\x9c\xca\x28\xe6\xf3\x13\x52\xa8\xd9\x49\xc6\xd3\x94\xb2\x34\xd2\xd6\x8c\xe1\x71\x00\x3b\x3f\x8f\xa2\x9a\x73\x46\x15\x17\x94\xa5\x5f\x86\x27\xc1\x85\x54\x5c\x90\x14\x23\x81\x24\xb9\xe5\x2c\x5b\xda\x75\x06\xab\xe7\x30\x22\x73\x94\xc6\x9e\xb5\x4b\xa2\x31\x02\x89\x63\x5e\x30\x25\xa3\x00\x00\x3a\x24\x99\x53\x06\x54\x82\xe2\x70\x87\xda\xc7\x24\x06\xda\x3e\x57\x44\x7e\x94\x06\xae\x90\x28\x6a\x60\x77\x4b\xfd\x2d\x2c\x76\x12\xab\x82\x64\xf0\x07\xbf\x73\x88\x17\x73\x0d\xbe\xc1\xa7\xf7\xc2\xf5\x45\x9d\x09\x20\x4a\x69\x47\x63\x9c\x84\x06\x79\xfd\x66\x00\xd7\x17\x1a\xc5\xf3\x8d\x9e\xde\xfc\x7a\xdb\x3b\xbf\x18\x8e\xda\x36\x81\xd6\xbe\x61\x35\xf4\x14\xfb\xe6\xd7\xdb\x77\xd3\xc1\x64\x17\xbc\x66\xba\x06\x7e\x7d\x71\x3b\xed\xed\x82\x5f\xcc\x2d\xf4\xf3\x00\x60\xc4\x13\x04\x6d\x51\x12\x32\x2a\xb5\x63\xa2\x0c\x18\x4f\x70\xcc\x79\x36\xb5\x8b\xeb\x39\x01\x83\x28\x18\x10\xa9\xd7\x45\x05\xf0\x07\x06\x7f\x67\x64\x8e\x3f\x77\xfe\xae\x11\xfc\x5c\x97\x45\x00\x40\x99\x54\x48\x92\xd2\x3d\xcb\x19\xd1\xbe\x75\x31\x07\xce\xf0\x27\xf8\x78\x1f\xab\x0c\xee\x28\x4b\x4a\x9c\x82\x67\x28\x8d\x6b\x71\x4b\xd1\xfb\x43\xaf\x44\x7f\x6b\x83\xf6\xf7\xc1\x0e\x2e\xc3\x1b\xe0\x02\x3e\xdc\xb4\xf8\x93\x3d\x32\x29\x5d\x47\x08\xf4\x1e\x42\xef\x1f\xab\xf1\x80\x99\xc4\x9a\x84\x9b\x7e\x64\x0f\xa1\xb4\x42\x28\x7d\x32\x21\xad\xb4\x69\xcd\xf2\xb5\xbb\x86\x34\xe3\x77\x24\x03\x92\x24\x3a\x1a\xa0\x84\x84\xb3\x50\x81\x22\x1f\x75\xfc\xba\xc3\x4c\xfe\x64\x94\xc0\x1f\x18\x0a\x39\xa3\xb9\x7b\x1a\x00\x10\x81\x20\x30\xe6\x22\xb1\xfa\xb7\xca\x48\x50\xc6\x82\xe6\x3a\x00\x94\x8a\xf4\x55\x73\xf9\x7e\x34\x98\xdc\xf6\x46\xa3\xcb\xab\x9e\x0e\x4e\x70\x06\x2f\x7c\xf5\x58\xec\x56\x1b\xab\xf5\xcb\x88\x2a\x9c\xcb\x17\x2f\xe1\x5f\x20\xb9\x50\xf0\x2f\x98\x93\xfc\x45\xf8\x07\xa7\xda\x6b\x86\x67\xa1\x1e\xd1\xff\x5e\x84\xc7\xa1\x75\x95\xcf\xe1\x4a\x1b\x4d\x71\xc7\x50\x3d\x70\xf1\x11\xf8\x3d\x90\xd2\x88\xae\xc7\x7d\x98\x71\xa9\xb4\x49\xfc\x81\xb1\x3a\x86\x87\x19\x1a\xd6\x21\xce\x0a\xa9\xcc\xee\xd6\x02\xb0\xeb\x81\x04\xef\x49\x91\x29\x70\xb8\x22\x6f\x3f\x4e\x7f\xeb\x4d\x06\xe7\xb7\x1a\x63\xd5\xc2\x2c\xa9\xeb\x3c\x76\xee\x47\xa0\xe4\x85\x88\x51\x76\x83\x0e\x68\xd3\xef\xc2\x6a\x55\xdb\xce\xeb\x75\x00\xa0\x96\x39\x76\x81\x92\x79\xb4\x38\x8d\x64\xc5\x3e\x03\xf0\x48\x74\x03\x00\x28\xd5\x38\x4c\x76\xa1\x03\x9d\x39\xe4\x19\x59\x8e\x0c\xcd\x52\xfd\x0e\xe3\xd6\x27\xbd\x29\xee\xf0\x3e\xe3\x0f\xce\xd9\x91\xd8\x04\x6f\xe3\xbe\x60\x75\xa4\x0d\xae\xa1\xb4\x23\x87\x7f\xab\xeb\x2e\x3c\x5b\xad\x9a\x80\xeb\xf5\xb3\x12\x0f\xb2\x84\xde\x1b\x81\x54\xa5\xb0\xf5\x51\x5f\x43\x08\x35\x6c\x4f\x96\x81\x71\xf0\xdf\x5a\x04\xde\x66\xfd\x1a\x32\xa8\xa3\xab\x09\xe1\x75\x7f\xdc\x10\x84\xe2\x7a\xe5\x40\xa4\x8e\x4e\xf5\xc1\x8a\x80\xfa\x6e\x9b\x5c\x5f\xc8\x3f\x4f\x3c\xab\x23\x43\x54\xfb\xb8\xa6\x8b\x3b\x5a\xfb\xe2\xf3\x3d\xe5\x7a\xdd\x59\xad\xec\xac\x2f\x96\xe3\x3e\xbc\x5f\x43\xa0\xda\xbb\x6c\x84\xba\xc5\xfe\xa7\xca\xd5\xfe\xd6\xd4\x6b\x36\x58\xc9\xaf\xcd\x12\x2d\x79\xdf\xad\xa5\x1f\xb1\x97\xd3\xeb\x4d\x06\x79\x76\x06\xe1\xe2\xf4\x0e\x15\x39\x0d\xe1\x68\x2b\xee\x34\xce\x3b\xfa\x97\x3c\x89\x39\xd3\xa7\x2d\x14\x1d\x07\xd7\x75\x5e\x57\x46\x19\x8f\xed\x19\x21\x72\x6e\x57\x5a\x9a\x26\x7c\x79\xc8\x36\x28\xb4\x06\x1d\x68\x50\x5d\x54\x53\x91\x39\x11\xc8\x54\xb7\xf4\xf1\xf2\x64\xb5\xb2\xa7\x0b\xf7\x20\xbc\x81\xf5\xfa\x64\xc3\x82\x1e\xf6\x17\xfa\x4f\xce\xd0\x80\x18\x64\xfa\x5f\x17\xf6\x82\x38\xbe\x2c\x71\xd8\x27\x55\xfd\xa1\x8c\x2a\x4a\x32\xb7\x8f\x9c\x3c\xad\x12\x7d\x12\x0e\xa9\x97\xb2\x97\x1a\x2d\x95\xe3\x05\xa0\xa3\x12\xb9\x8b\x53\xd5\xb5\x6f\x01\x3f\x84\x3a\xf8\x8d\x7d\x31\xd8\x04\xe0\xc4\x4d\x6c\xc0\xbb\xe7\xdb\xc5\x82\x17\x5a\x9f\x40\x46\x60\x5a\xca\xda\x87\xb3\x8f\x2d\xc8\x16\x6f\x03\x6c\x3b\xe4\x33\x42\xf3\x5e\x56\x6a\x71\xcc\x33\x1a\x2f\x4b\x15\x80\xde\x7c\xc3\xbc\x97\x51\x22\x51\x76\x41\x89\x02\x37\x43\x4e\xb2\x53\x8c\x39\x4b\x88\x58\x4e\x08\x4b\x71\x54\x2a\xcd\x27\x9b\xf3\x44\x9a\x51\x9f\x2a\x94\x19\xab\x3c\x00\x43\x09\xda\xc0\x52\xb5\x60\xfd\x29\xd3\x84\xb7\x26\x03\xda\xae\xc4\x5d\x28\xd8\xad\x1e\x7e\x74\x2e\x23\xdc\x8c\x3b\x5f\xf9\x11\x97\xc7\xb0\x20\x59\x81\x40\xd9\x61\x39\xd5\xd1\x76\x49\xab\x95\x46\x00\xeb\x75\x17\xc2\xd5\xca\xe1\x59\xaf\x2b\x54\xb6\xae\xc3\xb7\xc2\x83\x5d\x04\x17\x15\x58\x64\xfa\x8e\x64\xaa\x48\xfc\x31\x11\x74\x81\xc2\x66\x4b\x60\x3e\xcf\xe1\x3d\x02\x43\x4c\xe0\x34\x3a\x7d\x15\x7d\x0f\x8a\x83\x2c\xf2\x9c\x0b\x05\xde\x14\x7d\x4e\x8a\xdc\x14\x77\x24\x75\xae\xb6\x5b\xfe\xaf\x9f\x15\xb5\xfc\x04\x43\x85\xd2\xcd\xdb\x9e\x3f\x37\x53\xb7\x8f\x1e\x9d\xdd\x54\xe3\xe7\x38\xce\xf6\x79\x56\x42\xb7\x2a\x2f\x7c\xd1\xd8\xa7\x57\x79\xd1\x70\x4a\x15\xf8\x75\x15\x2f\xe3\xaa\xd5\x5f\x1c\xbe\x85\x0e\x25\xd6\x14\x47\xed\x49\xce\x93\x29\xc6\x85\xa0\x6a\x69\x29\xf6\x39\xbb\xa7\xe9\x96\xae\x45\x9d\x34\x48\x4a\x37\xcb\xc2\x87\x37\x1f\xc2\x06\xaa\xe6\xc2\x7d\x04\x0b\xfd\x23\x26\xd9\x98\x27\xbd\x42\x71\x19\x93\x4c\x5f\x3b\x78\xc2\x6d\x87\x68\x61\xcd\x73\x28\x87\x98\x80\xf6\x5c\x19\x27\xc9\x30\x41\xa6\xa8\x5a\xfa\x34\xeb\x63\x75\x71\x50\xf7\xdc\x5c\x61\xe4\x24\xb6\x4e\xa6\x11\xcf\x22\xb9\x88\x23\x9a\x18\x8b\x7d\x02\x67\x77\x94\x11\xb1\xec\x15\x6a\xc6\x05\xfd\xa7\xb1\x03\x9f\xb9\x96\xe1\x2f\x97\x86\xc0\x0c\x89\xc4\xfe\x8c\x30\x86\x99\x4f\xae\x3a\xb2\xa5\x14\xbb\x07\x75\x93\x68\x60\x7a\x92\x25\x36\x39\x73\x41\x66\x63\x4c\x1b\x50\x92\x24\x9c\xc9\xba\x6a\x2a\xe0\xf5\x41\x93\x24\x3a\x01\xdd\x93\x4c\x62\xd0\x32\x69\xbf\x30\x4d\x6a\xb3\xa0\x09\x8a\x2e\xf4\x7b\x6f\x87\xfd\xcb\x9d\x6b\xb1\xa7\xde\x84\xd0\x6c\x09\x73\x42\x99\x42\xe6\xae\xc2\x59\xc2\x1f\x80\x4a\x20\x99\xe4\xe5\xb1\x76\x71\x0a\xbd\xf1\x30\xda\x29\x07\x0f\xc3\x54\x11\xa1\xae\xe8\x1c\x7d\x71\x78\xe3\xf5\x75\x58\x82\x15\x31\x68\xa6\x2e\xb6\x33\xde\x37\x20\x00\x64\x49\xa5\x99\x08\xed\xe0\xa5\x92\x0d\x3d\xaa\xd8\x16\xdf\x21\xe8\x82\x28\x74\xc9\x40\x78\x53\x9f\xfd\x39\x1e\x33\x16\x48\x14\x4e\xbd\xfc\xa8\xa2\xcc\x9d\x39\x49\x8b\x93\xb4\xdc\xb9\x24\xb1\x6e\x5a\x73\xa2\x9f\x0e\xf3\xc5\x5f\xfa\x34\x11\xbf\x64\x3c\xfe\x78\x80\xb7\x6c\x99\x55\xcd\x6b\xac\x09\x8e\x2d\x69\x7d\xc5\x57\xe5\xd2\xce\x2f\x7d\x01\x26\x23\x97\xac\x7d\xbe\xff\xde\x8f\x71\x60\xf1\x54\x79\x3c\x44\xb5\x87\xa2\x3d\xda\xa2\x8d\x4b\x89\x48\xdf\x2e\xbf\x70\x01\x5a\xcc\x0d\xee\x1f\xf5\x4a\xcf\x41\x87\x1e\xb3\xf1\x75\xc2\xa0\x6b\x2c\x84\x25\x40\xb6\xf1\x48\x33\xa5\x8b\x5f\x12\x88\x40\xd0\x17\xdd\x65\x72\x64\x2f\xe2\x5c\x72\x11\x7d\x5e\x46\xa2\x89\x55\xd2\x47\x52\x63\xa7\x13\x6f\xd6\x8f\x1b\x59\xd6\xb2\x3b\x8f\xdb\xb1\x65\xd6\x55\x32\x9c\x3b\x6d\x0b\xae\x4d\x3e\xdb\xb0\xf8\x5a\x6b\x8e\x37\x8c\xae\x15\xc5\x5e\x8d\x00\xb8\x0b\xbe\x51\x6f\x0c\x67\x0d\x64\xad\x92\xa8\xcd\xa6\xf7\x7a\xb2\x2f\x9e\xa3\xfa\x2e\xd3\xdb\xab\xae\xe7\x9a\xbb\xd8\x1c\x08\x74\x45\xcd\xb3\xcb\xce\x66\xe4\xca\x9c\x91\xf5\xbd\x73\xe8\x5b\xed\x96\x81\x39\x65\xfa\x8e\xba\xca\x00\xc0\x9c\x32\x3a\x2f\xe6\xee\x9e\xa3\x02\xb7\x5e\x57\x31\x35\x84\x03\x30\x27\x9f\x6a\xb3\xc9\xa7\xe6\xec\x06\x97\x73\x9c\x73\xb1\xdc\xc3\xa8\x03\x38\x84\xd7\x0d\xe8\xe7\xb2\xbb\x13\x81\x2d\xe2\xc4\x98\xa1\x20\x8a\x0b\xa0\x6c\x3b\xc9\x7b\x5e\xe5\xb2\xbe\xd6\xd5\xca\xc7\x11\xe9\xab\x8c\x06\x21\x7a\x5f\x81\xd1\x37\xaf\x3b\xd7\x5d\x07\x7c\xe2\xaa\xfd\xe9\xf6\x02\xaa\x05\x41\xe5\x68\x57\xd1\x8d\x3b\xc3\x76\xdc\xfd\x98\xdd\xec\x66\x84\xeb\xca\x5b\x47\xc6\x3c\x47\xd9\xdc\x99\xbe\x69\x8f\x5c\xd5\xe5\xdc\xde\xa7\x57\xbd\xec\x3e\x52\x95\x45\x55\xaf\xf0\xb6\x1a\x6d\x4e\x3b\x40\x42\x1e\xd9\xdd\xeb\x00\x30\x63\x53\x33\xb4\x25\x58\x9b\xf0\x28\xb5\xc3\x9e\x35\x9f\x94\xc5\xaa\x8d\xbc\xfc\x3b\xbb\xb2\xfa\xec\x51\x77\x77\x4b\x5a\xda\xfd\x8d\x88\x2a\xb7\x4a\x65\xe9\xb9\x0e\xb9\x3f\xca\xda\xba\xb5\xe6\x66\x73\x1f\x55\xb9\x3e\x58\xec\xba\xc5\xaa\x4c\xf1\x93\xb6\x76\x99\xb4\xc6\x85\xdd\x19\xc5\x66\x35\x16\xa0\x43\x2a\xa7\xba\x96\x1d\x77\xf8\xe4\xfa\x5e\x3c\x40\xa4\xda\x31\xe9\xf5\x36\x2c\x62\x4e\x3e\x1d\x32\x9d\x7c\x6a\x9f\xde\x26\xa8\xb8\x71\xd8\x78\xda\xa1\xd3\x3f\x78\x5e\xa0\x22\x09\x51\xa4\x79\x80\xb1\x06\x58\x8e\x77\xf5\x75\xcb\xed\xc5\xe0\xaa\x77\xde\xbb\xea\xdd\x4e\x07\x93\xeb\xc1\xa4\xc6\x67\xf6\x68\x72\x66\x9e\xe0\xc8\xc3\xfb\xe5\x7c\x4d\x07\xfd\x77\x93\xc1\x01\x3e\x31\x9e\x51\xb6\xf5\xd1\x3b\xd4\x60\x80\xcc\x15\x76\x5d\x13\x2d\xfe\xa7\x51\x24\x5e\xaf\xff\xbb\xf5\x78\xae\xab\x10\xa9\x43\xe0\xfc\x94\xbe\x52\xda\xe3\x69\xea\x8d\x19\x15\x56\x9e\xc3\x14\x95\x36\x4c\x88\xf3\x02\xf2\x8c\xa8\x7b\x2e\xe6\xa0\x38\x20\x93\x85\x40\xe8\x5d\xff\xfe\x3d\x50\xb9\xcd\x0c\xa3\xaa\x41\xf7\xf3\x62\xec\x66\x75\x21\x1c\x32\x85\x19\xfc\x22\x38\x49\x1e\x30\xcb\xc2\x00\x60\x5e\x8a\xd7\x15\x1c\x72\x64\x89\xbc\x74\xc7\xff\xce\xce\x52\x93\xb5\xc3\x86\x58\xfe\xe3\xac\x59\x95\xde\x20\x6a\x11\x62\xb3\x26\x64\xee\x04\xe7\x84\x91\xd4\x36\x29\xb1\x6d\x0f\x02\x91\x20\x31\x27\x82\x28\xdc\x44\x62\x19\xd9\x19\x09\xb7\xdd\x62\x52\x7f\x13\xa5\x99\x7b\x40\x78\x20\xb6\x46\x33\xd7\x75\x6e\x7d\xbb\x90\xa2\xd4\xcf\x63\xc2\x20\xc1\x0c\x95\xa5\x81\x9f\xa8\xd4\xed\x5a\x1b\xac\x26\x3b\x56\x33\x64\x20\xd0\x9e\xf6\x80\x2a\x4d\xe9\x5d\x9e\x10\x03\x99\x70\x34\x05\x64\x6d\xc5\x9a\xa8\x16\x27\xdc\x61\x4c\x0a\x89\x9a\x02\x11\x08\xa6\x47\xca\xe6\xea\x0f\x9a\xa7\x92\x81\xd7\x6f\x06\xa1\x84\x42\xa3\x42\x2d\xff\x19\x4f\x4a\xfd\xc9\xc8\x54\xc5\xea\x89\x7c\x9b\xf3\xf8\x19\x5e\xd5\x6a\x64\xaf\x2b\xa1\xe2\x9b\x97\x76\xa2\x4d\x18\x3b\xa0\xc8\x53\xc2\x7e\xb3\x2a\xcf\x49\xc9\xe6\xc9\x8e\x92\x8d\xc3\x77\xc8\x7d\xa4\x47\xf9\x89\xc5\x23\x57\x75\x6c\xa3\x5f\x4a\xa4\x5a\x5f\x2a\x55\x1f\x1c\x18\xf9\xd3\x43\x22\xff\xd3\xe2\xfe\xd3\xa3\x7e\xd3\x2f\x93\xbd\xd7\xac\xbb\xd6\xf0\x58\xbc\xdf\xb3\x53\x1e\x8b\xf6\x7b\x63\x7d\xfa\x48\xac\xdf\x1b\xe9\xd3\x47\x22\x7d\x53\x38\xf5\x28\xff\xd4\x18\xff\x78\x24\x3d\x3c\xbe\x7f\x9d\xe8\xfe\x54\x8e\x6a\x91\xbd\x2d\xae\xef\x8b\xea\xe9\xe3\x51\xbd\x25\xa6\xbf\xfe\x5a\x31\xfd\x09\x11\xfd\x4b\xe2\xf9\x21\xd1\xdc\x7e\xbc\x93\x60\xe5\x08\xd6\xf1\x47\x76\x5b\x2f\x2b\xe6\x77\x28\x3a\x39\x0a\x63\xbf\xf5\xf4\xa8\x82\x7f\xa7\x3e\xb6\x2a\x78\x2c\xc9\x30\xf1\x5b\x47\x64\x73\xc5\xe5\xc2\xed\xe9\x36\xf0\x03\x51\x40\x40\xd1\x39\x46\x5e\x2e\xd1\xe2\x41\xed\xbe\x79\xfd\xa4\x9c\xe4\xf5\x01\x39\x49\xb5\x67\xe5\x79\x00\x7a\x07\xc1\xd4\xb6\xe1\x82\x28\x98\xe9\x50\x04\x5d\x46\x06\xc5\x8d\x60\x74\x8a\xe0\xfa\x74\xa3\xf4\x23\x46\x94\x9f\x88\x82\xe9\x35\x9c\xa5\x0b\x2a\xed\x8d\x43\x7a\xad\x7f\x1d\x03\x67\x40\xec\x4a\xf9\x3d\x50\x25\x03\x30\xfd\x93\xb6\x99\xb2\x8c\x1c\xa6\x4e\x2a\x81\x33\xdb\xf7\xce\x0b\xa5\x53\x12\x18\xaa\x50\x02\x29\xef\x02\xe1\x1e\x89\x2a\x04\x96\xad\x78\xf5\x8d\x6c\x39\x0a\x6f\x6a\x89\x43\xa5\xe1\x78\xfd\x75\x13\x81\xff\xb7\x78\xde\x1e\x4f\xfd\xe6\xe9\x9d\x31\xf5\xf4\xa0\xa8\x55\xb9\xc5\xab\xc6\x93\x57\xc1\x57\x38\x14\xfe\xbb\x85\x85\xa7\x78\xe4\x03\xce\x59\x74\x4e\x52\x37\xb9\x7f\x39\xbd\xed\x5f\x8e\xae\x7a\xc3\xd1\x60\x72\xbe\x81\x70\xba\x6a\xf2\xed\x06\xec\x64\xbb\x9f\x82\x6f\x71\x78\x3b\xc8\xd1\x7f\x4b\x5f\x77\xe8\x21\xa1\xe1\xee\x0e\x70\x73\xe0\x1a\x7b\xca\xe6\x58\x59\x76\xad\x3b\x91\x95\x7d\xdb\xdb\x77\x39\x4c\xdb\xac\xe7\x56\x7c\xee\x68\xae\x0b\xdb\xe1\x0d\xf8\xfe\x45\xbf\xcb\x53\x28\xd4\xe7\x01\xdb\x9f\xd4\xb3\xfd\xc9\x2d\x6e\xa3\xda\x09\x37\xd5\xaf\x9e\xc4\x30\x1c\x57\x9b\x16\x29\x4b\xf5\xf4\x68\x67\x53\x1d\xb4\xb7\xd0\x79\x2b\x7f\xe6\x3c\xfc\x7b\xaa\x66\x94\x01\x31\xad\xc4\x65\x63\x5f\x9f\x33\x25\x78\x26\x21\x47\x41\xe7\xa8\xdc\x5b\x2f\xa5\x87\x16\xa8\xbb\xfb\xad\xd7\x76\xef\x4a\xf5\xc6\x43\xd7\x80\x1d\x8b\x88\x6a\xf9\x09\x5e\xa4\xb3\x00\x0c\x90\x40\xfd\xca\x4f\xac\xd0\xd6\xf3\xb7\x1d\x28\x70\x3d\x1c\xeb\x9e\x65\x1a\xcf\x5c\xe1\x47\xbf\x61\x65\x31\x1b\x94\xfa\xc7\x96\x89\xcd\xe1\x11\x5c\x55\x2f\xb0\x67\x12\x59\xea\xc8\xeb\x91\xae\x32\x2c\x79\xb6\x30\x67\x60\x93\x83\x50\x65\xbb\xc0\xaf\x87\x63\xa0\xd2\xad\x27\x29\x99\xf6\x3b\xa5\x03\x00\xc1\x0b\x85\xa5\x4d\xe8\xda\xad\x60\xa8\x20\x25\x0a\x1f\xc8\x72\x47\x00\xda\x2e\xd8\xca\xa7\x97\x53\xb9\x79\x4b\xa5\xd1\xeb\x56\x16\x68\x06\x57\xef\x2f\x27\x6f\xe0\x0c\xc2\x4d\xd0\x08\xe1\xbb\x3d\x8d\x68\xdf\x41\xd8\x68\x78\xab\xcf\xd8\x36\x9a\x39\xd3\x77\xa7\xd5\x47\x88\xd6\x9c\x46\x1b\x25\x27\xa2\xb0\xbe\xa7\x1c\xe6\xc9\x60\x7a\x35\x19\xf6\xaf\x34\x23\xc3\xb1\x79\x0f\xe7\xf4\x6f\x7f\x8b\x7e\xf8\x31\x3a\xfd\xeb\x0f\xd1\x5f\x74\xbf\xbb\xf7\xff\xaf\xb5\xff\x3f\xd6\xfe\xff\x57\x78\xd3\x8e\x7b\x32\xe8\x5f\x4e\xce\xf5\x6b\x11\xab\x00\x20\xdc\xda\x57\xd8\x85\x0f\x76\xef\x87\x7a\xa7\x86\x5d\x08\xff\xb3\x66\x7e\x91\x26\x62\x3c\x76\x17\xc2\xbe\x76\x3b\xe6\x81\xca\xc2\x2e\xfc\xf0\xea\xd5\x31\x84\x42\x68\x17\x67\x70\x85\x3b\xad\x38\x0a\x6f\xd6\xc7\x35\x52\x7b\x80\x3d\x9a\xbd\xdd\xf4\xaa\xe2\x33\xf8\x6f\x8e\xcd\x0a\x63\xd1\xbe\x34\xb3\xf3\x9e\xb6\xa4\x72\x4e\x93\xff\x16\x6c\x4f\x66\x76\xed\x34\xa6\x7d\x97\xde\xa6\xc7\x90\x70\xdd\xfe\x00\x94\xc1\x87\x17\xbe\xaa\x8e\x21\xac\x4b\xe9\xe5\x31\xbc\x30\x6b\x3d\xde\x32\xf3\xf2\xe6\xd1\x6e\x69\x4d\x47\xff\xd4\xdf\x2d\xf9\x5d\xc2\x64\x67\x71\xda\xb5\xf7\x6e\xc9\xff\x68\xdf\xd1\xe2\x84\x1f\xc7\x6f\x7d\x35\x93\x9b\x2e\x49\xb7\xb2\x75\x5b\x3f\xf3\xc4\xba\x1f\x59\x81\x03\xc5\x77\xfb\xc5\x2f\xf3\xec\x00\x00\xba\x54\x75\x47\x33\xaa\x96\xdd\xb2\xfb\x22\xf0\x3a\x31\xae\x37\xc3\xd5\xac\xa3\xdc\xdc\x5e\x81\xc6\x3e\x79\x27\xb2\x2e\xec\x79\x69\xcd\x85\xb8\x93\xc5\xa9\xce\x53\x4b\xaf\xb2\xb6\x11\x76\x62\x5e\xab\xd1\x3b\xd7\xd4\xf8\x59\xa8\x80\xc0\xf9\x85\xd1\x8e\xf1\xc4\xcb\x50\x20\x90\x24\xc1\xc4\x04\x11\x81\x73\xbe\xb0\xef\xa2\x95\x77\x89\xce\xa3\x6b\xf1\xd7\xc3\xef\x6e\x23\xb0\xaf\xf3\xc8\x00\xdc\x2b\x10\x2d\x96\x90\xe8\x7c\xde\xd2\x88\x6c\x9a\xd2\x48\x6d\xdc\x81\xc6\xef\x8e\xe9\x40\x7f\x32\xe8\x5d\x0d\x76\xde\x25\x3f\x6a\x98\x8d\xe3\x82\x77\x2b\xd7\x48\xd8\x02\x9b\x86\x6e\x6c\xf6\x00\xdb\x24\x49\x42\xcd\xc1\xc2\xc0\x36\xfd\xe5\x07\x0d\x6c\x70\x3f\x49\x94\x1d\x7b\x9b\xfc\x67\x48\xf4\x7c\xf0\x76\xf0\xef\x2c\x51\xb3\xf2\x83\x24\x5a\xa9\x40\xfb\x91\xf1\xff\x06\x00\xbb\x19\xfe\xd5\x6b\x3e\x00\x00",
+	"deployment/gke/deployment_manager_configs/cluster.jinja.schema":       "\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xc4\x58\x5b\x6f\xdb\x3a\x12\x7e\xd7\xaf\x18\x24\x2f\x09\x20\x2b\x6d\xb1\xd8\x07\xb7\x28\xa0\x3a\xd9\xd4\x68\x2e\x46\xec\xb4\x38\x7d\x39\xa0\xa9\xb1\x34\x1b\x8a\xc3\x25\xa9\x38\x3e\xbb\xfb\xdf\x17\x24\xa5\xd4\x76\x8a\x6d\x9a\x53\xec\xe6\x49\x96\xe6\xf2\xcd\x37\x37\x32\x87\x30\x61\xb3\xb1\x54\x37\x1e\xde\xbc\x7a\xfd\x57\x38\x67\xae\x15\xc2\x54\xcb\x02\x4a\xa5\x20\x7e\x72\x60\xd1\xa1\xbd\xc7\xaa\xc8\x0e\xb3\x43\xb8\x20\x89\xda\x61\x05\x9d\xae\xd0\x82\x6f\x10\x4a\x23\x64\x83\xc3\x97\x1c\x3e\xa3\x75\xc4\x1a\xde\x14\xaf\xe0\x28\x08\x1c\xf4\x9f\x0e\x8e\xdf\x66\x87\xb0\xe1\x0e\x5a\xb1\x01\xcd\x1e\x3a\x87\xe0\x1b\x72\xb0\x22\x85\x80\x0f\x12\x8d\x07\xd2\x20\xb9\x35\x8a\x84\x96\x08\x6b\xf2\x4d\x74\xd3\x1b\x29\xb2\x43\xf8\xad\x37\xc1\x4b\x2f\x48\x83\x00\xc9\x66\x03\xbc\xda\x96\x03\xe1\x23\xe0\xf0\xd7\x78\x6f\xc6\x27\x27\xeb\xf5\xba\x10\x11\x6c\xc1\xb6\x3e\x51\x49\xd0\x9d\x5c\x4c\x27\x67\x57\xf3\xb3\xd1\x9b\xe2\x55\x54\xb9\xd5\x0a\x5d\x08\xfc\x1f\x1d\x59\xac\x60\xb9\x01\x61\x8c\x22\x29\x96\x0a\x41\x89\x35\xb0\x05\x51\x5b\xc4\x0a\x3c\x07\xbc\x6b\x4b\x9e\x74\x9d\x83\xe3\x95\x5f\x0b\x8b\xd9\x21\x54\xe4\xbc\xa5\x65\xe7\x77\xc8\x1a\xd0\x91\xdb\x11\x60\x0d\x42\xc3\x41\x39\x87\xe9\xfc\x00\x3e\x94\xf3\xe9\x3c\xcf\x0e\xe1\xcb\x74\xf1\xf1\xfa\x76\x01\x5f\xca\x9b\x9b\xf2\x6a\x31\x3d\x9b\xc3\xf5\x0d\x4c\xae\xaf\x4e\xa7\x8b\xe9\xf5\xd5\x1c\xae\xff\x06\xe5\xd5\x6f\xf0\x69\x7a\x75\x9a\x03\x92\x6f\xd0\x02\x3e\x18\x1b\xf0\xb3\x05\x0a\x34\xc6\xd4\xc1\x1c\x71\x07\xc0\x8a\x13\x20\x67\x50\xd2\x8a\x24\x28\xa1\xeb\x4e\xd4\x08\x35\xdf\xa3\xd5\xa4\x6b\x30\x68\x5b\x72\x21\x99\x0e\x84\xae\xb2\x43\x50\xd4\x92\x17\x3e\xbe\x79\x12\x54\x91\x65\xa4\x57\x3c\xce\x00\x3c\x79\x85\x63\x38\xff\x74\x06\x52\x75\xce\xa3\xcd\x00\x44\xe7\x1b\xb6\xe3\xbe\xd0\xf2\x58\x69\x19\x40\x85\x4e\x5a\x32\xc1\xe8\x18\xfe\x95\x01\x00\x4c\x2c\x0a\x8f\x0e\xc4\xb6\x85\x00\x01\x84\x73\x2c\x49\x04\xce\xfc\xc6\xa4\x30\x42\x15\x91\x86\xd3\xcb\x02\x16\x0d\xa6\xf7\x52\x68\x58\x62\x34\xd6\x85\x72\x25\x0d\x1c\xd9\x39\xbd\x04\xc9\x7a\x45\x75\x67\xfb\x38\x48\xc7\x20\x56\xac\x14\xaf\x43\xd8\xad\xd0\x1a\xed\x38\x8b\xda\x07\xc1\xdc\x18\xde\xf5\x20\x46\xe1\xe7\xfb\xf1\x89\x30\x74\x72\xff\xfa\x44\x8b\x16\x9d\x11\x12\xdd\xc9\x3f\x1f\x9f\xff\x7d\x12\x3a\x86\x24\xba\x83\x2c\x1b\xaa\x68\x9c\x8d\xe0\x0f\xd6\x98\x65\xc6\xb2\x41\xeb\x09\x5d\x60\x2a\xbc\x1b\x47\x4f\xc9\x51\xa8\x09\x5d\xc7\x17\x3b\xc4\x7c\x65\x1d\xa3\x5c\x37\x24\x53\x43\x0c\xb4\xb8\x86\x3b\x55\x81\xed\x74\x60\x93\x34\x79\x12\xea\x8a\x2b\x9c\x70\xa7\xfd\xb6\x6d\xd2\x1e\x6b\xb4\x4f\x8d\x4f\x93\x12\xe8\xae\x5d\xa2\x0d\x9d\xa4\xb9\x42\x17\x84\x62\x07\x90\xde\xf6\x58\xf4\x06\x56\xa2\x53\x7e\x0c\x7f\xc9\x00\x94\x58\xa2\x72\xdb\xbe\x78\xf9\x77\x94\xfe\xa9\xab\xeb\xb5\x46\xeb\x1a\x32\xbd\x0e\x38\xf4\xa1\xc3\xee\x56\xd2\x2b\xe0\x1d\x47\x31\xe3\x16\x25\xdb\x0a\xab\xe1\x5b\x4a\xa3\x45\xc7\x9d\x95\xe8\x02\x18\x69\xba\x91\x61\x56\xa3\xfb\x34\x7a\x9e\xc3\xe7\x30\xa5\x5c\xb7\x5a\xd1\xc3\x30\x3c\x06\x4b\x31\x7e\x08\x4f\x39\x6c\x5b\x86\x75\x83\x1a\x3a\xed\xd0\x17\x30\xdf\x46\x1e\x3f\x90\x0f\x70\xfb\xe2\x0d\xf6\x92\x05\x2c\xea\x22\x56\xaa\x00\x8d\x6b\x68\x85\x6c\x48\xa7\x42\x0d\xf0\xeb\x5f\x06\xbf\xfe\x7f\xc0\x0f\xbe\x7e\x06\x7a\x68\xe9\x01\xcf\x3e\xed\x21\xe1\x4f\x83\x70\x79\x94\xda\x53\x6a\x45\xac\x91\xfd\x90\x56\x96\xdb\x38\xd5\x8a\xfa\x0e\x8b\x60\x63\xe0\xea\x31\xd8\xa1\xbc\x3a\x53\x5b\x51\x61\xde\x37\xd5\xd3\xd8\x1d\x08\x0f\xe4\x87\x28\x67\xcc\x6a\x9e\x5a\xbb\x94\x32\x74\xd7\x4e\xc9\x0b\x6b\xc5\xe6\x69\xbc\x57\x8f\x61\xc0\xd1\x10\x68\xfe\x18\xe5\x31\xf8\x46\x78\xa8\xd1\x83\x80\x0a\x2b\x92\x71\xb8\xbd\xab\xd0\x28\xde\xb4\xa8\xfd\xfb\xd1\xbb\x20\xf8\x1e\xfa\xa9\x02\x22\xf9\x4e\x6d\x48\x1e\xdb\x1e\xc5\x13\xe2\x51\x87\x8d\x35\xf7\x42\xde\x55\x96\xee\xc3\x4c\xfb\x26\xb5\x64\x56\x28\xf4\x53\xbc\x5f\x1a\x8c\x2d\xe6\x19\x1c\xea\x2a\x92\xa1\xb8\x8e\x2b\x00\x5a\xf4\x96\xa4\x7b\x4c\x5c\xcf\xa4\x67\xd8\x72\x03\x9f\xba\x25\x5a\x8d\x81\xca\x33\x5d\x93\x46\xb8\x64\x4d\x9e\x6d\x5c\x92\xeb\x46\x78\x0c\x62\xf5\x1d\x96\x86\xfa\xf4\xec\x67\xef\x09\xf8\xc7\xfc\xed\xcd\x9f\x95\x50\x0e\x33\x00\x8b\x0a\x85\xc3\x49\x23\xb4\x46\xf5\xdc\x4a\xec\xb5\x40\x26\x35\x38\xba\x29\x67\xd3\xd3\x1c\x6e\xce\xce\x6f\x2f\xca\x1b\x60\x0b\xf3\x45\xf9\xe1\xe2\xec\x78\x27\x60\x72\x80\xda\xb2\x52\x71\x3a\xbe\x05\xd6\x6a\x03\xae\x33\x86\xad\x8f\xaf\x76\x83\x83\xfb\xd7\x4b\xf4\xe2\xf5\x77\x4a\x74\x27\xac\x56\x90\xf6\xa8\x85\x96\x21\x72\xeb\x17\xd4\x3e\x6b\x3b\x44\xe1\x1c\x3e\x7e\x1c\x5f\x5e\x06\xe7\xe7\x97\x8b\x7c\xc8\x51\x25\x48\x6d\xb6\x2d\xc3\x9a\x74\xc5\xeb\x1f\x61\x11\x9d\x67\x27\x85\x22\x5d\xcf\x2c\x87\x13\xda\x73\x90\x4c\x86\xd1\xdd\x6b\xa3\x05\x93\xb4\xe1\xe8\x43\x79\x51\x5e\x4d\xce\x4e\x81\x2d\x5c\xcf\x16\xd3\xcb\xe9\xd7\xb3\xdf\x6f\x17\xd3\x8b\xe9\xd7\x32\x9c\x67\x8e\x7f\x19\x8f\xc1\xbb\xb1\x7c\x4f\x41\x89\x74\x3d\x4a\x3b\xff\x39\x0b\x2a\xb6\x6b\xd0\x1f\x6d\x1b\x78\xdb\x37\x53\x95\x43\x4b\x7a\x24\x4d\x97\x43\x2b\x1e\xfa\x07\xd2\xa3\x16\x5b\xb6\x9b\xd4\x23\xe2\x61\xf8\x79\x74\xfe\xe1\x38\x09\x0a\x29\x51\xa1\x15\x9e\x2d\x1c\x05\x04\x39\xc4\x2e\x8e\x1a\x1c\x7d\x0b\x15\x2c\x1d\xe7\xf1\x55\x3c\x9b\xa5\x86\x1f\xf5\x0d\x9f\x44\xc3\x29\x6a\xe4\x24\x1b\x7c\xec\xc2\x6f\x83\x12\xd2\x04\xab\x0a\xb8\x7e\x31\x91\xc5\x3e\x79\x3b\xcc\xde\xa3\xf5\x24\x85\x9a\x71\x55\x7e\x2b\x90\x9f\x1d\x2c\x89\xcc\x47\x63\x60\xb8\xda\xae\xb7\x5f\x56\x07\xdf\x19\x13\x6b\xb6\x77\x8a\x45\x35\xad\x50\x7b\xf2\x9b\x17\x42\xff\xd2\x9b\x81\xc1\x4e\xde\x6f\x8d\xca\x41\xe7\x02\x6b\xe1\xe7\xf9\x64\xb6\x3f\xb6\xe3\x76\xa1\x9d\x19\xb9\x27\x01\xe4\x60\xc9\x5d\x28\x02\xfe\x33\x4c\x14\x2b\x14\xbe\xb3\x78\x1e\x77\xda\x0f\x68\xd1\xe8\x03\x33\x33\x56\x24\x5f\xc0\xc9\x8a\xad\xc4\xc1\x08\x98\x60\x85\xd0\xa5\xdb\xdb\x44\x28\x92\xfc\x67\x01\x3a\xa1\xab\x25\x3f\xfc\x2c\x34\x51\xf5\xbd\x94\xd4\xf7\x4e\x16\x60\x3b\x1d\x4b\x9c\xbc\x4b\xb9\x0b\xfc\x7e\x26\xc7\x36\x61\x0f\x2b\x62\x9e\x54\xff\x67\xa9\x70\x8d\xb0\x58\x7d\x36\xf2\x39\xe3\x2a\xdc\x78\x92\x02\x7c\x9e\x4d\xc0\x75\xcb\x21\x09\x7b\xeb\xaa\x9f\x0c\x71\x5b\x35\xec\xfc\xcc\x72\xb0\x98\x0f\x49\xcb\xc1\x62\x4d\xac\xf3\x2d\x1b\xdf\x46\x51\xbc\xdc\x00\xaf\x22\x51\x0e\x25\xeb\x4a\xd8\x0d\x58\xa1\x6b\x4c\xcc\xdd\x84\xc7\x28\x3f\xdc\x7e\xe2\x9b\x7d\x32\xb6\x5c\x27\xe1\xf0\xf2\x31\xe2\x1d\x6a\x96\xa4\x85\xdd\x94\xf1\xd6\x48\x7f\xc4\xdb\xda\x0b\x9b\xf5\x43\xb4\x04\x3b\xa6\xf2\x94\x4e\x51\xb5\xe4\xfd\xd0\xaf\xd4\x8a\x10\x4f\xaa\x86\xd0\x09\xc3\xd5\xc7\x0c\x90\xc3\x25\xd1\xbd\xb8\x14\xbe\x13\xd2\x8f\x8f\x35\x61\xd5\x4a\x8f\x55\xba\x37\x97\x86\xdc\xcf\xd2\x60\xd1\xb1\xba\x4f\xff\x00\x48\x56\xa0\x9c\x4d\xd3\x89\xae\x96\xb6\x20\xee\xa5\x7a\x4f\x45\x1d\x85\x84\x21\x57\x48\x6e\x07\x12\x86\xca\xda\x3d\xfc\xe5\xfd\xdd\x60\xa0\x28\xfe\x47\x26\xd4\x62\x7f\x54\x86\x09\x6b\x6f\xc3\x72\x32\x68\xa9\x45\x8f\x76\x9f\x96\xbe\x62\x66\xc3\xf7\x1f\x71\x92\xd2\xfa\xbb\x37\xdd\x0b\x0b\x62\xa2\xb8\xab\x60\x31\xbb\xcd\x53\x9b\x4f\x67\x20\x14\x09\x87\x2f\x4f\x6d\xb2\xbc\x30\xdd\x7f\x07\xff\x9f\x01\x00\x32\xb6\xf5\xfe\x77\x13\x00\x00",
+	"deployment/gke/deployment_manager_configs/gcfs.yaml":                  "\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x5c\x91\xc1\x6e\xdb\x30\x10\x44\xef\xfc\x8a\x01\x74\x96\x5c\xa5\x39\xf1\x66\xc4\x4e\x50\xb4\x76\x8a\x38\x3d\xe4\x14\xd0\xe4\x2a\x66\xa3\x72\x09\x72\x55\xc3\xfd\xfa\x82\x84\xe5\xd4\xbd\x51\xc3\xb7\xa3\xd9\x61\x83\x0d\x3b\x3f\x9c\x20\x07\x9f\xe1\x43\x16\x13\x2c\x41\x18\x36\x91\x11\x82\xc1\xc3\xdd\xfd\x0e\x83\x1f\x09\x59\x38\x51\xa7\x1a\xf4\x1d\xee\x0e\x26\xbc\x11\xe4\x40\xf8\xc3\xa1\x4e\x94\xb3\xa3\xec\x13\xb9\xaa\xa9\x06\x37\x57\xe0\x6c\xff\xc5\xfd\x8f\x7b\xa7\x1a\x7c\xbe\xc0\x81\xe4\xc8\xe9\x1d\x7e\x40\x20\x72\x54\x6e\x6f\xaf\xac\xac\x89\xc6\x7a\x39\x15\xe4\x6c\xd2\xa9\x44\x99\xa7\x64\x29\x6b\xd5\x22\x98\x5f\xa4\x6b\xee\x1a\x5b\x01\x72\x8a\xa4\xf1\x66\x63\x5b\x4e\x79\x51\xee\xda\xdf\xfd\x9e\xc4\xf4\x3a\x26\xfe\x49\x56\x72\x37\xb2\x35\xe2\x39\xe4\x6e\x8e\x9b\x15\x10\x13\x47\x4a\xe2\x8b\x37\x00\x44\x93\x28\x88\xc6\x3c\xb6\xf0\x99\x47\x23\xe4\xda\xb3\xb2\xb8\xf8\x2c\xa6\xdc\x1e\x29\x4b\xdf\xee\xeb\x68\x83\x65\x38\xd5\x78\xe0\xe1\xaa\x17\x1c\x79\x1a\x1d\x1c\x57\xee\xa3\x2d\x8d\x97\xc7\x1f\x4f\xaf\xab\xf5\xf7\x6f\x8f\x2f\x9b\xf5\xf6\xf9\x75\xbb\xdc\xac\x2b\x24\x9e\x92\xc6\xee\x79\xb9\x5d\x2d\x9f\x56\x55\x72\x94\x6d\xf2\xb1\xfc\x5b\xe3\x7e\xde\x1f\x03\x27\x7c\x9d\xf6\x34\x8c\x7c\xac\xdc\xb9\xe4\xf3\x42\xed\xfc\xad\xe1\x68\x30\xd3\x28\x55\x2e\x1d\xed\x0e\x26\xd1\x07\x56\x7b\x7d\xff\xd7\x09\x97\xe7\x78\xd8\x6b\xf4\x9f\x6e\x6e\xd5\xdf\x01\x00\x98\x9f\xdc\x63\x58\x02\x00\x00",
+	"deployment/gke/deployment_manager_configs/iam_bindings_template.yaml": "\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xb4\x54\xc1\x6e\x1b\x3b\x0c\xbc\xef\x57\x10\xf0\xe5\xbd\xa2\xde\xde\x73\x4b\xd2\x22\xc8\x21\x45\xd1\x16\xe9\xb1\xe0\x6a\x69\x2d\x6b\x49\xdc\x48\x54\x0c\xff\x7d\x21\x69\xed\xba\x8d\x61\x04\x05\x7a\x34\x67\x38\x43\x71\xb8\x5e\xc1\xd7\x89\x13\x18\x09\x1b\xb6\xc0\x09\x72\xa2\x11\x86\x3d\x30\xfa\xef\x33\xaa\x99\xfa\x79\xdf\xc3\xbd\x16\x2c\x88\x02\xc2\xfb\x87\x85\xde\x77\xab\x6e\x05\x5f\xcc\x44\x1e\x61\x23\x11\xb4\x48\xed\xd1\x3b\xd8\xb0\xa3\x6e\x05\x6f\x60\xe0\x30\x72\xb0\xa9\xb4\x23\x38\x4e\x0a\xb2\x81\xff\x3c\xf9\x81\x62\x7a\x0b\x51\x1c\xa5\xff\x61\x64\xa3\x95\xbf\x00\x80\x61\x6c\x18\x60\xa4\xda\x97\x2a\x4e\x68\xa6\x0a\x00\x87\x85\xc0\x09\x6c\xc4\xa0\x34\x82\x4a\x23\x34\x95\x42\x59\xf4\xba\xc3\x1c\x57\xdd\xfa\x50\xbb\xea\x00\xd6\x90\x48\xd7\xdb\x3c\xd0\xc6\xc9\x6e\x8d\xa3\xe7\xb0\x4e\x14\x9f\xd9\xd0\x1a\x8d\x91\x1c\xb4\x83\x66\x54\xf8\x2b\xb8\x2b\x56\x30\x53\xf4\x9c\x12\x4b\x48\x10\x88\xc6\xe6\x3d\xe7\x34\x81\x4e\x04\x38\xcf\xe5\x37\x82\x71\x92\x47\x88\x34\x4b\x62\x95\xb8\xaf\x96\x55\xed\x5d\x92\x1c\x0d\xf5\xd5\xb2\x2a\x2f\xb6\x1e\x03\x5a\xf2\x14\xb4\x61\xc0\x47\x8b\x61\x0f\xb7\x45\xf0\x43\x18\x67\xe1\xa0\x35\x37\x8d\xe2\x1c\x45\x48\x02\x3b\x02\x83\x01\x4c\x24\x54\x02\x3c\x28\x96\x51\x2c\x95\xe8\x26\x49\x1a\xd0\x53\x7f\x3a\xc7\x79\xdb\x3a\xd2\x47\xd2\x9d\xc4\x2d\xfc\x39\x88\x0a\x50\xc0\xc1\x11\xdc\x5f\x7f\xaa\x59\xb5\x8b\xc8\x91\x20\x2c\x3d\x89\x54\x6b\xf2\x8e\xb7\x04\x03\x9a\x2d\x85\x11\x94\x3d\x49\xd6\x16\xf0\x44\xe8\x74\x02\x33\x91\xd9\xa6\x93\x91\x8c\xf8\x39\x2b\xf5\x8b\xd4\x75\x9d\xe7\x52\x6e\x39\x51\xfc\xfb\xd8\x52\x1e\x3c\x2b\x0c\x99\xdd\x98\x4a\xe1\x4e\xc4\x3a\x6a\xbb\x86\x5b\x09\x8a\x1c\x28\xc2\x4d\x21\x50\x3c\x1d\xb4\x30\x6a\x5f\xdf\xba\x7b\x1a\x4b\xce\xd5\xb2\x51\x9e\x99\x76\x14\x81\x13\x44\x7a\xca\x1c\x69\xac\x9f\x4a\x29\x73\xb0\xf5\x5a\x9c\xd8\x54\xbe\x0a\x84\xbb\xdb\x9b\x36\xc6\x89\x47\x13\xf8\xa7\xb7\x77\x2c\xab\x44\xb4\x2f\xeb\x03\xdb\xa7\x4c\x71\xff\x02\x18\x51\xb1\x04\xf0\x02\xf0\xee\x2c\x77\x8e\x62\x7e\xad\xe8\xb7\x2d\xa6\xa7\x43\xcb\xa5\xa0\x9f\xfd\xe5\x98\x1f\x1f\x8e\x47\xbf\xe0\xc7\xff\x34\x15\xd8\x45\xd6\xb6\xee\x13\x77\x27\xd6\x72\xb0\xbd\x13\xfb\xad\xe0\xf1\xd5\x42\x5e\x42\x79\x49\x89\xb1\x3c\xee\xf4\xf9\x47\xa4\xf7\xa4\x91\xcd\xab\x95\xe7\xec\x1c\xb0\x47\x4b\xb0\x89\xe2\xc1\x9a\x78\x26\x20\x19\x7e\x90\xd1\xc7\x76\x19\x97\xf6\xc5\x38\x9f\x5b\xd4\x41\x8f\x71\xee\x27\xd5\x39\x7d\xa6\x76\x12\xd7\xc6\x50\x4a\x12\xbb\x9f\x03\x00\x62\x97\x75\xba\x18\x06\x00\x00",
+	"deployment/gke/deployment_manager_configs/network.jinja":              "\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x64\x91\xc1\x6e\xdb\x3c\x10\x84\xef\x7c\x8a\x81\x75\xf9\x7f\xc0\x96\x93\x9c\x0a\xf7\xa4\x3a\x69\x2b\x34\xb0\x81\xc8\x69\x10\x14\x3d\xd0\xd4\x5a\x5a\x94\x26\x59\x72\x65\x45\x08\xf2\xee\x85\x14\x07\x68\x50\x9e\x88\xdd\xe1\xf0\xdb\x9d\x0c\x6b\x1f\x86\xc8\x4d\x2b\xb8\xba\xb8\xfc\x80\x2f\xde\x37\x96\x50\x3a\x93\xa3\xb0\x16\x53\x2b\x21\x52\xa2\x78\xa2\x3a\x57\x99\xca\x70\xcb\x86\x5c\xa2\x1a\x9d\xab\x29\x42\x5a\x42\x11\xb4\x69\xe9\xad\x33\xc7\x77\x8a\x89\xbd\xc3\x55\x7e\x81\xff\x46\xc1\xec\xdc\x9a\xfd\xff\x51\x65\x18\x7c\x87\xa3\x1e\xe0\xbc\xa0\x4b\x04\x69\x39\xe1\xc0\x96\x40\x4f\x86\x82\x80\x1d\x8c\x3f\x06\xcb\xda\x19\x42\xcf\xd2\x4e\xdf\x9c\x4d\x72\x95\xe1\xf1\x6c\xe1\xf7\xa2\xd9\x41\xc3\xf8\x30\xc0\x1f\xfe\xd6\x41\xcb\x04\x3c\x9e\x56\x24\xac\x96\xcb\xbe\xef\x73\x3d\xc1\xe6\x3e\x36\x4b\xfb\x2a\x4c\xcb\xdb\x72\x7d\xb3\xa9\x6e\x16\x57\xf9\xc5\xf4\xe4\xde\x59\x4a\xe3\xe0\xbf\x3b\x8e\x54\x63\x3f\x40\x87\x60\xd9\xe8\xbd\x25\x58\xdd\xc3\x47\xe8\x26\x12\xd5\x10\x3f\xf2\xf6\x91\x85\x5d\x33\x47\xf2\x07\xe9\x75\x24\x95\xa1\xe6\x24\x91\xf7\x9d\xbc\x5b\xd6\x1b\x1d\xa7\x77\x02\xef\xa0\x1d\x66\x45\x85\xb2\x9a\xe1\x53\x51\x95\xd5\x5c\x65\x78\x28\x77\x5f\xb7\xf7\x3b\x3c\x14\x77\x77\xc5\x66\x57\xde\x54\xd8\xde\x61\xbd\xdd\x5c\x97\xbb\x72\xbb\xa9\xb0\xfd\x8c\x62\xf3\x88\x6f\xe5\xe6\x7a\x0e\x62\x69\x29\x82\x9e\x42\x1c\xf9\x7d\x04\x8f\x6b\x9c\xa2\x43\x45\xf4\x0e\xe0\xe0\x5f\x81\x52\x20\xc3\x07\x36\xb0\xda\x35\x9d\x6e\x08\x8d\x3f\x51\x74\xec\x1a\x04\x8a\x47\x4e\x63\x98\x09\xda\xd5\x2a\x83\xe5\x23\x8b\x96\xa9\xf2\xcf\x50\xb9\x52\x91\x92\xef\xa2\xa1\xb4\x52\x0b\xc8\x10\x68\x85\xc6\x84\xc5\x78\x4b\xcb\x31\xd5\x4e\x68\x71\xba\x5c\x39\x92\xde\xc7\x5f\x49\x01\x4e\x1f\x69\x85\x73\x61\xf1\xfc\x0c\x72\xa7\x1f\xb3\x9a\x82\xf5\xc3\x91\x9c\xcc\x7e\xe2\xe5\x45\x01\x21\xfa\x40\x51\x78\xf4\x06\x00\xdd\x89\x5f\x47\xd2\x42\x55\xb7\x7f\xf3\x5b\x41\x62\x47\xea\xcf\x00\xad\x2f\x75\x7f\xdc\x02\x00\x00",
+	"deployment/gke/deployment_manager_configs/network.yaml":               "\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x64\x91\xc1\x6e\xd3\x40\x10\x86\xef\xfb\x14\xbf\xe2\x0b\x48\xa9\x53\x7a\x42\xe1\x64\xd2\x00\x16\x95\x23\xc5\x29\x55\x8f\x1b\x7b\x62\x4f\x59\xef\x2e\xbb\xe3\xba\x7e\x7b\x64\x37\x45\x04\x7c\xf4\xff\xcd\xec\x37\x33\x09\x36\xce\x8f\x81\x9b\x56\x70\x73\xfd\xe1\x23\xbe\x3a\xd7\x18\x42\x6e\xab\x14\x99\x31\x98\xa3\x88\x40\x91\xc2\x33\xd5\xa9\x4a\x54\x82\x3b\xae\xc8\x46\xaa\xd1\xdb\x9a\x02\xa4\x25\x64\x5e\x57\x2d\xbd\x25\x4b\xfc\xa0\x10\xd9\x59\xdc\xa4\xd7\x78\x37\x01\x8b\x73\xb4\x78\xff\x49\x25\x18\x5d\x8f\x4e\x8f\xb0\x4e\xd0\x47\x82\xb4\x1c\x71\x62\x43\xa0\x97\x8a\xbc\x80\x2d\x2a\xd7\x79\xc3\xda\x56\x84\x81\xa5\x9d\x9f\x39\x37\x49\x55\x82\xc7\x73\x0b\x77\x14\xcd\x16\x1a\x95\xf3\x23\xdc\xe9\x6f\x0e\x5a\x66\xe1\xe9\x6b\x45\xfc\x7a\xb5\x1a\x86\x21\xd5\xb3\x6c\xea\x42\xb3\x32\xaf\x60\x5c\xdd\xe5\x9b\x6d\x51\x6e\xaf\x6e\xd2\xeb\xb9\xe4\xde\x1a\x8a\xd3\xe0\xbf\x7a\x0e\x54\xe3\x38\x42\x7b\x6f\xb8\xd2\x47\x43\x30\x7a\x80\x0b\xd0\x4d\x20\xaa\x21\x6e\xf2\x1d\x02\x0b\xdb\x66\x89\xe8\x4e\x32\xe8\x40\x2a\x41\xcd\x51\x02\x1f\x7b\xb9\x58\xd6\x9b\x1d\xc7\x0b\xc0\x59\x68\x8b\x45\x56\x22\x2f\x17\xf8\x9c\x95\x79\xb9\x54\x09\x1e\xf2\xc3\xb7\xdd\xfd\x01\x0f\xd9\x7e\x9f\x15\x87\x7c\x5b\x62\xb7\xc7\x66\x57\xdc\xe6\x87\x7c\x57\x94\xd8\x7d\x41\x56\x3c\xe2\x7b\x5e\xdc\x2e\x41\x2c\x2d\x05\xd0\x8b\x0f\x93\xbf\x0b\xe0\x69\x8d\xf3\xe9\x50\x12\x5d\x08\x9c\xdc\xab\x50\xf4\x54\xf1\x89\x2b\x18\x6d\x9b\x5e\x37\x84\xc6\x3d\x53\xb0\x6c\x1b\x78\x0a\x1d\xc7\xe9\x98\x11\xda\xd6\x2a\x81\xe1\x8e\x45\xcb\xfc\xe7\xbf\xa1\x52\xa5\xb8\xf3\x2e\x48\x5c\xab\x2b\x78\x2d\xed\x1a\x96\x64\x70\xe1\x67\xfa\xc4\xf6\x49\x2b\x15\x28\xba\x3e\x54\x34\x13\x56\x77\xf4\x87\x50\x80\x8c\x9e\xfe\xad\xf8\x3d\x00\xff\x7b\x5d\x82\xa6\x02\x00\x00",
+	"deployment/gke/deployment_manager_configs/storage-kubeflow.yaml":      "\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x94\x52\xc1\x8e\x9c\x46\x10\xbd\xf3\x15\x4f\x3b\x97\x44\xda\x61\x37\x7b\xc8\x61\x72\x22\x6b\xb2\x46\x59\x31\xa3\x81\x8d\xe5\x5c\x46\x0d\x14\x50\x76\xd3\xdd\xee\x2e\x8c\xc7\x5f\x1f\x01\x33\x92\x37\x7b\x32\x27\x54\xef\xd5\xab\x57\xaf\x6b\x83\x47\xeb\xce\x9e\xbb\x5e\xf0\x70\xff\xdb\xef\x78\xb2\xb6\xd3\x84\xcc\xd4\x31\x12\xad\xb1\x40\x01\x9e\x02\xf9\xaf\xd4\xc4\xd1\x26\xda\xe0\x99\x6b\x32\x81\x1a\x8c\xa6\x21\x0f\xe9\x09\x89\x53\x75\x4f\x57\xe4\x16\xff\x90\x0f\x6c\x0d\x1e\xe2\x7b\xfc\x32\x13\x6e\x2e\xd0\xcd\xaf\x7f\x44\x1b\x9c\xed\x88\x41\x9d\x61\xac\x60\x0c\x04\xe9\x39\xa0\x65\x4d\xa0\x6f\x35\x39\x01\x1b\xd4\x76\x70\x9a\x95\xa9\x09\x13\x4b\xbf\x8c\xb9\x88\xc4\xd1\x06\x1f\x2f\x12\xb6\x12\xc5\x06\x0a\xb5\x75\x67\xd8\xf6\x47\x1e\x94\x2c\x86\xe7\xaf\x17\x71\xbb\xbb\xbb\x69\x9a\x62\xb5\x98\x8d\xad\xef\xee\xf4\x4a\x0c\x77\xcf\xd9\x63\x9a\x17\xe9\xf6\x21\xbe\x5f\x5a\x5e\x8c\xa6\x30\x2f\xfe\x65\x64\x4f\x0d\xaa\x33\x94\x73\x9a\x6b\x55\x69\x82\x56\x13\xac\x87\xea\x3c\x51\x03\xb1\xb3\xdf\xc9\xb3\xb0\xe9\x6e\x11\x6c\x2b\x93\xf2\x14\x6d\xd0\x70\x10\xcf\xd5\x28\xaf\xc2\xba\xba\xe3\xf0\x8a\x60\x0d\x94\xc1\x4d\x52\x20\x2b\x6e\xf0\x67\x52\x64\xc5\x6d\xb4\xc1\x87\xac\x7c\xbf\x7f\x29\xf1\x21\x39\x1e\x93\xbc\xcc\xd2\x02\xfb\x23\x1e\xf7\xf9\xbb\xac\xcc\xf6\x79\x81\xfd\x5f\x48\xf2\x8f\xf8\x3b\xcb\xdf\xdd\x82\x58\x7a\xf2\xa0\x6f\xce\xcf\xfe\xad\x07\xcf\x31\x2e\x4f\x87\x82\xe8\x95\x81\xd6\xae\x86\x82\xa3\x9a\x5b\xae\xa1\x95\xe9\x46\xd5\x11\x3a\xfb\x95\xbc\x61\xd3\xc1\x91\x1f\x38\xcc\x8f\x19\xa0\x4c\x13\x6d\xa0\x79\x60\x51\xb2\x54\xde\x2c\x15\x47\x11\x0f\xce\x7a\x09\xbb\x68\x0b\xa7\xa4\xdf\x21\x88\xf5\xaa\xa3\xf8\x13\x9b\x4f\x2a\x8a\x3c\x05\x3b\xfa\x9a\x16\x86\x51\x03\xed\xf0\x79\xac\xa8\xd5\x76\x8a\x00\x39\x3b\xfa\x7f\x0b\xe0\xbc\x75\xe4\x85\xe7\x26\x00\xf8\x6e\x0d\xed\x50\xa4\xe5\xa9\x7c\x9f\x9e\xfe\xdd\xe7\xe9\x52\xae\x3d\x29\xa1\x03\x3b\xd2\x6c\xe8\x30\x1f\x61\x10\x32\x52\xac\x72\x6b\xc7\xe3\x31\x4d\xca\xf4\x74\xc8\x0e\xe9\x73\x96\xa7\xa7\x43\x7a\x2c\xb2\xa2\x4c\xf3\xf2\x54\x94\xfb\x63\xf2\xb4\x6a\x35\x1c\x3e\x5f\xa6\x61\x8b\xc0\xdf\xe9\xa9\xda\xe1\xe1\x7e\xad\xac\x78\xb9\x98\x75\xcd\x36\x88\x32\x8d\xf2\xcd\x15\x1c\xc3\x32\x6e\x20\x51\x8d\x12\xb5\x9d\xf7\xa1\xb7\x5a\x3f\x25\xa6\xbc\x70\xab\x6a\xf9\x41\x8c\xcc\x7c\x8e\xa7\x5a\xdb\xb1\x09\x5f\xf4\x0e\xad\xd2\x61\x85\xe6\xb1\x95\x0a\x74\xd9\xe0\x12\xf4\xa0\xdd\x25\x9c\x95\x54\xbd\x04\xf2\x57\xca\x38\xff\xc3\x5b\x2b\xd1\x7f\x03\x00\xa1\x51\x4a\x5b\x19\x04\x00\x00",
+	"deployment/gke/deployment_manager_configs/storage.jinja":              "\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x9c\x56\x5d\x6f\xda\x48\x17\xbe\xf7\xaf\x38\xa2\x2f\x32\xd1\x1b\xec\xa6\xaa\xf6\x82\x55\x2f\x58\x42\x53\xb4\x59\x93\x62\xb2\xdd\x28\x8a\xa2\xb1\x7d\x30\xd3\x0c\x33\xee\xcc\x18\x4a\x11\xff\x7d\x35\x1e\x1b\x9b\xf0\xd1\x6a\xef\x60\xe6\x39\xe7\x3c\xcf\xf9\x1a\x6f\xde\x38\x03\x91\xad\x25\x4d\xe7\x1a\xde\xbd\xbd\xfa\x0d\x6e\x84\x48\x19\xc2\x88\xc7\x1e\xf4\x19\x83\xe2\x4a\x81\x44\x85\x72\x89\x89\xe7\xdc\xd2\x18\xb9\xc2\x04\x72\x9e\xa0\x04\x3d\x47\xe8\x67\x24\x9e\x23\x94\x37\x97\xf0\x37\x4a\x45\x05\x87\x77\xde\x5b\xe8\x18\x40\xab\xbc\x6a\x5d\xfc\xee\xac\x45\x0e\x0b\xb2\x06\x2e\x34\xe4\x0a\x41\xcf\xa9\x82\x19\x65\x08\xf8\x3d\xc6\x4c\x03\xe5\x10\x8b\x45\xc6\x28\xe1\x31\xc2\x8a\xea\x79\x11\xa4\x74\xe1\x39\x0f\xa5\x03\x11\x69\x42\x39\x10\x88\x45\xb6\x06\x31\x6b\xa2\x80\x68\x07\x00\x60\xae\x75\xd6\xf3\xfd\xd5\x6a\xe5\x91\x82\xa4\x27\x64\xea\x33\x0b\x52\xfe\xed\x68\x30\x0c\xc2\x61\xf7\x9d\xf7\xd6\xb9\xe7\x0c\x95\x11\xfa\x2d\xa7\x12\x13\x88\xd6\x40\xb2\x8c\xd1\x98\x44\x0c\x81\x91\x15\x08\x09\x24\x95\x88\x09\x68\x61\x58\xae\x24\xd5\x94\xa7\x97\xa0\xc4\x4c\xaf\x88\x44\x27\xa1\x4a\x4b\x1a\xe5\x7a\x2f\x3d\x15\x27\xaa\xa0\x09\x10\x1c\x08\x87\x56\x3f\x84\x51\xd8\x82\x3f\xfa\xe1\x28\xbc\x74\xbe\x8c\xa6\x9f\xc6\xf7\x53\xf8\xd2\x9f\x4c\xfa\xc1\x74\x34\x0c\x61\x3c\x81\xc1\x38\xb8\x1e\x4d\x47\xe3\x20\x84\xf1\x47\xe8\x07\x0f\xf0\xe7\x28\xb8\xbe\x04\xa4\x7a\x8e\x12\xf0\x7b\x26\x0d\x77\x21\x81\x9a\xc4\x99\x32\x85\x88\x7b\xc1\x67\xc2\x92\x51\x19\xc6\x74\x46\x63\x60\x84\xa7\x39\x49\x11\x52\xb1\x44\xc9\x29\x4f\x21\x43\xb9\xa0\xca\x94\x4e\x01\xe1\x89\xc3\xe8\x82\x6a\xa2\x8b\xff\x07\x72\x3c\xe7\xcd\xd6\x71\x36\x6d\x58\x90\x58\x0a\xa3\xec\x25\x20\x0b\xec\x98\x1f\xe3\xe8\xeb\x05\x74\xdb\xdb\xcd\x06\x90\x2f\x1f\x5b\x09\x66\x4c\xac\x17\xc8\x75\xeb\x69\xbb\xed\x6e\x36\x50\xa2\x1e\x5b\xb9\x22\x29\xb6\x9e\x60\xbb\xdd\xb4\xbb\x80\x3c\xb1\xee\xda\xd6\xb7\x42\x0d\x41\xff\xaf\xe1\xf3\xdd\x64\xf8\x71\xf4\x0f\x7c\x28\xfc\xb9\xb5\x3f\xf7\xc9\x40\x37\x6f\x1c\x80\x3e\xdc\x09\xa5\x53\x89\x0a\x28\x57\xba\xe8\x1e\x6a\x0a\x6a\xea\x9e\x80\x9e\x4b\x91\xa7\xb6\x97\x06\x4c\xe4\x09\x84\x9f\x6f\x21\x93\xe2\xfb\x1a\x24\x29\xf2\xa8\xe7\xc4\xf4\x53\x26\xe9\x92\x68\x84\xd1\xdd\xa5\x49\x03\x50\xad\x1c\x30\xbd\x2a\x8d\xbf\x58\x22\xd1\xb6\x3f\x5e\x66\xb1\x66\x75\x87\x66\x44\xa9\x95\x90\x49\xd5\x8b\x0b\xd4\x24\x21\x9a\x74\x93\x08\x14\xc6\x12\x75\x91\xb3\x52\xd6\xdd\x38\x9c\xde\x4c\x86\x21\x7c\x80\x4e\x27\x93\x22\x43\xa9\x29\xaa\x47\x37\x36\xe4\xd4\x37\xe6\x3e\x99\x82\x6e\xb6\x17\x5e\x8a\xba\xe3\x1a\x4f\x11\x51\x58\x0e\x97\x7b\x61\x6e\x5d\xf7\xc2\x53\x9a\x48\xad\x0c\x8b\x8e\x5b\x39\x75\x2f\xa0\xbd\x8b\x14\x7e\xbe\x7d\x1e\x05\xe1\xb4\x1f\x0c\x86\xcf\x26\x9b\xf0\x01\x8e\xe4\xf1\xff\xd0\x71\xbb\x59\x99\x42\x17\xe8\xac\xa6\x88\x4c\x21\xb8\xdd\xc5\xda\xd0\x2a\x5c\x3b\x12\x95\xc8\x65\x8c\xaa\x67\xc2\xd0\x19\xec\x49\x28\x72\x74\x47\x33\x64\x94\xe3\x9d\x61\xac\x34\x72\x1d\x6a\x21\x49\x8a\x65\xd1\xda\x45\x53\x96\x9d\x60\x06\xaa\xe1\xa2\x65\x8e\x55\xab\x00\x76\x81\x93\x05\xf6\x60\xb3\x39\xd2\x65\xdb\xad\x03\xa0\xd7\x19\xf6\x8a\xad\x91\x6b\xf4\x96\x57\x9e\xb9\x76\xa0\xe1\xb0\x57\xac\x83\x1f\x82\x5b\x3f\xcd\x48\xe6\xb0\x68\xc0\x02\xa2\xe8\x0f\xbc\x89\x7a\xd0\xec\x51\x7b\x56\x63\x0e\x05\x33\x12\x21\x53\x56\x97\x41\xd8\xff\xaf\x43\xd5\xb0\xda\x11\xf2\x84\xce\x2a\x33\xab\xc3\x2c\x2d\x55\x6e\xad\xb4\x58\xc7\x24\xa3\xca\x8b\xc5\xc2\x2f\x25\xfa\xcb\x2b\x3f\x93\xe2\x2b\xc6\x5a\xf9\xd5\x90\x95\x07\x05\x4d\xdf\x88\x52\xfe\x7e\xf8\x5a\xa9\x6f\xa4\x4d\xd7\x99\x85\xec\x74\x56\xa7\x56\xa9\x25\x67\x4a\xd4\xde\x3a\x4d\xa6\x47\x0a\x8e\xdc\x6c\xc9\xe7\x46\xeb\xee\xd7\xed\xb0\x05\x1b\x75\x53\xdf\x18\x49\x16\x94\x7b\xcb\xab\x08\x35\x79\xef\x55\xe3\x7b\xa4\x82\x11\x89\x5f\x90\x27\xd3\xc2\x30\x1c\x9a\xc5\xf8\x7c\x33\x0c\x8a\xbb\xca\xcc\x5e\x0e\x6e\xc7\xf7\xd7\xcf\xcd\xc0\x05\xe8\xd5\x18\x1d\x94\xa8\x96\xf0\x78\x30\x72\xbb\xba\x49\x4c\x7f\x66\x6b\x21\xb5\x89\x42\x6d\x1e\x8b\x52\x06\x80\xa6\x28\xcf\x3a\x30\x80\xda\xdc\x12\xbf\xa6\xea\x25\xac\x1b\xf4\x1c\xef\x1a\x7a\xcc\x89\x4d\xd1\x2f\xb8\x30\xc0\xa6\x03\x65\x27\xb8\x9f\x6b\x31\x41\x33\x17\x3d\xd0\x32\xc7\xf2\xd6\x36\x86\x79\xd9\x77\xab\xa3\x5d\x59\x4a\x2c\x1e\x53\xf3\x9a\x94\xd5\x7b\x08\x06\x9f\x26\xe3\x60\x7c\x1f\xd6\xe6\x7b\xe3\x00\xc0\x84\xb5\xb8\x93\x38\x43\x89\x3c\xc6\x2a\x7f\xc7\x87\x79\x4f\x83\x01\x34\xb9\x1f\xb6\x6d\x55\xe0\x8f\x8c\xa4\x8d\xe9\x05\xd8\xbb\x38\x08\xf2\xda\x6c\xbb\x3d\x29\x80\xc4\x9a\x2e\xad\x04\xc1\x68\xbc\xee\x41\xff\xf6\x4b\xff\xa1\x52\x6c\xda\x39\xcf\x06\x82\xcf\x68\x9a\xcb\x02\x57\x0b\xb4\x43\x95\xec\x65\xf8\x7c\x8e\x01\x22\xca\x89\x5c\xdf\x8a\x74\x78\xca\xf8\x15\x41\x80\xe2\xfd\x98\xd2\xc5\xf9\x64\x5a\xa6\x61\x85\x6d\xaa\xa6\xa7\x04\x58\xaa\xc7\x68\xd2\x6c\xf9\xfe\x38\xc3\xf2\xfb\x2b\x54\xec\x08\x75\xf3\x0c\x35\xbc\x94\x0f\x75\x80\x7a\x25\xe4\x4b\x0f\x5e\xaf\x44\xb7\x3c\x28\xc8\xfa\x29\x13\x11\x61\x3e\xb7\x68\xe5\x27\x38\x23\x39\xd3\x3b\x6f\x24\xd7\x73\x21\xe9\x0f\x4c\x4a\x87\xea\x6c\x42\x0e\xe1\xcd\x9c\xec\xa7\xfa\x67\xab\xb0\x9b\x44\xa7\xb7\x61\xd5\x6d\x47\xb6\xe1\xce\xe9\xb1\xee\x34\x24\x0d\xa0\xa6\x55\x2d\xc8\x1e\xfc\xaf\x23\x71\xe6\x9d\x60\xe3\x19\xab\x8b\xc2\x22\x9e\x13\xa9\x50\x9f\x8d\x51\x62\x6c\x18\xe7\x78\x7b\xfe\x5c\x7f\x57\x0a\xa1\x4f\x27\xc1\x7c\x7d\xfd\x72\x02\xa2\x7b\x85\xf2\x50\xfe\x5c\x28\xdd\x03\x68\x9d\x84\x1b\x40\x01\x6f\xfd\xa7\x74\x55\xdf\x7b\x96\x5a\x82\x19\xf2\x44\x8d\x77\xf3\xd0\x3d\x57\xfd\x66\xb7\x34\x7f\xff\x3b\x00\xd7\xe6\xad\x14\xa1\x0d\x00\x00",
+	"deployment/gke/deployment_manager_configs/storage.jinja.schema":       "\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x9c\x55\x5d\x6f\xdb\x3a\x12\x7d\xd7\xaf\x38\x88\xb1\xe8\x2e\x10\xc9\x4e\xd0\xaf\x75\x9f\xbc\x71\xda\x18\x6d\xed\x6c\xe4\xdc\xa2\x4f\x01\x25\x8d\x24\xde\x50\xa4\x4a\x8e\xe2\xba\xb8\x3f\xfe\x82\xf2\x77\x54\xe7\xb6\xf1\x8b\xc5\x19\x72\xce\x99\x99\xc3\x61\x0f\x17\xa6\x5e\x5a\x59\x94\x8c\xf3\xc1\xd9\x6b\x7c\x30\xa6\x50\x84\x89\x4e\x23\x8c\x94\x42\xeb\x72\xb0\xe4\xc8\x3e\x50\x16\x05\xbd\xa0\x87\x4f\x32\x25\xed\x28\x43\xa3\x33\xb2\xe0\x92\x30\xaa\x45\x5a\xd2\xc6\x73\x8a\x3f\xc8\x3a\x69\x34\xce\xa3\x01\xfe\xed\x37\x9c\xac\x5d\x27\xff\x79\x17\xf4\xb0\x34\x0d\x2a\xb1\x84\x36\x8c\xc6\x11\xb8\x94\x0e\xb9\x54\x04\xfa\x9e\x52\xcd\x90\x1a\xa9\xa9\x6a\x25\x85\x4e\x09\x0b\xc9\x65\x0b\xb3\x0e\x12\x05\x3d\x7c\x5d\x87\x30\x09\x0b\xa9\x21\x90\x9a\x7a\x09\x93\xef\xef\x83\xe0\x96\xb0\xff\x95\xcc\xf5\xb0\xdf\x5f\x2c\x16\x91\x68\xc9\x46\xc6\x16\x7d\xb5\xda\xe8\xfa\x9f\x26\x17\x97\xd3\xf8\x32\x3c\x8f\x06\xed\x91\x5b\xad\xc8\xf9\xc4\xbf\x35\xd2\x52\x86\x64\x09\x51\xd7\x4a\xa6\x22\x51\x04\x25\x16\x30\x16\xa2\xb0\x44\x19\xd8\x78\xbe\x0b\x2b\x59\xea\xe2\x14\xce\xe4\xbc\x10\x96\x82\x1e\x32\xe9\xd8\xca\xa4\xe1\x83\x62\x6d\xd8\x49\x77\xb0\xc1\x68\x08\x8d\x93\x51\x8c\x49\x7c\x82\xff\x8d\xe2\x49\x7c\x1a\xf4\xf0\x65\x32\xbf\x9a\xdd\xce\xf1\x65\x74\x73\x33\x9a\xce\x27\x97\x31\x66\x37\xb8\x98\x4d\xc7\x93\xf9\x64\x36\x8d\x31\x7b\x8f\xd1\xf4\x2b\x3e\x4e\xa6\xe3\x53\x90\xe4\x92\x2c\xe8\x7b\x6d\x3d\x7f\x63\x21\x7d\x19\xdb\xd6\x21\x26\x3a\x20\x90\x9b\x15\x21\x57\x53\x2a\x73\x99\x42\x09\x5d\x34\xa2\x20\x14\xe6\x81\xac\x96\xba\x40\x4d\xb6\x92\xce\x37\xd3\x41\xe8\x2c\xe8\x41\xc9\x4a\xb2\xe0\xd6\xd2\x49\x2a\x0a\x02\xa9\x73\x33\x0c\x00\x96\xac\x68\x88\x8f\x4d\x42\xb9\x32\x0b\x5c\x93\xad\x84\x26\xcd\x88\xd9\x58\x51\x50\x00\x88\x86\x4b\x63\x87\x6b\xdd\x9d\xb6\xc2\x0b\x80\x8c\x5c\x6a\x65\xed\x31\x86\xf8\x2b\x00\x80\x0b\x4b\x82\xc9\xa1\xde\x46\x71\xab\x28\x6d\x16\x5b\x90\x8c\x6a\x65\x96\x15\x69\x0e\x82\x4d\xf3\x86\x41\x88\x1f\x46\x53\x10\xd4\xd6\xd4\x64\x59\x92\x1b\x06\x68\x6d\xfe\x1f\xe0\x65\x4d\x43\xf8\x56\xe8\x22\x00\x94\x48\x48\xb9\x7d\x97\x49\xfe\xa4\x94\x5b\xc3\x01\xb7\xd9\x42\x93\x75\xa5\xac\xd7\x67\xe0\x88\xbd\x56\xee\xf3\x94\x15\x8c\x6e\x4b\x93\x49\x77\xef\xda\xb4\xfc\xc7\x7e\x58\x61\xad\x58\xb6\x6b\xc9\x54\xad\x5d\x3f\xc1\x04\x76\xb9\xac\xd6\x21\x1a\xb7\x2a\xa1\xff\x1d\xe6\xb5\xfa\x39\xf9\x83\x3e\x24\xbb\xf5\x26\xac\xd4\x4c\x05\xd9\x3d\x7b\x46\xb9\x68\x14\x0f\x71\x3e\x18\x6c\xcd\x9e\xeb\xdc\x1f\xe8\x04\xd8\x96\xa9\x73\xbe\xce\x42\xc7\x42\x67\xc2\x66\x7b\x6e\xd2\x4d\xb5\x1f\x25\x3c\xb2\x6f\x65\x77\x3b\x53\x9b\xe1\x2f\xe1\xef\x75\x64\x51\x0a\x86\x74\xdb\xc2\xfb\xf9\x92\x79\x8d\x3c\xc9\xa8\x22\x16\x99\x60\x11\x7a\x51\xd1\x81\x4b\x58\x96\xb9\x48\x79\xed\x0a\x80\x54\x99\x26\x73\xdf\xd4\x51\x81\xac\xca\x71\xa4\x37\x8f\xd7\x80\x07\x4e\x84\xa3\xf5\xd0\xdc\x31\xfb\x69\xbe\x07\xd9\x7e\xfe\x1a\xff\xff\xd3\xdd\xab\xbb\x37\xa7\xdb\xcf\xd7\x30\x16\xd7\xb3\x78\xfe\xe1\xe6\x32\xbe\xfb\xef\xdd\xeb\x77\xad\x28\xd9\x1c\x18\x77\x22\xf5\xd7\xc7\x0f\x80\x68\x53\x83\x28\x4b\xb6\x29\x86\xb5\x71\x5c\x58\x72\x41\xa7\xd9\x5b\xbc\xbd\x2c\xc6\xd2\xdd\xc7\x8f\x74\xf7\x73\xd5\x55\x52\xcb\xaa\xa9\x86\x38\xdb\x49\xae\x12\xdf\x37\xb6\xc1\xa0\x0b\x78\x36\x78\x84\x74\x28\xcf\x23\xc5\xda\xd5\xea\x7a\x7c\x17\xc7\xe3\xb6\x3a\xe3\xbb\xab\xf1\xb8\x8b\xb0\xda\xb1\xb6\x27\x22\xbd\x6f\xea\x98\x85\xe5\xb9\xac\xe8\x77\xba\x72\x75\x35\xfc\xfc\x19\x52\xe3\xfc\x25\x4a\xd3\x58\x5f\xe3\x4a\x70\x17\x70\x30\x18\x6e\x33\x65\x49\xf6\x77\x40\xfc\x83\xe6\x86\xfd\x7e\xdb\xaa\xa8\x68\x07\x68\x94\x9a\xaa\xef\xbe\xa9\x7e\x6d\x65\x2a\x75\xd1\x3b\xd7\x59\x58\x90\x0e\xd7\xeb\x2e\x83\x2c\x09\xf5\x59\x58\xca\xa2\xac\xa8\x0a\x5f\x6e\xa7\x4d\xf1\x9b\x42\x94\x11\x45\x68\x5c\x98\x92\x66\x2b\xd4\x59\x17\xa9\xeb\xdc\xcd\xdf\xe7\x61\x84\xe2\x49\x94\xad\x7b\xf5\xc8\xc8\x1f\x94\x4d\x89\x17\xc6\xde\xbb\xc7\xa8\xbb\x39\xdc\x01\x1d\xe9\x95\x17\x26\x87\x50\xca\x2c\x28\xc3\xc5\x64\x7c\x83\x44\x99\xf4\x7e\x77\x2f\x0e\x06\x78\x27\x9f\x60\x77\xcb\xdf\x2b\x51\x1c\x79\x04\x8e\x02\x3f\xd9\xeb\xcc\xa4\xae\x5f\x2d\xfd\x67\xee\x63\x3f\xeb\x3d\x01\x42\x68\x51\xd1\xde\xf2\x41\xa8\xe6\xa9\x07\xc6\x6f\xff\xc7\xe9\xdc\x06\xe9\xec\xda\x5b\x7b\xa4\xee\x93\xe4\xad\x7b\xa5\x4b\x6e\xdd\xe6\x72\x74\x72\xe9\x52\x3b\x24\x76\x44\x57\x6b\xbd\x58\x63\x36\x25\x29\x8d\xe3\x5f\x3d\xf6\xe2\x5f\x2f\xf6\x7b\x7a\x84\x5a\xf7\xd1\xde\x96\xf8\x19\xac\xd3\x52\x58\x47\xfc\x9c\x19\x91\xd1\x43\xd4\x2a\xa4\x15\x4d\x66\xd2\xbe\xa5\xbc\x12\xba\xff\x2a\x7a\xd3\x27\xdd\x5f\x87\x8e\x4a\xae\xd4\x3b\xdc\xce\xdf\xbf\x6d\x9f\x85\xeb\xa3\xc3\xbf\xe1\xfc\x6d\xf0\xf7\x00\xe2\x9f\x37\x77\x3e\x0c\x00\x00",
+}
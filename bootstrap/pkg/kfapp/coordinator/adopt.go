@@ -0,0 +1,107 @@
+/*
+Copyright The Kubeflow Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package coordinator
+
+import (
+	"fmt"
+	"github.com/ghodss/yaml"
+	configtypes "github.com/kubeflow/kubeflow/bootstrap/config"
+	kftypes "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps"
+	kfdefs "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps/kfdef/v1alpha1"
+	"github.com/kubeflow/kubeflow/bootstrap/pkg/kfapp/gcp"
+	log "github.com/sirupsen/logrus"
+	valid "k8s.io/apimachinery/pkg/api/validation"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"os"
+	"path"
+	"strings"
+)
+
+// AdoptKfApp creates the app dir of an existing GCP deployment, named after its cluster, in the
+// current directory. The spec and gcp_config are reconstructed from what's deployed by gcp.Adopt;
+// the packages and component params are the defaults of its auth provider, as the deployment
+// doesn't record them. The returned KfApp still has to be initialized, like NewKfApp's.
+func AdoptKfApp(options map[string]interface{}) (kftypes.KfApp, error) {
+	cluster := options[string(kftypes.CLUSTER)].(string)
+	if errs := valid.NameIsDNSLabel(cluster, false); len(errs) > 0 {
+		return nil, fmt.Errorf(`invalid cluster name due to %v`, strings.Join(errs, ", "))
+	}
+	project := options[string(kftypes.PROJECT)].(string)
+	if project == "" {
+		return nil, fmt.Errorf("--%v is required", kftypes.PROJECT)
+	}
+	cwd, err := os.Getwd()
+	if err != nil {
+		return nil, fmt.Errorf("could not get current directory %v", err)
+	}
+	appDir := path.Join(cwd, cluster)
+	if _, err := os.Stat(appDir); err == nil {
+		return nil, fmt.Errorf("%v already exists; adopt the deployment from another directory", appDir)
+	}
+	version := options[string(kftypes.VERSION)].(string)
+	if strings.HasPrefix(version, "pull") && !strings.HasSuffix(version, "head") {
+		version = version + "/head"
+	}
+	mirror := options[string(kftypes.MIRROR)].(string)
+	useEmbeddedAssets := options[string(kftypes.USE_EMBEDDED_ASSETS)].(bool)
+	kfDef := &kfdefs.KfDef{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "KfDef",
+			APIVersion: "kfdef.apps.kubeflow.org/v1alpha1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cluster,
+			Namespace: options[string(kftypes.NAMESPACE)].(string),
+		},
+		Spec: kfdefs.KfDefSpec{
+			ComponentConfig: configtypes.ComponentConfig{
+				Platform: kftypes.GCP,
+			},
+			AppDir:            appDir,
+			AppDirVersion:     kftypes.AppDirVersion,
+			Version:           version,
+			Project:           project,
+			Zone:              options[string(kftypes.ZONE)].(string),
+			Email:             options[string(kftypes.EMAIL)].(string),
+			SkipInitProject:   true,
+			UseEmbeddedAssets: useEmbeddedAssets,
+			Mirror:            mirror,
+		},
+	}
+	if err = gcp.Adopt(kfDef); err != nil {
+		os.RemoveAll(appDir)
+		return nil, fmt.Errorf("couldn't adopt %v: %v", cluster, err)
+	}
+	pin := &repoPin{}
+	configFileBuffer, err := downloadToCache(kftypes.GCP, appDir, version, kftypes.AuthProvider(&kfDef.Spec),
+		mirror, useEmbeddedAssets, pin, kfDef.Spec.CaBundle)
+	if err != nil {
+		return nil, fmt.Errorf("could not download repo to cache Error %v", err)
+	}
+	adopted := kfDef.Spec
+	if err = yaml.Unmarshal(configFileBuffer, &kfDef.Spec); err != nil {
+		return nil, fmt.Errorf("couldn't unmarshal the default config. Error: %v", err)
+	}
+	kfDef.Spec.Platform = kftypes.GCP
+	kfDef.Spec.RepoSha = pin.sha
+	kfDef.Spec.RepoChecksum = pin.checksum
+	if len(adopted.Components) > 0 {
+		kfDef.Spec.Components = adopted.Components
+	}
+	log.Infof("Adopted %v with components %v", cluster, strings.Join(kfDef.Spec.Components, ", "))
+	return GetKfApp(kfDef), nil
+}
@@ -0,0 +1,67 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package coordinator
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	kftypes "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps"
+)
+
+func TestAdoptKfAppRefuses(t *testing.T) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir, err := ioutil.TempDir("", "kfctl-adopt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	if err = os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(cwd)
+	if err = os.Mkdir(filepath.Join(dir, "existing"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		cluster string
+		project string
+		wantErr string
+	}{
+		{"Not_A_Label", "my-project", "invalid cluster name"},
+		{"kf", "", "--" + string(kftypes.PROJECT) + " is required"},
+		{"existing", "my-project", "already exists"},
+	}
+	for _, test := range tests {
+		_, err := AdoptKfApp(map[string]interface{}{
+			string(kftypes.CLUSTER): test.cluster,
+			string(kftypes.PROJECT): test.project,
+		})
+		if err == nil || !strings.Contains(err.Error(), test.wantErr) {
+			t.Errorf("AdoptKfApp of %v in %q = %v; want %q", test.cluster, test.project, err, test.wantErr)
+		}
+	}
+	// Nothing is adopted over the existing app dir.
+	if files, err := ioutil.ReadDir(filepath.Join(dir, "existing")); err != nil || len(files) != 0 {
+		t.Errorf("existing app dir changed: %v, %v", files, err)
+	}
+}
@@ -0,0 +1,213 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package coordinator
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"github.com/ghodss/yaml"
+	kfapis "github.com/kubeflow/kubeflow/bootstrap/pkg/apis"
+	kftypes "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps"
+	"github.com/kubeflow/kubeflow/bootstrap/pkg/utils"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// ApplyCheckpointFile in the app dir records the phases done by an apply which ran out of
+// time, so the next apply of the same resources and spec resumes after them.
+const ApplyCheckpointFile = ".apply-checkpoint.yaml"
+
+// Phases of apply.
+const (
+	PlatformPhase  = "platform"
+	K8sPhase       = "k8s"
+	PostApplyPhase = "post-apply"
+)
+
+// applyPhase is a step of apply given share of the time left when it starts; the last phase
+// to run gets all of it. The ctx of run is cancelled once its share is spent.
+type applyPhase struct {
+	name  string
+	share float64
+	run   func(ctx context.Context) error
+}
+
+type applyCheckpoint struct {
+	Resources kftypes.ResourceEnum `json:"resources"`
+	SpecHash  string               `json:"specHash"`
+	Done      []string             `json:"done"`
+}
+
+func (c *applyCheckpoint) isDone(phase string) bool {
+	for _, done := range c.Done {
+		if done == phase {
+			return true
+		}
+	}
+	return false
+}
+
+// runPhases runs phases within spec.timeout, when it's set. A phase running past its share of
+// the budget is abandoned and the apply fails, after checkpointing the phases done before it.
+func (kfapp *coordinator) runPhases(resources kftypes.ResourceEnum, phases []applyPhase) error {
+	timeout := kfapp.KfDef.Spec.Timeout
+	hash, err := kfapp.specHash()
+	if err != nil {
+		return err
+	}
+	checkpoint := kfapp.loadCheckpoint(resources, hash)
+	pending := []applyPhase{}
+	for _, phase := range phases {
+		if checkpoint.isDone(phase.name) {
+			log.Infof("Skipping %v, done by the apply which timed out", phase.name)
+			continue
+		}
+		pending = append(pending, phase)
+	}
+	deadline := time.Now().Add(timeout)
+	for i, phase := range pending {
+		if timeout == 0 {
+			err = phase.run(context.Background())
+		} else {
+			budget := time.Until(deadline)
+			if i < len(pending)-1 {
+				budget = time.Duration(float64(budget) * phase.share)
+			}
+			err = runWithin(phase, budget)
+		}
+		if err != nil {
+			if _, timedOut := err.(*phaseTimeoutError); timedOut {
+				// The phases done may have changed app.yaml, e.g. recorded the resources they created;
+				// the next apply compares its spec with the one they left.
+				if hash, hashErr := kfapp.specHash(); hashErr != nil {
+					log.Warnf("couldn't save the apply checkpoint: %v", hashErr)
+				} else {
+					checkpoint.SpecHash = hash
+					if saveErr := kfapp.saveCheckpoint(checkpoint); saveErr != nil {
+						log.Warnf("couldn't save the apply checkpoint: %v", saveErr)
+					}
+				}
+				return &kfapis.KfError{
+					Code: int(kfapis.INTERNAL_ERROR),
+					Message: fmt.Sprintf("%v; apply again to resume from %v, its progress is kept in %v",
+						err, phase.name, ApplyCheckpointFile),
+				}
+			}
+			return err
+		}
+		checkpoint.Done = append(checkpoint.Done, phase.name)
+	}
+	return kfapp.removeCheckpoint()
+}
+
+type phaseTimeoutError struct {
+	phase  string
+	budget time.Duration
+}
+
+func (e *phaseTimeoutError) Error() string {
+	return fmt.Sprintf("%v didn't finish within its %v of the apply timeout", e.phase, e.budget.Round(time.Second))
+}
+
+// runWithin returns the error of phase, or a phaseTimeoutError when it runs longer than budget.
+// The ctx of the phase is cancelled then; a phase not watching it isn't interrupted, kfctl exits
+// on the error.
+func runWithin(phase applyPhase, budget time.Duration) error {
+	if budget <= 0 {
+		return &phaseTimeoutError{phase: phase.name, budget: 0}
+	}
+	log.Infof("Running %v within %v", phase.name, budget.Round(time.Second))
+	ctx, cancel := context.WithTimeout(context.Background(), budget)
+	defer cancel()
+	result := make(chan error, 1)
+	go func() {
+		result <- phase.run(ctx)
+	}()
+	select {
+	case err := <-result:
+		if ctx.Err() == context.DeadlineExceeded {
+			return &phaseTimeoutError{phase: phase.name, budget: budget}
+		}
+		return err
+	case <-ctx.Done():
+		return &phaseTimeoutError{phase: phase.name, budget: budget}
+	}
+}
+
+// specHash hashes the spec of app.yaml as it's persisted, whatever its apiVersion, rather than
+// the spec of the app, which kfctl defaults and changes while it runs.
+func (kfapp *coordinator) specHash() (string, error) {
+	appyaml := filepath.Join(kfapp.KfDef.Spec.AppDir, kftypes.KfConfigFile)
+	buf, err := ioutil.ReadFile(appyaml)
+	if err != nil {
+		return "", fmt.Errorf("couldn't read %v to hash its spec: %v", appyaml, err)
+	}
+	persisted := map[string]interface{}{}
+	if err = yaml.Unmarshal(buf, &persisted); err != nil {
+		return "", fmt.Errorf("couldn't unmarshal %v to hash its spec: %v", appyaml, err)
+	}
+	// Maps are marshaled with sorted keys, so the hash doesn't depend on the order in the file.
+	spec, err := json.Marshal(persisted["spec"])
+	if err != nil {
+		return "", fmt.Errorf("couldn't hash the spec of %v: %v", appyaml, err)
+	}
+	sum := sha256.Sum256(spec)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// loadCheckpoint returns the checkpoint of the last apply when it applied the same resources and
+// spec, an empty one otherwise.
+func (kfapp *coordinator) loadCheckpoint(resources kftypes.ResourceEnum, hash string) *applyCheckpoint {
+	empty := &applyCheckpoint{
+		Resources: resources,
+		SpecHash:  hash,
+	}
+	buf, err := ioutil.ReadFile(filepath.Join(kfapp.KfDef.Spec.AppDir, ApplyCheckpointFile))
+	if err != nil {
+		return empty
+	}
+	checkpoint := &applyCheckpoint{}
+	if err = yaml.Unmarshal(buf, checkpoint); err != nil {
+		log.Warnf("ignoring invalid %v: %v", ApplyCheckpointFile, err)
+		return empty
+	}
+	if checkpoint.Resources != resources || checkpoint.SpecHash != hash {
+		log.Infof("%v is for another apply, starting over", ApplyCheckpointFile)
+		return empty
+	}
+	return checkpoint
+}
+
+func (kfapp *coordinator) saveCheckpoint(checkpoint *applyCheckpoint) error {
+	buf, err := yaml.Marshal(checkpoint)
+	if err != nil {
+		return err
+	}
+	return utils.WriteFileAtomically(filepath.Join(kfapp.KfDef.Spec.AppDir, ApplyCheckpointFile), buf, 0644)
+}
+
+func (kfapp *coordinator) removeCheckpoint() error {
+	err := os.Remove(filepath.Join(kfapp.KfDef.Spec.AppDir, ApplyCheckpointFile))
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("couldn't remove %v: %v", ApplyCheckpointFile, err)
+	}
+	return nil
+}
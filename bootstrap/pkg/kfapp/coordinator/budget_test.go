@@ -0,0 +1,123 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package coordinator
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	kftypes "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps"
+	kfdefs "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps/kfdef/v1alpha1"
+	"golang.org/x/net/context"
+)
+
+func TestRunPhasesResumesAfterTimeout(t *testing.T) {
+	appDir, err := ioutil.TempDir("", "kfctl-budget")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(appDir)
+
+	appyaml := filepath.Join(appDir, kftypes.KfConfigFile)
+	writeAppYaml := func(project string) {
+		spec := "apiVersion: kfdef.apps.kubeflow.org/v1alpha1\nkind: KfDef\nspec:\n  project: " + project + "\n"
+		if err := ioutil.WriteFile(appyaml, []byte(spec), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeAppYaml("my-project")
+	kfdef := &kfdefs.KfDef{}
+	kfdef.Spec.AppDir = appDir
+	kfdef.Spec.Project = "my-project"
+	kfdef.Spec.Timeout = 200 * time.Millisecond
+	kfapp := &coordinator{KfDef: kfdef}
+
+	ran := []string{}
+	cancelled := make(chan struct{})
+	phases := func(block bool) []applyPhase {
+		return []applyPhase{
+			{name: PlatformPhase, share: 0.5, run: func(context.Context) error {
+				ran = append(ran, PlatformPhase)
+				return nil
+			}},
+			{name: K8sPhase, share: 1, run: func(ctx context.Context) error {
+				if block {
+					<-ctx.Done()
+					close(cancelled)
+					return ctx.Err()
+				}
+				ran = append(ran, K8sPhase)
+				return nil
+			}},
+		}
+	}
+
+	if err = kfapp.runPhases(kftypes.ALL, phases(true)); err == nil {
+		t.Fatalf("expected the blocked phase to time out")
+	}
+	select {
+	case <-cancelled:
+	case <-time.After(time.Second):
+		t.Fatalf("expected the context of the blocked phase to be cancelled")
+	}
+	if _, err = os.Stat(filepath.Join(appDir, ApplyCheckpointFile)); err != nil {
+		t.Fatalf("expected a checkpoint: %v", err)
+	}
+
+	// Defaults kfctl sets on the spec of the app don't change the spec the checkpoint is for.
+	kfdef.Spec.Zone = "us-east1-d"
+	ran = []string{}
+	if err = kfapp.runPhases(kftypes.ALL, phases(false)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(ran) != 1 || ran[0] != K8sPhase {
+		t.Errorf("expected only %v to run again, ran %v", K8sPhase, ran)
+	}
+	if _, err = os.Stat(filepath.Join(appDir, ApplyCheckpointFile)); !os.IsNotExist(err) {
+		t.Errorf("expected the checkpoint to be removed: %v", err)
+	}
+
+	// A checkpoint of another spec isn't resumed.
+	if err = kfapp.saveCheckpoint(&applyCheckpoint{Resources: kftypes.ALL, SpecHash: "other", Done: []string{PlatformPhase}}); err != nil {
+		t.Fatal(err)
+	}
+	ran = []string{}
+	if err = kfapp.runPhases(kftypes.ALL, phases(false)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(ran) != 2 {
+		t.Errorf("expected both phases to run, ran %v", ran)
+	}
+
+	// Nor is a checkpoint of app.yaml before it was edited.
+	hash, err := kfapp.specHash()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = kfapp.saveCheckpoint(&applyCheckpoint{Resources: kftypes.ALL, SpecHash: hash, Done: []string{PlatformPhase}}); err != nil {
+		t.Fatal(err)
+	}
+	writeAppYaml("other-project")
+	ran = []string{}
+	if err = kfapp.runPhases(kftypes.ALL, phases(false)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(ran) != 2 {
+		t.Errorf("expected both phases to run after app.yaml changed, ran %v", ran)
+	}
+}
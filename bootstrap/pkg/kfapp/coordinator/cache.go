@@ -0,0 +1,329 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package coordinator
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	kftypes "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps"
+	"github.com/kubeflow/kubeflow/bootstrap/pkg/utils"
+	log "github.com/sirupsen/logrus"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// KFCTL_CACHE_DIR overrides the directory the kubeflow repo tarballs are cached in, kfctl/repos
+// of the user's cache directory by default.
+const KFCTL_CACHE_DIR = "KFCTL_CACHE_DIR"
+
+var (
+	// githubCommitsUrl resolves a version of the kubeflow repo to its commit.
+	githubCommitsUrl = "https://api.github.com/repos/kubeflow/kubeflow/commits"
+	// githubTarballUrl serves the tarball of a commit of the kubeflow repo.
+	githubTarballUrl = kftypes.DefaultGitRepo
+	// commitSha matches a full commit SHA, which needs no resolving.
+	commitSha = regexp.MustCompile("^[0-9a-f]{40}$")
+)
+
+// cacheTimeout bounds the download of a tarball, which is tens of MB.
+const cacheTimeout = 10 * time.Minute
+
+// newCacheHttpClient returns the client of the github calls, trusting the CAs of caBundle too.
+func newCacheHttpClient(caBundle string) (*http.Client, error) {
+	client, err := utils.NewHTTPClient(caBundle)
+	if err != nil {
+		return nil, err
+	}
+	client.Timeout = cacheTimeout
+	return client, nil
+}
+
+// repoPin is the commit a version of the kubeflow repo was resolved to and the sha256 of its
+// tarball, kept as spec.repoSha and spec.repoChecksum.
+type repoPin struct {
+	sha      string
+	checksum string
+}
+
+// CacheEntry is a kubeflow repo tarball of the download cache.
+type CacheEntry struct {
+	// Sha is the commit of the tarball and Version the version it was downloaded for.
+	Sha     string `json:"sha"`
+	Version string `json:"version"`
+	// Checksum is the sha256 of the tarball when it was downloaded.
+	Checksum   string    `json:"checksum"`
+	Downloaded time.Time `json:"downloaded"`
+	Size       int64     `json:"-"`
+}
+
+// downloadCacheDir is the directory of the cached tarballs, created when missing.
+func downloadCacheDir() (string, error) {
+	dir := os.Getenv(KFCTL_CACHE_DIR)
+	if dir == "" {
+		userCacheDir, err := os.UserCacheDir()
+		if err != nil {
+			return "", fmt.Errorf("couldn't find the cache directory, set %v: %v", KFCTL_CACHE_DIR, err)
+		}
+		dir = filepath.Join(userCacheDir, "kfctl", "repos")
+	}
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return "", fmt.Errorf("couldn't create cache directory %v: %v", dir, err)
+	}
+	return dir, nil
+}
+
+func tarballPath(dir string, sha string) string {
+	return filepath.Join(dir, "kubeflow-"+sha+".tar.gz")
+}
+
+func entryPath(dir string, sha string) string {
+	return filepath.Join(dir, "kubeflow-"+sha+".json")
+}
+
+// resolveVersion returns the commit of version: a tag, a branch, pull/<ID>/head or a commit SHA.
+func resolveVersion(client *http.Client, version string) (string, error) {
+	if commitSha.MatchString(version) {
+		return version, nil
+	}
+	req, err := http.NewRequest(http.MethodGet, githubCommitsUrl+"/"+version, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Accept", "application/vnd.github.v3.sha")
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("github answered %v: %v", resp.Status, strings.TrimSpace(string(body)))
+	}
+	sha := strings.TrimSpace(string(body))
+	if !commitSha.MatchString(sha) {
+		return "", fmt.Errorf("github answered %q, not a commit", sha)
+	}
+	return sha, nil
+}
+
+// fileChecksum is the hex sha256 of the file at path.
+func fileChecksum(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	hash := sha256.New()
+	if _, err = io.Copy(hash, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// readCacheEntry returns the entry of sha, nil when it isn't cached or its tarball doesn't have
+// the checksum it was downloaded with, e.g. after an interrupted copy; such an entry is removed.
+func readCacheEntry(dir string, sha string) (*CacheEntry, error) {
+	buf, err := ioutil.ReadFile(entryPath(dir, sha))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	entry := &CacheEntry{}
+	if err = json.Unmarshal(buf, entry); err != nil {
+		log.Warnf("Removing unreadable cache entry %v: %v", sha, err)
+		return nil, removeCacheEntry(dir, sha)
+	}
+	checksum, err := fileChecksum(tarballPath(dir, sha))
+	if err != nil || checksum != entry.Checksum {
+		log.Warnf("Removing cached kubeflow repo %v, its tarball is missing or corrupted", sha)
+		return nil, removeCacheEntry(dir, sha)
+	}
+	return entry, nil
+}
+
+func removeCacheEntry(dir string, sha string) error {
+	for _, path := range []string{tarballPath(dir, sha), entryPath(dir, sha)} {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("couldn't remove %v: %v", path, err)
+		}
+	}
+	return nil
+}
+
+// downloadTarball downloads the tarball of sha into the cache and records its entry.
+func downloadTarball(client *http.Client, dir string, sha string, version string) (*CacheEntry, error) {
+	source := githubTarballUrl + "/" + sha
+	log.Infof("Downloading kubeflow repo %v (%v)", version, sha)
+	resp, err := client.Get(source)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't download kubeflow repo %v Error %v", source, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("couldn't download kubeflow repo %v: %v", source, resp.Status)
+	}
+	tmp, err := ioutil.TempFile(dir, "download-")
+	if err != nil {
+		return nil, fmt.Errorf("couldn't create a file in %v: %v", dir, err)
+	}
+	defer os.Remove(tmp.Name())
+	hash := sha256.New()
+	_, err = io.Copy(io.MultiWriter(tmp, hash), resp.Body)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return nil, fmt.Errorf("couldn't download kubeflow repo %v Error %v", source, err)
+	}
+	entry := &CacheEntry{
+		Sha:        sha,
+		Version:    version,
+		Checksum:   hex.EncodeToString(hash.Sum(nil)),
+		Downloaded: time.Now().UTC(),
+	}
+	if err = os.Rename(tmp.Name(), tarballPath(dir, sha)); err != nil {
+		return nil, fmt.Errorf("couldn't cache kubeflow repo %v: %v", sha, err)
+	}
+	buf, err := json.MarshalIndent(entry, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err = ioutil.WriteFile(entryPath(dir, sha), buf, 0644); err != nil {
+		return nil, fmt.Errorf("couldn't cache kubeflow repo %v: %v", sha, err)
+	}
+	return entry, nil
+}
+
+// cachedTarball returns the cached tarball of version, downloading it first when it isn't cached.
+// The commit of pin, when set, is used instead of resolving version, and the checksum of pin, when
+// set, must be the tarball's. pin is set to the commit and checksum of the tarball returned.
+// github is reached trusting the CAs of caBundle, spec.caBundle, too.
+func cachedTarball(version string, pin *repoPin, caBundle string) (string, error) {
+	dir, err := downloadCacheDir()
+	if err != nil {
+		return "", err
+	}
+	client, err := newCacheHttpClient(caBundle)
+	if err != nil {
+		return "", err
+	}
+	sha := pin.sha
+	if sha == "" {
+		if sha, err = resolveVersion(client, version); err != nil {
+			return "", fmt.Errorf("couldn't resolve kubeflow repo version %v: %v", version, err)
+		}
+	}
+	entry, err := readCacheEntry(dir, sha)
+	if err != nil {
+		return "", err
+	}
+	if entry == nil {
+		if entry, err = downloadTarball(client, dir, sha, version); err != nil {
+			return "", err
+		}
+	} else {
+		log.Infof("Using cached kubeflow repo %v (%v)", version, sha)
+	}
+	if pin.checksum != "" && pin.checksum != entry.Checksum {
+		return "", fmt.Errorf("kubeflow repo %v has checksum %v, not the expected %v", sha, entry.Checksum,
+			pin.checksum)
+	}
+	pin.sha = sha
+	pin.checksum = entry.Checksum
+	return tarballPath(dir, sha), nil
+}
+
+// ListCache returns the kubeflow repo tarballs of the download cache, the latest first.
+func ListCache() ([]CacheEntry, error) {
+	dir, err := downloadCacheDir()
+	if err != nil {
+		return nil, err
+	}
+	paths, err := filepath.Glob(filepath.Join(dir, "kubeflow-*.json"))
+	if err != nil {
+		return nil, err
+	}
+	entries := []CacheEntry{}
+	for _, path := range paths {
+		buf, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't read %v: %v", path, err)
+		}
+		entry := CacheEntry{}
+		if err = json.Unmarshal(buf, &entry); err != nil {
+			log.Warnf("Skipping unreadable cache entry %v: %v", path, err)
+			continue
+		}
+		if info, err := os.Stat(tarballPath(dir, entry.Sha)); err == nil {
+			entry.Size = info.Size()
+		}
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Downloaded.After(entries[j].Downloaded)
+	})
+	return entries, nil
+}
+
+// PurgeCache removes the tarballs of the commits shas, which may be abbreviated, from the
+// download cache, or all of them when shas is empty. It returns the commits removed.
+func PurgeCache(shas []string) ([]string, error) {
+	dir, err := downloadCacheDir()
+	if err != nil {
+		return nil, err
+	}
+	entries, err := ListCache()
+	if err != nil {
+		return nil, err
+	}
+	removed := []string{}
+	for _, entry := range entries {
+		match := len(shas) == 0
+		for _, sha := range shas {
+			if sha != "" && strings.HasPrefix(entry.Sha, sha) {
+				match = true
+			}
+		}
+		if !match {
+			continue
+		}
+		if err = removeCacheEntry(dir, entry.Sha); err != nil {
+			return removed, err
+		}
+		removed = append(removed, entry.Sha)
+	}
+	// Downloads interrupted before their entry was written.
+	if len(shas) == 0 {
+		leftovers, _ := filepath.Glob(filepath.Join(dir, "download-*"))
+		for _, path := range leftovers {
+			os.Remove(path)
+		}
+	}
+	return removed, nil
+}
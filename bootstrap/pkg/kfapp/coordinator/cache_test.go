@@ -0,0 +1,161 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package coordinator
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	kftypes "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps"
+	"github.com/kubeflow/kubeflow/bootstrap/pkg/kfapp/assets"
+)
+
+const testSha = "0123456789abcdef0123456789abcdef01234567"
+
+// fakeGithub resolves master to testSha and serves its tarball, counting the downloads.
+func fakeGithub(t *testing.T, downloads *int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/commits/master":
+			if r.Header.Get("Accept") != "application/vnd.github.v3.sha" {
+				t.Errorf("commit resolved with Accept %v", r.Header.Get("Accept"))
+			}
+			w.Write([]byte(testSha))
+		case "/tarball/" + testSha:
+			*downloads++
+			w.Write([]byte("tarball of " + testSha))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+}
+
+func withFakeGithub(t *testing.T, downloads *int) func() {
+	dir, err := ioutil.TempDir("", "kfctl-cache")
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := fakeGithub(t, downloads)
+	commitsUrl, tarballUrl := githubCommitsUrl, githubTarballUrl
+	githubCommitsUrl, githubTarballUrl = server.URL+"/commits", server.URL+"/tarball"
+	os.Setenv(KFCTL_CACHE_DIR, dir)
+	return func() {
+		githubCommitsUrl, githubTarballUrl = commitsUrl, tarballUrl
+		os.Unsetenv(KFCTL_CACHE_DIR)
+		server.Close()
+		os.RemoveAll(dir)
+	}
+}
+
+func TestCachedTarball(t *testing.T) {
+	downloads := 0
+	defer withFakeGithub(t, &downloads)()
+
+	pin := &repoPin{}
+	path, err := cachedTarball("master", pin, "")
+	if err != nil {
+		t.Fatalf("cachedTarball failed: %v", err)
+	}
+	if pin.sha != testSha || len(pin.checksum) != 64 {
+		t.Errorf("pinned %+v", pin)
+	}
+	checksum := pin.checksum
+
+	// Cached, and reused for the pinned commit.
+	pin = &repoPin{sha: testSha, checksum: checksum}
+	if _, err = cachedTarball("master", pin, ""); err != nil {
+		t.Fatalf("cachedTarball failed on the pinned commit: %v", err)
+	}
+	if downloads != 1 {
+		t.Errorf("downloaded %v times; want 1", downloads)
+	}
+
+	// A corrupted tarball is downloaded again.
+	if err = ioutil.WriteFile(path, []byte("corrupted"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = cachedTarball("master", &repoPin{}, ""); err != nil {
+		t.Fatalf("cachedTarball failed on a corrupted cache: %v", err)
+	}
+	if downloads != 2 {
+		t.Errorf("downloaded %v times; want 2", downloads)
+	}
+
+	// A tarball without the expected checksum is refused.
+	if _, err = cachedTarball("master", &repoPin{checksum: strings.Repeat("0", 64)}, ""); err == nil {
+		t.Errorf("cachedTarball succeeded with a wrong checksum")
+	}
+	if _, err = cachedTarball("no-such-branch", &repoPin{}, ""); err == nil {
+		t.Errorf("cachedTarball succeeded with an unknown version")
+	}
+}
+
+func TestListAndPurgeCache(t *testing.T) {
+	downloads := 0
+	defer withFakeGithub(t, &downloads)()
+
+	if _, err := cachedTarball("master", &repoPin{}, ""); err != nil {
+		t.Fatal(err)
+	}
+	entries, err := ListCache()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].Sha != testSha || entries[0].Version != "master" || entries[0].Size == 0 {
+		t.Fatalf("listed %+v", entries)
+	}
+
+	removed, err := PurgeCache([]string{"fedcba"})
+	if err != nil || len(removed) != 0 {
+		t.Errorf("PurgeCache of another commit = %v, %v", removed, err)
+	}
+	removed, err = PurgeCache([]string{testSha[:7]})
+	if err != nil || len(removed) != 1 {
+		t.Errorf("PurgeCache of %v = %v, %v", testSha[:7], removed, err)
+	}
+	if entries, _ = ListCache(); len(entries) != 0 {
+		t.Errorf("listed %+v after purge", entries)
+	}
+}
+
+func TestDownloadToCacheEmbeddedAssets(t *testing.T) {
+	downloads := 0
+	defer withFakeGithub(t, &downloads)()
+	appDir, err := ioutil.TempDir("", "kfctl-app")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(appDir)
+
+	pin := &repoPin{}
+	config, err := downloadToCache(kftypes.GCP, appDir, "master", kftypes.AUTH_BASIC_AUTH, "", true, pin, "")
+	if err != nil {
+		t.Fatalf("downloadToCache: %v", err)
+	}
+	expected, err := assets.Asset(configFilePath(kftypes.GCP, kftypes.AUTH_BASIC_AUTH))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(config) != string(expected) {
+		t.Errorf("downloadToCache didn't return the embedded basic auth config")
+	}
+	if downloads != 0 || pin.sha != "" {
+		t.Errorf("downloadToCache fetched the repo (%v downloads, sha %q) with the embedded assets", downloads, pin.sha)
+	}
+}
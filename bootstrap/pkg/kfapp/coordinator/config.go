@@ -0,0 +1,286 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package coordinator
+
+import (
+	"fmt"
+	"github.com/ghodss/yaml"
+	kftypes "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps"
+	kfdefs "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps/kfdef/v1alpha1"
+	kfdefsv1beta1 "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps/kfdef/v1beta1"
+	"github.com/kubeflow/kubeflow/bootstrap/pkg/utils"
+	log "github.com/sirupsen/logrus"
+	"io/ioutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// configSetters set the settings of kfctl config set in the spec, by key.
+var configSetters = map[string]func(spec *kfdefs.KfDefSpec, value string) error{
+	"usage-reporting": func(spec *kfdefs.KfDefSpec, value string) error {
+		enabled, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("usage-reporting is true or false, not %v", value)
+		}
+		if spec.UsageReporting == nil {
+			spec.UsageReporting = &kfdefs.UsageReportingConfig{}
+		}
+		spec.UsageReporting.Enabled = enabled
+		return nil
+	},
+	"usage-reporting-endpoint": func(spec *kfdefs.KfDefSpec, value string) error {
+		if spec.UsageReporting == nil {
+			spec.UsageReporting = &kfdefs.UsageReportingConfig{Enabled: true}
+		}
+		spec.UsageReporting.ReportEndpoint = value
+		return nil
+	},
+}
+
+// applyOnlyFields are the fields of the spec read by apply and delete only, so changing them
+// doesn't need a generate.
+var applyOnlyFields = map[string]bool{
+	"deleteStorage":      true,
+	"deleteFilestore":    true,
+	"deleteIp":           true,
+	"snapshotStorage":    true,
+	"deletionProtection": true,
+	"kubeconfig":         true,
+	"kubeContext":        true,
+	"notifications":      true,
+	"reportEndpoint":     true,
+	"stateBucket":        true,
+	"statePrefix":        true,
+}
+
+// ConfigChange is a setting kfctl config set changed in app.yaml.
+type ConfigChange struct {
+	Key string
+	// Regenerate is true when the change is only picked up by generate, false when apply or
+	// delete read it from app.yaml.
+	Regenerate bool
+}
+
+// ConfigKeys are the keys kfctl config set accepts besides the paths of the fields of the spec.
+func ConfigKeys() []string {
+	keys := []string{}
+	for key := range configSetters {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// SetAppConfig sets the settings of the app.yaml in appDir, each a key=value, and rewrites it.
+// A key is one of ConfigKeys or the path of a field of the spec by its json names, e.g.
+// spec.zone or spec.gpu.machineType, set to the YAML value, e.g. spec.skipApis=[a, b]; null
+// clears it. The settings are applied as app.yaml is read, e.g. usage-reporting=false removes
+// spartakus and its params. Nothing is written unless all of them are valid and the result
+// passes ValidateKfDef, and app.yaml is replaced at once, in its apiVersion, so an interrupted
+// set doesn't leave it truncated. The app dir is locked meanwhile, so a concurrent kfctl doesn't
+// overwrite the settings.
+func SetAppConfig(appDir string, settings []string) ([]ConfigChange, error) {
+	cfgfile := filepath.Join(appDir, kftypes.KfConfigFile)
+	unlock, err := utils.LockDir(appDir)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't lock %v: %v", appDir, err)
+	}
+	defer unlock()
+	kfdef, err := readAppConfig(appDir)
+	if err != nil {
+		return nil, err
+	}
+	apiVersion, err := appYamlApiVersion(cfgfile)
+	if err != nil {
+		return nil, err
+	}
+	changes := []ConfigChange{}
+	for _, setting := range settings {
+		kv := strings.SplitN(setting, "=", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("%v isn't a key=value", setting)
+		}
+		if setter, ok := configSetters[kv[0]]; ok {
+			if err := setter(&kfdef.Spec, kv[1]); err != nil {
+				return nil, err
+			}
+			changes = append(changes, ConfigChange{Key: kv[0], Regenerate: true})
+			continue
+		}
+		field, err := configField(kfdef, kv[0])
+		if err != nil {
+			return nil, err
+		}
+		if err = setConfigField(field, kv[1]); err != nil {
+			return nil, fmt.Errorf("invalid value of %v: %v", kv[0], err)
+		}
+		changes = append(changes, ConfigChange{Key: kv[0], Regenerate: !applyOnlyFields[specField(kv[0])]})
+	}
+	kftypes.ApplyUsageReporting(&kfdef.Spec)
+	kfdef.Spec.ComponentParams = kfdef.Spec.SortedComponentParams()
+	betaKfDef := kfdefsv1beta1.ConvertFromV1alpha1(kfdef)
+	if errs := kfdefsv1beta1.ValidateKfDef(betaKfDef); len(errs) > 0 {
+		return nil, invalidAppYaml(cfgfile, errs.ToAggregate())
+	}
+	var out interface{} = kfdef
+	if apiVersion == kfdefsv1beta1.SchemeGroupVersion.String() {
+		out = betaKfDef
+	}
+	buf, err := yaml.Marshal(out)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't marshal %v. Error: %v", cfgfile, err)
+	}
+	if err = utils.WriteFileAtomically(cfgfile, buf, 0644); err != nil {
+		return nil, fmt.Errorf("couldn't write %v. Error: %v", cfgfile, err)
+	}
+	log.Infof("set %v in %v", strings.Join(settings, ", "), cfgfile)
+	return changes, nil
+}
+
+// GetAppConfig returns the values of the fields of the spec of the app.yaml in appDir at paths,
+// as they'd be set with SetAppConfig: strings as is, the other values as YAML.
+func GetAppConfig(appDir string, paths []string) ([]string, error) {
+	kfdef, err := readAppConfig(appDir)
+	if err != nil {
+		return nil, err
+	}
+	values := []string{}
+	for _, path := range paths {
+		field, err := configField(kfdef, path)
+		if err != nil {
+			return nil, err
+		}
+		if field.Kind() == reflect.String {
+			values = append(values, field.String())
+			continue
+		}
+		buf, err := yaml.Marshal(field.Interface())
+		if err != nil {
+			return nil, fmt.Errorf("couldn't marshal %v: %v", path, err)
+		}
+		values = append(values, strings.TrimSuffix(string(buf), "\n"))
+	}
+	return values, nil
+}
+
+// AppName returns the name of the app of the app.yaml in appDir.
+func AppName(appDir string) (string, error) {
+	kfdef, err := readAppConfig(appDir)
+	if err != nil {
+		return "", err
+	}
+	return kfdef.Name, nil
+}
+
+// readAppConfig reads the app.yaml of appDir.
+func readAppConfig(appDir string) (*kfdefs.KfDef, error) {
+	cfgfile := filepath.Join(appDir, kftypes.KfConfigFile)
+	kfdef := &kfdefs.KfDef{}
+	if err := unmarshalAppYaml(cfgfile, kfdef); err != nil {
+		return nil, err
+	}
+	if kfdef.Name == "" {
+		return nil, fmt.Errorf("%v not found in %v", kftypes.KfConfigFile, appDir)
+	}
+	return kfdef, nil
+}
+
+// appYamlApiVersion returns the apiVersion of cfgfile, which is written back in it.
+func appYamlApiVersion(cfgfile string) (string, error) {
+	buf, err := ioutil.ReadFile(cfgfile)
+	if err != nil {
+		return "", fmt.Errorf("couldn't read %v. Error: %v", cfgfile, err)
+	}
+	typeMeta := &metav1.TypeMeta{}
+	if err = yaml.Unmarshal(buf, typeMeta); err != nil {
+		return "", fmt.Errorf("could not unmarshal %v. Error: %v", cfgfile, err)
+	}
+	return typeMeta.APIVersion, nil
+}
+
+// specField is the field of the spec path is in, e.g. gpu for spec.gpu.machineType.
+func specField(path string) string {
+	return strings.SplitN(strings.TrimPrefix(path, "spec."), ".", 2)[0]
+}
+
+// configField returns the field of the spec of kfdef at path, the json names of the fields from
+// the spec down, e.g. spec.gpu.machineType. The structs on the way are allocated when missing.
+// Fields that aren't in app.yaml aren't found.
+func configField(kfdef *kfdefs.KfDef, path string) (reflect.Value, error) {
+	if !strings.HasPrefix(path, "spec.") {
+		return reflect.Value{}, fmt.Errorf("unknown key %v; one of %v or spec.<field>", path,
+			strings.Join(ConfigKeys(), ", "))
+	}
+	value := reflect.ValueOf(&kfdef.Spec).Elem()
+	names := strings.Split(strings.TrimPrefix(path, "spec."), ".")
+	for i, name := range names {
+		if value.Kind() == reflect.Ptr {
+			if value.IsNil() {
+				value.Set(reflect.New(value.Type().Elem()))
+			}
+			value = value.Elem()
+		}
+		if value.Kind() != reflect.Struct {
+			return reflect.Value{}, fmt.Errorf("%v has no field %v", strings.Join(names[:i], "."), name)
+		}
+		field, ok := jsonField(value, name)
+		if !ok {
+			return reflect.Value{}, fmt.Errorf("unknown field %v of %v", name,
+				strings.Join(append([]string{"spec"}, names[:i]...), "."))
+		}
+		value = field
+	}
+	return value, nil
+}
+
+// jsonField returns the field of the struct value named name in json, looking into its inlined
+// structs too.
+func jsonField(value reflect.Value, name string) (reflect.Value, bool) {
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		tag := strings.Split(field.Tag.Get("json"), ",")
+		if tag[0] == "-" || field.PkgPath != "" {
+			continue
+		}
+		if field.Anonymous && tag[0] == "" {
+			if inner, ok := jsonField(value.Field(i), name); ok {
+				return inner, true
+			}
+			continue
+		}
+		if tag[0] == name || (tag[0] == "" && field.Name == name) {
+			return value.Field(i), true
+		}
+	}
+	return reflect.Value{}, false
+}
+
+// setConfigField sets field to value: strings as is, the other types from YAML.
+func setConfigField(field reflect.Value, value string) error {
+	if field.Kind() == reflect.String {
+		field.SetString(value)
+		return nil
+	}
+	parsed := reflect.New(field.Type())
+	if err := yaml.Unmarshal([]byte(value), parsed.Interface()); err != nil {
+		return err
+	}
+	field.Set(parsed.Elem())
+	return nil
+}
@@ -0,0 +1,118 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package coordinator
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	kftypes "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps"
+)
+
+func TestSetAppConfig(t *testing.T) {
+	appDir, err := ioutil.TempDir("", "kfctl-config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(appDir)
+	cfgfile := filepath.Join(appDir, kftypes.KfConfigFile)
+	appYaml := []byte(`apiVersion: kfdef.apps.kubeflow.org/v1alpha1
+kind: KfDef
+metadata:
+  name: kf-app
+spec:
+  platform: gcp
+  project: my-project
+  zone: us-central1-a
+  components:
+  - ambassador
+`)
+	if err = ioutil.WriteFile(cfgfile, appYaml, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	changes, err := SetAppConfig(appDir, []string{"spec.zone=us-east1-d", "spec.gpu.type=nvidia-tesla-k80",
+		"spec.skipApis=[ml.googleapis.com]", "spec.deleteStorage=true"})
+	if err != nil {
+		t.Fatalf("SetAppConfig failed: %v", err)
+	}
+	expected := []ConfigChange{
+		{Key: "spec.zone", Regenerate: true},
+		{Key: "spec.gpu.type", Regenerate: true},
+		{Key: "spec.skipApis", Regenerate: true},
+		{Key: "spec.deleteStorage", Regenerate: false},
+	}
+	if !reflect.DeepEqual(changes, expected) {
+		t.Errorf("changes = %+v; want %+v", changes, expected)
+	}
+	values, err := GetAppConfig(appDir, []string{"spec.zone", "spec.gpu.type", "spec.skipApis",
+		"spec.deleteStorage", "spec.components"})
+	if err != nil {
+		t.Fatalf("GetAppConfig failed: %v", err)
+	}
+	expectedValues := []string{"us-east1-d", "nvidia-tesla-k80", "- ml.googleapis.com", "true", "- ambassador"}
+	if !reflect.DeepEqual(values, expectedValues) {
+		t.Errorf("values = %q; want %q", values, expectedValues)
+	}
+	if name, err := AppName(appDir); err != nil || name != "kf-app" {
+		t.Errorf("AppName = %v, %v; want kf-app", name, err)
+	}
+
+	// Invalid settings leave app.yaml as it is.
+	before, _ := ioutil.ReadFile(cfgfile)
+	for _, setting := range []string{"spec.zone", "spec.zone=foo", "spec.noSuchField=1", "spec.zone.region=x",
+		"spec.deleteStorage=maybe", "spec.timeout=1h", "metadata.name=other"} {
+		if _, err := SetAppConfig(appDir, []string{"spec.hostname=kf.example.com", setting}); err == nil {
+			t.Errorf("SetAppConfig of %v succeeded", setting)
+		}
+	}
+	after, _ := ioutil.ReadFile(cfgfile)
+	if string(before) != string(after) {
+		t.Errorf("invalid settings changed %v", kftypes.KfConfigFile)
+	}
+	files, _ := ioutil.ReadDir(appDir)
+	if len(files) != 1 {
+		t.Errorf("expected only %v in the app dir; got %v files", kftypes.KfConfigFile, len(files))
+	}
+
+	// A v1beta1 app.yaml stays v1beta1.
+	appYaml = []byte(`apiVersion: kfdef.apps.kubeflow.org/v1beta1
+kind: KfDef
+metadata:
+  name: kf-app
+spec:
+  platform: gcp
+  project: my-project
+  zone: us-central1-a
+  hostname: kf.example.com
+`)
+	if err = ioutil.WriteFile(cfgfile, appYaml, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = SetAppConfig(appDir, []string{"spec.zone=us-east1-d"}); err != nil {
+		t.Fatalf("SetAppConfig of a v1beta1 %v failed: %v", kftypes.KfConfigFile, err)
+	}
+	after, _ = ioutil.ReadFile(cfgfile)
+	for _, expected := range []string{"apiVersion: kfdef.apps.kubeflow.org/v1beta1", "zone: us-east1-d",
+		"hostname: kf.example.com"} {
+		if !strings.Contains(string(after), expected) {
+			t.Errorf("SetAppConfig wrote %v without %v:\n%s", kftypes.KfConfigFile, expected, after)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package coordinator
+
+import (
+	"fmt"
+	"github.com/ghodss/yaml"
+	kftypes "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps"
+	kfdefs "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps/kfdef/v1alpha1"
+	kfdefsv1beta1 "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps/kfdef/v1beta1"
+	"github.com/kubeflow/kubeflow/bootstrap/pkg/utils"
+	log "github.com/sirupsen/logrus"
+	"io/ioutil"
+	"path/filepath"
+)
+
+// ConvertAppYaml rewrites the app.yaml in appDir as a v1beta1 KfDef, keeping the original
+// as app.yaml.v1alpha1.bak. The result is validated before anything is written.
+// When dryRun is true the converted app.yaml is returned but not written.
+func ConvertAppYaml(appDir string, dryRun bool) ([]byte, error) {
+	if err := migrateAppDir(appDir); err != nil {
+		return nil, err
+	}
+	cfgfile := filepath.Join(appDir, kftypes.KfConfigFile)
+	buf, err := ioutil.ReadFile(cfgfile)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't read %v. Error: %v", cfgfile, err)
+	}
+	kfdef := &kfdefs.KfDef{}
+	if err = unmarshalAppYaml(cfgfile, kfdef); err != nil {
+		return nil, err
+	}
+	converted, err := yaml.Marshal(kfdefsv1beta1.ConvertFromV1alpha1(kfdef))
+	if err != nil {
+		return nil, fmt.Errorf("couldn't marshal converted %v. Error: %v", cfgfile, err)
+	}
+	if dryRun {
+		return converted, nil
+	}
+	backup := cfgfile + ".v1alpha1.bak"
+	if err = ioutil.WriteFile(backup, buf, 0644); err != nil {
+		return nil, fmt.Errorf("couldn't back up %v to %v. Error: %v", cfgfile, backup, err)
+	}
+	if err = utils.WriteFileAtomically(cfgfile, converted, 0644); err != nil {
+		return nil, fmt.Errorf("couldn't write converted %v. Error: %v", cfgfile, err)
+	}
+	log.Infof("converted %v to %v; backup is at %v", cfgfile, kfdefsv1beta1.SchemeGroupVersion, backup)
+	return converted, nil
+}
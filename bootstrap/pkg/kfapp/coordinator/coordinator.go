@@ -23,12 +23,15 @@ import (
 	kfapis "github.com/kubeflow/kubeflow/bootstrap/pkg/apis"
 	kftypes "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps"
 	kfdefs "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps/kfdef/v1alpha1"
+	kfdefsv1beta1 "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps/kfdef/v1beta1"
+	"github.com/kubeflow/kubeflow/bootstrap/pkg/kfapp/assets"
 	"github.com/kubeflow/kubeflow/bootstrap/pkg/kfapp/gcp"
 	"github.com/kubeflow/kubeflow/bootstrap/pkg/kfapp/ksonnet"
 	"github.com/kubeflow/kubeflow/bootstrap/pkg/kfapp/minikube"
+	"github.com/kubeflow/kubeflow/bootstrap/pkg/utils"
 	"github.com/kubeflow/kubeflow/bootstrap/v2/pkg/kfapp/kustomize"
-	"github.com/mitchellh/go-homedir"
 	log "github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
 	"io/ioutil"
 	valid "k8s.io/apimachinery/pkg/api/validation"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -36,6 +39,7 @@ import (
 	"path"
 	"path/filepath"
 	"strings"
+	"time"
 )
 
 // The common entry point used to retrieve an implementation of KfApp.
@@ -62,19 +66,23 @@ func GetKfApp(kfdef *kfdefs.KfDef) kftypes.KfApp {
 	return _coordinator
 }
 
-// This function will download a version of kubeflow github repo where version can be
-//   master
-//	 tag
-//	 pull/<ID>[/head]
-// It returns one of the config files under bootstrap/config as a []byte buffer
-func downloadToCache(platform string, appDir string, version string, useBasicAuth bool) ([]byte, error) {
+// downloadToCache downloads version of the kubeflow github repo, master, a tag or pull/<ID>[/head],
+// into the cache of appDir. It returns the config file of authProvider under bootstrap/config,
+// taken from the assets built into kfctl when useEmbeddedAssets is set.
+// The repo is taken from mirror instead when it's set, so nothing is fetched from github. With
+// useEmbeddedAssets and no mirror the repo isn't fetched at all.
+// Otherwise the tarball of the commit of pin, or of version when pin has none, is taken from the
+// download cache and pin is set to its commit and checksum; see cachedTarball. caBundle is
+// spec.caBundle, trusted when downloading it.
+func downloadToCache(platform string, appDir string, version string, authProvider string,
+	mirror string, useEmbeddedAssets bool, pin *repoPin, caBundle string) ([]byte, error) {
 	if _, err := os.Stat(appDir); os.IsNotExist(err) {
 		appdirErr := os.Mkdir(appDir, os.ModePerm)
 		if appdirErr != nil {
 			log.Fatalf("couldn't create directory %v Error %v", appDir, appdirErr)
 		}
 	}
-	cacheDir := path.Join(appDir, kftypes.DefaultCacheDir)
+	cacheDir := filepath.Join(appDir, kftypes.DefaultCacheDir)
 	// idempotency
 	if _, err := os.Stat(cacheDir); !os.IsNotExist(err) {
 		os.RemoveAll(cacheDir)
@@ -83,21 +91,19 @@ func downloadToCache(platform string, appDir string, version string, useBasicAut
 	if cacheDirErr != nil {
 		return nil, fmt.Errorf("couldn't create directory %v Error %v", cacheDir, cacheDirErr)
 	}
+	configPath := configFilePath(platform, authProvider)
+	if useEmbeddedAssets && mirror == "" {
+		log.Infof("Using the assets built into kfctl, the kubeflow repo %v isn't downloaded", version)
+		return assets.Asset(configPath)
+	}
 	// Version can be
 	// --version master
 	// --version tag
 	// --version pull/<ID>/head
-	tarballUrl := kftypes.DefaultGitRepo + "/" + version + "?archive=tar.gz"
-	tarballUrlErr := gogetter.GetAny(cacheDir, tarballUrl)
-	if tarballUrlErr != nil {
-		return nil, fmt.Errorf("couldn't download kubeflow repo %v Error %v", tarballUrl, tarballUrlErr)
+	extractedPath, fetchErr := fetchRepo(cacheDir, version, mirror, pin, caBundle)
+	if fetchErr != nil {
+		return nil, fetchErr
 	}
-	files, filesErr := ioutil.ReadDir(cacheDir)
-	if filesErr != nil {
-		return nil, fmt.Errorf("couldn't read %v Error %v", cacheDir, filesErr)
-	}
-	subdir := files[0].Name()
-	extractedPath := filepath.Join(cacheDir, subdir)
 	newPath := filepath.Join(cacheDir, version)
 	if strings.Contains(version, "/") {
 		parts := strings.Split(version, "/")
@@ -114,18 +120,72 @@ func downloadToCache(platform string, appDir string, version string, useBasicAut
 	if renameErr != nil {
 		return nil, fmt.Errorf("couldn't rename %v to %v Error %v", extractedPath, newPath, renameErr)
 	}
+	if useEmbeddedAssets {
+		return assets.Asset(configPath)
+	}
+	return ioutil.ReadFile(filepath.Join(newPath, configPath))
+}
+
+// configFilePath is the config file of platform and authProvider in the kubeflow repo.
+func configFilePath(platform string, authProvider string) string {
 	//TODO see #2629
-	configPath := filepath.Join(newPath, kftypes.DefaultConfigDir)
+	configPath := kftypes.DefaultConfigDir
 	if platform == kftypes.GCP {
-		if useBasicAuth {
-			configPath = filepath.Join(configPath, kftypes.GcpBasicAuth)
-		} else {
-			configPath = filepath.Join(configPath, kftypes.GcpIapConfig)
+		switch authProvider {
+		case kftypes.AUTH_BASIC_AUTH:
+			return path.Join(configPath, kftypes.GcpBasicAuth)
+		case kftypes.AUTH_OIDC, kftypes.AUTH_LDAP:
+			return path.Join(configPath, kftypes.GcpDexConfig)
+		default:
+			return path.Join(configPath, kftypes.GcpIapConfig)
+		}
+	}
+	return path.Join(configPath, kftypes.DefaultConfigFile)
+}
+
+// fetchRepo extracts the kubeflow repo of version into cacheDir and returns where it was
+// extracted. A mirror directory is linked rather than copied; a mirror tarball is extracted like
+// the github one and must have the repo in a single top level directory too.
+func fetchRepo(cacheDir string, version string, mirror string, pin *repoPin, caBundle string) (string, error) {
+	var source string
+	if mirror == "" {
+		tarball, err := cachedTarball(version, pin, caBundle)
+		if err != nil {
+			return "", err
 		}
+		source = tarball
 	} else {
-		configPath = filepath.Join(configPath, kftypes.DefaultConfigFile)
+		mirrorPath, err := filepath.Abs(mirror)
+		if err != nil {
+			return "", fmt.Errorf("couldn't get the absolute path of mirror %v Error %v", mirror, err)
+		}
+		info, err := os.Stat(mirrorPath)
+		if err != nil {
+			return "", fmt.Errorf("couldn't read mirror %v Error %v", mirror, err)
+		}
+		if info.IsDir() {
+			linkPath := filepath.Join(cacheDir, "mirror")
+			if err = os.Symlink(mirrorPath, linkPath); err != nil {
+				// Creating symlinks needs a privilege on Windows; the mirror is used where it is.
+				log.Infof("couldn't link mirror %v, using it in place: %v", mirror, err)
+				return mirrorPath, nil
+			}
+			return linkPath, nil
+		}
+		source = mirrorPath
+	}
+	sourceErr := gogetter.GetAny(cacheDir, source)
+	if sourceErr != nil {
+		return "", fmt.Errorf("couldn't download kubeflow repo %v Error %v", source, sourceErr)
+	}
+	files, filesErr := ioutil.ReadDir(cacheDir)
+	if filesErr != nil {
+		return "", fmt.Errorf("couldn't read %v Error %v", cacheDir, filesErr)
 	}
-	return ioutil.ReadFile(configPath)
+	if len(files) == 0 {
+		return "", fmt.Errorf("kubeflow repo %v is empty", source)
+	}
+	return filepath.Join(cacheDir, files[0].Name()), nil
 }
 
 // GetPlatform will return an implementation of kftypes.KfApp that matches the platform string
@@ -150,24 +210,15 @@ func getPackageManagers(kfdef *kfdefs.KfDef) *map[string]kftypes.KfApp {
 		log.Fatalf("failed unmarshalling %v Error %v", appyaml, err)
 	}
 	var packagemanagers = make(map[string]kftypes.KfApp)
-	_packagemanager, _packagemanagerErr := getPackageManager("ksonnet", kfdef)
+	// spec.manifestsTool selects either ksonnet or kustomize.
+	manifestsTool := kftypes.ManifestsTool(&kfdef.Spec)
+	_packagemanager, _packagemanagerErr := getPackageManager(manifestsTool, kfdef)
 	if _packagemanagerErr != nil {
-		log.Fatalf("could not get packagemanager %v Error %v **", "ksonnet", _packagemanagerErr)
+		log.Fatalf("could not get packagemanager %v Error %v **", manifestsTool, _packagemanagerErr)
 	}
 	if _packagemanager != nil {
-		packagemanagers["ksonnet"] = _packagemanager
+		packagemanagers[manifestsTool] = _packagemanager
 	}
-	//TODO provide a global flag that adds kustomize so either kustomize or ksonnet can be selected
-	/*
-		_packagemanager, _packagemanagerErr = getPackageManager("kustomize", kfdef)
-		if _packagemanagerErr != nil {
-			log.Fatalf("could not get packagemanager %v Error %v **", "kustomize", _packagemanagerErr)
-
-		}
-		if _packagemanager != nil {
-			packagemanagers["kustomize"] = _packagemanager
-		}
-	*/
 	return &packagemanagers
 }
 
@@ -176,9 +227,9 @@ func getPackageManagers(kfdef *kfdefs.KfDef) *map[string]kftypes.KfApp {
 // kftypes.LoadKfApp which will try and dynamically load a .so
 func getPackageManager(packagemanager string, kfdef *kfdefs.KfDef) (kftypes.KfApp, error) {
 	switch packagemanager {
-	case "ksonnet":
+	case kftypes.MANIFESTS_KSONNET:
 		return ksonnet.GetKfApp(kfdef), nil
-	case "kustomize":
+	case kftypes.MANIFESTS_KUSTOMIZE:
 		return kustomize.GetKfApp(kfdef), nil
 	default:
 		log.Infof("** loading %v.so for package manager %v **", packagemanager, packagemanager)
@@ -186,24 +237,15 @@ func getPackageManager(packagemanager string, kfdef *kfdefs.KfDef) (kftypes.KfAp
 	}
 }
 
-// Helper function to filter out spartakus.
-func filterSpartakus(components []string) []string {
-	ret := []string{}
-	for _, comp := range components {
-		if comp != "spartakus" {
-			ret = append(ret, comp)
-		}
-	}
-	return ret
-}
-
 // Helper function to print out warning message if using usage reporting.
 func usageReportWarn(components []string) {
 	msg := "\n" +
 		"****************************************************************\n" +
 		"Notice anonymous usage reporting enabled using spartakus\n" +
-		"To disable it\n" +
-		"If you have already deployed it run the following commands:\n" +
+		"To disable it run the following command:\n" +
+		"  kfctl config set usage-reporting=false\n" +
+		"\n" +
+		"If you have already deployed it also run the following commands:\n" +
 		"  cd $(pwd)\n" +
 		"  ks delete default -c spartakus\n" +
 		"  kubectl -n ${K8S_NAMESPACE} delete deploy -l app=spartakus\n" +
@@ -215,7 +257,7 @@ func usageReportWarn(components []string) {
 		"****************************************************************\n" +
 		"\n"
 	for _, comp := range components {
-		if comp == "spartakus" {
+		if comp == kftypes.SPARTAKUS {
 			log.Warnf(msg)
 			return
 		}
@@ -227,28 +269,24 @@ func usageReportWarn(components []string) {
 func NewKfApp(options map[string]interface{}) (kftypes.KfApp, error) {
 	//appName can be a path
 	appName := options[string(kftypes.APPNAME)].(string)
-	appDir := path.Dir(appName)
+	appDir := filepath.Dir(appName)
 	if appDir == "" || appDir == "." {
 		cwd, err := os.Getwd()
 		if err != nil {
 			return nil, fmt.Errorf("could not get current directory %v", err)
 		}
-		appDir = path.Join(cwd, appName)
+		appDir = filepath.Join(cwd, appName)
 	} else {
 		if appDir == "~" {
-			home, homeErr := homedir.Dir()
-			if homeErr != nil {
-				return nil, fmt.Errorf("could not get home directory %v", homeErr)
+			home := kftypes.HomeDir()
+			if home == "" {
+				return nil, fmt.Errorf("could not get home directory: HOME is not set")
 			}
-			expanded, expandedErr := homedir.Expand(home)
-			if expandedErr != nil {
-				return nil, fmt.Errorf("could not expand home directory %v", homeErr)
-			}
-			appName = path.Base(appName)
-			appDir = path.Join(expanded, appName)
+			appName = filepath.Base(appName)
+			appDir = filepath.Join(home, appName)
 		} else {
-			appName = path.Base(appName)
-			appDir = path.Join(appDir, appName)
+			appName = filepath.Base(appName)
+			appDir = filepath.Join(appDir, appName)
 		}
 	}
 	errs := valid.NameIsDNSLabel(appName, false)
@@ -264,7 +302,28 @@ func NewKfApp(options map[string]interface{}) (kftypes.KfApp, error) {
 		}
 	}
 	useBasicAuth := options[string(kftypes.USE_BASIC_AUTH)].(bool)
-	configFileBuffer, configFileErr := downloadToCache(platform, appDir, version, useBasicAuth)
+	authProvider := ""
+	if options[string(kftypes.AUTH_PROVIDER)] != nil {
+		authProvider = options[string(kftypes.AUTH_PROVIDER)].(string)
+	}
+	if authProvider == "" && useBasicAuth {
+		authProvider = kftypes.AUTH_BASIC_AUTH
+	}
+	mirror := ""
+	if options[string(kftypes.MIRROR)] != nil {
+		mirror = options[string(kftypes.MIRROR)].(string)
+	}
+	useEmbeddedAssets := false
+	if options[string(kftypes.USE_EMBEDDED_ASSETS)] != nil {
+		useEmbeddedAssets = options[string(kftypes.USE_EMBEDDED_ASSETS)].(bool)
+	}
+	pin := &repoPin{}
+	if options[string(kftypes.REPO_CHECKSUM)] != nil {
+		pin.checksum = options[string(kftypes.REPO_CHECKSUM)].(string)
+	}
+	// There's no app.yaml, and so no spec.caBundle, yet.
+	configFileBuffer, configFileErr := downloadToCache(platform, appDir, version, authProvider,
+		mirror, useEmbeddedAssets, pin, "")
 	if configFileErr != nil {
 		log.Fatalf("could not download repo to cache Error %v", configFileErr)
 	}
@@ -279,26 +338,122 @@ func NewKfApp(options map[string]interface{}) (kftypes.KfApp, error) {
 	if specErr != nil {
 		log.Errorf("couldn't unmarshal app.yaml. Error: %v", specErr)
 	}
+	kftypes.ApplyComponentMatrix(&kfDef.Spec)
 	disableUsageReport := options[string(kftypes.DISABLE_USAGE_REPORT)].(bool)
 	if disableUsageReport {
-		kfDef.Spec.Components = filterSpartakus(kfDef.Spec.Components)
-		delete(kfDef.Spec.ComponentParams, "spartakus")
+		kfDef.Spec.UsageReporting = &kfdefs.UsageReportingConfig{Enabled: false}
 	}
+	kftypes.ApplyUsageReporting(&kfDef.Spec)
 
 	kfDef.Name = appName
 	kfDef.Spec.AppDir = appDir
+	kfDef.Spec.AppDirVersion = kftypes.AppDirVersion
 	kfDef.Spec.Platform = options[string(kftypes.PLATFORM)].(string)
 	kfDef.Namespace = options[string(kftypes.NAMESPACE)].(string)
 	kfDef.Spec.Version = options[string(kftypes.VERSION)].(string)
 	kfDef.Spec.Repo = options[string(kftypes.REPO)].(string)
 	kfDef.Spec.Project = options[string(kftypes.PROJECT)].(string)
 	kfDef.Spec.SkipInitProject = options[string(kftypes.SKIP_INIT_GCP_PROJECT)].(bool)
-	kfDef.Spec.UseBasicAuth = options[string(kftypes.USE_BASIC_AUTH)].(bool)
+	kfDef.Spec.UseBasicAuth = authProvider == kftypes.AUTH_BASIC_AUTH
+	if authProvider != "" {
+		kfDef.Spec.Auth = &kfdefs.AuthConfig{Provider: authProvider}
+	}
 	kfDef.Spec.UseIstio = options[string(kftypes.USE_ISTIO)].(bool)
+	if options[string(kftypes.MANIFESTS_TOOL)] != nil {
+		kfDef.Spec.ManifestsTool = options[string(kftypes.MANIFESTS_TOOL)].(string)
+	}
+	if options[string(kftypes.NAMESPACE_PREFIX)] != nil {
+		kfDef.Spec.NamespacePrefix = options[string(kftypes.NAMESPACE_PREFIX)].(string)
+		kfDef.Namespace = kftypes.PrefixedNamespace(&kfDef.Spec, kfDef.Namespace)
+	}
+	if options[string(kftypes.COMBINED_DEPLOYMENT)] != nil {
+		kfDef.Spec.CombinedDeployment = options[string(kftypes.COMBINED_DEPLOYMENT)].(bool)
+	}
+	kfDef.Spec.UseEmbeddedAssets = useEmbeddedAssets
+	kfDef.Spec.Mirror = mirror
+	kfDef.Spec.RepoSha = pin.sha
+	kfDef.Spec.RepoChecksum = pin.checksum
+	// Set by kfctl init --interactive; generate defaults them otherwise.
+	if options[string(kftypes.ZONE)] != nil && options[string(kftypes.ZONE)].(string) != "" {
+		kfDef.Spec.Zone = options[string(kftypes.ZONE)].(string)
+	}
+	if options[string(kftypes.EMAIL)] != nil && options[string(kftypes.EMAIL)].(string) != "" {
+		kfDef.Spec.Email = options[string(kftypes.EMAIL)].(string)
+	}
 	pApp := GetKfApp(kfDef)
 	return pApp, nil
 }
 
+// NewKfAppForKfDef is called by the operator, where the KfDef comes from a custom resource
+// rather than from subcommand options and app.yaml. The spec is written to spec.appDir/app.yaml
+// since the KfApp implementations reload it from there. The first time an appDir is used the
+// kubeflow repo is downloaded to its cache and Init is run.
+func NewKfAppForKfDef(kfdef *kfdefs.KfDef) (kftypes.KfApp, error) {
+	errs := valid.NameIsDNSLabel(kfdef.Name, false)
+	if errs != nil && len(errs) > 0 {
+		return nil, fmt.Errorf(`invalid name due to %v`, strings.Join(errs, ", "))
+	}
+	appDir := kfdef.Spec.AppDir
+	if err := os.MkdirAll(appDir, os.ModePerm); err != nil {
+		return nil, fmt.Errorf("couldn't create directory %v Error %v", appDir, err)
+	}
+	if kfdef.Spec.Version == "" {
+		kfdef.Spec.Version = kftypes.DefaultVersion
+	}
+	if strings.HasPrefix(kfdef.Spec.Version, "pull") && !strings.HasSuffix(kfdef.Spec.Version, "head") {
+		kfdef.Spec.Version = kfdef.Spec.Version + "/head"
+	}
+	if kfdef.Spec.AppDirVersion == 0 {
+		kfdef.Spec.AppDirVersion = kftypes.AppDirVersion
+	}
+	kftypes.ApplyComponentMatrix(&kfdef.Spec)
+	kftypes.ApplyUsageReporting(&kfdef.Spec)
+	cacheDir := filepath.Join(appDir, kftypes.DefaultCacheDir, kfdef.Spec.Version)
+	initialize := false
+	if _, err := os.Stat(cacheDir); os.IsNotExist(err) {
+		// The repo is recreated from the commit the app was pinned to, when it was.
+		pin := &repoPin{sha: kfdef.Spec.RepoSha, checksum: kfdef.Spec.RepoChecksum}
+		_, downloadErr := downloadToCache(kfdef.Spec.Platform, appDir, kfdef.Spec.Version, kftypes.AuthProvider(&kfdef.Spec),
+			kfdef.Spec.Mirror, kfdef.Spec.UseEmbeddedAssets, pin, kfdef.Spec.CaBundle)
+		if downloadErr != nil {
+			return nil, fmt.Errorf("could not download repo to cache Error %v", downloadErr)
+		}
+		kfdef.Spec.RepoSha = pin.sha
+		kfdef.Spec.RepoChecksum = pin.checksum
+		initialize = true
+	}
+	if kfdef.Spec.Repo == "" {
+		kfdef.Spec.Repo = filepath.Join(cacheDir, "kubeflow")
+	}
+	appYaml := &kfdefs.KfDef{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "KfDef",
+			APIVersion: "kfdef.apps.kubeflow.org/v1alpha1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      kfdef.Name,
+			Namespace: kfdef.Namespace,
+		},
+		Spec: kfdef.Spec,
+	}
+	appYaml.Spec.ComponentParams = kfdef.Spec.SortedComponentParams()
+	buf, err := yaml.Marshal(appYaml)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't marshal %v. Error: %v", kftypes.KfConfigFile, err)
+	}
+	cfgfile := filepath.Join(appDir, kftypes.KfConfigFile)
+	if err = utils.WriteFileAtomically(cfgfile, buf, 0644); err != nil {
+		return nil, fmt.Errorf("couldn't write %v. Error: %v", cfgfile, err)
+	}
+	pApp := GetKfApp(kfdef)
+	if initialize {
+		if err = pApp.Init(kftypes.ALL); err != nil {
+			return nil, err
+		}
+	}
+	return pApp, nil
+}
+
 // unmarshalAppYaml is a local function to marshal the contents of app.yaml into
 // the KfDef type
 func unmarshalAppYaml(cfgfile string, kfdef *kfdefs.KfDef) error {
@@ -308,14 +463,42 @@ func unmarshalAppYaml(cfgfile string, kfdef *kfdefs.KfDef) error {
 		if bufErr != nil {
 			return fmt.Errorf("couldn't read %v. Error: %v", cfgfile, bufErr)
 		}
-		err := yaml.Unmarshal(buf, kfdef)
+		typeMeta := &metav1.TypeMeta{}
+		if err = yaml.Unmarshal(buf, typeMeta); err != nil {
+			return fmt.Errorf("could not unmarshal %v. Error: %v", cfgfile, err)
+		}
+		if typeMeta.APIVersion == kfdefsv1beta1.SchemeGroupVersion.String() {
+			betaKfDef := &kfdefsv1beta1.KfDef{}
+			if err = yaml.Unmarshal(buf, betaKfDef); err != nil {
+				return fmt.Errorf("could not unmarshal %v. Error: %v", cfgfile, err)
+			}
+			if errs := kfdefsv1beta1.ValidateKfDef(betaKfDef); len(errs) > 0 {
+				return invalidAppYaml(cfgfile, errs.ToAggregate())
+			}
+			*kfdef = *kfdefsv1beta1.ConvertToV1alpha1(betaKfDef)
+			return nil
+		}
+		err = yaml.Unmarshal(buf, kfdef)
 		if err != nil {
 			return fmt.Errorf("could not unmarshal %v. Error: %v", cfgfile, err)
 		}
+		// v1alpha1 has the same fields as v1beta1, so it's held to the same validation.
+		if errs := kfdefsv1beta1.ValidateKfDef(kfdefsv1beta1.ConvertFromV1alpha1(kfdef)); len(errs) > 0 {
+			return invalidAppYaml(cfgfile, errs.ToAggregate())
+		}
+		kftypes.ApplyComponentMatrix(&kfdef.Spec)
+		kftypes.ApplyUsageReporting(&kfdef.Spec)
 	}
 	return nil
 }
 
+func invalidAppYaml(cfgfile string, err error) error {
+	return &kfapis.KfError{
+		Code:    int(kfapis.INVALID_ARGUMENT),
+		Message: fmt.Sprintf("invalid %v: %v", cfgfile, err),
+	}
+}
+
 // LoadKfApp is called from subcommands Apply, Delete, Generate and assumes the existence of an app.yaml
 // file which was created by the Init subcommand. It sets options needed by these subcommands
 func LoadKfApp(options map[string]interface{}) (kftypes.KfApp, error) {
@@ -323,6 +506,20 @@ func LoadKfApp(options map[string]interface{}) (kftypes.KfApp, error) {
 	if err != nil {
 		return nil, fmt.Errorf("could not get current directory %v", err)
 	}
+	if err = migrateAppDir(appDir); err != nil {
+		return nil, err
+	}
+	kfdef, err := loadKfDef(appDir, options)
+	if err != nil {
+		return nil, err
+	}
+	pApp := GetKfApp(kfdef)
+	pApp.(*coordinator).options = options
+	return pApp, nil
+}
+
+// loadKfDef reads the app.yaml of appDir with options set over it.
+func loadKfDef(appDir string, options map[string]interface{}) (*kfdefs.KfDef, error) {
 	cfgfile := filepath.Join(appDir, kftypes.KfConfigFile)
 	kfdef := &kfdefs.KfDef{
 		TypeMeta: metav1.TypeMeta{
@@ -331,7 +528,7 @@ func LoadKfApp(options map[string]interface{}) (kftypes.KfApp, error) {
 		},
 		Spec: kfdefs.KfDefSpec{},
 	}
-	err = unmarshalAppYaml(cfgfile, kfdef)
+	err := unmarshalAppYaml(cfgfile, kfdef)
 	if err != nil {
 		return nil, fmt.Errorf("could not unmarshal %v. Error: %v", cfgfile, err)
 	}
@@ -356,6 +553,9 @@ func LoadKfApp(options map[string]interface{}) (kftypes.KfApp, error) {
 	} else if kfdef.Spec.Platform == kftypes.GCP && kfdef.Spec.Zone == "" {
 		kfdef.Spec.Zone = kftypes.DefaultZone
 	}
+	if options[string(kftypes.REGION)] != nil && options[string(kftypes.REGION)].(string) != "" {
+		kfdef.Spec.Region = options[string(kftypes.REGION)].(string)
+	}
 	if options[string(kftypes.USE_BASIC_AUTH)] != nil {
 		kfdef.Spec.UseBasicAuth = options[string(kftypes.USE_BASIC_AUTH)].(bool)
 	}
@@ -365,11 +565,69 @@ func LoadKfApp(options map[string]interface{}) (kftypes.KfApp, error) {
 	if options[string(kftypes.MOUNT_LOCAL)] != nil {
 		kfdef.Spec.MountLocal = options[string(kftypes.MOUNT_LOCAL)].(bool)
 	}
+	if options[string(kftypes.KUBECONFIG)] != nil && options[string(kftypes.KUBECONFIG)].(string) != "" {
+		kfdef.Spec.Kubeconfig = options[string(kftypes.KUBECONFIG)].(string)
+	}
+	if options[string(kftypes.KUBECONTEXT)] != nil && options[string(kftypes.KUBECONTEXT)].(string) != "" {
+		kfdef.Spec.KubeContext = options[string(kftypes.KUBECONTEXT)].(string)
+	}
+	if options[string(kftypes.ENV)] != nil && options[string(kftypes.ENV)].(string) != "" {
+		env := options[string(kftypes.ENV)].(string)
+		if _, ok := kfdef.Spec.ComponentParamOverrides[env]; !ok {
+			return nil, &kfapis.KfError{
+				Code:    int(kfapis.INVALID_ARGUMENT),
+				Message: fmt.Sprintf("env %v is not in componentParamOverrides of %v", env, cfgfile),
+			}
+		}
+		kfdef.Spec.Env = env
+	}
+	if options[string(kftypes.PASSWORD_FILE)] != nil && options[string(kftypes.PASSWORD_FILE)].(string) != "" {
+		kfdef.Spec.PasswordFile = options[string(kftypes.PASSWORD_FILE)].(string)
+	}
+	if options[string(kftypes.OAUTH_CLIENT_FILE)] != nil && options[string(kftypes.OAUTH_CLIENT_FILE)].(string) != "" {
+		kfdef.Spec.OAuthClientFile = options[string(kftypes.OAUTH_CLIENT_FILE)].(string)
+	}
+	if options[string(kftypes.BCRYPT_COST)] != nil && options[string(kftypes.BCRYPT_COST)].(int) != 0 {
+		kfdef.Spec.BcryptCost = options[string(kftypes.BCRYPT_COST)].(int)
+	}
+	if options[string(kftypes.TIMEOUT)] != nil {
+		kfdef.Spec.Timeout = options[string(kftypes.TIMEOUT)].(time.Duration)
+	}
+	if options[string(kftypes.TARGET)] != nil && len(options[string(kftypes.TARGET)].([]string)) != 0 {
+		if kfdef.Spec.Platform != kftypes.GCP {
+			return nil, &kfapis.KfError{
+				Code:    int(kfapis.INVALID_ARGUMENT),
+				Message: fmt.Sprintf("--%v is only supported by the %v platform", kftypes.TARGET, kftypes.GCP),
+			}
+		}
+		kfdef.Spec.Targets = options[string(kftypes.TARGET)].([]string)
+	}
+	if options[string(kftypes.ROTATE_SA_KEYS)] != nil {
+		kfdef.Spec.RotateSaKeys = options[string(kftypes.ROTATE_SA_KEYS)].(bool)
+	}
+	if options[string(kftypes.DM_RECOVERY)] != nil && kfdef.Spec.Platform == kftypes.GCP {
+		kfdef.Spec.DmRecovery = options[string(kftypes.DM_RECOVERY)].(string)
+	}
 	if options[string(kftypes.DELETE_STORAGE)] != nil && kfdef.Spec.Platform == kftypes.GCP {
 		kfdef.Spec.DeleteStorage = options[string(kftypes.DELETE_STORAGE)].(bool)
 	}
-	pApp := GetKfApp(kfdef)
-	return pApp, nil
+	if options[string(kftypes.DELETE_FILESTORE)] != nil && kfdef.Spec.Platform == kftypes.GCP {
+		kfdef.Spec.DeleteFilestore = options[string(kftypes.DELETE_FILESTORE)].(bool)
+	}
+	if options[string(kftypes.DELETE_IP)] != nil && kfdef.Spec.Platform == kftypes.GCP {
+		kfdef.Spec.DeleteIp = options[string(kftypes.DELETE_IP)].(bool)
+	}
+	if options[string(kftypes.PRESERVE_DATA)] != nil {
+		kfdef.Spec.PreserveData = options[string(kftypes.PRESERVE_DATA)].(bool)
+		if kfdef.Spec.PreserveData && kfdef.Spec.DeleteStorage {
+			return nil, &kfapis.KfError{
+				Code: int(kfapis.INVALID_ARGUMENT),
+				Message: fmt.Sprintf("--%v and --%v can't be combined", kftypes.PRESERVE_DATA,
+					kftypes.DELETE_STORAGE),
+			}
+		}
+	}
+	return kfdef, nil
 }
 
 // this type holds platform implementations of KfApp and ksonnet (also an implementation of KfApp)
@@ -380,9 +638,23 @@ type coordinator struct {
 	Platforms       map[string]kftypes.KfApp
 	PackageManagers map[string]kftypes.KfApp
 	KfDef           *kfdefs.KfDef
+	// options are the ones LoadKfApp set over app.yaml, set again when the remote state pulled
+	// under the state lock reloads it.
+	options map[string]interface{}
 }
 
-func (kfapp *coordinator) Apply(resources kftypes.ResourceEnum) error {
+func (kfapp *coordinator) Apply(resources kftypes.ResourceEnum) (err error) {
+	done := kfapp.notifyStart("apply", resources)
+	defer func() {
+		done(err)
+	}()
+	release, err := kfapp.lockState("apply")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = release(err)
+	}()
 	platform := func() error {
 		if kfapp.KfDef.Spec.Platform != "" {
 			platform := kfapp.Platforms[kfapp.KfDef.Spec.Platform]
@@ -400,6 +672,12 @@ func (kfapp *coordinator) Apply(resources kftypes.ResourceEnum) error {
 	}
 
 	k8s := func() error {
+		// The platform applies its own resources of the cluster, e.g. secrets, on ALL.
+		if resources == kftypes.K8S {
+			if platformErr := platform(); platformErr != nil {
+				return platformErr
+			}
+		}
 		kfapp.PackageManagers = *getPackageManagers(kfapp.KfDef)
 		for packageManagerName, packageManager := range kfapp.PackageManagers {
 			packageManagerErr := packageManager.Apply(kftypes.K8S)
@@ -410,21 +688,90 @@ func (kfapp *coordinator) Apply(resources kftypes.ResourceEnum) error {
 		return nil
 	}
 
-	switch resources {
-	case kftypes.ALL:
-		if err := platform(); err != nil {
-			return err
+	postApply := func(ctx context.Context) error {
+		if postApply, ok := kfapp.Platforms[kfapp.KfDef.Spec.Platform].(kftypes.KfPostApply); ok && postApply != nil {
+			if postApplyErr := postApply.PostApply(ctx, resources); postApplyErr != nil {
+				return fmt.Errorf("coordinator PostApply failed for %v: %v",
+					kfapp.KfDef.Spec.Platform, postApplyErr)
+			}
 		}
-		return k8s()
-	case kftypes.PLATFORM:
-		return platform()
-	case kftypes.K8S:
-		return k8s()
+		return nil
 	}
-	return nil
+
+	// Creating the cluster takes most of an apply, waiting for IAP the least.
+	// With --target only the targeted pieces of the platform are applied.
+	// Only post-apply stops when its budget is spent, Apply of the platform and the package
+	// managers doesn't take a context.
+	targeted := len(kfapp.KfDef.Spec.Targets) != 0
+	phases := []applyPhase{}
+	if resources == kftypes.ALL || resources == kftypes.PLATFORM || targeted {
+		platformPhase := kfapp.withHooks(HookApplyPlatform, resources, platform)
+		phases = append(phases, applyPhase{name: PlatformPhase, share: 0.6,
+			run: func(context.Context) error { return platformPhase() }})
+	}
+	if (resources == kftypes.ALL || resources == kftypes.K8S) && !targeted {
+		k8sPhase := kfapp.withHooks(HookApplyK8s, resources, k8s)
+		phases = append(phases, applyPhase{name: K8sPhase, share: 0.7,
+			run: func(context.Context) error { return k8sPhase() }},
+			applyPhase{name: PostApplyPhase, share: 1, run: postApply})
+	}
+
+	start := time.Now()
+	err = kfapp.runPhases(resources, phases)
+	if err == nil {
+		kfapp.reportDeployment(resources, time.Since(start))
+	}
+	return err
 }
 
-func (kfapp *coordinator) Delete(resources kftypes.ResourceEnum) error {
+// Plan lists what Apply would do for resources: the actions of the platform, then those of
+// the package managers for ALL and K8S unless spec.targets limits apply to the platform.
+func (kfapp *coordinator) Plan(resources kftypes.ResourceEnum) ([]kftypes.Action, error) {
+	actions := []kftypes.Action{}
+	if kfapp.KfDef.Spec.Platform != "" {
+		platform := kfapp.Platforms[kfapp.KfDef.Spec.Platform]
+		if platform == nil {
+			return nil, fmt.Errorf("%v not in Platforms", kfapp.KfDef.Spec.Platform)
+		}
+		platformActions, platformErr := platform.Plan(resources)
+		if platformErr != nil {
+			return nil, fmt.Errorf("coordinator Plan failed for %v: %v",
+				kfapp.KfDef.Spec.Platform, platformErr)
+		}
+		actions = append(actions, platformActions...)
+	}
+	if (resources == kftypes.ALL || resources == kftypes.K8S) && len(kfapp.KfDef.Spec.Targets) == 0 {
+		kfapp.PackageManagers = *getPackageManagers(kfapp.KfDef)
+		for packageManagerName, packageManager := range kfapp.PackageManagers {
+			packageManagerActions, packageManagerErr := packageManager.Plan(kftypes.K8S)
+			if packageManagerErr != nil {
+				return nil, fmt.Errorf("kfApp Plan failed for %v: %v", packageManagerName, packageManagerErr)
+			}
+			actions = append(actions, packageManagerActions...)
+		}
+	}
+	return actions, nil
+}
+
+func (kfapp *coordinator) Delete(resources kftypes.ResourceEnum) (err error) {
+	if kfapp.KfDef.Spec.DeletionProtection {
+		return &kfapis.KfError{
+			Code: int(kfapis.INVALID_ARGUMENT),
+			Message: fmt.Sprintf("deletion protection is enabled for %v; set deletionProtection to false in %v to delete it",
+				kfapp.KfDef.Name, kftypes.KfConfigFile),
+		}
+	}
+	done := kfapp.notifyStart("delete", resources)
+	defer func() {
+		done(err)
+	}()
+	release, err := kfapp.lockState("delete")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = release(err)
+	}()
 	platform := func() error {
 		if kfapp.KfDef.Spec.Platform != "" {
 			platform := kfapp.Platforms[kfapp.KfDef.Spec.Platform]
@@ -452,6 +799,20 @@ func (kfapp *coordinator) Delete(resources kftypes.ResourceEnum) error {
 		return nil
 	}
 
+	if err := kfapp.runHooks(HookDelete, HookPre, resources); err != nil {
+		return err
+	}
+
+	// With --target only the targeted pieces of the platform are deleted, the k8s resources are kept.
+	if len(kfapp.KfDef.Spec.Targets) != 0 {
+		if err := platform(); err != nil {
+			return &kfapis.KfError{
+				Code:    int(kfapis.INTERNAL_ERROR),
+				Message: fmt.Sprintf("error while deleting platform resources. Error %v", err),
+			}
+		}
+		return kfapp.runHooks(HookDelete, HookPost, resources)
+	}
 	switch resources {
 	case kftypes.ALL:
 		// if we're deleting ALL, any problems with deleting k8s will abort and not delete the platform
@@ -485,11 +846,39 @@ func (kfapp *coordinator) Delete(resources kftypes.ResourceEnum) error {
 				Message: fmt.Sprintf("error while deleting k8 resources, aborting deleting the platform. Error %v", err),
 			}
 		}
+		// The platform deletes the k8s resources it applied itself, e.g. Istio on GCP, once those
+		// of the package managers which may depend on them are gone.
+		if err := platform(); err != nil {
+			return &kfapis.KfError{
+				Code:    int(kfapis.INTERNAL_ERROR),
+				Message: fmt.Sprintf("error while deleting k8 resources. Error %v", err),
+			}
+		}
 	}
-	return nil
+	return kfapp.runHooks(HookDelete, HookPost, resources)
 }
 
-func (kfapp *coordinator) Generate(resources kftypes.ResourceEnum) error {
+func (kfapp *coordinator) Generate(resources kftypes.ResourceEnum) (err error) {
+	done := kfapp.notifyStart("generate", resources)
+	defer func() {
+		done(err)
+	}()
+	release, err := kfapp.lockState("generate")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = release(err)
+	}()
+	if err := kftypes.ValidateComponents(&kfapp.KfDef.Spec); err != nil {
+		return &kfapis.KfError{
+			Code:    int(kfapis.INVALID_ARGUMENT),
+			Message: fmt.Sprintf("invalid components:\n%v", err),
+		}
+	}
+	if err := validateHooks(kfapp.KfDef.Spec.Hooks); err != nil {
+		return err
+	}
 	platform := func() error {
 		if kfapp.KfDef.Spec.Platform != "" {
 			platform := kfapp.Platforms[kfapp.KfDef.Spec.Platform]
@@ -520,21 +909,28 @@ func (kfapp *coordinator) Generate(resources kftypes.ResourceEnum) error {
 	// Print out warning message if using usage reporting component.
 	usageReportWarn(kfapp.KfDef.Spec.Components)
 
-	switch resources {
-	case kftypes.ALL:
-		if err := platform(); err != nil {
-			return err
+	generate := func() error {
+		switch resources {
+		case kftypes.ALL:
+			if err := platform(); err != nil {
+				return err
+			}
+			return k8s()
+		case kftypes.PLATFORM:
+			return platform()
+		case kftypes.K8S:
+			return k8s()
 		}
-		return k8s()
-	case kftypes.PLATFORM:
-		return platform()
-	case kftypes.K8S:
-		return k8s()
+		return nil
 	}
-	return nil
+	return kfapp.withHooks(HookGenerate, resources, generate)()
 }
 
-func (kfapp *coordinator) Init(resources kftypes.ResourceEnum) error {
+func (kfapp *coordinator) Init(resources kftypes.ResourceEnum) (err error) {
+	done := kfapp.notifyStart("init", resources)
+	defer func() {
+		done(err)
+	}()
 	switch resources {
 	case kftypes.K8S:
 		fallthrough
@@ -597,3 +993,267 @@ func (kfapp *coordinator) Show(resources kftypes.ResourceEnum, options map[strin
 	}
 	return nil
 }
+
+func (kfapp *coordinator) GarbageCollect(dryRun bool) error {
+	if kfapp.KfDef.Spec.Platform == "" {
+		return nil
+	}
+	platform := kfapp.Platforms[kfapp.KfDef.Spec.Platform]
+	gc, ok := platform.(kftypes.KfGarbageCollect)
+	if !ok || gc == nil {
+		return fmt.Errorf("%v does not support garbage collection", kfapp.KfDef.Spec.Platform)
+	}
+	if gcErr := gc.GarbageCollect(dryRun); gcErr != nil {
+		return fmt.Errorf("coordinator GarbageCollect failed for %v: %v",
+			kfapp.KfDef.Spec.Platform, gcErr)
+	}
+	return nil
+}
+
+func (kfapp *coordinator) platformUsers() (kftypes.KfUsers, error) {
+	platform := kfapp.Platforms[kfapp.KfDef.Spec.Platform]
+	users, ok := platform.(kftypes.KfUsers)
+	if !ok || users == nil {
+		return nil, fmt.Errorf("%v does not manage basic auth users", kfapp.KfDef.Spec.Platform)
+	}
+	return users, nil
+}
+
+func (kfapp *coordinator) AddUser(username string) error {
+	users, err := kfapp.platformUsers()
+	if err != nil {
+		return err
+	}
+	if addErr := users.AddUser(username); addErr != nil {
+		return fmt.Errorf("coordinator AddUser failed for %v: %v",
+			kfapp.KfDef.Spec.Platform, addErr)
+	}
+	return nil
+}
+
+func (kfapp *coordinator) RemoveUser(username string) error {
+	users, err := kfapp.platformUsers()
+	if err != nil {
+		return err
+	}
+	if removeErr := users.RemoveUser(username); removeErr != nil {
+		return fmt.Errorf("coordinator RemoveUser failed for %v: %v",
+			kfapp.KfDef.Spec.Platform, removeErr)
+	}
+	return nil
+}
+
+func (kfapp *coordinator) ListUsers() ([]string, error) {
+	users, err := kfapp.platformUsers()
+	if err != nil {
+		return nil, err
+	}
+	return users.ListUsers()
+}
+
+func (kfapp *coordinator) GrantUser(email string, namespace string) error {
+	platform := kfapp.Platforms[kfapp.KfDef.Spec.Platform]
+	granter, ok := platform.(kftypes.KfGrantUser)
+	if !ok || granter == nil {
+		return fmt.Errorf("%v does not grant users access", kfapp.KfDef.Spec.Platform)
+	}
+	if grantErr := granter.GrantUser(email, namespace); grantErr != nil {
+		return fmt.Errorf("coordinator GrantUser failed for %v: %v",
+			kfapp.KfDef.Spec.Platform, grantErr)
+	}
+	return nil
+}
+
+func (kfapp *coordinator) platformCluster() (kftypes.KfCluster, error) {
+	platform := kfapp.Platforms[kfapp.KfDef.Spec.Platform]
+	cluster, ok := platform.(kftypes.KfCluster)
+	if !ok || cluster == nil {
+		return nil, fmt.Errorf("%v does not manage the node pools of its cluster", kfapp.KfDef.Spec.Platform)
+	}
+	return cluster, nil
+}
+
+func (kfapp *coordinator) ResizeNodePool(pool string, nodes int) error {
+	cluster, err := kfapp.platformCluster()
+	if err != nil {
+		return err
+	}
+	if resizeErr := cluster.ResizeNodePool(pool, nodes); resizeErr != nil {
+		return fmt.Errorf("coordinator ResizeNodePool failed for %v: %v",
+			kfapp.KfDef.Spec.Platform, resizeErr)
+	}
+	return nil
+}
+
+func (kfapp *coordinator) SetNodePoolMachineType(pool string, machineType string) error {
+	cluster, err := kfapp.platformCluster()
+	if err != nil {
+		return err
+	}
+	if setErr := cluster.SetNodePoolMachineType(pool, machineType); setErr != nil {
+		return fmt.Errorf("coordinator SetNodePoolMachineType failed for %v: %v",
+			kfapp.KfDef.Spec.Platform, setErr)
+	}
+	return nil
+}
+
+func (kfapp *coordinator) UpgradeCluster(masterVersion string, nodeVersion string, maxSurge int) error {
+	cluster, err := kfapp.platformCluster()
+	if err != nil {
+		return err
+	}
+	if upgradeErr := cluster.UpgradeCluster(masterVersion, nodeVersion, maxSurge); upgradeErr != nil {
+		return fmt.Errorf("coordinator UpgradeCluster failed for %v: %v",
+			kfapp.KfDef.Spec.Platform, upgradeErr)
+	}
+	return nil
+}
+
+func (kfapp *coordinator) SyncSecrets() error {
+	platform := kfapp.Platforms[kfapp.KfDef.Spec.Platform]
+	secrets, ok := platform.(kftypes.KfSecrets)
+	if !ok || secrets == nil {
+		return fmt.Errorf("%v does not manage the secrets of the app", kfapp.KfDef.Spec.Platform)
+	}
+	if syncErr := secrets.SyncSecrets(); syncErr != nil {
+		return fmt.Errorf("coordinator SyncSecrets failed for %v: %v",
+			kfapp.KfDef.Spec.Platform, syncErr)
+	}
+	return nil
+}
+
+func (kfapp *coordinator) WriteKubeconfig() error {
+	platform := kfapp.Platforms[kfapp.KfDef.Spec.Platform]
+	kubeconfig, ok := platform.(kftypes.KfKubeconfig)
+	if !ok || kubeconfig == nil {
+		return fmt.Errorf("%v does not configure kubectl for its cluster", kfapp.KfDef.Spec.Platform)
+	}
+	if writeErr := kubeconfig.WriteKubeconfig(); writeErr != nil {
+		return fmt.Errorf("coordinator WriteKubeconfig failed for %v: %v",
+			kfapp.KfDef.Spec.Platform, writeErr)
+	}
+	return nil
+}
+
+func (kfapp *coordinator) Endpoint() (*kftypes.Endpoint, error) {
+	platform := kfapp.Platforms[kfapp.KfDef.Spec.Platform]
+	endpoint, ok := platform.(kftypes.KfEndpoint)
+	if !ok || endpoint == nil {
+		return nil, fmt.Errorf("%v does not expose the app at a URL", kfapp.KfDef.Spec.Platform)
+	}
+	info, endpointErr := endpoint.Endpoint()
+	if endpointErr != nil {
+		return nil, fmt.Errorf("coordinator Endpoint failed for %v: %v",
+			kfapp.KfDef.Spec.Platform, endpointErr)
+	}
+	return info, nil
+}
+
+func (kfapp *coordinator) RestoreStorage() error {
+	platform := kfapp.Platforms[kfapp.KfDef.Spec.Platform]
+	restore, ok := platform.(kftypes.KfRestoreStorage)
+	if !ok || restore == nil {
+		return fmt.Errorf("%v does not restore its storage", kfapp.KfDef.Spec.Platform)
+	}
+	if restoreErr := restore.RestoreStorage(); restoreErr != nil {
+		return fmt.Errorf("coordinator RestoreStorage failed for %v: %v",
+			kfapp.KfDef.Spec.Platform, restoreErr)
+	}
+	return nil
+}
+
+func (kfapp *coordinator) EstimateCost(currency string) (*kftypes.CostEstimate, error) {
+	platform := kfapp.Platforms[kfapp.KfDef.Spec.Platform]
+	costEstimate, ok := platform.(kftypes.KfCostEstimate)
+	if !ok || costEstimate == nil {
+		return nil, fmt.Errorf("%v does not price the resources of the app", kfapp.KfDef.Spec.Platform)
+	}
+	estimate, estimateErr := costEstimate.EstimateCost(currency)
+	if estimateErr != nil {
+		return nil, fmt.Errorf("coordinator EstimateCost failed for %v: %v",
+			kfapp.KfDef.Spec.Platform, estimateErr)
+	}
+	return estimate, nil
+}
+
+func (kfapp *coordinator) Diagnose() ([]kftypes.Problem, error) {
+	platform := kfapp.Platforms[kfapp.KfDef.Spec.Platform]
+	doctor, ok := platform.(kftypes.KfDoctor)
+	if !ok || doctor == nil {
+		return nil, fmt.Errorf("%v does not diagnose its deployments", kfapp.KfDef.Spec.Platform)
+	}
+	problems, diagnoseErr := doctor.Diagnose()
+	if diagnoseErr != nil {
+		return nil, fmt.Errorf("coordinator Diagnose failed for %v: %v",
+			kfapp.KfDef.Spec.Platform, diagnoseErr)
+	}
+	return problems, nil
+}
+
+// Export returns app.yaml and the files the platform and package managers apply for resources.
+// A file exported twice fails the export rather than one of them being dropped from the archive.
+func (kfapp *coordinator) Export(resources kftypes.ResourceEnum) (map[string][]byte, error) {
+	cfgfile := filepath.Join(kfapp.KfDef.Spec.AppDir, kftypes.KfConfigFile)
+	buf, err := ioutil.ReadFile(cfgfile)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't read %v: %v", cfgfile, err)
+	}
+	files := map[string][]byte{
+		kftypes.KfConfigFile: buf,
+	}
+	add := func(name string, exported map[string][]byte) error {
+		for file, data := range exported {
+			if _, ok := files[file]; ok {
+				return fmt.Errorf("%v exports %v, which is already in the archive", name, file)
+			}
+			files[file] = data
+		}
+		return nil
+	}
+	if kfapp.KfDef.Spec.Platform != "" {
+		platform := kfapp.Platforms[kfapp.KfDef.Spec.Platform]
+		export, ok := platform.(kftypes.KfExport)
+		if !ok || export == nil {
+			return nil, fmt.Errorf("%v does not export its files", kfapp.KfDef.Spec.Platform)
+		}
+		exported, exportErr := export.Export(resources)
+		if exportErr != nil {
+			return nil, fmt.Errorf("coordinator Export failed for %v: %v",
+				kfapp.KfDef.Spec.Platform, exportErr)
+		}
+		if err := add(kfapp.KfDef.Spec.Platform, exported); err != nil {
+			return nil, err
+		}
+	}
+	if resources == kftypes.K8S || resources == kftypes.ALL {
+		kfapp.PackageManagers = *getPackageManagers(kfapp.KfDef)
+		for packageManagerName, packageManager := range kfapp.PackageManagers {
+			export, ok := packageManager.(kftypes.KfExport)
+			if !ok || export == nil {
+				return nil, fmt.Errorf("%v does not export its manifests", packageManagerName)
+			}
+			exported, exportErr := export.Export(kftypes.K8S)
+			if exportErr != nil {
+				return nil, fmt.Errorf("coordinator Export failed for %v: %v", packageManagerName, exportErr)
+			}
+			if err := add(packageManagerName, exported); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return files, nil
+}
+
+func (kfapp *coordinator) Diff(resources kftypes.ResourceEnum) (string, error) {
+	platform := kfapp.Platforms[kfapp.KfDef.Spec.Platform]
+	differ, ok := platform.(kftypes.KfDiff)
+	if !ok || differ == nil {
+		return "", fmt.Errorf("%v does not compare its configs with what's deployed", kfapp.KfDef.Spec.Platform)
+	}
+	diff, diffErr := differ.Diff(resources)
+	if diffErr != nil {
+		return "", fmt.Errorf("coordinator Diff failed for %v: %v",
+			kfapp.KfDef.Spec.Platform, diffErr)
+	}
+	return diff, nil
+}
@@ -0,0 +1,56 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package coordinator
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	kftypes "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps"
+	kfdefs "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps/kfdef/v1alpha1"
+)
+
+func TestUnmarshalAppYamlAutoZone(t *testing.T) {
+	appDir, err := ioutil.TempDir("", "kfctl-app-yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(appDir)
+	cfgfile := filepath.Join(appDir, kftypes.KfConfigFile)
+	for _, apiVersion := range []string{"kfdef.apps.kubeflow.org/v1alpha1", "kfdef.apps.kubeflow.org/v1beta1"} {
+		appYaml := []byte(`apiVersion: ` + apiVersion + `
+kind: KfDef
+metadata:
+  name: kf-app
+spec:
+  platform: gcp
+  project: my-project
+  zone: auto
+`)
+		if err = ioutil.WriteFile(cfgfile, appYaml, 0644); err != nil {
+			t.Fatal(err)
+		}
+		kfdef := &kfdefs.KfDef{}
+		if err = unmarshalAppYaml(cfgfile, kfdef); err != nil {
+			t.Errorf("unmarshalAppYaml of %v with zone %v failed: %v", apiVersion, kftypes.AutoZone, err)
+			continue
+		}
+		if kfdef.Spec.Zone != kftypes.AutoZone {
+			t.Errorf("unmarshalAppYaml of %v got zone %v; want %v", apiVersion, kfdef.Spec.Zone, kftypes.AutoZone)
+		}
+	}
+}
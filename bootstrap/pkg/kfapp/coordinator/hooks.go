@@ -0,0 +1,171 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package coordinator
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	kfapis "github.com/kubeflow/kubeflow/bootstrap/pkg/apis"
+	kftypes "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps"
+	kfdefs "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps/kfdef/v1alpha1"
+	"github.com/kubeflow/kubeflow/bootstrap/pkg/utils"
+	log "github.com/sirupsen/logrus"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// Steps hooks run before or after.
+const (
+	HookGenerate      = "generate"
+	HookApplyPlatform = "apply-platform"
+	HookApplyK8s      = "apply-k8s"
+	HookDelete        = "delete"
+)
+
+// When a hook runs.
+const (
+	HookPre  = "pre"
+	HookPost = "post"
+)
+
+// defaultHookTimeout bounds a hook whose timeoutSeconds isn't set.
+const defaultHookTimeout = 5 * time.Minute
+
+// HookContext is the JSON a hook is passed: on stdin of a command, as the body of a webhook.
+type HookContext struct {
+	Hook      string        `json:"hook"`
+	Step      string        `json:"step"`
+	When      string        `json:"when"`
+	Resources string        `json:"resources"`
+	Time      time.Time     `json:"time"`
+	KfDef     *kfdefs.KfDef `json:"kfdef"`
+}
+
+// validateHooks checks each of spec.hooks has a known step and when, and either a command or a url.
+func validateHooks(hooks []kfdefs.Hook) error {
+	msgs := []string{}
+	for i, hook := range hooks {
+		name := hook.Name
+		if name == "" {
+			name = fmt.Sprintf("hooks[%v]", i)
+		}
+		switch hook.Step {
+		case HookGenerate, HookApplyPlatform, HookApplyK8s, HookDelete:
+		default:
+			msgs = append(msgs, fmt.Sprintf("%v: unknown step %q, must be one of %v, %v, %v or %v",
+				name, hook.Step, HookGenerate, HookApplyPlatform, HookApplyK8s, HookDelete))
+		}
+		if hook.When != HookPre && hook.When != HookPost {
+			msgs = append(msgs, fmt.Sprintf("%v: when must be %v or %v, not %q", name, HookPre, HookPost, hook.When))
+		}
+		if (len(hook.Command) == 0) == (hook.Url == "") {
+			msgs = append(msgs, fmt.Sprintf("%v: exactly one of command and url must be set", name))
+		}
+		if hook.TimeoutSeconds < 0 {
+			msgs = append(msgs, fmt.Sprintf("%v: timeoutSeconds must not be negative", name))
+		}
+	}
+	if len(msgs) != 0 {
+		return &kfapis.KfError{
+			Code:    int(kfapis.INVALID_ARGUMENT),
+			Message: fmt.Sprintf("invalid hooks:\n%v", strings.Join(msgs, "\n")),
+		}
+	}
+	return nil
+}
+
+// runHooks runs the hooks of step declared to run when, in the order of spec.hooks. It stops at
+// the first failing hook unless the hook ignores failures.
+func (kfapp *coordinator) runHooks(step string, when string, resources kftypes.ResourceEnum) error {
+	for _, hook := range kfapp.KfDef.Spec.Hooks {
+		if hook.Step != step || hook.When != when {
+			continue
+		}
+		log.Infof("Running %v-%v hook %v", when, step, hook.Name)
+		err := kfapp.runHook(hook, &HookContext{
+			Hook:      hook.Name,
+			Step:      step,
+			When:      when,
+			Resources: string(resources),
+			Time:      time.Now(),
+			KfDef:     kfapp.KfDef,
+		})
+		if err == nil {
+			continue
+		}
+		if hook.IgnoreFailure {
+			log.Warnf("%v-%v hook %v failed: %v", when, step, hook.Name, err)
+			continue
+		}
+		return &kfapis.KfError{
+			Code:    int(kfapis.INTERNAL_ERROR),
+			Message: fmt.Sprintf("%v-%v hook %v failed: %v", when, step, hook.Name, err),
+		}
+	}
+	return nil
+}
+
+func (kfapp *coordinator) runHook(hook kfdefs.Hook, hookContext *HookContext) error {
+	timeout := defaultHookTimeout
+	if hook.TimeoutSeconds > 0 {
+		timeout = time.Duration(hook.TimeoutSeconds) * time.Second
+	}
+	if hook.Url != "" {
+		client, err := utils.NewHTTPClient(kfapp.KfDef.Spec.CaBundle)
+		if err != nil {
+			return err
+		}
+		client.Timeout = timeout
+		return postJSON(client, hook.Url, hookContext)
+	}
+	if len(hook.Command) == 0 {
+		return fmt.Errorf("neither command nor url is set")
+	}
+	body, err := json.Marshal(hookContext)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, hook.Command[0], hook.Command[1:]...)
+	cmd.Dir = kfapp.KfDef.Spec.AppDir
+	cmd.Stdin = bytes.NewReader(body)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := utils.RunCommand(cmd); err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return fmt.Errorf("%v didn't finish within %v", strings.Join(hook.Command, " "), timeout)
+		}
+		return err
+	}
+	return nil
+}
+
+// withHooks returns run of step wrapped in the pre and post hooks of step.
+func (kfapp *coordinator) withHooks(step string, resources kftypes.ResourceEnum, run func() error) func() error {
+	return func() error {
+		if err := kfapp.runHooks(step, HookPre, resources); err != nil {
+			return err
+		}
+		if err := run(); err != nil {
+			return err
+		}
+		return kfapp.runHooks(step, HookPost, resources)
+	}
+}
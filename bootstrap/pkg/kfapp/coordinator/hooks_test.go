@@ -0,0 +1,168 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package coordinator
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	kftypes "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps"
+	kfdefs "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps/kfdef/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestValidateHooks(t *testing.T) {
+	type testCase struct {
+		Hooks []kfdefs.Hook
+		Valid bool
+	}
+	testCases := []testCase{
+		{
+			Hooks: []kfdefs.Hook{
+				{Name: "policy", Step: HookGenerate, When: HookPre, Command: []string{"opa", "test"}},
+				{Name: "cmdb", Step: HookApplyPlatform, When: HookPost, Url: "https://cmdb.example.com"},
+			},
+			Valid: true,
+		},
+		{
+			Hooks: []kfdefs.Hook{{Name: "bad-step", Step: "init", When: HookPre, Url: "https://example.com"}},
+			Valid: false,
+		},
+		{
+			Hooks: []kfdefs.Hook{{Name: "bad-when", Step: HookDelete, When: "during", Url: "https://example.com"}},
+			Valid: false,
+		},
+		{
+			Hooks: []kfdefs.Hook{{Name: "both", Step: HookDelete, When: HookPre, Url: "https://example.com",
+				Command: []string{"true"}}},
+			Valid: false,
+		},
+		{
+			Hooks: []kfdefs.Hook{{Name: "neither", Step: HookApplyK8s, When: HookPost}},
+			Valid: false,
+		},
+	}
+	for _, c := range testCases {
+		err := validateHooks(c.Hooks)
+		if c.Valid && err != nil {
+			t.Errorf("hooks %+v should be valid: %v", c.Hooks, err)
+		}
+		if !c.Valid && err == nil {
+			t.Errorf("hooks %+v should be invalid", c.Hooks)
+		}
+	}
+}
+
+func TestRunHooks(t *testing.T) {
+	hooks := []string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hookContext := HookContext{}
+		if err := json.NewDecoder(r.Body).Decode(&hookContext); err != nil {
+			t.Errorf("couldn't decode hook context: %v", err)
+		}
+		if hookContext.KfDef == nil || hookContext.KfDef.Name != "kubeflow" {
+			t.Errorf("hook context is missing the KfDef: %+v", hookContext)
+		}
+		hooks = append(hooks, hookContext.Hook+" "+hookContext.When+"-"+hookContext.Step)
+		if r.URL.Path == "/fail" {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	kfapp := &coordinator{
+		KfDef: &kfdefs.KfDef{
+			ObjectMeta: metav1.ObjectMeta{Name: "kubeflow"},
+			Spec: kfdefs.KfDefSpec{
+				Hooks: []kfdefs.Hook{
+					{Name: "check", Step: HookGenerate, When: HookPre, Url: server.URL + "/check"},
+					{Name: "register", Step: HookApplyPlatform, When: HookPost, Url: server.URL + "/register"},
+					{Name: "optional", Step: HookApplyPlatform, When: HookPost, Url: server.URL + "/fail",
+						IgnoreFailure: true},
+					{Name: "required", Step: HookDelete, When: HookPre, Url: server.URL + "/fail"},
+				},
+			},
+		},
+	}
+
+	ran := false
+	run := func() error {
+		ran = true
+		return nil
+	}
+	if err := kfapp.withHooks(HookApplyPlatform, kftypes.PLATFORM, run)(); err != nil {
+		t.Fatalf("apply-platform hooks failed: %v", err)
+	}
+	if !ran {
+		t.Errorf("apply-platform wasn't run")
+	}
+	expected := []string{"register post-apply-platform", "optional post-apply-platform"}
+	if !reflect.DeepEqual(hooks, expected) {
+		t.Errorf("hooks run %v; want %v", hooks, expected)
+	}
+
+	hooks = []string{}
+	ran = false
+	if err := kfapp.withHooks(HookDelete, kftypes.ALL, run)(); err == nil {
+		t.Errorf("a failing pre-delete hook should fail delete")
+	}
+	if ran {
+		t.Errorf("delete shouldn't run when a pre-delete hook fails")
+	}
+}
+
+func TestRunCommandHook(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh isn't available")
+	}
+	appDir, err := ioutil.TempDir("", "kfctl-hooks")
+	if err != nil {
+		t.Fatalf("couldn't create temp dir: %v", err)
+	}
+	defer os.RemoveAll(appDir)
+
+	kfapp := &coordinator{
+		KfDef: &kfdefs.KfDef{
+			ObjectMeta: metav1.ObjectMeta{Name: "kubeflow"},
+			Spec: kfdefs.KfDefSpec{
+				AppDir: appDir,
+				Hooks: []kfdefs.Hook{
+					{Name: "save", Step: HookApplyK8s, When: HookPre, Command: []string{"sh", "-c", "cat > context.json"}},
+				},
+			},
+		},
+	}
+	if err := kfapp.runHooks(HookApplyK8s, HookPre, kftypes.K8S); err != nil {
+		t.Fatalf("pre-apply-k8s hook failed: %v", err)
+	}
+	buf, err := ioutil.ReadFile(filepath.Join(appDir, "context.json"))
+	if err != nil {
+		t.Fatalf("the hook didn't write the context in the app dir: %v", err)
+	}
+	hookContext := HookContext{}
+	if err := json.Unmarshal(buf, &hookContext); err != nil {
+		t.Fatalf("couldn't decode hook context: %v", err)
+	}
+	if hookContext.Hook != "save" || hookContext.Step != HookApplyK8s || hookContext.Resources != string(kftypes.K8S) {
+		t.Errorf("unexpected hook context %+v", hookContext)
+	}
+}
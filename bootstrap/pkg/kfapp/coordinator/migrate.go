@@ -0,0 +1,128 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package coordinator
+
+import (
+	"fmt"
+	"github.com/ghodss/yaml"
+	kftypes "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps"
+	"github.com/kubeflow/kubeflow/bootstrap/pkg/utils"
+	log "github.com/sirupsen/logrus"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// appDirMigration moves an app dir from version `from` to version `from+1`.
+// The raw app.yaml content is passed in so field renames can be done before
+// it is unmarshalled into the current KfDef type.
+type appDirMigration struct {
+	from        int
+	description string
+	migrate     func(appDir string, config map[string]interface{}) error
+}
+
+// appDirMigrations must stay sorted by `from` and cover every version below
+// kftypes.AppDirVersion.
+var appDirMigrations = []appDirMigration{
+	{
+		from:        0,
+		description: "rename Client to KfDef",
+		migrate:     migrateClientToKfDef,
+	},
+}
+
+// Older kfctl binaries wrote app.yaml as a `Client` of the client.apps.kubeflow.org group.
+func migrateClientToKfDef(appDir string, config map[string]interface{}) error {
+	if kind, ok := config["kind"]; ok && kind == "Client" {
+		config["kind"] = "KfDef"
+	}
+	if apiVersion, ok := config["apiVersion"]; ok && apiVersion == "client.apps.kubeflow.org/v1alpha1" {
+		config["apiVersion"] = "kfdef.apps.kubeflow.org/v1alpha1"
+	}
+	return nil
+}
+
+// getAppDirVersion returns the layout version stamped into app.yaml; unversioned app dirs are 0.
+func getAppDirVersion(config map[string]interface{}) int {
+	spec, ok := config["spec"].(map[string]interface{})
+	if !ok {
+		return 0
+	}
+	// Numbers are unmarshalled as float64 by ghodss/yaml.
+	switch v := spec["appDirVersion"].(type) {
+	case float64:
+		return int(v)
+	case int:
+		return v
+	}
+	return 0
+}
+
+func setAppDirVersion(config map[string]interface{}, version int) {
+	spec, ok := config["spec"].(map[string]interface{})
+	if !ok {
+		spec = make(map[string]interface{})
+		config["spec"] = spec
+	}
+	spec["appDirVersion"] = version
+}
+
+// migrateAppDir brings the app dir forward to kftypes.AppDirVersion. The original
+// app.yaml is kept as app.yaml.v<version>.bak before the migrated one is written.
+func migrateAppDir(appDir string) error {
+	cfgfile := filepath.Join(appDir, kftypes.KfConfigFile)
+	buf, err := ioutil.ReadFile(cfgfile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("couldn't read %v. Error: %v", cfgfile, err)
+	}
+	config := make(map[string]interface{})
+	if err = yaml.Unmarshal(buf, &config); err != nil {
+		return fmt.Errorf("could not unmarshal %v. Error: %v", cfgfile, err)
+	}
+	version := getAppDirVersion(config)
+	if version > kftypes.AppDirVersion {
+		return fmt.Errorf("%v was written by a newer kfctl (app dir version %v, this kfctl supports %v); please upgrade kfctl",
+			cfgfile, version, kftypes.AppDirVersion)
+	}
+	if version == kftypes.AppDirVersion {
+		return nil
+	}
+	backup := fmt.Sprintf("%v.v%v.bak", cfgfile, version)
+	if err = ioutil.WriteFile(backup, buf, 0644); err != nil {
+		return fmt.Errorf("couldn't back up %v to %v. Error: %v", cfgfile, backup, err)
+	}
+	for _, m := range appDirMigrations {
+		if m.from < version {
+			continue
+		}
+		log.Infof("migrating %v from app dir version %v: %v", appDir, m.from, m.description)
+		if err = m.migrate(appDir, config); err != nil {
+			return fmt.Errorf("couldn't migrate %v from app dir version %v. Error: %v", appDir, m.from, err)
+		}
+	}
+	setAppDirVersion(config, kftypes.AppDirVersion)
+	if buf, err = yaml.Marshal(config); err != nil {
+		return fmt.Errorf("couldn't marshal migrated %v. Error: %v", cfgfile, err)
+	}
+	if err = utils.WriteFileAtomically(cfgfile, buf, 0644); err != nil {
+		return fmt.Errorf("couldn't write migrated %v. Error: %v", cfgfile, err)
+	}
+	log.Infof("migrated %v to app dir version %v; backup is at %v", appDir, kftypes.AppDirVersion, backup)
+	return nil
+}
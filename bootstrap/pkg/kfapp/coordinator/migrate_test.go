@@ -0,0 +1,94 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package coordinator
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ghodss/yaml"
+	kftypes "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps"
+)
+
+func TestMigrateAppDir(t *testing.T) {
+	tests := []struct {
+		name     string
+		appYaml  string
+		wantErr  bool
+		wantKind string
+		backup   bool
+	}{
+		{
+			name:     "unversioned Client app dir is migrated",
+			appYaml:  "apiVersion: client.apps.kubeflow.org/v1alpha1\nkind: Client\nspec:\n  platform: gcp\n",
+			wantKind: "KfDef",
+			backup:   true,
+		},
+		{
+			name:     "current app dir is untouched",
+			appYaml:  "apiVersion: kfdef.apps.kubeflow.org/v1alpha1\nkind: KfDef\nspec:\n  appDirVersion: 1\n",
+			wantKind: "KfDef",
+		},
+		{
+			name:    "newer app dir is rejected",
+			appYaml: "kind: KfDef\nspec:\n  appDirVersion: 99\n",
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		appDir, err := ioutil.TempDir("", "kfctl-migrate")
+		if err != nil {
+			t.Fatalf("couldn't create temp dir: %v", err)
+		}
+		defer os.RemoveAll(appDir)
+		cfgfile := filepath.Join(appDir, kftypes.KfConfigFile)
+		if err = ioutil.WriteFile(cfgfile, []byte(test.appYaml), 0644); err != nil {
+			t.Fatalf("couldn't write %v: %v", cfgfile, err)
+		}
+
+		err = migrateAppDir(appDir)
+		if test.wantErr {
+			if err == nil {
+				t.Errorf("%v: expected an error", test.name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%v: unexpected error: %v", test.name, err)
+			continue
+		}
+		buf, _ := ioutil.ReadFile(cfgfile)
+		config := make(map[string]interface{})
+		if err = yaml.Unmarshal(buf, &config); err != nil {
+			t.Errorf("%v: couldn't unmarshal migrated app.yaml: %v", test.name, err)
+			continue
+		}
+		if config["kind"] != test.wantKind {
+			t.Errorf("%v: kind = %v, want %v", test.name, config["kind"], test.wantKind)
+		}
+		if v := getAppDirVersion(config); v != kftypes.AppDirVersion {
+			t.Errorf("%v: appDirVersion = %v, want %v", test.name, v, kftypes.AppDirVersion)
+		}
+		_, statErr := os.Stat(cfgfile + ".v0.bak")
+		if test.backup && statErr != nil {
+			t.Errorf("%v: expected a backup of app.yaml", test.name)
+		}
+		if !test.backup && statErr == nil {
+			t.Errorf("%v: unexpected backup of app.yaml", test.name)
+		}
+	}
+}
@@ -0,0 +1,201 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package coordinator
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	kftypes "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps"
+	kfdefs "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps/kfdef/v1alpha1"
+	"github.com/kubeflow/kubeflow/bootstrap/pkg/kfapp/gcp"
+	"github.com/kubeflow/kubeflow/bootstrap/pkg/utils"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+	pubsub "google.golang.org/api/pubsub/v1"
+	"net/http"
+	"time"
+)
+
+// Phases of a lifecycle Event.
+const (
+	PhaseStarted   = "started"
+	PhaseSucceeded = "succeeded"
+	PhaseFailed    = "failed"
+)
+
+// notifyTimeout bounds how long a slow sink can hold up an operation.
+const notifyTimeout = 10 * time.Second
+
+// Event describes a step in the lifecycle of a kubeflow app.
+type Event struct {
+	App       string    `json:"app"`
+	Platform  string    `json:"platform,omitempty"`
+	Project   string    `json:"project,omitempty"`
+	Operation string    `json:"operation"`
+	Resources string    `json:"resources"`
+	Phase     string    `json:"phase"`
+	Error     string    `json:"error,omitempty"`
+	Time      time.Time `json:"time"`
+}
+
+func (event *Event) String() string {
+	msg := fmt.Sprintf("kfctl %v %v of %v %v", event.Operation, event.Resources, event.App, event.Phase)
+	if event.Error != "" {
+		msg += ": " + event.Error
+	}
+	return msg
+}
+
+// Notifier delivers Events to one of the sinks in spec.notifications.
+type Notifier interface {
+	Notify(event *Event) error
+}
+
+// newNotifier returns the Notifier for a spec.notifications entry, sending with client.
+func newNotifier(notification kfdefs.Notification, client *http.Client) (Notifier, error) {
+	switch notification.Type {
+	case "slack":
+		return &slackNotifier{url: notification.Url, client: client}, nil
+	case "http":
+		return &httpNotifier{url: notification.Url, client: client}, nil
+	case "pubsub":
+		return &pubsubNotifier{topic: notification.Topic, client: client}, nil
+	default:
+		return nil, fmt.Errorf("unknown notification type %v", notification.Type)
+	}
+}
+
+// slackNotifier posts a one line message to a Slack incoming webhook.
+type slackNotifier struct {
+	url    string
+	client *http.Client
+}
+
+func (n *slackNotifier) Notify(event *Event) error {
+	return postJSON(n.client, n.url, map[string]string{"text": event.String()})
+}
+
+// httpNotifier posts the Event as JSON.
+type httpNotifier struct {
+	url    string
+	client *http.Client
+}
+
+func (n *httpNotifier) Notify(event *Event) error {
+	return postJSON(n.client, n.url, event)
+}
+
+func postJSON(client *http.Client, url string, v interface{}) error {
+	body, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	resp, err := client.Post(url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %v", resp.Status)
+	}
+	return nil
+}
+
+// pubsubNotifier publishes the Event as JSON, with its operation and phase as attributes
+// so subscribers can filter on them.
+type pubsubNotifier struct {
+	topic  string
+	client *http.Client
+}
+
+func (n *pubsubNotifier) Notify(event *Event) error {
+	ctx, cancel := context.WithTimeout(context.Background(), notifyTimeout)
+	defer cancel()
+	client, err := gcp.DefaultClient(utils.WithHTTPClient(ctx, n.client))
+	if err != nil {
+		return fmt.Errorf("Error getting DefaultClient: %v", err)
+	}
+	service, err := pubsub.New(client)
+	if err != nil {
+		return fmt.Errorf("Error creating pubsub service: %v", err)
+	}
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	_, err = service.Projects.Topics.Publish(n.topic, &pubsub.PublishRequest{
+		Messages: []*pubsub.PubsubMessage{
+			{
+				Data: base64.StdEncoding.EncodeToString(data),
+				Attributes: map[string]string{
+					"operation": event.Operation,
+					"phase":     event.Phase,
+				},
+			},
+		},
+	}).Context(ctx).Do()
+	return err
+}
+
+// notify sends event to every configured sink. Notifications are best effort:
+// failures are logged and never fail the operation.
+func (kfapp *coordinator) notify(event *Event) {
+	if len(kfapp.KfDef.Spec.Notifications) == 0 {
+		return
+	}
+	client, err := utils.NewHTTPClient(kfapp.KfDef.Spec.CaBundle)
+	if err != nil {
+		log.Warnf("couldn't send notifications: %v", err)
+		return
+	}
+	client.Timeout = notifyTimeout
+	for _, notification := range kfapp.KfDef.Spec.Notifications {
+		notifier, err := newNotifier(notification, client)
+		if err == nil {
+			err = notifier.Notify(event)
+		}
+		if err != nil {
+			log.Warnf("couldn't send %v notification: %v", notification.Type, err)
+		}
+	}
+}
+
+// notifyStart sends the started event of operation and returns a func to defer with
+// the operation's error, which sends the succeeded or failed event.
+func (kfapp *coordinator) notifyStart(operation string, resources kftypes.ResourceEnum) func(error) {
+	newEvent := func(phase string) *Event {
+		return &Event{
+			App:       kfapp.KfDef.Name,
+			Platform:  kfapp.KfDef.Spec.Platform,
+			Project:   kfapp.KfDef.Spec.Project,
+			Operation: operation,
+			Resources: string(resources),
+			Phase:     phase,
+			Time:      time.Now(),
+		}
+	}
+	kfapp.notify(newEvent(PhaseStarted))
+	return func(err error) {
+		if err == nil {
+			kfapp.notify(newEvent(PhaseSucceeded))
+			return
+		}
+		event := newEvent(PhaseFailed)
+		event.Error = err.Error()
+		kfapp.notify(event)
+	}
+}
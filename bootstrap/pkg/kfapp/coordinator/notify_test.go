@@ -0,0 +1,76 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package coordinator
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	kftypes "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps"
+	kfdefs "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps/kfdef/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNotifyStart(t *testing.T) {
+	events := []Event{}
+	slackText := []string{}
+	httpServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		event := Event{}
+		if err := json.NewDecoder(r.Body).Decode(&event); err != nil {
+			t.Errorf("couldn't decode event: %v", err)
+		}
+		events = append(events, event)
+	}))
+	defer httpServer.Close()
+	slackServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		msg := map[string]string{}
+		if err := json.NewDecoder(r.Body).Decode(&msg); err != nil {
+			t.Errorf("couldn't decode slack message: %v", err)
+		}
+		slackText = append(slackText, msg["text"])
+	}))
+	defer slackServer.Close()
+
+	kfapp := &coordinator{
+		KfDef: &kfdefs.KfDef{
+			ObjectMeta: metav1.ObjectMeta{Name: "kubeflow"},
+			Spec: kfdefs.KfDefSpec{
+				Notifications: []kfdefs.Notification{
+					{Type: "http", Url: httpServer.URL},
+					{Type: "slack", Url: slackServer.URL},
+				},
+			},
+		},
+	}
+	kfapp.notifyStart("apply", kftypes.ALL)(errors.New("quota exceeded"))
+
+	if len(events) != 2 || events[0].Phase != PhaseStarted || events[1].Phase != PhaseFailed ||
+		events[1].Error != "quota exceeded" || events[1].Operation != "apply" {
+		t.Errorf("unexpected events %+v", events)
+	}
+	if len(slackText) != 2 || !strings.Contains(slackText[1], "kfctl apply all of kubeflow failed: quota exceeded") {
+		t.Errorf("unexpected slack messages %v", slackText)
+	}
+}
+
+func TestNewNotifierUnknownType(t *testing.T) {
+	if _, err := newNotifier(kfdefs.Notification{Type: "email"}, http.DefaultClient); err == nil {
+		t.Errorf("expected an error for an unknown notification type")
+	}
+}
@@ -0,0 +1,122 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package coordinator
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	kftypes "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps"
+	kfdefs "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps/kfdef/v1alpha1"
+	"github.com/kubeflow/kubeflow/bootstrap/pkg/utils"
+	log "github.com/sirupsen/logrus"
+	"time"
+)
+
+// reportTimeout bounds how long a slow report endpoint can hold up apply.
+const reportTimeout = 10 * time.Second
+
+// deploymentReport is posted as JSON to spec.reportEndpoint after a successful apply.
+// It carries nothing identifying the project, user or hosts.
+type deploymentReport struct {
+	Version         string  `json:"version"`
+	Platform        string  `json:"platform"`
+	Resources       string  `json:"resources"`
+	DurationSeconds float64 `json:"durationSeconds"`
+	ConfigHash      string  `json:"configHash"`
+}
+
+// configHash hashes the spec with every identifying field cleared, so the same
+// configuration deployed by different teams reports the same hash.
+func configHash(spec kfdefs.KfDefSpec) (string, error) {
+	spec.AppDir = ""
+	spec.Project = ""
+	spec.Email = ""
+	spec.IapMembers = nil
+	spec.ServiceAccountKeys = nil
+	spec.IpName = ""
+	spec.Hostname = ""
+	spec.Zone = ""
+	spec.Kubeconfig = ""
+	spec.KubeContext = ""
+	spec.ReportEndpoint = ""
+	spec.StateBucket = ""
+	spec.DeploymentId = ""
+	spec.Notifications = nil
+	spec.Repo = ""
+	spec.Mirror = ""
+	spec.ServerVersion = ""
+	spec.ComponentParams = nil
+	spec.ComponentParamOverrides = nil
+	if spec.Certificate != nil {
+		// Copied, spec shares the pointer with the app.
+		certificate := *spec.Certificate
+		certificate.CertFile = ""
+		certificate.KeyFile = ""
+		spec.Certificate = &certificate
+	}
+	buf, err := json.Marshal(spec)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(buf)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// reportDeployment posts a deploymentReport when the user configured a report endpoint.
+// Reporting is best effort: failures are logged and never fail the apply.
+func (kfapp *coordinator) reportDeployment(resources kftypes.ResourceEnum, duration time.Duration) {
+	endpoint := kfapp.KfDef.Spec.ReportEndpoint
+	if endpoint == "" {
+		return
+	}
+	if err := postDeploymentReport(endpoint, kfapp.KfDef.Spec, resources, duration); err != nil {
+		log.Warnf("couldn't send deployment report to %v: %v", endpoint, err)
+	}
+}
+
+func postDeploymentReport(endpoint string, spec kfdefs.KfDefSpec, resources kftypes.ResourceEnum,
+	duration time.Duration) error {
+	hash, err := configHash(spec)
+	if err != nil {
+		return fmt.Errorf("couldn't hash config: %v", err)
+	}
+	body, err := json.Marshal(deploymentReport{
+		Version:         spec.Version,
+		Platform:        spec.Platform,
+		Resources:       string(resources),
+		DurationSeconds: duration.Seconds(),
+		ConfigHash:      hash,
+	})
+	if err != nil {
+		return err
+	}
+	client, err := utils.NewHTTPClient(spec.CaBundle)
+	if err != nil {
+		return err
+	}
+	client.Timeout = reportTimeout
+	resp, err := client.Post(endpoint, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %v", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package coordinator
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	kftypes "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps"
+	kfdefs "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps/kfdef/v1alpha1"
+)
+
+func TestPostDeploymentReport(t *testing.T) {
+	var got deploymentReport
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("couldn't decode report: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	spec := kfdefs.KfDefSpec{
+		Version: "v0.4.1",
+		Project: "team-a",
+		Email:   "someone@example.com",
+	}
+	spec.Platform = kftypes.GCP
+	if err := postDeploymentReport(server.URL, spec, kftypes.ALL, 90*time.Second); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Version != "v0.4.1" || got.Platform != kftypes.GCP || got.DurationSeconds != 90 {
+		t.Errorf("unexpected report %+v", got)
+	}
+
+	// Identifying fields must not change the hash.
+	other := spec
+	other.Project = "team-b"
+	other.Email = "someone-else@example.com"
+	hash, _ := configHash(spec)
+	otherHash, _ := configHash(other)
+	if hash != otherHash || got.ConfigHash != hash {
+		t.Errorf("config hash depends on identifying fields: %v, %v, %v", hash, otherHash, got.ConfigHash)
+	}
+}
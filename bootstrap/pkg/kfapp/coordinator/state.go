@@ -0,0 +1,320 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package coordinator
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	kfapis "github.com/kubeflow/kubeflow/bootstrap/pkg/apis"
+	kftypes "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps"
+	kfdefs "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps/kfdef/v1alpha1"
+	"github.com/kubeflow/kubeflow/bootstrap/pkg/kfapp/gcp"
+	"github.com/kubeflow/kubeflow/bootstrap/pkg/utils"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+	"google.golang.org/api/googleapi"
+	storage "google.golang.org/api/storage/v1"
+	"io/ioutil"
+	"os"
+	"os/user"
+	"path"
+	"path/filepath"
+	"time"
+)
+
+// The gcp_config dir written by the gcp platform; it is shared along with app.yaml so
+// any operator can apply the same deployment manager configs.
+const stateConfigDir = "gcp_config"
+
+// DefaultStatePrefix is the object prefix used when spec.statePrefix is not set.
+const DefaultStatePrefix = "kfctl"
+
+// stateLock is the content of the lock object, telling other operators who holds it.
+type stateLock struct {
+	Who       string    `json:"who"`
+	Operation string    `json:"operation"`
+	Created   time.Time `json:"created"`
+}
+
+// remoteState keeps app.yaml and gcp_config in gs://<stateBucket>/<statePrefix>/<name>/ and
+// serializes kfctl runs on the same deployment with a lock object next to them, so several
+// operators and CI runners can share one deployment.
+type remoteState struct {
+	service *storage.Service
+	bucket  string
+	prefix  string
+	appDir  string
+	// generation of the lock object we created, 0 when not holding the lock.
+	lockGeneration int64
+}
+
+func newRemoteState(kfdef *kfdefs.KfDef) (*remoteState, error) {
+	if kfdef.Spec.StateBucket == "" {
+		return nil, nil
+	}
+	baseClient, err := utils.NewHTTPClient(kfdef.Spec.CaBundle)
+	if err != nil {
+		return nil, err
+	}
+	ctx := utils.WithHTTPClient(context.Background(), baseClient)
+	client, err := gcp.DefaultClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("Error getting DefaultClient for remote state: %v", err)
+	}
+	service, err := storage.New(client)
+	if err != nil {
+		return nil, fmt.Errorf("Error creating storage service for remote state: %v", err)
+	}
+	prefix := kfdef.Spec.StatePrefix
+	if prefix == "" {
+		prefix = DefaultStatePrefix
+	}
+	return &remoteState{
+		service: service,
+		bucket:  kfdef.Spec.StateBucket,
+		prefix:  path.Join(prefix, kfdef.Name),
+		appDir:  kfdef.Spec.AppDir,
+	}, nil
+}
+
+func (state *remoteState) object(name string) string {
+	return path.Join(state.prefix, name)
+}
+
+func (state *remoteState) url(name string) string {
+	return fmt.Sprintf("gs://%v/%v", state.bucket, state.object(name))
+}
+
+// stateFiles lists the app dir files kept in the remote state, relative to the app dir.
+func (state *remoteState) stateFiles() ([]string, error) {
+	files := []string{kftypes.KfConfigFile}
+	configs, err := ioutil.ReadDir(filepath.Join(state.appDir, stateConfigDir))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return files, nil
+		}
+		return nil, err
+	}
+	for _, config := range configs {
+		if !config.IsDir() {
+			files = append(files, path.Join(stateConfigDir, config.Name()))
+		}
+	}
+	return files, nil
+}
+
+// lock creates the lock object, failing if another run holds it.
+func (state *remoteState) lock(operation string) error {
+	who := "unknown"
+	if u, err := user.Current(); err == nil {
+		who = u.Username
+	}
+	if host, err := os.Hostname(); err == nil {
+		who = who + "@" + host
+	}
+	buf, err := json.Marshal(stateLock{
+		Who:       who,
+		Operation: operation,
+		Created:   time.Now(),
+	})
+	if err != nil {
+		return err
+	}
+	obj, err := state.service.Objects.Insert(state.bucket, &storage.Object{Name: state.object("lock")}).
+		Media(bytes.NewReader(buf)).IfGenerationMatch(0).Do()
+	if err != nil {
+		if e, ok := err.(*googleapi.Error); ok && e.Code == 412 {
+			return &kfapis.KfError{
+				Code:    int(kfapis.INVALID_ARGUMENT),
+				Message: state.lockHeldMessage(),
+			}
+		}
+		return fmt.Errorf("couldn't create lock %v: %v", state.url("lock"), err)
+	}
+	state.lockGeneration = obj.Generation
+	log.Infof("acquired lock %v for %v", state.url("lock"), operation)
+	return nil
+}
+
+func (state *remoteState) lockHeldMessage() string {
+	msg := fmt.Sprintf("deployment state is locked by another run (%v)", state.url("lock"))
+	resp, err := state.service.Objects.Get(state.bucket, state.object("lock")).Download()
+	if err != nil {
+		return msg
+	}
+	defer resp.Body.Close()
+	held := stateLock{}
+	if err = json.NewDecoder(resp.Body).Decode(&held); err == nil {
+		msg = fmt.Sprintf("deployment state is locked by %v running %v since %v (%v)",
+			held.Who, held.Operation, held.Created.Format(time.RFC3339), state.url("lock"))
+	}
+	return msg + "; if that run is gone, remove the lock with: gsutil rm " + state.url("lock")
+}
+
+// unlock removes the lock object, only if it is still the one we created.
+func (state *remoteState) unlock() error {
+	if state.lockGeneration == 0 {
+		return nil
+	}
+	err := state.service.Objects.Delete(state.bucket, state.object("lock")).
+		IfGenerationMatch(state.lockGeneration).Do()
+	if err != nil {
+		return fmt.Errorf("couldn't remove lock %v: %v", state.url("lock"), err)
+	}
+	state.lockGeneration = 0
+	return nil
+}
+
+// pull overwrites the local state files with the remote ones. It returns false when
+// there is no remote state yet.
+func (state *remoteState) pull() (bool, error) {
+	resp, err := state.service.Objects.Get(state.bucket, state.object(kftypes.KfConfigFile)).Download()
+	if err != nil {
+		if e, ok := err.(*googleapi.Error); ok && e.Code == 404 {
+			return false, nil
+		}
+		return false, fmt.Errorf("couldn't read %v: %v", state.url(kftypes.KfConfigFile), err)
+	}
+	resp.Body.Close()
+	objects, err := state.service.Objects.List(state.bucket).Prefix(state.object(stateConfigDir) + "/").Do()
+	if err != nil {
+		return false, fmt.Errorf("couldn't list %v: %v", state.url(stateConfigDir), err)
+	}
+	files := []string{kftypes.KfConfigFile}
+	for _, obj := range objects.Items {
+		rel, relErr := filepath.Rel(state.prefix, obj.Name)
+		if relErr != nil {
+			return false, relErr
+		}
+		files = append(files, rel)
+	}
+	if err = os.MkdirAll(filepath.Join(state.appDir, stateConfigDir), os.ModePerm); err != nil {
+		return false, err
+	}
+	for _, file := range files {
+		resp, err := state.service.Objects.Get(state.bucket, state.object(file)).Download()
+		if err != nil {
+			return false, fmt.Errorf("couldn't read %v: %v", state.url(file), err)
+		}
+		buf, err := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return false, fmt.Errorf("couldn't read %v: %v", state.url(file), err)
+		}
+		if err = utils.WriteFileAtomically(filepath.Join(state.appDir, file), buf, 0644); err != nil {
+			return false, err
+		}
+	}
+	log.Infof("pulled deployment state from %v", state.url(""))
+	return true, nil
+}
+
+// push uploads the local state files.
+func (state *remoteState) push() error {
+	files, err := state.stateFiles()
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		f, err := os.Open(filepath.Join(state.appDir, file))
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return err
+		}
+		_, err = state.service.Objects.Insert(state.bucket, &storage.Object{Name: state.object(file)}).
+			Media(f).Do()
+		f.Close()
+		if err != nil {
+			return fmt.Errorf("couldn't write %v: %v", state.url(file), err)
+		}
+	}
+	log.Infof("pushed deployment state to %v", state.url(""))
+	return nil
+}
+
+// lockState locks the app dir for operation, so concurrent kfctl runs of the app wait for each
+// other rather than interleave their writes, and takes the remote state lock too when
+// spec.stateBucket is set. The returned release pushes the state if the operation succeeded and
+// always unlocks.
+func (kfapp *coordinator) lockState(operation string) (func(error) error, error) {
+	unlockDir, err := utils.LockDir(kfapp.KfDef.Spec.AppDir)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't lock %v: %v", kfapp.KfDef.Spec.AppDir, err)
+	}
+	state, err := newRemoteState(kfapp.KfDef)
+	if err != nil {
+		unlockDir()
+		return nil, err
+	}
+	if state == nil {
+		return func(opErr error) error {
+			unlockDir()
+			return opErr
+		}, nil
+	}
+	if err = state.lock(operation); err != nil {
+		unlockDir()
+		return nil, err
+	}
+	// The state is only pulled once the lock is held, so a run doesn't act on the state another
+	// run changed while it waited.
+	if err = kfapp.pullState(state); err != nil {
+		if unlockErr := state.unlock(); unlockErr != nil {
+			log.Errorf("%v", unlockErr)
+		}
+		unlockDir()
+		return nil, fmt.Errorf("could not pull remote state for %v. Error: %v", kfapp.KfDef.Name, err)
+	}
+	return func(opErr error) error {
+		defer unlockDir()
+		if opErr == nil {
+			opErr = state.push()
+		}
+		if unlockErr := state.unlock(); unlockErr != nil {
+			if opErr == nil {
+				return unlockErr
+			}
+			log.Errorf("%v", unlockErr)
+		}
+		return opErr
+	}, nil
+}
+
+// pullState refreshes the local app dir from the remote state and reloads the app from it,
+// with the options of LoadKfApp set over it again.
+func (kfapp *coordinator) pullState(state *remoteState) error {
+	pulled, err := state.pull()
+	if err != nil || !pulled {
+		return err
+	}
+	kfdef, err := loadKfDef(kfapp.KfDef.Spec.AppDir, kfapp.options)
+	if err != nil {
+		return err
+	}
+	*kfapp.KfDef = *kfdef
+	if kfdef.Spec.Platform != "" {
+		platform, err := getPlatform(kfapp.KfDef)
+		if err != nil {
+			return fmt.Errorf("could not get platform %v Error %v", kfdef.Spec.Platform, err)
+		}
+		if platform != nil {
+			kfapp.Platforms[kfdef.Spec.Platform] = platform
+		}
+	}
+	return nil
+}
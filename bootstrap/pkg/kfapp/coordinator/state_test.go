@@ -0,0 +1,325 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package coordinator
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	kfapis "github.com/kubeflow/kubeflow/bootstrap/pkg/apis"
+	kftypes "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps"
+	storage "google.golang.org/api/storage/v1"
+)
+
+type fakeObject struct {
+	data       []byte
+	generation int64
+}
+
+// fakeGcs is a transport serving the GCS JSON API calls of remoteState for one bucket, with
+// the object generations and the ifGenerationMatch preconditions.
+type fakeGcs struct {
+	mu             sync.Mutex
+	bucket         string
+	objects        map[string]*fakeObject
+	lastGeneration int64
+}
+
+func newFakeGcs(bucket string) *fakeGcs {
+	return &fakeGcs{
+		bucket:  bucket,
+		objects: map[string]*fakeObject{},
+	}
+}
+
+func (f *fakeGcs) reply(req *http.Request, code int, body string) (*http.Response, error) {
+	return &http.Response{
+		StatusCode: code,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       ioutil.NopCloser(strings.NewReader(body)),
+		Request:    req,
+	}, nil
+}
+
+func (f *fakeGcs) replyError(req *http.Request, code int, message string) (*http.Response, error) {
+	return f.reply(req, code, fmt.Sprintf(`{"error": {"code": %v, "message": %q}}`, code, message))
+}
+
+func (f *fakeGcs) replyObject(req *http.Request, name string, obj *fakeObject) (*http.Response, error) {
+	buf, err := json.Marshal(&storage.Object{Bucket: f.bucket, Name: name, Generation: obj.generation})
+	if err != nil {
+		return nil, err
+	}
+	return f.reply(req, http.StatusOK, string(buf))
+}
+
+// precondition checks the ifGenerationMatch parameter against obj, which is nil when absent.
+func precondition(req *http.Request, obj *fakeObject) bool {
+	match := req.URL.Query().Get("ifGenerationMatch")
+	if match == "" {
+		return true
+	}
+	generation, err := strconv.ParseInt(match, 10, 64)
+	if err != nil {
+		return false
+	}
+	if obj == nil {
+		return generation == 0
+	}
+	return generation == obj.generation
+}
+
+func (f *fakeGcs) RoundTrip(req *http.Request) (*http.Response, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	objects := "/storage/v1/b/" + f.bucket + "/o"
+	switch {
+	case req.Method == "POST" && req.URL.Path == "/upload"+objects:
+		name, data, err := readUpload(req)
+		if err != nil {
+			return f.replyError(req, http.StatusBadRequest, err.Error())
+		}
+		if !precondition(req, f.objects[name]) {
+			return f.replyError(req, http.StatusPreconditionFailed, "conditionNotMet")
+		}
+		f.lastGeneration++
+		obj := &fakeObject{data: data, generation: f.lastGeneration}
+		f.objects[name] = obj
+		return f.replyObject(req, name, obj)
+	case req.Method == "GET" && req.URL.Path == objects:
+		prefix := req.URL.Query().Get("prefix")
+		list := &storage.Objects{}
+		for name, obj := range f.objects {
+			if strings.HasPrefix(name, prefix) {
+				list.Items = append(list.Items, &storage.Object{Name: name, Generation: obj.generation})
+			}
+		}
+		sort.Slice(list.Items, func(i, j int) bool { return list.Items[i].Name < list.Items[j].Name })
+		buf, err := json.Marshal(list)
+		if err != nil {
+			return nil, err
+		}
+		return f.reply(req, http.StatusOK, string(buf))
+	case strings.HasPrefix(req.URL.Path, objects+"/"):
+		name := strings.TrimPrefix(req.URL.Path, objects+"/")
+		obj := f.objects[name]
+		if obj == nil {
+			return f.replyError(req, http.StatusNotFound, "No such object: "+name)
+		}
+		switch req.Method {
+		case "GET":
+			if req.URL.Query().Get("alt") == "media" {
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       ioutil.NopCloser(bytes.NewReader(obj.data)),
+					Request:    req,
+				}, nil
+			}
+			return f.replyObject(req, name, obj)
+		case "DELETE":
+			if !precondition(req, obj) {
+				return f.replyError(req, http.StatusPreconditionFailed, "conditionNotMet")
+			}
+			delete(f.objects, name)
+			return &http.Response{
+				StatusCode: http.StatusNoContent,
+				Body:       ioutil.NopCloser(strings.NewReader("")),
+				Request:    req,
+			}, nil
+		}
+	}
+	return f.replyError(req, http.StatusBadRequest, fmt.Sprintf("unexpected %v %v", req.Method, req.URL))
+}
+
+// readUpload returns the object name and content of a multipart upload.
+func readUpload(req *http.Request) (string, []byte, error) {
+	_, params, err := mime.ParseMediaType(req.Header.Get("Content-Type"))
+	if err != nil {
+		return "", nil, err
+	}
+	reader := multipart.NewReader(req.Body, params["boundary"])
+	part, err := reader.NextPart()
+	if err != nil {
+		return "", nil, err
+	}
+	metadata := &storage.Object{}
+	if err = json.NewDecoder(part).Decode(metadata); err != nil {
+		return "", nil, err
+	}
+	part, err = reader.NextPart()
+	if err != nil {
+		return "", nil, err
+	}
+	data, err := ioutil.ReadAll(part)
+	return metadata.Name, data, err
+}
+
+func newTestRemoteState(t *testing.T, gcs *fakeGcs, appDir string) *remoteState {
+	service, err := storage.New(&http.Client{Transport: gcs})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &remoteState{
+		service: service,
+		bucket:  gcs.bucket,
+		prefix:  "kfctl/kf",
+		appDir:  appDir,
+	}
+}
+
+func TestRemoteStatePushPull(t *testing.T) {
+	dir, err := ioutil.TempDir("", "kfctl-state")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	pushDir, pullDir := filepath.Join(dir, "push"), filepath.Join(dir, "pull")
+	files := map[string]string{
+		kftypes.KfConfigFile: "kind: KfDef\n",
+		filepath.Join(stateConfigDir, "cluster-kubeflow.yaml"): "resources: []\n",
+		filepath.Join(stateConfigDir, "storage-kubeflow.yaml"): "resources: [disk]\n",
+	}
+	for file, content := range files {
+		if err = os.MkdirAll(filepath.Dir(filepath.Join(pushDir, file)), os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+		if err = ioutil.WriteFile(filepath.Join(pushDir, file), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	gcs := newFakeGcs("my-bucket")
+	pulled, err := newTestRemoteState(t, gcs, pullDir).pull()
+	if err != nil || pulled {
+		t.Fatalf("pull of a missing state = %v, %v; want false, nil", pulled, err)
+	}
+	if err = newTestRemoteState(t, gcs, pushDir).push(); err != nil {
+		t.Fatalf("push failed: %v", err)
+	}
+	for file := range files {
+		if _, ok := gcs.objects["kfctl/kf/"+filepath.ToSlash(file)]; !ok {
+			t.Errorf("%v wasn't pushed; objects %v", file, gcs.objects)
+		}
+	}
+	pulled, err = newTestRemoteState(t, gcs, pullDir).pull()
+	if err != nil || !pulled {
+		t.Fatalf("pull = %v, %v; want true, nil", pulled, err)
+	}
+	for file, content := range files {
+		buf, err := ioutil.ReadFile(filepath.Join(pullDir, file))
+		if err != nil || string(buf) != content {
+			t.Errorf("pulled %v = %q, %v; want %q", file, buf, err, content)
+		}
+	}
+}
+
+func TestRemoteStateLock(t *testing.T) {
+	gcs := newFakeGcs("my-bucket")
+	holder := newTestRemoteState(t, gcs, "")
+	if err := holder.lock("apply"); err != nil {
+		t.Fatalf("lock failed: %v", err)
+	}
+	if holder.lockGeneration == 0 {
+		t.Errorf("lock generation not kept")
+	}
+
+	// The lock is only created if absent.
+	other := newTestRemoteState(t, gcs, "")
+	err := other.lock("delete")
+	kfErr, ok := err.(*kfapis.KfError)
+	if !ok || kfErr.Code != int(kfapis.INVALID_ARGUMENT) || !strings.Contains(kfErr.Message, "running apply") ||
+		!strings.Contains(kfErr.Message, "gsutil rm gs://my-bucket/kfctl/kf/lock") {
+		t.Errorf("lock of a held state = %v; want the holder and how to remove the lock", err)
+	}
+	if other.lockGeneration != 0 {
+		t.Errorf("lock generation kept by a failed lock")
+	}
+	if err = other.unlock(); err != nil || gcs.objects["kfctl/kf/lock"] == nil {
+		t.Errorf("unlock without the lock = %v; want the lock kept", err)
+	}
+
+	// A lock removed and taken by another run isn't removed by the former holder.
+	delete(gcs.objects, "kfctl/kf/lock")
+	if err = other.lock("delete"); err != nil {
+		t.Fatalf("lock failed: %v", err)
+	}
+	if err = holder.unlock(); err == nil {
+		t.Errorf("unlock of a lock taken over succeeded")
+	}
+	if gcs.objects["kfctl/kf/lock"] == nil {
+		t.Errorf("lock taken over was removed")
+	}
+	if err = other.unlock(); err != nil {
+		t.Errorf("unlock failed: %v", err)
+	}
+	if len(gcs.objects) != 0 {
+		t.Errorf("objects left after unlock: %v", gcs.objects)
+	}
+}
+
+func TestPullStateReloads(t *testing.T) {
+	dir, err := ioutil.TempDir("", "kfctl-state")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	pushDir, appDir := filepath.Join(dir, "push"), filepath.Join(dir, "kf")
+	for _, d := range []string{pushDir, appDir} {
+		if err = os.MkdirAll(d, os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+	}
+	remote := "apiVersion: kfdef.apps.kubeflow.org/v1alpha1\nkind: KfDef\nmetadata:\n  name: kf\n" +
+		"spec:\n  appdir: " + appDir + "\n  project: remote-project\n  zone: us-west1-b\n"
+	if err = ioutil.WriteFile(filepath.Join(pushDir, kftypes.KfConfigFile), []byte(remote), 0644); err != nil {
+		t.Fatal(err)
+	}
+	gcs := newFakeGcs("my-bucket")
+	if err = newTestRemoteState(t, gcs, pushDir).push(); err != nil {
+		t.Fatalf("push failed: %v", err)
+	}
+
+	// The app was loaded before the lock was taken, from a stale app.yaml, with --project set.
+	kfdef, err := loadKfDef(appDir, map[string]interface{}{string(kftypes.PROJECT): "flag-project"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	kfdef.Spec.AppDir = appDir
+	kfdef.Spec.Zone = "us-east1-d"
+	kfapp := GetKfApp(kfdef).(*coordinator)
+	kfapp.options = map[string]interface{}{string(kftypes.PROJECT): "flag-project"}
+	if err = kfapp.pullState(newTestRemoteState(t, gcs, appDir)); err != nil {
+		t.Fatalf("pullState failed: %v", err)
+	}
+	if kfapp.KfDef.Spec.Zone != "us-west1-b" || kfapp.KfDef.Spec.Project != "flag-project" {
+		t.Errorf("pullState reloaded zone %v, project %v; want the remote zone and the flag's project",
+			kfapp.KfDef.Spec.Zone, kfapp.KfDef.Spec.Project)
+	}
+	if kfdef != kfapp.KfDef {
+		t.Errorf("pullState replaced the KfDef of the coordinator instead of reloading it")
+	}
+}
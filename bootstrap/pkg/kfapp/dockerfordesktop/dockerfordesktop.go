@@ -22,7 +22,7 @@ import (
 	"github.com/kubeflow/kubeflow/bootstrap/config"
 	kftypes "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps"
 	kfdefs "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps/kfdef/v1alpha1"
-	"io/ioutil"
+	"github.com/kubeflow/kubeflow/bootstrap/pkg/utils"
 	"os/user"
 	"path/filepath"
 	"strconv"
@@ -48,6 +48,11 @@ func (dockerfordesktop *DockerForDesktop) Apply(resources kftypes.ResourceEnum)
 	return nil
 }
 
+// Plan lists no actions, Apply has none.
+func (dockerfordesktop *DockerForDesktop) Plan(resources kftypes.ResourceEnum) ([]kftypes.Action, error) {
+	return []kftypes.Action{}, nil
+}
+
 func (dockerfordesktop *DockerForDesktop) Delete(resources kftypes.ResourceEnum) error {
 	return nil
 }
@@ -126,12 +131,14 @@ func (dockerfordesktop *DockerForDesktop) Init(resources kftypes.ResourceEnum) e
 }
 
 func (dockerfordesktop *DockerForDesktop) writeConfigFile() error {
-	buf, bufErr := yaml.Marshal(dockerfordesktop.KfDef)
+	kfdef := dockerfordesktop.KfDef
+	kfdef.Spec.ComponentParams = kfdef.Spec.SortedComponentParams()
+	buf, bufErr := yaml.Marshal(kfdef)
 	if bufErr != nil {
 		return bufErr
 	}
 	cfgFilePath := filepath.Join(dockerfordesktop.KfDef.Spec.AppDir, kftypes.KfConfigFile)
-	cfgFilePathErr := ioutil.WriteFile(cfgFilePath, buf, 0644)
+	cfgFilePathErr := utils.WriteFileAtomically(cfgFilePath, buf, 0644)
 	if cfgFilePathErr != nil {
 		return cfgFilePathErr
 	}
@@ -0,0 +1,70 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"fmt"
+	kfapis "github.com/kubeflow/kubeflow/bootstrap/pkg/apis"
+	kftypes "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps"
+	"strings"
+	"time"
+)
+
+// certProvisioningTimes is how long each certificate type may take to be provisioned once the
+// ingress has its address: cert-manager answers the HTTP-01 challenge of Let's Encrypt through
+// the load balancer, and Google provisions a managed certificate once the hostname resolves to
+// it. The certificates kfctl creates are there with the ingress.
+var certProvisioningTimes = map[string]time.Duration{
+	CERT_ACME:    20 * time.Minute,
+	CERT_MANAGED: 60 * time.Minute,
+}
+
+// Endpoint returns the URL of the app, its auth provider, how long its certificate may take and
+// the kubectl command showing its ingress. It only reads the spec, so it works before apply is
+// done and without credentials.
+func (gcp *Gcp) Endpoint() (*kftypes.Endpoint, error) {
+	if gcp.Spec.Hostname == "" {
+		return nil, &kfapis.KfError{
+			Code:    int(kfapis.INVALID_ARGUMENT),
+			Message: "spec.hostname isn't set; run kfctl generate first",
+		}
+	}
+	certType := gcp.certType()
+	return &kftypes.Endpoint{
+		URL:              fmt.Sprintf("https://%v/", gcp.Spec.Hostname),
+		AuthMode:         gcp.authProvider().Name(),
+		CertType:         certType,
+		CertProvisioning: certProvisioningTimes[certType],
+		StatusCommand:    gcp.ingressStatusCommand(),
+	}, nil
+}
+
+// ingressStatusCommand is the kubectl command showing the ingress of the app, in the context
+// WriteKubeconfig creates or the one of the user supplied kubeconfig.
+func (gcp *Gcp) ingressStatusCommand() string {
+	args := []string{"kubectl"}
+	if gcp.useKubeconfig() {
+		if gcp.Spec.Kubeconfig != "" {
+			args = append(args, "--kubeconfig="+gcp.Spec.Kubeconfig)
+		}
+		if gcp.Spec.KubeContext != "" {
+			args = append(args, "--context="+gcp.Spec.KubeContext)
+		}
+	} else {
+		args = append(args, "--context="+gcp.Name)
+	}
+	args = append(args, "-n", gcp.ingressNamespace(), "get", "ingress", INGRESS_NAME)
+	return strings.Join(args, " ")
+}
@@ -0,0 +1,66 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	kftypes "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps"
+	kfdefs "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps/kfdef/v1alpha1"
+)
+
+func TestEndpoint(t *testing.T) {
+	gcp := &Gcp{}
+	gcp.Name = "kf-app"
+	gcp.Namespace = "kubeflow"
+	if _, err := gcp.Endpoint(); err == nil {
+		t.Errorf("Endpoint succeeded without spec.hostname")
+	}
+
+	gcp.Spec.Hostname = "kf-app.endpoints.my-project.cloud.goog"
+	endpoint, err := gcp.Endpoint()
+	if err != nil {
+		t.Fatalf("Endpoint failed: %v", err)
+	}
+	expected := kftypes.Endpoint{
+		URL:              "https://kf-app.endpoints.my-project.cloud.goog/",
+		AuthMode:         kftypes.AUTH_IAP,
+		CertType:         CERT_ACME,
+		CertProvisioning: 20 * time.Minute,
+		StatusCommand:    "kubectl --context=kf-app -n kubeflow get ingress envoy-ingress",
+	}
+	if *endpoint != expected {
+		t.Errorf("Endpoint = %+v; want %+v", *endpoint, expected)
+	}
+
+	gcp.Spec.Certificate = &kfdefs.Certificate{Type: CERT_SELF_SIGNED}
+	gcp.Spec.KubeContext = "my-cluster"
+	endpoint, err = gcp.Endpoint()
+	if err != nil {
+		t.Fatalf("Endpoint failed: %v", err)
+	}
+	if endpoint.CertProvisioning != 0 {
+		t.Errorf("Endpoint with a self-signed certificate has provisioning time %v", endpoint.CertProvisioning)
+	}
+	if !strings.HasPrefix(endpoint.StatusCommand, "kubectl --context=my-cluster ") {
+		t.Errorf("Endpoint with spec.kubeContext has status command %v", endpoint.StatusCommand)
+	}
+	if summary := endpoint.Summary(); !strings.Contains(summary, endpoint.URL) ||
+		strings.Contains(summary, "may take") {
+		t.Errorf("Summary = %v", summary)
+	}
+}
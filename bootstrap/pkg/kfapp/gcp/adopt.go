@@ -0,0 +1,274 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"fmt"
+	"github.com/ghodss/yaml"
+	kfapis "github.com/kubeflow/kubeflow/bootstrap/pkg/apis"
+	kftypes "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps"
+	kfdefs "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps/kfdef/v1alpha1"
+	"github.com/kubeflow/kubeflow/bootstrap/pkg/utils"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientset "k8s.io/client-go/kubernetes"
+	"path"
+	"sort"
+	"strings"
+)
+
+const (
+	// KSONNET_COMPONENT_LABEL is set by ksonnet on the objects of a component to its name.
+	KSONNET_COMPONENT_LABEL = "ksonnet.io/component"
+	// IAP_ACCESSOR_ROLE is granted to the users allowed through IAP, see set-kubeflow-iap-account.
+	IAP_ACCESSOR_ROLE = "roles/iap.httpsResourceAccessor"
+	// STATIC_IP_ANNOTATION names the global address of the ingress.
+	STATIC_IP_ANNOTATION = "kubernetes.io/ingress.global-static-ip-name"
+)
+
+// Adopt fills in kfdef, named after the cluster of an existing deployment in spec.project and
+// spec.zone, from what's deployed, and writes the configs of its DM deployments and its IAM
+// bindings to gcp_config of spec.appDir, so a deployment created by the web deployer or by older
+// scripts can be managed by kfctl afterwards:
+// - the DM configs and templates are those of the deployments' manifests, and their labels give
+// spec.deploymentId and spec.combinedDeployment;
+// - iam_bindings.yaml has the project's bindings of the deployment's service accounts and of
+// the users allowed through IAP, spec.email being the only one of them when it's not set;
+// - the auth secrets give the auth provider, istio-system spec.useIstio, the ingress
+// spec.hostname and spec.ipName, and the ksonnet labels of the workloads spec.components.
+func Adopt(kfdef *kfdefs.KfDef) error {
+	baseClient, err := newBaseClient(kfdef)
+	if err != nil {
+		return err
+	}
+	ctx := utils.WithHTTPClient(context.Background(), baseClient)
+	creds, err := findCredentials(ctx)
+	if err != nil {
+		return err
+	}
+	_gcp := &Gcp{
+		KfDef: *kfdef.DeepCopy(),
+		isCLI: true,
+		store: NewAppDirStore(kfdef.Spec.AppDir),
+	}
+	_gcp.applyOptions([]Option{WithBaseClient(baseClient), WithTokenSource(creds.TokenSource)})
+	bundle := NewBundle()
+	if err = _gcp.adoptDeployments(ctx, bundle); err != nil {
+		return err
+	}
+	if err = _gcp.adoptIamBindings(ctx, bundle); err != nil {
+		return err
+	}
+	if err = _gcp.adoptCluster(ctx); err != nil {
+		return err
+	}
+	if err = _gcp.store.WriteBundle(bundle); err != nil {
+		return fmt.Errorf("cannot write the adopted configs in %v: %v", kfdef.Spec.AppDir, err)
+	}
+	kfdef.Spec = _gcp.Spec
+	return nil
+}
+
+// adoptDeployments puts the config and imports of the manifest of each DM deployment of the app
+// in bundle. The cluster deployment is required; the storage, network and gcfs ones are optional.
+func (gcp *Gcp) adoptDeployments(ctx context.Context, bundle *Bundle) error {
+	deploymentmanagerService, err := gcp.newDeploymentManagerClient()
+	if err != nil {
+		return err
+	}
+	project := gcp.Spec.Project
+	for _, c := range gcp.dmConfigs() {
+		d, err := deploymentmanagerService.GetDeployment(ctx, project, c.deployment)
+		if isNotFound(err) {
+			if c.target == COMPONENT_CLUSTER {
+				return &kfapis.KfError{
+					Code:    int(kfapis.INVALID_ARGUMENT),
+					Message: fmt.Sprintf("there's no deployment %v in %v", c.deployment, project),
+				}
+			}
+			continue
+		} else if err != nil {
+			return fmt.Errorf("couldn't get deployment %v/%v: %v", project, c.deployment, err)
+		}
+		labels := map[string]string{}
+		for _, l := range d.Labels {
+			labels[l.Key] = l.Value
+		}
+		file := c.file
+		if labels[LABEL_COMPONENT] == COMPONENT_COMBINED {
+			gcp.Spec.CombinedDeployment = true
+			file = COMBINED_FILE
+		}
+		if id := labels[LABEL_DEPLOYMENT_ID]; id != "" && gcp.Spec.DeploymentId == "" {
+			gcp.Spec.DeploymentId = id
+		}
+		if d.Manifest == "" {
+			return fmt.Errorf("deployment %v/%v has no manifest; wait for its operation to finish",
+				project, c.deployment)
+		}
+		manifest, err := deploymentmanagerService.GetManifest(ctx, project, c.deployment, path.Base(d.Manifest))
+		if err != nil {
+			return fmt.Errorf("couldn't get manifest of %v/%v: %v", project, c.deployment, err)
+		}
+		if manifest.Config == nil {
+			return fmt.Errorf("manifest of %v/%v has no config", project, c.deployment)
+		}
+		log.Infof("Adopting deployment %v as %v", c.deployment, file)
+		bundle.Put(path.Join(GCP_CONFIG, file), []byte(manifest.Config.Content))
+		for _, i := range manifest.Imports {
+			if path.IsAbs(i.Name) {
+				log.Warnf("Skipping import %v of %v: only imports relative to the config are adopted",
+					i.Name, c.deployment)
+				continue
+			}
+			bundle.Put(path.Join(GCP_CONFIG, i.Name), []byte(i.Content))
+		}
+		if gcp.Spec.CombinedDeployment {
+			// The combined deployment holds the others.
+			break
+		}
+	}
+	return nil
+}
+
+// adoptIamBindings puts the project's bindings of the service accounts of the app and of the
+// users allowed through IAP in bundle as iam_bindings.yaml, one entry per member.
+func (gcp *Gcp) adoptIamBindings(ctx context.Context, bundle *Bundle) error {
+	policy, err := utils.GetIamPolicy(gcp.Spec.Project, gcp.client)
+	if err != nil {
+		return fmt.Errorf("couldn't get the IAM policy of %v: %v", gcp.Spec.Project, err)
+	}
+	serviceAccounts := getDeploymentSAs(gcp.Name, gcp.Spec.Project)
+	roles := map[string][]string{}
+	users := []string{}
+	for _, binding := range policy.Bindings {
+		for _, member := range binding.Members {
+			if serviceAccounts.Contains(member) || binding.Role == IAP_ACCESSOR_ROLE {
+				roles[member] = append(roles[member], binding.Role)
+			}
+			if binding.Role == IAP_ACCESSOR_ROLE && strings.HasPrefix(member, "user:") {
+				users = append(users, strings.TrimPrefix(member, "user:"))
+			}
+		}
+	}
+	if gcp.Spec.Email == "" {
+		if len(users) == 1 {
+			gcp.Spec.Email = users[0]
+		} else {
+			log.Warnf("Couldn't tell the email of the deployment from the IAP users %v; set spec.email "+
+				"in %v", users, kftypes.KfConfigFile)
+		}
+	}
+	members := []string{}
+	for member := range roles {
+		members = append(members, member)
+	}
+	sort.Strings(members)
+	bindings := []interface{}{}
+	for _, member := range members {
+		sort.Strings(roles[member])
+		bindings = append(bindings, map[string]interface{}{
+			"members": []string{member},
+			"roles":   roles[member],
+		})
+	}
+	buf, err := yaml.Marshal(map[string]interface{}{
+		"bindings": bindings,
+	})
+	if err != nil {
+		return fmt.Errorf("couldn't marshal %v: %v", IAM_BINDINGS_FILE, err)
+	}
+	bundle.Put(path.Join(GCP_CONFIG, IAM_BINDINGS_FILE), buf)
+	return nil
+}
+
+// adoptCluster sets the spec kfctl reads from the cluster: the auth provider, Istio, the
+// hostname and address of the ingress, and the installed components.
+func (gcp *Gcp) adoptCluster(ctx context.Context) error {
+	k8sClient, err := gcp.getK8sClientset(ctx)
+	if err != nil {
+		return fmt.Errorf("Get K8s clientset error: %v", err)
+	}
+	if _, err = k8sClient.CoreV1().Namespaces().Get(gcp.istioNamespace(), metav1.GetOptions{}); err == nil {
+		gcp.Spec.UseIstio = true
+	} else if !k8serrors.IsNotFound(err) {
+		return err
+	}
+
+	if _, err = k8sClient.CoreV1().Secrets(gcp.namespace()).Get(BASIC_AUTH_SECRET, metav1.GetOptions{}); err == nil {
+		gcp.Spec.UseBasicAuth = true
+		gcp.Spec.Auth = &kfdefs.AuthConfig{Provider: kftypes.AUTH_BASIC_AUTH}
+	} else if !k8serrors.IsNotFound(err) {
+		return err
+	} else {
+		gcp.Spec.UseBasicAuth = false
+		gcp.Spec.Auth = nil
+	}
+
+	namespace := gcp.ingressNamespace()
+	ingress, err := k8sClient.ExtensionsV1beta1().Ingresses(namespace).Get(INGRESS_NAME, metav1.GetOptions{})
+	if err == nil {
+		if len(ingress.Spec.Rules) > 0 && ingress.Spec.Rules[0].Host != "" {
+			gcp.Spec.Hostname = ingress.Spec.Rules[0].Host
+		}
+		if ipName := ingress.Annotations[STATIC_IP_ANNOTATION]; ipName != "" {
+			gcp.Spec.IpName = ipName
+		}
+	} else if k8serrors.IsNotFound(err) {
+		log.Warnf("There's no ingress %v in %v; keeping the default hostname and address", INGRESS_NAME, namespace)
+	} else {
+		return err
+	}
+
+	components, err := installedComponents(k8sClient, gcp.namespace())
+	if err != nil {
+		return err
+	}
+	if len(components) > 0 {
+		gcp.Spec.Components = components
+	} else {
+		log.Warnf("Found no ksonnet components in %v; keeping the default components", gcp.namespace())
+	}
+	return nil
+}
+
+// installedComponents returns the ksonnet components of the deployments and stateful sets of
+// namespace, sorted.
+func installedComponents(k8sClient *clientset.Clientset, namespace string) ([]string, error) {
+	opts := metav1.ListOptions{LabelSelector: KSONNET_COMPONENT_LABEL}
+	found := map[string]bool{}
+	deployments, err := k8sClient.AppsV1().Deployments(namespace).List(opts)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't list the deployments of %v: %v", namespace, err)
+	}
+	for _, d := range deployments.Items {
+		found[d.Labels[KSONNET_COMPONENT_LABEL]] = true
+	}
+	statefulSets, err := k8sClient.AppsV1().StatefulSets(namespace).List(opts)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't list the stateful sets of %v: %v", namespace, err)
+	}
+	for _, s := range statefulSets.Items {
+		found[s.Labels[KSONNET_COMPONENT_LABEL]] = true
+	}
+	components := []string{}
+	for component := range found {
+		components = append(components, component)
+	}
+	sort.Strings(components)
+	return components, nil
+}
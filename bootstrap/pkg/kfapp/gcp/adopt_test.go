@@ -0,0 +1,229 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/ghodss/yaml"
+	kfapis "github.com/kubeflow/kubeflow/bootstrap/pkg/apis"
+	kftypes "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps"
+	"github.com/kubeflow/kubeflow/bootstrap/pkg/kfapp/gcp/fake"
+	"golang.org/x/net/context"
+	"google.golang.org/api/deploymentmanager/v2"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+// adoptedDeployment is a deployment of kf created by kfctl with config and imports.
+func adoptedDeployment(name string, component string, config string,
+	imports ...*deploymentmanager.ImportFile) *deploymentmanager.Deployment {
+	return &deploymentmanager.Deployment{
+		Name: name,
+		Labels: []*deploymentmanager.DeploymentLabelEntry{
+			{Key: LABEL_COMPONENT, Value: component},
+			{Key: LABEL_DEPLOYMENT_ID, Value: "abc123"},
+		},
+		Target: &deploymentmanager.TargetConfiguration{
+			Config:  &deploymentmanager.ConfigFile{Content: config},
+			Imports: imports,
+		},
+	}
+}
+
+func TestAdoptDeployments(t *testing.T) {
+	dm := fake.NewDeploymentManager("my-project",
+		adoptedDeployment("kf-storage", COMPONENT_STORAGE, "resources: [storage]\n"),
+		adoptedDeployment("kf", COMPONENT_CLUSTER, "resources: [cluster]\n",
+			&deploymentmanager.ImportFile{Name: "cluster.jinja", Content: "cluster template"},
+			&deploymentmanager.ImportFile{Name: "/home/someone/common.jinja", Content: "outside"}))
+	gcp := newDoctorGcp()
+	gcp.dmClient = dm
+	bundle := NewBundle()
+	if err := gcp.adoptDeployments(context.Background(), bundle); err != nil {
+		t.Fatalf("adoptDeployments failed: %v", err)
+	}
+	want := map[string]string{
+		STORAGE_FILE:    "resources: [storage]\n",
+		CONFIG_FILE:     "resources: [cluster]\n",
+		"cluster.jinja": "cluster template",
+	}
+	for file, content := range want {
+		if got, ok := bundle.Get(path.Join(GCP_CONFIG, file)); !ok || string(got) != content {
+			t.Errorf("%v = %q; want %q", file, got, content)
+		}
+	}
+	// The network and gcfs deployments are optional, imports outside gcp_config aren't adopted.
+	for _, file := range []string{NETWORK_FILE, GCFS_FILE, "home/someone/common.jinja", "/home/someone/common.jinja"} {
+		if _, ok := bundle.Get(path.Join(GCP_CONFIG, file)); ok {
+			t.Errorf("%v adopted", file)
+		}
+	}
+	if gcp.Spec.DeploymentId != "abc123" || gcp.Spec.CombinedDeployment {
+		t.Errorf("adopted deploymentId %q and combinedDeployment %v", gcp.Spec.DeploymentId, gcp.Spec.CombinedDeployment)
+	}
+
+	// A combined deployment holds all the configs.
+	gcp = newDoctorGcp()
+	gcp.dmClient = fake.NewDeploymentManager("my-project",
+		adoptedDeployment("kf", COMPONENT_COMBINED, "resources: [storage, cluster]\n"))
+	bundle = NewBundle()
+	if err := gcp.adoptDeployments(context.Background(), bundle); err != nil {
+		t.Fatalf("adoptDeployments of a combined deployment failed: %v", err)
+	}
+	if _, ok := bundle.Get(path.Join(GCP_CONFIG, COMBINED_FILE)); !ok || !gcp.Spec.CombinedDeployment {
+		t.Errorf("combined deployment adopted with combinedDeployment %v", gcp.Spec.CombinedDeployment)
+	}
+	if _, ok := bundle.Get(path.Join(GCP_CONFIG, CONFIG_FILE)); ok {
+		t.Errorf("combined deployment adopted as %v too", CONFIG_FILE)
+	}
+
+	// The cluster deployment is required.
+	gcp = newDoctorGcp()
+	gcp.dmClient = fake.NewDeploymentManager("my-project",
+		adoptedDeployment("kf-storage", COMPONENT_STORAGE, "resources: [storage]\n"))
+	err := gcp.adoptDeployments(context.Background(), NewBundle())
+	if kfErr, ok := err.(*kfapis.KfError); !ok || kfErr.Code != int(kfapis.INVALID_ARGUMENT) {
+		t.Errorf("adoptDeployments without a cluster = %v; want an invalid argument error", err)
+	}
+}
+
+func TestAdoptIamBindings(t *testing.T) {
+	gcp := newDoctorGcp()
+	gcp.client = &http.Client{Transport: fakeGcpApis{
+		"/v1/projects/my-project:getIamPolicy": `{"bindings": [
+			{"role": "roles/owner", "members": ["user:owner@example.com"]},
+			{"role": "roles/storage.admin", "members": ["serviceAccount:kf-user@my-project.iam.gserviceaccount.com",
+				"serviceAccount:other-user@my-project.iam.gserviceaccount.com"]},
+			{"role": "roles/source.admin", "members": ["serviceAccount:kf-admin@my-project.iam.gserviceaccount.com"]},
+			{"role": "roles/bigquery.admin", "members": ["serviceAccount:kf-user@my-project.iam.gserviceaccount.com"]},
+			{"role": "roles/iap.httpsResourceAccessor", "members": ["user:alice@example.com"]}]}`,
+	}}
+	bundle := NewBundle()
+	if err := gcp.adoptIamBindings(context.Background(), bundle); err != nil {
+		t.Fatalf("adoptIamBindings failed: %v", err)
+	}
+	buf, ok := bundle.Get(path.Join(GCP_CONFIG, IAM_BINDINGS_FILE))
+	if !ok {
+		t.Fatalf("%v wasn't adopted", IAM_BINDINGS_FILE)
+	}
+	type binding struct {
+		Members []string `json:"members"`
+		Roles   []string `json:"roles"`
+	}
+	adopted := struct {
+		Bindings []binding `json:"bindings"`
+	}{}
+	if err := yaml.Unmarshal(buf, &adopted); err != nil {
+		t.Fatal(err)
+	}
+	want := []binding{
+		{[]string{"serviceAccount:kf-admin@my-project.iam.gserviceaccount.com"}, []string{"roles/source.admin"}},
+		{[]string{"serviceAccount:kf-user@my-project.iam.gserviceaccount.com"},
+			[]string{"roles/bigquery.admin", "roles/storage.admin"}},
+		{[]string{"user:alice@example.com"}, []string{IAP_ACCESSOR_ROLE}},
+	}
+	if !reflect.DeepEqual(adopted.Bindings, want) {
+		t.Errorf("adopted bindings %+v; want %+v", adopted.Bindings, want)
+	}
+	if gcp.Spec.Email != "alice@example.com" {
+		t.Errorf("adopted email %q; want the only IAP user", gcp.Spec.Email)
+	}
+
+	// A set email isn't replaced.
+	gcp.Spec.Email = "bob@example.com"
+	if err := gcp.adoptIamBindings(context.Background(), NewBundle()); err != nil || gcp.Spec.Email != "bob@example.com" {
+		t.Errorf("adoptIamBindings with an email = %v, email %q", err, gcp.Spec.Email)
+	}
+}
+
+// writeTestKubeconfig writes a kubeconfig in dir with the context test of server.
+func writeTestKubeconfig(t *testing.T, dir string, server string) string {
+	file := filepath.Join(dir, "kubeconfig")
+	config := clientcmdapi.NewConfig()
+	config.Clusters["test"] = &clientcmdapi.Cluster{Server: server}
+	config.AuthInfos["test"] = &clientcmdapi.AuthInfo{}
+	config.Contexts["test"] = &clientcmdapi.Context{Cluster: "test", AuthInfo: "test"}
+	config.CurrentContext = "test"
+	if err := clientcmd.WriteToFile(*config, file); err != nil {
+		t.Fatal(err)
+	}
+	return file
+}
+
+func TestAdoptCluster(t *testing.T) {
+	apis := fakeKubeApis{
+		"/api/v1/namespaces/istio-system":                    `{"metadata": {"name": "istio-system"}}`,
+		"/api/v1/namespaces/kubeflow/secrets/kubeflow-login": `{"metadata": {"name": "kubeflow-login"}}`,
+		"/apis/extensions/v1beta1/namespaces/kubeflow/ingresses/envoy-ingress": `{"metadata": {"name": "envoy-ingress",
+			"annotations": {"kubernetes.io/ingress.global-static-ip-name": "kf-ip"}},
+			"spec": {"rules": [{"host": "kf.example.com"}]}}`,
+		"/apis/apps/v1/namespaces/kubeflow/deployments": `{"items": [
+			{"metadata": {"name": "jupyter-web-app", "labels": {"ksonnet.io/component": "jupyter-web-app"}}},
+			{"metadata": {"name": "ambassador", "labels": {"ksonnet.io/component": "ambassador"}}},
+			{"metadata": {"name": "ambassador-auth", "labels": {"ksonnet.io/component": "ambassador"}}}]}`,
+		"/apis/apps/v1/namespaces/kubeflow/statefulsets": `{"items": [
+			{"metadata": {"name": "metacontroller", "labels": {"ksonnet.io/component": "metacontroller"}}}]}`,
+	}
+	server := httptest.NewServer(apis)
+	defer server.Close()
+	dir, err := ioutil.TempDir("", "kfctl-adopt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	gcp := newDoctorGcp()
+	gcp.Spec.Kubeconfig = writeTestKubeconfig(t, dir, server.URL)
+	gcp.Spec.Components = []string{"default"}
+	if err = gcp.adoptCluster(context.Background()); err != nil {
+		t.Fatalf("adoptCluster failed: %v", err)
+	}
+	if !gcp.Spec.UseIstio || !gcp.Spec.UseBasicAuth || kftypes.AuthProvider(&gcp.Spec) != kftypes.AUTH_BASIC_AUTH {
+		t.Errorf("adopted useIstio %v, useBasicAuth %v and auth %v; want istio and basic auth",
+			gcp.Spec.UseIstio, gcp.Spec.UseBasicAuth, kftypes.AuthProvider(&gcp.Spec))
+	}
+	if gcp.Spec.Hostname != "kf.example.com" || gcp.Spec.IpName != "kf-ip" {
+		t.Errorf("adopted hostname %q and ipName %q", gcp.Spec.Hostname, gcp.Spec.IpName)
+	}
+	if want := []string{"ambassador", "jupyter-web-app", "metacontroller"}; !reflect.DeepEqual(gcp.Spec.Components, want) {
+		t.Errorf("adopted components %v; want %v", gcp.Spec.Components, want)
+	}
+
+	// Without the basic auth secret nor components the app is IAP's, with the default components.
+	delete(apis, "/api/v1/namespaces/kubeflow/secrets/kubeflow-login")
+	apis["/apis/apps/v1/namespaces/kubeflow/deployments"] = `{"items": []}`
+	apis["/apis/apps/v1/namespaces/kubeflow/statefulsets"] = `{"items": []}`
+	gcp = newDoctorGcp()
+	gcp.Spec.Kubeconfig = writeTestKubeconfig(t, dir, server.URL)
+	gcp.Spec.UseBasicAuth = true
+	gcp.Spec.Components = []string{"default"}
+	if err = gcp.adoptCluster(context.Background()); err != nil {
+		t.Fatalf("adoptCluster failed: %v", err)
+	}
+	if gcp.Spec.UseBasicAuth || kftypes.AuthProvider(&gcp.Spec) != kftypes.AUTH_IAP {
+		t.Errorf("adopted auth %v; want IAP", kftypes.AuthProvider(&gcp.Spec))
+	}
+	if !reflect.DeepEqual(gcp.Spec.Components, []string{"default"}) {
+		t.Errorf("adopted components %v; want the defaults kept", gcp.Spec.Components)
+	}
+}
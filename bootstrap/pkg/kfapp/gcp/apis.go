@@ -0,0 +1,99 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"fmt"
+	"github.com/cenkalti/backoff"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+	"google.golang.org/api/serviceusage/v1"
+)
+
+// maxBatchEnable is the most services serviceusage enables in a single BatchEnable call.
+const maxBatchEnable = 20
+
+// defaultApis are the APIs Kubeflow needs, enabled by gcpInitProject.
+var defaultApis = []string{
+	"deploymentmanager.googleapis.com",
+	"servicemanagement.googleapis.com",
+	"container.googleapis.com",
+	"cloudresourcemanager.googleapis.com",
+	"endpoints.googleapis.com",
+	"file.googleapis.com",
+	"ml.googleapis.com",
+	"iam.googleapis.com",
+	"sqladmin.googleapis.com",
+}
+
+// projectApis are the APIs gcpInitProject enables: defaultApis, the Stackdriver and TPU ones when
+// enabled, the perimeterApis with spec.servicePerimeter, the Binary Authorization API with
+// spec.binaryAuthorization and spec.extraApis, without spec.skipApis.
+func (gcp *Gcp) projectApis() []string {
+	apis := append([]string{}, defaultApis...)
+	if gcp.Spec.EnableStackdriver {
+		apis = append(apis, stackdriverApis...)
+	}
+	if gcp.Spec.EnableTpu {
+		apis = append(apis, tpuApi)
+	}
+	if gcp.Spec.ServicePerimeter != nil {
+		apis = append(apis, perimeterApis...)
+	}
+	if gcp.Spec.BinaryAuthorization != nil {
+		apis = append(apis, binauthzApi)
+	}
+	apis = append(apis, gcp.Spec.ExtraApis...)
+	skipped := map[string]bool{}
+	for _, api := range gcp.Spec.SkipApis {
+		skipped[api] = true
+	}
+	enabled := []string{}
+	for _, api := range apis {
+		if skipped[api] {
+			log.Infof("Skipping API service %v", api)
+			continue
+		}
+		skipped[api] = true
+		enabled = append(enabled, api)
+	}
+	return enabled
+}
+
+// waitServiceUsage waits for op to be done. Already enabled services give an op which is done.
+func (gcp *Gcp) waitServiceUsage(ctx context.Context, serviceusageService ServiceUsageClient,
+	op *serviceusage.Operation, logPrefix string) error {
+	return gcp.retry(func() error {
+		if !op.Done {
+			current, err := serviceusageService.GetOperation(ctx, op.Name)
+			if isVpcScViolation(err) {
+				return backoff.Permanent(fmt.Errorf("%v error: %v", logPrefix, gcp.perimeterError(err)))
+			}
+			if err != nil {
+				return fmt.Errorf("%v error: %v", logPrefix, err)
+			}
+			op = current
+		}
+		if !op.Done {
+			log.Infof("%v isn't done yet (op = %v)", logPrefix, op.Name)
+			return fmt.Errorf("%v isn't done (op = %v)", logPrefix, op.Name)
+		}
+		if op.Error != nil {
+			return backoff.Permanent(fmt.Errorf("%v error(%v): %v", logPrefix, op.Error.Code, op.Error.Message))
+		}
+		log.Infof("%v is finished", logPrefix)
+		return nil
+	}, backoff.NewExponentialBackOff())
+}
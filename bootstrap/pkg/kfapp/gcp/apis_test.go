@@ -0,0 +1,106 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	kfdefs "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps/kfdef/v1alpha1"
+	"github.com/kubeflow/kubeflow/bootstrap/pkg/kfapp/gcp/fake"
+	"golang.org/x/net/context"
+)
+
+func TestProjectApis(t *testing.T) {
+	gcp := &Gcp{}
+	if apis := gcp.projectApis(); !reflect.DeepEqual(apis, defaultApis) {
+		t.Errorf("projectApis got %v; want %v", apis, defaultApis)
+	}
+
+	gcp.Spec.EnableStackdriver = true
+	gcp.Spec.EnableTpu = true
+	gcp.Spec.ServicePerimeter = &kfdefs.ServicePerimeterConfig{}
+	gcp.Spec.BinaryAuthorization = &kfdefs.BinaryAuthorizationConfig{}
+	gcp.Spec.ExtraApis = []string{"bigquery.googleapis.com", "container.googleapis.com"}
+	gcp.Spec.SkipApis = []string{"sqladmin.googleapis.com", tpuApi}
+	apis := gcp.projectApis()
+
+	seen := map[string]bool{}
+	for _, api := range apis {
+		if seen[api] {
+			t.Errorf("projectApis has %v twice", api)
+		}
+		seen[api] = true
+	}
+	expected := append([]string{"bigquery.googleapis.com", binauthzApi}, stackdriverApis...)
+	expected = append(expected, perimeterApis...)
+	for _, api := range expected {
+		if !seen[api] {
+			t.Errorf("projectApis is missing %v", api)
+		}
+	}
+	for _, api := range gcp.Spec.SkipApis {
+		if seen[api] {
+			t.Errorf("projectApis has the skipped %v", api)
+		}
+	}
+}
+
+func TestEnableApisBatches(t *testing.T) {
+	serviceUsage := fake.NewServiceUsage()
+	serviceUsage.Pending = 2
+	gcp := &Gcp{clock: &sleepingClock{}}
+	apis := []string{}
+	for i := 0; i < 2*maxBatchEnable+5; i++ {
+		apis = append(apis, fmt.Sprintf("api%v.googleapis.com", i))
+	}
+	if err := gcp.enableApis(context.Background(), serviceUsage, "my-project", apis); err != nil {
+		t.Fatalf("enableApis: %v", err)
+	}
+	sizes := []int{}
+	for _, batch := range serviceUsage.Batches {
+		sizes = append(sizes, len(batch))
+	}
+	if expected := []int{maxBatchEnable, maxBatchEnable, 5}; !reflect.DeepEqual(sizes, expected) {
+		t.Errorf("enableApis made batches of %v APIs; want %v", sizes, expected)
+	}
+	for _, api := range apis {
+		if !serviceUsage.Enabled("projects/my-project", api) {
+			t.Errorf("%v wasn't enabled", api)
+		}
+	}
+	if gcp.clock.(*sleepingClock).slept == 0 {
+		t.Errorf("enableApis didn't wait for the pending operations")
+	}
+}
+
+func TestWaitServiceUsageError(t *testing.T) {
+	serviceUsage := fake.NewServiceUsage()
+	serviceUsage.Pending = 1
+	serviceUsage.Failures["ml.googleapis.com"] = "billing isn't enabled"
+	gcp := &Gcp{clock: &sleepingClock{}}
+	err := gcp.enableApis(context.Background(), serviceUsage, "my-project",
+		[]string{"iam.googleapis.com", "ml.googleapis.com"})
+	if err == nil || !strings.Contains(err.Error(), "billing isn't enabled") {
+		t.Errorf("enableApis got error %v; want the error of the operation", err)
+	}
+	// A failed operation isn't retried.
+	if slept := gcp.clock.(*sleepingClock).slept; slept > time.Minute {
+		t.Errorf("enableApis retried a failed operation for %v", slept)
+	}
+}
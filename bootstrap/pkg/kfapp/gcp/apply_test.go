@@ -0,0 +1,143 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	configtypes "github.com/kubeflow/kubeflow/bootstrap/config"
+	kftypes "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps"
+	kfdefs "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps/kfdef/v1alpha1"
+)
+
+type fakeClock struct{}
+
+func (fakeClock) Now() time.Time {
+	return time.Date(2019, 4, 1, 0, 0, 0, 0, time.UTC)
+}
+
+func (fakeClock) Sleep(time.Duration) {}
+
+// fakeDeploymentManager answers the DM calls of updateDeployment: there's no deployment yet, and
+// its insert is done at once. The project has no org policies, and the static IP exists.
+type fakeDeploymentManager struct {
+	mu       sync.Mutex
+	inserted []string
+}
+
+func (f *fakeDeploymentManager) RoundTrip(req *http.Request) (*http.Response, error) {
+	body := ""
+	status := http.StatusOK
+	switch {
+	case req.Method == "GET" && strings.Contains(req.URL.Path, "/global/deployments/"):
+		status = http.StatusNotFound
+		body = `{"error": {"code": 404, "message": "not found"}}`
+	case req.Method == "POST" && strings.HasSuffix(req.URL.Path, "/global/deployments"):
+		f.mu.Lock()
+		f.inserted = append(f.inserted, req.URL.Path)
+		f.mu.Unlock()
+		body = `{"name": "op-insert", "status": "DONE"}`
+	case req.Method == "GET" && strings.Contains(req.URL.Path, "/global/operations/"):
+		body = `{"name": "op-insert", "status": "DONE"}`
+	case req.Method == "GET" && strings.Contains(req.URL.Path, "/global/addresses/"):
+		// The static IP created by the cluster deployment.
+		body = fmt.Sprintf(`{"name": %q, "addressType": "EXTERNAL", "status": "RESERVED"}`, path.Base(req.URL.Path))
+	case req.Method == "POST" && strings.HasSuffix(req.URL.Path, ":getEffectiveOrgPolicy"):
+		body = `{}`
+	default:
+		status = http.StatusBadRequest
+		body = fmt.Sprintf(`{"error": {"code": 400, "message": "unexpected %v %v"}}`, req.Method, req.URL.Path)
+	}
+	return &http.Response{
+		StatusCode: status,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       ioutil.NopCloser(strings.NewReader(body)),
+		Request:    req,
+	}, nil
+}
+
+// Run with -race: the apps of the deploy server are generated and applied concurrently from the
+// same request, and must neither share their state nor write to the app dir.
+func TestApplyConcurrently(t *testing.T) {
+	appDir, err := ioutil.TempDir("", "kfctl-apply")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(appDir)
+
+	kfdef := &kfdefs.KfDef{}
+	kfdef.Name = "kf"
+	kfdef.Spec.AppDir = appDir
+	kfdef.Spec.Platform = kftypes.GCP
+	kfdef.Spec.Project = "my-project"
+	kfdef.Spec.Zone = "us-east1-d"
+	kfdef.Spec.Email = "user@example.com"
+	kfdef.Spec.UseEmbeddedAssets = true
+	kfdef.Spec.Components = []string{"spartakus"}
+	kfdef.Spec.ComponentParams = configtypes.Parameters{}
+	kfdef.Spec.Targets = []string{COMPONENT_STORAGE, COMPONENT_CLUSTER}
+
+	dm := &fakeDeploymentManager{}
+	apps := make([]*Gcp, 5)
+	for i := range apps {
+		apps[i], err = NewGcp(kfdef, Auth{OAuthClientId: "id", OAuthClientSecret: "secret"},
+			WithClient(&http.Client{Transport: dm}), WithClock(fakeClock{}))
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var wg sync.WaitGroup
+	for _, app := range apps {
+		wg.Add(1)
+		go func(app *Gcp) {
+			defer wg.Done()
+			if err := app.Generate(kftypes.PLATFORM); err != nil {
+				t.Error(err)
+				return
+			}
+			if err := app.Apply(kftypes.PLATFORM); err != nil {
+				t.Error(err)
+			}
+		}(app)
+	}
+	wg.Wait()
+
+	if got, want := len(dm.inserted), 2*len(apps); got != want {
+		t.Errorf("got %v deployment inserts, want %v", got, want)
+	}
+	for _, app := range apps {
+		for _, file := range []string{kftypes.KfConfigFile, path.Join(GCP_CONFIG, STORAGE_FILE),
+			path.Join(GCP_CONFIG, CONFIG_FILE)} {
+			if _, ok := app.Generated().Get(file); !ok {
+				t.Errorf("%v wasn't generated", file)
+			}
+		}
+	}
+	if files, err := ioutil.ReadDir(appDir); err != nil || len(files) > 0 {
+		t.Errorf("the app dir has %v files, want none (%v)", len(files), err)
+	}
+	if kfdef.Spec.DeploymentId != "" || len(kfdef.Spec.ComponentParams) > 0 {
+		t.Errorf("the KfDef the apps were created with was modified: %+v", kfdef.Spec)
+	}
+}
@@ -0,0 +1,125 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"fmt"
+	kftypes "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps"
+	kfdefs "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps/kfdef/v1alpha1"
+	"github.com/kubeflow/kubeflow/bootstrap/pkg/kfapp/assets"
+	"github.com/kubeflow/kubeflow/bootstrap/pkg/utils"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+	"k8s.io/api/core/v1"
+	"k8s.io/client-go/rest"
+	"path/filepath"
+)
+
+// assetLoader is where the DM templates and manifests are read from: the loader of
+// WithAssetLoader, else the assets built into kfctl with spec.useEmbeddedAssets, else the
+// checkout spec.repo is in.
+func (gcp *Gcp) assetLoader() assets.Loader {
+	if gcp.assets != nil {
+		return gcp.assets
+	}
+	if gcp.Spec.UseEmbeddedAssets {
+		return assets.Embedded
+	}
+	return assets.NewDirLoader(filepath.Dir(gcp.Spec.Repo))
+}
+
+// readAsset returns the file name of the kubeflow repo, e.g. a DM template.
+func (gcp *Gcp) readAsset(name string) ([]byte, error) {
+	return gcp.assetLoader().Read(name)
+}
+
+// withAssetFile runs f with a file holding the manifest asset name, for the functions reading a
+// path.
+func (gcp *Gcp) withAssetFile(name string, f func(file string) error) error {
+	file, cleanup, err := assets.LocalFile(gcp.manifestLoader(), name)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	return f(file)
+}
+
+// createResourceFromAsset applies the objects of the manifest asset name to the cluster, waiting
+// for them to be ready, and records them in the inventory, which kfctl delete k8s deletes. Its
+// progress is logged and kept in the Progressing condition of the status.
+func (gcp *Gcp) createResourceFromAsset(ctx context.Context, client *rest.Config, name string) error {
+	data, err := gcp.manifestLoader().Read(name)
+	if err != nil {
+		return fmt.Errorf("couldn't read %v: %v", name, err)
+	}
+	return gcp.createResources(ctx, client, name, data)
+}
+
+// createResources applies the objects of data, the manifest name, like createResourceFromAsset.
+func (gcp *Gcp) createResources(ctx context.Context, client *rest.Config, name string, data []byte) error {
+	refs, err := utils.ResourceRefs(data)
+	if err != nil {
+		return fmt.Errorf("couldn't read %v: %v", name, err)
+	}
+	// Recorded first, so the objects created before a failure are deleted too.
+	if err = gcp.recordInventory(client, refs); err != nil {
+		return err
+	}
+	gcp.setProgress(v1.ConditionTrue, "Applying", fmt.Sprintf("%v: 0/%v objects applied", name, len(refs)))
+	err = utils.ApplyResources(ctx, client, data, utils.ApplyOptions{
+		Progress: func(progress utils.ApplyProgress) {
+			if progress.Err != nil {
+				log.Warnf("Couldn't apply %v: %v", progress.Ref, progress.Err)
+			} else {
+				log.Infof("Applied %v (%v/%v)", progress.Ref, progress.Done, progress.Total)
+			}
+			gcp.setProgress(v1.ConditionTrue, "Applying", fmt.Sprintf("%v: %v/%v objects applied", name,
+				progress.Done, progress.Total))
+		},
+	})
+	if err != nil {
+		gcp.setProgress(v1.ConditionFalse, "ApplyFailed", fmt.Sprintf("%v: %v", name, err))
+		return err
+	}
+	gcp.setProgress(v1.ConditionFalse, "Applied", fmt.Sprintf("%v: %v objects applied", name, len(refs)))
+	return nil
+}
+
+// setProgress sets the Progressing condition of the status, which the deploy app may read while
+// apply runs.
+func (gcp *Gcp) setProgress(status v1.ConditionStatus, reason string, message string) {
+	gcp.specLock.Lock()
+	defer gcp.specLock.Unlock()
+	gcp.Status.SetCondition(kfdefs.KfProgressing, status, reason, message)
+}
+
+// GenerateConfigs returns the files Generate makes for kfdef, app.yaml and the DM configs,
+// reading the templates with loader. It neither calls GCP nor touches the disk, so a stateless
+// service, e.g. the bootstrap server on Cloud Run, can return them in its response; the
+// credentials NewGcp takes are only needed to apply them.
+func GenerateConfigs(kfdef *kfdefs.KfDef, loader assets.Loader, opts ...Option) (*Bundle, error) {
+	_gcp := &Gcp{
+		KfDef: *kfdef.DeepCopy(),
+		isCLI: false,
+	}
+	_gcp.applyOptions(append([]Option{WithAssetLoader(loader)}, opts...))
+	if err := _gcp.checkAuthProvider(); err != nil {
+		return nil, err
+	}
+	if err := _gcp.Generate(kftypes.PLATFORM); err != nil {
+		return nil, err
+	}
+	return _gcp.Generated(), nil
+}
@@ -0,0 +1,72 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"path"
+	"sync"
+	"testing"
+
+	configtypes "github.com/kubeflow/kubeflow/bootstrap/config"
+	kftypes "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps"
+	kfdefs "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps/kfdef/v1alpha1"
+	"github.com/kubeflow/kubeflow/bootstrap/pkg/kfapp/assets"
+)
+
+// recordingLoader reads the embedded assets, recording their names.
+type recordingLoader struct {
+	mu    sync.Mutex
+	names []string
+}
+
+func (l *recordingLoader) Read(name string) ([]byte, error) {
+	l.mu.Lock()
+	l.names = append(l.names, name)
+	l.mu.Unlock()
+	return assets.Embedded.Read(name)
+}
+
+func TestGenerateConfigs(t *testing.T) {
+	kfdef := &kfdefs.KfDef{}
+	kfdef.Name = "kf"
+	kfdef.Spec.Platform = kftypes.GCP
+	kfdef.Spec.Project = "my-project"
+	kfdef.Spec.Zone = "us-east1-d"
+	kfdef.Spec.Email = "user@example.com"
+	kfdef.Spec.Repo = "/nonexistent/kubeflow"
+	kfdef.Spec.ComponentParams = configtypes.Parameters{}
+
+	loader := &recordingLoader{}
+	bundle, err := GenerateConfigs(kfdef, loader, WithClock(fakeClock{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, file := range []string{kftypes.KfConfigFile, path.Join(GCP_CONFIG, CONFIG_FILE),
+		path.Join(GCP_CONFIG, STORAGE_FILE), path.Join(GCP_CONFIG, "cluster.jinja")} {
+		if _, ok := bundle.Get(file); !ok {
+			t.Errorf("%v wasn't generated", file)
+		}
+	}
+	read := map[string]bool{}
+	for _, name := range loader.names {
+		read[name] = true
+	}
+	if !read[path.Join(DM_CONFIGS_DIR, CONFIG_FILE)] || !read[path.Join(DM_CONFIGS_DIR, "cluster.jinja")] {
+		t.Errorf("the templates weren't read with the loader; read %v", loader.names)
+	}
+	if kfdef.Spec.DeploymentId != "" {
+		t.Errorf("the KfDef the configs were generated for was modified: %+v", kfdef.Spec)
+	}
+}
@@ -0,0 +1,317 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"cloud.google.com/go/logging"
+	"encoding/json"
+	"fmt"
+	"github.com/kubeflow/kubeflow/bootstrap/pkg/utils"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+	"google.golang.org/api/cloudresourcemanager/v1"
+	"google.golang.org/api/deploymentmanager/v2"
+	"google.golang.org/api/option"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	// AUDIT_LOG_FILE is the append-only audit log of the app in its app dir, a JSON AuditEntry
+	// per line.
+	AUDIT_LOG_FILE = "audit.jsonl"
+	// AUDIT_LOG_NAME is the Cloud Logging log of the project the entries are also written to with
+	// spec.cloudAuditLog.
+	AUDIT_LOG_NAME = "kfctl-audit"
+)
+
+// Actions of the audit log.
+const (
+	AUDIT_DM_INSERT        = "deploymentmanager.insert"
+	AUDIT_DM_UPDATE        = "deploymentmanager.update"
+	AUDIT_DM_DELETE        = "deploymentmanager.delete"
+	AUDIT_DM_STOP          = "deploymentmanager.stop"
+	AUDIT_IAM_SET_POLICY   = "iam.setIamPolicy"
+	AUDIT_IAP_SET_POLICY   = "iap.setIamPolicy"
+	AUDIT_SECRET_CREATE    = "secret.create"
+	AUDIT_SECRET_UPDATE    = "secret.update"
+	AUDIT_SECRET_DELETE    = "secret.delete"
+	AUDIT_RBAC_CREATE      = "rbac.create"
+	AUDIT_RBAC_UPDATE      = "rbac.update"
+	AUDIT_RBAC_DELETE      = "rbac.delete"
+	AUDIT_NAMESPACE_DELETE = "namespace.delete"
+	AUDIT_PERIMETER_UPDATE = "accesscontextmanager.servicePerimeters.patch"
+	AUDIT_BINAUTHZ_UPDATE  = "binaryauthorization.updatePolicy"
+)
+
+// AuditEntry records a change kfctl made to the project or the cluster. It never holds the
+// content of a secret, only the names of its keys.
+type AuditEntry struct {
+	Time time.Time `json:"time"`
+	// Caller is the account making the change: the impersonated service account, else the
+	// email of the app.
+	Caller   string `json:"caller"`
+	Project  string `json:"project"`
+	App      string `json:"app"`
+	Action   string `json:"action"`
+	Resource string `json:"resource"`
+	// Details are those of the action, e.g. the bindings added and removed by a SetIamPolicy.
+	Details map[string]interface{} `json:"details,omitempty"`
+	// Error is set when the change failed.
+	Error string `json:"error,omitempty"`
+}
+
+// AuditSink is where the audit entries of the app are written.
+type AuditSink interface {
+	Write(entry *AuditEntry) error
+}
+
+// fileAuditSink appends the entries to a file as JSON lines.
+type fileAuditSink struct {
+	mu   sync.Mutex
+	path string
+}
+
+func (s *fileAuditSink) Write(entry *AuditEntry) error {
+	buf, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	f, err := os.OpenFile(s.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err = f.Write(append(buf, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// cloudAuditSink writes the entries to the AUDIT_LOG_NAME log of the project. They're written
+// synchronously so an entry isn't lost when kfctl fails right after.
+type cloudAuditSink struct {
+	logger *logging.Logger
+}
+
+func (s *cloudAuditSink) Write(entry *AuditEntry) error {
+	severity := logging.Notice
+	if entry.Error != "" {
+		severity = logging.Error
+	}
+	return s.logger.LogSync(context.Background(), logging.Entry{
+		Timestamp: entry.Time,
+		Severity:  severity,
+		Payload:   entry,
+		Labels: map[string]string{
+			"app":    entry.App,
+			"action": entry.Action,
+		},
+	})
+}
+
+// newCloudAuditSink returns the sink of the AUDIT_LOG_NAME log of the project of the app.
+func (gcp *Gcp) newCloudAuditSink(ctx context.Context) (AuditSink, error) {
+	opts, err := utils.GrpcClientOptions(gcp.Spec.CaBundle)
+	if err != nil {
+		return nil, err
+	}
+	client, err := logging.NewClient(ctx, gcp.Spec.Project,
+		append([]option.ClientOption{option.WithTokenSource(gcp.tokenSource)}, opts...)...)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't create the Cloud Logging client of %v: %v", gcp.Spec.Project, err)
+	}
+	return &cloudAuditSink{logger: client.Logger(AUDIT_LOG_NAME)}, nil
+}
+
+// auditSinks returns the sinks of the audit log: the file in the app dir for kfctl, the Cloud
+// Logging log with spec.cloudAuditLog and those of WithAuditSink.
+func (gcp *Gcp) auditSinks() []AuditSink {
+	gcp.auditOnce.Do(func() {
+		if gcp.isCLI && gcp.Spec.AppDir != "" {
+			gcp.auditLog = append(gcp.auditLog, &fileAuditSink{path: filepath.Join(gcp.Spec.AppDir, AUDIT_LOG_FILE)})
+		}
+		if gcp.Spec.CloudAuditLog {
+			sink, err := gcp.newCloudAuditSink(context.Background())
+			if err != nil {
+				log.Warnf("The audit entries won't be written to Cloud Logging: %v", err)
+			} else {
+				gcp.auditLog = append(gcp.auditLog, sink)
+			}
+		}
+	})
+	return gcp.auditLog
+}
+
+// auditCaller is the account the changes of the app are made with.
+func (gcp *Gcp) auditCaller() string {
+	if serviceAccount := os.Getenv(IMPERSONATE_ENV); serviceAccount != "" {
+		return serviceAccount
+	}
+	return gcp.Spec.Email
+}
+
+// audit records action on resource, and err when it failed. Failing to write an entry only logs
+// a warning; the change itself was made.
+func (gcp *Gcp) audit(action string, resource string, details map[string]interface{}, err error) {
+	sinks := gcp.auditSinks()
+	if len(sinks) == 0 {
+		return
+	}
+	entry := &AuditEntry{
+		Time:     gcp.clock.Now().UTC(),
+		Caller:   gcp.auditCaller(),
+		Project:  gcp.Spec.Project,
+		App:      gcp.Name,
+		Action:   action,
+		Resource: resource,
+		Details:  details,
+	}
+	if err != nil {
+		entry.Error = err.Error()
+	}
+	for _, sink := range sinks {
+		if err := sink.Write(entry); err != nil {
+			log.Warnf("Failed to write the audit entry of %v %v: %v", action, resource, err)
+		}
+	}
+}
+
+// auditSecret records the creation or update of secret name in namespace with the names of
+// the keys of data.
+func (gcp *Gcp) auditSecret(action string, namespace string, name string, data map[string][]byte, err error) {
+	keys := []string{}
+	for key := range data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	gcp.audit(action, "secrets/"+namespace+"/"+name, map[string]interface{}{"keys": keys}, err)
+}
+
+// policyMembers returns the bindings of policy as "role member" strings.
+func policyMembers(policy *cloudresourcemanager.Policy) map[string]bool {
+	members := map[string]bool{}
+	for _, binding := range policy.Bindings {
+		for _, member := range binding.Members {
+			members[binding.Role+" "+member] = true
+		}
+	}
+	return members
+}
+
+// diffMembers returns the "role member" bindings of after that aren't in before, and those of
+// before that aren't in after, sorted.
+func diffMembers(before map[string]bool, after map[string]bool) ([]string, []string) {
+	added, removed := []string{}, []string{}
+	for m := range after {
+		if !before[m] {
+			added = append(added, m)
+		}
+	}
+	for m := range before {
+		if !after[m] {
+			removed = append(removed, m)
+		}
+	}
+	sort.Strings(added)
+	sort.Strings(removed)
+	return added, removed
+}
+
+// updateIamPolicy is utils.UpdateIamPolicy recording the bindings modify added and removed.
+func (gcp *Gcp) updateIamPolicy(project string, client *http.Client,
+	modify func(*cloudresourcemanager.Policy)) error {
+	var added, removed []string
+	err := utils.UpdateIamPolicy(project, client, func(policy *cloudresourcemanager.Policy) {
+		// Overwritten on every attempt as the policy is re-read after an etag conflict.
+		before := policyMembers(policy)
+		modify(policy)
+		added, removed = diffMembers(before, policyMembers(policy))
+	})
+	gcp.audit(AUDIT_IAM_SET_POLICY, "projects/"+project, map[string]interface{}{
+		"added":   added,
+		"removed": removed,
+	}, err)
+	return gcp.perimeterError(err)
+}
+
+// auditedDeploymentManager records the deployments inserted, updated, deleted and stopped through the
+// DeploymentManagerClient of the app.
+type auditedDeploymentManager struct {
+	DeploymentManagerClient
+	gcp *Gcp
+}
+
+func (a *auditedDeploymentManager) InsertDeployment(ctx context.Context, project string,
+	deployment *deploymentmanager.Deployment, createPolicy string) (*deploymentmanager.Operation, error) {
+	op, err := a.DeploymentManagerClient.InsertDeployment(ctx, project, deployment, createPolicy)
+	details := deploymentDetails(deployment, op)
+	if createPolicy != "" {
+		details["createPolicy"] = createPolicy
+	}
+	a.gcp.audit(AUDIT_DM_INSERT, "projects/"+project+"/deployments/"+deployment.Name, details, err)
+	return op, err
+}
+
+func (a *auditedDeploymentManager) UpdateDeployment(ctx context.Context, project string, name string,
+	deployment *deploymentmanager.Deployment, deletePolicy string) (*deploymentmanager.Operation, error) {
+	op, err := a.DeploymentManagerClient.UpdateDeployment(ctx, project, name, deployment, deletePolicy)
+	details := deploymentDetails(deployment, op)
+	if deletePolicy != "" {
+		details["deletePolicy"] = deletePolicy
+	}
+	a.gcp.audit(AUDIT_DM_UPDATE, "projects/"+project+"/deployments/"+name, details, err)
+	return op, err
+}
+
+func (a *auditedDeploymentManager) DeleteDeployment(ctx context.Context, project string,
+	name string, deletePolicy string) (*deploymentmanager.Operation, error) {
+	op, err := a.DeploymentManagerClient.DeleteDeployment(ctx, project, name, deletePolicy)
+	details := deploymentDetails(nil, op)
+	if deletePolicy != "" {
+		details["deletePolicy"] = deletePolicy
+	}
+	a.gcp.audit(AUDIT_DM_DELETE, "projects/"+project+"/deployments/"+name, details, err)
+	return op, err
+}
+
+func (a *auditedDeploymentManager) StopDeployment(ctx context.Context, project string,
+	name string, fingerprint string) (*deploymentmanager.Operation, error) {
+	op, err := a.DeploymentManagerClient.StopDeployment(ctx, project, name, fingerprint)
+	a.gcp.audit(AUDIT_DM_STOP, "projects/"+project+"/deployments/"+name, deploymentDetails(nil, op), err)
+	return op, err
+}
+
+// deploymentDetails are the imports of the config of deployment and the operation changing it.
+func deploymentDetails(deployment *deploymentmanager.Deployment, op *deploymentmanager.Operation) map[string]interface{} {
+	details := map[string]interface{}{}
+	if deployment != nil && deployment.Target != nil {
+		imports := []string{}
+		for _, i := range deployment.Target.Imports {
+			imports = append(imports, i.Name)
+		}
+		details["imports"] = imports
+	}
+	if op != nil {
+		details["operation"] = op.Name
+	}
+	return details
+}
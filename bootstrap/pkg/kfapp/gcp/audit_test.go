@@ -0,0 +1,98 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestAuditLog(t *testing.T) {
+	dir, err := ioutil.TempDir("", "audit")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	gcp := &Gcp{isCLI: true, clock: fakeClock{}}
+	gcp.Name = "app"
+	gcp.Spec.AppDir = dir
+	gcp.Spec.Project = "project"
+	gcp.Spec.Email = "user@example.com"
+	gcp.auditSecret(AUDIT_SECRET_CREATE, "kubeflow", "oauth", map[string][]byte{
+		"client_secret": []byte("secret"),
+		"client_id":     []byte("id"),
+	}, nil)
+	gcp.audit(AUDIT_RBAC_DELETE, "clusterrolebindings/default-admin", nil, fmt.Errorf("forbidden"))
+
+	f, err := os.Open(filepath.Join(dir, AUDIT_LOG_FILE))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	entries := []AuditEntry{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if strings.Contains(scanner.Text(), `"secret"`) {
+			t.Errorf("the content of the secret is logged: %v", scanner.Text())
+		}
+		entry := AuditEntry{}
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			t.Fatal(err)
+		}
+		entries = append(entries, entry)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("got %v entries, want 2", len(entries))
+	}
+	secret := entries[0]
+	if secret.Action != AUDIT_SECRET_CREATE || secret.Resource != "secrets/kubeflow/oauth" ||
+		secret.Caller != "user@example.com" || secret.Project != "project" || secret.App != "app" ||
+		!secret.Time.Equal(fakeClock{}.Now()) {
+		t.Errorf("unexpected entry %+v", secret)
+	}
+	if keys := secret.Details["keys"]; !reflect.DeepEqual(keys, []interface{}{"client_id", "client_secret"}) {
+		t.Errorf("got keys %v", keys)
+	}
+	if entries[1].Error != "forbidden" {
+		t.Errorf("got error %q, want forbidden", entries[1].Error)
+	}
+}
+
+func TestDiffMembers(t *testing.T) {
+	before := map[string]bool{
+		"roles/owner user:a@example.com":  true,
+		"roles/viewer user:b@example.com": true,
+	}
+	after := map[string]bool{
+		"roles/owner user:a@example.com":  true,
+		"roles/editor user:b@example.com": true,
+		"roles/editor user:c@example.com": true,
+	}
+	added, removed := diffMembers(before, after)
+	if !reflect.DeepEqual(added, []string{"roles/editor user:b@example.com", "roles/editor user:c@example.com"}) {
+		t.Errorf("got added %v", added)
+	}
+	if !reflect.DeepEqual(removed, []string{"roles/viewer user:b@example.com"}) {
+		t.Errorf("got removed %v", removed)
+	}
+}
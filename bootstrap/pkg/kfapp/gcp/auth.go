@@ -0,0 +1,138 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	kfapis "github.com/kubeflow/kubeflow/bootstrap/pkg/apis"
+	kftypes "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps"
+	"golang.org/x/crypto/bcrypt"
+	"golang.org/x/crypto/ssh/terminal"
+	"io"
+	"io/ioutil"
+	"os"
+)
+
+// Auth holds the credentials Apply stores in the cluster. kfctl loads them with loadAuth;
+// callers embedding the gcp kfapp pass them to NewGcp.
+type Auth struct {
+	// Username and Password are required for basic-auth.
+	// Password is zeroed once it has been hashed.
+	Username string
+	Password []byte
+	// OAuthClientId and OAuthClientSecret identify the OAuth client used by iap.
+	OAuthClientId     string
+	OAuthClientSecret string
+	// ConnectorSecret is the client secret of spec.auth.oidc for oidc, or the password of
+	// spec.auth.ldap.bindDn for ldap.
+	ConnectorSecret string
+}
+
+// PasswordFromStdin is the spec.passwordFile value reading the password from stdin.
+const PasswordFromStdin = "-"
+
+// loadAuth loads the credentials of the auth provider for kfctl apply. The basic auth password
+// is read from spec.passwordFile, "-" meaning stdin, then from KUBEFLOW_PASSWORD; when neither
+// is set and stdin is a terminal the user is prompted for it without echo.
+func (gcp *Gcp) loadAuth() (Auth, error) {
+	return gcp.authProvider().LoadAuth(gcp)
+}
+
+// loadPassword reads the basic auth password of username the way loadAuth does.
+func (gcp *Gcp) loadPassword(username string) ([]byte, error) {
+	switch {
+	case gcp.Spec.PasswordFile == PasswordFromStdin:
+		return readPassword(os.Stdin, username)
+	case gcp.Spec.PasswordFile != "":
+		return readPasswordFile(gcp.Spec.PasswordFile)
+	case os.Getenv(kftypes.KUBEFLOW_PASSWORD) != "":
+		return []byte(os.Getenv(kftypes.KUBEFLOW_PASSWORD)), nil
+	case terminal.IsTerminal(int(os.Stdin.Fd())):
+		return readPassword(os.Stdin, username)
+	default:
+		return nil, fmt.Errorf("basic auth needs --%v or ENV %v for the password of %v",
+			kftypes.PASSWORD_FILE, kftypes.KUBEFLOW_PASSWORD, username)
+	}
+}
+
+// readPassword reads one line from in, prompting without echo when in is a terminal.
+func readPassword(in *os.File, username string) ([]byte, error) {
+	fd := int(in.Fd())
+	if terminal.IsTerminal(fd) {
+		fmt.Fprintf(os.Stderr, "Password for %v: ", username)
+		password, err := terminal.ReadPassword(fd)
+		fmt.Fprintln(os.Stderr)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't read password: %v", err)
+		}
+		return password, nil
+	}
+	line, err := bufio.NewReader(in).ReadBytes('\n')
+	if err != nil && err != io.EOF {
+		return nil, fmt.Errorf("couldn't read password from stdin: %v", err)
+	}
+	return bytes.TrimRight(line, "\r\n"), nil
+}
+
+// readPasswordFile returns the first line of passwordFile.
+func readPasswordFile(passwordFile string) ([]byte, error) {
+	buf, err := ioutil.ReadFile(passwordFile)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't read password file %v: %v", passwordFile, err)
+	}
+	if i := bytes.IndexAny(buf, "\r\n"); i >= 0 {
+		zero(buf[i:])
+		buf = buf[:i]
+	}
+	return buf, nil
+}
+
+func zero(buf []byte) {
+	for i := range buf {
+		buf[i] = 0
+	}
+}
+
+// setAuth checks auth against the auth provider of the app and keeps what Apply needs;
+// the basic auth password is only kept as a bcrypt hash, costing spec.bcryptCost,
+// and auth.Password is zeroed.
+func (gcp *Gcp) setAuth(auth Auth) error {
+	defer zero(auth.Password)
+	return gcp.authProvider().SetAuth(gcp, auth)
+}
+
+// hashPassword returns the base64 encoded bcrypt hash of password, costing spec.bcryptCost,
+// as stored in the basic auth login secret.
+func (gcp *Gcp) hashPassword(password []byte) (string, error) {
+	cost := gcp.Spec.BcryptCost
+	if cost == 0 {
+		cost = bcrypt.DefaultCost
+	}
+	if cost < bcrypt.MinCost || cost > bcrypt.MaxCost {
+		return "", &kfapis.KfError{
+			Code: int(kfapis.INVALID_ARGUMENT),
+			Message: fmt.Sprintf("bcryptCost %v must be between %v and %v",
+				cost, bcrypt.MinCost, bcrypt.MaxCost),
+		}
+	}
+	passwordHash, err := bcrypt.GenerateFromPassword(password, cost)
+	if err != nil {
+		return "", fmt.Errorf("Error when hashing password: %v", err)
+	}
+	return base64.StdEncoding.EncodeToString(passwordHash), nil
+}
@@ -0,0 +1,173 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"encoding/base64"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	kfapis "github.com/kubeflow/kubeflow/bootstrap/pkg/apis"
+	kftypes "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// withStdin replaces os.Stdin with a pipe holding input until the returned func is called.
+func withStdin(t *testing.T, input string) func() {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = w.WriteString(input); err != nil {
+		t.Fatal(err)
+	}
+	w.Close()
+	stdin := os.Stdin
+	os.Stdin = r
+	return func() {
+		os.Stdin = stdin
+		r.Close()
+	}
+}
+
+func TestLoadPassword(t *testing.T) {
+	dir, err := ioutil.TempDir("", "kfctl-password")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	passwordFile := filepath.Join(dir, "password")
+	if err = ioutil.WriteFile(passwordFile, []byte("from-file\nsecond line\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		name         string
+		passwordFile string
+		env          string
+		stdin        string
+		want         string
+	}{
+		{"file", passwordFile, "from-env", "from-stdin\n", "from-file"},
+		{"stdin", PasswordFromStdin, "from-env", "from-stdin\r\n", "from-stdin"},
+		{"stdin without newline", PasswordFromStdin, "", "from-stdin", "from-stdin"},
+		{"env", "", "from-env", "from-stdin\n", "from-env"},
+		{"none", "", "", "from-stdin\n", ""},
+		{"missing file", filepath.Join(dir, "missing"), "from-env", "", ""},
+	}
+	defer os.Unsetenv(kftypes.KUBEFLOW_PASSWORD)
+	for _, c := range cases {
+		os.Setenv(kftypes.KUBEFLOW_PASSWORD, c.env)
+		restore := withStdin(t, c.stdin)
+		gcp := &Gcp{}
+		gcp.Spec.PasswordFile = c.passwordFile
+		password, err := gcp.loadPassword("admin")
+		restore()
+		if c.want == "" {
+			if err == nil {
+				t.Errorf("%v: loadPassword = %q; want an error", c.name, password)
+			}
+			continue
+		}
+		if err != nil || string(password) != c.want {
+			t.Errorf("%v: loadPassword = %q, %v; want %q", c.name, password, err, c.want)
+		}
+	}
+}
+
+func TestHashPassword(t *testing.T) {
+	cases := []struct {
+		cost     int
+		wantCost int
+		valid    bool
+	}{
+		{0, bcrypt.DefaultCost, true},
+		{bcrypt.MinCost, bcrypt.MinCost, true},
+		{bcrypt.MinCost - 1, 0, false},
+		{bcrypt.MaxCost + 1, 0, false},
+	}
+	for _, c := range cases {
+		gcp := &Gcp{}
+		gcp.Spec.BcryptCost = c.cost
+		encoded, err := gcp.hashPassword([]byte("password"))
+		if !c.valid {
+			kfErr, ok := err.(*kfapis.KfError)
+			if !ok || kfErr.Code != int(kfapis.INVALID_ARGUMENT) {
+				t.Errorf("hashPassword with cost %v = %v; want an invalid argument error", c.cost, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("hashPassword with cost %v failed: %v", c.cost, err)
+		}
+		hash, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			t.Fatalf("hash isn't base64 encoded: %v", err)
+		}
+		if err = bcrypt.CompareHashAndPassword(hash, []byte("password")); err != nil {
+			t.Errorf("hash doesn't match the password: %v", err)
+		}
+		if cost, err := bcrypt.Cost(hash); err != nil || cost != c.wantCost {
+			t.Errorf("hash costs %v, %v; want %v", cost, err, c.wantCost)
+		}
+	}
+}
+
+func TestSetAuthZeroesPassword(t *testing.T) {
+	gcp := &Gcp{}
+	gcp.Spec.UseBasicAuth = true
+	gcp.Spec.BcryptCost = bcrypt.MinCost
+	password := []byte("password")
+	if err := gcp.setAuth(Auth{Username: "admin", Password: password}); err != nil {
+		t.Fatalf("setAuth failed: %v", err)
+	}
+	if string(password) != string(make([]byte, len("password"))) {
+		t.Errorf("password not zeroed: %q", password)
+	}
+	if gcp.username != "admin" || gcp.encodedPassword == "" {
+		t.Errorf("setAuth kept username %q and password hash %q", gcp.username, gcp.encodedPassword)
+	}
+
+	// The password is zeroed when it's rejected too.
+	password = []byte("password")
+	gcp.Spec.BcryptCost = bcrypt.MaxCost + 1
+	if err := gcp.setAuth(Auth{Username: "admin", Password: password}); err == nil {
+		t.Errorf("setAuth with an invalid cost succeeded")
+	}
+	if string(password) != string(make([]byte, len("password"))) {
+		t.Errorf("rejected password not zeroed: %q", password)
+	}
+}
+
+func TestReadPasswordFileZeroesRest(t *testing.T) {
+	dir, err := ioutil.TempDir("", "kfctl-password")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	passwordFile := filepath.Join(dir, "password")
+	if err = ioutil.WriteFile(passwordFile, []byte("password\nrest"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	password, err := readPasswordFile(passwordFile)
+	if err != nil || string(password) != "password" {
+		t.Fatalf("readPasswordFile = %q, %v", password, err)
+	}
+	if rest := password[:cap(password)][len(password):]; string(rest) != string(make([]byte, len(rest))) {
+		t.Errorf("rest of the file not zeroed: %q", rest)
+	}
+}
@@ -0,0 +1,410 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"github.com/ghodss/yaml"
+	kfapis "github.com/kubeflow/kubeflow/bootstrap/pkg/apis"
+	kftypes "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientset "k8s.io/client-go/kubernetes"
+	"os"
+)
+
+const (
+	// OIDC_CLIENT_SECRET is read by kfctl apply for the client of spec.auth.oidc.
+	OIDC_CLIENT_SECRET = "OIDC_CLIENT_SECRET"
+	// LDAP_BIND_PASSWORD is read by kfctl apply for spec.auth.ldap.bindDn.
+	LDAP_BIND_PASSWORD = "LDAP_BIND_PASSWORD"
+	// DEX_CONFIG_SECRET holds the config.yaml of Dex, which has the secret of its connector.
+	DEX_CONFIG_SECRET = "dex"
+	// DEX_DEPLOYMENT reads DEX_CONFIG_SECRET when it starts.
+	DEX_DEPLOYMENT = "dex"
+	// AUTHSERVICE_SECRET holds the client_id and client_secret the auth service signs in to Dex with.
+	AUTHSERVICE_SECRET = "oidc-authservice"
+	// AUTHSERVICE_CLIENT_ID is the static client of the auth service in the Dex config.
+	AUTHSERVICE_CLIENT_ID = "kubeflow-oidc-authservice"
+)
+
+// AuthProvider signs the users in to the app, selected by spec.auth.provider. Generate sets its
+// component params, Apply creates its secrets and config in the cluster from the credentials
+// kfctl reads with LoadAuth or the caller of NewGcp passes in.
+type AuthProvider interface {
+	// Name is the spec.auth.provider of the provider.
+	Name() string
+	// IngressComponent is the component creating the GCLB ingress of the app.
+	IngressComponent() string
+	// LoadAuth reads the credentials of the provider for kfctl apply.
+	LoadAuth(gcp *Gcp) (Auth, error)
+	// SetAuth checks auth and keeps what CreateSecrets needs.
+	SetAuth(gcp *Gcp, auth Auth) error
+	// SetParams sets the component params of the provider.
+	SetParams(gcp *Gcp) error
+	// CreateSecrets creates the secrets and config of the provider.
+	CreateSecrets(ctx context.Context, gcp *Gcp, client *clientset.Clientset) error
+	// Secrets are the secrets CreateSecrets creates, for Plan.
+	Secrets(gcp *Gcp) []secretRef
+}
+
+// secretRef is a secret of the cluster by namespace and name.
+type secretRef struct {
+	namespace string
+	name      string
+}
+
+// authProviders are the providers of spec.auth.provider.
+var authProviders = map[string]AuthProvider{
+	kftypes.AUTH_IAP:        iapProvider{},
+	kftypes.AUTH_BASIC_AUTH: basicAuthProvider{},
+	kftypes.AUTH_OIDC:       dexProvider{connector: kftypes.AUTH_OIDC},
+	kftypes.AUTH_LDAP:       dexProvider{connector: kftypes.AUTH_LDAP},
+}
+
+// checkAuthProvider checks the auth provider of the app when the Gcp is created, so
+// authProvider can't fail.
+func (gcp *Gcp) checkAuthProvider() error {
+	name := kftypes.AuthProvider(&gcp.Spec)
+	if _, ok := authProviders[name]; !ok {
+		return &kfapis.KfError{
+			Code:    int(kfapis.INVALID_ARGUMENT),
+			Message: fmt.Sprintf("unknown auth provider %v", name),
+		}
+	}
+	if gcp.Spec.UseBasicAuth && name != kftypes.AUTH_BASIC_AUTH {
+		return &kfapis.KfError{
+			Code:    int(kfapis.INVALID_ARGUMENT),
+			Message: fmt.Sprintf("useBasicAuth conflicts with auth provider %v", name),
+		}
+	}
+	return nil
+}
+
+func (gcp *Gcp) authProvider() AuthProvider {
+	return authProviders[kftypes.AuthProvider(&gcp.Spec)]
+}
+
+// iapProvider puts the ingress behind Cloud IAP, with the OAuth client kfctl reads with
+// loadOAuthClient.
+type iapProvider struct{}
+
+func (iapProvider) Name() string {
+	return kftypes.AUTH_IAP
+}
+
+func (iapProvider) IngressComponent() string {
+	return "iap-ingress"
+}
+
+func (iapProvider) LoadAuth(gcp *Gcp) (Auth, error) {
+	return gcp.loadOAuthClient()
+}
+
+func (iapProvider) SetAuth(gcp *Gcp, auth Auth) error {
+	if auth.OAuthClientId == "" || auth.OAuthClientSecret == "" {
+		return fmt.Errorf("OAuth client id and secret are required for IAP")
+	}
+	gcp.oauthId = auth.OAuthClientId
+	gcp.oauthSecret = auth.OAuthClientSecret
+	return nil
+}
+
+func (iapProvider) SetParams(gcp *Gcp) error {
+	gcp.setComponentParam("iap-ingress", "ipName", gcp.Spec.IpName, true)
+	gcp.setComponentParam("iap-ingress", "hostname", gcp.Spec.Hostname, true)
+	if gcp.Spec.UseIstio {
+		gcp.setComponentParam("iap-ingress", "useIstio", "true", false)
+	}
+	return nil
+}
+
+func (iapProvider) CreateSecrets(ctx context.Context, gcp *Gcp, client *clientset.Clientset) error {
+	if err := gcp.createIapSecret(ctx, client); err != nil {
+		return fmt.Errorf("cannot create IAP auth secret: %v", err)
+	}
+	return nil
+}
+
+func (iapProvider) Secrets(gcp *Gcp) []secretRef {
+	return []secretRef{{gcp.oauthSecretNamespace(), KUBEFLOW_OAUTH}}
+}
+
+// basicAuthProvider signs the users in with the passwords in the basic auth login secret,
+// managed with kfctl user.
+type basicAuthProvider struct{}
+
+func (basicAuthProvider) Name() string {
+	return kftypes.AUTH_BASIC_AUTH
+}
+
+func (basicAuthProvider) IngressComponent() string {
+	return "basic-auth-ingress"
+}
+
+func (basicAuthProvider) LoadAuth(gcp *Gcp) (Auth, error) {
+	auth := Auth{
+		Username: os.Getenv(kftypes.KUBEFLOW_USERNAME),
+	}
+	if auth.Username == "" {
+		return auth, fmt.Errorf("gcp apply needs ENV %v set when using basic auth", kftypes.KUBEFLOW_USERNAME)
+	}
+	var err error
+	auth.Password, err = gcp.loadPassword(auth.Username)
+	return auth, err
+}
+
+func (basicAuthProvider) SetAuth(gcp *Gcp, auth Auth) error {
+	if auth.Username == "" || len(auth.Password) == 0 {
+		return fmt.Errorf("username and password are required when using basic auth")
+	}
+	encodedPassword, err := gcp.hashPassword(auth.Password)
+	if err != nil {
+		return err
+	}
+	gcp.username = auth.Username
+	gcp.encodedPassword = encodedPassword
+	return nil
+}
+
+func (basicAuthProvider) SetParams(gcp *Gcp) error {
+	gcp.setComponentParam("basic-auth-ingress", "ipName", gcp.Spec.IpName, true)
+	gcp.setComponentParam("basic-auth-ingress", "hostname", gcp.Spec.Hostname, true)
+	return nil
+}
+
+func (basicAuthProvider) CreateSecrets(ctx context.Context, gcp *Gcp, client *clientset.Clientset) error {
+	if err := gcp.createBasicAuthSecret(client); err != nil {
+		return fmt.Errorf("cannot create basic auth login secret: %v", err)
+	}
+	return nil
+}
+
+func (basicAuthProvider) Secrets(gcp *Gcp) []secretRef {
+	return []secretRef{{gcp.namespace(), BASIC_AUTH_SECRET}}
+}
+
+// dexProvider signs the users in with Dex, served under https://<hostname>/dex, through the
+// oidc connector federating spec.auth.oidc or the ldap connector checking spec.auth.ldap.
+// The auth service of the dex component checks the session of each request to ambassador.
+type dexProvider struct {
+	connector string
+}
+
+func (p dexProvider) Name() string {
+	return p.connector
+}
+
+func (dexProvider) IngressComponent() string {
+	return "basic-auth-ingress"
+}
+
+func (p dexProvider) LoadAuth(gcp *Gcp) (Auth, error) {
+	auth := Auth{}
+	if p.connector == kftypes.AUTH_OIDC {
+		auth.ConnectorSecret = os.Getenv(OIDC_CLIENT_SECRET)
+		if auth.ConnectorSecret == "" {
+			return auth, fmt.Errorf("Need to set environment variable `%v` for oidc.", OIDC_CLIENT_SECRET)
+		}
+		return auth, nil
+	}
+	auth.ConnectorSecret = os.Getenv(LDAP_BIND_PASSWORD)
+	if auth.ConnectorSecret == "" && p.bindDn(gcp) != "" {
+		return auth, fmt.Errorf("Need to set environment variable `%v` for the bind DN of ldap.", LDAP_BIND_PASSWORD)
+	}
+	return auth, nil
+}
+
+func (p dexProvider) SetAuth(gcp *Gcp, auth Auth) error {
+	if p.connector == kftypes.AUTH_OIDC && auth.ConnectorSecret == "" {
+		return fmt.Errorf("the OIDC client secret is required for oidc")
+	}
+	if p.connector == kftypes.AUTH_LDAP && auth.ConnectorSecret == "" && p.bindDn(gcp) != "" {
+		return fmt.Errorf("the bind password is required for ldap with a bind DN")
+	}
+	gcp.connectorSecret = auth.ConnectorSecret
+	return nil
+}
+
+func (p dexProvider) bindDn(gcp *Gcp) string {
+	if gcp.Spec.Auth == nil || gcp.Spec.Auth.Ldap == nil {
+		return ""
+	}
+	return gcp.Spec.Auth.Ldap.BindDn
+}
+
+func (p dexProvider) SetParams(gcp *Gcp) error {
+	auth := gcp.Spec.Auth
+	if p.connector == kftypes.AUTH_OIDC && (auth == nil || auth.Oidc == nil) ||
+		p.connector == kftypes.AUTH_LDAP && (auth == nil || auth.Ldap == nil) {
+		return &kfapis.KfError{
+			Code:    int(kfapis.INVALID_ARGUMENT),
+			Message: fmt.Sprintf("spec.auth.%v must be set in app.yaml for the %v auth provider", p.connector, p.connector),
+		}
+	}
+	gcp.setComponentParam("basic-auth-ingress", "ipName", gcp.Spec.IpName, true)
+	gcp.setComponentParam("basic-auth-ingress", "hostname", gcp.Spec.Hostname, true)
+	gcp.setComponentParam("dex", "hostname", gcp.Spec.Hostname, true)
+	return nil
+}
+
+// CreateSecrets creates the client of the auth service and writes the Dex config, restarting
+// Dex when it changed.
+func (p dexProvider) CreateSecrets(ctx context.Context, gcp *Gcp, client *clientset.Clientset) error {
+	clientSecret, err := gcp.authserviceClientSecret(client)
+	if err != nil {
+		return fmt.Errorf("cannot create secret %v: %v", AUTHSERVICE_SECRET, err)
+	}
+	config, err := yaml.Marshal(p.dexConfig(gcp, clientSecret))
+	if err != nil {
+		return fmt.Errorf("couldn't marshal the Dex config: %v", err)
+	}
+	data := map[string][]byte{"config.yaml": config}
+	secret, err := client.CoreV1().Secrets(gcp.namespace()).Get(DEX_CONFIG_SECRET, metav1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		log.Infof("Creating the Dex config in secret %v", DEX_CONFIG_SECRET)
+		return gcp.insertSecret(client, DEX_CONFIG_SECRET, gcp.namespace(), data)
+	}
+	if err != nil {
+		return fmt.Errorf("couldn't get secret %v: %v", DEX_CONFIG_SECRET, err)
+	}
+	if string(secret.Data["config.yaml"]) == string(config) {
+		log.Infof("Secret for %v already exists ...", DEX_CONFIG_SECRET)
+		return nil
+	}
+	log.Infof("Updating the Dex config in secret %v", DEX_CONFIG_SECRET)
+	secret.Data = data
+	_, err = client.CoreV1().Secrets(gcp.namespace()).Update(secret)
+	gcp.auditSecret(AUDIT_SECRET_UPDATE, gcp.namespace(), DEX_CONFIG_SECRET, data, err)
+	if err != nil {
+		return fmt.Errorf("couldn't update secret %v: %v", DEX_CONFIG_SECRET, err)
+	}
+	return gcp.restartDeployment(client, DEX_DEPLOYMENT)
+}
+
+func (dexProvider) Secrets(gcp *Gcp) []secretRef {
+	return []secretRef{{gcp.namespace(), AUTHSERVICE_SECRET}, {gcp.namespace(), DEX_CONFIG_SECRET}}
+}
+
+// dexConfig is the config of Dex: its issuer, the static client of the auth service and the
+// connector of the provider, keeping its state in custom resources.
+func (p dexProvider) dexConfig(gcp *Gcp, clientSecret string) map[string]interface{} {
+	issuer := "https://" + gcp.Spec.Hostname + "/dex"
+	var connector map[string]interface{}
+	if p.connector == kftypes.AUTH_OIDC {
+		oidc := gcp.Spec.Auth.Oidc
+		scopes := oidc.Scopes
+		if len(scopes) == 0 {
+			scopes = []string{"profile", "email"}
+		}
+		connector = map[string]interface{}{
+			"type": "oidc",
+			"id":   "oidc",
+			"name": "OpenID Connect",
+			"config": map[string]interface{}{
+				"issuer":       oidc.Issuer,
+				"clientID":     oidc.ClientId,
+				"clientSecret": gcp.connectorSecret,
+				"redirectURI":  issuer + "/callback",
+				"scopes":       scopes,
+			},
+		}
+	} else {
+		ldap := gcp.Spec.Auth.Ldap
+		usernameAttr := ldap.UsernameAttr
+		if usernameAttr == "" {
+			usernameAttr = "uid"
+		}
+		emailAttr := ldap.EmailAttr
+		if emailAttr == "" {
+			emailAttr = "mail"
+		}
+		config := map[string]interface{}{
+			"host":          ldap.Host,
+			"insecureNoSSL": ldap.InsecureNoSsl,
+			"userSearch": map[string]interface{}{
+				"baseDN":    ldap.UserBaseDn,
+				"filter":    ldap.UserFilter,
+				"username":  usernameAttr,
+				"idAttr":    "DN",
+				"emailAttr": emailAttr,
+				"nameAttr":  "cn",
+			},
+		}
+		if ldap.BindDn != "" {
+			config["bindDN"] = ldap.BindDn
+			config["bindPW"] = gcp.connectorSecret
+		}
+		if ldap.GroupBaseDn != "" {
+			config["groupSearch"] = map[string]interface{}{
+				"baseDN":    ldap.GroupBaseDn,
+				"filter":    "(objectClass=groupOfNames)",
+				"userAttr":  "DN",
+				"groupAttr": "member",
+				"nameAttr":  "cn",
+			}
+		}
+		connector = map[string]interface{}{
+			"type":   "ldap",
+			"id":     "ldap",
+			"name":   "LDAP",
+			"config": config,
+		}
+	}
+	return map[string]interface{}{
+		"issuer": issuer,
+		"storage": map[string]interface{}{
+			"type":   "kubernetes",
+			"config": map[string]interface{}{"inCluster": true},
+		},
+		"web":    map[string]interface{}{"http": "0.0.0.0:5556"},
+		"oauth2": map[string]interface{}{"skipApprovalScreen": true},
+		"staticClients": []interface{}{
+			map[string]interface{}{
+				"id":           AUTHSERVICE_CLIENT_ID,
+				"name":         "Kubeflow",
+				"secret":       clientSecret,
+				"redirectURIs": []string{"https://" + gcp.Spec.Hostname + "/login/oidc"},
+			},
+		},
+		"connectors": []interface{}{connector},
+	}
+}
+
+// authserviceClientSecret returns the client secret of the auth service, created the first
+// time so the sessions survive reapplying.
+func (gcp *Gcp) authserviceClientSecret(client *clientset.Clientset) (string, error) {
+	secret, err := client.CoreV1().Secrets(gcp.namespace()).Get(AUTHSERVICE_SECRET, metav1.GetOptions{})
+	if err == nil {
+		return string(secret.Data["client_secret"]), nil
+	}
+	if !k8serrors.IsNotFound(err) {
+		return "", err
+	}
+	buf := make([]byte, 32)
+	if _, err = rand.Read(buf); err != nil {
+		return "", fmt.Errorf("couldn't generate the client secret: %v", err)
+	}
+	clientSecret := base64.RawURLEncoding.EncodeToString(buf)
+	err = gcp.insertSecret(client, AUTHSERVICE_SECRET, gcp.namespace(), map[string][]byte{
+		"client_id":     []byte(AUTHSERVICE_CLIENT_ID),
+		"client_secret": []byte(clientSecret),
+	})
+	return clientSecret, err
+}
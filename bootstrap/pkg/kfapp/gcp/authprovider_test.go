@@ -0,0 +1,245 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	kfapis "github.com/kubeflow/kubeflow/bootstrap/pkg/apis"
+	kftypes "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps"
+	kfdefs "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps/kfdef/v1alpha1"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// newAuthGcp returns the Gcp of the app kf with auth.
+func newAuthGcp(auth *kfdefs.AuthConfig, useBasicAuth bool) *Gcp {
+	gcp := &Gcp{}
+	gcp.Name = "kf"
+	gcp.Namespace = "kubeflow"
+	gcp.Spec.Hostname = "kf.example.com"
+	gcp.Spec.IpName = "kf-ip"
+	gcp.Spec.Auth = auth
+	gcp.Spec.UseBasicAuth = useBasicAuth
+	return gcp
+}
+
+func TestAuthProviderSelection(t *testing.T) {
+	cases := []struct {
+		name         string
+		auth         *kfdefs.AuthConfig
+		useBasicAuth bool
+		want         string
+		ingress      string
+	}{
+		{"default", nil, false, kftypes.AUTH_IAP, "iap-ingress"},
+		{"empty provider", &kfdefs.AuthConfig{}, false, kftypes.AUTH_IAP, "iap-ingress"},
+		{"useBasicAuth", nil, true, kftypes.AUTH_BASIC_AUTH, "basic-auth-ingress"},
+		{"basic-auth with useBasicAuth", &kfdefs.AuthConfig{Provider: kftypes.AUTH_BASIC_AUTH}, true,
+			kftypes.AUTH_BASIC_AUTH, "basic-auth-ingress"},
+		{"oidc", &kfdefs.AuthConfig{Provider: kftypes.AUTH_OIDC}, false, kftypes.AUTH_OIDC, "basic-auth-ingress"},
+		{"ldap", &kfdefs.AuthConfig{Provider: kftypes.AUTH_LDAP}, false, kftypes.AUTH_LDAP, "basic-auth-ingress"},
+		{"unknown", &kfdefs.AuthConfig{Provider: "saml"}, false, "", ""},
+		{"useBasicAuth with oidc", &kfdefs.AuthConfig{Provider: kftypes.AUTH_OIDC}, true, "", ""},
+	}
+	for _, c := range cases {
+		gcp := newAuthGcp(c.auth, c.useBasicAuth)
+		err := gcp.checkAuthProvider()
+		if c.want == "" {
+			if kfErr, ok := err.(*kfapis.KfError); !ok || kfErr.Code != int(kfapis.INVALID_ARGUMENT) {
+				t.Errorf("%v: checkAuthProvider = %v; want an invalid argument error", c.name, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%v: checkAuthProvider failed: %v", c.name, err)
+			continue
+		}
+		provider := gcp.authProvider()
+		if provider.Name() != c.want || provider.IngressComponent() != c.ingress {
+			t.Errorf("%v: provider %v with ingress %v; want %v with %v", c.name, provider.Name(),
+				provider.IngressComponent(), c.want, c.ingress)
+		}
+	}
+}
+
+func TestAuthProviderSecrets(t *testing.T) {
+	cases := []struct {
+		provider string
+		useIstio bool
+		want     []secretRef
+	}{
+		{kftypes.AUTH_IAP, false, []secretRef{{"kubeflow", KUBEFLOW_OAUTH}}},
+		{kftypes.AUTH_IAP, true, []secretRef{{"istio-system", KUBEFLOW_OAUTH}}},
+		{kftypes.AUTH_BASIC_AUTH, true, []secretRef{{"kubeflow", BASIC_AUTH_SECRET}}},
+		{kftypes.AUTH_OIDC, false, []secretRef{{"kubeflow", AUTHSERVICE_SECRET}, {"kubeflow", DEX_CONFIG_SECRET}}},
+		{kftypes.AUTH_LDAP, true, []secretRef{{"kubeflow", AUTHSERVICE_SECRET}, {"kubeflow", DEX_CONFIG_SECRET}}},
+	}
+	for _, c := range cases {
+		gcp := newAuthGcp(&kfdefs.AuthConfig{Provider: c.provider}, c.provider == kftypes.AUTH_BASIC_AUTH)
+		gcp.Spec.UseIstio = c.useIstio
+		if got := gcp.authProvider().Secrets(gcp); !reflect.DeepEqual(got, c.want) {
+			t.Errorf("secrets of %v with useIstio %v: %v; want %v", c.provider, c.useIstio, got, c.want)
+		}
+	}
+}
+
+func TestAuthProviderSetParams(t *testing.T) {
+	oidc := &kfdefs.OidcConfig{Issuer: "https://accounts.google.com", ClientId: "id"}
+	ldap := &kfdefs.LdapConfig{Host: "ldap.example.com:636", UserBaseDn: "ou=People,dc=example,dc=com"}
+	cases := []struct {
+		name     string
+		auth     *kfdefs.AuthConfig
+		useIstio bool
+		// want are the params set, by component.param; nil when SetParams fails.
+		want map[string]string
+	}{
+		{"iap", nil, true, map[string]string{
+			"iap-ingress.ipName":   "kf-ip",
+			"iap-ingress.hostname": "kf.example.com",
+			"iap-ingress.useIstio": "true",
+		}},
+		{"iap without istio", nil, false, map[string]string{
+			"iap-ingress.ipName":   "kf-ip",
+			"iap-ingress.hostname": "kf.example.com",
+			"iap-ingress.useIstio": "",
+		}},
+		{"basic-auth", &kfdefs.AuthConfig{Provider: kftypes.AUTH_BASIC_AUTH}, false, map[string]string{
+			"basic-auth-ingress.ipName":   "kf-ip",
+			"basic-auth-ingress.hostname": "kf.example.com",
+			"iap-ingress.hostname":        "",
+		}},
+		{"oidc", &kfdefs.AuthConfig{Provider: kftypes.AUTH_OIDC, Oidc: oidc}, false, map[string]string{
+			"basic-auth-ingress.ipName":   "kf-ip",
+			"basic-auth-ingress.hostname": "kf.example.com",
+			"dex.hostname":                "kf.example.com",
+		}},
+		{"ldap", &kfdefs.AuthConfig{Provider: kftypes.AUTH_LDAP, Ldap: ldap}, false, map[string]string{
+			"basic-auth-ingress.hostname": "kf.example.com",
+			"dex.hostname":                "kf.example.com",
+		}},
+		{"oidc without its config", &kfdefs.AuthConfig{Provider: kftypes.AUTH_OIDC, Ldap: ldap}, false, nil},
+		{"ldap without its config", &kfdefs.AuthConfig{Provider: kftypes.AUTH_LDAP, Oidc: oidc}, false, nil},
+	}
+	for _, c := range cases {
+		gcp := newAuthGcp(c.auth, false)
+		gcp.Spec.UseIstio = c.useIstio
+		err := gcp.authProvider().SetParams(gcp)
+		if c.want == nil {
+			if kfErr, ok := err.(*kfapis.KfError); !ok || kfErr.Code != int(kfapis.INVALID_ARGUMENT) {
+				t.Errorf("%v: SetParams = %v; want an invalid argument error", c.name, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%v: SetParams failed: %v", c.name, err)
+			continue
+		}
+		for param, want := range c.want {
+			parts := strings.SplitN(param, ".", 2)
+			if got := gcp.componentParam(parts[0], parts[1]); got != want {
+				t.Errorf("%v: %v = %q; want %q", c.name, param, got, want)
+			}
+		}
+	}
+}
+
+func TestAuthProviderSetAuth(t *testing.T) {
+	cases := []struct {
+		name     string
+		provider string
+		bindDn   string
+		auth     Auth
+		valid    bool
+	}{
+		{"iap", kftypes.AUTH_IAP, "", Auth{OAuthClientId: "id", OAuthClientSecret: "secret"}, true},
+		{"iap without secret", kftypes.AUTH_IAP, "", Auth{OAuthClientId: "id"}, false},
+		{"basic-auth", kftypes.AUTH_BASIC_AUTH, "", Auth{Username: "admin", Password: []byte("password")}, true},
+		{"basic-auth without password", kftypes.AUTH_BASIC_AUTH, "", Auth{Username: "admin"}, false},
+		{"basic-auth without username", kftypes.AUTH_BASIC_AUTH, "", Auth{Password: []byte("password")}, false},
+		{"oidc", kftypes.AUTH_OIDC, "", Auth{ConnectorSecret: "client-secret"}, true},
+		{"oidc without secret", kftypes.AUTH_OIDC, "", Auth{}, false},
+		{"ldap anonymous", kftypes.AUTH_LDAP, "", Auth{}, true},
+		{"ldap with bind DN", kftypes.AUTH_LDAP, "cn=admin", Auth{ConnectorSecret: "bind-password"}, true},
+		{"ldap with bind DN without password", kftypes.AUTH_LDAP, "cn=admin", Auth{}, false},
+	}
+	for _, c := range cases {
+		gcp := newAuthGcp(&kfdefs.AuthConfig{
+			Provider: c.provider,
+			Ldap:     &kfdefs.LdapConfig{BindDn: c.bindDn},
+		}, c.provider == kftypes.AUTH_BASIC_AUTH)
+		gcp.Spec.BcryptCost = bcrypt.MinCost
+		err := gcp.authProvider().SetAuth(gcp, c.auth)
+		if !c.valid {
+			if err == nil {
+				t.Errorf("%v: SetAuth succeeded", c.name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%v: SetAuth failed: %v", c.name, err)
+			continue
+		}
+		kept := map[string]bool{
+			kftypes.AUTH_IAP:        gcp.oauthId == "id" && gcp.oauthSecret == "secret",
+			kftypes.AUTH_BASIC_AUTH: gcp.username == "admin" && gcp.encodedPassword != "",
+			kftypes.AUTH_OIDC:       gcp.connectorSecret == c.auth.ConnectorSecret,
+			kftypes.AUTH_LDAP:       gcp.connectorSecret == c.auth.ConnectorSecret,
+		}
+		if !kept[c.provider] {
+			t.Errorf("%v: SetAuth didn't keep the credentials of %v", c.name, c.provider)
+		}
+	}
+}
+
+func TestDexConfigConnectors(t *testing.T) {
+	gcp := newAuthGcp(&kfdefs.AuthConfig{
+		Provider: kftypes.AUTH_LDAP,
+		Ldap: &kfdefs.LdapConfig{
+			Host:        "ldap.example.com:636",
+			BindDn:      "cn=admin",
+			UserBaseDn:  "ou=People,dc=example,dc=com",
+			GroupBaseDn: "ou=Groups,dc=example,dc=com",
+		},
+		Oidc: &kfdefs.OidcConfig{Issuer: "https://accounts.google.com", ClientId: "id"},
+	}, false)
+	gcp.connectorSecret = "connector-secret"
+
+	config := dexProvider{connector: kftypes.AUTH_LDAP}.dexConfig(gcp, "client-secret")
+	if config["issuer"] != "https://kf.example.com/dex" {
+		t.Errorf("issuer %v", config["issuer"])
+	}
+	client := config["staticClients"].([]interface{})[0].(map[string]interface{})
+	if client["id"] != AUTHSERVICE_CLIENT_ID || client["secret"] != "client-secret" ||
+		!reflect.DeepEqual(client["redirectURIs"], []string{"https://kf.example.com/login/oidc"}) {
+		t.Errorf("static client %v", client)
+	}
+	ldap := config["connectors"].([]interface{})[0].(map[string]interface{})["config"].(map[string]interface{})
+	if ldap["bindDN"] != "cn=admin" || ldap["bindPW"] != "connector-secret" || ldap["groupSearch"] == nil {
+		t.Errorf("ldap connector %v; want the bind DN, its password and the group search", ldap)
+	}
+	if userSearch := ldap["userSearch"].(map[string]interface{}); userSearch["username"] != "uid" ||
+		userSearch["emailAttr"] != "mail" {
+		t.Errorf("ldap user search %v; want the default attributes", userSearch)
+	}
+
+	config = dexProvider{connector: kftypes.AUTH_OIDC}.dexConfig(gcp, "client-secret")
+	oidc := config["connectors"].([]interface{})[0].(map[string]interface{})["config"].(map[string]interface{})
+	if oidc["clientSecret"] != "connector-secret" || oidc["redirectURI"] != "https://kf.example.com/dex/callback" ||
+		!reflect.DeepEqual(oidc["scopes"], []string{"profile", "email"}) {
+		t.Errorf("oidc connector %v", oidc)
+	}
+}
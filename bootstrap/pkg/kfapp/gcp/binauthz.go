@@ -0,0 +1,170 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"fmt"
+	"github.com/ghodss/yaml"
+	kfapis "github.com/kubeflow/kubeflow/bootstrap/pkg/apis"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+	binaryauthorization "google.golang.org/api/binaryauthorization/v1beta1"
+	"path"
+	"strings"
+)
+
+// BINAUTHZ_POLICY_FILE is the Binary Authorization policy of gcp_config, in the format of
+// gcloud container binauthz policy import.
+const BINAUTHZ_POLICY_FILE = "binauthz-policy.yaml"
+
+// binauthzApi is enabled with spec.binaryAuthorization.
+const binauthzApi = "binaryauthorization.googleapis.com"
+
+// binauthzPatterns are the registries of the images of Kubeflow, and of those GKE runs on the
+// nodes, admitted by the policy.
+var binauthzPatterns = []string{
+	"gcr.io/kubeflow-images-public/*",
+	"gcr.io/kubeflow/*",
+	"gcr.io/ml-pipeline/*",
+	"gcr.io/istio-release/*",
+	"gcr.io/cloudsql-docker/*",
+	"gcr.io/stackdriver-agents/*",
+	"quay.io/jetstack/*",
+	"gcr.io/gke-release/*",
+	"gcr.io/google-containers/*",
+	"gcr.io/google_containers/*",
+	"k8s.gcr.io/*",
+}
+
+// validateBinaryAuthorization checks spec.binaryAuthorization is used with the v1beta1 API, and
+// names its attestors by resource name.
+func (gcp *Gcp) validateBinaryAuthorization() error {
+	binauthz := gcp.Spec.BinaryAuthorization
+	if binauthz == nil {
+		return nil
+	}
+	if gcp.gkeApiVersion() != GKE_API_V1BETA1 {
+		return &kfapis.KfError{
+			Code:    int(kfapis.INVALID_ARGUMENT),
+			Message: fmt.Sprintf("binaryAuthorization needs gke apiVersion %v", GKE_API_V1BETA1),
+		}
+	}
+	for _, attestor := range binauthz.Attestors {
+		parts := strings.Split(attestor, "/")
+		if len(parts) != 4 || parts[0] != "projects" || parts[2] != "attestors" || parts[1] == "" || parts[3] == "" {
+			return &kfapis.KfError{
+				Code: int(kfapis.INVALID_ARGUMENT),
+				Message: fmt.Sprintf("binaryAuthorization attestor %v must be projects/<project>/attestors/<name>",
+					attestor),
+			}
+		}
+	}
+	for _, pattern := range binauthz.AdmitPatterns {
+		if pattern == "" || strings.Contains(pattern, " ") {
+			return &kfapis.KfError{
+				Code:    int(kfapis.INVALID_ARGUMENT),
+				Message: fmt.Sprintf("binaryAuthorization admitPatterns has invalid pattern %q", pattern),
+			}
+		}
+	}
+	return nil
+}
+
+// binauthzPolicy is the starter policy of spec.binaryAuthorization: the images of Kubeflow, GKE,
+// the registry of the project and admitPatterns are admitted, the others need to be attested
+// by the attestors, or are denied when there are none.
+func (gcp *Gcp) binauthzPolicy() *binaryauthorization.Policy {
+	binauthz := gcp.Spec.BinaryAuthorization
+	// The images of a domain-scoped project, example.com:project, are in gcr.io/example.com/project.
+	patterns := append([]string{}, binauthzPatterns...)
+	patterns = append(patterns, "gcr.io/"+strings.Replace(gcp.Spec.Project, ":", "/", 1)+"/*")
+	patterns = append(patterns, binauthz.AdmitPatterns...)
+	policy := &binaryauthorization.Policy{
+		Description: fmt.Sprintf("Admits the images of Kubeflow deployment %v; generated by kfctl.", gcp.Name),
+		DefaultAdmissionRule: &binaryauthorization.AdmissionRule{
+			EvaluationMode:  "ALWAYS_DENY",
+			EnforcementMode: "ENFORCED_BLOCK_AND_AUDIT_LOG",
+		},
+	}
+	seen := map[string]bool{}
+	for _, pattern := range patterns {
+		if seen[pattern] {
+			continue
+		}
+		seen[pattern] = true
+		policy.AdmissionWhitelistPatterns = append(policy.AdmissionWhitelistPatterns,
+			&binaryauthorization.AdmissionWhitelistPattern{NamePattern: pattern})
+	}
+	if len(binauthz.Attestors) > 0 {
+		policy.DefaultAdmissionRule.EvaluationMode = "REQUIRE_ATTESTATION"
+		policy.DefaultAdmissionRule.RequireAttestationsBy = binauthz.Attestors
+	}
+	if binauthz.DryRun {
+		policy.DefaultAdmissionRule.EnforcementMode = "DRYRUN_AUDIT_LOG_ONLY"
+	}
+	return policy
+}
+
+// writeBinauthzPolicy writes the starter policy to binauthz-policy.yaml of bundle.
+func (gcp *Gcp) writeBinauthzPolicy(bundle *Bundle) error {
+	buf, err := yaml.Marshal(gcp.binauthzPolicy())
+	if err != nil {
+		return &kfapis.KfError{
+			Code:    int(kfapis.INTERNAL_ERROR),
+			Message: fmt.Sprintf("Error when marshaling %v: %v", BINAUTHZ_POLICY_FILE, err),
+		}
+	}
+	bundle.Put(path.Join(GCP_CONFIG, BINAUTHZ_POLICY_FILE), buf)
+	return nil
+}
+
+// applyBinauthzPolicy sets the policy of the project to binauthz-policy.yaml, as reviewed in
+// gcp_config, when spec.binaryAuthorization.applyPolicy is set.
+func (gcp *Gcp) applyBinauthzPolicy(ctx context.Context) error {
+	binauthz := gcp.Spec.BinaryAuthorization
+	if binauthz == nil || !binauthz.ApplyPolicy {
+		return nil
+	}
+	buf, err := gcp.readConfig(BINAUTHZ_POLICY_FILE)
+	if err != nil {
+		return &kfapis.KfError{
+			Code:    int(kfapis.INVALID_ARGUMENT),
+			Message: fmt.Sprintf("couldn't read %v; run kfctl generate platform: %v", BINAUTHZ_POLICY_FILE, err),
+		}
+	}
+	policy := &binaryauthorization.Policy{}
+	if err := yaml.Unmarshal(buf, policy); err != nil {
+		return &kfapis.KfError{
+			Code:    int(kfapis.INVALID_ARGUMENT),
+			Message: fmt.Sprintf("invalid %v: %v", BINAUTHZ_POLICY_FILE, err),
+		}
+	}
+	service, err := binaryauthorization.New(gcp.client)
+	if err != nil {
+		return fmt.Errorf("Error creating binaryauthorization service: %v", err)
+	}
+	name := fmt.Sprintf("projects/%v/policy", gcp.Spec.Project)
+	policy.Name = name
+	log.Infof("Setting Binary Authorization policy %v from %v", name, BINAUTHZ_POLICY_FILE)
+	_, err = service.Projects.UpdatePolicy(name, policy).Context(ctx).Do()
+	gcp.audit(AUDIT_BINAUTHZ_UPDATE, name, map[string]interface{}{
+		"admissionWhitelistPatterns": len(policy.AdmissionWhitelistPatterns),
+		"defaultAdmissionRule":       policy.DefaultAdmissionRule,
+	}, err)
+	if err != nil {
+		return fmt.Errorf("couldn't set Binary Authorization policy %v: %v", name, gcp.perimeterError(err))
+	}
+	return nil
+}
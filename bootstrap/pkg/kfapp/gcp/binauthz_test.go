@@ -0,0 +1,105 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/ghodss/yaml"
+	kfdefs "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps/kfdef/v1alpha1"
+	binaryauthorization "google.golang.org/api/binaryauthorization/v1beta1"
+)
+
+func TestValidateBinaryAuthorization(t *testing.T) {
+	cases := []struct {
+		apiVersion string
+		binauthz   *kfdefs.BinaryAuthorizationConfig
+		valid      bool
+	}{
+		{GKE_API_V1, nil, true},
+		{GKE_API_V1BETA1, &kfdefs.BinaryAuthorizationConfig{}, true},
+		{GKE_API_V1, &kfdefs.BinaryAuthorizationConfig{}, false},
+		{GKE_API_V1BETA1, &kfdefs.BinaryAuthorizationConfig{
+			Attestors: []string{"projects/security/attestors/built-by-ci"}}, true},
+		{GKE_API_V1BETA1, &kfdefs.BinaryAuthorizationConfig{Attestors: []string{"built-by-ci"}}, false},
+		{GKE_API_V1BETA1, &kfdefs.BinaryAuthorizationConfig{AdmitPatterns: []string{""}}, false},
+	}
+	for i, c := range cases {
+		gcp := &Gcp{}
+		gcp.Spec.Gke = &kfdefs.GkeConfig{ApiVersion: c.apiVersion}
+		gcp.Spec.BinaryAuthorization = c.binauthz
+		err := gcp.validateBinaryAuthorization()
+		if c.valid && err != nil {
+			t.Errorf("case %v: validateBinaryAuthorization failed: %v", i, err)
+		} else if !c.valid && err == nil {
+			t.Errorf("case %v: validateBinaryAuthorization succeeded", i)
+		}
+	}
+}
+
+func TestBinauthzPolicy(t *testing.T) {
+	gcp := &Gcp{}
+	gcp.Name = "kubeflow"
+	gcp.Spec.Project = "example.com:ml"
+	gcp.Spec.BinaryAuthorization = &kfdefs.BinaryAuthorizationConfig{
+		AdmitPatterns: []string{"us.gcr.io/ml-team/*", "gcr.io/kubeflow/*"},
+	}
+	policy := gcp.binauthzPolicy()
+	if policy.DefaultAdmissionRule.EvaluationMode != "ALWAYS_DENY" ||
+		policy.DefaultAdmissionRule.EnforcementMode != "ENFORCED_BLOCK_AND_AUDIT_LOG" {
+		t.Errorf("unexpected default admission rule %+v", policy.DefaultAdmissionRule)
+	}
+	patterns := map[string]int{}
+	for _, pattern := range policy.AdmissionWhitelistPatterns {
+		patterns[pattern.NamePattern]++
+	}
+	for _, pattern := range []string{"gcr.io/kubeflow-images-public/*", "gcr.io/example.com/ml/*",
+		"us.gcr.io/ml-team/*", "gcr.io/kubeflow/*"} {
+		if patterns[pattern] != 1 {
+			t.Errorf("pattern %v is admitted %v times; want once", pattern, patterns[pattern])
+		}
+	}
+
+	gcp.Spec.BinaryAuthorization.Attestors = []string{"projects/security/attestors/built-by-ci"}
+	gcp.Spec.BinaryAuthorization.DryRun = true
+	policy = gcp.binauthzPolicy()
+	if policy.DefaultAdmissionRule.EvaluationMode != "REQUIRE_ATTESTATION" ||
+		policy.DefaultAdmissionRule.EnforcementMode != "DRYRUN_AUDIT_LOG_ONLY" ||
+		len(policy.DefaultAdmissionRule.RequireAttestationsBy) != 1 {
+		t.Errorf("unexpected default admission rule %+v", policy.DefaultAdmissionRule)
+	}
+
+	// The file written by generate is read back by apply.
+	bundle := NewBundle()
+	if err := gcp.writeBinauthzPolicy(bundle); err != nil {
+		t.Fatalf("writeBinauthzPolicy failed: %v", err)
+	}
+	buf, ok := bundle.Get(path.Join(GCP_CONFIG, BINAUTHZ_POLICY_FILE))
+	if !ok {
+		t.Fatalf("%v wasn't written", BINAUTHZ_POLICY_FILE)
+	}
+	if !strings.Contains(string(buf), "admissionWhitelistPatterns:") {
+		t.Errorf("%v isn't in the format of gcloud:\n%s", BINAUTHZ_POLICY_FILE, buf)
+	}
+	read := &binaryauthorization.Policy{}
+	if err := yaml.Unmarshal(buf, read); err != nil {
+		t.Fatalf("couldn't read %v: %v", BINAUTHZ_POLICY_FILE, err)
+	}
+	if len(read.AdmissionWhitelistPatterns) != len(policy.AdmissionWhitelistPatterns) {
+		t.Errorf("read %v patterns; want %v", len(read.AdmissionWhitelistPatterns), len(policy.AdmissionWhitelistPatterns))
+	}
+}
@@ -0,0 +1,252 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	kfapis "github.com/kubeflow/kubeflow/bootstrap/pkg/apis"
+	kftypes "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps"
+	log "github.com/sirupsen/logrus"
+	"io/ioutil"
+	"k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientset "k8s.io/client-go/kubernetes"
+	"math/big"
+	"net"
+	"time"
+)
+
+// Values of spec.certificate.type.
+const (
+	// CERT_ACME has cert-manager get a Let's Encrypt certificate; it's the default.
+	CERT_ACME = "acme"
+	// CERT_MANAGED uses a Google-managed certificate, attached through a ManagedCertificate.
+	CERT_MANAGED = "managed-cert"
+	// CERT_SELF_SIGNED has kfctl create a self-signed certificate for the hostname.
+	CERT_SELF_SIGNED = "self-signed"
+	// CERT_BYO_SECRET has kfctl create the TLS secret from spec.certificate.certFile and keyFile.
+	CERT_BYO_SECRET = "byo-secret"
+)
+
+// DEFAULT_TLS_SECRET is the secretName default of the ingress components.
+const DEFAULT_TLS_SECRET = "envoy-ingress-tls"
+
+// selfSignedValidity is how long a self-signed certificate is valid for; apply renews it
+// once less than a tenth of it is left.
+const selfSignedValidity = 365 * 24 * time.Hour
+
+func (gcp *Gcp) certType() string {
+	if gcp.Spec.Certificate == nil || gcp.Spec.Certificate.Type == "" {
+		return CERT_ACME
+	}
+	return gcp.Spec.Certificate.Type
+}
+
+// ingressComponent is the component creating the GCLB ingress of the app.
+func (gcp *Gcp) ingressComponent() string {
+	return gcp.authProvider().IngressComponent()
+}
+
+// ingressNamespace is where the ingress component creates the ingress and its TLS secret.
+func (gcp *Gcp) ingressNamespace() string {
+	if gcp.Spec.UseIstio && gcp.authProvider().Name() == kftypes.AUTH_IAP {
+		return gcp.istioNamespace()
+	}
+	return gcp.namespace()
+}
+
+// tlsSecretName is the secretName param of the ingress component.
+func (gcp *Gcp) tlsSecretName() string {
+	if secretName := gcp.componentParam(gcp.ingressComponent(), "secretName"); secretName != "" {
+		return secretName
+	}
+	return DEFAULT_TLS_SECRET
+}
+
+// setCertificateParams checks spec.certificate against spec.hostname and passes its type
+// to the ingress component.
+func (gcp *Gcp) setCertificateParams() error {
+	certType := gcp.certType()
+	switch certType {
+	case CERT_ACME:
+	case CERT_MANAGED, CERT_SELF_SIGNED:
+		if net.ParseIP(gcp.Spec.Hostname) != nil {
+			return &kfapis.KfError{
+				Code:    int(kfapis.INVALID_ARGUMENT),
+				Message: fmt.Sprintf("certificate %v needs a DNS hostname, not %v", certType, gcp.Spec.Hostname),
+			}
+		}
+	case CERT_BYO_SECRET:
+		if _, err := gcp.loadCertificate(); err != nil {
+			return err
+		}
+	default:
+		return &kfapis.KfError{
+			Code:    int(kfapis.INVALID_ARGUMENT),
+			Message: fmt.Sprintf("unknown certificate type %v", certType),
+		}
+	}
+	component := gcp.ingressComponent()
+	gcp.setComponentParam(component, "certType", certType, true)
+	return nil
+}
+
+// loadCertificate reads the certificate and key of byo-secret and checks they're a pair
+// valid for spec.hostname.
+func (gcp *Gcp) loadCertificate() (map[string][]byte, error) {
+	certificate := gcp.Spec.Certificate
+	if certificate.CertFile == "" || certificate.KeyFile == "" {
+		return nil, &kfapis.KfError{
+			Code:    int(kfapis.INVALID_ARGUMENT),
+			Message: fmt.Sprintf("certificate %v needs certFile and keyFile", CERT_BYO_SECRET),
+		}
+	}
+	certPEM, err := ioutil.ReadFile(certificate.CertFile)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't read certFile %v: %v", certificate.CertFile, err)
+	}
+	keyPEM, err := ioutil.ReadFile(certificate.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't read keyFile %v: %v", certificate.KeyFile, err)
+	}
+	pair, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		return nil, &kfapis.KfError{
+			Code:    int(kfapis.INVALID_ARGUMENT),
+			Message: fmt.Sprintf("%v and %v aren't a certificate and key pair: %v", certificate.CertFile, certificate.KeyFile, err),
+		}
+	}
+	if err = checkCertificate(pair.Certificate[0], gcp.Spec.Hostname, gcp.clock.Now()); err != nil {
+		return nil, &kfapis.KfError{
+			Code:    int(kfapis.INVALID_ARGUMENT),
+			Message: fmt.Sprintf("%v: %v", certificate.CertFile, err),
+		}
+	}
+	return map[string][]byte{
+		v1.TLSCertKey:       certPEM,
+		v1.TLSPrivateKeyKey: keyPEM,
+	}, nil
+}
+
+// checkCertificate checks the DER encoded certificate is valid for hostname and hasn't expired by now.
+func checkCertificate(der []byte, hostname string, now time.Time) error {
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return fmt.Errorf("couldn't parse certificate: %v", err)
+	}
+	if err = cert.VerifyHostname(hostname); err != nil {
+		return fmt.Errorf("certificate doesn't match hostname %v: %v", hostname, err)
+	}
+	if now.After(cert.NotAfter) {
+		return fmt.Errorf("certificate expired on %v", cert.NotAfter)
+	}
+	return nil
+}
+
+// selfSignedCertificate returns the PEM encoded certificate and key of a new self-signed
+// certificate for hostname, valid from now.
+func selfSignedCertificate(hostname string, now time.Time) (map[string][]byte, error) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't generate key: %v", err)
+	}
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, err
+	}
+	template := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: hostname},
+		DNSNames:              []string{hostname},
+		NotBefore:             now,
+		NotAfter:              now.Add(selfSignedValidity),
+		KeyUsage:              x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't create certificate: %v", err)
+	}
+	return map[string][]byte{
+		v1.TLSCertKey:       pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		v1.TLSPrivateKeyKey: pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}),
+	}, nil
+}
+
+// createTlsSecret creates the TLS secret of the ingress for self-signed and byo-secret.
+// A self-signed certificate is kept until it no longer matches the hostname or nears expiry.
+func (gcp *Gcp) createTlsSecret(client *clientset.Clientset) error {
+	namespace := gcp.ingressNamespace()
+	name := gcp.tlsSecretName()
+	var data map[string][]byte
+	var err error
+	switch gcp.certType() {
+	case CERT_SELF_SIGNED:
+		existing, getErr := client.CoreV1().Secrets(namespace).Get(name, metav1.GetOptions{})
+		if getErr == nil && selfSignedValid(existing, gcp.Spec.Hostname, gcp.clock.Now()) {
+			return nil
+		}
+		log.Infof("Creating a self-signed certificate for %v", gcp.Spec.Hostname)
+		data, err = selfSignedCertificate(gcp.Spec.Hostname, gcp.clock.Now())
+	case CERT_BYO_SECRET:
+		data, err = gcp.loadCertificate()
+	default:
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	secret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Type: v1.SecretTypeTLS,
+		Data: data,
+	}
+	_, err = client.CoreV1().Secrets(namespace).Update(secret)
+	action := AUDIT_SECRET_UPDATE
+	if k8serrors.IsNotFound(err) {
+		action = AUDIT_SECRET_CREATE
+		_, err = client.CoreV1().Secrets(namespace).Create(secret)
+	}
+	gcp.auditSecret(action, namespace, name, data, err)
+	if err != nil {
+		return fmt.Errorf("couldn't write TLS secret %v/%v: %v", namespace, name, err)
+	}
+	return nil
+}
+
+// selfSignedValid is true when secret holds a certificate for hostname with at least a tenth
+// of selfSignedValidity left at now.
+func selfSignedValid(secret *v1.Secret, hostname string, now time.Time) bool {
+	block, _ := pem.Decode(secret.Data[v1.TLSCertKey])
+	if block == nil {
+		return false
+	}
+	if checkCertificate(block.Bytes, hostname, now) != nil {
+		return false
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	return err == nil && cert.NotAfter.Sub(now) > selfSignedValidity/10
+}
@@ -0,0 +1,452 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"cloud.google.com/go/container/apiv1"
+	"encoding/json"
+	"fmt"
+	"github.com/kubeflow/kubeflow/bootstrap/pkg/utils"
+	"golang.org/x/net/context"
+	dmalpha "google.golang.org/api/deploymentmanager/v0.alpha"
+	"google.golang.org/api/deploymentmanager/v2"
+	"google.golang.org/api/iam/v1"
+	"google.golang.org/api/option"
+	"google.golang.org/api/servicemanagement/v1"
+	"google.golang.org/api/serviceusage/v1"
+	containerpb "google.golang.org/genproto/googleapis/container/v1"
+)
+
+// DeploymentManagerClient is the part of the Deployment Manager API Gcp calls. It's the API
+// itself by default; WithDeploymentManagerClient replaces it, e.g. with fake.DeploymentManager
+// in tests. The errors of the API are *googleapi.Error.
+type DeploymentManagerClient interface {
+	GetDeployment(ctx context.Context, project string, name string) (*deploymentmanager.Deployment, error)
+	// ListDeployments calls f with each page of the deployments of project.
+	ListDeployments(ctx context.Context, project string,
+		f func(*deploymentmanager.DeploymentsListResponse) error) error
+	// InsertDeployment creates deployment; with createPolicy ACQUIRE it takes over the resources
+	// of its config which already exist instead of failing on them.
+	InsertDeployment(ctx context.Context, project string, deployment *deploymentmanager.Deployment,
+		createPolicy string) (*deploymentmanager.Operation, error)
+	// UpdateDeployment updates deployment name; the resources it no longer has are deleted, or
+	// only removed from it with deletePolicy ABANDON.
+	UpdateDeployment(ctx context.Context, project string, name string, deployment *deploymentmanager.Deployment,
+		deletePolicy string) (*deploymentmanager.Operation, error)
+	// DeleteDeployment deletes deployment name and its resources, or leaves them in the project
+	// with deletePolicy ABANDON.
+	DeleteDeployment(ctx context.Context, project string, name string,
+		deletePolicy string) (*deploymentmanager.Operation, error)
+	// StopDeployment stops the operation in progress on deployment name, whose fingerprint must
+	// be the current one.
+	StopDeployment(ctx context.Context, project string, name string,
+		fingerprint string) (*deploymentmanager.Operation, error)
+	GetManifest(ctx context.Context, project string, deployment string,
+		manifest string) (*deploymentmanager.Manifest, error)
+	// ListResources returns the resources of deployment, with the errors of those which failed
+	// to be created or updated.
+	ListResources(ctx context.Context, project string, deployment string) ([]*deploymentmanager.Resource, error)
+	GetOperation(ctx context.Context, project string, name string) (*deploymentmanager.Operation, error)
+}
+
+// IamClient is the part of the IAM API Gcp calls, on the keys of the service accounts.
+type IamClient interface {
+	CreateServiceAccountKey(ctx context.Context, account string,
+		req *iam.CreateServiceAccountKeyRequest) (*iam.ServiceAccountKey, error)
+	GetServiceAccountKey(ctx context.Context, name string) (*iam.ServiceAccountKey, error)
+	// ListServiceAccountKeys returns the keys of account, those of keyType when it's set.
+	ListServiceAccountKeys(ctx context.Context, account string, keyType string) ([]*iam.ServiceAccountKey, error)
+	DeleteServiceAccountKey(ctx context.Context, name string) error
+}
+
+// ServiceUsageClient is the part of the Service Usage API Gcp calls to enable the APIs of
+// the project.
+type ServiceUsageClient interface {
+	BatchEnableServices(ctx context.Context, parent string,
+		req *serviceusage.BatchEnableServicesRequest) (*serviceusage.Operation, error)
+	GetOperation(ctx context.Context, name string) (*serviceusage.Operation, error)
+}
+
+// ServiceManagementClient is the part of the Service Management API Gcp calls on the endpoints
+// service of spec.hostname.
+type ServiceManagementClient interface {
+	GetService(ctx context.Context, name string) (*servicemanagement.ManagedService, error)
+	// ListServices calls f with each page of the services produced by project.
+	ListServices(ctx context.Context, project string,
+		f func(*servicemanagement.ListServicesResponse) error) error
+	CreateService(ctx context.Context, service *servicemanagement.ManagedService) (*servicemanagement.Operation, error)
+	// GetServiceConfig returns the latest config of service name.
+	GetServiceConfig(ctx context.Context, name string) (*servicemanagement.Service, error)
+	SubmitConfigSource(ctx context.Context, name string,
+		req *servicemanagement.SubmitConfigSourceRequest) (*servicemanagement.Operation, error)
+	CreateRollout(ctx context.Context, name string,
+		rollout *servicemanagement.Rollout) (*servicemanagement.Operation, error)
+	DeleteService(ctx context.Context, name string) (*servicemanagement.Operation, error)
+	// UndeleteService restores service name in the 30 days it's kept after being deleted.
+	UndeleteService(ctx context.Context, name string) (*servicemanagement.Operation, error)
+	GetOperation(ctx context.Context, name string) (*servicemanagement.Operation, error)
+}
+
+// ContainerClient is the part of the GKE API Gcp calls, on the cluster and its node pools.
+type ContainerClient interface {
+	ListClusters(ctx context.Context, req *containerpb.ListClustersRequest) (*containerpb.ListClustersResponse, error)
+	ListNodePools(ctx context.Context, req *containerpb.ListNodePoolsRequest) (*containerpb.ListNodePoolsResponse, error)
+	CreateNodePool(ctx context.Context, req *containerpb.CreateNodePoolRequest) (*containerpb.Operation, error)
+	DeleteNodePool(ctx context.Context, req *containerpb.DeleteNodePoolRequest) (*containerpb.Operation, error)
+	SetNodePoolSize(ctx context.Context, req *containerpb.SetNodePoolSizeRequest) (*containerpb.Operation, error)
+	UpdateMaster(ctx context.Context, req *containerpb.UpdateMasterRequest) (*containerpb.Operation, error)
+	// GetServerConfig returns the master and node versions GKE supports in the zone.
+	GetServerConfig(ctx context.Context, req *containerpb.GetServerConfigRequest) (*containerpb.ServerConfig, error)
+	GetOperation(ctx context.Context, req *containerpb.GetOperationRequest) (*containerpb.Operation, error)
+}
+
+// newDeploymentManagerClient returns the DM client of the app, recording the changes it makes
+// in the audit log.
+func (gcp *Gcp) newDeploymentManagerClient() (DeploymentManagerClient, error) {
+	if gcp.dmClient != nil {
+		return &auditedDeploymentManager{DeploymentManagerClient: gcp.dmClient, gcp: gcp}, nil
+	}
+	service, err := deploymentmanager.New(gcp.client)
+	if err != nil {
+		return nil, fmt.Errorf("Error creating deploymentmanagerService: %v", err)
+	}
+	client := &deploymentManagerService{service: service}
+	if gcp.Spec.DeploymentManagerSA != "" {
+		if client.alpha, err = dmalpha.New(gcp.client); err != nil {
+			return nil, fmt.Errorf("Error creating deploymentmanagerService: %v", err)
+		}
+		client.serviceAccount = gcp.Spec.DeploymentManagerSA
+	}
+	return &auditedDeploymentManager{DeploymentManagerClient: client, gcp: gcp}, nil
+}
+
+// newIamClient returns the IAM client of the app.
+func (gcp *Gcp) newIamClient() (IamClient, error) {
+	if gcp.iamClient != nil {
+		return gcp.iamClient, nil
+	}
+	service, err := iam.New(gcp.client)
+	if err != nil {
+		return nil, fmt.Errorf("Error creating iamService: %v", err)
+	}
+	return &iamService{service: service}, nil
+}
+
+// newServiceUsageClient returns the Service Usage client of the app.
+func (gcp *Gcp) newServiceUsageClient() (ServiceUsageClient, error) {
+	if gcp.serviceUsageClient != nil {
+		return gcp.serviceUsageClient, nil
+	}
+	service, err := serviceusage.New(gcp.client)
+	if err != nil {
+		return nil, fmt.Errorf("could not create service usage service %v", err)
+	}
+	return &serviceUsageService{service: service}, nil
+}
+
+// newServiceManagementClient returns the Service Management client of the app.
+func (gcp *Gcp) newServiceManagementClient() (ServiceManagementClient, error) {
+	if gcp.serviceManagementClient != nil {
+		return gcp.serviceManagementClient, nil
+	}
+	service, err := servicemanagement.New(gcp.client)
+	if err != nil {
+		return nil, fmt.Errorf("could not create service management service %v", err)
+	}
+	return &serviceManagementService{service: service}, nil
+}
+
+// newContainerClient returns the GKE client of the app.
+func (gcp *Gcp) newContainerClient(ctx context.Context) (ContainerClient, error) {
+	if gcp.containerClient != nil {
+		return gcp.containerClient, nil
+	}
+	opts, err := utils.GrpcClientOptions(gcp.Spec.CaBundle)
+	if err != nil {
+		return nil, err
+	}
+	client, err := container.NewClusterManagerClient(ctx,
+		append([]option.ClientOption{option.WithTokenSource(gcp.tokenSource)}, opts...)...)
+	if err != nil {
+		return nil, fmt.Errorf("Error creating cluster manager client: %v", err)
+	}
+	return &clusterManager{client: client}, nil
+}
+
+type deploymentManagerService struct {
+	service *deploymentmanager.Service
+	// serviceAccount is the credential the deployments are inserted and updated with, when set.
+	// Only the alpha API takes the credential of a deployment, so they're inserted and updated
+	// through alpha then.
+	serviceAccount string
+	alpha          *dmalpha.Service
+}
+
+// convertJson converts in to out, a type of another version of the API with the same fields.
+func convertJson(in interface{}, out interface{}) error {
+	buf, err := json.Marshal(in)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(buf, out)
+}
+
+// withCredential returns deployment, of the alpha API, with the credential of s.serviceAccount.
+func (s *deploymentManagerService) withCredential(
+	deployment *deploymentmanager.Deployment) (*dmalpha.Deployment, error) {
+	converted := &dmalpha.Deployment{}
+	if err := convertJson(deployment, converted); err != nil {
+		return nil, fmt.Errorf("couldn't convert deployment %v to the alpha API: %v", deployment.Name, err)
+	}
+	converted.Credential = &dmalpha.Credential{
+		ServiceAccount: &dmalpha.ServiceAccount{Email: s.serviceAccount},
+	}
+	return converted, nil
+}
+
+// fromAlphaOperation converts op, of the alpha API, to the v2 operation.
+func fromAlphaOperation(op *dmalpha.Operation, err error) (*deploymentmanager.Operation, error) {
+	if err != nil {
+		return nil, err
+	}
+	converted := &deploymentmanager.Operation{}
+	if err = convertJson(op, converted); err != nil {
+		return nil, fmt.Errorf("couldn't convert operation %v from the alpha API: %v", op.Name, err)
+	}
+	return converted, nil
+}
+
+func (s *deploymentManagerService) GetDeployment(ctx context.Context, project string,
+	name string) (*deploymentmanager.Deployment, error) {
+	return s.service.Deployments.Get(project, name).Context(ctx).Do()
+}
+
+func (s *deploymentManagerService) ListDeployments(ctx context.Context, project string,
+	f func(*deploymentmanager.DeploymentsListResponse) error) error {
+	return s.service.Deployments.List(project).Pages(ctx, f)
+}
+
+func (s *deploymentManagerService) InsertDeployment(ctx context.Context, project string,
+	deployment *deploymentmanager.Deployment, createPolicy string) (*deploymentmanager.Operation, error) {
+	if s.serviceAccount != "" {
+		converted, err := s.withCredential(deployment)
+		if err != nil {
+			return nil, err
+		}
+		call := s.alpha.Deployments.Insert(project, converted)
+		if createPolicy != "" {
+			call = call.CreatePolicy(createPolicy)
+		}
+		return fromAlphaOperation(call.Context(ctx).Do())
+	}
+	call := s.service.Deployments.Insert(project, deployment)
+	if createPolicy != "" {
+		call = call.CreatePolicy(createPolicy)
+	}
+	return call.Context(ctx).Do()
+}
+
+func (s *deploymentManagerService) UpdateDeployment(ctx context.Context, project string, name string,
+	deployment *deploymentmanager.Deployment, deletePolicy string) (*deploymentmanager.Operation, error) {
+	if s.serviceAccount != "" {
+		converted, err := s.withCredential(deployment)
+		if err != nil {
+			return nil, err
+		}
+		call := s.alpha.Deployments.Update(project, name, converted)
+		if deletePolicy != "" {
+			call = call.DeletePolicy(deletePolicy)
+		}
+		return fromAlphaOperation(call.Context(ctx).Do())
+	}
+	call := s.service.Deployments.Update(project, name, deployment)
+	if deletePolicy != "" {
+		call = call.DeletePolicy(deletePolicy)
+	}
+	return call.Context(ctx).Do()
+}
+
+func (s *deploymentManagerService) DeleteDeployment(ctx context.Context, project string,
+	name string, deletePolicy string) (*deploymentmanager.Operation, error) {
+	call := s.service.Deployments.Delete(project, name)
+	if deletePolicy != "" {
+		call = call.DeletePolicy(deletePolicy)
+	}
+	return call.Context(ctx).Do()
+}
+
+func (s *deploymentManagerService) StopDeployment(ctx context.Context, project string,
+	name string, fingerprint string) (*deploymentmanager.Operation, error) {
+	return s.service.Deployments.Stop(project, name,
+		&deploymentmanager.DeploymentsStopRequest{Fingerprint: fingerprint}).Context(ctx).Do()
+}
+
+func (s *deploymentManagerService) GetManifest(ctx context.Context, project string, deployment string,
+	manifest string) (*deploymentmanager.Manifest, error) {
+	return s.service.Manifests.Get(project, deployment, manifest).Context(ctx).Do()
+}
+
+func (s *deploymentManagerService) ListResources(ctx context.Context, project string,
+	deployment string) ([]*deploymentmanager.Resource, error) {
+	resources := []*deploymentmanager.Resource{}
+	err := s.service.Resources.List(project, deployment).Pages(ctx,
+		func(resp *deploymentmanager.ResourcesListResponse) error {
+			resources = append(resources, resp.Resources...)
+			return nil
+		})
+	return resources, err
+}
+
+func (s *deploymentManagerService) GetOperation(ctx context.Context, project string,
+	name string) (*deploymentmanager.Operation, error) {
+	return s.service.Operations.Get(project, name).Context(ctx).Do()
+}
+
+type iamService struct {
+	service *iam.Service
+}
+
+func (s *iamService) CreateServiceAccountKey(ctx context.Context, account string,
+	req *iam.CreateServiceAccountKeyRequest) (*iam.ServiceAccountKey, error) {
+	return s.service.Projects.ServiceAccounts.Keys.Create(account, req).Context(ctx).Do()
+}
+
+func (s *iamService) GetServiceAccountKey(ctx context.Context, name string) (*iam.ServiceAccountKey, error) {
+	return s.service.Projects.ServiceAccounts.Keys.Get(name).Context(ctx).Do()
+}
+
+func (s *iamService) ListServiceAccountKeys(ctx context.Context, account string,
+	keyType string) ([]*iam.ServiceAccountKey, error) {
+	call := s.service.Projects.ServiceAccounts.Keys.List(account)
+	if keyType != "" {
+		call = call.KeyTypes(keyType)
+	}
+	resp, err := call.Context(ctx).Do()
+	if err != nil {
+		return nil, err
+	}
+	return resp.Keys, nil
+}
+
+func (s *iamService) DeleteServiceAccountKey(ctx context.Context, name string) error {
+	_, err := s.service.Projects.ServiceAccounts.Keys.Delete(name).Context(ctx).Do()
+	return err
+}
+
+type serviceUsageService struct {
+	service *serviceusage.Service
+}
+
+func (s *serviceUsageService) BatchEnableServices(ctx context.Context, parent string,
+	req *serviceusage.BatchEnableServicesRequest) (*serviceusage.Operation, error) {
+	return s.service.Services.BatchEnable(parent, req).Context(ctx).Do()
+}
+
+func (s *serviceUsageService) GetOperation(ctx context.Context, name string) (*serviceusage.Operation, error) {
+	return s.service.Operations.Get(name).Context(ctx).Do()
+}
+
+type serviceManagementService struct {
+	service *servicemanagement.APIService
+}
+
+func (s *serviceManagementService) GetService(ctx context.Context,
+	name string) (*servicemanagement.ManagedService, error) {
+	return s.service.Services.Get(name).Context(ctx).Do()
+}
+
+func (s *serviceManagementService) ListServices(ctx context.Context, project string,
+	f func(*servicemanagement.ListServicesResponse) error) error {
+	return s.service.Services.List().ProducerProjectId(project).Pages(ctx, f)
+}
+
+func (s *serviceManagementService) CreateService(ctx context.Context,
+	service *servicemanagement.ManagedService) (*servicemanagement.Operation, error) {
+	return s.service.Services.Create(service).Context(ctx).Do()
+}
+
+func (s *serviceManagementService) GetServiceConfig(ctx context.Context,
+	name string) (*servicemanagement.Service, error) {
+	return s.service.Services.GetConfig(name).Context(ctx).Do()
+}
+
+func (s *serviceManagementService) SubmitConfigSource(ctx context.Context, name string,
+	req *servicemanagement.SubmitConfigSourceRequest) (*servicemanagement.Operation, error) {
+	return s.service.Services.Configs.Submit(name, req).Context(ctx).Do()
+}
+
+func (s *serviceManagementService) CreateRollout(ctx context.Context, name string,
+	rollout *servicemanagement.Rollout) (*servicemanagement.Operation, error) {
+	return s.service.Services.Rollouts.Create(name, rollout).Context(ctx).Do()
+}
+
+func (s *serviceManagementService) DeleteService(ctx context.Context,
+	name string) (*servicemanagement.Operation, error) {
+	return s.service.Services.Delete(name).Context(ctx).Do()
+}
+
+func (s *serviceManagementService) UndeleteService(ctx context.Context,
+	name string) (*servicemanagement.Operation, error) {
+	return s.service.Services.Undelete(name).Context(ctx).Do()
+}
+
+func (s *serviceManagementService) GetOperation(ctx context.Context,
+	name string) (*servicemanagement.Operation, error) {
+	return s.service.Operations.Get(name).Context(ctx).Do()
+}
+
+type clusterManager struct {
+	client *container.ClusterManagerClient
+}
+
+func (c *clusterManager) ListClusters(ctx context.Context,
+	req *containerpb.ListClustersRequest) (*containerpb.ListClustersResponse, error) {
+	return c.client.ListClusters(ctx, req)
+}
+
+func (c *clusterManager) ListNodePools(ctx context.Context,
+	req *containerpb.ListNodePoolsRequest) (*containerpb.ListNodePoolsResponse, error) {
+	return c.client.ListNodePools(ctx, req)
+}
+
+func (c *clusterManager) CreateNodePool(ctx context.Context,
+	req *containerpb.CreateNodePoolRequest) (*containerpb.Operation, error) {
+	return c.client.CreateNodePool(ctx, req)
+}
+
+func (c *clusterManager) DeleteNodePool(ctx context.Context,
+	req *containerpb.DeleteNodePoolRequest) (*containerpb.Operation, error) {
+	return c.client.DeleteNodePool(ctx, req)
+}
+
+func (c *clusterManager) SetNodePoolSize(ctx context.Context,
+	req *containerpb.SetNodePoolSizeRequest) (*containerpb.Operation, error) {
+	return c.client.SetNodePoolSize(ctx, req)
+}
+
+func (c *clusterManager) UpdateMaster(ctx context.Context,
+	req *containerpb.UpdateMasterRequest) (*containerpb.Operation, error) {
+	return c.client.UpdateMaster(ctx, req)
+}
+
+func (c *clusterManager) GetServerConfig(ctx context.Context,
+	req *containerpb.GetServerConfigRequest) (*containerpb.ServerConfig, error) {
+	return c.client.GetServerConfig(ctx, req)
+}
+
+func (c *clusterManager) GetOperation(ctx context.Context,
+	req *containerpb.GetOperationRequest) (*containerpb.Operation, error) {
+	return c.client.GetOperation(ctx, req)
+}
@@ -0,0 +1,381 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"fmt"
+	"path"
+	"reflect"
+	"strings"
+	"testing"
+
+	configtypes "github.com/kubeflow/kubeflow/bootstrap/config"
+	kftypes "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps"
+	kfdefs "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps/kfdef/v1alpha1"
+	"github.com/kubeflow/kubeflow/bootstrap/pkg/kfapp/gcp/fake"
+	"golang.org/x/net/context"
+	"google.golang.org/api/deploymentmanager/v2"
+	"google.golang.org/api/iam/v1"
+	containerpb "google.golang.org/genproto/googleapis/container/v1"
+)
+
+func newFakeKfDef() *kfdefs.KfDef {
+	kfdef := &kfdefs.KfDef{}
+	kfdef.Name = "kf"
+	kfdef.Spec.Platform = kftypes.GCP
+	kfdef.Spec.Project = "my-project"
+	kfdef.Spec.Zone = "us-east1-d"
+	kfdef.Spec.Email = "user@example.com"
+	kfdef.Spec.UseEmbeddedAssets = true
+	kfdef.Spec.ComponentParams = configtypes.Parameters{}
+	kfdef.Spec.Targets = []string{COMPONENT_STORAGE, COMPONENT_CLUSTER}
+	return kfdef
+}
+
+func TestApplyAndDeleteWithFakes(t *testing.T) {
+	dm := fake.NewDeploymentManager("my-project",
+		&deploymentmanager.Deployment{
+			Name: "other-storage",
+			Labels: []*deploymentmanager.DeploymentLabelEntry{
+				{Key: LABEL_CREATED_BY, Value: CREATED_BY_KFCTL},
+				{Key: LABEL_NAME, Value: "other"},
+				{Key: LABEL_COMPONENT, Value: COMPONENT_STORAGE},
+			},
+		})
+	gcp, err := NewGcp(newFakeKfDef(), Auth{OAuthClientId: "id", OAuthClientSecret: "secret"},
+		WithDeploymentManagerClient(dm), WithClock(fakeClock{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = gcp.Generate(kftypes.PLATFORM); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		if err = gcp.Apply(kftypes.PLATFORM); err != nil {
+			t.Fatalf("Apply %v failed: %v", i, err)
+		}
+	}
+	for _, name := range []string{"kf", "kf-storage"} {
+		d := dm.Deployment("my-project", name)
+		if d == nil {
+			t.Fatalf("deployment %v wasn't created", name)
+		}
+		if getLabel(d, LABEL_DEPLOYMENT_ID) != gcp.Spec.DeploymentId {
+			t.Errorf("deployment %v has labels %v; want the deployment id %v", name, d.Labels, gcp.Spec.DeploymentId)
+		}
+		if d.Target == nil || d.Target.Config == nil || d.Target.Config.Content == "" {
+			t.Errorf("deployment %v has no config", name)
+		}
+	}
+	updates := 0
+	for _, call := range dm.Calls {
+		if call == "update my-project/kf" || call == "update my-project/kf-storage" {
+			updates++
+		}
+	}
+	if updates != 2 {
+		t.Errorf("got %v updates in %v; want the second apply to update both deployments", updates, dm.Calls)
+	}
+
+	gcp.Spec.Targets = []string{COMPONENT_STORAGE}
+	if err = gcp.Delete(kftypes.PLATFORM); err == nil {
+		t.Errorf("Delete of the storage succeeded without --%v", kftypes.DELETE_STORAGE)
+	}
+	gcp.Spec.DeleteStorage = true
+	if err = gcp.Delete(kftypes.PLATFORM); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if dm.Deployment("my-project", "kf-storage") != nil {
+		t.Errorf("kf-storage wasn't deleted")
+	}
+	for _, name := range []string{"kf", "other-storage"} {
+		if dm.Deployment("my-project", name) == nil {
+			t.Errorf("%v was deleted", name)
+		}
+	}
+}
+
+func TestGcpInitProjectWithFake(t *testing.T) {
+	serviceUsage := fake.NewServiceUsage()
+	gcp := &Gcp{serviceUsageClient: serviceUsage, clock: fakeClock{}}
+	gcp.Spec.Project = "my-project"
+	gcp.Spec.EnableStackdriver = true
+	if err := gcp.gcpInitProject(); err != nil {
+		t.Fatalf("gcpInitProject failed: %v", err)
+	}
+	for _, batch := range serviceUsage.Batches {
+		if len(batch) > maxBatchEnable {
+			t.Errorf("batch of %v APIs; want at most %v", len(batch), maxBatchEnable)
+		}
+	}
+	for _, api := range gcp.projectApis() {
+		if !serviceUsage.Enabled("projects/my-project", api) {
+			t.Errorf("%v wasn't enabled", api)
+		}
+	}
+
+	gcp.Spec.HostProject = "host-project"
+	if err := gcp.gcpInitProject(); err != nil {
+		t.Fatalf("gcpInitProject with a host project failed: %v", err)
+	}
+	for _, api := range hostProjectApis {
+		if !serviceUsage.Enabled("projects/host-project", api) {
+			t.Errorf("%v wasn't enabled in the host project", api)
+		}
+	}
+}
+
+func TestCheckKeyQuotaWithFake(t *testing.T) {
+	account := "projects/my-project/serviceAccounts/kf-admin@my-project.iam.gserviceaccount.com"
+	keys := []*iam.ServiceAccountKey{}
+	for i := 0; i < SA_KEY_QUOTA; i++ {
+		keys = append(keys, &iam.ServiceAccountKey{
+			Name:           fmt.Sprintf("%v/keys/%v", account, i),
+			ValidAfterTime: fmt.Sprintf("2019-04-01T00:00:%02dZ", SA_KEY_QUOTA-i),
+		})
+	}
+	iamClient := fake.NewIam(keys...)
+	gcp := &Gcp{iamClient: iamClient}
+	gcp.Spec.ServiceAccountKeys = []string{account + "/keys/3", account + "/keys/5", account + "/keys/99"}
+
+	client, err := gcp.newIamClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = gcp.checkKeyQuota(context.Background(), client, account); err == nil {
+		t.Errorf("checkKeyQuota succeeded with %v keys without --%v", SA_KEY_QUOTA, kftypes.ROTATE_SA_KEYS)
+	}
+	gcp.Spec.RotateSaKeys = true
+	if err = gcp.checkKeyQuota(context.Background(), client, account); err != nil {
+		t.Fatalf("checkKeyQuota failed: %v", err)
+	}
+	if _, err = iamClient.GetServiceAccountKey(context.Background(), account+"/keys/5"); err == nil {
+		t.Errorf("the oldest recorded key wasn't deleted")
+	}
+	if expected := []string{account + "/keys/3"}; !reflect.DeepEqual(gcp.Spec.ServiceAccountKeys, expected) {
+		t.Errorf("recorded keys: got %v; want %v", gcp.Spec.ServiceAccountKeys, expected)
+	}
+}
+
+func TestServiceAcctSecretWithFake(t *testing.T) {
+	secretJson := `{"metadata": {"name": "` + ADMIN_SECRET_NAME + `"}, "data": {"key.json": "e30="}}`
+	email := "kf-admin@my-project.iam.gserviceaccount.com"
+	namespaces := []string{"kubeflow", "istio-system"}
+	cases := []struct {
+		name      string
+		apis      fakeKubeApis
+		createKey bool
+		fails     bool
+		keys      int
+	}{
+		{
+			name: "copied from kubeflow",
+			apis: fakeKubeApis{
+				"/api/v1/namespaces/kubeflow/secrets/" + ADMIN_SECRET_NAME: secretJson,
+				"/api/v1/namespaces/istio-system/secrets":                  secretJson,
+			},
+		},
+		{
+			name:  "no secret to copy",
+			apis:  fakeKubeApis{},
+			fails: true,
+		},
+		{
+			name: "new key",
+			apis: fakeKubeApis{
+				"/api/v1/namespaces/kubeflow/secrets":     secretJson,
+				"/api/v1/namespaces/istio-system/secrets": secretJson,
+			},
+			createKey: true,
+			keys:      1,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			client, done := newFakeClientset(t, c.apis)
+			defer done()
+			iamClient := fake.NewIam()
+			gcp := &Gcp{iamClient: iamClient, clock: fakeClock{}}
+			gcp.Spec.Project = "my-project"
+			err := gcp.createGcpServiceAcctSecret(context.Background(), client, email, ADMIN_SECRET_NAME,
+				namespaces, c.createKey)
+			if (err != nil) != c.fails {
+				t.Errorf("createGcpServiceAcctSecret got error %v; want failure %v", err, c.fails)
+			}
+			if keys := iamClient.Keys(); len(keys) != c.keys {
+				t.Errorf("created keys %v; want %v", keys, c.keys)
+			}
+		})
+	}
+}
+
+func TestResizeNodePoolWithFake(t *testing.T) {
+	container := fake.NewContainer(&containerpb.Cluster{
+		Name: "kf",
+		NodePools: []*containerpb.NodePool{
+			{Name: "kf-cpu-pool-v1", InitialNodeCount: 2, Config: &containerpb.NodeConfig{MachineType: "n1-standard-8"}},
+		},
+	})
+	gcp, err := NewGcp(newFakeKfDef(), Auth{OAuthClientId: "id", OAuthClientSecret: "secret"},
+		WithContainerClient(container), WithClock(fakeClock{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = gcp.ResizeNodePool(CPU_POOL, 4); err != nil {
+		t.Fatalf("ResizeNodePool failed: %v", err)
+	}
+	if nodes := container.Cluster("kf").NodePools[0].InitialNodeCount; nodes != 4 {
+		t.Errorf("the pool has %v nodes; want 4", nodes)
+	}
+	if err = gcp.SetNodePoolMachineType(CPU_POOL, "n1-highmem-8"); err != nil {
+		t.Fatalf("SetNodePoolMachineType failed: %v", err)
+	}
+	pools := container.Cluster("kf").NodePools
+	if len(pools) != 1 || pools[0].Name != "kf-cpu-pool-v2" || pools[0].Config.MachineType != "n1-highmem-8" {
+		t.Errorf("got node pools %v; want kf-cpu-pool-v2 with n1-highmem-8", pools)
+	}
+	expected := []kfdefs.NodePool{{Pool: CPU_POOL, Nodes: 4, MachineType: "n1-highmem-8", PoolVersion: "v2"}}
+	if !reflect.DeepEqual(gcp.Spec.NodePools, expected) {
+		t.Errorf("spec.nodePools: got %v; want %v", gcp.Spec.NodePools, expected)
+	}
+	// The DM config names the new pool, so updating the deployment keeps it.
+	config, err := gcp.store.ReadFile(path.Join(GCP_CONFIG, CONFIG_FILE))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(config), CPU_POOL+"-version: v2") {
+		t.Errorf("%v doesn't set %v-version to v2:\n%s", CONFIG_FILE, CPU_POOL, config)
+	}
+}
+
+func TestPoolVersion(t *testing.T) {
+	cases := map[string]string{
+		"kf-cpu-pool-v1":  "v1",
+		"kf-cpu-pool-v12": "v12",
+		"kf-cpu-pool":     "",
+	}
+	for name, expected := range cases {
+		if version := poolVersion(name); version != expected {
+			t.Errorf("poolVersion(%v) got %q; want %q", name, version, expected)
+		}
+	}
+	if next := nextPoolName("kf-gpu-pool-v9"); next != "kf-gpu-pool-v10" {
+		t.Errorf("nextPoolName got %v; want kf-gpu-pool-v10", next)
+	}
+}
+
+func TestEndpointsWithFake(t *testing.T) {
+	servicemanagement := fake.NewServiceManagement()
+	gcp := &Gcp{serviceManagementClient: servicemanagement, clock: fakeClock{}}
+	gcp.Spec.Project = "my-project"
+	gcp.Spec.Hostname = "kf.endpoints.my-project.cloud.goog"
+	if !gcp.isEndpointsHostname() {
+		t.Fatalf("%v isn't an endpoints hostname", gcp.Spec.Hostname)
+	}
+	ctx := context.Background()
+	for _, ip := range []string{"35.1.2.3", "35.1.2.3", "35.4.5.6"} {
+		if err := gcp.submitEndpoints(ctx, servicemanagement, ip); err != nil {
+			t.Fatalf("submitEndpoints %v failed: %v", ip, err)
+		}
+		if target := servicemanagement.Target(gcp.Spec.Hostname); target != ip {
+			t.Errorf("%v resolves to %v; want %v", gcp.Spec.Hostname, target, ip)
+		}
+	}
+	submits := 0
+	for _, call := range servicemanagement.Calls {
+		if call == "submit "+gcp.Spec.Hostname {
+			submits++
+		}
+	}
+	if submits != 2 {
+		t.Errorf("got %v submits in %v; want none for an unchanged IP", submits, servicemanagement.Calls)
+	}
+
+	deleted, err := gcp.deleteEndpoints(ctx)
+	if err != nil || !deleted {
+		t.Fatalf("deleteEndpoints = %v, %v; want true", deleted, err)
+	}
+	if remaining, err := gcp.remainingEndpoints(ctx); err != nil || len(remaining) != 0 {
+		t.Errorf("remainingEndpoints = %v, %v; want none", remaining, err)
+	}
+	if deleted, err = gcp.deleteEndpoints(ctx); err != nil || deleted {
+		t.Errorf("deleteEndpoints of a deleted service = %v, %v; want false", deleted, err)
+	}
+	// The name of a deleted service can't be reused; it's undeleted instead.
+	if err = gcp.submitEndpoints(ctx, servicemanagement, "35.7.8.9"); err != nil {
+		t.Fatalf("submitEndpoints after delete failed: %v", err)
+	}
+	if target := servicemanagement.Target(gcp.Spec.Hostname); target != "35.7.8.9" {
+		t.Errorf("%v resolves to %v; want 35.7.8.9", gcp.Spec.Hostname, target)
+	}
+}
+
+func TestGarbageCollectWithFakes(t *testing.T) {
+	deployment := func(name string, app string, id string, component string, inserted string) *deploymentmanager.Deployment {
+		return &deploymentmanager.Deployment{
+			Name:       name,
+			InsertTime: inserted,
+			Labels: []*deploymentmanager.DeploymentLabelEntry{
+				{Key: LABEL_CREATED_BY, Value: CREATED_BY_KFCTL},
+				{Key: LABEL_NAME, Value: app},
+				{Key: LABEL_DEPLOYMENT_ID, Value: id},
+				{Key: LABEL_COMPONENT, Value: component},
+			},
+		}
+	}
+	old := "2019-03-01T00:00:00Z"
+	dm := fake.NewDeploymentManager("my-project",
+		deployment("kf", "kf", "live-id", COMPONENT_CLUSTER, old),
+		deployment("kf-storage", "kf", "live-id", COMPONENT_STORAGE, old),
+		deployment("kf-old-storage", "kf", "old-id", COMPONENT_STORAGE, old),
+		deployment("kf-old-network", "kf", "old-id", COMPONENT_NETWORK, old),
+		// Inserted 5 minutes before fakeClock's now, e.g. by an apply creating the cluster next.
+		deployment("kf-new-storage", "kf", "new-id", COMPONENT_STORAGE, "2019-03-31T23:55:00Z"),
+		deployment("other-storage", "other", "other-id", COMPONENT_STORAGE, old))
+	gcp, err := NewGcp(newFakeKfDef(), Auth{OAuthClientId: "id", OAuthClientSecret: "secret"},
+		WithDeploymentManagerClient(dm), WithClock(fakeClock{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	gcp.Spec.DeploymentId = "live-id"
+
+	gcp.Spec.DeletionProtection = true
+	if err = gcp.GarbageCollect(false); err == nil {
+		t.Errorf("GarbageCollect succeeded with deletion protection")
+	}
+	gcp.Spec.DeletionProtection = false
+	if err = gcp.GarbageCollect(false); err != nil {
+		t.Fatalf("GarbageCollect failed: %v", err)
+	}
+	if dm.Deployment("my-project", "kf-old-network") != nil {
+		t.Errorf("kf-old-network wasn't deleted")
+	}
+	for _, name := range []string{"kf", "kf-storage", "kf-old-storage", "kf-new-storage", "other-storage"} {
+		if dm.Deployment("my-project", name) == nil {
+			t.Errorf("%v was deleted", name)
+		}
+	}
+
+	gcp.Spec.DeleteStorage = true
+	if err = gcp.GarbageCollect(false); err != nil {
+		t.Fatalf("GarbageCollect failed: %v", err)
+	}
+	if dm.Deployment("my-project", "kf-old-storage") != nil {
+		t.Errorf("kf-old-storage wasn't deleted with DeleteStorage")
+	}
+	if dm.Deployment("my-project", "kf-new-storage") == nil || dm.Deployment("my-project", "other-storage") == nil {
+		t.Errorf("GarbageCollect deleted a young deployment or one of another app")
+	}
+}
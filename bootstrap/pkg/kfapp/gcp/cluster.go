@@ -0,0 +1,265 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"fmt"
+	"github.com/cenkalti/backoff"
+	kfapis "github.com/kubeflow/kubeflow/bootstrap/pkg/apis"
+	kfdefs "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps/kfdef/v1alpha1"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+	containerpb "google.golang.org/genproto/googleapis/container/v1"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// nodePoolTimeout bounds the wait for a node pool operation; recreating a pool drains its nodes.
+const nodePoolTimeout = 30 * time.Minute
+
+// poolVersionPattern matches the pool-version suffix cluster.jinja gives node pool names.
+var poolVersionPattern = regexp.MustCompile("^(.*)-v([0-9]+)$")
+
+// ResizeNodePool sets the number of nodes of pool, cpu-pool or gpu-pool, through the GKE API and
+// records it in spec.nodePools with the pool version of the live pool.
+func (gcp *Gcp) ResizeNodePool(pool string, nodes int) error {
+	if err := checkPool(pool); err != nil {
+		return err
+	}
+	if nodes < 0 {
+		return &kfapis.KfError{
+			Code:    int(kfapis.INVALID_ARGUMENT),
+			Message: fmt.Sprintf("can't resize %v to %v nodes", pool, nodes),
+		}
+	}
+	ctx := context.Background()
+	client, err := gcp.newContainerClient(ctx)
+	if err != nil {
+		return err
+	}
+	nodePool, err := gcp.getNodePool(ctx, client, pool)
+	if err != nil {
+		return err
+	}
+	if autoscaling := nodePool.Autoscaling; autoscaling != nil && autoscaling.Enabled {
+		if int32(nodes) < autoscaling.MinNodeCount || int32(nodes) > autoscaling.MaxNodeCount {
+			return &kfapis.KfError{
+				Code: int(kfapis.INVALID_ARGUMENT),
+				Message: fmt.Sprintf("%v autoscales between %v and %v nodes", nodePool.Name,
+					autoscaling.MinNodeCount, autoscaling.MaxNodeCount),
+			}
+		}
+		log.Warnf("%v autoscales; the autoscaler may change its size again", nodePool.Name)
+	}
+	log.Infof("Resizing node pool %v to %v nodes", nodePool.Name, nodes)
+	op, err := client.SetNodePoolSize(ctx, &containerpb.SetNodePoolSizeRequest{
+		ProjectId:  gcp.Spec.Project,
+		Zone:       gcp.Spec.Zone,
+		ClusterId:  gcp.Name,
+		NodePoolId: nodePool.Name,
+		NodeCount:  int32(nodes),
+	})
+	if err != nil {
+		return fmt.Errorf("couldn't resize node pool %v: %v", nodePool.Name, err)
+	}
+	if err = gcp.waitForNodePoolOperation(ctx, client, op); err != nil {
+		return err
+	}
+	return gcp.recordNodePool(pool, nodes, nodePool.Config.MachineType, poolVersion(nodePool.Name))
+}
+
+// SetNodePoolMachineType moves pool, cpu-pool or gpu-pool, to machineType and records it in
+// spec.nodePools. GKE can't change the machine type of a node pool, so a copy of the pool is
+// created with the next pool version and the old pool is deleted once it's ready, rescheduling
+// its pods on the new one. The new pool version is recorded too, so the DM config names the pool
+// that exists and the next update of the deployment doesn't recreate it.
+func (gcp *Gcp) SetNodePoolMachineType(pool string, machineType string) error {
+	if err := checkPool(pool); err != nil {
+		return err
+	}
+	if machineType == "" {
+		return &kfapis.KfError{
+			Code:    int(kfapis.INVALID_ARGUMENT),
+			Message: "machine type is required",
+		}
+	}
+	ctx := context.Background()
+	client, err := gcp.newContainerClient(ctx)
+	if err != nil {
+		return err
+	}
+	nodePool, err := gcp.getNodePool(ctx, client, pool)
+	if err != nil {
+		return err
+	}
+	nodes := int(nodePool.InitialNodeCount)
+	if nodePool.Config.MachineType == machineType {
+		log.Infof("Node pool %v already uses %v", nodePool.Name, machineType)
+		return gcp.recordNodePool(pool, nodes, machineType, poolVersion(nodePool.Name))
+	}
+	config := *nodePool.Config
+	config.MachineType = machineType
+	newPool := &containerpb.NodePool{
+		Name:             nextPoolName(nodePool.Name),
+		Config:           &config,
+		InitialNodeCount: nodePool.InitialNodeCount,
+		Autoscaling:      nodePool.Autoscaling,
+		Management:       nodePool.Management,
+		Version:          nodePool.Version,
+	}
+	log.Infof("Creating node pool %v with machine type %v", newPool.Name, machineType)
+	op, err := client.CreateNodePool(ctx, &containerpb.CreateNodePoolRequest{
+		ProjectId: gcp.Spec.Project,
+		Zone:      gcp.Spec.Zone,
+		ClusterId: gcp.Name,
+		NodePool:  newPool,
+	})
+	if err != nil {
+		return fmt.Errorf("couldn't create node pool %v: %v", newPool.Name, err)
+	}
+	if err = gcp.waitForNodePoolOperation(ctx, client, op); err != nil {
+		return err
+	}
+	log.Infof("Deleting node pool %v", nodePool.Name)
+	op, err = client.DeleteNodePool(ctx, &containerpb.DeleteNodePoolRequest{
+		ProjectId:  gcp.Spec.Project,
+		Zone:       gcp.Spec.Zone,
+		ClusterId:  gcp.Name,
+		NodePoolId: nodePool.Name,
+	})
+	if err != nil {
+		return fmt.Errorf("couldn't delete node pool %v: %v", nodePool.Name, err)
+	}
+	if err = gcp.waitForNodePoolOperation(ctx, client, op); err != nil {
+		return err
+	}
+	return gcp.recordNodePool(pool, nodes, machineType, poolVersion(newPool.Name))
+}
+
+func checkPool(pool string) error {
+	if pool != CPU_POOL && pool != GPU_POOL {
+		return &kfapis.KfError{
+			Code:    int(kfapis.INVALID_ARGUMENT),
+			Message: fmt.Sprintf("unknown node pool %v; must be %v or %v", pool, CPU_POOL, GPU_POOL),
+		}
+	}
+	return nil
+}
+
+// getNodePool returns the live node pool of pool, named <name>-<pool>-<pool version> by
+// cluster.jinja and SetNodePoolMachineType.
+func (gcp *Gcp) getNodePool(ctx context.Context, client ContainerClient,
+	pool string) (*containerpb.NodePool, error) {
+	list, err := client.ListNodePools(ctx, &containerpb.ListNodePoolsRequest{
+		ProjectId: gcp.Spec.Project,
+		Zone:      gcp.Spec.Zone,
+		ClusterId: gcp.Name,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("couldn't list node pools of %v: %v", gcp.Name, err)
+	}
+	prefix := gcp.Name + "-" + pool + "-"
+	for _, nodePool := range list.NodePools {
+		if strings.HasPrefix(nodePool.Name, prefix) {
+			return nodePool, nil
+		}
+	}
+	return nil, &kfapis.KfError{
+		Code:    int(kfapis.INVALID_ARGUMENT),
+		Message: fmt.Sprintf("cluster %v has no node pool %v", gcp.Name, pool),
+	}
+}
+
+// nextPoolName bumps the pool version suffix of name, e.g. kubeflow-cpu-pool-v1 to kubeflow-cpu-pool-v2.
+func nextPoolName(name string) string {
+	match := poolVersionPattern.FindStringSubmatch(name)
+	if match == nil {
+		return name + "-v2"
+	}
+	version, _ := strconv.Atoi(match[2])
+	return fmt.Sprintf("%v-v%v", match[1], version+1)
+}
+
+// poolVersion returns the pool version suffix of name, e.g. v2 for kubeflow-cpu-pool-v2, or ""
+// when it has none.
+func poolVersion(name string) string {
+	match := poolVersionPattern.FindStringSubmatch(name)
+	if match == nil {
+		return ""
+	}
+	return "v" + match[2]
+}
+
+func (gcp *Gcp) waitForNodePoolOperation(ctx context.Context, client ContainerClient,
+	op *containerpb.Operation) error {
+	b := backoff.NewExponentialBackOff()
+	b.MaxElapsedTime = nodePoolTimeout
+	return gcp.retry(func() error {
+		current, err := client.GetOperation(ctx, &containerpb.GetOperationRequest{
+			ProjectId:   gcp.Spec.Project,
+			Zone:        gcp.Spec.Zone,
+			OperationId: op.Name,
+		})
+		if err != nil {
+			return fmt.Errorf("couldn't get operation %v: %v", op.Name, err)
+		}
+		if current.Status != containerpb.Operation_DONE {
+			return fmt.Errorf("%v of %v did not finish; status: %v", current.OperationType, current.TargetLink,
+				current.Status)
+		}
+		if current.StatusMessage != "" {
+			return backoff.Permanent(fmt.Errorf("%v of %v failed: %v", current.OperationType, current.TargetLink,
+				current.StatusMessage))
+		}
+		return nil
+	}, b)
+}
+
+// recordNodePool records the size, machine type and pool version of pool in spec.nodePools and
+// regenerates the cluster config from it, so a new cluster is created like the current one.
+func (gcp *Gcp) recordNodePool(pool string, nodes int, machineType string, version string) error {
+	nodePool := kfdefs.NodePool{
+		Pool:        pool,
+		Nodes:       nodes,
+		MachineType: machineType,
+		PoolVersion: version,
+	}
+	found := false
+	for i, p := range gcp.Spec.NodePools {
+		if p.Pool == pool {
+			gcp.Spec.NodePools[i] = nodePool
+			found = true
+		}
+	}
+	if !found {
+		gcp.Spec.NodePools = append(gcp.Spec.NodePools, nodePool)
+	}
+	return gcp.writeClusterConfigs()
+}
+
+// writeClusterConfigs regenerates the DM configs from the spec after a change of the cluster,
+// and writes them with app.yaml.
+func (gcp *Gcp) writeClusterConfigs() error {
+	bundle := NewBundle()
+	if err := gcp.generateDMConfigs(bundle); err != nil {
+		return fmt.Errorf("could not regenerate deployment manager configs: %v", err)
+	}
+	if err := gcp.putConfigFile(bundle); err != nil {
+		return err
+	}
+	return gcp.store.WriteBundle(bundle)
+}
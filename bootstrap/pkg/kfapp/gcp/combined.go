@@ -0,0 +1,192 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"fmt"
+	"github.com/ghodss/yaml"
+	kfapis "github.com/kubeflow/kubeflow/bootstrap/pkg/apis"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+	"google.golang.org/api/deploymentmanager/v2"
+	"google.golang.org/api/googleapi"
+	"os"
+	"path"
+	"strings"
+)
+
+const (
+	// COMBINED_FILE is the DM config of spec.combinedDeployment, generated from the others.
+	COMBINED_FILE = "combined-kubeflow.yaml"
+	RESOURCES     = "resources"
+)
+
+// combinedParts are the configs merged into COMBINED_FILE; network and gcfs are optional.
+var combinedParts = []struct {
+	component string
+	file      string
+	required  bool
+}{
+	{COMPONENT_STORAGE, STORAGE_FILE, true},
+	{COMPONENT_CLUSTER, CONFIG_FILE, true},
+	{COMPONENT_NETWORK, NETWORK_FILE, false},
+	{COMPONENT_GCFS, GCFS_FILE, false},
+}
+
+// storageDeploymentName is the DM deployment holding the storage resources. The storage
+// template names the disks and database after it.
+func (gcp *Gcp) storageDeploymentName() string {
+	if gcp.Spec.CombinedDeployment {
+		return gcp.Name
+	}
+	return gcp.Name + "-storage"
+}
+
+// writeCombinedConfig merges the DM configs of bundle into COMBINED_FILE. The optional configs
+// that weren't generated, e.g. network and gcfs, are taken from the store. The top level
+// resources of each config are prefixed with their component, as every config names its own
+// "kubeflow"; references between the templates' resources are kept as they are, DM orders them
+// within the one deployment.
+func (gcp *Gcp) writeCombinedConfig(bundle *Bundle) error {
+	imports := []interface{}{}
+	importNames := map[string]bool{}
+	resources := []interface{}{}
+	for _, part := range combinedParts {
+		buf, ok := bundle.Get(path.Join(GCP_CONFIG, part.file))
+		if !ok {
+			var err error
+			buf, err = gcp.readConfig(part.file)
+			if err != nil {
+				if os.IsNotExist(err) && !part.required {
+					continue
+				}
+				return fmt.Errorf("Reading config file error: %v", err)
+			}
+		}
+		var config map[string]interface{}
+		if err := yaml.Unmarshal(buf, &config); err != nil {
+			return fmt.Errorf("Unable to read YAML %v: %v", part.file, err)
+		}
+		if entries, ok := config[IMPORTS].([]interface{}); ok {
+			for _, entry := range entries {
+				entryMap, _ := entry.(map[string]interface{})
+				name, _ := entryMap[NAME].(string)
+				if name == "" {
+					name, _ = entryMap[PATH].(string)
+				}
+				if importNames[name] {
+					continue
+				}
+				importNames[name] = true
+				imports = append(imports, entry)
+			}
+		}
+		entries, _ := config[RESOURCES].([]interface{})
+		for _, entry := range entries {
+			resource := entry.(map[string]interface{})
+			resource["name"] = fmt.Sprintf("%v-%v", part.component, resource["name"])
+			resources = append(resources, resource)
+		}
+	}
+	buf, err := yaml.Marshal(map[string]interface{}{
+		IMPORTS:   imports,
+		RESOURCES: resources,
+	})
+	if err != nil {
+		return fmt.Errorf("couldn't marshal %v: %v", COMBINED_FILE, err)
+	}
+	bundle.Put(path.Join(GCP_CONFIG, COMBINED_FILE), buf)
+	return nil
+}
+
+// updateCombinedDeployment creates or updates the single deployment of spec.combinedDeployment.
+// Apps with split deployments would have their resources created twice, so they're refused.
+func (gcp *Gcp) updateCombinedDeployment() error {
+	ctx := context.Background()
+	deploymentmanagerService, err := gcp.newDeploymentManagerClient()
+	if err != nil {
+		return err
+	}
+	split, err := liveDeployments(ctx, deploymentmanagerService, gcp.Spec.Project,
+		[]string{gcp.Name + "-storage", gcp.Name + "-network", gcp.Name + "-gcfs"})
+	if err != nil {
+		return err
+	}
+	if len(split) > 0 {
+		return &kfapis.KfError{
+			Code: int(kfapis.INVALID_ARGUMENT),
+			Message: fmt.Sprintf("%v already has the deployments %v; combinedDeployment only applies to new apps",
+				gcp.Name, strings.Join(split, ", ")),
+		}
+	}
+	return gcp.updateDeployment(gcp.Name, COMBINED_FILE, COMPONENT_COMBINED)
+}
+
+// abandonStorage updates the combined deployment name without the resources of the kept
+// components, e.g. storage, keeping them in the project, so deleting the deployment spares them
+// like the split deployments. The config is taken from the deployment's live manifest.
+func (gcp *Gcp) abandonStorage(deploymentmanagerService DeploymentManagerClient, ctx context.Context,
+	project string, name string, kept map[string]bool) error {
+	d, err := deploymentmanagerService.GetDeployment(ctx, project, name)
+	if err != nil {
+		if e, ok := err.(*googleapi.Error); ok && e.Code == 404 {
+			return nil
+		}
+		return fmt.Errorf("Deployment %v/%v has unexpected error: %v", project, name, err)
+	}
+	if d.Manifest == "" {
+		return nil
+	}
+	manifest, err := deploymentmanagerService.GetManifest(ctx, project, name, path.Base(d.Manifest))
+	if err != nil {
+		return fmt.Errorf("couldn't get manifest of %v/%v: %v", project, name, err)
+	}
+	var config map[string]interface{}
+	if err = yaml.Unmarshal([]byte(manifest.Config.Content), &config); err != nil {
+		return fmt.Errorf("Unable to read YAML of %v/%v: %v", project, name, err)
+	}
+	entries, _ := config[RESOURCES].([]interface{})
+	remaining := []interface{}{}
+	for _, entry := range entries {
+		resourceName, _ := entry.(map[string]interface{})["name"].(string)
+		if kept[strings.SplitN(resourceName, "-", 2)[0]] {
+			log.Infof("Keeping %v of %v/%v", resourceName, project, name)
+			continue
+		}
+		remaining = append(remaining, entry)
+	}
+	if len(remaining) == len(entries) {
+		return nil
+	}
+	config[RESOURCES] = remaining
+	buf, err := yaml.Marshal(config)
+	if err != nil {
+		return err
+	}
+	dp := &deploymentmanager.Deployment{
+		Name:        name,
+		Fingerprint: d.Fingerprint,
+		Labels:      d.Labels,
+		Target: &deploymentmanager.TargetConfiguration{
+			Config:  &deploymentmanager.ConfigFile{Content: string(buf)},
+			Imports: manifest.Imports,
+		},
+	}
+	op, err := deploymentmanagerService.UpdateDeployment(ctx, project, name, dp, "ABANDON")
+	if err != nil {
+		return fmt.Errorf("couldn't abandon the storage of %v/%v: %v", project, name, err)
+	}
+	return gcp.blockingWait(project, op.Name, deploymentmanagerService, ctx, "Abandoning storage of "+name)
+}
@@ -0,0 +1,210 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"path"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ghodss/yaml"
+	kfapis "github.com/kubeflow/kubeflow/bootstrap/pkg/apis"
+	kftypes "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps"
+	"github.com/kubeflow/kubeflow/bootstrap/pkg/kfapp/gcp/fake"
+	"golang.org/x/net/context"
+	"google.golang.org/api/deploymentmanager/v2"
+)
+
+// resourceNames returns the names of the top level resources of the DM config content.
+func resourceNames(t *testing.T, content []byte) []string {
+	var config struct {
+		Resources []struct {
+			Name string `json:"name"`
+		} `json:"resources"`
+	}
+	if err := yaml.Unmarshal(content, &config); err != nil {
+		t.Fatalf("couldn't read config: %v", err)
+	}
+	names := []string{}
+	for _, resource := range config.Resources {
+		names = append(names, resource.Name)
+	}
+	return names
+}
+
+func TestWriteCombinedConfig(t *testing.T) {
+	store := NewBundle()
+	store.Put(path.Join(GCP_CONFIG, NETWORK_FILE), []byte(`
+imports:
+- path: network.jinja
+resources:
+- name: kubeflow
+  type: network.jinja
+`))
+	gcp := &Gcp{store: store}
+	bundle := NewBundle()
+	bundle.Put(path.Join(GCP_CONFIG, STORAGE_FILE), []byte(`
+imports:
+- path: storage.jinja
+- path: common.jinja
+resources:
+- name: kubeflow
+  type: storage.jinja
+`))
+	bundle.Put(path.Join(GCP_CONFIG, CONFIG_FILE), []byte(`
+imports:
+- path: common.jinja
+- path: cluster.jinja
+  name: cluster
+resources:
+- name: kubeflow
+  type: cluster
+`))
+	if err := gcp.writeCombinedConfig(bundle); err != nil {
+		t.Fatalf("writeCombinedConfig failed: %v", err)
+	}
+	content, ok := bundle.Get(path.Join(GCP_CONFIG, COMBINED_FILE))
+	if !ok {
+		t.Fatalf("%v wasn't generated", COMBINED_FILE)
+	}
+	want := []string{"storage-kubeflow", "cluster-kubeflow", "network-kubeflow"}
+	if got := resourceNames(t, content); !reflect.DeepEqual(got, want) {
+		t.Errorf("combined resources %v; want %v", got, want)
+	}
+	var config struct {
+		Imports []map[string]string `json:"imports"`
+	}
+	if err := yaml.Unmarshal(content, &config); err != nil {
+		t.Fatal(err)
+	}
+	wantImports := []map[string]string{
+		{"path": "storage.jinja"},
+		{"path": "common.jinja"},
+		{"path": "cluster.jinja", "name": "cluster"},
+		{"path": "network.jinja"},
+	}
+	if !reflect.DeepEqual(config.Imports, wantImports) {
+		t.Errorf("combined imports %v; want %v", config.Imports, wantImports)
+	}
+
+	// The storage and cluster configs are required.
+	bundle = NewBundle()
+	bundle.Put(path.Join(GCP_CONFIG, STORAGE_FILE), []byte("resources: []\n"))
+	if err := gcp.writeCombinedConfig(bundle); err == nil {
+		t.Errorf("writeCombinedConfig without %v succeeded", CONFIG_FILE)
+	}
+}
+
+func TestCombinedDeploymentWithFakes(t *testing.T) {
+	dm := fake.NewDeploymentManager("my-project")
+	kfdef := newFakeKfDef()
+	kfdef.Spec.CombinedDeployment = true
+	gcp, err := NewGcp(kfdef, Auth{OAuthClientId: "id", OAuthClientSecret: "secret"},
+		WithDeploymentManagerClient(dm), WithClock(fakeClock{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = gcp.Generate(kftypes.PLATFORM); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	if err = gcp.Apply(kftypes.PLATFORM); err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+	d := dm.Deployment("my-project", "kf")
+	if d == nil {
+		t.Fatalf("deployment kf wasn't created; calls %v", dm.Calls)
+	}
+	if getLabel(d, LABEL_COMPONENT) != COMPONENT_COMBINED {
+		t.Errorf("deployment kf has labels %v; want component %v", d.Labels, COMPONENT_COMBINED)
+	}
+	names := strings.Join(resourceNames(t, []byte(d.Target.Config.Content)), " ")
+	if !strings.Contains(names, "storage-") || !strings.Contains(names, "cluster-") {
+		t.Errorf("deployment kf has resources %v; want those of storage and cluster", names)
+	}
+	if dm.Deployment("my-project", "kf-storage") != nil {
+		t.Errorf("split deployment kf-storage created with combinedDeployment")
+	}
+
+	// Apps with split deployments aren't combined.
+	dm = fake.NewDeploymentManager("my-project", &deploymentmanager.Deployment{Name: "kf-storage"})
+	gcp.dmClient = dm
+	err = gcp.Apply(kftypes.PLATFORM)
+	if err == nil || !strings.Contains(err.Error(), "kf-storage") {
+		t.Errorf("Apply with a split deployment = %v; want it refused", err)
+	}
+	if dm.Deployment("my-project", "kf") != nil {
+		t.Errorf("combined deployment created next to the split ones")
+	}
+}
+
+func TestAbandonStorage(t *testing.T) {
+	config := `
+resources:
+- name: storage-kubeflow
+  type: storage.jinja
+- name: cluster-kubeflow
+  type: cluster.jinja
+`
+	dm := fake.NewDeploymentManager("my-project", &deploymentmanager.Deployment{
+		Name: "kf",
+		Target: &deploymentmanager.TargetConfiguration{
+			Config: &deploymentmanager.ConfigFile{Content: config},
+		},
+	})
+	gcp := &Gcp{clock: fakeClock{}}
+	ctx := context.Background()
+	if err := gcp.abandonStorage(dm, ctx, "my-project", "kf", map[string]bool{COMPONENT_GCFS: true}); err != nil {
+		t.Fatalf("abandonStorage failed: %v", err)
+	}
+	for _, call := range dm.Calls {
+		if strings.HasPrefix(call, "update") {
+			t.Errorf("deployment updated without resources to keep: %v", dm.Calls)
+		}
+	}
+
+	kept := map[string]bool{COMPONENT_STORAGE: true, COMPONENT_GCFS: true}
+	if err := gcp.abandonStorage(dm, ctx, "my-project", "kf", kept); err != nil {
+		t.Fatalf("abandonStorage failed: %v", err)
+	}
+	if dm.Calls[len(dm.Calls)-1] != "update ABANDON my-project/kf" {
+		t.Errorf("calls %v; want the storage abandoned", dm.Calls)
+	}
+	d := dm.Deployment("my-project", "kf")
+	if names := resourceNames(t, []byte(d.Target.Config.Content)); !reflect.DeepEqual(names, []string{"cluster-kubeflow"}) {
+		t.Errorf("resources left %v; want cluster-kubeflow", names)
+	}
+
+	// A deployment already gone has nothing to abandon.
+	if err := gcp.abandonStorage(dm, ctx, "my-project", "missing", kept); err != nil {
+		t.Errorf("abandonStorage of a missing deployment = %v", err)
+	}
+}
+
+func TestCombinedDeleteNeedsAllTargets(t *testing.T) {
+	kfdef := newFakeKfDef()
+	kfdef.Spec.CombinedDeployment = true
+	kfdef.Spec.DeleteStorage = true
+	gcp, err := NewGcp(kfdef, Auth{OAuthClientId: "id", OAuthClientSecret: "secret"},
+		WithDeploymentManagerClient(fake.NewDeploymentManager("my-project")), WithClock(fakeClock{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = gcp.Delete(kftypes.PLATFORM)
+	kfErr, ok := err.(*kfapis.KfError)
+	if !ok || kfErr.Code != int(kfapis.INVALID_ARGUMENT) {
+		t.Errorf("Delete of part of a combined deployment = %v; want an invalid argument error", err)
+	}
+}
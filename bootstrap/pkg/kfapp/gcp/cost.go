@@ -0,0 +1,359 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"fmt"
+	"github.com/ghodss/yaml"
+	kfapis "github.com/kubeflow/kubeflow/bootstrap/pkg/apis"
+	kftypes "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+	"google.golang.org/api/cloudbilling/v1"
+	"google.golang.org/api/compute/v1"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// hoursPerMonth converts the hourly prices of the billing catalog to monthly ones.
+const hoursPerMonth = 730.0
+
+// Display names of the billing catalog services the resources of the app are billed by.
+const (
+	computeEngineService = "Compute Engine"
+	filestoreService     = "Cloud Filestore"
+)
+
+// pdResourceGroups are the billing catalog resource groups of the disk types of storage.jinja.
+var pdResourceGroups = map[string]string{
+	"pd-standard": "PDStandard",
+	"pd-ssd":      "SSD",
+}
+
+// pricedResource is a resource of the generated configs and how to find the SKU it's billed by.
+type pricedResource struct {
+	name    string
+	service string
+	// quantity is in the usage unit of the SKU, e.g. cores or GiB.
+	quantity float64
+	perNode  bool
+	match    func(sku *cloudbilling.Sku) bool
+}
+
+// EstimateCost prices the resources of the configs written by generate platform, the node
+// pools, static IP, disks and filestore, with the list prices of the Cloud Billing Catalog API.
+// Discounts, network traffic and the pods' own resources aren't included.
+func (gcp *Gcp) EstimateCost(currency string) (*kftypes.CostEstimate, error) {
+	ctx := context.Background()
+	resources, err := gcp.pricedResources(ctx)
+	if err != nil {
+		return nil, err
+	}
+	billingService, err := cloudbilling.New(gcp.client)
+	if err != nil {
+		return nil, fmt.Errorf("could not create cloud billing service %v", err)
+	}
+	skus := map[string][]*cloudbilling.Sku{}
+	estimate := &kftypes.CostEstimate{Currency: currency}
+	for _, r := range resources {
+		if _, ok := skus[r.service]; !ok {
+			if skus[r.service], err = listSkus(ctx, billingService, r.service, currency); err != nil {
+				return nil, &kfapis.KfError{
+					Code: int(kfapis.INTERNAL_ERROR),
+					Message: fmt.Sprintf("couldn't list the prices of %v; is cloudbilling.googleapis.com enabled? %v",
+						r.service, err),
+				}
+			}
+		}
+		monthly, ok := monthlyPrice(skus[r.service], r.match)
+		if !ok {
+			log.Warnf("No price found for %v", r.name)
+			estimate.Unpriced = append(estimate.Unpriced, r.name)
+			continue
+		}
+		estimate.Items = append(estimate.Items, kftypes.CostItem{
+			Resource: r.name,
+			Monthly:  monthly * r.quantity,
+			PerNode:  r.perNode,
+		})
+	}
+	return estimate, nil
+}
+
+// listSkus returns the SKUs of the billing catalog service named service.
+func listSkus(ctx context.Context, billingService *cloudbilling.APIService, service string,
+	currency string) ([]*cloudbilling.Sku, error) {
+	serviceName := ""
+	err := billingService.Services.List().Pages(ctx, func(page *cloudbilling.ListServicesResponse) error {
+		for _, s := range page.Services {
+			if s.DisplayName == service {
+				serviceName = s.Name
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if serviceName == "" {
+		return nil, fmt.Errorf("there's no %v service in the billing catalog", service)
+	}
+	skus := []*cloudbilling.Sku{}
+	err = billingService.Services.Skus.List(serviceName).CurrencyCode(currency).Pages(ctx,
+		func(page *cloudbilling.ListSkusResponse) error {
+			skus = append(skus, page.Skus...)
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+	return skus, nil
+}
+
+// monthlyPrice is the monthly price of a unit of the first SKU matched, at the rate of its last
+// tier, i.e. past the free usage.
+func monthlyPrice(skus []*cloudbilling.Sku, match func(sku *cloudbilling.Sku) bool) (float64, bool) {
+	for _, sku := range skus {
+		if !match(sku) || len(sku.PricingInfo) == 0 {
+			continue
+		}
+		expression := sku.PricingInfo[0].PricingExpression
+		if expression == nil || len(expression.TieredRates) == 0 {
+			continue
+		}
+		perMonth, ok := unitsPerMonth(expression.UsageUnit)
+		if !ok {
+			continue
+		}
+		price := expression.TieredRates[len(expression.TieredRates)-1].UnitPrice
+		if price == nil {
+			continue
+		}
+		return (float64(price.Units) + float64(price.Nanos)/1e9) * perMonth, true
+	}
+	return 0, false
+}
+
+// unitsPerMonth is how many usage units, e.g. h or GiBy.mo, there are in a month.
+func unitsPerMonth(usageUnit string) (float64, bool) {
+	switch {
+	case strings.HasSuffix(usageUnit, "mo"):
+		return 1, true
+	case strings.HasSuffix(usageUnit, "h"):
+		return hoursPerMonth, true
+	case strings.HasSuffix(usageUnit, "d"):
+		return hoursPerMonth / 24, true
+	}
+	return 0, false
+}
+
+// onDemandIn matches the on-demand SKUs of region.
+func onDemandIn(sku *cloudbilling.Sku, region string) bool {
+	if sku.Category == nil || sku.Category.UsageType != "OnDemand" {
+		return false
+	}
+	for _, r := range sku.ServiceRegions {
+		if r == region {
+			return true
+		}
+	}
+	return false
+}
+
+// configProperties returns the properties of the resources of a config generated in gcp_config,
+// or nil when it wasn't generated.
+func (gcp *Gcp) configProperties(file string) ([]map[string]interface{}, error) {
+	buf, err := gcp.readConfig(file)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var data struct {
+		Resources []struct {
+			Properties map[string]interface{} `json:"properties"`
+		} `json:"resources"`
+	}
+	if err = yaml.Unmarshal(buf, &data); err != nil {
+		return nil, fmt.Errorf("couldn't parse %v: %v", file, err)
+	}
+	properties := []map[string]interface{}{}
+	for _, r := range data.Resources {
+		if r.Properties != nil {
+			properties = append(properties, r.Properties)
+		}
+	}
+	return properties, nil
+}
+
+// pricedResources are the resources of the generated configs.
+func (gcp *Gcp) pricedResources(ctx context.Context) ([]pricedResource, error) {
+	clusterProperties, err := gcp.configProperties(CONFIG_FILE)
+	if err != nil {
+		return nil, err
+	}
+	if clusterProperties == nil {
+		return nil, &kfapis.KfError{
+			Code: int(kfapis.INVALID_ARGUMENT),
+			Message: fmt.Sprintf("there's no %v in %v; run kfctl generate platform first",
+				CONFIG_FILE, filepath.Join(gcp.Spec.AppDir, GCP_CONFIG)),
+		}
+	}
+	region := regionOf(gcp.Spec.Zone)
+	resources := []pricedResource{}
+	for _, properties := range clusterProperties {
+		for _, pool := range []string{CPU_POOL, GPU_POOL} {
+			poolResources, err := gcp.nodePoolResources(ctx, properties, pool, region)
+			if err != nil {
+				return nil, err
+			}
+			resources = append(resources, poolResources...)
+		}
+		if ipName, ok := properties["ipName"].(string); ok && ipName != "" {
+			resources = append(resources, pricedResource{
+				name:     "static IP " + ipName,
+				service:  computeEngineService,
+				quantity: 1,
+				match: func(sku *cloudbilling.Sku) bool {
+					return sku.Description == "Static Ip Charge" && onDemandIn(sku, region)
+				},
+			})
+		}
+	}
+	storageProperties, err := gcp.configProperties(STORAGE_FILE)
+	if err != nil {
+		return nil, err
+	}
+	for _, properties := range storageProperties {
+		disks, _ := properties["disks"].([]interface{})
+		for _, d := range disks {
+			disk, _ := d.(map[string]interface{})
+			diskType, _ := disk["diskType"].(string)
+			group := pdResourceGroups[diskType]
+			resources = append(resources, pricedResource{
+				name:     fmt.Sprintf("%v disk %v (%vGB)", diskType, disk["usage"], disk["sizeGb"]),
+				service:  computeEngineService,
+				quantity: number(disk["sizeGb"]),
+				match: func(sku *cloudbilling.Sku) bool {
+					return sku.Category != nil && sku.Category.ResourceGroup == group &&
+						strings.Contains(sku.Description, "PD Capacity") &&
+						!strings.Contains(sku.Description, "Regional") && onDemandIn(sku, region)
+				},
+			})
+		}
+	}
+	gcfsProperties, err := gcp.configProperties(GCFS_FILE)
+	if err != nil {
+		return nil, err
+	}
+	for _, properties := range gcfsProperties {
+		tier, _ := properties["tier"].(string)
+		shares, _ := properties["fileShares"].([]interface{})
+		for _, s := range shares {
+			share, _ := s.(map[string]interface{})
+			resources = append(resources, pricedResource{
+				name:     fmt.Sprintf("%v filestore %v (%vGB)", tier, share["name"], share["capacityGb"]),
+				service:  filestoreService,
+				quantity: number(share["capacityGb"]),
+				match: func(sku *cloudbilling.Sku) bool {
+					description := strings.ToLower(sku.Description)
+					return strings.Contains(description, "capacity") &&
+						strings.Contains(description, strings.ToLower(tier)) && onDemandIn(sku, region)
+				},
+			})
+		}
+	}
+	return resources, nil
+}
+
+// nodePoolResources are the cores, memory and GPUs of the nodes pool starts with. A pool of no
+// nodes autoscaled up to some is priced per node.
+func (gcp *Gcp) nodePoolResources(ctx context.Context, properties map[string]interface{}, pool string,
+	region string) ([]pricedResource, error) {
+	nodes := number(properties[pool+"-initialNodeCount"])
+	maxNodes := number(properties[pool+"-max-nodes"])
+	machineType, _ := properties[pool+"-machine-type"].(string)
+	if machineType == "" || (nodes == 0 && maxNodes == 0) {
+		return nil, nil
+	}
+	perNode := nodes == 0
+	name := fmt.Sprintf("%v: %v x %v", pool, nodes, machineType)
+	if perNode {
+		nodes = 1
+		name = fmt.Sprintf("%v: per %v node, autoscaled up to %v", pool, machineType, maxNodes)
+	}
+	computeService, err := compute.New(gcp.client)
+	if err != nil {
+		return nil, fmt.Errorf("could not create compute service %v", err)
+	}
+	mt, err := computeService.MachineTypes.Get(gcp.Spec.Project, gcp.Spec.Zone, machineType).Context(ctx).Do()
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get machine type %v: %v", machineType, err)
+	}
+	family := strings.ToUpper(strings.SplitN(machineType, "-", 2)[0]) + " "
+	instanceSku := func(resource string) func(sku *cloudbilling.Sku) bool {
+		return func(sku *cloudbilling.Sku) bool {
+			return strings.HasPrefix(sku.Description, family) && strings.Contains(sku.Description, resource) &&
+				!strings.Contains(sku.Description, "Custom") && !strings.Contains(sku.Description, "Sole Tenancy") &&
+				onDemandIn(sku, region)
+		}
+	}
+	resources := []pricedResource{
+		{
+			name:     name + " cores",
+			service:  computeEngineService,
+			quantity: nodes * float64(mt.GuestCpus),
+			perNode:  perNode,
+			match:    instanceSku("Instance Core"),
+		},
+		{
+			name:     name + " memory",
+			service:  computeEngineService,
+			quantity: nodes * float64(mt.MemoryMb) / 1024,
+			perNode:  perNode,
+			match:    instanceSku("Instance Ram"),
+		},
+	}
+	if gpuType, _ := properties["gpu-type"].(string); pool == GPU_POOL && gpuType != "" {
+		gpuCount := number(properties["gpu-number-per-node"])
+		gpuName := strings.Replace(gpuType, "-", " ", -1) + " gpu"
+		resources = append(resources, pricedResource{
+			name:     fmt.Sprintf("%v %v GPUs", name, gpuCount*nodes),
+			service:  computeEngineService,
+			quantity: gpuCount * nodes,
+			perNode:  perNode,
+			match: func(sku *cloudbilling.Sku) bool {
+				return sku.Category != nil && sku.Category.ResourceGroup == "GPU" &&
+					strings.HasPrefix(strings.ToLower(sku.Description), gpuName) && onDemandIn(sku, region)
+			},
+		})
+	}
+	return resources, nil
+}
+
+// number is a numeric property of a config, 0 when it isn't one.
+func number(value interface{}) float64 {
+	switch v := value.(type) {
+	case int:
+		return float64(v)
+	case int64:
+		return float64(v)
+	case float64:
+		return v
+	}
+	return 0
+}
@@ -0,0 +1,180 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"path"
+	"reflect"
+	"testing"
+
+	kfapis "github.com/kubeflow/kubeflow/bootstrap/pkg/apis"
+)
+
+// sku returns a billing catalog SKU of us-east1 costing nanos per unit.
+func sku(description string, resourceGroup string, usageType string, unit string, nanos int) string {
+	return fmt.Sprintf(`{"description": %q, "category": {"resourceGroup": %q, "usageType": %q},
+		"serviceRegions": ["us-east1"], "pricingInfo": [{"pricingExpression": {"usageUnit": %q,
+		"tieredRates": [{"unitPrice": {"units": "0", "nanos": %v}}]}}]}`,
+		description, resourceGroup, usageType, unit, nanos)
+}
+
+var costApis = fakeGcpApis{
+	"/compute/v1/projects/my-project/zones/us-east1-d/machineTypes/n1-standard-8": `{"name": "n1-standard-8", "guestCpus": 8, "memoryMb": 30720}`,
+	"/v1/services": `{"services": [{"name": "services/compute", "displayName": "Compute Engine"},
+		{"name": "services/filestore", "displayName": "Cloud Filestore"}]}`,
+	"/v1/services/compute/skus": `{"skus": [` +
+		sku("Preemptible N1 Predefined Instance Core running in Americas", "CPU", "Preemptible", "h", 1) + "," +
+		sku("N1 Predefined Instance Core running in Americas", "CPU", "OnDemand", "h", 31611000) + "," +
+		sku("N1 Predefined Instance Ram running in Americas", "RAM", "OnDemand", "GiBy.h", 4237000) + "," +
+		sku("Nvidia Tesla K80 GPU running in Americas", "GPU", "OnDemand", "h", 450000000) + "," +
+		sku("Static Ip Charge", "IpAddress", "OnDemand", "h", 10000000) + "," +
+		sku("Storage PD Capacity", "PDStandard", "OnDemand", "GiBy.mo", 40000000) + `]}`,
+	"/v1/services/filestore/skus": `{"skus": []}`,
+}
+
+const costClusterConfig = `
+resources:
+- name: kubeflow
+  properties:
+    cpu-pool-initialNodeCount: 2
+    cpu-pool-machine-type: n1-standard-8
+    gpu-pool-initialNodeCount: 0
+    gpu-pool-max-nodes: 4
+    gpu-pool-machine-type: n1-standard-8
+    gpu-type: nvidia-tesla-k80
+    gpu-number-per-node: 1
+    ipName: kf-ip
+`
+
+const costStorageConfig = `
+resources:
+- name: kubeflow
+  properties:
+    disks:
+    - diskType: pd-standard
+      usage: metadata-store
+      sizeGb: 10
+`
+
+const costGcfsConfig = `
+resources:
+- name: kubeflow
+  properties:
+    tier: STANDARD
+    fileShares:
+    - name: kubeflow
+      capacityGb: 1024
+`
+
+func newCostGcp(apis fakeGcpApis, configs map[string]string) *Gcp {
+	store := NewBundle()
+	for file, config := range configs {
+		store.Put(path.Join(GCP_CONFIG, file), []byte(config))
+	}
+	gcp := &Gcp{
+		client: &http.Client{Transport: apis},
+		store:  store,
+	}
+	gcp.Spec.Project = "my-project"
+	gcp.Spec.Zone = "us-east1-d"
+	return gcp
+}
+
+func TestEstimateCost(t *testing.T) {
+	gcp := newCostGcp(costApis, map[string]string{
+		CONFIG_FILE:  costClusterConfig,
+		STORAGE_FILE: costStorageConfig,
+		GCFS_FILE:    costGcfsConfig,
+	})
+	estimate, err := gcp.EstimateCost("USD")
+	if err != nil {
+		t.Fatalf("EstimateCost failed: %v", err)
+	}
+	core, ram, gpu := 0.031611*hoursPerMonth, 0.004237*hoursPerMonth, 0.45*hoursPerMonth
+	want := []struct {
+		resource string
+		monthly  float64
+		perNode  bool
+	}{
+		{"cpu-pool: 2 x n1-standard-8 cores", 16 * core, false},
+		{"cpu-pool: 2 x n1-standard-8 memory", 60 * ram, false},
+		{"gpu-pool: per n1-standard-8 node, autoscaled up to 4 cores", 8 * core, true},
+		{"gpu-pool: per n1-standard-8 node, autoscaled up to 4 memory", 30 * ram, true},
+		{"gpu-pool: per n1-standard-8 node, autoscaled up to 4 1 GPUs", gpu, true},
+		{"static IP kf-ip", 0.01 * hoursPerMonth, false},
+		{"pd-standard disk metadata-store (10GB)", 0.4, false},
+	}
+	if len(estimate.Items) != len(want) {
+		t.Fatalf("estimated %+v; want %v items", estimate.Items, len(want))
+	}
+	for i, w := range want {
+		item := estimate.Items[i]
+		if item.Resource != w.resource || math.Abs(item.Monthly-w.monthly) > 1e-6 || item.PerNode != w.perNode {
+			t.Errorf("item %v = %+v; want %+v", i, item, w)
+		}
+	}
+	if want := []string{"STANDARD filestore kubeflow (1024GB)"}; !reflect.DeepEqual(estimate.Unpriced, want) {
+		t.Errorf("unpriced %v; want %v", estimate.Unpriced, want)
+	}
+	if total := 16*core + 60*ram + 0.01*hoursPerMonth + 0.4; math.Abs(estimate.Total()-total) > 1e-6 {
+		t.Errorf("total %v; want %v without the per node items", estimate.Total(), total)
+	}
+}
+
+func TestEstimateCostErrors(t *testing.T) {
+	_, err := newCostGcp(costApis, map[string]string{}).EstimateCost("USD")
+	if kfErr, ok := err.(*kfapis.KfError); !ok || kfErr.Code != int(kfapis.INVALID_ARGUMENT) {
+		t.Errorf("EstimateCost without %v = %v; want an invalid argument error", CONFIG_FILE, err)
+	}
+
+	// The catalog can't be read without cloudbilling.googleapis.com.
+	apis := fakeGcpApis{}
+	for p, body := range costApis {
+		apis[p] = body
+	}
+	apis["/v1/services"] = `{"error": {"code": 403, "message": "cloudbilling.googleapis.com is disabled"}}`
+	_, err = newCostGcp(apis, map[string]string{CONFIG_FILE: costClusterConfig}).EstimateCost("USD")
+	if kfErr, ok := err.(*kfapis.KfError); !ok || kfErr.Code != int(kfapis.INTERNAL_ERROR) {
+		t.Errorf("EstimateCost without the billing catalog = %v; want an internal error", err)
+	}
+
+	// An unknown machine type fails the estimate rather than leaving the pool out.
+	delete(apis, "/compute/v1/projects/my-project/zones/us-east1-d/machineTypes/n1-standard-8")
+	if _, err = newCostGcp(apis, map[string]string{CONFIG_FILE: costClusterConfig}).EstimateCost("USD"); err == nil {
+		t.Errorf("EstimateCost with an unknown machine type succeeded")
+	}
+}
+
+func TestUnitsPerMonth(t *testing.T) {
+	cases := []struct {
+		unit string
+		want float64
+		ok   bool
+	}{
+		{"h", hoursPerMonth, true},
+		{"GiBy.h", hoursPerMonth, true},
+		{"GiBy.mo", 1, true},
+		{"GiBy.d", hoursPerMonth / 24, true},
+		{"GiBy", 0, false},
+	}
+	for _, c := range cases {
+		if perMonth, ok := unitsPerMonth(c.unit); perMonth != c.want || ok != c.ok {
+			t.Errorf("unitsPerMonth(%v) = %v, %v; want %v, %v", c.unit, perMonth, ok, c.want, c.ok)
+		}
+	}
+}
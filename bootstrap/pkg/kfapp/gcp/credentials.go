@@ -0,0 +1,201 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	kfapis "github.com/kubeflow/kubeflow/bootstrap/pkg/apis"
+	kftypes "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps"
+	"github.com/kubeflow/kubeflow/bootstrap/pkg/utils"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+	gke "google.golang.org/api/container/v1"
+	"google.golang.org/api/iamcredentials/v1"
+	"net/http"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// IMPERSONATE_ENV is the service account set with --impersonate-service-account. gcloud
+// impersonates it too.
+const IMPERSONATE_ENV = "CLOUDSDK_AUTH_IMPERSONATE_SERVICE_ACCOUNT"
+
+const noCredentialsHelp = `no GCP credentials found. kfctl uses the application default credentials, looked up in order from:
+  1. the key file in GOOGLE_APPLICATION_CREDENTIALS, or passed with --%v
+  2. gcloud's application default credentials, created by: gcloud auth application-default login
+  3. the GCE/GKE metadata server, when running on Google Cloud
+Error: %v`
+
+// findCredentials returns the credentials of the GCP calls: those of the service account in
+// IMPERSONATE_ENV when it's set, impersonated with the application default credentials, or the
+// application default credentials.
+func findCredentials(ctx context.Context) (*google.Credentials, error) {
+	creds, err := findDefaultCredentials(ctx)
+	if err != nil {
+		return nil, err
+	}
+	serviceAccount := os.Getenv(IMPERSONATE_ENV)
+	if serviceAccount == "" {
+		return creds, nil
+	}
+	log.Infof("Impersonating %v", serviceAccount)
+	tokenSource, err := ImpersonateTokenSource(ctx, creds.TokenSource, serviceAccount)
+	if err != nil {
+		return nil, err
+	}
+	return &google.Credentials{ProjectID: creds.ProjectID, TokenSource: tokenSource}, nil
+}
+
+// DefaultClient returns an HTTP client authorized with the credentials of the GCP calls, so the
+// other GCP clients of kfctl impersonate the service account in IMPERSONATE_ENV too. Its
+// requests go through the base client of ctx, set with utils.WithHTTPClient.
+func DefaultClient(ctx context.Context) (*http.Client, error) {
+	creds, err := findCredentials(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return oauth2.NewClient(ctx, creds.TokenSource), nil
+}
+
+// ImpersonateTokenSource returns a token source of access tokens of serviceAccount, generated
+// with the IAM Credentials API by the caller of tokenSource, which needs
+// roles/iam.serviceAccountTokenCreator on serviceAccount. CI systems can so deploy with a
+// low-privilege identity; pass it to NewGcp with WithTokenSource.
+func ImpersonateTokenSource(ctx context.Context, tokenSource oauth2.TokenSource,
+	serviceAccount string) (oauth2.TokenSource, error) {
+	service, err := iamcredentials.New(oauth2.NewClient(ctx, tokenSource))
+	if err != nil {
+		return nil, fmt.Errorf("Error creating iamcredentials service: %v", err)
+	}
+	impersonated := oauth2.ReuseTokenSource(nil, &impersonatedTokenSource{
+		ctx:            ctx,
+		service:        service,
+		serviceAccount: serviceAccount,
+	})
+	// Fail now rather than on the first GCP call when the caller can't impersonate.
+	if _, err = impersonated.Token(); err != nil {
+		return nil, &kfapis.KfError{
+			Code: int(kfapis.INVALID_ARGUMENT),
+			Message: fmt.Sprintf("couldn't impersonate %v, which needs roles/iam.serviceAccountTokenCreator "+
+				"granted to the caller: %v", serviceAccount, err),
+		}
+	}
+	return impersonated, nil
+}
+
+// impersonatedTokenSource generates an access token of serviceAccount for each call.
+type impersonatedTokenSource struct {
+	ctx            context.Context
+	service        *iamcredentials.Service
+	serviceAccount string
+}
+
+func (ts *impersonatedTokenSource) Token() (*oauth2.Token, error) {
+	name := "projects/-/serviceAccounts/" + ts.serviceAccount
+	resp, err := ts.service.Projects.ServiceAccounts.GenerateAccessToken(name,
+		&iamcredentials.GenerateAccessTokenRequest{
+			Scope:    []string{gke.CloudPlatformScope},
+			Lifetime: "3600s",
+		}).Context(ts.ctx).Do()
+	if err != nil {
+		return nil, fmt.Errorf("couldn't generate an access token of %v: %v", ts.serviceAccount, err)
+	}
+	expiry, err := time.Parse(time.RFC3339, resp.ExpireTime)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't parse the expiry of the access token of %v: %v", ts.serviceAccount, err)
+	}
+	return &oauth2.Token{
+		AccessToken: resp.AccessToken,
+		TokenType:   "Bearer",
+		Expiry:      expiry,
+	}, nil
+}
+
+// findDefaultCredentials returns the application default credentials. When there are none and
+// kfctl is run from a terminal with gcloud installed, it offers to run the browser based
+// 'gcloud auth application-default login' flow and retries.
+func findDefaultCredentials(ctx context.Context) (*google.Credentials, error) {
+	creds, err := google.FindDefaultCredentials(ctx, gke.CloudPlatformScope)
+	if err == nil {
+		return creds, nil
+	}
+	if keyFile := os.Getenv("GOOGLE_APPLICATION_CREDENTIALS"); keyFile != "" {
+		// The key file was set explicitly; logging in with gcloud wouldn't be used.
+		return nil, &kfapis.KfError{
+			Code:    int(kfapis.INVALID_ARGUMENT),
+			Message: fmt.Sprintf("couldn't load GCP credentials from %v: %v", keyFile, err),
+		}
+	}
+	if adcLogin() {
+		if creds, err = google.FindDefaultCredentials(ctx, gke.CloudPlatformScope); err == nil {
+			return creds, nil
+		}
+	}
+	return nil, &kfapis.KfError{
+		Code:    int(kfapis.INVALID_ARGUMENT),
+		Message: fmt.Sprintf(noCredentialsHelp, string(kftypes.CREDENTIALS_FILE), err),
+	}
+}
+
+// adcLogin asks whether to run 'gcloud auth application-default login' and runs it.
+// It returns false without asking when stdin isn't a terminal, gcloud isn't installed or
+// in no-exec mode.
+func adcLogin() bool {
+	stat, err := os.Stdin.Stat()
+	if err != nil || stat.Mode()&os.ModeCharDevice == 0 {
+		return false
+	}
+	if _, err = utils.LookPath("gcloud"); err != nil {
+		return false
+	}
+	fmt.Printf("No GCP application default credentials found.\n" +
+		"Run 'gcloud auth application-default login' now to create them in your browser? [y/N]: ")
+	answer, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil || strings.ToLower(strings.TrimSpace(answer)) != "y" {
+		return false
+	}
+	cmd := exec.Command("gcloud", "auth", "application-default", "login")
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err = utils.RunCommand(cmd); err != nil {
+		log.Errorf("gcloud auth application-default login failed: %v", err)
+		return false
+	}
+	return true
+}
+
+// credentialsEmail returns the email of the service account of a key file credentials, the
+// native replacement of 'gcloud config get-value account' in no-exec mode.
+func credentialsEmail(creds *google.Credentials) (string, error) {
+	key := struct {
+		ClientEmail string `json:"client_email"`
+	}{}
+	if len(creds.JSON) != 0 {
+		if err := json.Unmarshal(creds.JSON, &key); err != nil {
+			return "", fmt.Errorf("couldn't read the GCP credentials: %v", err)
+		}
+	}
+	if key.ClientEmail == "" {
+		return "", fmt.Errorf("the GCP credentials aren't a service account key; set --email")
+	}
+	return key.ClientEmail, nil
+}
@@ -0,0 +1,168 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	kfapis "github.com/kubeflow/kubeflow/bootstrap/pkg/apis"
+	"golang.org/x/net/context"
+	"golang.org/x/oauth2"
+	"google.golang.org/api/iamcredentials/v1"
+)
+
+const impersonatedAccount = "deployer@my-project.iam.gserviceaccount.com"
+
+// fakeIamCredentials is the IAM Credentials API generating access tokens of the service
+// accounts of tokens for the caller token, and the OAuth token endpoint refreshing the
+// caller token of the application default credentials.
+type fakeIamCredentials struct {
+	caller     string
+	tokens     map[string]string
+	expireTime string
+	// requests are the GenerateAccessToken requests made.
+	requests []*iamcredentials.GenerateAccessTokenRequest
+}
+
+func (f *fakeIamCredentials) RoundTrip(req *http.Request) (*http.Response, error) {
+	status, body := http.StatusOK, ""
+	switch {
+	case strings.HasSuffix(req.URL.Path, ":generateAccessToken"):
+		account := strings.TrimSuffix(strings.TrimPrefix(req.URL.Path, "/v1/projects/-/serviceAccounts/"),
+			":generateAccessToken")
+		request := &iamcredentials.GenerateAccessTokenRequest{}
+		if err := json.NewDecoder(req.Body).Decode(request); err != nil {
+			return nil, err
+		}
+		f.requests = append(f.requests, request)
+		token, ok := f.tokens[account]
+		if req.Header.Get("Authorization") != "Bearer "+f.caller || !ok {
+			status, body = http.StatusForbidden, `{"error": {"code": 403, "message": "iam.serviceAccounts.getAccessToken denied"}}`
+			break
+		}
+		body = `{"accessToken": "` + token + `", "expireTime": "` + f.expireTime + `"}`
+	case strings.HasSuffix(req.URL.Path, "/token"):
+		body = `{"access_token": "` + f.caller + `", "token_type": "Bearer", "expires_in": 3600}`
+	default:
+		status, body = http.StatusNotFound, `{"error": {"code": 404, "message": "not found"}}`
+	}
+	return &http.Response{
+		StatusCode: status,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       ioutil.NopCloser(strings.NewReader(body)),
+		Request:    req,
+	}, nil
+}
+
+func newFakeIamCredentials() (*fakeIamCredentials, context.Context) {
+	f := &fakeIamCredentials{
+		caller:     "caller-token",
+		tokens:     map[string]string{impersonatedAccount: "deployer-token"},
+		expireTime: "2099-01-01T00:00:00Z",
+	}
+	return f, context.WithValue(context.Background(), oauth2.HTTPClient, &http.Client{Transport: f})
+}
+
+func TestImpersonateTokenSource(t *testing.T) {
+	f, ctx := newFakeIamCredentials()
+	caller := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "caller-token"})
+	tokenSource, err := ImpersonateTokenSource(ctx, caller, impersonatedAccount)
+	if err != nil {
+		t.Fatalf("ImpersonateTokenSource failed: %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		token, err := tokenSource.Token()
+		if err != nil || token.AccessToken != "deployer-token" || token.Expiry.Year() != 2099 {
+			t.Fatalf("Token = %+v, %v; want the token of %v", token, err, impersonatedAccount)
+		}
+	}
+	// The token is generated once, when it's checked, and reused until it expires.
+	if len(f.requests) != 1 {
+		t.Fatalf("generated %v tokens; want 1", len(f.requests))
+	}
+	if scope := f.requests[0].Scope; !reflect.DeepEqual(scope, []string{"https://www.googleapis.com/auth/cloud-platform"}) {
+		t.Errorf("token generated for scopes %v", scope)
+	}
+}
+
+func TestImpersonateTokenSourceErrors(t *testing.T) {
+	f, ctx := newFakeIamCredentials()
+	caller := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "caller-token"})
+
+	// A caller without roles/iam.serviceAccountTokenCreator is refused when the token source is made.
+	_, err := ImpersonateTokenSource(ctx, caller, "other@my-project.iam.gserviceaccount.com")
+	kfErr, ok := err.(*kfapis.KfError)
+	if !ok || kfErr.Code != int(kfapis.INVALID_ARGUMENT) ||
+		!strings.Contains(kfErr.Message, "roles/iam.serviceAccountTokenCreator") {
+		t.Errorf("ImpersonateTokenSource without access = %v; want an invalid argument error", err)
+	}
+
+	f.expireTime = "tomorrow"
+	if _, err = ImpersonateTokenSource(ctx, caller, impersonatedAccount); err == nil ||
+		!strings.Contains(err.Error(), "expiry") {
+		t.Errorf("ImpersonateTokenSource with an invalid expiry = %v; want an error", err)
+	}
+}
+
+func TestFindCredentialsImpersonates(t *testing.T) {
+	dir, err := ioutil.TempDir("", "kfctl-credentials")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	keyFile := filepath.Join(dir, "credentials.json")
+	if err = ioutil.WriteFile(keyFile, []byte(`{"type": "authorized_user", "client_id": "id",
+		"client_secret": "secret", "refresh_token": "refresh"}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Setenv("GOOGLE_APPLICATION_CREDENTIALS", os.Getenv("GOOGLE_APPLICATION_CREDENTIALS"))
+	defer os.Setenv(IMPERSONATE_ENV, os.Getenv(IMPERSONATE_ENV))
+	os.Setenv("GOOGLE_APPLICATION_CREDENTIALS", keyFile)
+
+	cases := []struct {
+		serviceAccount string
+		want           string
+	}{
+		{"", "caller-token"},
+		{impersonatedAccount, "deployer-token"},
+	}
+	for _, c := range cases {
+		os.Setenv(IMPERSONATE_ENV, c.serviceAccount)
+		_, ctx := newFakeIamCredentials()
+		creds, err := findCredentials(ctx)
+		if err != nil {
+			t.Fatalf("findCredentials with %q impersonated failed: %v", c.serviceAccount, err)
+		}
+		token, err := creds.TokenSource.Token()
+		if err != nil || token.AccessToken != c.want {
+			t.Errorf("findCredentials with %q impersonated gave token %+v, %v; want %v",
+				c.serviceAccount, token, err, c.want)
+		}
+	}
+
+	// Impersonating an account the caller can't is an error, not a fallback to the caller.
+	os.Setenv(IMPERSONATE_ENV, "other@my-project.iam.gserviceaccount.com")
+	_, ctx := newFakeIamCredentials()
+	if _, err = findCredentials(ctx); err == nil {
+		t.Errorf("findCredentials impersonating an account without access succeeded")
+	}
+}
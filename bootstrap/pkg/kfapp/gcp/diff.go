@@ -0,0 +1,209 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"fmt"
+	kftypes "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps"
+	"github.com/kubeflow/kubeflow/bootstrap/pkg/utils"
+	"golang.org/x/net/context"
+	"google.golang.org/api/cloudresourcemanager/v1"
+	"path"
+	"sort"
+	"strings"
+)
+
+// dmConfig is the config file of a DM deployment of the app.
+type dmConfig struct {
+	target     string
+	deployment string
+	file       string
+}
+
+// dmConfigs are the DM deployments of the app and their config files in gcp_config.
+func (gcp *Gcp) dmConfigs() []dmConfig {
+	if gcp.Spec.CombinedDeployment {
+		return []dmConfig{{COMPONENT_CLUSTER, gcp.Name, COMBINED_FILE}}
+	}
+	return []dmConfig{
+		{COMPONENT_STORAGE, gcp.storageDeploymentName(), STORAGE_FILE},
+		{COMPONENT_CLUSTER, gcp.Name, CONFIG_FILE},
+		{COMPONENT_NETWORK, gcp.Name + "-network", NETWORK_FILE},
+		{COMPONENT_GCFS, gcp.Name + "-gcfs", GCFS_FILE},
+	}
+}
+
+// Diff compares the configs of the app with what's deployed, limited to spec.targets when set:
+// the DM configs and templates in gcp_config with the manifests of the deployments, the IAM
+// bindings of iam_bindings.yaml with the project's policy and, for K8S, the Istio and NVIDIA
+// driver manifests with the live objects. It returns their unified diff, from live to desired,
+// which is empty when nothing drifted.
+func (gcp *Gcp) Diff(resources kftypes.ResourceEnum) (string, error) {
+	targets, err := gcp.targets(resources)
+	if err != nil {
+		return "", err
+	}
+	ctx := context.Background()
+	diffs := ""
+	if dmTargeted(targets) {
+		diff, err := gcp.diffDeployments(ctx, targets)
+		if err != nil {
+			return "", err
+		}
+		diffs += diff
+	}
+	if targets[TARGET_IAM] {
+		diff, err := gcp.diffIamBindings(ctx)
+		if err != nil {
+			return "", err
+		}
+		diffs += diff
+	}
+	manifests := []string{}
+	if gcp.Spec.UseIstio && targets[TARGET_ISTIO] {
+		manifests = append(manifests, gcp.istioManifests()...)
+	}
+	if gcp.Spec.Gpu != nil && targets[TARGET_GPU_DRIVERS] {
+		manifests = append(manifests, NVIDIA_DRIVER_INSTALLER)
+	}
+	if len(manifests) == 0 {
+		return diffs, nil
+	}
+	client, err := gcp.getK8sRestConfig(ctx)
+	if err != nil {
+		return "", err
+	}
+	for _, manifest := range manifests {
+		err := gcp.withAssetFile(manifest, func(file string) error {
+			diff, err := utils.DiffResourcesFromFile(client, file)
+			diffs += diff
+			return err
+		})
+		if err != nil {
+			return "", fmt.Errorf("couldn't diff %v: %v", manifest, err)
+		}
+	}
+	return diffs, nil
+}
+
+// diffDeployments diffs the config and imports of the targeted deployments with their manifest.
+func (gcp *Gcp) diffDeployments(ctx context.Context, targets map[string]bool) (string, error) {
+	deploymentmanagerService, err := gcp.newDeploymentManagerClient()
+	if err != nil {
+		return "", err
+	}
+	project := gcp.Spec.Project
+	diffs := ""
+	for _, c := range gcp.dmConfigs() {
+		if !targets[c.target] && !gcp.Spec.CombinedDeployment {
+			continue
+		}
+		if !gcp.hasConfig(c.file) {
+			continue
+		}
+		desired, err := gcp.generateTarget(c.file)
+		if err != nil {
+			return "", err
+		}
+		live := map[string]string{}
+		d, err := deploymentmanagerService.GetDeployment(ctx, project, c.deployment)
+		if err != nil && !isNotFound(err) {
+			return "", fmt.Errorf("couldn't get deployment %v/%v: %v", project, c.deployment, err)
+		}
+		if err == nil && d.Manifest != "" {
+			manifest, err := deploymentmanagerService.GetManifest(ctx, project, c.deployment, path.Base(d.Manifest))
+			if err != nil {
+				return "", fmt.Errorf("couldn't get manifest of %v/%v: %v", project, c.deployment, err)
+			}
+			if manifest.Config != nil {
+				live[c.file] = manifest.Config.Content
+			}
+			for _, i := range manifest.Imports {
+				live[i.Name] = i.Content
+			}
+		}
+		files := map[string]string{c.file: desired.Config.Content}
+		for _, i := range desired.Imports {
+			files[i.Name] = i.Content
+		}
+		for name := range live {
+			if _, ok := files[name]; !ok {
+				files[name] = ""
+			}
+		}
+		names := []string{}
+		for name := range files {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			diff, err := utils.UnifiedDiff(live[name], files[name], "live/"+c.deployment+"/"+name,
+				"desired/"+c.deployment+"/"+name)
+			if err != nil {
+				return "", err
+			}
+			diffs += diff
+		}
+	}
+	return diffs, nil
+}
+
+// diffIamBindings diffs the bindings of iam_bindings.yaml with those of the project for the
+// members of the file and the service accounts of the app, one "role member" line each.
+func (gcp *Gcp) diffIamBindings(ctx context.Context) (string, error) {
+	want, err := gcp.readIamBindings()
+	if err != nil {
+		return "", fmt.Errorf("Read IAM policy YAML error: %v", err)
+	}
+	resourceManager, err := cloudresourcemanager.New(gcp.client)
+	if err != nil {
+		return "", err
+	}
+	policy, err := resourceManager.Projects.GetIamPolicy(gcp.Spec.Project,
+		&cloudresourcemanager.GetIamPolicyRequest{}).Context(ctx).Do()
+	if err != nil {
+		return "", fmt.Errorf("couldn't get the IAM policy of %v: %v", gcp.Spec.Project, err)
+	}
+	members := getDeploymentSAs(gcp.Name, gcp.Spec.Project)
+	desired := []string{}
+	for _, binding := range want.Bindings {
+		for _, member := range binding.Members {
+			members.Add(member)
+			desired = append(desired, binding.Role+" "+member)
+		}
+	}
+	live := []string{}
+	for _, binding := range policy.Bindings {
+		for _, member := range binding.Members {
+			if members.Contains(member) {
+				live = append(live, binding.Role+" "+member)
+			}
+		}
+	}
+	return utils.UnifiedDiff(bindingLines(live), bindingLines(desired),
+		"live/iam/"+gcp.Spec.Project, "desired/iam/"+gcp.Spec.Project)
+}
+
+// bindingLines are the sorted, unique bindings, one per line.
+func bindingLines(bindings []string) string {
+	sort.Strings(bindings)
+	lines := []string{}
+	for i, b := range bindings {
+		if i == 0 || b != bindings[i-1] {
+			lines = append(lines, b+"\n")
+		}
+	}
+	return strings.Join(lines, "")
+}
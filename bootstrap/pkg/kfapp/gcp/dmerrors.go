@@ -0,0 +1,213 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/ghodss/yaml"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+	"google.golang.org/api/deploymentmanager/v2"
+	"path"
+	"sort"
+	"strings"
+)
+
+// maxConfigLines bounds the config of a failed resource shown in the error of an operation.
+const maxConfigLines = 30
+
+// dmResourceError is the error of a resource of a deployment an operation failed on, with the
+// config of the resource in the manifest.
+type dmResourceError struct {
+	resource     string
+	resourceType string
+	message      string
+	config       string
+}
+
+// dmErrorMessage is the JSON of the message of a resource error, the error of the API of the
+// resource in resourceErrorMessage.
+type dmErrorMessage struct {
+	ResourceType         string          `json:"ResourceType"`
+	ResourceErrorCode    string          `json:"ResourceErrorCode"`
+	ResourceErrorMessage json.RawMessage `json:"ResourceErrorMessage"`
+}
+
+// parseDmErrorMessage returns the resource type and the message of the provider of msg, the
+// message of a DM error, or msg itself when it isn't the JSON of a resource error.
+func parseDmErrorMessage(msg string) (string, string) {
+	parsed := dmErrorMessage{}
+	if err := json.Unmarshal([]byte(msg), &parsed); err != nil || parsed.ResourceErrorMessage == nil {
+		return "", msg
+	}
+	message := ""
+	text := ""
+	providerErr := struct {
+		Message string `json:"message"`
+		Status  string `json:"status"`
+	}{}
+	if err := json.Unmarshal(parsed.ResourceErrorMessage, &text); err == nil {
+		message = text
+	} else if err := json.Unmarshal(parsed.ResourceErrorMessage, &providerErr); err == nil && providerErr.Message != "" {
+		message = providerErr.Message
+		if providerErr.Status != "" {
+			message = providerErr.Status + ": " + message
+		}
+	} else {
+		message = string(parsed.ResourceErrorMessage)
+	}
+	if parsed.ResourceErrorCode != "" {
+		message = fmt.Sprintf("(%v) %v", parsed.ResourceErrorCode, message)
+	}
+	return parsed.ResourceType, message
+}
+
+// resourceOfLocation is the resource of the location of a DM error,
+// /deployments/<deployment>/resources/<resource>, empty for other locations.
+func resourceOfLocation(location string) string {
+	parts := strings.Split(strings.Trim(location, "/"), "/")
+	if len(parts) == 4 && parts[0] == "deployments" && parts[2] == "resources" {
+		return parts[3]
+	}
+	return ""
+}
+
+// manifestResourceConfig is the YAML of resource in config, the expanded config of a manifest,
+// cut to maxConfigLines; empty when it isn't in config.
+func manifestResourceConfig(config string, resource string) string {
+	expanded := struct {
+		Resources []map[string]interface{} `json:"resources"`
+	}{}
+	if err := yaml.Unmarshal([]byte(config), &expanded); err != nil {
+		return ""
+	}
+	for _, r := range expanded.Resources {
+		if r["name"] != resource {
+			continue
+		}
+		buf, err := yaml.Marshal(r)
+		if err != nil {
+			return ""
+		}
+		lines := strings.Split(strings.TrimRight(string(buf), "\n"), "\n")
+		if len(lines) > maxConfigLines {
+			lines = append(lines[:maxConfigLines], fmt.Sprintf("... (%v more lines)", len(lines)-maxConfigLines))
+		}
+		return strings.Join(lines, "\n")
+	}
+	return ""
+}
+
+// dmResourceErrors returns the errors of the resources op failed on: those of op located at a
+// resource, and those of the resources of the deployment which failed to be created or
+// updated, with their config in the manifest the operation applied. They're best effort; the
+// resources and manifest which can't be read are left out.
+func dmResourceErrors(ctx context.Context, deploymentmanagerService DeploymentManagerClient,
+	project string, op *deploymentmanager.Operation) []*dmResourceError {
+	errs := map[string]*dmResourceError{}
+	add := func(resource string, resourceType string, msg string) {
+		parsedType, message := parseDmErrorMessage(msg)
+		if resourceType == "" {
+			resourceType = parsedType
+		}
+		if e, ok := errs[resource]; ok {
+			if !strings.Contains(e.message, message) {
+				e.message += "; " + message
+			}
+			if e.resourceType == "" {
+				e.resourceType = resourceType
+			}
+			return
+		}
+		errs[resource] = &dmResourceError{resource: resource, resourceType: resourceType, message: message}
+	}
+	if op.Error != nil {
+		for _, e := range op.Error.Errors {
+			if resource := resourceOfLocation(e.Location); resource != "" {
+				add(resource, "", e.Message)
+			}
+		}
+	}
+	if op.TargetLink == "" {
+		return sortedDmResourceErrors(errs)
+	}
+	deployment := path.Base(op.TargetLink)
+	resources, err := deploymentmanagerService.ListResources(ctx, project, deployment)
+	if err != nil {
+		log.Warnf("couldn't list the resources of deployment %v: %v", deployment, err)
+	}
+	for _, r := range resources {
+		if r.Update == nil || r.Update.Error == nil {
+			continue
+		}
+		for _, e := range r.Update.Error.Errors {
+			add(r.Name, r.Type, e.Message)
+		}
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	config := ""
+	if d, err := deploymentmanagerService.GetDeployment(ctx, project, deployment); err == nil {
+		// A failed update's manifest is that of the update, not yet the one of the deployment.
+		manifestName := d.Manifest
+		if d.Update != nil && d.Update.Manifest != "" {
+			manifestName = d.Update.Manifest
+		}
+		if manifestName != "" {
+			manifest, err := deploymentmanagerService.GetManifest(ctx, project, deployment, path.Base(manifestName))
+			if err == nil {
+				config = manifest.ExpandedConfig
+				if config == "" && manifest.Config != nil {
+					config = manifest.Config.Content
+				}
+			} else {
+				log.Warnf("couldn't get manifest %v of deployment %v: %v", manifestName, deployment, err)
+			}
+		}
+	}
+	for _, e := range errs {
+		e.config = manifestResourceConfig(config, e.resource)
+	}
+	return sortedDmResourceErrors(errs)
+}
+
+func sortedDmResourceErrors(errs map[string]*dmResourceError) []*dmResourceError {
+	sorted := []*dmResourceError{}
+	for _, e := range errs {
+		sorted = append(sorted, e)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].resource < sorted[j].resource
+	})
+	return sorted
+}
+
+// formatDmResourceErrors lists errs, each with its config indented below it.
+func formatDmResourceErrors(errs []*dmResourceError) string {
+	msg := ""
+	for _, e := range errs {
+		resource := e.resource
+		if e.resourceType != "" {
+			resource += " (" + e.resourceType + ")"
+		}
+		msg += fmt.Sprintf("\n  resource %v failed: %v", resource, e.message)
+		if e.config != "" {
+			msg += "\n    config:\n      " + strings.Replace(e.config, "\n", "\n      ", -1)
+		}
+	}
+	return msg
+}
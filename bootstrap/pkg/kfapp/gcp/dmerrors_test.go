@@ -0,0 +1,113 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kubeflow/kubeflow/bootstrap/pkg/kfapp/gcp/fake"
+	"golang.org/x/net/context"
+	"google.golang.org/api/deploymentmanager/v2"
+)
+
+func TestParseDmErrorMessage(t *testing.T) {
+	cases := []struct {
+		msg          string
+		resourceType string
+		message      string
+	}{
+		{"Quota exceeded", "", "Quota exceeded"},
+		{`{"ResourceType":"container.v1beta1.cluster","ResourceErrorCode":"400","ResourceErrorMessage":{"code":400,"message":"Node pools of f1-micro machines are not supported.","status":"INVALID_ARGUMENT"}}`,
+			"container.v1beta1.cluster", "(400) INVALID_ARGUMENT: Node pools of f1-micro machines are not supported."},
+		{`{"ResourceType":"gcp-types/cloudresourcemanager-v1:cloudresourcemanager.projects.setIamPolicy","ResourceErrorCode":"400","ResourceErrorMessage":"Policy members must be of the form \"<type>:<value>\"."}`,
+			"gcp-types/cloudresourcemanager-v1:cloudresourcemanager.projects.setIamPolicy",
+			`(400) Policy members must be of the form "<type>:<value>".`},
+	}
+	for i, c := range cases {
+		resourceType, message := parseDmErrorMessage(c.msg)
+		if resourceType != c.resourceType || message != c.message {
+			t.Errorf("case %v: parseDmErrorMessage = %q, %q; want %q, %q", i, resourceType, message,
+				c.resourceType, c.message)
+		}
+	}
+}
+
+func TestDmResourceErrors(t *testing.T) {
+	config := `resources:
+- name: kf-storage
+  type: compute.v1.disk
+- name: kf
+  type: container.v1beta1.cluster
+  properties:
+    zone: us-east1-d
+    cluster:
+      name: kf
+      nodePools:
+      - name: default-pool
+        config:
+          machineType: f1-micro
+`
+	dm := fake.NewDeploymentManager("my-project", &deploymentmanager.Deployment{
+		Name:   "kf",
+		Target: &deploymentmanager.TargetConfiguration{Config: &deploymentmanager.ConfigFile{Content: config}},
+	})
+	dm.SetResources("my-project", "kf",
+		&deploymentmanager.Resource{Name: "kf-storage", Type: "compute.v1.disk"},
+		&deploymentmanager.Resource{
+			Name: "kf",
+			Type: "container.v1beta1.cluster",
+			Update: &deploymentmanager.ResourceUpdate{
+				State: "FAILED",
+				Error: &deploymentmanager.ResourceUpdateError{
+					Errors: []*deploymentmanager.ResourceUpdateErrorErrors{
+						{Code: "RESOURCE_ERROR", Message: `{"ResourceType":"container.v1beta1.cluster","ResourceErrorCode":"400","ResourceErrorMessage":{"code":400,"message":"Node pools of f1-micro machines are not supported.","status":"INVALID_ARGUMENT"}}`},
+					},
+				},
+			},
+		})
+	op := &deploymentmanager.Operation{
+		Name:                "op-2",
+		Status:              "DONE",
+		HttpErrorStatusCode: 400,
+		HttpErrorMessage:    "BAD REQUEST",
+		TargetLink:          "https://www.googleapis.com/deploymentmanager/v2/projects/my-project/global/deployments/kf",
+		Error: &deploymentmanager.OperationError{
+			Errors: []*deploymentmanager.OperationErrorErrors{
+				{
+					Code:     "RESOURCE_ERROR",
+					Location: "/deployments/kf/resources/kf",
+					Message:  `{"ResourceType":"container.v1beta1.cluster","ResourceErrorCode":"400","ResourceErrorMessage":{"code":400,"message":"Node pools of f1-micro machines are not supported.","status":"INVALID_ARGUMENT"}}`,
+				},
+			},
+		},
+	}
+	errs := dmResourceErrors(context.Background(), dm, "my-project", op)
+	if len(errs) != 1 {
+		t.Fatalf("got %v resource errors; want 1: %+v", len(errs), errs)
+	}
+	e := errs[0]
+	if e.resource != "kf" || e.resourceType != "container.v1beta1.cluster" ||
+		e.message != "(400) INVALID_ARGUMENT: Node pools of f1-micro machines are not supported." {
+		t.Errorf("unexpected resource error %+v", e)
+	}
+	if !strings.Contains(e.config, "machineType: f1-micro") || strings.Contains(e.config, "compute.v1.disk") {
+		t.Errorf("config of the resource should be that of kf only:\n%v", e.config)
+	}
+	msg := formatDmResourceErrors(errs)
+	if !strings.Contains(msg, "resource kf (container.v1beta1.cluster) failed: (400) INVALID_ARGUMENT") {
+		t.Errorf("unexpected message %v", msg)
+	}
+}
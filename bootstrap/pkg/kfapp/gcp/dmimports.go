@@ -0,0 +1,184 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"fmt"
+	"github.com/ghodss/yaml"
+	"github.com/kubeflow/kubeflow/bootstrap/pkg/utils"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/api/deploymentmanager/v2"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// SCHEMA_SUFFIX names the schema of a template, e.g. cluster.jinja.schema for cluster.jinja.
+// DM matches them by their import names, and a schema lists the imports of its template, such
+// as the modules of a Python template.
+const SCHEMA_SUFFIX = ".schema"
+
+// importResolver collects the files a DM config imports, like gcloud deployment-manager does:
+// the templates, their schemas and the imports those schemas list, recursively. The types of
+// type providers and composite types are resolved by DM and need no import.
+type importResolver struct {
+	gcp   *Gcp
+	names map[string]bool
+	files []*deploymentmanager.ImportFile
+}
+
+// resolveImports returns the files imported by config, the content of the config file file.
+func (gcp *Gcp) resolveImports(file string, config map[string]interface{}) ([]*deploymentmanager.ImportFile, error) {
+	r := &importResolver{
+		gcp:   gcp,
+		names: map[string]bool{},
+	}
+	if err := r.add(file, config); err != nil {
+		return nil, err
+	}
+	return r.files, nil
+}
+
+// add adds the imports of config, the content of the config or schema from, whose relative
+// paths are relative to from.
+func (r *importResolver) add(from string, config map[string]interface{}) error {
+	entries, _ := config[IMPORTS].([]interface{})
+	for _, entry := range entries {
+		entryMap, ok := entry.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("invalid import %v in %v", entry, from)
+		}
+		importPath, _ := entryMap[PATH].(string)
+		if importPath == "" {
+			continue
+		}
+		// The name is what the resources and the other templates use as type, the path by default.
+		name, _ := entryMap[NAME].(string)
+		if name == "" {
+			name = importPath
+		}
+		if r.names[name] {
+			continue
+		}
+		r.names[name] = true
+		location := resolveImportPath(from, importPath)
+		log.Infof("Reading import file: %v", location)
+		buf, err := r.read(location)
+		if err != nil {
+			return fmt.Errorf("error reading import file: %v", err)
+		}
+		r.files = append(r.files, &deploymentmanager.ImportFile{
+			Name:    name,
+			Content: string(buf),
+		})
+		if !isTemplate(importPath) || r.names[name+SCHEMA_SUFFIX] {
+			continue
+		}
+		schemaBuf, err := r.read(location + SCHEMA_SUFFIX)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return fmt.Errorf("error reading schema file: %v", err)
+		}
+		r.names[name+SCHEMA_SUFFIX] = true
+		r.files = append(r.files, &deploymentmanager.ImportFile{
+			Name:    name + SCHEMA_SUFFIX,
+			Content: string(schemaBuf),
+		})
+		var schema map[string]interface{}
+		if err = yaml.Unmarshal(schemaBuf, &schema); err != nil {
+			return fmt.Errorf("Unable to read YAML %v: %v", location+SCHEMA_SUFFIX, err)
+		}
+		if err = r.add(location, schema); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// read returns the file at location: a URL, a local file for an absolute path, or else a file
+// of gcp_config.
+func (r *importResolver) read(location string) ([]byte, error) {
+	switch {
+	case isURL(location):
+		return r.download(location)
+	case filepath.IsAbs(location):
+		return ioutil.ReadFile(location)
+	default:
+		return r.gcp.readConfig(location)
+	}
+}
+
+// download returns the file at the URL location without the credentials of the app, which
+// aren't for third party hosts, trusting the CAs of spec.caBundle too.
+func (r *importResolver) download(location string) ([]byte, error) {
+	client := r.gcp.baseClient
+	if client == nil {
+		var err error
+		if client, err = utils.NewHTTPClient(r.gcp.Spec.CaBundle); err != nil {
+			return nil, err
+		}
+	}
+	resp, err := client.Get(location)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, &os.PathError{Op: "get", Path: location, Err: os.ErrNotExist}
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("couldn't get %v: %v", location, resp.Status)
+	}
+	return ioutil.ReadAll(resp.Body)
+}
+
+// resolveImportPath returns where importPath, imported by from, is: relative paths are relative
+// to the URL, the local directory or the gcp_config directory of from.
+func resolveImportPath(from string, importPath string) string {
+	switch {
+	case isURL(importPath) || filepath.IsAbs(importPath):
+		return importPath
+	case isURL(from):
+		base, err := url.Parse(from)
+		if err != nil {
+			return importPath
+		}
+		ref, err := url.Parse(importPath)
+		if err != nil {
+			return importPath
+		}
+		return base.ResolveReference(ref).String()
+	case filepath.IsAbs(from):
+		return filepath.Join(filepath.Dir(from), filepath.FromSlash(importPath))
+	default:
+		return path.Join(path.Dir(from), importPath)
+	}
+}
+
+func isURL(location string) bool {
+	return strings.HasPrefix(location, "http://") || strings.HasPrefix(location, "https://")
+}
+
+// isTemplate is true for the Jinja and Python templates, which may have a schema.
+func isTemplate(importPath string) bool {
+	ext := path.Ext(importPath)
+	return ext == ".jinja" || ext == ".py"
+}
@@ -0,0 +1,107 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"reflect"
+	"testing"
+)
+
+func TestGenerateTargetImports(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/templates/firewall.py":
+			fmt.Fprint(w, "def GenerateConfig(context): pass\n")
+		case "/templates/firewall.py.schema":
+			fmt.Fprint(w, "imports:\n- path: rules.py\n")
+		case "/templates/rules.py":
+			fmt.Fprint(w, "RULES = []\n")
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	store := NewBundle()
+	files := map[string]string{
+		"cluster-kubeflow.yaml": `imports:
+- path: cluster.jinja
+- path: cluster.jinja
+- path: vm/instance.py
+  name: instance.py
+- path: ` + server.URL + `/templates/firewall.py
+  name: firewall.py
+resources:
+- name: kubeflow
+  type: cluster.jinja
+- name: proxy
+  type: my-project/composite:proxy
+- name: bucket
+  type: my-project/storage-provider:buckets
+`,
+		"cluster.jinja":  "resources: []\n",
+		"vm/instance.py": "def GenerateConfig(context): pass\n",
+		"vm/instance.py.schema": `imports:
+- path: helpers/network.py
+- path: ../cluster.jinja
+  name: cluster.jinja
+`,
+		"vm/helpers/network.py":        "NETWORK = 'default'\n",
+		"vm/helpers/network.py.schema": "info:\n  title: network\n",
+	}
+	for name, content := range files {
+		store.Put(path.Join(GCP_CONFIG, name), []byte(content))
+	}
+	gcp := &Gcp{store: store}
+
+	target, err := gcp.generateTarget(CONFIG_FILE)
+	if err != nil {
+		t.Fatalf("generateTarget failed: %v", err)
+	}
+	got := map[string]string{}
+	names := []string{}
+	for _, i := range target.Imports {
+		got[i.Name] = i.Content
+		names = append(names, i.Name)
+	}
+	expected := []string{
+		"cluster.jinja",
+		"instance.py",
+		"instance.py.schema",
+		"helpers/network.py",
+		"helpers/network.py.schema",
+		"firewall.py",
+		"firewall.py.schema",
+		"rules.py",
+	}
+	if !reflect.DeepEqual(names, expected) {
+		t.Errorf("imports: got %v; want %v", names, expected)
+	}
+	if got["instance.py"] != files["vm/instance.py"] {
+		t.Errorf("instance.py: got %q; want the content of vm/instance.py", got["instance.py"])
+	}
+	if got["rules.py"] != "RULES = []\n" {
+		t.Errorf("rules.py: got %q; want it read relative to firewall.py", got["rules.py"])
+	}
+
+	store.Put(path.Join(GCP_CONFIG, STORAGE_FILE), []byte("imports:\n- path: missing.jinja\n"))
+	if _, err = gcp.generateTarget(STORAGE_FILE); err == nil {
+		t.Errorf("generateTarget succeeded with a missing import")
+	}
+}
@@ -0,0 +1,125 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"fmt"
+	kfapis "github.com/kubeflow/kubeflow/bootstrap/pkg/apis"
+	"github.com/kubeflow/kubeflow/bootstrap/pkg/utils"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+	"google.golang.org/api/cloudresourcemanager/v1"
+	"google.golang.org/api/iam/v1"
+	"strings"
+)
+
+// dmServiceAccountRoles are granted in the project to spec.deploymentManagerSA, which creates
+// the cluster, its service accounts, disks and node pools; the Google APIs service account DM
+// uses by default has the same.
+var dmServiceAccountRoles = []string{
+	"roles/editor",
+}
+
+// DM_SA_USER_ROLE lets the email of the app run deployments as spec.deploymentManagerSA, which
+// DM checks before using its credential.
+const DM_SA_USER_ROLE = "roles/iam.serviceAccountUser"
+
+// validateDeploymentManagerSA checks spec.deploymentManagerSA is the email of a service account.
+func (gcp *Gcp) validateDeploymentManagerSA() error {
+	email := gcp.Spec.DeploymentManagerSA
+	if email == "" || strings.HasSuffix(email, ".gserviceaccount.com") {
+		return nil
+	}
+	return &kfapis.KfError{
+		Code:    int(kfapis.INVALID_ARGUMENT),
+		Message: fmt.Sprintf("deploymentManagerSA %v isn't the email of a service account", email),
+	}
+}
+
+// dmServiceAccountBindings are the bindings of dmServiceAccountRoles for email.
+func dmServiceAccountBindings(email string) *cloudresourcemanager.Policy {
+	policy := &cloudresourcemanager.Policy{}
+	for _, role := range dmServiceAccountRoles {
+		policy.Bindings = append(policy.Bindings, &cloudresourcemanager.Binding{
+			Role:    role,
+			Members: []string{"serviceAccount:" + email},
+		})
+	}
+	return policy
+}
+
+// addServiceAccountUser adds member to the DM_SA_USER_ROLE binding of policy, and returns false
+// when it's there already.
+func addServiceAccountUser(policy *iam.Policy, member string) bool {
+	for _, binding := range policy.Bindings {
+		if binding.Role != DM_SA_USER_ROLE {
+			continue
+		}
+		for _, m := range binding.Members {
+			if m == member {
+				return false
+			}
+		}
+		binding.Members = append(binding.Members, member)
+		return true
+	}
+	policy.Bindings = append(policy.Bindings, &iam.Binding{Role: DM_SA_USER_ROLE, Members: []string{member}})
+	return true
+}
+
+// bindDeploymentManagerSA grants spec.deploymentManagerSA the roles of dmServiceAccountRoles in
+// the project, and the email of the app DM_SA_USER_ROLE on it, before the deployments are
+// inserted or updated with its credential. The bindings are left on delete, like those of the
+// Google APIs service account.
+func (gcp *Gcp) bindDeploymentManagerSA(ctx context.Context) error {
+	email := gcp.Spec.DeploymentManagerSA
+	if email == "" {
+		return nil
+	}
+	if err := gcp.validateDeploymentManagerSA(); err != nil {
+		return err
+	}
+	log.Infof("Granting %v the roles DM needs to create the deployments in %v", email, gcp.Spec.Project)
+	adding := dmServiceAccountBindings(email)
+	if err := gcp.updateIamPolicy(gcp.Spec.Project, gcp.client, func(policy *cloudresourcemanager.Policy) {
+		utils.RewriteIamPolicy(policy, adding)
+	}); err != nil {
+		return fmt.Errorf("couldn't grant %v its roles in %v: %v", email, gcp.Spec.Project, err)
+	}
+
+	service, err := iam.New(gcp.client)
+	if err != nil {
+		return fmt.Errorf("Error creating iamService: %v", err)
+	}
+	// The service account may be in another project; - stands for its project.
+	resource := "projects/-/serviceAccounts/" + email
+	policy, err := service.Projects.ServiceAccounts.GetIamPolicy(resource).Context(ctx).Do()
+	if err != nil {
+		return fmt.Errorf("couldn't get the IAM policy of %v: %v", email, err)
+	}
+	member := iapMember(gcp.Spec.Email)
+	if !addServiceAccountUser(policy, member) {
+		return nil
+	}
+	_, err = service.Projects.ServiceAccounts.SetIamPolicy(resource,
+		&iam.SetIamPolicyRequest{Policy: policy}).Context(ctx).Do()
+	gcp.audit(AUDIT_IAM_SET_POLICY, resource, map[string]interface{}{
+		"added": []string{DM_SA_USER_ROLE + " " + member},
+	}, err)
+	if err != nil {
+		return fmt.Errorf("couldn't let %v use %v: %v", gcp.Spec.Email, email, err)
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"testing"
+
+	"google.golang.org/api/iam/v1"
+)
+
+func TestValidateDeploymentManagerSA(t *testing.T) {
+	cases := []struct {
+		email string
+		valid bool
+	}{
+		{"", true},
+		{"dm@my-project.iam.gserviceaccount.com", true},
+		{"123@cloudservices.gserviceaccount.com", true},
+		{"dm@example.com", false},
+		{"dm", false},
+	}
+	for _, c := range cases {
+		gcp := &Gcp{}
+		gcp.Spec.DeploymentManagerSA = c.email
+		err := gcp.validateDeploymentManagerSA()
+		if c.valid && err != nil {
+			t.Errorf("validateDeploymentManagerSA(%v) failed: %v", c.email, err)
+		} else if !c.valid && err == nil {
+			t.Errorf("validateDeploymentManagerSA(%v) succeeded", c.email)
+		}
+	}
+}
+
+func TestAddServiceAccountUser(t *testing.T) {
+	policy := &iam.Policy{}
+	if !addServiceAccountUser(policy, "user:jane@example.com") {
+		t.Errorf("addServiceAccountUser didn't add to an empty policy")
+	}
+	if addServiceAccountUser(policy, "user:jane@example.com") {
+		t.Errorf("addServiceAccountUser added a member twice")
+	}
+	if !addServiceAccountUser(policy, "user:joe@example.com") {
+		t.Errorf("addServiceAccountUser didn't add a second member")
+	}
+	if len(policy.Bindings) != 1 || len(policy.Bindings[0].Members) != 2 ||
+		policy.Bindings[0].Role != DM_SA_USER_ROLE {
+		t.Errorf("addServiceAccountUser left bindings %+v", policy.Bindings)
+	}
+}
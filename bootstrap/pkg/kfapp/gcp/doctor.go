@@ -0,0 +1,345 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"fmt"
+	kftypes "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps"
+	"golang.org/x/net/context"
+	"google.golang.org/api/cloudresourcemanager/v1"
+	"google.golang.org/api/compute/v1"
+	containerpb "google.golang.org/genproto/googleapis/container/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientset "k8s.io/client-go/kubernetes"
+	"sort"
+	"strings"
+)
+
+// Checks of Diagnose, named in the problems they find.
+const (
+	CHECK_DEPLOYMENTS = "deployments"
+	CHECK_CLUSTER     = "cluster"
+	CHECK_IAM         = "iam"
+	CHECK_SECRETS     = "secrets"
+	CHECK_INGRESS     = "ingress"
+	CHECK_ISTIO       = "istio"
+	// INGRESS_NAME is the ingress created by the ingress components.
+	INGRESS_NAME = "envoy-ingress"
+)
+
+// diagnosis collects the problems found by the checks of Diagnose.
+type diagnosis struct {
+	problems []kftypes.Problem
+}
+
+func (d *diagnosis) add(severity kftypes.Severity, check string, message string, remediation string) {
+	d.problems = append(d.problems, kftypes.Problem{
+		Severity:    severity,
+		Check:       check,
+		Message:     message,
+		Remediation: remediation,
+	})
+}
+
+// failed records a check which couldn't run; what it would have found is unknown.
+func (d *diagnosis) failed(check string, err error) {
+	d.add(kftypes.WARNING, check, fmt.Sprintf("couldn't run the %v check: %v", check, err),
+		"check the credentials of kfctl have access to the project and the cluster")
+}
+
+// Diagnose inspects the deployment of the app: the errors of its DM deployments, the status of
+// the cluster and its node pools, the IAM bindings against iam_bindings.yaml, the secrets, the
+// ingress and IAP, and the Istio pods. It returns the problems found, the most severe first.
+func (gcp *Gcp) Diagnose() ([]kftypes.Problem, error) {
+	ctx := context.Background()
+	d := &diagnosis{}
+	gcp.checkDeployments(ctx, d)
+	clusterRunning := gcp.checkCluster(ctx, d)
+	gcp.checkIamBindings(ctx, d)
+	if clusterRunning {
+		k8sClient, err := gcp.getK8sClientset(ctx)
+		if err != nil {
+			d.add(kftypes.CRITICAL, CHECK_CLUSTER, fmt.Sprintf("couldn't reach the cluster: %v", err),
+				"check the cluster endpoint is reachable from this machine, or set spec.kubeconfig")
+		} else {
+			gcp.checkSecrets(d, k8sClient)
+			gcp.checkIngress(ctx, d, k8sClient)
+			gcp.checkIstio(d, k8sClient)
+		}
+	}
+	sort.SliceStable(d.problems, func(i, j int) bool {
+		return d.problems[i].Severity < d.problems[j].Severity
+	})
+	return d.problems, nil
+}
+
+// checkDeployments reports the errors of the last operation of the DM deployments of the app.
+func (gcp *Gcp) checkDeployments(ctx context.Context, d *diagnosis) {
+	deploymentmanagerService, err := gcp.newDeploymentManagerClient()
+	if err != nil {
+		d.failed(CHECK_DEPLOYMENTS, err)
+		return
+	}
+	deployments, err := gcp.listOwnedDeployments(ctx, deploymentmanagerService)
+	if err != nil {
+		d.failed(CHECK_DEPLOYMENTS, err)
+		return
+	}
+	if len(deployments) == 0 {
+		d.add(kftypes.CRITICAL, CHECK_DEPLOYMENTS, fmt.Sprintf("there are no deployments of %v in %v",
+			gcp.Name, gcp.Spec.Project), "run kfctl apply platform")
+		return
+	}
+	for _, deployment := range deployments {
+		op := deployment.Operation
+		if op == nil {
+			continue
+		}
+		if op.Error != nil {
+			for _, e := range op.Error.Errors {
+				_, message := parseDmErrorMessage(e.Message)
+				if resource := resourceOfLocation(e.Location); resource != "" {
+					message = fmt.Sprintf("resource %v: %v", resource, message)
+				}
+				d.add(kftypes.ERROR, CHECK_DEPLOYMENTS,
+					fmt.Sprintf("deployment %v failed: %v: %v", deployment.Name, e.Code, message),
+					fmt.Sprintf("fix the config of %v in %v and run kfctl apply platform", deployment.Name, GCP_CONFIG))
+			}
+		} else if op.Status != "DONE" {
+			d.add(kftypes.WARNING, CHECK_DEPLOYMENTS,
+				fmt.Sprintf("deployment %v is still being updated (op = %v)", deployment.Name, op.Name),
+				"wait for the operation to finish")
+		}
+	}
+}
+
+// checkCluster reports a cluster or node pools not running, and whether the cluster is running.
+func (gcp *Gcp) checkCluster(ctx context.Context, d *diagnosis) bool {
+	if gcp.useKubeconfig() {
+		return true
+	}
+	client, err := gcp.newContainerClient(ctx)
+	if err != nil {
+		d.failed(CHECK_CLUSTER, err)
+		return false
+	}
+	list, err := client.ListClusters(ctx, &containerpb.ListClustersRequest{
+		ProjectId: gcp.Spec.Project,
+		Zone:      gcp.Spec.Zone,
+	})
+	if err != nil {
+		d.failed(CHECK_CLUSTER, err)
+		return false
+	}
+	var cluster *containerpb.Cluster
+	for _, c := range list.Clusters {
+		if c.Name == gcp.Name {
+			cluster = c
+		}
+	}
+	if cluster == nil {
+		d.add(kftypes.CRITICAL, CHECK_CLUSTER, fmt.Sprintf("there's no cluster %v in %v", gcp.Name, gcp.Spec.Zone),
+			"run kfctl apply platform --target=cluster")
+		return false
+	}
+	if cluster.Status != containerpb.Cluster_RUNNING && cluster.Status != containerpb.Cluster_RECONCILING {
+		d.add(kftypes.CRITICAL, CHECK_CLUSTER,
+			fmt.Sprintf("cluster %v is %v: %v", gcp.Name, cluster.Status, cluster.StatusMessage),
+			"see the cluster in the GKE console; an errored cluster has to be deleted and applied again")
+		return false
+	}
+	for _, nodePool := range cluster.NodePools {
+		switch nodePool.Status {
+		case containerpb.NodePool_ERROR, containerpb.NodePool_RUNNING_WITH_ERROR:
+			d.add(kftypes.ERROR, CHECK_CLUSTER,
+				fmt.Sprintf("node pool %v is %v: %v", nodePool.Name, nodePool.Status, nodePool.StatusMessage),
+				"check the quotas of the project in the zone, and the machine type with kfctl cluster set-machine-type")
+		}
+	}
+	return true
+}
+
+// checkIamBindings reports the bindings of iam_bindings.yaml missing from the project, and the
+// roles of the app's service accounts which aren't in it.
+func (gcp *Gcp) checkIamBindings(ctx context.Context, d *diagnosis) {
+	want, err := gcp.readIamBindings()
+	if err != nil {
+		d.failed(CHECK_IAM, err)
+		return
+	}
+	resourceManager, err := cloudresourcemanager.New(gcp.client)
+	if err != nil {
+		d.failed(CHECK_IAM, err)
+		return
+	}
+	policy, err := resourceManager.Projects.GetIamPolicy(gcp.Spec.Project,
+		&cloudresourcemanager.GetIamPolicyRequest{}).Context(ctx).Do()
+	if err != nil {
+		d.failed(CHECK_IAM, err)
+		return
+	}
+	// Bindings are keyed by role and member.
+	project := map[string]bool{}
+	for _, binding := range policy.Bindings {
+		for _, member := range binding.Members {
+			project[binding.Role+" "+member] = true
+		}
+	}
+	wanted := map[string]bool{}
+	for _, binding := range want.Bindings {
+		for _, member := range binding.Members {
+			wanted[binding.Role+" "+member] = true
+			if !project[binding.Role+" "+member] {
+				d.add(kftypes.ERROR, CHECK_IAM, fmt.Sprintf("%v is missing %v", member, binding.Role),
+					"run kfctl apply platform --target=iam")
+			}
+		}
+	}
+	saSet := getDeploymentSAs(gcp.Name, gcp.Spec.Project)
+	for _, binding := range policy.Bindings {
+		for _, member := range binding.Members {
+			if saSet.Contains(member) && !wanted[binding.Role+" "+member] {
+				d.add(kftypes.WARNING, CHECK_IAM, fmt.Sprintf("%v has %v, which isn't in iam_bindings.yaml",
+					member, binding.Role), "run kfctl apply platform --target=iam to remove it")
+			}
+		}
+	}
+}
+
+// checkSecrets reports the service account and TLS secrets missing from their namespaces.
+func (gcp *Gcp) checkSecrets(d *diagnosis, k8sClient *clientset.Clientset) {
+	for _, namespace := range gcp.serviceAcctSecretNamespaces() {
+		for _, name := range []string{ADMIN_SECRET_NAME, USER_SECRET_NAME} {
+			_, err := k8sClient.CoreV1().Secrets(namespace).Get(name, metav1.GetOptions{})
+			if k8serrors.IsNotFound(err) {
+				d.add(kftypes.ERROR, CHECK_SECRETS, fmt.Sprintf("secret %v is missing from %v", name, namespace),
+					"run kfctl secrets sync")
+			} else if err != nil {
+				d.failed(CHECK_SECRETS, err)
+				return
+			}
+		}
+	}
+	namespace, name := gcp.ingressNamespace(), gcp.tlsSecretName()
+	_, err := k8sClient.CoreV1().Secrets(namespace).Get(name, metav1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		remediation := "run kfctl apply k8s --target=secrets"
+		if gcp.certType() == CERT_ACME {
+			remediation = "check the certificate of cert-manager with kubectl describe certificate -n " + namespace
+		}
+		d.add(kftypes.ERROR, CHECK_SECRETS, fmt.Sprintf("TLS secret %v is missing from %v", name, namespace),
+			remediation)
+	} else if err != nil {
+		d.failed(CHECK_SECRETS, err)
+	}
+}
+
+// checkIngress reports an ingress without an address and, with IAP, a backend service it
+// isn't enabled on.
+func (gcp *Gcp) checkIngress(ctx context.Context, d *diagnosis, k8sClient *clientset.Clientset) {
+	namespace := gcp.ingressNamespace()
+	ingress, err := k8sClient.ExtensionsV1beta1().Ingresses(namespace).Get(INGRESS_NAME, metav1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		d.add(kftypes.ERROR, CHECK_INGRESS, fmt.Sprintf("there's no ingress %v in %v", INGRESS_NAME, namespace),
+			"run kfctl apply k8s")
+		return
+	} else if err != nil {
+		d.failed(CHECK_INGRESS, err)
+		return
+	}
+	if len(ingress.Status.LoadBalancer.Ingress) == 0 {
+		d.add(kftypes.WARNING, CHECK_INGRESS, fmt.Sprintf("ingress %v has no address yet", INGRESS_NAME),
+			fmt.Sprintf("the load balancer takes a few minutes; see kubectl describe ingress -n %v %v",
+				namespace, INGRESS_NAME))
+		return
+	}
+	if gcp.authProvider().Name() != kftypes.AUTH_IAP {
+		return
+	}
+	svcNamespace, svcName, port := gcp.iapService()
+	svc, err := k8sClient.CoreV1().Services(svcNamespace).Get(svcName, metav1.GetOptions{})
+	if err != nil {
+		d.failed(CHECK_INGRESS, err)
+		return
+	}
+	var nodePort int32
+	for _, p := range svc.Spec.Ports {
+		if port == 0 || p.Port == port {
+			nodePort = p.NodePort
+			break
+		}
+	}
+	computeService, err := compute.New(gcp.client)
+	if err != nil {
+		d.failed(CHECK_INGRESS, err)
+		return
+	}
+	list, err := computeService.BackendServices.List(gcp.Spec.Project).
+		Filter(fmt.Sprintf("name eq k8s-be-%v-.*", nodePort)).Context(ctx).Do()
+	if err != nil {
+		d.failed(CHECK_INGRESS, err)
+		return
+	}
+	if len(list.Items) == 0 {
+		d.add(kftypes.WARNING, CHECK_INGRESS, fmt.Sprintf("the ingress has no backend service for %v/%v yet",
+			svcNamespace, svcName), "wait for the ingress to sync, then run kfctl apply k8s")
+		return
+	}
+	if backend := list.Items[0]; backend.Iap == nil || !backend.Iap.Enabled {
+		d.add(kftypes.ERROR, CHECK_INGRESS, fmt.Sprintf("IAP isn't enabled on backend service %v", backend.Name),
+			"run kfctl apply k8s to enable it with the OAuth client of the app")
+	}
+}
+
+// checkIstio reports the Istio pods which aren't running and ready.
+func (gcp *Gcp) checkIstio(d *diagnosis, k8sClient *clientset.Clientset) {
+	if !gcp.Spec.UseIstio {
+		return
+	}
+	pods, err := k8sClient.CoreV1().Pods(gcp.istioNamespace()).List(metav1.ListOptions{})
+	if err != nil {
+		d.failed(CHECK_ISTIO, err)
+		return
+	}
+	if len(pods.Items) == 0 {
+		d.add(kftypes.CRITICAL, CHECK_ISTIO, fmt.Sprintf("there are no pods in %v", gcp.istioNamespace()),
+			"run kfctl apply k8s --target=istio")
+		return
+	}
+	for _, pod := range pods.Items {
+		if pod.Status.Phase == "Succeeded" {
+			continue
+		}
+		reasons := []string{}
+		for _, c := range pod.Status.ContainerStatuses {
+			if c.Ready {
+				continue
+			}
+			if c.State.Waiting != nil {
+				reasons = append(reasons, fmt.Sprintf("%v: %v", c.Name, c.State.Waiting.Reason))
+			} else {
+				reasons = append(reasons, c.Name+" isn't ready")
+			}
+		}
+		if pod.Status.Phase != "Running" && len(reasons) == 0 {
+			reasons = append(reasons, string(pod.Status.Phase))
+		}
+		if len(reasons) != 0 {
+			d.add(kftypes.ERROR, CHECK_ISTIO, fmt.Sprintf("pod %v isn't healthy: %v", pod.Name,
+				strings.Join(reasons, ", ")), fmt.Sprintf("see kubectl describe pod -n %v %v", gcp.istioNamespace(), pod.Name))
+		}
+	}
+}
@@ -0,0 +1,302 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"reflect"
+	"testing"
+
+	kftypes "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps"
+	"github.com/kubeflow/kubeflow/bootstrap/pkg/kfapp/gcp/fake"
+	"golang.org/x/net/context"
+	"google.golang.org/api/deploymentmanager/v2"
+	containerpb "google.golang.org/genproto/googleapis/container/v1"
+	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+// fakeKubeApis is a K8s API server returning the JSON body of each path, a NotFound status
+// for the others.
+type fakeKubeApis map[string]string
+
+func (f fakeKubeApis) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	body, ok := f[r.URL.Path]
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprintf(w, `{"kind": "Status", "apiVersion": "v1", "status": "Failure", "reason": "NotFound", "code": 404, "message": "%v not found"}`, r.URL.Path)
+		return
+	}
+	fmt.Fprint(w, body)
+}
+
+func newFakeClientset(t *testing.T, apis fakeKubeApis) (*clientset.Clientset, func()) {
+	server := httptest.NewServer(apis)
+	k8sClient, err := clientset.NewForConfig(&rest.Config{Host: server.URL})
+	if err != nil {
+		server.Close()
+		t.Fatal(err)
+	}
+	return k8sClient, server.Close
+}
+
+func newDoctorGcp() *Gcp {
+	gcp := &Gcp{store: NewBundle()}
+	gcp.Name = "kf"
+	gcp.Namespace = "kubeflow"
+	gcp.Spec.Project = "my-project"
+	gcp.Spec.Zone = "us-east1-d"
+	return gcp
+}
+
+// kfctlDeployment is a deployment of the app kf.
+func kfctlDeployment(name string) *deploymentmanager.Deployment {
+	return &deploymentmanager.Deployment{
+		Name: name,
+		Labels: []*deploymentmanager.DeploymentLabelEntry{
+			{Key: LABEL_CREATED_BY, Value: CREATED_BY_KFCTL},
+			{Key: LABEL_NAME, Value: "kf"},
+		},
+	}
+}
+
+// messages returns the severity, check and message of problems.
+func messages(problems []kftypes.Problem) []string {
+	messages := []string{}
+	for _, p := range problems {
+		messages = append(messages, fmt.Sprintf("%v %v: %v", p.Severity, p.Check, p.Message))
+	}
+	return messages
+}
+
+func TestCheckDeployments(t *testing.T) {
+	dm := fake.NewDeploymentManager("my-project", kfctlDeployment("kf"), kfctlDeployment("kf-storage"),
+		kfctlDeployment("kf-updating"), &deploymentmanager.Deployment{Name: "not-kfctl"})
+	dm.Deployment("my-project", "kf-storage").Operation = &deploymentmanager.Operation{
+		Status: "DONE",
+		Error: &deploymentmanager.OperationError{
+			Errors: []*deploymentmanager.OperationErrorErrors{{
+				Code:     "RESOURCE_ERROR",
+				Location: "/deployments/kf-storage/resources/kf-storage-metadata-store",
+				Message: `{"ResourceType": "compute.v1.disk", "ResourceErrorCode": "403",
+					"ResourceErrorMessage": {"message": "quota exceeded", "status": "PERMISSION_DENIED"}}`,
+			}},
+		},
+	}
+	dm.Deployment("my-project", "kf-updating").Operation = &deploymentmanager.Operation{
+		Name:   "op-1",
+		Status: "RUNNING",
+	}
+	gcp := newDoctorGcp()
+	gcp.dmClient = dm
+	d := &diagnosis{}
+	gcp.checkDeployments(context.Background(), d)
+	want := []string{
+		fmt.Sprintf("%v deployments: deployment kf-storage failed: RESOURCE_ERROR: resource "+
+			"kf-storage-metadata-store: (403) PERMISSION_DENIED: quota exceeded", kftypes.ERROR),
+		fmt.Sprintf("%v deployments: deployment kf-updating is still being updated (op = op-1)", kftypes.WARNING),
+	}
+	if got := messages(d.problems); !reflect.DeepEqual(got, want) {
+		t.Errorf("problems %v; want %v", got, want)
+	}
+
+	// An app without deployments isn't deployed.
+	gcp.dmClient = fake.NewDeploymentManager("my-project", &deploymentmanager.Deployment{Name: "not-kfctl"})
+	d = &diagnosis{}
+	gcp.checkDeployments(context.Background(), d)
+	if len(d.problems) != 1 || d.problems[0].Severity != kftypes.CRITICAL {
+		t.Errorf("problems without deployments %v; want a critical one", messages(d.problems))
+	}
+
+	// Deployments which can't be listed are unknown, not missing.
+	dm = fake.NewDeploymentManager("my-project")
+	dm.Errors["list my-project/"] = fmt.Errorf("permission denied")
+	gcp.dmClient = dm
+	d = &diagnosis{}
+	gcp.checkDeployments(context.Background(), d)
+	if len(d.problems) != 1 || d.problems[0].Severity != kftypes.WARNING {
+		t.Errorf("problems of a failed list %v; want the check reported as failed", messages(d.problems))
+	}
+}
+
+func TestCheckCluster(t *testing.T) {
+	cases := []struct {
+		name     string
+		clusters []*containerpb.Cluster
+		running  bool
+		want     []kftypes.Severity
+	}{
+		{"running", []*containerpb.Cluster{{
+			Name:      "kf",
+			Status:    containerpb.Cluster_RUNNING,
+			NodePools: []*containerpb.NodePool{{Name: "cpu-pool", Status: containerpb.NodePool_RUNNING}},
+		}}, true, nil},
+		{"missing", []*containerpb.Cluster{{Name: "other", Status: containerpb.Cluster_RUNNING}},
+			false, []kftypes.Severity{kftypes.CRITICAL}},
+		{"errored", []*containerpb.Cluster{{Name: "kf", Status: containerpb.Cluster_ERROR}},
+			false, []kftypes.Severity{kftypes.CRITICAL}},
+		{"node pool errors", []*containerpb.Cluster{{
+			Name:   "kf",
+			Status: containerpb.Cluster_RECONCILING,
+			NodePools: []*containerpb.NodePool{
+				{Name: "cpu-pool", Status: containerpb.NodePool_RUNNING_WITH_ERROR},
+				{Name: "gpu-pool", Status: containerpb.NodePool_ERROR},
+			},
+		}}, true, []kftypes.Severity{kftypes.ERROR, kftypes.ERROR}},
+	}
+	for _, c := range cases {
+		gcp := newDoctorGcp()
+		gcp.containerClient = fake.NewContainer(c.clusters...)
+		d := &diagnosis{}
+		running := gcp.checkCluster(context.Background(), d)
+		var severities []kftypes.Severity
+		for _, p := range d.problems {
+			severities = append(severities, p.Severity)
+		}
+		if running != c.running || !reflect.DeepEqual(severities, c.want) {
+			t.Errorf("%v: checkCluster = %v with %v; want %v with %v", c.name, running,
+				messages(d.problems), c.running, c.want)
+		}
+	}
+
+	// The cluster of a kubeconfig isn't looked up in GKE.
+	gcp := newDoctorGcp()
+	gcp.Spec.KubeContext = "tunnel"
+	d := &diagnosis{}
+	if !gcp.checkCluster(context.Background(), d) || len(d.problems) != 0 {
+		t.Errorf("checkCluster with a kube context = %v", messages(d.problems))
+	}
+}
+
+func TestCheckIamBindings(t *testing.T) {
+	store := NewBundle()
+	store.Put(path.Join(GCP_CONFIG, IAM_BINDINGS_FILE), []byte(`
+bindings:
+- members:
+  - serviceAccount:kf-admin@my-project.iam.gserviceaccount.com
+  roles:
+  - roles/source.admin
+  - roles/servicemanagement.admin
+`))
+	gcp := newDoctorGcp()
+	gcp.store = store
+	gcp.client = &http.Client{Transport: fakeGcpApis{
+		"/v1/projects/my-project:getIamPolicy": `{"bindings": [
+			{"role": "roles/source.admin", "members": ["serviceAccount:kf-admin@my-project.iam.gserviceaccount.com"]},
+			{"role": "roles/owner", "members": ["serviceAccount:kf-user@my-project.iam.gserviceaccount.com",
+				"user:someone@example.com"]}]}`,
+	}}
+	d := &diagnosis{}
+	gcp.checkIamBindings(context.Background(), d)
+	want := []string{
+		fmt.Sprintf("%v iam: serviceAccount:kf-admin@my-project.iam.gserviceaccount.com is missing "+
+			"roles/servicemanagement.admin", kftypes.ERROR),
+		fmt.Sprintf("%v iam: serviceAccount:kf-user@my-project.iam.gserviceaccount.com has roles/owner, "+
+			"which isn't in iam_bindings.yaml", kftypes.WARNING),
+	}
+	if got := messages(d.problems); !reflect.DeepEqual(got, want) {
+		t.Errorf("problems %v; want %v", got, want)
+	}
+}
+
+func TestCheckK8s(t *testing.T) {
+	k8sClient, stop := newFakeClientset(t, fakeKubeApis{
+		"/api/v1/namespaces/kubeflow/secrets/admin-gcp-sa": `{"metadata": {"name": "admin-gcp-sa"}}`,
+		"/apis/extensions/v1beta1/namespaces/kubeflow/ingresses/envoy-ingress": `{"metadata": {"name": "envoy-ingress"},
+			"status": {"loadBalancer": {"ingress": [{"ip": "10.0.0.1"}]}}}`,
+		"/api/v1/namespaces/kubeflow/services/envoy": `{"metadata": {"name": "envoy"},
+			"spec": {"ports": [{"port": 8080, "nodePort": 31000}]}}`,
+		"/api/v1/namespaces/istio-system/pods": `{"items": [
+			{"metadata": {"name": "pilot"}, "status": {"phase": "Running", "containerStatuses": [
+				{"name": "discovery", "ready": false, "state": {"waiting": {"reason": "CrashLoopBackOff"}}},
+				{"name": "istio-proxy", "ready": true}]}},
+			{"metadata": {"name": "citadel"}, "status": {"phase": "Pending"}},
+			{"metadata": {"name": "cleanup"}, "status": {"phase": "Succeeded"}}]}`,
+	})
+	defer stop()
+	gcp := newDoctorGcp()
+	gcp.Spec.UseIstio = true
+	gcp.client = &http.Client{Transport: fakeGcpApis{
+		"/compute/v1/projects/my-project/global/backendServices": `{"items": [{"name": "k8s-be-31000--abc"}]}`,
+	}}
+	d := &diagnosis{}
+	gcp.checkSecrets(d, k8sClient)
+	gcp.checkIngress(context.Background(), d, k8sClient)
+	gcp.checkIstio(d, k8sClient)
+	want := []string{
+		fmt.Sprintf("%v secrets: secret user-gcp-sa is missing from kubeflow", kftypes.ERROR),
+		fmt.Sprintf("%v secrets: secret admin-gcp-sa is missing from istio-system", kftypes.ERROR),
+		fmt.Sprintf("%v secrets: secret user-gcp-sa is missing from istio-system", kftypes.ERROR),
+		fmt.Sprintf("%v secrets: TLS secret envoy-ingress-tls is missing from istio-system", kftypes.ERROR),
+		fmt.Sprintf("%v ingress: there's no ingress envoy-ingress in istio-system", kftypes.ERROR),
+		fmt.Sprintf("%v istio: pod pilot isn't healthy: discovery: CrashLoopBackOff", kftypes.ERROR),
+		fmt.Sprintf("%v istio: pod citadel isn't healthy: Pending", kftypes.ERROR),
+	}
+	if got := messages(d.problems); !reflect.DeepEqual(got, want) {
+		t.Errorf("problems with istio %v; want %v", got, want)
+	}
+
+	// Without Istio the ingress is in the app's namespace, behind a backend without IAP.
+	gcp.Spec.UseIstio = false
+	d = &diagnosis{}
+	gcp.checkIngress(context.Background(), d, k8sClient)
+	gcp.checkIstio(d, k8sClient)
+	want = []string{fmt.Sprintf("%v ingress: IAP isn't enabled on backend service k8s-be-31000--abc", kftypes.ERROR)}
+	if got := messages(d.problems); !reflect.DeepEqual(got, want) {
+		t.Errorf("problems without istio %v; want %v", got, want)
+	}
+
+	// Basic auth doesn't need IAP.
+	gcp.Spec.UseBasicAuth = true
+	d = &diagnosis{}
+	gcp.checkIngress(context.Background(), d, k8sClient)
+	if len(d.problems) != 0 {
+		t.Errorf("problems with basic auth %v", messages(d.problems))
+	}
+}
+
+func TestDiagnoseRanksProblems(t *testing.T) {
+	dm := fake.NewDeploymentManager("my-project", kfctlDeployment("kf-storage"))
+	dm.Deployment("my-project", "kf-storage").Operation = &deploymentmanager.Operation{
+		Status: "DONE",
+		Error: &deploymentmanager.OperationError{
+			Errors: []*deploymentmanager.OperationErrorErrors{{Code: "RESOURCE_ERROR", Message: "quota exceeded"}},
+		},
+	}
+	gcp := newDoctorGcp()
+	gcp.dmClient = dm
+	gcp.containerClient = fake.NewContainer()
+	problems, err := gcp.Diagnose()
+	if err != nil {
+		t.Fatalf("Diagnose failed: %v", err)
+	}
+	// The missing cluster comes first, the IAM check which couldn't read iam_bindings.yaml last.
+	checks := []string{}
+	for _, p := range problems {
+		checks = append(checks, p.Check)
+	}
+	if want := []string{CHECK_CLUSTER, CHECK_DEPLOYMENTS, CHECK_IAM}; !reflect.DeepEqual(checks, want) {
+		t.Errorf("problems %v; want those of %v", messages(problems), want)
+	}
+	for i := 1; i < len(problems); i++ {
+		if problems[i].Severity < problems[i-1].Severity {
+			t.Errorf("problems not ranked: %v", messages(problems))
+		}
+	}
+}
@@ -0,0 +1,243 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"github.com/cenkalti/backoff"
+	"github.com/ghodss/yaml"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+	"google.golang.org/api/compute/v1"
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/servicemanagement/v1"
+	"net/http"
+	"strings"
+)
+
+const (
+	// ENDPOINTS_CONFIG_FILE is the OpenAPI config kfctl submits for the endpoints service of
+	// spec.hostname.
+	ENDPOINTS_CONFIG_FILE = "openapi.yaml"
+	// ENDPOINTS_COMPONENT ran the controller resolving the endpoints service to the ingress in the
+	// cluster; kfctl does it instead for the hostnames it manages.
+	ENDPOINTS_COMPONENT = "cloud-endpoints"
+)
+
+// isEndpointsHostname reports whether spec.hostname is the endpoints service created for the app,
+// rather than a name the user owns.
+func (gcp *Gcp) isEndpointsHostname() bool {
+	return strings.HasSuffix(gcp.Spec.Hostname, ".endpoints."+gcp.Spec.Project+".cloud.goog")
+}
+
+// endpointsConfig returns the OpenAPI config of the endpoints service host resolving it to ip.
+// It has no API of its own; the service only names the ingress.
+func endpointsConfig(host string, ip string) ([]byte, error) {
+	return yaml.Marshal(map[string]interface{}{
+		"swagger": "2.0",
+		"info": map[string]interface{}{
+			"title":       host,
+			"description": "Kubeflow endpoints service, managed by kfctl",
+			"version":     "1.0.0",
+		},
+		"host":     host,
+		"basePath": "/",
+		"schemes":  []string{"https"},
+		"paths":    map[string]interface{}{},
+		"x-google-endpoints": []interface{}{
+			map[string]interface{}{
+				"name":   host,
+				"target": ip,
+			},
+		},
+	})
+}
+
+// disableEndpointsController removes ENDPOINTS_COMPONENT from the components of the app and
+// stops the ingress from creating the CloudEndpoint it watches, since Apply manages the endpoints
+// service of spec.hostname.
+func (gcp *Gcp) disableEndpointsController() {
+	gcp.specLock.Lock()
+	components := []string{}
+	for _, c := range gcp.Spec.Components {
+		if c != ENDPOINTS_COMPONENT {
+			components = append(components, c)
+		}
+	}
+	// Copied on write like the component params.
+	gcp.Spec.Components = components
+	gcp.specLock.Unlock()
+	gcp.setComponentParam(gcp.authProvider().IngressComponent(), "endpointsController", "false", false)
+}
+
+// applyEndpoints resolves the endpoints service of spec.hostname to the static IP of the ingress,
+// spec.ipName.
+func (gcp *Gcp) applyEndpoints(ctx context.Context) error {
+	computeService, err := compute.New(gcp.client)
+	if err != nil {
+		return fmt.Errorf("Error creating compute service: %v", err)
+	}
+	address, err := computeService.GlobalAddresses.Get(gcp.Spec.Project, gcp.Spec.IpName).Context(ctx).Do()
+	if err != nil {
+		return fmt.Errorf("couldn't get the static IP %v of %v: %v", gcp.Spec.IpName, gcp.Spec.Hostname, err)
+	}
+	servicemanagementService, err := gcp.newServiceManagementClient()
+	if err != nil {
+		return err
+	}
+	return gcp.submitEndpoints(ctx, servicemanagementService, address.Address)
+}
+
+// submitEndpoints creates the endpoints service of spec.hostname when it's missing and rolls out a
+// config resolving it to ip, unless its latest config already does.
+func (gcp *Gcp) submitEndpoints(ctx context.Context, servicemanagementService ServiceManagementClient,
+	ip string) error {
+	host := gcp.Spec.Hostname
+	if _, err := servicemanagementService.GetService(ctx, host); err != nil {
+		if !isNotFound(err) {
+			return fmt.Errorf("couldn't get endpoints service %v: %v", host, err)
+		}
+		if err = gcp.createEndpointsService(ctx, servicemanagementService); err != nil {
+			return err
+		}
+	} else {
+		config, err := servicemanagementService.GetServiceConfig(ctx, host)
+		if err != nil && !isNotFound(err) {
+			return fmt.Errorf("couldn't get the config of endpoints service %v: %v", host, err)
+		}
+		if err == nil {
+			for _, endpoint := range config.Endpoints {
+				if endpoint.Name == host && endpoint.Target == ip {
+					log.Infof("Endpoints service %v already resolves to %v", host, ip)
+					return nil
+				}
+			}
+		}
+	}
+
+	buf, err := endpointsConfig(host, ip)
+	if err != nil {
+		return fmt.Errorf("couldn't marshal the config of endpoints service %v: %v", host, err)
+	}
+	op, err := servicemanagementService.SubmitConfigSource(ctx, host, &servicemanagement.SubmitConfigSourceRequest{
+		ConfigSource: &servicemanagement.ConfigSource{
+			Files: []*servicemanagement.ConfigFile{
+				{
+					FilePath:     ENDPOINTS_CONFIG_FILE,
+					FileContents: base64.StdEncoding.EncodeToString(buf),
+					FileType:     "OPEN_API_YAML",
+				},
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("couldn't submit the config of endpoints service %v: %v", host, err)
+	}
+	if op, err = gcp.waitServiceManagement(ctx, servicemanagementService, op,
+		"Submitting the config of "+host); err != nil {
+		return err
+	}
+	submitted := &servicemanagement.SubmitConfigSourceResponse{}
+	if err = json.Unmarshal(op.Response, submitted); err != nil || submitted.ServiceConfig == nil {
+		return fmt.Errorf("couldn't read the config submitted for endpoints service %v: %v", host, err)
+	}
+
+	configId := submitted.ServiceConfig.Id
+	op, err = servicemanagementService.CreateRollout(ctx, host, &servicemanagement.Rollout{
+		ServiceName: host,
+		TrafficPercentStrategy: &servicemanagement.TrafficPercentStrategy{
+			Percentages: map[string]float64{configId: 100},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("couldn't roll out config %v of endpoints service %v: %v", configId, host, err)
+	}
+	if _, err = gcp.waitServiceManagement(ctx, servicemanagementService, op,
+		fmt.Sprintf("Rolling out config %v of %v", configId, host)); err != nil {
+		return err
+	}
+	log.Infof("Endpoints service %v resolves to %v", host, ip)
+	return nil
+}
+
+// createEndpointsService creates the endpoints service of spec.hostname, or restores it when it
+// was deleted less than 30 days ago, as its name can't be reused until then.
+func (gcp *Gcp) createEndpointsService(ctx context.Context, servicemanagementService ServiceManagementClient) error {
+	host := gcp.Spec.Hostname
+	log.Infof("Creating endpoints service %v", host)
+	op, err := servicemanagementService.CreateService(ctx, &servicemanagement.ManagedService{
+		ServiceName:       host,
+		ProducerProjectId: gcp.Spec.Project,
+	})
+	if e, ok := err.(*googleapi.Error); ok && e.Code == http.StatusConflict {
+		log.Infof("Endpoints service %v was deleted; restoring it", host)
+		op, err = servicemanagementService.UndeleteService(ctx, host)
+	}
+	if err != nil {
+		return fmt.Errorf("couldn't create endpoints service %v: %v", host, err)
+	}
+	_, err = gcp.waitServiceManagement(ctx, servicemanagementService, op, "Creating "+host)
+	return err
+}
+
+// deleteEndpoints deletes the endpoints service of spec.hostname, which isn't part of any
+// deployment. It reports whether there was one.
+func (gcp *Gcp) deleteEndpoints(ctx context.Context) (bool, error) {
+	servicemanagementService, err := gcp.newServiceManagementClient()
+	if err != nil {
+		return false, err
+	}
+	op, err := servicemanagementService.DeleteService(ctx, gcp.Spec.Hostname)
+	if err != nil {
+		if isNotFound(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("couldn't delete endpoints service %v: %v", gcp.Spec.Hostname, err)
+	}
+	if _, err = gcp.waitServiceManagement(ctx, servicemanagementService, op,
+		"Deleting "+gcp.Spec.Hostname); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// waitServiceManagement waits for op to be done and returns it.
+func (gcp *Gcp) waitServiceManagement(ctx context.Context, servicemanagementService ServiceManagementClient,
+	op *servicemanagement.Operation, logPrefix string) (*servicemanagement.Operation, error) {
+	err := gcp.retry(func() error {
+		if !op.Done {
+			current, err := servicemanagementService.GetOperation(ctx, op.Name)
+			if err != nil {
+				return fmt.Errorf("%v error: %v", logPrefix, err)
+			}
+			op = current
+		}
+		if !op.Done {
+			log.Infof("%v isn't done yet (op = %v)", logPrefix, op.Name)
+			return fmt.Errorf("%v isn't done (op = %v)", logPrefix, op.Name)
+		}
+		if op.Error != nil {
+			return backoff.Permanent(fmt.Errorf("%v error(%v): %v", logPrefix, op.Error.Code, op.Error.Message))
+		}
+		log.Infof("%v is finished", logPrefix)
+		return nil
+	}, backoff.NewExponentialBackOff())
+	if err != nil {
+		return nil, err
+	}
+	return op, nil
+}
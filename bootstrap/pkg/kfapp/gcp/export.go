@@ -0,0 +1,81 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"fmt"
+	kfapis "github.com/kubeflow/kubeflow/bootstrap/pkg/apis"
+	kftypes "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps"
+	"path"
+	"strings"
+)
+
+// ISTIO_EXPORT_DIR is the directory of the exported archive holding the Istio manifests.
+const ISTIO_EXPORT_DIR = "istio"
+
+// Export returns what apply applies: the configs and templates of gcp_config for PLATFORM, and
+// the Istio manifests for K8S when spec.useIstio is set, as they're applied, and the secrets-sync
+// manifest with spec.secretsSync. gcp_config and k8s_specs are read from the app dir, so changes
+// made to them since generate are exported too.
+func (gcp *Gcp) Export(resources kftypes.ResourceEnum) (map[string][]byte, error) {
+	files := map[string][]byte{}
+	if resources == kftypes.PLATFORM || resources == kftypes.ALL {
+		names, err := gcp.store.ListFiles(GCP_CONFIG)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't list the files of %v: %v", GCP_CONFIG, err)
+		}
+		if len(names) == 0 {
+			return nil, &kfapis.KfError{
+				Code: int(kfapis.INVALID_ARGUMENT),
+				Message: fmt.Sprintf("there's no %v in %v; run kfctl generate platform first",
+					GCP_CONFIG, gcp.Spec.AppDir),
+			}
+		}
+		for _, name := range names {
+			buf, err := gcp.store.ReadFile(name)
+			if err != nil {
+				return nil, fmt.Errorf("couldn't read %v: %v", name, err)
+			}
+			files[name] = buf
+		}
+	}
+	if (resources == kftypes.K8S || resources == kftypes.ALL) && gcp.Spec.UseIstio {
+		for _, manifest := range gcp.istioManifests() {
+			buf, err := gcp.manifestLoader().Read(manifest)
+			if err != nil {
+				return nil, fmt.Errorf("couldn't read Istio manifest %v: %v", manifest, err)
+			}
+			name := path.Join(ISTIO_EXPORT_DIR, strings.TrimPrefix(manifest, "dependencies/istio/"))
+			files[name] = buf
+		}
+		if gcp.useIstioIapIngress() {
+			name := path.Join(K8S_SPECS, ISTIO_IAP_INGRESS_FILE)
+			buf, err := gcp.store.ReadFile(name)
+			if err != nil {
+				return nil, fmt.Errorf("couldn't read %v: %v", name, err)
+			}
+			files[name] = buf
+		}
+	}
+	if (resources == kftypes.K8S || resources == kftypes.ALL) && gcp.Spec.SecretsSync {
+		name := path.Join(K8S_SPECS, SECRETS_SYNC_FILE)
+		buf, err := gcp.store.ReadFile(name)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't read %v: %v", name, err)
+		}
+		files[name] = buf
+	}
+	return files, nil
+}
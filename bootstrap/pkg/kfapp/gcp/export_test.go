@@ -0,0 +1,67 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"path"
+	"reflect"
+	"sort"
+	"testing"
+
+	kftypes "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps"
+)
+
+func TestExport(t *testing.T) {
+	store := NewBundle()
+	gcp := &Gcp{store: store}
+	gcp.Spec.UseEmbeddedAssets = true
+	gcp.Spec.UseIstio = true
+
+	if _, err := gcp.Export(kftypes.PLATFORM); err == nil {
+		t.Errorf("Export succeeded without gcp_config")
+	}
+
+	generated := NewBundle()
+	generated.Put(kftypes.KfConfigFile, []byte("kind: KfDef\n"))
+	generated.Put(path.Join(GCP_CONFIG, STORAGE_FILE), []byte("resources: []\n"))
+	generated.Put(path.Join(GCP_CONFIG, "storage.jinja"), []byte("{}\n"))
+	generated.Put(path.Join(K8S_SPECS, ISTIO_IAP_INGRESS_FILE), []byte("kind: BackendConfig\n"))
+	if err := store.WriteBundle(generated); err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		resources kftypes.ResourceEnum
+		expected  []string
+	}{
+		{kftypes.PLATFORM, []string{"gcp_config/storage-kubeflow.yaml", "gcp_config/storage.jinja"}},
+		{kftypes.K8S, []string{"istio/install/crds.yaml", "istio/install/istio-noauth.yaml",
+			"istio/install/profiles/noauth.yaml", "istio/kf-istio-resources.yaml", "k8s_specs/istio-iap-ingress.yaml"}},
+	}
+	for _, c := range cases {
+		files, err := gcp.Export(c.resources)
+		if err != nil {
+			t.Fatalf("Export(%v) failed: %v", c.resources, err)
+		}
+		names := []string{}
+		for name := range files {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		if !reflect.DeepEqual(names, c.expected) {
+			t.Errorf("Export(%v) = %v; want %v", c.resources, names, c.expected)
+		}
+	}
+}
@@ -0,0 +1,164 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fake
+
+import (
+	"fmt"
+	"golang.org/x/net/context"
+	containerpb "google.golang.org/genproto/googleapis/container/v1"
+	"sync"
+)
+
+// Container is a gcp.ContainerClient keeping the GKE clusters of a project and zone in memory.
+type Container struct {
+	mu       sync.Mutex
+	clusters []*containerpb.Cluster
+	ops      int
+	// ServerConfig is returned by GetServerConfig.
+	ServerConfig *containerpb.ServerConfig
+}
+
+// NewContainer returns a Container with clusters, in every project and zone.
+func NewContainer(clusters ...*containerpb.Cluster) *Container {
+	return &Container{
+		clusters:     clusters,
+		ServerConfig: &containerpb.ServerConfig{},
+	}
+}
+
+// Cluster returns the cluster name, nil when there's none.
+func (f *Container) Cluster(name string) *containerpb.Cluster {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.cluster(name)
+}
+
+func (f *Container) cluster(name string) *containerpb.Cluster {
+	for _, c := range f.clusters {
+		if c.Name == name {
+			return c
+		}
+	}
+	return nil
+}
+
+func (f *Container) nodePool(cluster string, name string) (*containerpb.Cluster, int, error) {
+	c := f.cluster(cluster)
+	if c == nil {
+		return nil, -1, notFound("cluster " + cluster)
+	}
+	for i, p := range c.NodePools {
+		if p.Name == name {
+			return c, i, nil
+		}
+	}
+	return c, -1, notFound("node pool " + name)
+}
+
+// done returns an operation of opType done at once.
+func (f *Container) done(opType containerpb.Operation_Type, target string) *containerpb.Operation {
+	f.ops++
+	return &containerpb.Operation{
+		Name:          fmt.Sprintf("operation-%v", f.ops),
+		OperationType: opType,
+		Status:        containerpb.Operation_DONE,
+		TargetLink:    target,
+	}
+}
+
+func (f *Container) ListClusters(ctx context.Context,
+	req *containerpb.ListClustersRequest) (*containerpb.ListClustersResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return &containerpb.ListClustersResponse{
+		Clusters: append([]*containerpb.Cluster{}, f.clusters...),
+	}, nil
+}
+
+func (f *Container) ListNodePools(ctx context.Context,
+	req *containerpb.ListNodePoolsRequest) (*containerpb.ListNodePoolsResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	c := f.cluster(req.ClusterId)
+	if c == nil {
+		return nil, notFound("cluster " + req.ClusterId)
+	}
+	return &containerpb.ListNodePoolsResponse{
+		NodePools: append([]*containerpb.NodePool{}, c.NodePools...),
+	}, nil
+}
+
+func (f *Container) CreateNodePool(ctx context.Context,
+	req *containerpb.CreateNodePoolRequest) (*containerpb.Operation, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	c := f.cluster(req.ClusterId)
+	if c == nil {
+		return nil, notFound("cluster " + req.ClusterId)
+	}
+	c.NodePools = append(c.NodePools, req.NodePool)
+	return f.done(containerpb.Operation_CREATE_NODE_POOL, req.NodePool.Name), nil
+}
+
+func (f *Container) DeleteNodePool(ctx context.Context,
+	req *containerpb.DeleteNodePoolRequest) (*containerpb.Operation, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	c, i, err := f.nodePool(req.ClusterId, req.NodePoolId)
+	if err != nil {
+		return nil, err
+	}
+	c.NodePools = append(c.NodePools[:i], c.NodePools[i+1:]...)
+	return f.done(containerpb.Operation_DELETE_NODE_POOL, req.NodePoolId), nil
+}
+
+func (f *Container) SetNodePoolSize(ctx context.Context,
+	req *containerpb.SetNodePoolSizeRequest) (*containerpb.Operation, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	c, i, err := f.nodePool(req.ClusterId, req.NodePoolId)
+	if err != nil {
+		return nil, err
+	}
+	c.NodePools[i].InitialNodeCount = req.NodeCount
+	return f.done(containerpb.Operation_SET_NODE_POOL_SIZE, req.NodePoolId), nil
+}
+
+func (f *Container) UpdateMaster(ctx context.Context,
+	req *containerpb.UpdateMasterRequest) (*containerpb.Operation, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	c := f.cluster(req.ClusterId)
+	if c == nil {
+		return nil, notFound("cluster " + req.ClusterId)
+	}
+	c.CurrentMasterVersion = req.MasterVersion
+	return f.done(containerpb.Operation_UPGRADE_MASTER, req.ClusterId), nil
+}
+
+func (f *Container) GetServerConfig(ctx context.Context,
+	req *containerpb.GetServerConfigRequest) (*containerpb.ServerConfig, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.ServerConfig, nil
+}
+
+func (f *Container) GetOperation(ctx context.Context,
+	req *containerpb.GetOperationRequest) (*containerpb.Operation, error) {
+	return &containerpb.Operation{
+		Name:   req.OperationId,
+		Status: containerpb.Operation_DONE,
+	}, nil
+}
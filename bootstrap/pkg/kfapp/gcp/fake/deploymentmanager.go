@@ -0,0 +1,276 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package fake has in-memory implementations of the clients of the GCP APIs the gcp kfapp
+// calls, to test it without credentials. Their operations are done at once, and they're safe
+// for concurrent use.
+package fake
+
+import (
+	"fmt"
+	"golang.org/x/net/context"
+	"google.golang.org/api/deploymentmanager/v2"
+	"google.golang.org/api/googleapi"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// DeploymentManager is a gcp.DeploymentManagerClient keeping the deployments and their last
+// manifest in memory.
+type DeploymentManager struct {
+	mu          sync.Mutex
+	deployments map[string]*deploymentmanager.Deployment
+	manifests   map[string]*deploymentmanager.Manifest
+	operations  map[string]*deploymentmanager.Operation
+	resources   map[string][]*deploymentmanager.Resource
+	ops         int
+	// Calls are the calls made, e.g. "insert my-project/kf-storage".
+	Calls []string
+	// Errors are returned by the calls of the same name, e.g. "insert my-project/kf-storage",
+	// instead of making them.
+	Errors map[string]error
+}
+
+// NewDeploymentManager returns a DeploymentManager with deployments in their projects.
+func NewDeploymentManager(project string, deployments ...*deploymentmanager.Deployment) *DeploymentManager {
+	f := &DeploymentManager{
+		deployments: map[string]*deploymentmanager.Deployment{},
+		manifests:   map[string]*deploymentmanager.Manifest{},
+		operations:  map[string]*deploymentmanager.Operation{},
+		resources:   map[string][]*deploymentmanager.Resource{},
+		Errors:      map[string]error{},
+	}
+	for _, d := range deployments {
+		f.put(project, d)
+	}
+	return f
+}
+
+// Deployment returns the deployment project/name, nil when there's none.
+func (f *DeploymentManager) Deployment(project string, name string) *deploymentmanager.Deployment {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.deployments[project+"/"+name]
+}
+
+// call records the call op on project/name and returns the error set for it.
+func (f *DeploymentManager) call(op string, project string, name string) error {
+	call := fmt.Sprintf("%v %v/%v", op, project, name)
+	f.Calls = append(f.Calls, call)
+	return f.Errors[call]
+}
+
+// put stores d with a done operation and its manifest, and returns the operation.
+func (f *DeploymentManager) put(project string, d *deploymentmanager.Deployment) *deploymentmanager.Operation {
+	op := f.done(project)
+	stored := *d
+	stored.Operation = op
+	stored.Fingerprint = fmt.Sprintf("fingerprint-%v", f.ops)
+	manifest := &deploymentmanager.Manifest{
+		Name: fmt.Sprintf("manifest-%v", f.ops),
+	}
+	if d.Target != nil {
+		manifest.Config = d.Target.Config
+		manifest.Imports = d.Target.Imports
+	}
+	stored.Manifest = "projects/" + project + "/global/deployments/" + d.Name + "/manifests/" + manifest.Name
+	f.deployments[project+"/"+d.Name] = &stored
+	f.manifests[project+"/"+d.Name+"/"+manifest.Name] = manifest
+	return op
+}
+
+func notFound(resource string) error {
+	return &googleapi.Error{
+		Code:    http.StatusNotFound,
+		Message: fmt.Sprintf("%v not found", resource),
+	}
+}
+
+func (f *DeploymentManager) GetDeployment(ctx context.Context, project string,
+	name string) (*deploymentmanager.Deployment, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.call("get", project, name); err != nil {
+		return nil, err
+	}
+	d, ok := f.deployments[project+"/"+name]
+	if !ok {
+		return nil, notFound("deployment " + project + "/" + name)
+	}
+	return d, nil
+}
+
+func (f *DeploymentManager) ListDeployments(ctx context.Context, project string,
+	page func(*deploymentmanager.DeploymentsListResponse) error) error {
+	f.mu.Lock()
+	if err := f.call("list", project, ""); err != nil {
+		f.mu.Unlock()
+		return err
+	}
+	names := []string{}
+	for key := range f.deployments {
+		names = append(names, key)
+	}
+	sort.Strings(names)
+	resp := &deploymentmanager.DeploymentsListResponse{}
+	for _, key := range names {
+		if strings.HasPrefix(key, project+"/") {
+			resp.Deployments = append(resp.Deployments, f.deployments[key])
+		}
+	}
+	f.mu.Unlock()
+	return page(resp)
+}
+
+func (f *DeploymentManager) InsertDeployment(ctx context.Context, project string,
+	deployment *deploymentmanager.Deployment, createPolicy string) (*deploymentmanager.Operation, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	op := "insert"
+	if createPolicy != "" {
+		op += " " + createPolicy
+	}
+	if err := f.call(op, project, deployment.Name); err != nil {
+		return nil, err
+	}
+	if _, ok := f.deployments[project+"/"+deployment.Name]; ok {
+		return nil, &googleapi.Error{
+			Code:    http.StatusConflict,
+			Message: fmt.Sprintf("deployment %v/%v already exists", project, deployment.Name),
+		}
+	}
+	return f.put(project, deployment), nil
+}
+
+func (f *DeploymentManager) UpdateDeployment(ctx context.Context, project string, name string,
+	deployment *deploymentmanager.Deployment, deletePolicy string) (*deploymentmanager.Operation, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	op := "update"
+	if deletePolicy != "" {
+		op += " " + deletePolicy
+	}
+	if err := f.call(op, project, name); err != nil {
+		return nil, err
+	}
+	current, ok := f.deployments[project+"/"+name]
+	if !ok {
+		return nil, notFound("deployment " + project + "/" + name)
+	}
+	if deployment.Fingerprint != current.Fingerprint {
+		return nil, &googleapi.Error{
+			Code:    http.StatusPreconditionFailed,
+			Message: fmt.Sprintf("fingerprint %v of %v/%v is stale", deployment.Fingerprint, project, name),
+		}
+	}
+	return f.put(project, deployment), nil
+}
+
+func (f *DeploymentManager) DeleteDeployment(ctx context.Context, project string,
+	name string, deletePolicy string) (*deploymentmanager.Operation, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	call := "delete"
+	if deletePolicy != "" {
+		call += " " + deletePolicy
+	}
+	if err := f.call(call, project, name); err != nil {
+		return nil, err
+	}
+	if _, ok := f.deployments[project+"/"+name]; !ok {
+		return nil, notFound("deployment " + project + "/" + name)
+	}
+	delete(f.deployments, project+"/"+name)
+	return f.done(project), nil
+}
+
+// StopDeployment marks the operation of the deployment done, as if it was stopped.
+func (f *DeploymentManager) StopDeployment(ctx context.Context, project string,
+	name string, fingerprint string) (*deploymentmanager.Operation, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.call("stop", project, name); err != nil {
+		return nil, err
+	}
+	current, ok := f.deployments[project+"/"+name]
+	if !ok {
+		return nil, notFound("deployment " + project + "/" + name)
+	}
+	if fingerprint != current.Fingerprint {
+		return nil, &googleapi.Error{
+			Code:    http.StatusPreconditionFailed,
+			Message: fmt.Sprintf("fingerprint %v of %v/%v is stale", fingerprint, project, name),
+		}
+	}
+	if current.Operation != nil {
+		current.Operation.Status = "DONE"
+	}
+	return f.done(project), nil
+}
+
+// done records and returns a new done operation.
+func (f *DeploymentManager) done(project string) *deploymentmanager.Operation {
+	f.ops++
+	op := &deploymentmanager.Operation{
+		Name:   fmt.Sprintf("op-%v", f.ops),
+		Status: "DONE",
+	}
+	f.operations[project+"/"+op.Name] = op
+	return op
+}
+
+func (f *DeploymentManager) GetManifest(ctx context.Context, project string, deployment string,
+	manifest string) (*deploymentmanager.Manifest, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	m, ok := f.manifests[project+"/"+deployment+"/"+manifest]
+	if !ok {
+		return nil, notFound("manifest " + manifest)
+	}
+	return m, nil
+}
+
+// SetResources sets the resources ListResources returns for deployment project/name, e.g. with
+// the errors of a failed operation.
+func (f *DeploymentManager) SetResources(project string, name string, resources ...*deploymentmanager.Resource) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.resources[project+"/"+name] = resources
+}
+
+func (f *DeploymentManager) ListResources(ctx context.Context, project string,
+	deployment string) ([]*deploymentmanager.Resource, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.call("resources", project, deployment); err != nil {
+		return nil, err
+	}
+	if _, ok := f.deployments[project+"/"+deployment]; !ok {
+		return nil, notFound("deployment " + project + "/" + deployment)
+	}
+	return f.resources[project+"/"+deployment], nil
+}
+
+func (f *DeploymentManager) GetOperation(ctx context.Context, project string,
+	name string) (*deploymentmanager.Operation, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	op, ok := f.operations[project+"/"+name]
+	if !ok {
+		return nil, notFound("operation " + name)
+	}
+	return op, nil
+}
@@ -0,0 +1,108 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fake
+
+import (
+	"encoding/base64"
+	"fmt"
+	"golang.org/x/net/context"
+	"google.golang.org/api/iam/v1"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Iam is a gcp.IamClient keeping the user managed keys of the service accounts in memory.
+type Iam struct {
+	mu   sync.Mutex
+	keys map[string]*iam.ServiceAccountKey
+	n    int
+}
+
+// NewIam returns an Iam with keys, named projects/<project>/serviceAccounts/<email>/keys/<id>.
+func NewIam(keys ...*iam.ServiceAccountKey) *Iam {
+	f := &Iam{
+		keys: map[string]*iam.ServiceAccountKey{},
+	}
+	for _, key := range keys {
+		f.keys[key.Name] = key
+	}
+	return f
+}
+
+// Keys returns the names of the keys, sorted.
+func (f *Iam) Keys() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	names := []string{}
+	for name := range f.keys {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (f *Iam) CreateServiceAccountKey(ctx context.Context, account string,
+	req *iam.CreateServiceAccountKeyRequest) (*iam.ServiceAccountKey, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.n++
+	key := &iam.ServiceAccountKey{
+		Name:           fmt.Sprintf("%v/keys/key-%v", account, f.n),
+		KeyAlgorithm:   req.KeyAlgorithm,
+		PrivateKeyType: req.PrivateKeyType,
+		PrivateKeyData: base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf(`{"type": "service_account", "private_key_id": "key-%v"}`, f.n))),
+		ValidAfterTime: time.Date(2019, 4, 1, 0, 0, f.n, 0, time.UTC).Format(time.RFC3339),
+	}
+	f.keys[key.Name] = key
+	return key, nil
+}
+
+func (f *Iam) GetServiceAccountKey(ctx context.Context, name string) (*iam.ServiceAccountKey, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	key, ok := f.keys[name]
+	if !ok {
+		return nil, notFound("key " + name)
+	}
+	return key, nil
+}
+
+func (f *Iam) ListServiceAccountKeys(ctx context.Context, account string,
+	keyType string) ([]*iam.ServiceAccountKey, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	keys := []*iam.ServiceAccountKey{}
+	for name, key := range f.keys {
+		if strings.HasPrefix(name, account+"/keys/") {
+			keys = append(keys, key)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].Name < keys[j].Name
+	})
+	return keys, nil
+}
+
+func (f *Iam) DeleteServiceAccountKey(ctx context.Context, name string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, ok := f.keys[name]; !ok {
+		return notFound("key " + name)
+	}
+	delete(f.keys, name)
+	return nil
+}
@@ -0,0 +1,224 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fake
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"github.com/ghodss/yaml"
+	"golang.org/x/net/context"
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/servicemanagement/v1"
+	"net/http"
+	"sort"
+	"sync"
+)
+
+// ServiceManagement is a gcp.ServiceManagementClient keeping the endpoints services, the configs
+// submitted for them and the config rolled out in memory. Deleted services can be undeleted.
+type ServiceManagement struct {
+	mu       sync.Mutex
+	services map[string]*servicemanagement.ManagedService
+	deleted  map[string]*servicemanagement.ManagedService
+	configs  map[string][]*servicemanagement.Service
+	rolled   map[string]*servicemanagement.Service
+	ops      int
+	// Calls are the calls made, e.g. "submit kf.endpoints.my-project.cloud.goog".
+	Calls []string
+}
+
+// NewServiceManagement returns a ServiceManagement without any service.
+func NewServiceManagement() *ServiceManagement {
+	return &ServiceManagement{
+		services: map[string]*servicemanagement.ManagedService{},
+		deleted:  map[string]*servicemanagement.ManagedService{},
+		configs:  map[string][]*servicemanagement.Service{},
+		rolled:   map[string]*servicemanagement.Service{},
+	}
+}
+
+// Target returns the target of the endpoint name in the config rolled out for service name, ""
+// when there's none.
+func (f *ServiceManagement) Target(name string) string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	config, ok := f.rolled[name]
+	if !ok {
+		return ""
+	}
+	for _, endpoint := range config.Endpoints {
+		if endpoint.Name == name {
+			return endpoint.Target
+		}
+	}
+	return ""
+}
+
+// done returns a done operation with response, marshalled to JSON.
+func (f *ServiceManagement) done(response interface{}) (*servicemanagement.Operation, error) {
+	f.ops++
+	op := &servicemanagement.Operation{
+		Name: fmt.Sprintf("operations/op-%v", f.ops),
+		Done: true,
+	}
+	if response != nil {
+		buf, err := json.Marshal(response)
+		if err != nil {
+			return nil, err
+		}
+		op.Response = buf
+	}
+	return op, nil
+}
+
+func (f *ServiceManagement) GetService(ctx context.Context, name string) (*servicemanagement.ManagedService, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.Calls = append(f.Calls, "get "+name)
+	s, ok := f.services[name]
+	if !ok {
+		return nil, notFound("service " + name)
+	}
+	return s, nil
+}
+
+func (f *ServiceManagement) ListServices(ctx context.Context, project string,
+	page func(*servicemanagement.ListServicesResponse) error) error {
+	f.mu.Lock()
+	names := []string{}
+	for name, s := range f.services {
+		if s.ProducerProjectId == project {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	resp := &servicemanagement.ListServicesResponse{}
+	for _, name := range names {
+		resp.Services = append(resp.Services, f.services[name])
+	}
+	f.mu.Unlock()
+	return page(resp)
+}
+
+func (f *ServiceManagement) CreateService(ctx context.Context,
+	service *servicemanagement.ManagedService) (*servicemanagement.Operation, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.Calls = append(f.Calls, "create "+service.ServiceName)
+	_, exists := f.services[service.ServiceName]
+	if _, deleted := f.deleted[service.ServiceName]; exists || deleted {
+		return nil, &googleapi.Error{
+			Code:    http.StatusConflict,
+			Message: fmt.Sprintf("service %v already exists", service.ServiceName),
+		}
+	}
+	stored := *service
+	f.services[service.ServiceName] = &stored
+	return f.done(&stored)
+}
+
+func (f *ServiceManagement) GetServiceConfig(ctx context.Context, name string) (*servicemanagement.Service, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	configs := f.configs[name]
+	if len(configs) == 0 {
+		return nil, notFound("config of service " + name)
+	}
+	return configs[len(configs)-1], nil
+}
+
+func (f *ServiceManagement) SubmitConfigSource(ctx context.Context, name string,
+	req *servicemanagement.SubmitConfigSourceRequest) (*servicemanagement.Operation, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.Calls = append(f.Calls, "submit "+name)
+	if _, ok := f.services[name]; !ok {
+		return nil, notFound("service " + name)
+	}
+	config := &servicemanagement.Service{
+		Id:   fmt.Sprintf("config-%v", len(f.configs[name])+1),
+		Name: name,
+	}
+	for _, file := range req.ConfigSource.Files {
+		buf, err := base64.StdEncoding.DecodeString(file.FileContents)
+		if err != nil {
+			return nil, &googleapi.Error{Code: http.StatusBadRequest, Message: err.Error()}
+		}
+		openapi := struct {
+			Endpoints []*servicemanagement.Endpoint `json:"x-google-endpoints"`
+		}{}
+		if err = yaml.Unmarshal(buf, &openapi); err != nil {
+			return nil, &googleapi.Error{Code: http.StatusBadRequest, Message: err.Error()}
+		}
+		config.Endpoints = append(config.Endpoints, openapi.Endpoints...)
+	}
+	f.configs[name] = append(f.configs[name], config)
+	return f.done(&servicemanagement.SubmitConfigSourceResponse{ServiceConfig: config})
+}
+
+func (f *ServiceManagement) CreateRollout(ctx context.Context, name string,
+	rollout *servicemanagement.Rollout) (*servicemanagement.Operation, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.Calls = append(f.Calls, "rollout "+name)
+	if rollout.TrafficPercentStrategy == nil {
+		return nil, &googleapi.Error{Code: http.StatusBadRequest, Message: "no traffic percent strategy"}
+	}
+	for id := range rollout.TrafficPercentStrategy.Percentages {
+		for _, config := range f.configs[name] {
+			if config.Id == id {
+				f.rolled[name] = config
+			}
+		}
+		if f.rolled[name] == nil || f.rolled[name].Id != id {
+			return nil, notFound("config " + id + " of service " + name)
+		}
+	}
+	return f.done(rollout)
+}
+
+func (f *ServiceManagement) DeleteService(ctx context.Context, name string) (*servicemanagement.Operation, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.Calls = append(f.Calls, "delete "+name)
+	s, ok := f.services[name]
+	if !ok {
+		return nil, notFound("service " + name)
+	}
+	delete(f.services, name)
+	f.deleted[name] = s
+	return f.done(nil)
+}
+
+func (f *ServiceManagement) UndeleteService(ctx context.Context, name string) (*servicemanagement.Operation, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.Calls = append(f.Calls, "undelete "+name)
+	s, ok := f.deleted[name]
+	if !ok {
+		return nil, notFound("deleted service " + name)
+	}
+	delete(f.deleted, name)
+	f.services[name] = s
+	return f.done(s)
+}
+
+func (f *ServiceManagement) GetOperation(ctx context.Context, name string) (*servicemanagement.Operation, error) {
+	return &servicemanagement.Operation{
+		Name: name,
+		Done: true,
+	}, nil
+}
@@ -0,0 +1,91 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fake
+
+import (
+	"fmt"
+	"golang.org/x/net/context"
+	"google.golang.org/api/serviceusage/v1"
+	"sync"
+)
+
+// ServiceUsage is a gcp.ServiceUsageClient recording the APIs enabled and their batches.
+type ServiceUsage struct {
+	mu      sync.Mutex
+	enabled map[string]bool
+	polls   map[string]int
+	errors  map[string]*serviceusage.Status
+	// Batches are the services of each BatchEnableServices call.
+	Batches [][]string
+	// Pending is how many times GetOperation returns an operation as not done yet.
+	Pending int
+	// Failures are the services whose enabling fails, with the message of the error.
+	Failures map[string]string
+}
+
+// NewServiceUsage returns a ServiceUsage without any API enabled.
+func NewServiceUsage() *ServiceUsage {
+	return &ServiceUsage{
+		enabled:  map[string]bool{},
+		polls:    map[string]int{},
+		errors:   map[string]*serviceusage.Status{},
+		Failures: map[string]string{},
+	}
+}
+
+// Enabled is true when service, e.g. compute.googleapis.com, was enabled in parent.
+func (f *ServiceUsage) Enabled(parent string, service string) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.enabled[parent+"/services/"+service]
+}
+
+func (f *ServiceUsage) BatchEnableServices(ctx context.Context, parent string,
+	req *serviceusage.BatchEnableServicesRequest) (*serviceusage.Operation, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.Batches = append(f.Batches, req.ServiceIds)
+	op := &serviceusage.Operation{
+		Name: fmt.Sprintf("operations/batch-%v", len(f.Batches)),
+		Done: f.Pending == 0,
+	}
+	for _, service := range req.ServiceIds {
+		if message, failed := f.Failures[service]; failed {
+			f.errors[op.Name] = &serviceusage.Status{Code: 9, Message: message}
+			continue
+		}
+		f.enabled[parent+"/services/"+service] = true
+	}
+	if op.Done {
+		op.Error = f.errors[op.Name]
+	}
+	return op, nil
+}
+
+// GetOperation returns the operation as done once it was polled Pending times. A failed
+// operation is only known once it's done.
+func (f *ServiceUsage) GetOperation(ctx context.Context, name string) (*serviceusage.Operation, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.polls[name]++
+	op := &serviceusage.Operation{
+		Name: name,
+		Done: f.polls[name] > f.Pending,
+	}
+	if op.Done {
+		op.Error = f.errors[name]
+	}
+	return op, nil
+}
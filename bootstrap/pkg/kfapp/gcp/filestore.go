@@ -0,0 +1,253 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"fmt"
+	"github.com/ghodss/yaml"
+	kfapis "github.com/kubeflow/kubeflow/bootstrap/pkg/apis"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+	"google.golang.org/api/file/v1beta1"
+	"k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientset "k8s.io/client-go/kubernetes"
+	"path"
+	"strings"
+)
+
+const (
+	// FILESTORE_SHARE is the file share of the Filestore instance, exported at /kubeflow.
+	FILESTORE_SHARE = "kubeflow"
+	// FILESTORE_STORAGE_CLASS is the StorageClass of the PersistentVolume of the Filestore
+	// instance; PersistentVolumeClaims with it are bound to the file share.
+	FILESTORE_STORAGE_CLASS = "kubeflow-filestore"
+	// FILESTORE_VOLUME is the PersistentVolume of the file share.
+	FILESTORE_VOLUME = "kubeflow-filestore"
+	// FILESTORE_TIER_STANDARD and FILESTORE_TIER_PREMIUM are the tiers of spec.filestore.
+	FILESTORE_TIER_STANDARD = "STANDARD"
+	FILESTORE_TIER_PREMIUM  = "PREMIUM"
+	// noProvisioner is the provisioner of a StorageClass of volumes created beforehand.
+	noProvisioner = "kubernetes.io/no-provisioner"
+)
+
+// filestoreMinCapacityGb is the smallest file share of each tier.
+var filestoreMinCapacityGb = map[string]int{
+	FILESTORE_TIER_STANDARD: 1024,
+	FILESTORE_TIER_PREMIUM:  2560,
+}
+
+// filestoreTier is the tier of spec.filestore, STANDARD when unset.
+func (gcp *Gcp) filestoreTier() string {
+	if gcp.Spec.Filestore.Tier == "" {
+		return FILESTORE_TIER_STANDARD
+	}
+	return strings.ToUpper(gcp.Spec.Filestore.Tier)
+}
+
+// filestoreCapacityGb is the capacity of spec.filestore, the minimum of its tier when unset.
+func (gcp *Gcp) filestoreCapacityGb() int {
+	if gcp.Spec.Filestore.CapacityGb == 0 {
+		return filestoreMinCapacityGb[gcp.filestoreTier()]
+	}
+	return gcp.Spec.Filestore.CapacityGb
+}
+
+// filestoreNetwork is the network of spec.filestore, default when unset.
+func (gcp *Gcp) filestoreNetwork() string {
+	if gcp.Spec.Filestore.Network == "" {
+		return "default"
+	}
+	return gcp.Spec.Filestore.Network
+}
+
+// validateFilestore checks the tier and capacity of spec.filestore.
+func (gcp *Gcp) validateFilestore() error {
+	if gcp.Spec.Filestore == nil {
+		return nil
+	}
+	tier := gcp.filestoreTier()
+	minCapacity, ok := filestoreMinCapacityGb[tier]
+	if !ok {
+		return &kfapis.KfError{
+			Code: int(kfapis.INVALID_ARGUMENT),
+			Message: fmt.Sprintf("unknown filestore tier %v; must be %v or %v", gcp.Spec.Filestore.Tier,
+				FILESTORE_TIER_STANDARD, FILESTORE_TIER_PREMIUM),
+		}
+	}
+	if capacity := gcp.filestoreCapacityGb(); capacity < minCapacity {
+		return &kfapis.KfError{
+			Code: int(kfapis.INVALID_ARGUMENT),
+			Message: fmt.Sprintf("filestore capacityGb %v is below the minimum of the %v tier, %v",
+				capacity, tier, minCapacity),
+		}
+	}
+	return nil
+}
+
+// writeGcfsConfig writes GCFS_FILE from the gcfs template with the instance of spec.filestore,
+// named after the app in its zone.
+func (gcp *Gcp) writeGcfsConfig(bundle *Bundle, src string) error {
+	dest := path.Join(GCP_CONFIG, GCFS_FILE)
+	buf, err := gcp.readAsset(src)
+	if err != nil {
+		return &kfapis.KfError{
+			Code:    int(kfapis.INTERNAL_ERROR),
+			Message: fmt.Sprintf("Error when reading gcfs template: %v", err),
+		}
+	}
+	var data map[string]interface{}
+	if err = yaml.Unmarshal(buf, &data); err != nil {
+		return &kfapis.KfError{
+			Code:    int(kfapis.INTERNAL_ERROR),
+			Message: fmt.Sprintf("Error when unmarshaling template %v: %v", src, err),
+		}
+	}
+	resources, ok := data[RESOURCES].([]interface{})
+	if !ok {
+		return &kfapis.KfError{
+			Code:    int(kfapis.INTERNAL_ERROR),
+			Message: "Invalid gcfs config - not able to find resources entry.",
+		}
+	}
+	for idx, re := range resources {
+		entry := re.(map[string]interface{})
+		properties, ok := entry["properties"].(map[string]interface{})
+		if !ok {
+			properties = make(map[string]interface{})
+		}
+		properties["parent"] = fmt.Sprintf("projects/%v/locations/%v", gcp.Spec.Project, gcp.Spec.Zone)
+		properties["instanceId"] = gcp.Name
+		properties["tier"] = gcp.filestoreTier()
+		properties["networks"] = []interface{}{
+			map[string]interface{}{
+				"network": gcp.filestoreNetwork(),
+			},
+		}
+		properties["fileShares"] = []interface{}{
+			map[string]interface{}{
+				"name":       FILESTORE_SHARE,
+				"capacityGb": gcp.filestoreCapacityGb(),
+			},
+		}
+		properties["labels"] = gcp.ownerLabels()
+		entry["properties"] = properties
+		resources[idx] = entry
+	}
+	data[RESOURCES] = resources
+
+	if buf, err = yaml.Marshal(data); err != nil {
+		return &kfapis.KfError{
+			Code:    int(kfapis.INTERNAL_ERROR),
+			Message: fmt.Sprintf("Error when marshaling for %v: %v", dest, err),
+		}
+	}
+	bundle.Put(dest, buf)
+	return nil
+}
+
+// filestoreAddress returns the IP address the Filestore instance of the app is reached at.
+func (gcp *Gcp) filestoreAddress(ctx context.Context) (string, error) {
+	fileService, err := file.New(gcp.client)
+	if err != nil {
+		return "", fmt.Errorf("Error creating fileService: %v", err)
+	}
+	name := fmt.Sprintf("projects/%v/locations/%v/instances/%v", gcp.Spec.Project, gcp.Spec.Zone, gcp.Name)
+	instance, err := fileService.Projects.Locations.Instances.Get(name).Context(ctx).Do()
+	if err != nil {
+		return "", fmt.Errorf("couldn't get Filestore instance %v: %v", name, err)
+	}
+	for _, network := range instance.Networks {
+		if len(network.IpAddresses) > 0 {
+			return network.IpAddresses[0], nil
+		}
+	}
+	return "", fmt.Errorf("Filestore instance %v has no IP address; state: %v", name, instance.State)
+}
+
+// filestoreVolume is the PersistentVolume of the file share at address, with the StorageClass
+// FILESTORE_STORAGE_CLASS. Its files are kept when the claim is deleted.
+func (gcp *Gcp) filestoreVolume(address string) *v1.PersistentVolume {
+	return &v1.PersistentVolume{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: FILESTORE_VOLUME,
+		},
+		Spec: v1.PersistentVolumeSpec{
+			Capacity: v1.ResourceList{
+				v1.ResourceStorage: resource.MustParse(fmt.Sprintf("%vGi", gcp.filestoreCapacityGb())),
+			},
+			AccessModes:                   []v1.PersistentVolumeAccessMode{v1.ReadWriteMany},
+			PersistentVolumeReclaimPolicy: v1.PersistentVolumeReclaimRetain,
+			StorageClassName:              FILESTORE_STORAGE_CLASS,
+			PersistentVolumeSource: v1.PersistentVolumeSource{
+				NFS: &v1.NFSVolumeSource{
+					Server: address,
+					Path:   "/" + FILESTORE_SHARE,
+				},
+			},
+		},
+	}
+}
+
+// configFilestore creates the StorageClass and PersistentVolume of the Filestore instance of
+// spec.filestore. A volume of another address is left as it is, as it may be bound.
+func (gcp *Gcp) configFilestore(ctx context.Context, k8sClientset *clientset.Clientset) error {
+	address, err := gcp.filestoreAddress(ctx)
+	if err != nil {
+		return err
+	}
+	reclaimPolicy := v1.PersistentVolumeReclaimRetain
+	storageClass := &storagev1.StorageClass{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: FILESTORE_STORAGE_CLASS,
+		},
+		Provisioner:   noProvisioner,
+		ReclaimPolicy: &reclaimPolicy,
+	}
+	_, err = k8sClientset.StorageV1().StorageClasses().Create(storageClass)
+	if err != nil && !k8serrors.IsAlreadyExists(err) {
+		return fmt.Errorf("couldn't create storage class %v: %v", FILESTORE_STORAGE_CLASS, err)
+	}
+
+	volume := gcp.filestoreVolume(address)
+	existing, err := k8sClientset.CoreV1().PersistentVolumes().Get(FILESTORE_VOLUME, metav1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		log.Infof("Creating persistent volume %v of Filestore %v", FILESTORE_VOLUME, address)
+		if _, err = k8sClientset.CoreV1().PersistentVolumes().Create(volume); err != nil {
+			return fmt.Errorf("couldn't create persistent volume %v: %v", FILESTORE_VOLUME, err)
+		}
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("couldn't get persistent volume %v: %v", FILESTORE_VOLUME, err)
+	}
+	if existing.Spec.NFS == nil || existing.Spec.NFS.Server != address {
+		log.Warnf("Persistent volume %v doesn't mount Filestore %v; delete it to have it recreated",
+			FILESTORE_VOLUME, address)
+		return nil
+	}
+	capacity := existing.Spec.Capacity[v1.ResourceStorage]
+	if capacity.Cmp(volume.Spec.Capacity[v1.ResourceStorage]) != 0 {
+		existing.Spec.Capacity = volume.Spec.Capacity
+		log.Infof("Resizing persistent volume %v", FILESTORE_VOLUME)
+		if _, err = k8sClientset.CoreV1().PersistentVolumes().Update(existing); err != nil {
+			return fmt.Errorf("couldn't update persistent volume %v: %v", FILESTORE_VOLUME, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"testing"
+
+	kfdefs "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps/kfdef/v1alpha1"
+	"k8s.io/api/core/v1"
+)
+
+func TestValidateFilestore(t *testing.T) {
+	cases := []struct {
+		filestore *kfdefs.FilestoreConfig
+		valid     bool
+	}{
+		{nil, true},
+		{&kfdefs.FilestoreConfig{}, true},
+		{&kfdefs.FilestoreConfig{Tier: "premium"}, true},
+		{&kfdefs.FilestoreConfig{Tier: "STANDARD", CapacityGb: 2048, Network: "kubeflow"}, true},
+		{&kfdefs.FilestoreConfig{CapacityGb: 512}, false},
+		{&kfdefs.FilestoreConfig{Tier: "PREMIUM", CapacityGb: 1024}, false},
+		{&kfdefs.FilestoreConfig{Tier: "BASIC"}, false},
+	}
+	for i, c := range cases {
+		gcp := &Gcp{}
+		gcp.Spec.Filestore = c.filestore
+		err := gcp.validateFilestore()
+		if c.valid && err != nil {
+			t.Errorf("case %v: validateFilestore failed: %v", i, err)
+		} else if !c.valid && err == nil {
+			t.Errorf("case %v: validateFilestore succeeded", i)
+		}
+	}
+}
+
+func TestFilestoreVolume(t *testing.T) {
+	gcp := &Gcp{}
+	gcp.Spec.Filestore = &kfdefs.FilestoreConfig{Tier: "PREMIUM"}
+	volume := gcp.filestoreVolume("10.0.0.2")
+	if volume.Spec.NFS == nil || volume.Spec.NFS.Server != "10.0.0.2" || volume.Spec.NFS.Path != "/kubeflow" {
+		t.Errorf("volume mounts %+v; want 10.0.0.2:/kubeflow", volume.Spec.NFS)
+	}
+	capacity := volume.Spec.Capacity[v1.ResourceStorage]
+	if capacity.String() != "2560Gi" {
+		t.Errorf("volume capacity is %v; want 2560Gi", capacity.String())
+	}
+	if volume.Spec.StorageClassName != FILESTORE_STORAGE_CLASS ||
+		volume.Spec.PersistentVolumeReclaimPolicy != v1.PersistentVolumeReclaimRetain {
+		t.Errorf("volume has class %v and reclaim policy %v; want %v and %v", volume.Spec.StorageClassName,
+			volume.Spec.PersistentVolumeReclaimPolicy, FILESTORE_STORAGE_CLASS, v1.PersistentVolumeReclaimRetain)
+	}
+}
@@ -17,42 +17,39 @@ limitations under the License.
 package gcp
 
 import (
+	"bytes"
 	"encoding/base64"
 	"fmt"
 	"github.com/cenkalti/backoff"
 	"github.com/deckarep/golang-set"
 	"github.com/ghodss/yaml"
-	bootstrap "github.com/kubeflow/kubeflow/bootstrap/cmd/bootstrap/app"
-	configtypes "github.com/kubeflow/kubeflow/bootstrap/config"
 	kfapis "github.com/kubeflow/kubeflow/bootstrap/pkg/apis"
 	kftypes "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps"
 	kfdefs "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps/kfdef/v1alpha1"
+	"github.com/kubeflow/kubeflow/bootstrap/pkg/kfapp/assets"
 	"github.com/kubeflow/kubeflow/bootstrap/pkg/utils"
 	log "github.com/sirupsen/logrus"
-	"golang.org/x/crypto/bcrypt"
 	"golang.org/x/net/context"
 	"golang.org/x/oauth2"
 	"golang.org/x/oauth2/google"
-	gke "google.golang.org/api/container/v1"
+	"google.golang.org/api/cloudresourcemanager/v1"
 	"google.golang.org/api/deploymentmanager/v2"
-	"google.golang.org/api/googleapi"
 	"google.golang.org/api/iam/v1"
 	"google.golang.org/api/serviceusage/v1"
-	"io"
-	"io/ioutil"
 	"k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
 	"math/rand"
 	"net/http"
-	"os"
 	"os/exec"
 	"path"
 	"path/filepath"
 	"strconv"
 	"strings"
-	"time"
+	"sync"
 )
 
 // TODO: golint complains that we should not use all capital var name.
@@ -62,11 +59,13 @@ const (
 	STORAGE_FILE      = "storage-kubeflow.yaml"
 	NETWORK_FILE      = "network.yaml"
 	GCFS_FILE         = "gcfs.yaml"
+	IAM_BINDINGS_FILE = "iam_bindings.yaml"
 	ADMIN_SECRET_NAME = "admin-gcp-sa"
 	USER_SECRET_NAME  = "user-gcp-sa"
 	KUBEFLOW_OAUTH    = "kubeflow-oauth"
 	IMPORTS           = "imports"
 	PATH              = "path"
+	NAME              = "name"
 	CLIENT_ID         = "CLIENT_ID"
 	CLIENT_SECRET     = "CLIENT_SECRET"
 	BASIC_AUTH_SECRET = "kubeflow-login"
@@ -76,93 +75,181 @@ const (
 // The namespace for Istio
 const IstioNamespace = "istio-system"
 
+// Node pools of cluster.jinja which can get a dedicated service account.
+const (
+	CPU_POOL = "cpu-pool"
+	GPU_POOL = "gpu-pool"
+)
+
 // Gcp implements KfApp Interface
 // It includes the KsApp along with additional Gcp types
 type Gcp struct {
 	kfdefs.KfDef
 	client      *http.Client
+	baseClient  *http.Client
 	tokenSource oauth2.TokenSource
+	clock       Clock
+	runCommand  CommandRunner
 	// When isCLI is false, following code need to be multi-thread safe, and can not access local configs or gcloud cli
 	isCLI bool
+	// store keeps the generated files: the app dir for kfctl, a Bundle otherwise.
+	store ConfigStore
+	// generated are the files of the last Generate.
+	generated *Bundle
+	// assets is where the DM templates and manifests are read from; see assetLoader.
+	assets assets.Loader
+	// The clients of the GCP APIs set with options, the APIs themselves when they're nil.
+	dmClient                DeploymentManagerClient
+	iamClient               IamClient
+	serviceUsageClient      ServiceUsageClient
+	serviceManagementClient ServiceManagementClient
+	containerClient         ContainerClient
 	// requried when choose basic-auth
 	username        string
 	encodedPassword string
 	// requried when choose iap
 	oauthId     string
 	oauthSecret string
+	// requried when choose oidc, or ldap with a bind DN
+	connectorSecret string
+	// specLock guards the changes to the spec made once the Gcp may be shared, by the deploy app
+	// or concurrent secret creations: the component params, defaults and recorded keys.
+	specLock sync.Mutex
+	// deleteChecks are run after Delete besides those of the resources kfctl creates.
+	deleteChecks []DeleteCheck
+	// auditLog are the sinks of the audit entries, set up once by auditSinks.
+	auditLog  []AuditSink
+	auditOnce sync.Once
 }
 
 // GetKfApp returns the gcp kfapp. It's called by coordinator.GetKfApp
 func GetKfApp(kfdef *kfdefs.KfDef) (kftypes.KfApp, error) {
-	ctx := context.Background()
-	client, err := google.DefaultClient(ctx, gke.CloudPlatformScope)
+	baseClient, err := newBaseClient(kfdef)
 	if err != nil {
-		log.Fatalf("Could not authenticate Client: %v", err)
 		return nil, err
 	}
-	ts, err := google.DefaultTokenSource(ctx, iam.CloudPlatformScope)
+	ctx := utils.WithHTTPClient(context.Background(), baseClient)
+	creds, err := findCredentials(ctx)
 	if err != nil {
-		return nil, &kfapis.KfError{
-			Code:    int(kfapis.INVALID_ARGUMENT),
-			Message: fmt.Sprintf("Get token error: %v", err),
-		}
+		return nil, err
 	}
 	_gcp := &Gcp{
-		KfDef:       *kfdef,
-		client:      client,
-		tokenSource: ts,
-		isCLI:       true,
+		KfDef: *kfdef.DeepCopy(),
+		isCLI: true,
+		store: NewAppDirStore(kfdef.Spec.AppDir),
 	}
+	if err = _gcp.checkAuthProvider(); err != nil {
+		return nil, err
+	}
+	_gcp.applyOptions([]Option{WithBaseClient(baseClient), WithTokenSource(creds.TokenSource)})
 	if _gcp.Spec.Email == "" {
-		if err = _gcp.getAccount(); err != nil {
+		if err = _gcp.getAccount(creds); err != nil {
 			log.Infof("cannot get gcloud account email. Error: %v", err)
 		}
 	}
 	return _gcp, nil
 }
 
+// NewGcp returns a gcp kfapp for callers that embed kubeflow provisioning, e.g. pkg/client/deploy.
+// Unlike GetKfApp it doesn't read the environment or call gcloud: the auth is passed in explicitly,
+// and the GCP client or token source with opts, which can also replace the clock and the commands
+// run for tests. The generated files are kept in memory unless opts set a ConfigStore, and kfdef
+// is copied, so several Gcps can be created from it and used concurrently.
+func NewGcp(kfdef *kfdefs.KfDef, auth Auth, opts ...Option) (*Gcp, error) {
+	baseClient, err := newBaseClient(kfdef)
+	if err != nil {
+		return nil, err
+	}
+	_gcp := &Gcp{
+		KfDef: *kfdef.DeepCopy(),
+		isCLI: false,
+	}
+	_gcp.applyOptions(append([]Option{WithBaseClient(baseClient)}, opts...))
+	if err := _gcp.checkAuthProvider(); err != nil {
+		return nil, err
+	}
+	if err := _gcp.setAuth(auth); err != nil {
+		return nil, &kfapis.KfError{
+			Code:    int(kfapis.INVALID_ARGUMENT),
+			Message: err.Error(),
+		}
+	}
+	return _gcp, nil
+}
+
 func getSA(name string, nameSuffix string, project string) string {
 	return fmt.Sprintf("%v-%v@%v.iam.gserviceaccount.com", name, nameSuffix, project)
 }
 
-// getAccount if --email is not supplied try and get account info using gcloud
-func (gcp *Gcp) getAccount() error {
-	output, err := exec.Command("gcloud", "config", "get-value", "account").Output()
-	if err != nil {
-		return fmt.Errorf("could not call 'gcloud config get-value account': %v", err)
+// getDeploymentSAs returns the IAM members of every service account a deployment may create.
+func getDeploymentSAs(name string, project string) mapset.Set {
+	return mapset.NewSet(
+		"serviceAccount:"+getSA(name, "admin", project),
+		"serviceAccount:"+getSA(name, "user", project),
+		"serviceAccount:"+getSA(name, "vm", project),
+		"serviceAccount:"+getSA(name, CPU_POOL, project),
+		"serviceAccount:"+getSA(name, GPU_POOL, project))
+}
+
+// validateNodePoolServiceAccounts checks spec.nodePoolServiceAccounts only names known pools, once each.
+func (gcp *Gcp) validateNodePoolServiceAccounts() error {
+	seen := map[string]bool{}
+	for _, sa := range gcp.Spec.NodePoolServiceAccounts {
+		if sa.Pool != CPU_POOL && sa.Pool != GPU_POOL {
+			return &kfapis.KfError{
+				Code: int(kfapis.INVALID_ARGUMENT),
+				Message: fmt.Sprintf("unknown node pool %v in nodePoolServiceAccounts; must be %v or %v",
+					sa.Pool, CPU_POOL, GPU_POOL),
+			}
+		}
+		if seen[sa.Pool] {
+			return &kfapis.KfError{
+				Code:    int(kfapis.INVALID_ARGUMENT),
+				Message: fmt.Sprintf("node pool %v is listed twice in nodePoolServiceAccounts", sa.Pool),
+			}
+		}
+		seen[sa.Pool] = true
 	}
-	account := string(output)
-	gcp.Spec.Email = strings.TrimSpace(account)
 	return nil
 }
 
-func (gcp *Gcp) writeConfigFile() error {
-	buf, bufErr := yaml.Marshal(gcp.KfDef)
-	if bufErr != nil {
-		return bufErr
+// getAccount if --email is not supplied try and get account info using gcloud, or from the
+// service account key of creds in no-exec mode or without gcloud, e.g. in a container.
+func (gcp *Gcp) getAccount(creds *google.Credentials) error {
+	if _, err := utils.LookPath("gcloud"); err != nil {
+		email, err := credentialsEmail(creds)
+		if err != nil {
+			return err
+		}
+		gcp.Spec.Email = email
+		return nil
 	}
-	cfgFilePath := filepath.Join(gcp.Spec.AppDir, kftypes.KfConfigFile)
-	cfgFilePathErr := ioutil.WriteFile(cfgFilePath, buf, 0644)
-	if cfgFilePathErr != nil {
-		return cfgFilePathErr
+	var output bytes.Buffer
+	cmd := exec.Command("gcloud", "config", "get-value", "account")
+	cmd.Stdout = &output
+	if err := gcp.runCommand(cmd); err != nil {
+		return fmt.Errorf("could not call 'gcloud config get-value account': %v", err)
 	}
+	account := output.String()
+	gcp.Spec.Email = strings.TrimSpace(account)
 	return nil
 }
 
-// Simple deploymentmanager.TargetConfiguration factory method. This method assumes imported paths
-// are all within the same filesystem. From gcloud CLI source codes it appears URL is a possible
-// option. We might need to update this method or find a way to work with Python source code from
-// gcloud.
-func generateTarget(configPath string) (*deploymentmanager.TargetConfiguration, error) {
-	if !filepath.IsAbs(configPath) {
-		if p, err := filepath.Abs(configPath); err != nil {
-			return nil, fmt.Errorf("Getting absolute path error: %v", err)
-		} else {
-			configPath = p
-		}
+// writeConfigFile writes app.yaml to the store.
+func (gcp *Gcp) writeConfigFile() error {
+	bundle := NewBundle()
+	if err := gcp.putConfigFile(bundle); err != nil {
+		return err
 	}
-	log.Infof("Reading config file: %v", configPath)
-	configBuf, bufErr := ioutil.ReadFile(configPath)
+	return gcp.store.WriteBundle(bundle)
+}
+
+// generateTarget returns the DM target of the config file of gcp_config, with its imports and
+// theirs; see resolveImports. Imports with a relative path are read from gcp_config too, those
+// with an absolute one from the disk and URLs from their host.
+func (gcp *Gcp) generateTarget(file string) (*deploymentmanager.TargetConfiguration, error) {
+	log.Infof("Reading config file: %v", file)
+	configBuf, bufErr := gcp.readConfig(file)
 	if bufErr != nil {
 		return nil, fmt.Errorf("Reading config file error: %v", bufErr)
 	}
@@ -176,55 +263,62 @@ func generateTarget(configPath string) (*deploymentmanager.TargetConfiguration,
 	if err := yaml.Unmarshal(configBuf, &config); err != nil {
 		return nil, fmt.Errorf("Unable to read YAML: %v", err)
 	}
-	if _, ok := config[IMPORTS]; !ok {
-		return targetConfig, nil
-	}
-
-	entries := config[IMPORTS].([]interface{})
-	dirName := filepath.Dir(configPath)
-	for _, entry := range entries {
-		entryMap := entry.(map[string]interface{})
-		if _, ok := entryMap[PATH]; !ok {
-			continue
-		}
-		importPath := entryMap[PATH].(string)
-		if !filepath.IsAbs(importPath) {
-			importPath = path.Join(dirName, importPath)
-		}
-		log.Infof("Reading import file: %v", importPath)
-		if buf, err := ioutil.ReadFile(importPath); err == nil {
-			targetConfig.Imports = append(targetConfig.Imports, &deploymentmanager.ImportFile{
-				Name:    entryMap[PATH].(string),
-				Content: string(buf),
-			})
-		} else {
-			return nil, fmt.Errorf("error reading import file: %v", err)
-		}
+	imports, err := gcp.resolveImports(file, config)
+	if err != nil {
+		return nil, err
 	}
+	targetConfig.Imports = imports
 	return targetConfig, nil
 }
 
-func (gcp *Gcp) getK8sClientset(ctx context.Context) (*clientset.Clientset, error) {
+// useKubeconfig is true when the user supplied a kubeconfig or context to reach the cluster,
+// e.g. private clusters behind a tunnel, instead of looking it up through the GKE API.
+func (gcp *Gcp) useKubeconfig() bool {
+	return gcp.Spec.Kubeconfig != "" || gcp.Spec.KubeContext != ""
+}
+
+// getK8sRestConfig builds the config of the cluster from the user supplied kubeconfig and
+// context, without the GKE API, or from the cluster GKE returns.
+func (gcp *Gcp) getK8sRestConfig(ctx context.Context) (*rest.Config, error) {
+	if gcp.useKubeconfig() {
+		config, err := kftypes.GetConfigForContext(gcp.Spec.Kubeconfig, gcp.Spec.KubeContext)
+		if err != nil {
+			return nil, fmt.Errorf("build ClientConfig from context %v of kubeconfig %v error: %v",
+				gcp.Spec.KubeContext, gcp.Spec.Kubeconfig, err)
+		}
+		return config, nil
+	}
+	opts, err := utils.GrpcClientOptions(gcp.Spec.CaBundle)
+	if err != nil {
+		return nil, err
+	}
 	cluster, err := utils.GetClusterInfo(ctx, gcp.Spec.Project,
-		gcp.Spec.Zone, gcp.Name, gcp.tokenSource)
+		gcp.Spec.Zone, gcp.Name, gcp.tokenSource, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("get Cluster error: %v", err)
 	}
-	config, err := utils.BuildConfigFromClusterInfo(ctx, cluster, gcp.tokenSource)
+	config, err := utils.BuildConfigFromClusterInfo(ctx, cluster, gcp.tokenSource, gcp.Spec.CaBundle)
 	if err != nil {
 		return nil, fmt.Errorf("build ClientConfig error: %v", err)
 	}
+	return config, nil
+}
 
+func (gcp *Gcp) getK8sClientset(ctx context.Context) (*clientset.Clientset, error) {
+	config, err := gcp.getK8sRestConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
 	return clientset.NewForConfig(config)
 }
 
-func blockingWait(project string, opName string, deploymentmanagerService *deploymentmanager.Service,
+func (gcp *Gcp) blockingWait(project string, opName string, deploymentmanagerService DeploymentManagerClient,
 	ctx context.Context, logPrefix string) error {
 	// Explicitly copy string to avoid memory leak.
 	p := "" + project
 	name := "" + opName
-	return backoff.Retry(func() error {
-		op, err := deploymentmanagerService.Operations.Get(p, name).Context(ctx).Do()
+	return gcp.retry(func() error {
+		op, err := deploymentmanagerService.GetOperation(ctx, p, name)
 
 		if err != nil {
 			// Retry here as there's a chance to get error for newly created DM operation.
@@ -237,9 +331,11 @@ func blockingWait(project string, opName string, deploymentmanagerService *deplo
 		}
 		if op.Status == "DONE" {
 			if op.HttpErrorStatusCode > 0 {
-				return backoff.Permanent(fmt.Errorf("%v error(%v): %v",
+				// The status is that of DM; which resource failed, and why, is in its resources.
+				return backoff.Permanent(fmt.Errorf("%v error(%v): %v%v",
 					logPrefix,
-					op.HttpErrorStatusCode, op.HttpErrorMessage))
+					op.HttpErrorStatusCode, op.HttpErrorMessage,
+					formatDmResourceErrors(dmResourceErrors(ctx, deploymentmanagerService, p, op))))
 			}
 			log.Infof("%v is finished: %v", logPrefix, op.Status)
 			return nil
@@ -250,48 +346,56 @@ func blockingWait(project string, opName string, deploymentmanagerService *deplo
 	}, backoff.NewExponentialBackOff())
 }
 
-func (gcp *Gcp) updateDeployment(deployment string, yamlfile string) error {
-	appDir := gcp.Spec.AppDir
-	gcpConfigDir := path.Join(appDir, GCP_CONFIG)
+func (gcp *Gcp) updateDeployment(deployment string, yamlfile string, component string) error {
 	ctx := context.Background()
-	deploymentmanagerService, err := deploymentmanager.New(gcp.client)
+	deploymentmanagerService, err := gcp.newDeploymentManagerClient()
 	if err != nil {
-		return fmt.Errorf("Error creating deploymentmanagerService: %v", err)
+		return err
 	}
-	filePath := filepath.Join(gcpConfigDir, yamlfile)
 	dp := &deploymentmanager.Deployment{
-		Name: deployment,
+		Name:   deployment,
+		Labels: gcp.deploymentLabels(component),
 	}
-	if target, targetErr := generateTarget(filePath); targetErr != nil {
+	if target, targetErr := gcp.generateTarget(yamlfile); targetErr != nil {
 		return targetErr
 	} else {
 		dp.Target = target
 	}
 
 	project := gcp.Spec.Project
-	resp, err := deploymentmanagerService.Deployments.Get(project, deployment).Context(ctx).Do()
+	createPolicy := ""
+	resp, err := deploymentmanagerService.GetDeployment(ctx, project, deployment)
 	if err == nil {
+		resp, createPolicy, err = gcp.recoverDeployment(ctx, deploymentmanagerService, resp, component)
+		if err != nil {
+			return err
+		}
+	}
+	if resp != nil {
 		dp.Fingerprint = resp.Fingerprint
 		opName := resp.Operation.Name
 		if resp.Operation.Status == "DONE" {
 			log.Infof("Updating deployment %v", deployment)
-			op, updateErr := deploymentmanagerService.Deployments.Update(project, deployment, dp).Context(ctx).Do()
+			op, updateErr := deploymentmanagerService.UpdateDeployment(ctx, project, deployment, dp, "")
 			if updateErr != nil {
-				return fmt.Errorf("Update deployment error: %v", updateErr)
+				return fmt.Errorf("Update deployment error: %v%v", updateErr, gcp.recoveryHint())
 			}
 			opName = op.Name
 		} else {
 			log.Infof("Wait running deployment %v to finish; operation name: %v.", deployment, opName)
 		}
-		return blockingWait(project, opName, deploymentmanagerService, ctx,
-			"Updating "+deployment)
+		if err = gcp.blockingWait(project, opName, deploymentmanagerService, ctx,
+			"Updating "+deployment); err != nil {
+			return fmt.Errorf("%v%v", err, gcp.recoveryHint())
+		}
+		return nil
 	} else {
 		log.Infof("Creating deployment %v", deployment)
-		op, insertErr := deploymentmanagerService.Deployments.Insert(project, dp).Context(ctx).Do()
+		op, insertErr := deploymentmanagerService.InsertDeployment(ctx, project, dp, createPolicy)
 		if insertErr != nil {
 			return fmt.Errorf("Insert deployment error: %v", insertErr)
 		}
-		return blockingWait(project, op.Name, deploymentmanagerService, ctx,
+		return gcp.blockingWait(project, op.Name, deploymentmanagerService, ctx,
 			"Creating "+deployment)
 	}
 }
@@ -314,10 +418,12 @@ func createNamespace(k8sClientset *clientset.Clientset, namespace string) error
 	return err
 }
 
-func bindAdmin(k8sClientset *clientset.Clientset, user string) error {
-	log.Infof("Binding admin role for %v ...", user)
-	defaultAdmin := "default-admin"
-	_, err := k8sClientset.RbacV1().ClusterRoleBindings().Get(defaultAdmin,
+// bindAdmin binds members, IAM style members, to clusterRole with the ClusterRoleBinding name,
+// e.g. default-admin. The role of a binding can't be changed, so it's recreated when
+// spec.adminRole changed.
+func (gcp *Gcp) bindAdmin(k8sClientset *clientset.Clientset, name string, members []string, clusterRole string) error {
+	log.Infof("Binding %v role for %v ...", clusterRole, strings.Join(members, ", "))
+	existing, err := k8sClientset.RbacV1().ClusterRoleBindings().Get(name,
 		metav1.GetOptions{
 			TypeMeta: metav1.TypeMeta{
 				APIVersion: "rbac.authorization.k8s.io/v1beta1",
@@ -331,26 +437,35 @@ func bindAdmin(k8sClientset *clientset.Clientset, user string) error {
 			Kind:       "ClusterRoleBinding",
 		},
 		ObjectMeta: metav1.ObjectMeta{
-			Name: "default-admin",
+			Name: name,
 		},
 		RoleRef: rbacv1.RoleRef{
 			APIGroup: "rbac.authorization.k8s.io",
 			Kind:     "ClusterRole",
-			Name:     "cluster-admin",
-		},
-		Subjects: []rbacv1.Subject{
-			{
-				Kind: rbacv1.UserKind,
-				Name: user,
-			},
+			Name:     clusterRole,
 		},
-	}
-	if err == nil {
-		log.Infof("Updating default-admin...")
+		Subjects: tenantSubjects(members),
+	}
+	resource := "clusterrolebindings/" + name
+	details := map[string]interface{}{"role": clusterRole, "members": members}
+	if err == nil && existing.RoleRef.Name != clusterRole {
+		log.Infof("Recreating %v for %v...", name, clusterRole)
+		err = k8sClientset.RbacV1().ClusterRoleBindings().Delete(name,
+			&metav1.DeleteOptions{})
+		gcp.audit(AUDIT_RBAC_DELETE, resource, map[string]interface{}{"role": existing.RoleRef.Name}, err)
+		if err != nil {
+			return err
+		}
+		_, err = k8sClientset.RbacV1().ClusterRoleBindings().Create(binding)
+		gcp.audit(AUDIT_RBAC_CREATE, resource, details, err)
+	} else if err == nil {
+		log.Infof("Updating %v...", name)
 		_, err = k8sClientset.RbacV1().ClusterRoleBindings().Update(binding)
+		gcp.audit(AUDIT_RBAC_UPDATE, resource, details, err)
 	} else {
-		log.Infof("default-admin not found, creating...")
+		log.Infof("%v not found, creating...", name)
 		_, err = k8sClientset.RbacV1().ClusterRoleBindings().Create(binding)
+		gcp.audit(AUDIT_RBAC_CREATE, resource, details, err)
 	}
 	return err
 }
@@ -361,412 +476,491 @@ func (gcp *Gcp) ConfigK8s() error {
 	if err != nil {
 		return err
 	}
-	if err = createNamespace(k8sClientset, gcp.Namespace); err != nil {
+	if err = createNamespace(k8sClientset, gcp.namespace()); err != nil {
 		return fmt.Errorf("Creating namespace error: %v", err)
 	}
-	if err = bindAdmin(k8sClientset, gcp.Spec.Email); err != nil {
-		return fmt.Errorf("Binding user as admin error: %v", err)
-	}
-
-	return nil
-}
-
-// Add a conveniently named context to KUBECONFIG.
-func (gcp *Gcp) AddNamedContext() error {
-	name := strings.Replace(KUBECONFIG_FORMAT, "{project}", gcp.Spec.Project, 1)
-	name = strings.Replace(name, "{zone}", gcp.Spec.Zone, 1)
-	name = strings.Replace(name, "{cluster}", gcp.Name, 1)
-	log.Infof("KUBECONFIG name is %v", name)
-
-	buf, err := ioutil.ReadFile(kftypes.KubeConfigPath())
-	if err != nil {
-		return &kfapis.KfError{
-			Code:    int(kfapis.INTERNAL_ERROR),
-			Message: fmt.Sprintf("Reading KUBECONFIG error: %v", err),
+	if gcp.Spec.UseIstio {
+		if err = createNamespace(k8sClientset, gcp.istioNamespace()); err != nil {
+			return fmt.Errorf("Creating namespace error: %v", err)
 		}
 	}
-	var config map[string]interface{}
-	if err = yaml.Unmarshal(buf, &config); err != nil {
-		return &kfapis.KfError{
-			Code:    int(kfapis.INTERNAL_ERROR),
-			Message: fmt.Sprintf("Unmarshaling KUBECONFIG error: %v", err),
-		}
+	if err = gcp.configAdmin(k8sClientset); err != nil {
+		return fmt.Errorf("Binding user as admin error: %v", err)
 	}
-
-	configNameChecker := func(config map[string]interface{}, entryName string, name string) error {
-		e, ok := config[entryName]
-		if !ok {
-			return &kfapis.KfError{
-				Code:    int(kfapis.INTERNAL_ERROR),
-				Message: fmt.Sprintf("Not able to find %v in KUBECONFIG", entryName),
-			}
-		}
-		entries := e.([]interface{})
-		for _, entry := range entries {
-			en := entry.(map[string]interface{})
-			if mm, ok := en["name"]; ok {
-				n := mm.(string)
-				if n == name {
-					return nil
-				}
-			} else {
-				return &kfapis.KfError{
-					Code:    int(kfapis.INTERNAL_ERROR),
-					Message: "Not able to find name in the entry",
-				}
-			}
+	if len(gcp.Spec.Tenants) > 0 {
+		config, err := gcp.getK8sRestConfig(ctx)
+		if err != nil {
+			return err
 		}
-		return &kfapis.KfError{
-			Code:    int(kfapis.INTERNAL_ERROR),
-			Message: fmt.Sprintf("Not able to find %v from %v in KUBECONFIG", name, entryName),
+		if err = gcp.configTenants(k8sClientset, config); err != nil {
+			return fmt.Errorf("Configuring tenants error: %v", err)
 		}
 	}
 
-	if err = configNameChecker(config, "clusters", name); err != nil {
-		return err
-	}
-	if err = configNameChecker(config, "users", name); err != nil {
-		return err
-	}
-	if err = configNameChecker(config, "contexts", name); err != nil {
-		return err
-	}
+	return nil
+}
 
-	e, ok := config["contexts"]
-	if !ok {
-		return &kfapis.KfError{
-			Code:    int(kfapis.INTERNAL_ERROR),
-			Message: "Not able to find contexts in KUBECONFIG",
+// updateSplitDeployments creates or updates one deployment per DM config, the default layout.
+func (gcp *Gcp) updateSplitDeployments(targets map[string]bool) error {
+	if targets[COMPONENT_STORAGE] {
+		if err := gcp.updateDeployment(gcp.Name+"-storage", STORAGE_FILE, COMPONENT_STORAGE); err != nil {
+			return fmt.Errorf("could not update %v: %v", STORAGE_FILE, err)
 		}
 	}
-	contexts := e.([]interface{})
-	context := make(map[string]interface{})
-	context["name"] = gcp.Name
-	context["context"] = map[string]string{
-		"cluster":   name,
-		"user":      name,
-		"namespace": gcp.Namespace,
-	}
-	for idx, ctx := range contexts {
-		c := ctx.(map[string]interface{})
-		if c["name"] == gcp.Name {
-			// Remove the entry to override.
-			contexts = append(contexts[:idx], contexts[idx+1:]...)
-			break
+	if targets[COMPONENT_CLUSTER] {
+		if err := gcp.updateDeployment(gcp.Name, CONFIG_FILE, COMPONENT_CLUSTER); err != nil {
+			return fmt.Errorf("could not update %v: %v", CONFIG_FILE, err)
 		}
 	}
-	contexts = append(contexts, context)
-	config["contexts"] = contexts
-	config["current-context"] = gcp.Name
-
-	buf, err = yaml.Marshal(config)
-	if err != nil {
-		return &kfapis.KfError{
-			Code:    int(kfapis.INTERNAL_ERROR),
-			Message: fmt.Sprintf("Error when marshaling KUBECONFIG: %v", err),
+	if targets[COMPONENT_NETWORK] && gcp.hasConfig(NETWORK_FILE) {
+		err := gcp.updateDeployment(gcp.Name+"-network", NETWORK_FILE, COMPONENT_NETWORK)
+		if err != nil {
+			return fmt.Errorf("could not update %v: %v", NETWORK_FILE, err)
 		}
 	}
-	if err = ioutil.WriteFile(kftypes.KubeConfigPath(), buf, 0644); err != nil {
-		return &kfapis.KfError{
-			Code:    int(kfapis.INTERNAL_ERROR),
-			Message: fmt.Sprintf("Error when writing KUBECONFIG: %v", err),
+	if targets[COMPONENT_GCFS] && gcp.hasConfig(GCFS_FILE) {
+		err := gcp.updateDeployment(gcp.Name+"-gcfs", GCFS_FILE, COMPONENT_GCFS)
+		if err != nil {
+			return fmt.Errorf("could not update %v: %v", GCFS_FILE, err)
 		}
 	}
-
-	log.Infof("KUBECONFIG context %v is created and currently using", gcp.Name)
 	return nil
 }
 
-func (gcp *Gcp) updateDM(resources kftypes.ResourceEnum) error {
-	ctx := context.Background()
-	gcpClient := oauth2.NewClient(ctx, gcp.tokenSource)
-	if err := gcp.updateDeployment(gcp.Name+"-storage", STORAGE_FILE); err != nil {
-		return fmt.Errorf("could not update %v: %v", STORAGE_FILE, err)
-	}
-	if err := gcp.updateDeployment(gcp.Name, CONFIG_FILE); err != nil {
-		return fmt.Errorf("could not update %v: %v", CONFIG_FILE, err)
+// updateDM updates the targeted DM deployments and IAM bindings.
+func (gcp *Gcp) updateDM(targets map[string]bool) error {
+	// GKE can only create the cluster in the shared VPC once it may use the host's subnetwork.
+	if targets[COMPONENT_CLUSTER] {
+		if err := gcp.bindHostProject(context.Background()); err != nil {
+			return err
+		}
+		// The cluster's calls to the restricted APIs fail until the project is in the perimeter.
+		if err := gcp.ensureInPerimeter(context.Background()); err != nil {
+			return err
+		}
+		// The policy is in place before the cluster enforcing it runs any pod.
+		if err := gcp.applyBinauthzPolicy(context.Background()); err != nil {
+			return err
+		}
 	}
-	if _, networkStatErr := os.Stat(path.Join(gcp.Spec.AppDir, NETWORK_FILE)); !os.IsNotExist(networkStatErr) {
-		err := gcp.updateDeployment(gcp.Name+"-network", NETWORK_FILE)
-		if err != nil {
-			return fmt.Errorf("could not update %v: %v", NETWORK_FILE, err)
+	// DM checks the service account of spec.deploymentManagerSA may be used when it's given.
+	if dmTargeted(targets) {
+		if err := gcp.bindDeploymentManagerSA(context.Background()); err != nil {
+			return err
 		}
 	}
-	if _, gcfsStatErr := os.Stat(path.Join(gcp.Spec.AppDir, GCFS_FILE)); !os.IsNotExist(gcfsStatErr) {
-		err := gcp.updateDeployment(gcp.Name+"-gcfs", GCFS_FILE)
-		if err != nil {
-			return fmt.Errorf("could not update %v: %v", GCFS_FILE, err)
+	if gcp.Spec.CombinedDeployment {
+		if dmTargeted(targets) {
+			if err := gcp.updateCombinedDeployment(); err != nil {
+				return fmt.Errorf("could not update %v: %v", COMBINED_FILE, err)
+			}
 		}
+	} else if err := gcp.updateSplitDeployments(targets); err != nil {
+		return err
 	}
-
-	policy, policyErr := utils.GetIamPolicy(gcp.Spec.Project, gcpClient)
-	if policyErr != nil {
-		return fmt.Errorf("GetIamPolicy error: %v", policyErr)
+	if !targets[TARGET_IAM] {
+		return nil
 	}
-	appDir := gcp.Spec.AppDir
-	gcpConfigDir := path.Join(appDir, GCP_CONFIG)
-	iamPolicy, iamPolicyErr := utils.ReadIamBindingsYAML(
-		filepath.Join(gcpConfigDir, "iam_bindings.yaml"))
+
+	iamPolicy, iamPolicyErr := gcp.readIamBindings()
 	if iamPolicyErr != nil {
 		return fmt.Errorf("Read IAM policy YAML error: %v", iamPolicyErr)
 	}
-	utils.ClearIamPolicy(policy, gcp.Name, gcp.Spec.Project)
-	if err := utils.SetIamPolicy(gcp.Spec.Project, policy, gcpClient); err != nil {
-		return fmt.Errorf("Set Cleared IamPolicy error: %v", err)
+	if err := gcp.updateIamPolicy(gcp.Spec.Project, gcp.client, func(policy *cloudresourcemanager.Policy) {
+		utils.ReconcileIamPolicy(policy, iamPolicy, gcp.Name, gcp.Spec.Project)
+	}); err != nil {
+		return fmt.Errorf("Update IamPolicy error: %v", err)
 	}
+	return nil
+}
 
-	// Need to read policy again as latest Etag changed.
-	newPolicy, policyErr := utils.GetIamPolicy(gcp.Spec.Project, gcpClient)
-	if policyErr != nil {
-		return fmt.Errorf("GetIamPolicy error: %v", policyErr)
-	}
-	utils.RewriteIamPolicy(newPolicy, iamPolicy)
-	if err := utils.SetIamPolicy(gcp.Spec.Project, newPolicy, gcpClient); err != nil {
-		return fmt.Errorf("Set New IamPolicy error: %v", err)
+// configCluster creates the targeted resources of the cluster: Istio, the secrets, the NVIDIA
+// drivers and the volume of the Filestore instance.
+func (gcp *Gcp) configCluster(targets map[string]bool) error {
+	if !targets[TARGET_ISTIO] && !targets[TARGET_SECRETS] && !targets[TARGET_GPU_DRIVERS] &&
+		!targets[TARGET_FILESTORE] {
+		return nil
 	}
-
+	ctx := context.Background()
 	if err := gcp.ConfigK8s(); err != nil {
 		return fmt.Errorf("Configure K8s is failed: %v", err)
 	}
 
-	cluster, err := utils.GetClusterInfo(ctx, gcp.Spec.Project,
-		gcp.Spec.Zone, gcp.Name, gcp.tokenSource)
+	client, err := gcp.getK8sRestConfig(ctx)
 	if err != nil {
-		return fmt.Errorf("Get Cluster error: %v", err)
-	}
-	client, err := utils.BuildConfigFromClusterInfo(ctx, cluster, gcp.tokenSource)
-	if err != nil {
-		return fmt.Errorf("Build ClientConfig error: %v", err)
+		return err
 	}
 	// Install Istio
-	if gcp.Spec.UseIstio {
+	if gcp.Spec.UseIstio && targets[TARGET_ISTIO] {
 		log.Infof("Installing istio...")
-		parentDir := path.Dir(gcp.Spec.Repo)
-		err = bootstrap.CreateResourceFromFile(client, path.Join(parentDir, "dependencies/istio/install/crds.yaml"))
-		if err != nil {
-			log.Errorf("Failed to create istio CRD: %v", err)
+		if err = gcp.validateIstio(); err != nil {
 			return err
 		}
-		err = bootstrap.CreateResourceFromFile(client, path.Join(parentDir, "dependencies/istio/install/istio-noauth.yaml"))
-		if err != nil {
-			log.Errorf("Failed to create istio manifest: %v", err)
+		for _, manifest := range gcp.istioManifests() {
+			if err = gcp.createResourceFromAsset(ctx, client, manifest); err != nil {
+				log.Errorf("Failed to create istio manifest %v: %v", manifest, err)
+				return err
+			}
+		}
+		if gcp.useIstioIapIngress() {
+			if err = gcp.installIstioIapIngress(ctx, client); err != nil {
+				return fmt.Errorf("couldn't put istio-ingressgateway behind IAP: %v", err)
+			}
+		}
+		log.Infof("Done installing istio with profile %v.", gcp.istioProfile())
+	}
+	if gcp.Spec.Gpu != nil && targets[TARGET_GPU_DRIVERS] {
+		if err = gcp.installGpuDrivers(ctx, client); err != nil {
 			return err
 		}
-		err = bootstrap.CreateResourceFromFile(client, path.Join(parentDir, "dependencies/istio/kf-istio-resources.yaml"))
+	}
+	if gcp.Spec.Filestore != nil && targets[TARGET_FILESTORE] {
+		k8sClientset, err := gcp.getK8sClientset(ctx)
 		if err != nil {
-			log.Errorf("Failed to create kubeflow istio resource: %v", err)
 			return err
 		}
-		log.Infof("Done installing istio.")
+		if err = gcp.configFilestore(ctx, k8sClientset); err != nil {
+			return fmt.Errorf("Configuring filestore error: %v", err)
+		}
+	}
+	if targets[TARGET_SECRETS] {
+		// Insert secrets into the cluster
+		if err = gcp.createSecrets(); err != nil {
+			return fmt.Errorf("gcp apply could not create secrets Error %v", err)
+		}
+		if gcp.Spec.SecretsSync {
+			if err = gcp.installSecretsSync(ctx, client); err != nil {
+				return fmt.Errorf("couldn't install the secrets-sync controller: %v", err)
+			}
+		}
 	}
 	return nil
 }
 
-// Apply applies the gcp kfapp.
+// Apply applies the gcp kfapp: the DM deployments and IAM bindings for PLATFORM, Istio and the
+// secrets in the cluster for K8S, limited to spec.targets when set.
 // Remind: Need to be thread-safe: this entry is share among kfctl and deploy app
 func (gcp *Gcp) Apply(resources kftypes.ResourceEnum) error {
+	targets, err := gcp.targets(resources)
+	if err != nil {
+		return err
+	}
 	// kfctl only
 	if gcp.isCLI {
-		if gcp.Spec.UseBasicAuth {
-			if os.Getenv(kftypes.KUBEFLOW_USERNAME) == "" || os.Getenv(kftypes.KUBEFLOW_PASSWORD) == "" {
-				return fmt.Errorf("gcp apply needs ENV %v and %v set when using basic auth",
-					kftypes.KUBEFLOW_USERNAME, kftypes.KUBEFLOW_PASSWORD)
-			}
-			gcp.username = os.Getenv(kftypes.KUBEFLOW_USERNAME)
-			password := os.Getenv(kftypes.KUBEFLOW_PASSWORD)
-			passwordHash, err := bcrypt.GenerateFromPassword([]byte(password), 10)
-			if err != nil {
-				return fmt.Errorf("Error when hashing password: %v", err)
-			}
-			gcp.encodedPassword = base64.StdEncoding.EncodeToString(passwordHash)
-		} else {
-			if os.Getenv(CLIENT_ID) == "" {
-				return fmt.Errorf("Need to set environment variable `%v` for IAP.",
-					CLIENT_ID)
-			}
-			if os.Getenv(CLIENT_SECRET) == "" {
-				return fmt.Errorf("Need to set environment variable `%v` for IAP.",
-					CLIENT_SECRET)
+		auth, err := gcp.loadAuth()
+		if err != nil {
+			return err
+		}
+		if err = gcp.setAuth(auth); err != nil {
+			return err
+		}
+		// A wrong OAuth client otherwise only shows once IAP is up, long after the deployments.
+		if gcp.authProvider().Name() == kftypes.AUTH_IAP && resources != kftypes.K8S {
+			if err = gcp.checkOAuthClient(context.Background()); err != nil {
+				return err
 			}
-			gcp.oauthId = os.Getenv(CLIENT_ID)
-			gcp.oauthSecret = os.Getenv(CLIENT_SECRET)
+		}
+	}
+	// Apps generated before deployment ids were introduced get one on their next apply.
+	created, err := gcp.ensureDeploymentId()
+	if err != nil {
+		return err
+	}
+	if created {
+		if err = gcp.writeConfigFile(); err != nil {
+			return fmt.Errorf("cannot write to config file app.yaml in %v: %v", gcp.Spec.AppDir, err)
 		}
 	}
 
 	// Update deployment manager
-	updateDMErr := gcp.updateDM(resources)
+	updateDMErr := gcp.updateDM(targets)
 	if updateDMErr != nil {
 		return fmt.Errorf("gcp apply could not update deployment manager Error %v", updateDMErr)
 	}
-	// Insert secrets into the cluster
-	secretsErr := gcp.createSecrets()
-	if secretsErr != nil {
-		return fmt.Errorf("gcp apply could not create secrets Error %v", secretsErr)
+	if targets[COMPONENT_CLUSTER] {
+		if err = gcp.ensureStaticIp(context.Background()); err != nil {
+			return err
+		}
 	}
-
-	// kfctl only
-	if gcp.isCLI {
-		// TODO(#2604): Need to create a named context.
-		cred_cmd := exec.Command("gcloud", "container", "clusters", "get-credentials",
-			gcp.Name,
-			"--zone="+gcp.Spec.Zone,
-			"--project="+gcp.Spec.Project)
-		cred_cmd.Stdout = os.Stdout
-		log.Infof("Running get-credentials %v --zone=%v --project=%v ...", gcp.KfDef.Name,
-			gcp.KfDef.Spec.Zone, gcp.KfDef.Spec.Project)
-		if err := cred_cmd.Run(); err != nil {
-			return fmt.Errorf("Error when running gcloud container clusters get-credentials: %v", err)
+	// The endpoints service resolves to the static IP created with the cluster.
+	if targets[TARGET_ENDPOINTS] && gcp.isEndpointsHostname() {
+		if err = gcp.applyEndpoints(context.Background()); err != nil {
+			return err
 		}
-		if _, err := os.Stat(kftypes.KubeConfigPath()); !os.IsNotExist(err) {
-			gcp.AddNamedContext()
+	}
+	if err = gcp.configCluster(targets); err != nil {
+		return err
+	}
+
+	// kfctl only; a user supplied kubeconfig is used as is.
+	if gcp.isCLI && !gcp.useKubeconfig() && resources != kftypes.K8S {
+		// It only configures kubectl, kfctl reaches the cluster through the GKE API.
+		if err = gcp.WriteKubeconfig(); err != nil {
+			return err
 		}
 	}
 	return nil
 }
 
 // Try to get information for the deployment. If returned, delete it.
-func deleteDeployment(deploymentmanagerService *deploymentmanager.Service, ctx context.Context,
+func (gcp *Gcp) deleteDeployment(deploymentmanagerService DeploymentManagerClient, ctx context.Context,
 	project string, name string) error {
-	_, err := deploymentmanagerService.Deployments.Get(project, name).Context(ctx).Do()
+	_, err := deploymentmanagerService.GetDeployment(ctx, project, name)
 	if err != nil {
-		e := err.(*googleapi.Error)
-		if e.Code == 404 {
+		if isNotFound(err) {
 			// Don't treat not found deployment deletion as error to make kfctl delete idempotent.
 			log.Infof("Deployment %v/%v is not found during deletion.", project, name)
 			return nil
-		} else {
-			return fmt.Errorf("Deployment %v/%v has unexpected error: %v", project, name, err)
 		}
+		return fmt.Errorf("Deployment %v/%v has unexpected error: %v", project, name, err)
 	}
 
-	op, err := deploymentmanagerService.Deployments.Delete(project, name).Context(ctx).Do()
+	op, err := deploymentmanagerService.DeleteDeployment(ctx, project, name, "")
 	if err != nil {
 		return fmt.Errorf("Gcp.Delete is failed for %v/%v: %v", project, name, err)
 	}
-	if err = blockingWait(project, op.Name, deploymentmanagerService, ctx,
+	if err = gcp.blockingWait(project, op.Name, deploymentmanagerService, ctx,
 		"Deleting "+name); err != nil {
 		return fmt.Errorf("Gcp.Delete is failed for %v/%v: %v", project, name, err)
 	}
 	return nil
 }
 
+// keptComponents are the DM components holding data, which delete leaves in the project unless
+// asked for: storage without --delete_storage and gcfs without --delete_filestore.
+func (gcp *Gcp) keptComponents() map[string]bool {
+	kept := map[string]bool{}
+	if !gcp.Spec.DeleteStorage {
+		kept[COMPONENT_STORAGE] = true
+	}
+	if !gcp.Spec.DeleteFilestore {
+		kept[COMPONENT_GCFS] = true
+	}
+	return kept
+}
+
+// Delete deletes the DM deployments and the IAM bindings of the app, limited to spec.targets when
+// set. Istio and the secrets go with the cluster; for K8S, the objects of the inventory kfctl
+// applied, e.g. Istio, are deleted instead. It then waits for the deleted resources to be
+// gone, writes TEARDOWN_REPORT_FILE and fails if some are still there.
 func (gcp *Gcp) Delete(resources kftypes.ResourceEnum) error {
-	ctx := context.Background()
-	// TODO: make client a parameter
-	client, err := google.DefaultClient(ctx, deploymentmanager.CloudPlatformScope)
+	if resources == kftypes.K8S {
+		// The targeted pieces of the platform are deleted by themselves.
+		if len(gcp.Spec.Targets) != 0 {
+			return nil
+		}
+		config, err := gcp.getK8sRestConfig(context.Background())
+		if err != nil {
+			return err
+		}
+		return gcp.deleteK8sResources(config)
+	}
+	targets, err := gcp.targets(kftypes.PLATFORM)
 	if err != nil {
-		return fmt.Errorf("Error getting DefaultClient: %v", err)
+		return err
 	}
-	deploymentmanagerService, err := deploymentmanager.New(client)
+	if len(gcp.Spec.Targets) != 0 {
+		if targets[COMPONENT_STORAGE] && !gcp.Spec.DeleteStorage {
+			return &kfapis.KfError{
+				Code:    int(kfapis.INVALID_ARGUMENT),
+				Message: fmt.Sprintf("targeting %v also needs --%v", COMPONENT_STORAGE, kftypes.DELETE_STORAGE),
+			}
+		}
+		if targets[COMPONENT_GCFS] && !gcp.Spec.DeleteFilestore {
+			return &kfapis.KfError{
+				Code:    int(kfapis.INVALID_ARGUMENT),
+				Message: fmt.Sprintf("targeting %v also needs --%v", COMPONENT_GCFS, kftypes.DELETE_FILESTORE),
+			}
+		}
+		if gcp.Spec.CombinedDeployment && dmTargeted(targets) && !allDmTargeted(targets) {
+			return &kfapis.KfError{
+				Code: int(kfapis.INVALID_ARGUMENT),
+				Message: fmt.Sprintf("%v has a single deployment for %v; target all of them to delete it",
+					gcp.Name, strings.Join(dmTargets, ", ")),
+			}
+		}
+	}
+	ctx := context.Background()
+	client := gcp.client
+	deploymentmanagerService, err := gcp.newDeploymentManagerClient()
 	if err != nil {
-		return fmt.Errorf("Error creating deploymentmanagerService: %v", err)
+		return err
 	}
 
-	// cluster and storage deployments are required to be deleted. network and gcfs deployments are optional.
+	// Deployments are discovered by their ownership labels; the storage and gcfs deployments are
+	// kept unless asked for.
 	project := gcp.Spec.Project
-	deletingDeployments := []string{
-		gcp.Name,
-	}
-	if gcp.Spec.DeleteStorage {
-		deletingDeployments = append(deletingDeployments, gcp.Name+"-storage")
+	owned, err := gcp.listOwnedDeployments(ctx, deploymentmanagerService)
+	if err != nil {
+		return err
 	}
-	if _, networkStatErr := os.Stat(path.Join(gcp.Spec.AppDir, NETWORK_FILE)); !os.IsNotExist(networkStatErr) {
-		deletingDeployments = append(deletingDeployments, gcp.Name+"-network")
+	kept := gcp.keptComponents()
+	deletingDeployments := []string{}
+	for _, d := range owned {
+		component := getLabel(d, LABEL_COMPONENT)
+		if kept[component] {
+			continue
+		}
+		if component == COMPONENT_COMBINED && !allDmTargeted(targets) ||
+			component != COMPONENT_COMBINED && !targets[component] {
+			continue
+		}
+		if component == COMPONENT_COMBINED && len(kept) > 0 {
+			if err = gcp.abandonStorage(deploymentmanagerService, ctx, project, d.Name, kept); err != nil {
+				return err
+			}
+		}
+		deletingDeployments = append(deletingDeployments, d.Name)
+	}
+	if len(owned) == 0 {
+		// Deployments created before ownership labels were introduced are found by name;
+		// only those that exist are deleted, whatever configs the local app dir has.
+		log.Infof("No labeled deployments found for %v; falling back to deployment names.", gcp.Name)
+		names := []string{}
+		for _, d := range []struct{ component, name string }{
+			{COMPONENT_CLUSTER, gcp.Name},
+			{COMPONENT_NETWORK, gcp.Name + "-network"},
+			{COMPONENT_GCFS, gcp.Name + "-gcfs"},
+			{COMPONENT_STORAGE, gcp.Name + "-storage"},
+		} {
+			if targets[d.component] && !kept[d.component] {
+				names = append(names, d.name)
+			}
+		}
+		if deletingDeployments, err = liveDeployments(ctx, deploymentmanagerService, project, names); err != nil {
+			return err
+		}
 	}
-	if _, gcfsStatErr := os.Stat(path.Join(gcp.Spec.AppDir, GCFS_FILE)); !os.IsNotExist(gcfsStatErr) {
-		deletingDeployments = append(deletingDeployments, gcp.Name+"-gcfs")
+
+	// The disks are snapshotted first, as nothing can be done about them once the deletion started.
+	if gcp.Spec.SnapshotStorage && !kept[COMPONENT_STORAGE] && targets[COMPONENT_STORAGE] &&
+		len(deletingDeployments) > 0 {
+		if err = gcp.snapshotStorage(ctx); err != nil {
+			return err
+		}
 	}
 
+	// report records every resource that was actually destroyed, and what's still there once
+	// verified, for the teardown report.
+	report := &teardownReport{
+		Name:    gcp.Name,
+		Project: project,
+		Deleted: []string{},
+	}
+	defer func() {
+		gcp.reportTeardown(report)
+	}()
 	for _, d := range deletingDeployments {
-		if err = deleteDeployment(deploymentmanagerService, ctx, project, d); err != nil {
+		if err = gcp.deleteDeployment(deploymentmanagerService, ctx, project, d); err != nil {
 			return err
 		}
+		report.Deleted = append(report.Deleted, fmt.Sprintf("deployment %v/%v", project, d))
 	}
-
-	policy, err := utils.GetIamPolicy(project, client)
-	if err != nil {
-		return fmt.Errorf("Error when getting IAM policy: %v", err)
-	}
-	saSet := mapset.NewSet(
-		"serviceAccount:"+getSA(gcp.Name, "admin", project),
-		"serviceAccount:"+getSA(gcp.Name, "user", project),
-		"serviceAccount:"+getSA(gcp.Name, "vm", project))
-	for idx, binding := range policy.Bindings {
-		cleanedMembers := []string{}
-		for _, member := range binding.Members {
-			if saSet.Contains(member) {
-				log.Infof("Removing %v from %v", member, binding.Role)
-			} else {
-				cleanedMembers = append(cleanedMembers, member)
-			}
+	// The static IP kfctl reserved doesn't go with the cluster deployment.
+	if targets[COMPONENT_CLUSTER] {
+		if err = gcp.forgetInitialVersion(); err != nil {
+			return err
+		}
+		released, err := gcp.releaseStaticIp(ctx)
+		if err != nil {
+			return err
+		}
+		if released {
+			report.Deleted = append(report.Deleted, "address "+gcp.Spec.IpName)
 		}
-		policy.Bindings[idx].Members = cleanedMembers
 	}
-	if err = utils.SetIamPolicy(project, policy, client); err != nil {
-		return fmt.Errorf("Error when cleaning IAM policy: %v", err)
+	if (targets[COMPONENT_CLUSTER] || targets[TARGET_ENDPOINTS]) && gcp.isEndpointsHostname() {
+		deletedEndpoints, err := gcp.deleteEndpoints(ctx)
+		if err != nil {
+			return err
+		}
+		if deletedEndpoints {
+			report.Deleted = append(report.Deleted, "endpoints service "+gcp.Spec.Hostname)
+		}
 	}
 
-	return nil
+	if targets[TARGET_IAM] {
+		saSet := getDeploymentSAs(gcp.Name, project)
+		var removedBindings []string
+		if err = gcp.updateIamPolicy(project, client, func(policy *cloudresourcemanager.Policy) {
+			// Overwritten on every attempt as the policy is re-read after an etag conflict.
+			removedBindings = removeMembers(policy, saSet)
+		}); err != nil {
+			return fmt.Errorf("Error when cleaning IAM policy: %v", err)
+		}
+		report.Deleted = append(report.Deleted, removedBindings...)
+	}
+
+	gcp.verifyDeleted(ctx, gcp.teardownChecks(deploymentmanagerService, targets, deletingDeployments), report)
+	return gcp.remainingError(report)
 }
 
-func (gcp *Gcp) copyFile(source string, dest string) error {
-	from, err := os.Open(source)
-	if err != nil {
-		return fmt.Errorf("cannot create directory %v", err)
-	}
-	defer from.Close()
-	to, err := os.OpenFile(dest, os.O_RDWR|os.O_CREATE, 0666)
-	if err != nil {
-		return fmt.Errorf("cannot create dest file %v  Error %v", dest, err)
+// reportDeleted prints exactly which GCP resources were destroyed by Delete.
+func (gcp *Gcp) reportDeleted(deleted []string) {
+	report := fmt.Sprintf("Deleted %v resources for %v:\n", len(deleted), gcp.Name)
+	for _, d := range deleted {
+		report += "  " + d + "\n"
 	}
-	defer to.Close()
-	_, err = io.Copy(to, from)
-	if err != nil {
-		return fmt.Errorf("copy failed source %v dest %v Error %v", source, dest, err)
+	if gcp.isCLI {
+		fmt.Print(report)
+	} else {
+		log.Info(report)
 	}
+}
 
-	return nil
+// setComponentParam sets the parameter name of component, appending it when it's missing.
+// Usage: gcp.setComponentParam("cert-manager", "acmeEmail", gcp.Spec.Email, true)
+func (gcp *Gcp) setComponentParam(component string, name string, val string, required bool) {
+	gcp.specLock.Lock()
+	defer gcp.specLock.Unlock()
+	log.Infof("Setting %v.%v to %v", component, name, val)
+	gcp.Spec.SetComponentParam(component, name, val, required)
 }
 
-// Usage: a = setNameVal(a, "acmeEmail", gcp.Spec.Email, true), similar to append
-func setNameVal(entries []configtypes.NameValue, name string, val string, required bool) []configtypes.NameValue {
-	for i, nv := range entries {
-		if nv.Name == name {
-			log.Infof("Setting %v to %v", name, val)
-			entries[i].Value = val
-			return entries
-		}
-	}
-	log.Infof("Appending %v as %v", name, val)
-	entries = append(entries, configtypes.NameValue{
-		Name:         name,
-		Value:        val,
-		InitRequired: required,
-	})
-	return entries
+// componentParam returns the value of the parameter name of component, empty when it's missing.
+func (gcp *Gcp) componentParam(component string, name string) string {
+	gcp.specLock.Lock()
+	defer gcp.specLock.Unlock()
+	val, _ := gcp.Spec.GetComponentParam(component, name)
+	return val
 }
 
-// Helper function to generate account field for IAP.
-func (gcp *Gcp) getIapAccount() string {
-	iapAcct := "serviceAccount:" + gcp.Spec.Email
-	if !strings.Contains(gcp.Spec.Email, "iam.gserviceaccount.com") {
-		iapAcct = "user:" + gcp.Spec.Email
+// adminMembers are the IAM members of the email and spec.adminMembers, without duplicates.
+func (gcp *Gcp) adminMembers() []string {
+	emails := gcp.Spec.AdminMembers
+	if gcp.Spec.Email != "" {
+		emails = append([]string{gcp.Spec.Email}, emails...)
+	}
+	members := []string{}
+	seen := map[string]bool{}
+	for _, email := range emails {
+		member := iapMember(email)
+		if !seen[member] {
+			seen[member] = true
+			members = append(members, member)
+		}
 	}
-	return iapAcct
+	return members
 }
 
-// Write IAM binding rules based on GCP app config.
-func (gcp *Gcp) writeIamBindingsFile(src string, dest string) error {
-	buf, err := ioutil.ReadFile(src)
-	if err != nil {
-		return &kfapis.KfError{
-			Code:    int(kfapis.INTERNAL_ERROR),
-			Message: fmt.Sprintf("Error when reading template %v: %v", src, err),
+// iapMember is the IAM member of email: a serviceAccount: member for GCP service accounts,
+// else a user: member. Members, e.g. group:ml-admins@example.com, are returned as they are.
+func iapMember(email string) string {
+	for _, prefix := range []string{"user:", "group:", "serviceAccount:", "domain:"} {
+		if strings.HasPrefix(email, prefix) {
+			return email
 		}
 	}
+	if strings.Contains(email, "iam.gserviceaccount.com") {
+		return "serviceAccount:" + email
+	}
+	return "user:" + email
+}
 
+// Write IAM binding rules based on GCP app config to bundle. buf is the template, read from src.
+func (gcp *Gcp) writeIamBindingsFile(bundle *Bundle, buf []byte, src string) error {
 	var data map[string]interface{}
-	if err = yaml.Unmarshal(buf, &data); err != nil {
+	if err := yaml.Unmarshal(buf, &data); err != nil {
 		return &kfapis.KfError{
 			Code:    int(kfapis.INTERNAL_ERROR),
 			Message: fmt.Sprintf("Error when unmarshaling template %v: %v", src, err),
@@ -785,10 +979,10 @@ func (gcp *Gcp) writeIamBindingsFile(src string, dest string) error {
 		"set-kubeflow-admin-service-account": "serviceAccount:" + getSA(gcp.Name, "admin", gcp.Spec.Project),
 		"set-kubeflow-user-service-account":  "serviceAccount:" + getSA(gcp.Name, "user", gcp.Spec.Project),
 		"set-kubeflow-vm-service-account":    "serviceAccount:" + getSA(gcp.Name, "vm", gcp.Spec.Project),
-		"set-kubeflow-iap-account":           gcp.getIapAccount(),
 	}
 
 	bindings := e.([]interface{})
+	var vmRoles []interface{}
 	for idx, b := range bindings {
 		binding := b.(map[string]interface{})
 		if mem, ok := binding["members"]; ok {
@@ -796,7 +990,12 @@ func (gcp *Gcp) writeIamBindingsFile(src string, dest string) error {
 			var newMembers []string
 			for _, m := range members {
 				member := m.(string)
-				if acct, ok := roles[member]; ok {
+				if member == "set-kubeflow-vm-service-account" {
+					vmRoles, _ = binding["roles"].([]interface{})
+				}
+				if member == "set-kubeflow-iap-account" {
+					newMembers = append(newMembers, gcp.adminMembers()...)
+				} else if acct, ok := roles[member]; ok {
 					newMembers = append(newMembers, acct)
 				} else {
 					newMembers = append(newMembers, member)
@@ -811,26 +1010,37 @@ func (gcp *Gcp) writeIamBindingsFile(src string, dest string) error {
 			}
 		}
 	}
+	// Dedicated node pool service accounts get their declared roles, or the roles of the vm one.
+	for _, sa := range gcp.Spec.NodePoolServiceAccounts {
+		var poolRoles []interface{}
+		for _, role := range sa.Roles {
+			poolRoles = append(poolRoles, role)
+		}
+		if len(poolRoles) == 0 {
+			poolRoles = vmRoles
+		}
+		bindings = append(bindings, map[string]interface{}{
+			"members": []string{"serviceAccount:" + getSA(gcp.Name, sa.Pool, gcp.Spec.Project)},
+			"roles":   poolRoles,
+		})
+	}
 	data["bindings"] = bindings
 
-	if buf, err = yaml.Marshal(data); err != nil {
+	buf, err := yaml.Marshal(data)
+	if err != nil {
 		return &kfapis.KfError{
 			Code:    int(kfapis.INTERNAL_ERROR),
 			Message: fmt.Sprintf("Error when marshaling IAM bindings: %v", err),
 		}
 	}
-	if err = ioutil.WriteFile(dest, buf, 0644); err != nil {
-		return &kfapis.KfError{
-			Code:    int(kfapis.INTERNAL_ERROR),
-			Message: fmt.Sprintf("Error when writing IAM bindings: %v", err),
-		}
-	}
+	bundle.Put(path.Join(GCP_CONFIG, IAM_BINDINGS_FILE), buf)
 	return nil
 }
 
-// Replace placeholders and write to cluster-kubeflow.yaml
-func (gcp *Gcp) writeClusterConfig(src string, dest string) error {
-	buf, err := ioutil.ReadFile(src)
+// Replace placeholders of the repo's config src and write it to cluster-kubeflow.yaml of bundle.
+func (gcp *Gcp) writeClusterConfig(bundle *Bundle, src string) error {
+	dest := path.Join(GCP_CONFIG, CONFIG_FILE)
+	buf, err := gcp.readAsset(src)
 	if err != nil {
 		return &kfapis.KfError{
 			Code:    int(kfapis.INTERNAL_ERROR),
@@ -855,6 +1065,7 @@ func (gcp *Gcp) writeClusterConfig(src string, dest string) error {
 	}
 
 	resources := res.([]interface{})
+	clusterProperties := []map[string]interface{}{}
 	for idx, re := range resources {
 		resource := re.(map[string]interface{})
 		var properties map[string]interface{}
@@ -863,14 +1074,57 @@ func (gcp *Gcp) writeClusterConfig(src string, dest string) error {
 		} else {
 			properties = make(map[string]interface{})
 		}
-		properties["gkeApiVersion"] = kftypes.DefaultGkeApiVer
-		properties["zone"] = gcp.Spec.Zone
-		properties["users"] = []string{
-			gcp.getIapAccount(),
+		gcp.setGkeProperties(properties)
+		for _, p := range gcp.Spec.NodePools {
+			properties[p.Pool+"-initialNodeCount"] = p.Nodes
+			properties[p.Pool+"-machine-type"] = p.MachineType
+			if p.PoolVersion != "" {
+				properties[p.Pool+"-version"] = p.PoolVersion
+			}
+		}
+		gcp.setAcceleratorProperties(properties)
+		if sharedVpc := gcp.sharedVpcProperties(); sharedVpc != nil {
+			properties["sharedVpc"] = sharedVpc
 		}
+		properties["restrictedGoogleApis"] = gcp.Spec.ServicePerimeter != nil
+		properties["binaryAuthorization"] = gcp.Spec.BinaryAuthorization != nil
+		properties["users"] = gcp.adminMembers()
 		properties["ipName"] = gcp.Spec.IpName
+		properties["labels"] = gcp.ownerLabels()
+		pools := []string{}
+		for _, sa := range gcp.Spec.NodePoolServiceAccounts {
+			pools = append(pools, sa.Pool)
+		}
+		properties["nodePoolServiceAccounts"] = pools
+		properties["enableStackdriver"] = gcp.Spec.EnableStackdriver
+		if gke := gcp.Spec.Gke; gke != nil {
+			properties["releaseChannel"] = gke.ReleaseChannel
+			properties["maintenanceStartTime"] = gke.MaintenanceStartTime
+			properties["autoscalingProfile"] = gke.AutoscalingProfile
+			properties["verticalPodAutoscaling"] = gke.VerticalPodAutoscaling
+			// The initial version of an existing cluster can't change, so it's kept after an upgrade
+			// of its master.
+			if gke.InitialVersion != "" {
+				properties["cluster-version"] = gke.InitialVersion
+			} else if gke.MasterVersion != "" {
+				properties["cluster-version"] = gke.MasterVersion
+			}
+			if gke.NodeVersion != "" {
+				properties["node-version"] = gke.NodeVersion
+			}
+		}
 		resource["properties"] = properties
 		resources[idx] = resource
+		clusterProperties = append(clusterProperties, properties)
+	}
+	// The zone is selected once, for the node pools of the cluster.
+	if gcp.Spec.Zone == kftypes.AutoZone && len(clusterProperties) > 0 {
+		if err = gcp.selectZone(context.Background(), clusterProperties[0]); err != nil {
+			return err
+		}
+	}
+	for _, properties := range clusterProperties {
+		properties["zone"] = gcp.Spec.Zone
 	}
 	data["resources"] = resources
 
@@ -880,19 +1134,14 @@ func (gcp *Gcp) writeClusterConfig(src string, dest string) error {
 			Message: fmt.Sprintf("Error when marshaling for %v: %v", dest, err),
 		}
 	}
-	if err = ioutil.WriteFile(dest, buf, 0644); err != nil {
-		return &kfapis.KfError{
-			Code:    int(kfapis.INTERNAL_ERROR),
-			Message: fmt.Sprintf("Error when writing to %v: %v", dest, err),
-		}
-	}
-
+	bundle.Put(dest, buf)
 	return nil
 }
 
-// Replace placeholders and write to storage-kubeflow.yaml
-func (gcp *Gcp) writeStorageConfig(src string, dest string) error {
-	buf, err := ioutil.ReadFile(src)
+// Replace placeholders of the repo's config src and write it to storage-kubeflow.yaml of bundle.
+func (gcp *Gcp) writeStorageConfig(bundle *Bundle, src string) error {
+	dest := path.Join(GCP_CONFIG, STORAGE_FILE)
+	buf, err := gcp.readAsset(src)
 	if err != nil {
 		return &kfapis.KfError{
 			Code:    int(kfapis.INTERNAL_ERROR),
@@ -927,6 +1176,8 @@ func (gcp *Gcp) writeStorageConfig(src string, dest string) error {
 		}
 		properties["zone"] = gcp.Spec.Zone
 		properties["createPipelinePersistentStorage"] = true
+		properties["labels"] = gcp.ownerLabels()
+		gcp.setMetadataDbProperties(properties)
 		resource["properties"] = properties
 		resources[idx] = resource
 	}
@@ -938,59 +1189,99 @@ func (gcp *Gcp) writeStorageConfig(src string, dest string) error {
 			Message: fmt.Sprintf("Error when marshaling for %v: %v", dest, err),
 		}
 	}
-	if err = ioutil.WriteFile(dest, buf, 0644); err != nil {
-		return &kfapis.KfError{
-			Code:    int(kfapis.INTERNAL_ERROR),
-			Message: fmt.Sprintf("Error when writing to %v: %v", dest, err),
-		}
-	}
-
+	bundle.Put(dest, buf)
 	return nil
 }
 
-func (gcp *Gcp) generateDMConfigs() error {
-	appDir := gcp.Spec.AppDir
-	gcpConfigDir := path.Join(appDir, GCP_CONFIG)
-	gcpConfigDirErr := os.MkdirAll(gcpConfigDir, os.ModePerm)
-	if gcpConfigDirErr != nil {
-		return fmt.Errorf("cannot create directory %v", gcpConfigDirErr)
+// generateDMConfigs puts the DM configs and templates of gcp_config in bundle.
+func (gcp *Gcp) generateDMConfigs(bundle *Bundle) error {
+	if err := gcp.validateNodePoolServiceAccounts(); err != nil {
+		return err
+	}
+	if err := gcp.validateTenants(); err != nil {
+		return err
+	}
+	if err := gcp.validateAdminRole(); err != nil {
+		return err
+	}
+	if err := gcp.validateAdminMembers(); err != nil {
+		return err
+	}
+	if err := gcp.validateNamespacePrefix(); err != nil {
+		return err
+	}
+	if err := gcp.validateFilestore(); err != nil {
+		return err
+	}
+	if err := gcp.validateGke(); err != nil {
+		return err
+	}
+	if err := gcp.validateSharedVpc(); err != nil {
+		return err
+	}
+	if err := gcp.validateDeploymentManagerSA(); err != nil {
+		return err
+	}
+	if err := gcp.validateServicePerimeter(); err != nil {
+		return err
+	}
+	if err := gcp.validateBinaryAuthorization(); err != nil {
+		return err
+	}
+	if err := gcp.validateMetadata(); err != nil {
+		return err
+	}
+	if err := gcp.validateIstio(); err != nil {
+		return err
 	}
-	repo := gcp.Spec.Repo
-	parentDir := path.Dir(repo)
-	sourceDir := path.Join(parentDir, "deployment/gke/deployment_manager_configs")
 	files := []string{"cluster.jinja", "cluster.jinja.schema", "storage.jinja",
 		"storage.jinja.schema"}
 	for _, file := range files {
-		sourceFile := filepath.Join(sourceDir, file)
-		destFile := filepath.Join(gcpConfigDir, file)
-		copyErr := gcp.copyFile(sourceFile, destFile)
-		if copyErr != nil {
-			return fmt.Errorf("could not copy %v to %v Error %v", sourceFile, destFile, copyErr)
+		buf, _, err := gcp.readTemplate(file)
+		if err != nil {
+			return err
 		}
+		bundle.Put(path.Join(GCP_CONFIG, file), buf)
 	}
 
 	// Reading from templates and write to gcp_config directory with content had placeholders
 	// replaced.
-	from := filepath.Join(sourceDir, "iam_bindings_template.yaml")
-	to := filepath.Join(gcpConfigDir, "iam_bindings.yaml")
-	if err := gcp.writeIamBindingsFile(from, to); err != nil {
+	buf, from, err := gcp.readTemplate("iam_bindings_template.yaml")
+	if err != nil {
 		return err
 	}
-	from = filepath.Join(sourceDir, CONFIG_FILE)
-	to = filepath.Join(gcpConfigDir, CONFIG_FILE)
-	if err := gcp.writeClusterConfig(from, to); err != nil {
+	if err := gcp.writeIamBindingsFile(bundle, buf, from); err != nil {
 		return err
 	}
-	from = filepath.Join(sourceDir, STORAGE_FILE)
-	to = filepath.Join(gcpConfigDir, STORAGE_FILE)
-	if err := gcp.writeStorageConfig(from, to); err != nil {
+	if err := gcp.writeClusterConfig(bundle, path.Join(DM_CONFIGS_DIR, CONFIG_FILE)); err != nil {
 		return err
 	}
-
-	return nil
+	if err := gcp.writeStorageConfig(bundle, path.Join(DM_CONFIGS_DIR, STORAGE_FILE)); err != nil {
+		return err
+	}
+	if gcp.Spec.Filestore != nil {
+		if err := gcp.writeGcfsConfig(bundle, path.Join(DM_CONFIGS_DIR, GCFS_FILE)); err != nil {
+			return err
+		}
+	}
+	if gcp.Spec.BinaryAuthorization != nil {
+		if err := gcp.writeBinauthzPolicy(bundle); err != nil {
+			return err
+		}
+	}
+	if gcp.Spec.CombinedDeployment {
+		if err := gcp.writeCombinedConfig(bundle); err != nil {
+			return err
+		}
+	}
+	templates := append(files, "iam_bindings_template.yaml", CONFIG_FILE, STORAGE_FILE)
+	if gcp.Spec.Filestore != nil {
+		templates = append(templates, GCFS_FILE)
+	}
+	return gcp.recordTemplateSha256(templates)
 }
 
-func insertSecret(client *clientset.Clientset, secretName string, namespace string, data map[string][]byte) error {
+func (gcp *Gcp) insertSecret(client *clientset.Clientset, secretName string, namespace string, data map[string][]byte) error {
 	secret := &v1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      secretName,
@@ -999,80 +1290,147 @@ func insertSecret(client *clientset.Clientset, secretName string, namespace stri
 		Data: data,
 	}
 	_, err := client.CoreV1().Secrets(namespace).Create(secret)
+	gcp.auditSecret(AUDIT_SECRET_CREATE, namespace, secretName, data, err)
 	return err
 }
 
 // Create key for service account and write to GCP as secret.
+// createGcpServiceAcctSecret creates the secret secretName holding a key of the service account
+// email in the namespaces missing it. A single key is shared by the namespaces, taken from one
+// already having the secret when there is one, as a service account can't have more than 10 keys.
+// A new key is only created with createKey, it's an error otherwise when no namespace has one.
 func (gcp *Gcp) createGcpServiceAcctSecret(ctx context.Context, client *clientset.Clientset,
-	email string, secretName string, namespace string) error {
-	_, err := client.CoreV1().Secrets(namespace).Get(secretName, metav1.GetOptions{})
-	if err == nil {
+	email string, secretName string, namespaces []string, createKey bool) error {
+	var data map[string][]byte
+	missing := []string{}
+	for _, namespace := range namespaces {
+		secret, err := client.CoreV1().Secrets(namespace).Get(secretName, metav1.GetOptions{})
+		if err == nil {
+			if data == nil {
+				data = secret.Data
+			}
+			continue
+		}
+		if !k8serrors.IsNotFound(err) {
+			return fmt.Errorf("couldn't get secret %v/%v: %v", namespace, secretName, err)
+		}
+		missing = append(missing, namespace)
+	}
+	if len(missing) == 0 {
 		log.Infof("Secret for %v already exists ...", secretName)
 		return nil
 	}
-
-	log.Infof("Secret for %v not found, creating ...", secretName)
-	oClient := oauth2.NewClient(ctx, gcp.tokenSource)
-	iamService, err := iam.New(oClient)
-	if err != nil {
-		return fmt.Errorf("Get Oauth Client error: %v", err)
+	if data == nil && !createKey {
+		return fmt.Errorf("secret %v doesn't exist in %v to copy its key from; kfctl apply creates it",
+			secretName, strings.Join(namespaces, ", "))
 	}
-	name := fmt.Sprintf("projects/%v/serviceAccounts/%v", gcp.Spec.Project,
-		email)
-	req := &iam.CreateServiceAccountKeyRequest{
-		KeyAlgorithm:   "KEY_ALG_RSA_2048",
-		PrivateKeyType: "TYPE_GOOGLE_CREDENTIALS_FILE",
+	if data == nil {
+		log.Infof("Secret for %v not found, creating ...", secretName)
+		iamService, err := gcp.newIamClient()
+		if err != nil {
+			return err
+		}
+		name := fmt.Sprintf("projects/%v/serviceAccounts/%v", gcp.Spec.Project,
+			email)
+		req := &iam.CreateServiceAccountKeyRequest{
+			KeyAlgorithm:   "KEY_ALG_RSA_2048",
+			PrivateKeyType: "TYPE_GOOGLE_CREDENTIALS_FILE",
+		}
+		if err = gcp.checkKeyQuota(ctx, iamService, name); err != nil {
+			return err
+		}
+		saKey, err := iamService.CreateServiceAccountKey(ctx, name, req)
+		if err != nil {
+			return fmt.Errorf("Service account key creation error: %v", err)
+		}
+		gcp.recordServiceAccountKey(saKey.Name)
+		privateKeyData, err := base64.StdEncoding.DecodeString(saKey.PrivateKeyData)
+		if err != nil {
+			return fmt.Errorf("PrivateKeyData decoding error: %v", err)
+		}
+		data = map[string][]byte{
+			secretName + ".json": privateKeyData,
+		}
 	}
-	saKey, err := iamService.Projects.ServiceAccounts.Keys.Create(name, req).Context(ctx).Do()
-	if err != nil {
-		return fmt.Errorf("Service account key creation error: %v", err)
+	for _, namespace := range missing {
+		log.Infof("Creating secret %v in %v", secretName, namespace)
+		if err := gcp.insertSecret(client, secretName, namespace, data); err != nil {
+			return err
+		}
 	}
-	privateKeyData, err := base64.StdEncoding.DecodeString(saKey.PrivateKeyData)
-	if err != nil {
-		return fmt.Errorf("PrivateKeyData decoding error: %v", err)
+	return nil
+}
+
+// serviceAcctSecretNamespaces are the namespaces the GCP service account secrets are created in.
+func (gcp *Gcp) serviceAcctSecretNamespaces() []string {
+	// Also create service account secret in istio namespace
+	if gcp.Spec.UseIstio {
+		return []string{gcp.namespace(), gcp.istioNamespace()}
 	}
-	return insertSecret(client, secretName, namespace, map[string][]byte{
-		secretName + ".json": privateKeyData,
-	})
+	return []string{gcp.namespace()}
 }
 
-// User CLIENT_ID and CLIENT_SECRET from GCP to create a secret for IAP.
-func (gcp *Gcp) createIapSecret(ctx context.Context, client *clientset.Clientset) error {
-	oauthSecretNamespace := gcp.Namespace
+// serviceAcctSecretTasks create the admin and user service account secrets, creating their keys
+// with createKey. There are none with spec.disableServiceAccountKeys.
+func (gcp *Gcp) serviceAcctSecretTasks(ctx context.Context, k8sClient *clientset.Clientset,
+	createKey bool) []func() error {
+	if gcp.Spec.DisableServiceAccountKeys {
+		log.Infof("Not creating the service account secrets, their keys are disabled.")
+		return nil
+	}
+	namespaces := gcp.serviceAcctSecretNamespaces()
+	adminEmail := getSA(gcp.Name, "admin", gcp.Spec.Project)
+	userEmail := getSA(gcp.Name, "user", gcp.Spec.Project)
+	return []func() error{
+		func() error {
+			if err := gcp.createGcpServiceAcctSecret(ctx, k8sClient, adminEmail, ADMIN_SECRET_NAME, namespaces,
+				createKey); err != nil {
+				return fmt.Errorf("cannot create admin secret %v Error %v", ADMIN_SECRET_NAME, err)
+			}
+			return nil
+		},
+		func() error {
+			if err := gcp.createGcpServiceAcctSecret(ctx, k8sClient, userEmail, USER_SECRET_NAME, namespaces,
+				createKey); err != nil {
+				return fmt.Errorf("cannot create user secret %v Error %v", USER_SECRET_NAME, err)
+			}
+			return nil
+		},
+	}
+}
+
+// oauthSecretNamespace is the namespace of the IAP OAuth client secret, that of the ingress.
+func (gcp *Gcp) oauthSecretNamespace() string {
 	if gcp.Spec.UseIstio {
-		oauthSecretNamespace = IstioNamespace
+		return gcp.istioNamespace()
 	}
+	return gcp.namespace()
+}
 
+// User CLIENT_ID and CLIENT_SECRET from GCP to create a secret for IAP.
+func (gcp *Gcp) createIapSecret(ctx context.Context, client *clientset.Clientset) error {
+	oauthSecretNamespace := gcp.oauthSecretNamespace()
 	if _, err := client.CoreV1().Secrets(oauthSecretNamespace).
 		Get(KUBEFLOW_OAUTH, metav1.GetOptions{}); err == nil {
 		log.Infof("Secret for %v already exits ...", KUBEFLOW_OAUTH)
 		return nil
 	}
 
-	return insertSecret(client, KUBEFLOW_OAUTH, oauthSecretNamespace, map[string][]byte{
+	return gcp.insertSecret(client, KUBEFLOW_OAUTH, oauthSecretNamespace, map[string][]byte{
 		strings.ToLower(CLIENT_ID):     []byte(gcp.oauthId),
 		strings.ToLower(CLIENT_SECRET): []byte(gcp.oauthSecret),
 	})
 }
 
 // Use username and password provided by user and create secret for basic auth.
+// createBasicAuthSecret adds or updates the apply user, keeping the users added by kfctl user.
 func (gcp *Gcp) createBasicAuthSecret(client *clientset.Clientset) error {
-	secret := &v1.Secret{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      BASIC_AUTH_SECRET,
-			Namespace: gcp.Namespace,
-		},
-		Data: map[string][]byte{
-			"username":     []byte(gcp.username),
-			"passwordhash": []byte(gcp.encodedPassword),
-		},
-	}
-	_, err := client.CoreV1().Secrets(gcp.KfDef.Namespace).Update(secret)
+	users, err := gcp.readUsers(client)
 	if err != nil {
-		log.Warnf("Updating basic auth login is failed, trying to create one: %v", err)
-		_, err = client.CoreV1().Secrets(gcp.Namespace).Create(secret)
+		return err
 	}
-	return err
+	users = setUser(users, basicAuthUser{username: gcp.username, passwordHash: gcp.encodedPassword})
+	return gcp.writeUsers(client, users)
 }
 
 func (gcp *Gcp) createSecrets() error {
@@ -1081,35 +1439,61 @@ func (gcp *Gcp) createSecrets() error {
 	if err != nil {
 		return fmt.Errorf("Get K8s clientset error: %v", err)
 	}
-	adminEmail := getSA(gcp.Name, "admin", gcp.Spec.Project)
-	userEmail := getSA(gcp.Name, "user", gcp.Spec.Project)
-	if err := gcp.createGcpServiceAcctSecret(ctx, k8sClient, adminEmail, ADMIN_SECRET_NAME, gcp.Namespace); err != nil {
-		return fmt.Errorf("cannot create admin secret %v Error %v", ADMIN_SECRET_NAME, err)
-	}
-	if err := gcp.createGcpServiceAcctSecret(ctx, k8sClient, userEmail, USER_SECRET_NAME, gcp.Namespace); err != nil {
-		return fmt.Errorf("cannot create user secret %v Error %v", USER_SECRET_NAME, err)
+	tasks := gcp.serviceAcctSecretTasks(ctx, k8sClient, true)
+	tasks = append(tasks, func() error {
+		return gcp.createTlsSecret(k8sClient)
+	})
+	tasks = append(tasks, func() error {
+		return gcp.authProvider().CreateSecrets(ctx, gcp, k8sClient)
+	})
+	tasks = append(tasks, func() error {
+		return gcp.createMetadataDbSecret(ctx, k8sClient)
+	})
+	return gcp.writeServiceAccountKeys(runConcurrently(tasks...))
+}
+
+// SyncSecrets creates the secrets missing from the namespaces they're needed in, e.g. those
+// created since the last apply, without creating new service account keys: the service account
+// secrets are copied from a namespace having them, and it fails when none has.
+func (gcp *Gcp) SyncSecrets() error {
+	ctx := context.Background()
+	k8sClient, err := gcp.getK8sClientset(ctx)
+	if err != nil {
+		return fmt.Errorf("Get K8s clientset error: %v", err)
 	}
-	// Also create service account secret in istio namespace
-	if gcp.Spec.UseIstio {
-		if err := gcp.createGcpServiceAcctSecret(ctx, k8sClient, adminEmail, ADMIN_SECRET_NAME, IstioNamespace); err != nil {
-			return fmt.Errorf("cannot create admin secret %v Error %v", ADMIN_SECRET_NAME, err)
-		}
-		if err := gcp.createGcpServiceAcctSecret(ctx, k8sClient, userEmail, USER_SECRET_NAME, IstioNamespace); err != nil {
-			return fmt.Errorf("cannot create user secret %v Error %v", USER_SECRET_NAME, err)
-		}
+	err = gcp.writeServiceAccountKeys(runConcurrently(gcp.serviceAcctSecretTasks(ctx, k8sClient, false)...))
+	if err != nil {
+		return err
 	}
-	if gcp.Spec.UseBasicAuth {
-		if err := gcp.createBasicAuthSecret(k8sClient); err != nil {
-			return fmt.Errorf("cannot create basic auth login secret: %v", err)
-		}
-	} else {
-		if err := gcp.createIapSecret(ctx, k8sClient); err != nil {
-			return fmt.Errorf("cannot create IAP auth secret: %v", err)
+	return gcp.propagateSecrets(k8sClient)
+}
+
+// runConcurrently runs tasks in parallel and returns the error of the first failed one.
+func runConcurrently(tasks ...func() error) error {
+	errs := make([]error, len(tasks))
+	var wg sync.WaitGroup
+	for i, task := range tasks {
+		wg.Add(1)
+		go func(i int, task func() error) {
+			defer wg.Done()
+			errs[i] = task()
+		}(i, task)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return err
 		}
 	}
 	return nil
 }
 
+// hasUsageId is false for the usageId of spartakus in the configs of the repo, a placeholder
+// replaced by generate, and for the default of its prototype.
+func hasUsageId(usageId string) bool {
+	return usageId != "" && usageId != "unknown_cluster" && !strings.HasPrefix(usageId, "<")
+}
+
 // Generate generates the gcp kfapp manifest.
 // Remind: Need to be thread-safe: this entry is share among kfctl and deploy app
 func (gcp *Gcp) Generate(resources kftypes.ResourceEnum) error {
@@ -1119,77 +1503,123 @@ func (gcp *Gcp) Generate(resources kftypes.ResourceEnum) error {
 		}
 		return fmt.Errorf("email not specified.")
 	}
+	if _, err := gcp.ensureDeploymentId(); err != nil {
+		return err
+	}
+	// The spec may be adapted to the org policies, so it's done before the DM configs.
+	if resources == kftypes.ALL || resources == kftypes.PLATFORM {
+		if err := gcp.checkOrgPolicies(context.Background()); err != nil {
+			return err
+		}
+	}
+	bundle := NewBundle()
 	switch resources {
 	case kftypes.ALL:
-		gcpConfigFilesErr := gcp.generateDMConfigs()
+		gcpConfigFilesErr := gcp.generateDMConfigs(bundle)
 		if gcpConfigFilesErr != nil {
 			return fmt.Errorf("could not generate deployment manager configs under %v Error: %v", GCP_CONFIG, gcpConfigFilesErr)
 		}
 	case kftypes.PLATFORM:
-		gcpConfigFilesErr := gcp.generateDMConfigs()
+		gcpConfigFilesErr := gcp.generateDMConfigs(bundle)
 		if gcpConfigFilesErr != nil {
 			return fmt.Errorf("could not generate deployment manager configs under %v Error: %v", GCP_CONFIG, gcpConfigFilesErr)
 		}
 	}
-	gcp.Spec.ComponentParams["cert-manager"] = setNameVal(gcp.Spec.ComponentParams["cert-manager"], "acmeEmail", gcp.Spec.Email, true)
+	gcp.setComponentParam("cert-manager", "acmeEmail", gcp.Spec.Email, true)
+	gcp.specLock.Lock()
 	if gcp.Spec.IpName == "" {
 		gcp.Spec.IpName = gcp.Name + "-ip"
 	}
 	if gcp.Spec.Hostname == "" {
 		gcp.Spec.Hostname = gcp.Name + ".endpoints." + gcp.Spec.Project + ".cloud.goog"
 	}
-	if gcp.Spec.UseBasicAuth {
-		gcp.Spec.ComponentParams["basic-auth-ingress"] = setNameVal(gcp.Spec.ComponentParams["basic-auth-ingress"], "ipName", gcp.Spec.IpName, true)
-		gcp.Spec.ComponentParams["basic-auth-ingress"] = setNameVal(gcp.Spec.ComponentParams["basic-auth-ingress"], "hostname", gcp.Spec.Hostname, true)
-	} else {
-		gcp.Spec.ComponentParams["iap-ingress"] = setNameVal(gcp.Spec.ComponentParams["iap-ingress"], "ipName", gcp.Spec.IpName, true)
-		gcp.Spec.ComponentParams["iap-ingress"] = setNameVal(gcp.Spec.ComponentParams["iap-ingress"], "hostname", gcp.Spec.Hostname, true)
+	gcp.specLock.Unlock()
+	if err := gcp.authProvider().SetParams(gcp); err != nil {
+		return err
 	}
-	gcp.Spec.ComponentParams["pipeline"] = setNameVal(gcp.Spec.ComponentParams["pipeline"], "mysqlPd", gcp.Name+"-storage-metadata-store", false)
-	gcp.Spec.ComponentParams["pipeline"] = setNameVal(gcp.Spec.ComponentParams["pipeline"], "minioPd", gcp.Name+"-storage-artifact-store", false)
+	if gcp.isEndpointsHostname() {
+		gcp.disableEndpointsController()
+	}
+	if err := gcp.setCertificateParams(); err != nil {
+		return err
+	}
+	gcp.setNamespaceParams()
+	gcp.setComponentParam("pipeline", "mysqlPd", gcp.storageDeploymentName()+"-metadata-store", false)
+	gcp.setComponentParam("pipeline", "minioPd", gcp.storageDeploymentName()+"-artifact-store", false)
+	gcp.setMetadataDbParams()
 
+	// spec.usageReporting keeps its usageId, set in the params when app.yaml is read. Without it
+	// the usageId of a previous generate is kept too, only the placeholder of the config is
+	// replaced, so app.yaml doesn't change on each generate.
 	for _, comp := range gcp.Spec.Components {
-		if comp == "spartakus" {
-			rand.Seed(time.Now().UnixNano())
-			gcp.Spec.ComponentParams["spartakus"] = setNameVal(gcp.Spec.ComponentParams["spartakus"],
-				"usageId", strconv.Itoa(rand.Int()), true)
+		if comp == kftypes.SPARTAKUS && gcp.Spec.UsageReporting == nil &&
+			!hasUsageId(gcp.componentParam(kftypes.SPARTAKUS, "usageId")) {
+			// A source of its own rather than the global one, which other apps use concurrently.
+			random := rand.New(rand.NewSource(gcp.clock.Now().UnixNano()))
+			gcp.setComponentParam(kftypes.SPARTAKUS, "usageId", strconv.Itoa(random.Int()), true)
 		}
 	}
 
-	if gcp.Spec.UseIstio {
-		gcp.Spec.ComponentParams["iap-ingress"] = setNameVal(gcp.Spec.ComponentParams["iap-ingress"], "useIstio", "true", false)
+	if gcp.Spec.EnableStackdriver {
+		gcp.addStackdriverLogging()
 	}
 
-	createConfigErr := gcp.writeConfigFile()
-	if createConfigErr != nil {
-		return fmt.Errorf("cannot create config file app.yaml in %v", gcp.Spec.AppDir)
+	if err := gcp.putSecretsSyncManifest(bundle); err != nil {
+		return err
+	}
+	if err := gcp.putIstioIapIngressManifest(bundle); err != nil {
+		return err
+	}
+	if err := gcp.putConfigFile(bundle); err != nil {
+		return fmt.Errorf("cannot create config file app.yaml: %v", err)
+	}
+	gcp.specLock.Lock()
+	gcp.generated = bundle
+	gcp.specLock.Unlock()
+	if err := gcp.store.WriteBundle(bundle); err != nil {
+		return fmt.Errorf("cannot write the generated files in %v: %v", gcp.Spec.AppDir, err)
 	}
 	return nil
 }
 
+// gcpInitProject enables the APIs of projectApis in batches and waits for them to be enabled,
+// so the deployment manager calls that follow don't race with the enablement. Those of
+// hostProjectApis are enabled in spec.hostProject.
 func (gcp *Gcp) gcpInitProject() error {
 	ctx := context.Background()
-	serviceusageService, serviceusageServiceErr := serviceusage.New(gcp.client)
-	if serviceusageServiceErr != nil {
-		return fmt.Errorf("could not create service usage service %v", serviceusageServiceErr)
-	}
-
-	enabledApis := []string{
-		"deploymentmanager.googleapis.com",
-		"servicemanagement.googleapis.com",
-		"container.googleapis.com",
-		"cloudresourcemanager.googleapis.com",
-		"endpoints.googleapis.com",
-		"file.googleapis.com",
-		"ml.googleapis.com",
-		"iam.googleapis.com",
-		"sqladmin.googleapis.com",
-	}
-	for _, api := range enabledApis {
-		service := fmt.Sprintf("projects/%v/services/%v", gcp.Spec.Project, api)
-		_, opErr := serviceusageService.Services.Enable(service, &serviceusage.EnableServiceRequest{}).Context(ctx).Do()
+	serviceusageService, err := gcp.newServiceUsageClient()
+	if err != nil {
+		return err
+	}
+	if err = gcp.enableApis(ctx, serviceusageService, gcp.Spec.Project, gcp.projectApis()); err != nil {
+		return err
+	}
+	if gcp.Spec.HostProject != "" {
+		return gcp.enableApis(ctx, serviceusageService, gcp.Spec.HostProject, hostProjectApis)
+	}
+	return nil
+}
+
+// enableApis enables apis in project in batches of maxBatchEnable, waiting for each.
+func (gcp *Gcp) enableApis(ctx context.Context, serviceusageService ServiceUsageClient, project string,
+	apis []string) error {
+	parent := fmt.Sprintf("projects/%v", project)
+	for start := 0; start < len(apis); start += maxBatchEnable {
+		end := start + maxBatchEnable
+		if end > len(apis) {
+			end = len(apis)
+		}
+		batch := strings.Join(apis[start:end], ", ")
+		log.Infof("Enabling API services %v in %v", batch, project)
+		op, opErr := serviceusageService.BatchEnableServices(ctx, parent, &serviceusage.BatchEnableServicesRequest{
+			ServiceIds: apis[start:end],
+		})
 		if opErr != nil {
-			return fmt.Errorf("could not enable API service %v: %v", api, opErr)
+			return fmt.Errorf("could not enable API services %v in %v: %v", batch, project,
+				gcp.perimeterError(opErr))
+		}
+		if err := gcp.waitServiceUsage(ctx, serviceusageService, op, "Enabling "+batch); err != nil {
+			return err
 		}
 	}
 	return nil
@@ -1197,11 +1627,11 @@ func (gcp *Gcp) gcpInitProject() error {
 
 // Init initializes a gcp kfapp
 func (gcp *Gcp) Init(resources kftypes.ResourceEnum) error {
-	cacheDir := path.Join(gcp.Spec.AppDir, kftypes.DefaultCacheDir)
+	cacheDir := filepath.Join(gcp.Spec.AppDir, kftypes.DefaultCacheDir)
 	newPath := filepath.Join(cacheDir, gcp.Spec.Version)
 	swaggerFile := filepath.Join(newPath, kftypes.DefaultSwaggerFile)
 	gcp.Spec.ServerVersion = "file:" + swaggerFile
-	gcp.Spec.Repo = path.Join(newPath, "kubeflow")
+	gcp.Spec.Repo = filepath.Join(newPath, "kubeflow")
 	createConfigErr := gcp.writeConfigFile()
 	if createConfigErr != nil {
 		return fmt.Errorf("cannot create config file app.yaml in %v", gcp.Spec.AppDir)
@@ -0,0 +1,245 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"fmt"
+	kfapis "github.com/kubeflow/kubeflow/bootstrap/pkg/apis"
+	kftypes "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps"
+	kfdefs "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps/kfdef/v1alpha1"
+	"sort"
+	"strings"
+)
+
+// The container API versions of spec.gke.apiVersion.
+const (
+	GKE_API_V1      = "v1"
+	GKE_API_V1BETA1 = "v1beta1"
+)
+
+// The features of spec.gke.featureGates.
+const (
+	GKE_FEATURE_WORKLOAD_IDENTITY = "workloadIdentity"
+	GKE_FEATURE_NETWORK_POLICY    = "networkPolicy"
+	GKE_FEATURE_SANDBOX           = "sandbox"
+)
+
+// DEFAULT_MASTER_CIDR is the range of the masters of a private cluster, that of
+// cluster-kubeflow.yaml, when its config has none.
+const DEFAULT_MASTER_CIDR = "172.16.0.16/28"
+
+// gkeFeatures are the feature gates, true for those needing the v1beta1 API. Each is the boolean
+// property of cluster.jinja of the same name.
+var gkeFeatures = map[string]bool{
+	GKE_FEATURE_WORKLOAD_IDENTITY: true,
+	GKE_FEATURE_NETWORK_POLICY:    false,
+	GKE_FEATURE_SANDBOX:           true,
+}
+
+// gkeApiVersion is the container API of spec.gke, kftypes.DefaultGkeApiVer when unset.
+func (gcp *Gcp) gkeApiVersion() string {
+	if gcp.Spec.Gke == nil || gcp.Spec.Gke.ApiVersion == "" {
+		return kftypes.DefaultGkeApiVer
+	}
+	return gcp.Spec.Gke.ApiVersion
+}
+
+// gkeFeatureEnabled is true when feature is turned on in spec.gke.featureGates.
+func (gcp *Gcp) gkeFeatureEnabled(feature string) bool {
+	return gcp.Spec.Gke != nil && gcp.Spec.Gke.FeatureGates[feature]
+}
+
+// validateGke checks the API version and feature gates of spec.gke; beta features can't be used
+// with the v1 API, cluster.jinja only renders them for v1beta1.
+func (gcp *Gcp) validateGke() error {
+	apiVersion := gcp.gkeApiVersion()
+	if apiVersion != GKE_API_V1 && apiVersion != GKE_API_V1BETA1 {
+		return &kfapis.KfError{
+			Code:    int(kfapis.INVALID_ARGUMENT),
+			Message: fmt.Sprintf("unknown gke apiVersion %v; must be %v or %v", apiVersion, GKE_API_V1, GKE_API_V1BETA1),
+		}
+	}
+	if beta := gcp.betaGkeSettings(); apiVersion != GKE_API_V1BETA1 && len(beta) > 0 {
+		return &kfapis.KfError{
+			Code: int(kfapis.INVALID_ARGUMENT),
+			Message: fmt.Sprintf("%v need gke apiVersion %v", strings.Join(beta, ", "),
+				GKE_API_V1BETA1),
+		}
+	}
+	if gcp.Spec.DisableServiceAccountKeys && !gcp.gkeFeatureEnabled(GKE_FEATURE_WORKLOAD_IDENTITY) {
+		return &kfapis.KfError{
+			Code: int(kfapis.INVALID_ARGUMENT),
+			Message: fmt.Sprintf("disableServiceAccountKeys needs gke feature gate %v",
+				GKE_FEATURE_WORKLOAD_IDENTITY),
+		}
+	}
+	if gcp.Spec.Gke == nil {
+		return nil
+	}
+	features := []string{}
+	for feature := range gcp.Spec.Gke.FeatureGates {
+		features = append(features, feature)
+	}
+	sort.Strings(features)
+	for _, feature := range features {
+		beta, known := gkeFeatures[feature]
+		if !known {
+			return &kfapis.KfError{
+				Code: int(kfapis.INVALID_ARGUMENT),
+				Message: fmt.Sprintf("unknown gke feature gate %v; must be %v, %v or %v", feature,
+					GKE_FEATURE_WORKLOAD_IDENTITY, GKE_FEATURE_NETWORK_POLICY, GKE_FEATURE_SANDBOX),
+			}
+		}
+		if beta && gcp.Spec.Gke.FeatureGates[feature] && apiVersion != GKE_API_V1BETA1 {
+			return &kfapis.KfError{
+				Code:    int(kfapis.INVALID_ARGUMENT),
+				Message: fmt.Sprintf("gke feature gate %v needs apiVersion %v", feature, GKE_API_V1BETA1),
+			}
+		}
+	}
+	if nap := gcp.Spec.Gke.Autoprovisioning; nap != nil && nap.Enabled {
+		if apiVersion != GKE_API_V1BETA1 {
+			return &kfapis.KfError{
+				Code:    int(kfapis.INVALID_ARGUMENT),
+				Message: fmt.Sprintf("gke autoprovisioning needs apiVersion %v", GKE_API_V1BETA1),
+			}
+		}
+		if err := validateAutoprovisioning(nap); err != nil {
+			return &kfapis.KfError{
+				Code:    int(kfapis.INVALID_ARGUMENT),
+				Message: fmt.Sprintf("invalid gke autoprovisioning: %v", err),
+			}
+		}
+	}
+	return nil
+}
+
+// betaGkeSettings lists the settings of the spec only the v1beta1 API supports, other than the
+// feature gates and autoprovisioning.
+func (gcp *Gcp) betaGkeSettings() []string {
+	beta := []string{}
+	if gcp.Spec.EnableTpu {
+		beta = append(beta, "enableTpu")
+	}
+	if gcp.Spec.BinaryAuthorization != nil {
+		beta = append(beta, "binaryAuthorization")
+	}
+	if gke := gcp.Spec.Gke; gke != nil {
+		if gke.ReleaseChannel != "" {
+			beta = append(beta, "gke releaseChannel")
+		}
+		if gke.AutoscalingProfile != "" {
+			beta = append(beta, "gke autoscalingProfile")
+		}
+		if gke.VerticalPodAutoscaling {
+			beta = append(beta, "gke verticalPodAutoscaling")
+		}
+		if gke.PrivateCluster {
+			beta = append(beta, "gke privateCluster")
+		}
+	}
+	return beta
+}
+
+// validateAutoprovisioning checks the limits of nap, whose cpu and memory need a maximum, and
+// its service account.
+func validateAutoprovisioning(nap *kfdefs.AutoprovisioningConfig) error {
+	if err := validateAutoprovisioningLimit("cpu", nap.MinCpu, nap.MaxCpu); err != nil {
+		return err
+	}
+	if err := validateAutoprovisioningLimit("memory", nap.MinMemory, nap.MaxMemory); err != nil {
+		return err
+	}
+	seen := map[string]bool{}
+	for _, accelerator := range nap.Accelerators {
+		if accelerator.Type == "" {
+			return fmt.Errorf("accelerators need a type")
+		}
+		if seen[accelerator.Type] {
+			return fmt.Errorf("accelerator %v is listed twice", accelerator.Type)
+		}
+		seen[accelerator.Type] = true
+		if err := validateAutoprovisioningLimit(accelerator.Type, accelerator.Min, accelerator.Max); err != nil {
+			return err
+		}
+	}
+	if nap.ServiceAccount != "" && !strings.Contains(nap.ServiceAccount, "@") {
+		return fmt.Errorf("serviceAccount %v isn't an email", nap.ServiceAccount)
+	}
+	return nil
+}
+
+func validateAutoprovisioningLimit(resource string, min int64, max int64) error {
+	if max <= 0 {
+		return fmt.Errorf("%v needs a maximum above 0", resource)
+	}
+	if min < 0 || min > max {
+		return fmt.Errorf("minimum %v of %v must be between 0 and its maximum %v", min, resource, max)
+	}
+	return nil
+}
+
+// setGkeProperties sets the API version, the feature gates, the private nodes and the node
+// auto-provisioning of the cluster config.
+func (gcp *Gcp) setGkeProperties(properties map[string]interface{}) {
+	properties["gkeApiVersion"] = gcp.gkeApiVersion()
+	for feature := range gkeFeatures {
+		properties[feature] = gcp.gkeFeatureEnabled(feature)
+	}
+	if gcp.Spec.Gke != nil && gcp.Spec.Gke.PrivateCluster {
+		securityConfig, _ := properties["securityConfig"].(map[string]interface{})
+		if securityConfig == nil {
+			securityConfig = map[string]interface{}{}
+		}
+		securityConfig["privatecluster"] = true
+		if _, ok := securityConfig["masterIpv4CidrBlock"]; !ok {
+			securityConfig["masterIpv4CidrBlock"] = DEFAULT_MASTER_CIDR
+		}
+		if _, ok := securityConfig["masterAuthorizedNetworksConfigEnabled"]; !ok {
+			securityConfig["masterAuthorizedNetworksConfigEnabled"] = false
+		}
+		properties["securityConfig"] = securityConfig
+	}
+	if gcp.Spec.Gke != nil && gcp.Spec.Gke.Autoprovisioning != nil {
+		properties["autoprovisioning-config"] = autoprovisioningProperties(gcp.Spec.Gke.Autoprovisioning)
+	}
+}
+
+// autoprovisioningProperties is the autoprovisioning-config property of cluster.jinja for nap.
+func autoprovisioningProperties(nap *kfdefs.AutoprovisioningConfig) map[string]interface{} {
+	accelerators := []interface{}{}
+	for _, accelerator := range nap.Accelerators {
+		accelerators = append(accelerators, map[string]interface{}{
+			"type":  accelerator.Type,
+			"min":   accelerator.Min,
+			"count": accelerator.Max,
+		})
+	}
+	properties := map[string]interface{}{
+		"enabled":         nap.Enabled,
+		"min-cpu":         nap.MinCpu,
+		"max-cpu":         nap.MaxCpu,
+		"min-memory":      nap.MinMemory,
+		"max-memory":      nap.MaxMemory,
+		"max-accelerator": accelerators,
+	}
+	if nap.ServiceAccount != "" {
+		properties["service-account"] = nap.ServiceAccount
+	}
+	if len(nap.OauthScopes) > 0 {
+		properties["oauth-scopes"] = nap.OauthScopes
+	}
+	return properties
+}
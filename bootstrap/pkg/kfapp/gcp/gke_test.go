@@ -0,0 +1,167 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"testing"
+
+	kfdefs "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps/kfdef/v1alpha1"
+)
+
+func TestValidateGke(t *testing.T) {
+	cases := []struct {
+		gke   *kfdefs.GkeConfig
+		valid bool
+	}{
+		{nil, true},
+		{&kfdefs.GkeConfig{FeatureGates: map[string]bool{"workloadIdentity": true, "sandbox": true}}, true},
+		{&kfdefs.GkeConfig{ApiVersion: "v1", FeatureGates: map[string]bool{"networkPolicy": true}}, true},
+		{&kfdefs.GkeConfig{ApiVersion: "v1", FeatureGates: map[string]bool{"sandbox": false}}, true},
+		{&kfdefs.GkeConfig{ApiVersion: "v1", FeatureGates: map[string]bool{"workloadIdentity": true}}, false},
+		{&kfdefs.GkeConfig{ApiVersion: "v1alpha1"}, false},
+		{&kfdefs.GkeConfig{FeatureGates: map[string]bool{"istio": true}}, false},
+		{&kfdefs.GkeConfig{PrivateCluster: true}, true},
+		{&kfdefs.GkeConfig{ApiVersion: "v1", PrivateCluster: true}, false},
+		{&kfdefs.GkeConfig{ApiVersion: "v1", MaintenanceStartTime: "03:00"}, true},
+		{&kfdefs.GkeConfig{ApiVersion: "v1", ReleaseChannel: "REGULAR"}, false},
+		{&kfdefs.GkeConfig{ApiVersion: "v1", AutoscalingProfile: "BALANCED"}, false},
+		{&kfdefs.GkeConfig{ApiVersion: "v1", VerticalPodAutoscaling: true}, false},
+		{&kfdefs.GkeConfig{AutoscalingProfile: "BALANCED", VerticalPodAutoscaling: true}, true},
+	}
+	for i, c := range cases {
+		gcp := &Gcp{}
+		gcp.Spec.Gke = c.gke
+		err := gcp.validateGke()
+		if c.valid && err != nil {
+			t.Errorf("case %v: validateGke failed: %v", i, err)
+		} else if !c.valid && err == nil {
+			t.Errorf("case %v: validateGke succeeded", i)
+		}
+	}
+
+	gcp := &Gcp{}
+	gcp.Spec.EnableTpu = true
+	if err := gcp.validateGke(); err != nil {
+		t.Errorf("validateGke failed with enableTpu: %v", err)
+	}
+	gcp.Spec.Gke = &kfdefs.GkeConfig{ApiVersion: "v1"}
+	if err := gcp.validateGke(); err == nil {
+		t.Errorf("validateGke succeeded with enableTpu and apiVersion v1")
+	}
+}
+
+func TestSetGkeProperties(t *testing.T) {
+	gcp := &Gcp{}
+	properties := map[string]interface{}{}
+	gcp.setGkeProperties(properties)
+	if properties["gkeApiVersion"] != "v1beta1" || properties["workloadIdentity"] != false ||
+		properties["networkPolicy"] != false || properties["sandbox"] != false {
+		t.Errorf("default properties are %v", properties)
+	}
+
+	gcp.Spec.Gke = &kfdefs.GkeConfig{
+		ApiVersion:   "v1",
+		FeatureGates: map[string]bool{"networkPolicy": true},
+	}
+	gcp.setGkeProperties(properties)
+	if properties["gkeApiVersion"] != "v1" || properties["networkPolicy"] != true ||
+		properties["workloadIdentity"] != false {
+		t.Errorf("properties are %v", properties)
+	}
+}
+
+func TestValidateAutoprovisioning(t *testing.T) {
+	valid := kfdefs.AutoprovisioningConfig{
+		Enabled:        true,
+		MinCpu:         4,
+		MaxCpu:         64,
+		MaxMemory:      256,
+		Accelerators:   []kfdefs.AutoprovisioningAccelerator{{Type: "nvidia-tesla-v100", Max: 8}},
+		ServiceAccount: "kf-vm@my-project.iam.gserviceaccount.com",
+	}
+	cases := []struct {
+		apiVersion string
+		update     func(*kfdefs.AutoprovisioningConfig)
+		valid      bool
+	}{
+		{"", func(*kfdefs.AutoprovisioningConfig) {}, true},
+		{"v1", func(*kfdefs.AutoprovisioningConfig) {}, false},
+		{"v1", func(nap *kfdefs.AutoprovisioningConfig) { nap.Enabled = false }, true},
+		{"", func(nap *kfdefs.AutoprovisioningConfig) { nap.MaxMemory = 0 }, false},
+		{"", func(nap *kfdefs.AutoprovisioningConfig) { nap.MinCpu = 65 }, false},
+		{"", func(nap *kfdefs.AutoprovisioningConfig) {
+			nap.Accelerators = append(nap.Accelerators, kfdefs.AutoprovisioningAccelerator{Type: "nvidia-tesla-v100", Max: 4})
+		}, false},
+		{"", func(nap *kfdefs.AutoprovisioningConfig) { nap.ServiceAccount = "kf-vm" }, false},
+	}
+	for i, c := range cases {
+		nap := valid
+		c.update(&nap)
+		gcp := &Gcp{}
+		gcp.Spec.Gke = &kfdefs.GkeConfig{ApiVersion: c.apiVersion, Autoprovisioning: &nap}
+		err := gcp.validateGke()
+		if c.valid && err != nil {
+			t.Errorf("case %v: validateGke failed: %v", i, err)
+		} else if !c.valid && err == nil {
+			t.Errorf("case %v: validateGke succeeded", i)
+		}
+	}
+}
+
+func TestPrivateClusterProperties(t *testing.T) {
+	gcp := &Gcp{}
+	gcp.Spec.Gke = &kfdefs.GkeConfig{PrivateCluster: true}
+	properties := map[string]interface{}{
+		"securityConfig": map[string]interface{}{"privatecluster": false, "podSecurityPolicy": true},
+	}
+	gcp.setGkeProperties(properties)
+	securityConfig := properties["securityConfig"].(map[string]interface{})
+	if securityConfig["privatecluster"] != true || securityConfig["podSecurityPolicy"] != true ||
+		securityConfig["masterIpv4CidrBlock"] != DEFAULT_MASTER_CIDR {
+		t.Errorf("securityConfig is %v", securityConfig)
+	}
+}
+
+func TestAutoprovisioningProperties(t *testing.T) {
+	gcp := &Gcp{}
+	properties := map[string]interface{}{}
+	gcp.setGkeProperties(properties)
+	if _, ok := properties["autoprovisioning-config"]; ok {
+		t.Errorf("autoprovisioning-config is set without spec.gke.autoprovisioning")
+	}
+
+	gcp.Spec.Gke = &kfdefs.GkeConfig{Autoprovisioning: &kfdefs.AutoprovisioningConfig{
+		Enabled:      true,
+		MaxCpu:       64,
+		MaxMemory:    256,
+		Accelerators: []kfdefs.AutoprovisioningAccelerator{{Type: "nvidia-tesla-v100", Min: 1, Max: 8}},
+		OauthScopes:  []string{"https://www.googleapis.com/auth/cloud-platform"},
+	}}
+	gcp.setGkeProperties(properties)
+	nap := properties["autoprovisioning-config"].(map[string]interface{})
+	if nap["enabled"] != true || nap["max-cpu"] != int64(64) || nap["max-memory"] != int64(256) {
+		t.Errorf("autoprovisioning-config is %v", nap)
+	}
+	accelerator := nap["max-accelerator"].([]interface{})[0].(map[string]interface{})
+	if accelerator["type"] != "nvidia-tesla-v100" || accelerator["min"] != int64(1) || accelerator["count"] != int64(8) {
+		t.Errorf("accelerator is %v", accelerator)
+	}
+	if _, ok := nap["service-account"]; ok {
+		t.Errorf("service-account is set without spec.gke.autoprovisioning.serviceAccount")
+	}
+	if len(nap["oauth-scopes"].([]string)) != 1 {
+		t.Errorf("oauth-scopes is %v", nap["oauth-scopes"])
+	}
+}
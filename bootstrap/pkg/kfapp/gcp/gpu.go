@@ -0,0 +1,62 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+	"k8s.io/client-go/rest"
+)
+
+const (
+	// NVIDIA_DRIVER_INSTALLER is the DaemonSet, by its path in the kubeflow repo, installing the
+	// NVIDIA drivers on the gpu-pool nodes.
+	NVIDIA_DRIVER_INSTALLER = "dependencies/gpu/nvidia-driver-installer.yaml"
+	// tpuApi is enabled by gcpInitProject when spec.enableTpu is set.
+	tpuApi = "tpu.googleapis.com"
+)
+
+// setAcceleratorProperties sets the TPU flag and the gpu-pool properties of the cluster config.
+func (gcp *Gcp) setAcceleratorProperties(properties map[string]interface{}) {
+	properties["enable_tpu"] = gcp.Spec.EnableTpu
+	gpu := gcp.Spec.Gpu
+	if gpu == nil {
+		return
+	}
+	if gpu.Type != "" {
+		properties["gpu-type"] = gpu.Type
+	}
+	count := gpu.Count
+	if count == 0 {
+		count = 1
+	}
+	properties["gpu-number-per-node"] = count
+	maxNodes := gpu.MaxNodes
+	if maxNodes == 0 {
+		maxNodes = 1
+	}
+	properties["gpu-pool-max-nodes"] = maxNodes
+}
+
+// installGpuDrivers creates the NVIDIA driver installer DaemonSet, which runs on the nodes with
+// GPUs once the gpu-pool scales up.
+func (gcp *Gcp) installGpuDrivers(ctx context.Context, client *rest.Config) error {
+	log.Infof("Installing the NVIDIA drivers...")
+	if err := gcp.createResourceFromAsset(ctx, client, NVIDIA_DRIVER_INSTALLER); err != nil {
+		log.Errorf("Failed to create the NVIDIA driver installer: %v", err)
+		return err
+	}
+	return nil
+}
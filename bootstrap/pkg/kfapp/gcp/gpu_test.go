@@ -0,0 +1,106 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	kfdefs "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps/kfdef/v1alpha1"
+	"golang.org/x/net/context"
+	"k8s.io/api/core/v1"
+	"k8s.io/client-go/rest"
+)
+
+func TestSetAcceleratorProperties(t *testing.T) {
+	cases := []struct {
+		name      string
+		enableTpu bool
+		gpu       *kfdefs.GpuConfig
+		expected  map[string]interface{}
+	}{
+		{
+			name:     "no accelerators",
+			expected: map[string]interface{}{"enable_tpu": false},
+		},
+		{
+			name:      "tpu and default gpus",
+			enableTpu: true,
+			gpu:       &kfdefs.GpuConfig{},
+			expected: map[string]interface{}{
+				"enable_tpu":          true,
+				"gpu-number-per-node": 1,
+				"gpu-pool-max-nodes":  1,
+			},
+		},
+		{
+			name: "sized gpus",
+			gpu:  &kfdefs.GpuConfig{Type: "nvidia-tesla-v100", Count: 4, MaxNodes: 3},
+			expected: map[string]interface{}{
+				"enable_tpu":          false,
+				"gpu-type":            "nvidia-tesla-v100",
+				"gpu-number-per-node": 4,
+				"gpu-pool-max-nodes":  3,
+			},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			gcp := &Gcp{}
+			gcp.Spec.EnableTpu = c.enableTpu
+			gcp.Spec.Gpu = c.gpu
+			properties := map[string]interface{}{}
+			gcp.setAcceleratorProperties(properties)
+			if !reflect.DeepEqual(properties, c.expected) {
+				t.Errorf("setAcceleratorProperties got %v; want %v", properties, c.expected)
+			}
+		})
+	}
+}
+
+func TestInstallGpuDrivers(t *testing.T) {
+	// The cluster has no API to apply the DaemonSet with, but the inventory is recorded first. The
+	// ctx is done so the apply isn't retried.
+	recorder := &recordingKubeApis{apis: fakeKubeApis{}, written: map[string][]byte{}}
+	server := httptest.NewServer(recorder)
+	defer server.Close()
+	loader := &recordingLoader{}
+	gcp := newDoctorGcp()
+	gcp.clock = fakeClock{}
+	gcp.assets = loader
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := gcp.installGpuDrivers(ctx, &rest.Config{Host: server.URL}); err == nil {
+		t.Errorf("installGpuDrivers should fail without the apps API")
+	}
+	if !reflect.DeepEqual(loader.names, []string{NVIDIA_DRIVER_INSTALLER}) {
+		t.Errorf("installGpuDrivers read %v; want %v", loader.names, NVIDIA_DRIVER_INSTALLER)
+	}
+
+	inventory := &v1.ConfigMap{}
+	if err := json.Unmarshal(recorder.written["POST /api/v1/namespaces/kube-system/configmaps"], inventory); err != nil {
+		t.Fatalf("installGpuDrivers didn't record the inventory: %v", err)
+	}
+	if !strings.Contains(inventory.Data[INVENTORY_KEY], "nvidia-driver-installer") {
+		t.Errorf("the inventory %q is missing the DaemonSet", inventory.Data[INVENTORY_KEY])
+	}
+	conditions := gcp.Status.Conditions
+	if len(conditions) != 1 || conditions[0].Type != kfdefs.KfProgressing || conditions[0].Reason != "ApplyFailed" {
+		t.Errorf("installGpuDrivers set the conditions %v", conditions)
+	}
+}
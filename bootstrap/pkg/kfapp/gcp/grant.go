@@ -0,0 +1,123 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"fmt"
+	kfapis "github.com/kubeflow/kubeflow/bootstrap/pkg/apis"
+	kftypes "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+	"google.golang.org/api/compute/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"regexp"
+	"strings"
+	"time"
+)
+
+const (
+	// USER_BINDING_PREFIX prefixes the RoleBindings of the users granted with GrantUser, named
+	// after their email.
+	USER_BINDING_PREFIX = "kubeflow-user-"
+	// USER_ROLE is the ClusterRole users are bound to in their namespace, as the members of
+	// spec.tenants are.
+	USER_ROLE = "edit"
+	// grantTimeout bounds the wait for the IAP backend service in GrantUser, which is there once
+	// the app was applied.
+	grantTimeout = time.Minute
+)
+
+// userBindingChars are the characters of an email that can't be in the name of a RoleBinding.
+var userBindingChars = regexp.MustCompile("[^a-z0-9.-]+")
+
+// userBindingName is the name of the RoleBinding of email, e.g. kubeflow-user-jane-example.com
+// for jane@example.com.
+func userBindingName(email string) string {
+	return USER_BINDING_PREFIX + strings.Trim(userBindingChars.ReplaceAllString(strings.ToLower(email), "-"), "-.")
+}
+
+// addIapMember adds the IAM member of email to spec.iapMembers, so later applies keep granting
+// it. It returns whether it was added.
+func (gcp *Gcp) addIapMember(email string) bool {
+	member := iapMember(email)
+	gcp.specLock.Lock()
+	defer gcp.specLock.Unlock()
+	if containsMember(gcp.adminMembers(), member) || containsMember(gcp.Spec.IapMembers, member) {
+		return false
+	}
+	gcp.Spec.IapMembers = append(gcp.Spec.IapMembers, member)
+	return true
+}
+
+// GrantUser onboards email to the applied app without a full apply: it binds email to USER_ROLE
+// in namespace, the app's when empty, and lets it log in, through IAP by adding it to
+// spec.iapMembers and the IAP policy, or with basic auth by adding it as a user, whose password
+// is read the way apply reads it.
+func (gcp *Gcp) GrantUser(email string, namespace string) error {
+	subject, err := tenantSubject(email)
+	if err != nil || subject.Kind != rbacv1.UserKind || strings.Contains(email, ":") {
+		return &kfapis.KfError{
+			Code:    int(kfapis.INVALID_ARGUMENT),
+			Message: fmt.Sprintf("invalid email %q", email),
+		}
+	}
+	if namespace == "" {
+		namespace = gcp.namespace()
+	}
+	provider := gcp.authProvider().Name()
+	if provider == kftypes.AUTH_IAP && gcp.addIapMember(email) && gcp.isCLI {
+		if err = gcp.writeConfigFile(); err != nil {
+			return fmt.Errorf("cannot write to config file app.yaml in %v: %v", gcp.Spec.AppDir, err)
+		}
+	}
+
+	ctx := context.Background()
+	k8sClient, err := gcp.getK8sClientset(ctx)
+	if err != nil {
+		return fmt.Errorf("Get K8s clientset error: %v", err)
+	}
+	name := userBindingName(email)
+	err = applyRoleBinding(k8sClient, namespace, name, USER_ROLE, []rbacv1.Subject{subject})
+	gcp.audit(AUDIT_RBAC_CREATE, "rolebindings/"+namespace+"/"+name, map[string]interface{}{
+		"role":    USER_ROLE,
+		"subject": email,
+	}, err)
+	if err != nil {
+		return err
+	}
+
+	switch provider {
+	case kftypes.AUTH_IAP:
+		computeService, err := compute.New(gcp.client)
+		if err != nil {
+			return fmt.Errorf("Error creating compute service: %v", err)
+		}
+		backend, err := gcp.waitForIapBackend(ctx, k8sClient, computeService, grantTimeout)
+		if err != nil {
+			return fmt.Errorf("%v is granted on the next apply: %v", email, err)
+		}
+		projectNumber, err := gcp.projectNumber(ctx)
+		if err != nil {
+			return err
+		}
+		resource := fmt.Sprintf("projects/%v/iap_web/compute/services/%v", projectNumber, backend.Id)
+		return gcp.setIapMembers(ctx, resource)
+	case kftypes.AUTH_BASIC_AUTH:
+		return gcp.AddUser(email)
+	default:
+		log.Infof("%v logs in with %v, which kfctl doesn't manage the users of", email, provider)
+		return nil
+	}
+}
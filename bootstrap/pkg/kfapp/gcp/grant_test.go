@@ -0,0 +1,51 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestUserBindingName(t *testing.T) {
+	cases := map[string]string{
+		"jane@example.com":                      "kubeflow-user-jane-example.com",
+		"John.Doe+ml@example.com":               "kubeflow-user-john.doe-ml-example.com",
+		"ci@my-project.iam.gserviceaccount.com": "kubeflow-user-ci-my-project.iam.gserviceaccount.com",
+	}
+	for email, want := range cases {
+		if got := userBindingName(email); got != want {
+			t.Errorf("userBindingName(%v) = %v; want %v", email, got, want)
+		}
+	}
+}
+
+func TestAddIapMember(t *testing.T) {
+	gcp := &Gcp{}
+	gcp.Spec.Email = "admin@example.com"
+	gcp.Spec.IapMembers = []string{"user:jane@example.com"}
+	for _, email := range []string{"admin@example.com", "jane@example.com"} {
+		if gcp.addIapMember(email) {
+			t.Errorf("addIapMember(%v) added a member already granted", email)
+		}
+	}
+	if !gcp.addIapMember("ci@my-project.iam.gserviceaccount.com") {
+		t.Errorf("addIapMember didn't add a new member")
+	}
+	want := []string{"user:jane@example.com", "serviceAccount:ci@my-project.iam.gserviceaccount.com"}
+	if !reflect.DeepEqual(gcp.Spec.IapMembers, want) {
+		t.Errorf("iapMembers = %v; want %v", gcp.Spec.IapMembers, want)
+	}
+}
@@ -0,0 +1,280 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"fmt"
+	"github.com/cenkalti/backoff"
+	kfapis "github.com/kubeflow/kubeflow/bootstrap/pkg/apis"
+	kftypes "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+	"google.golang.org/api/cloudresourcemanager/v1"
+	"google.golang.org/api/compute/v1"
+	iap "google.golang.org/api/iap/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientset "k8s.io/client-go/kubernetes"
+	"time"
+)
+
+const (
+	IAP_ROLE = "roles/iap.httpsResourceAccessor"
+	// JWT_AUDIENCE_ANNOTATION is set on the IAP ingress service by the iap-enabler with the
+	// audience the JWT of IAP requests are validated against.
+	JWT_AUDIENCE_ANNOTATION = "kubeflow.org/jwt-audience"
+	// iapTimeout bounds the wait for the ingress to create the backend service and for the
+	// iap-enabler to pick it up.
+	iapTimeout = 20 * time.Minute
+)
+
+// iapService is the service backing the IAP ingress, and its port.
+func (gcp *Gcp) iapService() (namespace string, name string, port int32) {
+	if gcp.Spec.UseIstio {
+		return gcp.istioNamespace(), "istio-ingressgateway", 80
+	}
+	return gcp.namespace(), "envoy", 0
+}
+
+// PostApply copies the credentials of the app into the synced namespaces, and configures IAP
+// on the backend service created by the ingress: it's enabled with the OAuth client of the app,
+// its health check is pointed at IAP_HEALTH_CHECK_PATH with useIstio, the email the app was
+// created with and spec.iapMembers are granted access, and the JWT audience validated in the
+// cluster is checked to match the backend.
+// Otherwise requests are refused with a 403 until the in cluster jobs catch up, if they do.
+// Each wait is bounded by iapTimeout, and all of them by ctx.
+func (gcp *Gcp) PostApply(ctx context.Context, resources kftypes.ResourceEnum) error {
+	k8sClient, err := gcp.getK8sClientset(ctx)
+	if err != nil {
+		return fmt.Errorf("Get K8s clientset error: %v", err)
+	}
+	if err = gcp.propagateSecrets(k8sClient); err != nil {
+		return err
+	}
+	if gcp.authProvider().Name() != kftypes.AUTH_IAP {
+		return nil
+	}
+	if gcp.oauthId == "" && gcp.isCLI {
+		auth, err := gcp.loadAuth()
+		if err != nil {
+			return err
+		}
+		if err = gcp.setAuth(auth); err != nil {
+			return err
+		}
+	}
+	computeService, err := compute.New(gcp.client)
+	if err != nil {
+		return fmt.Errorf("Error creating compute service: %v", err)
+	}
+	backend, err := gcp.waitForIapBackend(ctx, k8sClient, computeService, iapTimeout)
+	if err != nil {
+		return err
+	}
+	if err = gcp.enableIap(ctx, computeService, backend); err != nil {
+		return err
+	}
+	if gcp.Spec.UseIstio {
+		if err = gcp.setIapHealthCheck(ctx, computeService, backend); err != nil {
+			return err
+		}
+	}
+	projectNumber, err := gcp.projectNumber(ctx)
+	if err != nil {
+		return err
+	}
+	resource := fmt.Sprintf("projects/%v/iap_web/compute/services/%v", projectNumber, backend.Id)
+	if err = gcp.setIapMembers(ctx, resource); err != nil {
+		return err
+	}
+	audience := fmt.Sprintf("/projects/%v/global/backendServices/%v", projectNumber, backend.Id)
+	return gcp.verifyJwtAudience(ctx, k8sClient, audience)
+}
+
+// waitForIapBackend returns the backend service the GKE ingress creates for the node port of
+// the IAP service, named k8s-be-<nodePort>--<hash>, waiting up to timeout for it unless ctx is
+// done first.
+func (gcp *Gcp) waitForIapBackend(ctx context.Context, k8sClient *clientset.Clientset,
+	computeService *compute.Service, timeout time.Duration) (*compute.BackendService, error) {
+	namespace, name, port := gcp.iapService()
+	var backend *compute.BackendService
+	b := backoff.NewExponentialBackOff()
+	b.MaxElapsedTime = timeout
+	err := gcp.retryContext(ctx, func() error {
+		svc, err := k8sClient.CoreV1().Services(namespace).Get(name, metav1.GetOptions{})
+		if err != nil {
+			return fmt.Errorf("couldn't get service %v/%v: %v", namespace, name, err)
+		}
+		var nodePort int32
+		for _, p := range svc.Spec.Ports {
+			if port == 0 || p.Port == port {
+				nodePort = p.NodePort
+				break
+			}
+		}
+		if nodePort == 0 {
+			return fmt.Errorf("service %v/%v has no node port", namespace, name)
+		}
+		list, err := computeService.BackendServices.List(gcp.Spec.Project).
+			Filter(fmt.Sprintf("name eq k8s-be-%v-.*", nodePort)).Context(ctx).Do()
+		if err != nil {
+			return fmt.Errorf("couldn't list backend services: %v", err)
+		}
+		if len(list.Items) == 0 {
+			log.Infof("Waiting for the backend service of %v/%v (node port %v)", namespace, name, nodePort)
+			return fmt.Errorf("no backend service for node port %v of %v/%v", nodePort, namespace, name)
+		}
+		backend = list.Items[0]
+		return nil
+	}, b)
+	if err != nil {
+		return nil, &kfapis.KfError{
+			Code:    int(kfapis.INTERNAL_ERROR),
+			Message: fmt.Sprintf("IAP backend service wasn't created within %v: %v", timeout, err),
+		}
+	}
+	return backend, nil
+}
+
+// enableIap turns IAP on for backend with the OAuth client of the app.
+func (gcp *Gcp) enableIap(ctx context.Context, computeService *compute.Service, backend *compute.BackendService) error {
+	if backend.Iap != nil && backend.Iap.Enabled && backend.Iap.Oauth2ClientId == gcp.oauthId {
+		return nil
+	}
+	log.Infof("Enabling IAP on backend service %v", backend.Name)
+	op, err := computeService.BackendServices.Patch(gcp.Spec.Project, backend.Name, &compute.BackendService{
+		Iap: &compute.BackendServiceIAP{
+			Enabled:            true,
+			Oauth2ClientId:     gcp.oauthId,
+			Oauth2ClientSecret: gcp.oauthSecret,
+		},
+	}).Context(ctx).Do()
+	if err != nil {
+		return fmt.Errorf("couldn't enable IAP on backend service %v: %v", backend.Name, err)
+	}
+	return gcp.retryContext(ctx, func() error {
+		op, err = computeService.GlobalOperations.Get(gcp.Spec.Project, op.Name).Context(ctx).Do()
+		if err != nil {
+			return fmt.Errorf("Enabling IAP error: %v", err)
+		}
+		if op.Status != "DONE" {
+			return fmt.Errorf("Enabling IAP did not succeed; status: %v (op = %v)", op.Status, op.Name)
+		}
+		if op.Error != nil && len(op.Error.Errors) > 0 {
+			return backoff.Permanent(fmt.Errorf("Enabling IAP error: %v", op.Error.Errors[0].Message))
+		}
+		return nil
+	}, backoff.NewExponentialBackOff())
+}
+
+func (gcp *Gcp) projectNumber(ctx context.Context) (int64, error) {
+	resourceManager, err := cloudresourcemanager.New(gcp.client)
+	if err != nil {
+		return 0, fmt.Errorf("Error creating cloudresourcemanager service: %v", err)
+	}
+	project, err := resourceManager.Projects.Get(gcp.Spec.Project).Context(ctx).Do()
+	if err != nil {
+		return 0, fmt.Errorf("couldn't get project %v: %v", gcp.Spec.Project, err)
+	}
+	return project.ProjectNumber, nil
+}
+
+// setIapMembers grants IAP_ROLE on resource to the email the app was created with,
+// spec.adminMembers and spec.iapMembers, keeping the members granted otherwise.
+func (gcp *Gcp) setIapMembers(ctx context.Context, resource string) error {
+	members := append(gcp.adminMembers(), gcp.Spec.IapMembers...)
+	if len(members) == 0 {
+		return nil
+	}
+	iapService, err := iap.New(gcp.client)
+	if err != nil {
+		return fmt.Errorf("Error creating IAP service: %v", err)
+	}
+	policy, err := iapService.V1beta1.GetIamPolicy(resource, &iap.GetIamPolicyRequest{}).Context(ctx).Do()
+	if err != nil {
+		return fmt.Errorf("couldn't get the IAP policy of %v: %v", resource, err)
+	}
+	var binding *iap.Binding
+	for _, b := range policy.Bindings {
+		if b.Role == IAP_ROLE {
+			binding = b
+			break
+		}
+	}
+	if binding == nil {
+		binding = &iap.Binding{Role: IAP_ROLE}
+		policy.Bindings = append(policy.Bindings, binding)
+	}
+	newMembers := []string{}
+	for _, member := range members {
+		if !containsMember(binding.Members, member) {
+			binding.Members = append(binding.Members, member)
+			newMembers = append(newMembers, IAP_ROLE+" "+member)
+		}
+	}
+	if len(newMembers) == 0 {
+		return nil
+	}
+	log.Infof("Granting %v on %v to %v", IAP_ROLE, resource, members)
+	_, err = iapService.V1beta1.SetIamPolicy(resource, &iap.SetIamPolicyRequest{Policy: policy}).Context(ctx).Do()
+	gcp.audit(AUDIT_IAP_SET_POLICY, resource, map[string]interface{}{
+		"added":   newMembers,
+		"removed": []string{},
+	}, err)
+	if err != nil {
+		return fmt.Errorf("couldn't set the IAP policy of %v: %v", resource, err)
+	}
+	return nil
+}
+
+func containsMember(members []string, member string) bool {
+	for _, m := range members {
+		if m == member {
+			return true
+		}
+	}
+	return false
+}
+
+// verifyJwtAudience waits for the iap-enabler to record the audience it configured on the
+// IAP service and checks it's the one IAP signs requests for, giving up after iapTimeout or
+// once ctx is done.
+func (gcp *Gcp) verifyJwtAudience(ctx context.Context, k8sClient *clientset.Clientset, audience string) error {
+	namespace, name, _ := gcp.iapService()
+	configured := ""
+	b := backoff.NewExponentialBackOff()
+	b.MaxElapsedTime = iapTimeout
+	err := gcp.retryContext(ctx, func() error {
+		svc, err := k8sClient.CoreV1().Services(namespace).Get(name, metav1.GetOptions{})
+		if err != nil {
+			return fmt.Errorf("couldn't get service %v/%v: %v", namespace, name, err)
+		}
+		configured = svc.Annotations[JWT_AUDIENCE_ANNOTATION]
+		if configured != audience {
+			log.Infof("Waiting for the JWT audience of %v/%v to be %v, got %q", namespace, name, audience, configured)
+			return fmt.Errorf("JWT audience is %q", configured)
+		}
+		return nil
+	}, b)
+	if err != nil {
+		return &kfapis.KfError{
+			Code: int(kfapis.INTERNAL_ERROR),
+			Message: fmt.Sprintf("the JWT audience validated in the cluster is %q instead of %v; requests through IAP "+
+				"will be refused with a 403 until it matches. Check the logs of the iap-enabler pod in %v",
+				configured, audience, namespace),
+		}
+	}
+	log.Infof("IAP is configured for %v with JWT audience %v", gcp.Name, audience)
+	return nil
+}
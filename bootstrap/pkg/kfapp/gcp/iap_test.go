@@ -0,0 +1,126 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+	"google.golang.org/api/compute/v1"
+)
+
+const envoyServiceJson = `{"metadata": {"name": "envoy",
+	"annotations": {"kubeflow.org/jwt-audience": "/projects/123/global/backendServices/42"}},
+	"spec": {"ports": [{"port": 8080, "nodePort": 31000}]}}`
+
+func newIapGcp(t *testing.T, apis fakeGcpApis) (*Gcp, *compute.Service) {
+	gcp := newDoctorGcp()
+	gcp.clock = &sleepingClock{}
+	gcp.client = &http.Client{Transport: apis}
+	computeService, err := compute.New(gcp.client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return gcp, computeService
+}
+
+func TestWaitForIapBackend(t *testing.T) {
+	k8sClient, done := newFakeClientset(t, fakeKubeApis{
+		"/api/v1/namespaces/kubeflow/services/envoy": envoyServiceJson,
+	})
+	defer done()
+	gcp, computeService := newIapGcp(t, fakeGcpApis{
+		"/compute/v1/projects/my-project/global/backendServices": `{"items": [
+			{"name": "k8s-be-31000--abc", "id": "42"}]}`,
+	})
+	backend, err := gcp.waitForIapBackend(context.Background(), k8sClient, computeService, time.Minute)
+	if err != nil {
+		t.Fatalf("waitForIapBackend: %v", err)
+	}
+	if backend.Name != "k8s-be-31000--abc" || backend.Id != 42 {
+		t.Errorf("waitForIapBackend got backend %v (%v)", backend.Name, backend.Id)
+	}
+}
+
+func TestWaitForIapBackendTimeout(t *testing.T) {
+	k8sClient, done := newFakeClientset(t, fakeKubeApis{
+		"/api/v1/namespaces/kubeflow/services/envoy": envoyServiceJson,
+	})
+	defer done()
+	gcp, computeService := newIapGcp(t, fakeGcpApis{
+		"/compute/v1/projects/my-project/global/backendServices": `{"items": []}`,
+	})
+	if _, err := gcp.waitForIapBackend(context.Background(), k8sClient, computeService, time.Minute); err == nil {
+		t.Errorf("waitForIapBackend should time out without a backend service")
+	}
+	if slept := gcp.clock.(*sleepingClock).slept; slept < time.Minute || slept > 2*time.Minute {
+		t.Errorf("waitForIapBackend waited %v; want about a minute", slept)
+	}
+
+	// A done ctx stops the wait before the timeout.
+	gcp.clock = &sleepingClock{}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := gcp.waitForIapBackend(ctx, k8sClient, computeService, iapTimeout); err == nil {
+		t.Errorf("waitForIapBackend should fail once ctx is done")
+	}
+	if slept := gcp.clock.(*sleepingClock).slept; slept != 0 {
+		t.Errorf("waitForIapBackend waited %v after ctx was done", slept)
+	}
+}
+
+func TestVerifyJwtAudience(t *testing.T) {
+	k8sClient, done := newFakeClientset(t, fakeKubeApis{
+		"/api/v1/namespaces/kubeflow/services/envoy": envoyServiceJson,
+	})
+	defer done()
+	gcp, _ := newIapGcp(t, fakeGcpApis{})
+	if err := gcp.verifyJwtAudience(context.Background(), k8sClient,
+		"/projects/123/global/backendServices/42"); err != nil {
+		t.Errorf("verifyJwtAudience: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := gcp.verifyJwtAudience(ctx, k8sClient, "/projects/123/global/backendServices/7"); err == nil {
+		t.Errorf("verifyJwtAudience should fail on another audience")
+	}
+	if slept := gcp.clock.(*sleepingClock).slept; slept != 0 {
+		t.Errorf("verifyJwtAudience waited %v after ctx was done", slept)
+	}
+}
+
+func TestEnableIap(t *testing.T) {
+	gcp, computeService := newIapGcp(t, fakeGcpApis{
+		"/compute/v1/projects/my-project/global/backendServices/k8s-be-31000--abc": `{"name": "op-1", "status": "RUNNING"}`,
+		"/compute/v1/projects/my-project/global/operations/op-1":                   `{"name": "op-1", "status": "DONE"}`,
+	})
+	gcp.oauthId = "client-id"
+	gcp.oauthSecret = "client-secret"
+	backend := &compute.BackendService{Name: "k8s-be-31000--abc"}
+	if err := gcp.enableIap(context.Background(), computeService, backend); err != nil {
+		t.Errorf("enableIap: %v", err)
+	}
+
+	// IAP is already on with the client of the app.
+	backend.Iap = &compute.BackendServiceIAP{Enabled: true, Oauth2ClientId: "client-id"}
+	gcp, computeService = newIapGcp(t, fakeGcpApis{})
+	gcp.oauthId = "client-id"
+	if err := gcp.enableIap(context.Background(), computeService, backend); err != nil {
+		t.Errorf("enableIap should leave the backend as is: %v", err)
+	}
+}
@@ -0,0 +1,197 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"encoding/json"
+	"fmt"
+	kfdefs "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps/kfdef/v1alpha1"
+	"github.com/kubeflow/kubeflow/bootstrap/pkg/utils"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+	"google.golang.org/api/cloudresourcemanager/v1"
+	"google.golang.org/api/compute/v1"
+	"google.golang.org/api/googleapi"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// iapBrandsUrl lists the OAuth brands, the consent screens, of a project.
+const iapBrandsUrl = "https://iap.googleapis.com/v1/projects/%v/brands"
+
+// consentScreenUrl is where the OAuth consent screen of a project is set up.
+const consentScreenUrl = "https://console.cloud.google.com/apis/credentials/consent?project=%v"
+
+// InitChecker checks the settings of kfctl init --interactive against GCP as they're entered,
+// so a typo in the project or zone is caught before apply fails on it.
+type InitChecker struct {
+	gcp     *Gcp
+	project string
+}
+
+// NewInitChecker returns an InitChecker calling GCP with the credentials kfctl uses.
+func NewInitChecker() (*InitChecker, error) {
+	baseClient, err := newBaseClient(&kfdefs.KfDef{})
+	if err != nil {
+		return nil, err
+	}
+	creds, err := findCredentials(utils.WithHTTPClient(context.Background(), baseClient))
+	if err != nil {
+		return nil, err
+	}
+	_gcp := &Gcp{
+		isCLI: true,
+	}
+	_gcp.applyOptions([]Option{WithBaseClient(baseClient), WithTokenSource(creds.TokenSource)})
+	if err = _gcp.getAccount(creds); err != nil {
+		log.Infof("cannot get gcloud account email. Error: %v", err)
+	}
+	return &InitChecker{
+		gcp:     _gcp,
+		project: creds.ProjectID,
+	}, nil
+}
+
+// Account is the account kfctl calls GCP as, the default email of the app; empty when unknown.
+func (c *InitChecker) Account() string {
+	return c.gcp.Spec.Email
+}
+
+// DefaultProject is the project of the credentials; empty when they have none.
+func (c *InitChecker) DefaultProject() string {
+	return c.project
+}
+
+// isApiDisabled is true for the errors of the APIs not enabled yet in a project, which
+// kfctl apply enables; the settings are then left unchecked.
+func isApiDisabled(err error) bool {
+	e, ok := err.(*googleapi.Error)
+	if !ok || e.Code != http.StatusForbidden {
+		return false
+	}
+	for _, item := range e.Errors {
+		if item.Reason == "accessNotConfigured" {
+			return true
+		}
+	}
+	return strings.Contains(e.Message, "SERVICE_DISABLED") || strings.Contains(e.Message, "has not been used")
+}
+
+// CheckProject checks project exists, is active and can be read by the account.
+func (c *InitChecker) CheckProject(project string) error {
+	service, err := cloudresourcemanager.New(c.gcp.client)
+	if err != nil {
+		return fmt.Errorf("Error creating cloudresourcemanager service: %v", err)
+	}
+	p, err := service.Projects.Get(project).Context(context.Background()).Do()
+	if err != nil {
+		if e, ok := err.(*googleapi.Error); ok && (e.Code == http.StatusForbidden || e.Code == http.StatusNotFound) {
+			return fmt.Errorf("project %v doesn't exist or %v can't access it", project, c.Account())
+		}
+		return fmt.Errorf("couldn't get project %v: %v", project, err)
+	}
+	if p.LifecycleState != "ACTIVE" {
+		return fmt.Errorf("project %v is %v", project, p.LifecycleState)
+	}
+	return nil
+}
+
+// CheckZone checks zone is a zone of GCP, suggesting those of its region when it's not.
+func (c *InitChecker) CheckZone(project string, zone string) error {
+	service, err := compute.New(c.gcp.client)
+	if err != nil {
+		return fmt.Errorf("Error creating compute service: %v", err)
+	}
+	ctx := context.Background()
+	_, err = service.Zones.Get(project, zone).Context(ctx).Do()
+	if err == nil {
+		return nil
+	}
+	if isApiDisabled(err) {
+		log.Warnf("The compute API isn't enabled in %v yet, zone %v is left unchecked.", project, zone)
+		return nil
+	}
+	if e, ok := err.(*googleapi.Error); !ok || (e.Code != http.StatusNotFound && e.Code != http.StatusBadRequest) {
+		return fmt.Errorf("couldn't get zone %v: %v", zone, err)
+	}
+	zones, err := service.Zones.List(project).Context(ctx).Do()
+	if err != nil {
+		return fmt.Errorf("unknown zone %v", zone)
+	}
+	return fmt.Errorf("unknown zone %v; %v", zone, zoneSuggestions(zone, zones.Items))
+}
+
+// zoneSuggestions names the zones of the region of zone, or all of them when it's unknown.
+func zoneSuggestions(zone string, zones []*compute.Zone) string {
+	region := regionOf(zone)
+	all := []string{}
+	inRegion := []string{}
+	for _, z := range zones {
+		all = append(all, z.Name)
+		if strings.HasPrefix(z.Name, region+"-") {
+			inRegion = append(inRegion, z.Name)
+		}
+	}
+	if len(inRegion) > 0 {
+		sort.Strings(inRegion)
+		return fmt.Sprintf("the zones of %v are %v", region, strings.Join(inRegion, ", "))
+	}
+	sort.Strings(all)
+	return fmt.Sprintf("the zones are %v", strings.Join(all, ", "))
+}
+
+// CheckOAuthBrand checks project has the OAuth consent screen IAP needs for its OAuth client.
+func (c *InitChecker) CheckOAuthBrand(project string) error {
+	brands, err := c.gcp.oauthBrands(project)
+	if err != nil {
+		if isApiDisabled(err) {
+			log.Warnf("The IAP API isn't enabled in %v yet, its OAuth consent screen is left unchecked.", project)
+			return nil
+		}
+		return fmt.Errorf("couldn't list the OAuth brands of %v: %v", project, err)
+	}
+	if len(brands) == 0 {
+		return fmt.Errorf("project %v has no OAuth consent screen, which IAP needs; set it up at %v",
+			project, fmt.Sprintf(consentScreenUrl, project))
+	}
+	return nil
+}
+
+// oauthBrands returns the names of the OAuth brands of project, e.g. projects/1/brands/1. The
+// errors of the IAP API are returned as is, for isApiDisabled.
+func (gcp *Gcp) oauthBrands(project string) ([]string, error) {
+	resp, err := gcp.client.Get(fmt.Sprintf(iapBrandsUrl, project))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if err = googleapi.CheckResponse(resp); err != nil {
+		return nil, err
+	}
+	brands := struct {
+		Brands []struct {
+			Name string `json:"name"`
+		} `json:"brands"`
+	}{}
+	if err = json.NewDecoder(resp.Body).Decode(&brands); err != nil {
+		return nil, err
+	}
+	names := []string{}
+	for _, brand := range brands.Brands {
+		names = append(names, brand.Name)
+	}
+	return names, nil
+}
@@ -0,0 +1,105 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// fakeGcpApis answers the calls of InitChecker with the bodies of their paths, and 404 for
+// the others.
+type fakeGcpApis map[string]string
+
+func (f fakeGcpApis) RoundTrip(req *http.Request) (*http.Response, error) {
+	status := http.StatusOK
+	body, ok := f[req.URL.Path]
+	if !ok {
+		status = http.StatusNotFound
+		body = `{"error": {"code": 404, "message": "not found"}}`
+	} else if strings.Contains(body, `"error"`) {
+		status = http.StatusForbidden
+	}
+	return &http.Response{
+		StatusCode: status,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       ioutil.NopCloser(strings.NewReader(body)),
+		Request:    req,
+	}, nil
+}
+
+func newFakeInitChecker(apis fakeGcpApis) *InitChecker {
+	return &InitChecker{
+		gcp: &Gcp{client: &http.Client{Transport: apis}},
+	}
+}
+
+func TestInitCheckerProject(t *testing.T) {
+	c := newFakeInitChecker(fakeGcpApis{
+		"/v1/projects/my-project":  `{"projectId": "my-project", "lifecycleState": "ACTIVE"}`,
+		"/v1/projects/old-project": `{"projectId": "old-project", "lifecycleState": "DELETE_REQUESTED"}`,
+	})
+	if err := c.CheckProject("my-project"); err != nil {
+		t.Errorf("CheckProject(my-project) failed: %v", err)
+	}
+	for _, project := range []string{"old-project", "my-projcet"} {
+		if err := c.CheckProject(project); err == nil {
+			t.Errorf("CheckProject(%v) succeeded", project)
+		}
+	}
+}
+
+func TestInitCheckerZone(t *testing.T) {
+	c := newFakeInitChecker(fakeGcpApis{
+		"/compute/v1/projects/my-project/zones/us-east1-d": `{"name": "us-east1-d"}`,
+		"/compute/v1/projects/my-project/zones": `{"items": [{"name": "us-east1-d"}, {"name": "us-east1-b"},
+			{"name": "us-central1-a"}]}`,
+	})
+	if err := c.CheckZone("my-project", "us-east1-d"); err != nil {
+		t.Errorf("CheckZone(us-east1-d) failed: %v", err)
+	}
+	err := c.CheckZone("my-project", "us-east1-a")
+	if err == nil || !strings.Contains(err.Error(), "the zones of us-east1 are us-east1-b, us-east1-d") {
+		t.Errorf("CheckZone(us-east1-a) returned %v; want the zones of us-east1 suggested", err)
+	}
+
+	disabled := newFakeInitChecker(fakeGcpApis{
+		"/compute/v1/projects/new-project/zones/us-east1-a": `{"error": {"code": 403, "message": "Access Not Configured",
+			"errors": [{"reason": "accessNotConfigured"}]}}`,
+	})
+	if err = disabled.CheckZone("new-project", "us-east1-a"); err != nil {
+		t.Errorf("CheckZone failed with the compute API disabled: %v", err)
+	}
+}
+
+func TestInitCheckerOAuthBrand(t *testing.T) {
+	c := newFakeInitChecker(fakeGcpApis{
+		"/v1/projects/my-project/brands":  `{"brands": [{"name": "projects/1/brands/1"}]}`,
+		"/v1/projects/new-project/brands": `{}`,
+		"/v1/projects/off-project/brands": `{"error": {"code": 403,
+			"message": "Cloud Identity-Aware Proxy API has not been used in project off-project"}}`,
+	})
+	if err := c.CheckOAuthBrand("my-project"); err != nil {
+		t.Errorf("CheckOAuthBrand(my-project) failed: %v", err)
+	}
+	if err := c.CheckOAuthBrand("new-project"); err == nil || !strings.Contains(err.Error(), "consent") {
+		t.Errorf("CheckOAuthBrand(new-project) returned %v; want no consent screen", err)
+	}
+	if err := c.CheckOAuthBrand("off-project"); err != nil {
+		t.Errorf("CheckOAuthBrand failed with the IAP API disabled: %v", err)
+	}
+}
@@ -0,0 +1,128 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"fmt"
+	"github.com/ghodss/yaml"
+	"github.com/kubeflow/kubeflow/bootstrap/pkg/utils"
+	log "github.com/sirupsen/logrus"
+	"k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+const (
+	// INVENTORY_CONFIGMAP lists the objects kfctl applied from manifests, e.g. Istio, so
+	// kfctl delete k8s removes exactly those. It's kept in kube-system, which outlives the
+	// namespaces of the app.
+	INVENTORY_CONFIGMAP = "kfctl-inventory"
+	INVENTORY_KEY       = "resources.yaml"
+)
+
+// readInventory returns the objects recorded in the inventory, and the ConfigMap holding it,
+// nil when there's none yet.
+func readInventory(client *clientset.Clientset) ([]utils.ResourceRef, *v1.ConfigMap, error) {
+	configMap, err := client.CoreV1().ConfigMaps(metav1.NamespaceSystem).Get(INVENTORY_CONFIGMAP, metav1.GetOptions{})
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil, nil, nil
+		}
+		return nil, nil, fmt.Errorf("couldn't get the inventory %v: %v", INVENTORY_CONFIGMAP, err)
+	}
+	refs := []utils.ResourceRef{}
+	if err = yaml.Unmarshal([]byte(configMap.Data[INVENTORY_KEY]), &refs); err != nil {
+		return nil, nil, fmt.Errorf("couldn't read the inventory %v: %v", INVENTORY_CONFIGMAP, err)
+	}
+	return refs, configMap, nil
+}
+
+// recordInventory adds refs, the objects just applied, to the inventory.
+func (gcp *Gcp) recordInventory(config *rest.Config, refs []utils.ResourceRef) error {
+	client, err := clientset.NewForConfig(config)
+	if err != nil {
+		return err
+	}
+	recorded, configMap, err := readInventory(client)
+	if err != nil {
+		return err
+	}
+	buf, err := yaml.Marshal(utils.MergeResourceRefs(recorded, refs))
+	if err != nil {
+		return fmt.Errorf("couldn't marshal the inventory: %v", err)
+	}
+	if configMap == nil {
+		configMap = &v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      INVENTORY_CONFIGMAP,
+				Namespace: metav1.NamespaceSystem,
+				Labels: map[string]string{
+					LABEL_NAME:       toLabelValue(gcp.Name),
+					LABEL_CREATED_BY: CREATED_BY_KFCTL,
+				},
+			},
+			Data: map[string]string{INVENTORY_KEY: string(buf)},
+		}
+		_, err = client.CoreV1().ConfigMaps(metav1.NamespaceSystem).Create(configMap)
+	} else {
+		if configMap.Data == nil {
+			configMap.Data = map[string]string{}
+		}
+		configMap.Data[INVENTORY_KEY] = string(buf)
+		_, err = client.CoreV1().ConfigMaps(metav1.NamespaceSystem).Update(configMap)
+	}
+	if err != nil {
+		return fmt.Errorf("couldn't record the inventory %v: %v", INVENTORY_CONFIGMAP, err)
+	}
+	return nil
+}
+
+// deleteInventory deletes the objects of the inventory in reverse order and then the inventory.
+// The objects which couldn't be deleted are kept in it, so delete can be run again.
+func (gcp *Gcp) deleteInventory(config *rest.Config) error {
+	client, err := clientset.NewForConfig(config)
+	if err != nil {
+		return err
+	}
+	refs, configMap, err := readInventory(client)
+	if err != nil {
+		return err
+	}
+	if configMap == nil {
+		log.Infof("No inventory %v in %v; nothing kfctl applied to delete.", INVENTORY_CONFIGMAP,
+			metav1.NamespaceSystem)
+		return nil
+	}
+	remaining, deleteErr := utils.DeleteResources(config, refs)
+	if len(remaining) != 0 {
+		buf, err := yaml.Marshal(remaining)
+		if err != nil {
+			return fmt.Errorf("couldn't marshal the inventory: %v", err)
+		}
+		configMap.Data[INVENTORY_KEY] = string(buf)
+		if _, err = client.CoreV1().ConfigMaps(metav1.NamespaceSystem).Update(configMap); err != nil {
+			log.Warnf("couldn't update the inventory %v: %v", INVENTORY_CONFIGMAP, err)
+		}
+		return deleteErr
+	}
+	err = client.CoreV1().ConfigMaps(metav1.NamespaceSystem).Delete(INVENTORY_CONFIGMAP, &metav1.DeleteOptions{})
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return fmt.Errorf("couldn't delete the inventory %v: %v", INVENTORY_CONFIGMAP, err)
+	}
+	log.Infof("Deleted the %v objects of the inventory", len(refs))
+	return nil
+}
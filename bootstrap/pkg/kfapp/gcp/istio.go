@@ -0,0 +1,271 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/ghodss/yaml"
+	kfapis "github.com/kubeflow/kubeflow/bootstrap/pkg/apis"
+	kftypes "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps"
+	kfdefs "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps/kfdef/v1alpha1"
+	"github.com/kubeflow/kubeflow/bootstrap/pkg/kfapp/assets"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"strings"
+)
+
+const (
+	// ISTIO_MANIFEST is the manifest of the Istio control plane and gateways, the same for
+	// every profile.
+	ISTIO_MANIFEST = "dependencies/istio/install/istio-noauth.yaml"
+	// ISTIO_PROFILE_NOAUTH, ISTIO_PROFILE_MTLS_PERMISSIVE and ISTIO_PROFILE_MTLS_STRICT are the
+	// profiles of spec.istio, each applied by its manifest in ISTIO_PROFILES_DIR.
+	ISTIO_PROFILE_NOAUTH          = "noauth"
+	ISTIO_PROFILE_MTLS_PERMISSIVE = "mtls-permissive"
+	ISTIO_PROFILE_MTLS_STRICT     = "mtls-strict"
+	ISTIO_PROFILES_DIR            = "dependencies/istio/install/profiles"
+	// ISTIO_INGRESS_GATEWAY and ISTIO_EGRESS_GATEWAY name the Deployment and the
+	// HorizontalPodAutoscaler of each gateway.
+	ISTIO_INGRESS_GATEWAY = "istio-ingressgateway"
+	ISTIO_EGRESS_GATEWAY  = "istio-egressgateway"
+	// istioProxyContainer is the container of the gateways.
+	istioProxyContainer = "istio-proxy"
+)
+
+// istioMeshModes are the modes of the default MeshPolicy of each profile.
+var istioMeshModes = map[string]string{
+	ISTIO_PROFILE_NOAUTH:          "PERMISSIVE",
+	ISTIO_PROFILE_MTLS_PERMISSIVE: "PERMISSIVE",
+	ISTIO_PROFILE_MTLS_STRICT:     "STRICT",
+}
+
+// istioProfile is the profile of spec.istio, noauth when unset.
+func (gcp *Gcp) istioProfile() string {
+	if gcp.Spec.Istio == nil || gcp.Spec.Istio.Profile == "" {
+		return ISTIO_PROFILE_NOAUTH
+	}
+	return strings.ToLower(gcp.Spec.Istio.Profile)
+}
+
+// istioManifests are the Istio manifests configCluster creates in their order, by their path in
+// the kubeflow repo.
+func (gcp *Gcp) istioManifests() []string {
+	return []string{
+		"dependencies/istio/install/crds.yaml",
+		ISTIO_MANIFEST,
+		ISTIO_PROFILES_DIR + "/" + gcp.istioProfile() + ".yaml",
+		"dependencies/istio/kf-istio-resources.yaml",
+	}
+}
+
+// validateIstio checks the profile of spec.istio is known and supported by the auth provider,
+// and the sizes of its gateways.
+func (gcp *Gcp) validateIstio() error {
+	if gcp.Spec.Istio == nil {
+		return nil
+	}
+	if !gcp.Spec.UseIstio {
+		return &kfapis.KfError{
+			Code:    int(kfapis.INVALID_ARGUMENT),
+			Message: "spec.istio is set but useIstio isn't",
+		}
+	}
+	profile := gcp.istioProfile()
+	if _, ok := istioMeshModes[profile]; !ok {
+		return &kfapis.KfError{
+			Code: int(kfapis.INVALID_ARGUMENT),
+			Message: fmt.Sprintf("unknown istio profile %v; must be %v, %v or %v", gcp.Spec.Istio.Profile,
+				ISTIO_PROFILE_NOAUTH, ISTIO_PROFILE_MTLS_PERMISSIVE, ISTIO_PROFILE_MTLS_STRICT),
+		}
+	}
+	// The IAP components in the kubeflow namespace, e.g. the envoy proxies and the backend
+	// updater, and the health checks of the load balancer have no sidecar.
+	if profile == ISTIO_PROFILE_MTLS_STRICT && gcp.authProvider().Name() == kftypes.AUTH_IAP {
+		return &kfapis.KfError{
+			Code: int(kfapis.INVALID_ARGUMENT),
+			Message: fmt.Sprintf("istio profile %v isn't supported with IAP; use %v",
+				ISTIO_PROFILE_MTLS_STRICT, ISTIO_PROFILE_MTLS_PERMISSIVE),
+		}
+	}
+	gateways := map[string]*kfdefs.IstioGatewayConfig{
+		"ingressGateway": gcp.Spec.Istio.IngressGateway,
+		"egressGateway":  gcp.Spec.Istio.EgressGateway,
+	}
+	for name, gateway := range gateways {
+		if gateway == nil {
+			continue
+		}
+		if err := validateIstioGateway(gateway); err != nil {
+			return &kfapis.KfError{
+				Code:    int(kfapis.INVALID_ARGUMENT),
+				Message: fmt.Sprintf("invalid istio %v: %v", name, err),
+			}
+		}
+	}
+	return nil
+}
+
+// validateIstioGateway checks the replicas and resource requests of gateway.
+func validateIstioGateway(gateway *kfdefs.IstioGatewayConfig) error {
+	if gateway.MinReplicas < 0 || gateway.MaxReplicas < 0 {
+		return fmt.Errorf("replicas can't be negative")
+	}
+	if gateway.MaxReplicas != 0 && gateway.MaxReplicas < istioMinReplicas(gateway) {
+		return fmt.Errorf("maxReplicas %v is below minReplicas %v", gateway.MaxReplicas, istioMinReplicas(gateway))
+	}
+	for _, quantity := range []string{gateway.Cpu, gateway.Memory} {
+		if quantity == "" {
+			continue
+		}
+		if _, err := resource.ParseQuantity(quantity); err != nil {
+			return fmt.Errorf("%v: %v", quantity, err)
+		}
+	}
+	return nil
+}
+
+// istioMinReplicas is the minReplicas of gateway, 1 when unset.
+func istioMinReplicas(gateway *kfdefs.IstioGatewayConfig) int32 {
+	if gateway.MinReplicas == 0 {
+		return 1
+	}
+	return gateway.MinReplicas
+}
+
+// istioRenderLoader renders the Istio manifests for spec.istio: ISTIO_MANIFEST with the mesh
+// mode of the profile and the sizes of the gateways, and the profiles with the kubeflow
+// namespace of the app.
+type istioRenderLoader struct {
+	assets.Loader
+	meshMode  string
+	gateways  map[string]*kfdefs.IstioGatewayConfig
+	namespace string
+}
+
+func (l *istioRenderLoader) Read(name string) ([]byte, error) {
+	buf, err := l.Loader.Read(name)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case name == ISTIO_MANIFEST:
+		// The MeshPolicy of the post-install job of Istio, which would reset the profile's.
+		buf = bytes.Replace(buf, []byte("mode: PERMISSIVE"), []byte("mode: "+l.meshMode), -1)
+		return renderIstioGateways(buf, l.gateways)
+	case strings.HasPrefix(name, ISTIO_PROFILES_DIR+"/") && l.namespace != "":
+		return bytes.Replace(buf, []byte("*.kubeflow.svc.cluster.local"),
+			[]byte("*."+l.namespace+".svc.cluster.local"), -1), nil
+	}
+	return buf, nil
+}
+
+// istioLoader wraps loader with the rendering of the Istio manifests for spec.istio.
+func (gcp *Gcp) istioLoader(loader assets.Loader) assets.Loader {
+	l := &istioRenderLoader{
+		Loader:    loader,
+		meshMode:  istioMeshModes[gcp.istioProfile()],
+		gateways:  map[string]*kfdefs.IstioGatewayConfig{},
+		namespace: gcp.namespace(),
+	}
+	if l.meshMode == "" {
+		l.meshMode = istioMeshModes[ISTIO_PROFILE_NOAUTH]
+	}
+	if gcp.Spec.Istio != nil {
+		if gcp.Spec.Istio.IngressGateway != nil {
+			l.gateways[ISTIO_INGRESS_GATEWAY] = gcp.Spec.Istio.IngressGateway
+		}
+		if gcp.Spec.Istio.EgressGateway != nil {
+			l.gateways[ISTIO_EGRESS_GATEWAY] = gcp.Spec.Istio.EgressGateway
+		}
+	}
+	return l
+}
+
+// renderIstioGateways sets the replicas and resource requests of the Deployments and the bounds
+// of the HorizontalPodAutoscalers of gateways in manifest. The other objects are kept as is.
+func renderIstioGateways(manifest []byte, gateways map[string]*kfdefs.IstioGatewayConfig) ([]byte, error) {
+	if len(gateways) == 0 {
+		return manifest, nil
+	}
+	separator := []byte("\n---\n")
+	objects := bytes.Split(manifest, separator)
+	for i, object := range objects {
+		var o map[string]interface{}
+		if err := yaml.Unmarshal(object, &o); err != nil || o == nil {
+			continue
+		}
+		metadata, _ := o["metadata"].(map[string]interface{})
+		name, _ := metadata["name"].(string)
+		gateway, ok := gateways[name]
+		if !ok {
+			continue
+		}
+		spec, _ := o["spec"].(map[string]interface{})
+		if spec == nil {
+			continue
+		}
+		switch o["kind"] {
+		case "Deployment":
+			spec["replicas"] = istioMinReplicas(gateway)
+			setIstioProxyRequests(spec, gateway)
+		case "HorizontalPodAutoscaler":
+			spec["minReplicas"] = istioMinReplicas(gateway)
+			if gateway.MaxReplicas != 0 {
+				spec["maxReplicas"] = gateway.MaxReplicas
+			}
+		default:
+			continue
+		}
+		buf, err := yaml.Marshal(o)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't render %v %v: %v", o["kind"], name, err)
+		}
+		objects[i] = bytes.TrimSuffix(buf, []byte("\n"))
+	}
+	return bytes.Join(objects, separator), nil
+}
+
+// setIstioProxyRequests sets the resource requests of the istio-proxy container of the
+// Deployment spec.
+func setIstioProxyRequests(spec map[string]interface{}, gateway *kfdefs.IstioGatewayConfig) {
+	if gateway.Cpu == "" && gateway.Memory == "" {
+		return
+	}
+	template, _ := spec["template"].(map[string]interface{})
+	podSpec, _ := template["spec"].(map[string]interface{})
+	containers, _ := podSpec["containers"].([]interface{})
+	for _, c := range containers {
+		container, _ := c.(map[string]interface{})
+		if container == nil || container["name"] != istioProxyContainer {
+			continue
+		}
+		resources, _ := container["resources"].(map[string]interface{})
+		if resources == nil {
+			resources = map[string]interface{}{}
+			container["resources"] = resources
+		}
+		requests, _ := resources["requests"].(map[string]interface{})
+		if requests == nil {
+			requests = map[string]interface{}{}
+			resources["requests"] = requests
+		}
+		if gateway.Cpu != "" {
+			requests["cpu"] = gateway.Cpu
+		}
+		if gateway.Memory != "" {
+			requests["memory"] = gateway.Memory
+		}
+	}
+}
@@ -0,0 +1,92 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"bytes"
+	"testing"
+
+	kfdefs "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps/kfdef/v1alpha1"
+)
+
+func TestValidateIstio(t *testing.T) {
+	cases := []struct {
+		istio        *kfdefs.IstioConfig
+		useBasicAuth bool
+		valid        bool
+	}{
+		{nil, false, true},
+		{&kfdefs.IstioConfig{Profile: ISTIO_PROFILE_MTLS_PERMISSIVE}, false, true},
+		{&kfdefs.IstioConfig{Profile: ISTIO_PROFILE_MTLS_STRICT}, false, false},
+		{&kfdefs.IstioConfig{Profile: ISTIO_PROFILE_MTLS_STRICT}, true, true},
+		{&kfdefs.IstioConfig{Profile: "auth"}, true, false},
+		{&kfdefs.IstioConfig{IngressGateway: &kfdefs.IstioGatewayConfig{MinReplicas: 2, MaxReplicas: 4,
+			Cpu: "500m", Memory: "256Mi"}}, false, true},
+		{&kfdefs.IstioConfig{IngressGateway: &kfdefs.IstioGatewayConfig{MinReplicas: 3, MaxReplicas: 2}}, false, false},
+		{&kfdefs.IstioConfig{EgressGateway: &kfdefs.IstioGatewayConfig{Cpu: "half"}}, false, false},
+	}
+	for _, c := range cases {
+		gcp := &Gcp{}
+		gcp.Spec.UseIstio = true
+		gcp.Spec.UseBasicAuth = c.useBasicAuth
+		gcp.Spec.Istio = c.istio
+		err := gcp.validateIstio()
+		if c.valid && err != nil {
+			t.Errorf("validateIstio(%+v, basic auth %v) failed: %v", c.istio, c.useBasicAuth, err)
+		}
+		if !c.valid && err == nil {
+			t.Errorf("validateIstio(%+v, basic auth %v) succeeded", c.istio, c.useBasicAuth)
+		}
+	}
+}
+
+func TestIstioLoader(t *testing.T) {
+	gcp := &Gcp{}
+	gcp.Namespace = "kubeflow"
+	gcp.Spec.UseEmbeddedAssets = true
+	gcp.Spec.UseIstio = true
+	gcp.Spec.NamespacePrefix = "team"
+	gcp.Spec.Istio = &kfdefs.IstioConfig{
+		Profile:        ISTIO_PROFILE_MTLS_STRICT,
+		IngressGateway: &kfdefs.IstioGatewayConfig{MinReplicas: 2, MaxReplicas: 6, Cpu: "500m"},
+	}
+	manifests := gcp.istioManifests()
+	if manifests[2] != ISTIO_PROFILES_DIR+"/mtls-strict.yaml" {
+		t.Errorf("got profile manifest %v", manifests[2])
+	}
+
+	buf, err := gcp.manifestLoader().Read(ISTIO_MANIFEST)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range []string{"mode: STRICT", "replicas: 2", "maxReplicas: 6", "minReplicas: 2", "cpu: 500m"} {
+		if !bytes.Contains(buf, []byte(s)) {
+			t.Errorf("%v isn't in the rendered manifest", s)
+		}
+	}
+	if bytes.Contains(buf, []byte("mode: PERMISSIVE")) {
+		t.Errorf("the MeshPolicy of the manifest is still permissive")
+	}
+
+	buf, err = gcp.manifestLoader().Read(manifests[2])
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range []string{"*.team-kubeflow.svc.cluster.local", "namespace: team-istio-system"} {
+		if !bytes.Contains(buf, []byte(s)) {
+			t.Errorf("%v isn't in the profile manifest", s)
+		}
+	}
+}
@@ -0,0 +1,242 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/ghodss/yaml"
+	kftypes "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+	"google.golang.org/api/compute/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"path"
+	"strings"
+)
+
+const (
+	// ISTIO_IAP_INGRESS_FILE is the manifest in K8S_SPECS of the objects putting
+	// istio-ingressgateway behind the GKE ingress and IAP with useIstio.
+	ISTIO_IAP_INGRESS_FILE = "istio-iap-ingress.yaml"
+	// IAP_BACKEND_CONFIG enables IAP on the backend service of the ingress, with the OAuth client
+	// of KUBEFLOW_OAUTH.
+	IAP_BACKEND_CONFIG = "iap-backendconfig"
+	// BACKEND_CONFIG_ANNOTATION maps the ports of a service to their BackendConfig.
+	BACKEND_CONFIG_ANNOTATION = "beta.cloud.google.com/backend-config"
+	// KUBEFLOW_GATEWAY is the Istio Gateway of istio-ingressgateway serving the app.
+	KUBEFLOW_GATEWAY = "kubeflow-gateway"
+	// ISTIO_DEFAULT_ROUTES is the VirtualService routing the health checks of the load balancer
+	// and the requests of the app through KUBEFLOW_GATEWAY.
+	ISTIO_DEFAULT_ROUTES = "default-routes"
+	// IAP_HEALTH_CHECK_PATH is answered by whoami-app, so the load balancer's health checks of the
+	// gateway don't depend on the components behind ambassador.
+	IAP_HEALTH_CHECK_PATH = "/healthz"
+	// istioIngressPortName is the port of istio-ingressgateway backing the ingress.
+	istioIngressPortName = "http2"
+)
+
+// istioIapIngressObjects are the objects GKE ingress and IAP need in front of
+// istio-ingressgateway: the BackendConfig enabling IAP, and the Gateway and VirtualService
+// routing the health checks to whoami-app and the rest to ambassador.
+func (gcp *Gcp) istioIapIngressObjects() []interface{} {
+	namespace := gcp.namespace()
+	destination := func(host string) []interface{} {
+		return []interface{}{
+			map[string]interface{}{
+				"destination": map[string]interface{}{
+					"host": fmt.Sprintf("%v.%v.svc.cluster.local", host, namespace),
+					"port": map[string]interface{}{"number": 80},
+				},
+			},
+		}
+	}
+	return []interface{}{
+		map[string]interface{}{
+			"apiVersion": "cloud.google.com/v1beta1",
+			"kind":       "BackendConfig",
+			"metadata": map[string]interface{}{
+				"name":      IAP_BACKEND_CONFIG,
+				"namespace": gcp.istioNamespace(),
+			},
+			"spec": map[string]interface{}{
+				"iap": map[string]interface{}{
+					"enabled": true,
+					"oauthclientCredentials": map[string]interface{}{
+						"secretName": KUBEFLOW_OAUTH,
+					},
+				},
+			},
+		},
+		map[string]interface{}{
+			"apiVersion": "networking.istio.io/v1alpha3",
+			"kind":       "Gateway",
+			"metadata": map[string]interface{}{
+				"name":      KUBEFLOW_GATEWAY,
+				"namespace": namespace,
+			},
+			"spec": map[string]interface{}{
+				"selector": map[string]interface{}{"istio": "ingressgateway"},
+				"servers": []interface{}{
+					map[string]interface{}{
+						"port": map[string]interface{}{
+							"number":   80,
+							"name":     "http",
+							"protocol": "HTTP",
+						},
+						"hosts": []interface{}{"*"},
+					},
+				},
+			},
+		},
+		map[string]interface{}{
+			"apiVersion": "networking.istio.io/v1alpha3",
+			"kind":       "VirtualService",
+			"metadata": map[string]interface{}{
+				"name":      ISTIO_DEFAULT_ROUTES,
+				"namespace": namespace,
+			},
+			"spec": map[string]interface{}{
+				"hosts":    []interface{}{"*"},
+				"gateways": []interface{}{KUBEFLOW_GATEWAY},
+				"http": []interface{}{
+					map[string]interface{}{
+						"match": []interface{}{map[string]interface{}{
+							"uri": map[string]interface{}{"exact": IAP_HEALTH_CHECK_PATH},
+						}},
+						"route": destination("whoami-app"),
+					},
+					map[string]interface{}{
+						"match": []interface{}{map[string]interface{}{
+							"uri": map[string]interface{}{"exact": "/whoami"},
+						}},
+						"route": destination("whoami-app"),
+					},
+					map[string]interface{}{
+						"match": []interface{}{map[string]interface{}{
+							"uri": map[string]interface{}{"prefix": "/"},
+						}},
+						"route": destination("ambassador"),
+					},
+				},
+			},
+		},
+	}
+}
+
+// istioIapIngressManifest is the manifest of istioIapIngressObjects.
+func (gcp *Gcp) istioIapIngressManifest() ([]byte, error) {
+	var buf bytes.Buffer
+	for i, object := range gcp.istioIapIngressObjects() {
+		data, err := yaml.Marshal(object)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't marshal the Istio IAP ingress manifest: %v", err)
+		}
+		if i > 0 {
+			buf.WriteString("---\n")
+		}
+		buf.Write(data)
+	}
+	return buf.Bytes(), nil
+}
+
+// putIstioIapIngressManifest puts the manifest of the Istio IAP ingress in bundle when useIstio
+// is set with IAP, so the routes can be edited before apply.
+func (gcp *Gcp) putIstioIapIngressManifest(bundle *Bundle) error {
+	if !gcp.useIstioIapIngress() {
+		return nil
+	}
+	manifest, err := gcp.istioIapIngressManifest()
+	if err != nil {
+		return err
+	}
+	bundle.Put(path.Join(K8S_SPECS, ISTIO_IAP_INGRESS_FILE), manifest)
+	return nil
+}
+
+// useIstioIapIngress is whether IAP is in front of istio-ingressgateway.
+func (gcp *Gcp) useIstioIapIngress() bool {
+	return gcp.Spec.UseIstio && gcp.authProvider().Name() == kftypes.AUTH_IAP
+}
+
+// installIstioIapIngress applies the Istio IAP ingress manifest of the app dir and points the
+// serving port of istio-ingressgateway at IAP_BACKEND_CONFIG.
+func (gcp *Gcp) installIstioIapIngress(ctx context.Context, client *rest.Config) error {
+	name := path.Join(K8S_SPECS, ISTIO_IAP_INGRESS_FILE)
+	manifest, err := gcp.store.ReadFile(name)
+	if err != nil {
+		return fmt.Errorf("couldn't read %v; run kfctl generate first: %v", name, err)
+	}
+	if err = gcp.createResources(ctx, client, name, manifest); err != nil {
+		return err
+	}
+	k8sClient, err := clientset.NewForConfig(client)
+	if err != nil {
+		return fmt.Errorf("Get K8s clientset error: %v", err)
+	}
+	return gcp.setIstioBackendConfig(k8sClient)
+}
+
+// setIstioBackendConfig annotates istio-ingressgateway with IAP_BACKEND_CONFIG for its serving
+// port, which the Istio manifest may have been edited without.
+func (gcp *Gcp) setIstioBackendConfig(k8sClient *clientset.Clientset) error {
+	namespace := gcp.istioNamespace()
+	svc, err := k8sClient.CoreV1().Services(namespace).Get(ISTIO_INGRESS_GATEWAY, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("couldn't get service %v/%v: %v", namespace, ISTIO_INGRESS_GATEWAY, err)
+	}
+	annotation := fmt.Sprintf(`{"ports": {"%v":"%v"}}`, istioIngressPortName, IAP_BACKEND_CONFIG)
+	if svc.Annotations[BACKEND_CONFIG_ANNOTATION] == annotation {
+		return nil
+	}
+	if svc.Annotations == nil {
+		svc.Annotations = map[string]string{}
+	}
+	svc.Annotations[BACKEND_CONFIG_ANNOTATION] = annotation
+	log.Infof("Setting the BackendConfig of %v/%v to %v", namespace, ISTIO_INGRESS_GATEWAY, IAP_BACKEND_CONFIG)
+	if _, err = k8sClient.CoreV1().Services(namespace).Update(svc); err != nil {
+		return fmt.Errorf("couldn't update service %v/%v: %v", namespace, ISTIO_INGRESS_GATEWAY, err)
+	}
+	return nil
+}
+
+// setIapHealthCheck points the health check of backend, created by the ingress for
+// istio-ingressgateway, at IAP_HEALTH_CHECK_PATH. The ingress can't infer it from the readiness
+// probe of the gateway, which is on its status port, and checks / otherwise.
+func (gcp *Gcp) setIapHealthCheck(ctx context.Context, computeService *compute.Service,
+	backend *compute.BackendService) error {
+	for _, url := range backend.HealthChecks {
+		name := url[strings.LastIndex(url, "/")+1:]
+		check, err := computeService.HealthChecks.Get(gcp.Spec.Project, name).Context(ctx).Do()
+		if err != nil {
+			return fmt.Errorf("couldn't get health check %v: %v", name, err)
+		}
+		if check.HttpHealthCheck == nil || check.HttpHealthCheck.RequestPath == IAP_HEALTH_CHECK_PATH {
+			continue
+		}
+		log.Infof("Setting the request path of health check %v to %v", name, IAP_HEALTH_CHECK_PATH)
+		httpCheck := *check.HttpHealthCheck
+		httpCheck.RequestPath = IAP_HEALTH_CHECK_PATH
+		_, err = computeService.HealthChecks.Patch(gcp.Spec.Project, name, &compute.HealthCheck{
+			HttpHealthCheck: &httpCheck,
+		}).Context(ctx).Do()
+		if err != nil {
+			return fmt.Errorf("couldn't update health check %v: %v", name, err)
+		}
+	}
+	return nil
+}
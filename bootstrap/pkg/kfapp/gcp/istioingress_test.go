@@ -0,0 +1,73 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"path"
+	"reflect"
+	"strings"
+	"testing"
+
+	kftypes "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps"
+	kfdefs "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps/kfdef/v1alpha1"
+	"github.com/kubeflow/kubeflow/bootstrap/pkg/utils"
+)
+
+func TestIstioIapIngressManifest(t *testing.T) {
+	gcp := &Gcp{}
+	gcp.Namespace = "kubeflow"
+	gcp.Spec.NamespacePrefix = "team"
+
+	bundle := NewBundle()
+	if err := gcp.putIstioIapIngressManifest(bundle); err != nil {
+		t.Fatalf("putIstioIapIngressManifest failed: %v", err)
+	}
+	if _, ok := bundle.Get(path.Join(K8S_SPECS, ISTIO_IAP_INGRESS_FILE)); ok {
+		t.Errorf("putIstioIapIngressManifest put %v without useIstio", ISTIO_IAP_INGRESS_FILE)
+	}
+
+	gcp.Spec.UseIstio = true
+	if err := gcp.putIstioIapIngressManifest(bundle); err != nil {
+		t.Fatalf("putIstioIapIngressManifest failed: %v", err)
+	}
+	manifest, ok := bundle.Get(path.Join(K8S_SPECS, ISTIO_IAP_INGRESS_FILE))
+	if !ok {
+		t.Fatalf("putIstioIapIngressManifest didn't put %v", ISTIO_IAP_INGRESS_FILE)
+	}
+	refs, err := utils.ResourceRefs(manifest)
+	if err != nil {
+		t.Fatalf("ResourceRefs failed: %v", err)
+	}
+	objects := []string{}
+	for _, ref := range refs {
+		objects = append(objects, ref.Kind+"/"+ref.Namespace+"/"+ref.Name)
+	}
+	expected := []string{"BackendConfig/team-istio-system/iap-backendconfig",
+		"Gateway/team-kubeflow/kubeflow-gateway", "VirtualService/team-kubeflow/default-routes"}
+	if !reflect.DeepEqual(objects, expected) {
+		t.Errorf("Istio IAP ingress manifest has %v; want %v", objects, expected)
+	}
+	for _, s := range []string{KUBEFLOW_OAUTH, "whoami-app.team-kubeflow.svc.cluster.local",
+		"ambassador.team-kubeflow.svc.cluster.local", IAP_HEALTH_CHECK_PATH} {
+		if !strings.Contains(string(manifest), s) {
+			t.Errorf("Istio IAP ingress manifest doesn't mention %v", s)
+		}
+	}
+
+	gcp.Spec.Auth = &kfdefs.AuthConfig{Provider: kftypes.AUTH_BASIC_AUTH}
+	if gcp.useIstioIapIngress() {
+		t.Errorf("useIstioIapIngress with basic auth = true")
+	}
+}
@@ -0,0 +1,136 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	kftypes "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps"
+	log "github.com/sirupsen/logrus"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"sort"
+)
+
+// deleteK8sResources deletes what kfctl apply created in the cluster besides the components:
+// the ClusterRoleBinding of the admin, the credentials kfctl created as secrets, the objects of
+// the inventory, e.g. Istio, and, unless spec.preserveData, the namespace of the app with its
+// persistent volume claims. The objects already gone are skipped, so it can be run again.
+func (gcp *Gcp) deleteK8sResources(config *rest.Config) error {
+	client, err := clientset.NewForConfig(config)
+	if err != nil {
+		return err
+	}
+	if err = gcp.deleteAdminRbac(client); err != nil {
+		return err
+	}
+	if err = gcp.deleteCredentialSecrets(client); err != nil {
+		return err
+	}
+	if err = gcp.deleteInventory(config); err != nil {
+		return err
+	}
+	if gcp.Spec.PreserveData {
+		log.Infof("Keeping namespace %v and its persistent volume claims", gcp.namespace())
+		return nil
+	}
+	return gcp.deleteNamespace(client, gcp.namespace())
+}
+
+// deleteAdminRbac deletes the ClusterRoleBinding of the admin and, with the kubeflow-admin role,
+// the ClusterRoles kfctl created for it. The roles are shared by the apps of the cluster with a
+// namespace prefix, so they're kept then.
+func (gcp *Gcp) deleteAdminRbac(client *clientset.Clientset) error {
+	name := gcp.adminBinding()
+	err := client.RbacV1().ClusterRoleBindings().Delete(name, &metav1.DeleteOptions{})
+	if !k8serrors.IsNotFound(err) {
+		gcp.audit(AUDIT_RBAC_DELETE, "clusterrolebindings/"+name, nil, err)
+		if err != nil {
+			return err
+		}
+		log.Infof("Deleted ClusterRoleBinding %v", name)
+	}
+	if err = gcp.bindAdminNamespaces(client, nil); err != nil {
+		return err
+	}
+	if kftypes.AdminRole(&gcp.Spec) != kftypes.ADMIN_ROLE_KUBEFLOW_ADMIN || gcp.Spec.NamespacePrefix != "" {
+		return nil
+	}
+	for _, role := range append(kubeflowAdminRoles(), kubeflowAdminNamespaceRole()) {
+		err := client.RbacV1().ClusterRoles().Delete(role.Name, &metav1.DeleteOptions{})
+		if k8serrors.IsNotFound(err) {
+			continue
+		}
+		gcp.audit(AUDIT_RBAC_DELETE, "clusterroles/"+role.Name, nil, err)
+		if err != nil {
+			return err
+		}
+		log.Infof("Deleted ClusterRole %v", role.Name)
+	}
+	return nil
+}
+
+// credentialSecrets are the secrets holding the service account keys and the OAuth client secret
+// kfctl created, by namespace. Those of the namespace of the app go with it, so they're only
+// listed when it's kept with spec.preserveData.
+func (gcp *Gcp) credentialSecrets() map[string][]string {
+	secrets := map[string][]string{}
+	for _, namespace := range gcp.serviceAcctSecretNamespaces() {
+		secrets[namespace] = append(secrets[namespace], ADMIN_SECRET_NAME, USER_SECRET_NAME)
+	}
+	secrets[gcp.oauthSecretNamespace()] = append(secrets[gcp.oauthSecretNamespace()], KUBEFLOW_OAUTH)
+	if !gcp.Spec.PreserveData {
+		delete(secrets, gcp.namespace())
+	}
+	return secrets
+}
+
+// deleteCredentialSecrets deletes the credentialSecrets.
+func (gcp *Gcp) deleteCredentialSecrets(client *clientset.Clientset) error {
+	secrets := gcp.credentialSecrets()
+	namespaces := []string{}
+	for namespace := range secrets {
+		namespaces = append(namespaces, namespace)
+	}
+	sort.Strings(namespaces)
+	for _, namespace := range namespaces {
+		for _, name := range secrets[namespace] {
+			err := client.CoreV1().Secrets(namespace).Delete(name, &metav1.DeleteOptions{})
+			if k8serrors.IsNotFound(err) {
+				continue
+			}
+			gcp.audit(AUDIT_SECRET_DELETE, "secrets/"+namespace+"/"+name, nil, err)
+			if err != nil {
+				return err
+			}
+			log.Infof("Deleted secret %v of namespace %v", name, namespace)
+		}
+	}
+	return nil
+}
+
+// deleteNamespace deletes namespace with the objects in it.
+func (gcp *Gcp) deleteNamespace(client *clientset.Clientset, namespace string) error {
+	err := client.CoreV1().Namespaces().Delete(namespace, &metav1.DeleteOptions{})
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+	gcp.audit(AUDIT_NAMESPACE_DELETE, "namespaces/"+namespace, nil, err)
+	if err != nil {
+		return err
+	}
+	log.Infof("Deleting namespace %v", namespace)
+	return nil
+}
@@ -0,0 +1,49 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCredentialSecrets(t *testing.T) {
+	cases := []struct {
+		useIstio     bool
+		preserveData bool
+		expected     map[string][]string
+	}{
+		{true, false, map[string][]string{
+			"istio-system": {ADMIN_SECRET_NAME, USER_SECRET_NAME, KUBEFLOW_OAUTH},
+		}},
+		{true, true, map[string][]string{
+			"kubeflow":     {ADMIN_SECRET_NAME, USER_SECRET_NAME},
+			"istio-system": {ADMIN_SECRET_NAME, USER_SECRET_NAME, KUBEFLOW_OAUTH},
+		}},
+		{false, false, map[string][]string{}},
+		{false, true, map[string][]string{
+			"kubeflow": {ADMIN_SECRET_NAME, USER_SECRET_NAME, KUBEFLOW_OAUTH},
+		}},
+	}
+	for i, c := range cases {
+		gcp := &Gcp{}
+		gcp.Namespace = "kubeflow"
+		gcp.Spec.UseIstio = c.useIstio
+		gcp.Spec.PreserveData = c.preserveData
+		if secrets := gcp.credentialSecrets(); !reflect.DeepEqual(secrets, c.expected) {
+			t.Errorf("case %v: credentialSecrets = %v; want %v", i, secrets, c.expected)
+		}
+	}
+}
@@ -0,0 +1,141 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"fmt"
+	kfapis "github.com/kubeflow/kubeflow/bootstrap/pkg/apis"
+	kftypes "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+	"google.golang.org/api/iam/v1"
+	"strings"
+)
+
+// SA_KEY_QUOTA is the number of user managed keys a service account can have; creating one
+// more fails with a 429.
+const SA_KEY_QUOTA = 10
+
+// checkKeyQuota makes room for a new key of the service account name when it has SA_KEY_QUOTA
+// keys: the oldest key recorded in spec.serviceAccountKeys is deleted with --rotate-sa-keys,
+// otherwise an error tells how to free one.
+func (gcp *Gcp) checkKeyQuota(ctx context.Context, iamService IamClient, name string) error {
+	keys, err := iamService.ListServiceAccountKeys(ctx, name, "USER_MANAGED")
+	if err != nil {
+		return fmt.Errorf("couldn't list the keys of %v: %v", name, err)
+	}
+	gcp.forgetDeletedKeys(name, keys)
+	if len(keys) < SA_KEY_QUOTA {
+		return nil
+	}
+	var oldest *iam.ServiceAccountKey
+	for _, key := range keys {
+		if !gcp.isRecordedKey(key.Name) {
+			continue
+		}
+		// ValidAfterTime is RFC3339 in UTC, ordered like the times.
+		if oldest == nil || key.ValidAfterTime < oldest.ValidAfterTime {
+			oldest = key
+		}
+	}
+	if !gcp.Spec.RotateSaKeys {
+		hint := "delete the unused ones in the console"
+		if oldest != nil {
+			hint += fmt.Sprintf(" or run apply with --%v to delete the oldest key created by kfctl, %v",
+				kftypes.ROTATE_SA_KEYS, oldest.Name)
+		}
+		return &kfapis.KfError{
+			Code: int(kfapis.INVALID_ARGUMENT),
+			Message: fmt.Sprintf("%v has %v keys, the most a service account can have, and its secret is missing; %v",
+				name, len(keys), hint),
+		}
+	}
+	if oldest == nil {
+		return &kfapis.KfError{
+			Code: int(kfapis.INVALID_ARGUMENT),
+			Message: fmt.Sprintf("%v has %v keys and none was created by kfctl; delete the unused ones in the console",
+				name, len(keys)),
+		}
+	}
+	log.Warnf("Deleting key %v created on %v to make room for a new one", oldest.Name, oldest.ValidAfterTime)
+	if err = iamService.DeleteServiceAccountKey(ctx, oldest.Name); err != nil {
+		return fmt.Errorf("couldn't delete key %v: %v", oldest.Name, err)
+	}
+	gcp.forgetKey(oldest.Name)
+	return nil
+}
+
+func (gcp *Gcp) isRecordedKey(keyName string) bool {
+	gcp.specLock.Lock()
+	defer gcp.specLock.Unlock()
+	for _, recorded := range gcp.Spec.ServiceAccountKeys {
+		if recorded == keyName {
+			return true
+		}
+	}
+	return false
+}
+
+// recordServiceAccountKey adds keyName to spec.serviceAccountKeys.
+func (gcp *Gcp) recordServiceAccountKey(keyName string) {
+	gcp.specLock.Lock()
+	defer gcp.specLock.Unlock()
+	gcp.Spec.ServiceAccountKeys = append(gcp.Spec.ServiceAccountKeys, keyName)
+}
+
+func (gcp *Gcp) forgetKey(keyName string) {
+	gcp.specLock.Lock()
+	defer gcp.specLock.Unlock()
+	kept := []string{}
+	for _, recorded := range gcp.Spec.ServiceAccountKeys {
+		if recorded != keyName {
+			kept = append(kept, recorded)
+		}
+	}
+	if len(kept) == 0 {
+		kept = nil
+	}
+	gcp.Spec.ServiceAccountKeys = kept
+}
+
+// forgetDeletedKeys drops the recorded keys of the service account name which aren't in keys
+// anymore, e.g. deleted in the console.
+func (gcp *Gcp) forgetDeletedKeys(name string, keys []*iam.ServiceAccountKey) {
+	existing := map[string]bool{}
+	for _, key := range keys {
+		existing[key.Name] = true
+	}
+	gcp.specLock.Lock()
+	recorded := append([]string{}, gcp.Spec.ServiceAccountKeys...)
+	gcp.specLock.Unlock()
+	prefix := name + "/keys/"
+	for _, keyName := range recorded {
+		if strings.HasPrefix(keyName, prefix) && !existing[keyName] {
+			gcp.forgetKey(keyName)
+		}
+	}
+}
+
+// writeServiceAccountKeys saves the keys recorded while creating the secrets to app.yaml, whether
+// or not the creation, which err is the error of, succeeded.
+func (gcp *Gcp) writeServiceAccountKeys(err error) error {
+	if !gcp.isCLI {
+		return err
+	}
+	if writeErr := gcp.writeConfigFile(); writeErr != nil && err == nil {
+		return fmt.Errorf("couldn't record the service account keys in %v: %v", kftypes.KfConfigFile, writeErr)
+	}
+	return err
+}
@@ -0,0 +1,90 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/kubeflow/kubeflow/bootstrap/pkg/kfapp/gcp/fake"
+	"golang.org/x/net/context"
+	"google.golang.org/api/iam/v1"
+)
+
+const testAccount = "projects/my-project/serviceAccounts/kf-user@my-project.iam.gserviceaccount.com"
+
+func testAccountKeys(n int) []*iam.ServiceAccountKey {
+	keys := []*iam.ServiceAccountKey{}
+	for i := 0; i < n; i++ {
+		keys = append(keys, &iam.ServiceAccountKey{
+			Name:           fmt.Sprintf("%v/keys/%v", testAccount, i),
+			ValidAfterTime: fmt.Sprintf("2019-04-01T00:00:%02dZ", i),
+		})
+	}
+	return keys
+}
+
+func TestCheckKeyQuotaBelowQuota(t *testing.T) {
+	iamClient := fake.NewIam(testAccountKeys(SA_KEY_QUOTA - 1)...)
+	gcp := &Gcp{}
+	gcp.Spec.RotateSaKeys = true
+	gcp.Spec.ServiceAccountKeys = []string{testAccount + "/keys/0"}
+	if err := gcp.checkKeyQuota(context.Background(), iamClient, testAccount); err != nil {
+		t.Fatalf("checkKeyQuota: %v", err)
+	}
+	if keys := iamClient.Keys(); len(keys) != SA_KEY_QUOTA-1 {
+		t.Errorf("checkKeyQuota deleted a key below the quota; %v are left", len(keys))
+	}
+}
+
+func TestCheckKeyQuotaWithoutKfctlKeys(t *testing.T) {
+	iamClient := fake.NewIam(testAccountKeys(SA_KEY_QUOTA)...)
+	gcp := &Gcp{}
+	gcp.Spec.RotateSaKeys = true
+	// The recorded key of another service account isn't deleted.
+	other := "projects/my-project/serviceAccounts/kf-admin@my-project.iam.gserviceaccount.com/keys/0"
+	gcp.Spec.ServiceAccountKeys = []string{other}
+	if err := gcp.checkKeyQuota(context.Background(), iamClient, testAccount); err == nil {
+		t.Errorf("checkKeyQuota should fail when no key was created by kfctl")
+	}
+	if keys := iamClient.Keys(); len(keys) != SA_KEY_QUOTA {
+		t.Errorf("checkKeyQuota deleted a key not created by kfctl")
+	}
+	if expected := []string{other}; !reflect.DeepEqual(gcp.Spec.ServiceAccountKeys, expected) {
+		t.Errorf("recorded keys: got %v; want %v", gcp.Spec.ServiceAccountKeys, expected)
+	}
+}
+
+func TestForgetDeletedKeys(t *testing.T) {
+	gcp := &Gcp{}
+	other := "projects/my-project/serviceAccounts/kf-admin@my-project.iam.gserviceaccount.com/keys/7"
+	gcp.Spec.ServiceAccountKeys = []string{testAccount + "/keys/1", testAccount + "/keys/9", other}
+	gcp.forgetDeletedKeys(testAccount, testAccountKeys(3))
+	if expected := []string{testAccount + "/keys/1", other}; !reflect.DeepEqual(gcp.Spec.ServiceAccountKeys, expected) {
+		t.Errorf("forgetDeletedKeys kept %v; want %v", gcp.Spec.ServiceAccountKeys, expected)
+	}
+
+	gcp.recordServiceAccountKey(testAccount + "/keys/2")
+	gcp.forgetKey(testAccount + "/keys/1")
+	gcp.forgetKey(other)
+	if expected := []string{testAccount + "/keys/2"}; !reflect.DeepEqual(gcp.Spec.ServiceAccountKeys, expected) {
+		t.Errorf("recorded keys: got %v; want %v", gcp.Spec.ServiceAccountKeys, expected)
+	}
+	gcp.forgetKey(testAccount + "/keys/2")
+	if gcp.Spec.ServiceAccountKeys != nil {
+		t.Errorf("forgetKey left %v", gcp.Spec.ServiceAccountKeys)
+	}
+}
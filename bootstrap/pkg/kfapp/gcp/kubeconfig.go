@@ -0,0 +1,153 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"encoding/base64"
+	"fmt"
+	"github.com/cenkalti/backoff"
+	kfapis "github.com/kubeflow/kubeflow/bootstrap/pkg/apis"
+	kftypes "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps"
+	"github.com/kubeflow/kubeflow/bootstrap/pkg/utils"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+	containerpb "google.golang.org/genproto/googleapis/container/v1"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// kubeconfigTimeout bounds the wait for the endpoint of a cluster still being created.
+const kubeconfigTimeout = 10 * time.Minute
+
+// kubeconfigName is the name of the cluster and user of the app in KUBECONFIG, the one gcloud
+// gives them.
+func (gcp *Gcp) kubeconfigName() string {
+	name := strings.Replace(KUBECONFIG_FORMAT, "{project}", gcp.Spec.Project, 1)
+	name = strings.Replace(name, "{zone}", gcp.Spec.Zone, 1)
+	return strings.Replace(name, "{cluster}", gcp.Name, 1)
+}
+
+// WriteKubeconfig adds the cluster of the app to KUBECONFIG, like gcloud container clusters
+// get-credentials without gcloud, with a context named after the app made current. The user
+// is authenticated by the gcp auth provider of kubectl, which refreshes its access token with
+// gcloud when it's installed or the application default credentials otherwise, so the context
+// doesn't expire after an hour like a static token. The cluster is looked up until it has an
+// endpoint, and KUBECONFIG is replaced at once, so an interrupted run leaves it as it was.
+// With spec.kubeconfig or spec.kubeContext, e.g. where the GKE API is blocked, the user
+// supplied context is left as is.
+func (gcp *Gcp) WriteKubeconfig() error {
+	if gcp.useKubeconfig() {
+		log.Infof("The cluster is reached through the context %v of %v, KUBECONFIG is left as is",
+			gcp.Spec.KubeContext, gcp.Spec.Kubeconfig)
+		return nil
+	}
+	ctx := context.Background()
+	opts, err := utils.GrpcClientOptions(gcp.Spec.CaBundle)
+	if err != nil {
+		return err
+	}
+	var cluster *containerpb.Cluster
+	b := backoff.NewExponentialBackOff()
+	b.MaxElapsedTime = kubeconfigTimeout
+	err = gcp.retry(func() error {
+		c, err := utils.GetClusterInfo(ctx, gcp.Spec.Project, gcp.Spec.Zone, gcp.Name, gcp.tokenSource, opts...)
+		if err != nil {
+			return fmt.Errorf("get Cluster error: %v", err)
+		}
+		if c.Endpoint == "" || c.MasterAuth == nil {
+			return fmt.Errorf("cluster %v has no endpoint yet; status: %v", gcp.Name, c.Status)
+		}
+		cluster = c
+		return nil
+	}, b)
+	if err != nil {
+		return err
+	}
+	gcloudPath := ""
+	if path, err := utils.LookPath("gcloud"); err == nil {
+		gcloudPath = path
+	} else {
+		log.Infof("gcloud isn't available, kubectl will use the application default credentials: %v", err)
+	}
+	if err = gcp.writeKubeconfigFile(kftypes.KubeConfigPath(), cluster, gcloudPath); err != nil {
+		return &kfapis.KfError{
+			Code:    int(kfapis.INTERNAL_ERROR),
+			Message: fmt.Sprintf("Error when writing KUBECONFIG: %v", err),
+		}
+	}
+	log.Infof("KUBECONFIG context %v is created and currently using", gcp.Name)
+	return nil
+}
+
+// writeKubeconfigFile sets the cluster, user and context of the app in the kubeconfig file,
+// keeping its other entries. Its directory is locked meanwhile, so the apps applied
+// concurrently don't drop the contexts of each other.
+func (gcp *Gcp) writeKubeconfigFile(file string, cluster *containerpb.Cluster, gcloudPath string) error {
+	dir := filepath.Dir(file)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	unlock, err := utils.LockDir(dir)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	config := clientcmdapi.NewConfig()
+	if _, err := os.Stat(file); err == nil {
+		if config, err = clientcmd.LoadFromFile(file); err != nil {
+			return err
+		}
+	}
+	ca, err := base64.StdEncoding.DecodeString(cluster.MasterAuth.ClusterCaCertificate)
+	if err != nil {
+		return fmt.Errorf("invalid CA certificate of cluster %v: %v", gcp.Name, err)
+	}
+	name := gcp.kubeconfigName()
+	config.Clusters[name] = &clientcmdapi.Cluster{
+		Server:                   "https://" + cluster.Endpoint,
+		CertificateAuthorityData: ca,
+	}
+	authProvider := &clientcmdapi.AuthProviderConfig{
+		Name:   "gcp",
+		Config: map[string]string{},
+	}
+	if gcloudPath != "" {
+		authProvider.Config = map[string]string{
+			"cmd-path":   gcloudPath,
+			"cmd-args":   "config config-helper --format=json",
+			"token-key":  "{.credential.access_token}",
+			"expiry-key": "{.credential.token_expiry}",
+		}
+	}
+	config.AuthInfos[name] = &clientcmdapi.AuthInfo{
+		AuthProvider: authProvider,
+	}
+	config.Contexts[gcp.Name] = &clientcmdapi.Context{
+		Cluster:   name,
+		AuthInfo:  name,
+		Namespace: gcp.namespace(),
+	}
+	config.CurrentContext = gcp.Name
+
+	buf, err := clientcmd.Write(*config)
+	if err != nil {
+		return err
+	}
+	return utils.WriteFileAtomically(file, buf, 0600)
+}
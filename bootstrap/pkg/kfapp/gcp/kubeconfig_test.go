@@ -0,0 +1,129 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"encoding/base64"
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+
+	"golang.org/x/net/context"
+	containerpb "google.golang.org/genproto/googleapis/container/v1"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+func TestWriteKubeconfigFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "kfctl-kubeconfig")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	file := path.Join(dir, ".kube", "config")
+
+	gcp := &Gcp{}
+	gcp.Name = "kf"
+	gcp.Namespace = "kubeflow"
+	gcp.Spec.Project = "my-project"
+	gcp.Spec.Zone = "us-east1-d"
+	cluster := &containerpb.Cluster{
+		Endpoint: "10.0.0.1",
+		MasterAuth: &containerpb.MasterAuth{
+			ClusterCaCertificate: base64.StdEncoding.EncodeToString([]byte("ca")),
+		},
+	}
+	if err = gcp.writeKubeconfigFile(file, cluster, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	config, err := clientcmd.LoadFromFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	config.Contexts["other"] = &clientcmdapi.Context{Cluster: "other", AuthInfo: "other"}
+	if err = clientcmd.WriteToFile(*config, file); err != nil {
+		t.Fatal(err)
+	}
+	if err = gcp.writeKubeconfigFile(file, cluster, "/usr/bin/gcloud"); err != nil {
+		t.Fatal(err)
+	}
+
+	if config, err = clientcmd.LoadFromFile(file); err != nil {
+		t.Fatal(err)
+	}
+	name := "gke_my-project_us-east1-d_kf"
+	if config.CurrentContext != "kf" || config.Contexts["kf"] == nil || config.Contexts["kf"].Cluster != name ||
+		config.Contexts["kf"].Namespace != "kubeflow" {
+		t.Errorf("the context of the app is wrong: %+v", config.Contexts["kf"])
+	}
+	if config.Contexts["other"] == nil {
+		t.Errorf("the other contexts weren't kept")
+	}
+	if c := config.Clusters[name]; c == nil || c.Server != "https://10.0.0.1" || string(c.CertificateAuthorityData) != "ca" {
+		t.Errorf("the cluster of the app is wrong: %+v", c)
+	}
+	user := config.AuthInfos[name]
+	if user == nil || user.Token != "" || user.AuthProvider == nil || user.AuthProvider.Name != "gcp" ||
+		user.AuthProvider.Config["cmd-path"] != "/usr/bin/gcloud" {
+		t.Errorf("the user of the app doesn't refresh its token: %+v", user)
+	}
+}
+
+func TestKubeContext(t *testing.T) {
+	dir, err := ioutil.TempDir("", "kfctl-kubeconfig")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	file := path.Join(dir, "config")
+	config := clientcmdapi.NewConfig()
+	for name, server := range map[string]string{"gke": "https://10.0.0.1", "tunnel": "https://127.0.0.1:8443"} {
+		config.Clusters[name] = &clientcmdapi.Cluster{Server: server}
+		config.AuthInfos[name] = &clientcmdapi.AuthInfo{}
+		config.Contexts[name] = &clientcmdapi.Context{Cluster: name, AuthInfo: name}
+	}
+	config.CurrentContext = "gke"
+	if err = clientcmd.WriteToFile(*config, file); err != nil {
+		t.Fatal(err)
+	}
+
+	// Without credentials, the cluster is only reachable through the kubeconfig, not the GKE API.
+	gcp := &Gcp{}
+	gcp.Name = "kf"
+	gcp.Spec.Project = "my-project"
+	gcp.Spec.Zone = "us-east1-d"
+	gcp.Spec.Kubeconfig = file
+	gcp.Spec.KubeContext = "tunnel"
+	restConfig, err := gcp.getK8sRestConfig(context.Background())
+	if err != nil {
+		t.Fatalf("getK8sRestConfig failed: %v", err)
+	}
+	if restConfig.Host != "https://127.0.0.1:8443" {
+		t.Errorf("getK8sRestConfig with spec.kubeContext reaches %v; want the tunnel", restConfig.Host)
+	}
+	if err = gcp.WriteKubeconfig(); err != nil {
+		t.Errorf("WriteKubeconfig with spec.kubeContext failed: %v", err)
+	}
+	if config, err = clientcmd.LoadFromFile(file); err != nil || config.CurrentContext != "gke" || len(config.Contexts) != 2 {
+		t.Errorf("WriteKubeconfig with spec.kubeContext changed the kubeconfig: %+v, %v", config, err)
+	}
+
+	gcp.Spec.KubeContext = "missing"
+	if _, err = gcp.getK8sRestConfig(context.Background()); err == nil {
+		t.Errorf("getK8sRestConfig with a missing context succeeded")
+	}
+}
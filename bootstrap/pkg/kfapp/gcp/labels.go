@@ -0,0 +1,332 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"crypto/rand"
+	"fmt"
+	"github.com/deckarep/golang-set"
+	kfapis "github.com/kubeflow/kubeflow/bootstrap/pkg/apis"
+	kftypes "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+	"google.golang.org/api/cloudresourcemanager/v1"
+	"google.golang.org/api/deploymentmanager/v2"
+	"google.golang.org/api/googleapi"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Ownership labels set on every DM deployment and on the GCP resources that take labels.
+// Resources which don't (service accounts, global addresses) carry them in their description.
+const (
+	LABEL_NAME       = "kubeflow-name"
+	LABEL_VERSION    = "kubeflow-version"
+	LABEL_CREATED_BY = "created-by"
+	LABEL_COMPONENT  = "kubeflow-component"
+	CREATED_BY_KFCTL = "kfctl"
+	// LABEL_DEPLOYMENT_ID holds spec.deploymentId, which tells apart deployments sharing a name.
+	LABEL_DEPLOYMENT_ID = "kubeflow-deployment-id"
+)
+
+// Values of LABEL_COMPONENT, one per DM deployment.
+const (
+	COMPONENT_CLUSTER = "cluster"
+	COMPONENT_STORAGE = "storage"
+	COMPONENT_NETWORK = "network"
+	COMPONENT_GCFS    = "gcfs"
+	// COMPONENT_COMBINED is all of the above in one deployment, see spec.combinedDeployment.
+	COMPONENT_COMBINED = "combined"
+)
+
+var invalidLabelChars = regexp.MustCompile("[^a-z0-9_-]")
+
+// toLabelValue maps s onto the GCP label charset: lowercase letters, digits, '_' and '-', at most 63 chars.
+// e.g. v0.4.1 becomes v0-4-1
+func toLabelValue(s string) string {
+	v := invalidLabelChars.ReplaceAllString(strings.ToLower(s), "-")
+	if len(v) > 63 {
+		v = v[:63]
+	}
+	return v
+}
+
+// newDeploymentId returns a random (version 4) UUID.
+func newDeploymentId() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:]), nil
+}
+
+// ensureDeploymentId sets spec.deploymentId if it's not set yet and reports whether it did.
+func (gcp *Gcp) ensureDeploymentId() (bool, error) {
+	gcp.specLock.Lock()
+	defer gcp.specLock.Unlock()
+	if gcp.Spec.DeploymentId != "" {
+		return false, nil
+	}
+	id, err := newDeploymentId()
+	if err != nil {
+		return false, fmt.Errorf("couldn't generate deployment id: %v", err)
+	}
+	gcp.Spec.DeploymentId = id
+	return true, nil
+}
+
+// ownerLabels are the labels identifying resources created by kfctl for this app.
+func (gcp *Gcp) ownerLabels() map[string]string {
+	labels := map[string]string{
+		LABEL_NAME:       toLabelValue(gcp.Name),
+		LABEL_VERSION:    toLabelValue(gcp.Spec.Version),
+		LABEL_CREATED_BY: CREATED_BY_KFCTL,
+	}
+	if gcp.Spec.DeploymentId != "" {
+		labels[LABEL_DEPLOYMENT_ID] = gcp.Spec.DeploymentId
+	}
+	return labels
+}
+
+// deploymentLabels returns the ownerLabels plus the component label in the form DM expects.
+// They are sorted by key so updates don't show spurious diffs.
+func (gcp *Gcp) deploymentLabels(component string) []*deploymentmanager.DeploymentLabelEntry {
+	labels := gcp.ownerLabels()
+	labels[LABEL_COMPONENT] = component
+	keys := []string{}
+	for key := range labels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	entries := []*deploymentmanager.DeploymentLabelEntry{}
+	for _, key := range keys {
+		entries = append(entries, &deploymentmanager.DeploymentLabelEntry{
+			Key:   key,
+			Value: labels[key],
+		})
+	}
+	return entries
+}
+
+func getLabel(deployment *deploymentmanager.Deployment, key string) string {
+	for _, label := range deployment.Labels {
+		if label.Key == key {
+			return label.Value
+		}
+	}
+	return ""
+}
+
+// listKfctlDeployments returns all DM deployments in project labeled as created by kfctl.
+func listKfctlDeployments(ctx context.Context, deploymentmanagerService DeploymentManagerClient,
+	project string) ([]*deploymentmanager.Deployment, error) {
+	owned := []*deploymentmanager.Deployment{}
+	err := deploymentmanagerService.ListDeployments(ctx, project,
+		func(resp *deploymentmanager.DeploymentsListResponse) error {
+			for _, d := range resp.Deployments {
+				if getLabel(d, LABEL_CREATED_BY) == CREATED_BY_KFCTL {
+					owned = append(owned, d)
+				}
+			}
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("couldn't list deployments in %v: %v", project, err)
+	}
+	return owned, nil
+}
+
+// listOwnedDeployments returns the DM deployments labeled as belonging to this app: those with
+// its deployment id or, for apps generated before deployment ids were introduced, its name.
+func (gcp *Gcp) listOwnedDeployments(ctx context.Context,
+	deploymentmanagerService DeploymentManagerClient) ([]*deploymentmanager.Deployment, error) {
+	all, err := listKfctlDeployments(ctx, deploymentmanagerService, gcp.Spec.Project)
+	if err != nil {
+		return nil, err
+	}
+	owned := []*deploymentmanager.Deployment{}
+	for _, d := range all {
+		if gcp.Spec.DeploymentId != "" {
+			if getLabel(d, LABEL_DEPLOYMENT_ID) == gcp.Spec.DeploymentId {
+				owned = append(owned, d)
+			}
+		} else if getLabel(d, LABEL_NAME) == toLabelValue(gcp.Name) {
+			owned = append(owned, d)
+		}
+	}
+	return owned, nil
+}
+
+// liveDeployments returns which of names exist as DM deployments in project.
+func liveDeployments(ctx context.Context, deploymentmanagerService DeploymentManagerClient,
+	project string, names []string) ([]string, error) {
+	live := []string{}
+	for _, name := range names {
+		_, err := deploymentmanagerService.GetDeployment(ctx, project, name)
+		if err != nil {
+			if e, ok := err.(*googleapi.Error); ok && e.Code == 404 {
+				continue
+			}
+			return nil, fmt.Errorf("couldn't get deployment %v/%v: %v", project, name, err)
+		}
+		live = append(live, name)
+	}
+	return live, nil
+}
+
+// GC_MIN_AGE is how old the deployments of an app must be for GarbageCollect to take them for
+// orphaned: a younger group may be one an apply is still creating, storage before the cluster.
+const GC_MIN_AGE = DM_STUCK_AFTER
+
+// GarbageCollect deletes the deployments kfctl created for this app, by its name or deployment
+// id, that no longer have a cluster deployment, e.g. leftovers of an interrupted delete or of an
+// earlier deployment of the app. Storage deployments are only reported unless DeleteStorage is
+// set, and groups with a deployment younger than GC_MIN_AGE are left alone. When dryRun is true
+// nothing is deleted.
+func (gcp *Gcp) GarbageCollect(dryRun bool) error {
+	if gcp.Spec.DeletionProtection {
+		return &kfapis.KfError{
+			Code: int(kfapis.INVALID_ARGUMENT),
+			Message: fmt.Sprintf("deletion protection is enabled for %v; set deletionProtection to false in %v "+
+				"to collect its deployments", gcp.Name, kftypes.KfConfigFile),
+		}
+	}
+	ctx := context.Background()
+	client := gcp.client
+	deploymentmanagerService, err := gcp.newDeploymentManagerClient()
+	if err != nil {
+		return err
+	}
+	project := gcp.Spec.Project
+	all, err := listKfctlDeployments(ctx, deploymentmanagerService, project)
+	if err != nil {
+		return err
+	}
+	// Deployments of an app share its deployment id; older ones only its name.
+	byApp := make(map[string][]*deploymentmanager.Deployment)
+	// Service accounts are named after the app, so they're in use while any app of that name has a cluster.
+	liveNames := mapset.NewSet()
+	for _, d := range all {
+		if getLabel(d, LABEL_NAME) != toLabelValue(gcp.Name) &&
+			(gcp.Spec.DeploymentId == "" || getLabel(d, LABEL_DEPLOYMENT_ID) != gcp.Spec.DeploymentId) {
+			continue
+		}
+		app := getLabel(d, LABEL_DEPLOYMENT_ID)
+		if app == "" {
+			app = getLabel(d, LABEL_NAME)
+		}
+		byApp[app] = append(byApp[app], d)
+		if hasCluster(d) {
+			liveNames.Add(getLabel(d, LABEL_NAME))
+		}
+	}
+
+	deleted := []string{}
+	defer func() {
+		gcp.reportDeleted(deleted)
+	}()
+	for _, deployments := range byApp {
+		clusterFound := false
+		for _, d := range deployments {
+			if hasCluster(d) {
+				clusterFound = true
+			}
+		}
+		if clusterFound {
+			continue
+		}
+		name := getLabel(deployments[0], LABEL_NAME)
+		if young := gcp.youngDeployment(deployments); young != "" {
+			log.Warnf("Keeping deployment %v/%v of %v and its group, created less than %v ago; an apply may "+
+				"still be creating it", project, young, name, GC_MIN_AGE)
+			continue
+		}
+		orphanedSAs := false
+		for _, d := range deployments {
+			if getLabel(d, LABEL_COMPONENT) == COMPONENT_STORAGE && !gcp.Spec.DeleteStorage {
+				log.Warnf("Keeping orphaned storage deployment %v/%v of %v; set --%v to delete it",
+					project, d.Name, name, kftypes.DELETE_STORAGE)
+				continue
+			}
+			if getLabel(d, LABEL_COMPONENT) == COMPONENT_GCFS && !gcp.Spec.DeleteFilestore {
+				log.Warnf("Keeping orphaned gcfs deployment %v/%v of %v; set --%v to delete it",
+					project, d.Name, name, kftypes.DELETE_FILESTORE)
+				continue
+			}
+			if dryRun {
+				log.Warnf("Would delete orphaned deployment %v/%v of %v", project, d.Name, name)
+				continue
+			}
+			if err = gcp.deleteDeployment(deploymentmanagerService, ctx, project, d.Name); err != nil {
+				return err
+			}
+			deleted = append(deleted, fmt.Sprintf("deployment %v/%v", project, d.Name))
+			orphanedSAs = true
+		}
+		if !orphanedSAs || liveNames.Contains(name) {
+			continue
+		}
+		// The cluster deployment created the app's service accounts; drop their leftover bindings.
+		saSet := getDeploymentSAs(name, project)
+		var removedBindings []string
+		if err = gcp.updateIamPolicy(project, client, func(policy *cloudresourcemanager.Policy) {
+			removedBindings = removeMembers(policy, saSet)
+		}); err != nil {
+			return fmt.Errorf("Error when cleaning IAM policy: %v", err)
+		}
+		deleted = append(deleted, removedBindings...)
+	}
+	return nil
+}
+
+// youngDeployment returns the name of the first of deployments inserted less than GC_MIN_AGE
+// ago, empty when there's none. A deployment without a valid insert time is taken for young.
+func (gcp *Gcp) youngDeployment(deployments []*deploymentmanager.Deployment) string {
+	for _, d := range deployments {
+		inserted, err := time.Parse(time.RFC3339, d.InsertTime)
+		if err != nil || gcp.clock.Now().Sub(inserted) < GC_MIN_AGE {
+			return d.Name
+		}
+	}
+	return ""
+}
+
+// hasCluster is true for the deployments creating the GKE cluster, which are never orphaned.
+func hasCluster(d *deploymentmanager.Deployment) bool {
+	component := getLabel(d, LABEL_COMPONENT)
+	return component == COMPONENT_CLUSTER || component == COMPONENT_COMBINED
+}
+
+// removeMembers drops every member in saSet from the policy bindings and describes what was removed.
+func removeMembers(policy *cloudresourcemanager.Policy, saSet mapset.Set) []string {
+	removedBindings := []string{}
+	for idx, binding := range policy.Bindings {
+		cleanedMembers := []string{}
+		for _, member := range binding.Members {
+			if saSet.Contains(member) {
+				log.Infof("Removing %v from %v", member, binding.Role)
+				removedBindings = append(removedBindings, fmt.Sprintf("IAM binding %v for %v", binding.Role, member))
+			} else {
+				cleanedMembers = append(cleanedMembers, member)
+			}
+		}
+		policy.Bindings[idx].Members = cleanedMembers
+	}
+	return removedBindings
+}
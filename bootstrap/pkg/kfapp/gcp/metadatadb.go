@@ -0,0 +1,210 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"github.com/cenkalti/backoff"
+	kfapis "github.com/kubeflow/kubeflow/bootstrap/pkg/apis"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+	sqladmin "google.golang.org/api/sqladmin/v1beta4"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientset "k8s.io/client-go/kubernetes"
+)
+
+const (
+	// METADATA_DB_CLOUDSQL_POSTGRES is the spec.metadata.db of a Cloud SQL Postgres instance.
+	METADATA_DB_CLOUDSQL_POSTGRES = "cloudsql-postgres"
+	// METADATA_DB_SECRET holds the user, password and database of the instance, the pipeline
+	// api server and metadata connect with.
+	METADATA_DB_SECRET       = "metadata-db"
+	METADATA_DB_NAME         = "mlpipeline"
+	METADATA_DB_USER         = "kubeflow"
+	METADATA_DB_VERSION      = "POSTGRES_9_6"
+	METADATA_DB_DEFAULT_TIER = "db-custom-1-3840"
+	// METADATA_DB_PROXY is the service of the Cloud SQL proxy the pipeline package deploys.
+	METADATA_DB_PROXY  = "cloudsql-proxy"
+	METADATA_DB_PORT   = "5432"
+	METADATA_COMPONENT = "metadata"
+)
+
+// validateMetadata checks spec.metadata.db is a database kfctl provisions.
+func (gcp *Gcp) validateMetadata() error {
+	metadata := gcp.Spec.Metadata
+	if metadata == nil || metadata.Db == "" || metadata.Db == METADATA_DB_CLOUDSQL_POSTGRES {
+		return nil
+	}
+	return &kfapis.KfError{
+		Code: int(kfapis.INVALID_ARGUMENT),
+		Message: fmt.Sprintf("unsupported metadata db %v; supported: %v", metadata.Db,
+			METADATA_DB_CLOUDSQL_POSTGRES),
+	}
+}
+
+// usesCloudSqlPostgres is true when the metadata store is a Cloud SQL Postgres instance.
+func (gcp *Gcp) usesCloudSqlPostgres() bool {
+	return gcp.Spec.Metadata != nil && gcp.Spec.Metadata.Db == METADATA_DB_CLOUDSQL_POSTGRES
+}
+
+// metadataDbInstance is the Cloud SQL instance the storage template names after the storage
+// deployment.
+func (gcp *Gcp) metadataDbInstance() string {
+	return gcp.storageDeploymentName() + "-postgres"
+}
+
+// metadataDbConnectionName is the project:region:instance the Cloud SQL proxy connects to.
+func (gcp *Gcp) metadataDbConnectionName() string {
+	return fmt.Sprintf("%v:%v:%v", gcp.Spec.Project, regionOf(gcp.Spec.Zone), gcp.metadataDbInstance())
+}
+
+// setMetadataDbProperties has the storage template create the Postgres instance and database
+// of spec.metadata.db cloudsql-postgres.
+func (gcp *Gcp) setMetadataDbProperties(properties map[string]interface{}) {
+	if !gcp.usesCloudSqlPostgres() {
+		return
+	}
+	tier := gcp.Spec.Metadata.Tier
+	if tier == "" {
+		tier = METADATA_DB_DEFAULT_TIER
+	}
+	properties["enable_cloudsql"] = true
+	properties["cloudsql"] = map[string]interface{}{
+		"databaseVersion":    METADATA_DB_VERSION,
+		"region":             regionOf(gcp.Spec.Zone),
+		"zone":               gcp.Spec.Zone,
+		"tier":               tier,
+		"dataDiskSizeGb":     10,
+		"dataDiskType":       "PD_SSD",
+		"backupStartTime":    "00:00",
+		"authorizedNetworks": []interface{}{},
+	}
+	properties["database"] = map[string]interface{}{
+		"name":    METADATA_DB_NAME,
+		"charset": "UTF8",
+	}
+}
+
+// setMetadataDbParams points pipelines, and metadata when it's a component, at the instance.
+func (gcp *Gcp) setMetadataDbParams() {
+	if !gcp.usesCloudSqlPostgres() {
+		return
+	}
+	for _, comp := range gcp.Spec.Components {
+		if comp != "pipeline" && comp != METADATA_COMPONENT {
+			continue
+		}
+		gcp.setComponentParam(comp, "dbType", "postgres", false)
+		gcp.setComponentParam(comp, "cloudsqlInstance", gcp.metadataDbConnectionName(), false)
+		gcp.setComponentParam(comp, "dbSecret", METADATA_DB_SECRET, false)
+	}
+}
+
+// createMetadataDbSecret sets the password of the user of the instance and writes it to
+// METADATA_DB_SECRET. The password of an existing secret is kept, so reapplying doesn't
+// disconnect the running servers.
+func (gcp *Gcp) createMetadataDbSecret(ctx context.Context, client *clientset.Clientset) error {
+	if !gcp.usesCloudSqlPostgres() {
+		return nil
+	}
+	namespace := gcp.namespace()
+	secret, err := client.CoreV1().Secrets(namespace).Get(METADATA_DB_SECRET, metav1.GetOptions{})
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return err
+	}
+	found := err == nil
+	password := ""
+	if found {
+		password = string(secret.Data["password"])
+	}
+	if password == "" {
+		buf := make([]byte, 24)
+		if _, err := rand.Read(buf); err != nil {
+			return fmt.Errorf("couldn't generate the metadata db password: %v", err)
+		}
+		password = base64.RawURLEncoding.EncodeToString(buf)
+	}
+	if err := gcp.setMetadataDbUser(ctx, password); err != nil {
+		return err
+	}
+	data := map[string][]byte{
+		"user":           []byte(METADATA_DB_USER),
+		"password":       []byte(password),
+		"dbname":         []byte(METADATA_DB_NAME),
+		"host":           []byte(METADATA_DB_PROXY),
+		"port":           []byte(METADATA_DB_PORT),
+		"connectionName": []byte(gcp.metadataDbConnectionName()),
+	}
+	if !found {
+		return gcp.insertSecret(client, METADATA_DB_SECRET, namespace, data)
+	}
+	secret.Data = data
+	_, err = client.CoreV1().Secrets(namespace).Update(secret)
+	gcp.auditSecret(AUDIT_SECRET_UPDATE, namespace, METADATA_DB_SECRET, data, err)
+	return err
+}
+
+// setMetadataDbUser creates METADATA_DB_USER in the instance with password, or sets its
+// password when it exists.
+func (gcp *Gcp) setMetadataDbUser(ctx context.Context, password string) error {
+	service, err := sqladmin.New(gcp.client)
+	if err != nil {
+		return fmt.Errorf("Error creating sqladmin service: %v", err)
+	}
+	project := gcp.Spec.Project
+	instance := gcp.metadataDbInstance()
+	users, err := service.Users.List(project, instance).Context(ctx).Do()
+	if err != nil {
+		return fmt.Errorf("couldn't list the users of Cloud SQL instance %v: %v", instance, err)
+	}
+	user := &sqladmin.User{Name: METADATA_DB_USER, Password: password}
+	var op *sqladmin.Operation
+	exists := false
+	for _, u := range users.Items {
+		if u.Name == METADATA_DB_USER {
+			exists = true
+		}
+	}
+	if exists {
+		op, err = service.Users.Update(project, instance, METADATA_DB_USER, user).Context(ctx).Do()
+	} else {
+		log.Infof("Creating user %v of Cloud SQL instance %v", METADATA_DB_USER, instance)
+		op, err = service.Users.Insert(project, instance, user).Context(ctx).Do()
+	}
+	if err != nil {
+		return fmt.Errorf("couldn't set user %v of Cloud SQL instance %v: %v", METADATA_DB_USER, instance, err)
+	}
+	return gcp.retry(func() error {
+		if op.Status != "DONE" {
+			current, err := service.Operations.Get(project, op.Name).Context(ctx).Do()
+			if err != nil {
+				return fmt.Errorf("couldn't get operation %v: %v", op.Name, err)
+			}
+			op = current
+		}
+		if op.Status != "DONE" {
+			return fmt.Errorf("setting user %v of Cloud SQL instance %v isn't done (op = %v)",
+				METADATA_DB_USER, instance, op.Name)
+		}
+		if op.Error != nil && len(op.Error.Errors) > 0 {
+			return backoff.Permanent(fmt.Errorf("couldn't set user %v of Cloud SQL instance %v: %v",
+				METADATA_DB_USER, instance, op.Error.Errors[0].Message))
+		}
+		return nil
+	}, backoff.NewExponentialBackOff())
+}
@@ -0,0 +1,73 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"testing"
+
+	kfdefs "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps/kfdef/v1alpha1"
+)
+
+func TestValidateMetadata(t *testing.T) {
+	cases := []struct {
+		metadata *kfdefs.MetadataConfig
+		valid    bool
+	}{
+		{nil, true},
+		{&kfdefs.MetadataConfig{}, true},
+		{&kfdefs.MetadataConfig{Db: METADATA_DB_CLOUDSQL_POSTGRES}, true},
+		{&kfdefs.MetadataConfig{Db: "cloudsql-mysql"}, false},
+	}
+	for i, c := range cases {
+		gcp := &Gcp{}
+		gcp.Spec.Metadata = c.metadata
+		err := gcp.validateMetadata()
+		if c.valid && err != nil {
+			t.Errorf("case %v: validateMetadata failed: %v", i, err)
+		} else if !c.valid && err == nil {
+			t.Errorf("case %v: validateMetadata succeeded", i)
+		}
+	}
+}
+
+func TestSetMetadataDbProperties(t *testing.T) {
+	gcp := &Gcp{}
+	gcp.Name = "kf-app"
+	gcp.Spec.Project = "kf-project"
+	gcp.Spec.Zone = "us-east1-d"
+
+	properties := map[string]interface{}{}
+	gcp.setMetadataDbProperties(properties)
+	if len(properties) != 0 {
+		t.Errorf("Expected no properties without spec.metadata; got %v", properties)
+	}
+
+	gcp.Spec.Metadata = &kfdefs.MetadataConfig{Db: METADATA_DB_CLOUDSQL_POSTGRES}
+	gcp.setMetadataDbProperties(properties)
+	if properties["enable_cloudsql"] != true {
+		t.Errorf("Expected enable_cloudsql; got %v", properties)
+	}
+	cloudsql := properties["cloudsql"].(map[string]interface{})
+	if cloudsql["databaseVersion"] != METADATA_DB_VERSION || cloudsql["region"] != "us-east1" ||
+		cloudsql["tier"] != METADATA_DB_DEFAULT_TIER {
+		t.Errorf("Unexpected cloudsql properties %v", cloudsql)
+	}
+	if name := properties["database"].(map[string]interface{})["name"]; name != METADATA_DB_NAME {
+		t.Errorf("database name = %v; want %v", name, METADATA_DB_NAME)
+	}
+	if name := gcp.metadataDbConnectionName(); name != "kf-project:us-east1:kf-app-storage-postgres" {
+		t.Errorf("metadataDbConnectionName = %v", name)
+	}
+}
@@ -0,0 +1,99 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"bytes"
+	"fmt"
+	kfapis "github.com/kubeflow/kubeflow/bootstrap/pkg/apis"
+	kftypes "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps"
+	"github.com/kubeflow/kubeflow/bootstrap/pkg/kfapp/assets"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"strings"
+)
+
+// validateNamespacePrefix checks spec.namespacePrefix keeps the namespaces of the app valid.
+func (gcp *Gcp) validateNamespacePrefix() error {
+	if gcp.Spec.NamespacePrefix == "" {
+		return nil
+	}
+	for _, namespace := range []string{gcp.namespace(), gcp.istioNamespace()} {
+		if errs := validation.IsDNS1123Label(namespace); len(errs) > 0 {
+			return &kfapis.KfError{
+				Code: int(kfapis.INVALID_ARGUMENT),
+				Message: fmt.Sprintf("invalid namespacePrefix %q, namespace %v: %v", gcp.Spec.NamespacePrefix,
+					namespace, strings.Join(errs, "; ")),
+			}
+		}
+	}
+	return nil
+}
+
+// namespace is the namespace Kubeflow is deployed in. kfctl init prefixes it already; apps given
+// a prefix afterwards get it here.
+func (gcp *Gcp) namespace() string {
+	return kftypes.PrefixedNamespace(&gcp.Spec, gcp.Namespace)
+}
+
+// istioNamespace is the namespace Istio is installed in, IstioNamespace with spec.namespacePrefix.
+func (gcp *Gcp) istioNamespace() string {
+	return kftypes.PrefixedNamespace(&gcp.Spec, IstioNamespace)
+}
+
+// adminBinding is the name of the ClusterRoleBinding of the email of the app. Prefixed, it
+// doesn't clash with the bindings of the other apps of the cluster.
+func (gcp *Gcp) adminBinding() string {
+	if gcp.Spec.NamespacePrefix == "" {
+		return DEFAULT_ADMIN_BINDING
+	}
+	return gcp.Spec.NamespacePrefix + "-" + DEFAULT_ADMIN_BINDING
+}
+
+// setNamespaceParams points the components at the prefixed Istio namespace.
+func (gcp *Gcp) setNamespaceParams() {
+	if gcp.Spec.NamespacePrefix == "" || !gcp.Spec.UseIstio {
+		return
+	}
+	gcp.setComponentParam("iap-ingress", "istioNamespace", gcp.istioNamespace(), false)
+}
+
+// istioAssetLoader reads the Istio manifests with istio-system replaced by the prefixed
+// namespace. The cluster-scoped objects named after it, e.g. the ClusterRoles of the Istio
+// components, are renamed too, so each app has its own.
+type istioAssetLoader struct {
+	assets.Loader
+	namespace string
+}
+
+func (l *istioAssetLoader) Read(name string) ([]byte, error) {
+	buf, err := l.Loader.Read(name)
+	if err != nil {
+		return nil, err
+	}
+	return bytes.Replace(buf, []byte(IstioNamespace), []byte(l.namespace), -1), nil
+}
+
+// manifestLoader is the loader of the manifests Apply creates, the assetLoader rendering the
+// Istio manifests for spec.istio and prefixing the Istio namespace with spec.namespacePrefix.
+func (gcp *Gcp) manifestLoader() assets.Loader {
+	loader := gcp.istioLoader(gcp.assetLoader())
+	if gcp.Spec.NamespacePrefix == "" {
+		return loader
+	}
+	return &istioAssetLoader{
+		Loader:    loader,
+		namespace: gcp.istioNamespace(),
+	}
+}
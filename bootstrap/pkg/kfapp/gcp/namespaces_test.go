@@ -0,0 +1,75 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/kubeflow/kubeflow/bootstrap/pkg/kfapp/assets"
+)
+
+func TestNamespacePrefix(t *testing.T) {
+	gcp := &Gcp{}
+	gcp.Namespace = "kubeflow"
+	if gcp.namespace() != "kubeflow" || gcp.istioNamespace() != IstioNamespace ||
+		gcp.adminBinding() != DEFAULT_ADMIN_BINDING {
+		t.Errorf("without a prefix: got %v, %v and %v", gcp.namespace(), gcp.istioNamespace(), gcp.adminBinding())
+	}
+
+	gcp.Spec.NamespacePrefix = "mlplatform"
+	if err := gcp.validateNamespacePrefix(); err != nil {
+		t.Errorf("validateNamespacePrefix failed: %v", err)
+	}
+	if ns := gcp.namespace(); ns != "mlplatform-kubeflow" {
+		t.Errorf("namespace() = %v; want mlplatform-kubeflow", ns)
+	}
+	// kfctl init prefixes the namespace of the app already.
+	gcp.Namespace = "mlplatform-kubeflow"
+	if ns := gcp.namespace(); ns != "mlplatform-kubeflow" {
+		t.Errorf("namespace() = %v; want mlplatform-kubeflow", ns)
+	}
+	if ns := gcp.istioNamespace(); ns != "mlplatform-istio-system" {
+		t.Errorf("istioNamespace() = %v; want mlplatform-istio-system", ns)
+	}
+	if name := gcp.adminBinding(); name != "mlplatform-default-admin" {
+		t.Errorf("adminBinding() = %v; want mlplatform-default-admin", name)
+	}
+
+	gcp.Spec.NamespacePrefix = "ML_Platform"
+	if err := gcp.validateNamespacePrefix(); err == nil {
+		t.Errorf("validateNamespacePrefix succeeded for %v", gcp.Spec.NamespacePrefix)
+	}
+}
+
+func TestManifestLoader(t *testing.T) {
+	gcp := &Gcp{}
+	gcp.Spec.UseEmbeddedAssets = true
+	gcp.Spec.NamespacePrefix = "mlplatform"
+	buf, err := gcp.manifestLoader().Read("dependencies/istio/install/istio-noauth.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Contains(buf, []byte("namespace: mlplatform-istio-system")) {
+		t.Errorf("the istio manifest isn't deployed in mlplatform-istio-system")
+	}
+	unprefixed, err := assets.Embedded.Read("dependencies/istio/install/istio-noauth.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Count(buf, []byte("mlplatform-istio-system")) != bytes.Count(unprefixed, []byte(IstioNamespace)) {
+		t.Errorf("istio-system isn't replaced everywhere")
+	}
+}
@@ -0,0 +1,255 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"encoding/json"
+	"fmt"
+	kfapis "github.com/kubeflow/kubeflow/bootstrap/pkg/apis"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+	"google.golang.org/api/googleapi"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const (
+	// IAP_REDIRECT_PATH is the path of the redirect URI the OAuth client of IAP needs for the
+	// hostname of the app.
+	IAP_REDIRECT_PATH = "/_gcp_gatekeeper/authenticate"
+	// oauthAuthorizeUrl and oauthTokenUrl are the endpoints of Google's OAuth 2.0 server.
+	oauthAuthorizeUrl = "https://accounts.google.com/o/oauth2/v2/auth"
+	oauthTokenUrl     = "https://oauth2.googleapis.com/token"
+	// iapClientUrl is an OAuth client created with the IAP API in brand, which needs no
+	// redirect URI.
+	iapClientUrl = "https://iap.googleapis.com/v1/%v/identityAwareProxyClients/%v"
+	// oauthClientConsoleUrl is where the redirect URIs of an OAuth client are edited. There's
+	// no API for the clients created in the console, so kfctl can't add the URI itself.
+	oauthClientConsoleUrl = "https://console.cloud.google.com/apis/credentials/oauthclient/%v?project=%v"
+)
+
+// checkOAuthClient checks CLIENT_ID and CLIENT_SECRET before apply creates anything: the client
+// must be of the project of the app, which needs an OAuth consent screen, its secret must
+// match and its redirect URIs must have the one of spec.hostname. The checks which can't be
+// made, e.g. as the IAP API isn't enabled yet, only log a warning.
+func (gcp *Gcp) checkOAuthClient(ctx context.Context) error {
+	if gcp.oauthId == "" {
+		return nil
+	}
+	project := gcp.Spec.Project
+	number, err := gcp.projectNumber(ctx)
+	if err != nil {
+		log.Warnf("The OAuth client %v is left unchecked: %v", gcp.oauthId, err)
+		return nil
+	}
+	if owner := oauthClientProjectNumber(gcp.oauthId); owner != "" && owner != strconv.FormatInt(number, 10) {
+		return &kfapis.KfError{
+			Code: int(kfapis.INVALID_ARGUMENT),
+			Message: fmt.Sprintf("%v %v is an OAuth client of project number %v, not of %v (%v); create one "+
+				"in %v", CLIENT_ID, gcp.oauthId, owner, project, number,
+				fmt.Sprintf("https://console.cloud.google.com/apis/credentials?project=%v", project)),
+		}
+	}
+
+	brands, err := gcp.oauthBrands(project)
+	switch {
+	case err != nil && isApiDisabled(err):
+		log.Warnf("The IAP API isn't enabled in %v yet, its OAuth consent screen is left unchecked.", project)
+	case err != nil:
+		return fmt.Errorf("couldn't list the OAuth brands of %v: %v", project, err)
+	case len(brands) == 0:
+		return &kfapis.KfError{
+			Code: int(kfapis.INVALID_ARGUMENT),
+			Message: fmt.Sprintf("project %v has no OAuth consent screen, which IAP needs; set it up at %v",
+				project, fmt.Sprintf(consentScreenUrl, project)),
+		}
+	}
+	for _, brand := range brands {
+		managed, err := gcp.checkIapClient(brand)
+		if err != nil {
+			return err
+		}
+		if managed {
+			return nil
+		}
+	}
+
+	if err = gcp.checkOAuthSecret(); err != nil {
+		return err
+	}
+	return gcp.checkRedirectUri()
+}
+
+// oauthClientProjectNumber is the number of the project of OAuth client id, which it starts
+// with, e.g. 123 of 123-abc.apps.googleusercontent.com; empty when it doesn't.
+func oauthClientProjectNumber(id string) string {
+	i := strings.Index(id, "-")
+	if i <= 0 {
+		return ""
+	}
+	if _, err := strconv.ParseInt(id[:i], 10, 64); err != nil {
+		return ""
+	}
+	return id[:i]
+}
+
+// checkIapClient checks the secret of the OAuth client of the app when it was created with the
+// IAP API in brand, and returns whether it was.
+func (gcp *Gcp) checkIapClient(brand string) (bool, error) {
+	resp, err := gcp.client.Get(fmt.Sprintf(iapClientUrl, brand, gcp.oauthId))
+	if err != nil {
+		return false, fmt.Errorf("couldn't get OAuth client %v: %v", gcp.oauthId, err)
+	}
+	defer resp.Body.Close()
+	if err = googleapi.CheckResponse(resp); err != nil {
+		// Not an IAP client, or not readable; the other checks apply.
+		return false, nil
+	}
+	client := struct {
+		Secret string `json:"secret"`
+	}{}
+	if err = json.NewDecoder(resp.Body).Decode(&client); err != nil {
+		return false, fmt.Errorf("couldn't read OAuth client %v: %v", gcp.oauthId, err)
+	}
+	if client.Secret != gcp.oauthSecret {
+		return true, &kfapis.KfError{
+			Code:    int(kfapis.INVALID_ARGUMENT),
+			Message: fmt.Sprintf("%v isn't the secret of OAuth client %v", CLIENT_SECRET, gcp.oauthId),
+		}
+	}
+	return true, nil
+}
+
+// oauthHttpClient calls the OAuth server without the credentials of kfctl, through the proxy
+// and CAs of the base client. It doesn't follow redirects.
+func (gcp *Gcp) oauthHttpClient() *http.Client {
+	client := http.Client{}
+	if gcp.baseClient != nil {
+		client = *gcp.baseClient
+	}
+	client.CheckRedirect = func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+	return &client
+}
+
+// oauthError is the error of a response of the OAuth server, e.g. invalid_client.
+func oauthError(resp *http.Response) string {
+	buf, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return ""
+	}
+	e := struct {
+		Error string `json:"error"`
+	}{}
+	if json.Unmarshal(buf, &e) == nil && e.Error != "" {
+		return e.Error
+	}
+	// The authorization endpoint answers with an HTML page naming the error.
+	for _, known := range []string{"redirect_uri_mismatch", "invalid_client", "deleted_client"} {
+		if strings.Contains(string(buf), known) {
+			return known
+		}
+	}
+	return ""
+}
+
+// checkOAuthSecret checks CLIENT_SECRET by exchanging a made-up code with the OAuth server:
+// the code is refused as invalid_grant only once the client is authenticated.
+func (gcp *Gcp) checkOAuthSecret() error {
+	resp, err := gcp.oauthHttpClient().PostForm(oauthTokenUrl, url.Values{
+		"client_id":     {gcp.oauthId},
+		"client_secret": {gcp.oauthSecret},
+		"code":          {"kfctl-preflight-check"},
+		"grant_type":    {"authorization_code"},
+		"redirect_uri":  {gcp.redirectUri()},
+	})
+	if err != nil {
+		log.Warnf("The secret of OAuth client %v is left unchecked: %v", gcp.oauthId, err)
+		return nil
+	}
+	defer resp.Body.Close()
+	switch e := oauthError(resp); e {
+	case "invalid_client", "unauthorized_client", "deleted_client":
+		return &kfapis.KfError{
+			Code: int(kfapis.INVALID_ARGUMENT),
+			Message: fmt.Sprintf("OAuth client %v was refused with %v; check %v and %v", gcp.oauthId, e,
+				CLIENT_ID, CLIENT_SECRET),
+		}
+	case "invalid_grant", "redirect_uri_mismatch":
+		return nil
+	default:
+		log.Warnf("The secret of OAuth client %v is left unchecked, the OAuth server answered %v %v",
+			gcp.oauthId, resp.StatusCode, e)
+		return nil
+	}
+}
+
+// redirectUri is the redirect URI of the OAuth client for spec.hostname.
+func (gcp *Gcp) redirectUri() string {
+	return "https://" + gcp.Spec.Hostname + IAP_REDIRECT_PATH
+}
+
+// checkRedirectUri checks the redirect URI of spec.hostname is registered for the OAuth client,
+// by starting an authorization with it: the OAuth server refuses the unregistered URIs.
+func (gcp *Gcp) checkRedirectUri() error {
+	if gcp.Spec.Hostname == "" {
+		return nil
+	}
+	redirectUri := gcp.redirectUri()
+	resp, err := gcp.oauthHttpClient().Get(oauthAuthorizeUrl + "?" + url.Values{
+		"client_id":     {gcp.oauthId},
+		"redirect_uri":  {redirectUri},
+		"response_type": {"code"},
+		"scope":         {"openid email"},
+	}.Encode())
+	if err != nil {
+		log.Warnf("The redirect URIs of OAuth client %v are left unchecked: %v", gcp.oauthId, err)
+		return nil
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 == 3 {
+		location, _ := url.Parse(resp.Header.Get("Location"))
+		if location == nil || !strings.Contains(location.RawQuery, "redirect_uri_mismatch") {
+			return nil
+		}
+		return gcp.redirectUriError(redirectUri)
+	}
+	switch e := oauthError(resp); e {
+	case "redirect_uri_mismatch":
+		return gcp.redirectUriError(redirectUri)
+	case "invalid_client", "deleted_client":
+		return &kfapis.KfError{
+			Code:    int(kfapis.INVALID_ARGUMENT),
+			Message: fmt.Sprintf("OAuth client %v was refused with %v; check %v", gcp.oauthId, e, CLIENT_ID),
+		}
+	default:
+		log.Warnf("The redirect URIs of OAuth client %v are left unchecked, the OAuth server answered %v %v",
+			gcp.oauthId, resp.StatusCode, e)
+		return nil
+	}
+}
+
+func (gcp *Gcp) redirectUriError(redirectUri string) error {
+	return &kfapis.KfError{
+		Code: int(kfapis.INVALID_ARGUMENT),
+		Message: fmt.Sprintf("%v isn't an authorized redirect URI of OAuth client %v; add it at %v and run "+
+			"kfctl apply again", redirectUri, gcp.oauthId,
+			fmt.Sprintf(oauthClientConsoleUrl, gcp.oauthId, gcp.Spec.Project)),
+	}
+}
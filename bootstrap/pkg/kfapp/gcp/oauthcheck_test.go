@@ -0,0 +1,117 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"golang.org/x/net/context"
+)
+
+// fakeOAuthServer answers the token and authorization endpoints with tokenError and, when
+// redirectRegistered, a redirect to the consent page or else a redirect_uri_mismatch page.
+type fakeOAuthServer struct {
+	tokenError         string
+	redirectRegistered bool
+}
+
+func (f fakeOAuthServer) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp := &http.Response{
+		StatusCode: http.StatusBadRequest,
+		Header:     http.Header{},
+		Request:    req,
+	}
+	body := ""
+	switch req.URL.Path {
+	case "/token":
+		body = `{"error": "` + f.tokenError + `"}`
+	case "/o/oauth2/v2/auth":
+		if f.redirectRegistered {
+			resp.StatusCode = http.StatusFound
+			resp.Header.Set("Location", "https://accounts.google.com/signin/oauth?client_id=1")
+		} else {
+			body = "<html>Error 400: redirect_uri_mismatch</html>"
+		}
+	}
+	resp.Body = ioutil.NopCloser(strings.NewReader(body))
+	return resp, nil
+}
+
+func newOAuthCheckGcp(apis fakeGcpApis, server fakeOAuthServer) *Gcp {
+	gcp := &Gcp{
+		client:     &http.Client{Transport: apis},
+		baseClient: &http.Client{Transport: server},
+	}
+	gcp.Spec.Project = "my-project"
+	gcp.Spec.Hostname = "kf.endpoints.my-project.cloud.goog"
+	gcp.oauthId = "123-abc.apps.googleusercontent.com"
+	gcp.oauthSecret = "secret"
+	return gcp
+}
+
+func TestCheckOAuthClient(t *testing.T) {
+	project := `{"projectId": "my-project", "projectNumber": "123"}`
+	brands := `{"brands": [{"name": "projects/123/brands/123"}]}`
+	cases := []struct {
+		name   string
+		apis   fakeGcpApis
+		server fakeOAuthServer
+		valid  bool
+	}{
+		{"registered", fakeGcpApis{"/v1/projects/my-project": project, "/v1/projects/my-project/brands": brands},
+			fakeOAuthServer{"invalid_grant", true}, true},
+		{"other project", fakeGcpApis{"/v1/projects/my-project": `{"projectNumber": "456"}`},
+			fakeOAuthServer{"invalid_grant", true}, false},
+		{"no consent screen", fakeGcpApis{"/v1/projects/my-project": project, "/v1/projects/my-project/brands": `{}`},
+			fakeOAuthServer{"invalid_grant", true}, false},
+		{"IAP API disabled", fakeGcpApis{"/v1/projects/my-project": project,
+			"/v1/projects/my-project/brands": `{"error": {"code": 403, "message": "IAP API has not been used",
+				"errors": [{"reason": "accessNotConfigured"}]}}`},
+			fakeOAuthServer{"invalid_grant", true}, true},
+		{"wrong secret", fakeGcpApis{"/v1/projects/my-project": project, "/v1/projects/my-project/brands": brands},
+			fakeOAuthServer{"invalid_client", true}, false},
+		{"unregistered redirect URI", fakeGcpApis{"/v1/projects/my-project": project,
+			"/v1/projects/my-project/brands": brands}, fakeOAuthServer{"invalid_grant", false}, false},
+		{"IAP client", fakeGcpApis{"/v1/projects/my-project": project, "/v1/projects/my-project/brands": brands,
+			"/v1/projects/123/brands/123/identityAwareProxyClients/123-abc.apps.googleusercontent.com": `{"secret": "secret"}`},
+			fakeOAuthServer{"invalid_client", false}, true},
+	}
+	for _, c := range cases {
+		err := newOAuthCheckGcp(c.apis, c.server).checkOAuthClient(context.Background())
+		if c.valid && err != nil {
+			t.Errorf("checkOAuthClient(%v) failed: %v", c.name, err)
+		}
+		if !c.valid && err == nil {
+			t.Errorf("checkOAuthClient(%v) succeeded", c.name)
+		}
+	}
+}
+
+func TestCheckRedirectUriError(t *testing.T) {
+	gcp := newOAuthCheckGcp(fakeGcpApis{}, fakeOAuthServer{"invalid_grant", false})
+	err := gcp.checkRedirectUri()
+	if err == nil {
+		t.Fatalf("checkRedirectUri succeeded with an unregistered redirect URI")
+	}
+	for _, s := range []string{"https://kf.endpoints.my-project.cloud.goog" + IAP_REDIRECT_PATH,
+		"oauthclient/123-abc.apps.googleusercontent.com?project=my-project"} {
+		if !strings.Contains(err.Error(), s) {
+			t.Errorf("%v isn't in the error %v", s, err)
+		}
+	}
+}
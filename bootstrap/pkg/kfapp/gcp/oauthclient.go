@@ -0,0 +1,187 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	kftypes "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps"
+	kfdefs "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps/kfdef/v1alpha1"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/api/googleapi"
+	"io/ioutil"
+	"os"
+	"strings"
+)
+
+// secretVersionAccessUrl returns the payload of a Secret Manager secret version.
+const secretVersionAccessUrl = "https://secretmanager.googleapis.com/v1/%v:access"
+
+// oauthClientSource is a place kfctl apply reads the OAuth client of IAP from. load returns
+// what the source has of the id and secret of the client, both empty when it isn't configured.
+type oauthClientSource struct {
+	name string
+	load func() (string, string, error)
+}
+
+// oauthClientSources are the sources of the OAuth client of IAP, first to last: the file of
+// --oauth-client-file, the credentials file of spec.auth.oauthClient, its Secret Manager
+// secrets, then CLIENT_ID and CLIENT_SECRET.
+func (gcp *Gcp) oauthClientSources() []oauthClientSource {
+	config := &kfdefs.OAuthClientConfig{}
+	if gcp.Spec.Auth != nil && gcp.Spec.Auth.OAuthClient != nil {
+		config = gcp.Spec.Auth.OAuthClient
+	}
+	return []oauthClientSource{
+		{
+			name: "--" + string(kftypes.OAUTH_CLIENT_FILE),
+			load: func() (string, string, error) {
+				return readOAuthClientFile(gcp.Spec.OAuthClientFile)
+			},
+		},
+		{
+			name: "spec.auth.oauthClient.credentialsFile",
+			load: func() (string, string, error) {
+				return readOAuthClientFile(config.CredentialsFile)
+			},
+		},
+		{
+			name: "Secret Manager",
+			load: func() (string, string, error) {
+				id, err := gcp.accessSecretVersion(config.ClientIdSecret)
+				if err != nil {
+					return "", "", err
+				}
+				secret, err := gcp.accessSecretVersion(config.ClientSecretSecret)
+				return id, secret, err
+			},
+		},
+		{
+			name: "the environment",
+			load: func() (string, string, error) {
+				return os.Getenv(CLIENT_ID), os.Getenv(CLIENT_SECRET), nil
+			},
+		},
+	}
+}
+
+// loadOAuthClient reads the OAuth client of IAP from the first of oauthClientSources having
+// its id, and its secret from the first having it, so e.g. the id can be in a file and the
+// secret in Secret Manager.
+func (gcp *Gcp) loadOAuthClient() (Auth, error) {
+	auth := Auth{}
+	for _, source := range gcp.oauthClientSources() {
+		id, secret, err := source.load()
+		if err != nil {
+			return auth, fmt.Errorf("couldn't read the OAuth client of IAP from %v: %v", source.name, err)
+		}
+		if auth.OAuthClientId == "" && id != "" {
+			log.Infof("Using the OAuth client id of %v", source.name)
+			auth.OAuthClientId = id
+		}
+		if auth.OAuthClientSecret == "" && secret != "" {
+			log.Infof("Using the OAuth client secret of %v", source.name)
+			auth.OAuthClientSecret = secret
+		}
+		if auth.OAuthClientId != "" && auth.OAuthClientSecret != "" {
+			return auth, nil
+		}
+	}
+	return auth, fmt.Errorf("IAP needs an OAuth client: set --%v, spec.auth.oauthClient in app.yaml, "+
+		"or the environment variables `%v` and `%v`", kftypes.OAUTH_CLIENT_FILE, CLIENT_ID, CLIENT_SECRET)
+}
+
+// readOAuthClientFile returns the id and secret of the OAuth client in credentialsFile: the
+// client_secret JSON file downloaded from the Cloud Console, with the client under web or
+// installed, or a JSON file with its client_id and client_secret. Nothing is read when
+// credentialsFile is empty.
+func readOAuthClientFile(credentialsFile string) (string, string, error) {
+	if credentialsFile == "" {
+		return "", "", nil
+	}
+	buf, err := ioutil.ReadFile(credentialsFile)
+	if err != nil {
+		return "", "", fmt.Errorf("couldn't read %v: %v", credentialsFile, err)
+	}
+	type client struct {
+		ClientId     string `json:"client_id"`
+		ClientSecret string `json:"client_secret"`
+	}
+	file := struct {
+		client
+		Web       *client `json:"web"`
+		Installed *client `json:"installed"`
+	}{}
+	if err = json.Unmarshal(buf, &file); err != nil {
+		return "", "", fmt.Errorf("couldn't parse %v: %v", credentialsFile, err)
+	}
+	c := file.client
+	if file.Web != nil {
+		c = *file.Web
+	} else if file.Installed != nil {
+		c = *file.Installed
+	}
+	if c.ClientId == "" || c.ClientSecret == "" {
+		return "", "", fmt.Errorf("%v has no client_id and client_secret", credentialsFile)
+	}
+	return c.ClientId, c.ClientSecret, nil
+}
+
+// secretVersionName completes name to the resource name of a Secret Manager secret version:
+// the latest version when it names a secret, of project when it's a bare secret name.
+func secretVersionName(project string, name string) string {
+	if !strings.HasPrefix(name, "projects/") {
+		name = fmt.Sprintf("projects/%v/secrets/%v", project, name)
+	}
+	if !strings.Contains(name, "/versions/") {
+		name += "/versions/latest"
+	}
+	return name
+}
+
+// accessSecretVersion returns the payload of the Secret Manager secret version name, completed
+// with secretVersionName; nothing when name is empty.
+func (gcp *Gcp) accessSecretVersion(name string) (string, error) {
+	if name == "" {
+		return "", nil
+	}
+	name = secretVersionName(gcp.Spec.Project, name)
+	resp, err := gcp.client.Get(fmt.Sprintf(secretVersionAccessUrl, name))
+	if err != nil {
+		return "", fmt.Errorf("couldn't access secret %v: %v", name, err)
+	}
+	defer resp.Body.Close()
+	if err = googleapi.CheckResponse(resp); err != nil {
+		if isApiDisabled(err) {
+			return "", fmt.Errorf("couldn't access secret %v: enable secretmanager.googleapis.com in %v",
+				name, gcp.Spec.Project)
+		}
+		return "", fmt.Errorf("couldn't access secret %v: %v", name, err)
+	}
+	version := struct {
+		Payload struct {
+			Data string `json:"data"`
+		} `json:"payload"`
+	}{}
+	if err = json.NewDecoder(resp.Body).Decode(&version); err != nil {
+		return "", fmt.Errorf("couldn't read secret %v: %v", name, err)
+	}
+	data, err := base64.StdEncoding.DecodeString(version.Payload.Data)
+	if err != nil {
+		return "", fmt.Errorf("couldn't decode secret %v: %v", name, err)
+	}
+	return strings.TrimSpace(string(data)), nil
+}
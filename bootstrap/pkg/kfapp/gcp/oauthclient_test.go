@@ -0,0 +1,123 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	kfdefs "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps/kfdef/v1alpha1"
+)
+
+func TestReadOAuthClientFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "oauthclient")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	cases := []struct {
+		content string
+		valid   bool
+	}{
+		{`{"web": {"client_id": "id", "client_secret": "secret"}}`, true},
+		{`{"installed": {"client_id": "id", "client_secret": "secret"}}`, true},
+		{`{"client_id": "id", "client_secret": "secret"}`, true},
+		{`{"web": {"client_id": "id"}}`, false},
+		{`id`, false},
+	}
+	for i, c := range cases {
+		file := filepath.Join(dir, "client.json")
+		if err = ioutil.WriteFile(file, []byte(c.content), 0600); err != nil {
+			t.Fatal(err)
+		}
+		id, secret, err := readOAuthClientFile(file)
+		if !c.valid {
+			if err == nil {
+				t.Errorf("case %v: readOAuthClientFile succeeded", i)
+			}
+			continue
+		}
+		if err != nil || id != "id" || secret != "secret" {
+			t.Errorf("case %v: readOAuthClientFile = %v, %v, %v; want id, secret", i, id, secret, err)
+		}
+	}
+}
+
+func TestSecretVersionName(t *testing.T) {
+	cases := map[string]string{
+		"iap-client":                                             "projects/my-project/secrets/iap-client/versions/latest",
+		"projects/other/secrets/iap-client":                      "projects/other/secrets/iap-client/versions/latest",
+		"projects/other/secrets/iap-client/versions/3":           "projects/other/secrets/iap-client/versions/3",
+		"projects/my-project/secrets/iap-client/versions/latest": "projects/my-project/secrets/iap-client/versions/latest",
+	}
+	for name, expected := range cases {
+		if actual := secretVersionName("my-project", name); actual != expected {
+			t.Errorf("secretVersionName(%v) = %v; want %v", name, actual, expected)
+		}
+	}
+}
+
+func TestLoadOAuthClient(t *testing.T) {
+	dir, err := ioutil.TempDir("", "oauthclient")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "client.json")
+	if err = ioutil.WriteFile(file, []byte(`{"web": {"client_id": "file-id", "client_secret": "file-secret"}}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	os.Setenv(CLIENT_ID, "env-id")
+	os.Setenv(CLIENT_SECRET, "env-secret")
+	defer os.Unsetenv(CLIENT_ID)
+	defer os.Unsetenv(CLIENT_SECRET)
+
+	gcp := &Gcp{client: &http.Client{Transport: fakeGcpApis{
+		// "sm-secret" base64 encoded.
+		"/v1/projects/my-project/secrets/iap-secret/versions/latest:access": `{"payload": {"data": "c20tc2VjcmV0"}}`,
+	}}}
+	gcp.Spec.Project = "my-project"
+
+	auth, err := gcp.loadOAuthClient()
+	if err != nil || auth.OAuthClientId != "env-id" || auth.OAuthClientSecret != "env-secret" {
+		t.Errorf("loadOAuthClient = %+v, %v; want the client of the environment", auth, err)
+	}
+
+	gcp.Spec.Auth = &kfdefs.AuthConfig{OAuthClient: &kfdefs.OAuthClientConfig{ClientSecretSecret: "iap-secret"}}
+	auth, err = gcp.loadOAuthClient()
+	if err != nil || auth.OAuthClientId != "env-id" || auth.OAuthClientSecret != "sm-secret" {
+		t.Errorf("loadOAuthClient = %+v, %v; want env-id and the secret of Secret Manager", auth, err)
+	}
+
+	gcp.Spec.Auth.OAuthClient.CredentialsFile = file
+	auth, err = gcp.loadOAuthClient()
+	if err != nil || auth.OAuthClientId != "file-id" || auth.OAuthClientSecret != "file-secret" {
+		t.Errorf("loadOAuthClient = %+v, %v; want the client of the credentials file", auth, err)
+	}
+
+	gcp.Spec.OAuthClientFile = filepath.Join(dir, "missing.json")
+	if _, err = gcp.loadOAuthClient(); err == nil {
+		t.Errorf("loadOAuthClient succeeded with a missing --oauth-client-file")
+	}
+
+	gcp.Spec.OAuthClientFile = ""
+	gcp.Spec.Auth.OAuthClient = &kfdefs.OAuthClientConfig{ClientSecretSecret: "missing"}
+	if _, err = gcp.loadOAuthClient(); err == nil {
+		t.Errorf("loadOAuthClient succeeded with a missing secret")
+	}
+}
@@ -0,0 +1,227 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"fmt"
+	"github.com/cenkalti/backoff"
+	kfapis "github.com/kubeflow/kubeflow/bootstrap/pkg/apis"
+	kfdefs "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps/kfdef/v1alpha1"
+	"github.com/kubeflow/kubeflow/bootstrap/pkg/kfapp/assets"
+	"github.com/kubeflow/kubeflow/bootstrap/pkg/utils"
+	"golang.org/x/net/context"
+	"golang.org/x/oauth2"
+	"net/http"
+	"time"
+)
+
+// Clock is the time source of Gcp: the backoffs of the calls to GCP and the cluster, and the
+// timestamps and expiries it checks. Tests replace it so retries don't wait.
+type Clock interface {
+	Now() time.Time
+	Sleep(d time.Duration)
+}
+
+// CommandRunner runs the external commands of Gcp, e.g. gcloud. It's passed the command to
+// run with its Stdout and Stderr set, and returns what cmd.Run would.
+type CommandRunner = utils.CommandRunner
+
+// Option configures a Gcp created by NewGcp.
+type Option func(*Gcp)
+
+// WithClient sets the http client the GCP APIs are called with. It defaults to an oauth2
+// client of the token source.
+func WithClient(client *http.Client) Option {
+	return func(gcp *Gcp) {
+		gcp.client = client
+	}
+}
+
+// WithBaseClient sets the http client the oauth2 client of the token source sends the calls to
+// GCP with; it's unused with WithClient. NewGcp defaults it to the client of utils.NewHTTPClient
+// trusting spec.caBundle.
+func WithBaseClient(client *http.Client) Option {
+	return func(gcp *Gcp) {
+		gcp.baseClient = client
+	}
+}
+
+// WithTokenSource sets the token source used to authenticate to GCP and the GKE cluster.
+func WithTokenSource(tokenSource oauth2.TokenSource) Option {
+	return func(gcp *Gcp) {
+		gcp.tokenSource = tokenSource
+	}
+}
+
+// WithClock sets the time source; it defaults to the system clock.
+func WithClock(clock Clock) Option {
+	return func(gcp *Gcp) {
+		gcp.clock = clock
+	}
+}
+
+// WithCommandRunner sets how external commands are run; it defaults to utils.RunCommand, which
+// fails in no-exec mode.
+func WithCommandRunner(runCommand CommandRunner) Option {
+	return func(gcp *Gcp) {
+		gcp.runCommand = runCommand
+	}
+}
+
+// WithConfigStore sets where the generated files are kept; NewGcp keeps them in a Bundle by
+// default, e.g. NewAppDirStore writes them to a directory instead.
+func WithConfigStore(store ConfigStore) Option {
+	return func(gcp *Gcp) {
+		gcp.store = store
+	}
+}
+
+// WithAssetLoader sets where the DM templates and manifests are read from, e.g. a GCS bucket
+// for a server without a checkout; they're read from spec.repo, or the ones built in with
+// spec.useEmbeddedAssets, by default.
+func WithAssetLoader(loader assets.Loader) Option {
+	return func(gcp *Gcp) {
+		gcp.assets = loader
+	}
+}
+
+// WithDeploymentManagerClient sets the client of the Deployment Manager API, e.g. a
+// fake.DeploymentManager; it defaults to the API called with the http client.
+func WithDeploymentManagerClient(client DeploymentManagerClient) Option {
+	return func(gcp *Gcp) {
+		gcp.dmClient = client
+	}
+}
+
+// WithIamClient sets the client of the IAM API; it defaults to the API called with the http client.
+func WithIamClient(client IamClient) Option {
+	return func(gcp *Gcp) {
+		gcp.iamClient = client
+	}
+}
+
+// WithServiceUsageClient sets the client of the Service Usage API; it defaults to the API called
+// with the http client.
+func WithServiceUsageClient(client ServiceUsageClient) Option {
+	return func(gcp *Gcp) {
+		gcp.serviceUsageClient = client
+	}
+}
+
+// WithServiceManagementClient sets the client of the Service Management API; it defaults to the
+// API called with the http client.
+func WithServiceManagementClient(client ServiceManagementClient) Option {
+	return func(gcp *Gcp) {
+		gcp.serviceManagementClient = client
+	}
+}
+
+// WithAuditSink adds sink to the sinks of the audit log, besides the file in the app dir and
+// Cloud Logging with spec.cloudAuditLog.
+func WithAuditSink(sink AuditSink) Option {
+	return func(gcp *Gcp) {
+		gcp.auditLog = append(gcp.auditLog, sink)
+	}
+}
+
+// WithContainerClient sets the client of the GKE API; it defaults to a cluster manager client of
+// the token source.
+func WithContainerClient(client ContainerClient) Option {
+	return func(gcp *Gcp) {
+		gcp.containerClient = client
+	}
+}
+
+// Client returns the http client the GCP APIs are called with.
+func (gcp *Gcp) Client() *http.Client {
+	return gcp.client
+}
+
+// TokenSource returns the token source used to authenticate to GCP.
+func (gcp *Gcp) TokenSource() oauth2.TokenSource {
+	return gcp.tokenSource
+}
+
+// newBaseClient returns the client of the outbound calls of the app, honoring the proxy env vars
+// and spec.caBundle.
+func newBaseClient(kfdef *kfdefs.KfDef) (*http.Client, error) {
+	client, err := utils.NewHTTPClient(kfdef.Spec.CaBundle)
+	if err != nil {
+		return nil, &kfapis.KfError{
+			Code:    int(kfapis.INVALID_ARGUMENT),
+			Message: fmt.Sprintf("invalid caBundle: %v", err),
+		}
+	}
+	return client, nil
+}
+
+// applyOptions sets opts on gcp and fills in the defaults of those left unset.
+func (gcp *Gcp) applyOptions(opts []Option) {
+	for _, opt := range opts {
+		opt(gcp)
+	}
+	if gcp.client == nil && gcp.tokenSource != nil {
+		gcp.client = oauth2.NewClient(utils.WithHTTPClient(context.Background(), gcp.baseClient), gcp.tokenSource)
+	}
+	if gcp.clock == nil {
+		gcp.clock = systemClock{}
+	}
+	if gcp.runCommand == nil {
+		gcp.runCommand = utils.RunCommand
+	}
+	if gcp.store == nil {
+		gcp.store = NewBundle()
+	}
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) Sleep(d time.Duration) {
+	time.Sleep(d)
+}
+
+// retry is backoff.Retry on gcp's clock.
+func (gcp *Gcp) retry(operation backoff.Operation, b *backoff.ExponentialBackOff) error {
+	b.Clock = gcp.clock
+	b.Reset()
+	for {
+		err := operation()
+		if err == nil {
+			return nil
+		}
+		if permanent, ok := err.(*backoff.PermanentError); ok {
+			return permanent.Err
+		}
+		next := b.NextBackOff()
+		if next == backoff.Stop {
+			return err
+		}
+		gcp.clock.Sleep(next)
+	}
+}
+
+// retryContext is retry giving up with the error of ctx once it's done.
+func (gcp *Gcp) retryContext(ctx context.Context, operation backoff.Operation, b *backoff.ExponentialBackOff) error {
+	return gcp.retry(func() error {
+		if err := ctx.Err(); err != nil {
+			return backoff.Permanent(err)
+		}
+		return operation()
+	}, b)
+}
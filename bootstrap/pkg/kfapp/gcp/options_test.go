@@ -0,0 +1,131 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"errors"
+	"net/http"
+	"os/exec"
+	"testing"
+
+	"github.com/cenkalti/backoff"
+	"golang.org/x/net/context"
+	"golang.org/x/oauth2"
+)
+
+func TestNewGcpOptions(t *testing.T) {
+	kfdef := newFakeKfDef()
+	client := &http.Client{}
+	tokenSource := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "token"})
+	ran := []string{}
+	runCommand := func(cmd *exec.Cmd) error {
+		ran = append(ran, cmd.Path)
+		return nil
+	}
+	gcp, err := NewGcp(kfdef, Auth{OAuthClientId: "id", OAuthClientSecret: "secret"},
+		WithClient(client), WithTokenSource(tokenSource), WithClock(fakeClock{}),
+		WithCommandRunner(runCommand))
+	if err != nil {
+		t.Fatalf("NewGcp: %v", err)
+	}
+	if gcp.Client() != client {
+		t.Errorf("Client isn't the client of WithClient")
+	}
+	if gcp.TokenSource() != tokenSource {
+		t.Errorf("TokenSource isn't the token source of WithTokenSource")
+	}
+	if !gcp.clock.Now().Equal(fakeClock{}.Now()) {
+		t.Errorf("clock isn't the clock of WithClock")
+	}
+	if err := gcp.runCommand(exec.Command("gcloud")); err != nil || len(ran) != 1 {
+		t.Errorf("runCommand didn't run the command with the runner of WithCommandRunner")
+	}
+
+	// The Gcp has a copy of kfdef.
+	gcp.Spec.Project = "other-project"
+	if kfdef.Spec.Project != "my-project" {
+		t.Errorf("NewGcp didn't copy kfdef")
+	}
+}
+
+func TestNewGcpDefaults(t *testing.T) {
+	tokenSource := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "token"})
+	gcp, err := NewGcp(newFakeKfDef(), Auth{OAuthClientId: "id", OAuthClientSecret: "secret"},
+		WithTokenSource(tokenSource))
+	if err != nil {
+		t.Fatalf("NewGcp: %v", err)
+	}
+	if gcp.Client() == nil {
+		t.Errorf("NewGcp should default to a client of the token source")
+	}
+	if _, ok := gcp.clock.(systemClock); !ok {
+		t.Errorf("NewGcp should default to the system clock; got %T", gcp.clock)
+	}
+	if gcp.runCommand == nil || gcp.store == nil {
+		t.Errorf("NewGcp should default the command runner and the config store")
+	}
+
+	kfdef := newFakeKfDef()
+	kfdef.Spec.CaBundle = "not a certificate"
+	if _, err := NewGcp(kfdef, Auth{OAuthClientId: "id", OAuthClientSecret: "secret"}); err == nil {
+		t.Errorf("NewGcp should reject an invalid caBundle")
+	}
+}
+
+func TestRetry(t *testing.T) {
+	clock := &sleepingClock{}
+	gcp := &Gcp{clock: clock}
+	calls := 0
+	err := gcp.retry(func() error {
+		calls++
+		if calls < 3 {
+			return errors.New("not yet")
+		}
+		return nil
+	}, backoff.NewExponentialBackOff())
+	if err != nil || calls != 3 {
+		t.Errorf("retry got %v after %v calls; want success after 3", err, calls)
+	}
+	if clock.slept == 0 {
+		t.Errorf("retry didn't back off on the clock")
+	}
+
+	permanent := errors.New("permanent")
+	calls = 0
+	err = gcp.retry(func() error {
+		calls++
+		return backoff.Permanent(permanent)
+	}, backoff.NewExponentialBackOff())
+	if err != permanent || calls != 1 {
+		t.Errorf("retry got %v after %v calls; want the permanent error at once", err, calls)
+	}
+}
+
+func TestRetryContext(t *testing.T) {
+	clock := &sleepingClock{}
+	gcp := &Gcp{clock: clock}
+	ctx, cancel := context.WithCancel(context.Background())
+	calls := 0
+	err := gcp.retryContext(ctx, func() error {
+		calls++
+		if calls == 2 {
+			cancel()
+		}
+		return errors.New("not yet")
+	}, backoff.NewExponentialBackOff())
+	if err != context.Canceled || calls != 2 {
+		t.Errorf("retryContext got %v after %v calls; want %v after 2", err, calls, context.Canceled)
+	}
+}
@@ -0,0 +1,245 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"fmt"
+	kfapis "github.com/kubeflow/kubeflow/bootstrap/pkg/apis"
+	kfdefs "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps/kfdef/v1alpha1"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+	"google.golang.org/api/cloudresourcemanager/v1"
+	"google.golang.org/api/compute/v1"
+	"google.golang.org/api/googleapi"
+	"net/http"
+	"strings"
+)
+
+// The org policy constraints checked by checkOrgPolicies.
+const (
+	CONSTRAINT_VM_EXTERNAL_IP       = "constraints/compute.vmExternalIpAccess"
+	CONSTRAINT_SA_KEY_CREATION      = "constraints/iam.disableServiceAccountKeyCreation"
+	CONSTRAINT_DEFAULT_NETWORK      = "constraints/compute.skipDefaultNetworkCreation"
+	CONSTRAINT_LOAD_BALANCER_TYPES  = "constraints/compute.restrictLoadBalancerCreationForTypes"
+	CONSTRAINT_REQUIRE_SHIELDED_VMS = "constraints/compute.requireShieldedVm"
+	// externalHttpsLoadBalancer is the load balancer type of the ingress.
+	externalHttpsLoadBalancer = "EXTERNAL_HTTP_HTTPS"
+)
+
+// orgPolicyConstraints are the constraints read by checkOrgPolicies.
+var orgPolicyConstraints = []string{
+	CONSTRAINT_VM_EXTERNAL_IP,
+	CONSTRAINT_SA_KEY_CREATION,
+	CONSTRAINT_DEFAULT_NETWORK,
+	CONSTRAINT_LOAD_BALANCER_TYPES,
+	CONSTRAINT_REQUIRE_SHIELDED_VMS,
+}
+
+// orgPolicyFindings are what the effective org policies of a project mean for the app: the
+// changes of the spec which comply with them, and the violations which can't be adapted to.
+type orgPolicyFindings struct {
+	// adaptations describe the changes made to the spec, one per constraint.
+	adaptations []string
+	violations  []string
+}
+
+// listPolicyDenies is true when policy, of a list constraint, denies value, matched as is or by
+// one of the value groups, e.g. in:EXTERNAL.
+func listPolicyDenies(policy *cloudresourcemanager.OrgPolicy, value string, groups ...string) bool {
+	if policy == nil || policy.ListPolicy == nil {
+		return false
+	}
+	list := policy.ListPolicy
+	matches := func(values []string) bool {
+		for _, v := range values {
+			for _, name := range append([]string{value}, groups...) {
+				if v == name || v == "is:"+name {
+					return true
+				}
+			}
+		}
+		return false
+	}
+	switch list.AllValues {
+	case "DENY":
+		return true
+	case "ALLOW":
+		return false
+	}
+	if matches(list.DeniedValues) {
+		return true
+	}
+	return len(list.AllowedValues) > 0 && !matches(list.AllowedValues)
+}
+
+// booleanPolicyEnforced is true when policy, of a boolean constraint, is enforced.
+func booleanPolicyEnforced(policy *cloudresourcemanager.OrgPolicy) bool {
+	return policy != nil && policy.BooleanPolicy != nil && policy.BooleanPolicy.Enforced
+}
+
+// adaptToOrgPolicies changes spec to comply with the effective org policies of its project, by
+// constraint, when it can: a private cluster when the nodes can't have external IPs, workload
+// identity instead of service account keys. hasDefaultNetwork tells whether the project has the
+// default network, which the cluster is created in.
+func adaptToOrgPolicies(spec *kfdefs.KfDefSpec, policies map[string]*cloudresourcemanager.OrgPolicy,
+	hasDefaultNetwork bool) orgPolicyFindings {
+	findings := orgPolicyFindings{}
+	gkeApiVersion := func() string {
+		if spec.Gke == nil || spec.Gke.ApiVersion == "" {
+			return GKE_API_V1BETA1
+		}
+		return spec.Gke.ApiVersion
+	}
+	ensureGke := func() {
+		if spec.Gke == nil {
+			spec.Gke = &kfdefs.GkeConfig{}
+		}
+	}
+
+	// The policy lists VMs by name, and GKE names the nodes; they're only sure to get external IPs
+	// when the policy doesn't restrict them to an allowed list.
+	if policy := policies[CONSTRAINT_VM_EXTERNAL_IP]; policy != nil && policy.ListPolicy != nil &&
+		(policy.ListPolicy.AllValues == "DENY" || len(policy.ListPolicy.AllowedValues) > 0) &&
+		(spec.Gke == nil || !spec.Gke.PrivateCluster) {
+		if gkeApiVersion() != GKE_API_V1BETA1 {
+			findings.violations = append(findings.violations, fmt.Sprintf("%v denies the nodes external "+
+				"IPs, and a private cluster needs gke apiVersion %v; set it, or allow external IPs to the "+
+				"project's VMs", CONSTRAINT_VM_EXTERNAL_IP, GKE_API_V1BETA1))
+		} else {
+			ensureGke()
+			spec.Gke.PrivateCluster = true
+			findings.adaptations = append(findings.adaptations, fmt.Sprintf("%v denies the nodes external "+
+				"IPs: the cluster is private; images outside gcr.io need a Cloud NAT to be pulled",
+				CONSTRAINT_VM_EXTERNAL_IP))
+		}
+	}
+
+	if booleanPolicyEnforced(policies[CONSTRAINT_SA_KEY_CREATION]) && !spec.DisableServiceAccountKeys {
+		if gkeApiVersion() != GKE_API_V1BETA1 {
+			findings.violations = append(findings.violations, fmt.Sprintf("%v blocks the keys of the "+
+				"service account secrets, and workload identity needs gke apiVersion %v; set it, or lift "+
+				"the constraint on the project", CONSTRAINT_SA_KEY_CREATION, GKE_API_V1BETA1))
+		} else {
+			ensureGke()
+			if spec.Gke.FeatureGates == nil {
+				spec.Gke.FeatureGates = map[string]bool{}
+			}
+			spec.Gke.FeatureGates[GKE_FEATURE_WORKLOAD_IDENTITY] = true
+			spec.DisableServiceAccountKeys = true
+			findings.adaptations = append(findings.adaptations, fmt.Sprintf("%v blocks service account "+
+				"keys: the pods use workload identity instead of the admin and user secrets",
+				CONSTRAINT_SA_KEY_CREATION))
+		}
+	}
+
+	if booleanPolicyEnforced(policies[CONSTRAINT_DEFAULT_NETWORK]) && !hasDefaultNetwork {
+		findings.violations = append(findings.violations, fmt.Sprintf("%v left the project without the "+
+			"default network the cluster is created in; create it with `gcloud compute networks create "+
+			"default --subnet-mode=auto --project=%v`", CONSTRAINT_DEFAULT_NETWORK, spec.Project))
+	}
+
+	if listPolicyDenies(policies[CONSTRAINT_LOAD_BALANCER_TYPES], externalHttpsLoadBalancer,
+		"in:EXTERNAL", "in:GLOBAL") {
+		findings.violations = append(findings.violations, fmt.Sprintf("%v blocks the %v load balancer of "+
+			"the ingress; allow it on the project", CONSTRAINT_LOAD_BALANCER_TYPES, externalHttpsLoadBalancer))
+	}
+
+	if booleanPolicyEnforced(policies[CONSTRAINT_REQUIRE_SHIELDED_VMS]) {
+		findings.violations = append(findings.violations, fmt.Sprintf("%v requires shielded nodes, which "+
+			"cluster.jinja doesn't create; override the template to set shieldedInstanceConfig, or lift the "+
+			"constraint on the project", CONSTRAINT_REQUIRE_SHIELDED_VMS))
+	}
+	return findings
+}
+
+// effectiveOrgPolicies returns the effective policies of the constraints on project, by
+// constraint. nil is returned without error when the account can't read them.
+func (gcp *Gcp) effectiveOrgPolicies(ctx context.Context, project string,
+	constraints []string) (map[string]*cloudresourcemanager.OrgPolicy, error) {
+	service, err := cloudresourcemanager.New(gcp.client)
+	if err != nil {
+		return nil, fmt.Errorf("Error creating cloudresourcemanager service: %v", err)
+	}
+	policies := map[string]*cloudresourcemanager.OrgPolicy{}
+	for _, constraint := range constraints {
+		policy, err := service.Projects.GetEffectiveOrgPolicy("projects/"+project,
+			&cloudresourcemanager.GetEffectiveOrgPolicyRequest{Constraint: constraint}).Context(ctx).Do()
+		if err != nil {
+			if e, ok := err.(*googleapi.Error); ok && e.Code == http.StatusForbidden {
+				log.Warnf("%v can't read the org policies of %v, they're left unchecked: %v", gcp.Spec.Email,
+					project, err)
+				return nil, nil
+			}
+			return nil, fmt.Errorf("couldn't get the org policy %v of %v: %v", constraint, project, err)
+		}
+		policies[constraint] = policy
+	}
+	return policies, nil
+}
+
+// hasDefaultNetwork tells whether project has the default network. It's assumed to when the
+// compute API isn't enabled yet.
+func (gcp *Gcp) hasDefaultNetwork(ctx context.Context, project string) (bool, error) {
+	service, err := compute.New(gcp.client)
+	if err != nil {
+		return false, fmt.Errorf("Error creating compute service: %v", err)
+	}
+	if _, err = service.Networks.Get(project, "default").Context(ctx).Do(); err != nil {
+		if e, ok := err.(*googleapi.Error); ok && e.Code == http.StatusNotFound {
+			return false, nil
+		}
+		if isApiDisabled(err) {
+			return true, nil
+		}
+		return false, fmt.Errorf("couldn't get the default network of %v: %v", project, err)
+	}
+	return true, nil
+}
+
+// checkOrgPolicies reads the effective org policies of the project which commonly break apply,
+// e.g. denying external IPs or service account keys. The spec is adapted to those it can comply
+// with, before the DM configs are generated from it; the others are returned as an error naming
+// how to fix them. It's skipped without credentials, e.g. by GenerateConfigs.
+func (gcp *Gcp) checkOrgPolicies(ctx context.Context) error {
+	if gcp.client == nil {
+		return nil
+	}
+	project := gcp.Spec.Project
+	policies, err := gcp.effectiveOrgPolicies(ctx, project, orgPolicyConstraints)
+	if err != nil || policies == nil {
+		return err
+	}
+	hasDefaultNetwork := true
+	if booleanPolicyEnforced(policies[CONSTRAINT_DEFAULT_NETWORK]) {
+		if hasDefaultNetwork, err = gcp.hasDefaultNetwork(ctx, project); err != nil {
+			return err
+		}
+	}
+
+	gcp.specLock.Lock()
+	findings := adaptToOrgPolicies(&gcp.Spec, policies, hasDefaultNetwork)
+	gcp.specLock.Unlock()
+	for _, adaptation := range findings.adaptations {
+		log.Warnf("Org policy %v", adaptation)
+	}
+	if len(findings.violations) != 0 {
+		return &kfapis.KfError{
+			Code: int(kfapis.INVALID_ARGUMENT),
+			Message: fmt.Sprintf("the org policies of project %v block the app:\n%v", project,
+				strings.Join(findings.violations, "\n")),
+		}
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"testing"
+
+	kfdefs "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps/kfdef/v1alpha1"
+	"google.golang.org/api/cloudresourcemanager/v1"
+)
+
+func TestListPolicyDenies(t *testing.T) {
+	cases := []struct {
+		list   *cloudresourcemanager.ListPolicy
+		denies bool
+	}{
+		{nil, false},
+		{&cloudresourcemanager.ListPolicy{}, false},
+		{&cloudresourcemanager.ListPolicy{AllValues: "DENY"}, true},
+		{&cloudresourcemanager.ListPolicy{AllValues: "ALLOW"}, false},
+		{&cloudresourcemanager.ListPolicy{DeniedValues: []string{"in:EXTERNAL"}}, true},
+		{&cloudresourcemanager.ListPolicy{DeniedValues: []string{"INTERNAL_TCP_UDP"}}, false},
+		{&cloudresourcemanager.ListPolicy{AllowedValues: []string{"INTERNAL_TCP_UDP"}}, true},
+		{&cloudresourcemanager.ListPolicy{AllowedValues: []string{"is:EXTERNAL_HTTP_HTTPS"}}, false},
+	}
+	for i, c := range cases {
+		policy := &cloudresourcemanager.OrgPolicy{ListPolicy: c.list}
+		if denies := listPolicyDenies(policy, externalHttpsLoadBalancer, "in:EXTERNAL"); denies != c.denies {
+			t.Errorf("case %v: listPolicyDenies = %v; want %v", i, denies, c.denies)
+		}
+	}
+}
+
+func TestAdaptToOrgPolicies(t *testing.T) {
+	policies := map[string]*cloudresourcemanager.OrgPolicy{
+		CONSTRAINT_VM_EXTERNAL_IP: {ListPolicy: &cloudresourcemanager.ListPolicy{AllValues: "DENY"}},
+		CONSTRAINT_SA_KEY_CREATION: {
+			BooleanPolicy: &cloudresourcemanager.BooleanPolicy{Enforced: true},
+		},
+		CONSTRAINT_DEFAULT_NETWORK: {
+			BooleanPolicy: &cloudresourcemanager.BooleanPolicy{Enforced: true},
+		},
+	}
+	spec := &kfdefs.KfDefSpec{}
+	findings := adaptToOrgPolicies(spec, policies, true)
+	if len(findings.violations) != 0 {
+		t.Errorf("adaptToOrgPolicies found violations %v", findings.violations)
+	}
+	if len(findings.adaptations) != 2 || spec.Gke == nil || !spec.Gke.PrivateCluster ||
+		!spec.Gke.FeatureGates[GKE_FEATURE_WORKLOAD_IDENTITY] || !spec.DisableServiceAccountKeys {
+		t.Errorf("adaptToOrgPolicies made %v, spec %+v", findings.adaptations, spec)
+	}
+	if findings = adaptToOrgPolicies(spec, policies, true); len(findings.adaptations) != 0 {
+		t.Errorf("adaptToOrgPolicies adapted an adapted spec again: %v", findings.adaptations)
+	}
+
+	// The v1 API has neither private clusters nor workload identity.
+	spec = &kfdefs.KfDefSpec{Gke: &kfdefs.GkeConfig{ApiVersion: GKE_API_V1}}
+	findings = adaptToOrgPolicies(spec, policies, false)
+	if len(findings.violations) != 3 || len(findings.adaptations) != 0 || spec.Gke.PrivateCluster {
+		t.Errorf("adaptToOrgPolicies with the v1 API made %v and found %v", findings.adaptations,
+			findings.violations)
+	}
+
+	if findings = adaptToOrgPolicies(&kfdefs.KfDefSpec{}, nil, true); len(findings.adaptations) != 0 ||
+		len(findings.violations) != 0 {
+		t.Errorf("adaptToOrgPolicies without policies made %v and found %v", findings.adaptations,
+			findings.violations)
+	}
+}
@@ -0,0 +1,125 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	kftypes "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps"
+	kfdefs "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps/kfdef/v1alpha1"
+	log "github.com/sirupsen/logrus"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// OVERRIDES_DIR is the directory of the app dir holding the users' versions of the DM templates.
+const OVERRIDES_DIR = "overrides"
+
+// DM_CONFIGS_DIR is the directory of the kubeflow repo holding the DM templates and configs.
+const DM_CONFIGS_DIR = "deployment/gke/deployment_manager_configs"
+
+// readTemplate returns the template file generateDMConfigs uses and where it was read from: the
+// override in OVERRIDES_DIR when there is one, the one of the repo otherwise. Only kfctl apps have
+// overrides, the apps of the deploy server have no app dir to keep them in.
+// The hash of the upstream template is recorded in spec.templateOverrides the first time an
+// override is used; when the upstream template changes afterwards, e.g. with a new version, the
+// override is still used but a warning asks to merge the changes.
+func (gcp *Gcp) readTemplate(file string) ([]byte, string, error) {
+	source := path.Join(DM_CONFIGS_DIR, file)
+	upstream, err := gcp.readAsset(source)
+	if err != nil {
+		return nil, "", fmt.Errorf("couldn't read %v: %v", source, err)
+	}
+	if !gcp.isCLI {
+		return upstream, source, nil
+	}
+	override := filepath.Join(gcp.Spec.AppDir, OVERRIDES_DIR, file)
+	buf, err := ioutil.ReadFile(override)
+	if os.IsNotExist(err) {
+		gcp.removeTemplateOverride(file)
+		return upstream, source, nil
+	} else if err != nil {
+		return nil, "", fmt.Errorf("couldn't read override %v: %v", override, err)
+	}
+	upstreamSha256 := sha256Hex(upstream)
+	for _, o := range gcp.Spec.TemplateOverrides {
+		if o.File != file {
+			continue
+		}
+		if o.UpstreamSha256 != upstreamSha256 {
+			log.Warnf("%v overrides a template which changed since; merge the changes of %v into it, "+
+				"then remove %v from templateOverrides in %v", override, source, file, kftypes.KfConfigFile)
+		}
+		return buf, override, nil
+	}
+	log.Infof("Using %v instead of %v", override, source)
+	gcp.Spec.TemplateOverrides = append(gcp.Spec.TemplateOverrides, kfdefs.TemplateOverride{
+		File:           file,
+		UpstreamSha256: upstreamSha256,
+	})
+	return buf, override, nil
+}
+
+// removeTemplateOverride forgets the override of file, once the user removed it.
+func (gcp *Gcp) removeTemplateOverride(file string) {
+	kept := []kfdefs.TemplateOverride{}
+	for _, o := range gcp.Spec.TemplateOverrides {
+		if o.File != file {
+			kept = append(kept, o)
+		}
+	}
+	if len(kept) == 0 {
+		kept = nil
+	}
+	gcp.Spec.TemplateOverrides = kept
+}
+
+// recordTemplateSha256 records the hash of each of the DM templates of the repo in
+// spec.templateSha256, and tells which changed since the configs were last generated, e.g.
+// with a new version: the configs generated from them differ while the spec is the same.
+func (gcp *Gcp) recordTemplateSha256(files []string) error {
+	hashes := map[string]string{}
+	changed := []string{}
+	for _, file := range files {
+		source := path.Join(DM_CONFIGS_DIR, file)
+		buf, err := gcp.readAsset(source)
+		if err != nil {
+			return fmt.Errorf("couldn't read %v: %v", source, err)
+		}
+		hashes[file] = sha256Hex(buf)
+		if previous, ok := gcp.Spec.TemplateSha256[file]; ok && previous != hashes[file] {
+			changed = append(changed, file)
+		}
+	}
+	if len(changed) > 0 {
+		sort.Strings(changed)
+		log.Warnf("The templates %v changed since the configs of %v were last generated; review the "+
+			"changes of %v before apply", strings.Join(changed, ", "), GCP_CONFIG, GCP_CONFIG)
+	}
+	gcp.specLock.Lock()
+	gcp.Spec.TemplateSha256 = hashes
+	gcp.specLock.Unlock()
+	return nil
+}
+
+func sha256Hex(buf []byte) string {
+	sum := sha256.Sum256(buf)
+	return hex.EncodeToString(sum[:])
+}
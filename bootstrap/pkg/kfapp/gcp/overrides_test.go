@@ -0,0 +1,124 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kubeflow/kubeflow/bootstrap/pkg/kfapp/assets"
+)
+
+func writeTestFile(t *testing.T, file string, contents string) {
+	if err := os.MkdirAll(filepath.Dir(file), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(file, []byte(contents), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestReadTemplate(t *testing.T) {
+	dir, err := ioutil.TempDir("", "kfctl-overrides")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	repoDir := filepath.Join(dir, "repo")
+	appDir := filepath.Join(dir, "app")
+	upstream := filepath.Join(repoDir, DM_CONFIGS_DIR, "cluster.jinja")
+	override := filepath.Join(appDir, OVERRIDES_DIR, "cluster.jinja")
+	writeTestFile(t, upstream, "upstream v1")
+
+	gcp := &Gcp{isCLI: true, assets: assets.NewDirLoader(repoDir)}
+	gcp.Spec.AppDir = appDir
+	buf, source, err := gcp.readTemplate("cluster.jinja")
+	if err != nil {
+		t.Fatalf("readTemplate: %v", err)
+	}
+	if string(buf) != "upstream v1" || len(gcp.Spec.TemplateOverrides) != 0 {
+		t.Errorf("readTemplate without an override got %q from %v", buf, source)
+	}
+
+	// The override is used, recording the hash of the upstream template.
+	writeTestFile(t, override, "override")
+	buf, source, err = gcp.readTemplate("cluster.jinja")
+	if err != nil {
+		t.Fatalf("readTemplate: %v", err)
+	}
+	if string(buf) != "override" || source != override {
+		t.Errorf("readTemplate got %q from %v; want the override", buf, source)
+	}
+	if len(gcp.Spec.TemplateOverrides) != 1 ||
+		gcp.Spec.TemplateOverrides[0].UpstreamSha256 != sha256Hex([]byte("upstream v1")) {
+		t.Fatalf("readTemplate recorded the overrides %v", gcp.Spec.TemplateOverrides)
+	}
+
+	// A new upstream template keeps the override and the hash it was made from.
+	writeTestFile(t, upstream, "upstream v2")
+	if buf, _, err = gcp.readTemplate("cluster.jinja"); err != nil || string(buf) != "override" {
+		t.Errorf("readTemplate got %q, %v; want the override", buf, err)
+	}
+	if gcp.Spec.TemplateOverrides[0].UpstreamSha256 != sha256Hex([]byte("upstream v1")) {
+		t.Errorf("readTemplate shouldn't update the hash of the override")
+	}
+
+	// Removing the override forgets it.
+	if err = os.Remove(override); err != nil {
+		t.Fatal(err)
+	}
+	if buf, _, err = gcp.readTemplate("cluster.jinja"); err != nil || string(buf) != "upstream v2" {
+		t.Errorf("readTemplate got %q, %v; want the upstream template", buf, err)
+	}
+	if gcp.Spec.TemplateOverrides != nil {
+		t.Errorf("readTemplate kept the overrides %v", gcp.Spec.TemplateOverrides)
+	}
+
+	// Apps of the deploy server have no overrides.
+	writeTestFile(t, override, "override")
+	gcp.isCLI = false
+	if buf, _, err = gcp.readTemplate("cluster.jinja"); err != nil || string(buf) != "upstream v2" {
+		t.Errorf("readTemplate of the deploy server got %q, %v; want the upstream template", buf, err)
+	}
+}
+
+func TestRecordTemplateSha256(t *testing.T) {
+	dir, err := ioutil.TempDir("", "kfctl-templates")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	writeTestFile(t, filepath.Join(dir, DM_CONFIGS_DIR, "cluster.jinja"), "cluster")
+	writeTestFile(t, filepath.Join(dir, DM_CONFIGS_DIR, "storage.jinja"), "storage")
+
+	gcp := &Gcp{assets: assets.NewDirLoader(dir)}
+	if err := gcp.recordTemplateSha256([]string{"cluster.jinja", "storage.jinja"}); err != nil {
+		t.Fatalf("recordTemplateSha256: %v", err)
+	}
+	expected := map[string]string{
+		"cluster.jinja": sha256Hex([]byte("cluster")),
+		"storage.jinja": sha256Hex([]byte("storage")),
+	}
+	for file, hash := range expected {
+		if gcp.Spec.TemplateSha256[file] != hash {
+			t.Errorf("templateSha256 of %v got %v; want %v", file, gcp.Spec.TemplateSha256[file], hash)
+		}
+	}
+	if err := gcp.recordTemplateSha256([]string{"iam_bindings_template.yaml"}); err == nil {
+		t.Errorf("recordTemplateSha256 should fail on a missing template")
+	}
+}
@@ -0,0 +1,111 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	configtypes "github.com/kubeflow/kubeflow/bootstrap/config"
+	kftypes "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps"
+)
+
+// Run with -race: the params are set while secret creations read them and app.yaml is written.
+func TestComponentParamsConcurrently(t *testing.T) {
+	appDir, err := ioutil.TempDir("", "kfctl-params")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(appDir)
+
+	shared := configtypes.Parameters{
+		"iap-ingress": {{Name: "secretName", Value: "custom-tls"}},
+	}
+	gcp := &Gcp{}
+	gcp.Name = "kf"
+	gcp.Spec.AppDir = appDir
+	gcp.store = NewAppDirStore(appDir)
+	gcp.Spec.ComponentParams = shared
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(3)
+		go func(i int) {
+			defer wg.Done()
+			gcp.setComponentParam("pipeline", fmt.Sprintf("param%v", i), "value", false)
+		}(i)
+		go func() {
+			defer wg.Done()
+			if secretName := gcp.tlsSecretName(); secretName != "custom-tls" {
+				t.Errorf("tlsSecretName() = %v, want custom-tls", secretName)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			if err := gcp.writeConfigFile(); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := len(gcp.Spec.ComponentParams["pipeline"]); got != 10 {
+		t.Errorf("got %v pipeline params, want 10", got)
+	}
+	gcp.setComponentParam("iap-ingress", "secretName", "other-tls", true)
+	if got := gcp.Spec.ComponentParams["iap-ingress"]; len(got) != 1 || got[0].Value != "other-tls" {
+		t.Errorf("iap-ingress params = %v, want secretName other-tls", got)
+	}
+	if len(shared) != 1 || shared["iap-ingress"][0].Value != "custom-tls" {
+		t.Errorf("the params the app was created with were modified: %v", shared)
+	}
+}
+
+func TestConfigFileSortsParams(t *testing.T) {
+	gcp := &Gcp{}
+	gcp.Name = "kf"
+	gcp.Spec.ComponentParams = configtypes.Parameters{
+		"spartakus": {{Name: "usageId", Value: "42"}, {Name: "reportUsage", Value: "true"}},
+	}
+	bundle := NewBundle()
+	if err := gcp.putConfigFile(bundle); err != nil {
+		t.Fatal(err)
+	}
+	buf, _ := bundle.Get(kftypes.KfConfigFile)
+	if report, usage := strings.Index(string(buf), "reportUsage"), strings.Index(string(buf), "usageId"); report < 0 ||
+		report > usage {
+		t.Errorf("app.yaml doesn't have the params sorted by name:\n%s", buf)
+	}
+	if got := gcp.Spec.ComponentParams["spartakus"][0].Name; got != "usageId" {
+		t.Errorf("putConfigFile reordered the params of the spec")
+	}
+}
+
+func TestHasUsageId(t *testing.T) {
+	for usageId, want := range map[string]bool{
+		"":                        false,
+		"<randomly-generated-id>": false,
+		"unknown_cluster":         false,
+		"5577006791947779410":     true,
+	} {
+		if got := hasUsageId(usageId); got != want {
+			t.Errorf("hasUsageId(%q) = %v; want %v", usageId, got, want)
+		}
+	}
+}
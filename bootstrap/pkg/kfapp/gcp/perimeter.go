@@ -0,0 +1,178 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"fmt"
+	"github.com/cenkalti/backoff"
+	kfapis "github.com/kubeflow/kubeflow/bootstrap/pkg/apis"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+	accesscontextmanager "google.golang.org/api/accesscontextmanager/v1beta"
+	"google.golang.org/api/googleapi"
+	"strings"
+)
+
+// perimeterApis are enabled with spec.servicePerimeter: Cloud DNS, for the private zones of
+// restricted.googleapis.com, and Access Context Manager, apply checks the perimeter with.
+var perimeterApis = []string{
+	"dns.googleapis.com",
+	"accesscontextmanager.googleapis.com",
+}
+
+// vpcScViolationMarkers are in the errors of the calls VPC Service Controls denies.
+var vpcScViolationMarkers = []string{
+	"vpcServiceControlsUniqueIdentifier",
+	"SECURITY_POLICY_VIOLATED",
+}
+
+// validateServicePerimeter checks spec.servicePerimeter names its access policy and perimeter.
+func (gcp *Gcp) validateServicePerimeter() error {
+	perimeter := gcp.Spec.ServicePerimeter
+	if perimeter == nil {
+		return nil
+	}
+	if perimeter.AccessPolicy == "" || perimeter.Perimeter == "" {
+		return &kfapis.KfError{
+			Code:    int(kfapis.INVALID_ARGUMENT),
+			Message: "servicePerimeter needs its accessPolicy and perimeter",
+		}
+	}
+	if strings.Contains(perimeter.Perimeter, "/") {
+		return &kfapis.KfError{
+			Code: int(kfapis.INVALID_ARGUMENT),
+			Message: fmt.Sprintf("servicePerimeter perimeter %v must be the short name of the perimeter",
+				perimeter.Perimeter),
+		}
+	}
+	return nil
+}
+
+// perimeterName is the resource name of spec.servicePerimeter,
+// accessPolicies/<number>/servicePerimeters/<name>.
+func (gcp *Gcp) perimeterName() string {
+	perimeter := gcp.Spec.ServicePerimeter
+	policy := perimeter.AccessPolicy
+	if !strings.HasPrefix(policy, "accessPolicies/") {
+		policy = "accessPolicies/" + policy
+	}
+	return policy + "/servicePerimeters/" + perimeter.Perimeter
+}
+
+// isVpcScViolation is true when err is, or wraps the message of, the denial of a call by VPC
+// Service Controls, e.g. made from outside the perimeter of the project.
+func isVpcScViolation(err error) bool {
+	if err == nil {
+		return false
+	}
+	text := err.Error()
+	if apiErr, ok := err.(*googleapi.Error); ok {
+		text += apiErr.Body
+	}
+	for _, marker := range vpcScViolationMarkers {
+		if strings.Contains(text, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// perimeterError explains err when VPC Service Controls denied the call: kfctl has to run from
+// a network inside the perimeter, or match one of its access levels. Other errors are returned
+// as they are.
+func (gcp *Gcp) perimeterError(err error) error {
+	if !isVpcScViolation(err) {
+		return err
+	}
+	perimeter := "the VPC Service Controls perimeter of " + gcp.Spec.Project
+	if gcp.Spec.ServicePerimeter != nil {
+		perimeter = gcp.perimeterName()
+	}
+	return &kfapis.KfError{
+		Code: int(kfapis.PERMISSION_DENIED),
+		Message: fmt.Sprintf("%v denied the call; run kfctl from a VM inside it, or add an access level "+
+			"for where it runs: %v", perimeter, err),
+	}
+}
+
+// ensureInPerimeter checks the project is a resource of spec.servicePerimeter before the
+// cluster is created in it, adding it with servicePerimeter.addProject. The project is left in
+// the perimeter on delete.
+func (gcp *Gcp) ensureInPerimeter(ctx context.Context) error {
+	if gcp.Spec.ServicePerimeter == nil {
+		return nil
+	}
+	if err := gcp.validateServicePerimeter(); err != nil {
+		return err
+	}
+	projectNumber, err := gcp.projectNumber(ctx)
+	if err != nil {
+		return err
+	}
+	service, err := accesscontextmanager.New(gcp.client)
+	if err != nil {
+		return fmt.Errorf("Error creating accesscontextmanager service: %v", err)
+	}
+	name := gcp.perimeterName()
+	perimeter, err := service.AccessPolicies.ServicePerimeters.Get(name).Context(ctx).Do()
+	if err != nil {
+		return fmt.Errorf("couldn't get service perimeter %v: %v", name, err)
+	}
+	if perimeter.Status == nil {
+		perimeter.Status = &accesscontextmanager.ServicePerimeterConfig{}
+	}
+	resource := fmt.Sprintf("projects/%v", projectNumber)
+	for _, r := range perimeter.Status.Resources {
+		if r == resource {
+			return nil
+		}
+	}
+	if !gcp.Spec.ServicePerimeter.AddProject {
+		return &kfapis.KfError{
+			Code: int(kfapis.INVALID_ARGUMENT),
+			Message: fmt.Sprintf("project %v isn't in service perimeter %v; add it or set "+
+				"servicePerimeter.addProject", gcp.Spec.Project, name),
+		}
+	}
+	log.Infof("Adding project %v to service perimeter %v", gcp.Spec.Project, name)
+	resources := append(append([]string{}, perimeter.Status.Resources...), resource)
+	op, err := service.AccessPolicies.ServicePerimeters.Patch(name, &accesscontextmanager.ServicePerimeter{
+		Status: &accesscontextmanager.ServicePerimeterConfig{Resources: resources},
+	}).UpdateMask("status.resources").Context(ctx).Do()
+	gcp.audit(AUDIT_PERIMETER_UPDATE, name, map[string]interface{}{
+		"added": []string{resource},
+	}, err)
+	if err != nil {
+		return fmt.Errorf("couldn't add %v to service perimeter %v: %v", gcp.Spec.Project, name, err)
+	}
+	return gcp.retry(func() error {
+		if !op.Done {
+			current, err := service.Operations.Get(op.Name).Context(ctx).Do()
+			if err != nil {
+				return fmt.Errorf("couldn't get operation %v: %v", op.Name, err)
+			}
+			op = current
+		}
+		if !op.Done {
+			return fmt.Errorf("adding %v to service perimeter %v isn't done (op = %v)", gcp.Spec.Project,
+				name, op.Name)
+		}
+		if op.Error != nil {
+			return backoff.Permanent(fmt.Errorf("couldn't add %v to service perimeter %v: %v",
+				gcp.Spec.Project, name, op.Error.Message))
+		}
+		return nil
+	}, backoff.NewExponentialBackOff())
+}
@@ -0,0 +1,78 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	kfdefs "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps/kfdef/v1alpha1"
+	"google.golang.org/api/googleapi"
+)
+
+func TestValidateServicePerimeter(t *testing.T) {
+	cases := []struct {
+		perimeter *kfdefs.ServicePerimeterConfig
+		valid     bool
+		name      string
+	}{
+		{nil, true, ""},
+		{&kfdefs.ServicePerimeterConfig{AccessPolicy: "123", Perimeter: "kubeflow"}, true,
+			"accessPolicies/123/servicePerimeters/kubeflow"},
+		{&kfdefs.ServicePerimeterConfig{AccessPolicy: "accessPolicies/123", Perimeter: "kubeflow"}, true,
+			"accessPolicies/123/servicePerimeters/kubeflow"},
+		{&kfdefs.ServicePerimeterConfig{AccessPolicy: "123"}, false, ""},
+		{&kfdefs.ServicePerimeterConfig{Perimeter: "kubeflow"}, false, ""},
+		{&kfdefs.ServicePerimeterConfig{AccessPolicy: "123",
+			Perimeter: "accessPolicies/123/servicePerimeters/kubeflow"}, false, ""},
+	}
+	for i, c := range cases {
+		gcp := &Gcp{}
+		gcp.Spec.ServicePerimeter = c.perimeter
+		err := gcp.validateServicePerimeter()
+		if c.valid && err != nil {
+			t.Errorf("case %v: validateServicePerimeter failed: %v", i, err)
+		} else if !c.valid && err == nil {
+			t.Errorf("case %v: validateServicePerimeter succeeded", i)
+		}
+		if c.name != "" && gcp.perimeterName() != c.name {
+			t.Errorf("case %v: perimeterName = %v; want %v", i, gcp.perimeterName(), c.name)
+		}
+	}
+}
+
+func TestIsVpcScViolation(t *testing.T) {
+	violation := &googleapi.Error{
+		Code:    403,
+		Message: "Request is prohibited by organization's policy. vpcServiceControlsUniqueIdentifier: 1a2b3c",
+	}
+	cases := []struct {
+		err       error
+		violation bool
+	}{
+		{nil, false},
+		{violation, true},
+		{fmt.Errorf("SetIamPolicy error: %v", violation), true},
+		{&googleapi.Error{Code: 403, Body: `{"error": {"details": [{"violations": [{"type": "SECURITY_POLICY_VIOLATED"}]}]}}`}, true},
+		{&googleapi.Error{Code: 403, Message: "The caller does not have permission"}, false},
+		{errors.New("connection refused"), false},
+	}
+	for i, c := range cases {
+		if isVpcScViolation(c.err) != c.violation {
+			t.Errorf("case %v: isVpcScViolation(%v) = %v", i, c.err, !c.violation)
+		}
+	}
+}
@@ -0,0 +1,247 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"fmt"
+	kftypes "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps"
+	"github.com/kubeflow/kubeflow/bootstrap/pkg/utils"
+	"golang.org/x/net/context"
+	"google.golang.org/api/cloudresourcemanager/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientset "k8s.io/client-go/kubernetes"
+)
+
+// Plan lists what Apply would do for resources, limited to spec.targets when set, in the order
+// Apply does it: the DM deployments it creates or updates, the IAM bindings it adds or removes,
+// the endpoints service, then the namespaces, manifests and secrets of the cluster. The cluster
+// is looked into only once its deployment exists; before, everything in it is to be created.
+func (gcp *Gcp) Plan(resources kftypes.ResourceEnum) ([]kftypes.Action, error) {
+	targets, err := gcp.targets(resources)
+	if err != nil {
+		return nil, err
+	}
+	ctx := context.Background()
+	deploymentmanagerService, err := gcp.newDeploymentManagerClient()
+	if err != nil {
+		return nil, err
+	}
+	actions := []kftypes.Action{}
+	if targets[COMPONENT_CLUSTER] {
+		action, err := gcp.planHostProject(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if action != nil {
+			actions = append(actions, *action)
+		}
+	}
+	if dmTargeted(targets) {
+		deployments, err := gcp.planDeployments(ctx, deploymentmanagerService, targets)
+		if err != nil {
+			return nil, err
+		}
+		actions = append(actions, deployments...)
+	}
+	if targets[TARGET_IAM] {
+		action, err := gcp.planIamPolicy(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if action != nil {
+			actions = append(actions, *action)
+		}
+	}
+	if targets[TARGET_ENDPOINTS] && gcp.isEndpointsHostname() {
+		actions = append(actions, kftypes.Action{
+			Type:   kftypes.UPDATE_ENDPOINTS,
+			Target: gcp.Spec.Hostname,
+		})
+	}
+	if !targets[TARGET_ISTIO] && !targets[TARGET_SECRETS] && !targets[TARGET_GPU_DRIVERS] &&
+		!targets[TARGET_FILESTORE] {
+		return actions, nil
+	}
+
+	var k8sClient *clientset.Clientset
+	_, err = deploymentmanagerService.GetDeployment(ctx, gcp.Spec.Project, gcp.Name)
+	if err != nil && !isNotFound(err) {
+		return nil, fmt.Errorf("couldn't get deployment %v/%v: %v", gcp.Spec.Project, gcp.Name, err)
+	}
+	if err == nil {
+		if k8sClient, err = gcp.getK8sClientset(ctx); err != nil {
+			return nil, fmt.Errorf("Get K8s clientset error: %v", err)
+		}
+	}
+	namespaces := []string{gcp.namespace()}
+	if gcp.Spec.UseIstio {
+		namespaces = append(namespaces, gcp.istioNamespace())
+	}
+	for _, namespace := range namespaces {
+		missing, err := namespaceMissing(k8sClient, namespace)
+		if err != nil {
+			return nil, err
+		}
+		if missing {
+			actions = append(actions, kftypes.Action{Type: kftypes.CREATE_NAMESPACE, Target: namespace})
+		}
+	}
+	manifests := []string{}
+	if gcp.Spec.UseIstio && targets[TARGET_ISTIO] {
+		manifests = append(manifests, gcp.istioManifests()...)
+	}
+	if gcp.Spec.Gpu != nil && targets[TARGET_GPU_DRIVERS] {
+		manifests = append(manifests, NVIDIA_DRIVER_INSTALLER)
+	}
+	for _, manifest := range manifests {
+		actions = append(actions, kftypes.Action{Type: kftypes.APPLY_MANIFEST, Target: manifest})
+	}
+	if gcp.Spec.Filestore != nil && targets[TARGET_FILESTORE] {
+		actions = append(actions, kftypes.Action{
+			Type:   kftypes.APPLY_MANIFEST,
+			Target: "persistentvolumes/" + FILESTORE_VOLUME,
+			Detail: "storage class " + FILESTORE_STORAGE_CLASS,
+		})
+	}
+	if targets[TARGET_SECRETS] {
+		secrets, err := gcp.planSecrets(k8sClient)
+		if err != nil {
+			return nil, err
+		}
+		actions = append(actions, secrets...)
+	}
+	return actions, nil
+}
+
+// planDeployments lists the targeted DM deployments to create, those missing, or update.
+func (gcp *Gcp) planDeployments(ctx context.Context, deploymentmanagerService DeploymentManagerClient,
+	targets map[string]bool) ([]kftypes.Action, error) {
+	actions := []kftypes.Action{}
+	for _, c := range gcp.dmConfigs() {
+		if !targets[c.target] && !gcp.Spec.CombinedDeployment {
+			continue
+		}
+		if !gcp.hasConfig(c.file) {
+			continue
+		}
+		action := kftypes.Action{Type: kftypes.UPDATE_DEPLOYMENT, Target: c.target, Detail: c.deployment}
+		_, err := deploymentmanagerService.GetDeployment(ctx, gcp.Spec.Project, c.deployment)
+		if isNotFound(err) {
+			action.Type = kftypes.CREATE_DEPLOYMENT
+		} else if err != nil {
+			return nil, fmt.Errorf("couldn't get deployment %v/%v: %v", gcp.Spec.Project, c.deployment, err)
+		}
+		actions = append(actions, action)
+	}
+	return actions, nil
+}
+
+// planIamPolicy counts the bindings reconciling the project's policy with iam_bindings.yaml
+// adds and removes; nil when there are none.
+func (gcp *Gcp) planIamPolicy(ctx context.Context) (*kftypes.Action, error) {
+	want, err := gcp.readIamBindings()
+	if err != nil {
+		return nil, fmt.Errorf("Read IAM policy YAML error: %v", err)
+	}
+	resourceManager, err := cloudresourcemanager.New(gcp.client)
+	if err != nil {
+		return nil, err
+	}
+	policy, err := resourceManager.Projects.GetIamPolicy(gcp.Spec.Project,
+		&cloudresourcemanager.GetIamPolicyRequest{}).Context(ctx).Do()
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get the IAM policy of %v: %v", gcp.Spec.Project, err)
+	}
+	before := policyBindings(policy)
+	utils.ReconcileIamPolicy(policy, want, gcp.Name, gcp.Spec.Project)
+	after := policyBindings(policy)
+	added, removed := 0, 0
+	for binding := range after {
+		if !before[binding] {
+			added++
+		}
+	}
+	for binding := range before {
+		if !after[binding] {
+			removed++
+		}
+	}
+	if added == 0 && removed == 0 {
+		return nil, nil
+	}
+	return &kftypes.Action{
+		Type:   kftypes.UPDATE_IAM_POLICY,
+		Target: gcp.Spec.Project,
+		Detail: fmt.Sprintf("+%v -%v bindings", added, removed),
+	}, nil
+}
+
+// policyBindings are the "role member" pairs of policy.
+func policyBindings(policy *cloudresourcemanager.Policy) map[string]bool {
+	bindings := map[string]bool{}
+	for _, binding := range policy.Bindings {
+		for _, member := range binding.Members {
+			bindings[binding.Role+" "+member] = true
+		}
+	}
+	return bindings
+}
+
+// namespaceMissing is true when namespace isn't in the cluster of client, or there's no cluster
+// yet when client is nil.
+func namespaceMissing(client *clientset.Clientset, namespace string) (bool, error) {
+	if client == nil {
+		return true, nil
+	}
+	_, err := client.CoreV1().Namespaces().Get(namespace, metav1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		return true, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("couldn't get namespace %v: %v", namespace, err)
+	}
+	return false, nil
+}
+
+// planSecrets lists the secrets of the service accounts, the certificate and the auth provider
+// missing from the cluster of client, all of them when client is nil.
+func (gcp *Gcp) planSecrets(client *clientset.Clientset) ([]kftypes.Action, error) {
+	secrets := []secretRef{}
+	for _, namespace := range gcp.serviceAcctSecretNamespaces() {
+		secrets = append(secrets, secretRef{namespace, ADMIN_SECRET_NAME}, secretRef{namespace, USER_SECRET_NAME})
+	}
+	if certType := gcp.certType(); certType == CERT_SELF_SIGNED || certType == CERT_BYO_SECRET {
+		secrets = append(secrets, secretRef{gcp.ingressNamespace(), gcp.tlsSecretName()})
+	}
+	secrets = append(secrets, gcp.authProvider().Secrets(gcp)...)
+	actions := []kftypes.Action{}
+	for _, secret := range secrets {
+		if client != nil {
+			_, err := client.CoreV1().Secrets(secret.namespace).Get(secret.name, metav1.GetOptions{})
+			if err == nil {
+				continue
+			}
+			if !k8serrors.IsNotFound(err) {
+				return nil, fmt.Errorf("couldn't get secret %v/%v: %v", secret.namespace, secret.name, err)
+			}
+		}
+		actions = append(actions, kftypes.Action{
+			Type:   kftypes.CREATE_SECRET,
+			Target: secret.namespace + "/" + secret.name,
+		})
+	}
+	return actions, nil
+}
@@ -0,0 +1,96 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"reflect"
+	"testing"
+
+	kftypes "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps"
+	"github.com/kubeflow/kubeflow/bootstrap/pkg/kfapp/gcp/fake"
+	"google.golang.org/api/cloudresourcemanager/v1"
+)
+
+func TestPlanWithFakes(t *testing.T) {
+	dm := fake.NewDeploymentManager("my-project")
+	gcp, err := NewGcp(newFakeKfDef(), Auth{OAuthClientId: "id", OAuthClientSecret: "secret"},
+		WithDeploymentManagerClient(dm), WithClock(fakeClock{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = gcp.Generate(kftypes.PLATFORM); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	plan := func() []kftypes.Action {
+		actions, err := gcp.Plan(kftypes.PLATFORM)
+		if err != nil {
+			t.Fatalf("Plan failed: %v", err)
+		}
+		return actions
+	}
+	want := []kftypes.Action{
+		{Type: kftypes.CREATE_DEPLOYMENT, Target: COMPONENT_STORAGE, Detail: "kf-storage"},
+		{Type: kftypes.CREATE_DEPLOYMENT, Target: COMPONENT_CLUSTER, Detail: "kf"},
+	}
+	if actions := plan(); !reflect.DeepEqual(actions, want) {
+		t.Errorf("planned %v before apply; want %v", actions, want)
+	}
+	for _, call := range dm.Calls {
+		if call != "get my-project/kf-storage" && call != "get my-project/kf" {
+			t.Errorf("Plan made call %v", call)
+		}
+	}
+
+	if err = gcp.Apply(kftypes.PLATFORM); err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+	want = []kftypes.Action{
+		{Type: kftypes.UPDATE_DEPLOYMENT, Target: COMPONENT_STORAGE, Detail: "kf-storage"},
+		{Type: kftypes.UPDATE_DEPLOYMENT, Target: COMPONENT_CLUSTER, Detail: "kf"},
+	}
+	if actions := plan(); !reflect.DeepEqual(actions, want) {
+		t.Errorf("planned %v after apply; want %v", actions, want)
+	}
+}
+
+func TestPolicyBindings(t *testing.T) {
+	policy := &cloudresourcemanager.Policy{
+		Bindings: []*cloudresourcemanager.Binding{
+			{Role: "roles/viewer", Members: []string{"user:a@example.com", "user:b@example.com"}},
+			{Role: "roles/editor", Members: []string{"user:a@example.com"}},
+		},
+	}
+	want := map[string]bool{
+		"roles/viewer user:a@example.com": true,
+		"roles/viewer user:b@example.com": true,
+		"roles/editor user:a@example.com": true,
+	}
+	if got := policyBindings(policy); !reflect.DeepEqual(got, want) {
+		t.Errorf("policyBindings = %v; want %v", got, want)
+	}
+}
+
+func TestActionString(t *testing.T) {
+	cases := map[string]kftypes.Action{
+		"CreateSecret kubeflow/admin-gcp-sa": {Type: kftypes.CREATE_SECRET, Target: "kubeflow/admin-gcp-sa"},
+		"UpdateIamPolicy my-project +5 -0 bindings": {Type: kftypes.UPDATE_IAM_POLICY, Target: "my-project",
+			Detail: "+5 -0 bindings"},
+	}
+	for want, action := range cases {
+		if got := action.String(); got != want {
+			t.Errorf("String() = %q; want %q", got, want)
+		}
+	}
+}
@@ -0,0 +1,206 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"fmt"
+	log "github.com/sirupsen/logrus"
+	"k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientset "k8s.io/client-go/kubernetes"
+	"reflect"
+)
+
+const (
+	PROFILES_COMPONENT = "profiles"
+	// PROFILE_KIND owns the namespaces the profile controller creates for users.
+	PROFILE_KIND = "Profile"
+	// PROPAGATED_FROM_ANNOTATION is set on the secrets copied into profile namespaces with the
+	// namespace they're copied from.
+	PROPAGATED_FROM_ANNOTATION = "kubeflow.org/propagated-from"
+	// SYNC_SECRETS_LABEL set to "true" on a namespace gets it the propagated secrets like a
+	// profile namespace.
+	SYNC_SECRETS_LABEL = "kubeflow.org/sync-secrets"
+)
+
+// profilesEnabled is true when users get their own namespace from the profile controller.
+func (gcp *Gcp) profilesEnabled() bool {
+	if !gcp.Spec.UseIstio {
+		return false
+	}
+	for _, component := range gcp.Spec.Components {
+		if component == PROFILES_COMPONENT {
+			return true
+		}
+	}
+	return false
+}
+
+// isProfileNamespace is true when ns was created by the profile controller.
+func isProfileNamespace(ns *v1.Namespace) bool {
+	for _, owner := range ns.OwnerReferences {
+		if owner.Kind == PROFILE_KIND {
+			return true
+		}
+	}
+	return false
+}
+
+// isSyncedNamespace is true when the propagated secrets are copied into ns: profile namespaces
+// when profiles are enabled, those labeled with SYNC_SECRETS_LABEL, and those the secrets-sync
+// controller copies them into with spec.secretsSync.
+func (gcp *Gcp) isSyncedNamespace(ns *v1.Namespace) bool {
+	if ns.Status.Phase == v1.NamespaceTerminating || ns.Name == gcp.namespace() {
+		return false
+	}
+	if ns.Labels[SYNC_SECRETS_LABEL] == "true" {
+		return true
+	}
+	if _, ok := ns.Labels[PROFILE_NAMESPACE_LABEL]; ok && gcp.Spec.SecretsSync {
+		return true
+	}
+	return gcp.profilesEnabled() && isProfileNamespace(ns)
+}
+
+// propagatedSecrets are the secrets of the app namespace profile namespaces need: the user
+// GCP service account key, used by pipelines, and the image pull secrets.
+func (gcp *Gcp) propagatedSecrets(client *clientset.Clientset) ([]v1.Secret, error) {
+	list, err := client.CoreV1().Secrets(gcp.namespace()).List(metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("couldn't list secrets of %v: %v", gcp.namespace(), err)
+	}
+	secrets := []v1.Secret{}
+	for _, secret := range list.Items {
+		if secret.Name == USER_SECRET_NAME || isImagePullSecret(&secret) {
+			secrets = append(secrets, secret)
+		}
+	}
+	return secrets, nil
+}
+
+func isImagePullSecret(secret *v1.Secret) bool {
+	return secret.Type == v1.SecretTypeDockerConfigJson || secret.Type == v1.SecretTypeDockercfg
+}
+
+// propagateSecrets copies the propagatedSecrets into every synced namespace, so the pipelines of
+// users created since the last apply don't fail on missing credentials, and adds the image pull
+// secrets to the namespace's default service account.
+func (gcp *Gcp) propagateSecrets(client *clientset.Clientset) error {
+	secrets, err := gcp.propagatedSecrets(client)
+	if err != nil {
+		return err
+	}
+	if len(secrets) == 0 {
+		return nil
+	}
+	namespaces, err := client.CoreV1().Namespaces().List(metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("couldn't list namespaces: %v", err)
+	}
+	for i := range namespaces.Items {
+		ns := &namespaces.Items[i]
+		if !gcp.isSyncedNamespace(ns) {
+			continue
+		}
+		pullSecrets := []v1.LocalObjectReference{}
+		for _, secret := range secrets {
+			if err = gcp.copySecret(client, &secret, ns.Name); err != nil {
+				return err
+			}
+			if isImagePullSecret(&secret) {
+				pullSecrets = append(pullSecrets, v1.LocalObjectReference{Name: secret.Name})
+			}
+		}
+		if err = addImagePullSecrets(client, ns.Name, pullSecrets); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// copySecret creates or updates the copy of secret in namespace.
+func (gcp *Gcp) copySecret(client *clientset.Clientset, secret *v1.Secret, namespace string) error {
+	existing, err := client.CoreV1().Secrets(namespace).Get(secret.Name, metav1.GetOptions{})
+	if err == nil {
+		if existing.Type == secret.Type && reflect.DeepEqual(existing.Data, secret.Data) {
+			return nil
+		}
+		existing.Data = secret.Data
+		log.Infof("Updating secret %v in namespace %v", secret.Name, namespace)
+		_, err = client.CoreV1().Secrets(namespace).Update(existing)
+		gcp.auditSecret(AUDIT_SECRET_UPDATE, namespace, secret.Name, secret.Data, err)
+		if err != nil {
+			return fmt.Errorf("couldn't update secret %v/%v: %v", namespace, secret.Name, err)
+		}
+		return nil
+	}
+	if !k8serrors.IsNotFound(err) {
+		return fmt.Errorf("couldn't get secret %v/%v: %v", namespace, secret.Name, err)
+	}
+	log.Infof("Creating secret %v in namespace %v", secret.Name, namespace)
+	_, err = client.CoreV1().Secrets(namespace).Create(&v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      secret.Name,
+			Namespace: namespace,
+			Annotations: map[string]string{
+				PROPAGATED_FROM_ANNOTATION: gcp.namespace(),
+			},
+		},
+		Type: secret.Type,
+		Data: secret.Data,
+	})
+	gcp.auditSecret(AUDIT_SECRET_CREATE, namespace, secret.Name, secret.Data, err)
+	if err != nil {
+		return fmt.Errorf("couldn't create secret %v/%v: %v", namespace, secret.Name, err)
+	}
+	return nil
+}
+
+// addImagePullSecrets adds pullSecrets to the default service account of namespace. It's skipped
+// when the service account doesn't exist yet; the next apply adds them.
+func addImagePullSecrets(client *clientset.Clientset, namespace string, pullSecrets []v1.LocalObjectReference) error {
+	if len(pullSecrets) == 0 {
+		return nil
+	}
+	sa, err := client.CoreV1().ServiceAccounts(namespace).Get("default", metav1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("couldn't get service account %v/default: %v", namespace, err)
+	}
+	added := false
+	for _, pullSecret := range pullSecrets {
+		found := false
+		for _, existing := range sa.ImagePullSecrets {
+			if existing.Name == pullSecret.Name {
+				found = true
+				break
+			}
+		}
+		if !found {
+			sa.ImagePullSecrets = append(sa.ImagePullSecrets, pullSecret)
+			added = true
+		}
+	}
+	if !added {
+		return nil
+	}
+	if _, err = client.CoreV1().ServiceAccounts(namespace).Update(sa); err != nil {
+		return fmt.Errorf("couldn't update service account %v/default: %v", namespace, err)
+	}
+	return nil
+}
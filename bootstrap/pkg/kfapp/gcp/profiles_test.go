@@ -0,0 +1,151 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+// recordingKubeApis answers the GETs with apis and records the other calls, with the body last
+// sent by method and path.
+type recordingKubeApis struct {
+	apis    fakeKubeApis
+	calls   []string
+	written map[string][]byte
+}
+
+func (f *recordingKubeApis) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodGet {
+		f.apis.ServeHTTP(w, r)
+		return
+	}
+	body, _ := ioutil.ReadAll(r.Body)
+	f.calls = append(f.calls, r.Method+" "+r.URL.Path)
+	f.written[r.Method+" "+r.URL.Path] = body
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(body)
+}
+
+func newRecordingClientset(t *testing.T, apis fakeKubeApis) (*clientset.Clientset, *recordingKubeApis, func()) {
+	recorder := &recordingKubeApis{apis: apis, written: map[string][]byte{}}
+	server := httptest.NewServer(recorder)
+	k8sClient, err := clientset.NewForConfig(&rest.Config{Host: server.URL})
+	if err != nil {
+		server.Close()
+		t.Fatal(err)
+	}
+	return k8sClient, recorder, server.Close
+}
+
+func TestIsSyncedNamespace(t *testing.T) {
+	gcp := newDoctorGcp()
+	profileNs := &v1.Namespace{ObjectMeta: metav1.ObjectMeta{
+		Name:            "jane",
+		OwnerReferences: []metav1.OwnerReference{{Kind: PROFILE_KIND, Name: "jane"}},
+	}}
+	labeledNs := &v1.Namespace{ObjectMeta: metav1.ObjectMeta{
+		Name:   "team",
+		Labels: map[string]string{SYNC_SECRETS_LABEL: "true"},
+	}}
+	appNs := &v1.Namespace{ObjectMeta: metav1.ObjectMeta{
+		Name:   "kubeflow",
+		Labels: map[string]string{SYNC_SECRETS_LABEL: "true"},
+	}}
+	if gcp.isSyncedNamespace(profileNs) {
+		t.Errorf("profile namespaces shouldn't be synced without profiles")
+	}
+	if !gcp.isSyncedNamespace(labeledNs) {
+		t.Errorf("a namespace labeled with %v should be synced", SYNC_SECRETS_LABEL)
+	}
+	if gcp.isSyncedNamespace(appNs) {
+		t.Errorf("the app namespace shouldn't be synced")
+	}
+
+	gcp.Spec.UseIstio = true
+	gcp.Spec.Components = []string{"jupyter", PROFILES_COMPONENT}
+	if !gcp.isSyncedNamespace(profileNs) {
+		t.Errorf("profile namespaces should be synced with profiles")
+	}
+	profileNs.Status.Phase = v1.NamespaceTerminating
+	if gcp.isSyncedNamespace(profileNs) {
+		t.Errorf("a terminating namespace shouldn't be synced")
+	}
+}
+
+func TestPropagateSecrets(t *testing.T) {
+	client, recorder, done := newRecordingClientset(t, fakeKubeApis{
+		"/api/v1/namespaces/kubeflow/secrets": `{"items": [
+			{"metadata": {"name": "user-gcp-sa"}, "data": {"user-gcp-sa.json": "a2V5"}},
+			{"metadata": {"name": "admin-gcp-sa"}, "data": {"admin-gcp-sa.json": "a2V5"}},
+			{"metadata": {"name": "registry"}, "type": "kubernetes.io/dockerconfigjson",
+				"data": {".dockerconfigjson": "e30="}}]}`,
+		"/api/v1/namespaces": `{"items": [
+			{"metadata": {"name": "kubeflow"}},
+			{"metadata": {"name": "default"}},
+			{"metadata": {"name": "jane", "ownerReferences": [
+				{"apiVersion": "kubeflow.org/v1alpha1", "kind": "Profile", "name": "jane", "uid": "1"}]}},
+			{"metadata": {"name": "joe", "ownerReferences": [
+				{"apiVersion": "kubeflow.org/v1alpha1", "kind": "Profile", "name": "joe", "uid": "2"}]}}]}`,
+		// joe already has an up to date copy of user-gcp-sa, and registry in its service account.
+		"/api/v1/namespaces/joe/secrets/user-gcp-sa": `{"metadata": {"name": "user-gcp-sa"},
+			"data": {"user-gcp-sa.json": "a2V5"}}`,
+		"/api/v1/namespaces/jane/serviceaccounts/default": `{"metadata": {"name": "default"}}`,
+		"/api/v1/namespaces/joe/serviceaccounts/default": `{"metadata": {"name": "default"},
+			"imagePullSecrets": [{"name": "registry"}]}`,
+	})
+	defer done()
+	gcp := newDoctorGcp()
+	gcp.Spec.UseIstio = true
+	gcp.Spec.Components = []string{PROFILES_COMPONENT}
+	if err := gcp.propagateSecrets(client); err != nil {
+		t.Fatalf("propagateSecrets: %v", err)
+	}
+
+	expected := []string{
+		"POST /api/v1/namespaces/jane/secrets",
+		"POST /api/v1/namespaces/jane/secrets",
+		"PUT /api/v1/namespaces/jane/serviceaccounts/default",
+		"POST /api/v1/namespaces/joe/secrets",
+	}
+	if !reflect.DeepEqual(recorder.calls, expected) {
+		t.Errorf("propagateSecrets called %v; want %v", recorder.calls, expected)
+	}
+
+	secret := &v1.Secret{}
+	if err := json.Unmarshal(recorder.written["POST /api/v1/namespaces/joe/secrets"], secret); err != nil {
+		t.Fatal(err)
+	}
+	if secret.Name != "registry" || secret.Type != v1.SecretTypeDockerConfigJson ||
+		secret.Annotations[PROPAGATED_FROM_ANNOTATION] != "kubeflow" {
+		t.Errorf("propagateSecrets created %v", secret)
+	}
+	sa := &v1.ServiceAccount{}
+	if err := json.Unmarshal(recorder.written["PUT /api/v1/namespaces/jane/serviceaccounts/default"], sa); err != nil {
+		t.Fatal(err)
+	}
+	if len(sa.ImagePullSecrets) != 1 || sa.ImagePullSecrets[0].Name != "registry" {
+		t.Errorf("propagateSecrets set the image pull secrets %v", sa.ImagePullSecrets)
+	}
+}
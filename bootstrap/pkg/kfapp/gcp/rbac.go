@@ -0,0 +1,265 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"fmt"
+	kfapis "github.com/kubeflow/kubeflow/bootstrap/pkg/apis"
+	kftypes "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps"
+	log "github.com/sirupsen/logrus"
+	rbacv1 "k8s.io/api/rbac/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientset "k8s.io/client-go/kubernetes"
+	"reflect"
+)
+
+const (
+	// DEFAULT_ADMIN_BINDING binds the email of the app to spec.adminRole, prefixed with
+	// spec.namespacePrefix.
+	DEFAULT_ADMIN_BINDING = "default-admin"
+	// KUBEFLOW_ADMIN_AGGREGATE_LABEL set to "true" on a ClusterRole adds its rules to kubeflow-admin,
+	// e.g. for the CRDs of extra components.
+	KUBEFLOW_ADMIN_AGGREGATE_LABEL = "rbac.authorization.kubeflow.org/aggregate-to-kubeflow-admin"
+	// KUBEFLOW_ADMIN_BASE_ROLE has the cluster-wide, read-only, rules of kubeflow-admin, aggregated
+	// into it.
+	KUBEFLOW_ADMIN_BASE_ROLE = "kubeflow-admin-base"
+	// KUBEFLOW_ADMIN_NAMESPACE_ROLE has the rules of kubeflow-admin on the objects Kubeflow deploys,
+	// bound in the namespaces of the app only.
+	KUBEFLOW_ADMIN_NAMESPACE_ROLE = "kubeflow-admin-namespace"
+)
+
+// validateAdminRole checks spec.adminRole is a known role.
+func (gcp *Gcp) validateAdminRole() error {
+	switch kftypes.AdminRole(&gcp.Spec) {
+	case kftypes.ADMIN_ROLE_CLUSTER_ADMIN, kftypes.ADMIN_ROLE_KUBEFLOW_ADMIN, kftypes.ADMIN_ROLE_NONE:
+		return nil
+	default:
+		return &kfapis.KfError{
+			Code: int(kfapis.INVALID_ARGUMENT),
+			Message: fmt.Sprintf("unknown adminRole %v; must be %v, %v or %v", gcp.Spec.AdminRole,
+				kftypes.ADMIN_ROLE_CLUSTER_ADMIN, kftypes.ADMIN_ROLE_KUBEFLOW_ADMIN, kftypes.ADMIN_ROLE_NONE),
+		}
+	}
+}
+
+// validateAdminMembers checks spec.adminMembers are members kfctl can bind.
+func (gcp *Gcp) validateAdminMembers() error {
+	for _, member := range gcp.Spec.AdminMembers {
+		if _, err := tenantSubject(member); err != nil {
+			return &kfapis.KfError{
+				Code:    int(kfapis.INVALID_ARGUMENT),
+				Message: fmt.Sprintf("adminMembers: %v", err),
+			}
+		}
+	}
+	return nil
+}
+
+// configAdmin binds the email of the app and spec.adminMembers to spec.adminRole, creating the
+// kubeflow-admin roles first when it's used. With none the binding is deleted, and they're left
+// with the roles granted by the cluster's admins.
+func (gcp *Gcp) configAdmin(k8sClientset *clientset.Clientset) error {
+	if err := gcp.validateAdminRole(); err != nil {
+		return err
+	}
+	if err := gcp.validateAdminMembers(); err != nil {
+		return err
+	}
+	role := kftypes.AdminRole(&gcp.Spec)
+	// The namespaced rules of kubeflow-admin are bound in the namespaces of the app; the bindings
+	// are deleted with the other roles.
+	namespaceSubjects := []rbacv1.Subject{}
+	switch role {
+	case kftypes.ADMIN_ROLE_NONE:
+		if err := gcp.bindAdminNamespaces(k8sClientset, namespaceSubjects); err != nil {
+			return err
+		}
+		log.Infof("Deleting %v, adminRole is %v", gcp.adminBinding(), role)
+		err := k8sClientset.RbacV1().ClusterRoleBindings().Delete(gcp.adminBinding(), &metav1.DeleteOptions{})
+		if k8serrors.IsNotFound(err) {
+			return nil
+		}
+		gcp.audit(AUDIT_RBAC_DELETE, "clusterrolebindings/"+gcp.adminBinding(), nil, err)
+		return err
+	case kftypes.ADMIN_ROLE_KUBEFLOW_ADMIN:
+		for _, clusterRole := range append(kubeflowAdminRoles(), kubeflowAdminNamespaceRole()) {
+			if err := gcp.applyClusterRole(k8sClientset, clusterRole); err != nil {
+				return err
+			}
+		}
+		namespaceSubjects = tenantSubjects(gcp.adminMembers())
+	}
+	if err := gcp.bindAdminNamespaces(k8sClientset, namespaceSubjects); err != nil {
+		return err
+	}
+	return gcp.bindAdmin(k8sClientset, gcp.adminBinding(), gcp.adminMembers(), role)
+}
+
+// adminNamespaces are the namespaces the admins are bound to KUBEFLOW_ADMIN_NAMESPACE_ROLE in
+// with kubeflow-admin: those of the app and of Istio.
+func (gcp *Gcp) adminNamespaces() []string {
+	namespaces := []string{gcp.namespace()}
+	if gcp.Spec.UseIstio {
+		namespaces = append(namespaces, gcp.istioNamespace())
+	}
+	return namespaces
+}
+
+// bindAdminNamespaces binds subjects to KUBEFLOW_ADMIN_NAMESPACE_ROLE in adminNamespaces with a
+// RoleBinding named like the ClusterRoleBinding of the admins, deleting it when there are none.
+func (gcp *Gcp) bindAdminNamespaces(k8sClientset *clientset.Clientset, subjects []rbacv1.Subject) error {
+	for _, namespace := range gcp.adminNamespaces() {
+		if err := applyRoleBinding(k8sClientset, namespace, gcp.adminBinding(), KUBEFLOW_ADMIN_NAMESPACE_ROLE,
+			subjects); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// kubeflowAdminRoles are the ClusterRoles of kubeflow-admin bound cluster-wide: the role
+// aggregating those labeled with KUBEFLOW_ADMIN_AGGREGATE_LABEL, and the base role reading what
+// Kubeflow needs of the cluster. Nothing cluster-scoped can be changed with it, nor roles bound
+// beyond the admin's own: the CRDs, ClusterRoles and webhooks of the components are applied by a
+// cluster-admin.
+func kubeflowAdminRoles() []*rbacv1.ClusterRole {
+	read := []string{"get", "list", "watch"}
+	return []*rbacv1.ClusterRole{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: kftypes.ADMIN_ROLE_KUBEFLOW_ADMIN,
+			},
+			AggregationRule: &rbacv1.AggregationRule{
+				ClusterRoleSelectors: []metav1.LabelSelector{
+					{
+						MatchLabels: map[string]string{
+							KUBEFLOW_ADMIN_AGGREGATE_LABEL: "true",
+						},
+					},
+				},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: KUBEFLOW_ADMIN_BASE_ROLE,
+				Labels: map[string]string{
+					KUBEFLOW_ADMIN_AGGREGATE_LABEL: "true",
+				},
+			},
+			Rules: []rbacv1.PolicyRule{
+				{
+					APIGroups: []string{""},
+					Resources: []string{"namespaces", "nodes", "persistentvolumes"},
+					Verbs:     read,
+				},
+				{
+					APIGroups: []string{"rbac.authorization.k8s.io"},
+					Resources: []string{"clusterroles", "clusterrolebindings"},
+					Verbs:     read,
+				},
+				{
+					APIGroups: []string{"apiextensions.k8s.io"},
+					Resources: []string{"customresourcedefinitions"},
+					Verbs:     read,
+				},
+				{
+					APIGroups: []string{"storage.k8s.io"},
+					Resources: []string{"storageclasses"},
+					Verbs:     read,
+				},
+			},
+		},
+	}
+}
+
+// kubeflowAdminNamespaceRole is the ClusterRole of kubeflow-admin bound in adminNamespaces, with
+// the objects Kubeflow deploys there, its secrets included.
+func kubeflowAdminNamespaceRole() *rbacv1.ClusterRole {
+	all := []string{"*"}
+	return &rbacv1.ClusterRole{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: KUBEFLOW_ADMIN_NAMESPACE_ROLE,
+		},
+		Rules: []rbacv1.PolicyRule{
+			{
+				APIGroups: []string{""},
+				Resources: []string{"pods", "pods/log", "pods/exec", "pods/portforward", "services", "endpoints",
+					"configmaps", "secrets", "serviceaccounts", "persistentvolumeclaims", "events"},
+				Verbs: all,
+			},
+			{
+				APIGroups: []string{"apps", "extensions"},
+				Resources: []string{"deployments", "statefulsets", "daemonsets", "replicasets", "ingresses"},
+				Verbs:     all,
+			},
+			{
+				APIGroups: []string{"batch", "autoscaling", "policy", "networking.k8s.io"},
+				Resources: all,
+				Verbs:     all,
+			},
+			{
+				APIGroups: []string{"rbac.authorization.k8s.io"},
+				Resources: []string{"roles", "rolebindings"},
+				Verbs:     []string{"get", "list", "watch", "create", "update", "patch", "delete"},
+			},
+			{
+				APIGroups: []string{"kubeflow.org", "app.k8s.io", "argoproj.io", "certmanager.k8s.io",
+					"metacontroller.k8s.io", "networking.istio.io", "rbac.istio.io",
+					"authentication.istio.io", "config.istio.io", "getambassador.io"},
+				Resources: all,
+				Verbs:     all,
+			},
+		},
+	}
+}
+
+// applyClusterRole creates clusterRole, or updates it when it differs.
+func (gcp *Gcp) applyClusterRole(k8sClientset *clientset.Clientset, clusterRole *rbacv1.ClusterRole) error {
+	existing, err := k8sClientset.RbacV1().ClusterRoles().Get(clusterRole.Name, metav1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		log.Infof("Creating cluster role %v", clusterRole.Name)
+		_, err = k8sClientset.RbacV1().ClusterRoles().Create(clusterRole)
+		gcp.audit(AUDIT_RBAC_CREATE, "clusterroles/"+clusterRole.Name, nil, err)
+		if err != nil {
+			return fmt.Errorf("couldn't create cluster role %v: %v", clusterRole.Name, err)
+		}
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("couldn't get cluster role %v: %v", clusterRole.Name, err)
+	}
+	if clusterRole.AggregationRule != nil {
+		// The rules of an aggregated role are maintained by the controller manager.
+		if reflect.DeepEqual(existing.AggregationRule, clusterRole.AggregationRule) {
+			return nil
+		}
+		existing.AggregationRule = clusterRole.AggregationRule
+	} else {
+		if reflect.DeepEqual(existing.Rules, clusterRole.Rules) &&
+			reflect.DeepEqual(existing.Labels, clusterRole.Labels) {
+			return nil
+		}
+		existing.Rules = clusterRole.Rules
+		existing.Labels = clusterRole.Labels
+	}
+	log.Infof("Updating cluster role %v", clusterRole.Name)
+	_, err = k8sClientset.RbacV1().ClusterRoles().Update(existing)
+	gcp.audit(AUDIT_RBAC_UPDATE, "clusterroles/"+clusterRole.Name, nil, err)
+	if err != nil {
+		return fmt.Errorf("couldn't update cluster role %v: %v", clusterRole.Name, err)
+	}
+	return nil
+}
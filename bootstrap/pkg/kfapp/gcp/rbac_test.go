@@ -0,0 +1,174 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"encoding/json"
+	"path"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ghodss/yaml"
+	kftypes "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps"
+	rbacv1 "k8s.io/api/rbac/v1"
+)
+
+func TestValidateAdminRole(t *testing.T) {
+	cases := []struct {
+		adminRole string
+		valid     bool
+	}{
+		{"", true},
+		{kftypes.ADMIN_ROLE_CLUSTER_ADMIN, true},
+		{kftypes.ADMIN_ROLE_KUBEFLOW_ADMIN, true},
+		{kftypes.ADMIN_ROLE_NONE, true},
+		{"admin", false},
+	}
+	for _, c := range cases {
+		gcp := &Gcp{}
+		gcp.Spec.AdminRole = c.adminRole
+		err := gcp.validateAdminRole()
+		if c.valid && err != nil {
+			t.Errorf("adminRole %q: validateAdminRole failed: %v", c.adminRole, err)
+		} else if !c.valid && err == nil {
+			t.Errorf("adminRole %q: validateAdminRole succeeded", c.adminRole)
+		}
+	}
+}
+
+func TestKubeflowAdminRoles(t *testing.T) {
+	roles := kubeflowAdminRoles()
+	aggregated := roles[0]
+	if aggregated.Name != kftypes.ADMIN_ROLE_KUBEFLOW_ADMIN || aggregated.AggregationRule == nil ||
+		len(aggregated.Rules) > 0 {
+		t.Fatalf("%v isn't an aggregated role: %+v", kftypes.ADMIN_ROLE_KUBEFLOW_ADMIN, aggregated)
+	}
+	selector := aggregated.AggregationRule.ClusterRoleSelectors[0].MatchLabels
+	for _, role := range roles[1:] {
+		for k, v := range selector {
+			if role.Labels[k] != v {
+				t.Errorf("%v isn't aggregated into %v: its labels are %v", role.Name, aggregated.Name, role.Labels)
+			}
+		}
+		// The cluster-wide rules only read.
+		for _, rule := range role.Rules {
+			for _, verb := range rule.Verbs {
+				if verb != "get" && verb != "list" && verb != "watch" {
+					t.Errorf("%v grants %v on %v cluster-wide", role.Name, verb, rule.Resources)
+				}
+			}
+		}
+	}
+
+	namespaceRole := kubeflowAdminNamespaceRole()
+	if _, ok := namespaceRole.Labels[KUBEFLOW_ADMIN_AGGREGATE_LABEL]; ok {
+		t.Errorf("%v is aggregated into %v, binding it cluster-wide", namespaceRole.Name, aggregated.Name)
+	}
+	for _, rule := range namespaceRole.Rules {
+		for _, group := range rule.APIGroups {
+			if group == "*" || group == "apiextensions.k8s.io" || group == "admissionregistration.k8s.io" {
+				t.Errorf("%v grants API group %q", namespaceRole.Name, group)
+			}
+		}
+		for _, verb := range rule.Verbs {
+			if verb == "bind" || verb == "escalate" {
+				t.Errorf("%v grants %v on %v", namespaceRole.Name, verb, rule.Resources)
+			}
+		}
+		for _, resource := range rule.Resources {
+			if resource == "clusterroles" || resource == "clusterrolebindings" {
+				t.Errorf("%v grants %v", namespaceRole.Name, resource)
+			}
+		}
+	}
+}
+
+func TestConfigAdminKubeflowAdmin(t *testing.T) {
+	k8sClient, recorder, done := newRecordingClientset(t, fakeKubeApis{})
+	defer done()
+	gcp := newDoctorGcp()
+	gcp.Spec.UseIstio = true
+	gcp.Spec.Email = "jane@example.com"
+	gcp.Spec.AdminRole = kftypes.ADMIN_ROLE_KUBEFLOW_ADMIN
+	if err := gcp.configAdmin(k8sClient); err != nil {
+		t.Fatalf("configAdmin failed: %v", err)
+	}
+	want := []string{
+		"POST /apis/rbac.authorization.k8s.io/v1/clusterroles",
+		"POST /apis/rbac.authorization.k8s.io/v1/clusterroles",
+		"POST /apis/rbac.authorization.k8s.io/v1/clusterroles",
+		"POST /apis/rbac.authorization.k8s.io/v1/namespaces/kubeflow/rolebindings",
+		"POST /apis/rbac.authorization.k8s.io/v1/namespaces/istio-system/rolebindings",
+		"POST /apis/rbac.authorization.k8s.io/v1/clusterrolebindings",
+	}
+	if !reflect.DeepEqual(recorder.calls, want) {
+		t.Errorf("configAdmin calls got %v; want %v", recorder.calls, want)
+	}
+	binding := rbacv1.RoleBinding{}
+	if err := json.Unmarshal(recorder.written[want[3]], &binding); err != nil {
+		t.Fatalf("couldn't read the role binding: %v", err)
+	}
+	if binding.RoleRef.Name != KUBEFLOW_ADMIN_NAMESPACE_ROLE || len(binding.Subjects) != 1 ||
+		binding.Subjects[0].Name != "jane@example.com" {
+		t.Errorf("the role binding of kubeflow got %+v; want jane@example.com bound to %v", binding,
+			KUBEFLOW_ADMIN_NAMESPACE_ROLE)
+	}
+}
+
+func TestAdminMembers(t *testing.T) {
+	gcp := &Gcp{}
+	gcp.Name = "kf"
+	gcp.Spec.Project = "my-project"
+	gcp.Spec.Email = "jane@example.com"
+	gcp.Spec.AdminMembers = []string{"group:ml-admins@example.com", "ci@my-project.iam.gserviceaccount.com",
+		"user:jane@example.com"}
+	if err := gcp.validateAdminMembers(); err != nil {
+		t.Fatalf("validateAdminMembers failed: %v", err)
+	}
+	want := []string{"user:jane@example.com", "group:ml-admins@example.com",
+		"serviceAccount:ci@my-project.iam.gserviceaccount.com"}
+	if members := gcp.adminMembers(); !reflect.DeepEqual(members, want) {
+		t.Errorf("adminMembers = %v; want %v", members, want)
+	}
+
+	template := `bindings:
+- members:
+  - set-kubeflow-iap-account
+  roles:
+  - roles/iap.httpsResourceAccessor
+`
+	bundle := NewBundle()
+	if err := gcp.writeIamBindingsFile(bundle, []byte(template), "iam_bindings_template.yaml"); err != nil {
+		t.Fatalf("writeIamBindingsFile failed: %v", err)
+	}
+	buf, _ := bundle.Get(path.Join(GCP_CONFIG, IAM_BINDINGS_FILE))
+	bindings := struct {
+		Bindings []struct {
+			Members []string `json:"members"`
+		} `json:"bindings"`
+	}{}
+	if err := yaml.Unmarshal(buf, &bindings); err != nil {
+		t.Fatalf("couldn't read %v: %v", IAM_BINDINGS_FILE, err)
+	}
+	if len(bindings.Bindings) != 1 || !reflect.DeepEqual(bindings.Bindings[0].Members, want) {
+		t.Errorf("set-kubeflow-iap-account expanded to %v; want %v", bindings.Bindings, want)
+	}
+
+	gcp.Spec.AdminMembers = []string{"domain:example.com"}
+	if err := gcp.validateAdminMembers(); err == nil || !strings.Contains(err.Error(), "domain:example.com") {
+		t.Errorf("validateAdminMembers should reject domain:example.com; got %v", err)
+	}
+}
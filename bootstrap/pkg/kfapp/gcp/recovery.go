@@ -0,0 +1,158 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"fmt"
+	kfapis "github.com/kubeflow/kubeflow/bootstrap/pkg/apis"
+	kftypes "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+	"google.golang.org/api/deploymentmanager/v2"
+	"strings"
+	"time"
+)
+
+// Values of spec.dmRecovery, set with kfctl apply --dm-recovery.
+const (
+	// DM_RECOVERY_STOP stops the operation in progress, then updates the deployment.
+	DM_RECOVERY_STOP = "stop"
+	// DM_RECOVERY_ABANDON deletes the deployment leaving its resources in the project, then
+	// creates it again taking them over.
+	DM_RECOVERY_ABANDON = "abandon"
+	// DM_RECOVERY_RECREATE deletes the deployment with its resources, then creates it again.
+	DM_RECOVERY_RECREATE = "recreate"
+)
+
+// DM_STUCK_AFTER is how long an operation of a deployment can be pending or running before
+// apply takes it for stuck, e.g. left by a kfctl killed mid-apply.
+const DM_STUCK_AFTER = 30 * time.Minute
+
+// deploymentProblem says why d needs recovering, empty when it doesn't: its last operation
+// failed, or it's been in progress for more than DM_STUCK_AFTER at now.
+func deploymentProblem(d *deploymentmanager.Deployment, now time.Time) string {
+	op := d.Operation
+	if op == nil {
+		return ""
+	}
+	if op.Status == "DONE" {
+		if op.HttpErrorStatusCode > 0 {
+			return fmt.Sprintf("its last operation %v failed (%v): %v", op.Name, op.HttpErrorStatusCode,
+				op.HttpErrorMessage)
+		}
+		if op.Error != nil && len(op.Error.Errors) > 0 {
+			messages := []string{}
+			for _, e := range op.Error.Errors {
+				messages = append(messages, e.Message)
+			}
+			return fmt.Sprintf("its last operation %v failed: %v", op.Name, strings.Join(messages, "; "))
+		}
+		return ""
+	}
+	started := op.StartTime
+	if started == "" {
+		started = op.InsertTime
+	}
+	t, err := time.Parse(time.RFC3339, started)
+	if err != nil || now.Sub(t) < DM_STUCK_AFTER {
+		return ""
+	}
+	return fmt.Sprintf("its operation %v has been %v since %v", op.Name, op.Status, started)
+}
+
+// recoveryHint is appended to the errors of a deployment update to name the --dm-recovery
+// options, when none was given.
+func (gcp *Gcp) recoveryHint() string {
+	if gcp.Spec.DmRecovery != "" {
+		return ""
+	}
+	return fmt.Sprintf("; rerun apply with --%v=%v to stop its operation, %v to delete it keeping its "+
+		"resources and create it again, or %v to delete it with its resources and create it again",
+		kftypes.DM_RECOVERY, DM_RECOVERY_STOP, DM_RECOVERY_ABANDON, DM_RECOVERY_RECREATE)
+}
+
+// recoverDeployment recovers d of component as spec.dmRecovery says when its last operation
+// failed or is stuck. It returns the deployment to update, or nil once it's deleted with the
+// create policy to insert it again with. Without spec.dmRecovery a failed deployment is updated
+// as is, which fixes a bad config, while a stuck one is an error naming the options.
+func (gcp *Gcp) recoverDeployment(ctx context.Context, deploymentmanagerService DeploymentManagerClient,
+	d *deploymentmanager.Deployment, component string) (*deploymentmanager.Deployment, string, error) {
+	problem := deploymentProblem(d, gcp.clock.Now())
+	if problem == "" {
+		return d, "", nil
+	}
+	project := gcp.Spec.Project
+	inProgress := d.Operation.Status != "DONE"
+	switch gcp.Spec.DmRecovery {
+	case "":
+		if !inProgress {
+			log.Warnf("Deployment %v %v; updating it", d.Name, problem)
+			return d, "", nil
+		}
+		return nil, "", &kfapis.KfError{
+			Code:    int(kfapis.INVALID_ARGUMENT),
+			Message: fmt.Sprintf("deployment %v %v%v", d.Name, problem, gcp.recoveryHint()),
+		}
+	case DM_RECOVERY_STOP, DM_RECOVERY_ABANDON:
+	case DM_RECOVERY_RECREATE:
+		if component == COMPONENT_STORAGE || component == COMPONENT_GCFS || component == COMPONENT_COMBINED {
+			return nil, "", &kfapis.KfError{
+				Code: int(kfapis.INVALID_ARGUMENT),
+				Message: fmt.Sprintf("--%v=%v would delete the disks of deployment %v; use %v to keep them",
+					kftypes.DM_RECOVERY, DM_RECOVERY_RECREATE, d.Name, DM_RECOVERY_ABANDON),
+			}
+		}
+	default:
+		return nil, "", &kfapis.KfError{
+			Code: int(kfapis.INVALID_ARGUMENT),
+			Message: fmt.Sprintf("invalid --%v %v; must be %v, %v or %v", kftypes.DM_RECOVERY,
+				gcp.Spec.DmRecovery, DM_RECOVERY_STOP, DM_RECOVERY_ABANDON, DM_RECOVERY_RECREATE),
+		}
+	}
+	log.Warnf("Deployment %v %v; recovering it with --%v=%v", d.Name, problem, kftypes.DM_RECOVERY,
+		gcp.Spec.DmRecovery)
+
+	if inProgress {
+		op, err := deploymentmanagerService.StopDeployment(ctx, project, d.Name, d.Fingerprint)
+		if err != nil {
+			return nil, "", fmt.Errorf("couldn't stop deployment %v/%v: %v", project, d.Name, err)
+		}
+		if err = gcp.blockingWait(project, op.Name, deploymentmanagerService, ctx,
+			"Stopping "+d.Name); err != nil {
+			return nil, "", err
+		}
+	}
+	if gcp.Spec.DmRecovery == DM_RECOVERY_STOP {
+		stopped, err := deploymentmanagerService.GetDeployment(ctx, project, d.Name)
+		if err != nil {
+			return nil, "", fmt.Errorf("couldn't get deployment %v/%v: %v", project, d.Name, err)
+		}
+		return stopped, "", nil
+	}
+
+	deletePolicy, createPolicy := "", ""
+	if gcp.Spec.DmRecovery == DM_RECOVERY_ABANDON {
+		deletePolicy, createPolicy = "ABANDON", "ACQUIRE"
+	}
+	op, err := deploymentmanagerService.DeleteDeployment(ctx, project, d.Name, deletePolicy)
+	if err != nil {
+		return nil, "", fmt.Errorf("couldn't delete deployment %v/%v: %v", project, d.Name, err)
+	}
+	if err = gcp.blockingWait(project, op.Name, deploymentmanagerService, ctx,
+		"Deleting "+d.Name); err != nil {
+		return nil, "", err
+	}
+	return nil, createPolicy, nil
+}
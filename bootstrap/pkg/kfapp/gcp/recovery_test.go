@@ -0,0 +1,109 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/kubeflow/kubeflow/bootstrap/pkg/kfapp/gcp/fake"
+	"golang.org/x/net/context"
+	"google.golang.org/api/deploymentmanager/v2"
+)
+
+func TestDeploymentProblem(t *testing.T) {
+	now := fakeClock{}.Now()
+	cases := map[string]struct {
+		op      *deploymentmanager.Operation
+		problem bool
+	}{
+		"done": {&deploymentmanager.Operation{Status: "DONE"}, false},
+		"failed": {&deploymentmanager.Operation{Status: "DONE", Error: &deploymentmanager.OperationError{
+			Errors: []*deploymentmanager.OperationErrorErrors{{Message: "bad manifest"}},
+		}}, true},
+		"http error":   {&deploymentmanager.Operation{Status: "DONE", HttpErrorStatusCode: 400}, true},
+		"running":      {&deploymentmanager.Operation{Status: "RUNNING", StartTime: "2019-03-31T23:50:00Z"}, false},
+		"stuck":        {&deploymentmanager.Operation{Status: "RUNNING", StartTime: "2019-03-31T23:00:00Z"}, true},
+		"stuck queued": {&deploymentmanager.Operation{Status: "PENDING", InsertTime: "2019-03-31T22:00:00Z"}, true},
+	}
+	for name, c := range cases {
+		problem := deploymentProblem(&deploymentmanager.Deployment{Name: "kf", Operation: c.op}, now)
+		if (problem != "") != c.problem {
+			t.Errorf("%v: deploymentProblem = %q", name, problem)
+		}
+	}
+}
+
+// stuckDeploymentManager has the deployment kf of my-project, running since an hour.
+func stuckDeploymentManager() *fake.DeploymentManager {
+	dm := fake.NewDeploymentManager("my-project", &deploymentmanager.Deployment{Name: "kf"})
+	op := dm.Deployment("my-project", "kf").Operation
+	op.Status = "RUNNING"
+	op.StartTime = "2019-03-31T23:00:00Z"
+	dm.Calls = nil
+	return dm
+}
+
+func TestRecoverDeployment(t *testing.T) {
+	ctx := context.Background()
+	recoverWith := func(dm *fake.DeploymentManager, recovery string,
+		component string) (*deploymentmanager.Deployment, string, error) {
+		gcp := &Gcp{clock: fakeClock{}}
+		gcp.Spec.Project = "my-project"
+		gcp.Spec.DmRecovery = recovery
+		return gcp.recoverDeployment(ctx, dm, dm.Deployment("my-project", "kf"), component)
+	}
+
+	dm := stuckDeploymentManager()
+	if _, _, err := recoverWith(dm, "", COMPONENT_CLUSTER); err == nil {
+		t.Errorf("recoverDeployment of a stuck deployment succeeded without --dm-recovery")
+	}
+	if len(dm.Calls) != 0 {
+		t.Errorf("recoverDeployment without --dm-recovery made calls %v", dm.Calls)
+	}
+
+	dm = stuckDeploymentManager()
+	d, _, err := recoverWith(dm, DM_RECOVERY_STOP, COMPONENT_CLUSTER)
+	if err != nil {
+		t.Fatalf("recoverDeployment with %v failed: %v", DM_RECOVERY_STOP, err)
+	}
+	if d == nil || d.Operation.Status != "DONE" {
+		t.Errorf("recoverDeployment with %v returned %+v; want the stopped deployment", DM_RECOVERY_STOP, d)
+	}
+	if want := []string{"stop my-project/kf", "get my-project/kf"}; !reflect.DeepEqual(dm.Calls, want) {
+		t.Errorf("recoverDeployment with %v made calls %v; want %v", DM_RECOVERY_STOP, dm.Calls, want)
+	}
+
+	dm = stuckDeploymentManager()
+	d, createPolicy, err := recoverWith(dm, DM_RECOVERY_ABANDON, COMPONENT_CLUSTER)
+	if err != nil {
+		t.Fatalf("recoverDeployment with %v failed: %v", DM_RECOVERY_ABANDON, err)
+	}
+	if d != nil || createPolicy != "ACQUIRE" {
+		t.Errorf("recoverDeployment with %v returned %+v, %q; want nil, ACQUIRE", DM_RECOVERY_ABANDON, d,
+			createPolicy)
+	}
+	if want := []string{"stop my-project/kf", "delete ABANDON my-project/kf"}; !reflect.DeepEqual(dm.Calls, want) {
+		t.Errorf("recoverDeployment with %v made calls %v; want %v", DM_RECOVERY_ABANDON, dm.Calls, want)
+	}
+
+	dm = stuckDeploymentManager()
+	if _, _, err = recoverWith(dm, DM_RECOVERY_RECREATE, COMPONENT_STORAGE); err == nil {
+		t.Errorf("recoverDeployment with %v succeeded on the storage deployment", DM_RECOVERY_RECREATE)
+	}
+	if _, _, err = recoverWith(dm, "restart", COMPONENT_CLUSTER); err == nil {
+		t.Errorf("recoverDeployment succeeded with an invalid --dm-recovery")
+	}
+}
@@ -0,0 +1,196 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/ghodss/yaml"
+	"golang.org/x/net/context"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+	"path"
+	"strings"
+)
+
+const (
+	// K8S_SPECS is the directory of the app dir holding the manifests generate writes for the
+	// cluster.
+	K8S_SPECS = "k8s_specs"
+	// SECRETS_SYNC_FILE is the manifest of the secrets-sync controller in K8S_SPECS.
+	SECRETS_SYNC_FILE = "secrets-sync.yaml"
+	// SECRETS_SYNC_NAME names the objects of the secrets-sync controller.
+	SECRETS_SYNC_NAME = "secrets-sync"
+	// SECRETS_SYNC_IMAGE runs the controller; kubectl get --export was removed in 1.18.
+	SECRETS_SYNC_IMAGE = "bitnami/kubectl:1.13"
+	// PROFILE_NAMESPACE_LABEL is the label of the user namespaces the secrets-sync controller
+	// copies the secrets into.
+	PROFILE_NAMESPACE_LABEL = "kubeflow-profile"
+	// secretsSyncResync bounds the watch of the controller; the secrets of every namespace are
+	// copied again when it restarts, so a rotated key reaches them within it.
+	secretsSyncResync = "10m"
+)
+
+// secretsSyncScript watches the namespaces labeled with PROFILE_NAMESPACE_LABEL and applies the
+// secrets of $SECRETS in $SOURCE_NAMESPACE into each, annotated with PROPAGATED_FROM_ANNOTATION.
+// A failed copy is logged and retried on the next resync.
+var secretsSyncScript = strings.Join([]string{
+	`sync() {`,
+	`  for secret in $SECRETS; do`,
+	`    kubectl get secret "$secret" -n "$SOURCE_NAMESPACE" -o yaml --export |`,
+	`      kubectl annotate --local --overwrite -f - -o yaml ` + PROPAGATED_FROM_ANNOTATION + `="$SOURCE_NAMESPACE" |`,
+	`      kubectl apply -n "$1" -f - || echo "couldn't copy $secret into $1"`,
+	`  done`,
+	`}`,
+	`while true; do`,
+	`  kubectl get namespaces -l ` + PROFILE_NAMESPACE_LABEL + ` --watch -o name --request-timeout=` +
+		secretsSyncResync + ` |`,
+	`    while read -r ns; do sync "${ns#namespace/}"; done`,
+	`  sleep 10`,
+	`done`,
+}, "\n")
+
+// secretsSyncClusterRole is the name of the cluster-scoped objects of the controller, prefixed
+// like adminBinding so the apps of a cluster don't share them.
+func (gcp *Gcp) secretsSyncClusterRole() string {
+	if gcp.Spec.NamespacePrefix == "" {
+		return SECRETS_SYNC_NAME
+	}
+	return gcp.Spec.NamespacePrefix + "-" + SECRETS_SYNC_NAME
+}
+
+// secretsSyncObjects are the objects of the secrets-sync controller: its service account, the
+// role letting it read the namespaces and write the secrets, and its deployment in the app
+// namespace.
+func (gcp *Gcp) secretsSyncObjects() []interface{} {
+	namespace := gcp.namespace()
+	labels := map[string]string{"app": SECRETS_SYNC_NAME}
+	replicas := int32(1)
+	return []interface{}{
+		&v1.ServiceAccount{
+			TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "ServiceAccount"},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      SECRETS_SYNC_NAME,
+				Namespace: namespace,
+			},
+		},
+		&rbacv1.ClusterRole{
+			TypeMeta: metav1.TypeMeta{APIVersion: "rbac.authorization.k8s.io/v1", Kind: "ClusterRole"},
+			ObjectMeta: metav1.ObjectMeta{
+				Name: gcp.secretsSyncClusterRole(),
+			},
+			Rules: []rbacv1.PolicyRule{
+				{
+					APIGroups: []string{""},
+					Resources: []string{"namespaces"},
+					Verbs:     []string{"get", "list", "watch"},
+				},
+				{
+					APIGroups: []string{""},
+					Resources: []string{"secrets"},
+					Verbs:     []string{"get", "create", "update", "patch"},
+				},
+			},
+		},
+		&rbacv1.ClusterRoleBinding{
+			TypeMeta: metav1.TypeMeta{APIVersion: "rbac.authorization.k8s.io/v1", Kind: "ClusterRoleBinding"},
+			ObjectMeta: metav1.ObjectMeta{
+				Name: gcp.secretsSyncClusterRole(),
+			},
+			RoleRef: rbacv1.RoleRef{
+				APIGroup: "rbac.authorization.k8s.io",
+				Kind:     "ClusterRole",
+				Name:     gcp.secretsSyncClusterRole(),
+			},
+			Subjects: []rbacv1.Subject{
+				{
+					Kind:      rbacv1.ServiceAccountKind,
+					Name:      SECRETS_SYNC_NAME,
+					Namespace: namespace,
+				},
+			},
+		},
+		&appsv1.Deployment{
+			TypeMeta: metav1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      SECRETS_SYNC_NAME,
+				Namespace: namespace,
+				Labels:    labels,
+			},
+			Spec: appsv1.DeploymentSpec{
+				Replicas: &replicas,
+				Selector: &metav1.LabelSelector{MatchLabels: labels},
+				Template: v1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{Labels: labels},
+					Spec: v1.PodSpec{
+						ServiceAccountName: SECRETS_SYNC_NAME,
+						Containers: []v1.Container{
+							{
+								Name:    SECRETS_SYNC_NAME,
+								Image:   SECRETS_SYNC_IMAGE,
+								Command: []string{"/bin/bash", "-c", secretsSyncScript},
+								Env: []v1.EnvVar{
+									{Name: "SOURCE_NAMESPACE", Value: namespace},
+									{Name: "SECRETS", Value: USER_SECRET_NAME},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// secretsSyncManifest is the manifest of secretsSyncObjects.
+func (gcp *Gcp) secretsSyncManifest() ([]byte, error) {
+	var buf bytes.Buffer
+	for i, object := range gcp.secretsSyncObjects() {
+		data, err := yaml.Marshal(object)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't marshal the secrets-sync manifest: %v", err)
+		}
+		if i > 0 {
+			buf.WriteString("---\n")
+		}
+		buf.Write(data)
+	}
+	return buf.Bytes(), nil
+}
+
+// putSecretsSyncManifest puts the manifest of the secrets-sync controller in bundle, whether or
+// not spec.secretsSync installs it, so it can be applied by hand too.
+func (gcp *Gcp) putSecretsSyncManifest(bundle *Bundle) error {
+	manifest, err := gcp.secretsSyncManifest()
+	if err != nil {
+		return err
+	}
+	bundle.Put(path.Join(K8S_SPECS, SECRETS_SYNC_FILE), manifest)
+	return nil
+}
+
+// installSecretsSync applies the secrets-sync manifest of the app dir, so the changes made to it
+// since generate are applied too.
+func (gcp *Gcp) installSecretsSync(ctx context.Context, client *rest.Config) error {
+	name := path.Join(K8S_SPECS, SECRETS_SYNC_FILE)
+	manifest, err := gcp.store.ReadFile(name)
+	if err != nil {
+		return fmt.Errorf("couldn't read %v; run kfctl generate first: %v", name, err)
+	}
+	return gcp.createResources(ctx, client, name, manifest)
+}
@@ -0,0 +1,76 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"path"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/kubeflow/kubeflow/bootstrap/pkg/utils"
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSecretsSyncManifest(t *testing.T) {
+	gcp := &Gcp{}
+	gcp.Namespace = "kubeflow"
+	gcp.Spec.NamespacePrefix = "team"
+	bundle := NewBundle()
+	if err := gcp.putSecretsSyncManifest(bundle); err != nil {
+		t.Fatalf("putSecretsSyncManifest failed: %v", err)
+	}
+	manifest, ok := bundle.Get(path.Join(K8S_SPECS, SECRETS_SYNC_FILE))
+	if !ok {
+		t.Fatalf("putSecretsSyncManifest didn't put %v", SECRETS_SYNC_FILE)
+	}
+	refs, err := utils.ResourceRefs(manifest)
+	if err != nil {
+		t.Fatalf("ResourceRefs failed: %v", err)
+	}
+	kinds := []string{}
+	for _, ref := range refs {
+		kinds = append(kinds, ref.Kind+"/"+ref.Name)
+	}
+	expected := []string{"ServiceAccount/secrets-sync", "ClusterRole/team-secrets-sync",
+		"ClusterRoleBinding/team-secrets-sync", "Deployment/secrets-sync"}
+	if !reflect.DeepEqual(kinds, expected) {
+		t.Errorf("secrets-sync manifest has %v; want %v", kinds, expected)
+	}
+	for _, s := range []string{"team-kubeflow", USER_SECRET_NAME, PROFILE_NAMESPACE_LABEL} {
+		if !strings.Contains(string(manifest), s) {
+			t.Errorf("secrets-sync manifest doesn't mention %v", s)
+		}
+	}
+}
+
+func TestIsSyncedNamespaceProfileLabel(t *testing.T) {
+	gcp := &Gcp{}
+	gcp.Namespace = "kubeflow"
+	ns := &v1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "jane",
+			Labels: map[string]string{PROFILE_NAMESPACE_LABEL: ""},
+		},
+	}
+	if gcp.isSyncedNamespace(ns) {
+		t.Errorf("isSyncedNamespace without spec.secretsSync = true")
+	}
+	gcp.Spec.SecretsSync = true
+	if !gcp.isSyncedNamespace(ns) {
+		t.Errorf("isSyncedNamespace with spec.secretsSync = false")
+	}
+}
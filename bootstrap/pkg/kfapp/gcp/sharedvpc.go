@@ -0,0 +1,179 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"fmt"
+	kfapis "github.com/kubeflow/kubeflow/bootstrap/pkg/apis"
+	kftypes "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps"
+	"github.com/kubeflow/kubeflow/bootstrap/pkg/utils"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+	"google.golang.org/api/cloudresourcemanager/v1"
+	"sort"
+	"strings"
+)
+
+// hostProjectApis are the APIs gcpInitProject enables in spec.hostProject, whose network the
+// cluster uses.
+var hostProjectApis = []string{
+	"compute.googleapis.com",
+	"container.googleapis.com",
+}
+
+// hostProjectRoles are the roles granted in spec.hostProject to the service agents of the
+// project, so GKE can create the cluster in its subnetwork and the ingress its firewall rules.
+var hostProjectRoles = map[string][]string{
+	"roles/compute.networkUser": {
+		"serviceAccount:service-%v@container-engine-robot.iam.gserviceaccount.com",
+		"serviceAccount:%v@cloudservices.gserviceaccount.com",
+	},
+	"roles/container.hostServiceAgentUser": {
+		"serviceAccount:service-%v@container-engine-robot.iam.gserviceaccount.com",
+	},
+	"roles/compute.securityAdmin": {
+		"serviceAccount:service-%v@container-engine-robot.iam.gserviceaccount.com",
+	},
+}
+
+// validateSharedVpc checks spec.hostProject and spec.sharedVpc are set together, and name the
+// subnetwork and its secondary ranges.
+func (gcp *Gcp) validateSharedVpc() error {
+	invalid := func(format string, args ...interface{}) error {
+		return &kfapis.KfError{
+			Code:    int(kfapis.INVALID_ARGUMENT),
+			Message: fmt.Sprintf(format, args...),
+		}
+	}
+	host, vpc := gcp.Spec.HostProject, gcp.Spec.SharedVpc
+	switch {
+	case host == "" && vpc == nil:
+		return nil
+	case host == "":
+		return invalid("sharedVpc needs the hostProject its network is in")
+	case vpc == nil:
+		return invalid("hostProject needs the sharedVpc subnetwork the cluster is created in")
+	case host == gcp.Spec.Project:
+		return invalid("hostProject %v is the project of the app; leave it out for a network of the project",
+			host)
+	}
+	missing := []string{}
+	for field, value := range map[string]string{"network": vpc.Network, "subnetwork": vpc.Subnetwork,
+		"podsRange": vpc.PodsRange, "servicesRange": vpc.ServicesRange} {
+		if value == "" {
+			missing = append(missing, field)
+		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return invalid("sharedVpc needs %v", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// sharedVpcProperties is the sharedVpc property of cluster.jinja, nil without spec.sharedVpc.
+// The subnetwork is in the region of the zone of the cluster.
+func (gcp *Gcp) sharedVpcProperties() map[string]interface{} {
+	vpc := gcp.Spec.SharedVpc
+	if vpc == nil {
+		return nil
+	}
+	return map[string]interface{}{
+		"hostProject":   gcp.Spec.HostProject,
+		"network":       vpc.Network,
+		"region":        regionOf(gcp.Spec.Zone),
+		"subnetwork":    vpc.Subnetwork,
+		"podsRange":     vpc.PodsRange,
+		"servicesRange": vpc.ServicesRange,
+	}
+}
+
+// hostProjectBindings are the bindings of hostProjectRoles for the project numbered
+// projectNumber.
+func hostProjectBindings(projectNumber int64) *cloudresourcemanager.Policy {
+	roles := []string{}
+	for role := range hostProjectRoles {
+		roles = append(roles, role)
+	}
+	sort.Strings(roles)
+	policy := &cloudresourcemanager.Policy{}
+	for _, role := range roles {
+		binding := &cloudresourcemanager.Binding{Role: role}
+		for _, member := range hostProjectRoles[role] {
+			binding.Members = append(binding.Members, fmt.Sprintf(member, projectNumber))
+		}
+		policy.Bindings = append(policy.Bindings, binding)
+	}
+	return policy
+}
+
+// bindHostProject grants the service agents of the project the roles of hostProjectRoles in
+// spec.hostProject, before the cluster is created. The bindings are left on delete: they're
+// those of the project, which other clusters of it may use.
+func (gcp *Gcp) bindHostProject(ctx context.Context) error {
+	if gcp.Spec.HostProject == "" {
+		return nil
+	}
+	projectNumber, err := gcp.projectNumber(ctx)
+	if err != nil {
+		return err
+	}
+	log.Infof("Granting the service agents of %v the roles of shared VPC service projects in %v",
+		gcp.Spec.Project, gcp.Spec.HostProject)
+	adding := hostProjectBindings(projectNumber)
+	if err = gcp.updateIamPolicy(gcp.Spec.HostProject, gcp.client, func(policy *cloudresourcemanager.Policy) {
+		utils.RewriteIamPolicy(policy, adding)
+	}); err != nil {
+		return fmt.Errorf("couldn't update the IAM policy of host project %v: %v", gcp.Spec.HostProject, err)
+	}
+	return nil
+}
+
+// planHostProject is the update of the IAM policy of spec.hostProject bindHostProject would
+// make; nil when there's no host project or the roles are granted already.
+func (gcp *Gcp) planHostProject(ctx context.Context) (*kftypes.Action, error) {
+	if gcp.Spec.HostProject == "" {
+		return nil, nil
+	}
+	projectNumber, err := gcp.projectNumber(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resourceManager, err := cloudresourcemanager.New(gcp.client)
+	if err != nil {
+		return nil, err
+	}
+	policy, err := resourceManager.Projects.GetIamPolicy(gcp.Spec.HostProject,
+		&cloudresourcemanager.GetIamPolicyRequest{}).Context(ctx).Do()
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get the IAM policy of %v: %v", gcp.Spec.HostProject, err)
+	}
+	before := policyBindings(policy)
+	utils.RewriteIamPolicy(policy, hostProjectBindings(projectNumber))
+	added := 0
+	for binding := range policyBindings(policy) {
+		if !before[binding] {
+			added++
+		}
+	}
+	if added == 0 {
+		return nil, nil
+	}
+	return &kftypes.Action{
+		Type:   kftypes.UPDATE_IAM_POLICY,
+		Target: gcp.Spec.HostProject,
+		Detail: fmt.Sprintf("+%v bindings", added),
+	}, nil
+}
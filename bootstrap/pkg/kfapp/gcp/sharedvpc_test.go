@@ -0,0 +1,83 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"testing"
+
+	kfdefs "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps/kfdef/v1alpha1"
+)
+
+func TestValidateSharedVpc(t *testing.T) {
+	vpc := &kfdefs.SharedVpcConfig{
+		Network:       "shared",
+		Subnetwork:    "kubeflow",
+		PodsRange:     "pods",
+		ServicesRange: "services",
+	}
+	cases := []struct {
+		hostProject string
+		sharedVpc   *kfdefs.SharedVpcConfig
+		valid       bool
+	}{
+		{"", nil, true},
+		{"host-project", vpc, true},
+		{"host-project", nil, false},
+		{"", vpc, false},
+		{"my-project", vpc, false},
+		{"host-project", &kfdefs.SharedVpcConfig{Network: "shared", Subnetwork: "kubeflow"}, false},
+	}
+	for i, c := range cases {
+		gcp := &Gcp{}
+		gcp.Spec.Project = "my-project"
+		gcp.Spec.HostProject = c.hostProject
+		gcp.Spec.SharedVpc = c.sharedVpc
+		err := gcp.validateSharedVpc()
+		if c.valid && err != nil {
+			t.Errorf("case %v: validateSharedVpc failed: %v", i, err)
+		} else if !c.valid && err == nil {
+			t.Errorf("case %v: validateSharedVpc succeeded", i)
+		}
+	}
+}
+
+func TestSharedVpcProperties(t *testing.T) {
+	gcp := &Gcp{}
+	if properties := gcp.sharedVpcProperties(); properties != nil {
+		t.Errorf("sharedVpcProperties without spec.sharedVpc = %v", properties)
+	}
+	gcp.Spec.Zone = "us-east1-d"
+	gcp.Spec.HostProject = "host-project"
+	gcp.Spec.SharedVpc = &kfdefs.SharedVpcConfig{Network: "shared", Subnetwork: "kubeflow"}
+	properties := gcp.sharedVpcProperties()
+	if properties["hostProject"] != "host-project" || properties["region"] != "us-east1" ||
+		properties["subnetwork"] != "kubeflow" {
+		t.Errorf("sharedVpcProperties = %v", properties)
+	}
+}
+
+func TestHostProjectBindings(t *testing.T) {
+	bindings := policyBindings(hostProjectBindings(123))
+	for _, binding := range []string{
+		"roles/compute.networkUser serviceAccount:123@cloudservices.gserviceaccount.com",
+		"roles/compute.networkUser serviceAccount:service-123@container-engine-robot.iam.gserviceaccount.com",
+		"roles/container.hostServiceAgentUser " +
+			"serviceAccount:service-123@container-engine-robot.iam.gserviceaccount.com",
+	} {
+		if !bindings[binding] {
+			t.Errorf("hostProjectBindings is missing %v", binding)
+		}
+	}
+}
@@ -0,0 +1,168 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"fmt"
+	"github.com/cenkalti/backoff"
+	kfapis "github.com/kubeflow/kubeflow/bootstrap/pkg/apis"
+	kftypes "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps"
+	kfdefs "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps/kfdef/v1alpha1"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+	"google.golang.org/api/compute/v1"
+	"google.golang.org/api/googleapi"
+	"time"
+)
+
+// storageDiskUsages are the disks of the storage template, named <storage deployment>-<usage>.
+var storageDiskUsages = []string{"metadata-store", "artifact-store"}
+
+// snapshotTimeFormat is the suffix of the snapshot names; they must be lowercase.
+const snapshotTimeFormat = "20060102-150405"
+
+// storageDisks are the names of the storage disks of the app.
+func (gcp *Gcp) storageDisks() []string {
+	disks := []string{}
+	for _, usage := range storageDiskUsages {
+		disks = append(disks, gcp.storageDeploymentName()+"-"+usage)
+	}
+	return disks
+}
+
+// snapshotName is the name of the snapshot of disk taken at t, within the 63 characters of a
+// resource name.
+func snapshotName(disk string, t time.Time) string {
+	suffix := "-" + t.UTC().Format(snapshotTimeFormat)
+	if len(disk)+len(suffix) > 63 {
+		disk = disk[:63-len(suffix)]
+	}
+	return disk + suffix
+}
+
+// latestSnapshot is the last snapshot recorded of disk.
+func (gcp *Gcp) latestSnapshot(disk string) *kfdefs.StorageSnapshot {
+	for i := len(gcp.Spec.StorageSnapshots) - 1; i >= 0; i-- {
+		if gcp.Spec.StorageSnapshots[i].Disk == disk {
+			return &gcp.Spec.StorageSnapshots[i]
+		}
+	}
+	return nil
+}
+
+// waitZoneOperation waits for the compute operation op of the app's zone to be done.
+func (gcp *Gcp) waitZoneOperation(ctx context.Context, computeService *compute.Service,
+	op *compute.Operation, logPrefix string) error {
+	return gcp.retry(func() error {
+		current, err := computeService.ZoneOperations.Get(gcp.Spec.Project, gcp.Spec.Zone, op.Name).Context(ctx).Do()
+		if err != nil {
+			return fmt.Errorf("%v error: %v", logPrefix, err)
+		}
+		if current.Status != "DONE" {
+			return fmt.Errorf("%v did not succeed; status: %v (op = %v)", logPrefix, current.Status, op.Name)
+		}
+		if current.Error != nil && len(current.Error.Errors) > 0 {
+			return backoff.Permanent(fmt.Errorf("%v error: %v", logPrefix, current.Error.Errors[0].Message))
+		}
+		return nil
+	}, backoff.NewExponentialBackOff())
+}
+
+// snapshotStorage snapshots the storage disks of the app which exist and records the snapshots
+// in app.yaml, before they're deleted or the nodes they're attached to are.
+func (gcp *Gcp) snapshotStorage(ctx context.Context) error {
+	computeService, err := compute.New(gcp.client)
+	if err != nil {
+		return fmt.Errorf("Error creating compute service: %v", err)
+	}
+	taken := false
+	for _, disk := range gcp.storageDisks() {
+		name := snapshotName(disk, gcp.clock.Now())
+		log.Infof("Snapshotting disk %v to %v", disk, name)
+		op, err := computeService.Disks.CreateSnapshot(gcp.Spec.Project, gcp.Spec.Zone, disk, &compute.Snapshot{
+			Name:   name,
+			Labels: gcp.ownerLabels(),
+		}).Context(ctx).Do()
+		if err != nil {
+			if e, ok := err.(*googleapi.Error); ok && e.Code == 404 {
+				log.Infof("Disk %v is not found, no snapshot taken.", disk)
+				continue
+			}
+			return fmt.Errorf("couldn't snapshot disk %v: %v", disk, err)
+		}
+		if err = gcp.waitZoneOperation(ctx, computeService, op, "Snapshotting "+disk); err != nil {
+			return err
+		}
+		gcp.Spec.StorageSnapshots = append(gcp.Spec.StorageSnapshots, kfdefs.StorageSnapshot{
+			Disk:         disk,
+			Snapshot:     name,
+			CreationTime: gcp.clock.Now().UTC().Format(time.RFC3339),
+		})
+		taken = true
+	}
+	if !taken || !gcp.isCLI {
+		return nil
+	}
+	if err = gcp.writeConfigFile(); err != nil {
+		return fmt.Errorf("couldn't record the storage snapshots in %v: %v", kftypes.KfConfigFile, err)
+	}
+	return nil
+}
+
+// RestoreStorage recreates the storage disks of the app which are gone from their latest
+// snapshot. kfctl apply then adopts them in the storage deployment instead of creating empty
+// ones, as DM acquires the resources which already exist.
+func (gcp *Gcp) RestoreStorage() error {
+	ctx := context.Background()
+	computeService, err := compute.New(gcp.client)
+	if err != nil {
+		return fmt.Errorf("Error creating compute service: %v", err)
+	}
+	restoring := map[string]*kfdefs.StorageSnapshot{}
+	for _, disk := range gcp.storageDisks() {
+		snapshot := gcp.latestSnapshot(disk)
+		if snapshot == nil {
+			return &kfapis.KfError{
+				Code:    int(kfapis.INVALID_ARGUMENT),
+				Message: fmt.Sprintf("no snapshot of disk %v is recorded in %v", disk, kftypes.KfConfigFile),
+			}
+		}
+		restoring[disk] = snapshot
+	}
+	for _, disk := range gcp.storageDisks() {
+		_, err := computeService.Disks.Get(gcp.Spec.Project, gcp.Spec.Zone, disk).Context(ctx).Do()
+		if err == nil {
+			log.Warnf("Disk %v still exists, it's not restored.", disk)
+			continue
+		}
+		if e, ok := err.(*googleapi.Error); !ok || e.Code != 404 {
+			return fmt.Errorf("couldn't get disk %v: %v", disk, err)
+		}
+		snapshot := restoring[disk]
+		log.Infof("Restoring disk %v from snapshot %v of %v", disk, snapshot.Snapshot, snapshot.CreationTime)
+		op, err := computeService.Disks.Insert(gcp.Spec.Project, gcp.Spec.Zone, &compute.Disk{
+			Name:           disk,
+			SourceSnapshot: fmt.Sprintf("projects/%v/global/snapshots/%v", gcp.Spec.Project, snapshot.Snapshot),
+			Labels:         gcp.ownerLabels(),
+		}).Context(ctx).Do()
+		if err != nil {
+			return fmt.Errorf("couldn't restore disk %v: %v", disk, err)
+		}
+		if err = gcp.waitZoneOperation(ctx, computeService, op, "Restoring "+disk); err != nil {
+			return err
+		}
+	}
+	return nil
+}
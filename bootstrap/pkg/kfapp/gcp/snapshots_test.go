@@ -0,0 +1,58 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	kfdefs "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps/kfdef/v1alpha1"
+)
+
+func TestSnapshotName(t *testing.T) {
+	at := time.Date(2019, 3, 4, 5, 6, 7, 0, time.UTC)
+	if name := snapshotName("kf-storage-metadata-store", at); name != "kf-storage-metadata-store-20190304-050607" {
+		t.Errorf("snapshotName is %v", name)
+	}
+	long := strings.Repeat("a", 60) + "-artifact-store"
+	if name := snapshotName(long, at); len(name) != 63 || !strings.HasSuffix(name, "-20190304-050607") {
+		t.Errorf("snapshotName of a long disk is %v", name)
+	}
+}
+
+func TestStorageDisksAndLatestSnapshot(t *testing.T) {
+	gcp := &Gcp{}
+	gcp.Name = "kf"
+	if disks := gcp.storageDisks(); strings.Join(disks, ",") != "kf-storage-metadata-store,kf-storage-artifact-store" {
+		t.Errorf("storageDisks are %v", disks)
+	}
+	gcp.Spec.CombinedDeployment = true
+	if disks := gcp.storageDisks(); disks[0] != "kf-metadata-store" {
+		t.Errorf("storageDisks of a combined deployment are %v", disks)
+	}
+
+	gcp.Spec.StorageSnapshots = []kfdefs.StorageSnapshot{
+		{Disk: "kf-metadata-store", Snapshot: "first"},
+		{Disk: "kf-artifact-store", Snapshot: "other"},
+		{Disk: "kf-metadata-store", Snapshot: "second"},
+	}
+	if snapshot := gcp.latestSnapshot("kf-metadata-store"); snapshot == nil || snapshot.Snapshot != "second" {
+		t.Errorf("latestSnapshot is %+v; want second", snapshot)
+	}
+	if snapshot := gcp.latestSnapshot("kf-missing"); snapshot != nil {
+		t.Errorf("latestSnapshot of a disk without snapshots is %+v", snapshot)
+	}
+}
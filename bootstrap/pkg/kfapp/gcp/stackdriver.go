@@ -0,0 +1,59 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"strings"
+)
+
+const (
+	// STACKDRIVER_LOGGING_COMPONENT ships the container logs of the Kubeflow namespaces to the
+	// kubeflow log of the project; it's added to the app when spec.enableStackdriver is set.
+	STACKDRIVER_LOGGING_COMPONENT = "fluentd-stackdriver"
+)
+
+// stackdriverApis are enabled by gcpInitProject when spec.enableStackdriver is set.
+var stackdriverApis = []string{
+	"logging.googleapis.com",
+	"monitoring.googleapis.com",
+	"stackdriver.googleapis.com",
+}
+
+// stackdriverNamespaces are the Kubeflow namespaces whose container logs are shipped.
+func (gcp *Gcp) stackdriverNamespaces() []string {
+	namespaces := []string{gcp.namespace()}
+	if gcp.Spec.UseIstio {
+		namespaces = append(namespaces, gcp.istioNamespace())
+	}
+	return namespaces
+}
+
+// addStackdriverLogging adds STACKDRIVER_LOGGING_COMPONENT to the components of the app, set up
+// for the Kubeflow namespaces.
+func (gcp *Gcp) addStackdriverLogging() {
+	gcp.specLock.Lock()
+	found := false
+	for _, c := range gcp.Spec.Components {
+		if c == STACKDRIVER_LOGGING_COMPONENT {
+			found = true
+		}
+	}
+	if !found {
+		// Copied on write like the component params.
+		gcp.Spec.Components = append(append([]string{}, gcp.Spec.Components...), STACKDRIVER_LOGGING_COMPONENT)
+	}
+	gcp.specLock.Unlock()
+	gcp.setComponentParam(STACKDRIVER_LOGGING_COMPONENT, "namespaces", strings.Join(gcp.stackdriverNamespaces(), ","), false)
+}
@@ -0,0 +1,70 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"path"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestAddStackdriverLogging(t *testing.T) {
+	gcp := newDoctorGcp()
+	gcp.Spec.UseIstio = true
+	components := []string{"jupyter", "pipeline"}
+	gcp.Spec.Components = components
+	gcp.addStackdriverLogging()
+	gcp.addStackdriverLogging()
+
+	expected := []string{"jupyter", "pipeline", STACKDRIVER_LOGGING_COMPONENT}
+	if !reflect.DeepEqual(gcp.Spec.Components, expected) {
+		t.Errorf("components got %v; want %v", gcp.Spec.Components, expected)
+	}
+	if len(components) != 2 {
+		t.Errorf("addStackdriverLogging modified the components it was given")
+	}
+	if namespaces := gcp.componentParam(STACKDRIVER_LOGGING_COMPONENT, "namespaces"); namespaces != "kubeflow,istio-system" {
+		t.Errorf("the namespaces of %v got %q; want kubeflow,istio-system", STACKDRIVER_LOGGING_COMPONENT, namespaces)
+	}
+
+	gcp.Spec.UseIstio = false
+	if namespaces := gcp.stackdriverNamespaces(); !reflect.DeepEqual(namespaces, []string{"kubeflow"}) {
+		t.Errorf("stackdriverNamespaces without istio got %v", namespaces)
+	}
+}
+
+func TestStackdriverClusterConfig(t *testing.T) {
+	for _, enabled := range []bool{false, true} {
+		gcp := newDoctorGcp()
+		gcp.Spec.UseEmbeddedAssets = true
+		gcp.Spec.EnableStackdriver = enabled
+		bundle := NewBundle()
+		if err := gcp.writeClusterConfig(bundle, path.Join(DM_CONFIGS_DIR, CONFIG_FILE)); err != nil {
+			t.Fatalf("writeClusterConfig: %v", err)
+		}
+		config, ok := bundle.Get(path.Join(GCP_CONFIG, CONFIG_FILE))
+		if !ok {
+			t.Fatalf("writeClusterConfig didn't write %v", CONFIG_FILE)
+		}
+		expected := "enableStackdriver: false"
+		if enabled {
+			expected = "enableStackdriver: true"
+		}
+		if !strings.Contains(string(config), expected) {
+			t.Errorf("%v doesn't set %v:\n%s", CONFIG_FILE, expected, config)
+		}
+	}
+}
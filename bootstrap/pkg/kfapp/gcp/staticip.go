@@ -0,0 +1,143 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"fmt"
+	"github.com/cenkalti/backoff"
+	kfapis "github.com/kubeflow/kubeflow/bootstrap/pkg/apis"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+	"google.golang.org/api/compute/v1"
+	"strings"
+)
+
+// ensureStaticIp checks spec.ipName, the static IP of the ingress, can be used by it. The
+// cluster deployment creates it; when it's missing, e.g. the cluster config was changed not to,
+// kfctl reserves it here rather than the ingress controller failing on it much later. It's
+// skipped without credentials, e.g. with a fake DeploymentManagerClient.
+func (gcp *Gcp) ensureStaticIp(ctx context.Context) error {
+	if gcp.client == nil {
+		return nil
+	}
+	computeService, err := compute.New(gcp.client)
+	if err != nil {
+		return fmt.Errorf("Error creating compute service: %v", err)
+	}
+	name := gcp.Spec.IpName
+	address, err := computeService.GlobalAddresses.Get(gcp.Spec.Project, name).Context(ctx).Do()
+	if err == nil {
+		return validateStaticIp(address)
+	}
+	if !isNotFound(err) {
+		return fmt.Errorf("couldn't get the static IP %v: %v", name, err)
+	}
+	log.Infof("Reserving static IP %v", name)
+	op, err := computeService.GlobalAddresses.Insert(gcp.Spec.Project, &compute.Address{
+		Name:        name,
+		Description: fmt.Sprintf("Static IP for Kubeflow ingress, reserved by kfctl for %v.", gcp.Name),
+	}).Context(ctx).Do()
+	if err != nil {
+		return fmt.Errorf("couldn't reserve the static IP %v: %v", name, err)
+	}
+	if err = gcp.waitGlobalOperation(ctx, computeService, op, "Reserving "+name); err != nil {
+		return err
+	}
+	gcp.specLock.Lock()
+	gcp.Spec.IpReserved = true
+	gcp.specLock.Unlock()
+	if gcp.isCLI {
+		if err = gcp.writeConfigFile(); err != nil {
+			return fmt.Errorf("cannot write to config file app.yaml in %v: %v", gcp.Spec.AppDir, err)
+		}
+	}
+	return nil
+}
+
+// validateStaticIp checks address is a global external address the ingress can use.
+func validateStaticIp(address *compute.Address) error {
+	if address.AddressType == "INTERNAL" {
+		return &kfapis.KfError{
+			Code:    int(kfapis.INVALID_ARGUMENT),
+			Message: fmt.Sprintf("static IP %v is internal; the ingress needs an external address", address.Name),
+		}
+	}
+	if address.Purpose != "" {
+		return &kfapis.KfError{
+			Code: int(kfapis.INVALID_ARGUMENT),
+			Message: fmt.Sprintf("static IP %v is reserved for %v; the ingress needs an address without a purpose",
+				address.Name, address.Purpose),
+		}
+	}
+	if address.Status == "IN_USE" {
+		log.Infof("Static IP %v (%v) is used by %v", address.Name, address.Address, strings.Join(address.Users, ", "))
+	}
+	return nil
+}
+
+// releaseStaticIp releases spec.ipName when kfctl reserved it and DeleteIp is set. It returns
+// whether it was released.
+func (gcp *Gcp) releaseStaticIp(ctx context.Context) (bool, error) {
+	if !gcp.Spec.IpReserved {
+		return false, nil
+	}
+	name := gcp.Spec.IpName
+	if !gcp.Spec.DeleteIp {
+		log.Warnf("Keeping static IP %v reserved by kfctl; delete with --delete_ip to release it", name)
+		return false, nil
+	}
+	computeService, err := compute.New(gcp.client)
+	if err != nil {
+		return false, fmt.Errorf("Error creating compute service: %v", err)
+	}
+	log.Infof("Releasing static IP %v", name)
+	op, err := computeService.GlobalAddresses.Delete(gcp.Spec.Project, name).Context(ctx).Do()
+	if err != nil && !isNotFound(err) {
+		return false, fmt.Errorf("couldn't release the static IP %v, the load balancer of the ingress may "+
+			"still use it: %v", name, err)
+	}
+	if err == nil {
+		if err = gcp.waitGlobalOperation(ctx, computeService, op, "Releasing "+name); err != nil {
+			return false, err
+		}
+	}
+	gcp.specLock.Lock()
+	gcp.Spec.IpReserved = false
+	gcp.specLock.Unlock()
+	if gcp.isCLI {
+		if err = gcp.writeConfigFile(); err != nil {
+			return true, fmt.Errorf("cannot write to config file app.yaml in %v: %v", gcp.Spec.AppDir, err)
+		}
+	}
+	return true, nil
+}
+
+// waitGlobalOperation waits for the global compute operation op to be done.
+func (gcp *Gcp) waitGlobalOperation(ctx context.Context, computeService *compute.Service,
+	op *compute.Operation, logPrefix string) error {
+	return gcp.retry(func() error {
+		current, err := computeService.GlobalOperations.Get(gcp.Spec.Project, op.Name).Context(ctx).Do()
+		if err != nil {
+			return fmt.Errorf("%v error: %v", logPrefix, err)
+		}
+		if current.Status != "DONE" {
+			return fmt.Errorf("%v did not succeed; status: %v (op = %v)", logPrefix, current.Status, op.Name)
+		}
+		if current.Error != nil && len(current.Error.Errors) > 0 {
+			return backoff.Permanent(fmt.Errorf("%v error: %v", logPrefix, current.Error.Errors[0].Message))
+		}
+		return nil
+	}, backoff.NewExponentialBackOff())
+}
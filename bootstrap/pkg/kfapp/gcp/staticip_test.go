@@ -0,0 +1,90 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"net/http"
+	"testing"
+
+	"golang.org/x/net/context"
+)
+
+func newStaticIpGcp(apis fakeGcpApis) *Gcp {
+	gcp := &Gcp{client: &http.Client{Transport: apis}, clock: fakeClock{}}
+	gcp.Name = "kf"
+	gcp.Spec.Project = "my-project"
+	gcp.Spec.IpName = "kf-ip"
+	return gcp
+}
+
+func TestEnsureStaticIp(t *testing.T) {
+	existing := newStaticIpGcp(fakeGcpApis{
+		"/compute/v1/projects/my-project/global/addresses/kf-ip": `{"name": "kf-ip", "address": "1.2.3.4",
+			"status": "IN_USE", "users": ["forwardingRules/k8s-fw"]}`,
+	})
+	if err := existing.ensureStaticIp(context.Background()); err != nil {
+		t.Errorf("ensureStaticIp failed with the address of the cluster deployment: %v", err)
+	}
+	if existing.Spec.IpReserved {
+		t.Errorf("the address of the cluster deployment is recorded as reserved by kfctl")
+	}
+
+	internal := newStaticIpGcp(fakeGcpApis{
+		"/compute/v1/projects/my-project/global/addresses/kf-ip": `{"name": "kf-ip", "addressType": "INTERNAL",
+			"purpose": "VPC_PEERING"}`,
+	})
+	if err := internal.ensureStaticIp(context.Background()); err == nil {
+		t.Errorf("ensureStaticIp succeeded with an internal address")
+	}
+
+	missing := newStaticIpGcp(fakeGcpApis{
+		"/compute/v1/projects/my-project/global/addresses":       `{"name": "op-1", "status": "PENDING"}`,
+		"/compute/v1/projects/my-project/global/operations/op-1": `{"name": "op-1", "status": "DONE"}`,
+	})
+	if err := missing.ensureStaticIp(context.Background()); err != nil {
+		t.Fatalf("ensureStaticIp failed to reserve the address: %v", err)
+	}
+	if !missing.Spec.IpReserved {
+		t.Errorf("the address kfctl reserved isn't recorded")
+	}
+}
+
+func TestReleaseStaticIp(t *testing.T) {
+	apis := fakeGcpApis{
+		"/compute/v1/projects/my-project/global/addresses/kf-ip": `{"name": "op-2", "status": "PENDING"}`,
+		"/compute/v1/projects/my-project/global/operations/op-2": `{"name": "op-2", "status": "DONE"}`,
+	}
+	cases := []struct {
+		reserved bool
+		deleteIp bool
+		released bool
+	}{
+		{false, true, false},
+		{true, false, false},
+		{true, true, true},
+	}
+	for _, c := range cases {
+		gcp := newStaticIpGcp(apis)
+		gcp.Spec.IpReserved = c.reserved
+		gcp.Spec.DeleteIp = c.deleteIp
+		released, err := gcp.releaseStaticIp(context.Background())
+		if err != nil {
+			t.Errorf("releaseStaticIp(reserved %v, deleteIp %v) failed: %v", c.reserved, c.deleteIp, err)
+		}
+		if released != c.released {
+			t.Errorf("releaseStaticIp(reserved %v, deleteIp %v) = %v; want %v", c.reserved, c.deleteIp, released, c.released)
+		}
+	}
+}
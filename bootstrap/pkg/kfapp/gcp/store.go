@@ -0,0 +1,221 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"fmt"
+	"github.com/ghodss/yaml"
+	kftypes "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps"
+	"github.com/kubeflow/kubeflow/bootstrap/pkg/utils"
+	"google.golang.org/api/cloudresourcemanager/v1"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Bundle holds the files generated for an app by their path relative to the app dir, e.g.
+// gcp_config/cluster-kubeflow.yaml. It's safe for concurrent use.
+type Bundle struct {
+	mu    sync.RWMutex
+	files map[string][]byte
+}
+
+// NewBundle returns an empty Bundle.
+func NewBundle() *Bundle {
+	return &Bundle{
+		files: make(map[string][]byte),
+	}
+}
+
+// Put sets the file name to a copy of data.
+func (b *Bundle) Put(name string, data []byte) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.files[name] = append([]byte{}, data...)
+}
+
+// Get returns a copy of the file name, and false when it's missing.
+func (b *Bundle) Get(name string) ([]byte, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	data, ok := b.files[name]
+	if !ok {
+		return nil, false
+	}
+	return append([]byte{}, data...), true
+}
+
+// Names returns the names of the files, sorted.
+func (b *Bundle) Names() []string {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	names := make([]string, 0, len(b.files))
+	for name := range b.files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// WriteBundle copies the files of bundle into b, so a Bundle is the in-memory ConfigStore.
+func (b *Bundle) WriteBundle(bundle *Bundle) error {
+	for _, name := range bundle.Names() {
+		data, _ := bundle.Get(name)
+		b.Put(name, data)
+	}
+	return nil
+}
+
+// ListFiles returns the names of the files under dir, sorted.
+func (b *Bundle) ListFiles(dir string) ([]string, error) {
+	names := []string{}
+	for _, name := range b.Names() {
+		if strings.HasPrefix(name, dir+"/") {
+			names = append(names, name)
+		}
+	}
+	return names, nil
+}
+
+// ReadFile returns the file name, or an error satisfying os.IsNotExist when it's missing.
+func (b *Bundle) ReadFile(name string) ([]byte, error) {
+	data, ok := b.Get(name)
+	if !ok {
+		return nil, &os.PathError{Op: "open", Path: name, Err: os.ErrNotExist}
+	}
+	return data, nil
+}
+
+// ConfigStore is where Gcp keeps the files it generates, app.yaml and the DM configs, and reads
+// them back from when applying. kfctl uses the app dir, so they can be reviewed and edited in
+// between; the apps of the deploy server and pkg/client/deploy keep them in a Bundle, so apps
+// run concurrently share no files.
+type ConfigStore interface {
+	// WriteBundle persists the files of bundle, leaving the others as they are.
+	WriteBundle(bundle *Bundle) error
+	// ReadFile returns the file name, relative to the app dir, or an error satisfying
+	// os.IsNotExist when it's missing.
+	ReadFile(name string) ([]byte, error)
+	// ListFiles returns the names of the files under dir, relative to the app dir and sorted;
+	// there are none when dir is missing.
+	ListFiles(dir string) ([]string, error)
+}
+
+// appDirStore is the ConfigStore of kfctl, the app dir.
+type appDirStore struct {
+	appDir string
+}
+
+// NewAppDirStore returns a ConfigStore writing the files under appDir.
+func NewAppDirStore(appDir string) ConfigStore {
+	return &appDirStore{appDir: appDir}
+}
+
+// WriteBundle writes each file atomically with the app dir locked, so a concurrent kfctl or a
+// crash doesn't leave app.yaml or the DM configs half written.
+func (s *appDirStore) WriteBundle(bundle *Bundle) error {
+	unlock, err := utils.LockDir(s.appDir)
+	if err != nil {
+		return fmt.Errorf("couldn't lock %v: %v", s.appDir, err)
+	}
+	defer unlock()
+	for _, name := range bundle.Names() {
+		data, _ := bundle.Get(name)
+		file := filepath.Join(s.appDir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(file), os.ModePerm); err != nil {
+			return fmt.Errorf("cannot create directory %v", err)
+		}
+		if err := utils.WriteFileAtomically(file, data, 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *appDirStore) ReadFile(name string) ([]byte, error) {
+	return ioutil.ReadFile(filepath.Join(s.appDir, filepath.FromSlash(name)))
+}
+
+func (s *appDirStore) ListFiles(dir string) ([]string, error) {
+	names := []string{}
+	root := filepath.Join(s.appDir, filepath.FromSlash(dir))
+	err := filepath.Walk(root, func(file string, info os.FileInfo, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) && file == root {
+				return filepath.SkipDir
+			}
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		name, err := filepath.Rel(s.appDir, file)
+		if err != nil {
+			return err
+		}
+		names = append(names, filepath.ToSlash(name))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// readConfig returns the file name of gcp_config from the store.
+func (gcp *Gcp) readConfig(name string) ([]byte, error) {
+	return gcp.store.ReadFile(path.Join(GCP_CONFIG, name))
+}
+
+// hasConfig is true when the file name of gcp_config is in the store.
+func (gcp *Gcp) hasConfig(name string) bool {
+	_, err := gcp.readConfig(name)
+	return err == nil
+}
+
+// readIamBindings returns the bindings of iam_bindings.yaml of gcp_config.
+func (gcp *Gcp) readIamBindings() (*cloudresourcemanager.Policy, error) {
+	buf, err := gcp.readConfig(IAM_BINDINGS_FILE)
+	if err != nil {
+		return nil, err
+	}
+	return utils.ParseIamBindingsYAML(buf)
+}
+
+// putConfigFile puts app.yaml, the KfDef of the app, in bundle.
+func (gcp *Gcp) putConfigFile(bundle *Bundle) error {
+	gcp.specLock.Lock()
+	kfdef := gcp.KfDef
+	kfdef.Spec.ComponentParams = kfdef.Spec.SortedComponentParams()
+	buf, err := yaml.Marshal(kfdef)
+	gcp.specLock.Unlock()
+	if err != nil {
+		return err
+	}
+	bundle.Put(kftypes.KfConfigFile, buf)
+	return nil
+}
+
+// Generated returns the files of the last Generate, nil before it's run.
+func (gcp *Gcp) Generated() *Bundle {
+	gcp.specLock.Lock()
+	defer gcp.specLock.Unlock()
+	return gcp.generated
+}
@@ -0,0 +1,105 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"fmt"
+	kfapis "github.com/kubeflow/kubeflow/bootstrap/pkg/apis"
+	kftypes "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps"
+	"strings"
+)
+
+// Targets of --target besides the DM components, limiting apply and delete to pieces of the app.
+const (
+	TARGET_IAM         = "iam"
+	TARGET_ISTIO       = "istio"
+	TARGET_SECRETS     = "secrets"
+	TARGET_GPU_DRIVERS = "gpu-drivers"
+	TARGET_FILESTORE   = "filestore"
+	// TARGET_ENDPOINTS is the endpoints service of spec.hostname, resolving it to the ingress.
+	TARGET_ENDPOINTS = "endpoints"
+)
+
+// dmTargets are the targets of the DM deployments, on GCP like TARGET_IAM; TARGET_ISTIO,
+// TARGET_SECRETS, TARGET_GPU_DRIVERS and TARGET_FILESTORE, the volume of the gcfs instance, are in
+// the cluster.
+var dmTargets = []string{COMPONENT_STORAGE, COMPONENT_CLUSTER, COMPONENT_NETWORK, COMPONENT_GCFS}
+
+// resourceTargets returns the targets making resources.
+func resourceTargets(resources kftypes.ResourceEnum) []string {
+	platform := append(append([]string{}, dmTargets...), TARGET_IAM, TARGET_ENDPOINTS)
+	k8s := []string{TARGET_ISTIO, TARGET_SECRETS, TARGET_GPU_DRIVERS, TARGET_FILESTORE}
+	switch resources {
+	case kftypes.PLATFORM:
+		return platform
+	case kftypes.K8S:
+		return k8s
+	default:
+		return append(platform, k8s...)
+	}
+}
+
+// targets returns the targets of resources the operation is limited to by spec.targets, all of
+// them when it's empty.
+func (gcp *Gcp) targets(resources kftypes.ResourceEnum) (map[string]bool, error) {
+	all := resourceTargets(resources)
+	targets := map[string]bool{}
+	if len(gcp.Spec.Targets) == 0 {
+		for _, target := range all {
+			targets[target] = true
+		}
+		return targets, nil
+	}
+	for _, target := range gcp.Spec.Targets {
+		if !containsTarget(all, target) {
+			return nil, &kfapis.KfError{
+				Code: int(kfapis.INVALID_ARGUMENT),
+				Message: fmt.Sprintf("unknown target %v for %v resources; must be one of %v", target, resources,
+					strings.Join(all, ", ")),
+			}
+		}
+		targets[target] = true
+	}
+	return targets, nil
+}
+
+// dmTargeted is true when a DM deployment is targeted.
+func dmTargeted(targets map[string]bool) bool {
+	for _, target := range dmTargets {
+		if targets[target] {
+			return true
+		}
+	}
+	return false
+}
+
+// allDmTargeted is true when every DM deployment is targeted, as needed to delete the combined one.
+func allDmTargeted(targets map[string]bool) bool {
+	for _, target := range dmTargets {
+		if !targets[target] {
+			return false
+		}
+	}
+	return true
+}
+
+func containsTarget(targets []string, target string) bool {
+	for _, t := range targets {
+		if t == target {
+			return true
+		}
+	}
+	return false
+}
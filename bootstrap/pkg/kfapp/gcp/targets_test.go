@@ -0,0 +1,102 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"testing"
+
+	kftypes "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps"
+)
+
+func TestTargets(t *testing.T) {
+	cases := []struct {
+		name      string
+		targets   []string
+		resources kftypes.ResourceEnum
+		expected  []string
+		fails     bool
+	}{
+		{
+			name:      "all platform targets",
+			resources: kftypes.PLATFORM,
+			expected:  append(append([]string{}, dmTargets...), TARGET_IAM, TARGET_ENDPOINTS),
+		},
+		{
+			name:      "all k8s targets",
+			resources: kftypes.K8S,
+			expected:  []string{TARGET_ISTIO, TARGET_SECRETS, TARGET_GPU_DRIVERS, TARGET_FILESTORE},
+		},
+		{
+			name:      "some targets",
+			targets:   []string{COMPONENT_STORAGE, TARGET_ISTIO},
+			resources: kftypes.ALL,
+			expected:  []string{COMPONENT_STORAGE, TARGET_ISTIO},
+		},
+		{
+			name:      "k8s target of platform resources",
+			targets:   []string{TARGET_ISTIO},
+			resources: kftypes.PLATFORM,
+			fails:     true,
+		},
+		{
+			name:      "unknown target",
+			targets:   []string{"network", "dns"},
+			resources: kftypes.ALL,
+			fails:     true,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			gcp := &Gcp{}
+			gcp.Spec.Targets = c.targets
+			targets, err := gcp.targets(c.resources)
+			if c.fails {
+				if err == nil {
+					t.Errorf("targets should fail; got %v", targets)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("targets: %v", err)
+			}
+			if len(targets) != len(c.expected) {
+				t.Errorf("targets got %v; want %v", targets, c.expected)
+			}
+			for _, target := range c.expected {
+				if !targets[target] {
+					t.Errorf("targets is missing %v", target)
+				}
+			}
+		})
+	}
+}
+
+func TestDmTargeted(t *testing.T) {
+	all := map[string]bool{}
+	for _, target := range dmTargets {
+		all[target] = true
+	}
+	some := map[string]bool{COMPONENT_CLUSTER: true, TARGET_ISTIO: true}
+	none := map[string]bool{TARGET_IAM: true, TARGET_SECRETS: true}
+	if !dmTargeted(all) || !allDmTargeted(all) {
+		t.Errorf("all the DM deployments should be targeted")
+	}
+	if !dmTargeted(some) || allDmTargeted(some) {
+		t.Errorf("only some DM deployments should be targeted")
+	}
+	if dmTargeted(none) || allDmTargeted(none) {
+		t.Errorf("no DM deployment should be targeted")
+	}
+}
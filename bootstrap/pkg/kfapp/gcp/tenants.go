@@ -0,0 +1,369 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/ghodss/yaml"
+	kfapis "github.com/kubeflow/kubeflow/bootstrap/pkg/apis"
+	kftypes "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps"
+	kfdefs "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps/kfdef/v1alpha1"
+	"github.com/kubeflow/kubeflow/bootstrap/pkg/utils"
+	log "github.com/sirupsen/logrus"
+	"io/ioutil"
+	"k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"os"
+	"strings"
+)
+
+const (
+	// TENANT_LABEL is set on the namespaces of spec.tenants, to the namespace.
+	TENANT_LABEL = "kubeflow.org/tenant"
+	// TENANT_ADMIN_BINDING and TENANT_EDIT_BINDING are the RoleBindings of the admins and members
+	// of a tenant in its namespace.
+	TENANT_ADMIN_BINDING = "kubeflow-tenant-admin"
+	TENANT_EDIT_BINDING  = "kubeflow-tenant-edit"
+	// TENANT_SERVICE_ROLE is the Istio ServiceRole allowing the tenant's users to reach the
+	// services of its namespace through the ingress.
+	TENANT_SERVICE_ROLE = "kubeflow-tenant-access"
+	// IAP_USER_HEADER holds accounts.google.com:<email> of the user signed in through IAP.
+	IAP_USER_HEADER = "x-goog-authenticated-user-email"
+	// DEX_USER_HEADER holds the email of the user signed in through Dex, set by the authservice.
+	DEX_USER_HEADER = "kubeflow-userid"
+)
+
+// validateTenants checks spec.tenants have distinct namespaces, which must be valid names
+// other than the app's and Istio's, and members kfctl can bind.
+func (gcp *Gcp) validateTenants() error {
+	seen := map[string]bool{}
+	for _, tenant := range gcp.Spec.Tenants {
+		if errs := validation.IsDNS1123Label(tenant.Namespace); len(errs) > 0 {
+			return &kfapis.KfError{
+				Code: int(kfapis.INVALID_ARGUMENT),
+				Message: fmt.Sprintf("invalid namespace %q in tenants: %v", tenant.Namespace,
+					strings.Join(errs, "; ")),
+			}
+		}
+		if tenant.Namespace == gcp.namespace() || tenant.Namespace == gcp.istioNamespace() {
+			return &kfapis.KfError{
+				Code:    int(kfapis.INVALID_ARGUMENT),
+				Message: fmt.Sprintf("namespace %v of tenants is reserved", tenant.Namespace),
+			}
+		}
+		if seen[tenant.Namespace] {
+			return &kfapis.KfError{
+				Code:    int(kfapis.INVALID_ARGUMENT),
+				Message: fmt.Sprintf("namespace %v is listed twice in tenants", tenant.Namespace),
+			}
+		}
+		seen[tenant.Namespace] = true
+		for _, member := range append(append([]string{}, tenant.Admins...), tenant.Members...) {
+			if _, err := tenantSubject(member); err != nil {
+				return &kfapis.KfError{
+					Code:    int(kfapis.INVALID_ARGUMENT),
+					Message: fmt.Sprintf("tenant %v: %v", tenant.Namespace, err),
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// tenantSubject returns the RBAC subject of an IAM style member. GKE authenticates GCP service
+// accounts as users named by their email.
+func tenantSubject(member string) (rbacv1.Subject, error) {
+	kind := rbacv1.UserKind
+	name := member
+	if i := strings.Index(member, ":"); i >= 0 {
+		name = member[i+1:]
+		switch member[:i] {
+		case "user", "serviceAccount":
+		case "group":
+			kind = rbacv1.GroupKind
+		default:
+			return rbacv1.Subject{}, fmt.Errorf("unsupported member %v; must be a user:, group: or "+
+				"serviceAccount: member", member)
+		}
+	}
+	if !strings.Contains(name, "@") {
+		return rbacv1.Subject{}, fmt.Errorf("member %v isn't an email", member)
+	}
+	return rbacv1.Subject{
+		Kind:     kind,
+		APIGroup: rbacv1.GroupName,
+		Name:     name,
+	}, nil
+}
+
+// tenantSubjects returns the subjects of members, which validateTenants checked.
+func tenantSubjects(members []string) []rbacv1.Subject {
+	subjects := []rbacv1.Subject{}
+	for _, member := range members {
+		subject, _ := tenantSubject(member)
+		subjects = append(subjects, subject)
+	}
+	return subjects
+}
+
+// configTenants creates the namespaces of spec.tenants and binds their admins and members in
+// them. The namespaces are labeled with SYNC_SECRETS_LABEL, so the user service account secret
+// is copied in after apply, and get the Istio RBAC policies of their users when Istio is used.
+func (gcp *Gcp) configTenants(k8sClientset *clientset.Clientset, config *rest.Config) error {
+	if len(gcp.Spec.Tenants) == 0 {
+		return nil
+	}
+	if err := gcp.validateTenants(); err != nil {
+		return err
+	}
+	for _, tenant := range gcp.Spec.Tenants {
+		if err := gcp.createTenantNamespace(k8sClientset, tenant.Namespace); err != nil {
+			return err
+		}
+		if err := applyRoleBinding(k8sClientset, tenant.Namespace, TENANT_ADMIN_BINDING, "admin",
+			tenantSubjects(tenant.Admins)); err != nil {
+			return err
+		}
+		if err := applyRoleBinding(k8sClientset, tenant.Namespace, TENANT_EDIT_BINDING, "edit",
+			tenantSubjects(tenant.Members)); err != nil {
+			return err
+		}
+	}
+	if !gcp.Spec.UseIstio {
+		return nil
+	}
+	policies, err := gcp.tenantIstioPolicies()
+	if err != nil || policies == nil {
+		return err
+	}
+	return applyManifest(config, policies)
+}
+
+// createTenantNamespace creates namespace, or labels it when it exists.
+func (gcp *Gcp) createTenantNamespace(k8sClientset *clientset.Clientset, namespace string) error {
+	labels := map[string]string{
+		TENANT_LABEL:       namespace,
+		SYNC_SECRETS_LABEL: "true",
+	}
+	if gcp.Spec.UseIstio {
+		labels["istio-injection"] = "enabled"
+	}
+	ns, err := k8sClientset.CoreV1().Namespaces().Get(namespace, metav1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		log.Infof("Creating tenant namespace %v", namespace)
+		_, err = k8sClientset.CoreV1().Namespaces().Create(&v1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   namespace,
+				Labels: labels,
+			},
+		})
+		if err != nil {
+			return fmt.Errorf("couldn't create namespace %v: %v", namespace, err)
+		}
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("couldn't get namespace %v: %v", namespace, err)
+	}
+	if ns.Labels == nil {
+		ns.Labels = map[string]string{}
+	}
+	updated := false
+	for k, v := range labels {
+		if ns.Labels[k] != v {
+			ns.Labels[k] = v
+			updated = true
+		}
+	}
+	if !updated {
+		return nil
+	}
+	if _, err = k8sClientset.CoreV1().Namespaces().Update(ns); err != nil {
+		return fmt.Errorf("couldn't update namespace %v: %v", namespace, err)
+	}
+	return nil
+}
+
+// applyRoleBinding binds subjects to clusterRole in namespace with the RoleBinding name,
+// deleting it when there are no subjects.
+func applyRoleBinding(k8sClientset *clientset.Clientset, namespace string, name string, clusterRole string,
+	subjects []rbacv1.Subject) error {
+	bindings := k8sClientset.RbacV1().RoleBindings(namespace)
+	existing, err := bindings.Get(name, metav1.GetOptions{})
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return fmt.Errorf("couldn't get role binding %v/%v: %v", namespace, name, err)
+	}
+	found := err == nil
+	if len(subjects) == 0 {
+		if !found {
+			return nil
+		}
+		log.Infof("Deleting role binding %v/%v", namespace, name)
+		if err = bindings.Delete(name, &metav1.DeleteOptions{}); err != nil && !k8serrors.IsNotFound(err) {
+			return fmt.Errorf("couldn't delete role binding %v/%v: %v", namespace, name, err)
+		}
+		return nil
+	}
+	binding := &rbacv1.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     "ClusterRole",
+			Name:     clusterRole,
+		},
+		Subjects: subjects,
+	}
+	if !found {
+		log.Infof("Creating role binding %v/%v", namespace, name)
+		_, err = bindings.Create(binding)
+	} else if existing.RoleRef != binding.RoleRef {
+		// The role of a binding can't be changed.
+		log.Infof("Recreating role binding %v/%v", namespace, name)
+		if err = bindings.Delete(name, &metav1.DeleteOptions{}); err == nil {
+			_, err = bindings.Create(binding)
+		}
+	} else {
+		existing.Subjects = subjects
+		log.Infof("Updating role binding %v/%v", namespace, name)
+		_, err = bindings.Update(existing)
+	}
+	if err != nil {
+		return fmt.Errorf("couldn't apply role binding %v/%v: %v", namespace, name, err)
+	}
+	return nil
+}
+
+// tenantIstioPolicies returns the manifest turning Istio RBAC on for the tenant namespaces and
+// allowing their users, by the identity header of the auth provider, to reach them. It's nil
+// with basic auth, whose users aren't known to the mesh.
+func (gcp *Gcp) tenantIstioPolicies() ([]byte, error) {
+	var header, prefix string
+	switch gcp.authProvider().Name() {
+	case kftypes.AUTH_IAP:
+		header, prefix = IAP_USER_HEADER, "accounts.google.com:"
+	case kftypes.AUTH_OIDC, kftypes.AUTH_LDAP:
+		header = DEX_USER_HEADER
+	default:
+		log.Warnf("Not creating the Istio RBAC policies of the tenants: %v doesn't identify the users "+
+			"to Istio", gcp.authProvider().Name())
+		return nil, nil
+	}
+	objects := []interface{}{}
+	for _, tenant := range gcp.Spec.Tenants {
+		objects = append(objects, map[string]interface{}{
+			"apiVersion": "rbac.istio.io/v1alpha1",
+			"kind":       "ServiceRole",
+			"metadata": map[string]interface{}{
+				"name":      TENANT_SERVICE_ROLE,
+				"namespace": tenant.Namespace,
+			},
+			"spec": map[string]interface{}{
+				"rules": []interface{}{
+					map[string]interface{}{
+						"services": []string{"*"},
+					},
+				},
+			},
+		})
+		subjects := []interface{}{}
+		for _, member := range append(append([]string{}, tenant.Admins...), tenant.Members...) {
+			subject, _ := tenantSubject(member)
+			if subject.Kind != rbacv1.UserKind {
+				log.Warnf("Tenant %v: %v can't be allowed by Istio, only users can", tenant.Namespace, member)
+				continue
+			}
+			subjects = append(subjects, map[string]interface{}{
+				"properties": map[string]string{
+					"request.headers[" + header + "]": prefix + subject.Name,
+				},
+			})
+		}
+		if len(subjects) == 0 {
+			continue
+		}
+		objects = append(objects, map[string]interface{}{
+			"apiVersion": "rbac.istio.io/v1alpha1",
+			"kind":       "ServiceRoleBinding",
+			"metadata": map[string]interface{}{
+				"name":      TENANT_SERVICE_ROLE,
+				"namespace": tenant.Namespace,
+			},
+			"spec": map[string]interface{}{
+				"subjects": subjects,
+				"roleRef": map[string]string{
+					"kind": "ServiceRole",
+					"name": TENANT_SERVICE_ROLE,
+				},
+			},
+		})
+	}
+	objects = append([]interface{}{map[string]interface{}{
+		"apiVersion": "rbac.istio.io/v1alpha1",
+		"kind":       "ClusterRbacConfig",
+		"metadata": map[string]interface{}{
+			"name": "default",
+		},
+		"spec": map[string]interface{}{
+			"mode": "ON_WITH_INCLUSION",
+			"inclusion": map[string]interface{}{
+				"namespaces": tenantNamespaces(gcp.Spec.Tenants),
+			},
+		},
+	}}, objects...)
+	docs := [][]byte{}
+	for _, o := range objects {
+		buf, err := yaml.Marshal(o)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't marshal the Istio RBAC policies: %v", err)
+		}
+		docs = append(docs, buf)
+	}
+	return bytes.Join(docs, []byte("---\n")), nil
+}
+
+// applyManifest creates or patches the objects of manifest.
+func applyManifest(config *rest.Config, manifest []byte) error {
+	f, err := ioutil.TempFile("", "kfctl-manifest")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	if _, err = f.Write(manifest); err != nil {
+		f.Close()
+		return err
+	}
+	if err = f.Close(); err != nil {
+		return err
+	}
+	return utils.CreateResourceFromFile(config, f.Name())
+}
+
+// tenantNamespaces are the namespaces of tenants.
+func tenantNamespaces(tenants []kfdefs.Tenant) []string {
+	namespaces := []string{}
+	for _, tenant := range tenants {
+		namespaces = append(namespaces, tenant.Namespace)
+	}
+	return namespaces
+}
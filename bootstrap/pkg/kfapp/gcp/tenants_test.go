@@ -0,0 +1,115 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"strings"
+	"testing"
+
+	kfdefs "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps/kfdef/v1alpha1"
+	rbacv1 "k8s.io/api/rbac/v1"
+)
+
+func TestTenantSubject(t *testing.T) {
+	cases := []struct {
+		member string
+		kind   string
+		name   string
+		valid  bool
+	}{
+		{"alice@example.com", rbacv1.UserKind, "alice@example.com", true},
+		{"user:alice@example.com", rbacv1.UserKind, "alice@example.com", true},
+		{"group:ml-team@example.com", rbacv1.GroupKind, "ml-team@example.com", true},
+		{"serviceAccount:kf-user@p.iam.gserviceaccount.com", rbacv1.UserKind,
+			"kf-user@p.iam.gserviceaccount.com", true},
+		{"domain:example.com", "", "", false},
+		{"user:alice", "", "", false},
+	}
+	for _, c := range cases {
+		subject, err := tenantSubject(c.member)
+		if !c.valid {
+			if err == nil {
+				t.Errorf("tenantSubject(%v) succeeded", c.member)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("tenantSubject(%v) failed: %v", c.member, err)
+			continue
+		}
+		if subject.Kind != c.kind || subject.Name != c.name || subject.APIGroup != rbacv1.GroupName {
+			t.Errorf("tenantSubject(%v) is %+v; want %v %v", c.member, subject, c.kind, c.name)
+		}
+	}
+}
+
+func TestValidateTenants(t *testing.T) {
+	cases := []struct {
+		tenants []kfdefs.Tenant
+		valid   bool
+	}{
+		{[]kfdefs.Tenant{{Namespace: "team-a", Admins: []string{"alice@example.com"}},
+			{Namespace: "team-b", Members: []string{"group:b@example.com"}}}, true},
+		{[]kfdefs.Tenant{{Namespace: "Team_A"}}, false},
+		{[]kfdefs.Tenant{{Namespace: "kubeflow"}}, false},
+		{[]kfdefs.Tenant{{Namespace: IstioNamespace}}, false},
+		{[]kfdefs.Tenant{{Namespace: "team-a"}, {Namespace: "team-a"}}, false},
+		{[]kfdefs.Tenant{{Namespace: "team-a", Members: []string{"domain:example.com"}}}, false},
+	}
+	for i, c := range cases {
+		gcp := &Gcp{}
+		gcp.Namespace = "kubeflow"
+		gcp.Spec.Tenants = c.tenants
+		err := gcp.validateTenants()
+		if c.valid && err != nil {
+			t.Errorf("case %v: validateTenants failed: %v", i, err)
+		} else if !c.valid && err == nil {
+			t.Errorf("case %v: validateTenants succeeded", i)
+		}
+	}
+}
+
+func TestTenantIstioPolicies(t *testing.T) {
+	gcp := &Gcp{}
+	gcp.Spec.UseIstio = true
+	gcp.Spec.Tenants = []kfdefs.Tenant{
+		{Namespace: "team-a", Admins: []string{"alice@example.com"}, Members: []string{"group:a@example.com"}},
+		{Namespace: "team-b", Members: []string{"group:b@example.com"}},
+	}
+	manifest, err := gcp.tenantIstioPolicies()
+	if err != nil {
+		t.Fatal(err)
+	}
+	docs := strings.Split(string(manifest), "---\n")
+	// The ClusterRbacConfig, the ServiceRoles of both tenants and the ServiceRoleBinding of
+	// team-a; team-b has no users.
+	if len(docs) != 4 {
+		t.Fatalf("got %v objects; want 4:\n%v", len(docs), string(manifest))
+	}
+	for _, expected := range []string{"ON_WITH_INCLUSION", "- team-a\n", "- team-b\n",
+		"request.headers[x-goog-authenticated-user-email]: accounts.google.com:alice@example.com"} {
+		if !strings.Contains(string(manifest), expected) {
+			t.Errorf("the policies don't have %q:\n%v", expected, string(manifest))
+		}
+	}
+	if strings.Contains(string(manifest), "a@example.com\n") {
+		t.Errorf("the policies allow a group:\n%v", string(manifest))
+	}
+
+	gcp.Spec.UseBasicAuth = true
+	if manifest, err = gcp.tenantIstioPolicies(); err != nil || manifest != nil {
+		t.Errorf("got policies %v (%v) with basic auth; want none", string(manifest), err)
+	}
+}
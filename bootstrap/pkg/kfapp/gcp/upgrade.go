@@ -0,0 +1,554 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"fmt"
+	"github.com/cenkalti/backoff"
+	kfapis "github.com/kubeflow/kubeflow/bootstrap/pkg/apis"
+	kfdefs "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps/kfdef/v1alpha1"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+	containerpb "google.golang.org/genproto/googleapis/container/v1"
+	"k8s.io/api/core/v1"
+	policy "k8s.io/api/policy/v1beta1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientset "k8s.io/client-go/kubernetes"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// GKE_NODE_POOL_LABEL is the label GKE gives the nodes of a node pool, set to its name.
+	GKE_NODE_POOL_LABEL = "cloud.google.com/gke-nodepool"
+	// LATEST_GKE_VERSION upgrades to the latest version GKE supports in the zone.
+	LATEST_GKE_VERSION = "latest"
+	// drainTimeout bounds the eviction of the pods of a node; evictions are refused while they'd
+	// break a PodDisruptionBudget.
+	drainTimeout = 20 * time.Minute
+	// healthTimeout bounds the wait for the components to be available again after an upgrade.
+	healthTimeout = 10 * time.Minute
+)
+
+// gkeVersionPattern matches the GKE versions, e.g. 1.12 or 1.12.7-gke.10.
+var gkeVersionPattern = regexp.MustCompile("^([0-9]+)\\.([0-9]+)(?:\\.([0-9]+))?(?:-gke\\.([0-9]+))?$")
+
+// UpgradeCluster upgrades the master of the cluster to masterVersion, then its node pools to
+// nodeVersion, and records the versions in spec.gke with the version the cluster was created
+// with, which the DM config keeps as its initial version. Either may be empty to keep it; a version
+// can be latest, a minor version like 1.13 for its latest patch, or a version GKE supports in
+// the zone. The master goes first since nodes can't be newer than it.
+//
+// The container API has no in-place surge upgrade, so each node pool is replaced by a copy at
+// nodeVersion with the next pool version, recorded like SetNodePoolMachineType does. The nodes of the old
+// pool are cordoned, then drained maxSurge at a time as the new pool grows by maxSurge nodes.
+// Pods are evicted rather than deleted, so the PodDisruptionBudgets of the components are
+// respected. The storage disks are snapshotted before any pool is with spec.snapshotStorage.
+// The components are checked to be available again once the upgrade is done.
+func (gcp *Gcp) UpgradeCluster(masterVersion string, nodeVersion string, maxSurge int) error {
+	if masterVersion == "" && nodeVersion == "" {
+		return &kfapis.KfError{
+			Code:    int(kfapis.INVALID_ARGUMENT),
+			Message: "a master or a node version is required",
+		}
+	}
+	if maxSurge < 1 {
+		return &kfapis.KfError{
+			Code:    int(kfapis.INVALID_ARGUMENT),
+			Message: fmt.Sprintf("max surge must be at least 1; got %v", maxSurge),
+		}
+	}
+	ctx := context.Background()
+	client, err := gcp.newContainerClient(ctx)
+	if err != nil {
+		return err
+	}
+	cluster, err := gcp.getCluster(ctx, client)
+	if err != nil {
+		return err
+	}
+	serverConfig, err := client.GetServerConfig(ctx, &containerpb.GetServerConfigRequest{
+		ProjectId: gcp.Spec.Project,
+		Zone:      gcp.Spec.Zone,
+	})
+	if err != nil {
+		return fmt.Errorf("couldn't get the GKE versions of %v: %v", gcp.Spec.Zone, err)
+	}
+	master, err := resolveGkeVersion(masterVersion, serverConfig.ValidMasterVersions)
+	if err != nil {
+		return err
+	}
+	nodes, err := resolveGkeVersion(nodeVersion, serverConfig.ValidNodeVersions)
+	if err != nil {
+		return err
+	}
+	currentMaster := cluster.CurrentMasterVersion
+	if master != "" {
+		if compareGkeVersions(master, currentMaster) < 0 {
+			return &kfapis.KfError{
+				Code:    int(kfapis.INVALID_ARGUMENT),
+				Message: fmt.Sprintf("can't downgrade the master of %v from %v to %v", gcp.Name, currentMaster, master),
+			}
+		}
+		currentMaster = master
+	}
+	if nodes != "" && compareGkeVersions(nodes, currentMaster) > 0 {
+		return &kfapis.KfError{
+			Code:    int(kfapis.INVALID_ARGUMENT),
+			Message: fmt.Sprintf("nodes can't be newer than the master; %v is newer than %v", nodes, currentMaster),
+		}
+	}
+	k8sClient, err := gcp.getK8sClientset(ctx)
+	if err != nil {
+		return fmt.Errorf("couldn't reach the cluster: %v", err)
+	}
+	if nodes != "" {
+		if err = checkDisruptionBudgets(k8sClient, gcp.namespace(), gcp.istioNamespace()); err != nil {
+			return err
+		}
+	}
+
+	if master != "" && master != cluster.CurrentMasterVersion {
+		log.Infof("Upgrading the master of %v from %v to %v", gcp.Name, cluster.CurrentMasterVersion, master)
+		op, err := client.UpdateMaster(ctx, &containerpb.UpdateMasterRequest{
+			ProjectId:     gcp.Spec.Project,
+			Zone:          gcp.Spec.Zone,
+			ClusterId:     gcp.Name,
+			MasterVersion: master,
+		})
+		if err != nil {
+			return fmt.Errorf("couldn't upgrade the master of %v: %v", gcp.Name, err)
+		}
+		if err = gcp.waitForNodePoolOperation(ctx, client, op); err != nil {
+			return err
+		}
+	}
+	if nodes != "" {
+		if err = gcp.upgradeNodePools(ctx, client, k8sClient, nodes, maxSurge); err != nil {
+			return err
+		}
+	}
+	if err = gcp.recordClusterVersions(cluster.InitialClusterVersion, master, nodes); err != nil {
+		return err
+	}
+	return gcp.verifyComponentHealth(k8sClient, gcp.namespace(), gcp.istioNamespace())
+}
+
+// upgradeNodePools replaces the node pools of the app which don't run nodes yet. With
+// spec.snapshotStorage the storage disks are snapshotted first, as the nodes they're attached to
+// are drained and deleted.
+func (gcp *Gcp) upgradeNodePools(ctx context.Context, client ContainerClient, k8sClient clientset.Interface,
+	nodes string, maxSurge int) error {
+	upgrading := map[string]*containerpb.NodePool{}
+	for _, pool := range []string{CPU_POOL, GPU_POOL} {
+		nodePool, err := gcp.getNodePool(ctx, client, pool)
+		if err != nil {
+			if pool == GPU_POOL {
+				continue
+			}
+			return err
+		}
+		if nodePool.Version == nodes {
+			log.Infof("Node pool %v already runs %v", nodePool.Name, nodes)
+			continue
+		}
+		upgrading[pool] = nodePool
+	}
+	if len(upgrading) > 0 && gcp.Spec.SnapshotStorage {
+		if err := gcp.snapshotStorage(ctx); err != nil {
+			return err
+		}
+	}
+	for _, pool := range []string{CPU_POOL, GPU_POOL} {
+		nodePool, ok := upgrading[pool]
+		if !ok {
+			continue
+		}
+		if err := gcp.upgradeNodePool(ctx, client, k8sClient, pool, nodePool, nodes, maxSurge); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getCluster returns the live cluster of the app.
+func (gcp *Gcp) getCluster(ctx context.Context, client ContainerClient) (*containerpb.Cluster, error) {
+	list, err := client.ListClusters(ctx, &containerpb.ListClustersRequest{
+		ProjectId: gcp.Spec.Project,
+		Zone:      gcp.Spec.Zone,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("couldn't list the clusters of %v: %v", gcp.Spec.Project, err)
+	}
+	for _, c := range list.Clusters {
+		if c.Name == gcp.Name {
+			return c, nil
+		}
+	}
+	return nil, &kfapis.KfError{
+		Code:    int(kfapis.INVALID_ARGUMENT),
+		Message: fmt.Sprintf("there's no cluster %v in %v", gcp.Name, gcp.Spec.Zone),
+	}
+}
+
+// upgradeNodePool replaces nodePool, the live pool of pool, by a copy running version, draining
+// the old nodes maxSurge at a time as the new pool grows. The pool version of the copy is recorded
+// in spec.nodePools, so the DM config names it and the next update doesn't recreate the pool.
+func (gcp *Gcp) upgradeNodePool(ctx context.Context, client ContainerClient, k8sClient clientset.Interface,
+	pool string, nodePool *containerpb.NodePool, version string, maxSurge int) error {
+	oldNodes, err := poolNodes(k8sClient, nodePool.Name)
+	if err != nil {
+		return err
+	}
+	size := int(nodePool.InitialNodeCount)
+	if len(oldNodes) > size {
+		size = len(oldNodes)
+	}
+	newPool := &containerpb.NodePool{
+		Name:             nextPoolName(nodePool.Name),
+		Config:           nodePool.Config,
+		InitialNodeCount: int32(minInt(maxSurge, size)),
+		Autoscaling:      nodePool.Autoscaling,
+		Management:       nodePool.Management,
+		Version:          version,
+	}
+	log.Infof("Creating node pool %v with version %v", newPool.Name, version)
+	op, err := client.CreateNodePool(ctx, &containerpb.CreateNodePoolRequest{
+		ProjectId: gcp.Spec.Project,
+		Zone:      gcp.Spec.Zone,
+		ClusterId: gcp.Name,
+		NodePool:  newPool,
+	})
+	if err != nil {
+		return fmt.Errorf("couldn't create node pool %v: %v", newPool.Name, err)
+	}
+	if err = gcp.waitForNodePoolOperation(ctx, client, op); err != nil {
+		return err
+	}
+	if err = cordonNodes(k8sClient, oldNodes); err != nil {
+		return err
+	}
+	newSize := int(newPool.InitialNodeCount)
+	for start := 0; start < len(oldNodes); start += maxSurge {
+		end := minInt(start+maxSurge, len(oldNodes))
+		if target := minInt(end, size); target > newSize {
+			log.Infof("Growing node pool %v to %v nodes", newPool.Name, target)
+			op, err = client.SetNodePoolSize(ctx, &containerpb.SetNodePoolSizeRequest{
+				ProjectId:  gcp.Spec.Project,
+				Zone:       gcp.Spec.Zone,
+				ClusterId:  gcp.Name,
+				NodePoolId: newPool.Name,
+				NodeCount:  int32(target),
+			})
+			if err != nil {
+				return fmt.Errorf("couldn't resize node pool %v: %v", newPool.Name, err)
+			}
+			if err = gcp.waitForNodePoolOperation(ctx, client, op); err != nil {
+				return err
+			}
+			newSize = target
+		}
+		for _, node := range oldNodes[start:end] {
+			if err = gcp.drainNode(k8sClient, node); err != nil {
+				return err
+			}
+		}
+	}
+	if newSize < size {
+		op, err = client.SetNodePoolSize(ctx, &containerpb.SetNodePoolSizeRequest{
+			ProjectId:  gcp.Spec.Project,
+			Zone:       gcp.Spec.Zone,
+			ClusterId:  gcp.Name,
+			NodePoolId: newPool.Name,
+			NodeCount:  int32(size),
+		})
+		if err != nil {
+			return fmt.Errorf("couldn't resize node pool %v: %v", newPool.Name, err)
+		}
+		if err = gcp.waitForNodePoolOperation(ctx, client, op); err != nil {
+			return err
+		}
+	}
+	log.Infof("Deleting node pool %v", nodePool.Name)
+	op, err = client.DeleteNodePool(ctx, &containerpb.DeleteNodePoolRequest{
+		ProjectId:  gcp.Spec.Project,
+		Zone:       gcp.Spec.Zone,
+		ClusterId:  gcp.Name,
+		NodePoolId: nodePool.Name,
+	})
+	if err != nil {
+		return fmt.Errorf("couldn't delete node pool %v: %v", nodePool.Name, err)
+	}
+	if err = gcp.waitForNodePoolOperation(ctx, client, op); err != nil {
+		return err
+	}
+	return gcp.recordNodePool(pool, size, nodePool.Config.MachineType, poolVersion(newPool.Name))
+}
+
+// poolNodes returns the names of the nodes of the node pool name, sorted.
+func poolNodes(k8sClient clientset.Interface, name string) ([]string, error) {
+	list, err := k8sClient.CoreV1().Nodes().List(metav1.ListOptions{
+		LabelSelector: GKE_NODE_POOL_LABEL + "=" + name,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("couldn't list the nodes of %v: %v", name, err)
+	}
+	nodes := []string{}
+	for _, node := range list.Items {
+		nodes = append(nodes, node.Name)
+	}
+	sort.Strings(nodes)
+	return nodes, nil
+}
+
+// cordonNodes marks nodes unschedulable, so the pods evicted from them land on the new pool.
+func cordonNodes(k8sClient clientset.Interface, nodes []string) error {
+	for _, name := range nodes {
+		node, err := k8sClient.CoreV1().Nodes().Get(name, metav1.GetOptions{})
+		if err != nil {
+			return fmt.Errorf("couldn't get node %v: %v", name, err)
+		}
+		if node.Spec.Unschedulable {
+			continue
+		}
+		node.Spec.Unschedulable = true
+		if _, err = k8sClient.CoreV1().Nodes().Update(node); err != nil {
+			return fmt.Errorf("couldn't cordon node %v: %v", name, err)
+		}
+	}
+	return nil
+}
+
+// drainablePods returns the pods of pods a drain evicts: those not run by a DaemonSet, which
+// would only be recreated on the node, and not mirror pods of the kubelet.
+func drainablePods(pods []v1.Pod) []v1.Pod {
+	drainable := []v1.Pod{}
+	for _, pod := range pods {
+		if _, mirror := pod.Annotations[v1.MirrorPodAnnotationKey]; mirror {
+			continue
+		}
+		if pod.Status.Phase == v1.PodSucceeded || pod.Status.Phase == v1.PodFailed {
+			continue
+		}
+		daemon := false
+		for _, owner := range pod.OwnerReferences {
+			if owner.Kind == "DaemonSet" {
+				daemon = true
+			}
+		}
+		if !daemon {
+			drainable = append(drainable, pod)
+		}
+	}
+	return drainable
+}
+
+// drainNode evicts the pods of node and waits for them to be gone. The API server refuses an
+// eviction breaking a PodDisruptionBudget with 429; it's retried until the budget allows it.
+func (gcp *Gcp) drainNode(k8sClient clientset.Interface, node string) error {
+	log.Infof("Draining node %v", node)
+	b := backoff.NewExponentialBackOff()
+	b.MaxElapsedTime = drainTimeout
+	return gcp.retry(func() error {
+		list, err := k8sClient.CoreV1().Pods(metav1.NamespaceAll).List(metav1.ListOptions{
+			FieldSelector: "spec.nodeName=" + node,
+		})
+		if err != nil {
+			return fmt.Errorf("couldn't list the pods of node %v: %v", node, err)
+		}
+		pods := drainablePods(list.Items)
+		if len(pods) == 0 {
+			return nil
+		}
+		for _, pod := range pods {
+			err = k8sClient.PolicyV1beta1().Evictions(pod.Namespace).Evict(&policy.Eviction{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      pod.Name,
+					Namespace: pod.Namespace,
+				},
+			})
+			if err != nil && !k8serrors.IsNotFound(err) && !k8serrors.IsTooManyRequests(err) {
+				return backoff.Permanent(fmt.Errorf("couldn't evict pod %v/%v: %v", pod.Namespace, pod.Name, err))
+			}
+		}
+		return fmt.Errorf("node %v still runs %v pods", node, len(pods))
+	}, b)
+}
+
+// checkDisruptionBudgets fails on the PodDisruptionBudgets of namespaces allowing no disruption:
+// the drain of the nodes of their pods would wait on them until it times out.
+func checkDisruptionBudgets(k8sClient clientset.Interface, namespaces ...string) error {
+	blocking := []string{}
+	for _, namespace := range namespaces {
+		list, err := k8sClient.PolicyV1beta1().PodDisruptionBudgets(namespace).List(metav1.ListOptions{})
+		if err != nil {
+			return fmt.Errorf("couldn't list the PodDisruptionBudgets of %v: %v", namespace, err)
+		}
+		for _, pdb := range list.Items {
+			if pdb.Status.ExpectedPods > 0 && pdb.Status.PodDisruptionsAllowed < 1 {
+				blocking = append(blocking, namespace+"/"+pdb.Name)
+			}
+		}
+	}
+	if len(blocking) > 0 {
+		return &kfapis.KfError{
+			Code: int(kfapis.INVALID_ARGUMENT),
+			Message: fmt.Sprintf("PodDisruptionBudgets %v allow no disruption; scale up their pods before "+
+				"upgrading the nodes", strings.Join(blocking, ", ")),
+		}
+	}
+	return nil
+}
+
+// unavailableComponents returns the deployments and stateful sets of namespace which don't have
+// all their replicas available.
+func unavailableComponents(k8sClient clientset.Interface, namespace string) ([]string, error) {
+	unavailable := []string{}
+	deployments, err := k8sClient.AppsV1().Deployments(namespace).List(metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("couldn't list the deployments of %v: %v", namespace, err)
+	}
+	for _, d := range deployments.Items {
+		if d.Spec.Replicas != nil && d.Status.AvailableReplicas < *d.Spec.Replicas {
+			unavailable = append(unavailable, fmt.Sprintf("deployment %v/%v", namespace, d.Name))
+		}
+	}
+	statefulSets, err := k8sClient.AppsV1().StatefulSets(namespace).List(metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("couldn't list the stateful sets of %v: %v", namespace, err)
+	}
+	for _, s := range statefulSets.Items {
+		if s.Spec.Replicas != nil && s.Status.ReadyReplicas < *s.Spec.Replicas {
+			unavailable = append(unavailable, fmt.Sprintf("statefulset %v/%v", namespace, s.Name))
+		}
+	}
+	return unavailable, nil
+}
+
+// verifyComponentHealth waits for the components of namespaces to be available.
+func (gcp *Gcp) verifyComponentHealth(k8sClient clientset.Interface, namespaces ...string) error {
+	b := backoff.NewExponentialBackOff()
+	b.MaxElapsedTime = healthTimeout
+	return gcp.retry(func() error {
+		unavailable := []string{}
+		for _, namespace := range namespaces {
+			components, err := unavailableComponents(k8sClient, namespace)
+			if err != nil {
+				return err
+			}
+			unavailable = append(unavailable, components...)
+		}
+		if len(unavailable) > 0 {
+			return fmt.Errorf("components not available after the upgrade: %v", strings.Join(unavailable, ", "))
+		}
+		return nil
+	}, b)
+}
+
+// recordClusterVersions records the initial, master and node versions in spec.gke and
+// regenerates the cluster config from them.
+func (gcp *Gcp) recordClusterVersions(initialVersion string, masterVersion string, nodeVersion string) error {
+	if gcp.Spec.Gke == nil {
+		gcp.Spec.Gke = &kfdefs.GkeConfig{}
+	}
+	if gcp.Spec.Gke.InitialVersion == "" {
+		gcp.Spec.Gke.InitialVersion = initialVersion
+	}
+	if masterVersion != "" {
+		gcp.Spec.Gke.MasterVersion = masterVersion
+	}
+	if nodeVersion != "" {
+		gcp.Spec.Gke.NodeVersion = nodeVersion
+	}
+	return gcp.writeClusterConfigs()
+}
+
+// forgetInitialVersion clears spec.gke.initialVersion once the cluster is deleted, so a new
+// cluster is created at spec.gke.masterVersion.
+func (gcp *Gcp) forgetInitialVersion() error {
+	gcp.specLock.Lock()
+	gke := gcp.Spec.Gke
+	if gke == nil || gke.InitialVersion == "" {
+		gcp.specLock.Unlock()
+		return nil
+	}
+	gke.InitialVersion = ""
+	gcp.specLock.Unlock()
+	if !gcp.isCLI {
+		return nil
+	}
+	if err := gcp.writeConfigFile(); err != nil {
+		return fmt.Errorf("cannot write to config file app.yaml in %v: %v", gcp.Spec.AppDir, err)
+	}
+	return nil
+}
+
+// resolveGkeVersion returns the version of valid version stands for: itself, the latest of valid
+// for latest, or the latest patch of valid for a minor version.
+func resolveGkeVersion(version string, valid []string) (string, error) {
+	if version == "" {
+		return "", nil
+	}
+	resolved := ""
+	for _, v := range valid {
+		if v == version {
+			return v, nil
+		}
+		if version == LATEST_GKE_VERSION || strings.HasPrefix(v, version+".") || strings.HasPrefix(v, version+"-") {
+			if resolved == "" || compareGkeVersions(v, resolved) > 0 {
+				resolved = v
+			}
+		}
+	}
+	if resolved == "" {
+		return "", &kfapis.KfError{
+			Code:    int(kfapis.INVALID_ARGUMENT),
+			Message: fmt.Sprintf("GKE doesn't support version %v; valid versions are %v", version, valid),
+		}
+	}
+	return resolved, nil
+}
+
+// compareGkeVersions returns -1, 0 or 1 as a is older than, the same as or newer than b. The
+// missing parts of a version are 0; versions GKE doesn't use are equal to every version.
+func compareGkeVersions(a string, b string) int {
+	pa := gkeVersionPattern.FindStringSubmatch(a)
+	pb := gkeVersionPattern.FindStringSubmatch(b)
+	if pa == nil || pb == nil {
+		return 0
+	}
+	for i := 1; i < len(pa); i++ {
+		na, _ := strconv.Atoi(pa[i])
+		nb, _ := strconv.Atoi(pb[i])
+		if na < nb {
+			return -1
+		}
+		if na > nb {
+			return 1
+		}
+	}
+	return 0
+}
+
+func minInt(a int, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,225 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"io/ioutil"
+	"net/http"
+	"path"
+	"reflect"
+	"strings"
+	"testing"
+
+	kfdefs "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps/kfdef/v1alpha1"
+	"github.com/kubeflow/kubeflow/bootstrap/pkg/kfapp/gcp/fake"
+	"golang.org/x/net/context"
+	containerpb "google.golang.org/genproto/googleapis/container/v1"
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestResolveGkeVersion(t *testing.T) {
+	valid := []string{"1.13.6-gke.0", "1.12.8-gke.6", "1.12.7-gke.17", "1.12.7-gke.10", "1.11.10-gke.4"}
+	cases := []struct {
+		version  string
+		expected string
+		valid    bool
+	}{
+		{"", "", true},
+		{"latest", "1.13.6-gke.0", true},
+		{"1.12", "1.12.8-gke.6", true},
+		{"1.12.7", "1.12.7-gke.17", true},
+		{"1.12.7-gke.10", "1.12.7-gke.10", true},
+		{"1.1", "", false},
+		{"1.14", "", false},
+	}
+	for _, c := range cases {
+		resolved, err := resolveGkeVersion(c.version, valid)
+		if c.valid && err != nil {
+			t.Errorf("resolveGkeVersion(%v) failed: %v", c.version, err)
+		} else if !c.valid && err == nil {
+			t.Errorf("resolveGkeVersion(%v) = %v; want an error", c.version, resolved)
+		} else if resolved != c.expected {
+			t.Errorf("resolveGkeVersion(%v) = %v; want %v", c.version, resolved, c.expected)
+		}
+	}
+}
+
+func TestCompareGkeVersions(t *testing.T) {
+	cases := []struct {
+		a        string
+		b        string
+		expected int
+	}{
+		{"1.12.7-gke.10", "1.12.7-gke.10", 0},
+		{"1.12.7-gke.10", "1.12.7-gke.17", -1},
+		{"1.13.6-gke.0", "1.12.8-gke.6", 1},
+		{"1.9.7-gke.1", "1.10.2-gke.3", -1},
+		{"1.12", "1.12.0", 0},
+	}
+	for _, c := range cases {
+		if actual := compareGkeVersions(c.a, c.b); actual != c.expected {
+			t.Errorf("compareGkeVersions(%v, %v) = %v; want %v", c.a, c.b, actual, c.expected)
+		}
+	}
+}
+
+func TestDrainablePods(t *testing.T) {
+	pods := []v1.Pod{
+		{ObjectMeta: metav1.ObjectMeta{Name: "centraldashboard"}},
+		{ObjectMeta: metav1.ObjectMeta{
+			Name:            "fluentd",
+			OwnerReferences: []metav1.OwnerReference{{Kind: "DaemonSet", Name: "fluentd"}},
+		}},
+		{ObjectMeta: metav1.ObjectMeta{
+			Name:        "kube-proxy",
+			Annotations: map[string]string{v1.MirrorPodAnnotationKey: "hash"},
+		}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "job"}, Status: v1.PodStatus{Phase: v1.PodSucceeded}},
+	}
+	drainable := drainablePods(pods)
+	if len(drainable) != 1 || drainable[0].Name != "centraldashboard" {
+		t.Errorf("drainablePods = %v; want centraldashboard", drainable)
+	}
+}
+
+func TestUpgradeNodePool(t *testing.T) {
+	client := fake.NewContainer(&containerpb.Cluster{
+		Name: "kf",
+		NodePools: []*containerpb.NodePool{{
+			Name:             "kf-cpu-pool-v1",
+			Config:           &containerpb.NodeConfig{MachineType: "n1-standard-8"},
+			InitialNodeCount: 2,
+			Version:          "1.12.7-gke.10",
+		}},
+	})
+	k8sClient, _, done := newRecordingClientset(t, fakeKubeApis{
+		"/api/v1/nodes": `{"items": []}`,
+	})
+	defer done()
+	store := NewBundle()
+	gcp := newDoctorGcp()
+	gcp.store = store
+	gcp.clock = fakeClock{}
+	gcp.Spec.UseEmbeddedAssets = true
+	gcp.Spec.Gke = &kfdefs.GkeConfig{}
+	if err := gcp.recordClusterVersions("1.12.7-gke.10", "1.13.6-gke.6", "1.13.6-gke.6"); err != nil {
+		t.Fatalf("recordClusterVersions: %v", err)
+	}
+	nodePool := client.Cluster("kf").NodePools[0]
+	if err := gcp.upgradeNodePool(context.Background(), client, k8sClient, CPU_POOL, nodePool,
+		"1.13.6-gke.6", 1); err != nil {
+		t.Fatalf("upgradeNodePool: %v", err)
+	}
+
+	pools := client.Cluster("kf").NodePools
+	if len(pools) != 1 || pools[0].Name != "kf-cpu-pool-v2" || pools[0].Version != "1.13.6-gke.6" {
+		t.Fatalf("node pools after the upgrade got %v; want kf-cpu-pool-v2 at 1.13.6-gke.6", pools)
+	}
+	if len(gcp.Spec.NodePools) != 1 || gcp.Spec.NodePools[0].PoolVersion != "v2" ||
+		gcp.Spec.NodePools[0].Nodes != 2 || gcp.Spec.NodePools[0].MachineType != "n1-standard-8" {
+		t.Errorf("spec.nodePools got %+v; want cpu-pool v2 with 2 n1-standard-8 nodes", gcp.Spec.NodePools)
+	}
+	config, ok := store.Get(path.Join(GCP_CONFIG, CONFIG_FILE))
+	if !ok {
+		t.Fatalf("upgradeNodePool didn't write %v", CONFIG_FILE)
+	}
+	for _, expected := range []string{"cpu-pool-version: v2", "node-version: 1.13.6-gke.6",
+		"cluster-version: 1.12.7-gke.10"} {
+		if !strings.Contains(string(config), expected) {
+			t.Errorf("%v doesn't set %v:\n%s", CONFIG_FILE, expected, config)
+		}
+	}
+
+	if err := gcp.forgetInitialVersion(); err != nil {
+		t.Fatalf("forgetInitialVersion: %v", err)
+	}
+	if gcp.Spec.Gke.InitialVersion != "" {
+		t.Errorf("forgetInitialVersion kept %v", gcp.Spec.Gke.InitialVersion)
+	}
+}
+
+// snapshottingCompute serves the snapshots of the storage disks, recording the node pools of
+// cluster when each is taken.
+type snapshottingCompute struct {
+	cluster func() *containerpb.Cluster
+	pools   [][]string
+}
+
+func (f *snapshottingCompute) RoundTrip(req *http.Request) (*http.Response, error) {
+	if strings.HasSuffix(req.URL.Path, "/createSnapshot") {
+		pools := []string{}
+		for _, nodePool := range f.cluster().NodePools {
+			pools = append(pools, nodePool.Name)
+		}
+		f.pools = append(f.pools, pools)
+	}
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       ioutil.NopCloser(strings.NewReader(`{"name": "op-1", "status": "DONE"}`)),
+		Request:    req,
+	}, nil
+}
+
+func TestUpgradeNodePoolsSnapshotsStorage(t *testing.T) {
+	client := fake.NewContainer(&containerpb.Cluster{
+		Name: "kf",
+		NodePools: []*containerpb.NodePool{{
+			Name:             "kf-cpu-pool-v1",
+			Config:           &containerpb.NodeConfig{MachineType: "n1-standard-8"},
+			InitialNodeCount: 1,
+			Version:          "1.12.7-gke.10",
+		}},
+	})
+	k8sClient, _, done := newRecordingClientset(t, fakeKubeApis{
+		"/api/v1/nodes": `{"items": []}`,
+	})
+	defer done()
+	compute := &snapshottingCompute{cluster: func() *containerpb.Cluster { return client.Cluster("kf") }}
+	gcp := newDoctorGcp()
+	gcp.clock = fakeClock{}
+	gcp.client = &http.Client{Transport: compute}
+	gcp.Spec.UseEmbeddedAssets = true
+	gcp.Spec.SnapshotStorage = true
+
+	// The pool already runs the version, so nothing is replaced nor snapshotted.
+	if err := gcp.upgradeNodePools(context.Background(), client, k8sClient, "1.12.7-gke.10", 1); err != nil {
+		t.Fatalf("upgradeNodePools: %v", err)
+	}
+	if len(compute.pools) != 0 || len(gcp.Spec.StorageSnapshots) != 0 {
+		t.Errorf("upgradeNodePools snapshotted %v without replacing a pool", gcp.Spec.StorageSnapshots)
+	}
+
+	if err := gcp.upgradeNodePools(context.Background(), client, k8sClient, "1.13.6-gke.6", 1); err != nil {
+		t.Fatalf("upgradeNodePools: %v", err)
+	}
+	// Both disks are snapshotted while the old pool is still there.
+	expected := [][]string{{"kf-cpu-pool-v1"}, {"kf-cpu-pool-v1"}}
+	if !reflect.DeepEqual(compute.pools, expected) {
+		t.Errorf("the disks were snapshotted with the node pools %v; want %v", compute.pools, expected)
+	}
+	disks := []string{}
+	for _, snapshot := range gcp.Spec.StorageSnapshots {
+		disks = append(disks, snapshot.Disk)
+	}
+	if !reflect.DeepEqual(disks, gcp.storageDisks()) {
+		t.Errorf("spec.storageSnapshots recorded the disks %v; want %v", disks, gcp.storageDisks())
+	}
+	pools := client.Cluster("kf").NodePools
+	if len(pools) != 1 || pools[0].Name != "kf-cpu-pool-v2" {
+		t.Errorf("node pools after the upgrade got %v; want kf-cpu-pool-v2", pools)
+	}
+}
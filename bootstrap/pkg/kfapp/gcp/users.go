@@ -0,0 +1,256 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"fmt"
+	kfapis "github.com/kubeflow/kubeflow/bootstrap/pkg/apis"
+	kftypes "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+	"k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	clientset "k8s.io/client-go/kubernetes"
+	"strings"
+	"time"
+)
+
+const (
+	// BASIC_AUTH_DEPLOYMENT is the gatekeeper deployment reading BASIC_AUTH_SECRET.
+	BASIC_AUTH_DEPLOYMENT = "basic-auth"
+	// HTPASSWD_KEY holds one username:passwordhash entry per line in BASIC_AUTH_SECRET.
+	HTPASSWD_KEY = "htpasswd"
+	// RESTARTED_AT_ANNOTATION is set on the pod template to roll the pods reading a secret.
+	RESTARTED_AT_ANNOTATION = "kubeflow.org/restartedAt"
+)
+
+// basicAuthUser is an entry of the htpasswd key, passwordHash being the base64 encoded bcrypt hash.
+type basicAuthUser struct {
+	username     string
+	passwordHash string
+}
+
+// parseHtpasswd parses username:passwordhash lines, skipping blank ones.
+func parseHtpasswd(htpasswd string) ([]basicAuthUser, error) {
+	users := []basicAuthUser{}
+	for _, line := range strings.Split(htpasswd, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		entry := strings.SplitN(line, ":", 2)
+		if len(entry) != 2 || entry[0] == "" || entry[1] == "" {
+			return nil, fmt.Errorf("malformed %v entry for %v", HTPASSWD_KEY, entry[0])
+		}
+		users = append(users, basicAuthUser{username: entry[0], passwordHash: entry[1]})
+	}
+	return users, nil
+}
+
+func formatHtpasswd(users []basicAuthUser) string {
+	lines := []string{}
+	for _, user := range users {
+		lines = append(lines, user.username+":"+user.passwordHash)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// setUser replaces the entry of user.username or appends user.
+func setUser(users []basicAuthUser, user basicAuthUser) []basicAuthUser {
+	for i := range users {
+		if users[i].username == user.username {
+			users[i] = user
+			return users
+		}
+	}
+	return append(users, user)
+}
+
+// readUsers returns the users in the basic auth secret. Secrets written before the htpasswd
+// key existed hold a single user in their username and passwordhash keys.
+func (gcp *Gcp) readUsers(client *clientset.Clientset) ([]basicAuthUser, error) {
+	secret, err := client.CoreV1().Secrets(gcp.namespace()).Get(BASIC_AUTH_SECRET, metav1.GetOptions{})
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return []basicAuthUser{}, nil
+		}
+		return nil, fmt.Errorf("couldn't get secret %v: %v", BASIC_AUTH_SECRET, err)
+	}
+	if htpasswd, ok := secret.Data[HTPASSWD_KEY]; ok {
+		return parseHtpasswd(string(htpasswd))
+	}
+	if len(secret.Data["username"]) == 0 {
+		return []basicAuthUser{}, nil
+	}
+	return []basicAuthUser{{
+		username:     string(secret.Data["username"]),
+		passwordHash: string(secret.Data["passwordhash"]),
+	}}, nil
+}
+
+// writeUsers regenerates the basic auth secret from users. The first user is also kept in the
+// username and passwordhash keys for gatekeeper images without htpasswd support.
+func (gcp *Gcp) writeUsers(client *clientset.Clientset, users []basicAuthUser) error {
+	if len(users) == 0 {
+		return &kfapis.KfError{
+			Code:    int(kfapis.INVALID_ARGUMENT),
+			Message: "basic auth needs at least one user",
+		}
+	}
+	secret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      BASIC_AUTH_SECRET,
+			Namespace: gcp.namespace(),
+		},
+		Data: map[string][]byte{
+			"username":     []byte(users[0].username),
+			"passwordhash": []byte(users[0].passwordHash),
+			HTPASSWD_KEY:   []byte(formatHtpasswd(users)),
+		},
+	}
+	_, err := client.CoreV1().Secrets(gcp.namespace()).Update(secret)
+	action := AUDIT_SECRET_UPDATE
+	if err != nil {
+		log.Warnf("Updating basic auth login is failed, trying to create one: %v", err)
+		action = AUDIT_SECRET_CREATE
+		_, err = client.CoreV1().Secrets(gcp.namespace()).Create(secret)
+	}
+	gcp.auditSecret(action, gcp.namespace(), BASIC_AUTH_SECRET, secret.Data, err)
+	return err
+}
+
+// restartBasicAuth rolls the basic auth pods so they pick up the regenerated secret,
+// which is only read into their environment at startup.
+func (gcp *Gcp) restartBasicAuth(client *clientset.Clientset) error {
+	return gcp.restartDeployment(client, BASIC_AUTH_DEPLOYMENT)
+}
+
+// restartDeployment rolls the pods of the deployment name of the app's namespace.
+func (gcp *Gcp) restartDeployment(client *clientset.Clientset, name string) error {
+	patch := fmt.Sprintf(`{"spec":{"template":{"metadata":{"annotations":{%q:%q}}}}}`,
+		RESTARTED_AT_ANNOTATION, gcp.clock.Now().Format(time.RFC3339))
+	_, err := client.ExtensionsV1beta1().Deployments(gcp.namespace()).Patch(name,
+		types.StrategicMergePatchType, []byte(patch))
+	if k8serrors.IsNotFound(err) {
+		// Not applied yet; the pods will read the secret when they are created.
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("couldn't restart deployment %v: %v", name, err)
+	}
+	return nil
+}
+
+// updateUsers applies update to the users in the basic auth secret, writes them back and
+// restarts the basic auth pods.
+func (gcp *Gcp) updateUsers(update func([]basicAuthUser) ([]basicAuthUser, error)) error {
+	if gcp.authProvider().Name() != kftypes.AUTH_BASIC_AUTH {
+		return &kfapis.KfError{
+			Code:    int(kfapis.INVALID_ARGUMENT),
+			Message: fmt.Sprintf("%v doesn't use basic auth", gcp.Name),
+		}
+	}
+	client, err := gcp.getK8sClientset(context.Background())
+	if err != nil {
+		return fmt.Errorf("Get K8s clientset error: %v", err)
+	}
+	users, err := gcp.readUsers(client)
+	if err != nil {
+		return err
+	}
+	if users, err = update(users); err != nil {
+		return err
+	}
+	if err = gcp.writeUsers(client, users); err != nil {
+		return fmt.Errorf("couldn't write basic auth users: %v", err)
+	}
+	return gcp.restartBasicAuth(client)
+}
+
+// AddUser adds username to the basic auth users, or changes its password. The password is
+// read the way apply reads it.
+func (gcp *Gcp) AddUser(username string) error {
+	if username == "" || strings.ContainsAny(username, ":\n") {
+		return &kfapis.KfError{
+			Code:    int(kfapis.INVALID_ARGUMENT),
+			Message: fmt.Sprintf("invalid username %q", username),
+		}
+	}
+	password, err := gcp.loadPassword(username)
+	defer zero(password)
+	if err != nil {
+		return err
+	}
+	if len(password) == 0 {
+		return fmt.Errorf("password of %v can't be empty", username)
+	}
+	passwordHash, err := gcp.hashPassword(password)
+	if err != nil {
+		return err
+	}
+	return gcp.updateUsers(func(users []basicAuthUser) ([]basicAuthUser, error) {
+		return setUser(users, basicAuthUser{username: username, passwordHash: passwordHash}), nil
+	})
+}
+
+// RemoveUser removes username from the basic auth users. The last user can't be removed.
+func (gcp *Gcp) RemoveUser(username string) error {
+	return gcp.updateUsers(func(users []basicAuthUser) ([]basicAuthUser, error) {
+		remaining := []basicAuthUser{}
+		for _, user := range users {
+			if user.username != username {
+				remaining = append(remaining, user)
+			}
+		}
+		if len(remaining) == len(users) {
+			return nil, &kfapis.KfError{
+				Code:    int(kfapis.INVALID_ARGUMENT),
+				Message: fmt.Sprintf("no basic auth user %v", username),
+			}
+		}
+		if len(remaining) == 0 {
+			return nil, &kfapis.KfError{
+				Code:    int(kfapis.INVALID_ARGUMENT),
+				Message: fmt.Sprintf("can't remove %v, the last basic auth user", username),
+			}
+		}
+		return remaining, nil
+	})
+}
+
+// ListUsers returns the basic auth usernames.
+func (gcp *Gcp) ListUsers() ([]string, error) {
+	if gcp.authProvider().Name() != kftypes.AUTH_BASIC_AUTH {
+		return nil, &kfapis.KfError{
+			Code:    int(kfapis.INVALID_ARGUMENT),
+			Message: fmt.Sprintf("%v doesn't use basic auth", gcp.Name),
+		}
+	}
+	client, err := gcp.getK8sClientset(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("Get K8s clientset error: %v", err)
+	}
+	users, err := gcp.readUsers(client)
+	if err != nil {
+		return nil, err
+	}
+	usernames := []string{}
+	for _, user := range users {
+		usernames = append(usernames, user.username)
+	}
+	return usernames, nil
+}
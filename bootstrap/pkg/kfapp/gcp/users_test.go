@@ -0,0 +1,188 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"k8s.io/api/core/v1"
+	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+func TestParseHtpasswd(t *testing.T) {
+	users, err := parseHtpasswd("alice:aGFzaDE=\n\n  bob:aGFzaDI=  \n")
+	if err != nil {
+		t.Fatalf("parseHtpasswd: %v", err)
+	}
+	expected := []basicAuthUser{
+		{username: "alice", passwordHash: "aGFzaDE="},
+		{username: "bob", passwordHash: "aGFzaDI="},
+	}
+	if !reflect.DeepEqual(users, expected) {
+		t.Errorf("parseHtpasswd got %v; want %v", users, expected)
+	}
+	if formatted := formatHtpasswd(users); formatted != "alice:aGFzaDE=\nbob:aGFzaDI=" {
+		t.Errorf("formatHtpasswd got %q", formatted)
+	}
+
+	for _, htpasswd := range []string{"alice", "alice:", ":aGFzaDE="} {
+		if _, err := parseHtpasswd(htpasswd); err == nil {
+			t.Errorf("parseHtpasswd(%q) should fail", htpasswd)
+		}
+	}
+}
+
+func TestSetUser(t *testing.T) {
+	users := []basicAuthUser{
+		{username: "alice", passwordHash: "old"},
+		{username: "bob", passwordHash: "bob"},
+	}
+	users = setUser(users, basicAuthUser{username: "alice", passwordHash: "new"})
+	users = setUser(users, basicAuthUser{username: "carol", passwordHash: "carol"})
+	expected := []basicAuthUser{
+		{username: "alice", passwordHash: "new"},
+		{username: "bob", passwordHash: "bob"},
+		{username: "carol", passwordHash: "carol"},
+	}
+	if !reflect.DeepEqual(users, expected) {
+		t.Errorf("setUser got %v; want %v", users, expected)
+	}
+}
+
+func basicAuthSecretJson(t *testing.T, data map[string]string) string {
+	secret := v1.Secret{Data: map[string][]byte{}}
+	secret.Kind = "Secret"
+	secret.APIVersion = "v1"
+	secret.Name = BASIC_AUTH_SECRET
+	secret.Namespace = "kubeflow"
+	for key, value := range data {
+		secret.Data[key] = []byte(value)
+	}
+	body, err := json.Marshal(secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(body)
+}
+
+func TestReadUsers(t *testing.T) {
+	secretPath := "/api/v1/namespaces/kubeflow/secrets/" + BASIC_AUTH_SECRET
+	cases := []struct {
+		name     string
+		apis     fakeKubeApis
+		expected []basicAuthUser
+	}{
+		{
+			name:     "no secret",
+			apis:     fakeKubeApis{},
+			expected: []basicAuthUser{},
+		},
+		{
+			name: "htpasswd",
+			apis: fakeKubeApis{
+				secretPath: basicAuthSecretJson(t, map[string]string{
+					"username":     "alice",
+					"passwordhash": "aGFzaDE=",
+					HTPASSWD_KEY:   "alice:aGFzaDE=\nbob:aGFzaDI=",
+				}),
+			},
+			expected: []basicAuthUser{
+				{username: "alice", passwordHash: "aGFzaDE="},
+				{username: "bob", passwordHash: "aGFzaDI="},
+			},
+		},
+		{
+			name: "single user secret",
+			apis: fakeKubeApis{
+				secretPath: basicAuthSecretJson(t, map[string]string{
+					"username":     "alice",
+					"passwordhash": "aGFzaDE=",
+				}),
+			},
+			expected: []basicAuthUser{
+				{username: "alice", passwordHash: "aGFzaDE="},
+			},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			client, done := newFakeClientset(t, c.apis)
+			defer done()
+			users, err := newDoctorGcp().readUsers(client)
+			if err != nil {
+				t.Fatalf("readUsers: %v", err)
+			}
+			if !reflect.DeepEqual(users, c.expected) {
+				t.Errorf("readUsers got %v; want %v", users, c.expected)
+			}
+		})
+	}
+}
+
+func TestWriteUsers(t *testing.T) {
+	var written *v1.Secret
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		if r.Method != http.MethodPut ||
+			r.URL.Path != "/api/v1/namespaces/kubeflow/secrets/"+BASIC_AUTH_SECRET {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		written = &v1.Secret{}
+		if err := json.Unmarshal(body, written); err != nil {
+			t.Errorf("bad secret %s: %v", body, err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(body)
+	}))
+	defer server.Close()
+	client, err := clientset.NewForConfig(&rest.Config{Host: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	gcp := newDoctorGcp()
+	if err := gcp.writeUsers(client, []basicAuthUser{}); err == nil {
+		t.Errorf("writeUsers should reject an empty user list")
+	}
+	hash := base64.StdEncoding.EncodeToString([]byte("hash"))
+	users := []basicAuthUser{
+		{username: "alice", passwordHash: hash},
+		{username: "bob", passwordHash: hash},
+	}
+	if err := gcp.writeUsers(client, users); err != nil {
+		t.Fatalf("writeUsers: %v", err)
+	}
+	if written == nil {
+		t.Fatalf("writeUsers didn't update the secret")
+	}
+	expected := map[string]string{
+		"username":     "alice",
+		"passwordhash": hash,
+		HTPASSWD_KEY:   "alice:" + hash + "\nbob:" + hash,
+	}
+	for key, value := range expected {
+		if string(written.Data[key]) != value {
+			t.Errorf("secret key %v got %q; want %q", key, written.Data[key], value)
+		}
+	}
+}
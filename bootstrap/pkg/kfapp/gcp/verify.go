@@ -0,0 +1,289 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"fmt"
+	"github.com/cenkalti/backoff"
+	"github.com/ghodss/yaml"
+	kfapis "github.com/kubeflow/kubeflow/bootstrap/pkg/apis"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+	"google.golang.org/api/cloudresourcemanager/v1"
+	"google.golang.org/api/compute/v1"
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/servicemanagement/v1"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// TEARDOWN_REPORT_FILE is written to the app dir by Delete with what was deleted and what's left.
+	TEARDOWN_REPORT_FILE = "teardown-report.yaml"
+	// verifyTimeout bounds the wait for GCP to actually remove what Delete deleted; some
+	// resources, e.g. IAM members and addresses, are still listed for a while.
+	verifyTimeout = 5 * time.Minute
+)
+
+// DeleteCheck lists the resources of one kind which are still there after Delete. The checks
+// are retried while resources remain, as GCP removes some of them asynchronously.
+type DeleteCheck struct {
+	// Kind of the resources checked, e.g. "disk", used in the teardown report.
+	Kind string
+	// Remaining returns the resources of Kind that still exist.
+	Remaining func(ctx context.Context) ([]string, error)
+}
+
+// WithDeleteChecks adds checks run after Delete to those of the resources kfctl creates.
+func WithDeleteChecks(checks ...DeleteCheck) Option {
+	return func(gcp *Gcp) {
+		gcp.deleteChecks = append(gcp.deleteChecks, checks...)
+	}
+}
+
+// teardownReport is what Delete destroyed and, per kind, what's still there.
+type teardownReport struct {
+	Name    string   `json:"name"`
+	Project string   `json:"project"`
+	Deleted []string `json:"deleted"`
+	// Remaining resources per kind, e.g. disks still billing.
+	Remaining map[string][]string `json:"remaining,omitempty"`
+	// Unverified kinds with the error of their check.
+	Unverified map[string]string `json:"unverified,omitempty"`
+}
+
+// verifyDeleted runs checks until none has remaining resources or verifyTimeout is exceeded,
+// and records the outcome in report.
+func (gcp *Gcp) verifyDeleted(ctx context.Context, checks []DeleteCheck, report *teardownReport) {
+	b := backoff.NewExponentialBackOff()
+	b.MaxElapsedTime = verifyTimeout
+	pending := checks
+	report.Unverified = map[string]string{}
+	gcp.retry(func() error {
+		report.Remaining = map[string][]string{}
+		failed := []DeleteCheck{}
+		for _, check := range pending {
+			remaining, err := check.Remaining(ctx)
+			if err != nil {
+				report.Unverified[check.Kind] = err.Error()
+				continue
+			}
+			if len(remaining) != 0 {
+				report.Remaining[check.Kind] = append(report.Remaining[check.Kind], remaining...)
+				failed = append(failed, check)
+			}
+		}
+		pending = failed
+		if len(pending) == 0 {
+			return nil
+		}
+		kinds := []string{}
+		for _, check := range pending {
+			kinds = append(kinds, check.Kind)
+		}
+		log.Infof("Waiting for the %v resources of %v to be gone", strings.Join(kinds, ", "), gcp.Name)
+		return fmt.Errorf("resources remain")
+	}, b)
+	if len(report.Remaining) == 0 {
+		report.Remaining = nil
+	}
+	if len(report.Unverified) == 0 {
+		report.Unverified = nil
+	}
+}
+
+// teardownChecks are the checks of what Delete deleted, limited to targets, followed by those
+// added with WithDeleteChecks.
+func (gcp *Gcp) teardownChecks(deploymentmanagerService DeploymentManagerClient, targets map[string]bool,
+	deployments []string) []DeleteCheck {
+	project := gcp.Spec.Project
+	checks := []DeleteCheck{}
+	if len(deployments) != 0 {
+		checks = append(checks, DeleteCheck{
+			Kind: "deployment",
+			Remaining: func(ctx context.Context) ([]string, error) {
+				return liveDeployments(ctx, deploymentmanagerService, project, deployments)
+			},
+		})
+	}
+	if targets[COMPONENT_CLUSTER] {
+		// The static IP kfctl reserved is kept without --delete_ip.
+		if !gcp.Spec.IpReserved {
+			checks = append(checks, DeleteCheck{Kind: "address", Remaining: gcp.remainingAddresses})
+		}
+		checks = append(checks, DeleteCheck{Kind: "service account key", Remaining: gcp.remainingServiceAccountKeys})
+	}
+	if (targets[COMPONENT_CLUSTER] || targets[TARGET_ENDPOINTS]) && gcp.isEndpointsHostname() {
+		checks = append(checks, DeleteCheck{Kind: "endpoints service", Remaining: gcp.remainingEndpoints})
+	}
+	if targets[COMPONENT_STORAGE] && gcp.Spec.DeleteStorage {
+		checks = append(checks, DeleteCheck{Kind: "disk", Remaining: gcp.remainingDisks})
+	}
+	if targets[TARGET_IAM] {
+		checks = append(checks, DeleteCheck{Kind: "IAM member", Remaining: gcp.remainingIamMembers})
+	}
+	return append(checks, gcp.deleteChecks...)
+}
+
+func isNotFound(err error) bool {
+	e, ok := err.(*googleapi.Error)
+	return ok && e.Code == 404
+}
+
+func (gcp *Gcp) remainingAddresses(ctx context.Context) ([]string, error) {
+	computeService, err := compute.New(gcp.client)
+	if err != nil {
+		return nil, err
+	}
+	address, err := computeService.GlobalAddresses.Get(gcp.Spec.Project, gcp.Spec.IpName).Context(ctx).Do()
+	if err != nil {
+		if isNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return []string{fmt.Sprintf("%v (%v)", address.Name, address.Address)}, nil
+}
+
+func (gcp *Gcp) remainingServiceAccountKeys(ctx context.Context) ([]string, error) {
+	iamService, err := gcp.newIamClient()
+	if err != nil {
+		return nil, err
+	}
+	remaining := []string{}
+	for _, keyName := range gcp.Spec.ServiceAccountKeys {
+		if _, err = iamService.GetServiceAccountKey(ctx, keyName); err != nil {
+			if isNotFound(err) {
+				continue
+			}
+			return nil, err
+		}
+		remaining = append(remaining, keyName)
+	}
+	return remaining, nil
+}
+
+func (gcp *Gcp) remainingEndpoints(ctx context.Context) ([]string, error) {
+	servicemanagementService, err := gcp.newServiceManagementClient()
+	if err != nil {
+		return nil, err
+	}
+	remaining := []string{}
+	err = servicemanagementService.ListServices(ctx, gcp.Spec.Project,
+		func(page *servicemanagement.ListServicesResponse) error {
+			for _, s := range page.Services {
+				if s.ServiceName == gcp.Spec.Hostname {
+					remaining = append(remaining, s.ServiceName)
+				}
+			}
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+	return remaining, nil
+}
+
+func (gcp *Gcp) remainingDisks(ctx context.Context) ([]string, error) {
+	computeService, err := compute.New(gcp.client)
+	if err != nil {
+		return nil, err
+	}
+	remaining := []string{}
+	for _, usage := range []string{"metadata-store", "artifact-store"} {
+		name := gcp.storageDeploymentName() + "-" + usage
+		disk, err := computeService.Disks.Get(gcp.Spec.Project, gcp.Spec.Zone, name).Context(ctx).Do()
+		if err != nil {
+			if isNotFound(err) {
+				continue
+			}
+			return nil, err
+		}
+		remaining = append(remaining, fmt.Sprintf("%v/%v (%vGB)", gcp.Spec.Zone, disk.Name, disk.SizeGb))
+	}
+	return remaining, nil
+}
+
+func (gcp *Gcp) remainingIamMembers(ctx context.Context) ([]string, error) {
+	resourceManager, err := cloudresourcemanager.New(gcp.client)
+	if err != nil {
+		return nil, err
+	}
+	policy, err := resourceManager.Projects.GetIamPolicy(gcp.Spec.Project,
+		&cloudresourcemanager.GetIamPolicyRequest{}).Context(ctx).Do()
+	if err != nil {
+		return nil, err
+	}
+	saSet := getDeploymentSAs(gcp.Name, gcp.Spec.Project)
+	remaining := []string{}
+	for _, binding := range policy.Bindings {
+		for _, member := range binding.Members {
+			if saSet.Contains(member) {
+				remaining = append(remaining, fmt.Sprintf("%v for %v", binding.Role, member))
+			}
+		}
+	}
+	return remaining, nil
+}
+
+// reportTeardown prints what Delete destroyed and what's still there, and writes it to
+// TEARDOWN_REPORT_FILE to the store.
+func (gcp *Gcp) reportTeardown(report *teardownReport) {
+	gcp.reportDeleted(report.Deleted)
+	kinds := []string{}
+	for kind := range report.Remaining {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+	for _, kind := range kinds {
+		log.Warnf("Still there after delete, %v: %v", kind, strings.Join(report.Remaining[kind], ", "))
+	}
+	kinds = []string{}
+	for kind := range report.Unverified {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+	for _, kind := range kinds {
+		log.Warnf("Couldn't verify the %v resources are gone: %v", kind, report.Unverified[kind])
+	}
+	buf, err := yaml.Marshal(report)
+	if err != nil {
+		log.Warnf("couldn't marshal the teardown report: %v", err)
+		return
+	}
+	bundle := NewBundle()
+	bundle.Put(TEARDOWN_REPORT_FILE, buf)
+	if err = gcp.store.WriteBundle(bundle); err != nil {
+		log.Warnf("couldn't write the teardown report %v: %v", TEARDOWN_REPORT_FILE, err)
+	}
+}
+
+// remainingError is the error of Delete when resources are still there after verifyDeleted.
+func (gcp *Gcp) remainingError(report *teardownReport) error {
+	count := 0
+	for _, remaining := range report.Remaining {
+		count += len(remaining)
+	}
+	if count == 0 {
+		return nil
+	}
+	return &kfapis.KfError{
+		Code: int(kfapis.INTERNAL_ERROR),
+		Message: fmt.Sprintf("%v resources of %v are still there after delete; see %v",
+			count, gcp.Name, filepath.Join(gcp.Spec.AppDir, TEARDOWN_REPORT_FILE)),
+	}
+}
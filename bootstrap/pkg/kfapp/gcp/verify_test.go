@@ -0,0 +1,198 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ghodss/yaml"
+	kfapis "github.com/kubeflow/kubeflow/bootstrap/pkg/apis"
+	"github.com/kubeflow/kubeflow/bootstrap/pkg/kfapp/gcp/fake"
+	"golang.org/x/net/context"
+	"google.golang.org/api/deploymentmanager/v2"
+	"google.golang.org/api/iam/v1"
+	"google.golang.org/api/servicemanagement/v1"
+)
+
+// sleepingClock is a fakeClock whose time passes as it sleeps, so retries time out at once.
+type sleepingClock struct {
+	slept time.Duration
+}
+
+func (c *sleepingClock) Now() time.Time {
+	return fakeClock{}.Now().Add(c.slept)
+}
+
+func (c *sleepingClock) Sleep(d time.Duration) {
+	c.slept += d
+}
+
+func TestVerifyDeleted(t *testing.T) {
+	clock := &sleepingClock{}
+	gcp := &Gcp{clock: clock}
+	gcp.Name = "kf"
+	deploymentChecks := 0
+	checks := []DeleteCheck{
+		{"deployment", func(ctx context.Context) ([]string, error) {
+			deploymentChecks++
+			if deploymentChecks < 3 {
+				return []string{"kf-storage"}, nil
+			}
+			return nil, nil
+		}},
+		{"disk", func(ctx context.Context) ([]string, error) {
+			return []string{"kf-storage-metadata-store"}, nil
+		}},
+		{"address", func(ctx context.Context) ([]string, error) {
+			return nil, fmt.Errorf("permission denied")
+		}},
+	}
+	report := &teardownReport{}
+	gcp.verifyDeleted(context.Background(), checks, report)
+	if deploymentChecks != 3 {
+		t.Errorf("deployments checked %v times; want them checked until gone", deploymentChecks)
+	}
+	if clock.slept < verifyTimeout/2 {
+		t.Errorf("gave up after %v; want about %v", clock.slept, verifyTimeout)
+	}
+	if want := map[string][]string{"disk": {"kf-storage-metadata-store"}}; !reflect.DeepEqual(report.Remaining, want) {
+		t.Errorf("remaining %v; want %v", report.Remaining, want)
+	}
+	if want := map[string]string{"address": "permission denied"}; !reflect.DeepEqual(report.Unverified, want) {
+		t.Errorf("unverified %v; want %v", report.Unverified, want)
+	}
+
+	// Nothing left, nothing reported.
+	report = &teardownReport{}
+	gcp.verifyDeleted(context.Background(), checks[:1], report)
+	if report.Remaining != nil || report.Unverified != nil {
+		t.Errorf("report of a complete delete %+v", report)
+	}
+}
+
+func TestTeardownChecks(t *testing.T) {
+	extra := DeleteCheck{Kind: "bucket"}
+	gcp, err := NewGcp(newFakeKfDef(), Auth{OAuthClientId: "id", OAuthClientSecret: "secret"},
+		WithDeleteChecks(extra))
+	if err != nil {
+		t.Fatal(err)
+	}
+	gcp.Spec.Hostname = "kf.endpoints.my-project.cloud.goog"
+	kinds := func(targets map[string]bool, deployments []string) []string {
+		kinds := []string{}
+		for _, check := range gcp.teardownChecks(fake.NewDeploymentManager("my-project"), targets, deployments) {
+			kinds = append(kinds, check.Kind)
+		}
+		return kinds
+	}
+	all := map[string]bool{COMPONENT_CLUSTER: true, COMPONENT_STORAGE: true, TARGET_IAM: true}
+	gcp.Spec.DeleteStorage = true
+	want := []string{"deployment", "address", "service account key", "endpoints service", "disk", "IAM member", "bucket"}
+	if got := kinds(all, []string{"kf"}); !reflect.DeepEqual(got, want) {
+		t.Errorf("checks %v; want %v", got, want)
+	}
+
+	// A reserved IP and the kept storage aren't expected to be gone.
+	gcp.Spec.IpReserved = true
+	gcp.Spec.DeleteStorage = false
+	want = []string{"service account key", "endpoints service", "IAM member", "bucket"}
+	if got := kinds(all, nil); !reflect.DeepEqual(got, want) {
+		t.Errorf("checks with a reserved IP and kept storage %v; want %v", got, want)
+	}
+}
+
+func TestRemainingWithFakes(t *testing.T) {
+	ctx := context.Background()
+	account := "projects/my-project/serviceAccounts/kf-admin@my-project.iam.gserviceaccount.com"
+	iamClient := fake.NewIam(&iam.ServiceAccountKey{Name: account + "/keys/live"})
+	serviceManagement := fake.NewServiceManagement()
+	dm := fake.NewDeploymentManager("my-project", &deploymentmanager.Deployment{Name: "kf"})
+	gcp, err := NewGcp(newFakeKfDef(), Auth{OAuthClientId: "id", OAuthClientSecret: "secret"},
+		WithIamClient(iamClient), WithServiceManagementClient(serviceManagement),
+		WithDeploymentManagerClient(dm), WithClock(fakeClock{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	gcp.Spec.Hostname = "kf.endpoints.my-project.cloud.goog"
+	gcp.Spec.ServiceAccountKeys = []string{account + "/keys/live", account + "/keys/deleted"}
+
+	keys, err := gcp.remainingServiceAccountKeys(ctx)
+	if err != nil || !reflect.DeepEqual(keys, []string{account + "/keys/live"}) {
+		t.Errorf("remaining keys %v, %v; want the live key", keys, err)
+	}
+
+	if _, err = serviceManagement.CreateService(ctx, &servicemanagement.ManagedService{
+		ServiceName:       gcp.Spec.Hostname,
+		ProducerProjectId: "my-project",
+	}); err != nil {
+		t.Fatal(err)
+	}
+	services, err := gcp.remainingEndpoints(ctx)
+	if err != nil || !reflect.DeepEqual(services, []string{gcp.Spec.Hostname}) {
+		t.Errorf("remaining endpoints %v, %v; want %v", services, err, gcp.Spec.Hostname)
+	}
+	if _, err = serviceManagement.DeleteService(ctx, gcp.Spec.Hostname); err != nil {
+		t.Fatal(err)
+	}
+	if services, err = gcp.remainingEndpoints(ctx); err != nil || len(services) != 0 {
+		t.Errorf("remaining endpoints after delete %v, %v", services, err)
+	}
+
+	dmClient, err := gcp.newDeploymentManagerClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	checks := gcp.teardownChecks(dmClient, map[string]bool{}, []string{"kf", "kf-storage"})
+	deployments, err := checks[0].Remaining(ctx)
+	if err != nil || !reflect.DeepEqual(deployments, []string{"kf"}) {
+		t.Errorf("remaining deployments %v, %v; want kf", deployments, err)
+	}
+}
+
+func TestReportTeardown(t *testing.T) {
+	store := NewBundle()
+	gcp := &Gcp{store: store}
+	gcp.Name = "kf"
+	gcp.Spec.AppDir = "/apps/kf"
+	report := &teardownReport{
+		Name:      "kf",
+		Project:   "my-project",
+		Deleted:   []string{"deployment my-project/kf"},
+		Remaining: map[string][]string{"disk": {"a", "b"}, "address": {"kf-ip"}},
+	}
+	gcp.reportTeardown(report)
+	buf, err := store.ReadFile(TEARDOWN_REPORT_FILE)
+	if err != nil {
+		t.Fatalf("teardown report wasn't written: %v", err)
+	}
+	written := &teardownReport{}
+	if err = yaml.Unmarshal(buf, written); err != nil || !reflect.DeepEqual(written, report) {
+		t.Errorf("written report %+v, %v; want %+v", written, err, report)
+	}
+
+	err = gcp.remainingError(report)
+	kfErr, ok := err.(*kfapis.KfError)
+	if !ok || !strings.Contains(kfErr.Message, "3 resources of kf") ||
+		!strings.Contains(kfErr.Message, "/apps/kf/"+TEARDOWN_REPORT_FILE) {
+		t.Errorf("remainingError = %v; want the 3 remaining resources", err)
+	}
+	if err = gcp.remainingError(&teardownReport{}); err != nil {
+		t.Errorf("remainingError of a complete delete = %v", err)
+	}
+}
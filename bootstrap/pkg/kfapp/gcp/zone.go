@@ -0,0 +1,226 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"fmt"
+	kfapis "github.com/kubeflow/kubeflow/bootstrap/pkg/apis"
+	kftypes "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+	"google.golang.org/api/compute/v1"
+	"sort"
+	"strings"
+)
+
+// zonePool is what a node pool needs of a zone: its machine type and accelerators for the nodes
+// it starts with, or for one node when it's autoscaled up from none.
+type zonePool struct {
+	name        string
+	nodes       int64
+	machineType string
+	gpuType     string
+	gpus        int64
+}
+
+// regionOf is the region of zone, e.g. us-east1 for us-east1-d.
+func regionOf(zone string) string {
+	if i := strings.LastIndex(zone, "-"); i > 0 {
+		return zone[:i]
+	}
+	return zone
+}
+
+// gpuQuotaMetric is the regional quota of the GPUs of gpuType, e.g. NVIDIA_K80_GPUS for
+// nvidia-tesla-k80.
+func gpuQuotaMetric(gpuType string) string {
+	model := strings.TrimPrefix(strings.TrimPrefix(gpuType, "nvidia-"), "tesla-")
+	return "NVIDIA_" + strings.ToUpper(strings.Replace(model, "-", "_", -1)) + "_GPUS"
+}
+
+// requestedPools are the node pools of the cluster config properties: the cpu-pool and, when it
+// can scale up, the gpu-pool.
+func requestedPools(properties map[string]interface{}) []zonePool {
+	pools := []zonePool{}
+	for _, name := range []string{CPU_POOL, GPU_POOL} {
+		nodes := int64(number(properties[name+"-initialNodeCount"]))
+		maxNodes := int64(number(properties[name+"-max-nodes"]))
+		machineType, _ := properties[name+"-machine-type"].(string)
+		if machineType == "" || (nodes == 0 && maxNodes == 0) {
+			continue
+		}
+		if nodes == 0 {
+			nodes = 1
+		}
+		pool := zonePool{name: name, nodes: nodes, machineType: machineType}
+		if gpuType, _ := properties["gpu-type"].(string); name == GPU_POOL && gpuType != "" {
+			pool.gpuType = gpuType
+			pool.gpus = int64(number(properties["gpu-number-per-node"]))
+		}
+		pools = append(pools, pool)
+	}
+	return pools
+}
+
+// zoneFits returns why zone can't run pools, empty when it can, and the CPUs pools take there.
+func (gcp *Gcp) zoneFits(ctx context.Context, computeService *compute.Service, zone string,
+	pools []zonePool) (string, int64, error) {
+	cpus := int64(0)
+	for _, pool := range pools {
+		mt, err := computeService.MachineTypes.Get(gcp.Spec.Project, zone, pool.machineType).Context(ctx).Do()
+		if isNotFound(err) {
+			return fmt.Sprintf("%v of %v isn't offered", pool.machineType, pool.name), 0, nil
+		}
+		if err != nil {
+			return "", 0, fmt.Errorf("couldn't get machine type %v in %v: %v", pool.machineType, zone, err)
+		}
+		cpus += pool.nodes * mt.GuestCpus
+		if pool.gpuType == "" {
+			continue
+		}
+		at, err := computeService.AcceleratorTypes.Get(gcp.Spec.Project, zone, pool.gpuType).Context(ctx).Do()
+		if isNotFound(err) {
+			return fmt.Sprintf("%v of %v isn't offered", pool.gpuType, pool.name), 0, nil
+		}
+		if err != nil {
+			return "", 0, fmt.Errorf("couldn't get accelerator type %v in %v: %v", pool.gpuType, zone, err)
+		}
+		if at.MaximumCardsPerInstance < pool.gpus {
+			return fmt.Sprintf("%v of %v is offered up to %v per node", pool.gpuType, pool.name,
+				at.MaximumCardsPerInstance), 0, nil
+		}
+	}
+	return "", cpus, nil
+}
+
+// quotaShortages checks the free regional quotas cover the CPUs and GPUs by metric needed,
+// returning a line per quota checked and whether one is short.
+func quotaShortages(quotas []*compute.Quota, needed map[string]int64) ([]string, bool) {
+	metrics := []string{}
+	for metric := range needed {
+		metrics = append(metrics, metric)
+	}
+	sort.Strings(metrics)
+	lines := []string{}
+	short := false
+	for _, metric := range metrics {
+		free := float64(0)
+		for _, q := range quotas {
+			if q.Metric == metric {
+				free = q.Limit - q.Usage
+			}
+		}
+		line := fmt.Sprintf("%v: %v needed, %v free", metric, needed[metric], free)
+		if float64(needed[metric]) > free {
+			line += " (short)"
+			short = true
+		}
+		lines = append(lines, line)
+	}
+	return lines, short
+}
+
+// selectZone resolves spec.zone auto to a zone of spec.region, that of kftypes.DefaultZone when
+// unset, offering the machine types and GPUs of the node pools of the cluster config properties,
+// once the regional CPU and GPU quotas are checked to fit them. The first such zone by name is
+// recorded as spec.zone and the decision is printed.
+func (gcp *Gcp) selectZone(ctx context.Context, properties map[string]interface{}) error {
+	region := gcp.Spec.Region
+	if region == "" {
+		region = regionOf(kftypes.DefaultZone)
+	}
+	computeService, err := compute.New(gcp.client)
+	if err != nil {
+		return fmt.Errorf("Error creating compute service: %v", err)
+	}
+	zoneList, err := computeService.Zones.List(gcp.Spec.Project).Context(ctx).Do()
+	if err != nil {
+		return fmt.Errorf("couldn't list the zones of %v: %v", gcp.Spec.Project, err)
+	}
+	zones := []string{}
+	for _, z := range zoneList.Items {
+		if strings.HasSuffix(z.Region, "/regions/"+region) && z.Status == "UP" {
+			zones = append(zones, z.Name)
+		}
+	}
+	if len(zones) == 0 {
+		return &kfapis.KfError{
+			Code:    int(kfapis.INVALID_ARGUMENT),
+			Message: fmt.Sprintf("region %v has no zone up; set spec.region or --%v", region, kftypes.REGION),
+		}
+	}
+	sort.Strings(zones)
+
+	pools := requestedPools(properties)
+	lines := []string{}
+	fitting := []string{}
+	cpus := int64(0)
+	for _, zone := range zones {
+		reason, zoneCpus, err := gcp.zoneFits(ctx, computeService, zone, pools)
+		if err != nil {
+			return err
+		}
+		if reason != "" {
+			lines = append(lines, fmt.Sprintf("  %v: %v", zone, reason))
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("  %v: fits", zone))
+		fitting = append(fitting, zone)
+		cpus = zoneCpus
+	}
+	if len(fitting) == 0 {
+		return &kfapis.KfError{
+			Code: int(kfapis.INVALID_ARGUMENT),
+			Message: fmt.Sprintf("no zone of %v can run the node pools:\n%v", region,
+				strings.Join(lines, "\n")),
+		}
+	}
+
+	r, err := computeService.Regions.Get(gcp.Spec.Project, region).Context(ctx).Do()
+	if err != nil {
+		return fmt.Errorf("couldn't get the quotas of %v: %v", region, err)
+	}
+	needed := map[string]int64{"CPUS": cpus}
+	for _, pool := range pools {
+		if pool.gpuType != "" {
+			needed[gpuQuotaMetric(pool.gpuType)] += pool.nodes * pool.gpus
+		}
+	}
+	quotaLines, short := quotaShortages(r.Quotas, needed)
+	for _, line := range quotaLines {
+		lines = append(lines, "  quota "+line)
+	}
+	if short {
+		return &kfapis.KfError{
+			Code: int(kfapis.INVALID_ARGUMENT),
+			Message: fmt.Sprintf("the quotas of %v can't fit the node pools; request more at "+
+				"https://console.cloud.google.com/iam-admin/quotas?project=%v:\n%v", region, gcp.Spec.Project,
+				strings.Join(lines, "\n")),
+		}
+	}
+
+	zone := fitting[0]
+	gcp.specLock.Lock()
+	gcp.Spec.Zone = zone
+	gcp.specLock.Unlock()
+	report := fmt.Sprintf("Selected zone %v of %v for the node pools, the first that fits:\n%v\n", zone, region,
+		strings.Join(lines, "\n"))
+	if gcp.isCLI {
+		fmt.Print(report)
+	} else {
+		log.Info(report)
+	}
+	return nil
+}
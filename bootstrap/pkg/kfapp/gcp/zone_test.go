@@ -0,0 +1,97 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"net/http"
+	"testing"
+
+	kftypes "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps"
+	"golang.org/x/net/context"
+)
+
+func TestGpuQuotaMetric(t *testing.T) {
+	cases := map[string]string{
+		"nvidia-tesla-k80":  "NVIDIA_K80_GPUS",
+		"nvidia-tesla-v100": "NVIDIA_V100_GPUS",
+		"nvidia-tesla-t4":   "NVIDIA_T4_GPUS",
+	}
+	for gpuType, want := range cases {
+		if got := gpuQuotaMetric(gpuType); got != want {
+			t.Errorf("gpuQuotaMetric(%v) = %v; want %v", gpuType, got, want)
+		}
+	}
+}
+
+// zoneApis are the compute APIs of us-east1, whose zone b has no K80 and whose zone d is down.
+func zoneApis(regionQuotas string) fakeGcpApis {
+	zones := "/compute/v1/projects/my-project/zones/"
+	return fakeGcpApis{
+		"/compute/v1/projects/my-project/zones": `{"items": [
+			{"name": "us-east1-c", "region": "https://www.googleapis.com/compute/v1/projects/my-project/regions/us-east1", "status": "UP"},
+			{"name": "us-east1-b", "region": "https://www.googleapis.com/compute/v1/projects/my-project/regions/us-east1", "status": "UP"},
+			{"name": "us-east1-d", "region": "https://www.googleapis.com/compute/v1/projects/my-project/regions/us-east1", "status": "DOWN"},
+			{"name": "us-west1-a", "region": "https://www.googleapis.com/compute/v1/projects/my-project/regions/us-west1", "status": "UP"}]}`,
+		zones + "us-east1-b/machineTypes/n1-standard-8":        `{"name": "n1-standard-8", "guestCpus": 8}`,
+		zones + "us-east1-c/machineTypes/n1-standard-8":        `{"name": "n1-standard-8", "guestCpus": 8}`,
+		zones + "us-east1-c/acceleratorTypes/nvidia-tesla-k80": `{"name": "nvidia-tesla-k80", "maximumCardsPerInstance": 8}`,
+		"/compute/v1/projects/my-project/regions/us-east1":     regionQuotas,
+	}
+}
+
+func newZoneGcp(apis fakeGcpApis) *Gcp {
+	gcp := &Gcp{client: &http.Client{Transport: apis}}
+	gcp.Spec.Project = "my-project"
+	gcp.Spec.Zone = kftypes.AutoZone
+	return gcp
+}
+
+func TestSelectZone(t *testing.T) {
+	properties := map[string]interface{}{
+		"cpu-pool-initialNodeCount": 2,
+		"cpu-pool-machine-type":     "n1-standard-8",
+		"gpu-pool-initialNodeCount": 0,
+		"gpu-pool-max-nodes":        1,
+		"gpu-pool-machine-type":     "n1-standard-8",
+		"gpu-type":                  "nvidia-tesla-k80",
+		"gpu-number-per-node":       1,
+	}
+
+	gcp := newZoneGcp(zoneApis(`{"name": "us-east1", "quotas": [
+		{"metric": "CPUS", "limit": 24, "usage": 0},
+		{"metric": "NVIDIA_K80_GPUS", "limit": 1, "usage": 0}]}`))
+	if err := gcp.selectZone(context.Background(), properties); err != nil {
+		t.Fatalf("selectZone failed: %v", err)
+	}
+	if gcp.Spec.Zone != "us-east1-c" {
+		t.Errorf("selected zone %v; want us-east1-c, the only zone up offering the K80", gcp.Spec.Zone)
+	}
+
+	short := newZoneGcp(zoneApis(`{"name": "us-east1", "quotas": [
+		{"metric": "CPUS", "limit": 24, "usage": 4},
+		{"metric": "NVIDIA_K80_GPUS", "limit": 1, "usage": 0}]}`))
+	if err := short.selectZone(context.Background(), properties); err == nil {
+		t.Errorf("selectZone succeeded with 20 CPUs free for 24")
+	}
+	if short.Spec.Zone != kftypes.AutoZone {
+		t.Errorf("zone set to %v though the quota is short", short.Spec.Zone)
+	}
+
+	west := newZoneGcp(zoneApis(`{}`))
+	west.Spec.Region = "us-west1"
+	if err := west.selectZone(context.Background(), properties); err == nil {
+		t.Errorf("selectZone succeeded in a region offering no machine type")
+	}
+}
@@ -27,6 +27,7 @@ import (
 	configtypes "github.com/kubeflow/kubeflow/bootstrap/config"
 	kftypes "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps"
 	kfdefs "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps/kfdef/v1alpha1"
+	"github.com/kubeflow/kubeflow/bootstrap/pkg/utils"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/afero"
 	"io/ioutil"
@@ -48,10 +49,10 @@ type ksApp struct {
 	// ksonnet root name
 	KsName string
 	// ksonnet env name
-	KsEnvName string
-	KApp      app.App
+	KsEnvName  string
+	KApp       app.App
 	restConfig *rest.Config
-	apiConfig *clientcmdapi.Config
+	apiConfig  *clientcmdapi.Config
 }
 
 const (
@@ -79,6 +80,19 @@ func GetKfApp(kfdef *kfdefs.KfDef) kftypes.KfApp {
 	if goPathVar != "" {
 		_kfapp.Spec.Repo = re.ReplaceAllString(_kfapp.Spec.Repo, goPathVar+`$2`)
 	}
+	if _kfapp.Spec.Kubeconfig != "" || _kfapp.Spec.KubeContext != "" {
+		restConfig, restConfigErr := kftypes.GetConfigForContext(_kfapp.Spec.Kubeconfig, _kfapp.Spec.KubeContext)
+		if restConfigErr != nil {
+			log.Warnf("could not build config for context %v Error %v", _kfapp.Spec.KubeContext, restConfigErr)
+		}
+		apiConfig, apiConfigErr := kftypes.GetKubeConfigForContext(_kfapp.Spec.Kubeconfig, _kfapp.Spec.KubeContext)
+		if apiConfigErr != nil {
+			log.Warnf("could not load kubeconfig Error: %v", apiConfigErr)
+		}
+		_kfapp.restConfig = restConfig
+		_kfapp.apiConfig = apiConfig
+		return _kfapp
+	}
 	// build restConfig and apiConfig using $HOME/.kube/config if the file exist
 	_kfapp.restConfig = kftypes.GetConfig()
 	_kfapp.apiConfig = kftypes.GetKubeConfig()
@@ -215,6 +229,30 @@ func (ksApp *ksApp) components() (map[string]*kfdefs.KsComponent, error) {
 	return comps, nil
 }
 
+// Plan lists the namespace of the app, when it's missing, and the components Apply applies. The
+// namespace is planned when there's no cluster to look into yet.
+func (ksApp *ksApp) Plan(resources kftypes.ResourceEnum) ([]kftypes.Action, error) {
+	planned := []kftypes.Action{}
+	namespace := ksApp.ObjectMeta.Namespace
+	nsMissing := ksApp.restConfig == nil
+	if !nsMissing {
+		clientset := kftypes.GetClientset(ksApp.restConfig)
+		_, nsMissingErr := clientset.CoreV1().Namespaces().Get(namespace, metav1.GetOptions{})
+		nsMissing = nsMissingErr != nil
+	}
+	if nsMissing {
+		planned = append(planned, kftypes.Action{Type: kftypes.CREATE_NAMESPACE, Target: namespace})
+	}
+	for _, component := range ksApp.Spec.Components {
+		planned = append(planned, kftypes.Action{
+			Type:   kftypes.APPLY_MANIFEST,
+			Target: component,
+			Detail: "ksonnet env " + KsEnvName,
+		})
+	}
+	return planned, nil
+}
+
 func (ksApp *ksApp) deleteGlobalResources(config *rest.Config) error {
 	apiextclientset := kftypes.GetApiExtClientset(config)
 	do := &metav1.DeleteOptions{}
@@ -256,7 +294,10 @@ func (ksApp *ksApp) deleteGlobalResources(config *rest.Config) error {
 }
 
 func (ksApp *ksApp) Delete(resources kftypes.ResourceEnum) error {
-	config := kftypes.GetConfig()
+	config := ksApp.restConfig
+	if config == nil {
+		config = kftypes.GetConfig()
+	}
 	err := ksApp.deleteGlobalResources(config)
 	if err != nil {
 		log.Errorf("there was a problem deleting global resources: %v", err)
@@ -265,7 +306,10 @@ func (ksApp *ksApp) Delete(resources kftypes.ResourceEnum) error {
 	if envSetErr != nil {
 		return fmt.Errorf("couldn't create ksonnet env %v Error: %v", ksApp.KsEnvName, envSetErr)
 	}
-	clientConfig := kftypes.GetKubeConfig()
+	clientConfig := ksApp.apiConfig
+	if clientConfig == nil {
+		clientConfig = kftypes.GetKubeConfig()
+	}
 	components := []string{"application", "metacontroller"}
 	err = actions.RunDelete(map[string]interface{}{
 		actions.OptionApp: ksApp.KApp,
@@ -281,6 +325,10 @@ func (ksApp *ksApp) Delete(resources kftypes.ResourceEnum) error {
 		log.Infof("there was a problem deleting %v: %v", components, err)
 	}
 	namespace := ksApp.ObjectMeta.Namespace
+	if ksApp.Spec.PreserveData {
+		log.Infof("keeping namespace %v and its persistent volume claims", namespace)
+		return nil
+	}
 	log.Infof("deleting namespace: %v", namespace)
 	clientset := kftypes.GetClientset(config)
 	ns, nsMissingErr := clientset.CoreV1().Namespaces().Get(namespace, metav1.GetOptions{})
@@ -344,13 +392,14 @@ func (ksApp *ksApp) Generate(resources kftypes.ResourceEnum) error {
 			return fmt.Errorf("couldn't add package %v. Error: %v", pkg.Name, packageAddErr)
 		}
 	}
+	componentParams := ksApp.Spec.GetComponentParams()
 	for _, compName := range ksApp.Spec.Components {
 		comp := kfdefs.KsComponent{
 			Name:      compName,
 			Prototype: compName,
 		}
 		parameterArgs := []string{}
-		if val, ok := ksApp.Spec.ComponentParams[compName]; ok {
+		if val, ok := componentParams[compName]; ok {
 			for _, nv := range val {
 				if nv.InitRequired {
 					name := "--" + nv.Name
@@ -364,7 +413,7 @@ func (ksApp *ksApp) Generate(resources kftypes.ResourceEnum) error {
 			return fmt.Errorf("couldn't add comp %v. Error: %v", comp.Name, componentAddErr)
 		}
 	}
-	for compName, namevals := range ksApp.Spec.ComponentParams {
+	for compName, namevals := range componentParams {
 		for _, nv := range namevals {
 			args := map[string]interface{}{
 				actions.OptionAppRoot: ksApp.ksRoot(),
@@ -430,9 +479,9 @@ func (ksApp *ksApp) initKs() error {
 func (ksApp *ksApp) envSet(envName string, host string) error {
 	ksApp.KsEnvName = envName
 	err := actions.RunEnvSet(map[string]interface{}{
-		actions.OptionAppRoot: ksApp.ksRoot(),
-		actions.OptionEnvName: ksApp.KsEnvName,
-		actions.OptionServer:  host,
+		actions.OptionAppRoot:  ksApp.ksRoot(),
+		actions.OptionEnvName:  ksApp.KsEnvName,
+		actions.OptionServer:   host,
 		actions.OptionOverride: true,
 	})
 	if err != nil {
@@ -533,7 +582,8 @@ func (ksApp *ksApp) registryAdd(registry *kfdefs.RegistryConfig) error {
 	return nil
 }
 
-func (ksApp *ksApp) Show(resources kftypes.ResourceEnum, options map[string]interface{}) error {
+// render returns the manifests of the components of the env, as ks show prints them.
+func (ksApp *ksApp) render() (string, error) {
 	capture := kftypes.Capture()
 	err := actions.RunShow(map[string]interface{}{
 		actions.OptionApp:            ksApp.KApp,
@@ -541,18 +591,26 @@ func (ksApp *ksApp) Show(resources kftypes.ResourceEnum, options map[string]inte
 		actions.OptionEnvName:        ksApp.KsEnvName,
 		actions.OptionFormat:         "yaml",
 	})
+	output, outputErr := capture()
 	if err != nil {
-		return fmt.Errorf("there was a problem calling show: %v", err)
+		return "", fmt.Errorf("there was a problem calling show: %v", err)
+	}
+	if outputErr != nil {
+		return "", fmt.Errorf("there was a problem calling capture: %v", outputErr)
+	}
+	return output, nil
+}
+
+func (ksApp *ksApp) Show(resources kftypes.ResourceEnum, options map[string]interface{}) error {
+	output, err := ksApp.render()
+	if err != nil {
+		return err
 	}
 	yamlDir := filepath.Join(ksApp.Spec.AppDir, "yamls")
 	err = os.Mkdir(yamlDir, os.ModePerm)
 	if err != nil {
 		return fmt.Errorf("couldn't create directory %v, most likely it already exists", yamlDir)
 	}
-	output, outputErr := capture()
-	if outputErr != nil {
-		return fmt.Errorf("there was a problem calling capture: %v", outputErr)
-	}
 	yamlFile := filepath.Join(yamlDir, "default.yaml")
 	yamlFileErr := ioutil.WriteFile(yamlFile, []byte(output), 0644)
 	if yamlFileErr != nil {
@@ -561,13 +619,26 @@ func (ksApp *ksApp) Show(resources kftypes.ResourceEnum, options map[string]inte
 	return nil
 }
 
+// Export returns the manifests of the components, rendered like Show, in k8s/<env>.yaml.
+func (ksApp *ksApp) Export(resources kftypes.ResourceEnum) (map[string][]byte, error) {
+	output, err := ksApp.render()
+	if err != nil {
+		return nil, err
+	}
+	return map[string][]byte{
+		path.Join("k8s", ksApp.KsEnvName+".yaml"): []byte(output),
+	}, nil
+}
+
 func (ksApp *ksApp) writeConfigFile() error {
-	buf, bufErr := yaml.Marshal(&ksApp.KfDef)
+	kfdef := ksApp.KfDef
+	kfdef.Spec.ComponentParams = kfdef.Spec.SortedComponentParams()
+	buf, bufErr := yaml.Marshal(&kfdef)
 	if bufErr != nil {
 		return bufErr
 	}
 	cfgFilePath := filepath.Join(ksApp.Spec.AppDir, kftypes.KfConfigFile)
-	cfgFilePathErr := ioutil.WriteFile(cfgFilePath, buf, 0644)
+	cfgFilePathErr := utils.WriteFileAtomically(cfgFilePath, buf, 0644)
 	if cfgFilePathErr != nil {
 		return cfgFilePathErr
 	}
@@ -22,7 +22,7 @@ import (
 	"github.com/kubeflow/kubeflow/bootstrap/config"
 	kftypes "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps"
 	kfdefs "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps/kfdef/v1alpha1"
-	"io/ioutil"
+	"github.com/kubeflow/kubeflow/bootstrap/pkg/utils"
 	"os/user"
 	"path/filepath"
 	"strconv"
@@ -47,6 +47,11 @@ func (minikube *Minikube) Apply(resources kftypes.ResourceEnum) error {
 	return nil
 }
 
+// Plan lists no actions, Apply has none.
+func (minikube *Minikube) Plan(resources kftypes.ResourceEnum) ([]kftypes.Action, error) {
+	return []kftypes.Action{}, nil
+}
+
 func (minikube *Minikube) Delete(resources kftypes.ResourceEnum) error {
 	return nil
 }
@@ -125,12 +130,14 @@ func (minikube *Minikube) Init(kftypes.ResourceEnum) error {
 }
 
 func (minikube *Minikube) writeConfigFile() error {
-	buf, bufErr := yaml.Marshal(minikube.KfDef)
+	kfdef := minikube.KfDef
+	kfdef.Spec.ComponentParams = kfdef.Spec.SortedComponentParams()
+	buf, bufErr := yaml.Marshal(kfdef)
 	if bufErr != nil {
 		return bufErr
 	}
 	cfgFilePath := filepath.Join(minikube.KfDef.Spec.AppDir, kftypes.KfConfigFile)
-	cfgFilePathErr := ioutil.WriteFile(cfgFilePath, buf, 0644)
+	cfgFilePathErr := utils.WriteFileAtomically(cfgFilePath, buf, 0644)
 	if cfgFilePathErr != nil {
 		return cfgFilePathErr
 	}
@@ -0,0 +1,301 @@
+/*
+Copyright The Kubeflow Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/cenkalti/backoff"
+	"github.com/ghodss/yaml"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/discovery/cached"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/rest"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultApplyParallelism is how many objects ApplyResources applies at once by default.
+	DefaultApplyParallelism = 8
+	// DefaultApplyTimeout bounds the wait of ApplyResources for the CRDs to be established and
+	// the workloads to be ready, by default.
+	DefaultApplyTimeout = 10 * time.Minute
+	// applyPollInterval is how often ApplyResources checks the objects it waits for.
+	applyPollInterval = 5 * time.Second
+)
+
+// ApplyOptions configures ApplyResources; the zero value applies with the defaults.
+type ApplyOptions struct {
+	// Parallelism is how many objects are applied and waited for at once.
+	Parallelism int
+	// Timeout bounds the waits for the objects to be established or ready.
+	Timeout time.Duration
+	// Progress, if set, is called after each object is applied and ready, or failed. It may be
+	// called from several goroutines, one at a time.
+	Progress func(ApplyProgress)
+}
+
+// ApplyProgress reports an object ApplyResources is done with.
+type ApplyProgress struct {
+	Ref ResourceRef
+	// Done counts the objects done so far, including Ref, out of Total.
+	Done  int
+	Total int
+	// Err is why Ref couldn't be applied or didn't get ready, nil when it did.
+	Err error
+}
+
+// manifestObject is an object of a manifest with its JSON, as patchOrCreate sends it.
+type manifestObject struct {
+	ref  ResourceRef
+	data []byte
+}
+
+// manifestObjects splits the manifest data into its objects, skipping the documents
+// ResourceRefs skips.
+func manifestObjects(data []byte) ([]manifestObject, error) {
+	objects := []manifestObject{}
+	for _, document := range bytes.Split(data, []byte(yamlSeparator)) {
+		refs, err := ResourceRefs(document)
+		if err != nil {
+			return nil, err
+		}
+		if len(refs) == 0 {
+			continue
+		}
+		object, err := yaml.YAMLToJSON(document)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't read %v: %v", refs[0], err)
+		}
+		objects = append(objects, manifestObject{ref: refs[0], data: object})
+	}
+	return objects, nil
+}
+
+// applyPhases orders objects in the phases ApplyResources applies them in: the CRDs and
+// namespaces, which the others need to be mapped or created, then the rest. The order of the
+// manifest is kept within a phase.
+func applyPhases(objects []manifestObject) [][]manifestObject {
+	first, rest := []manifestObject{}, []manifestObject{}
+	for _, object := range objects {
+		switch object.ref.Kind {
+		case "CustomResourceDefinition", "Namespace":
+			first = append(first, object)
+		default:
+			rest = append(rest, object)
+		}
+	}
+	phases := [][]manifestObject{}
+	for _, phase := range [][]manifestObject{first, rest} {
+		if len(phase) > 0 {
+			phases = append(phases, phase)
+		}
+	}
+	return phases
+}
+
+// objectStatus holds the fields of an object objectReady looks at.
+type objectStatus struct {
+	Metadata struct {
+		Generation int64 `json:"generation"`
+	} `json:"metadata"`
+	Spec struct {
+		Replicas *int32 `json:"replicas"`
+	} `json:"spec"`
+	Status struct {
+		ObservedGeneration     int64 `json:"observedGeneration"`
+		UpdatedReplicas        int32 `json:"updatedReplicas"`
+		AvailableReplicas      int32 `json:"availableReplicas"`
+		ReadyReplicas          int32 `json:"readyReplicas"`
+		DesiredNumberScheduled int32 `json:"desiredNumberScheduled"`
+		UpdatedNumberScheduled int32 `json:"updatedNumberScheduled"`
+		NumberAvailable        int32 `json:"numberAvailable"`
+		Conditions             []struct {
+			Type   string `json:"type"`
+			Status string `json:"status"`
+		} `json:"conditions"`
+	} `json:"status"`
+}
+
+// objectReady says whether the live object of kind, in JSON, is ready: a CRD once it's
+// established, a Deployment, StatefulSet or DaemonSet once its pods are updated and available.
+// The objects of the other kinds are ready once they exist.
+func objectReady(kind string, data []byte) (bool, error) {
+	var o objectStatus
+	if err := json.Unmarshal(data, &o); err != nil {
+		return false, err
+	}
+	if kind == "CustomResourceDefinition" {
+		for _, condition := range o.Status.Conditions {
+			if condition.Type == "Established" {
+				return condition.Status == "True", nil
+			}
+		}
+		return false, nil
+	}
+	replicas := int32(1)
+	if o.Spec.Replicas != nil {
+		replicas = *o.Spec.Replicas
+	}
+	switch kind {
+	case "Deployment", "StatefulSet", "DaemonSet":
+		// The status is of a previous spec until the controller observes the patch.
+		if o.Status.ObservedGeneration < o.Metadata.Generation {
+			return false, nil
+		}
+	}
+	switch kind {
+	case "Deployment":
+		return o.Status.UpdatedReplicas >= replicas && o.Status.AvailableReplicas >= replicas, nil
+	case "StatefulSet":
+		return o.Status.ReadyReplicas >= replicas, nil
+	case "DaemonSet":
+		return o.Status.UpdatedNumberScheduled >= o.Status.DesiredNumberScheduled &&
+			o.Status.NumberAvailable >= o.Status.DesiredNumberScheduled, nil
+	}
+	return true, nil
+}
+
+// ApplyResources applies the objects of the manifest data, creating those missing and patching
+// the others, and waits for them to be ready: the CRDs and namespaces first, until the CRDs are
+// established, then the rest with opts.Parallelism at once, until the workloads are available.
+// Cancelling ctx stops the retries and waits. The errors of all the objects are returned
+// together, so one broken object doesn't hide the others.
+func ApplyResources(ctx context.Context, config *rest.Config, data []byte, opts ApplyOptions) error {
+	if opts.Parallelism <= 0 {
+		opts.Parallelism = DefaultApplyParallelism
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = DefaultApplyTimeout
+	}
+	objects, err := manifestObjects(data)
+	if err != nil {
+		return err
+	}
+	discoveryClient, err := discovery.NewDiscoveryClientForConfig(config)
+	if err != nil {
+		return err
+	}
+	mapper := discovery.NewDeferredDiscoveryRESTMapper(cached.NewMemCacheClient(discoveryClient),
+		dynamic.VersionInterfaces)
+
+	var lock sync.Mutex
+	done := 0
+	errs := []string{}
+	for _, phase := range applyPhases(objects) {
+		// The kinds of the CRDs just established aren't in the discovery cache yet.
+		mapper.Reset()
+		semaphore := make(chan struct{}, opts.Parallelism)
+		var wg sync.WaitGroup
+		for _, object := range phase {
+			wg.Add(1)
+			go func(object manifestObject) {
+				defer wg.Done()
+				semaphore <- struct{}{}
+				defer func() { <-semaphore }()
+				err := applyObject(ctx, config, mapper, object, opts.Timeout)
+
+				lock.Lock()
+				defer lock.Unlock()
+				done++
+				if err != nil {
+					errs = append(errs, fmt.Sprintf("%v: %v", object.ref, err))
+				}
+				if opts.Progress != nil {
+					opts.Progress(ApplyProgress{Ref: object.ref, Done: done, Total: len(objects), Err: err})
+				}
+			}(object)
+		}
+		wg.Wait()
+		// The rest likely depends on the CRDs and namespaces which failed.
+		if len(errs) != 0 {
+			break
+		}
+	}
+	if len(errs) != 0 {
+		return fmt.Errorf("couldn't apply %v of %v objects: %v", len(errs), len(objects),
+			strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// applyObject patches or creates object, retrying until ctx is done, then waits up to timeout
+// for it to be ready.
+func applyObject(ctx context.Context, config *rest.Config, mapper *discovery.DeferredDiscoveryRESTMapper,
+	object manifestObject, timeout time.Duration) error {
+	ref := object.ref
+	gk := schema.GroupKind{Group: ref.Group, Kind: ref.Kind}
+	b := backoff.NewExponentialBackOff()
+	b.MaxElapsedTime = timeout
+	err := backoff.Retry(func() error {
+		mapping, err := mapper.RESTMapping(gk, ref.Version)
+		if err != nil {
+			// The kind of a CRD just created may not be discovered yet.
+			mapper.Reset()
+			return err
+		}
+		if err = patchOrCreate(mapping, config, ref.Group, ref.Version, ref.Namespace, ref.Name,
+			object.data); err != nil {
+			log.Infof("Applying %v failed, backoff and retry: %v", ref, err)
+		}
+		return err
+	}, backoff.WithContext(b, ctx))
+	if err != nil {
+		return err
+	}
+
+	mapping, err := mapper.RESTMapping(gk, ref.Version)
+	if err != nil {
+		return err
+	}
+	restClient, err := getRESTClient(config, ref.Group, ref.Version)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	ticker := time.NewTicker(applyPollInterval)
+	defer ticker.Stop()
+	for {
+		live, err := restClient.
+			Get().
+			Resource(mapping.Resource).
+			NamespaceIfScoped(ref.Namespace, mapping.Scope.Name() == "namespace").
+			Name(ref.Name).
+			DoRaw()
+		if err == nil {
+			var ready bool
+			if ready, err = objectReady(ref.Kind, live); ready {
+				return nil
+			}
+		}
+		if err != nil {
+			log.Infof("Checking %v failed: %v", ref, err)
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("not ready after %v: %v", timeout, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
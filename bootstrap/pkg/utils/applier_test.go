@@ -0,0 +1,103 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestApplyPhases(t *testing.T) {
+	manifest := `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: istio-pilot
+  namespace: istio-system
+---
+# A comment only.
+---
+apiVersion: apiextensions.k8s.io/v1beta1
+kind: CustomResourceDefinition
+metadata:
+  name: gateways.networking.istio.io
+---
+apiVersion: networking.istio.io/v1alpha3
+kind: Gateway
+metadata:
+  name: kubeflow-gateway
+  namespace: kubeflow
+---
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: istio-system
+`
+	objects, err := manifestObjects([]byte(manifest))
+	if err != nil {
+		t.Fatalf("manifestObjects failed: %v", err)
+	}
+	phases := [][]string{}
+	for _, phase := range applyPhases(objects) {
+		names := []string{}
+		for _, object := range phase {
+			names = append(names, object.ref.Name)
+		}
+		phases = append(phases, names)
+	}
+	want := [][]string{
+		{"gateways.networking.istio.io", "istio-system"},
+		{"istio-pilot", "kubeflow-gateway"},
+	}
+	if !reflect.DeepEqual(phases, want) {
+		t.Errorf("applyPhases = %v; want %v", phases, want)
+	}
+	if got := string(objects[0].data); got !=
+		`{"apiVersion":"apps/v1","kind":"Deployment","metadata":{"name":"istio-pilot","namespace":"istio-system"}}` {
+		t.Errorf("manifestObjects data = %v", got)
+	}
+}
+
+func TestObjectReady(t *testing.T) {
+	cases := []struct {
+		kind   string
+		object string
+		ready  bool
+	}{
+		{"CustomResourceDefinition", `{"status":{"conditions":[{"type":"Established","status":"True"}]}}`, true},
+		{"CustomResourceDefinition", `{"status":{"conditions":[{"type":"NamesAccepted","status":"True"}]}}`, false},
+		{"Deployment", `{"metadata":{"generation":2},"spec":{"replicas":2},` +
+			`"status":{"observedGeneration":2,"updatedReplicas":2,"availableReplicas":2}}`, true},
+		{"Deployment", `{"metadata":{"generation":2},"spec":{"replicas":2},` +
+			`"status":{"observedGeneration":1,"updatedReplicas":2,"availableReplicas":2}}`, false},
+		{"Deployment", `{"metadata":{"generation":1},"status":{"observedGeneration":1}}`, false},
+		{"StatefulSet", `{"metadata":{"generation":1},"spec":{"replicas":1},` +
+			`"status":{"observedGeneration":1,"readyReplicas":1}}`, true},
+		{"DaemonSet", `{"metadata":{"generation":1},"status":{"observedGeneration":1,` +
+			`"desiredNumberScheduled":0}}`, true},
+		{"DaemonSet", `{"metadata":{"generation":1},"status":{"observedGeneration":1,` +
+			`"desiredNumberScheduled":3,"updatedNumberScheduled":3,"numberAvailable":2}}`, false},
+		{"ConfigMap", `{"metadata":{"name":"istio"}}`, true},
+	}
+	for _, c := range cases {
+		ready, err := objectReady(c.kind, []byte(c.object))
+		if err != nil {
+			t.Errorf("objectReady(%v, %v) failed: %v", c.kind, c.object, err)
+			continue
+		}
+		if ready != c.ready {
+			t.Errorf("objectReady(%v, %v) = %v; want %v", c.kind, c.object, ready, c.ready)
+		}
+	}
+}
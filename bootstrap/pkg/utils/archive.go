@@ -0,0 +1,88 @@
+/*
+Copyright The Kubeflow Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+)
+
+// ChecksumsFile is the manifest of the archives of WriteArchive, in the format of sha256sum, so
+// `sha256sum -c SHA256SUMS` checks the extracted files.
+const ChecksumsFile = "SHA256SUMS"
+
+// archiveTime is the modification time of the files of the archives, so they only depend on
+// the files' contents.
+var archiveTime = time.Unix(0, 0).UTC()
+
+// WriteArchive writes files, keyed by their slash separated path, to w as a tar.gz with their
+// ChecksumsFile first. The archive is reproducible: the files are sorted by path and have no
+// owner or time, so the same files give the same bytes.
+func WriteArchive(w io.Writer, files map[string][]byte) error {
+	if _, ok := files[ChecksumsFile]; ok {
+		return fmt.Errorf("%v is reserved for the checksums of the archive", ChecksumsFile)
+	}
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	checksums := &bytes.Buffer{}
+	for _, name := range names {
+		sum := sha256.Sum256(files[name])
+		fmt.Fprintf(checksums, "%v  %v\n", hex.EncodeToString(sum[:]), name)
+	}
+
+	gz := gzip.NewWriter(w)
+	gz.ModTime = archiveTime
+	tw := tar.NewWriter(gz)
+	writeFile := func(name string, data []byte) error {
+		hdr := &tar.Header{
+			Name:     name,
+			Mode:     0644,
+			Size:     int64(len(data)),
+			ModTime:  archiveTime,
+			Typeflag: tar.TypeReg,
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return fmt.Errorf("couldn't add %v to the archive: %v", name, err)
+		}
+		if _, err := tw.Write(data); err != nil {
+			return fmt.Errorf("couldn't add %v to the archive: %v", name, err)
+		}
+		return nil
+	}
+	if err := writeFile(ChecksumsFile, checksums.Bytes()); err != nil {
+		return err
+	}
+	for _, name := range names {
+		if err := writeFile(name, files[name]); err != nil {
+			return err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gz.Close()
+}
@@ -0,0 +1,83 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"io/ioutil"
+	"reflect"
+	"testing"
+)
+
+func TestWriteArchive(t *testing.T) {
+	files := map[string][]byte{
+		"gcp_config/storage-kubeflow.yaml": []byte("resources: []\n"),
+		"app.yaml":                         []byte("apiVersion: kfdef.apps.kubeflow.org/v1alpha1\n"),
+	}
+	first := &bytes.Buffer{}
+	if err := WriteArchive(first, files); err != nil {
+		t.Fatal(err)
+	}
+	second := &bytes.Buffer{}
+	if err := WriteArchive(second, files); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(first.Bytes(), second.Bytes()) {
+		t.Errorf("archives of the same files differ")
+	}
+
+	gz, err := gzip.NewReader(first)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tr := tar.NewReader(gz)
+	names := []string{}
+	contents := map[string]string{}
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		buf, err := ioutil.ReadAll(tr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		names = append(names, hdr.Name)
+		contents[hdr.Name] = string(buf)
+	}
+	expectedNames := []string{ChecksumsFile, "app.yaml", "gcp_config/storage-kubeflow.yaml"}
+	if !reflect.DeepEqual(names, expectedNames) {
+		t.Errorf("archive has %v; want %v", names, expectedNames)
+	}
+	expectedChecksums := "bde7532884bfdd79a9e01c92ee83bfbab40dd90ccd8f70ccb6863e1370d55e7f  app.yaml\n" +
+		"6c71762bddd54c97932b035aedf43fd9d460da4df3c19a294dc46e1b356f781d  gcp_config/storage-kubeflow.yaml\n"
+	if contents[ChecksumsFile] != expectedChecksums {
+		t.Errorf("%v is\n%v\nwant\n%v", ChecksumsFile, contents[ChecksumsFile], expectedChecksums)
+	}
+	if contents["app.yaml"] != string(files["app.yaml"]) {
+		t.Errorf("app.yaml is %q; want %q", contents["app.yaml"], files["app.yaml"])
+	}
+
+	files[ChecksumsFile] = []byte{}
+	if err := WriteArchive(&bytes.Buffer{}, files); err == nil {
+		t.Errorf("WriteArchive took a file named %v", ChecksumsFile)
+	}
+}
@@ -0,0 +1,94 @@
+/*
+Copyright The Kubeflow Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"encoding/json"
+	"github.com/ghodss/yaml"
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// UnifiedDiff returns the unified diff from a, named fromName, to b, named toName; it's empty
+// when they're the same.
+func UnifiedDiff(a string, b string, fromName string, toName string) (string, error) {
+	if a == b {
+		return "", nil
+	}
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(a),
+		B:        difflib.SplitLines(b),
+		FromFile: fromName,
+		ToFile:   toName,
+		Context:  3,
+	})
+}
+
+// PruneTo drops the fields of live which aren't set in desired, e.g. those defaulted by the API
+// server, so live can be compared with desired. Lists of the same length are pruned item by
+// item; other values are kept as they are.
+func PruneTo(live interface{}, desired interface{}) interface{} {
+	switch d := desired.(type) {
+	case map[string]interface{}:
+		l, ok := live.(map[string]interface{})
+		if !ok {
+			return live
+		}
+		pruned := map[string]interface{}{}
+		for key, value := range d {
+			if liveValue, ok := l[key]; ok {
+				pruned[key] = PruneTo(liveValue, value)
+			}
+		}
+		return pruned
+	case []interface{}:
+		l, ok := live.([]interface{})
+		if !ok || len(l) != len(d) {
+			return live
+		}
+		pruned := make([]interface{}, len(l))
+		for i := range l {
+			pruned[i] = PruneTo(l[i], d[i])
+		}
+		return pruned
+	}
+	return live
+}
+
+// DiffObjects returns the unified diff of the YAML of desired and of live pruned to it, named
+// after the object; it's empty when live has the fields of desired.
+func DiffObjects(name string, desired map[string]interface{}, live map[string]interface{}) (string, error) {
+	// Round trip desired through JSON so its numbers are compared as the live ones are.
+	buf, err := json.Marshal(desired)
+	if err != nil {
+		return "", err
+	}
+	var normalized interface{}
+	if err = json.Unmarshal(buf, &normalized); err != nil {
+		return "", err
+	}
+	desiredYaml, err := yaml.Marshal(normalized)
+	if err != nil {
+		return "", err
+	}
+	liveYaml := []byte{}
+	if live != nil {
+		if liveYaml, err = yaml.Marshal(PruneTo(live, normalized)); err != nil {
+			return "", err
+		}
+	}
+	return UnifiedDiff(string(liveYaml), string(desiredYaml), "live/"+name, "desired/"+name)
+}
@@ -0,0 +1,76 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestPruneTo(t *testing.T) {
+	live := map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"name":            "gateway",
+			"resourceVersion": "42",
+		},
+		"spec": map[string]interface{}{
+			"ports":    []interface{}{map[string]interface{}{"port": 80.0, "protocol": "TCP"}},
+			"selector": []interface{}{"a", "b"},
+		},
+	}
+	desired := map[string]interface{}{
+		"metadata": map[string]interface{}{"name": "gateway"},
+		"spec": map[string]interface{}{
+			"ports":    []interface{}{map[string]interface{}{"port": 80.0}},
+			"selector": []interface{}{"a"},
+			"missing":  true,
+		},
+	}
+	want := map[string]interface{}{
+		"metadata": map[string]interface{}{"name": "gateway"},
+		"spec": map[string]interface{}{
+			"ports":    []interface{}{map[string]interface{}{"port": 80.0}},
+			"selector": []interface{}{"a", "b"},
+		},
+	}
+	if got := PruneTo(live, desired); !reflect.DeepEqual(got, want) {
+		t.Errorf("PruneTo() = %v, want %v", got, want)
+	}
+}
+
+func TestDiffObjects(t *testing.T) {
+	desired := map[string]interface{}{"kind": "Service", "spec": map[string]interface{}{"port": 80}}
+	live := map[string]interface{}{"kind": "Service", "spec": map[string]interface{}{"port": 80.0, "clusterIP": "10.0.0.1"}}
+	diff, err := DiffObjects("service/kubeflow/envoy", desired, live)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff != "" {
+		t.Errorf("DiffObjects() of the same fields = %v, want no diff", diff)
+	}
+	live["spec"].(map[string]interface{})["port"] = 8080.0
+	if diff, err = DiffObjects("service/kubeflow/envoy", desired, live); err != nil {
+		t.Fatal(err)
+	}
+	for _, line := range []string{"--- live/service/kubeflow/envoy", "+++ desired/service/kubeflow/envoy", "-  port: 8080", "+  port: 80"} {
+		if !strings.Contains(diff, line+"\n") {
+			t.Errorf("DiffObjects() = %v, want a line %v", diff, line)
+		}
+	}
+	if diff, err = DiffObjects("service/kubeflow/envoy", desired, nil); err != nil || diff == "" {
+		t.Errorf("DiffObjects() of a missing object = %q, %v; want a diff", diff, err)
+	}
+}
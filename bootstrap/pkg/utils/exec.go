@@ -0,0 +1,80 @@
+/*
+Copyright The Kubeflow Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+	"sync/atomic"
+)
+
+// CommandRunner runs an external command, e.g. gcloud. It's passed the command to run with its
+// Stdout and Stderr set, and returns what cmd.Run would.
+type CommandRunner func(cmd *exec.Cmd) error
+
+// noExec is 1 when running subprocesses is forbidden.
+var noExec int32
+
+// SetNoExec forbids, or allows again, running subprocesses. The server and hardened environments
+// set it so the native API code paths are used, and a missing one fails with a NoExecError
+// instead of depending on gcloud or a shell.
+func SetNoExec(forbidden bool) {
+	if forbidden {
+		atomic.StoreInt32(&noExec, 1)
+	} else {
+		atomic.StoreInt32(&noExec, 0)
+	}
+}
+
+// NoExec reports whether running subprocesses is forbidden.
+func NoExec() bool {
+	return atomic.LoadInt32(&noExec) == 1
+}
+
+// NoExecError is returned for a command that would have been run in no-exec mode.
+type NoExecError struct {
+	Command string
+}
+
+func (e *NoExecError) Error() string {
+	return fmt.Sprintf("running %v is forbidden in no-exec mode; there's no native implementation of it", e.Command)
+}
+
+// RunCommand is the default CommandRunner: cmd.Run, unless in no-exec mode.
+func RunCommand(cmd *exec.Cmd) error {
+	if NoExec() {
+		return &NoExecError{Command: strings.Join(cmd.Args, " ")}
+	}
+	return cmd.Run()
+}
+
+// CombinedOutput is cmd.CombinedOutput, unless in no-exec mode.
+func CombinedOutput(cmd *exec.Cmd) ([]byte, error) {
+	if NoExec() {
+		return nil, &NoExecError{Command: strings.Join(cmd.Args, " ")}
+	}
+	return cmd.CombinedOutput()
+}
+
+// LookPath is exec.LookPath, failing in no-exec mode as the program found would be run.
+func LookPath(file string) (string, error) {
+	if NoExec() {
+		return "", &NoExecError{Command: file}
+	}
+	return exec.LookPath(file)
+}
@@ -0,0 +1,56 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+func TestNoExec(t *testing.T) {
+	dir, err := ioutil.TempDir("", "kfctl-exec")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	marker := filepath.Join(dir, "ran")
+
+	SetNoExec(true)
+	defer SetNoExec(false)
+	err = RunCommand(exec.Command("touch", marker))
+	if _, ok := err.(*NoExecError); !ok {
+		t.Errorf("RunCommand() error = %v, want a NoExecError", err)
+	}
+	if _, err = CombinedOutput(exec.Command("touch", marker)); err == nil {
+		t.Errorf("CombinedOutput() ran in no-exec mode")
+	}
+	if _, err = LookPath("touch"); err == nil {
+		t.Errorf("LookPath() found a program to run in no-exec mode")
+	}
+	if _, err = os.Stat(marker); !os.IsNotExist(err) {
+		t.Errorf("the command ran in no-exec mode")
+	}
+
+	SetNoExec(false)
+	if err = RunCommand(exec.Command("touch", marker)); err != nil {
+		t.Fatalf("RunCommand() error = %v", err)
+	}
+	if _, err = os.Stat(marker); err != nil {
+		t.Errorf("the command didn't run: %v", err)
+	}
+}
@@ -0,0 +1,135 @@
+/*
+Copyright The Kubeflow Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	log "github.com/sirupsen/logrus"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// WriteFileAtomically writes buf to file through a temporary file in its directory renamed to
+// file, so readers and a crash midway see the old content or the new one, never a truncated
+// file.
+func WriteFileAtomically(file string, buf []byte, perm os.FileMode) error {
+	tmp, err := ioutil.TempFile(filepath.Dir(file), filepath.Base(file)+".tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err = tmp.Write(buf); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err = tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+	if err = os.Chmod(tmp.Name(), perm); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), file)
+}
+
+// dirLock is a lock LockDir took, with the number of its holders in the process. locked is
+// closed once the lock of dir is taken, or failed with err.
+type dirLock struct {
+	dir     *os.File
+	holders int
+	locked  chan struct{}
+	err     error
+}
+
+var (
+	dirLocksMutex sync.Mutex
+	dirLocks      = map[string]*dirLock{}
+)
+
+// LockDir takes an exclusive lock of dir, waiting for the other processes holding it, e.g.
+// another kfctl applying the same app. It excludes processes only: the callers in the process
+// share the lock, so e.g. an apply holding the lock of the app dir can write app.yaml through
+// functions locking it too. Concurrent callers in the process, such as two applies of the same
+// app in the deploy server, aren't excluded from each other either and must serialize
+// themselves. The lock is released by the last call of the returned unlock, or when the process
+// exits.
+func LockDir(dir string) (func(), error) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	dirLocksMutex.Lock()
+	lock, ok := dirLocks[abs]
+	if !ok {
+		lock = &dirLock{locked: make(chan struct{})}
+		dirLocks[abs] = lock
+	}
+	lock.holders++
+	dirLocksMutex.Unlock()
+	if !ok {
+		// dirLocksMutex isn't held while waiting for the other process, so the locks of the
+		// other dirs, and their unlocks, don't wait too.
+		lock.dir, lock.err = lockDirFile(abs)
+		if lock.err != nil {
+			dirLocksMutex.Lock()
+			delete(dirLocks, abs)
+			dirLocksMutex.Unlock()
+		}
+		close(lock.locked)
+	}
+	<-lock.locked
+	if lock.err != nil {
+		return nil, lock.err
+	}
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			dirLocksMutex.Lock()
+			defer dirLocksMutex.Unlock()
+			lock.holders--
+			if lock.holders == 0 {
+				delete(dirLocks, abs)
+				if err := unlockFile(lock.dir); err != nil {
+					log.Warnf("couldn't unlock %v: %v", abs, err)
+				}
+				lock.dir.Close()
+			}
+		})
+	}, nil
+}
+
+// lockDirFile opens dir and takes its lock, waiting for the other processes holding it.
+func lockDirFile(dir string) (*os.File, error) {
+	f, err := os.Open(dir)
+	if err != nil {
+		return nil, err
+	}
+	locked, err := tryLockFile(f)
+	if err == nil && !locked {
+		log.Warnf("Waiting for another kfctl to release %v", dir)
+		err = lockFile(f)
+	}
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	return f, nil
+}
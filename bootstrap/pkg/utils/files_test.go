@@ -0,0 +1,70 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWriteFileAtomically(t *testing.T) {
+	dir, err := ioutil.TempDir("", "kfctl-files")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "app.yaml")
+	for _, content := range []string{"spec:\n  zone: us-central1-a\n", "spec: {}\n"} {
+		if err = WriteFileAtomically(file, []byte(content), 0644); err != nil {
+			t.Fatalf("WriteFileAtomically failed: %v", err)
+		}
+		buf, err := ioutil.ReadFile(file)
+		if err != nil || string(buf) != content {
+			t.Errorf("%v = %q, %v; want %q", file, buf, err, content)
+		}
+	}
+	files, _ := ioutil.ReadDir(dir)
+	if len(files) != 1 {
+		t.Errorf("expected only app.yaml; got %v files", len(files))
+	}
+}
+
+func TestLockDirIsReentrant(t *testing.T) {
+	dir, err := ioutil.TempDir("", "kfctl-lock")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	unlock, err := LockDir(dir)
+	if err != nil {
+		t.Fatalf("LockDir failed: %v", err)
+	}
+	// The callers in the process share the lock.
+	unlockAgain, err := LockDir(filepath.Join(dir, "."))
+	if err != nil {
+		t.Fatalf("LockDir of a locked dir failed: %v", err)
+	}
+	unlockAgain()
+	unlockAgain()
+	if abs, _ := filepath.Abs(dir); dirLocks[abs] == nil {
+		t.Errorf("the lock was released while held")
+	}
+	unlock()
+	if len(dirLocks) != 0 {
+		t.Errorf("the lock wasn't released: %v", dirLocks)
+	}
+}
@@ -0,0 +1,109 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !windows
+// +build !windows
+
+package utils
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestLockDirExcludesOtherLocks(t *testing.T) {
+	dir, err := ioutil.TempDir("", "kfctl-lock")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	unlock, err := LockDir(dir)
+	if err != nil {
+		t.Fatalf("LockDir failed: %v", err)
+	}
+	// Another open of the dir, as another kfctl would, doesn't get the flock.
+	other, err := os.Open(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer other.Close()
+	if locked, err := tryLockFile(other); err != nil || locked {
+		t.Errorf("tryLockFile of a locked dir = %v, %v; want false", locked, err)
+	}
+	unlock()
+	if locked, err := tryLockFile(other); err != nil || !locked {
+		t.Errorf("tryLockFile of an unlocked dir = %v, %v; want true", locked, err)
+	}
+}
+
+func TestLockDirWaitsWithoutBlockingOtherDirs(t *testing.T) {
+	dir, err := ioutil.TempDir("", "kfctl-lock")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	otherDir, err := ioutil.TempDir("", "kfctl-lock")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(otherDir)
+	// Another kfctl holds the lock of dir.
+	other, err := os.Open(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer other.Close()
+	if err = lockFile(other); err != nil {
+		t.Fatal(err)
+	}
+	waited := make(chan error)
+	go func() {
+		unlock, err := LockDir(dir)
+		if err == nil {
+			unlock()
+		}
+		waited <- err
+	}()
+
+	locked := make(chan error)
+	go func() {
+		unlock, err := LockDir(otherDir)
+		if err == nil {
+			unlock()
+		}
+		locked <- err
+	}()
+	select {
+	case err = <-locked:
+		if err != nil {
+			t.Fatalf("LockDir of another dir failed: %v", err)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatalf("LockDir of another dir waited for the lock of %v", dir)
+	}
+	select {
+	case err = <-waited:
+		t.Fatalf("LockDir of %v didn't wait for the other lock: %v", dir, err)
+	default:
+	}
+
+	if err = unlockFile(other); err != nil {
+		t.Fatal(err)
+	}
+	if err = <-waited; err != nil {
+		t.Errorf("LockDir of %v failed: %v", dir, err)
+	}
+}
@@ -24,15 +24,17 @@ import (
 	"golang.org/x/oauth2"
 	"google.golang.org/api/option"
 	containerpb "google.golang.org/genproto/googleapis/container/v1"
+	"io/ioutil"
 	"k8s.io/client-go/rest"
 	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
-	"os/exec"
 )
 
 // Use default token source and retrieve cluster information with given project/location/cluster
-// information.
-func GetClusterInfo(ctx context.Context, project string, loc string, cluster string, ts oauth2.TokenSource) (*containerpb.Cluster, error) {
-	c, err := container.NewClusterManagerClient(ctx, option.WithTokenSource(ts))
+// information. opts are added to the options of the GKE client, e.g. GrpcClientOptions.
+func GetClusterInfo(ctx context.Context, project string, loc string, cluster string, ts oauth2.TokenSource,
+	opts ...option.ClientOption) (*containerpb.Cluster, error) {
+	c, err := container.NewClusterManagerClient(ctx, append([]option.ClientOption{option.WithTokenSource(ts)},
+		opts...)...)
 	if err != nil {
 		return nil, err
 	}
@@ -46,12 +48,22 @@ func GetClusterInfo(ctx context.Context, project string, loc string, cluster str
 
 // BuildConfigFromClusterInfo returns k8s config using gcloud Application Default Credentials
 // typically $HOME/.config/gcloud/application_default_credentials.json
-func BuildConfigFromClusterInfo(ctx context.Context, cluster *containerpb.Cluster, ts oauth2.TokenSource) (*rest.Config, error) {
+// The CAs of the PEM file caBundle, when set, are trusted besides the cluster's; the proxy env
+// vars are honored by the transport of client-go.
+func BuildConfigFromClusterInfo(ctx context.Context, cluster *containerpb.Cluster, ts oauth2.TokenSource,
+	caBundle string) (*rest.Config, error) {
 	t, err := ts.Token()
 	if err != nil {
 		return nil, fmt.Errorf("Token retrieval error: %v", err)
 	}
 	caDec, _ := base64.StdEncoding.DecodeString(cluster.MasterAuth.ClusterCaCertificate)
+	if caBundle != "" {
+		pem, err := ioutil.ReadFile(caBundle)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't read the CA bundle %v: %v", caBundle, err)
+		}
+		caDec = append(append(caDec, '\n'), pem...)
+	}
 	config := &rest.Config{
 		Host:        "https://" + cluster.Endpoint,
 		BearerToken: t.AccessToken,
@@ -77,7 +89,7 @@ func CreateKubeconfig(ctx context.Context, project string, loc string, cluster s
 		return nil, fmt.Errorf("Token retrieval error: %v", err)
 	}
 	caDec, _ := base64.StdEncoding.DecodeString(clusterInfo.MasterAuth.ClusterCaCertificate)
-	gcloudPath, err := exec.LookPath("gcloud")
+	gcloudPath, err := LookPath("gcloud")
 	if err != nil {
 		return nil, fmt.Errorf("Not able to find gcloud: %v", err)
 	}
@@ -18,10 +18,13 @@ package utils
 
 import (
 	"fmt"
+	"github.com/cenkalti/backoff"
 	"github.com/deckarep/golang-set"
 	"github.com/ghodss/yaml"
+	log "github.com/sirupsen/logrus"
 	"golang.org/x/net/context"
 	"google.golang.org/api/cloudresourcemanager/v1"
+	"google.golang.org/api/googleapi"
 	"io/ioutil"
 	"net/http"
 )
@@ -53,13 +56,21 @@ func GetIamPolicy(project string, gcpClient *http.Client) (*cloudresourcemanager
 	return service.Projects.GetIamPolicy(project, req).Context(ctx).Do()
 }
 
+// IAM members owned by a deployment, including the optional per node pool service accounts:
+// only these are ever removed from the project's policy.
+func deploymentServiceAccounts(deployName string, project string) map[string]bool {
+	return map[string]bool{
+		fmt.Sprintf("serviceAccount:%v-admin@%v.iam.gserviceaccount.com", deployName, project):    true,
+		fmt.Sprintf("serviceAccount:%v-user@%v.iam.gserviceaccount.com", deployName, project):     true,
+		fmt.Sprintf("serviceAccount:%v-vm@%v.iam.gserviceaccount.com", deployName, project):       true,
+		fmt.Sprintf("serviceAccount:%v-cpu-pool@%v.iam.gserviceaccount.com", deployName, project): true,
+		fmt.Sprintf("serviceAccount:%v-gpu-pool@%v.iam.gserviceaccount.com", deployName, project): true,
+	}
+}
+
 // Modify currentPolicy: Remove existing bindings associated with service accounts of current deployment
 func ClearIamPolicy(currentPolicy *cloudresourcemanager.Policy, deployName string, project string) {
-	serviceAccounts := map[string]bool{
-		fmt.Sprintf("serviceAccount:%v-admin@%v.iam.gserviceaccount.com", deployName, project): true,
-		fmt.Sprintf("serviceAccount:%v-user@%v.iam.gserviceaccount.com", deployName, project):  true,
-		fmt.Sprintf("serviceAccount:%v-vm@%v.iam.gserviceaccount.com", deployName, project):    true,
-	}
+	serviceAccounts := deploymentServiceAccounts(deployName, project)
 	var newBindings []*cloudresourcemanager.Binding
 	for _, binding := range currentPolicy.Bindings {
 		newBinding := cloudresourcemanager.Binding{
@@ -96,7 +107,11 @@ func ReadIamBindingsYAML(filename string) (*cloudresourcemanager.Policy, error)
 	if bufErr != nil {
 		return nil, bufErr
 	}
+	return ParseIamBindingsYAML(buf)
+}
 
+// Parses IAM bindings in YAML format, as in the file ReadIamBindingsYAML reads.
+func ParseIamBindingsYAML(buf []byte) (*cloudresourcemanager.Policy, error) {
 	iam := IamBindingsYAML{}
 	if err := yaml.Unmarshal(buf, &iam); err != nil {
 		return nil, err
@@ -171,3 +186,94 @@ func SetIamPolicy(project string, policy *cloudresourcemanager.Policy, gcpClient
 	_, err := service.Projects.SetIamPolicy(project, req).Context(ctx).Do()
 	return err
 }
+
+// Modify currentPolicy in place so that it grants exactly the roles in `adding` to the service
+// accounts owned by the deployment, and additionally grants every other member of `adding`.
+// Members not owned by the deployment are never removed, and bindings that already hold the
+// desired members are left as they are, so the result can be written in a single SetIamPolicy.
+func ReconcileIamPolicy(currentPolicy *cloudresourcemanager.Policy, adding *cloudresourcemanager.Policy,
+	deployName string, project string) {
+	owned := deploymentServiceAccounts(deployName, project)
+	wanted := map[string]map[string]bool{}
+	for _, binding := range adding.Bindings {
+		if _, ok := wanted[binding.Role]; !ok {
+			wanted[binding.Role] = make(map[string]bool)
+		}
+		for _, member := range binding.Members {
+			wanted[binding.Role][member] = true
+		}
+	}
+
+	var newBindings []*cloudresourcemanager.Binding
+	existing := map[string]*cloudresourcemanager.Binding{}
+	for _, binding := range currentPolicy.Bindings {
+		var members []string
+		for _, member := range binding.Members {
+			if owned[member] && !wanted[binding.Role][member] {
+				log.Infof("Removing %v from %v", member, binding.Role)
+				continue
+			}
+			members = append(members, member)
+		}
+		if len(members) == 0 {
+			continue
+		}
+		binding.Members = members
+		existing[binding.Role] = binding
+		newBindings = append(newBindings, binding)
+	}
+
+	for _, binding := range adding.Bindings {
+		current, ok := existing[binding.Role]
+		if !ok {
+			current = &cloudresourcemanager.Binding{
+				Role: binding.Role,
+			}
+			existing[binding.Role] = current
+			newBindings = append(newBindings, current)
+		}
+		present := map[string]bool{}
+		for _, member := range current.Members {
+			present[member] = true
+		}
+		for _, member := range binding.Members {
+			if !present[member] {
+				log.Infof("Adding %v to %v", member, binding.Role)
+				current.Members = append(current.Members, member)
+				present[member] = true
+			}
+		}
+	}
+	currentPolicy.Bindings = newBindings
+}
+
+// SetIamPolicy returns 409 when the etag of the policy doesn't match the current one.
+func isEtagConflict(err error) bool {
+	if e, ok := err.(*googleapi.Error); ok {
+		return e.Code == http.StatusConflict
+	}
+	return false
+}
+
+// Read-modify-write the project's IAM policy. The policy is written back with the etag it was
+// read with, so a concurrent editor makes SetIamPolicy fail instead of being clobbered; in that
+// case the policy is re-read and `modify` is applied again.
+func UpdateIamPolicy(project string, gcpClient *http.Client, modify func(*cloudresourcemanager.Policy)) error {
+	bo := backoff.WithMaxRetries(backoff.NewExponentialBackOff(), 5)
+	return backoff.Retry(func() error {
+		policy, err := GetIamPolicy(project, gcpClient)
+		if err != nil {
+			return backoff.Permanent(fmt.Errorf("GetIamPolicy error: %v", err))
+		}
+		modify(policy)
+		err = SetIamPolicy(project, policy, gcpClient)
+		if err == nil {
+			return nil
+		}
+		if isEtagConflict(err) {
+			log.Warnf("IAM policy of %v was modified concurrently, retrying: %v", project, err)
+			return err
+		}
+		return backoff.Permanent(fmt.Errorf("SetIamPolicy error: %v", err))
+	}, bo)
+}
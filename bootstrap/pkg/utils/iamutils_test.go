@@ -84,6 +84,74 @@ func Test(t *testing.T) {
 	}
 }
 
+func TestReconcileIamPolicy(t *testing.T) {
+	currentPolicy := &cloudresourcemanager.Policy{
+		Bindings: []*cloudresourcemanager.Binding{
+			&cloudresourcemanager.Binding{
+				Role: "roles/source.admin",
+				Members: []string{
+					// Owned by the deployment but no longer wanted: removed.
+					"serviceAccount:kfctl-admin@project.iam.gserviceaccount.com",
+					"serviceAccount:should-stay@project.iam.gserviceaccount.com",
+				},
+			},
+			&cloudresourcemanager.Binding{
+				Role: "roles/editor",
+				Members: []string{
+					"serviceAccount:kfctl-vm@project.iam.gserviceaccount.com",
+					"user:user1@google.com",
+				},
+			},
+		},
+		Etag: "ShouldKeep",
+	}
+	adding := &cloudresourcemanager.Policy{
+		Bindings: []*cloudresourcemanager.Binding{
+			&cloudresourcemanager.Binding{
+				Role: "roles/editor",
+				Members: []string{
+					"serviceAccount:kfctl-vm@project.iam.gserviceaccount.com",
+				},
+			},
+			&cloudresourcemanager.Binding{
+				Role: "roles/logging.logWriter",
+				Members: []string{
+					"serviceAccount:kfctl-admin@project.iam.gserviceaccount.com",
+				},
+			},
+		},
+	}
+	expectedPolicy := &cloudresourcemanager.Policy{
+		Bindings: []*cloudresourcemanager.Binding{
+			&cloudresourcemanager.Binding{
+				Role: "roles/source.admin",
+				Members: []string{
+					"serviceAccount:should-stay@project.iam.gserviceaccount.com",
+				},
+			},
+			&cloudresourcemanager.Binding{
+				Role: "roles/editor",
+				Members: []string{
+					"serviceAccount:kfctl-vm@project.iam.gserviceaccount.com",
+					"user:user1@google.com",
+				},
+			},
+			&cloudresourcemanager.Binding{
+				Role: "roles/logging.logWriter",
+				Members: []string{
+					"serviceAccount:kfctl-admin@project.iam.gserviceaccount.com",
+				},
+			},
+		},
+		Etag: "ShouldKeep",
+	}
+	ReconcileIamPolicy(currentPolicy, adding, "kfctl", "project")
+	if !reflect.DeepEqual(currentPolicy, expectedPolicy) {
+		t.Errorf("Expect:\n%v; Output:\n%v", PolicyToString(expectedPolicy),
+			PolicyToString(currentPolicy))
+	}
+}
+
 func PolicyToString(input *cloudresourcemanager.Policy) string {
 	policy, err := input.MarshalJSON()
 	if err != nil {
@@ -0,0 +1,149 @@
+/*
+Copyright The Kubeflow Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/ghodss/yaml"
+	log "github.com/sirupsen/logrus"
+	"io/ioutil"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/discovery/cached"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/rest"
+	"strings"
+)
+
+// ResourceRef identifies an object of a manifest, e.g. in the inventory of the objects kfctl
+// applied to a cluster.
+type ResourceRef struct {
+	Group     string `json:"group,omitempty"`
+	Version   string `json:"version"`
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+}
+
+func (r ResourceRef) String() string {
+	gk := schema.GroupKind{Group: r.Group, Kind: r.Kind}
+	if r.Namespace == "" {
+		return fmt.Sprintf("%v %v", gk, r.Name)
+	}
+	return fmt.Sprintf("%v %v/%v", gk, r.Namespace, r.Name)
+}
+
+// ResourceRefsFromFile returns the objects of the manifest filename in their order, read like
+// CreateResourceFromFile does: the documents without apiVersion or name are skipped, and the
+// objects without namespace are in default.
+func ResourceRefsFromFile(filename string) ([]ResourceRef, error) {
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	return ResourceRefs(data)
+}
+
+// ResourceRefs returns the objects of the manifest data; see ResourceRefsFromFile.
+func ResourceRefs(data []byte) ([]ResourceRef, error) {
+	refs := []ResourceRef{}
+	for _, object := range bytes.Split(data, []byte(yamlSeparator)) {
+		var o struct {
+			APIVersion string `json:"apiVersion"`
+			Kind       string `json:"kind"`
+			Metadata   struct {
+				Name      string `json:"name"`
+				Namespace string `json:"namespace"`
+			} `json:"metadata"`
+		}
+		if err := yaml.Unmarshal(object, &o); err != nil {
+			return nil, fmt.Errorf("couldn't read the manifest: %v", err)
+		}
+		if o.APIVersion == "" || o.Metadata.Name == "" {
+			continue
+		}
+		ref := ResourceRef{
+			Version:   o.APIVersion,
+			Kind:      o.Kind,
+			Namespace: o.Metadata.Namespace,
+			Name:      o.Metadata.Name,
+		}
+		if i := strings.Index(o.APIVersion, "/"); i >= 0 {
+			ref.Group, ref.Version = o.APIVersion[:i], o.APIVersion[i+1:]
+		}
+		if ref.Namespace == "" {
+			ref.Namespace = "default"
+		}
+		refs = append(refs, ref)
+	}
+	return refs, nil
+}
+
+// MergeResourceRefs appends the refs of added not in refs yet, keeping the order they were
+// applied in.
+func MergeResourceRefs(refs []ResourceRef, added []ResourceRef) []ResourceRef {
+	seen := map[ResourceRef]bool{}
+	merged := []ResourceRef{}
+	for _, ref := range append(append([]ResourceRef{}, refs...), added...) {
+		if seen[ref] {
+			continue
+		}
+		seen[ref] = true
+		merged = append(merged, ref)
+	}
+	return merged
+}
+
+// DeleteResources deletes refs in reverse order, so the objects applied last, e.g. the custom
+// resources, go before those they depend on, e.g. their CRDs and namespaces. The objects
+// already gone, including those whose kind isn't served anymore, are skipped. It returns the
+// refs which couldn't be deleted with the error.
+func DeleteResources(config *rest.Config, refs []ResourceRef) ([]ResourceRef, error) {
+	discoveryClient, err := discovery.NewDiscoveryClientForConfig(config)
+	if err != nil {
+		return refs, err
+	}
+	cached := cached.NewMemCacheClient(discoveryClient)
+	mapper := discovery.NewDeferredDiscoveryRESTMapper(cached, dynamic.VersionInterfaces)
+
+	remaining := []ResourceRef{}
+	errs := []string{}
+	for i := len(refs) - 1; i >= 0; i-- {
+		ref := refs[i]
+		mapping, err := mapper.RESTMapping(schema.GroupKind{Group: ref.Group, Kind: ref.Kind}, ref.Version)
+		if err != nil {
+			if meta.IsNoMatchError(err) {
+				log.Infof("%v is gone with its kind", ref)
+				continue
+			}
+			remaining = append(remaining, ref)
+			errs = append(errs, fmt.Sprintf("%v: %v", ref, err))
+			continue
+		}
+		log.Infof("Deleting %v", ref)
+		if err = deleteResource(mapping, config, ref.Group, ref.Version, ref.Namespace, ref.Name); err != nil {
+			remaining = append(remaining, ref)
+			errs = append(errs, fmt.Sprintf("%v: %v", ref, err))
+		}
+	}
+	if len(errs) != 0 {
+		return remaining, fmt.Errorf("couldn't delete %v objects: %v", len(errs), strings.Join(errs, "; "))
+	}
+	return nil, nil
+}
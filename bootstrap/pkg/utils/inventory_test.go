@@ -0,0 +1,75 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestResourceRefs(t *testing.T) {
+	manifest := `apiVersion: v1
+kind: Namespace
+metadata:
+  name: istio-system
+---
+# A comment only.
+---
+apiVersion: apiextensions.k8s.io/v1beta1
+kind: CustomResourceDefinition
+metadata:
+  name: gateways.networking.istio.io
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: istio-pilot
+  namespace: istio-system
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: settings
+`
+	refs, err := ResourceRefs([]byte(manifest))
+	if err != nil {
+		t.Fatalf("ResourceRefs failed: %v", err)
+	}
+	expected := []ResourceRef{
+		{Version: "v1", Kind: "Namespace", Namespace: "default", Name: "istio-system"},
+		{Group: "apiextensions.k8s.io", Version: "v1beta1", Kind: "CustomResourceDefinition", Namespace: "default",
+			Name: "gateways.networking.istio.io"},
+		{Group: "apps", Version: "v1", Kind: "Deployment", Namespace: "istio-system", Name: "istio-pilot"},
+		{Version: "v1", Kind: "ConfigMap", Namespace: "default", Name: "settings"},
+	}
+	if !reflect.DeepEqual(refs, expected) {
+		t.Errorf("got %v; want %v", refs, expected)
+	}
+	if s := refs[2].String(); s != "Deployment.apps istio-system/istio-pilot" {
+		t.Errorf("String() = %q", s)
+	}
+}
+
+func TestMergeResourceRefs(t *testing.T) {
+	crd := ResourceRef{Group: "apiextensions.k8s.io", Version: "v1beta1", Kind: "CustomResourceDefinition",
+		Namespace: "default", Name: "gateways.networking.istio.io"}
+	namespace := ResourceRef{Version: "v1", Kind: "Namespace", Namespace: "default", Name: "istio-system"}
+	gateway := ResourceRef{Group: "networking.istio.io", Version: "v1alpha3", Kind: "Gateway",
+		Namespace: "kubeflow", Name: "kubeflow-gateway"}
+	merged := MergeResourceRefs([]ResourceRef{crd, namespace}, []ResourceRef{namespace, gateway})
+	if expected := []ResourceRef{crd, namespace, gateway}; !reflect.DeepEqual(merged, expected) {
+		t.Errorf("got %v; want %v", merged, expected)
+	}
+}
@@ -15,6 +15,7 @@ package utils
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"github.com/cenkalti/backoff"
 	"github.com/ghodss/yaml"
@@ -313,3 +314,70 @@ func CreateResourceFromFile(config *rest.Config, filename string) error {
 	}
 	return nil
 }
+
+// DiffResourcesFromFile compares the resources of a file with the live ones, like
+// `kubectl diff -f filename`, and returns the unified diff of those which differ or are missing.
+// The live resources are pruned to the fields set in the file.
+func DiffResourcesFromFile(config *rest.Config, filename string) (string, error) {
+	discoveryClient, err := discovery.NewDiscoveryClientForConfig(config)
+	if err != nil {
+		return "", err
+	}
+	cached := cached.NewMemCacheClient(discoveryClient)
+	mapper := discovery.NewDeferredDiscoveryRESTMapper(cached, dynamic.VersionInterfaces)
+
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return "", err
+	}
+	diffs := ""
+	for _, object := range bytes.Split(data, []byte(yamlSeparator)) {
+		var o map[string]interface{}
+		if err = yaml.Unmarshal(object, &o); err != nil {
+			return "", fmt.Errorf("Resource marshal error: %v", err)
+		}
+		apiVersion, _ := o["apiVersion"].(string)
+		kind, _ := o["kind"].(string)
+		metadata, _ := o["metadata"].(map[string]interface{})
+		if apiVersion == "" || kind == "" || metadata == nil {
+			continue
+		}
+		name, _ := metadata["name"].(string)
+		namespace, _ := metadata["namespace"].(string)
+		if namespace == "" {
+			namespace = "default"
+		}
+		group, version := "", apiVersion
+		if i := strings.Index(apiVersion, "/"); i >= 0 {
+			group, version = apiVersion[:i], apiVersion[i+1:]
+		}
+		mapping, err := mapper.RESTMapping(schema.GroupKind{Group: group, Kind: kind}, version)
+		if err != nil {
+			return "", fmt.Errorf("couldn't map %v %v: %v", kind, name, err)
+		}
+		restClient, err := getRESTClient(config, group, version)
+		if err != nil {
+			return "", fmt.Errorf("diffResources error: %v", err)
+		}
+		liveBuf, err := restClient.
+			Get().
+			Resource(mapping.Resource).
+			NamespaceIfScoped(namespace, mapping.Scope.Name() == "namespace").
+			Name(name).
+			DoRaw()
+		var live map[string]interface{}
+		if err == nil {
+			if err = json.Unmarshal(liveBuf, &live); err != nil {
+				return "", err
+			}
+		} else if !k8serrors.IsNotFound(err) {
+			return "", fmt.Errorf("couldn't get %v %v/%v: %v", kind, namespace, name, err)
+		}
+		diff, err := DiffObjects(fmt.Sprintf("%v/%v/%v", strings.ToLower(kind), namespace, name), o, live)
+		if err != nil {
+			return "", err
+		}
+		diffs += diff
+	}
+	return diffs, nil
+}
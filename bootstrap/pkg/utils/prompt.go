@@ -0,0 +1,105 @@
+/*
+Copyright The Kubeflow Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Prompter asks the questions of an interactive command on out and reads the answers from in,
+// a line each.
+type Prompter struct {
+	in  *bufio.Reader
+	out io.Writer
+}
+
+// NewPrompter returns a Prompter reading in and writing out, e.g. os.Stdin and os.Stdout.
+func NewPrompter(in io.Reader, out io.Writer) *Prompter {
+	return &Prompter{
+		in:  bufio.NewReader(in),
+		out: out,
+	}
+}
+
+// Ask asks question until the answer passes check, which may be nil, printing why it didn't.
+// An empty answer is def when it's set. It fails when in ends before a valid answer.
+func (p *Prompter) Ask(question string, def string, check func(string) error) (string, error) {
+	for {
+		if def != "" {
+			fmt.Fprintf(p.out, "%v [%v]: ", question, def)
+		} else {
+			fmt.Fprintf(p.out, "%v: ", question)
+		}
+		line, err := p.in.ReadString('\n')
+		if err != nil && (err != io.EOF || line == "") {
+			fmt.Fprintln(p.out)
+			return "", fmt.Errorf("no answer to %q: %v", question, err)
+		}
+		answer := strings.TrimSpace(line)
+		if answer == "" {
+			answer = def
+		}
+		if answer == "" {
+			fmt.Fprintln(p.out, "  an answer is required")
+			continue
+		}
+		if check != nil {
+			if err := check(answer); err != nil {
+				fmt.Fprintf(p.out, "  %v\n", err)
+				continue
+			}
+		}
+		return answer, nil
+	}
+}
+
+// Choose asks question until the answer is one of choices and passes check, which may be nil.
+func (p *Prompter) Choose(question string, choices []string, def string, check func(string) error) (string, error) {
+	return p.Ask(fmt.Sprintf("%v (%v)", question, strings.Join(choices, "|")), def, func(answer string) error {
+		for _, choice := range choices {
+			if answer == choice {
+				if check != nil {
+					return check(answer)
+				}
+				return nil
+			}
+		}
+		return fmt.Errorf("must be one of %v", strings.Join(choices, ", "))
+	})
+}
+
+// Confirm asks the yes or no question, def being the answer when it's empty.
+func (p *Prompter) Confirm(question string, def bool) (bool, error) {
+	defAnswer := "n"
+	if def {
+		defAnswer = "y"
+	}
+	answer, err := p.Ask(question+" (y/n)", defAnswer, func(answer string) error {
+		switch strings.ToLower(answer) {
+		case "y", "yes", "n", "no":
+			return nil
+		}
+		return fmt.Errorf("answer y or n")
+	})
+	if err != nil {
+		return false, err
+	}
+	return strings.HasPrefix(strings.ToLower(answer), "y"), nil
+}
@@ -0,0 +1,58 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestPrompter(t *testing.T) {
+	var out bytes.Buffer
+	p := NewPrompter(strings.NewReader("my-projcet\nmy-project\n\nldap\noidc\nmaybe\ny\n"), &out)
+
+	project, err := p.Ask("Project", "", func(answer string) error {
+		if answer != "my-project" {
+			return fmt.Errorf("project %v doesn't exist", answer)
+		}
+		return nil
+	})
+	if err != nil || project != "my-project" {
+		t.Errorf("Ask returned %q, %v; want my-project", project, err)
+	}
+	zone, err := p.Ask("Zone", "us-east1-d", nil)
+	if err != nil || zone != "us-east1-d" {
+		t.Errorf("Ask returned %q, %v; want the default us-east1-d", zone, err)
+	}
+	auth, err := p.Choose("Auth", []string{"iap", "oidc"}, "iap", nil)
+	if err != nil || auth != "oidc" {
+		t.Errorf("Choose returned %q, %v; want oidc", auth, err)
+	}
+	istio, err := p.Confirm("Use Istio", false)
+	if err != nil || !istio {
+		t.Errorf("Confirm returned %v, %v; want true", istio, err)
+	}
+	if _, err = p.Ask("Email", "", nil); err == nil {
+		t.Errorf("Ask succeeded with no answer left")
+	}
+	for _, want := range []string{"project my-projcet doesn't exist", "Zone [us-east1-d]: ", "must be one of iap, oidc",
+		"answer y or n"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("the prompts %q don't contain %q", out.String(), want)
+		}
+	}
+}
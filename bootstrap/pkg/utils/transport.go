@@ -0,0 +1,206 @@
+/*
+Copyright The Kubeflow Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+	"golang.org/x/oauth2"
+	"google.golang.org/api/option"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"sync/atomic"
+	"time"
+)
+
+// debugHTTP is 1 when the calls of the clients of NewHTTPClient are logged.
+var debugHTTP int32
+
+// SetDebugHTTP turns the logging of the calls of the clients NewHTTPClient returns afterwards on
+// or off, e.g. with kfctl --debug-http, for the triage of failed deployments.
+func SetDebugHTTP(enabled bool) {
+	if enabled {
+		atomic.StoreInt32(&debugHTTP, 1)
+	} else {
+		atomic.StoreInt32(&debugHTTP, 0)
+	}
+}
+
+// DebugHTTP reports whether the calls of the clients of NewHTTPClient are logged.
+func DebugHTTP() bool {
+	return atomic.LoadInt32(&debugHTTP) == 1
+}
+
+// httpLog is the log of the calls with SetDebugHTTP, written whatever the level of the log of
+// the command.
+var httpLog = &log.Logger{
+	Out:       os.Stderr,
+	Formatter: new(log.TextFormatter),
+	Hooks:     make(log.LevelHooks),
+	Level:     log.DebugLevel,
+}
+
+// redactedParams are the query parameters holding credentials, whose values aren't logged.
+var redactedParams = []string{"access_token", "token", "key", "client_secret", "refresh_token", "assertion"}
+
+// redactedFields match the credentials of the JSON bodies logged.
+var redactedFields = regexp.MustCompile(
+	`("(?:access_token|refresh_token|id_token|client_secret|private_key|password)"\s*:\s*)"[^"]*"`)
+
+// maxLoggedBody bounds the part of the body of a failed call that's logged.
+const maxLoggedBody = 2048
+
+// loggingTransport logs the method, URL, status and latency of the calls, and the start of the
+// body of those failing. The credentials are redacted: it never logs the headers, e.g. the
+// Authorization of the oauth2 transport calling it, and drops the credentials of URLs and bodies.
+type loggingTransport struct {
+	base http.RoundTripper
+}
+
+func (t *loggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := t.base.RoundTrip(req)
+	latency := time.Since(start)
+	target := redactURL(req.URL)
+	if err != nil {
+		httpLog.Debugf("%v %v failed after %v: %v", req.Method, target, latency, err)
+		return resp, err
+	}
+	if resp.StatusCode < 400 {
+		httpLog.Debugf("%v %v %v in %v", req.Method, target, resp.StatusCode, latency)
+		return resp, nil
+	}
+	head := make([]byte, maxLoggedBody)
+	n, _ := io.ReadFull(resp.Body, head)
+	head = head[:n]
+	// The caller reads the whole body still.
+	resp.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(head), resp.Body), resp.Body}
+	httpLog.Debugf("%v %v %v in %v: %v", req.Method, target, resp.StatusCode, latency,
+		redactedFields.ReplaceAllString(string(head), `$1"REDACTED"`))
+	return resp, nil
+}
+
+// redactURL is u with the values of the redactedParams replaced.
+func redactURL(u *url.URL) string {
+	redacted := *u
+	query := redacted.Query()
+	for _, param := range redactedParams {
+		if _, ok := query[param]; ok {
+			query.Set(param, "REDACTED")
+		}
+	}
+	redacted.RawQuery = query.Encode()
+	return redacted.String()
+}
+
+// debugTransport wraps base, the default transport when nil, with a loggingTransport when
+// SetDebugHTTP is on.
+func debugTransport(base http.RoundTripper) http.RoundTripper {
+	if !DebugHTTP() {
+		return base
+	}
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &loggingTransport{base: base}
+}
+
+// NewHTTPClient returns the client of the outbound HTTP calls of kfctl. It goes through the
+// proxy of HTTPS_PROXY, HTTP_PROXY and NO_PROXY, and trusts the CAs of the PEM file caBundle
+// besides the system ones when it's set, e.g. those of a proxy inspecting TLS. Its calls are
+// logged with SetDebugHTTP.
+func NewHTTPClient(caBundle string) (*http.Client, error) {
+	if caBundle == "" {
+		// The default transport already honors the proxy env vars.
+		return &http.Client{Transport: debugTransport(nil)}, nil
+	}
+	pool, err := CertPool(caBundle)
+	if err != nil {
+		return nil, err
+	}
+	return &http.Client{
+		Transport: debugTransport(&http.Transport{
+			Proxy: http.ProxyFromEnvironment,
+			DialContext: (&net.Dialer{
+				Timeout:   30 * time.Second,
+				KeepAlive: 30 * time.Second,
+			}).DialContext,
+			MaxIdleConns:          100,
+			IdleConnTimeout:       90 * time.Second,
+			TLSHandshakeTimeout:   10 * time.Second,
+			ExpectContinueTimeout: 1 * time.Second,
+			TLSClientConfig: &tls.Config{
+				RootCAs: pool,
+			},
+		}),
+	}, nil
+}
+
+// CertPool returns the system CAs with those of the PEM file caBundle.
+func CertPool(caBundle string) (*x509.CertPool, error) {
+	pem, err := ioutil.ReadFile(caBundle)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't read the CA bundle %v: %v", caBundle, err)
+	}
+	pool, err := x509.SystemCertPool()
+	if err != nil || pool == nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("the CA bundle %v has no PEM certificates", caBundle)
+	}
+	return pool, nil
+}
+
+// WithHTTPClient returns ctx making oauth2 and the google credentials request their tokens
+// with client, and the clients they create send their calls with it.
+func WithHTTPClient(ctx context.Context, client *http.Client) context.Context {
+	if client == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, oauth2.HTTPClient, client)
+}
+
+// GrpcClientOptions returns the options of the gRPC clients of GCP, e.g. GKE's, trusting the
+// CAs of caBundle. gRPC honors the proxy env vars itself.
+func GrpcClientOptions(caBundle string) ([]option.ClientOption, error) {
+	if caBundle == "" {
+		return nil, nil
+	}
+	pool, err := CertPool(caBundle)
+	if err != nil {
+		return nil, err
+	}
+	creds := credentials.NewTLS(&tls.Config{RootCAs: pool})
+	return []option.ClientOption{
+		option.WithGRPCDialOption(grpc.WithTransportCredentials(creds)),
+	}, nil
+}
@@ -0,0 +1,110 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"bytes"
+	"encoding/pem"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"strings"
+	"testing"
+)
+
+func TestNewHTTPClient(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+	dir, err := ioutil.TempDir("", "kfctl-ca")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	client, err := NewHTTPClient("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = client.Get(server.URL); err == nil {
+		t.Errorf("the server's CA is trusted without a CA bundle")
+	}
+
+	caBundle := path.Join(dir, "ca.pem")
+	buf := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	if err = ioutil.WriteFile(caBundle, buf, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if client, err = NewHTTPClient(caBundle); err != nil {
+		t.Fatal(err)
+	}
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("the server's CA isn't trusted with the CA bundle: %v", err)
+	}
+	resp.Body.Close()
+
+	empty := path.Join(dir, "empty.pem")
+	if err = ioutil.WriteFile(empty, []byte("no certificates"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = NewHTTPClient(empty); err == nil {
+		t.Errorf("NewHTTPClient took a CA bundle without certificates")
+	}
+}
+
+func TestDebugHTTP(t *testing.T) {
+	body := `{"error": {"code": 403, "message": "denied"}, "access_token": "ya29.secret"}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(body))
+	}))
+	defer server.Close()
+	var logged bytes.Buffer
+	httpLog.Out = &logged
+	defer func() {
+		httpLog.Out = os.Stderr
+	}()
+	SetDebugHTTP(true)
+	defer SetDebugHTTP(false)
+
+	client, err := NewHTTPClient("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	req, _ := http.NewRequest("GET", server.URL+"/v1/projects/p?key=AIzaSecret&alt=json", nil)
+	req.Header.Set("Authorization", "Bearer ya29.header")
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	buf, _ := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if string(buf) != body {
+		t.Errorf("body = %q; want %q", buf, body)
+	}
+	entry := logged.String()
+	for _, expected := range []string{"GET", "/v1/projects/p", "403", "denied", "key=REDACTED"} {
+		if !strings.Contains(entry, expected) {
+			t.Errorf("log %q doesn't have %q", entry, expected)
+		}
+	}
+	for _, secret := range []string{"AIzaSecret", "ya29.secret", "ya29.header"} {
+		if strings.Contains(entry, secret) {
+			t.Errorf("log %q has %q", entry, secret)
+		}
+	}
+}
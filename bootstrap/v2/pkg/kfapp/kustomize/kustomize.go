@@ -17,12 +17,18 @@ limitations under the License.
 package kustomize
 
 import (
+	"bytes"
 	"fmt"
 	"github.com/ghodss/yaml"
 	gogetter "github.com/hashicorp/go-getter"
 	kftypes "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps"
 	cltypes "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps/kfdef/v1alpha1"
+	"github.com/kubeflow/kubeflow/bootstrap/pkg/utils"
+	log "github.com/sirupsen/logrus"
 	"io/ioutil"
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
 	"os"
 	"path"
 	"path/filepath"
@@ -57,55 +63,174 @@ type kustomize struct {
 	outputFile string
 	out        *os.File
 	err        *os.File
+	restConfig *rest.Config
 }
 
 func GetKfApp(kfdef *cltypes.KfDef) kftypes.KfApp {
 	_kustomize := &kustomize{
-		KfDef: *kfdef,
+		KfDef:      *kfdef,
 		factory:    k8sdeps.NewFactory(),
 		fsys:       fs.MakeRealFS(),
 		outputFile: "output.yaml",
 		out:        os.Stdout,
 		err:        os.Stderr,
 	}
+	if _kustomize.Spec.Kubeconfig != "" || _kustomize.Spec.KubeContext != "" {
+		restConfig, restConfigErr := kftypes.GetConfigForContext(_kustomize.Spec.Kubeconfig, _kustomize.Spec.KubeContext)
+		if restConfigErr != nil {
+			log.Warnf("could not build config for context %v Error %v", _kustomize.Spec.KubeContext, restConfigErr)
+		}
+		_kustomize.restConfig = restConfig
+		return _kustomize
+	}
+	_kustomize.restConfig = kftypes.GetConfig()
 	return _kustomize
 }
 
+// Apply creates the objects generate rendered to output.yaml in the namespace of the app.
 func (kustomize *kustomize) Apply(resources kftypes.ResourceEnum) error {
+	if kustomize.restConfig == nil {
+		return fmt.Errorf("Error: kustomize has nil restConfig, exit")
+	}
+	kustomizeFile := filepath.Join(kustomize.Spec.AppDir, kustomize.outputFile)
+	if _, err := os.Stat(kustomizeFile); err != nil {
+		return fmt.Errorf("couldn't find %v; run generate first: %v", kustomizeFile, err)
+	}
+	clientset := kftypes.GetClientset(kustomize.restConfig)
+	namespace := kustomize.ObjectMeta.Namespace
+	_, nsMissingErr := clientset.CoreV1().Namespaces().Get(namespace, metav1.GetOptions{})
+	if nsMissingErr != nil {
+		log.Infof("Creating namespace: %v", namespace)
+		nsSpec := &v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: namespace}}
+		_, nsErr := clientset.CoreV1().Namespaces().Create(nsSpec)
+		if nsErr != nil {
+			return fmt.Errorf("couldn't create "+string(kftypes.NAMESPACE)+" %v Error: %v", namespace, nsErr)
+		}
+	}
+	if err := utils.CreateResourceFromFile(kustomize.restConfig, kustomizeFile); err != nil {
+		return fmt.Errorf("couldn't create the objects of %v Error: %v", kustomizeFile, err)
+	}
 	return nil
 }
 
+// Delete deletes the objects of output.yaml, the last applied first.
+// Plan lists the namespace of the app, when it's missing, and the kustomize output Apply
+// creates the objects of. The namespace is planned when there's no cluster to look into yet.
+func (kustomize *kustomize) Plan(resources kftypes.ResourceEnum) ([]kftypes.Action, error) {
+	actions := []kftypes.Action{}
+	namespace := kustomize.ObjectMeta.Namespace
+	nsMissing := kustomize.restConfig == nil
+	if !nsMissing {
+		clientset := kftypes.GetClientset(kustomize.restConfig)
+		_, nsMissingErr := clientset.CoreV1().Namespaces().Get(namespace, metav1.GetOptions{})
+		nsMissing = nsMissingErr != nil
+	}
+	if nsMissing {
+		actions = append(actions, kftypes.Action{Type: kftypes.CREATE_NAMESPACE, Target: namespace})
+	}
+	actions = append(actions, kftypes.Action{
+		Type:   kftypes.APPLY_MANIFEST,
+		Target: kustomize.outputFile,
+	})
+	return actions, nil
+}
+
 func (kustomize *kustomize) Delete(resources kftypes.ResourceEnum) error {
+	config := kustomize.restConfig
+	if config == nil {
+		config = kftypes.GetConfig()
+	}
+	kustomizeFile := filepath.Join(kustomize.Spec.AppDir, kustomize.outputFile)
+	refs, err := utils.ResourceRefsFromFile(kustomizeFile)
+	if err != nil {
+		return fmt.Errorf("couldn't read the objects of %v Error: %v", kustomizeFile, err)
+	}
+	if _, err = utils.DeleteResources(config, refs); err != nil {
+		return fmt.Errorf("couldn't delete the objects of %v Error: %v", kustomizeFile, err)
+	}
 	return nil
 }
 
-func (kustomize *kustomize) generate() error {
+// manifestsDir is the checkout of kubeflow/manifests downloaded by init.
+func (kustomize *kustomize) manifestsDir() string {
 	//TODO see #2629
-	kustomizeDir := path.Join(kustomize.Spec.AppDir, "manifests", "master")
-	loader, loaderErr := loader.NewLoader(kustomizeDir, kustomize.fsys)
+	return path.Join(kustomize.Spec.AppDir, "manifests", "master")
+}
+
+// writeKustomizations writes the kustomization of each component of the app found in the
+// manifests to <appDir>/kustomize/<component>, and returns those components in order.
+func (kustomize *kustomize) writeKustomizations() ([]string, error) {
+	components, err := findComponents(kustomize.manifestsDir())
+	if err != nil {
+		return nil, err
+	}
+	genRoot := filepath.Join(kustomize.Spec.AppDir, KustomizeDir)
+	overlays := kustomize.overlays()
+	params := kustomize.Spec.GetComponentParams()
+	generated := []string{}
+	for _, component := range kustomize.Spec.Components {
+		componentDir, ok := components[component]
+		if !ok {
+			log.Warnf("%v isn't in the manifests of %v; skipping it", component, kustomize.manifestsDir())
+			continue
+		}
+		if err := writeComponent(genRoot, component, componentDir, overlays, params[component],
+			kustomize.Namespace); err != nil {
+			return nil, fmt.Errorf("couldn't write the kustomization of %v: %v", component, err)
+		}
+		generated = append(generated, component)
+	}
+	return generated, nil
+}
+
+// build runs kustomize build on dir.
+func (kustomize *kustomize) build(dir string) ([]byte, error) {
+	loader, loaderErr := loader.NewLoader(dir, kustomize.fsys)
 	if loaderErr != nil {
-		return fmt.Errorf("could not load kustomize loader: %v", loaderErr)
+		return nil, fmt.Errorf("could not load kustomize loader: %v", loaderErr)
 	}
 	defer loader.Cleanup()
 	kt, err := target.NewKustTarget(loader, kustomize.factory.ResmapF, kustomize.factory.TransformerF)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	allResources, err := kt.MakeCustomizedResMap()
 	if err != nil {
-		return err
+		return nil, err
 	}
-	// Output the objects.
-	res, err := allResources.EncodeAsYaml()
+	return allResources.EncodeAsYaml()
+}
+
+// render builds the kustomizations of the components and returns their objects, in the order
+// of spec.components.
+func (kustomize *kustomize) render() ([]byte, error) {
+	components, err := kustomize.writeKustomizations()
+	if err != nil {
+		return nil, err
+	}
+	rendered := [][]byte{}
+	for _, component := range components {
+		res, err := kustomize.build(filepath.Join(kustomize.Spec.AppDir, KustomizeDir, component))
+		if err != nil {
+			return nil, fmt.Errorf("couldn't build %v: %v", component, err)
+		}
+		rendered = append(rendered, res)
+	}
+	return bytes.Join(rendered, []byte("---\n")), nil
+}
+
+func (kustomize *kustomize) generate() error {
+	res, err := kustomize.render()
 	if err != nil {
 		return err
 	}
+	// Output the objects.
 	kustomizeFile := filepath.Join(kustomize.Spec.AppDir, kustomize.outputFile)
 	kustomizeFileErr := kustomize.fsys.WriteFile(kustomizeFile, res)
 	if kustomizeFileErr != nil {
 		return kustomizeFileErr
 	}
-	return err
+	return nil
 }
 
 // kfctl generate all -V --email <service_account_name>@<project>.iam.gserviceaccount.com
@@ -123,15 +248,32 @@ func (kustomize *kustomize) Generate(resources kftypes.ResourceEnum) error {
 	return nil
 }
 
+// Export returns the objects of the components, rendered like generate, in kustomize/output.yaml.
+func (kustomize *kustomize) Export(resources kftypes.ResourceEnum) (map[string][]byte, error) {
+	res, err := kustomize.render()
+	if err != nil {
+		return nil, err
+	}
+	return map[string][]byte{
+		path.Join(KustomizeDir, kustomize.outputFile): res,
+	}, nil
+}
+
 // kfctl init kustomize -V --platform kustomize --project <project>
 func (kustomize *kustomize) Init(resources kftypes.ResourceEnum) error {
+	version := kustomize.Spec.Version
+	repoPath := path.Join(kustomize.Spec.AppDir, kftypes.DefaultCacheDir, version)
+	kustomize.Spec.Repo = path.Join(repoPath, "kubeflow")
+	if _, err := os.Stat(kustomize.manifestsDir()); err == nil {
+		log.Infof("Using the manifests in %v", kustomize.manifestsDir())
+		return kustomize.writeConfigFile()
+	}
 	kustomizeDir := path.Join(kustomize.Spec.AppDir, "manifests")
-	kustomizeDirErr := os.Mkdir(kustomizeDir, os.ModePerm)
+	kustomizeDirErr := os.MkdirAll(kustomizeDir, os.ModePerm)
 	if kustomizeDirErr != nil {
 		return fmt.Errorf("couldn't create directory %v Error %v", kustomizeDir, kustomizeDirErr)
 	}
 	//TODO see #2629
-	version := kustomize.Spec.Version
 	//tarballUrl := "https://github.com/kubeflow/manifests/tarball/" + version + "?archive=tar.gz"
 	tarballUrl := "https://github.com/kubeflow/manifests/tarball/master?archive=tar.gz"
 	tarballUrlErr := gogetter.GetAny(kustomizeDir, tarballUrl)
@@ -144,13 +286,11 @@ func (kustomize *kustomize) Init(resources kftypes.ResourceEnum) error {
 	}
 	subdir := files[0].Name()
 	extractedPath := filepath.Join(kustomizeDir, subdir)
-	newPath := filepath.Join(kustomizeDir, "master")
+	newPath := kustomize.manifestsDir()
 	renameErr := os.Rename(extractedPath, newPath)
 	if renameErr != nil {
 		return fmt.Errorf("couldn't rename %v to %v Error %v", extractedPath, newPath, renameErr)
 	}
-	repoPath := path.Join(kustomize.Spec.AppDir, kftypes.DefaultCacheDir, version)
-	kustomize.Spec.Repo = path.Join(repoPath, "kubeflow")
 	createConfigErr := kustomize.writeConfigFile()
 	if createConfigErr != nil {
 		return fmt.Errorf("cannot create config file app.yaml in %v", kustomize.Spec.AppDir)
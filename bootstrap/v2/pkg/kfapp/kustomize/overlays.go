@@ -0,0 +1,339 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kustomize
+
+import (
+	"fmt"
+	"github.com/ghodss/yaml"
+	"github.com/kubeflow/kubeflow/bootstrap/config"
+	kftypes "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps"
+	log "github.com/sirupsen/logrus"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// KustomizeDir holds a kustomization per component in the app dir, written by generate.
+	KustomizeDir = "kustomize"
+	// KustomizationFile is the file kustomize builds a directory from.
+	KustomizationFile = "kustomization.yaml"
+	// ParametersConfigMap is the generated ConfigMap of kubeflow/manifests the components read
+	// their params from; the componentParams are merged into it.
+	ParametersConfigMap = "parameters"
+	// ISTIO_OVERLAY is applied to the components with useIstio.
+	ISTIO_OVERLAY = "istio"
+)
+
+// kustomization is a kustomization.yaml, kept as a map so the fields generate doesn't know of
+// are passed through.
+type kustomization map[string]interface{}
+
+// overlays are the overlays of kubeflow/manifests applied to each component having them, in
+// order: the platform, then its auth provider for gcp, e.g. gcp then iap or basic-auth, then
+// istio with useIstio.
+func (kustomize *kustomize) overlays() []string {
+	overlays := []string{}
+	if kustomize.Spec.Platform != "" {
+		overlays = append(overlays, kustomize.Spec.Platform)
+	}
+	if kustomize.Spec.Platform == kftypes.GCP {
+		overlays = append(overlays, kftypes.AuthProvider(&kustomize.Spec))
+	}
+	if kustomize.Spec.UseIstio {
+		overlays = append(overlays, ISTIO_OVERLAY)
+	}
+	return overlays
+}
+
+// findComponents returns the directories of the components of manifestsDir by name, those
+// having a base/kustomization.yaml, e.g. jupyter-web-app in jupyter/jupyter-web-app. The first
+// in lexical order wins when names clash.
+func findComponents(manifestsDir string) (map[string]string, error) {
+	components := map[string]string{}
+	err := filepath.Walk(manifestsDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() {
+			return nil
+		}
+		if path != manifestsDir && strings.HasPrefix(info.Name(), ".") {
+			return filepath.SkipDir
+		}
+		if info.Name() != "base" {
+			return nil
+		}
+		if _, err := os.Stat(filepath.Join(path, KustomizationFile)); err != nil {
+			return nil
+		}
+		dir := filepath.Dir(path)
+		if _, ok := components[filepath.Base(dir)]; !ok {
+			components[filepath.Base(dir)] = dir
+		}
+		return filepath.SkipDir
+	})
+	if err != nil {
+		return nil, fmt.Errorf("couldn't read the manifests in %v: %v", manifestsDir, err)
+	}
+	return components, nil
+}
+
+func readKustomization(dir string) (kustomization, error) {
+	buf, err := ioutil.ReadFile(filepath.Join(dir, KustomizationFile))
+	if err != nil {
+		return nil, err
+	}
+	k := kustomization{}
+	if err = yaml.Unmarshal(buf, &k); err != nil {
+		return nil, fmt.Errorf("couldn't read %v: %v", filepath.Join(dir, KustomizationFile), err)
+	}
+	return k, nil
+}
+
+func (k kustomization) list(key string) []interface{} {
+	if l, ok := k[key].([]interface{}); ok {
+		return l
+	}
+	return []interface{}{}
+}
+
+// hasGenerator tells whether k generates the ConfigMap name.
+func (k kustomization) hasGenerator(name string) bool {
+	for _, generator := range k.list("configMapGenerator") {
+		if g, ok := generator.(map[string]interface{}); ok && g["name"] == name {
+			return true
+		}
+	}
+	return false
+}
+
+// overlayMerger merges the overlays of a component into the kustomization of genDir. The files
+// of an overlay are copied into genDir/<overlay>, since kustomize only reads the files below the
+// kustomization.
+type overlayMerger struct {
+	baseDir string
+	genDir  string
+	k       kustomization
+}
+
+// copy copies the file p of the overlay in overlayDir and returns its path in genDir.
+func (m *overlayMerger) copy(overlay string, overlayDir string, p string) (string, error) {
+	// Rooting p keeps the copy in genDir/<overlay> when it's e.g. ../base/patch.yaml.
+	rel := filepath.Join(overlay, filepath.Clean("/"+p))
+	buf, err := ioutil.ReadFile(filepath.Join(overlayDir, p))
+	if err != nil {
+		return "", err
+	}
+	dest := filepath.Join(m.genDir, rel)
+	if err = os.MkdirAll(filepath.Dir(dest), os.ModePerm); err != nil {
+		return "", err
+	}
+	if err = ioutil.WriteFile(dest, buf, 0644); err != nil {
+		return "", err
+	}
+	return filepath.ToSlash(rel), nil
+}
+
+// copyList copies the files of the list key and appends them to the kustomization.
+func (m *overlayMerger) copyList(key string, overlay string, overlayDir string, files []interface{}) error {
+	l := m.k.list(key)
+	for _, f := range files {
+		p, ok := f.(string)
+		if !ok {
+			return fmt.Errorf("%v of overlay %v isn't a list of files", key, overlay)
+		}
+		copied, err := m.copy(overlay, overlayDir, p)
+		if err != nil {
+			return err
+		}
+		l = append(l, copied)
+	}
+	m.k[key] = l
+	return nil
+}
+
+// copyGenerator copies the files and env of a configMapGenerator or secretGenerator.
+func (m *overlayMerger) copyGenerator(overlay string, overlayDir string, generator map[string]interface{}) error {
+	if env, ok := generator["env"].(string); ok {
+		copied, err := m.copy(overlay, overlayDir, env)
+		if err != nil {
+			return err
+		}
+		generator["env"] = copied
+	}
+	if files, ok := generator["files"].([]interface{}); ok {
+		for i, f := range files {
+			source, ok := f.(string)
+			if !ok {
+				continue
+			}
+			// A file source is path or key=path.
+			key, p := "", source
+			if j := strings.Index(source, "="); j >= 0 {
+				key, p = source[:j+1], source[j+1:]
+			}
+			copied, err := m.copy(overlay, overlayDir, p)
+			if err != nil {
+				return err
+			}
+			files[i] = key + copied
+		}
+	}
+	return nil
+}
+
+// merge adds the overlay in overlayDir to the kustomization. Its base is the one of the
+// component already in bases; the other bases are kept where they are.
+func (m *overlayMerger) merge(overlay string, overlayDir string, o kustomization) error {
+	for key, value := range o {
+		switch key {
+		case "apiVersion", "kind":
+		case "bases":
+			l := m.k.list(key)
+			for _, b := range o.list(key) {
+				base, ok := b.(string)
+				if !ok {
+					return fmt.Errorf("bases of overlay %v isn't a list of directories", overlay)
+				}
+				dir := filepath.Clean(filepath.Join(overlayDir, base))
+				if dir == m.baseDir {
+					continue
+				}
+				rel, err := filepath.Rel(m.genDir, dir)
+				if err != nil {
+					return err
+				}
+				l = append(l, filepath.ToSlash(rel))
+			}
+			m.k[key] = l
+		case "resources", "patchesStrategicMerge", "crds", "configurations":
+			if err := m.copyList(key, overlay, overlayDir, o.list(key)); err != nil {
+				return fmt.Errorf("couldn't copy the %v of overlay %v: %v", key, overlay, err)
+			}
+		case "patchesJson6902":
+			l := m.k.list(key)
+			for _, patch := range o.list(key) {
+				if p, ok := patch.(map[string]interface{}); ok {
+					if path, ok := p["path"].(string); ok {
+						copied, err := m.copy(overlay, overlayDir, path)
+						if err != nil {
+							return fmt.Errorf("couldn't copy the %v of overlay %v: %v", key, overlay, err)
+						}
+						p["path"] = copied
+					}
+				}
+				l = append(l, patch)
+			}
+			m.k[key] = l
+		case "configMapGenerator", "secretGenerator":
+			l := m.k.list(key)
+			for _, generator := range o.list(key) {
+				if g, ok := generator.(map[string]interface{}); ok {
+					if err := m.copyGenerator(overlay, overlayDir, g); err != nil {
+						return fmt.Errorf("couldn't copy the %v of overlay %v: %v", key, overlay, err)
+					}
+				}
+				l = append(l, generator)
+			}
+			m.k[key] = l
+		case "images", "vars":
+			m.k[key] = append(m.k.list(key), o.list(key)...)
+		case "commonLabels", "commonAnnotations":
+			merged, _ := m.k[key].(map[string]interface{})
+			if merged == nil {
+				merged = map[string]interface{}{}
+			}
+			if values, ok := value.(map[string]interface{}); ok {
+				for name, v := range values {
+					merged[name] = v
+				}
+			}
+			m.k[key] = merged
+		default:
+			// e.g. namespace or namePrefix; the last overlay setting it wins.
+			m.k[key] = value
+		}
+	}
+	return nil
+}
+
+// writeComponent writes the kustomization of component to genRoot/<component>: the base of the
+// component in componentDir, with the overlays it has and the params it reads from its
+// parameters ConfigMap. namespace replaces the kubeflow namespace of the manifests.
+func writeComponent(genRoot string, component string, componentDir string, overlays []string,
+	params []config.NameValue, namespace string) error {
+	genDir := filepath.Join(genRoot, component)
+	if err := os.RemoveAll(genDir); err != nil {
+		return fmt.Errorf("couldn't remove %v: %v", genDir, err)
+	}
+	if err := os.MkdirAll(genDir, os.ModePerm); err != nil {
+		return fmt.Errorf("couldn't create %v: %v", genDir, err)
+	}
+	baseDir := filepath.Join(componentDir, "base")
+	base, err := readKustomization(baseDir)
+	if err != nil {
+		return err
+	}
+	rel, err := filepath.Rel(genDir, baseDir)
+	if err != nil {
+		return err
+	}
+	m := &overlayMerger{
+		baseDir: baseDir,
+		genDir:  genDir,
+		k:       kustomization{"bases": []interface{}{filepath.ToSlash(rel)}},
+	}
+	for _, overlay := range overlays {
+		overlayDir := filepath.Join(componentDir, "overlays", overlay)
+		o, err := readKustomization(overlayDir)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return err
+		}
+		log.Infof("Applying overlay %v to %v", overlay, component)
+		if err = m.merge(overlay, overlayDir, o); err != nil {
+			return err
+		}
+	}
+	if namespace != "" && base["namespace"] == "kubeflow" {
+		if _, ok := m.k["namespace"]; !ok {
+			m.k["namespace"] = namespace
+		}
+	}
+	if len(params) != 0 {
+		if base.hasGenerator(ParametersConfigMap) {
+			literals := []interface{}{}
+			for _, nv := range params {
+				literals = append(literals, nv.Name+"="+nv.Value)
+			}
+			m.k["configMapGenerator"] = append(m.k.list("configMapGenerator"), map[string]interface{}{
+				"name":     ParametersConfigMap,
+				"behavior": "merge",
+				"literals": literals,
+			})
+		} else {
+			log.Warnf("%v has no %v ConfigMap; its componentParams are ignored", component, ParametersConfigMap)
+		}
+	}
+	buf, err := yaml.Marshal(m.k)
+	if err != nil {
+		return fmt.Errorf("couldn't marshal the kustomization of %v: %v", component, err)
+	}
+	return ioutil.WriteFile(filepath.Join(genDir, KustomizationFile), buf, 0644)
+}
@@ -0,0 +1,170 @@
+// Copyright 2018 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kustomize
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/ghodss/yaml"
+	"github.com/kubeflow/kubeflow/bootstrap/config"
+	kftypes "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps"
+	cltypes "github.com/kubeflow/kubeflow/bootstrap/pkg/apis/apps/kfdef/v1alpha1"
+)
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	for name, content := range files {
+		p := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(p), os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(p, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestOverlays(t *testing.T) {
+	for _, test := range []struct {
+		spec     cltypes.KfDefSpec
+		expected []string
+	}{
+		{
+			spec:     cltypes.KfDefSpec{Platform: kftypes.GCP},
+			expected: []string{"gcp", "iap"},
+		},
+		{
+			spec:     cltypes.KfDefSpec{Platform: kftypes.GCP, UseBasicAuth: true, UseIstio: true},
+			expected: []string{"gcp", "basic-auth", "istio"},
+		},
+		{
+			spec:     cltypes.KfDefSpec{Platform: kftypes.MINIKUBE},
+			expected: []string{"minikube"},
+		},
+	} {
+		kustomize := &kustomize{}
+		kustomize.Spec = test.spec
+		if overlays := kustomize.overlays(); !reflect.DeepEqual(overlays, test.expected) {
+			t.Errorf("overlays of %+v: got %v; want %v", test.spec, overlays, test.expected)
+		}
+	}
+}
+
+func TestWriteComponent(t *testing.T) {
+	dir, err := ioutil.TempDir("", "kustomize")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	manifests := filepath.Join(dir, "manifests")
+	writeFiles(t, manifests, map[string]string{
+		"jupyter/jupyter-web-app/base/kustomization.yaml": `namespace: kubeflow
+resources:
+- deployment.yaml
+configMapGenerator:
+- name: parameters
+  env: params.env
+`,
+		"jupyter/jupyter-web-app/overlays/istio/kustomization.yaml": `apiVersion: kustomize.config.k8s.io/v1beta1
+kind: Kustomization
+bases:
+- ../../base
+resources:
+- virtual-service.yaml
+configMapGenerator:
+- name: parameters
+  behavior: merge
+  env: params.env
+commonLabels:
+  kustomize.component: jupyter-web-app
+`,
+		"jupyter/jupyter-web-app/overlays/istio/virtual-service.yaml": "kind: VirtualService\n",
+		"jupyter/jupyter-web-app/overlays/istio/params.env":           "prefix=/jupyter\n",
+		"jupyter/jupyter-web-app/overlays/application/kustomization.yaml": `bases:
+- ../../base
+`,
+		"gcp/iap-ingress/base/kustomization.yaml": "resources:\n- ingress.yaml\n",
+		".git/base/kustomization.yaml":            "resources: []\n",
+	})
+
+	components, err := findComponents(manifests)
+	if err != nil {
+		t.Fatalf("findComponents failed: %v", err)
+	}
+	expectedComponents := map[string]string{
+		"jupyter-web-app": filepath.Join(manifests, "jupyter", "jupyter-web-app"),
+		"iap-ingress":     filepath.Join(manifests, "gcp", "iap-ingress"),
+	}
+	if !reflect.DeepEqual(components, expectedComponents) {
+		t.Errorf("components: got %v; want %v", components, expectedComponents)
+	}
+
+	genRoot := filepath.Join(dir, KustomizeDir)
+	params := []config.NameValue{{Name: "image", Value: "gcr.io/kubeflow-images-public/jupyter-web-app:v0.5.0"}}
+	err = writeComponent(genRoot, "jupyter-web-app", components["jupyter-web-app"],
+		[]string{"gcp", "iap", "istio"}, params, "ml")
+	if err != nil {
+		t.Fatalf("writeComponent failed: %v", err)
+	}
+	buf, err := ioutil.ReadFile(filepath.Join(genRoot, "jupyter-web-app", KustomizationFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `bases:
+- ../../manifests/jupyter/jupyter-web-app/base
+commonLabels:
+  kustomize.component: jupyter-web-app
+configMapGenerator:
+- behavior: merge
+  env: istio/params.env
+  name: parameters
+- behavior: merge
+  literals:
+  - image=gcr.io/kubeflow-images-public/jupyter-web-app:v0.5.0
+  name: parameters
+namespace: ml
+resources:
+- istio/virtual-service.yaml
+`
+	if string(buf) != expected {
+		t.Errorf("kustomization:\n%v\nwant:\n%v", string(buf), expected)
+	}
+	for _, name := range []string{"istio/virtual-service.yaml", "istio/params.env"} {
+		if _, err := os.Stat(filepath.Join(genRoot, "jupyter-web-app", name)); err != nil {
+			t.Errorf("%v wasn't copied: %v", name, err)
+		}
+	}
+
+	// The params of a component without a parameters ConfigMap are ignored.
+	err = writeComponent(genRoot, "iap-ingress", components["iap-ingress"], []string{"gcp", "iap"},
+		[]config.NameValue{{Name: "hostname", Value: "kf.endpoints.my-project.cloud.goog"}}, "ml")
+	if err != nil {
+		t.Fatalf("writeComponent failed: %v", err)
+	}
+	buf, err = ioutil.ReadFile(filepath.Join(genRoot, "iap-ingress", KustomizationFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	k := kustomization{}
+	if err = yaml.Unmarshal(buf, &k); err != nil {
+		t.Fatal(err)
+	}
+	if expected := (kustomization{"bases": []interface{}{"../../manifests/gcp/iap-ingress/base"}}); !reflect.DeepEqual(k, expected) {
+		t.Errorf("kustomization: got %v; want %v", k, expected)
+	}
+}
@@ -28,15 +28,13 @@ import (
 	"time"
 )
 
-// start with easy case: each server struct only has one valid pair of u/p
 type authServer struct {
-	username	string
-	// password bcrypt hash
-	pwhash      string
+	// username to password bcrypt hash
+	users map[string]string
 	// authorized cookies and their expire time (12 hour by default)
-	cookies 	map[string]time.Time
-	serverMux   sync.Mutex
-	allowHttp	bool
+	cookies   map[string]time.Time
+	serverMux sync.Mutex
+	allowHttp bool
 }
 
 const CookieName = "KUBEFLOW-AUTH-KEY"
@@ -45,22 +43,41 @@ const LoginPageHeader = "x-from-login"
 const WhoAmIPath = "whoami"
 
 func NewAuthServer(opt *options.ServerOption) *authServer {
-	data, err := base64.StdEncoding.DecodeString(opt.Pwhash)
-	if err != nil {
-		log.Fatal("error:", err)
-	}
 	server := &authServer{
-		username: opt.Username,
-		pwhash: string(data),
-		cookies: make(map[string]time.Time),
+		users:     make(map[string]string),
+		cookies:   make(map[string]time.Time),
 		allowHttp: opt.AllowHttp,
 	}
+	if opt.Htpasswd == "" {
+		server.addUser(opt.Username, opt.Pwhash)
+		return server
+	}
+	for _, line := range strings.Split(opt.Htpasswd, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		namehash := strings.SplitN(line, ":", 2)
+		if len(namehash) != 2 {
+			log.Fatalf("error: malformed htpasswd entry for %v", namehash[0])
+		}
+		server.addUser(namehash[0], namehash[1])
+	}
 	return server
 }
 
+// addUser adds a user with its base64 encoded password bcrypt hash
+func (s *authServer) addUser(username string, pwhash string) {
+	data, err := base64.StdEncoding.DecodeString(pwhash)
+	if err != nil {
+		log.Fatal("error:", err)
+	}
+	s.users[username] = string(data)
+}
+
 // Default auth check service
 func (s *authServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	if strings.HasPrefix(r.URL.Path, "/" + WhoAmIPath) {
+	if strings.HasPrefix(r.URL.Path, "/"+WhoAmIPath) {
 		// Used for health check
 		log.Infof("Allow health check")
 		w.WriteHeader(http.StatusOK)
@@ -75,7 +92,7 @@ func (s *authServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	}
 	log.Infof("Path check, url: %v, path: %v", r.URL, r.URL.Path)
 	// login page open to everyone; all other path requires auth with Password or cookie
-	if strings.HasPrefix(r.URL.Path, "/" + LoginPagePath) || s.authCookie(r) == true {
+	if strings.HasPrefix(r.URL.Path, "/"+LoginPagePath) || s.authCookie(r) == true {
 		// Handle user's re-login
 		// They already have auth cookie in browser, so "StatusResetContent" bring them to kubeflow central dashboard.
 		if r.Header.Get(LoginPageHeader) != "" {
@@ -109,7 +126,7 @@ func (s *authServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	log.Infof("Unauthorized, redirect to %v", "https://" + path.Join(r.Host, LoginPagePath))
+	log.Infof("Unauthorized, redirect to %v", "https://"+path.Join(r.Host, LoginPagePath))
 	// redirect to login page
 	s.redirectToLogin(w, r)
 }
@@ -126,17 +143,16 @@ func (s *authServer) authpwd(r *http.Request) bool {
 		return false
 	}
 
-	namepw := strings.Split(string(upBytes), ":")
+	namepw := strings.SplitN(string(upBytes), ":", 2)
 
 	if len(namepw) != 2 {
 		return false
 	}
-	err = bcrypt.CompareHashAndPassword([]byte(s.pwhash), []byte(namepw[1]))
-	if namepw[0] == s.username && err == nil {
-
-		return true
+	pwhash, ok := s.users[namepw[0]]
+	if !ok {
+		return false
 	}
-	return false
+	return bcrypt.CompareHashAndPassword([]byte(pwhash), []byte(namepw[1])) == nil
 }
 
 // auth with cookie
@@ -144,13 +160,13 @@ func (s *authServer) authCookie(r *http.Request) bool {
 	if cookie, err := r.Cookie(CookieName); err == nil {
 		if val, ok := s.cookies[cookie.Value]; ok {
 			if time.Now().Before(val) {
-				log.Info("cookie auth: passed! %v", cookie.Value)
+				log.Infof("cookie auth: passed! %v", cookie.Value)
 				return true
 			}
 			log.Info("cookie auth: cookie value expired!")
 			return false
 		}
-		log.Info("cookie auth: cookie value not found! %v", cookie.Value)
+		log.Infof("cookie auth: cookie value not found! %v", cookie.Value)
 		return false
 	}
 	log.Info("cookie auth: cookie does't exist in request!")
@@ -159,7 +175,7 @@ func (s *authServer) authCookie(r *http.Request) bool {
 
 // redirect to login page when unauthorized
 func (s *authServer) redirectToLogin(w http.ResponseWriter, r *http.Request) {
-	http.Redirect(w, r, "https://" + path.Join(r.Host, LoginPagePath), http.StatusTemporaryRedirect)
+	http.Redirect(w, r, "https://"+path.Join(r.Host, LoginPagePath), http.StatusTemporaryRedirect)
 }
 
 func generateCookieValue() string {
@@ -184,10 +200,10 @@ func (s *authServer) setCookieAndReset(w http.ResponseWriter, r *http.Request) {
 	cookieVal := generateCookieValue()
 	s.addNewCookieValue(cookieVal)
 	cookie := http.Cookie{
-		Name: CookieName,
-		Value: cookieVal,
+		Name:    CookieName,
+		Value:   cookieVal,
 		Expires: time.Now().Add(12 * time.Hour),
-		Path: "/",
+		Path:    "/",
 		// prevent cross-origin information leakage.
 		SameSite: http.SameSiteStrictMode,
 	}
@@ -207,4 +223,3 @@ func (s *authServer) Start(port int) {
 	http.Handle("/", s)
 	log.Fatal(http.ListenAndServe(fmt.Sprintf(":%d", port), nil))
 }
-
@@ -0,0 +1,80 @@
+// Copyright 2019 The Kubeflow Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"encoding/base64"
+	"net/http"
+	"testing"
+
+	"github.com/kubeflow/kubeflow/components/gatekeeper/cmd/gatekeeper/options"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func hashPassword(t *testing.T, password string) string {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.MinCost)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return base64.StdEncoding.EncodeToString(hash)
+}
+
+func basicAuthRequest(username string, password string) *http.Request {
+	r, _ := http.NewRequest("GET", "https://kf.example.com/", nil)
+	r.SetBasicAuth(username, password)
+	return r
+}
+
+func TestAuthpwdHtpasswd(t *testing.T) {
+	opt := options.NewServerOption()
+	opt.Htpasswd = "alice:" + hashPassword(t, "alice-pw") + "\n\n" +
+		"bob:" + hashPassword(t, "bob:pw") + "\n"
+	server := NewAuthServer(opt)
+
+	cases := []struct {
+		username string
+		password string
+		expected bool
+	}{
+		{"alice", "alice-pw", true},
+		{"bob", "bob:pw", true},
+		{"alice", "bob:pw", false},
+		{"bob", "bob", false},
+		{"carol", "alice-pw", false},
+	}
+	for _, c := range cases {
+		if got := server.authpwd(basicAuthRequest(c.username, c.password)); got != c.expected {
+			t.Errorf("authpwd(%v, %v) got %v; want %v", c.username, c.password, got, c.expected)
+		}
+	}
+}
+
+func TestAuthpwdSingleUser(t *testing.T) {
+	opt := options.NewServerOption()
+	opt.Username = "admin"
+	opt.Pwhash = hashPassword(t, "secret")
+	server := NewAuthServer(opt)
+
+	if !server.authpwd(basicAuthRequest("admin", "secret")) {
+		t.Errorf("authpwd should accept the configured user")
+	}
+	if server.authpwd(basicAuthRequest("admin", "wrong")) {
+		t.Errorf("authpwd should reject a wrong password")
+	}
+	r, _ := http.NewRequest("GET", "https://kf.example.com/", nil)
+	if server.authpwd(r) {
+		t.Errorf("authpwd should reject a request without credentials")
+	}
+}
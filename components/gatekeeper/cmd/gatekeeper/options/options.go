@@ -14,12 +14,17 @@
 
 package options
 
-import "flag"
+import (
+	"flag"
+	"os"
+)
 
 type ServerOption struct {
-	Username   string
-	Pwhash     string
-	AllowHttp  bool
+	Username string
+	Pwhash   string
+	// Newline separated username:pwhash entries, one per user
+	Htpasswd  string
+	AllowHttp bool
 	// Email for password reset?
 	// Email                string
 }
@@ -32,5 +37,6 @@ func NewServerOption() *ServerOption {
 func (s *ServerOption) AddFlags(fs *flag.FlagSet) {
 	fs.StringVar(&s.Username, "username", "", "Username for login")
 	fs.StringVar(&s.Pwhash, "pwhash", "", "Bcrypt hash of password for login.")
+	fs.StringVar(&s.Htpasswd, "htpasswd", os.Getenv("HTPASSWD"), "Newline separated username:pwhash entries for login, used instead of username and pwhash when set. Defaults to $HTPASSWD.")
 	fs.BoolVar(&s.AllowHttp, "allowhttp", false, "Whether or not allow http traffic. Http for test only")
 }
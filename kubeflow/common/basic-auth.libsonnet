@@ -87,6 +87,17 @@
                       },
                     },
                   },
+                  {
+                    // One username:passwordhash entry per line, maintained by kfctl user.
+                    name: "HTPASSWD",
+                    valueFrom: {
+                      secretKeyRef: {
+                        name: params.authSecretName,
+                        key: "htpasswd",
+                        optional: true,
+                      },
+                    },
+                  },
                 ],
                 command: [
                   "/opt/kubeflow/gatekeeper",
@@ -0,0 +1,305 @@
+{
+  local util = import "kubeflow/common/util.libsonnet",
+  new(_env, _params):: {
+    local params = _params + _env,
+
+    local authserviceName = "authservice",
+    local issuer = "https://" + params.hostname + "/dex",
+
+    local serviceAccount = {
+      apiVersion: "v1",
+      kind: "ServiceAccount",
+      metadata: {
+        name: params.name,
+        namespace: params.namespace,
+      },
+    },
+    serviceAccount:: serviceAccount,
+
+    // Dex keeps its state in custom resources, which it creates when it starts.
+    local clusterRole = {
+      apiVersion: "rbac.authorization.k8s.io/v1beta1",
+      kind: "ClusterRole",
+      metadata: {
+        name: params.name,
+      },
+      rules: [
+        {
+          apiGroups: ["dex.coreos.com"],
+          resources: ["*"],
+          verbs: ["*"],
+        },
+        {
+          apiGroups: ["apiextensions.k8s.io"],
+          resources: ["customresourcedefinitions"],
+          verbs: ["create"],
+        },
+      ],
+    },
+    clusterRole:: clusterRole,
+
+    local clusterRoleBinding = {
+      apiVersion: "rbac.authorization.k8s.io/v1beta1",
+      kind: "ClusterRoleBinding",
+      metadata: {
+        name: params.name,
+      },
+      roleRef: {
+        apiGroup: "rbac.authorization.k8s.io",
+        kind: "ClusterRole",
+        name: params.name,
+      },
+      subjects: [
+        {
+          kind: "ServiceAccount",
+          name: params.name,
+          namespace: params.namespace,
+        },
+      ],
+    },
+    clusterRoleBinding:: clusterRoleBinding,
+
+    local dexService = {
+      apiVersion: "v1",
+      kind: "Service",
+      metadata: {
+        labels: {
+          app: params.name,
+        },
+        name: params.name,
+        namespace: params.namespace,
+        annotations: {
+          "getambassador.io/config":
+            std.join("\n", [
+              "---",
+              "apiVersion: ambassador/v0",
+              "kind:  Mapping",
+              "name: dex-mapping",
+              "prefix: /dex/",
+              "rewrite: /dex/",
+              "service: " + params.name + "." + params.namespace + ":5556",
+            ]),
+        },  //annotations
+      },
+      spec: {
+        ports: [
+          {
+            port: 5556,
+            targetPort: 5556,
+          },
+        ],
+        selector: {
+          app: params.name,
+        },
+        type: "ClusterIP",
+      },
+    },
+    dexService:: dexService,
+
+    local dexDeployment = {
+      apiVersion: "extensions/v1beta1",
+      kind: "Deployment",
+      metadata: {
+        name: params.name,
+        namespace: params.namespace,
+      },
+      spec: {
+        replicas: 1,
+        template: {
+          metadata: {
+            labels: {
+              app: params.name,
+            },
+          },
+          spec: {
+            serviceAccountName: params.name,
+            containers: [
+              {
+                image: params.image,
+                name: "dex",
+                command: [
+                  "/usr/local/bin/dex",
+                  "serve",
+                  "/etc/dex/cfg/config.yaml",
+                ],
+                ports: [
+                  {
+                    containerPort: 5556,
+                  },
+                ],
+                volumeMounts: [
+                  {
+                    name: "config",
+                    mountPath: "/etc/dex/cfg",
+                  },
+                ],
+              },
+            ],
+            volumes: [
+              {
+                name: "config",
+                secret: {
+                  secretName: params.configSecretName,
+                },
+              },
+            ],
+          },
+        },
+      },
+    },
+    dexDeployment:: dexDeployment,
+
+    local authService = {
+      apiVersion: "v1",
+      kind: "Service",
+      metadata: {
+        labels: {
+          app: authserviceName,
+        },
+        name: authserviceName,
+        namespace: params.namespace,
+        annotations: {
+          "getambassador.io/config":
+            std.join("\n", [
+              "---",
+              "apiVersion: ambassador/v0",
+              "kind:  AuthService",
+              "name: " + authserviceName,
+              "auth_service: " + authserviceName + "." + params.namespace + ":8080",
+              'allowed_headers:\n- "kubeflow-userid"',
+            ]),
+        },  //annotations
+      },
+      spec: {
+        ports: [
+          {
+            port: 8080,
+            targetPort: 8080,
+          },
+        ],
+        selector: {
+          app: authserviceName,
+        },
+        type: "ClusterIP",
+      },
+    },
+    authService:: authService,
+
+    local authDeployment = {
+      apiVersion: "extensions/v1beta1",
+      kind: "Deployment",
+      metadata: {
+        name: authserviceName,
+        namespace: params.namespace,
+      },
+      spec: {
+        // replicas here should always be 1:
+        // the sessions are stored on the pod's disk and aren't shared among pods.
+        replicas: 1,
+        template: {
+          metadata: {
+            labels: {
+              app: authserviceName,
+            },
+          },
+          spec: {
+            containers: [
+              {
+                image: params.authserviceImage,
+                name: "app",
+                env: [
+                  {
+                    name: "CLIENT_ID",
+                    valueFrom: {
+                      secretKeyRef: {
+                        name: params.clientSecretName,
+                        key: "client_id",
+                      },
+                    },
+                  },
+                  {
+                    name: "CLIENT_SECRET",
+                    valueFrom: {
+                      secretKeyRef: {
+                        name: params.clientSecretName,
+                        key: "client_secret",
+                      },
+                    },
+                  },
+                  {
+                    name: "OIDC_PROVIDER",
+                    value: issuer,
+                  },
+                  {
+                    name: "OIDC_AUTH_URL",
+                    value: "/dex/auth",
+                  },
+                  {
+                    name: "OIDC_SCOPES",
+                    value: "profile email groups",
+                  },
+                  {
+                    name: "REDIRECT_URL",
+                    value: "https://" + params.hostname + "/login/oidc",
+                  },
+                  {
+                    // Dex signs the users in, so it must be reachable without a session.
+                    name: "SKIP_AUTH_URI",
+                    value: "/dex",
+                  },
+                  {
+                    name: "USERID_HEADER",
+                    value: "kubeflow-userid",
+                  },
+                  {
+                    name: "USERID_CLAIM",
+                    value: "email",
+                  },
+                  {
+                    name: "PORT",
+                    value: "8080",
+                  },
+                  {
+                    name: "STORE_PATH",
+                    value: "/var/lib/authservice/data.db",
+                  },
+                ],
+                ports: [
+                  {
+                    containerPort: 8080,
+                  },
+                ],
+                volumeMounts: [
+                  {
+                    name: "data",
+                    mountPath: "/var/lib/authservice",
+                  },
+                ],
+              },
+            ],
+            volumes: [
+              {
+                name: "data",
+                emptyDir: {},
+              },
+            ],
+          },
+        },
+      },
+    },
+    authDeployment:: authDeployment,
+
+    parts:: self,
+    all:: [
+      self.serviceAccount,
+      self.clusterRole,
+      self.clusterRoleBinding,
+      self.dexService,
+      self.dexDeployment,
+      self.authService,
+      self.authDeployment,
+    ],
+
+    list(obj=self.all):: util.list(obj),
+  },
+}
@@ -4,6 +4,9 @@
   new(_env, _params):: {
     local params = _params + _env {
       reportUsageBool: util.toBool(_params.reportUsage),
+      reportEndpoint: if std.objectHas(_params, "reportEndpoint") && _params.reportEndpoint != ""
+      then _params.reportEndpoint
+      else "https://stats-collector.kubeflow.org",
     },
 
     // Spartakus needs to be able to get information about the cluster to create a report.
@@ -96,7 +99,7 @@
                 args: [
                   "volunteer",
                   "--cluster-id=" + params.usageId,
-                  "--database=https://stats-collector.kubeflow.org",
+                  "--database=" + params.reportEndpoint,
                 ],
               },
             ],
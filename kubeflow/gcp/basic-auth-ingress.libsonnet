@@ -4,6 +4,10 @@
   new(_env, _params):: {
     local params = _params + _env {
       hostname: if std.objectHas(_params, "hostname") then _params.hostname else "null",
+      // One of acme, managed-cert, self-signed or byo-secret; kfctl creates the secret of the last two.
+      certType: if std.objectHas(_params, "certType") then _params.certType else "acme",
+      endpointsController: if std.objectHas(_params, "endpointsController") then
+        util.toBool(_params.endpointsController) else true,
     },
     local namespace = params.namespace,
 
@@ -332,11 +336,14 @@
         name: "envoy-ingress",
         namespace: namespace,
         annotations: {
-          "kubernetes.io/tls-acme": "true",
           "ingress.kubernetes.io/ssl-redirect": "true",
           "kubernetes.io/ingress.global-static-ip-name": params.ipName,
+        } + if params.certType == "acme" then {
+          "kubernetes.io/tls-acme": "true",
           "certmanager.k8s.io/issuer": params.issuer,
-        },
+        } else if params.certType == "managed-cert" then {
+          "networking.gke.io/managed-certificates": "envoy-ingress",
+        } else {},
       },
       spec: {
         rules: [
@@ -398,7 +405,23 @@
     ),
     certificate:: certificate,
 
-    local cloudEndpoint = if isCloudEndpoint(params.hostname) then (
+    // Google-managed certificate, used with certType managed-cert.
+    local managedCertificate = {
+      apiVersion: "networking.gke.io/v1beta1",
+      kind: "ManagedCertificate",
+      metadata: {
+        name: "envoy-ingress",
+        namespace: namespace,
+      },
+      spec: {
+        domains: [
+          params.hostname,
+        ],
+      },
+    },  // managedCertificate
+    managedCertificate:: managedCertificate,
+
+    local cloudEndpoint = if isCloudEndpoint(params.hostname) && params.endpointsController then (
       {
         local makeEndpointParams(str) = {
           local toks = std.split(str, "."),
@@ -434,12 +457,21 @@
       self.whoamiApp,
       self.backendUpdater,
       self.configMap,
-      self.ingressBootstrapConfigMap,
-      self.ingressBootstrapJob,
       self.ingress,
-      self.certificate,
       self.cloudEndpoint,
-    ],
+    ] + (
+      // The managed certificate is attached by the ingress controller; the others are patched in.
+      if params.certType == "managed-cert" then [
+        self.managedCertificate,
+      ] else [
+        self.ingressBootstrapConfigMap,
+        self.ingressBootstrapJob,
+      ]
+    ) + (
+      if params.certType == "acme" then [
+        self.certificate,
+      ] else []
+    ),
 
     list(obj=self.all):: k.core.v1.list.new(obj,),
   },
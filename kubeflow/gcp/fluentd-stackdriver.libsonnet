@@ -0,0 +1,129 @@
+{
+  local k = import "k.libsonnet",
+  new(_env, _params):: {
+    local params = _params + _env + {
+      local namespaces = if std.objectHas(_params, "namespaces") then _params.namespaces else "null",
+      namespaces: if namespaces == "null" then [_env.namespace] else std.split(namespaces, ","),
+      image: if std.objectHas(_params, "image") then _params.image
+      else "gcr.io/stackdriver-agents/stackdriver-logging-agent:1.6.8",
+    },
+
+    local serviceAccount = {
+      apiVersion: "v1",
+      kind: "ServiceAccount",
+      metadata: {
+        name: "fluentd-kubeflow",
+        namespace: params.namespace,
+      },
+    },
+    serviceAccount:: serviceAccount,
+
+    local configMap = {
+      apiVersion: "v1",
+      kind: "ConfigMap",
+      metadata: {
+        name: "fluentd-kubeflow",
+        namespace: params.namespace,
+      },
+      data: {
+        "google-fluentd.conf": (importstr "fluentd-stackdriver.conf") % {
+          paths: std.join(",", ["/var/log/containers/*_%s_*.log" % ns for ns in params.namespaces]),
+        },
+      },
+    },
+    configMap:: configMap,
+
+    local daemonSet = {
+      apiVersion: "apps/v1",
+      kind: "DaemonSet",
+      metadata: {
+        name: "fluentd-kubeflow",
+        namespace: params.namespace,
+      },
+      spec: {
+        selector: {
+          matchLabels: {
+            app: "fluentd-kubeflow",
+          },
+        },
+        template: {
+          metadata: {
+            labels: {
+              app: "fluentd-kubeflow",
+            },
+          },
+          spec: {
+            containers: [
+              {
+                image: params.image,
+                name: "fluentd",
+                resources: {
+                  limits: {
+                    memory: "300Mi",
+                  },
+                  requests: {
+                    cpu: "50m",
+                    memory: "100Mi",
+                  },
+                },
+                volumeMounts: [
+                  {
+                    mountPath: "/etc/google-fluentd",
+                    name: "config-volume",
+                  },
+                  {
+                    mountPath: "/var/log",
+                    name: "varlog",
+                  },
+                  {
+                    mountPath: "/var/lib/docker/containers",
+                    name: "varlibdockercontainers",
+                    readOnly: true,
+                  },
+                ],
+              },
+            ],
+            serviceAccountName: "fluentd-kubeflow",
+            terminationGracePeriodSeconds: 30,
+            tolerations: [
+              {
+                effect: "NoSchedule",
+                operator: "Exists",
+              },
+            ],
+            volumes: [
+              {
+                configMap: {
+                  name: "fluentd-kubeflow",
+                },
+                name: "config-volume",
+              },
+              {
+                hostPath: {
+                  path: "/var/log",
+                },
+                name: "varlog",
+              },
+              {
+                hostPath: {
+                  path: "/var/lib/docker/containers",
+                },
+                name: "varlibdockercontainers",
+              },
+            ],
+          },
+        },
+      },
+    },
+    daemonSet:: daemonSet,
+
+    parts:: self,
+    all:: [
+      self.serviceAccount,
+      self.configMap,
+      self.daemonSet,
+    ],
+
+    list(obj=self.all):: k.core.v1.list.new(obj,),
+  },
+}
@@ -5,10 +5,14 @@
     local params = _params + _env {
       disableJwtChecking: util.toBool(_params.disableJwtChecking),
       hostname: if std.objectHas(_params, "hostname") then _params.hostname else "null",
+      // One of acme, managed-cert, self-signed or byo-secret; kfctl creates the secret of the last two.
+      certType: if std.objectHas(_params, "certType") then _params.certType else "acme",
       envoyPort: 8080,
       envoyAdminPort: 8001,
       envoyStatsPort: 8025,
       useIstio: util.toBool(_params.useIstio),
+      endpointsController: if std.objectHas(_params, "endpointsController") then
+        util.toBool(_params.endpointsController) else true,
     },
     local namespace = if params.useIstio then params.istioNamespace else params.namespace,
 
@@ -704,7 +708,7 @@
               lb_type: "round_robin",
               hosts: [
                 {
-                  url: "tcp://istio-ingressgateway.istio-system:80",
+                  url: "tcp://istio-ingressgateway." + params.istioNamespace + ":80",
                 },
               ],
             },
@@ -737,7 +741,6 @@
         "update_backend.sh": importstr "update_backend.sh",
       } + if params.useIstio then {
         "jwt-policy-template.yaml": importstr "jwt-policy-template.yaml",
-        "healthcheck_route.yaml": importstr "healthcheck_route.yaml",
       } else {
         "envoy-config.json": std.manifestJson(envoyConfig(params)),
         "configure_envoy_for_iap.sh": importstr "configure_envoy_for_iap.sh",
@@ -918,11 +921,14 @@
         name: "envoy-ingress",
         namespace: namespace,
         annotations: {
-          "kubernetes.io/tls-acme": "true",
           "ingress.kubernetes.io/ssl-redirect": "true",
           "kubernetes.io/ingress.global-static-ip-name": params.ipName,
+        } + if params.certType == "acme" then {
+          "kubernetes.io/tls-acme": "true",
           "certmanager.k8s.io/issuer": params.issuer,
-        },
+        } else if params.certType == "managed-cert" then {
+          "networking.gke.io/managed-certificates": "envoy-ingress",
+        } else {},
       },
       spec: {
         rules: [
@@ -982,6 +988,22 @@
     },  // certificate
     certificate:: certificate,
 
+    // Google-managed certificate, used with certType managed-cert.
+    local managedCertificate = {
+      apiVersion: "networking.gke.io/v1beta1",
+      kind: "ManagedCertificate",
+      metadata: {
+        name: "envoy-ingress",
+        namespace: namespace,
+      },
+      spec: {
+        domains: [
+          params.hostname,
+        ],
+      },
+    },  // managedCertificate
+    managedCertificate:: managedCertificate,
+
     local cloudEndpoint = {
       local makeEndpointParams(str) = {
         local toks = std.split(str, "."),
@@ -1017,20 +1039,27 @@
       self.configMap,
       self.whoamiService,
       self.whoamiApp,
-      self.backendConfig,
-      self.ingressBootstrapConfigMap,
-      self.ingressBootstrapJob,
       self.ingress,
     ] + (
-      if params.privateGKECluster == "false" then [
+      // The managed certificate is attached by the ingress controller; the others are patched in.
+      if params.certType == "managed-cert" then [
+        self.managedCertificate,
+      ] else [
+        self.ingressBootstrapConfigMap,
+        self.ingressBootstrapJob,
+      ]
+    ) + (
+      if params.privateGKECluster == "false" && params.certType == "acme" then [
         self.certificate,
       ] else []
     ) + (
-      if isCloudEndpoint(params.hostname) then [
+      if isCloudEndpoint(params.hostname) && params.endpointsController then [
         self.cloudEndpoint,
       ] else []
     ) + (
+      // With Istio, kfctl creates the BackendConfig of istio-ingressgateway and its routes.
       if !params.useIstio then [
+        self.backendConfig,
         self.service,
         self.deploy,
       ] else []
@@ -48,6 +48,9 @@ else
   kubectl apply -f /var/shared/jwt-policy.yaml
 fi
 
+# Record the audience for kfctl to check it matches the backend IAP was enabled on.
+kubectl --namespace=${NAMESPACE} annotate svc "${SERVICE}" --overwrite "kubeflow.org/jwt-audience=${JWT_AUDIENCE}"
+
 echo "Clearing lock on service annotation"
 kubectl patch svc "${SERVICE}" -p "{\"metadata\": { \"annotations\": {\"backendlock\": \"\" }}}"
 
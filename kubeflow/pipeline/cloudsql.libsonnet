@@ -0,0 +1,85 @@
+{
+  // The Cloud SQL proxy serving the Postgres instance of a cloudsql-postgres metadata store at
+  // cloudsql-proxy:5432, authenticated with the user GCP service account.
+  all(namespace, proxyImage, instance):: [
+    $.parts(namespace).service,
+    $.parts(namespace).deploy(proxyImage, instance),
+  ],
+  parts(namespace):: {
+    service: {
+      apiVersion: "v1",
+      kind: "Service",
+      metadata: {
+        name: "cloudsql-proxy",
+        namespace: namespace,
+      },
+      spec: {
+        ports: [
+          {
+            port: 5432,
+          },
+        ],
+        selector: {
+          app: "cloudsql-proxy",
+        },
+      },
+    },  //service
+
+    deploy(image, instance): {
+      apiVersion: "apps/v1beta2",
+      kind: "Deployment",
+      metadata: {
+        name: "cloudsql-proxy",
+        namespace: namespace,
+      },
+      spec: {
+        selector: {
+          matchLabels: {
+            app: "cloudsql-proxy",
+          },
+        },
+        template: {
+          metadata: {
+            labels: {
+              app: "cloudsql-proxy",
+            },
+          },
+          spec: {
+            containers: [
+              {
+                image: image,
+                name: "cloudsql-proxy",
+                command: [
+                  "/cloud_sql_proxy",
+                  "-instances=" + instance + "=tcp:0.0.0.0:5432",
+                  "-credential_file=/secrets/user-gcp-sa/user-gcp-sa.json",
+                ],
+                ports: [
+                  {
+                    containerPort: 5432,
+                    name: "postgres",
+                  },
+                ],
+                volumeMounts: [
+                  {
+                    name: "user-gcp-sa",
+                    mountPath: "/secrets/user-gcp-sa",
+                    readOnly: true,
+                  },
+                ],
+              },
+            ],
+            volumes: [
+              {
+                name: "user-gcp-sa",
+                secret: {
+                  secretName: "user-gcp-sa",
+                },
+              },
+            ],
+          },
+        },
+      },
+    },  // deploy
+  },  // parts
+}
@@ -1,10 +1,10 @@
 {
-  all(namespace, apiImage):: [
+  all(namespace, apiImage, dbEnv=[]):: [
     $.parts(namespace).serviceAccount,
     $.parts(namespace).roleBinding,
     $.parts(namespace).role,
     $.parts(namespace).service,
-    $.parts(namespace).deploy(apiImage),
+    $.parts(namespace).deploy(apiImage, dbEnv),
     $.parts(namespace).pipelineRunnerServiceAccount,
     $.parts(namespace).pipelineRunnerRole,
     $.parts(namespace).pipelineRunnerRoleBinding,
@@ -125,7 +125,8 @@
       },
     },  //service
 
-    deploy(image): {
+    // dbEnv configures the database of the api server when it isn't the mysql deployment.
+    deploy(image, dbEnv=[]): {
       apiVersion: "apps/v1beta2",
       kind: "Deployment",
       metadata: {
@@ -170,7 +171,7 @@
                       },
                     },
                   },
-                ],
+                ] + dbEnv,
               },
             ],
             serviceAccountName: "ml-pipeline",
@@ -15,6 +15,12 @@
     mysqlPd: null,
     minioPd: null,
     nfsPd: null,
+    // dbType postgres keeps the runs in the Cloud SQL instance cloudsqlInstance, its connection
+    // name, instead of the mysql deployment; dbSecret holds its user, password and database.
+    dbType: "mysql",
+    cloudsqlInstance: null,
+    dbSecret: null,
+    cloudsqlProxyImage: "gcr.io/cloudsql-docker/gce-proxy:1.14",
   },
 
   parts:: {
@@ -22,6 +28,7 @@
     local nfs = import "kubeflow/pipeline/nfs.libsonnet",
     local minio = import "kubeflow/pipeline/minio.libsonnet",
     local mysql = import "kubeflow/pipeline/mysql.libsonnet",
+    local cloudsql = import "kubeflow/pipeline/cloudsql.libsonnet",
     local pipeline_apiserver = import "kubeflow/pipeline/pipeline-apiserver.libsonnet",
     local pipeline_scheduledworkflow = import "kubeflow/pipeline/pipeline-scheduledworkflow.libsonnet",
     local pipeline_persistenceagent = import "kubeflow/pipeline/pipeline-persistenceagent.libsonnet",
@@ -44,13 +51,29 @@
     local mysqlPd = $.params.mysqlPd,
     local minioPd = $.params.minioPd,
     local nfsPd = $.params.nfsPd,
+    local postgres = $.params.dbType == "postgres",
+    local dbEnv = if postgres then [
+      { name: "DBCONFIG_DRIVERNAME", value: "postgres" },
+      { name: "DBCONFIG_HOST", value: "cloudsql-proxy" },
+      { name: "DBCONFIG_PORT", value: "5432" },
+    ] + [
+      {
+        name: "DBCONFIG_" + std.asciiUpper(key),
+        valueFrom: { secretKeyRef: { name: $.params.dbSecret, key: key } },
+      }
+      for key in ["user", "password", "dbname"]
+    ] else [],
+    local database = if postgres then
+      cloudsql.all(namespace, $.params.cloudsqlProxyImage, $.params.cloudsqlInstance)
+    else
+      mysql.all(namespace, mysqlImage),
     nfs:: if (nfsPvName != null) || (nfsPd != null) then
              nfs.all(namespace, nfsImage)
            else [],
     local minioPvcName = if (nfsPvName != null) || (nfsPd != null) then "nfs-pvc" else "minio-pvc",
     all:: minio.all(namespace, minioImage, minioPvcName) +
-          mysql.all(namespace, mysqlImage) +
-          pipeline_apiserver.all(namespace, apiImage) +
+          database +
+          pipeline_apiserver.all(namespace, apiImage, dbEnv) +
           pipeline_scheduledworkflow.all(namespace, scheduledWorkflowImage) +
           pipeline_persistenceagent.all(namespace, persistenceAgentImage) +
           pipeline_viewercrd.all(namespace, viewerCrdControllerImage) +